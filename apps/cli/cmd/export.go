@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/exporter"
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/importer"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput     string
+	exportSourceType string
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write the export to this file instead of stdout")
+	exportCmd.Flags().StringVar(&exportSourceType, "from", "",
+		"Source format (openapi, postman, har, curl, graphql, yamlflow); detected from the content when empty")
+	addOpenAPIFilterFlags(exportCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [format] [file|url]",
+	Short: "Export a spec, collection or flow file to another format",
+	Long: fmt.Sprintf(`Import a source document into a throwaway workspace and export it again.
+Supported output formats: %s.
+
+The source can be an OpenAPI/Swagger spec, a Postman collection, a HAR file, a curl
+command or a yamlflow file, read from a path or an http(s) URL. This lets CI pipelines
+regenerate yamlflow test suites from specs without the desktop app:
+
+  devtools export yaml openapi.yaml --tag orders --base-url http://localhost:8080 -o orders.yaml
+  devtools export yaml openapi.yaml --generate-tests -o orders-tests.yaml
+  devtools flow run orders.yaml
+
+Code formats render every request as a program (go, python, javascript, httpie)
+with the active environment interpolated, or every flow as a k6 script that keeps
+chaining and assertions:
+
+  devtools export k6 orders.yaml -o orders.js`, strings.Join(exporter.Formats(), ", ")),
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: exporter.Formats(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		format := exporter.Format(strings.ToLower(args[0]))
+
+		data, err := importer.ReadSource(ctx, args[1])
+		if err != nil {
+			return err
+		}
+
+		sourceFormat := importer.SourceFormat(strings.ToLower(exportSourceType))
+		if sourceFormat == "" {
+			sourceFormat, err = importer.DetectFormat(data)
+			if err != nil {
+				return fmt.Errorf("cannot detect source format, use --from: %w", err)
+			}
+		}
+
+		name := filepath.Base(args[1])
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+
+		opts := importer.BundleOptions{
+			WorkspaceID:   idwrap.NewNow(),
+			Name:          name,
+			Tags:          openapiTags,
+			Operations:    openapiOperations,
+			BaseURL:       openapiBaseURL,
+			GenerateTests: openapiTests,
+		}
+		if !strings.HasPrefix(args[1], "http://") && !strings.HasPrefix(args[1], "https://") {
+			opts.BaseDir = filepath.Dir(args[1])
+		}
+		bundle, err := importer.BuildBundle(data, sourceFormat, opts)
+		if err != nil {
+			return err
+		}
+
+		out, err := exporter.Export(ctx, slog.Default(), bundle, format)
+		if err != nil {
+			return err
+		}
+
+		if exportOutput == "" {
+			_, err = os.Stdout.Write(out)
+			return err
+		}
+		if err := os.WriteFile(exportOutput, out, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+		}
+		fmt.Fprintf(os.Stderr, "✅ Exported %d HTTP requests and %d flows to %s\n", len(bundle.HTTPRequests), len(bundle.Flows), exportOutput)
+		return nil
+	},
+}
@@ -6,10 +6,13 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/the-dev-tools/dev-tools/apps/cli/internal/common"
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/importer"
 	"github.com/the-dev-tools/dev-tools/apps/cli/internal/loadrun"
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/model"
 	"github.com/the-dev-tools/dev-tools/apps/cli/internal/reporter"
 	"github.com/the-dev-tools/dev-tools/apps/cli/internal/runner"
 	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlitemem"
@@ -30,9 +33,12 @@ import (
 )
 
 var (
-	quietMode  bool
-	showOutput bool
-	loadOpts   loadrun.Options
+	quietMode    bool
+	showOutput   bool
+	resumeExecID string
+	loadOpts     loadrun.Options
+	webhookAddr  string
+	allowExec    bool
 )
 
 func init() {
@@ -42,6 +48,12 @@ func init() {
 	yamlflowRunCmd.Flags().StringSliceVar(&reportFormats, "report", []string{"console"}, "Report outputs to produce (format[:path]). Supported formats: console, json, junit.")
 	yamlflowRunCmd.Flags().BoolVarP(&quietMode, "quiet", "q", false, "Suppress non-essential output for CI/CD usage")
 	yamlflowRunCmd.Flags().BoolVar(&showOutput, "show-output", false, "Show node output data (including AI metrics) after each node completes")
+	yamlflowRunCmd.Flags().StringVar(&resumeExecID, "resume", "",
+		"Resume a failed run by its execution ID, skipping the steps that completed")
+	yamlflowRunCmd.Flags().StringVar(&webhookAddr, "webhook-listen", "",
+		"Wait for one request on the flow's webhook path at this address (e.g. :8080) and run the flow with it")
+	yamlflowRunCmd.Flags().BoolVar(&allowExec, "allow-exec", false,
+		"Allow exec steps to run local commands; off by default so an untrusted file can't run them")
 
 	yamlflowRunCmd.Flags().StringVar(&loadOpts.Scenario, "scenario", "",
 		"Run the named entry of the file's load: block as a load test")
@@ -57,6 +69,11 @@ func init() {
 	yamlflowRunCmd.MarkFlagsMutuallyExclusive("scenario", "vus")
 	yamlflowRunCmd.MarkFlagsMutuallyExclusive("scenario", "duration")
 	yamlflowRunCmd.MarkFlagsMutuallyExclusive("scenario", "iterations")
+	for _, name := range []string{"scenario", "vus", "duration", "iterations"} {
+		yamlflowRunCmd.MarkFlagsMutuallyExclusive("resume", name)
+		yamlflowRunCmd.MarkFlagsMutuallyExclusive("webhook-listen", name)
+	}
+	yamlflowRunCmd.MarkFlagsMutuallyExclusive("resume", "webhook-listen")
 }
 
 var flowCmd = &cobra.Command{
@@ -92,7 +109,33 @@ Load mode
 
   JUnit output carries no load data. Load results go to the console table
   and the JSON report's additive load_report field only; --report junit
-  during a load run still writes a file, but as an empty test suite.`,
+  during a load run still writes a file, but as an empty test suite.
+
+Resuming a failed run
+  When a flow fails, its run is recorded under an execution ID, printed
+  with the failure and included in the JSON report. --resume <execution-id>
+  runs that flow again from the step that failed: steps that completed are
+  skipped and their recorded outputs restored as variables, so later steps
+  read them as before. The flow name defaults to the recorded one. Steps
+  inside a loop are resumed from the loop, and a teardown section runs in
+  full again. Only runs of a single named flow are recorded, not the flows
+  of a run: block.
+
+Webhook flows
+  A flow that starts with a webhook step runs with an empty request by
+  default. --webhook-listen <addr> instead serves the step's path on addr,
+  waits for one request, verifies its signature when the step has a secret,
+  and runs the flow with it. The flow's sub_flow_return step replies to the
+  caller; without one the caller gets 204, or 500 when the flow failed. The
+  command exits once the flow has finished, so a webhook producer can be
+  tested end to end against a local stand-in. Requires a flow name.
+
+Exec steps
+  An exec step runs a local command and records its stdout, stderr and exit
+  code. Because a yamlflow file can come from anywhere, a flow containing an
+  exec step fails to build unless --allow-exec is passed. The command is
+  started directly, not through a shell, so pipes and globs need an explicit
+  "sh -c" if they are wanted.`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
@@ -138,8 +181,26 @@ Load mode
 			return err
 		}
 
+		var checkpoint *runner.Checkpoint
+		checkpointDir, checkpointDirErr := runner.CheckpointDir()
+		if resumeExecID != "" {
+			if checkpointDirErr != nil {
+				return checkpointDirErr
+			}
+			checkpoint, err = runner.LoadCheckpoint(checkpointDir, resumeExecID)
+			if err != nil {
+				return err
+			}
+			if len(args) > 1 && args[1] != checkpoint.FlowName {
+				return fmt.Errorf("execution %s is a run of flow '%s', not '%s'", resumeExecID, checkpoint.FlowName, args[1])
+			}
+		}
+
 		// Check if flow name was provided as argument
-		if len(args) > 1 {
+		if checkpoint != nil {
+			flowName = checkpoint.FlowName
+			runMultiple = false
+		} else if len(args) > 1 {
 			flowName = args[1]
 			runMultiple = false
 		} else {
@@ -160,6 +221,9 @@ Load mode
 				return fmt.Errorf("no flow name provided and no run field found in workflow file")
 			}
 		}
+		if webhookAddr != "" && flowName == "" {
+			return fmt.Errorf("--webhook-listen requires a flow name")
+		}
 
 		// If quiet mode is enabled, suppress console reporter
 		if quietMode {
@@ -201,6 +265,7 @@ Load mode
 		resolved, err := yamlflowsimplev2.ConvertSimplifiedYAML(fileData, yamlflowsimplev2.ConvertOptionsV2{
 			WorkspaceID:   workspaceID,
 			CredentialMap: credentialMap,
+			ReadFile:      importer.RelativeFileReader(filepath.Dir(yamlflowFilePath)),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to convert YAML using v2: %w", err)
@@ -255,6 +320,27 @@ Load mode
 			llmFactory,
 		)
 
+		builder.NodeParallel = &services.NodeParallel
+		builder.NodePoll = &services.NodePoll
+		builder.NodeSwitch = &services.NodeSwitch
+		builder.NodeWebhookTrigger = &services.NodeWebhookTrigger
+		builder.NodeGraphQLSubscription = &services.NodeGraphQLSubscription
+		builder.NodeWsExpect = &services.NodeWsExpect
+		builder.NodeSocketConnection = &services.NodeSocketConnection
+		builder.NodeSocketSend = &services.NodeSocketSend
+		builder.NodeSocketReceive = &services.NodeSocketReceive
+		builder.NodeDbQuery = &services.NodeDbQuery
+		builder.NodeMqPublish = &services.NodeMqPublish
+		builder.NodeMqConsume = &services.NodeMqConsume
+		builder.NodeExec = &services.NodeExec
+		builder.AllowExec = allowExec
+		// The CLI runs as the user on their own machine, so SQLite files
+		// are no more reachable than they already are.
+		builder.AllowLocalDatabases = true
+		builder.Credential = &services.Credential
+		builder.WebSocketMessage = &services.WebSocketMessage
+		builder.GraphQLSchema = &services.GraphQLSchema
+
 		// Wire sub-flow executor so RunSubFlow nodes can invoke other flows
 		builder.SubFlowExecutor = flowbuilder.NewSubFlowExecutor(
 			builder, &services.Flow, &services.FlowEdge, nil, services.Logger,
@@ -376,10 +462,21 @@ Load mode
 			if !quietMode {
 				log.Println("found flow", flowPtr.Name)
 			}
-			_, runErr = runner.RunFlow(ctx, flowPtr, runnerServices, reporters)
+			var result model.FlowRunResult
+			switch {
+			case checkpoint != nil:
+				result, runErr = runner.ResumeFlow(ctx, flowPtr, checkpoint, runnerServices, reporters)
+			case webhookAddr != "":
+				result, runErr = runWebhookFlow(ctx, webhookAddr, *flowPtr, c, func(ctx context.Context) (model.FlowRunResult, error) {
+					return runner.RunFlow(ctx, flowPtr, runnerServices, reporters)
+				})
+			default:
+				result, runErr = runner.RunFlow(ctx, flowPtr, runnerServices, reporters)
+			}
 
 			if runErr != nil {
 				logger.Error(runErr.Error())
+				recordFailedRun(checkpointDir, checkpointDirErr, yamlflowFilePath, result, checkpoint)
 			}
 		}
 
@@ -393,6 +490,28 @@ Load mode
 
 var reportFormats []string
 
+// recordFailedRun saves a checkpoint of a failed single-flow run so it can be
+// resumed with --resume. Failing to save it does not fail the command.
+func recordFailedRun(dir string, dirErr error, file string, result model.FlowRunResult, previous *runner.Checkpoint) {
+	if result.ExecutionID == "" {
+		return
+	}
+	if dirErr != nil {
+		log.Printf("could not record run for --resume: %v", dirErr)
+		return
+	}
+	if absFile, err := filepath.Abs(file); err == nil {
+		file = absFile
+	}
+	if err := runner.SaveCheckpoint(dir, runner.NewCheckpoint(file, result, previous)); err != nil {
+		log.Printf("could not record run for --resume: %v", err)
+		return
+	}
+	if !quietMode {
+		log.Printf("resume this run from the failed step with: flow run %s --resume %s", file, result.ExecutionID)
+	}
+}
+
 func checkFlowsHaveJSNodes(ctx context.Context, flows []mflow.Flow, c *common.Services) (bool, error) {
 	for _, flow := range flows {
 		nodes, err := c.Node.GetNodesByFlowID(ctx, flow.ID)
@@ -434,6 +553,10 @@ func processYAMLCredentials(ctx context.Context, credentials []yamlflowsimplev2.
 			kind = mcredential.CREDENTIAL_KIND_ANTHROPIC
 		case yamlflowsimplev2.CredentialTypeGemini, yamlflowsimplev2.CredentialTypeGoogle:
 			kind = mcredential.CREDENTIAL_KIND_GEMINI
+		case yamlflowsimplev2.CredentialTypeDatabase:
+			kind = mcredential.CREDENTIAL_KIND_DATABASE
+		case yamlflowsimplev2.CredentialTypeBroker:
+			kind = mcredential.CREDENTIAL_KIND_MESSAGE_BROKER
 		default:
 			return nil, fmt.Errorf("unknown credential type: %s", yamlCred.Type)
 		}
@@ -526,6 +649,38 @@ func processYAMLCredentials(ctx context.Context, credentials []yamlflowsimplev2.
 			if err := services.Credential.CreateCredentialGemini(ctx, geminiCred); err != nil {
 				return nil, fmt.Errorf("failed to create gemini credential %s: %w", yamlCred.Name, err)
 			}
+
+		case mcredential.CREDENTIAL_KIND_DATABASE:
+			connectionString, err := interpolateValue(env, yamlCred.ConnectionString)
+			if err != nil {
+				return nil, fmt.Errorf("database credential %s: failed to resolve connection_string: %w", yamlCred.Name, err)
+			}
+			if connectionString == "" {
+				return nil, fmt.Errorf("database credential %s: connection_string is required (use {{ #env:VAR_NAME }} syntax)", yamlCred.Name)
+			}
+			databaseCred := &mcredential.CredentialDatabase{
+				CredentialID:     credID,
+				ConnectionString: connectionString,
+			}
+			if err := services.Credential.CreateCredentialDatabase(ctx, databaseCred); err != nil {
+				return nil, fmt.Errorf("failed to create database credential %s: %w", yamlCred.Name, err)
+			}
+
+		case mcredential.CREDENTIAL_KIND_MESSAGE_BROKER:
+			url, err := interpolateValue(env, yamlCred.URL)
+			if err != nil {
+				return nil, fmt.Errorf("broker credential %s: failed to resolve url: %w", yamlCred.Name, err)
+			}
+			if url == "" {
+				return nil, fmt.Errorf("broker credential %s: url is required (use {{ #env:VAR_NAME }} syntax)", yamlCred.Name)
+			}
+			brokerCred := &mcredential.CredentialMessageBroker{
+				CredentialID: credID,
+				Url:          url,
+			}
+			if err := services.Credential.CreateCredentialMessageBroker(ctx, brokerCred); err != nil {
+				return nil, fmt.Errorf("failed to create broker credential %s: %w", yamlCred.Name, err)
+			}
 		}
 
 		credentialMap[yamlCred.Name] = credID
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/common"
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/model"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwebhook"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// runWebhookFlow serves the webhook step of flow on addr until one valid
+// request arrives, runs the flow with it through run and answers the caller
+// with the flow's reply. Requests that fail the step's method or signature
+// checks are rejected and do not count.
+func runWebhookFlow(
+	ctx context.Context,
+	addr string,
+	flow mflow.Flow,
+	c *common.Services,
+	run func(context.Context) (model.FlowRunResult, error),
+) (model.FlowRunResult, error) {
+	trigger, err := findWebhookTrigger(ctx, flow, c)
+	if err != nil {
+		return model.FlowRunResult{}, err
+	}
+	path := mflow.NormalizeWebhookPath(trigger.Path)
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return model.FlowRunResult{}, fmt.Errorf("listen for webhook: %w", err)
+	}
+
+	var (
+		once     sync.Once
+		requests = make(chan *nwebhook.Exchange, 1)
+		finished = make(chan struct{})
+		replied  = make(chan struct{})
+		runErr   error
+	)
+	srv := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if mflow.NormalizeWebhookPath(r.URL.Path) != path {
+				nwebhook.WriteError(w, http.StatusNotFound, fmt.Errorf("no webhook listens on %s", r.URL.Path))
+				return
+			}
+			ex, status, err := nwebhook.Accept(r, trigger)
+			if err != nil {
+				nwebhook.WriteError(w, status, err)
+				return
+			}
+			accepted := false
+			once.Do(func() { accepted = true })
+			if !accepted {
+				nwebhook.WriteError(w, http.StatusConflict, errors.New("webhook has already been received"))
+				return
+			}
+			defer close(replied)
+
+			requests <- ex
+			select {
+			case <-ex.Responded():
+				ex.WriteResponse(w, nil)
+			case <-finished:
+				ex.WriteResponse(w, runErr)
+			}
+		}),
+	}
+	go func() {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("webhook listener: %v", err)
+		}
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	if !quietMode {
+		log.Printf("waiting for webhook on http://%s%s", listener.Addr(), path)
+	}
+
+	var ex *nwebhook.Exchange
+	select {
+	case ex = <-requests:
+	case <-ctx.Done():
+		return model.FlowRunResult{}, ctx.Err()
+	}
+
+	result, err := run(nwebhook.WithExchange(ctx, ex))
+	runErr = err
+	close(finished)
+	<-replied
+	return result, err
+}
+
+// findWebhookTrigger returns the configuration of the flow's only webhook step.
+func findWebhookTrigger(ctx context.Context, flow mflow.Flow, c *common.Services) (mflow.NodeWebhookTrigger, error) {
+	nodes, err := c.Node.GetNodesByFlowID(ctx, flow.ID)
+	if err != nil {
+		return mflow.NodeWebhookTrigger{}, err
+	}
+
+	var webhooks []mflow.Node
+	for _, n := range nodes {
+		if n.NodeKind == mflow.NODE_KIND_WEBHOOK_TRIGGER {
+			webhooks = append(webhooks, n)
+		}
+	}
+	switch len(webhooks) {
+	case 0:
+		return mflow.NodeWebhookTrigger{}, fmt.Errorf("flow '%s' has no webhook step", flow.Name)
+	case 1:
+	default:
+		return mflow.NodeWebhookTrigger{}, fmt.Errorf("flow '%s' has %d webhook steps; --webhook-listen serves one", flow.Name, len(webhooks))
+	}
+
+	trigger, err := c.NodeWebhookTrigger.GetNodeWebhookTrigger(ctx, webhooks[0].ID)
+	if err != nil {
+		return mflow.NodeWebhookTrigger{}, fmt.Errorf("get webhook step: %w", err)
+	}
+	if trigger == nil {
+		return mflow.NodeWebhookTrigger{FlowNodeID: webhooks[0].ID}, nil
+	}
+	return *trigger, nil
+}
@@ -7,10 +7,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/the-dev-tools/dev-tools/apps/cli/internal/common"
 	"github.com/the-dev-tools/dev-tools/apps/cli/internal/importer"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/schema"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mgraphql"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/harv2"
 	tcurlv2 "github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcurlv2"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tpostmanv2"
@@ -21,6 +24,17 @@ import (
 var (
 	workspaceID string
 	folderID    string
+
+	openapiTags       []string
+	openapiOperations []string
+	openapiBaseURL    string
+	openapiTests      bool
+
+	graphqlEndpoint   string
+	graphqlDepth      int
+	graphqlHeaders    []string
+	graphqlOperations []string
+	graphqlInline     bool
 )
 
 func init() {
@@ -37,13 +51,34 @@ func init() {
 	importCmd.AddCommand(importCurlCmd)
 	importCmd.AddCommand(importPostmanCmd)
 	importCmd.AddCommand(importHarCmd)
+	importCmd.AddCommand(importOpenAPICmd)
+
+	importCmd.AddCommand(importGraphQLCmd)
+
+	addOpenAPIFilterFlags(importOpenAPICmd)
+
+	importGraphQLCmd.Flags().StringVar(&graphqlEndpoint, "endpoint", "", "GraphQL endpoint the requests are sent to; introspected when no file or URL is given")
+	importGraphQLCmd.Flags().IntVar(&graphqlDepth, "depth", schema.DefaultDepth, "Levels of object fields selected by default")
+	importGraphQLCmd.Flags().StringSliceVar(&graphqlHeaders, "header", nil, "Header sent with the introspection request, as \"Key: Value\" (repeatable)")
+	importGraphQLCmd.Flags().StringSliceVar(&graphqlOperations, "operation", nil, "Only import these root fields, by name or \"type name\", or these named operations of a document (repeatable)")
+	importGraphQLCmd.Flags().BoolVar(&graphqlInline, "inline-fragments", false, "Inline the fragments of document operations instead of storing them with each operation")
+}
+
+// addOpenAPIFilterFlags registers the flags that narrow down which operations
+// of an OpenAPI spec are imported.
+func addOpenAPIFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&openapiTags, "tag", nil, "Only import operations with one of these tags (repeatable)")
+	cmd.Flags().StringSliceVar(&openapiOperations, "operation", nil, "Only import these operations, by operationId or \"METHOD /path\" (repeatable)")
+	cmd.Flags().StringVar(&openapiBaseURL, "base-url", "", "Override the server URL declared by the spec")
+	cmd.Flags().BoolVar(&openapiTests, "generate-tests", false,
+		"Generate a test suite: happy-path requests with faker data plus negative and boundary cases expecting 4xx")
 }
 
 var importCmd = &cobra.Command{
 	Use:   "import",
 	Short: "Import data from various formats",
 	Long: `Import data from various formats like curl commands, Postman collections,
-and HAR files into your DevTools workspace using modern v2 translation services.`,
+HAR files, OpenAPI specs and GraphQL schemas into your DevTools workspace using modern v2 translation services.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		_ = cmd.Help()
 	},
@@ -234,3 +269,126 @@ into flows based on request dependencies.`,
 		})
 	},
 }
+
+var importOpenAPICmd = &cobra.Command{
+	Use:   "openapi [file|url]",
+	Short: "Import an OpenAPI or Swagger spec",
+	Long: `Import an OpenAPI 3.x or Swagger 2.0 spec (JSON or YAML) from a file or an http(s) URL
+into your workspace using the topenapiv2 translation service. Every operation becomes an
+HTTP request with a status assertion, chained in a flow named after the spec title.
+
+Use --tag and --operation to import only part of the spec, and --base-url to point the
+requests at another server, e.g. a local or staging deployment.
+
+With --generate-tests every operation also gets negative cases derived from its schema
+(missing required fields, wrong types, out-of-range enum/min/max values) that expect a
+4xx response, and the happy path asserts the documented response schema.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importer.RunImport(cmd.Context(), slog.Default(), workspaceID, folderID, func(ctx context.Context, services *common.Services, wsID idwrap.IDWrap, folderIDPtr *idwrap.IDWrap) error {
+			specData, err := importer.ReadSource(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			bundle, err := importer.BuildBundle(specData, importer.FormatOpenAPI, importer.BundleOptions{
+				WorkspaceID:   wsID,
+				FolderID:      folderIDPtr,
+				Tags:          openapiTags,
+				Operations:    openapiOperations,
+				BaseURL:       openapiBaseURL,
+				GenerateTests: openapiTests,
+			})
+			if err != nil {
+				return err
+			}
+
+			if _, err := importer.ImportBundle(ctx, services, bundle, wsID, folderIDPtr); err != nil {
+				return err
+			}
+
+			fmt.Printf("✅ Successfully imported OpenAPI spec '%s'\n", bundle.Flows[0].Name)
+			fmt.Printf("   Imported %d HTTP requests\n", len(bundle.HTTPRequests))
+			fmt.Printf("   Workspace: %s\n", wsID.String())
+			if folderIDPtr != nil {
+				fmt.Printf("   Folder: %s\n", folderIDPtr.String())
+			}
+			return nil
+		})
+	},
+}
+
+var importGraphQLCmd = &cobra.Command{
+	Use:   "graphql [file|url]",
+	Short: "Import a GraphQL schema or .graphql document",
+	Long: `Import a GraphQL schema from an introspection result or SDL (a file or an http(s) URL),
+or by introspecting --endpoint when no source is given, using the tgraphqlv2 translation
+service. Every query and mutation becomes a GraphQL request that declares the field's
+arguments as variables and selects its leaf fields, descending --depth levels into
+object fields.
+
+A .graphql document with operations imports one GraphQL request per operation, named
+after it and sent with its operation name. The fragments an operation uses are stored
+with it, or inlined with --inline-fragments.
+
+Use --operation to import only some root fields or operations and --header to
+authenticate the introspection request.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && graphqlEndpoint == "" {
+			return fmt.Errorf("either a source file/URL or --endpoint is required")
+		}
+
+		return importer.RunImport(cmd.Context(), slog.Default(), workspaceID, folderID, func(ctx context.Context, services *common.Services, wsID idwrap.IDWrap, folderIDPtr *idwrap.IDWrap) error {
+			var data []byte
+			var err error
+			if len(args) == 1 {
+				data, err = importer.ReadSource(ctx, args[0])
+			} else {
+				data, err = importer.Introspect(ctx, graphqlEndpoint, graphqlHeaders)
+			}
+			if err != nil {
+				return err
+			}
+
+			bundle, err := importer.BuildBundle(data, importer.FormatGraphQL, importer.BundleOptions{
+				WorkspaceID:     wsID,
+				FolderID:        folderIDPtr,
+				Operations:      graphqlOperations,
+				Endpoint:        graphqlEndpoint,
+				Depth:           graphqlDepth,
+				InlineFragments: graphqlInline,
+			})
+			if err != nil {
+				return err
+			}
+
+			if _, err := importer.ImportBundle(ctx, services, bundle, wsID, folderIDPtr); err != nil {
+				return err
+			}
+
+			// Cache an introspected schema for every request so runs are
+			// validated right away.
+			if schema.IsIntrospection(data) {
+				fetchedAt := time.Now().Unix()
+				for _, gql := range bundle.GraphQLRequests {
+					if err := services.GraphQLSchema.Upsert(ctx, mgraphql.GraphQLSchema{
+						GraphQLID:     gql.ID,
+						Introspection: string(data),
+						FetchedAt:     fetchedAt,
+					}); err != nil {
+						return fmt.Errorf("failed to cache schema for %s: %w", gql.Name, err)
+					}
+				}
+			}
+
+			fmt.Printf("✅ Successfully imported GraphQL source\n")
+			fmt.Printf("   Imported %d GraphQL requests\n", len(bundle.GraphQLRequests))
+			fmt.Printf("   Workspace: %s\n", wsID.String())
+			if folderIDPtr != nil {
+				fmt.Printf("   Folder: %s\n", folderIDPtr.String())
+			}
+			return nil
+		})
+	},
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mock"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	mockWorkspace   string
+	mockOpenAPIFile string
+	mockListen      string
+	mockLatency     time.Duration
+	mockJitter      time.Duration
+	mockFaultRate   float64
+	mockFaultStatus int
+)
+
+func init() {
+	rootCmd.AddCommand(mockCmd)
+	mockCmd.AddCommand(mockServeCmd)
+
+	flags := mockServeCmd.Flags()
+	flags.StringVar(&mockWorkspace, "workspace", "", "Serve the saved responses of this workspace of the desktop database")
+	flags.StringVar(&workspaceDBName, "db-name", os.Getenv("DB_NAME"), "Name of the desktop database (defaults to $DB_NAME)")
+	flags.StringVar(&workspaceDBPath, "db-path", os.Getenv("DB_PATH"), "Directory of the desktop database (defaults to $DB_PATH)")
+	flags.StringVar(&workspaceEncryptionKey, "encryption-key", os.Getenv("DB_ENCRYPTION_KEY"), "Key of the desktop database (defaults to $DB_ENCRYPTION_KEY)")
+	flags.StringVar(&mockOpenAPIFile, "openapi", "", "Serve the documented responses of an OpenAPI/Swagger spec file")
+	flags.StringVar(&mockListen, "listen", "localhost:4010", "Address to serve the mock on")
+	flags.DurationVar(&mockLatency, "latency", 0, "Delay added to every response")
+	flags.DurationVar(&mockJitter, "jitter", 0, "Random extra delay of up to this duration")
+	flags.Float64Var(&mockFaultRate, "fault-rate", 0, "Share of requests, from 0 to 1, that fail")
+	flags.IntVar(&mockFaultStatus, "fault-status", http.StatusServiceUnavailable, "Status of failed requests; 0 drops the connection instead")
+}
+
+var mockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "Serve stored responses as a mock server",
+}
+
+var mockServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a workspace's saved responses or an OpenAPI spec over HTTP",
+	Long: `Serve stored examples over HTTP so frontends and flows can run offline.
+
+Every request of a workspace with a saved response becomes a route serving its
+latest response. Requests are matched on method and path; templated path
+segments such as {{ id }}, {id} or :id match any value. Enabled query params
+and headers without templates must match too, and the most specific route wins.
+An OpenAPI spec adds one route per operation, answering with its documented
+2xx response.
+
+Bodies are templates resolved on every request: {{ request.params.id }},
+{{ request.query.page }}, {{ request.headers.Accept }} and {{ request.body.name }}
+read the request, and {{ faker.email() }} and the other faker helpers generate
+data. Requests no route matches get a 404 and are logged.
+
+  devtools mock serve --workspace 01J...
+  devtools mock serve --openapi petstore.yaml --latency 200ms --fault-rate 0.1`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if mockWorkspace == "" && mockOpenAPIFile == "" {
+			return errors.New("pass --workspace, --openapi or both")
+		}
+		if mockFaultRate < 0 || mockFaultRate > 1 {
+			return fmt.Errorf("--fault-rate must be between 0 and 1, got %g", mockFaultRate)
+		}
+
+		routes, err := loadMockRoutes(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if len(routes) == 0 {
+			return errors.New("nothing to serve: no saved responses or documented operations found")
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		server := mock.New(routes,
+			mock.WithLogger(slog.Default()),
+			mock.WithLatency(mockLatency, mockJitter),
+			mock.WithFaults(mockFaultRate, mockFaultStatus),
+		)
+		return serveMock(ctx, mockListen, server)
+	},
+}
+
+// loadMockRoutes reads the routes of the workspace first, so its saved
+// responses win over the spec's generated ones.
+func loadMockRoutes(ctx context.Context) ([]mock.Route, error) {
+	var routes []mock.Route
+
+	if mockWorkspace != "" {
+		wsID, err := idwrap.NewText(mockWorkspace)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --workspace: %w", err)
+		}
+		localDB, err := openWorkspaceDB(mockServeCmd)
+		if err != nil {
+			return nil, err
+		}
+		defer localDB.CleanupFunc()
+
+		data, err := mock.LoadWorkspace(ctx, gen.New(localDB.ReadDB), wsID)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, mock.WorkspaceRoutes(data)...)
+	}
+
+	if mockOpenAPIFile != "" {
+		data, err := os.ReadFile(mockOpenAPIFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+		}
+		specRoutes, err := mock.OpenAPIRoutes(data)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, specRoutes...)
+	}
+	return routes, nil
+}
+
+// serveMock serves server on addr until ctx is done.
+func serveMock(ctx context.Context, addr string, server *mock.Server) error {
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen for mock: %w", err)
+	}
+
+	srv := &http.Server{Handler: server, ReadHeaderTimeout: 10 * time.Second}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(listener) }()
+
+	log.Printf("serving %d mock routes on http://%s", len(server.Routes()), listener.Addr())
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("mock server: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("stop mock server: %w", err)
+	}
+	if unmatched := server.Unmatched(); len(unmatched) > 0 {
+		log.Printf("%d recent requests matched no route:", len(unmatched))
+		for _, u := range unmatched {
+			log.Printf("  %s %s", u.Method, u.Path)
+		}
+	}
+	return nil
+}
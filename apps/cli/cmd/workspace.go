@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/tursolocal"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/dirsync"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	workspaceDBName        string
+	workspaceDBPath        string
+	workspaceEncryptionKey string
+	workspaceSyncID        string
+	workspaceDryRun        bool
+)
+
+func init() {
+	rootCmd.AddCommand(workspaceCmd)
+	workspaceCmd.AddCommand(workspacePullCmd)
+	workspaceCmd.AddCommand(workspacePushCmd)
+
+	workspaceCmd.PersistentFlags().StringVar(&workspaceDBName, "db-name", os.Getenv("DB_NAME"), "Name of the desktop database (defaults to $DB_NAME)")
+	workspaceCmd.PersistentFlags().StringVar(&workspaceDBPath, "db-path", os.Getenv("DB_PATH"), "Directory of the desktop database (defaults to $DB_PATH)")
+	workspaceCmd.PersistentFlags().StringVar(&workspaceEncryptionKey, "encryption-key", os.Getenv("DB_ENCRYPTION_KEY"), "Key of the desktop database (defaults to $DB_ENCRYPTION_KEY)")
+	workspaceCmd.PersistentFlags().BoolVar(&workspaceDryRun, "dry-run", false, "List the changes without applying them")
+
+	workspacePullCmd.Flags().StringVar(&workspaceSyncID, "workspace", "", "Workspace to pull; defaults to the one the directory is bound to")
+}
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Sync a workspace with a directory under version control",
+	Long: `Sync a workspace of the desktop app with a directory meant for code review.
+
+The directory mirrors the workspace tree: one file per request, one file per flow,
+one directory per folder, and one file per environment under .devtools/environments.
+Values of secret variables are never written. Only the files whose content changed
+are written by a pull, and only the matching entities are updated by a push:
+
+  devtools workspace pull ./api --workspace 01J...
+  git diff ./api
+  devtools workspace push ./api`,
+}
+
+var workspacePullCmd = &cobra.Command{
+	Use:   "pull <dir>",
+	Short: "Write a workspace to a directory",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id idwrap.IDWrap
+		if workspaceSyncID != "" {
+			var err error
+			if id, err = idwrap.NewText(workspaceSyncID); err != nil {
+				return fmt.Errorf("invalid --workspace: %w", err)
+			}
+		}
+
+		localDB, err := openWorkspaceDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer localDB.CleanupFunc()
+
+		changes, err := dirsync.Pull(cmd.Context(), localDB.WriteDB, args[0], id, dirsync.Options{
+			DryRun: workspaceDryRun,
+			Logger: slog.New(slog.DiscardHandler),
+		})
+		if errors.Is(err, dirsync.ErrNotBound) {
+			return fmt.Errorf("%w, pass --workspace", err)
+		}
+		if err != nil {
+			return err
+		}
+		printChanges(changes)
+		return nil
+	},
+}
+
+var workspacePushCmd = &cobra.Command{
+	Use:   "push <dir>",
+	Short: "Apply the changes made in a directory to its workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		localDB, err := openWorkspaceDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer localDB.CleanupFunc()
+
+		changes, err := dirsync.Push(cmd.Context(), localDB.WriteDB, args[0], dirsync.Options{
+			DryRun: workspaceDryRun,
+			Logger: slog.New(slog.DiscardHandler),
+		})
+		if err != nil {
+			return err
+		}
+		printChanges(changes)
+		return nil
+	},
+}
+
+// openWorkspaceDB opens the database of the desktop app. The app owns the
+// schema, so it must have run at least once against it.
+func openWorkspaceDB(cmd *cobra.Command) (*tursolocal.LocalDB, error) {
+	if workspaceDBName == "" || workspaceDBPath == "" {
+		return nil, errors.New("--db-name and --db-path (or DB_NAME and DB_PATH) are required")
+	}
+	localDB, err := tursolocal.NewTursoLocal(cmd.Context(), workspaceDBName, workspaceDBPath, workspaceEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if localDB.CleanupFunc == nil {
+		localDB.CleanupFunc = func() {}
+	}
+	return localDB, nil
+}
+
+func printChanges(changes []dirsync.Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "Already up to date")
+		return
+	}
+	for _, c := range changes {
+		fmt.Printf("%-9s %s\n", c.Op, c.Path)
+	}
+	verb := "Applied"
+	if workspaceDryRun {
+		verb = "Would apply"
+	}
+	fmt.Fprintf(os.Stderr, "%s %d changes\n", verb, len(changes))
+}
@@ -49,15 +49,30 @@ type Services struct {
 	NodeSubFlowTrigger   sflow.NodeSubFlowTriggerService
 	NodeSubFlowReturn    sflow.NodeSubFlowReturnService
 	NodeRunSubFlow       sflow.NodeRunSubFlowService
+	NodeParallel         sflow.NodeParallelService
+	NodePoll             sflow.NodePollService
+	NodeSwitch           sflow.NodeSwitchService
+	NodeWebhookTrigger   sflow.NodeWebhookTriggerService
+	NodeGraphQLSubscription sflow.NodeGraphQLSubscriptionService
+	NodeWsExpect            sflow.NodeWsExpectService
+	NodeSocketConnection    sflow.NodeSocketConnectionService
+	NodeSocketSend          sflow.NodeSocketSendService
+	NodeSocketReceive       sflow.NodeSocketReceiveService
+	NodeDbQuery             sflow.NodeDbQueryService
+	NodeMqPublish           sflow.NodeMqPublishService
+	NodeMqConsume           sflow.NodeMqConsumeService
+	NodeExec                sflow.NodeExecService
 
 	// WebSocket
-	WebSocket       swebsocket.WebSocketService
-	WebSocketHeader swebsocket.WebSocketHeaderService
+	WebSocket        swebsocket.WebSocketService
+	WebSocketHeader  swebsocket.WebSocketHeaderService
+	WebSocketMessage swebsocket.WebSocketMessageService
 
 	// GraphQL
 	GraphQL       sgraphql.GraphQLService
 	GraphQLHeader sgraphql.GraphQLHeaderService
 	GraphQLAssert sgraphql.GraphQLAssertService
+	GraphQLSchema sgraphql.GraphQLSchemaService
 
 	// Credentials
 	Credential scredential.CredentialService
@@ -112,15 +127,30 @@ func CreateServices(ctx context.Context, db *sql.DB, logger *slog.Logger) (*Serv
 		NodeSubFlowTrigger: sflow.NewNodeSubFlowTriggerService(queries),
 		NodeSubFlowReturn:  sflow.NewNodeSubFlowReturnService(queries),
 		NodeRunSubFlow:     sflow.NewNodeRunSubFlowService(queries),
+		NodeParallel:       sflow.NewNodeParallelService(queries),
+		NodePoll:           sflow.NewNodePollService(queries),
+		NodeSwitch:         sflow.NewNodeSwitchService(queries),
+		NodeWebhookTrigger: sflow.NewNodeWebhookTriggerService(queries),
+		NodeGraphQLSubscription: sflow.NewNodeGraphQLSubscriptionService(queries),
+		NodeWsExpect:            sflow.NewNodeWsExpectService(queries),
+		NodeSocketConnection:    sflow.NewNodeSocketConnectionService(queries),
+		NodeSocketSend:          sflow.NewNodeSocketSendService(queries),
+		NodeSocketReceive:       sflow.NewNodeSocketReceiveService(queries),
+		NodeDbQuery:             sflow.NewNodeDbQueryService(queries),
+		NodeMqPublish:           sflow.NewNodeMqPublishService(queries),
+		NodeMqConsume:           sflow.NewNodeMqConsumeService(queries),
+		NodeExec:                sflow.NewNodeExecService(queries),
 
 		// WebSocket
-		WebSocket:       swebsocket.New(queries, logger),
-		WebSocketHeader: swebsocket.NewWebSocketHeaderService(queries),
+		WebSocket:        swebsocket.New(queries, logger),
+		WebSocketHeader:  swebsocket.NewWebSocketHeaderService(queries),
+		WebSocketMessage: swebsocket.NewWebSocketMessageService(queries),
 
 		// GraphQL
 		GraphQL:       sgraphql.New(queries, logger),
 		GraphQLHeader: sgraphql.NewGraphQLHeaderService(queries),
 		GraphQLAssert: sgraphql.NewGraphQLAssertService(queries),
+		GraphQLSchema: sgraphql.NewGraphQLSchemaService(queries),
 
 		// Credentials
 		Credential: scredential.NewCredentialService(queries),
@@ -0,0 +1,192 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/common"
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/importer"
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlitemem"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcodegen"
+	tcurlv2 "github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcurlv2"
+	yamlflowsimplev2 "github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/yamlflowsimplev2"
+)
+
+// Format names an output format of the export command
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatCurl Format = "curl"
+)
+
+// encoder turns an exported workspace bundle into the bytes of one format
+type encoder func(bundle *ioworkspace.WorkspaceBundle) ([]byte, error)
+
+var encoders = map[Format]encoder{
+	FormatYAML: encodeYAML,
+	FormatCurl: encodeCurl,
+}
+
+func init() {
+	// Every code generation target (go, python, k6, ...) is a format of its own.
+	for _, lang := range tcodegen.Languages() {
+		encoders[Format(lang)] = encodeCode(tcodegen.Language(lang))
+	}
+}
+
+// Formats returns the supported output formats in a stable order
+func Formats() []string {
+	formats := make([]string, 0, len(encoders))
+	for f := range encoders {
+		formats = append(formats, string(f))
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Export imports bundle into a fresh in-memory workspace and encodes the
+// workspace as read back from the database. This is the same path the
+// server's export RPC takes, so the CLI produces the files the desktop app
+// would.
+func Export(ctx context.Context, logger *slog.Logger, bundle *ioworkspace.WorkspaceBundle, format Format) ([]byte, error) {
+	encode, ok := encoders[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported export format %q (supported: %s)", format, strings.Join(Formats(), ", "))
+	}
+
+	db, _, err := sqlitemem.NewSQLiteMem(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	services, err := common.CreateServices(ctx, db, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	wsID := bundle.Workspace.ID
+	if err := services.Workspace.Create(ctx, &bundle.Workspace); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	if _, err := importer.ImportBundle(ctx, services, bundle, wsID, nil); err != nil {
+		return nil, err
+	}
+
+	exported, err := ioworkspace.New(services.Queries, logger).Export(ctx, ioworkspace.ExportOptions{
+		WorkspaceID:         wsID,
+		IncludeHTTP:         true,
+		IncludeFlows:        true,
+		IncludeEnvironments: true,
+		IncludeFiles:        true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export workspace: %w", err)
+	}
+	// Load scenarios have no storage yet, so carry them over from the source.
+	exported.LoadScenarios = bundle.LoadScenarios
+
+	return encode(exported)
+}
+
+func encodeYAML(bundle *ioworkspace.WorkspaceBundle) ([]byte, error) {
+	data, err := yamlflowsimplev2.MarshalSimplifiedYAML(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("YAML marshalling failed: %w", err)
+	}
+	return data, nil
+}
+
+// encodeCurl renders every base HTTP request as a curl command, separated by
+// a comment line carrying the request name
+func encodeCurl(bundle *ioworkspace.WorkspaceBundle) ([]byte, error) {
+	var commands []string
+	for _, resolved := range resolveRequests(bundle) {
+		cmd, err := tcurlv2.BuildCurl(resolved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build curl for %s: %w", resolved.HTTP.Name, err)
+		}
+		commands = append(commands, fmt.Sprintf("# %s\n%s", resolved.HTTP.Name, cmd))
+	}
+	if len(commands) == 0 {
+		return nil, nil
+	}
+	return []byte(strings.Join(commands, "\n\n") + "\n"), nil
+}
+
+// encodeCode renders the base HTTP requests as a program of lang, or the
+// flows as a k6 script
+func encodeCode(lang tcodegen.Language) encoder {
+	return func(bundle *ioworkspace.WorkspaceBundle) ([]byte, error) {
+		out, err := tcodegen.GenerateBundle(bundle, lang, tcodegen.BundleOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("%s code generation failed: %w", lang, err)
+		}
+		return []byte(out), nil
+	}
+}
+
+// resolveRequests groups the child entities of each base HTTP request of the
+// bundle. Delta requests are skipped, they are variations of a base request
+// and only make sense inside a flow.
+func resolveRequests(bundle *ioworkspace.WorkspaceBundle) []*tcurlv2.CurlResolvedV2 {
+	byID := make(map[idwrap.IDWrap]*tcurlv2.CurlResolvedV2, len(bundle.HTTPRequests))
+	var ordered []*tcurlv2.CurlResolvedV2
+	for _, h := range bundle.HTTPRequests {
+		if h.IsDelta {
+			continue
+		}
+		resolved := &tcurlv2.CurlResolvedV2{HTTP: h}
+		byID[h.ID] = resolved
+		ordered = append(ordered, resolved)
+	}
+
+	for _, h := range bundle.HTTPHeaders {
+		if r, ok := byID[h.HttpID]; ok {
+			r.Headers = append(r.Headers, h)
+		}
+	}
+	for _, p := range bundle.HTTPSearchParams {
+		if r, ok := byID[p.HttpID]; ok {
+			r.SearchParams = append(r.SearchParams, p)
+		}
+	}
+	for _, f := range bundle.HTTPBodyForms {
+		if r, ok := byID[f.HttpID]; ok {
+			r.BodyForms = append(r.BodyForms, f)
+		}
+	}
+	for _, u := range bundle.HTTPBodyUrlencoded {
+		if r, ok := byID[u.HttpID]; ok {
+			r.BodyUrlencoded = append(r.BodyUrlencoded, u)
+		}
+	}
+	for i := range bundle.HTTPBodyRaw {
+		raw := bundle.HTTPBodyRaw[i]
+		if r, ok := byID[raw.HttpID]; ok {
+			r.BodyRaw = &raw
+		}
+	}
+
+	// Only send the body kind the request is actually configured with.
+	for _, r := range ordered {
+		switch r.HTTP.BodyKind {
+		case mhttp.HttpBodyKindRaw:
+			r.BodyForms, r.BodyUrlencoded = nil, nil
+		case mhttp.HttpBodyKindFormData:
+			r.BodyRaw, r.BodyUrlencoded = nil, nil
+		case mhttp.HttpBodyKindUrlEncoded:
+			r.BodyRaw, r.BodyForms = nil, nil
+		}
+	}
+
+	return ordered
+}
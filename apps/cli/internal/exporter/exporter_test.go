@@ -0,0 +1,178 @@
+package exporter_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/exporter"
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/importer"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	yamlflowsimplev2 "github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/yamlflowsimplev2"
+)
+
+const spec = `{
+	"openapi": "3.0.0",
+	"info": {"title": "Orders"},
+	"servers": [{"url": "https://api.example.com"}],
+	"paths": {
+		"/orders": {
+			"post": {
+				"operationId": "createOrder",
+				"requestBody": {"content": {"application/json": {"example": {"item": "book"}}}},
+				"responses": {"201": {"description": "created"}}
+			}
+		}
+	}
+}`
+
+func TestExport_YAML(t *testing.T) {
+	ctx := context.Background()
+	b, err := importer.BuildBundle([]byte(spec), importer.FormatOpenAPI, importer.BundleOptions{
+		WorkspaceID: idwrap.NewNow(),
+		Name:        "orders",
+	})
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+
+	out, err := exporter.Export(ctx, slog.Default(), b, exporter.FormatYAML)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	// The generated file must be runnable, i.e. convertible again.
+	roundTrip, err := yamlflowsimplev2.ConvertSimplifiedYAML(out, yamlflowsimplev2.ConvertOptionsV2{
+		WorkspaceID: idwrap.NewNow(),
+	})
+	if err != nil {
+		t.Fatalf("exported YAML does not convert back: %v\n%s", err, out)
+	}
+	if len(roundTrip.Flows) != 1 {
+		t.Errorf("expected 1 flow after round trip, got %d", len(roundTrip.Flows))
+	}
+	if !strings.Contains(string(out), "https://api.example.com/orders") {
+		t.Errorf("exported YAML misses the request URL:\n%s", out)
+	}
+}
+
+func TestExport_GeneratedTests(t *testing.T) {
+	ctx := context.Background()
+	const schemaSpec = `{
+		"openapi": "3.0.0",
+		"info": {"title": "Orders"},
+		"servers": [{"url": "https://api.example.com"}],
+		"paths": {
+			"/orders": {
+				"post": {
+					"operationId": "createOrder",
+					"requestBody": {"content": {"application/json": {"schema": {
+						"type": "object",
+						"required": ["quantity"],
+						"properties": {"quantity": {"type": "integer", "minimum": 1}}
+					}}}},
+					"responses": {"201": {"description": "created"}}
+				}
+			}
+		}
+	}`
+	b, err := importer.BuildBundle([]byte(schemaSpec), importer.FormatOpenAPI, importer.BundleOptions{
+		WorkspaceID:   idwrap.NewNow(),
+		Name:          "orders",
+		GenerateTests: true,
+	})
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+
+	out, err := exporter.Export(ctx, slog.Default(), b, exporter.FormatYAML)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	roundTrip, err := yamlflowsimplev2.ConvertSimplifiedYAML(out, yamlflowsimplev2.ConvertOptionsV2{
+		WorkspaceID: idwrap.NewNow(),
+	})
+	if err != nil {
+		t.Fatalf("exported YAML does not convert back: %v\n%s", err, out)
+	}
+	if len(roundTrip.HTTPRequests) < 4 {
+		t.Errorf("expected happy path plus negative cases, got %d requests", len(roundTrip.HTTPRequests))
+	}
+	for _, want := range []string{"createOrder_missing_quantity", "createOrder_quantity_below_minimum", "faker.randomInt(1, 1001)"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("exported suite misses %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExport_Curl(t *testing.T) {
+	ctx := context.Background()
+	b, err := importer.BuildBundle([]byte(spec), importer.FormatOpenAPI, importer.BundleOptions{
+		WorkspaceID: idwrap.NewNow(),
+		Name:        "orders",
+	})
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+
+	out, err := exporter.Export(ctx, slog.Default(), b, exporter.FormatCurl)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	got := string(out)
+	for _, want := range []string{"# createOrder", "curl 'https://api.example.com/orders'", "-X POST", `--data-raw '{"item":"book"}'`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("curl export missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestExport_UnknownFormat(t *testing.T) {
+	_, err := exporter.Export(context.Background(), slog.Default(), nil, exporter.Format("xml"))
+	if err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestExport_Code(t *testing.T) {
+	ctx := context.Background()
+	b, err := importer.BuildBundle([]byte("export HOST=https://api.example.com\n"+
+		"curl -X POST \"$HOST/orders\" -H 'Content-Type: application/json' -d '{\"item\":\"book\"}'\n"+
+		"curl \"$HOST/orders/{{ http_1.response.body.id }}\"\n"), importer.FormatCurl, importer.BundleOptions{
+		WorkspaceID: idwrap.NewNow(),
+		Name:        "orders",
+	})
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+
+	tests := []struct {
+		format exporter.Format
+		want   []string
+	}{
+		{format: "go", want: []string{"package main", `http.NewRequest("POST", "https://api.example.com/orders"`}},
+		{format: "python", want: []string{"import requests", `"https://api.example.com/orders",`}},
+		{format: "javascript", want: []string{`await fetch("https://api.example.com/orders", {`}},
+		{format: "httpie", want: []string{"http --raw '{\"item\":\"book\"}' POST https://api.example.com/orders"}},
+		{format: "k6", want: []string{
+			`HOST: __ENV.HOST || "https://api.example.com",`,
+			"const http_2 = http.request(\"GET\", `${env.HOST}/orders/${http_1.json().id}`, null);",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			out, err := exporter.Export(ctx, slog.Default(), b, tt.format)
+			if err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("%s export missing %q:\n%s", tt.format, want, out)
+				}
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/apps/cli/internal/common"
 	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlitemem"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
 )
 
 // ImportCallback is the function signature for the actual import logic
@@ -51,3 +52,28 @@ func RunImport(ctx context.Context, logger *slog.Logger, workspaceID, folderID s
 	// Run specific import logic
 	return fn(ctx, services, wsID, folderIDPtr)
 }
+
+// ImportBundle stores every entity of a translated bundle in the workspace
+// through ioworkspace, inside a single transaction. IDs generated by the
+// translator are preserved so they stay stable across the import.
+func ImportBundle(ctx context.Context, services *common.Services, bundle *ioworkspace.WorkspaceBundle, wsID idwrap.IDWrap, folderIDPtr *idwrap.IDWrap) (*ioworkspace.ImportResult, error) {
+	tx, err := services.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	importOpts := ioworkspace.GetDefaultImportOptions(wsID)
+	importOpts.ParentFolderID = folderIDPtr
+	importOpts.PreserveIDs = true
+
+	result, err := ioworkspace.New(services.Queries, services.Logger).Import(ctx, tx, bundle, importOpts)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to import workspace bundle: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
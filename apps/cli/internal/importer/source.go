@@ -0,0 +1,397 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/schema"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/harv2"
+	tcurlv2 "github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcurlv2"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tgraphqlv2"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/topenapiv2"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tpostmanv2"
+	yamlflowsimplev2 "github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/yamlflowsimplev2"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxSourceSize caps how much is read from a remote source (50MB), matching
+// the server-side import URL fetcher.
+const maxSourceSize = 50 * 1024 * 1024
+
+// curlLinePattern finds a curl invocation at the start of any line of a script.
+var curlLinePattern = regexp.MustCompile(`(?m)^\s*curl\s`)
+
+// SourceFormat identifies the kind of document a source file holds.
+type SourceFormat string
+
+const (
+	FormatOpenAPI  SourceFormat = "openapi"
+	FormatPostman  SourceFormat = "postman"
+	FormatHAR      SourceFormat = "har"
+	FormatCurl     SourceFormat = "curl"
+	FormatYAMLFlow SourceFormat = "yamlflow"
+	FormatGraphQL  SourceFormat = "graphql"
+)
+
+// BundleOptions configures how a source document is turned into a workspace bundle.
+type BundleOptions struct {
+	WorkspaceID idwrap.IDWrap
+	FolderID    *idwrap.IDWrap
+	// Name is used as the workspace name and as the collection name for
+	// formats that do not carry one themselves.
+	Name string
+
+	// OpenAPI options, ignored for every other format. Operations also
+	// filters the root fields of a GraphQL schema.
+	Tags          []string
+	Operations    []string
+	BaseURL       string
+	GenerateTests bool
+
+	// GraphQL options: the endpoint requests are sent to, the depth of the
+	// default selection sets generated from a schema, and whether document
+	// operations inline their fragments instead of storing them.
+	Endpoint        string
+	Depth           int
+	InlineFragments bool
+
+	// BaseDir resolves "#file:" query references of yamlflow documents. If
+	// empty, such references are rejected.
+	BaseDir string
+}
+
+// RelativeFileReader returns a reader for files referenced by a document in
+// dir. Relative paths are resolved against dir.
+func RelativeFileReader(dir string) func(path string) ([]byte, error) {
+	return func(path string) ([]byte, error) {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		return os.ReadFile(path)
+	}
+}
+
+// ReadSource reads a source document from a local path or an http(s) URL.
+func ReadSource(ctx context.Context, ref string) ([]byte, error) {
+	if !strings.HasPrefix(ref, "http://") && !strings.HasPrefix(ref, "https://") {
+		data, err := os.ReadFile(ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", ref, err)
+		}
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ref, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source URL: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to fetch %s: unexpected status %s", ref, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSourceSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", ref, err)
+	}
+	if len(data) > maxSourceSize {
+		return nil, fmt.Errorf("source %s exceeds %d bytes", ref, maxSourceSize)
+	}
+	return data, nil
+}
+
+// Introspect runs the introspection query against a GraphQL endpoint and
+// returns the raw result. Headers are given as "Key: Value".
+func Introspect(ctx context.Context, endpoint string, headers []string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"query": schema.IntrospectionQuery})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode introspection query: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, h := range headers {
+		key, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q, expected \"Key: Value\"", h)
+		}
+		req.Header.Set(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspection request to %s failed: %w", endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSourceSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", endpoint, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("introspection of %s failed: unexpected status %s", endpoint, resp.Status)
+	}
+	if len(data) > maxSourceSize {
+		return nil, fmt.Errorf("introspection result of %s exceeds %d bytes", endpoint, maxSourceSize)
+	}
+	return data, nil
+}
+
+// DetectFormat guesses the format of a source document from its content.
+func DetectFormat(data []byte) (SourceFormat, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return "", fmt.Errorf("source is empty")
+	}
+
+	if bytes.HasPrefix(trimmed, []byte("curl ")) || bytes.HasPrefix(trimmed, []byte("curl\t")) {
+		return FormatCurl, nil
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(trimmed, &doc); err != nil {
+		if err := yaml.Unmarshal(trimmed, &doc); err != nil {
+			// Shell scripts with a shebang, comments or variable assignments
+			// before their first curl command.
+			if curlLinePattern.Match(trimmed) {
+				return FormatCurl, nil
+			}
+			if isGraphQLDocument(string(trimmed)) {
+				return FormatGraphQL, nil
+			}
+			return "", fmt.Errorf("source is neither JSON, YAML, GraphQL nor a curl command")
+		}
+	}
+
+	if _, ok := doc["openapi"]; ok {
+		return FormatOpenAPI, nil
+	}
+	if _, ok := doc["swagger"]; ok {
+		return FormatOpenAPI, nil
+	}
+	if log, ok := doc["log"].(map[string]interface{}); ok {
+		if _, ok := log["entries"]; ok {
+			return FormatHAR, nil
+		}
+	}
+	if info, ok := doc["info"].(map[string]interface{}); ok {
+		if schema, ok := info["schema"].(string); ok && strings.Contains(schema, "getpostman.com") {
+			return FormatPostman, nil
+		}
+	}
+	if _, ok := doc["flows"]; ok {
+		return FormatYAMLFlow, nil
+	}
+	if _, ok := doc["__schema"]; ok {
+		return FormatGraphQL, nil
+	}
+	if inner, ok := doc["data"].(map[string]interface{}); ok {
+		if _, ok := inner["__schema"]; ok {
+			return FormatGraphQL, nil
+		}
+	}
+
+	return "", fmt.Errorf("unrecognized source format")
+}
+
+// isGraphQLDocument reports whether src is a .graphql file: operations and
+// fragments, or a schema in SDL.
+func isGraphQLDocument(src string) bool {
+	if _, err := schema.ParseQuery(src); err == nil {
+		return true
+	}
+	_, err := schema.ParseSDL(src)
+	return err == nil
+}
+
+// BuildBundle translates a source document into a workspace bundle that can
+// be imported with ioworkspace or marshalled straight back out.
+func BuildBundle(data []byte, format SourceFormat, opts BundleOptions) (*ioworkspace.WorkspaceBundle, error) {
+	bundle := &ioworkspace.WorkspaceBundle{
+		Workspace: mworkspace.Workspace{
+			ID:   opts.WorkspaceID,
+			Name: opts.Name,
+		},
+	}
+
+	switch format {
+	case FormatOpenAPI:
+		resolved, err := topenapiv2.ConvertOpenAPI(data, topenapiv2.ConvertOptions{
+			WorkspaceID:   opts.WorkspaceID,
+			FolderID:      opts.FolderID,
+			Tags:          opts.Tags,
+			Operations:    opts.Operations,
+			BaseURL:       opts.BaseURL,
+			GenerateTests: opts.GenerateTests,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert OpenAPI spec: %w", err)
+		}
+		bundle.HTTPRequests = resolved.HTTPRequests
+		bundle.HTTPHeaders = resolved.Headers
+		bundle.HTTPSearchParams = resolved.SearchParams
+		bundle.HTTPBodyRaw = resolved.BodyRaw
+		bundle.HTTPAsserts = resolved.Asserts
+		bundle.Files = resolved.Files
+		bundle.Flows = append(bundle.Flows, resolved.Flow)
+		bundle.FlowNodes = resolved.Nodes
+		bundle.FlowRequestNodes = resolved.RequestNodes
+		bundle.FlowEdges = resolved.Edges
+
+	case FormatGraphQL:
+		resolved, err := tgraphqlv2.Convert(data, tgraphqlv2.ConvertOptions{
+			WorkspaceID:     opts.WorkspaceID,
+			FolderID:        opts.FolderID,
+			Endpoint:        opts.Endpoint,
+			Depth:           opts.Depth,
+			Operations:      opts.Operations,
+			InlineFragments: opts.InlineFragments,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert GraphQL source: %w", err)
+		}
+		bundle.GraphQLRequests = resolved.GraphQLRequests
+		bundle.Files = resolved.Files
+
+	case FormatPostman:
+		resolved, err := tpostmanv2.ConvertPostmanCollection(data, tpostmanv2.ConvertOptions{
+			WorkspaceID:    opts.WorkspaceID,
+			FolderID:       opts.FolderID,
+			CollectionName: opts.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert Postman collection: %w", err)
+		}
+		bundle.HTTPRequests = resolved.HTTPRequests
+		bundle.HTTPHeaders = resolved.Headers
+		bundle.HTTPSearchParams = resolved.SearchParams
+		bundle.HTTPBodyForms = resolved.BodyForms
+		bundle.HTTPBodyUrlencoded = resolved.BodyUrlencoded
+		bundle.HTTPBodyRaw = resolved.BodyRaw
+		bundle.HTTPAsserts = resolved.Asserts
+		bundle.Files = resolved.Files
+		bundle.Flows = append(bundle.Flows, resolved.Flow)
+		bundle.FlowNodes = resolved.Nodes
+		bundle.FlowRequestNodes = resolved.RequestNodes
+		bundle.FlowEdges = resolved.Edges
+
+	case FormatHAR:
+		har, err := harv2.ConvertRaw(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse HAR file: %w", err)
+		}
+		resolved, err := harv2.ConvertHAR(har, opts.WorkspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert HAR file: %w", err)
+		}
+		bundle.HTTPRequests = resolved.HTTPRequests
+		bundle.HTTPHeaders = resolved.HTTPHeaders
+		bundle.HTTPSearchParams = resolved.HTTPSearchParams
+		bundle.HTTPBodyForms = resolved.HTTPBodyForms
+		bundle.HTTPBodyUrlencoded = resolved.HTTPBodyUrlEncoded
+		bundle.HTTPBodyRaw = resolved.HTTPBodyRaws
+		bundle.HTTPAsserts = resolved.HTTPAsserts
+		bundle.Files = resolved.Files
+		bundle.Flows = append(bundle.Flows, resolved.Flow)
+		bundle.FlowNodes = resolved.Nodes
+		bundle.FlowRequestNodes = resolved.RequestNodes
+		bundle.FlowEdges = resolved.Edges
+
+	case FormatCurl:
+		resolved, err := tcurlv2.ConvertCurlScript(string(data), tcurlv2.ConvertCurlScriptOptions{
+			WorkspaceID: opts.WorkspaceID,
+			FolderID:    opts.FolderID,
+			FlowName:    opts.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert curl command: %w", err)
+		}
+		for _, r := range resolved.Requests {
+			bundle.HTTPRequests = append(bundle.HTTPRequests, r.HTTP)
+			bundle.HTTPHeaders = append(bundle.HTTPHeaders, r.Headers...)
+			bundle.HTTPSearchParams = append(bundle.HTTPSearchParams, r.SearchParams...)
+			bundle.HTTPBodyForms = append(bundle.HTTPBodyForms, r.BodyForms...)
+			bundle.HTTPBodyUrlencoded = append(bundle.HTTPBodyUrlencoded, r.BodyUrlencoded...)
+			if r.BodyRaw != nil {
+				bundle.HTTPBodyRaw = append(bundle.HTTPBodyRaw, *r.BodyRaw)
+			}
+			bundle.Files = append(bundle.Files, r.File)
+		}
+		bundle.Flows = append(bundle.Flows, resolved.Flow)
+		bundle.FlowNodes = resolved.Nodes
+		bundle.FlowRequestNodes = resolved.RequestNodes
+		bundle.FlowEdges = resolved.Edges
+		bundle.Files = append(bundle.Files, resolved.FlowFile)
+
+		// Shell variables become an environment the requests resolve against.
+		if len(resolved.Variables) > 0 {
+			env := menv.Env{
+				ID:          idwrap.NewNow(),
+				WorkspaceID: opts.WorkspaceID,
+				Type:        menv.EnvNormal,
+				Name:        resolved.Flow.Name,
+			}
+			bundle.Environments = append(bundle.Environments, env)
+			bundle.Workspace.ActiveEnv = env.ID
+			for i, v := range resolved.Variables {
+				bundle.EnvironmentVars = append(bundle.EnvironmentVars, menv.Variable{
+					ID:      idwrap.NewNow(),
+					EnvID:   env.ID,
+					VarKey:  v.Key,
+					Value:   v.Value,
+					Enabled: true,
+					Order:   float64(i + 1),
+				})
+			}
+		}
+
+	case FormatYAMLFlow:
+		yamlOpts := yamlflowsimplev2.ConvertOptionsV2{
+			WorkspaceID: opts.WorkspaceID,
+			FolderID:    opts.FolderID,
+		}
+		if opts.BaseDir != "" {
+			yamlOpts.ReadFile = RelativeFileReader(opts.BaseDir)
+		}
+		resolved, err := yamlflowsimplev2.ConvertSimplifiedYAML(data, yamlOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert yamlflow file: %w", err)
+		}
+		if resolved.Workspace.Name == "" {
+			resolved.Workspace.Name = opts.Name
+		}
+		return resolved, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported source format %q", format)
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,225 @@
+package importer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/importer"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+)
+
+const petstoreSpec = `openapi: "3.0.0"
+info:
+  title: Petstore
+servers:
+  - url: https://petstore.example.com/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      responses:
+        "200":
+          description: OK
+  /users:
+    get:
+      operationId: listUsers
+      tags: [users]
+      responses:
+        "200":
+          description: OK
+`
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want importer.SourceFormat
+	}{
+		{"openapi yaml", petstoreSpec, importer.FormatOpenAPI},
+		{"swagger json", `{"swagger": "2.0", "paths": {}}`, importer.FormatOpenAPI},
+		{"har", `{"log": {"version": "1.2", "entries": []}}`, importer.FormatHAR},
+		{"postman", `{"info": {"name": "c", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"}, "item": []}`, importer.FormatPostman},
+		{"curl", "  curl https://example.com", importer.FormatCurl},
+		{"curl script", "#!/bin/sh\nexport HOST=https://example.com\ncurl \"$HOST/a\"\n", importer.FormatCurl},
+		{"yamlflow", "workspace_name: ws\nflows:\n  - name: f\n", importer.FormatYAMLFlow},
+		{"graphql introspection", graphqlIntrospection, importer.FormatGraphQL},
+		{"graphql introspection data", `{"__schema": {"queryType": {"name": "Query"}, "types": []}}`, importer.FormatGraphQL},
+		{"graphql document", "# users\nquery GetUser($id: ID!) {\n  user(id: $id) { ...F }\n}\nfragment F on User { id }\n", importer.FormatGraphQL},
+		{"graphql sdl", "type Query {\n  book(id: ID!): Book\n}\n\ntype Book { id: ID! }\n", importer.FormatGraphQL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := importer.DetectFormat([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("DetectFormat() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := importer.DetectFormat([]byte(`{"hello": "world"}`)); err == nil {
+		t.Error("expected error for unrecognized document")
+	}
+}
+
+func TestBuildBundle_OpenAPIFilters(t *testing.T) {
+	wsID := idwrap.NewNow()
+	bundle, err := importer.BuildBundle([]byte(petstoreSpec), importer.FormatOpenAPI, importer.BundleOptions{
+		WorkspaceID: wsID,
+		Name:        "petstore",
+		Tags:        []string{"pets"},
+		BaseURL:     "http://localhost:4010",
+	})
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+
+	if bundle.Workspace.ID != wsID || bundle.Workspace.Name != "petstore" {
+		t.Errorf("unexpected workspace %+v", bundle.Workspace)
+	}
+	if len(bundle.HTTPRequests) != 1 {
+		t.Fatalf("expected 1 HTTP request, got %d", len(bundle.HTTPRequests))
+	}
+	if got := bundle.HTTPRequests[0].Url; got != "http://localhost:4010/pets" {
+		t.Errorf("unexpected URL %q", got)
+	}
+	if len(bundle.Flows) != 1 || len(bundle.FlowRequestNodes) != 1 {
+		t.Errorf("expected one flow with one request node, got %d flows and %d request nodes", len(bundle.Flows), len(bundle.FlowRequestNodes))
+	}
+}
+
+const graphqlIntrospection = `{"data": {"__schema": {
+  "queryType": {"name": "Query"},
+  "types": [
+    {"kind": "OBJECT", "name": "Query", "fields": [
+      {"name": "book", "args": [{"name": "id", "type": {"kind": "NON_NULL", "ofType": {"kind": "SCALAR", "name": "ID"}}}],
+       "type": {"kind": "OBJECT", "name": "Book"}},
+      {"name": "ping", "args": [], "type": {"kind": "SCALAR", "name": "String"}}
+    ]},
+    {"kind": "OBJECT", "name": "Book", "fields": [
+      {"name": "id", "args": [], "type": {"kind": "SCALAR", "name": "ID"}},
+      {"name": "title", "args": [], "type": {"kind": "SCALAR", "name": "String"}}
+    ]}
+  ]
+}}}`
+
+func TestBuildBundle_GraphQLSchema(t *testing.T) {
+	bundle, err := importer.BuildBundle([]byte(graphqlIntrospection), importer.FormatGraphQL, importer.BundleOptions{
+		WorkspaceID: idwrap.NewNow(),
+		Name:        "books",
+		Endpoint:    "http://localhost:4000/graphql",
+	})
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+
+	if len(bundle.GraphQLRequests) != 2 || len(bundle.Files) != 2 {
+		t.Fatalf("expected 2 GraphQL requests with files, got %d requests and %d files", len(bundle.GraphQLRequests), len(bundle.Files))
+	}
+	book := bundle.GraphQLRequests[0]
+	if book.Name != "book" || book.Url != "http://localhost:4000/graphql" {
+		t.Errorf("unexpected request %+v", book)
+	}
+	want := "query book($id: ID!) {\n  book(id: $id) {\n    id\n    title\n  }\n}\n"
+	if book.Query != want {
+		t.Errorf("query = %q, want %q", book.Query, want)
+	}
+}
+
+func TestBuildBundle_GraphQLDocument(t *testing.T) {
+	doc := `query GetBook($id: ID!) { book(id: $id) { ...BookFields } }
+query ListBooks { books { ...BookFields } }
+fragment BookFields on Book { id title }
+`
+	bundle, err := importer.BuildBundle([]byte(doc), importer.FormatGraphQL, importer.BundleOptions{
+		WorkspaceID:     idwrap.NewNow(),
+		Endpoint:        "http://localhost:4000/graphql",
+		Operations:      []string{"GetBook"},
+		InlineFragments: true,
+	})
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+
+	if len(bundle.GraphQLRequests) != 1 || len(bundle.Files) != 1 {
+		t.Fatalf("expected 1 GraphQL request with a file, got %d requests and %d files", len(bundle.GraphQLRequests), len(bundle.Files))
+	}
+	book := bundle.GraphQLRequests[0]
+	if book.Name != "GetBook" || book.OperationName != "GetBook" {
+		t.Errorf("unexpected request %+v", book)
+	}
+	want := "query GetBook($id: ID!) {\n  book(id: $id) {\n    ... on Book {\n      id\n      title\n    }\n  }\n}\n"
+	if book.Query != want {
+		t.Errorf("query = %q, want %q", book.Query, want)
+	}
+}
+
+func TestBuildBundle_YAMLFlowQueryFile(t *testing.T) {
+	dir := t.TempDir()
+	query := "query GetBook { book { id } }\n"
+	if err := os.WriteFile(filepath.Join(dir, "book.graphql"), []byte(query), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	flow := `workspace_name: ws
+flows:
+  - name: f
+    steps:
+      - graphql:
+          name: Book
+          url: http://localhost:4000/graphql
+          query: "#file:book.graphql"
+          operation_name: GetBook
+`
+	opts := importer.BundleOptions{WorkspaceID: idwrap.NewNow()}
+	if _, err := importer.BuildBundle([]byte(flow), importer.FormatYAMLFlow, opts); err == nil {
+		t.Error("expected an error without a base directory")
+	}
+
+	opts.BaseDir = dir
+	bundle, err := importer.BuildBundle([]byte(flow), importer.FormatYAMLFlow, opts)
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+	if len(bundle.GraphQLRequests) != 1 {
+		t.Fatalf("expected 1 GraphQL request, got %d", len(bundle.GraphQLRequests))
+	}
+	if gql := bundle.GraphQLRequests[0]; gql.Query != query || gql.QueryFile != "book.graphql" || gql.OperationName != "GetBook" {
+		t.Errorf("unexpected request %+v", gql)
+	}
+}
+
+func TestBuildBundle_CurlScript(t *testing.T) {
+	script := `HOST=https://example.com
+curl "$HOST/users" -H "Authorization: Bearer $TOKEN"
+curl -X DELETE "$HOST/users/1"
+`
+	bundle, err := importer.BuildBundle([]byte(script), importer.FormatCurl, importer.BundleOptions{
+		WorkspaceID: idwrap.NewNow(),
+		Name:        "cleanup",
+	})
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+
+	if len(bundle.HTTPRequests) != 2 || len(bundle.FlowRequestNodes) != 2 {
+		t.Fatalf("expected 2 requests in the flow, got %d requests and %d nodes", len(bundle.HTTPRequests), len(bundle.FlowRequestNodes))
+	}
+	if len(bundle.Flows) != 1 || bundle.Flows[0].Name != "cleanup" {
+		t.Errorf("unexpected flows %+v", bundle.Flows)
+	}
+	if len(bundle.Environments) != 1 || bundle.Workspace.ActiveEnv != bundle.Environments[0].ID {
+		t.Fatalf("expected one active environment, got %+v", bundle.Environments)
+	}
+
+	vars := make(map[string]string)
+	for _, v := range bundle.EnvironmentVars {
+		vars[v.VarKey] = v.Value
+	}
+	if len(vars) != 2 || vars["HOST"] != "https://example.com" || vars["TOKEN"] != "" {
+		t.Errorf("unexpected environment variables %v", vars)
+	}
+}
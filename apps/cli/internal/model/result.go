@@ -22,11 +22,16 @@ type NodeRunResult struct {
 }
 
 type FlowRunResult struct {
-	FlowID   string          `json:"flow_id"`
-	FlowName string          `json:"flow_name"`
-	Started  time.Time       `json:"started_at"`
-	Duration time.Duration   `json:"duration"`
-	Status   string          `json:"status"`
-	Error    string          `json:"error,omitempty"`
-	Nodes    []NodeRunResult `json:"nodes"`
+	FlowID      string          `json:"flow_id"`
+	ExecutionID string          `json:"execution_id,omitempty"` // Resumable with flow run --resume when the run failed
+	FlowName    string          `json:"flow_name"`
+	Started     time.Time       `json:"started_at"`
+	Duration    time.Duration   `json:"duration"`
+	Status      string          `json:"status"`
+	Error       string          `json:"error,omitempty"`
+	Nodes       []NodeRunResult `json:"nodes"`
+	// Teardown holds the nodes of the flow's teardown section, which are
+	// reported apart from Nodes. TeardownError is the first of their errors.
+	Teardown      []NodeRunResult `json:"teardown,omitempty"`
+	TeardownError string          `json:"teardown_error,omitempty"`
 }
@@ -273,6 +273,44 @@ type junitFailure struct {
 	Data    string `xml:",chardata"`
 }
 
+// junitSuite builds a test suite with a test case per node, failing the
+// cases of nodes that did not succeed.
+func junitSuite(name string, duration time.Duration, nodes []model.NodeRunResult) junitTestSuite {
+	suite := junitTestSuite{
+		Name:     name,
+		Tests:    len(nodes),
+		Failures: 0,
+		Time:     fmt.Sprintf("%.6f", duration.Seconds()),
+		Cases:    make([]junitTestCase, 0, len(nodes)),
+	}
+
+	for _, node := range nodes {
+		testCase := junitTestCase{
+			Name: node.Name,
+			Time: fmt.Sprintf("%.6f", node.Duration.Seconds()),
+		}
+
+		if strings.EqualFold(node.State, mflow.StringNodeState(mflow.NODE_STATE_SUCCESS)) {
+			// no failure
+		} else {
+			failureType := node.State
+			if failureType == "" {
+				failureType = "Failure"
+			}
+			testCase.Failure = &junitFailure{
+				Message: failureType,
+				Type:    failureType,
+				Data:    node.Error,
+			}
+			suite.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	return suite
+}
+
 func (j *junitReporter) Flush() error {
 	j.mu.Lock()
 	defer j.mu.Unlock()
@@ -283,39 +321,17 @@ func (j *junitReporter) Flush() error {
 
 	suites := make([]junitTestSuite, 0, len(j.results))
 	for _, result := range j.results {
-		suite := junitTestSuite{
-			Name:     result.FlowName,
-			Tests:    len(result.Nodes),
-			Failures: 0,
-			Time:     fmt.Sprintf("%.6f", result.Duration.Seconds()),
-			Cases:    make([]junitTestCase, 0, len(result.Nodes)),
-		}
-
-		for _, node := range result.Nodes {
-			testCase := junitTestCase{
-				Name: node.Name,
-				Time: fmt.Sprintf("%.6f", node.Duration.Seconds()),
+		suites = append(suites, junitSuite(result.FlowName, result.Duration, result.Nodes))
+
+		// Teardown nodes get a suite of their own so a failed cleanup is
+		// not mistaken for a failure of the flow's steps.
+		if len(result.Teardown) > 0 {
+			var duration time.Duration
+			for _, node := range result.Teardown {
+				duration += node.Duration
 			}
-
-			if strings.EqualFold(node.State, mflow.StringNodeState(mflow.NODE_STATE_SUCCESS)) {
-				// no failure
-			} else {
-				failureType := node.State
-				if failureType == "" {
-					failureType = "Failure"
-				}
-				testCase.Failure = &junitFailure{
-					Message: failureType,
-					Type:    failureType,
-					Data:    node.Error,
-				}
-				suite.Failures++
-			}
-
-			suite.Cases = append(suite.Cases, testCase)
+			suites = append(suites, junitSuite(result.FlowName+" (teardown)", duration, result.Teardown))
 		}
-
-		suites = append(suites, suite)
 	}
 
 	output := junitTestSuites{Suites: suites}
@@ -352,6 +368,10 @@ type consoleFlowState struct {
 	totalNodes     int
 	successCount   int
 	maxStepNameLen int
+
+	// Teardown nodes are counted apart from the flow's steps.
+	teardownCount   int
+	teardownSuccess int
 }
 
 func newConsoleReporter(showOutput bool) Reporter {
@@ -438,6 +458,22 @@ func (c *consoleReporter) HandleNodeStatus(event NodeStatusEvent) {
 		return
 	}
 
+	if event.Status.Teardown {
+		c.mu.Lock()
+		first := state.teardownCount == 0
+		state.teardownCount++
+		if event.Status.State == mflow.NODE_STATE_SUCCESS {
+			state.teardownSuccess++
+		}
+		c.mu.Unlock()
+		if first {
+			title := " Teardown"
+			fmt.Println(state.separator)
+			fmt.Printf("|%s%s|\n", title, strings.Repeat(" ", max(len(state.topBorder)-2-len(title), 0)))
+			fmt.Println(state.separator)
+		}
+	}
+
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
 	statusStr := mflow.StringNodeStateWithIcons(event.Status.State)
 
@@ -454,7 +490,7 @@ func (c *consoleReporter) HandleNodeStatus(event NodeStatusEvent) {
 		c.printOutputData(event.Status.OutputData, event.Status.Name)
 	}
 
-	if event.Status.State == mflow.NODE_STATE_SUCCESS {
+	if event.Status.State == mflow.NODE_STATE_SUCCESS && !event.Status.Teardown {
 		c.mu.Lock()
 		state.successCount++
 		c.mu.Unlock()
@@ -476,7 +512,10 @@ func (c *consoleReporter) HandleFlowResult(result model.FlowRunResult) {
 	}
 
 	fmt.Println(state.topBorder)
-	fmt.Printf("Flow Duration: %v | Steps: %d/%d Successful\n", result.Duration, state.successCount, state.totalNodes)
+	fmt.Printf("Flow Duration: %v | Steps: %d/%d Successful\n", result.Duration, state.successCount, state.totalNodes-state.teardownCount)
+	if state.teardownCount > 0 {
+		fmt.Printf("Teardown: %d/%d Successful\n", state.teardownSuccess, state.teardownCount)
+	}
 }
 
 func (c *consoleReporter) SetLoadReport(report *LoadReport) {
@@ -174,3 +174,73 @@ func TestJUnitReporterFlush(t *testing.T) {
 		t.Fatalf("expected failure message 'fail', got %q", suite.Cases[1].Failure.Data)
 	}
 }
+
+func TestJUnitReporterTeardownSuite(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "report.xml")
+
+	specs := []ReportSpec{{Format: ReportFormatJUnit, Path: outputPath}}
+	group, err := NewReporterGroup(specs, ReporterOptions{})
+	if err != nil {
+		t.Fatalf("failed to create reporter group: %v", err)
+	}
+
+	sample := model.FlowRunResult{
+		FlowID:   "01HZXPM0Q8",
+		FlowName: "Sample",
+		Started:  time.Unix(0, 0).UTC(),
+		Duration: time.Second,
+		Status:   "failed",
+		Error:    "teardown failed: gone",
+		Nodes: []model.NodeRunResult{
+			{
+				NodeID:   "Node1",
+				Name:     "CreateUser",
+				State:    mflow.StringNodeState(mflow.NODE_STATE_SUCCESS),
+				Duration: 100 * time.Millisecond,
+			},
+		},
+		Teardown: []model.NodeRunResult{
+			{
+				NodeID:   "Node2",
+				Name:     "DeleteUser",
+				State:    mflow.StringNodeState(mflow.NODE_STATE_FAILURE),
+				Duration: 50 * time.Millisecond,
+				Error:    "gone",
+			},
+		},
+		TeardownError: "gone",
+	}
+
+	group.HandleFlowResult(sample)
+	if err := group.Flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("failed to unmarshal junit report: %v", err)
+	}
+
+	if len(suites.Suites) != 2 {
+		t.Fatalf("expected flow and teardown suites, got %d", len(suites.Suites))
+	}
+	if suites.Suites[0].Failures != 0 {
+		t.Fatalf("expected the flow suite to pass, got %d failures", suites.Suites[0].Failures)
+	}
+	teardown := suites.Suites[1]
+	if teardown.Name != "Sample (teardown)" {
+		t.Fatalf("unexpected teardown suite name %q", teardown.Name)
+	}
+	if teardown.Failures != 1 || len(teardown.Cases) != 1 {
+		t.Fatalf("expected 1 failing teardown case, got %d failures in %d cases", teardown.Failures, len(teardown.Cases))
+	}
+	if teardown.Cases[0].Failure == nil || teardown.Cases[0].Failure.Data != "gone" {
+		t.Fatalf("expected teardown failure 'gone', got %+v", teardown.Cases[0].Failure)
+	}
+}
@@ -0,0 +1,139 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/model"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// ErrCheckpointNotFound is returned when no failed run was recorded under an
+// execution ID.
+var ErrCheckpointNotFound = errors.New("no failed run recorded for execution")
+
+// Checkpoint records what a failed flow run got through, so that
+// `flow run --resume <execution-id>` can continue it from the failed step.
+// The CLI imports the workflow file afresh on every run, so nodes are keyed
+// by name rather than by their per-run IDs.
+type Checkpoint struct {
+	ExecutionID string           `json:"execution_id"`
+	File        string           `json:"file"`
+	FlowName    string           `json:"flow_name"`
+	FailedAt    time.Time        `json:"failed_at"`
+	Nodes       []CheckpointNode `json:"nodes"`
+}
+
+// CheckpointNode is the last state and output a node recorded in the run.
+type CheckpointNode struct {
+	Name   string `json:"name"`
+	State  string `json:"state"`
+	Output any    `json:"output,omitempty"`
+}
+
+// CheckpointDir is where failed runs are recorded.
+func CheckpointDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locate cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "devtools", "executions"), nil
+}
+
+// NewCheckpoint records a failed run's node results. Loop iterations are left
+// out: a failure inside a loop body is resumed from the loop node itself.
+// When the run was itself a resumed one, the nodes it skipped keep what the
+// previous checkpoint recorded for them.
+func NewCheckpoint(file string, result model.FlowRunResult, previous *Checkpoint) Checkpoint {
+	checkpoint := Checkpoint{
+		ExecutionID: result.ExecutionID,
+		File:        file,
+		FlowName:    result.FlowName,
+		FailedAt:    time.Now(),
+	}
+
+	index := make(map[string]int)
+	record := func(node CheckpointNode) {
+		if i, ok := index[node.Name]; ok {
+			checkpoint.Nodes[i] = node
+			return
+		}
+		index[node.Name] = len(checkpoint.Nodes)
+		checkpoint.Nodes = append(checkpoint.Nodes, node)
+	}
+
+	if previous != nil {
+		for _, node := range previous.Nodes {
+			record(node)
+		}
+	}
+	for _, node := range result.Nodes {
+		if node.IterationContext != nil {
+			continue
+		}
+		record(CheckpointNode{Name: node.Name, State: node.State, Output: node.OutputData})
+	}
+	return checkpoint
+}
+
+// States returns each recorded node's state by name.
+func (c Checkpoint) States() map[string]mflow.NodeState {
+	states := make(map[string]mflow.NodeState, len(c.Nodes))
+	for _, node := range c.Nodes {
+		states[node.Name] = parseNodeState(node.State)
+	}
+	return states
+}
+
+func parseNodeState(s string) mflow.NodeState {
+	for _, state := range []mflow.NodeState{
+		mflow.NODE_STATE_RUNNING,
+		mflow.NODE_STATE_SUCCESS,
+		mflow.NODE_STATE_FAILURE,
+		mflow.NODE_STATE_CANCELED,
+	} {
+		if mflow.StringNodeState(state) == s {
+			return state
+		}
+	}
+	return mflow.NODE_STATE_UNSPECIFIED
+}
+
+// SaveCheckpoint writes checkpoint to dir as <execution-id>.json.
+func SaveCheckpoint(dir string, checkpoint Checkpoint) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create checkpoint directory: %w", err)
+	}
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+	path := filepath.Join(dir, checkpoint.ExecutionID+".json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// LoadCheckpoint reads the checkpoint recorded in dir for executionID.
+func LoadCheckpoint(dir, executionID string) (*Checkpoint, error) {
+	if executionID == "" || filepath.Base(executionID) != executionID {
+		return nil, fmt.Errorf("invalid execution id %q", executionID)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, executionID+".json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w %s", ErrCheckpointNotFound, executionID)
+		}
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("decode checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
@@ -0,0 +1,89 @@
+package runner_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/model"
+	"github.com/the-dev-tools/dev-tools/apps/cli/internal/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	result := model.FlowRunResult{
+		ExecutionID: "01EXEC",
+		FlowName:    "Checkout",
+		Nodes: []model.NodeRunResult{
+			{Name: "Start", State: "Success"},
+			{Name: "CreateUser", State: "Success", OutputData: map[string]any{"id": "user-1"}},
+			{Name: "Body", State: "Success", IterationContext: &model.IterationContextResult{IterationPath: []int{0}}},
+			{Name: "GetUser", State: "Failure", Error: "assertion failed"},
+		},
+	}
+
+	dir := t.TempDir()
+	if err := runner.SaveCheckpoint(dir, runner.NewCheckpoint("/flows/checkout.yaml", result, nil)); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	checkpoint, err := runner.LoadCheckpoint(dir, "01EXEC")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if checkpoint.FlowName != "Checkout" || checkpoint.File != "/flows/checkout.yaml" {
+		t.Errorf("unexpected checkpoint header: %+v", checkpoint)
+	}
+	states := checkpoint.States()
+	if len(states) != 3 {
+		t.Errorf("expected loop iterations to be left out, got %v", states)
+	}
+	if states["CreateUser"] != mflow.NODE_STATE_SUCCESS || states["GetUser"] != mflow.NODE_STATE_FAILURE {
+		t.Errorf("unexpected states: %v", states)
+	}
+	output, ok := checkpoint.Nodes[1].Output.(map[string]any)
+	if !ok || output["id"] != "user-1" {
+		t.Errorf("expected CreateUser output to round-trip, got %#v", checkpoint.Nodes[1].Output)
+	}
+}
+
+func TestCheckpointKeepsSkippedStepsOfResumedRun(t *testing.T) {
+	previous := &runner.Checkpoint{
+		ExecutionID: "01FIRST",
+		FlowName:    "Checkout",
+		Nodes: []runner.CheckpointNode{
+			{Name: "CreateUser", State: "Success", Output: map[string]any{"id": "user-1"}},
+			{Name: "GetUser", State: "Failure"},
+		},
+	}
+	resumed := model.FlowRunResult{
+		ExecutionID: "01SECOND",
+		FlowName:    "Checkout",
+		Nodes: []model.NodeRunResult{
+			{Name: "GetUser", State: "Success"},
+			{Name: "Pay", State: "Failure"},
+		},
+	}
+
+	checkpoint := runner.NewCheckpoint("checkout.yaml", resumed, previous)
+	states := checkpoint.States()
+	want := map[string]mflow.NodeState{
+		"CreateUser": mflow.NODE_STATE_SUCCESS,
+		"GetUser":    mflow.NODE_STATE_SUCCESS,
+		"Pay":        mflow.NODE_STATE_FAILURE,
+	}
+	for name, state := range want {
+		if states[name] != state {
+			t.Errorf("%s: expected state %d, got %d", name, state, states[name])
+		}
+	}
+}
+
+func TestLoadCheckpointNotFound(t *testing.T) {
+	_, err := runner.LoadCheckpoint(t.TempDir(), "01MISSING")
+	if !errors.Is(err, runner.ErrCheckpointNotFound) {
+		t.Fatalf("expected ErrCheckpointNotFound, got %v", err)
+	}
+	if _, err := runner.LoadCheckpoint(t.TempDir(), "../escape"); err == nil {
+		t.Fatal("expected a path-like execution id to be rejected")
+	}
+}
@@ -175,10 +175,23 @@ func RunMultipleFlows(ctx context.Context, fileData []byte, allFlows []mflow.Flo
 }
 
 func RunFlow(ctx context.Context, flowPtr *mflow.Flow, services RunnerServices, reporters *reporter.ReporterGroup) (model.FlowRunResult, error) {
+	return runFlow(ctx, flowPtr, services, reporters, nil)
+}
+
+// ResumeFlow continues the failed run recorded in checkpoint. Steps that
+// completed in it are not run again: their recorded outputs are restored as
+// variables and the flow continues from the step that failed.
+func ResumeFlow(ctx context.Context, flowPtr *mflow.Flow, checkpoint *Checkpoint, services RunnerServices, reporters *reporter.ReporterGroup) (model.FlowRunResult, error) {
+	return runFlow(ctx, flowPtr, services, reporters, checkpoint)
+}
+
+func runFlow(ctx context.Context, flowPtr *mflow.Flow, services RunnerServices, reporters *reporter.ReporterGroup, resume *Checkpoint) (model.FlowRunResult, error) {
+	executionID := idwrap.NewNow()
 	result := model.FlowRunResult{
-		FlowID:   flowPtr.ID.String(),
-		FlowName: flowPtr.Name,
-		Started:  time.Now(),
+		FlowID:      flowPtr.ID.String(),
+		ExecutionID: executionID.String(),
+		FlowName:    flowPtr.Name,
+		Started:     time.Now(),
 	}
 
 	markFailure := func(err error) (model.FlowRunResult, error) {
@@ -277,7 +290,13 @@ func RunFlow(ctx context.Context, flowPtr *mflow.Flow, services RunnerServices,
 	}
 
 	// Use the same timeout for the flow runner
-	runnerInst := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), latestFlowID, startNodeIDs, flowNodeMap, edgeMap, nodeTimeout, nil)
+	runnerInst := flowlocalrunner.CreateFlowRunner(executionID, latestFlowID, startNodeIDs, flowNodeMap, edgeMap, nodeTimeout, nil)
+
+	if resume != nil {
+		if err := applyCheckpoint(runnerInst, resume, nodes, flowVarsMap); err != nil {
+			return markFailure(err)
+		}
+	}
 
 	// Use a large buffer for CLI to avoid blocking
 	flowNodeStatusChan := make(chan runner.FlowNodeStatus, 10000)
@@ -309,7 +328,10 @@ func RunFlow(ctx context.Context, flowPtr *mflow.Flow, services RunnerServices,
 
 	// Collect results
 	nodeResults := make([]model.NodeRunResult, 0)
+	var teardownResults []model.NodeRunResult
 	var finalStatus runner.FlowStatus
+	ctxDone := ctx.Done()
+	canceled := false
 
 	// Wait for completion
 	for {
@@ -332,7 +354,11 @@ func RunFlow(ctx context.Context, flowPtr *mflow.Flow, services RunnerServices,
 				if nodeStatus.Name == ".git" || strings.HasPrefix(nodeStatus.Name, ".git/") || strings.HasPrefix(nodeStatus.Name, ".git\\") {
 					continue
 				}
-				nodeResults = append(nodeResults, buildNodeRunResult(nodeStatus))
+				if nodeStatus.Teardown {
+					teardownResults = append(teardownResults, buildNodeRunResult(nodeStatus))
+				} else {
+					nodeResults = append(nodeResults, buildNodeRunResult(nodeStatus))
+				}
 			}
 
 		case flowStatus, ok := <-flowStatusChan:
@@ -345,14 +371,28 @@ func RunFlow(ctx context.Context, flowPtr *mflow.Flow, services RunnerServices,
 				goto Done
 			}
 
-		case <-ctx.Done():
-			return markFailure(ctx.Err())
+		case <-ctxDone:
+			// Keep collecting: the runner still runs the flow's teardown
+			// before it reports the final status.
+			ctxDone = nil
+			canceled = true
 		}
 	}
 
 Done:
 	result.Duration = time.Since(result.Started)
 	result.Nodes = nodeResults
+	result.Teardown = teardownResults
+	for _, nr := range teardownResults {
+		if nr.Error != "" {
+			result.TeardownError = nr.Error
+			break
+		}
+	}
+
+	if canceled {
+		return markFailure(ctx.Err())
+	}
 
 	if finalStatus == runner.FlowStatusSuccess {
 		result.Status = "success"
@@ -365,6 +405,9 @@ Done:
 				break
 			}
 		}
+		if result.Error == "" && result.TeardownError != "" {
+			result.Error = "teardown failed: " + result.TeardownError
+		}
 		if result.Error == "" {
 			result.Error = fmt.Sprintf("Flow finished with status: %s", runner.FlowStatusString(finalStatus))
 		}
@@ -381,6 +424,38 @@ Done:
 	return result, nil
 }
 
+// applyCheckpoint sets runnerInst to resume the run recorded in checkpoint,
+// restoring each completed step's output into vars under the step's name.
+func applyCheckpoint(runnerInst *flowlocalrunner.FlowLocalRunner, checkpoint *Checkpoint, nodes []mflow.Node, vars map[string]any) error {
+	stateByName := checkpoint.States()
+	outputByName := make(map[string]any, len(checkpoint.Nodes))
+	for _, node := range checkpoint.Nodes {
+		outputByName[node.Name] = node.Output
+	}
+
+	states := make(map[idwrap.IDWrap]mflow.NodeState, len(nodes))
+	for _, node := range nodes {
+		if state, ok := stateByName[node.Name]; ok {
+			states[node.ID] = state
+		}
+	}
+
+	point, err := runnerInst.PlanResume(states, nil)
+	if err != nil {
+		return fmt.Errorf("resume execution %s: %w", checkpoint.ExecutionID, err)
+	}
+	for _, node := range nodes {
+		if _, ok := point.Completed[node.ID]; !ok {
+			continue
+		}
+		if output := outputByName[node.Name]; output != nil {
+			vars[node.Name] = output
+		}
+	}
+	runnerInst.SetResumePoint(&point)
+	return nil
+}
+
 func buildNodeRunResult(status runner.FlowNodeStatus) model.NodeRunResult {
 	nodeResult := model.NodeRunResult{
 		NodeID:      status.NodeID.String(),
@@ -1496,3 +1496,105 @@ run:
 		t.Errorf("expected an unmarshal error naming the parse failure, got: %v", err)
 	}
 }
+
+// TestFlowRun_ResumeFromFailedStep fails a flow at its second request, then
+// resumes it: the first request must not be sent again, and the resumed step
+// must read the first request's recorded output.
+func TestFlowRun_ResumeFromFailedStep(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	fixture := newFlowTestFixture(t)
+
+	var mu sync.Mutex
+	creates, gets := 0, 0
+	fixture.mockServer.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users":
+			creates++
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": fmt.Sprintf("user-%d", creates)})
+		case "/users/user-1":
+			gets++
+			if gets == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "Ada"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	yamlContent := fmt.Sprintf(`workspace_name: Resume Test
+flows:
+  - name: ResumeFlow
+    steps:
+      - manual_start:
+          name: Start
+      - request:
+          name: CreateUser
+          method: POST
+          url: %[1]s/users
+          depends_on: Start
+      - request:
+          name: GetUser
+          method: GET
+          url: "%[1]s/users/{{ CreateUser.response.body.id }}"
+          depends_on: CreateUser
+          assertions:
+            - response.status == 200
+`, fixture.mockServer.URL)
+
+	resolved, err := yamlflowsimplev2.ConvertSimplifiedYAML([]byte(yamlContent), yamlflowsimplev2.ConvertOptionsV2{
+		WorkspaceID: fixture.workspaceID,
+	})
+	if err != nil {
+		t.Fatalf("failed to convert YAML: %v", err)
+	}
+	fixture.importWorkspaceBundle(resolved)
+
+	flow := fixture.getFlowByName("ResumeFlow")
+	if flow == nil {
+		t.Fatal("ResumeFlow not found")
+	}
+
+	failed, err := runner.RunFlow(fixture.ctx, flow, fixture.getRunnerServices(nil), nil)
+	if err == nil {
+		t.Fatal("expected the first run to fail at GetUser")
+	}
+
+	dir := t.TempDir()
+	if err := runner.SaveCheckpoint(dir, runner.NewCheckpoint("flow.yaml", failed, nil)); err != nil {
+		t.Fatalf("failed to save checkpoint: %v", err)
+	}
+	checkpoint, err := runner.LoadCheckpoint(dir, failed.ExecutionID)
+	if err != nil {
+		t.Fatalf("failed to load checkpoint: %v", err)
+	}
+
+	resumed, err := runner.ResumeFlow(fixture.ctx, flow, checkpoint, fixture.getRunnerServices(nil), nil)
+	if err != nil {
+		t.Fatalf("resumed run failed: %v", err)
+	}
+	if resumed.Status != "success" {
+		t.Errorf("expected status 'success', got '%s'. Error: %s", resumed.Status, resumed.Error)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if creates != 1 {
+		t.Errorf("expected CreateUser to be sent once, got %d", creates)
+	}
+	if gets != 2 {
+		t.Errorf("expected GetUser to be retried once, got %d requests", gets)
+	}
+	for _, node := range resumed.Nodes {
+		if node.Name == "CreateUser" || node.Name == "Start" {
+			t.Errorf("completed step %s ran again", node.Name)
+		}
+	}
+}
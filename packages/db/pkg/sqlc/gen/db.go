@@ -135,12 +135,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.cleanupOrphanedFlowNodeGraphQLStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeGraphQL); err != nil {
 		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeGraphQL: %w", err)
 	}
+	if q.cleanupOrphanedFlowNodeGraphQLSubscriptionStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeGraphQLSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeGraphQLSubscription: %w", err)
+	}
 	if q.cleanupOrphanedFlowNodeHttpStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeHttp); err != nil {
 		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeHttp: %w", err)
 	}
 	if q.cleanupOrphanedFlowNodeJsStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeJs); err != nil {
 		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeJs: %w", err)
 	}
+	if q.cleanupOrphanedFlowNodeParallelStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeParallel); err != nil {
+		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeParallel: %w", err)
+	}
+	if q.cleanupOrphanedFlowNodePollStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodePoll); err != nil {
+		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodePoll: %w", err)
+	}
 	if q.cleanupOrphanedFlowNodeRunSubFlowStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeRunSubFlow); err != nil {
 		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeRunSubFlow: %w", err)
 	}
@@ -150,9 +159,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.cleanupOrphanedFlowNodeSubFlowTriggerStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeSubFlowTrigger); err != nil {
 		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeSubFlowTrigger: %w", err)
 	}
+	if q.cleanupOrphanedFlowNodeSwitchStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeSwitch); err != nil {
+		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeSwitch: %w", err)
+	}
 	if q.cleanupOrphanedFlowNodeWaitStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeWait); err != nil {
 		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeWait: %w", err)
 	}
+	if q.cleanupOrphanedFlowNodeWebhookTriggerStmt, err = db.PrepareContext(ctx, cleanupOrphanedFlowNodeWebhookTrigger); err != nil {
+		return nil, fmt.Errorf("error preparing query CleanupOrphanedFlowNodeWebhookTrigger: %w", err)
+	}
 	if q.cleanupOrphanedNodeExecutionsStmt, err = db.PrepareContext(ctx, cleanupOrphanedNodeExecutions); err != nil {
 		return nil, fmt.Errorf("error preparing query CleanupOrphanedNodeExecutions: %w", err)
 	}
@@ -162,9 +177,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createCredentialAnthropicStmt, err = db.PrepareContext(ctx, createCredentialAnthropic); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCredentialAnthropic: %w", err)
 	}
+	if q.createCredentialDatabaseStmt, err = db.PrepareContext(ctx, createCredentialDatabase); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCredentialDatabase: %w", err)
+	}
 	if q.createCredentialGeminiStmt, err = db.PrepareContext(ctx, createCredentialGemini); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCredentialGemini: %w", err)
 	}
+	if q.createCredentialMessageBrokerStmt, err = db.PrepareContext(ctx, createCredentialMessageBroker); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCredentialMessageBroker: %w", err)
+	}
 	if q.createCredentialOpenAIStmt, err = db.PrepareContext(ctx, createCredentialOpenAI); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCredentialOpenAI: %w", err)
 	}
@@ -192,6 +213,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createFlowNodeConditionStmt, err = db.PrepareContext(ctx, createFlowNodeCondition); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeCondition: %w", err)
 	}
+	if q.createFlowNodeDbQueryStmt, err = db.PrepareContext(ctx, createFlowNodeDbQuery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeDbQuery: %w", err)
+	}
+	if q.createFlowNodeExecStmt, err = db.PrepareContext(ctx, createFlowNodeExec); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeExec: %w", err)
+	}
 	if q.createFlowNodeForStmt, err = db.PrepareContext(ctx, createFlowNodeFor); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeFor: %w", err)
 	}
@@ -201,6 +228,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createFlowNodeGraphQLStmt, err = db.PrepareContext(ctx, createFlowNodeGraphQL); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeGraphQL: %w", err)
 	}
+	if q.createFlowNodeGraphQLSubscriptionStmt, err = db.PrepareContext(ctx, createFlowNodeGraphQLSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeGraphQLSubscription: %w", err)
+	}
 	if q.createFlowNodeHTTPStmt, err = db.PrepareContext(ctx, createFlowNodeHTTP); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeHTTP: %w", err)
 	}
@@ -210,30 +240,66 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createFlowNodeMemoryStmt, err = db.PrepareContext(ctx, createFlowNodeMemory); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeMemory: %w", err)
 	}
+	if q.createFlowNodeMqConsumeStmt, err = db.PrepareContext(ctx, createFlowNodeMqConsume); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeMqConsume: %w", err)
+	}
+	if q.createFlowNodeMqPublishStmt, err = db.PrepareContext(ctx, createFlowNodeMqPublish); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeMqPublish: %w", err)
+	}
+	if q.createFlowNodeParallelStmt, err = db.PrepareContext(ctx, createFlowNodeParallel); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeParallel: %w", err)
+	}
+	if q.createFlowNodePollStmt, err = db.PrepareContext(ctx, createFlowNodePoll); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodePoll: %w", err)
+	}
 	if q.createFlowNodeRunSubFlowStmt, err = db.PrepareContext(ctx, createFlowNodeRunSubFlow); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeRunSubFlow: %w", err)
 	}
+	if q.createFlowNodeSocketConnectionStmt, err = db.PrepareContext(ctx, createFlowNodeSocketConnection); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeSocketConnection: %w", err)
+	}
+	if q.createFlowNodeSocketReceiveStmt, err = db.PrepareContext(ctx, createFlowNodeSocketReceive); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeSocketReceive: %w", err)
+	}
+	if q.createFlowNodeSocketSendStmt, err = db.PrepareContext(ctx, createFlowNodeSocketSend); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeSocketSend: %w", err)
+	}
 	if q.createFlowNodeSubFlowReturnStmt, err = db.PrepareContext(ctx, createFlowNodeSubFlowReturn); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeSubFlowReturn: %w", err)
 	}
 	if q.createFlowNodeSubFlowTriggerStmt, err = db.PrepareContext(ctx, createFlowNodeSubFlowTrigger); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeSubFlowTrigger: %w", err)
 	}
+	if q.createFlowNodeSwitchStmt, err = db.PrepareContext(ctx, createFlowNodeSwitch); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeSwitch: %w", err)
+	}
 	if q.createFlowNodeWaitStmt, err = db.PrepareContext(ctx, createFlowNodeWait); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeWait: %w", err)
 	}
+	if q.createFlowNodeWebhookTriggerStmt, err = db.PrepareContext(ctx, createFlowNodeWebhookTrigger); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeWebhookTrigger: %w", err)
+	}
 	if q.createFlowNodeWithStateStmt, err = db.PrepareContext(ctx, createFlowNodeWithState); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeWithState: %w", err)
 	}
 	if q.createFlowNodeWsConnectionStmt, err = db.PrepareContext(ctx, createFlowNodeWsConnection); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeWsConnection: %w", err)
 	}
+	if q.createFlowNodeWsExpectStmt, err = db.PrepareContext(ctx, createFlowNodeWsExpect); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowNodeWsExpect: %w", err)
+	}
 	if q.createFlowNodeWsSendStmt, err = db.PrepareContext(ctx, createFlowNodeWsSend); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodeWsSend: %w", err)
 	}
 	if q.createFlowNodesBulkStmt, err = db.PrepareContext(ctx, createFlowNodesBulk); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowNodesBulk: %w", err)
 	}
+	if q.createFlowScheduleStmt, err = db.PrepareContext(ctx, createFlowSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowSchedule: %w", err)
+	}
+	if q.createFlowScheduleRunStmt, err = db.PrepareContext(ctx, createFlowScheduleRun); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFlowScheduleRun: %w", err)
+	}
 	if q.createFlowTagStmt, err = db.PrepareContext(ctx, createFlowTag); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFlowTag: %w", err)
 	}
@@ -342,6 +408,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createWebSocketHeaderStmt, err = db.PrepareContext(ctx, createWebSocketHeader); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateWebSocketHeader: %w", err)
 	}
+	if q.createWebSocketMessageStmt, err = db.PrepareContext(ctx, createWebSocketMessage); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebSocketMessage: %w", err)
+	}
 	if q.createWorkspaceStmt, err = db.PrepareContext(ctx, createWorkspace); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateWorkspace: %w", err)
 	}
@@ -354,9 +423,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteCredentialAnthropicStmt, err = db.PrepareContext(ctx, deleteCredentialAnthropic); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCredentialAnthropic: %w", err)
 	}
+	if q.deleteCredentialDatabaseStmt, err = db.PrepareContext(ctx, deleteCredentialDatabase); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCredentialDatabase: %w", err)
+	}
 	if q.deleteCredentialGeminiStmt, err = db.PrepareContext(ctx, deleteCredentialGemini); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCredentialGemini: %w", err)
 	}
+	if q.deleteCredentialMessageBrokerStmt, err = db.PrepareContext(ctx, deleteCredentialMessageBroker); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCredentialMessageBroker: %w", err)
+	}
 	if q.deleteCredentialOpenAIStmt, err = db.PrepareContext(ctx, deleteCredentialOpenAI); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCredentialOpenAI: %w", err)
 	}
@@ -384,6 +459,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteFlowNodeConditionStmt, err = db.PrepareContext(ctx, deleteFlowNodeCondition); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeCondition: %w", err)
 	}
+	if q.deleteFlowNodeDbQueryStmt, err = db.PrepareContext(ctx, deleteFlowNodeDbQuery); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeDbQuery: %w", err)
+	}
+	if q.deleteFlowNodeExecStmt, err = db.PrepareContext(ctx, deleteFlowNodeExec); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeExec: %w", err)
+	}
 	if q.deleteFlowNodeForStmt, err = db.PrepareContext(ctx, deleteFlowNodeFor); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeFor: %w", err)
 	}
@@ -393,6 +474,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteFlowNodeGraphQLStmt, err = db.PrepareContext(ctx, deleteFlowNodeGraphQL); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeGraphQL: %w", err)
 	}
+	if q.deleteFlowNodeGraphQLSubscriptionStmt, err = db.PrepareContext(ctx, deleteFlowNodeGraphQLSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeGraphQLSubscription: %w", err)
+	}
 	if q.deleteFlowNodeHTTPStmt, err = db.PrepareContext(ctx, deleteFlowNodeHTTP); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeHTTP: %w", err)
 	}
@@ -402,24 +486,57 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteFlowNodeMemoryStmt, err = db.PrepareContext(ctx, deleteFlowNodeMemory); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeMemory: %w", err)
 	}
+	if q.deleteFlowNodeMqConsumeStmt, err = db.PrepareContext(ctx, deleteFlowNodeMqConsume); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeMqConsume: %w", err)
+	}
+	if q.deleteFlowNodeMqPublishStmt, err = db.PrepareContext(ctx, deleteFlowNodeMqPublish); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeMqPublish: %w", err)
+	}
+	if q.deleteFlowNodeParallelStmt, err = db.PrepareContext(ctx, deleteFlowNodeParallel); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeParallel: %w", err)
+	}
+	if q.deleteFlowNodePollStmt, err = db.PrepareContext(ctx, deleteFlowNodePoll); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodePoll: %w", err)
+	}
 	if q.deleteFlowNodeRunSubFlowStmt, err = db.PrepareContext(ctx, deleteFlowNodeRunSubFlow); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeRunSubFlow: %w", err)
 	}
+	if q.deleteFlowNodeSocketConnectionStmt, err = db.PrepareContext(ctx, deleteFlowNodeSocketConnection); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeSocketConnection: %w", err)
+	}
+	if q.deleteFlowNodeSocketReceiveStmt, err = db.PrepareContext(ctx, deleteFlowNodeSocketReceive); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeSocketReceive: %w", err)
+	}
+	if q.deleteFlowNodeSocketSendStmt, err = db.PrepareContext(ctx, deleteFlowNodeSocketSend); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeSocketSend: %w", err)
+	}
 	if q.deleteFlowNodeSubFlowReturnStmt, err = db.PrepareContext(ctx, deleteFlowNodeSubFlowReturn); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeSubFlowReturn: %w", err)
 	}
 	if q.deleteFlowNodeSubFlowTriggerStmt, err = db.PrepareContext(ctx, deleteFlowNodeSubFlowTrigger); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeSubFlowTrigger: %w", err)
 	}
+	if q.deleteFlowNodeSwitchStmt, err = db.PrepareContext(ctx, deleteFlowNodeSwitch); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeSwitch: %w", err)
+	}
 	if q.deleteFlowNodeWaitStmt, err = db.PrepareContext(ctx, deleteFlowNodeWait); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeWait: %w", err)
 	}
+	if q.deleteFlowNodeWebhookTriggerStmt, err = db.PrepareContext(ctx, deleteFlowNodeWebhookTrigger); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeWebhookTrigger: %w", err)
+	}
 	if q.deleteFlowNodeWsConnectionStmt, err = db.PrepareContext(ctx, deleteFlowNodeWsConnection); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeWsConnection: %w", err)
 	}
+	if q.deleteFlowNodeWsExpectStmt, err = db.PrepareContext(ctx, deleteFlowNodeWsExpect); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowNodeWsExpect: %w", err)
+	}
 	if q.deleteFlowNodeWsSendStmt, err = db.PrepareContext(ctx, deleteFlowNodeWsSend); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowNodeWsSend: %w", err)
 	}
+	if q.deleteFlowScheduleStmt, err = db.PrepareContext(ctx, deleteFlowSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFlowSchedule: %w", err)
+	}
 	if q.deleteFlowTagStmt, err = db.PrepareContext(ctx, deleteFlowTag); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFlowTag: %w", err)
 	}
@@ -441,6 +558,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteGraphQLResponseHeaderStmt, err = db.PrepareContext(ctx, deleteGraphQLResponseHeader); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteGraphQLResponseHeader: %w", err)
 	}
+	if q.deleteGraphQLSchemaStmt, err = db.PrepareContext(ctx, deleteGraphQLSchema); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteGraphQLSchema: %w", err)
+	}
 	if q.deleteHTTPStmt, err = db.PrepareContext(ctx, deleteHTTP); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteHTTP: %w", err)
 	}
@@ -498,6 +618,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteWebSocketHeadersByWebSocketIDStmt, err = db.PrepareContext(ctx, deleteWebSocketHeadersByWebSocketID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteWebSocketHeadersByWebSocketID: %w", err)
 	}
+	if q.deleteWebSocketMessagesByWebSocketIDStmt, err = db.PrepareContext(ctx, deleteWebSocketMessagesByWebSocketID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWebSocketMessagesByWebSocketID: %w", err)
+	}
 	if q.deleteWorkspaceStmt, err = db.PrepareContext(ctx, deleteWorkspace); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteWorkspace: %w", err)
 	}
@@ -525,15 +648,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getCredentialAnthropicStmt, err = db.PrepareContext(ctx, getCredentialAnthropic); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCredentialAnthropic: %w", err)
 	}
+	if q.getCredentialDatabaseStmt, err = db.PrepareContext(ctx, getCredentialDatabase); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCredentialDatabase: %w", err)
+	}
 	if q.getCredentialGeminiStmt, err = db.PrepareContext(ctx, getCredentialGemini); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCredentialGemini: %w", err)
 	}
+	if q.getCredentialMessageBrokerStmt, err = db.PrepareContext(ctx, getCredentialMessageBroker); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCredentialMessageBroker: %w", err)
+	}
 	if q.getCredentialOpenAIStmt, err = db.PrepareContext(ctx, getCredentialOpenAI); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCredentialOpenAI: %w", err)
 	}
 	if q.getCredentialsByWorkspaceIDStmt, err = db.PrepareContext(ctx, getCredentialsByWorkspaceID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCredentialsByWorkspaceID: %w", err)
 	}
+	if q.getEnabledFlowSchedulesStmt, err = db.PrepareContext(ctx, getEnabledFlowSchedules); err != nil {
+		return nil, fmt.Errorf("error preparing query GetEnabledFlowSchedules: %w", err)
+	}
 	if q.getEnvironmentStmt, err = db.PrepareContext(ctx, getEnvironment); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEnvironment: %w", err)
 	}
@@ -606,6 +738,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getFlowNodeConditionStmt, err = db.PrepareContext(ctx, getFlowNodeCondition); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeCondition: %w", err)
 	}
+	if q.getFlowNodeDbQueryStmt, err = db.PrepareContext(ctx, getFlowNodeDbQuery); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeDbQuery: %w", err)
+	}
+	if q.getFlowNodeExecStmt, err = db.PrepareContext(ctx, getFlowNodeExec); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeExec: %w", err)
+	}
 	if q.getFlowNodeForStmt, err = db.PrepareContext(ctx, getFlowNodeFor); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeFor: %w", err)
 	}
@@ -615,6 +753,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getFlowNodeGraphQLStmt, err = db.PrepareContext(ctx, getFlowNodeGraphQL); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeGraphQL: %w", err)
 	}
+	if q.getFlowNodeGraphQLSubscriptionStmt, err = db.PrepareContext(ctx, getFlowNodeGraphQLSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeGraphQLSubscription: %w", err)
+	}
 	if q.getFlowNodeHTTPStmt, err = db.PrepareContext(ctx, getFlowNodeHTTP); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeHTTP: %w", err)
 	}
@@ -624,21 +765,54 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getFlowNodeMemoryStmt, err = db.PrepareContext(ctx, getFlowNodeMemory); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeMemory: %w", err)
 	}
+	if q.getFlowNodeMqConsumeStmt, err = db.PrepareContext(ctx, getFlowNodeMqConsume); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeMqConsume: %w", err)
+	}
+	if q.getFlowNodeMqPublishStmt, err = db.PrepareContext(ctx, getFlowNodeMqPublish); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeMqPublish: %w", err)
+	}
+	if q.getFlowNodeParallelStmt, err = db.PrepareContext(ctx, getFlowNodeParallel); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeParallel: %w", err)
+	}
+	if q.getFlowNodePollStmt, err = db.PrepareContext(ctx, getFlowNodePoll); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodePoll: %w", err)
+	}
 	if q.getFlowNodeRunSubFlowStmt, err = db.PrepareContext(ctx, getFlowNodeRunSubFlow); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeRunSubFlow: %w", err)
 	}
+	if q.getFlowNodeSocketConnectionStmt, err = db.PrepareContext(ctx, getFlowNodeSocketConnection); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeSocketConnection: %w", err)
+	}
+	if q.getFlowNodeSocketReceiveStmt, err = db.PrepareContext(ctx, getFlowNodeSocketReceive); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeSocketReceive: %w", err)
+	}
+	if q.getFlowNodeSocketSendStmt, err = db.PrepareContext(ctx, getFlowNodeSocketSend); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeSocketSend: %w", err)
+	}
 	if q.getFlowNodeSubFlowReturnStmt, err = db.PrepareContext(ctx, getFlowNodeSubFlowReturn); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeSubFlowReturn: %w", err)
 	}
 	if q.getFlowNodeSubFlowTriggerStmt, err = db.PrepareContext(ctx, getFlowNodeSubFlowTrigger); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeSubFlowTrigger: %w", err)
 	}
+	if q.getFlowNodeSwitchStmt, err = db.PrepareContext(ctx, getFlowNodeSwitch); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeSwitch: %w", err)
+	}
 	if q.getFlowNodeWaitStmt, err = db.PrepareContext(ctx, getFlowNodeWait); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeWait: %w", err)
 	}
+	if q.getFlowNodeWebhookTriggerStmt, err = db.PrepareContext(ctx, getFlowNodeWebhookTrigger); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeWebhookTrigger: %w", err)
+	}
+	if q.getFlowNodeWebhookTriggersByPathStmt, err = db.PrepareContext(ctx, getFlowNodeWebhookTriggersByPath); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeWebhookTriggersByPath: %w", err)
+	}
 	if q.getFlowNodeWsConnectionStmt, err = db.PrepareContext(ctx, getFlowNodeWsConnection); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeWsConnection: %w", err)
 	}
+	if q.getFlowNodeWsExpectStmt, err = db.PrepareContext(ctx, getFlowNodeWsExpect); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowNodeWsExpect: %w", err)
+	}
 	if q.getFlowNodeWsSendStmt, err = db.PrepareContext(ctx, getFlowNodeWsSend); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodeWsSend: %w", err)
 	}
@@ -648,6 +822,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getFlowNodesByFlowIDsStmt, err = db.PrepareContext(ctx, getFlowNodesByFlowIDs); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowNodesByFlowIDs: %w", err)
 	}
+	if q.getFlowScheduleStmt, err = db.PrepareContext(ctx, getFlowSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowSchedule: %w", err)
+	}
+	if q.getFlowScheduleRunsByScheduleIDStmt, err = db.PrepareContext(ctx, getFlowScheduleRunsByScheduleID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowScheduleRunsByScheduleID: %w", err)
+	}
+	if q.getFlowSchedulesByFlowIDStmt, err = db.PrepareContext(ctx, getFlowSchedulesByFlowID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlowSchedulesByFlowID: %w", err)
+	}
 	if q.getFlowTagStmt, err = db.PrepareContext(ctx, getFlowTag); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFlowTag: %w", err)
 	}
@@ -732,6 +915,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getGraphQLResponsesByWorkspaceIDStmt, err = db.PrepareContext(ctx, getGraphQLResponsesByWorkspaceID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetGraphQLResponsesByWorkspaceID: %w", err)
 	}
+	if q.getGraphQLSchemaStmt, err = db.PrepareContext(ctx, getGraphQLSchema); err != nil {
+		return nil, fmt.Errorf("error preparing query GetGraphQLSchema: %w", err)
+	}
 	if q.getGraphQLVersionsByGraphQLIDStmt, err = db.PrepareContext(ctx, getGraphQLVersionsByGraphQLID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetGraphQLVersionsByGraphQLID: %w", err)
 	}
@@ -960,6 +1146,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getWebSocketHeadersStmt, err = db.PrepareContext(ctx, getWebSocketHeaders); err != nil {
 		return nil, fmt.Errorf("error preparing query GetWebSocketHeaders: %w", err)
 	}
+	if q.getWebSocketMessagesStmt, err = db.PrepareContext(ctx, getWebSocketMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebSocketMessages: %w", err)
+	}
 	if q.getWebSocketWorkspaceIDStmt, err = db.PrepareContext(ctx, getWebSocketWorkspaceID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetWebSocketWorkspaceID: %w", err)
 	}
@@ -1002,6 +1191,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listNodeExecutionsByStateStmt, err = db.PrepareContext(ctx, listNodeExecutionsByState); err != nil {
 		return nil, fmt.Errorf("error preparing query ListNodeExecutionsByState: %w", err)
 	}
+	if q.pruneFlowScheduleRunsStmt, err = db.PrepareContext(ctx, pruneFlowScheduleRuns); err != nil {
+		return nil, fmt.Errorf("error preparing query PruneFlowScheduleRuns: %w", err)
+	}
 	if q.resetHTTPBodyFormDeltaStmt, err = db.PrepareContext(ctx, resetHTTPBodyFormDelta); err != nil {
 		return nil, fmt.Errorf("error preparing query ResetHTTPBodyFormDelta: %w", err)
 	}
@@ -1014,9 +1206,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateCredentialAnthropicStmt, err = db.PrepareContext(ctx, updateCredentialAnthropic); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateCredentialAnthropic: %w", err)
 	}
+	if q.updateCredentialDatabaseStmt, err = db.PrepareContext(ctx, updateCredentialDatabase); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCredentialDatabase: %w", err)
+	}
 	if q.updateCredentialGeminiStmt, err = db.PrepareContext(ctx, updateCredentialGemini); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateCredentialGemini: %w", err)
 	}
+	if q.updateCredentialMessageBrokerStmt, err = db.PrepareContext(ctx, updateCredentialMessageBroker); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCredentialMessageBroker: %w", err)
+	}
 	if q.updateCredentialOpenAIStmt, err = db.PrepareContext(ctx, updateCredentialOpenAI); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateCredentialOpenAI: %w", err)
 	}
@@ -1047,6 +1245,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateFlowNodeConditionStmt, err = db.PrepareContext(ctx, updateFlowNodeCondition); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeCondition: %w", err)
 	}
+	if q.updateFlowNodeDbQueryStmt, err = db.PrepareContext(ctx, updateFlowNodeDbQuery); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeDbQuery: %w", err)
+	}
+	if q.updateFlowNodeExecStmt, err = db.PrepareContext(ctx, updateFlowNodeExec); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeExec: %w", err)
+	}
 	if q.updateFlowNodeForStmt, err = db.PrepareContext(ctx, updateFlowNodeFor); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeFor: %w", err)
 	}
@@ -1056,6 +1260,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateFlowNodeGraphQLStmt, err = db.PrepareContext(ctx, updateFlowNodeGraphQL); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeGraphQL: %w", err)
 	}
+	if q.updateFlowNodeGraphQLSubscriptionStmt, err = db.PrepareContext(ctx, updateFlowNodeGraphQLSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeGraphQLSubscription: %w", err)
+	}
 	if q.updateFlowNodeHTTPStmt, err = db.PrepareContext(ctx, updateFlowNodeHTTP); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeHTTP: %w", err)
 	}
@@ -1068,9 +1275,30 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateFlowNodeMemoryStmt, err = db.PrepareContext(ctx, updateFlowNodeMemory); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeMemory: %w", err)
 	}
+	if q.updateFlowNodeMqConsumeStmt, err = db.PrepareContext(ctx, updateFlowNodeMqConsume); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeMqConsume: %w", err)
+	}
+	if q.updateFlowNodeMqPublishStmt, err = db.PrepareContext(ctx, updateFlowNodeMqPublish); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeMqPublish: %w", err)
+	}
+	if q.updateFlowNodeParallelStmt, err = db.PrepareContext(ctx, updateFlowNodeParallel); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeParallel: %w", err)
+	}
+	if q.updateFlowNodePollStmt, err = db.PrepareContext(ctx, updateFlowNodePoll); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodePoll: %w", err)
+	}
 	if q.updateFlowNodeRunSubFlowStmt, err = db.PrepareContext(ctx, updateFlowNodeRunSubFlow); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeRunSubFlow: %w", err)
 	}
+	if q.updateFlowNodeSocketConnectionStmt, err = db.PrepareContext(ctx, updateFlowNodeSocketConnection); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeSocketConnection: %w", err)
+	}
+	if q.updateFlowNodeSocketReceiveStmt, err = db.PrepareContext(ctx, updateFlowNodeSocketReceive); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeSocketReceive: %w", err)
+	}
+	if q.updateFlowNodeSocketSendStmt, err = db.PrepareContext(ctx, updateFlowNodeSocketSend); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeSocketSend: %w", err)
+	}
 	if q.updateFlowNodeStateStmt, err = db.PrepareContext(ctx, updateFlowNodeState); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeState: %w", err)
 	}
@@ -1080,15 +1308,30 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateFlowNodeSubFlowTriggerStmt, err = db.PrepareContext(ctx, updateFlowNodeSubFlowTrigger); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeSubFlowTrigger: %w", err)
 	}
+	if q.updateFlowNodeSwitchStmt, err = db.PrepareContext(ctx, updateFlowNodeSwitch); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeSwitch: %w", err)
+	}
 	if q.updateFlowNodeWaitStmt, err = db.PrepareContext(ctx, updateFlowNodeWait); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeWait: %w", err)
 	}
+	if q.updateFlowNodeWebhookTriggerStmt, err = db.PrepareContext(ctx, updateFlowNodeWebhookTrigger); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeWebhookTrigger: %w", err)
+	}
 	if q.updateFlowNodeWsConnectionStmt, err = db.PrepareContext(ctx, updateFlowNodeWsConnection); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeWsConnection: %w", err)
 	}
+	if q.updateFlowNodeWsExpectStmt, err = db.PrepareContext(ctx, updateFlowNodeWsExpect); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowNodeWsExpect: %w", err)
+	}
 	if q.updateFlowNodeWsSendStmt, err = db.PrepareContext(ctx, updateFlowNodeWsSend); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowNodeWsSend: %w", err)
 	}
+	if q.updateFlowScheduleStmt, err = db.PrepareContext(ctx, updateFlowSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowSchedule: %w", err)
+	}
+	if q.updateFlowScheduleLastRunStmt, err = db.PrepareContext(ctx, updateFlowScheduleLastRun); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFlowScheduleLastRun: %w", err)
+	}
 	if q.updateFlowVariableStmt, err = db.PrepareContext(ctx, updateFlowVariable); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFlowVariable: %w", err)
 	}
@@ -1203,6 +1446,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateWorkspaceUserStmt, err = db.PrepareContext(ctx, updateWorkspaceUser); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateWorkspaceUser: %w", err)
 	}
+	if q.upsertGraphQLSchemaStmt, err = db.PrepareContext(ctx, upsertGraphQLSchema); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertGraphQLSchema: %w", err)
+	}
 	if q.upsertNodeExecutionStmt, err = db.PrepareContext(ctx, upsertNodeExecution); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertNodeExecution: %w", err)
 	}
@@ -1399,6 +1645,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeGraphQLStmt: %w", cerr)
 		}
 	}
+	if q.cleanupOrphanedFlowNodeGraphQLSubscriptionStmt != nil {
+		if cerr := q.cleanupOrphanedFlowNodeGraphQLSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeGraphQLSubscriptionStmt: %w", cerr)
+		}
+	}
 	if q.cleanupOrphanedFlowNodeHttpStmt != nil {
 		if cerr := q.cleanupOrphanedFlowNodeHttpStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeHttpStmt: %w", cerr)
@@ -1409,6 +1660,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeJsStmt: %w", cerr)
 		}
 	}
+	if q.cleanupOrphanedFlowNodeParallelStmt != nil {
+		if cerr := q.cleanupOrphanedFlowNodeParallelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeParallelStmt: %w", cerr)
+		}
+	}
+	if q.cleanupOrphanedFlowNodePollStmt != nil {
+		if cerr := q.cleanupOrphanedFlowNodePollStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cleanupOrphanedFlowNodePollStmt: %w", cerr)
+		}
+	}
 	if q.cleanupOrphanedFlowNodeRunSubFlowStmt != nil {
 		if cerr := q.cleanupOrphanedFlowNodeRunSubFlowStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeRunSubFlowStmt: %w", cerr)
@@ -1424,11 +1685,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeSubFlowTriggerStmt: %w", cerr)
 		}
 	}
+	if q.cleanupOrphanedFlowNodeSwitchStmt != nil {
+		if cerr := q.cleanupOrphanedFlowNodeSwitchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeSwitchStmt: %w", cerr)
+		}
+	}
 	if q.cleanupOrphanedFlowNodeWaitStmt != nil {
 		if cerr := q.cleanupOrphanedFlowNodeWaitStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeWaitStmt: %w", cerr)
 		}
 	}
+	if q.cleanupOrphanedFlowNodeWebhookTriggerStmt != nil {
+		if cerr := q.cleanupOrphanedFlowNodeWebhookTriggerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cleanupOrphanedFlowNodeWebhookTriggerStmt: %w", cerr)
+		}
+	}
 	if q.cleanupOrphanedNodeExecutionsStmt != nil {
 		if cerr := q.cleanupOrphanedNodeExecutionsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing cleanupOrphanedNodeExecutionsStmt: %w", cerr)
@@ -1444,11 +1715,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createCredentialAnthropicStmt: %w", cerr)
 		}
 	}
+	if q.createCredentialDatabaseStmt != nil {
+		if cerr := q.createCredentialDatabaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCredentialDatabaseStmt: %w", cerr)
+		}
+	}
 	if q.createCredentialGeminiStmt != nil {
 		if cerr := q.createCredentialGeminiStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createCredentialGeminiStmt: %w", cerr)
 		}
 	}
+	if q.createCredentialMessageBrokerStmt != nil {
+		if cerr := q.createCredentialMessageBrokerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCredentialMessageBrokerStmt: %w", cerr)
+		}
+	}
 	if q.createCredentialOpenAIStmt != nil {
 		if cerr := q.createCredentialOpenAIStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createCredentialOpenAIStmt: %w", cerr)
@@ -1494,6 +1775,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createFlowNodeConditionStmt: %w", cerr)
 		}
 	}
+	if q.createFlowNodeDbQueryStmt != nil {
+		if cerr := q.createFlowNodeDbQueryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeDbQueryStmt: %w", cerr)
+		}
+	}
+	if q.createFlowNodeExecStmt != nil {
+		if cerr := q.createFlowNodeExecStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeExecStmt: %w", cerr)
+		}
+	}
 	if q.createFlowNodeForStmt != nil {
 		if cerr := q.createFlowNodeForStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFlowNodeForStmt: %w", cerr)
@@ -1509,6 +1800,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createFlowNodeGraphQLStmt: %w", cerr)
 		}
 	}
+	if q.createFlowNodeGraphQLSubscriptionStmt != nil {
+		if cerr := q.createFlowNodeGraphQLSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeGraphQLSubscriptionStmt: %w", cerr)
+		}
+	}
 	if q.createFlowNodeHTTPStmt != nil {
 		if cerr := q.createFlowNodeHTTPStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFlowNodeHTTPStmt: %w", cerr)
@@ -1524,11 +1820,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createFlowNodeMemoryStmt: %w", cerr)
 		}
 	}
+	if q.createFlowNodeMqConsumeStmt != nil {
+		if cerr := q.createFlowNodeMqConsumeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeMqConsumeStmt: %w", cerr)
+		}
+	}
+	if q.createFlowNodeMqPublishStmt != nil {
+		if cerr := q.createFlowNodeMqPublishStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeMqPublishStmt: %w", cerr)
+		}
+	}
+	if q.createFlowNodeParallelStmt != nil {
+		if cerr := q.createFlowNodeParallelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeParallelStmt: %w", cerr)
+		}
+	}
+	if q.createFlowNodePollStmt != nil {
+		if cerr := q.createFlowNodePollStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodePollStmt: %w", cerr)
+		}
+	}
 	if q.createFlowNodeRunSubFlowStmt != nil {
 		if cerr := q.createFlowNodeRunSubFlowStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFlowNodeRunSubFlowStmt: %w", cerr)
 		}
 	}
+	if q.createFlowNodeSocketConnectionStmt != nil {
+		if cerr := q.createFlowNodeSocketConnectionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeSocketConnectionStmt: %w", cerr)
+		}
+	}
+	if q.createFlowNodeSocketReceiveStmt != nil {
+		if cerr := q.createFlowNodeSocketReceiveStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeSocketReceiveStmt: %w", cerr)
+		}
+	}
+	if q.createFlowNodeSocketSendStmt != nil {
+		if cerr := q.createFlowNodeSocketSendStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeSocketSendStmt: %w", cerr)
+		}
+	}
 	if q.createFlowNodeSubFlowReturnStmt != nil {
 		if cerr := q.createFlowNodeSubFlowReturnStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFlowNodeSubFlowReturnStmt: %w", cerr)
@@ -1539,11 +1870,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createFlowNodeSubFlowTriggerStmt: %w", cerr)
 		}
 	}
+	if q.createFlowNodeSwitchStmt != nil {
+		if cerr := q.createFlowNodeSwitchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeSwitchStmt: %w", cerr)
+		}
+	}
 	if q.createFlowNodeWaitStmt != nil {
 		if cerr := q.createFlowNodeWaitStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFlowNodeWaitStmt: %w", cerr)
 		}
 	}
+	if q.createFlowNodeWebhookTriggerStmt != nil {
+		if cerr := q.createFlowNodeWebhookTriggerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeWebhookTriggerStmt: %w", cerr)
+		}
+	}
 	if q.createFlowNodeWithStateStmt != nil {
 		if cerr := q.createFlowNodeWithStateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFlowNodeWithStateStmt: %w", cerr)
@@ -1554,6 +1895,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createFlowNodeWsConnectionStmt: %w", cerr)
 		}
 	}
+	if q.createFlowNodeWsExpectStmt != nil {
+		if cerr := q.createFlowNodeWsExpectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowNodeWsExpectStmt: %w", cerr)
+		}
+	}
 	if q.createFlowNodeWsSendStmt != nil {
 		if cerr := q.createFlowNodeWsSendStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFlowNodeWsSendStmt: %w", cerr)
@@ -1564,6 +1910,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createFlowNodesBulkStmt: %w", cerr)
 		}
 	}
+	if q.createFlowScheduleStmt != nil {
+		if cerr := q.createFlowScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowScheduleStmt: %w", cerr)
+		}
+	}
+	if q.createFlowScheduleRunStmt != nil {
+		if cerr := q.createFlowScheduleRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFlowScheduleRunStmt: %w", cerr)
+		}
+	}
 	if q.createFlowTagStmt != nil {
 		if cerr := q.createFlowTagStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFlowTagStmt: %w", cerr)
@@ -1744,6 +2100,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createWebSocketHeaderStmt: %w", cerr)
 		}
 	}
+	if q.createWebSocketMessageStmt != nil {
+		if cerr := q.createWebSocketMessageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebSocketMessageStmt: %w", cerr)
+		}
+	}
 	if q.createWorkspaceStmt != nil {
 		if cerr := q.createWorkspaceStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createWorkspaceStmt: %w", cerr)
@@ -1764,11 +2125,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteCredentialAnthropicStmt: %w", cerr)
 		}
 	}
+	if q.deleteCredentialDatabaseStmt != nil {
+		if cerr := q.deleteCredentialDatabaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCredentialDatabaseStmt: %w", cerr)
+		}
+	}
 	if q.deleteCredentialGeminiStmt != nil {
 		if cerr := q.deleteCredentialGeminiStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteCredentialGeminiStmt: %w", cerr)
 		}
 	}
+	if q.deleteCredentialMessageBrokerStmt != nil {
+		if cerr := q.deleteCredentialMessageBrokerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCredentialMessageBrokerStmt: %w", cerr)
+		}
+	}
 	if q.deleteCredentialOpenAIStmt != nil {
 		if cerr := q.deleteCredentialOpenAIStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteCredentialOpenAIStmt: %w", cerr)
@@ -1814,6 +2185,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteFlowNodeConditionStmt: %w", cerr)
 		}
 	}
+	if q.deleteFlowNodeDbQueryStmt != nil {
+		if cerr := q.deleteFlowNodeDbQueryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeDbQueryStmt: %w", cerr)
+		}
+	}
+	if q.deleteFlowNodeExecStmt != nil {
+		if cerr := q.deleteFlowNodeExecStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeExecStmt: %w", cerr)
+		}
+	}
 	if q.deleteFlowNodeForStmt != nil {
 		if cerr := q.deleteFlowNodeForStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteFlowNodeForStmt: %w", cerr)
@@ -1829,6 +2210,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteFlowNodeGraphQLStmt: %w", cerr)
 		}
 	}
+	if q.deleteFlowNodeGraphQLSubscriptionStmt != nil {
+		if cerr := q.deleteFlowNodeGraphQLSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeGraphQLSubscriptionStmt: %w", cerr)
+		}
+	}
 	if q.deleteFlowNodeHTTPStmt != nil {
 		if cerr := q.deleteFlowNodeHTTPStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteFlowNodeHTTPStmt: %w", cerr)
@@ -1844,11 +2230,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteFlowNodeMemoryStmt: %w", cerr)
 		}
 	}
+	if q.deleteFlowNodeMqConsumeStmt != nil {
+		if cerr := q.deleteFlowNodeMqConsumeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeMqConsumeStmt: %w", cerr)
+		}
+	}
+	if q.deleteFlowNodeMqPublishStmt != nil {
+		if cerr := q.deleteFlowNodeMqPublishStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeMqPublishStmt: %w", cerr)
+		}
+	}
+	if q.deleteFlowNodeParallelStmt != nil {
+		if cerr := q.deleteFlowNodeParallelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeParallelStmt: %w", cerr)
+		}
+	}
+	if q.deleteFlowNodePollStmt != nil {
+		if cerr := q.deleteFlowNodePollStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodePollStmt: %w", cerr)
+		}
+	}
 	if q.deleteFlowNodeRunSubFlowStmt != nil {
 		if cerr := q.deleteFlowNodeRunSubFlowStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteFlowNodeRunSubFlowStmt: %w", cerr)
 		}
 	}
+	if q.deleteFlowNodeSocketConnectionStmt != nil {
+		if cerr := q.deleteFlowNodeSocketConnectionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeSocketConnectionStmt: %w", cerr)
+		}
+	}
+	if q.deleteFlowNodeSocketReceiveStmt != nil {
+		if cerr := q.deleteFlowNodeSocketReceiveStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeSocketReceiveStmt: %w", cerr)
+		}
+	}
+	if q.deleteFlowNodeSocketSendStmt != nil {
+		if cerr := q.deleteFlowNodeSocketSendStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeSocketSendStmt: %w", cerr)
+		}
+	}
 	if q.deleteFlowNodeSubFlowReturnStmt != nil {
 		if cerr := q.deleteFlowNodeSubFlowReturnStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteFlowNodeSubFlowReturnStmt: %w", cerr)
@@ -1859,21 +2280,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteFlowNodeSubFlowTriggerStmt: %w", cerr)
 		}
 	}
+	if q.deleteFlowNodeSwitchStmt != nil {
+		if cerr := q.deleteFlowNodeSwitchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeSwitchStmt: %w", cerr)
+		}
+	}
 	if q.deleteFlowNodeWaitStmt != nil {
 		if cerr := q.deleteFlowNodeWaitStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteFlowNodeWaitStmt: %w", cerr)
 		}
 	}
+	if q.deleteFlowNodeWebhookTriggerStmt != nil {
+		if cerr := q.deleteFlowNodeWebhookTriggerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeWebhookTriggerStmt: %w", cerr)
+		}
+	}
 	if q.deleteFlowNodeWsConnectionStmt != nil {
 		if cerr := q.deleteFlowNodeWsConnectionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteFlowNodeWsConnectionStmt: %w", cerr)
 		}
 	}
+	if q.deleteFlowNodeWsExpectStmt != nil {
+		if cerr := q.deleteFlowNodeWsExpectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowNodeWsExpectStmt: %w", cerr)
+		}
+	}
 	if q.deleteFlowNodeWsSendStmt != nil {
 		if cerr := q.deleteFlowNodeWsSendStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteFlowNodeWsSendStmt: %w", cerr)
 		}
 	}
+	if q.deleteFlowScheduleStmt != nil {
+		if cerr := q.deleteFlowScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFlowScheduleStmt: %w", cerr)
+		}
+	}
 	if q.deleteFlowTagStmt != nil {
 		if cerr := q.deleteFlowTagStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteFlowTagStmt: %w", cerr)
@@ -1909,6 +2350,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteGraphQLResponseHeaderStmt: %w", cerr)
 		}
 	}
+	if q.deleteGraphQLSchemaStmt != nil {
+		if cerr := q.deleteGraphQLSchemaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteGraphQLSchemaStmt: %w", cerr)
+		}
+	}
 	if q.deleteHTTPStmt != nil {
 		if cerr := q.deleteHTTPStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteHTTPStmt: %w", cerr)
@@ -2004,6 +2450,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteWebSocketHeadersByWebSocketIDStmt: %w", cerr)
 		}
 	}
+	if q.deleteWebSocketMessagesByWebSocketIDStmt != nil {
+		if cerr := q.deleteWebSocketMessagesByWebSocketIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWebSocketMessagesByWebSocketIDStmt: %w", cerr)
+		}
+	}
 	if q.deleteWorkspaceStmt != nil {
 		if cerr := q.deleteWorkspaceStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteWorkspaceStmt: %w", cerr)
@@ -2049,11 +2500,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getCredentialAnthropicStmt: %w", cerr)
 		}
 	}
+	if q.getCredentialDatabaseStmt != nil {
+		if cerr := q.getCredentialDatabaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCredentialDatabaseStmt: %w", cerr)
+		}
+	}
 	if q.getCredentialGeminiStmt != nil {
 		if cerr := q.getCredentialGeminiStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getCredentialGeminiStmt: %w", cerr)
 		}
 	}
+	if q.getCredentialMessageBrokerStmt != nil {
+		if cerr := q.getCredentialMessageBrokerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCredentialMessageBrokerStmt: %w", cerr)
+		}
+	}
 	if q.getCredentialOpenAIStmt != nil {
 		if cerr := q.getCredentialOpenAIStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getCredentialOpenAIStmt: %w", cerr)
@@ -2064,6 +2525,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getCredentialsByWorkspaceIDStmt: %w", cerr)
 		}
 	}
+	if q.getEnabledFlowSchedulesStmt != nil {
+		if cerr := q.getEnabledFlowSchedulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getEnabledFlowSchedulesStmt: %w", cerr)
+		}
+	}
 	if q.getEnvironmentStmt != nil {
 		if cerr := q.getEnvironmentStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getEnvironmentStmt: %w", cerr)
@@ -2184,6 +2650,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getFlowNodeConditionStmt: %w", cerr)
 		}
 	}
+	if q.getFlowNodeDbQueryStmt != nil {
+		if cerr := q.getFlowNodeDbQueryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeDbQueryStmt: %w", cerr)
+		}
+	}
+	if q.getFlowNodeExecStmt != nil {
+		if cerr := q.getFlowNodeExecStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeExecStmt: %w", cerr)
+		}
+	}
 	if q.getFlowNodeForStmt != nil {
 		if cerr := q.getFlowNodeForStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFlowNodeForStmt: %w", cerr)
@@ -2199,6 +2675,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getFlowNodeGraphQLStmt: %w", cerr)
 		}
 	}
+	if q.getFlowNodeGraphQLSubscriptionStmt != nil {
+		if cerr := q.getFlowNodeGraphQLSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeGraphQLSubscriptionStmt: %w", cerr)
+		}
+	}
 	if q.getFlowNodeHTTPStmt != nil {
 		if cerr := q.getFlowNodeHTTPStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFlowNodeHTTPStmt: %w", cerr)
@@ -2214,11 +2695,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getFlowNodeMemoryStmt: %w", cerr)
 		}
 	}
+	if q.getFlowNodeMqConsumeStmt != nil {
+		if cerr := q.getFlowNodeMqConsumeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeMqConsumeStmt: %w", cerr)
+		}
+	}
+	if q.getFlowNodeMqPublishStmt != nil {
+		if cerr := q.getFlowNodeMqPublishStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeMqPublishStmt: %w", cerr)
+		}
+	}
+	if q.getFlowNodeParallelStmt != nil {
+		if cerr := q.getFlowNodeParallelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeParallelStmt: %w", cerr)
+		}
+	}
+	if q.getFlowNodePollStmt != nil {
+		if cerr := q.getFlowNodePollStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodePollStmt: %w", cerr)
+		}
+	}
 	if q.getFlowNodeRunSubFlowStmt != nil {
 		if cerr := q.getFlowNodeRunSubFlowStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFlowNodeRunSubFlowStmt: %w", cerr)
 		}
 	}
+	if q.getFlowNodeSocketConnectionStmt != nil {
+		if cerr := q.getFlowNodeSocketConnectionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeSocketConnectionStmt: %w", cerr)
+		}
+	}
+	if q.getFlowNodeSocketReceiveStmt != nil {
+		if cerr := q.getFlowNodeSocketReceiveStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeSocketReceiveStmt: %w", cerr)
+		}
+	}
+	if q.getFlowNodeSocketSendStmt != nil {
+		if cerr := q.getFlowNodeSocketSendStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeSocketSendStmt: %w", cerr)
+		}
+	}
 	if q.getFlowNodeSubFlowReturnStmt != nil {
 		if cerr := q.getFlowNodeSubFlowReturnStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFlowNodeSubFlowReturnStmt: %w", cerr)
@@ -2229,16 +2745,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getFlowNodeSubFlowTriggerStmt: %w", cerr)
 		}
 	}
+	if q.getFlowNodeSwitchStmt != nil {
+		if cerr := q.getFlowNodeSwitchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeSwitchStmt: %w", cerr)
+		}
+	}
 	if q.getFlowNodeWaitStmt != nil {
 		if cerr := q.getFlowNodeWaitStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFlowNodeWaitStmt: %w", cerr)
 		}
 	}
+	if q.getFlowNodeWebhookTriggerStmt != nil {
+		if cerr := q.getFlowNodeWebhookTriggerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeWebhookTriggerStmt: %w", cerr)
+		}
+	}
+	if q.getFlowNodeWebhookTriggersByPathStmt != nil {
+		if cerr := q.getFlowNodeWebhookTriggersByPathStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeWebhookTriggersByPathStmt: %w", cerr)
+		}
+	}
 	if q.getFlowNodeWsConnectionStmt != nil {
 		if cerr := q.getFlowNodeWsConnectionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFlowNodeWsConnectionStmt: %w", cerr)
 		}
 	}
+	if q.getFlowNodeWsExpectStmt != nil {
+		if cerr := q.getFlowNodeWsExpectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowNodeWsExpectStmt: %w", cerr)
+		}
+	}
 	if q.getFlowNodeWsSendStmt != nil {
 		if cerr := q.getFlowNodeWsSendStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFlowNodeWsSendStmt: %w", cerr)
@@ -2254,6 +2790,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getFlowNodesByFlowIDsStmt: %w", cerr)
 		}
 	}
+	if q.getFlowScheduleStmt != nil {
+		if cerr := q.getFlowScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowScheduleStmt: %w", cerr)
+		}
+	}
+	if q.getFlowScheduleRunsByScheduleIDStmt != nil {
+		if cerr := q.getFlowScheduleRunsByScheduleIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowScheduleRunsByScheduleIDStmt: %w", cerr)
+		}
+	}
+	if q.getFlowSchedulesByFlowIDStmt != nil {
+		if cerr := q.getFlowSchedulesByFlowIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlowSchedulesByFlowIDStmt: %w", cerr)
+		}
+	}
 	if q.getFlowTagStmt != nil {
 		if cerr := q.getFlowTagStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFlowTagStmt: %w", cerr)
@@ -2394,6 +2945,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getGraphQLResponsesByWorkspaceIDStmt: %w", cerr)
 		}
 	}
+	if q.getGraphQLSchemaStmt != nil {
+		if cerr := q.getGraphQLSchemaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getGraphQLSchemaStmt: %w", cerr)
+		}
+	}
 	if q.getGraphQLVersionsByGraphQLIDStmt != nil {
 		if cerr := q.getGraphQLVersionsByGraphQLIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getGraphQLVersionsByGraphQLIDStmt: %w", cerr)
@@ -2774,6 +3330,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getWebSocketHeadersStmt: %w", cerr)
 		}
 	}
+	if q.getWebSocketMessagesStmt != nil {
+		if cerr := q.getWebSocketMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebSocketMessagesStmt: %w", cerr)
+		}
+	}
 	if q.getWebSocketWorkspaceIDStmt != nil {
 		if cerr := q.getWebSocketWorkspaceIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getWebSocketWorkspaceIDStmt: %w", cerr)
@@ -2844,6 +3405,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listNodeExecutionsByStateStmt: %w", cerr)
 		}
 	}
+	if q.pruneFlowScheduleRunsStmt != nil {
+		if cerr := q.pruneFlowScheduleRunsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing pruneFlowScheduleRunsStmt: %w", cerr)
+		}
+	}
 	if q.resetHTTPBodyFormDeltaStmt != nil {
 		if cerr := q.resetHTTPBodyFormDeltaStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing resetHTTPBodyFormDeltaStmt: %w", cerr)
@@ -2864,11 +3430,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateCredentialAnthropicStmt: %w", cerr)
 		}
 	}
+	if q.updateCredentialDatabaseStmt != nil {
+		if cerr := q.updateCredentialDatabaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCredentialDatabaseStmt: %w", cerr)
+		}
+	}
 	if q.updateCredentialGeminiStmt != nil {
 		if cerr := q.updateCredentialGeminiStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateCredentialGeminiStmt: %w", cerr)
 		}
 	}
+	if q.updateCredentialMessageBrokerStmt != nil {
+		if cerr := q.updateCredentialMessageBrokerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCredentialMessageBrokerStmt: %w", cerr)
+		}
+	}
 	if q.updateCredentialOpenAIStmt != nil {
 		if cerr := q.updateCredentialOpenAIStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateCredentialOpenAIStmt: %w", cerr)
@@ -2919,6 +3495,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateFlowNodeConditionStmt: %w", cerr)
 		}
 	}
+	if q.updateFlowNodeDbQueryStmt != nil {
+		if cerr := q.updateFlowNodeDbQueryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeDbQueryStmt: %w", cerr)
+		}
+	}
+	if q.updateFlowNodeExecStmt != nil {
+		if cerr := q.updateFlowNodeExecStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeExecStmt: %w", cerr)
+		}
+	}
 	if q.updateFlowNodeForStmt != nil {
 		if cerr := q.updateFlowNodeForStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFlowNodeForStmt: %w", cerr)
@@ -2934,6 +3520,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateFlowNodeGraphQLStmt: %w", cerr)
 		}
 	}
+	if q.updateFlowNodeGraphQLSubscriptionStmt != nil {
+		if cerr := q.updateFlowNodeGraphQLSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeGraphQLSubscriptionStmt: %w", cerr)
+		}
+	}
 	if q.updateFlowNodeHTTPStmt != nil {
 		if cerr := q.updateFlowNodeHTTPStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFlowNodeHTTPStmt: %w", cerr)
@@ -2954,11 +3545,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateFlowNodeMemoryStmt: %w", cerr)
 		}
 	}
+	if q.updateFlowNodeMqConsumeStmt != nil {
+		if cerr := q.updateFlowNodeMqConsumeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeMqConsumeStmt: %w", cerr)
+		}
+	}
+	if q.updateFlowNodeMqPublishStmt != nil {
+		if cerr := q.updateFlowNodeMqPublishStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeMqPublishStmt: %w", cerr)
+		}
+	}
+	if q.updateFlowNodeParallelStmt != nil {
+		if cerr := q.updateFlowNodeParallelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeParallelStmt: %w", cerr)
+		}
+	}
+	if q.updateFlowNodePollStmt != nil {
+		if cerr := q.updateFlowNodePollStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodePollStmt: %w", cerr)
+		}
+	}
 	if q.updateFlowNodeRunSubFlowStmt != nil {
 		if cerr := q.updateFlowNodeRunSubFlowStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFlowNodeRunSubFlowStmt: %w", cerr)
 		}
 	}
+	if q.updateFlowNodeSocketConnectionStmt != nil {
+		if cerr := q.updateFlowNodeSocketConnectionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeSocketConnectionStmt: %w", cerr)
+		}
+	}
+	if q.updateFlowNodeSocketReceiveStmt != nil {
+		if cerr := q.updateFlowNodeSocketReceiveStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeSocketReceiveStmt: %w", cerr)
+		}
+	}
+	if q.updateFlowNodeSocketSendStmt != nil {
+		if cerr := q.updateFlowNodeSocketSendStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeSocketSendStmt: %w", cerr)
+		}
+	}
 	if q.updateFlowNodeStateStmt != nil {
 		if cerr := q.updateFlowNodeStateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFlowNodeStateStmt: %w", cerr)
@@ -2974,21 +3600,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateFlowNodeSubFlowTriggerStmt: %w", cerr)
 		}
 	}
+	if q.updateFlowNodeSwitchStmt != nil {
+		if cerr := q.updateFlowNodeSwitchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeSwitchStmt: %w", cerr)
+		}
+	}
 	if q.updateFlowNodeWaitStmt != nil {
 		if cerr := q.updateFlowNodeWaitStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFlowNodeWaitStmt: %w", cerr)
 		}
 	}
+	if q.updateFlowNodeWebhookTriggerStmt != nil {
+		if cerr := q.updateFlowNodeWebhookTriggerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeWebhookTriggerStmt: %w", cerr)
+		}
+	}
 	if q.updateFlowNodeWsConnectionStmt != nil {
 		if cerr := q.updateFlowNodeWsConnectionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFlowNodeWsConnectionStmt: %w", cerr)
 		}
 	}
+	if q.updateFlowNodeWsExpectStmt != nil {
+		if cerr := q.updateFlowNodeWsExpectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowNodeWsExpectStmt: %w", cerr)
+		}
+	}
 	if q.updateFlowNodeWsSendStmt != nil {
 		if cerr := q.updateFlowNodeWsSendStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFlowNodeWsSendStmt: %w", cerr)
 		}
 	}
+	if q.updateFlowScheduleStmt != nil {
+		if cerr := q.updateFlowScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowScheduleStmt: %w", cerr)
+		}
+	}
+	if q.updateFlowScheduleLastRunStmt != nil {
+		if cerr := q.updateFlowScheduleLastRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFlowScheduleLastRunStmt: %w", cerr)
+		}
+	}
 	if q.updateFlowVariableStmt != nil {
 		if cerr := q.updateFlowVariableStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFlowVariableStmt: %w", cerr)
@@ -3179,6 +3830,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateWorkspaceUserStmt: %w", cerr)
 		}
 	}
+	if q.upsertGraphQLSchemaStmt != nil {
+		if cerr := q.upsertGraphQLSchemaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertGraphQLSchemaStmt: %w", cerr)
+		}
+	}
 	if q.upsertNodeExecutionStmt != nil {
 		if cerr := q.upsertNodeExecutionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertNodeExecutionStmt: %w", cerr)
@@ -3226,803 +3882,967 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                         DBTX
-	tx                                         *sql.Tx
-	authCountUsersStmt                         *sql.Stmt
-	authCreateAccountStmt                      *sql.Stmt
-	authCreateJwksStmt                         *sql.Stmt
-	authCreateSessionStmt                      *sql.Stmt
-	authCreateUserStmt                         *sql.Stmt
-	authCreateVerificationStmt                 *sql.Stmt
-	authDeleteAccountStmt                      *sql.Stmt
-	authDeleteAccountsByUserStmt               *sql.Stmt
-	authDeleteExpiredSessionsStmt              *sql.Stmt
-	authDeleteExpiredVerificationsStmt         *sql.Stmt
-	authDeleteJwksStmt                         *sql.Stmt
-	authDeleteSessionStmt                      *sql.Stmt
-	authDeleteSessionByTokenStmt               *sql.Stmt
-	authDeleteSessionsByUserStmt               *sql.Stmt
-	authDeleteUserStmt                         *sql.Stmt
-	authDeleteVerificationStmt                 *sql.Stmt
-	authGetAccountStmt                         *sql.Stmt
-	authGetAccountByProviderStmt               *sql.Stmt
-	authGetJwksStmt                            *sql.Stmt
-	authGetSessionStmt                         *sql.Stmt
-	authGetSessionByTokenStmt                  *sql.Stmt
-	authGetUserStmt                            *sql.Stmt
-	authGetUserByEmailStmt                     *sql.Stmt
-	authGetVerificationStmt                    *sql.Stmt
-	authGetVerificationByIdentifierStmt        *sql.Stmt
-	authListAccountsByUserStmt                 *sql.Stmt
-	authListJwksStmt                           *sql.Stmt
-	authListSessionsByUserStmt                 *sql.Stmt
-	authUpdateAccountStmt                      *sql.Stmt
-	authUpdateSessionStmt                      *sql.Stmt
-	authUpdateUserStmt                         *sql.Stmt
-	checkIFWorkspaceUserExistsStmt             *sql.Stmt
-	cleanupOrphanedFlowEdgesStmt               *sql.Stmt
-	cleanupOrphanedFlowNodeConditionStmt       *sql.Stmt
-	cleanupOrphanedFlowNodeForStmt             *sql.Stmt
-	cleanupOrphanedFlowNodeForEachStmt         *sql.Stmt
-	cleanupOrphanedFlowNodeGraphQLStmt         *sql.Stmt
-	cleanupOrphanedFlowNodeHttpStmt            *sql.Stmt
-	cleanupOrphanedFlowNodeJsStmt              *sql.Stmt
-	cleanupOrphanedFlowNodeRunSubFlowStmt      *sql.Stmt
-	cleanupOrphanedFlowNodeSubFlowReturnStmt   *sql.Stmt
-	cleanupOrphanedFlowNodeSubFlowTriggerStmt  *sql.Stmt
-	cleanupOrphanedFlowNodeWaitStmt            *sql.Stmt
-	cleanupOrphanedNodeExecutionsStmt          *sql.Stmt
-	createCredentialStmt                       *sql.Stmt
-	createCredentialAnthropicStmt              *sql.Stmt
-	createCredentialGeminiStmt                 *sql.Stmt
-	createCredentialOpenAIStmt                 *sql.Stmt
-	createEnvironmentStmt                      *sql.Stmt
-	createFileStmt                             *sql.Stmt
-	createFlowStmt                             *sql.Stmt
-	createFlowEdgeStmt                         *sql.Stmt
-	createFlowNodeStmt                         *sql.Stmt
-	createFlowNodeAIStmt                       *sql.Stmt
-	createFlowNodeAiProviderStmt               *sql.Stmt
-	createFlowNodeConditionStmt                *sql.Stmt
-	createFlowNodeForStmt                      *sql.Stmt
-	createFlowNodeForEachStmt                  *sql.Stmt
-	createFlowNodeGraphQLStmt                  *sql.Stmt
-	createFlowNodeHTTPStmt                     *sql.Stmt
-	createFlowNodeJsStmt                       *sql.Stmt
-	createFlowNodeMemoryStmt                   *sql.Stmt
-	createFlowNodeRunSubFlowStmt               *sql.Stmt
-	createFlowNodeSubFlowReturnStmt            *sql.Stmt
-	createFlowNodeSubFlowTriggerStmt           *sql.Stmt
-	createFlowNodeWaitStmt                     *sql.Stmt
-	createFlowNodeWithStateStmt                *sql.Stmt
-	createFlowNodeWsConnectionStmt             *sql.Stmt
-	createFlowNodeWsSendStmt                   *sql.Stmt
-	createFlowNodesBulkStmt                    *sql.Stmt
-	createFlowTagStmt                          *sql.Stmt
-	createFlowVariableStmt                     *sql.Stmt
-	createFlowVariableBulkStmt                 *sql.Stmt
-	createFlowsBulkStmt                        *sql.Stmt
-	createGraphQLStmt                          *sql.Stmt
-	createGraphQLAssertStmt                    *sql.Stmt
-	createGraphQLHeaderStmt                    *sql.Stmt
-	createGraphQLResponseStmt                  *sql.Stmt
-	createGraphQLResponseAssertStmt            *sql.Stmt
-	createGraphQLResponseHeaderStmt            *sql.Stmt
-	createGraphQLResponseHeaderBulkStmt        *sql.Stmt
-	createGraphQLVersionStmt                   *sql.Stmt
-	createHTTPStmt                             *sql.Stmt
-	createHTTPAssertStmt                       *sql.Stmt
-	createHTTPAssertBulkStmt                   *sql.Stmt
-	createHTTPBodyFormStmt                     *sql.Stmt
-	createHTTPBodyRawStmt                      *sql.Stmt
-	createHTTPBodyUrlEncodedStmt               *sql.Stmt
-	createHTTPBodyUrlEncodedBulkStmt           *sql.Stmt
-	createHTTPHeaderStmt                       *sql.Stmt
-	createHTTPResponseStmt                     *sql.Stmt
-	createHTTPResponseAssertStmt               *sql.Stmt
-	createHTTPResponseAssertBulkStmt           *sql.Stmt
-	createHTTPResponseBulkStmt                 *sql.Stmt
-	createHTTPResponseHeaderStmt               *sql.Stmt
-	createHTTPResponseHeaderBulkStmt           *sql.Stmt
-	createHTTPSearchParamStmt                  *sql.Stmt
-	createHttpVersionStmt                      *sql.Stmt
-	createMigrationStmt                        *sql.Stmt
-	createNodeExecutionStmt                    *sql.Stmt
-	createTagStmt                              *sql.Stmt
-	createUserStmt                             *sql.Stmt
-	createVariableStmt                         *sql.Stmt
-	createVariableBulkStmt                     *sql.Stmt
-	createWebSocketStmt                        *sql.Stmt
-	createWebSocketHeaderStmt                  *sql.Stmt
-	createWorkspaceStmt                        *sql.Stmt
-	createWorkspaceUserStmt                    *sql.Stmt
-	deleteCredentialStmt                       *sql.Stmt
-	deleteCredentialAnthropicStmt              *sql.Stmt
-	deleteCredentialGeminiStmt                 *sql.Stmt
-	deleteCredentialOpenAIStmt                 *sql.Stmt
-	deleteEnvironmentStmt                      *sql.Stmt
-	deleteFileStmt                             *sql.Stmt
-	deleteFlowStmt                             *sql.Stmt
-	deleteFlowEdgeStmt                         *sql.Stmt
-	deleteFlowNodeStmt                         *sql.Stmt
-	deleteFlowNodeAIStmt                       *sql.Stmt
-	deleteFlowNodeAiProviderStmt               *sql.Stmt
-	deleteFlowNodeConditionStmt                *sql.Stmt
-	deleteFlowNodeForStmt                      *sql.Stmt
-	deleteFlowNodeForEachStmt                  *sql.Stmt
-	deleteFlowNodeGraphQLStmt                  *sql.Stmt
-	deleteFlowNodeHTTPStmt                     *sql.Stmt
-	deleteFlowNodeJsStmt                       *sql.Stmt
-	deleteFlowNodeMemoryStmt                   *sql.Stmt
-	deleteFlowNodeRunSubFlowStmt               *sql.Stmt
-	deleteFlowNodeSubFlowReturnStmt            *sql.Stmt
-	deleteFlowNodeSubFlowTriggerStmt           *sql.Stmt
-	deleteFlowNodeWaitStmt                     *sql.Stmt
-	deleteFlowNodeWsConnectionStmt             *sql.Stmt
-	deleteFlowNodeWsSendStmt                   *sql.Stmt
-	deleteFlowTagStmt                          *sql.Stmt
-	deleteFlowVariableStmt                     *sql.Stmt
-	deleteGraphQLStmt                          *sql.Stmt
-	deleteGraphQLAssertStmt                    *sql.Stmt
-	deleteGraphQLHeaderStmt                    *sql.Stmt
-	deleteGraphQLResponseStmt                  *sql.Stmt
-	deleteGraphQLResponseHeaderStmt            *sql.Stmt
-	deleteHTTPStmt                             *sql.Stmt
-	deleteHTTPAssertStmt                       *sql.Stmt
-	deleteHTTPBodyFormStmt                     *sql.Stmt
-	deleteHTTPBodyRawStmt                      *sql.Stmt
-	deleteHTTPBodyUrlEncodedStmt               *sql.Stmt
-	deleteHTTPHeaderStmt                       *sql.Stmt
-	deleteHTTPResponseStmt                     *sql.Stmt
-	deleteHTTPResponseAssertStmt               *sql.Stmt
-	deleteHTTPResponseHeaderStmt               *sql.Stmt
-	deleteHTTPSearchParamStmt                  *sql.Stmt
-	deleteMigrationStmt                        *sql.Stmt
-	deleteNodeExecutionsByNodeIDStmt           *sql.Stmt
-	deleteNodeExecutionsByNodeIDsStmt          *sql.Stmt
-	deleteTagStmt                              *sql.Stmt
-	deleteUserStmt                             *sql.Stmt
-	deleteVariableStmt                         *sql.Stmt
-	deleteWebSocketStmt                        *sql.Stmt
-	deleteWebSocketHeaderStmt                  *sql.Stmt
-	deleteWebSocketHeadersByWebSocketIDStmt    *sql.Stmt
-	deleteWorkspaceStmt                        *sql.Stmt
-	deleteWorkspaceUserStmt                    *sql.Stmt
-	findFileByPathHashStmt                     *sql.Stmt
-	findHTTPByContentHashStmt                  *sql.Stmt
-	findHTTPByURLAndMethodStmt                 *sql.Stmt
-	getAllFlowsByWorkspaceIDStmt               *sql.Stmt
-	getAllWorkspacesByUserIDStmt               *sql.Stmt
-	getCredentialStmt                          *sql.Stmt
-	getCredentialAnthropicStmt                 *sql.Stmt
-	getCredentialGeminiStmt                    *sql.Stmt
-	getCredentialOpenAIStmt                    *sql.Stmt
-	getCredentialsByWorkspaceIDStmt            *sql.Stmt
-	getEnvironmentStmt                         *sql.Stmt
-	getEnvironmentWorkspaceIDStmt              *sql.Stmt
-	getEnvironmentsByWorkspaceIDStmt           *sql.Stmt
-	getEnvironmentsByWorkspaceIDOrderedStmt    *sql.Stmt
-	getFileStmt                                *sql.Stmt
-	getFileByContentIDStmt                     *sql.Stmt
-	getFileWithContentStmt                     *sql.Stmt
-	getFileWorkspaceIDStmt                     *sql.Stmt
-	getFilesByContentIDsStmt                   *sql.Stmt
-	getFilesByParentIDStmt                     *sql.Stmt
-	getFilesByParentIDOrderedStmt              *sql.Stmt
-	getFilesByWorkspaceIDStmt                  *sql.Stmt
-	getFilesByWorkspaceIDOrderedStmt           *sql.Stmt
-	getFlowStmt                                *sql.Stmt
-	getFlowContentStmt                         *sql.Stmt
-	getFlowEdgeStmt                            *sql.Stmt
-	getFlowEdgesByFlowIDStmt                   *sql.Stmt
-	getFlowEdgesByFlowIDsStmt                  *sql.Stmt
-	getFlowEdgesBySourceNodeIDsStmt            *sql.Stmt
-	getFlowEdgesByTargetNodeIDsStmt            *sql.Stmt
-	getFlowNodeStmt                            *sql.Stmt
-	getFlowNodeAIStmt                          *sql.Stmt
-	getFlowNodeAiProviderStmt                  *sql.Stmt
-	getFlowNodeConditionStmt                   *sql.Stmt
-	getFlowNodeForStmt                         *sql.Stmt
-	getFlowNodeForEachStmt                     *sql.Stmt
-	getFlowNodeGraphQLStmt                     *sql.Stmt
-	getFlowNodeHTTPStmt                        *sql.Stmt
-	getFlowNodeJsStmt                          *sql.Stmt
-	getFlowNodeMemoryStmt                      *sql.Stmt
-	getFlowNodeRunSubFlowStmt                  *sql.Stmt
-	getFlowNodeSubFlowReturnStmt               *sql.Stmt
-	getFlowNodeSubFlowTriggerStmt              *sql.Stmt
-	getFlowNodeWaitStmt                        *sql.Stmt
-	getFlowNodeWsConnectionStmt                *sql.Stmt
-	getFlowNodeWsSendStmt                      *sql.Stmt
-	getFlowNodesByFlowIDStmt                   *sql.Stmt
-	getFlowNodesByFlowIDsStmt                  *sql.Stmt
-	getFlowTagStmt                             *sql.Stmt
-	getFlowTagsByFlowIDStmt                    *sql.Stmt
-	getFlowTagsByTagIDStmt                     *sql.Stmt
-	getFlowVariableStmt                        *sql.Stmt
-	getFlowVariablesByFlowIDStmt               *sql.Stmt
-	getFlowVariablesByFlowIDOrderedStmt        *sql.Stmt
-	getFlowVariablesByFlowIDsStmt              *sql.Stmt
-	getFlowsByVersionParentIDStmt              *sql.Stmt
-	getFlowsByWorkspaceIDStmt                  *sql.Stmt
-	getGraphQLStmt                             *sql.Stmt
-	getGraphQLAssertStmt                       *sql.Stmt
-	getGraphQLAssertDeltasByParentIDStmt       *sql.Stmt
-	getGraphQLAssertDeltasByWorkspaceIDStmt    *sql.Stmt
-	getGraphQLAssertsByGraphQLIDStmt           *sql.Stmt
-	getGraphQLAssertsByIDsStmt                 *sql.Stmt
-	getGraphQLDeltasByParentIDStmt             *sql.Stmt
-	getGraphQLDeltasByWorkspaceIDStmt          *sql.Stmt
-	getGraphQLHeaderDeltasByParentIDStmt       *sql.Stmt
-	getGraphQLHeaderDeltasByWorkspaceIDStmt    *sql.Stmt
-	getGraphQLHeadersStmt                      *sql.Stmt
-	getGraphQLHeadersByIDsStmt                 *sql.Stmt
-	getGraphQLResponseStmt                     *sql.Stmt
-	getGraphQLResponseAssertsByResponseIDStmt  *sql.Stmt
-	getGraphQLResponseAssertsByWorkspaceIDStmt *sql.Stmt
-	getGraphQLResponseHeadersByResponseIDStmt  *sql.Stmt
-	getGraphQLResponseHeadersByWorkspaceIDStmt *sql.Stmt
-	getGraphQLResponsesByGraphQLIDStmt         *sql.Stmt
-	getGraphQLResponsesByWorkspaceIDStmt       *sql.Stmt
-	getGraphQLVersionsByGraphQLIDStmt          *sql.Stmt
-	getGraphQLWorkspaceIDStmt                  *sql.Stmt
-	getGraphQLsByWorkspaceIDStmt               *sql.Stmt
-	getHTTPStmt                                *sql.Stmt
-	getHTTPAssertStmt                          *sql.Stmt
-	getHTTPAssertsByHttpIDStmt                 *sql.Stmt
-	getHTTPAssertsByHttpIDsStmt                *sql.Stmt
-	getHTTPAssertsByIDsStmt                    *sql.Stmt
-	getHTTPBatchForStreamingStmt               *sql.Stmt
-	getHTTPBodyFormStreamingStmt               *sql.Stmt
-	getHTTPBodyFormsStmt                       *sql.Stmt
-	getHTTPBodyFormsByHttpIDsStmt              *sql.Stmt
-	getHTTPBodyFormsByIDsStmt                  *sql.Stmt
-	getHTTPBodyRawStmt                         *sql.Stmt
-	getHTTPBodyRawByIDStmt                     *sql.Stmt
-	getHTTPBodyRawsByHttpIDsStmt               *sql.Stmt
-	getHTTPBodyUrlEncodedStmt                  *sql.Stmt
-	getHTTPBodyUrlEncodedByHttpIDStmt          *sql.Stmt
-	getHTTPBodyUrlEncodedsByIDsStmt            *sql.Stmt
-	getHTTPBodyUrlencodedsByHttpIDsStmt        *sql.Stmt
-	getHTTPDeltasByParentIDStmt                *sql.Stmt
-	getHTTPDeltasByWorkspaceIDStmt             *sql.Stmt
-	getHTTPDeltasSinceStmt                     *sql.Stmt
-	getHTTPHeadersStmt                         *sql.Stmt
-	getHTTPHeadersByHttpIDsStmt                *sql.Stmt
-	getHTTPHeadersByIDsStmt                    *sql.Stmt
-	getHTTPHeadersStreamingStmt                *sql.Stmt
-	getHTTPIncrementalUpdatesStmt              *sql.Stmt
-	getHTTPResponseStmt                        *sql.Stmt
-	getHTTPResponseAssertStmt                  *sql.Stmt
-	getHTTPResponseAssertsByHttpIDStmt         *sql.Stmt
-	getHTTPResponseAssertsByIDsStmt            *sql.Stmt
-	getHTTPResponseAssertsByResponseIDStmt     *sql.Stmt
-	getHTTPResponseAssertsByWorkspaceIDStmt    *sql.Stmt
-	getHTTPResponseHeaderStmt                  *sql.Stmt
-	getHTTPResponseHeadersByHttpIDStmt         *sql.Stmt
-	getHTTPResponseHeadersByIDsStmt            *sql.Stmt
-	getHTTPResponseHeadersByResponseIDStmt     *sql.Stmt
-	getHTTPResponseHeadersByWorkspaceIDStmt    *sql.Stmt
-	getHTTPResponsesByHttpIDStmt               *sql.Stmt
-	getHTTPResponsesByIDsStmt                  *sql.Stmt
-	getHTTPResponsesByWorkspaceIDStmt          *sql.Stmt
-	getHTTPSearchParamsStmt                    *sql.Stmt
-	getHTTPSearchParamsByHttpIDsStmt           *sql.Stmt
-	getHTTPSearchParamsByIDsStmt               *sql.Stmt
-	getHTTPSearchParamsStreamingStmt           *sql.Stmt
-	getHTTPSnapshotCountStmt                   *sql.Stmt
-	getHTTPSnapshotPageStmt                    *sql.Stmt
-	getHTTPSnapshotsByWorkspaceIDStmt          *sql.Stmt
-	getHTTPStreamingMetricsStmt                *sql.Stmt
-	getHTTPWorkspaceActivityStmt               *sql.Stmt
-	getHTTPWorkspaceIDStmt                     *sql.Stmt
-	getHTTPsByFolderIDStmt                     *sql.Stmt
-	getHTTPsByIDsStmt                          *sql.Stmt
-	getHTTPsByWorkspaceIDStmt                  *sql.Stmt
-	getHttpVersionsByHttpIDStmt                *sql.Stmt
-	getLatestNodeExecutionByNodeIDStmt         *sql.Stmt
-	getLatestVersionByParentIDStmt             *sql.Stmt
-	getMigrationStmt                           *sql.Stmt
-	getMigrationsStmt                          *sql.Stmt
-	getNodeExecutionStmt                       *sql.Stmt
-	getNodeExecutionsByNodeIDStmt              *sql.Stmt
-	getRootFilesByWorkspaceIDStmt              *sql.Stmt
-	getTagStmt                                 *sql.Stmt
-	getTagsByWorkspaceIDStmt                   *sql.Stmt
-	getUserStmt                                *sql.Stmt
-	getUserByEmailStmt                         *sql.Stmt
-	getUserByEmailAndProviderTypeStmt          *sql.Stmt
-	getUserByExternalIDStmt                    *sql.Stmt
-	getUserByProviderIDandTypeStmt             *sql.Stmt
-	getVariableStmt                            *sql.Stmt
-	getVariablesByEnvironmentIDStmt            *sql.Stmt
-	getVariablesByEnvironmentIDOrderedStmt     *sql.Stmt
-	getWebSocketStmt                           *sql.Stmt
-	getWebSocketHeaderByIDStmt                 *sql.Stmt
-	getWebSocketHeadersStmt                    *sql.Stmt
-	getWebSocketWorkspaceIDStmt                *sql.Stmt
-	getWebSocketsByWorkspaceIDStmt             *sql.Stmt
-	getWorkspaceStmt                           *sql.Stmt
-	getWorkspaceByUserIDStmt                   *sql.Stmt
-	getWorkspaceByUserIDandWorkspaceIDStmt     *sql.Stmt
-	getWorkspaceUserStmt                       *sql.Stmt
-	getWorkspaceUserByUserIDStmt               *sql.Stmt
-	getWorkspaceUserByWorkspaceIDStmt          *sql.Stmt
-	getWorkspaceUserByWorkspaceIDAndUserIDStmt *sql.Stmt
-	getWorkspacesByUserIDStmt                  *sql.Stmt
-	getWorkspacesByUserIDOrderedStmt           *sql.Stmt
-	listNodeExecutionsStmt                     *sql.Stmt
-	listNodeExecutionsByFlowRunStmt            *sql.Stmt
-	listNodeExecutionsByStateStmt              *sql.Stmt
-	resetHTTPBodyFormDeltaStmt                 *sql.Stmt
-	resolveHTTPWithDeltasStmt                  *sql.Stmt
-	updateCredentialStmt                       *sql.Stmt
-	updateCredentialAnthropicStmt              *sql.Stmt
-	updateCredentialGeminiStmt                 *sql.Stmt
-	updateCredentialOpenAIStmt                 *sql.Stmt
-	updateEnvironmentStmt                      *sql.Stmt
-	updateFileStmt                             *sql.Stmt
-	updateFlowStmt                             *sql.Stmt
-	updateFlowEdgeStmt                         *sql.Stmt
-	updateFlowEdgeStateStmt                    *sql.Stmt
-	updateFlowNodeStmt                         *sql.Stmt
-	updateFlowNodeAIStmt                       *sql.Stmt
-	updateFlowNodeAiProviderStmt               *sql.Stmt
-	updateFlowNodeConditionStmt                *sql.Stmt
-	updateFlowNodeForStmt                      *sql.Stmt
-	updateFlowNodeForEachStmt                  *sql.Stmt
-	updateFlowNodeGraphQLStmt                  *sql.Stmt
-	updateFlowNodeHTTPStmt                     *sql.Stmt
-	updateFlowNodeIDMappingStmt                *sql.Stmt
-	updateFlowNodeJsStmt                       *sql.Stmt
-	updateFlowNodeMemoryStmt                   *sql.Stmt
-	updateFlowNodeRunSubFlowStmt               *sql.Stmt
-	updateFlowNodeStateStmt                    *sql.Stmt
-	updateFlowNodeSubFlowReturnStmt            *sql.Stmt
-	updateFlowNodeSubFlowTriggerStmt           *sql.Stmt
-	updateFlowNodeWaitStmt                     *sql.Stmt
-	updateFlowNodeWsConnectionStmt             *sql.Stmt
-	updateFlowNodeWsSendStmt                   *sql.Stmt
-	updateFlowVariableStmt                     *sql.Stmt
-	updateFlowVariableOrderStmt                *sql.Stmt
-	updateGraphQLStmt                          *sql.Stmt
-	updateGraphQLAssertStmt                    *sql.Stmt
-	updateGraphQLAssertDeltaStmt               *sql.Stmt
-	updateGraphQLDeltaStmt                     *sql.Stmt
-	updateGraphQLHeaderStmt                    *sql.Stmt
-	updateGraphQLHeaderDeltaStmt               *sql.Stmt
-	updateHTTPStmt                             *sql.Stmt
-	updateHTTPAssertStmt                       *sql.Stmt
-	updateHTTPAssertDeltaStmt                  *sql.Stmt
-	updateHTTPBodyFormStmt                     *sql.Stmt
-	updateHTTPBodyFormDeltaStmt                *sql.Stmt
-	updateHTTPBodyFormOrderStmt                *sql.Stmt
-	updateHTTPBodyRawStmt                      *sql.Stmt
-	updateHTTPBodyRawDeltaStmt                 *sql.Stmt
-	updateHTTPBodyUrlEncodedStmt               *sql.Stmt
-	updateHTTPBodyUrlEncodedDeltaStmt          *sql.Stmt
-	updateHTTPDeltaStmt                        *sql.Stmt
-	updateHTTPHeaderStmt                       *sql.Stmt
-	updateHTTPHeaderDeltaStmt                  *sql.Stmt
-	updateHTTPHeaderOrderStmt                  *sql.Stmt
-	updateHTTPResponseStmt                     *sql.Stmt
-	updateHTTPResponseAssertStmt               *sql.Stmt
-	updateHTTPResponseHeaderStmt               *sql.Stmt
-	updateHTTPSearchParamStmt                  *sql.Stmt
-	updateHTTPSearchParamDeltaStmt             *sql.Stmt
-	updateHTTPSearchParamOrderStmt             *sql.Stmt
-	updateNodeExecutionStmt                    *sql.Stmt
-	updateNodeExecutionNodeIDStmt              *sql.Stmt
-	updateTagStmt                              *sql.Stmt
-	updateUserStmt                             *sql.Stmt
-	updateVariableStmt                         *sql.Stmt
-	updateWebSocketStmt                        *sql.Stmt
-	updateWebSocketHeaderStmt                  *sql.Stmt
-	updateWorkspaceStmt                        *sql.Stmt
-	updateWorkspaceUpdatedTimeStmt             *sql.Stmt
-	updateWorkspaceUserStmt                    *sql.Stmt
-	upsertNodeExecutionStmt                    *sql.Stmt
-	upsertVariableStmt                         *sql.Stmt
+	db                                             DBTX
+	tx                                             *sql.Tx
+	authCountUsersStmt                             *sql.Stmt
+	authCreateAccountStmt                          *sql.Stmt
+	authCreateJwksStmt                             *sql.Stmt
+	authCreateSessionStmt                          *sql.Stmt
+	authCreateUserStmt                             *sql.Stmt
+	authCreateVerificationStmt                     *sql.Stmt
+	authDeleteAccountStmt                          *sql.Stmt
+	authDeleteAccountsByUserStmt                   *sql.Stmt
+	authDeleteExpiredSessionsStmt                  *sql.Stmt
+	authDeleteExpiredVerificationsStmt             *sql.Stmt
+	authDeleteJwksStmt                             *sql.Stmt
+	authDeleteSessionStmt                          *sql.Stmt
+	authDeleteSessionByTokenStmt                   *sql.Stmt
+	authDeleteSessionsByUserStmt                   *sql.Stmt
+	authDeleteUserStmt                             *sql.Stmt
+	authDeleteVerificationStmt                     *sql.Stmt
+	authGetAccountStmt                             *sql.Stmt
+	authGetAccountByProviderStmt                   *sql.Stmt
+	authGetJwksStmt                                *sql.Stmt
+	authGetSessionStmt                             *sql.Stmt
+	authGetSessionByTokenStmt                      *sql.Stmt
+	authGetUserStmt                                *sql.Stmt
+	authGetUserByEmailStmt                         *sql.Stmt
+	authGetVerificationStmt                        *sql.Stmt
+	authGetVerificationByIdentifierStmt            *sql.Stmt
+	authListAccountsByUserStmt                     *sql.Stmt
+	authListJwksStmt                               *sql.Stmt
+	authListSessionsByUserStmt                     *sql.Stmt
+	authUpdateAccountStmt                          *sql.Stmt
+	authUpdateSessionStmt                          *sql.Stmt
+	authUpdateUserStmt                             *sql.Stmt
+	checkIFWorkspaceUserExistsStmt                 *sql.Stmt
+	cleanupOrphanedFlowEdgesStmt                   *sql.Stmt
+	cleanupOrphanedFlowNodeConditionStmt           *sql.Stmt
+	cleanupOrphanedFlowNodeForStmt                 *sql.Stmt
+	cleanupOrphanedFlowNodeForEachStmt             *sql.Stmt
+	cleanupOrphanedFlowNodeGraphQLStmt             *sql.Stmt
+	cleanupOrphanedFlowNodeGraphQLSubscriptionStmt *sql.Stmt
+	cleanupOrphanedFlowNodeHttpStmt                *sql.Stmt
+	cleanupOrphanedFlowNodeJsStmt                  *sql.Stmt
+	cleanupOrphanedFlowNodeParallelStmt            *sql.Stmt
+	cleanupOrphanedFlowNodePollStmt                *sql.Stmt
+	cleanupOrphanedFlowNodeRunSubFlowStmt          *sql.Stmt
+	cleanupOrphanedFlowNodeSubFlowReturnStmt       *sql.Stmt
+	cleanupOrphanedFlowNodeSubFlowTriggerStmt      *sql.Stmt
+	cleanupOrphanedFlowNodeSwitchStmt              *sql.Stmt
+	cleanupOrphanedFlowNodeWaitStmt                *sql.Stmt
+	cleanupOrphanedFlowNodeWebhookTriggerStmt      *sql.Stmt
+	cleanupOrphanedNodeExecutionsStmt              *sql.Stmt
+	createCredentialStmt                           *sql.Stmt
+	createCredentialAnthropicStmt                  *sql.Stmt
+	createCredentialDatabaseStmt                   *sql.Stmt
+	createCredentialGeminiStmt                     *sql.Stmt
+	createCredentialMessageBrokerStmt              *sql.Stmt
+	createCredentialOpenAIStmt                     *sql.Stmt
+	createEnvironmentStmt                          *sql.Stmt
+	createFileStmt                                 *sql.Stmt
+	createFlowStmt                                 *sql.Stmt
+	createFlowEdgeStmt                             *sql.Stmt
+	createFlowNodeStmt                             *sql.Stmt
+	createFlowNodeAIStmt                           *sql.Stmt
+	createFlowNodeAiProviderStmt                   *sql.Stmt
+	createFlowNodeConditionStmt                    *sql.Stmt
+	createFlowNodeDbQueryStmt                      *sql.Stmt
+	createFlowNodeExecStmt                         *sql.Stmt
+	createFlowNodeForStmt                          *sql.Stmt
+	createFlowNodeForEachStmt                      *sql.Stmt
+	createFlowNodeGraphQLStmt                      *sql.Stmt
+	createFlowNodeGraphQLSubscriptionStmt          *sql.Stmt
+	createFlowNodeHTTPStmt                         *sql.Stmt
+	createFlowNodeJsStmt                           *sql.Stmt
+	createFlowNodeMemoryStmt                       *sql.Stmt
+	createFlowNodeMqConsumeStmt                    *sql.Stmt
+	createFlowNodeMqPublishStmt                    *sql.Stmt
+	createFlowNodeParallelStmt                     *sql.Stmt
+	createFlowNodePollStmt                         *sql.Stmt
+	createFlowNodeRunSubFlowStmt                   *sql.Stmt
+	createFlowNodeSocketConnectionStmt             *sql.Stmt
+	createFlowNodeSocketReceiveStmt                *sql.Stmt
+	createFlowNodeSocketSendStmt                   *sql.Stmt
+	createFlowNodeSubFlowReturnStmt                *sql.Stmt
+	createFlowNodeSubFlowTriggerStmt               *sql.Stmt
+	createFlowNodeSwitchStmt                       *sql.Stmt
+	createFlowNodeWaitStmt                         *sql.Stmt
+	createFlowNodeWebhookTriggerStmt               *sql.Stmt
+	createFlowNodeWithStateStmt                    *sql.Stmt
+	createFlowNodeWsConnectionStmt                 *sql.Stmt
+	createFlowNodeWsExpectStmt                     *sql.Stmt
+	createFlowNodeWsSendStmt                       *sql.Stmt
+	createFlowNodesBulkStmt                        *sql.Stmt
+	createFlowScheduleStmt                         *sql.Stmt
+	createFlowScheduleRunStmt                      *sql.Stmt
+	createFlowTagStmt                              *sql.Stmt
+	createFlowVariableStmt                         *sql.Stmt
+	createFlowVariableBulkStmt                     *sql.Stmt
+	createFlowsBulkStmt                            *sql.Stmt
+	createGraphQLStmt                              *sql.Stmt
+	createGraphQLAssertStmt                        *sql.Stmt
+	createGraphQLHeaderStmt                        *sql.Stmt
+	createGraphQLResponseStmt                      *sql.Stmt
+	createGraphQLResponseAssertStmt                *sql.Stmt
+	createGraphQLResponseHeaderStmt                *sql.Stmt
+	createGraphQLResponseHeaderBulkStmt            *sql.Stmt
+	createGraphQLVersionStmt                       *sql.Stmt
+	createHTTPStmt                                 *sql.Stmt
+	createHTTPAssertStmt                           *sql.Stmt
+	createHTTPAssertBulkStmt                       *sql.Stmt
+	createHTTPBodyFormStmt                         *sql.Stmt
+	createHTTPBodyRawStmt                          *sql.Stmt
+	createHTTPBodyUrlEncodedStmt                   *sql.Stmt
+	createHTTPBodyUrlEncodedBulkStmt               *sql.Stmt
+	createHTTPHeaderStmt                           *sql.Stmt
+	createHTTPResponseStmt                         *sql.Stmt
+	createHTTPResponseAssertStmt                   *sql.Stmt
+	createHTTPResponseAssertBulkStmt               *sql.Stmt
+	createHTTPResponseBulkStmt                     *sql.Stmt
+	createHTTPResponseHeaderStmt                   *sql.Stmt
+	createHTTPResponseHeaderBulkStmt               *sql.Stmt
+	createHTTPSearchParamStmt                      *sql.Stmt
+	createHttpVersionStmt                          *sql.Stmt
+	createMigrationStmt                            *sql.Stmt
+	createNodeExecutionStmt                        *sql.Stmt
+	createTagStmt                                  *sql.Stmt
+	createUserStmt                                 *sql.Stmt
+	createVariableStmt                             *sql.Stmt
+	createVariableBulkStmt                         *sql.Stmt
+	createWebSocketStmt                            *sql.Stmt
+	createWebSocketHeaderStmt                      *sql.Stmt
+	createWebSocketMessageStmt                     *sql.Stmt
+	createWorkspaceStmt                            *sql.Stmt
+	createWorkspaceUserStmt                        *sql.Stmt
+	deleteCredentialStmt                           *sql.Stmt
+	deleteCredentialAnthropicStmt                  *sql.Stmt
+	deleteCredentialDatabaseStmt                   *sql.Stmt
+	deleteCredentialGeminiStmt                     *sql.Stmt
+	deleteCredentialMessageBrokerStmt              *sql.Stmt
+	deleteCredentialOpenAIStmt                     *sql.Stmt
+	deleteEnvironmentStmt                          *sql.Stmt
+	deleteFileStmt                                 *sql.Stmt
+	deleteFlowStmt                                 *sql.Stmt
+	deleteFlowEdgeStmt                             *sql.Stmt
+	deleteFlowNodeStmt                             *sql.Stmt
+	deleteFlowNodeAIStmt                           *sql.Stmt
+	deleteFlowNodeAiProviderStmt                   *sql.Stmt
+	deleteFlowNodeConditionStmt                    *sql.Stmt
+	deleteFlowNodeDbQueryStmt                      *sql.Stmt
+	deleteFlowNodeExecStmt                         *sql.Stmt
+	deleteFlowNodeForStmt                          *sql.Stmt
+	deleteFlowNodeForEachStmt                      *sql.Stmt
+	deleteFlowNodeGraphQLStmt                      *sql.Stmt
+	deleteFlowNodeGraphQLSubscriptionStmt          *sql.Stmt
+	deleteFlowNodeHTTPStmt                         *sql.Stmt
+	deleteFlowNodeJsStmt                           *sql.Stmt
+	deleteFlowNodeMemoryStmt                       *sql.Stmt
+	deleteFlowNodeMqConsumeStmt                    *sql.Stmt
+	deleteFlowNodeMqPublishStmt                    *sql.Stmt
+	deleteFlowNodeParallelStmt                     *sql.Stmt
+	deleteFlowNodePollStmt                         *sql.Stmt
+	deleteFlowNodeRunSubFlowStmt                   *sql.Stmt
+	deleteFlowNodeSocketConnectionStmt             *sql.Stmt
+	deleteFlowNodeSocketReceiveStmt                *sql.Stmt
+	deleteFlowNodeSocketSendStmt                   *sql.Stmt
+	deleteFlowNodeSubFlowReturnStmt                *sql.Stmt
+	deleteFlowNodeSubFlowTriggerStmt               *sql.Stmt
+	deleteFlowNodeSwitchStmt                       *sql.Stmt
+	deleteFlowNodeWaitStmt                         *sql.Stmt
+	deleteFlowNodeWebhookTriggerStmt               *sql.Stmt
+	deleteFlowNodeWsConnectionStmt                 *sql.Stmt
+	deleteFlowNodeWsExpectStmt                     *sql.Stmt
+	deleteFlowNodeWsSendStmt                       *sql.Stmt
+	deleteFlowScheduleStmt                         *sql.Stmt
+	deleteFlowTagStmt                              *sql.Stmt
+	deleteFlowVariableStmt                         *sql.Stmt
+	deleteGraphQLStmt                              *sql.Stmt
+	deleteGraphQLAssertStmt                        *sql.Stmt
+	deleteGraphQLHeaderStmt                        *sql.Stmt
+	deleteGraphQLResponseStmt                      *sql.Stmt
+	deleteGraphQLResponseHeaderStmt                *sql.Stmt
+	deleteGraphQLSchemaStmt                        *sql.Stmt
+	deleteHTTPStmt                                 *sql.Stmt
+	deleteHTTPAssertStmt                           *sql.Stmt
+	deleteHTTPBodyFormStmt                         *sql.Stmt
+	deleteHTTPBodyRawStmt                          *sql.Stmt
+	deleteHTTPBodyUrlEncodedStmt                   *sql.Stmt
+	deleteHTTPHeaderStmt                           *sql.Stmt
+	deleteHTTPResponseStmt                         *sql.Stmt
+	deleteHTTPResponseAssertStmt                   *sql.Stmt
+	deleteHTTPResponseHeaderStmt                   *sql.Stmt
+	deleteHTTPSearchParamStmt                      *sql.Stmt
+	deleteMigrationStmt                            *sql.Stmt
+	deleteNodeExecutionsByNodeIDStmt               *sql.Stmt
+	deleteNodeExecutionsByNodeIDsStmt              *sql.Stmt
+	deleteTagStmt                                  *sql.Stmt
+	deleteUserStmt                                 *sql.Stmt
+	deleteVariableStmt                             *sql.Stmt
+	deleteWebSocketStmt                            *sql.Stmt
+	deleteWebSocketHeaderStmt                      *sql.Stmt
+	deleteWebSocketHeadersByWebSocketIDStmt        *sql.Stmt
+	deleteWebSocketMessagesByWebSocketIDStmt       *sql.Stmt
+	deleteWorkspaceStmt                            *sql.Stmt
+	deleteWorkspaceUserStmt                        *sql.Stmt
+	findFileByPathHashStmt                         *sql.Stmt
+	findHTTPByContentHashStmt                      *sql.Stmt
+	findHTTPByURLAndMethodStmt                     *sql.Stmt
+	getAllFlowsByWorkspaceIDStmt                   *sql.Stmt
+	getAllWorkspacesByUserIDStmt                   *sql.Stmt
+	getCredentialStmt                              *sql.Stmt
+	getCredentialAnthropicStmt                     *sql.Stmt
+	getCredentialDatabaseStmt                      *sql.Stmt
+	getCredentialGeminiStmt                        *sql.Stmt
+	getCredentialMessageBrokerStmt                 *sql.Stmt
+	getCredentialOpenAIStmt                        *sql.Stmt
+	getCredentialsByWorkspaceIDStmt                *sql.Stmt
+	getEnabledFlowSchedulesStmt                    *sql.Stmt
+	getEnvironmentStmt                             *sql.Stmt
+	getEnvironmentWorkspaceIDStmt                  *sql.Stmt
+	getEnvironmentsByWorkspaceIDStmt               *sql.Stmt
+	getEnvironmentsByWorkspaceIDOrderedStmt        *sql.Stmt
+	getFileStmt                                    *sql.Stmt
+	getFileByContentIDStmt                         *sql.Stmt
+	getFileWithContentStmt                         *sql.Stmt
+	getFileWorkspaceIDStmt                         *sql.Stmt
+	getFilesByContentIDsStmt                       *sql.Stmt
+	getFilesByParentIDStmt                         *sql.Stmt
+	getFilesByParentIDOrderedStmt                  *sql.Stmt
+	getFilesByWorkspaceIDStmt                      *sql.Stmt
+	getFilesByWorkspaceIDOrderedStmt               *sql.Stmt
+	getFlowStmt                                    *sql.Stmt
+	getFlowContentStmt                             *sql.Stmt
+	getFlowEdgeStmt                                *sql.Stmt
+	getFlowEdgesByFlowIDStmt                       *sql.Stmt
+	getFlowEdgesByFlowIDsStmt                      *sql.Stmt
+	getFlowEdgesBySourceNodeIDsStmt                *sql.Stmt
+	getFlowEdgesByTargetNodeIDsStmt                *sql.Stmt
+	getFlowNodeStmt                                *sql.Stmt
+	getFlowNodeAIStmt                              *sql.Stmt
+	getFlowNodeAiProviderStmt                      *sql.Stmt
+	getFlowNodeConditionStmt                       *sql.Stmt
+	getFlowNodeDbQueryStmt                         *sql.Stmt
+	getFlowNodeExecStmt                            *sql.Stmt
+	getFlowNodeForStmt                             *sql.Stmt
+	getFlowNodeForEachStmt                         *sql.Stmt
+	getFlowNodeGraphQLStmt                         *sql.Stmt
+	getFlowNodeGraphQLSubscriptionStmt             *sql.Stmt
+	getFlowNodeHTTPStmt                            *sql.Stmt
+	getFlowNodeJsStmt                              *sql.Stmt
+	getFlowNodeMemoryStmt                          *sql.Stmt
+	getFlowNodeMqConsumeStmt                       *sql.Stmt
+	getFlowNodeMqPublishStmt                       *sql.Stmt
+	getFlowNodeParallelStmt                        *sql.Stmt
+	getFlowNodePollStmt                            *sql.Stmt
+	getFlowNodeRunSubFlowStmt                      *sql.Stmt
+	getFlowNodeSocketConnectionStmt                *sql.Stmt
+	getFlowNodeSocketReceiveStmt                   *sql.Stmt
+	getFlowNodeSocketSendStmt                      *sql.Stmt
+	getFlowNodeSubFlowReturnStmt                   *sql.Stmt
+	getFlowNodeSubFlowTriggerStmt                  *sql.Stmt
+	getFlowNodeSwitchStmt                          *sql.Stmt
+	getFlowNodeWaitStmt                            *sql.Stmt
+	getFlowNodeWebhookTriggerStmt                  *sql.Stmt
+	getFlowNodeWebhookTriggersByPathStmt           *sql.Stmt
+	getFlowNodeWsConnectionStmt                    *sql.Stmt
+	getFlowNodeWsExpectStmt                        *sql.Stmt
+	getFlowNodeWsSendStmt                          *sql.Stmt
+	getFlowNodesByFlowIDStmt                       *sql.Stmt
+	getFlowNodesByFlowIDsStmt                      *sql.Stmt
+	getFlowScheduleStmt                            *sql.Stmt
+	getFlowScheduleRunsByScheduleIDStmt            *sql.Stmt
+	getFlowSchedulesByFlowIDStmt                   *sql.Stmt
+	getFlowTagStmt                                 *sql.Stmt
+	getFlowTagsByFlowIDStmt                        *sql.Stmt
+	getFlowTagsByTagIDStmt                         *sql.Stmt
+	getFlowVariableStmt                            *sql.Stmt
+	getFlowVariablesByFlowIDStmt                   *sql.Stmt
+	getFlowVariablesByFlowIDOrderedStmt            *sql.Stmt
+	getFlowVariablesByFlowIDsStmt                  *sql.Stmt
+	getFlowsByVersionParentIDStmt                  *sql.Stmt
+	getFlowsByWorkspaceIDStmt                      *sql.Stmt
+	getGraphQLStmt                                 *sql.Stmt
+	getGraphQLAssertStmt                           *sql.Stmt
+	getGraphQLAssertDeltasByParentIDStmt           *sql.Stmt
+	getGraphQLAssertDeltasByWorkspaceIDStmt        *sql.Stmt
+	getGraphQLAssertsByGraphQLIDStmt               *sql.Stmt
+	getGraphQLAssertsByIDsStmt                     *sql.Stmt
+	getGraphQLDeltasByParentIDStmt                 *sql.Stmt
+	getGraphQLDeltasByWorkspaceIDStmt              *sql.Stmt
+	getGraphQLHeaderDeltasByParentIDStmt           *sql.Stmt
+	getGraphQLHeaderDeltasByWorkspaceIDStmt        *sql.Stmt
+	getGraphQLHeadersStmt                          *sql.Stmt
+	getGraphQLHeadersByIDsStmt                     *sql.Stmt
+	getGraphQLResponseStmt                         *sql.Stmt
+	getGraphQLResponseAssertsByResponseIDStmt      *sql.Stmt
+	getGraphQLResponseAssertsByWorkspaceIDStmt     *sql.Stmt
+	getGraphQLResponseHeadersByResponseIDStmt      *sql.Stmt
+	getGraphQLResponseHeadersByWorkspaceIDStmt     *sql.Stmt
+	getGraphQLResponsesByGraphQLIDStmt             *sql.Stmt
+	getGraphQLResponsesByWorkspaceIDStmt           *sql.Stmt
+	getGraphQLSchemaStmt                           *sql.Stmt
+	getGraphQLVersionsByGraphQLIDStmt              *sql.Stmt
+	getGraphQLWorkspaceIDStmt                      *sql.Stmt
+	getGraphQLsByWorkspaceIDStmt                   *sql.Stmt
+	getHTTPStmt                                    *sql.Stmt
+	getHTTPAssertStmt                              *sql.Stmt
+	getHTTPAssertsByHttpIDStmt                     *sql.Stmt
+	getHTTPAssertsByHttpIDsStmt                    *sql.Stmt
+	getHTTPAssertsByIDsStmt                        *sql.Stmt
+	getHTTPBatchForStreamingStmt                   *sql.Stmt
+	getHTTPBodyFormStreamingStmt                   *sql.Stmt
+	getHTTPBodyFormsStmt                           *sql.Stmt
+	getHTTPBodyFormsByHttpIDsStmt                  *sql.Stmt
+	getHTTPBodyFormsByIDsStmt                      *sql.Stmt
+	getHTTPBodyRawStmt                             *sql.Stmt
+	getHTTPBodyRawByIDStmt                         *sql.Stmt
+	getHTTPBodyRawsByHttpIDsStmt                   *sql.Stmt
+	getHTTPBodyUrlEncodedStmt                      *sql.Stmt
+	getHTTPBodyUrlEncodedByHttpIDStmt              *sql.Stmt
+	getHTTPBodyUrlEncodedsByIDsStmt                *sql.Stmt
+	getHTTPBodyUrlencodedsByHttpIDsStmt            *sql.Stmt
+	getHTTPDeltasByParentIDStmt                    *sql.Stmt
+	getHTTPDeltasByWorkspaceIDStmt                 *sql.Stmt
+	getHTTPDeltasSinceStmt                         *sql.Stmt
+	getHTTPHeadersStmt                             *sql.Stmt
+	getHTTPHeadersByHttpIDsStmt                    *sql.Stmt
+	getHTTPHeadersByIDsStmt                        *sql.Stmt
+	getHTTPHeadersStreamingStmt                    *sql.Stmt
+	getHTTPIncrementalUpdatesStmt                  *sql.Stmt
+	getHTTPResponseStmt                            *sql.Stmt
+	getHTTPResponseAssertStmt                      *sql.Stmt
+	getHTTPResponseAssertsByHttpIDStmt             *sql.Stmt
+	getHTTPResponseAssertsByIDsStmt                *sql.Stmt
+	getHTTPResponseAssertsByResponseIDStmt         *sql.Stmt
+	getHTTPResponseAssertsByWorkspaceIDStmt        *sql.Stmt
+	getHTTPResponseHeaderStmt                      *sql.Stmt
+	getHTTPResponseHeadersByHttpIDStmt             *sql.Stmt
+	getHTTPResponseHeadersByIDsStmt                *sql.Stmt
+	getHTTPResponseHeadersByResponseIDStmt         *sql.Stmt
+	getHTTPResponseHeadersByWorkspaceIDStmt        *sql.Stmt
+	getHTTPResponsesByHttpIDStmt                   *sql.Stmt
+	getHTTPResponsesByIDsStmt                      *sql.Stmt
+	getHTTPResponsesByWorkspaceIDStmt              *sql.Stmt
+	getHTTPSearchParamsStmt                        *sql.Stmt
+	getHTTPSearchParamsByHttpIDsStmt               *sql.Stmt
+	getHTTPSearchParamsByIDsStmt                   *sql.Stmt
+	getHTTPSearchParamsStreamingStmt               *sql.Stmt
+	getHTTPSnapshotCountStmt                       *sql.Stmt
+	getHTTPSnapshotPageStmt                        *sql.Stmt
+	getHTTPSnapshotsByWorkspaceIDStmt              *sql.Stmt
+	getHTTPStreamingMetricsStmt                    *sql.Stmt
+	getHTTPWorkspaceActivityStmt                   *sql.Stmt
+	getHTTPWorkspaceIDStmt                         *sql.Stmt
+	getHTTPsByFolderIDStmt                         *sql.Stmt
+	getHTTPsByIDsStmt                              *sql.Stmt
+	getHTTPsByWorkspaceIDStmt                      *sql.Stmt
+	getHttpVersionsByHttpIDStmt                    *sql.Stmt
+	getLatestNodeExecutionByNodeIDStmt             *sql.Stmt
+	getLatestVersionByParentIDStmt                 *sql.Stmt
+	getMigrationStmt                               *sql.Stmt
+	getMigrationsStmt                              *sql.Stmt
+	getNodeExecutionStmt                           *sql.Stmt
+	getNodeExecutionsByNodeIDStmt                  *sql.Stmt
+	getRootFilesByWorkspaceIDStmt                  *sql.Stmt
+	getTagStmt                                     *sql.Stmt
+	getTagsByWorkspaceIDStmt                       *sql.Stmt
+	getUserStmt                                    *sql.Stmt
+	getUserByEmailStmt                             *sql.Stmt
+	getUserByEmailAndProviderTypeStmt              *sql.Stmt
+	getUserByExternalIDStmt                        *sql.Stmt
+	getUserByProviderIDandTypeStmt                 *sql.Stmt
+	getVariableStmt                                *sql.Stmt
+	getVariablesByEnvironmentIDStmt                *sql.Stmt
+	getVariablesByEnvironmentIDOrderedStmt         *sql.Stmt
+	getWebSocketStmt                               *sql.Stmt
+	getWebSocketHeaderByIDStmt                     *sql.Stmt
+	getWebSocketHeadersStmt                        *sql.Stmt
+	getWebSocketMessagesStmt                       *sql.Stmt
+	getWebSocketWorkspaceIDStmt                    *sql.Stmt
+	getWebSocketsByWorkspaceIDStmt                 *sql.Stmt
+	getWorkspaceStmt                               *sql.Stmt
+	getWorkspaceByUserIDStmt                       *sql.Stmt
+	getWorkspaceByUserIDandWorkspaceIDStmt         *sql.Stmt
+	getWorkspaceUserStmt                           *sql.Stmt
+	getWorkspaceUserByUserIDStmt                   *sql.Stmt
+	getWorkspaceUserByWorkspaceIDStmt              *sql.Stmt
+	getWorkspaceUserByWorkspaceIDAndUserIDStmt     *sql.Stmt
+	getWorkspacesByUserIDStmt                      *sql.Stmt
+	getWorkspacesByUserIDOrderedStmt               *sql.Stmt
+	listNodeExecutionsStmt                         *sql.Stmt
+	listNodeExecutionsByFlowRunStmt                *sql.Stmt
+	listNodeExecutionsByStateStmt                  *sql.Stmt
+	pruneFlowScheduleRunsStmt                      *sql.Stmt
+	resetHTTPBodyFormDeltaStmt                     *sql.Stmt
+	resolveHTTPWithDeltasStmt                      *sql.Stmt
+	updateCredentialStmt                           *sql.Stmt
+	updateCredentialAnthropicStmt                  *sql.Stmt
+	updateCredentialDatabaseStmt                   *sql.Stmt
+	updateCredentialGeminiStmt                     *sql.Stmt
+	updateCredentialMessageBrokerStmt              *sql.Stmt
+	updateCredentialOpenAIStmt                     *sql.Stmt
+	updateEnvironmentStmt                          *sql.Stmt
+	updateFileStmt                                 *sql.Stmt
+	updateFlowStmt                                 *sql.Stmt
+	updateFlowEdgeStmt                             *sql.Stmt
+	updateFlowEdgeStateStmt                        *sql.Stmt
+	updateFlowNodeStmt                             *sql.Stmt
+	updateFlowNodeAIStmt                           *sql.Stmt
+	updateFlowNodeAiProviderStmt                   *sql.Stmt
+	updateFlowNodeConditionStmt                    *sql.Stmt
+	updateFlowNodeDbQueryStmt                      *sql.Stmt
+	updateFlowNodeExecStmt                         *sql.Stmt
+	updateFlowNodeForStmt                          *sql.Stmt
+	updateFlowNodeForEachStmt                      *sql.Stmt
+	updateFlowNodeGraphQLStmt                      *sql.Stmt
+	updateFlowNodeGraphQLSubscriptionStmt          *sql.Stmt
+	updateFlowNodeHTTPStmt                         *sql.Stmt
+	updateFlowNodeIDMappingStmt                    *sql.Stmt
+	updateFlowNodeJsStmt                           *sql.Stmt
+	updateFlowNodeMemoryStmt                       *sql.Stmt
+	updateFlowNodeMqConsumeStmt                    *sql.Stmt
+	updateFlowNodeMqPublishStmt                    *sql.Stmt
+	updateFlowNodeParallelStmt                     *sql.Stmt
+	updateFlowNodePollStmt                         *sql.Stmt
+	updateFlowNodeRunSubFlowStmt                   *sql.Stmt
+	updateFlowNodeSocketConnectionStmt             *sql.Stmt
+	updateFlowNodeSocketReceiveStmt                *sql.Stmt
+	updateFlowNodeSocketSendStmt                   *sql.Stmt
+	updateFlowNodeStateStmt                        *sql.Stmt
+	updateFlowNodeSubFlowReturnStmt                *sql.Stmt
+	updateFlowNodeSubFlowTriggerStmt               *sql.Stmt
+	updateFlowNodeSwitchStmt                       *sql.Stmt
+	updateFlowNodeWaitStmt                         *sql.Stmt
+	updateFlowNodeWebhookTriggerStmt               *sql.Stmt
+	updateFlowNodeWsConnectionStmt                 *sql.Stmt
+	updateFlowNodeWsExpectStmt                     *sql.Stmt
+	updateFlowNodeWsSendStmt                       *sql.Stmt
+	updateFlowScheduleStmt                         *sql.Stmt
+	updateFlowScheduleLastRunStmt                  *sql.Stmt
+	updateFlowVariableStmt                         *sql.Stmt
+	updateFlowVariableOrderStmt                    *sql.Stmt
+	updateGraphQLStmt                              *sql.Stmt
+	updateGraphQLAssertStmt                        *sql.Stmt
+	updateGraphQLAssertDeltaStmt                   *sql.Stmt
+	updateGraphQLDeltaStmt                         *sql.Stmt
+	updateGraphQLHeaderStmt                        *sql.Stmt
+	updateGraphQLHeaderDeltaStmt                   *sql.Stmt
+	updateHTTPStmt                                 *sql.Stmt
+	updateHTTPAssertStmt                           *sql.Stmt
+	updateHTTPAssertDeltaStmt                      *sql.Stmt
+	updateHTTPBodyFormStmt                         *sql.Stmt
+	updateHTTPBodyFormDeltaStmt                    *sql.Stmt
+	updateHTTPBodyFormOrderStmt                    *sql.Stmt
+	updateHTTPBodyRawStmt                          *sql.Stmt
+	updateHTTPBodyRawDeltaStmt                     *sql.Stmt
+	updateHTTPBodyUrlEncodedStmt                   *sql.Stmt
+	updateHTTPBodyUrlEncodedDeltaStmt              *sql.Stmt
+	updateHTTPDeltaStmt                            *sql.Stmt
+	updateHTTPHeaderStmt                           *sql.Stmt
+	updateHTTPHeaderDeltaStmt                      *sql.Stmt
+	updateHTTPHeaderOrderStmt                      *sql.Stmt
+	updateHTTPResponseStmt                         *sql.Stmt
+	updateHTTPResponseAssertStmt                   *sql.Stmt
+	updateHTTPResponseHeaderStmt                   *sql.Stmt
+	updateHTTPSearchParamStmt                      *sql.Stmt
+	updateHTTPSearchParamDeltaStmt                 *sql.Stmt
+	updateHTTPSearchParamOrderStmt                 *sql.Stmt
+	updateNodeExecutionStmt                        *sql.Stmt
+	updateNodeExecutionNodeIDStmt                  *sql.Stmt
+	updateTagStmt                                  *sql.Stmt
+	updateUserStmt                                 *sql.Stmt
+	updateVariableStmt                             *sql.Stmt
+	updateWebSocketStmt                            *sql.Stmt
+	updateWebSocketHeaderStmt                      *sql.Stmt
+	updateWorkspaceStmt                            *sql.Stmt
+	updateWorkspaceUpdatedTimeStmt                 *sql.Stmt
+	updateWorkspaceUserStmt                        *sql.Stmt
+	upsertGraphQLSchemaStmt                        *sql.Stmt
+	upsertNodeExecutionStmt                        *sql.Stmt
+	upsertVariableStmt                             *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                         tx,
-		tx:                                         tx,
-		authCountUsersStmt:                         q.authCountUsersStmt,
-		authCreateAccountStmt:                      q.authCreateAccountStmt,
-		authCreateJwksStmt:                         q.authCreateJwksStmt,
-		authCreateSessionStmt:                      q.authCreateSessionStmt,
-		authCreateUserStmt:                         q.authCreateUserStmt,
-		authCreateVerificationStmt:                 q.authCreateVerificationStmt,
-		authDeleteAccountStmt:                      q.authDeleteAccountStmt,
-		authDeleteAccountsByUserStmt:               q.authDeleteAccountsByUserStmt,
-		authDeleteExpiredSessionsStmt:              q.authDeleteExpiredSessionsStmt,
-		authDeleteExpiredVerificationsStmt:         q.authDeleteExpiredVerificationsStmt,
-		authDeleteJwksStmt:                         q.authDeleteJwksStmt,
-		authDeleteSessionStmt:                      q.authDeleteSessionStmt,
-		authDeleteSessionByTokenStmt:               q.authDeleteSessionByTokenStmt,
-		authDeleteSessionsByUserStmt:               q.authDeleteSessionsByUserStmt,
-		authDeleteUserStmt:                         q.authDeleteUserStmt,
-		authDeleteVerificationStmt:                 q.authDeleteVerificationStmt,
-		authGetAccountStmt:                         q.authGetAccountStmt,
-		authGetAccountByProviderStmt:               q.authGetAccountByProviderStmt,
-		authGetJwksStmt:                            q.authGetJwksStmt,
-		authGetSessionStmt:                         q.authGetSessionStmt,
-		authGetSessionByTokenStmt:                  q.authGetSessionByTokenStmt,
-		authGetUserStmt:                            q.authGetUserStmt,
-		authGetUserByEmailStmt:                     q.authGetUserByEmailStmt,
-		authGetVerificationStmt:                    q.authGetVerificationStmt,
-		authGetVerificationByIdentifierStmt:        q.authGetVerificationByIdentifierStmt,
-		authListAccountsByUserStmt:                 q.authListAccountsByUserStmt,
-		authListJwksStmt:                           q.authListJwksStmt,
-		authListSessionsByUserStmt:                 q.authListSessionsByUserStmt,
-		authUpdateAccountStmt:                      q.authUpdateAccountStmt,
-		authUpdateSessionStmt:                      q.authUpdateSessionStmt,
-		authUpdateUserStmt:                         q.authUpdateUserStmt,
-		checkIFWorkspaceUserExistsStmt:             q.checkIFWorkspaceUserExistsStmt,
-		cleanupOrphanedFlowEdgesStmt:               q.cleanupOrphanedFlowEdgesStmt,
-		cleanupOrphanedFlowNodeConditionStmt:       q.cleanupOrphanedFlowNodeConditionStmt,
-		cleanupOrphanedFlowNodeForStmt:             q.cleanupOrphanedFlowNodeForStmt,
-		cleanupOrphanedFlowNodeForEachStmt:         q.cleanupOrphanedFlowNodeForEachStmt,
-		cleanupOrphanedFlowNodeGraphQLStmt:         q.cleanupOrphanedFlowNodeGraphQLStmt,
-		cleanupOrphanedFlowNodeHttpStmt:            q.cleanupOrphanedFlowNodeHttpStmt,
-		cleanupOrphanedFlowNodeJsStmt:              q.cleanupOrphanedFlowNodeJsStmt,
-		cleanupOrphanedFlowNodeRunSubFlowStmt:      q.cleanupOrphanedFlowNodeRunSubFlowStmt,
-		cleanupOrphanedFlowNodeSubFlowReturnStmt:   q.cleanupOrphanedFlowNodeSubFlowReturnStmt,
-		cleanupOrphanedFlowNodeSubFlowTriggerStmt:  q.cleanupOrphanedFlowNodeSubFlowTriggerStmt,
-		cleanupOrphanedFlowNodeWaitStmt:            q.cleanupOrphanedFlowNodeWaitStmt,
-		cleanupOrphanedNodeExecutionsStmt:          q.cleanupOrphanedNodeExecutionsStmt,
-		createCredentialStmt:                       q.createCredentialStmt,
-		createCredentialAnthropicStmt:              q.createCredentialAnthropicStmt,
-		createCredentialGeminiStmt:                 q.createCredentialGeminiStmt,
-		createCredentialOpenAIStmt:                 q.createCredentialOpenAIStmt,
-		createEnvironmentStmt:                      q.createEnvironmentStmt,
-		createFileStmt:                             q.createFileStmt,
-		createFlowStmt:                             q.createFlowStmt,
-		createFlowEdgeStmt:                         q.createFlowEdgeStmt,
-		createFlowNodeStmt:                         q.createFlowNodeStmt,
-		createFlowNodeAIStmt:                       q.createFlowNodeAIStmt,
-		createFlowNodeAiProviderStmt:               q.createFlowNodeAiProviderStmt,
-		createFlowNodeConditionStmt:                q.createFlowNodeConditionStmt,
-		createFlowNodeForStmt:                      q.createFlowNodeForStmt,
-		createFlowNodeForEachStmt:                  q.createFlowNodeForEachStmt,
-		createFlowNodeGraphQLStmt:                  q.createFlowNodeGraphQLStmt,
-		createFlowNodeHTTPStmt:                     q.createFlowNodeHTTPStmt,
-		createFlowNodeJsStmt:                       q.createFlowNodeJsStmt,
-		createFlowNodeMemoryStmt:                   q.createFlowNodeMemoryStmt,
-		createFlowNodeRunSubFlowStmt:               q.createFlowNodeRunSubFlowStmt,
-		createFlowNodeSubFlowReturnStmt:            q.createFlowNodeSubFlowReturnStmt,
-		createFlowNodeSubFlowTriggerStmt:           q.createFlowNodeSubFlowTriggerStmt,
-		createFlowNodeWaitStmt:                     q.createFlowNodeWaitStmt,
-		createFlowNodeWithStateStmt:                q.createFlowNodeWithStateStmt,
-		createFlowNodeWsConnectionStmt:             q.createFlowNodeWsConnectionStmt,
-		createFlowNodeWsSendStmt:                   q.createFlowNodeWsSendStmt,
-		createFlowNodesBulkStmt:                    q.createFlowNodesBulkStmt,
-		createFlowTagStmt:                          q.createFlowTagStmt,
-		createFlowVariableStmt:                     q.createFlowVariableStmt,
-		createFlowVariableBulkStmt:                 q.createFlowVariableBulkStmt,
-		createFlowsBulkStmt:                        q.createFlowsBulkStmt,
-		createGraphQLStmt:                          q.createGraphQLStmt,
-		createGraphQLAssertStmt:                    q.createGraphQLAssertStmt,
-		createGraphQLHeaderStmt:                    q.createGraphQLHeaderStmt,
-		createGraphQLResponseStmt:                  q.createGraphQLResponseStmt,
-		createGraphQLResponseAssertStmt:            q.createGraphQLResponseAssertStmt,
-		createGraphQLResponseHeaderStmt:            q.createGraphQLResponseHeaderStmt,
-		createGraphQLResponseHeaderBulkStmt:        q.createGraphQLResponseHeaderBulkStmt,
-		createGraphQLVersionStmt:                   q.createGraphQLVersionStmt,
-		createHTTPStmt:                             q.createHTTPStmt,
-		createHTTPAssertStmt:                       q.createHTTPAssertStmt,
-		createHTTPAssertBulkStmt:                   q.createHTTPAssertBulkStmt,
-		createHTTPBodyFormStmt:                     q.createHTTPBodyFormStmt,
-		createHTTPBodyRawStmt:                      q.createHTTPBodyRawStmt,
-		createHTTPBodyUrlEncodedStmt:               q.createHTTPBodyUrlEncodedStmt,
-		createHTTPBodyUrlEncodedBulkStmt:           q.createHTTPBodyUrlEncodedBulkStmt,
-		createHTTPHeaderStmt:                       q.createHTTPHeaderStmt,
-		createHTTPResponseStmt:                     q.createHTTPResponseStmt,
-		createHTTPResponseAssertStmt:               q.createHTTPResponseAssertStmt,
-		createHTTPResponseAssertBulkStmt:           q.createHTTPResponseAssertBulkStmt,
-		createHTTPResponseBulkStmt:                 q.createHTTPResponseBulkStmt,
-		createHTTPResponseHeaderStmt:               q.createHTTPResponseHeaderStmt,
-		createHTTPResponseHeaderBulkStmt:           q.createHTTPResponseHeaderBulkStmt,
-		createHTTPSearchParamStmt:                  q.createHTTPSearchParamStmt,
-		createHttpVersionStmt:                      q.createHttpVersionStmt,
-		createMigrationStmt:                        q.createMigrationStmt,
-		createNodeExecutionStmt:                    q.createNodeExecutionStmt,
-		createTagStmt:                              q.createTagStmt,
-		createUserStmt:                             q.createUserStmt,
-		createVariableStmt:                         q.createVariableStmt,
-		createVariableBulkStmt:                     q.createVariableBulkStmt,
-		createWebSocketStmt:                        q.createWebSocketStmt,
-		createWebSocketHeaderStmt:                  q.createWebSocketHeaderStmt,
-		createWorkspaceStmt:                        q.createWorkspaceStmt,
-		createWorkspaceUserStmt:                    q.createWorkspaceUserStmt,
-		deleteCredentialStmt:                       q.deleteCredentialStmt,
-		deleteCredentialAnthropicStmt:              q.deleteCredentialAnthropicStmt,
-		deleteCredentialGeminiStmt:                 q.deleteCredentialGeminiStmt,
-		deleteCredentialOpenAIStmt:                 q.deleteCredentialOpenAIStmt,
-		deleteEnvironmentStmt:                      q.deleteEnvironmentStmt,
-		deleteFileStmt:                             q.deleteFileStmt,
-		deleteFlowStmt:                             q.deleteFlowStmt,
-		deleteFlowEdgeStmt:                         q.deleteFlowEdgeStmt,
-		deleteFlowNodeStmt:                         q.deleteFlowNodeStmt,
-		deleteFlowNodeAIStmt:                       q.deleteFlowNodeAIStmt,
-		deleteFlowNodeAiProviderStmt:               q.deleteFlowNodeAiProviderStmt,
-		deleteFlowNodeConditionStmt:                q.deleteFlowNodeConditionStmt,
-		deleteFlowNodeForStmt:                      q.deleteFlowNodeForStmt,
-		deleteFlowNodeForEachStmt:                  q.deleteFlowNodeForEachStmt,
-		deleteFlowNodeGraphQLStmt:                  q.deleteFlowNodeGraphQLStmt,
-		deleteFlowNodeHTTPStmt:                     q.deleteFlowNodeHTTPStmt,
-		deleteFlowNodeJsStmt:                       q.deleteFlowNodeJsStmt,
-		deleteFlowNodeMemoryStmt:                   q.deleteFlowNodeMemoryStmt,
-		deleteFlowNodeRunSubFlowStmt:               q.deleteFlowNodeRunSubFlowStmt,
-		deleteFlowNodeSubFlowReturnStmt:            q.deleteFlowNodeSubFlowReturnStmt,
-		deleteFlowNodeSubFlowTriggerStmt:           q.deleteFlowNodeSubFlowTriggerStmt,
-		deleteFlowNodeWaitStmt:                     q.deleteFlowNodeWaitStmt,
-		deleteFlowNodeWsConnectionStmt:             q.deleteFlowNodeWsConnectionStmt,
-		deleteFlowNodeWsSendStmt:                   q.deleteFlowNodeWsSendStmt,
-		deleteFlowTagStmt:                          q.deleteFlowTagStmt,
-		deleteFlowVariableStmt:                     q.deleteFlowVariableStmt,
-		deleteGraphQLStmt:                          q.deleteGraphQLStmt,
-		deleteGraphQLAssertStmt:                    q.deleteGraphQLAssertStmt,
-		deleteGraphQLHeaderStmt:                    q.deleteGraphQLHeaderStmt,
-		deleteGraphQLResponseStmt:                  q.deleteGraphQLResponseStmt,
-		deleteGraphQLResponseHeaderStmt:            q.deleteGraphQLResponseHeaderStmt,
-		deleteHTTPStmt:                             q.deleteHTTPStmt,
-		deleteHTTPAssertStmt:                       q.deleteHTTPAssertStmt,
-		deleteHTTPBodyFormStmt:                     q.deleteHTTPBodyFormStmt,
-		deleteHTTPBodyRawStmt:                      q.deleteHTTPBodyRawStmt,
-		deleteHTTPBodyUrlEncodedStmt:               q.deleteHTTPBodyUrlEncodedStmt,
-		deleteHTTPHeaderStmt:                       q.deleteHTTPHeaderStmt,
-		deleteHTTPResponseStmt:                     q.deleteHTTPResponseStmt,
-		deleteHTTPResponseAssertStmt:               q.deleteHTTPResponseAssertStmt,
-		deleteHTTPResponseHeaderStmt:               q.deleteHTTPResponseHeaderStmt,
-		deleteHTTPSearchParamStmt:                  q.deleteHTTPSearchParamStmt,
-		deleteMigrationStmt:                        q.deleteMigrationStmt,
-		deleteNodeExecutionsByNodeIDStmt:           q.deleteNodeExecutionsByNodeIDStmt,
-		deleteNodeExecutionsByNodeIDsStmt:          q.deleteNodeExecutionsByNodeIDsStmt,
-		deleteTagStmt:                              q.deleteTagStmt,
-		deleteUserStmt:                             q.deleteUserStmt,
-		deleteVariableStmt:                         q.deleteVariableStmt,
-		deleteWebSocketStmt:                        q.deleteWebSocketStmt,
-		deleteWebSocketHeaderStmt:                  q.deleteWebSocketHeaderStmt,
-		deleteWebSocketHeadersByWebSocketIDStmt:    q.deleteWebSocketHeadersByWebSocketIDStmt,
-		deleteWorkspaceStmt:                        q.deleteWorkspaceStmt,
-		deleteWorkspaceUserStmt:                    q.deleteWorkspaceUserStmt,
-		findFileByPathHashStmt:                     q.findFileByPathHashStmt,
-		findHTTPByContentHashStmt:                  q.findHTTPByContentHashStmt,
-		findHTTPByURLAndMethodStmt:                 q.findHTTPByURLAndMethodStmt,
-		getAllFlowsByWorkspaceIDStmt:               q.getAllFlowsByWorkspaceIDStmt,
-		getAllWorkspacesByUserIDStmt:               q.getAllWorkspacesByUserIDStmt,
-		getCredentialStmt:                          q.getCredentialStmt,
-		getCredentialAnthropicStmt:                 q.getCredentialAnthropicStmt,
-		getCredentialGeminiStmt:                    q.getCredentialGeminiStmt,
-		getCredentialOpenAIStmt:                    q.getCredentialOpenAIStmt,
-		getCredentialsByWorkspaceIDStmt:            q.getCredentialsByWorkspaceIDStmt,
-		getEnvironmentStmt:                         q.getEnvironmentStmt,
-		getEnvironmentWorkspaceIDStmt:              q.getEnvironmentWorkspaceIDStmt,
-		getEnvironmentsByWorkspaceIDStmt:           q.getEnvironmentsByWorkspaceIDStmt,
-		getEnvironmentsByWorkspaceIDOrderedStmt:    q.getEnvironmentsByWorkspaceIDOrderedStmt,
-		getFileStmt:                                q.getFileStmt,
-		getFileByContentIDStmt:                     q.getFileByContentIDStmt,
-		getFileWithContentStmt:                     q.getFileWithContentStmt,
-		getFileWorkspaceIDStmt:                     q.getFileWorkspaceIDStmt,
-		getFilesByContentIDsStmt:                   q.getFilesByContentIDsStmt,
-		getFilesByParentIDStmt:                     q.getFilesByParentIDStmt,
-		getFilesByParentIDOrderedStmt:              q.getFilesByParentIDOrderedStmt,
-		getFilesByWorkspaceIDStmt:                  q.getFilesByWorkspaceIDStmt,
-		getFilesByWorkspaceIDOrderedStmt:           q.getFilesByWorkspaceIDOrderedStmt,
-		getFlowStmt:                                q.getFlowStmt,
-		getFlowContentStmt:                         q.getFlowContentStmt,
-		getFlowEdgeStmt:                            q.getFlowEdgeStmt,
-		getFlowEdgesByFlowIDStmt:                   q.getFlowEdgesByFlowIDStmt,
-		getFlowEdgesByFlowIDsStmt:                  q.getFlowEdgesByFlowIDsStmt,
-		getFlowEdgesBySourceNodeIDsStmt:            q.getFlowEdgesBySourceNodeIDsStmt,
-		getFlowEdgesByTargetNodeIDsStmt:            q.getFlowEdgesByTargetNodeIDsStmt,
-		getFlowNodeStmt:                            q.getFlowNodeStmt,
-		getFlowNodeAIStmt:                          q.getFlowNodeAIStmt,
-		getFlowNodeAiProviderStmt:                  q.getFlowNodeAiProviderStmt,
-		getFlowNodeConditionStmt:                   q.getFlowNodeConditionStmt,
-		getFlowNodeForStmt:                         q.getFlowNodeForStmt,
-		getFlowNodeForEachStmt:                     q.getFlowNodeForEachStmt,
-		getFlowNodeGraphQLStmt:                     q.getFlowNodeGraphQLStmt,
-		getFlowNodeHTTPStmt:                        q.getFlowNodeHTTPStmt,
-		getFlowNodeJsStmt:                          q.getFlowNodeJsStmt,
-		getFlowNodeMemoryStmt:                      q.getFlowNodeMemoryStmt,
-		getFlowNodeRunSubFlowStmt:                  q.getFlowNodeRunSubFlowStmt,
-		getFlowNodeSubFlowReturnStmt:               q.getFlowNodeSubFlowReturnStmt,
-		getFlowNodeSubFlowTriggerStmt:              q.getFlowNodeSubFlowTriggerStmt,
-		getFlowNodeWaitStmt:                        q.getFlowNodeWaitStmt,
-		getFlowNodeWsConnectionStmt:                q.getFlowNodeWsConnectionStmt,
-		getFlowNodeWsSendStmt:                      q.getFlowNodeWsSendStmt,
-		getFlowNodesByFlowIDStmt:                   q.getFlowNodesByFlowIDStmt,
-		getFlowNodesByFlowIDsStmt:                  q.getFlowNodesByFlowIDsStmt,
-		getFlowTagStmt:                             q.getFlowTagStmt,
-		getFlowTagsByFlowIDStmt:                    q.getFlowTagsByFlowIDStmt,
-		getFlowTagsByTagIDStmt:                     q.getFlowTagsByTagIDStmt,
-		getFlowVariableStmt:                        q.getFlowVariableStmt,
-		getFlowVariablesByFlowIDStmt:               q.getFlowVariablesByFlowIDStmt,
-		getFlowVariablesByFlowIDOrderedStmt:        q.getFlowVariablesByFlowIDOrderedStmt,
-		getFlowVariablesByFlowIDsStmt:              q.getFlowVariablesByFlowIDsStmt,
-		getFlowsByVersionParentIDStmt:              q.getFlowsByVersionParentIDStmt,
-		getFlowsByWorkspaceIDStmt:                  q.getFlowsByWorkspaceIDStmt,
-		getGraphQLStmt:                             q.getGraphQLStmt,
-		getGraphQLAssertStmt:                       q.getGraphQLAssertStmt,
-		getGraphQLAssertDeltasByParentIDStmt:       q.getGraphQLAssertDeltasByParentIDStmt,
-		getGraphQLAssertDeltasByWorkspaceIDStmt:    q.getGraphQLAssertDeltasByWorkspaceIDStmt,
-		getGraphQLAssertsByGraphQLIDStmt:           q.getGraphQLAssertsByGraphQLIDStmt,
-		getGraphQLAssertsByIDsStmt:                 q.getGraphQLAssertsByIDsStmt,
-		getGraphQLDeltasByParentIDStmt:             q.getGraphQLDeltasByParentIDStmt,
-		getGraphQLDeltasByWorkspaceIDStmt:          q.getGraphQLDeltasByWorkspaceIDStmt,
-		getGraphQLHeaderDeltasByParentIDStmt:       q.getGraphQLHeaderDeltasByParentIDStmt,
-		getGraphQLHeaderDeltasByWorkspaceIDStmt:    q.getGraphQLHeaderDeltasByWorkspaceIDStmt,
-		getGraphQLHeadersStmt:                      q.getGraphQLHeadersStmt,
-		getGraphQLHeadersByIDsStmt:                 q.getGraphQLHeadersByIDsStmt,
-		getGraphQLResponseStmt:                     q.getGraphQLResponseStmt,
-		getGraphQLResponseAssertsByResponseIDStmt:  q.getGraphQLResponseAssertsByResponseIDStmt,
-		getGraphQLResponseAssertsByWorkspaceIDStmt: q.getGraphQLResponseAssertsByWorkspaceIDStmt,
-		getGraphQLResponseHeadersByResponseIDStmt:  q.getGraphQLResponseHeadersByResponseIDStmt,
-		getGraphQLResponseHeadersByWorkspaceIDStmt: q.getGraphQLResponseHeadersByWorkspaceIDStmt,
-		getGraphQLResponsesByGraphQLIDStmt:         q.getGraphQLResponsesByGraphQLIDStmt,
-		getGraphQLResponsesByWorkspaceIDStmt:       q.getGraphQLResponsesByWorkspaceIDStmt,
-		getGraphQLVersionsByGraphQLIDStmt:          q.getGraphQLVersionsByGraphQLIDStmt,
-		getGraphQLWorkspaceIDStmt:                  q.getGraphQLWorkspaceIDStmt,
-		getGraphQLsByWorkspaceIDStmt:               q.getGraphQLsByWorkspaceIDStmt,
-		getHTTPStmt:                                q.getHTTPStmt,
-		getHTTPAssertStmt:                          q.getHTTPAssertStmt,
-		getHTTPAssertsByHttpIDStmt:                 q.getHTTPAssertsByHttpIDStmt,
-		getHTTPAssertsByHttpIDsStmt:                q.getHTTPAssertsByHttpIDsStmt,
-		getHTTPAssertsByIDsStmt:                    q.getHTTPAssertsByIDsStmt,
-		getHTTPBatchForStreamingStmt:               q.getHTTPBatchForStreamingStmt,
-		getHTTPBodyFormStreamingStmt:               q.getHTTPBodyFormStreamingStmt,
-		getHTTPBodyFormsStmt:                       q.getHTTPBodyFormsStmt,
-		getHTTPBodyFormsByHttpIDsStmt:              q.getHTTPBodyFormsByHttpIDsStmt,
-		getHTTPBodyFormsByIDsStmt:                  q.getHTTPBodyFormsByIDsStmt,
-		getHTTPBodyRawStmt:                         q.getHTTPBodyRawStmt,
-		getHTTPBodyRawByIDStmt:                     q.getHTTPBodyRawByIDStmt,
-		getHTTPBodyRawsByHttpIDsStmt:               q.getHTTPBodyRawsByHttpIDsStmt,
-		getHTTPBodyUrlEncodedStmt:                  q.getHTTPBodyUrlEncodedStmt,
-		getHTTPBodyUrlEncodedByHttpIDStmt:          q.getHTTPBodyUrlEncodedByHttpIDStmt,
-		getHTTPBodyUrlEncodedsByIDsStmt:            q.getHTTPBodyUrlEncodedsByIDsStmt,
-		getHTTPBodyUrlencodedsByHttpIDsStmt:        q.getHTTPBodyUrlencodedsByHttpIDsStmt,
-		getHTTPDeltasByParentIDStmt:                q.getHTTPDeltasByParentIDStmt,
-		getHTTPDeltasByWorkspaceIDStmt:             q.getHTTPDeltasByWorkspaceIDStmt,
-		getHTTPDeltasSinceStmt:                     q.getHTTPDeltasSinceStmt,
-		getHTTPHeadersStmt:                         q.getHTTPHeadersStmt,
-		getHTTPHeadersByHttpIDsStmt:                q.getHTTPHeadersByHttpIDsStmt,
-		getHTTPHeadersByIDsStmt:                    q.getHTTPHeadersByIDsStmt,
-		getHTTPHeadersStreamingStmt:                q.getHTTPHeadersStreamingStmt,
-		getHTTPIncrementalUpdatesStmt:              q.getHTTPIncrementalUpdatesStmt,
-		getHTTPResponseStmt:                        q.getHTTPResponseStmt,
-		getHTTPResponseAssertStmt:                  q.getHTTPResponseAssertStmt,
-		getHTTPResponseAssertsByHttpIDStmt:         q.getHTTPResponseAssertsByHttpIDStmt,
-		getHTTPResponseAssertsByIDsStmt:            q.getHTTPResponseAssertsByIDsStmt,
-		getHTTPResponseAssertsByResponseIDStmt:     q.getHTTPResponseAssertsByResponseIDStmt,
-		getHTTPResponseAssertsByWorkspaceIDStmt:    q.getHTTPResponseAssertsByWorkspaceIDStmt,
-		getHTTPResponseHeaderStmt:                  q.getHTTPResponseHeaderStmt,
-		getHTTPResponseHeadersByHttpIDStmt:         q.getHTTPResponseHeadersByHttpIDStmt,
-		getHTTPResponseHeadersByIDsStmt:            q.getHTTPResponseHeadersByIDsStmt,
-		getHTTPResponseHeadersByResponseIDStmt:     q.getHTTPResponseHeadersByResponseIDStmt,
-		getHTTPResponseHeadersByWorkspaceIDStmt:    q.getHTTPResponseHeadersByWorkspaceIDStmt,
-		getHTTPResponsesByHttpIDStmt:               q.getHTTPResponsesByHttpIDStmt,
-		getHTTPResponsesByIDsStmt:                  q.getHTTPResponsesByIDsStmt,
-		getHTTPResponsesByWorkspaceIDStmt:          q.getHTTPResponsesByWorkspaceIDStmt,
-		getHTTPSearchParamsStmt:                    q.getHTTPSearchParamsStmt,
-		getHTTPSearchParamsByHttpIDsStmt:           q.getHTTPSearchParamsByHttpIDsStmt,
-		getHTTPSearchParamsByIDsStmt:               q.getHTTPSearchParamsByIDsStmt,
-		getHTTPSearchParamsStreamingStmt:           q.getHTTPSearchParamsStreamingStmt,
-		getHTTPSnapshotCountStmt:                   q.getHTTPSnapshotCountStmt,
-		getHTTPSnapshotPageStmt:                    q.getHTTPSnapshotPageStmt,
-		getHTTPSnapshotsByWorkspaceIDStmt:          q.getHTTPSnapshotsByWorkspaceIDStmt,
-		getHTTPStreamingMetricsStmt:                q.getHTTPStreamingMetricsStmt,
-		getHTTPWorkspaceActivityStmt:               q.getHTTPWorkspaceActivityStmt,
-		getHTTPWorkspaceIDStmt:                     q.getHTTPWorkspaceIDStmt,
-		getHTTPsByFolderIDStmt:                     q.getHTTPsByFolderIDStmt,
-		getHTTPsByIDsStmt:                          q.getHTTPsByIDsStmt,
-		getHTTPsByWorkspaceIDStmt:                  q.getHTTPsByWorkspaceIDStmt,
-		getHttpVersionsByHttpIDStmt:                q.getHttpVersionsByHttpIDStmt,
-		getLatestNodeExecutionByNodeIDStmt:         q.getLatestNodeExecutionByNodeIDStmt,
-		getLatestVersionByParentIDStmt:             q.getLatestVersionByParentIDStmt,
-		getMigrationStmt:                           q.getMigrationStmt,
-		getMigrationsStmt:                          q.getMigrationsStmt,
-		getNodeExecutionStmt:                       q.getNodeExecutionStmt,
-		getNodeExecutionsByNodeIDStmt:              q.getNodeExecutionsByNodeIDStmt,
-		getRootFilesByWorkspaceIDStmt:              q.getRootFilesByWorkspaceIDStmt,
-		getTagStmt:                                 q.getTagStmt,
-		getTagsByWorkspaceIDStmt:                   q.getTagsByWorkspaceIDStmt,
-		getUserStmt:                                q.getUserStmt,
-		getUserByEmailStmt:                         q.getUserByEmailStmt,
-		getUserByEmailAndProviderTypeStmt:          q.getUserByEmailAndProviderTypeStmt,
-		getUserByExternalIDStmt:                    q.getUserByExternalIDStmt,
-		getUserByProviderIDandTypeStmt:             q.getUserByProviderIDandTypeStmt,
-		getVariableStmt:                            q.getVariableStmt,
-		getVariablesByEnvironmentIDStmt:            q.getVariablesByEnvironmentIDStmt,
-		getVariablesByEnvironmentIDOrderedStmt:     q.getVariablesByEnvironmentIDOrderedStmt,
-		getWebSocketStmt:                           q.getWebSocketStmt,
-		getWebSocketHeaderByIDStmt:                 q.getWebSocketHeaderByIDStmt,
-		getWebSocketHeadersStmt:                    q.getWebSocketHeadersStmt,
-		getWebSocketWorkspaceIDStmt:                q.getWebSocketWorkspaceIDStmt,
-		getWebSocketsByWorkspaceIDStmt:             q.getWebSocketsByWorkspaceIDStmt,
-		getWorkspaceStmt:                           q.getWorkspaceStmt,
-		getWorkspaceByUserIDStmt:                   q.getWorkspaceByUserIDStmt,
-		getWorkspaceByUserIDandWorkspaceIDStmt:     q.getWorkspaceByUserIDandWorkspaceIDStmt,
-		getWorkspaceUserStmt:                       q.getWorkspaceUserStmt,
-		getWorkspaceUserByUserIDStmt:               q.getWorkspaceUserByUserIDStmt,
-		getWorkspaceUserByWorkspaceIDStmt:          q.getWorkspaceUserByWorkspaceIDStmt,
-		getWorkspaceUserByWorkspaceIDAndUserIDStmt: q.getWorkspaceUserByWorkspaceIDAndUserIDStmt,
-		getWorkspacesByUserIDStmt:                  q.getWorkspacesByUserIDStmt,
-		getWorkspacesByUserIDOrderedStmt:           q.getWorkspacesByUserIDOrderedStmt,
-		listNodeExecutionsStmt:                     q.listNodeExecutionsStmt,
-		listNodeExecutionsByFlowRunStmt:            q.listNodeExecutionsByFlowRunStmt,
-		listNodeExecutionsByStateStmt:              q.listNodeExecutionsByStateStmt,
-		resetHTTPBodyFormDeltaStmt:                 q.resetHTTPBodyFormDeltaStmt,
-		resolveHTTPWithDeltasStmt:                  q.resolveHTTPWithDeltasStmt,
-		updateCredentialStmt:                       q.updateCredentialStmt,
-		updateCredentialAnthropicStmt:              q.updateCredentialAnthropicStmt,
-		updateCredentialGeminiStmt:                 q.updateCredentialGeminiStmt,
-		updateCredentialOpenAIStmt:                 q.updateCredentialOpenAIStmt,
-		updateEnvironmentStmt:                      q.updateEnvironmentStmt,
-		updateFileStmt:                             q.updateFileStmt,
-		updateFlowStmt:                             q.updateFlowStmt,
-		updateFlowEdgeStmt:                         q.updateFlowEdgeStmt,
-		updateFlowEdgeStateStmt:                    q.updateFlowEdgeStateStmt,
-		updateFlowNodeStmt:                         q.updateFlowNodeStmt,
-		updateFlowNodeAIStmt:                       q.updateFlowNodeAIStmt,
-		updateFlowNodeAiProviderStmt:               q.updateFlowNodeAiProviderStmt,
-		updateFlowNodeConditionStmt:                q.updateFlowNodeConditionStmt,
-		updateFlowNodeForStmt:                      q.updateFlowNodeForStmt,
-		updateFlowNodeForEachStmt:                  q.updateFlowNodeForEachStmt,
-		updateFlowNodeGraphQLStmt:                  q.updateFlowNodeGraphQLStmt,
-		updateFlowNodeHTTPStmt:                     q.updateFlowNodeHTTPStmt,
-		updateFlowNodeIDMappingStmt:                q.updateFlowNodeIDMappingStmt,
-		updateFlowNodeJsStmt:                       q.updateFlowNodeJsStmt,
-		updateFlowNodeMemoryStmt:                   q.updateFlowNodeMemoryStmt,
-		updateFlowNodeRunSubFlowStmt:               q.updateFlowNodeRunSubFlowStmt,
-		updateFlowNodeStateStmt:                    q.updateFlowNodeStateStmt,
-		updateFlowNodeSubFlowReturnStmt:            q.updateFlowNodeSubFlowReturnStmt,
-		updateFlowNodeSubFlowTriggerStmt:           q.updateFlowNodeSubFlowTriggerStmt,
-		updateFlowNodeWaitStmt:                     q.updateFlowNodeWaitStmt,
-		updateFlowNodeWsConnectionStmt:             q.updateFlowNodeWsConnectionStmt,
-		updateFlowNodeWsSendStmt:                   q.updateFlowNodeWsSendStmt,
-		updateFlowVariableStmt:                     q.updateFlowVariableStmt,
-		updateFlowVariableOrderStmt:                q.updateFlowVariableOrderStmt,
-		updateGraphQLStmt:                          q.updateGraphQLStmt,
-		updateGraphQLAssertStmt:                    q.updateGraphQLAssertStmt,
-		updateGraphQLAssertDeltaStmt:               q.updateGraphQLAssertDeltaStmt,
-		updateGraphQLDeltaStmt:                     q.updateGraphQLDeltaStmt,
-		updateGraphQLHeaderStmt:                    q.updateGraphQLHeaderStmt,
-		updateGraphQLHeaderDeltaStmt:               q.updateGraphQLHeaderDeltaStmt,
-		updateHTTPStmt:                             q.updateHTTPStmt,
-		updateHTTPAssertStmt:                       q.updateHTTPAssertStmt,
-		updateHTTPAssertDeltaStmt:                  q.updateHTTPAssertDeltaStmt,
-		updateHTTPBodyFormStmt:                     q.updateHTTPBodyFormStmt,
-		updateHTTPBodyFormDeltaStmt:                q.updateHTTPBodyFormDeltaStmt,
-		updateHTTPBodyFormOrderStmt:                q.updateHTTPBodyFormOrderStmt,
-		updateHTTPBodyRawStmt:                      q.updateHTTPBodyRawStmt,
-		updateHTTPBodyRawDeltaStmt:                 q.updateHTTPBodyRawDeltaStmt,
-		updateHTTPBodyUrlEncodedStmt:               q.updateHTTPBodyUrlEncodedStmt,
-		updateHTTPBodyUrlEncodedDeltaStmt:          q.updateHTTPBodyUrlEncodedDeltaStmt,
-		updateHTTPDeltaStmt:                        q.updateHTTPDeltaStmt,
-		updateHTTPHeaderStmt:                       q.updateHTTPHeaderStmt,
-		updateHTTPHeaderDeltaStmt:                  q.updateHTTPHeaderDeltaStmt,
-		updateHTTPHeaderOrderStmt:                  q.updateHTTPHeaderOrderStmt,
-		updateHTTPResponseStmt:                     q.updateHTTPResponseStmt,
-		updateHTTPResponseAssertStmt:               q.updateHTTPResponseAssertStmt,
-		updateHTTPResponseHeaderStmt:               q.updateHTTPResponseHeaderStmt,
-		updateHTTPSearchParamStmt:                  q.updateHTTPSearchParamStmt,
-		updateHTTPSearchParamDeltaStmt:             q.updateHTTPSearchParamDeltaStmt,
-		updateHTTPSearchParamOrderStmt:             q.updateHTTPSearchParamOrderStmt,
-		updateNodeExecutionStmt:                    q.updateNodeExecutionStmt,
-		updateNodeExecutionNodeIDStmt:              q.updateNodeExecutionNodeIDStmt,
-		updateTagStmt:                              q.updateTagStmt,
-		updateUserStmt:                             q.updateUserStmt,
-		updateVariableStmt:                         q.updateVariableStmt,
-		updateWebSocketStmt:                        q.updateWebSocketStmt,
-		updateWebSocketHeaderStmt:                  q.updateWebSocketHeaderStmt,
-		updateWorkspaceStmt:                        q.updateWorkspaceStmt,
-		updateWorkspaceUpdatedTimeStmt:             q.updateWorkspaceUpdatedTimeStmt,
-		updateWorkspaceUserStmt:                    q.updateWorkspaceUserStmt,
-		upsertNodeExecutionStmt:                    q.upsertNodeExecutionStmt,
-		upsertVariableStmt:                         q.upsertVariableStmt,
+		db:                                             tx,
+		tx:                                             tx,
+		authCountUsersStmt:                             q.authCountUsersStmt,
+		authCreateAccountStmt:                          q.authCreateAccountStmt,
+		authCreateJwksStmt:                             q.authCreateJwksStmt,
+		authCreateSessionStmt:                          q.authCreateSessionStmt,
+		authCreateUserStmt:                             q.authCreateUserStmt,
+		authCreateVerificationStmt:                     q.authCreateVerificationStmt,
+		authDeleteAccountStmt:                          q.authDeleteAccountStmt,
+		authDeleteAccountsByUserStmt:                   q.authDeleteAccountsByUserStmt,
+		authDeleteExpiredSessionsStmt:                  q.authDeleteExpiredSessionsStmt,
+		authDeleteExpiredVerificationsStmt:             q.authDeleteExpiredVerificationsStmt,
+		authDeleteJwksStmt:                             q.authDeleteJwksStmt,
+		authDeleteSessionStmt:                          q.authDeleteSessionStmt,
+		authDeleteSessionByTokenStmt:                   q.authDeleteSessionByTokenStmt,
+		authDeleteSessionsByUserStmt:                   q.authDeleteSessionsByUserStmt,
+		authDeleteUserStmt:                             q.authDeleteUserStmt,
+		authDeleteVerificationStmt:                     q.authDeleteVerificationStmt,
+		authGetAccountStmt:                             q.authGetAccountStmt,
+		authGetAccountByProviderStmt:                   q.authGetAccountByProviderStmt,
+		authGetJwksStmt:                                q.authGetJwksStmt,
+		authGetSessionStmt:                             q.authGetSessionStmt,
+		authGetSessionByTokenStmt:                      q.authGetSessionByTokenStmt,
+		authGetUserStmt:                                q.authGetUserStmt,
+		authGetUserByEmailStmt:                         q.authGetUserByEmailStmt,
+		authGetVerificationStmt:                        q.authGetVerificationStmt,
+		authGetVerificationByIdentifierStmt:            q.authGetVerificationByIdentifierStmt,
+		authListAccountsByUserStmt:                     q.authListAccountsByUserStmt,
+		authListJwksStmt:                               q.authListJwksStmt,
+		authListSessionsByUserStmt:                     q.authListSessionsByUserStmt,
+		authUpdateAccountStmt:                          q.authUpdateAccountStmt,
+		authUpdateSessionStmt:                          q.authUpdateSessionStmt,
+		authUpdateUserStmt:                             q.authUpdateUserStmt,
+		checkIFWorkspaceUserExistsStmt:                 q.checkIFWorkspaceUserExistsStmt,
+		cleanupOrphanedFlowEdgesStmt:                   q.cleanupOrphanedFlowEdgesStmt,
+		cleanupOrphanedFlowNodeConditionStmt:           q.cleanupOrphanedFlowNodeConditionStmt,
+		cleanupOrphanedFlowNodeForStmt:                 q.cleanupOrphanedFlowNodeForStmt,
+		cleanupOrphanedFlowNodeForEachStmt:             q.cleanupOrphanedFlowNodeForEachStmt,
+		cleanupOrphanedFlowNodeGraphQLStmt:             q.cleanupOrphanedFlowNodeGraphQLStmt,
+		cleanupOrphanedFlowNodeGraphQLSubscriptionStmt: q.cleanupOrphanedFlowNodeGraphQLSubscriptionStmt,
+		cleanupOrphanedFlowNodeHttpStmt:                q.cleanupOrphanedFlowNodeHttpStmt,
+		cleanupOrphanedFlowNodeJsStmt:                  q.cleanupOrphanedFlowNodeJsStmt,
+		cleanupOrphanedFlowNodeParallelStmt:            q.cleanupOrphanedFlowNodeParallelStmt,
+		cleanupOrphanedFlowNodePollStmt:                q.cleanupOrphanedFlowNodePollStmt,
+		cleanupOrphanedFlowNodeRunSubFlowStmt:          q.cleanupOrphanedFlowNodeRunSubFlowStmt,
+		cleanupOrphanedFlowNodeSubFlowReturnStmt:       q.cleanupOrphanedFlowNodeSubFlowReturnStmt,
+		cleanupOrphanedFlowNodeSubFlowTriggerStmt:      q.cleanupOrphanedFlowNodeSubFlowTriggerStmt,
+		cleanupOrphanedFlowNodeSwitchStmt:              q.cleanupOrphanedFlowNodeSwitchStmt,
+		cleanupOrphanedFlowNodeWaitStmt:                q.cleanupOrphanedFlowNodeWaitStmt,
+		cleanupOrphanedFlowNodeWebhookTriggerStmt:      q.cleanupOrphanedFlowNodeWebhookTriggerStmt,
+		cleanupOrphanedNodeExecutionsStmt:              q.cleanupOrphanedNodeExecutionsStmt,
+		createCredentialStmt:                           q.createCredentialStmt,
+		createCredentialAnthropicStmt:                  q.createCredentialAnthropicStmt,
+		createCredentialDatabaseStmt:                   q.createCredentialDatabaseStmt,
+		createCredentialGeminiStmt:                     q.createCredentialGeminiStmt,
+		createCredentialMessageBrokerStmt:              q.createCredentialMessageBrokerStmt,
+		createCredentialOpenAIStmt:                     q.createCredentialOpenAIStmt,
+		createEnvironmentStmt:                          q.createEnvironmentStmt,
+		createFileStmt:                                 q.createFileStmt,
+		createFlowStmt:                                 q.createFlowStmt,
+		createFlowEdgeStmt:                             q.createFlowEdgeStmt,
+		createFlowNodeStmt:                             q.createFlowNodeStmt,
+		createFlowNodeAIStmt:                           q.createFlowNodeAIStmt,
+		createFlowNodeAiProviderStmt:                   q.createFlowNodeAiProviderStmt,
+		createFlowNodeConditionStmt:                    q.createFlowNodeConditionStmt,
+		createFlowNodeDbQueryStmt:                      q.createFlowNodeDbQueryStmt,
+		createFlowNodeExecStmt:                         q.createFlowNodeExecStmt,
+		createFlowNodeForStmt:                          q.createFlowNodeForStmt,
+		createFlowNodeForEachStmt:                      q.createFlowNodeForEachStmt,
+		createFlowNodeGraphQLStmt:                      q.createFlowNodeGraphQLStmt,
+		createFlowNodeGraphQLSubscriptionStmt:          q.createFlowNodeGraphQLSubscriptionStmt,
+		createFlowNodeHTTPStmt:                         q.createFlowNodeHTTPStmt,
+		createFlowNodeJsStmt:                           q.createFlowNodeJsStmt,
+		createFlowNodeMemoryStmt:                       q.createFlowNodeMemoryStmt,
+		createFlowNodeMqConsumeStmt:                    q.createFlowNodeMqConsumeStmt,
+		createFlowNodeMqPublishStmt:                    q.createFlowNodeMqPublishStmt,
+		createFlowNodeParallelStmt:                     q.createFlowNodeParallelStmt,
+		createFlowNodePollStmt:                         q.createFlowNodePollStmt,
+		createFlowNodeRunSubFlowStmt:                   q.createFlowNodeRunSubFlowStmt,
+		createFlowNodeSocketConnectionStmt:             q.createFlowNodeSocketConnectionStmt,
+		createFlowNodeSocketReceiveStmt:                q.createFlowNodeSocketReceiveStmt,
+		createFlowNodeSocketSendStmt:                   q.createFlowNodeSocketSendStmt,
+		createFlowNodeSubFlowReturnStmt:                q.createFlowNodeSubFlowReturnStmt,
+		createFlowNodeSubFlowTriggerStmt:               q.createFlowNodeSubFlowTriggerStmt,
+		createFlowNodeSwitchStmt:                       q.createFlowNodeSwitchStmt,
+		createFlowNodeWaitStmt:                         q.createFlowNodeWaitStmt,
+		createFlowNodeWebhookTriggerStmt:               q.createFlowNodeWebhookTriggerStmt,
+		createFlowNodeWithStateStmt:                    q.createFlowNodeWithStateStmt,
+		createFlowNodeWsConnectionStmt:                 q.createFlowNodeWsConnectionStmt,
+		createFlowNodeWsExpectStmt:                     q.createFlowNodeWsExpectStmt,
+		createFlowNodeWsSendStmt:                       q.createFlowNodeWsSendStmt,
+		createFlowNodesBulkStmt:                        q.createFlowNodesBulkStmt,
+		createFlowScheduleStmt:                         q.createFlowScheduleStmt,
+		createFlowScheduleRunStmt:                      q.createFlowScheduleRunStmt,
+		createFlowTagStmt:                              q.createFlowTagStmt,
+		createFlowVariableStmt:                         q.createFlowVariableStmt,
+		createFlowVariableBulkStmt:                     q.createFlowVariableBulkStmt,
+		createFlowsBulkStmt:                            q.createFlowsBulkStmt,
+		createGraphQLStmt:                              q.createGraphQLStmt,
+		createGraphQLAssertStmt:                        q.createGraphQLAssertStmt,
+		createGraphQLHeaderStmt:                        q.createGraphQLHeaderStmt,
+		createGraphQLResponseStmt:                      q.createGraphQLResponseStmt,
+		createGraphQLResponseAssertStmt:                q.createGraphQLResponseAssertStmt,
+		createGraphQLResponseHeaderStmt:                q.createGraphQLResponseHeaderStmt,
+		createGraphQLResponseHeaderBulkStmt:            q.createGraphQLResponseHeaderBulkStmt,
+		createGraphQLVersionStmt:                       q.createGraphQLVersionStmt,
+		createHTTPStmt:                                 q.createHTTPStmt,
+		createHTTPAssertStmt:                           q.createHTTPAssertStmt,
+		createHTTPAssertBulkStmt:                       q.createHTTPAssertBulkStmt,
+		createHTTPBodyFormStmt:                         q.createHTTPBodyFormStmt,
+		createHTTPBodyRawStmt:                          q.createHTTPBodyRawStmt,
+		createHTTPBodyUrlEncodedStmt:                   q.createHTTPBodyUrlEncodedStmt,
+		createHTTPBodyUrlEncodedBulkStmt:               q.createHTTPBodyUrlEncodedBulkStmt,
+		createHTTPHeaderStmt:                           q.createHTTPHeaderStmt,
+		createHTTPResponseStmt:                         q.createHTTPResponseStmt,
+		createHTTPResponseAssertStmt:                   q.createHTTPResponseAssertStmt,
+		createHTTPResponseAssertBulkStmt:               q.createHTTPResponseAssertBulkStmt,
+		createHTTPResponseBulkStmt:                     q.createHTTPResponseBulkStmt,
+		createHTTPResponseHeaderStmt:                   q.createHTTPResponseHeaderStmt,
+		createHTTPResponseHeaderBulkStmt:               q.createHTTPResponseHeaderBulkStmt,
+		createHTTPSearchParamStmt:                      q.createHTTPSearchParamStmt,
+		createHttpVersionStmt:                          q.createHttpVersionStmt,
+		createMigrationStmt:                            q.createMigrationStmt,
+		createNodeExecutionStmt:                        q.createNodeExecutionStmt,
+		createTagStmt:                                  q.createTagStmt,
+		createUserStmt:                                 q.createUserStmt,
+		createVariableStmt:                             q.createVariableStmt,
+		createVariableBulkStmt:                         q.createVariableBulkStmt,
+		createWebSocketStmt:                            q.createWebSocketStmt,
+		createWebSocketHeaderStmt:                      q.createWebSocketHeaderStmt,
+		createWebSocketMessageStmt:                     q.createWebSocketMessageStmt,
+		createWorkspaceStmt:                            q.createWorkspaceStmt,
+		createWorkspaceUserStmt:                        q.createWorkspaceUserStmt,
+		deleteCredentialStmt:                           q.deleteCredentialStmt,
+		deleteCredentialAnthropicStmt:                  q.deleteCredentialAnthropicStmt,
+		deleteCredentialDatabaseStmt:                   q.deleteCredentialDatabaseStmt,
+		deleteCredentialGeminiStmt:                     q.deleteCredentialGeminiStmt,
+		deleteCredentialMessageBrokerStmt:              q.deleteCredentialMessageBrokerStmt,
+		deleteCredentialOpenAIStmt:                     q.deleteCredentialOpenAIStmt,
+		deleteEnvironmentStmt:                          q.deleteEnvironmentStmt,
+		deleteFileStmt:                                 q.deleteFileStmt,
+		deleteFlowStmt:                                 q.deleteFlowStmt,
+		deleteFlowEdgeStmt:                             q.deleteFlowEdgeStmt,
+		deleteFlowNodeStmt:                             q.deleteFlowNodeStmt,
+		deleteFlowNodeAIStmt:                           q.deleteFlowNodeAIStmt,
+		deleteFlowNodeAiProviderStmt:                   q.deleteFlowNodeAiProviderStmt,
+		deleteFlowNodeConditionStmt:                    q.deleteFlowNodeConditionStmt,
+		deleteFlowNodeDbQueryStmt:                      q.deleteFlowNodeDbQueryStmt,
+		deleteFlowNodeExecStmt:                         q.deleteFlowNodeExecStmt,
+		deleteFlowNodeForStmt:                          q.deleteFlowNodeForStmt,
+		deleteFlowNodeForEachStmt:                      q.deleteFlowNodeForEachStmt,
+		deleteFlowNodeGraphQLStmt:                      q.deleteFlowNodeGraphQLStmt,
+		deleteFlowNodeGraphQLSubscriptionStmt:          q.deleteFlowNodeGraphQLSubscriptionStmt,
+		deleteFlowNodeHTTPStmt:                         q.deleteFlowNodeHTTPStmt,
+		deleteFlowNodeJsStmt:                           q.deleteFlowNodeJsStmt,
+		deleteFlowNodeMemoryStmt:                       q.deleteFlowNodeMemoryStmt,
+		deleteFlowNodeMqConsumeStmt:                    q.deleteFlowNodeMqConsumeStmt,
+		deleteFlowNodeMqPublishStmt:                    q.deleteFlowNodeMqPublishStmt,
+		deleteFlowNodeParallelStmt:                     q.deleteFlowNodeParallelStmt,
+		deleteFlowNodePollStmt:                         q.deleteFlowNodePollStmt,
+		deleteFlowNodeRunSubFlowStmt:                   q.deleteFlowNodeRunSubFlowStmt,
+		deleteFlowNodeSocketConnectionStmt:             q.deleteFlowNodeSocketConnectionStmt,
+		deleteFlowNodeSocketReceiveStmt:                q.deleteFlowNodeSocketReceiveStmt,
+		deleteFlowNodeSocketSendStmt:                   q.deleteFlowNodeSocketSendStmt,
+		deleteFlowNodeSubFlowReturnStmt:                q.deleteFlowNodeSubFlowReturnStmt,
+		deleteFlowNodeSubFlowTriggerStmt:               q.deleteFlowNodeSubFlowTriggerStmt,
+		deleteFlowNodeSwitchStmt:                       q.deleteFlowNodeSwitchStmt,
+		deleteFlowNodeWaitStmt:                         q.deleteFlowNodeWaitStmt,
+		deleteFlowNodeWebhookTriggerStmt:               q.deleteFlowNodeWebhookTriggerStmt,
+		deleteFlowNodeWsConnectionStmt:                 q.deleteFlowNodeWsConnectionStmt,
+		deleteFlowNodeWsExpectStmt:                     q.deleteFlowNodeWsExpectStmt,
+		deleteFlowNodeWsSendStmt:                       q.deleteFlowNodeWsSendStmt,
+		deleteFlowScheduleStmt:                         q.deleteFlowScheduleStmt,
+		deleteFlowTagStmt:                              q.deleteFlowTagStmt,
+		deleteFlowVariableStmt:                         q.deleteFlowVariableStmt,
+		deleteGraphQLStmt:                              q.deleteGraphQLStmt,
+		deleteGraphQLAssertStmt:                        q.deleteGraphQLAssertStmt,
+		deleteGraphQLHeaderStmt:                        q.deleteGraphQLHeaderStmt,
+		deleteGraphQLResponseStmt:                      q.deleteGraphQLResponseStmt,
+		deleteGraphQLResponseHeaderStmt:                q.deleteGraphQLResponseHeaderStmt,
+		deleteGraphQLSchemaStmt:                        q.deleteGraphQLSchemaStmt,
+		deleteHTTPStmt:                                 q.deleteHTTPStmt,
+		deleteHTTPAssertStmt:                           q.deleteHTTPAssertStmt,
+		deleteHTTPBodyFormStmt:                         q.deleteHTTPBodyFormStmt,
+		deleteHTTPBodyRawStmt:                          q.deleteHTTPBodyRawStmt,
+		deleteHTTPBodyUrlEncodedStmt:                   q.deleteHTTPBodyUrlEncodedStmt,
+		deleteHTTPHeaderStmt:                           q.deleteHTTPHeaderStmt,
+		deleteHTTPResponseStmt:                         q.deleteHTTPResponseStmt,
+		deleteHTTPResponseAssertStmt:                   q.deleteHTTPResponseAssertStmt,
+		deleteHTTPResponseHeaderStmt:                   q.deleteHTTPResponseHeaderStmt,
+		deleteHTTPSearchParamStmt:                      q.deleteHTTPSearchParamStmt,
+		deleteMigrationStmt:                            q.deleteMigrationStmt,
+		deleteNodeExecutionsByNodeIDStmt:               q.deleteNodeExecutionsByNodeIDStmt,
+		deleteNodeExecutionsByNodeIDsStmt:              q.deleteNodeExecutionsByNodeIDsStmt,
+		deleteTagStmt:                                  q.deleteTagStmt,
+		deleteUserStmt:                                 q.deleteUserStmt,
+		deleteVariableStmt:                             q.deleteVariableStmt,
+		deleteWebSocketStmt:                            q.deleteWebSocketStmt,
+		deleteWebSocketHeaderStmt:                      q.deleteWebSocketHeaderStmt,
+		deleteWebSocketHeadersByWebSocketIDStmt:        q.deleteWebSocketHeadersByWebSocketIDStmt,
+		deleteWebSocketMessagesByWebSocketIDStmt:       q.deleteWebSocketMessagesByWebSocketIDStmt,
+		deleteWorkspaceStmt:                            q.deleteWorkspaceStmt,
+		deleteWorkspaceUserStmt:                        q.deleteWorkspaceUserStmt,
+		findFileByPathHashStmt:                         q.findFileByPathHashStmt,
+		findHTTPByContentHashStmt:                      q.findHTTPByContentHashStmt,
+		findHTTPByURLAndMethodStmt:                     q.findHTTPByURLAndMethodStmt,
+		getAllFlowsByWorkspaceIDStmt:                   q.getAllFlowsByWorkspaceIDStmt,
+		getAllWorkspacesByUserIDStmt:                   q.getAllWorkspacesByUserIDStmt,
+		getCredentialStmt:                              q.getCredentialStmt,
+		getCredentialAnthropicStmt:                     q.getCredentialAnthropicStmt,
+		getCredentialDatabaseStmt:                      q.getCredentialDatabaseStmt,
+		getCredentialGeminiStmt:                        q.getCredentialGeminiStmt,
+		getCredentialMessageBrokerStmt:                 q.getCredentialMessageBrokerStmt,
+		getCredentialOpenAIStmt:                        q.getCredentialOpenAIStmt,
+		getCredentialsByWorkspaceIDStmt:                q.getCredentialsByWorkspaceIDStmt,
+		getEnabledFlowSchedulesStmt:                    q.getEnabledFlowSchedulesStmt,
+		getEnvironmentStmt:                             q.getEnvironmentStmt,
+		getEnvironmentWorkspaceIDStmt:                  q.getEnvironmentWorkspaceIDStmt,
+		getEnvironmentsByWorkspaceIDStmt:               q.getEnvironmentsByWorkspaceIDStmt,
+		getEnvironmentsByWorkspaceIDOrderedStmt:        q.getEnvironmentsByWorkspaceIDOrderedStmt,
+		getFileStmt:                                    q.getFileStmt,
+		getFileByContentIDStmt:                         q.getFileByContentIDStmt,
+		getFileWithContentStmt:                         q.getFileWithContentStmt,
+		getFileWorkspaceIDStmt:                         q.getFileWorkspaceIDStmt,
+		getFilesByContentIDsStmt:                       q.getFilesByContentIDsStmt,
+		getFilesByParentIDStmt:                         q.getFilesByParentIDStmt,
+		getFilesByParentIDOrderedStmt:                  q.getFilesByParentIDOrderedStmt,
+		getFilesByWorkspaceIDStmt:                      q.getFilesByWorkspaceIDStmt,
+		getFilesByWorkspaceIDOrderedStmt:               q.getFilesByWorkspaceIDOrderedStmt,
+		getFlowStmt:                                    q.getFlowStmt,
+		getFlowContentStmt:                             q.getFlowContentStmt,
+		getFlowEdgeStmt:                                q.getFlowEdgeStmt,
+		getFlowEdgesByFlowIDStmt:                       q.getFlowEdgesByFlowIDStmt,
+		getFlowEdgesByFlowIDsStmt:                      q.getFlowEdgesByFlowIDsStmt,
+		getFlowEdgesBySourceNodeIDsStmt:                q.getFlowEdgesBySourceNodeIDsStmt,
+		getFlowEdgesByTargetNodeIDsStmt:                q.getFlowEdgesByTargetNodeIDsStmt,
+		getFlowNodeStmt:                                q.getFlowNodeStmt,
+		getFlowNodeAIStmt:                              q.getFlowNodeAIStmt,
+		getFlowNodeAiProviderStmt:                      q.getFlowNodeAiProviderStmt,
+		getFlowNodeConditionStmt:                       q.getFlowNodeConditionStmt,
+		getFlowNodeDbQueryStmt:                         q.getFlowNodeDbQueryStmt,
+		getFlowNodeExecStmt:                            q.getFlowNodeExecStmt,
+		getFlowNodeForStmt:                             q.getFlowNodeForStmt,
+		getFlowNodeForEachStmt:                         q.getFlowNodeForEachStmt,
+		getFlowNodeGraphQLStmt:                         q.getFlowNodeGraphQLStmt,
+		getFlowNodeGraphQLSubscriptionStmt:             q.getFlowNodeGraphQLSubscriptionStmt,
+		getFlowNodeHTTPStmt:                            q.getFlowNodeHTTPStmt,
+		getFlowNodeJsStmt:                              q.getFlowNodeJsStmt,
+		getFlowNodeMemoryStmt:                          q.getFlowNodeMemoryStmt,
+		getFlowNodeMqConsumeStmt:                       q.getFlowNodeMqConsumeStmt,
+		getFlowNodeMqPublishStmt:                       q.getFlowNodeMqPublishStmt,
+		getFlowNodeParallelStmt:                        q.getFlowNodeParallelStmt,
+		getFlowNodePollStmt:                            q.getFlowNodePollStmt,
+		getFlowNodeRunSubFlowStmt:                      q.getFlowNodeRunSubFlowStmt,
+		getFlowNodeSocketConnectionStmt:                q.getFlowNodeSocketConnectionStmt,
+		getFlowNodeSocketReceiveStmt:                   q.getFlowNodeSocketReceiveStmt,
+		getFlowNodeSocketSendStmt:                      q.getFlowNodeSocketSendStmt,
+		getFlowNodeSubFlowReturnStmt:                   q.getFlowNodeSubFlowReturnStmt,
+		getFlowNodeSubFlowTriggerStmt:                  q.getFlowNodeSubFlowTriggerStmt,
+		getFlowNodeSwitchStmt:                          q.getFlowNodeSwitchStmt,
+		getFlowNodeWaitStmt:                            q.getFlowNodeWaitStmt,
+		getFlowNodeWebhookTriggerStmt:                  q.getFlowNodeWebhookTriggerStmt,
+		getFlowNodeWebhookTriggersByPathStmt:           q.getFlowNodeWebhookTriggersByPathStmt,
+		getFlowNodeWsConnectionStmt:                    q.getFlowNodeWsConnectionStmt,
+		getFlowNodeWsExpectStmt:                        q.getFlowNodeWsExpectStmt,
+		getFlowNodeWsSendStmt:                          q.getFlowNodeWsSendStmt,
+		getFlowNodesByFlowIDStmt:                       q.getFlowNodesByFlowIDStmt,
+		getFlowNodesByFlowIDsStmt:                      q.getFlowNodesByFlowIDsStmt,
+		getFlowScheduleStmt:                            q.getFlowScheduleStmt,
+		getFlowScheduleRunsByScheduleIDStmt:            q.getFlowScheduleRunsByScheduleIDStmt,
+		getFlowSchedulesByFlowIDStmt:                   q.getFlowSchedulesByFlowIDStmt,
+		getFlowTagStmt:                                 q.getFlowTagStmt,
+		getFlowTagsByFlowIDStmt:                        q.getFlowTagsByFlowIDStmt,
+		getFlowTagsByTagIDStmt:                         q.getFlowTagsByTagIDStmt,
+		getFlowVariableStmt:                            q.getFlowVariableStmt,
+		getFlowVariablesByFlowIDStmt:                   q.getFlowVariablesByFlowIDStmt,
+		getFlowVariablesByFlowIDOrderedStmt:            q.getFlowVariablesByFlowIDOrderedStmt,
+		getFlowVariablesByFlowIDsStmt:                  q.getFlowVariablesByFlowIDsStmt,
+		getFlowsByVersionParentIDStmt:                  q.getFlowsByVersionParentIDStmt,
+		getFlowsByWorkspaceIDStmt:                      q.getFlowsByWorkspaceIDStmt,
+		getGraphQLStmt:                                 q.getGraphQLStmt,
+		getGraphQLAssertStmt:                           q.getGraphQLAssertStmt,
+		getGraphQLAssertDeltasByParentIDStmt:           q.getGraphQLAssertDeltasByParentIDStmt,
+		getGraphQLAssertDeltasByWorkspaceIDStmt:        q.getGraphQLAssertDeltasByWorkspaceIDStmt,
+		getGraphQLAssertsByGraphQLIDStmt:               q.getGraphQLAssertsByGraphQLIDStmt,
+		getGraphQLAssertsByIDsStmt:                     q.getGraphQLAssertsByIDsStmt,
+		getGraphQLDeltasByParentIDStmt:                 q.getGraphQLDeltasByParentIDStmt,
+		getGraphQLDeltasByWorkspaceIDStmt:              q.getGraphQLDeltasByWorkspaceIDStmt,
+		getGraphQLHeaderDeltasByParentIDStmt:           q.getGraphQLHeaderDeltasByParentIDStmt,
+		getGraphQLHeaderDeltasByWorkspaceIDStmt:        q.getGraphQLHeaderDeltasByWorkspaceIDStmt,
+		getGraphQLHeadersStmt:                          q.getGraphQLHeadersStmt,
+		getGraphQLHeadersByIDsStmt:                     q.getGraphQLHeadersByIDsStmt,
+		getGraphQLResponseStmt:                         q.getGraphQLResponseStmt,
+		getGraphQLResponseAssertsByResponseIDStmt:      q.getGraphQLResponseAssertsByResponseIDStmt,
+		getGraphQLResponseAssertsByWorkspaceIDStmt:     q.getGraphQLResponseAssertsByWorkspaceIDStmt,
+		getGraphQLResponseHeadersByResponseIDStmt:      q.getGraphQLResponseHeadersByResponseIDStmt,
+		getGraphQLResponseHeadersByWorkspaceIDStmt:     q.getGraphQLResponseHeadersByWorkspaceIDStmt,
+		getGraphQLResponsesByGraphQLIDStmt:             q.getGraphQLResponsesByGraphQLIDStmt,
+		getGraphQLResponsesByWorkspaceIDStmt:           q.getGraphQLResponsesByWorkspaceIDStmt,
+		getGraphQLSchemaStmt:                           q.getGraphQLSchemaStmt,
+		getGraphQLVersionsByGraphQLIDStmt:              q.getGraphQLVersionsByGraphQLIDStmt,
+		getGraphQLWorkspaceIDStmt:                      q.getGraphQLWorkspaceIDStmt,
+		getGraphQLsByWorkspaceIDStmt:                   q.getGraphQLsByWorkspaceIDStmt,
+		getHTTPStmt:                                    q.getHTTPStmt,
+		getHTTPAssertStmt:                              q.getHTTPAssertStmt,
+		getHTTPAssertsByHttpIDStmt:                     q.getHTTPAssertsByHttpIDStmt,
+		getHTTPAssertsByHttpIDsStmt:                    q.getHTTPAssertsByHttpIDsStmt,
+		getHTTPAssertsByIDsStmt:                        q.getHTTPAssertsByIDsStmt,
+		getHTTPBatchForStreamingStmt:                   q.getHTTPBatchForStreamingStmt,
+		getHTTPBodyFormStreamingStmt:                   q.getHTTPBodyFormStreamingStmt,
+		getHTTPBodyFormsStmt:                           q.getHTTPBodyFormsStmt,
+		getHTTPBodyFormsByHttpIDsStmt:                  q.getHTTPBodyFormsByHttpIDsStmt,
+		getHTTPBodyFormsByIDsStmt:                      q.getHTTPBodyFormsByIDsStmt,
+		getHTTPBodyRawStmt:                             q.getHTTPBodyRawStmt,
+		getHTTPBodyRawByIDStmt:                         q.getHTTPBodyRawByIDStmt,
+		getHTTPBodyRawsByHttpIDsStmt:                   q.getHTTPBodyRawsByHttpIDsStmt,
+		getHTTPBodyUrlEncodedStmt:                      q.getHTTPBodyUrlEncodedStmt,
+		getHTTPBodyUrlEncodedByHttpIDStmt:              q.getHTTPBodyUrlEncodedByHttpIDStmt,
+		getHTTPBodyUrlEncodedsByIDsStmt:                q.getHTTPBodyUrlEncodedsByIDsStmt,
+		getHTTPBodyUrlencodedsByHttpIDsStmt:            q.getHTTPBodyUrlencodedsByHttpIDsStmt,
+		getHTTPDeltasByParentIDStmt:                    q.getHTTPDeltasByParentIDStmt,
+		getHTTPDeltasByWorkspaceIDStmt:                 q.getHTTPDeltasByWorkspaceIDStmt,
+		getHTTPDeltasSinceStmt:                         q.getHTTPDeltasSinceStmt,
+		getHTTPHeadersStmt:                             q.getHTTPHeadersStmt,
+		getHTTPHeadersByHttpIDsStmt:                    q.getHTTPHeadersByHttpIDsStmt,
+		getHTTPHeadersByIDsStmt:                        q.getHTTPHeadersByIDsStmt,
+		getHTTPHeadersStreamingStmt:                    q.getHTTPHeadersStreamingStmt,
+		getHTTPIncrementalUpdatesStmt:                  q.getHTTPIncrementalUpdatesStmt,
+		getHTTPResponseStmt:                            q.getHTTPResponseStmt,
+		getHTTPResponseAssertStmt:                      q.getHTTPResponseAssertStmt,
+		getHTTPResponseAssertsByHttpIDStmt:             q.getHTTPResponseAssertsByHttpIDStmt,
+		getHTTPResponseAssertsByIDsStmt:                q.getHTTPResponseAssertsByIDsStmt,
+		getHTTPResponseAssertsByResponseIDStmt:         q.getHTTPResponseAssertsByResponseIDStmt,
+		getHTTPResponseAssertsByWorkspaceIDStmt:        q.getHTTPResponseAssertsByWorkspaceIDStmt,
+		getHTTPResponseHeaderStmt:                      q.getHTTPResponseHeaderStmt,
+		getHTTPResponseHeadersByHttpIDStmt:             q.getHTTPResponseHeadersByHttpIDStmt,
+		getHTTPResponseHeadersByIDsStmt:                q.getHTTPResponseHeadersByIDsStmt,
+		getHTTPResponseHeadersByResponseIDStmt:         q.getHTTPResponseHeadersByResponseIDStmt,
+		getHTTPResponseHeadersByWorkspaceIDStmt:        q.getHTTPResponseHeadersByWorkspaceIDStmt,
+		getHTTPResponsesByHttpIDStmt:                   q.getHTTPResponsesByHttpIDStmt,
+		getHTTPResponsesByIDsStmt:                      q.getHTTPResponsesByIDsStmt,
+		getHTTPResponsesByWorkspaceIDStmt:              q.getHTTPResponsesByWorkspaceIDStmt,
+		getHTTPSearchParamsStmt:                        q.getHTTPSearchParamsStmt,
+		getHTTPSearchParamsByHttpIDsStmt:               q.getHTTPSearchParamsByHttpIDsStmt,
+		getHTTPSearchParamsByIDsStmt:                   q.getHTTPSearchParamsByIDsStmt,
+		getHTTPSearchParamsStreamingStmt:               q.getHTTPSearchParamsStreamingStmt,
+		getHTTPSnapshotCountStmt:                       q.getHTTPSnapshotCountStmt,
+		getHTTPSnapshotPageStmt:                        q.getHTTPSnapshotPageStmt,
+		getHTTPSnapshotsByWorkspaceIDStmt:              q.getHTTPSnapshotsByWorkspaceIDStmt,
+		getHTTPStreamingMetricsStmt:                    q.getHTTPStreamingMetricsStmt,
+		getHTTPWorkspaceActivityStmt:                   q.getHTTPWorkspaceActivityStmt,
+		getHTTPWorkspaceIDStmt:                         q.getHTTPWorkspaceIDStmt,
+		getHTTPsByFolderIDStmt:                         q.getHTTPsByFolderIDStmt,
+		getHTTPsByIDsStmt:                              q.getHTTPsByIDsStmt,
+		getHTTPsByWorkspaceIDStmt:                      q.getHTTPsByWorkspaceIDStmt,
+		getHttpVersionsByHttpIDStmt:                    q.getHttpVersionsByHttpIDStmt,
+		getLatestNodeExecutionByNodeIDStmt:             q.getLatestNodeExecutionByNodeIDStmt,
+		getLatestVersionByParentIDStmt:                 q.getLatestVersionByParentIDStmt,
+		getMigrationStmt:                               q.getMigrationStmt,
+		getMigrationsStmt:                              q.getMigrationsStmt,
+		getNodeExecutionStmt:                           q.getNodeExecutionStmt,
+		getNodeExecutionsByNodeIDStmt:                  q.getNodeExecutionsByNodeIDStmt,
+		getRootFilesByWorkspaceIDStmt:                  q.getRootFilesByWorkspaceIDStmt,
+		getTagStmt:                                     q.getTagStmt,
+		getTagsByWorkspaceIDStmt:                       q.getTagsByWorkspaceIDStmt,
+		getUserStmt:                                    q.getUserStmt,
+		getUserByEmailStmt:                             q.getUserByEmailStmt,
+		getUserByEmailAndProviderTypeStmt:              q.getUserByEmailAndProviderTypeStmt,
+		getUserByExternalIDStmt:                        q.getUserByExternalIDStmt,
+		getUserByProviderIDandTypeStmt:                 q.getUserByProviderIDandTypeStmt,
+		getVariableStmt:                                q.getVariableStmt,
+		getVariablesByEnvironmentIDStmt:                q.getVariablesByEnvironmentIDStmt,
+		getVariablesByEnvironmentIDOrderedStmt:         q.getVariablesByEnvironmentIDOrderedStmt,
+		getWebSocketStmt:                               q.getWebSocketStmt,
+		getWebSocketHeaderByIDStmt:                     q.getWebSocketHeaderByIDStmt,
+		getWebSocketHeadersStmt:                        q.getWebSocketHeadersStmt,
+		getWebSocketMessagesStmt:                       q.getWebSocketMessagesStmt,
+		getWebSocketWorkspaceIDStmt:                    q.getWebSocketWorkspaceIDStmt,
+		getWebSocketsByWorkspaceIDStmt:                 q.getWebSocketsByWorkspaceIDStmt,
+		getWorkspaceStmt:                               q.getWorkspaceStmt,
+		getWorkspaceByUserIDStmt:                       q.getWorkspaceByUserIDStmt,
+		getWorkspaceByUserIDandWorkspaceIDStmt:         q.getWorkspaceByUserIDandWorkspaceIDStmt,
+		getWorkspaceUserStmt:                           q.getWorkspaceUserStmt,
+		getWorkspaceUserByUserIDStmt:                   q.getWorkspaceUserByUserIDStmt,
+		getWorkspaceUserByWorkspaceIDStmt:              q.getWorkspaceUserByWorkspaceIDStmt,
+		getWorkspaceUserByWorkspaceIDAndUserIDStmt:     q.getWorkspaceUserByWorkspaceIDAndUserIDStmt,
+		getWorkspacesByUserIDStmt:                      q.getWorkspacesByUserIDStmt,
+		getWorkspacesByUserIDOrderedStmt:               q.getWorkspacesByUserIDOrderedStmt,
+		listNodeExecutionsStmt:                         q.listNodeExecutionsStmt,
+		listNodeExecutionsByFlowRunStmt:                q.listNodeExecutionsByFlowRunStmt,
+		listNodeExecutionsByStateStmt:                  q.listNodeExecutionsByStateStmt,
+		pruneFlowScheduleRunsStmt:                      q.pruneFlowScheduleRunsStmt,
+		resetHTTPBodyFormDeltaStmt:                     q.resetHTTPBodyFormDeltaStmt,
+		resolveHTTPWithDeltasStmt:                      q.resolveHTTPWithDeltasStmt,
+		updateCredentialStmt:                           q.updateCredentialStmt,
+		updateCredentialAnthropicStmt:                  q.updateCredentialAnthropicStmt,
+		updateCredentialDatabaseStmt:                   q.updateCredentialDatabaseStmt,
+		updateCredentialGeminiStmt:                     q.updateCredentialGeminiStmt,
+		updateCredentialMessageBrokerStmt:              q.updateCredentialMessageBrokerStmt,
+		updateCredentialOpenAIStmt:                     q.updateCredentialOpenAIStmt,
+		updateEnvironmentStmt:                          q.updateEnvironmentStmt,
+		updateFileStmt:                                 q.updateFileStmt,
+		updateFlowStmt:                                 q.updateFlowStmt,
+		updateFlowEdgeStmt:                             q.updateFlowEdgeStmt,
+		updateFlowEdgeStateStmt:                        q.updateFlowEdgeStateStmt,
+		updateFlowNodeStmt:                             q.updateFlowNodeStmt,
+		updateFlowNodeAIStmt:                           q.updateFlowNodeAIStmt,
+		updateFlowNodeAiProviderStmt:                   q.updateFlowNodeAiProviderStmt,
+		updateFlowNodeConditionStmt:                    q.updateFlowNodeConditionStmt,
+		updateFlowNodeDbQueryStmt:                      q.updateFlowNodeDbQueryStmt,
+		updateFlowNodeExecStmt:                         q.updateFlowNodeExecStmt,
+		updateFlowNodeForStmt:                          q.updateFlowNodeForStmt,
+		updateFlowNodeForEachStmt:                      q.updateFlowNodeForEachStmt,
+		updateFlowNodeGraphQLStmt:                      q.updateFlowNodeGraphQLStmt,
+		updateFlowNodeGraphQLSubscriptionStmt:          q.updateFlowNodeGraphQLSubscriptionStmt,
+		updateFlowNodeHTTPStmt:                         q.updateFlowNodeHTTPStmt,
+		updateFlowNodeIDMappingStmt:                    q.updateFlowNodeIDMappingStmt,
+		updateFlowNodeJsStmt:                           q.updateFlowNodeJsStmt,
+		updateFlowNodeMemoryStmt:                       q.updateFlowNodeMemoryStmt,
+		updateFlowNodeMqConsumeStmt:                    q.updateFlowNodeMqConsumeStmt,
+		updateFlowNodeMqPublishStmt:                    q.updateFlowNodeMqPublishStmt,
+		updateFlowNodeParallelStmt:                     q.updateFlowNodeParallelStmt,
+		updateFlowNodePollStmt:                         q.updateFlowNodePollStmt,
+		updateFlowNodeRunSubFlowStmt:                   q.updateFlowNodeRunSubFlowStmt,
+		updateFlowNodeSocketConnectionStmt:             q.updateFlowNodeSocketConnectionStmt,
+		updateFlowNodeSocketReceiveStmt:                q.updateFlowNodeSocketReceiveStmt,
+		updateFlowNodeSocketSendStmt:                   q.updateFlowNodeSocketSendStmt,
+		updateFlowNodeStateStmt:                        q.updateFlowNodeStateStmt,
+		updateFlowNodeSubFlowReturnStmt:                q.updateFlowNodeSubFlowReturnStmt,
+		updateFlowNodeSubFlowTriggerStmt:               q.updateFlowNodeSubFlowTriggerStmt,
+		updateFlowNodeSwitchStmt:                       q.updateFlowNodeSwitchStmt,
+		updateFlowNodeWaitStmt:                         q.updateFlowNodeWaitStmt,
+		updateFlowNodeWebhookTriggerStmt:               q.updateFlowNodeWebhookTriggerStmt,
+		updateFlowNodeWsConnectionStmt:                 q.updateFlowNodeWsConnectionStmt,
+		updateFlowNodeWsExpectStmt:                     q.updateFlowNodeWsExpectStmt,
+		updateFlowNodeWsSendStmt:                       q.updateFlowNodeWsSendStmt,
+		updateFlowScheduleStmt:                         q.updateFlowScheduleStmt,
+		updateFlowScheduleLastRunStmt:                  q.updateFlowScheduleLastRunStmt,
+		updateFlowVariableStmt:                         q.updateFlowVariableStmt,
+		updateFlowVariableOrderStmt:                    q.updateFlowVariableOrderStmt,
+		updateGraphQLStmt:                              q.updateGraphQLStmt,
+		updateGraphQLAssertStmt:                        q.updateGraphQLAssertStmt,
+		updateGraphQLAssertDeltaStmt:                   q.updateGraphQLAssertDeltaStmt,
+		updateGraphQLDeltaStmt:                         q.updateGraphQLDeltaStmt,
+		updateGraphQLHeaderStmt:                        q.updateGraphQLHeaderStmt,
+		updateGraphQLHeaderDeltaStmt:                   q.updateGraphQLHeaderDeltaStmt,
+		updateHTTPStmt:                                 q.updateHTTPStmt,
+		updateHTTPAssertStmt:                           q.updateHTTPAssertStmt,
+		updateHTTPAssertDeltaStmt:                      q.updateHTTPAssertDeltaStmt,
+		updateHTTPBodyFormStmt:                         q.updateHTTPBodyFormStmt,
+		updateHTTPBodyFormDeltaStmt:                    q.updateHTTPBodyFormDeltaStmt,
+		updateHTTPBodyFormOrderStmt:                    q.updateHTTPBodyFormOrderStmt,
+		updateHTTPBodyRawStmt:                          q.updateHTTPBodyRawStmt,
+		updateHTTPBodyRawDeltaStmt:                     q.updateHTTPBodyRawDeltaStmt,
+		updateHTTPBodyUrlEncodedStmt:                   q.updateHTTPBodyUrlEncodedStmt,
+		updateHTTPBodyUrlEncodedDeltaStmt:              q.updateHTTPBodyUrlEncodedDeltaStmt,
+		updateHTTPDeltaStmt:                            q.updateHTTPDeltaStmt,
+		updateHTTPHeaderStmt:                           q.updateHTTPHeaderStmt,
+		updateHTTPHeaderDeltaStmt:                      q.updateHTTPHeaderDeltaStmt,
+		updateHTTPHeaderOrderStmt:                      q.updateHTTPHeaderOrderStmt,
+		updateHTTPResponseStmt:                         q.updateHTTPResponseStmt,
+		updateHTTPResponseAssertStmt:                   q.updateHTTPResponseAssertStmt,
+		updateHTTPResponseHeaderStmt:                   q.updateHTTPResponseHeaderStmt,
+		updateHTTPSearchParamStmt:                      q.updateHTTPSearchParamStmt,
+		updateHTTPSearchParamDeltaStmt:                 q.updateHTTPSearchParamDeltaStmt,
+		updateHTTPSearchParamOrderStmt:                 q.updateHTTPSearchParamOrderStmt,
+		updateNodeExecutionStmt:                        q.updateNodeExecutionStmt,
+		updateNodeExecutionNodeIDStmt:                  q.updateNodeExecutionNodeIDStmt,
+		updateTagStmt:                                  q.updateTagStmt,
+		updateUserStmt:                                 q.updateUserStmt,
+		updateVariableStmt:                             q.updateVariableStmt,
+		updateWebSocketStmt:                            q.updateWebSocketStmt,
+		updateWebSocketHeaderStmt:                      q.updateWebSocketHeaderStmt,
+		updateWorkspaceStmt:                            q.updateWorkspaceStmt,
+		updateWorkspaceUpdatedTimeStmt:                 q.updateWorkspaceUpdatedTimeStmt,
+		updateWorkspaceUserStmt:                        q.updateWorkspaceUserStmt,
+		upsertGraphQLSchemaStmt:                        q.upsertGraphQLSchemaStmt,
+		upsertNodeExecutionStmt:                        q.upsertNodeExecutionStmt,
+		upsertVariableStmt:                             q.upsertVariableStmt,
 	}
 }
@@ -61,6 +61,15 @@ func (q *Queries) CleanupOrphanedFlowNodeGraphQL(ctx context.Context) error {
 	return err
 }
 
+const cleanupOrphanedFlowNodeGraphQLSubscription = `-- name: CleanupOrphanedFlowNodeGraphQLSubscription :exec
+DELETE FROM flow_node_graphql_subscription WHERE flow_node_id NOT IN (SELECT id FROM flow_node)
+`
+
+func (q *Queries) CleanupOrphanedFlowNodeGraphQLSubscription(ctx context.Context) error {
+	_, err := q.exec(ctx, q.cleanupOrphanedFlowNodeGraphQLSubscriptionStmt, cleanupOrphanedFlowNodeGraphQLSubscription)
+	return err
+}
+
 const cleanupOrphanedFlowNodeHttp = `-- name: CleanupOrphanedFlowNodeHttp :exec
 DELETE FROM flow_node_http WHERE flow_node_id NOT IN (SELECT id FROM flow_node)
 `
@@ -79,6 +88,24 @@ func (q *Queries) CleanupOrphanedFlowNodeJs(ctx context.Context) error {
 	return err
 }
 
+const cleanupOrphanedFlowNodeParallel = `-- name: CleanupOrphanedFlowNodeParallel :exec
+DELETE FROM flow_node_parallel WHERE flow_node_id NOT IN (SELECT id FROM flow_node)
+`
+
+func (q *Queries) CleanupOrphanedFlowNodeParallel(ctx context.Context) error {
+	_, err := q.exec(ctx, q.cleanupOrphanedFlowNodeParallelStmt, cleanupOrphanedFlowNodeParallel)
+	return err
+}
+
+const cleanupOrphanedFlowNodePoll = `-- name: CleanupOrphanedFlowNodePoll :exec
+DELETE FROM flow_node_poll WHERE flow_node_id NOT IN (SELECT id FROM flow_node)
+`
+
+func (q *Queries) CleanupOrphanedFlowNodePoll(ctx context.Context) error {
+	_, err := q.exec(ctx, q.cleanupOrphanedFlowNodePollStmt, cleanupOrphanedFlowNodePoll)
+	return err
+}
+
 const cleanupOrphanedFlowNodeRunSubFlow = `-- name: CleanupOrphanedFlowNodeRunSubFlow :exec
 DELETE FROM flow_node_run_sub_flow WHERE flow_node_id NOT IN (SELECT id FROM flow_node)
 `
@@ -106,6 +133,15 @@ func (q *Queries) CleanupOrphanedFlowNodeSubFlowTrigger(ctx context.Context) err
 	return err
 }
 
+const cleanupOrphanedFlowNodeSwitch = `-- name: CleanupOrphanedFlowNodeSwitch :exec
+DELETE FROM flow_node_switch WHERE flow_node_id NOT IN (SELECT id FROM flow_node)
+`
+
+func (q *Queries) CleanupOrphanedFlowNodeSwitch(ctx context.Context) error {
+	_, err := q.exec(ctx, q.cleanupOrphanedFlowNodeSwitchStmt, cleanupOrphanedFlowNodeSwitch)
+	return err
+}
+
 const cleanupOrphanedFlowNodeWait = `-- name: CleanupOrphanedFlowNodeWait :exec
 DELETE FROM flow_node_wait WHERE flow_node_id NOT IN (SELECT id FROM flow_node)
 `
@@ -115,6 +151,15 @@ func (q *Queries) CleanupOrphanedFlowNodeWait(ctx context.Context) error {
 	return err
 }
 
+const cleanupOrphanedFlowNodeWebhookTrigger = `-- name: CleanupOrphanedFlowNodeWebhookTrigger :exec
+DELETE FROM flow_node_webhook_trigger WHERE flow_node_id NOT IN (SELECT id FROM flow_node)
+`
+
+func (q *Queries) CleanupOrphanedFlowNodeWebhookTrigger(ctx context.Context) error {
+	_, err := q.exec(ctx, q.cleanupOrphanedFlowNodeWebhookTriggerStmt, cleanupOrphanedFlowNodeWebhookTrigger)
+	return err
+}
+
 const cleanupOrphanedNodeExecutions = `-- name: CleanupOrphanedNodeExecutions :exec
 DELETE FROM node_execution WHERE node_id NOT IN (SELECT id FROM flow_node)
 `
@@ -253,9 +298,9 @@ func (q *Queries) CreateFlowNodeFor(ctx context.Context, arg CreateFlowNodeForPa
 
 const createFlowNodeForEach = `-- name: CreateFlowNodeForEach :exec
 INSERT INTO
-  flow_node_for_each (flow_node_id, iter_expression, error_handling, expression)
+  flow_node_for_each (flow_node_id, iter_expression, error_handling, expression, concurrency)
 VALUES
-  (?, ?, ?, ?)
+  (?, ?, ?, ?, ?)
 `
 
 type CreateFlowNodeForEachParams struct {
@@ -263,6 +308,7 @@ type CreateFlowNodeForEachParams struct {
 	IterExpression string
 	ErrorHandling  int8
 	Expression     string
+	Concurrency    int32
 }
 
 func (q *Queries) CreateFlowNodeForEach(ctx context.Context, arg CreateFlowNodeForEachParams) error {
@@ -271,6 +317,7 @@ func (q *Queries) CreateFlowNodeForEach(ctx context.Context, arg CreateFlowNodeF
 		arg.IterExpression,
 		arg.ErrorHandling,
 		arg.Expression,
+		arg.Concurrency,
 	)
 	return err
 }
@@ -290,6 +337,34 @@ func (q *Queries) CreateFlowNodeGraphQL(ctx context.Context, arg CreateFlowNodeG
 	return err
 }
 
+const createFlowNodeGraphQLSubscription = `-- name: CreateFlowNodeGraphQLSubscription :exec
+INSERT INTO
+  flow_node_graphql_subscription (flow_node_id, graphql_id, delta_graphql_id, connection_payload, max_events, timeout_ms)
+VALUES
+  (?, ?, ?, ?, ?, ?)
+`
+
+type CreateFlowNodeGraphQLSubscriptionParams struct {
+	FlowNodeID        idwrap.IDWrap
+	GraphqlID         idwrap.IDWrap
+	DeltaGraphqlID    []byte
+	ConnectionPayload string
+	MaxEvents         int64
+	TimeoutMs         int64
+}
+
+func (q *Queries) CreateFlowNodeGraphQLSubscription(ctx context.Context, arg CreateFlowNodeGraphQLSubscriptionParams) error {
+	_, err := q.exec(ctx, q.createFlowNodeGraphQLSubscriptionStmt, createFlowNodeGraphQLSubscription,
+		arg.FlowNodeID,
+		arg.GraphqlID,
+		arg.DeltaGraphqlID,
+		arg.ConnectionPayload,
+		arg.MaxEvents,
+		arg.TimeoutMs,
+	)
+	return err
+}
+
 const createFlowNodeHTTP = `-- name: CreateFlowNodeHTTP :exec
 INSERT INTO
   flow_node_http (
@@ -330,6 +405,52 @@ func (q *Queries) CreateFlowNodeJs(ctx context.Context, arg CreateFlowNodeJsPara
 	return err
 }
 
+const createFlowNodeParallel = `-- name: CreateFlowNodeParallel :exec
+INSERT INTO
+  flow_node_parallel (flow_node_id, join_mode, concurrency)
+VALUES
+  (?, ?, ?)
+`
+
+type CreateFlowNodeParallelParams struct {
+	FlowNodeID  idwrap.IDWrap
+	JoinMode    int8
+	Concurrency int32
+}
+
+func (q *Queries) CreateFlowNodeParallel(ctx context.Context, arg CreateFlowNodeParallelParams) error {
+	_, err := q.exec(ctx, q.createFlowNodeParallelStmt, createFlowNodeParallel, arg.FlowNodeID, arg.JoinMode, arg.Concurrency)
+	return err
+}
+
+const createFlowNodePoll = `-- name: CreateFlowNodePoll :exec
+INSERT INTO
+  flow_node_poll (flow_node_id, until_expression, interval_ms, backoff, max_duration_ms, max_attempts)
+VALUES
+  (?, ?, ?, ?, ?, ?)
+`
+
+type CreateFlowNodePollParams struct {
+	FlowNodeID      idwrap.IDWrap
+	UntilExpression string
+	IntervalMs      int64
+	Backoff         float64
+	MaxDurationMs   int64
+	MaxAttempts     int32
+}
+
+func (q *Queries) CreateFlowNodePoll(ctx context.Context, arg CreateFlowNodePollParams) error {
+	_, err := q.exec(ctx, q.createFlowNodePollStmt, createFlowNodePoll,
+		arg.FlowNodeID,
+		arg.UntilExpression,
+		arg.IntervalMs,
+		arg.Backoff,
+		arg.MaxDurationMs,
+		arg.MaxAttempts,
+	)
+	return err
+}
+
 const createFlowNodeRunSubFlow = `-- name: CreateFlowNodeRunSubFlow :exec
 INSERT INTO flow_node_run_sub_flow (flow_node_id, target_flow_id, target_flow_name, inputs)
 VALUES (?, ?, ?, ?)
@@ -382,6 +503,23 @@ func (q *Queries) CreateFlowNodeSubFlowTrigger(ctx context.Context, arg CreateFl
 	return err
 }
 
+const createFlowNodeSwitch = `-- name: CreateFlowNodeSwitch :exec
+INSERT INTO
+  flow_node_switch (flow_node_id, cases)
+VALUES
+  (?, ?)
+`
+
+type CreateFlowNodeSwitchParams struct {
+	FlowNodeID idwrap.IDWrap
+	Cases      []byte
+}
+
+func (q *Queries) CreateFlowNodeSwitch(ctx context.Context, arg CreateFlowNodeSwitchParams) error {
+	_, err := q.exec(ctx, q.createFlowNodeSwitchStmt, createFlowNodeSwitch, arg.FlowNodeID, arg.Cases)
+	return err
+}
+
 const createFlowNodeWait = `-- name: CreateFlowNodeWait :exec
 INSERT INTO
   flow_node_wait (flow_node_id, duration_ms)
@@ -399,6 +537,32 @@ func (q *Queries) CreateFlowNodeWait(ctx context.Context, arg CreateFlowNodeWait
 	return err
 }
 
+const createFlowNodeWebhookTrigger = `-- name: CreateFlowNodeWebhookTrigger :exec
+INSERT INTO
+  flow_node_webhook_trigger (flow_node_id, path, method, secret, signature_header)
+VALUES
+  (?, ?, ?, ?, ?)
+`
+
+type CreateFlowNodeWebhookTriggerParams struct {
+	FlowNodeID      idwrap.IDWrap
+	Path            string
+	Method          string
+	Secret          string
+	SignatureHeader string
+}
+
+func (q *Queries) CreateFlowNodeWebhookTrigger(ctx context.Context, arg CreateFlowNodeWebhookTriggerParams) error {
+	_, err := q.exec(ctx, q.createFlowNodeWebhookTriggerStmt, createFlowNodeWebhookTrigger,
+		arg.FlowNodeID,
+		arg.Path,
+		arg.Method,
+		arg.Secret,
+		arg.SignatureHeader,
+	)
+	return err
+}
+
 const createFlowNodeWithState = `-- name: CreateFlowNodeWithState :exec
 INSERT INTO
   flow_node (id, flow_id, name, node_kind, position_x, position_y, state)
@@ -574,6 +738,64 @@ func (q *Queries) CreateFlowNodesBulk(ctx context.Context, arg CreateFlowNodesBu
 	return err
 }
 
+const createFlowSchedule = `-- name: CreateFlowSchedule :exec
+INSERT INTO
+  flow_schedule (id, flow_id, cron_expression, interval_seconds, environment_id, enabled)
+VALUES
+  (?, ?, ?, ?, ?, ?)
+`
+
+type CreateFlowScheduleParams struct {
+	ID              idwrap.IDWrap
+	FlowID          idwrap.IDWrap
+	CronExpression  string
+	IntervalSeconds int64
+	EnvironmentID   *idwrap.IDWrap
+	Enabled         bool
+}
+
+func (q *Queries) CreateFlowSchedule(ctx context.Context, arg CreateFlowScheduleParams) error {
+	_, err := q.exec(ctx, q.createFlowScheduleStmt, createFlowSchedule,
+		arg.ID,
+		arg.FlowID,
+		arg.CronExpression,
+		arg.IntervalSeconds,
+		arg.EnvironmentID,
+		arg.Enabled,
+	)
+	return err
+}
+
+const createFlowScheduleRun = `-- name: CreateFlowScheduleRun :exec
+INSERT INTO
+  flow_schedule_run (id, schedule_id, flow_version_id, state, error, started_at, duration_ms)
+VALUES
+  (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateFlowScheduleRunParams struct {
+	ID            idwrap.IDWrap
+	ScheduleID    idwrap.IDWrap
+	FlowVersionID *idwrap.IDWrap
+	State         int8
+	Error         sql.NullString
+	StartedAt     int64
+	DurationMs    int64
+}
+
+func (q *Queries) CreateFlowScheduleRun(ctx context.Context, arg CreateFlowScheduleRunParams) error {
+	_, err := q.exec(ctx, q.createFlowScheduleRunStmt, createFlowScheduleRun,
+		arg.ID,
+		arg.ScheduleID,
+		arg.FlowVersionID,
+		arg.State,
+		arg.Error,
+		arg.StartedAt,
+		arg.DurationMs,
+	)
+	return err
+}
+
 const createFlowTag = `-- name: CreateFlowTag :exec
 INSERT INTO
   flow_tag (id, flow_id, tag_id)
@@ -1153,6 +1375,15 @@ func (q *Queries) DeleteFlowNodeGraphQL(ctx context.Context, flowNodeID idwrap.I
 	return err
 }
 
+const deleteFlowNodeGraphQLSubscription = `-- name: DeleteFlowNodeGraphQLSubscription :exec
+DELETE FROM flow_node_graphql_subscription WHERE flow_node_id = ?
+`
+
+func (q *Queries) DeleteFlowNodeGraphQLSubscription(ctx context.Context, flowNodeID idwrap.IDWrap) error {
+	_, err := q.exec(ctx, q.deleteFlowNodeGraphQLSubscriptionStmt, deleteFlowNodeGraphQLSubscription, flowNodeID)
+	return err
+}
+
 const deleteFlowNodeHTTP = `-- name: DeleteFlowNodeHTTP :exec
 DELETE FROM flow_node_http
 WHERE
@@ -1175,6 +1406,28 @@ func (q *Queries) DeleteFlowNodeJs(ctx context.Context, flowNodeID idwrap.IDWrap
 	return err
 }
 
+const deleteFlowNodeParallel = `-- name: DeleteFlowNodeParallel :exec
+DELETE FROM flow_node_parallel
+WHERE
+  flow_node_id = ?
+`
+
+func (q *Queries) DeleteFlowNodeParallel(ctx context.Context, flowNodeID idwrap.IDWrap) error {
+	_, err := q.exec(ctx, q.deleteFlowNodeParallelStmt, deleteFlowNodeParallel, flowNodeID)
+	return err
+}
+
+const deleteFlowNodePoll = `-- name: DeleteFlowNodePoll :exec
+DELETE FROM flow_node_poll
+WHERE
+  flow_node_id = ?
+`
+
+func (q *Queries) DeleteFlowNodePoll(ctx context.Context, flowNodeID idwrap.IDWrap) error {
+	_, err := q.exec(ctx, q.deleteFlowNodePollStmt, deleteFlowNodePoll, flowNodeID)
+	return err
+}
+
 const deleteFlowNodeRunSubFlow = `-- name: DeleteFlowNodeRunSubFlow :exec
 DELETE FROM flow_node_run_sub_flow
 WHERE flow_node_id = ?
@@ -1205,6 +1458,17 @@ func (q *Queries) DeleteFlowNodeSubFlowTrigger(ctx context.Context, flowNodeID i
 	return err
 }
 
+const deleteFlowNodeSwitch = `-- name: DeleteFlowNodeSwitch :exec
+DELETE FROM flow_node_switch
+WHERE
+  flow_node_id = ?
+`
+
+func (q *Queries) DeleteFlowNodeSwitch(ctx context.Context, flowNodeID idwrap.IDWrap) error {
+	_, err := q.exec(ctx, q.deleteFlowNodeSwitchStmt, deleteFlowNodeSwitch, flowNodeID)
+	return err
+}
+
 const deleteFlowNodeWait = `-- name: DeleteFlowNodeWait :exec
 DELETE FROM flow_node_wait
 WHERE
@@ -1216,6 +1480,28 @@ func (q *Queries) DeleteFlowNodeWait(ctx context.Context, flowNodeID idwrap.IDWr
 	return err
 }
 
+const deleteFlowNodeWebhookTrigger = `-- name: DeleteFlowNodeWebhookTrigger :exec
+DELETE FROM flow_node_webhook_trigger
+WHERE
+  flow_node_id = ?
+`
+
+func (q *Queries) DeleteFlowNodeWebhookTrigger(ctx context.Context, flowNodeID idwrap.IDWrap) error {
+	_, err := q.exec(ctx, q.deleteFlowNodeWebhookTriggerStmt, deleteFlowNodeWebhookTrigger, flowNodeID)
+	return err
+}
+
+const deleteFlowSchedule = `-- name: DeleteFlowSchedule :exec
+DELETE FROM flow_schedule
+WHERE
+  id = ?
+`
+
+func (q *Queries) DeleteFlowSchedule(ctx context.Context, id idwrap.IDWrap) error {
+	_, err := q.exec(ctx, q.deleteFlowScheduleStmt, deleteFlowSchedule, id)
+	return err
+}
+
 const deleteFlowTag = `-- name: DeleteFlowTag :exec
 DELETE FROM flow_tag
 WHERE
@@ -1337,6 +1623,54 @@ func (q *Queries) GetAllFlowsByWorkspaceID(ctx context.Context, workspaceID idwr
 	return items, nil
 }
 
+const getEnabledFlowSchedules = `-- name: GetEnabledFlowSchedules :many
+SELECT
+  id,
+  flow_id,
+  cron_expression,
+  interval_seconds,
+  environment_id,
+  enabled,
+  last_run_at,
+  last_state
+FROM
+  flow_schedule
+WHERE
+  enabled = TRUE
+`
+
+func (q *Queries) GetEnabledFlowSchedules(ctx context.Context) ([]FlowSchedule, error) {
+	rows, err := q.query(ctx, q.getEnabledFlowSchedulesStmt, getEnabledFlowSchedules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FlowSchedule{}
+	for rows.Next() {
+		var i FlowSchedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.FlowID,
+			&i.CronExpression,
+			&i.IntervalSeconds,
+			&i.EnvironmentID,
+			&i.Enabled,
+			&i.LastRunAt,
+			&i.LastState,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFlow = `-- name: GetFlow :one
 SELECT
   id,
@@ -1661,7 +1995,8 @@ SELECT
   flow_node_id,
   iter_expression,
   error_handling,
-  expression
+  expression,
+  concurrency
 FROM
   flow_node_for_each
 WHERE
@@ -1677,6 +2012,7 @@ func (q *Queries) GetFlowNodeForEach(ctx context.Context, flowNodeID idwrap.IDWr
 		&i.IterExpression,
 		&i.ErrorHandling,
 		&i.Expression,
+		&i.Concurrency,
 	)
 	return i, err
 }
@@ -1700,6 +2036,35 @@ func (q *Queries) GetFlowNodeGraphQL(ctx context.Context, flowNodeID idwrap.IDWr
 	return i, err
 }
 
+const getFlowNodeGraphQLSubscription = `-- name: GetFlowNodeGraphQLSubscription :one
+SELECT
+  flow_node_id,
+  graphql_id,
+  delta_graphql_id,
+  connection_payload,
+  max_events,
+  timeout_ms
+FROM
+  flow_node_graphql_subscription
+WHERE
+  flow_node_id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetFlowNodeGraphQLSubscription(ctx context.Context, flowNodeID idwrap.IDWrap) (FlowNodeGraphqlSubscription, error) {
+	row := q.queryRow(ctx, q.getFlowNodeGraphQLSubscriptionStmt, getFlowNodeGraphQLSubscription, flowNodeID)
+	var i FlowNodeGraphqlSubscription
+	err := row.Scan(
+		&i.FlowNodeID,
+		&i.GraphqlID,
+		&i.DeltaGraphqlID,
+		&i.ConnectionPayload,
+		&i.MaxEvents,
+		&i.TimeoutMs,
+	)
+	return i, err
+}
+
 const getFlowNodeHTTP = `-- name: GetFlowNodeHTTP :one
 SELECT
   flow_node_id,
@@ -1738,6 +2103,52 @@ func (q *Queries) GetFlowNodeJs(ctx context.Context, flowNodeID idwrap.IDWrap) (
 	return i, err
 }
 
+const getFlowNodeParallel = `-- name: GetFlowNodeParallel :one
+SELECT
+  flow_node_id,
+  join_mode,
+  concurrency
+FROM
+  flow_node_parallel
+WHERE
+  flow_node_id = ?
+`
+
+func (q *Queries) GetFlowNodeParallel(ctx context.Context, flowNodeID idwrap.IDWrap) (FlowNodeParallel, error) {
+	row := q.queryRow(ctx, q.getFlowNodeParallelStmt, getFlowNodeParallel, flowNodeID)
+	var i FlowNodeParallel
+	err := row.Scan(&i.FlowNodeID, &i.JoinMode, &i.Concurrency)
+	return i, err
+}
+
+const getFlowNodePoll = `-- name: GetFlowNodePoll :one
+SELECT
+  flow_node_id,
+  until_expression,
+  interval_ms,
+  backoff,
+  max_duration_ms,
+  max_attempts
+FROM
+  flow_node_poll
+WHERE
+  flow_node_id = ?
+`
+
+func (q *Queries) GetFlowNodePoll(ctx context.Context, flowNodeID idwrap.IDWrap) (FlowNodePoll, error) {
+	row := q.queryRow(ctx, q.getFlowNodePollStmt, getFlowNodePoll, flowNodeID)
+	var i FlowNodePoll
+	err := row.Scan(
+		&i.FlowNodeID,
+		&i.UntilExpression,
+		&i.IntervalMs,
+		&i.Backoff,
+		&i.MaxDurationMs,
+		&i.MaxAttempts,
+	)
+	return i, err
+}
+
 const getFlowNodeRunSubFlow = `-- name: GetFlowNodeRunSubFlow :one
 SELECT flow_node_id, target_flow_id, target_flow_name, inputs
 FROM flow_node_run_sub_flow
@@ -1788,6 +2199,23 @@ func (q *Queries) GetFlowNodeSubFlowTrigger(ctx context.Context, flowNodeID idwr
 	return i, err
 }
 
+const getFlowNodeSwitch = `-- name: GetFlowNodeSwitch :one
+SELECT
+  flow_node_id,
+  cases
+FROM
+  flow_node_switch
+WHERE
+  flow_node_id = ?
+`
+
+func (q *Queries) GetFlowNodeSwitch(ctx context.Context, flowNodeID idwrap.IDWrap) (FlowNodeSwitch, error) {
+	row := q.queryRow(ctx, q.getFlowNodeSwitchStmt, getFlowNodeSwitch, flowNodeID)
+	var i FlowNodeSwitch
+	err := row.Scan(&i.FlowNodeID, &i.Cases)
+	return i, err
+}
+
 const getFlowNodeWait = `-- name: GetFlowNodeWait :one
 SELECT
   flow_node_id,
@@ -1805,6 +2233,89 @@ func (q *Queries) GetFlowNodeWait(ctx context.Context, flowNodeID idwrap.IDWrap)
 	return i, err
 }
 
+const getFlowNodeWebhookTrigger = `-- name: GetFlowNodeWebhookTrigger :one
+SELECT
+  flow_node_id,
+  path,
+  method,
+  secret,
+  signature_header
+FROM
+  flow_node_webhook_trigger
+WHERE
+  flow_node_id = ?
+`
+
+func (q *Queries) GetFlowNodeWebhookTrigger(ctx context.Context, flowNodeID idwrap.IDWrap) (FlowNodeWebhookTrigger, error) {
+	row := q.queryRow(ctx, q.getFlowNodeWebhookTriggerStmt, getFlowNodeWebhookTrigger, flowNodeID)
+	var i FlowNodeWebhookTrigger
+	err := row.Scan(
+		&i.FlowNodeID,
+		&i.Path,
+		&i.Method,
+		&i.Secret,
+		&i.SignatureHeader,
+	)
+	return i, err
+}
+
+const getFlowNodeWebhookTriggersByPath = `-- name: GetFlowNodeWebhookTriggersByPath :many
+SELECT
+  w.flow_node_id,
+  w.path,
+  w.method,
+  w.secret,
+  w.signature_header,
+  n.flow_id
+FROM
+  flow_node_webhook_trigger w
+  INNER JOIN flow_node n ON n.id = w.flow_node_id
+  INNER JOIN flow f ON f.id = n.flow_id
+WHERE
+  w.path = ?
+  AND f.version_parent_id IS NULL
+`
+
+type GetFlowNodeWebhookTriggersByPathRow struct {
+	FlowNodeID      idwrap.IDWrap
+	Path            string
+	Method          string
+	Secret          string
+	SignatureHeader string
+	FlowID          idwrap.IDWrap
+}
+
+// Webhook triggers listening on a path, excluding those of flow versions.
+func (q *Queries) GetFlowNodeWebhookTriggersByPath(ctx context.Context, path string) ([]GetFlowNodeWebhookTriggersByPathRow, error) {
+	rows, err := q.query(ctx, q.getFlowNodeWebhookTriggersByPathStmt, getFlowNodeWebhookTriggersByPath, path)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetFlowNodeWebhookTriggersByPathRow{}
+	for rows.Next() {
+		var i GetFlowNodeWebhookTriggersByPathRow
+		if err := rows.Scan(
+			&i.FlowNodeID,
+			&i.Path,
+			&i.Method,
+			&i.Secret,
+			&i.SignatureHeader,
+			&i.FlowID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFlowNodesByFlowID = `-- name: GetFlowNodesByFlowID :many
 SELECT
   id,
@@ -1896,6 +2407,136 @@ func (q *Queries) GetFlowNodesByFlowIDs(ctx context.Context, flowIds []idwrap.ID
 	return items, nil
 }
 
+const getFlowSchedule = `-- name: GetFlowSchedule :one
+SELECT
+  id,
+  flow_id,
+  cron_expression,
+  interval_seconds,
+  environment_id,
+  enabled,
+  last_run_at,
+  last_state
+FROM
+  flow_schedule
+WHERE
+  id = ?
+LIMIT
+  1
+`
+
+func (q *Queries) GetFlowSchedule(ctx context.Context, id idwrap.IDWrap) (FlowSchedule, error) {
+	row := q.queryRow(ctx, q.getFlowScheduleStmt, getFlowSchedule, id)
+	var i FlowSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.FlowID,
+		&i.CronExpression,
+		&i.IntervalSeconds,
+		&i.EnvironmentID,
+		&i.Enabled,
+		&i.LastRunAt,
+		&i.LastState,
+	)
+	return i, err
+}
+
+const getFlowScheduleRunsByScheduleID = `-- name: GetFlowScheduleRunsByScheduleID :many
+SELECT
+  id,
+  schedule_id,
+  flow_version_id,
+  state,
+  error,
+  started_at,
+  duration_ms
+FROM
+  flow_schedule_run
+WHERE
+  schedule_id = ?
+ORDER BY
+  started_at DESC
+`
+
+func (q *Queries) GetFlowScheduleRunsByScheduleID(ctx context.Context, scheduleID idwrap.IDWrap) ([]FlowScheduleRun, error) {
+	rows, err := q.query(ctx, q.getFlowScheduleRunsByScheduleIDStmt, getFlowScheduleRunsByScheduleID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FlowScheduleRun{}
+	for rows.Next() {
+		var i FlowScheduleRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduleID,
+			&i.FlowVersionID,
+			&i.State,
+			&i.Error,
+			&i.StartedAt,
+			&i.DurationMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFlowSchedulesByFlowID = `-- name: GetFlowSchedulesByFlowID :many
+SELECT
+  id,
+  flow_id,
+  cron_expression,
+  interval_seconds,
+  environment_id,
+  enabled,
+  last_run_at,
+  last_state
+FROM
+  flow_schedule
+WHERE
+  flow_id = ?
+`
+
+func (q *Queries) GetFlowSchedulesByFlowID(ctx context.Context, flowID idwrap.IDWrap) ([]FlowSchedule, error) {
+	rows, err := q.query(ctx, q.getFlowSchedulesByFlowIDStmt, getFlowSchedulesByFlowID, flowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FlowSchedule{}
+	for rows.Next() {
+		var i FlowSchedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.FlowID,
+			&i.CronExpression,
+			&i.IntervalSeconds,
+			&i.EnvironmentID,
+			&i.Enabled,
+			&i.LastRunAt,
+			&i.LastState,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFlowTag = `-- name: GetFlowTag :one
 SELECT
   id,
@@ -2654,6 +3295,36 @@ func (q *Queries) ListNodeExecutionsByState(ctx context.Context, arg ListNodeExe
 	return items, nil
 }
 
+const pruneFlowScheduleRuns = `-- name: PruneFlowScheduleRuns :exec
+DELETE FROM flow_schedule_run
+WHERE
+  schedule_id = ?
+  AND id NOT IN (
+    SELECT
+      id
+    FROM
+      flow_schedule_run AS recent
+    WHERE
+      recent.schedule_id = ?
+    ORDER BY
+      recent.started_at DESC
+    LIMIT
+      ?
+  )
+`
+
+type PruneFlowScheduleRunsParams struct {
+	ScheduleID   idwrap.IDWrap
+	ScheduleID_2 idwrap.IDWrap
+	Limit        int64
+}
+
+// Keeps only the most recent runs of a schedule
+func (q *Queries) PruneFlowScheduleRuns(ctx context.Context, arg PruneFlowScheduleRunsParams) error {
+	_, err := q.exec(ctx, q.pruneFlowScheduleRunsStmt, pruneFlowScheduleRuns, arg.ScheduleID, arg.ScheduleID_2, arg.Limit)
+	return err
+}
+
 const updateFlow = `-- name: UpdateFlow :exec
 UPDATE flow
 SET
@@ -2806,7 +3477,8 @@ UPDATE flow_node_for_each
 SET
   iter_expression = ?,
   error_handling = ?,
-  expression = ?
+  expression = ?,
+  concurrency = ?
 WHERE
   flow_node_id = ?
 `
@@ -2815,6 +3487,7 @@ type UpdateFlowNodeForEachParams struct {
 	IterExpression string
 	ErrorHandling  int8
 	Expression     string
+	Concurrency    int32
 	FlowNodeID     idwrap.IDWrap
 }
 
@@ -2823,6 +3496,7 @@ func (q *Queries) UpdateFlowNodeForEach(ctx context.Context, arg UpdateFlowNodeF
 		arg.IterExpression,
 		arg.ErrorHandling,
 		arg.Expression,
+		arg.Concurrency,
 		arg.FlowNodeID,
 	)
 	return err
@@ -2846,6 +3520,40 @@ func (q *Queries) UpdateFlowNodeGraphQL(ctx context.Context, arg UpdateFlowNodeG
 	return err
 }
 
+const updateFlowNodeGraphQLSubscription = `-- name: UpdateFlowNodeGraphQLSubscription :exec
+INSERT INTO
+  flow_node_graphql_subscription (flow_node_id, graphql_id, delta_graphql_id, connection_payload, max_events, timeout_ms)
+VALUES
+  (?, ?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  graphql_id = excluded.graphql_id,
+  delta_graphql_id = excluded.delta_graphql_id,
+  connection_payload = excluded.connection_payload,
+  max_events = excluded.max_events,
+  timeout_ms = excluded.timeout_ms
+`
+
+type UpdateFlowNodeGraphQLSubscriptionParams struct {
+	FlowNodeID        idwrap.IDWrap
+	GraphqlID         idwrap.IDWrap
+	DeltaGraphqlID    []byte
+	ConnectionPayload string
+	MaxEvents         int64
+	TimeoutMs         int64
+}
+
+func (q *Queries) UpdateFlowNodeGraphQLSubscription(ctx context.Context, arg UpdateFlowNodeGraphQLSubscriptionParams) error {
+	_, err := q.exec(ctx, q.updateFlowNodeGraphQLSubscriptionStmt, updateFlowNodeGraphQLSubscription,
+		arg.FlowNodeID,
+		arg.GraphqlID,
+		arg.DeltaGraphqlID,
+		arg.ConnectionPayload,
+		arg.MaxEvents,
+		arg.TimeoutMs,
+	)
+	return err
+}
+
 const updateFlowNodeHTTP = `-- name: UpdateFlowNodeHTTP :exec
 INSERT INTO flow_node_http (
     flow_node_id,
@@ -2906,6 +3614,59 @@ func (q *Queries) UpdateFlowNodeJs(ctx context.Context, arg UpdateFlowNodeJsPara
 	return err
 }
 
+const updateFlowNodeParallel = `-- name: UpdateFlowNodeParallel :exec
+UPDATE flow_node_parallel
+SET
+  join_mode = ?,
+  concurrency = ?
+WHERE
+  flow_node_id = ?
+`
+
+type UpdateFlowNodeParallelParams struct {
+	JoinMode    int8
+	Concurrency int32
+	FlowNodeID  idwrap.IDWrap
+}
+
+func (q *Queries) UpdateFlowNodeParallel(ctx context.Context, arg UpdateFlowNodeParallelParams) error {
+	_, err := q.exec(ctx, q.updateFlowNodeParallelStmt, updateFlowNodeParallel, arg.JoinMode, arg.Concurrency, arg.FlowNodeID)
+	return err
+}
+
+const updateFlowNodePoll = `-- name: UpdateFlowNodePoll :exec
+UPDATE flow_node_poll
+SET
+  until_expression = ?,
+  interval_ms = ?,
+  backoff = ?,
+  max_duration_ms = ?,
+  max_attempts = ?
+WHERE
+  flow_node_id = ?
+`
+
+type UpdateFlowNodePollParams struct {
+	UntilExpression string
+	IntervalMs      int64
+	Backoff         float64
+	MaxDurationMs   int64
+	MaxAttempts     int32
+	FlowNodeID      idwrap.IDWrap
+}
+
+func (q *Queries) UpdateFlowNodePoll(ctx context.Context, arg UpdateFlowNodePollParams) error {
+	_, err := q.exec(ctx, q.updateFlowNodePollStmt, updateFlowNodePoll,
+		arg.UntilExpression,
+		arg.IntervalMs,
+		arg.Backoff,
+		arg.MaxDurationMs,
+		arg.MaxAttempts,
+		arg.FlowNodeID,
+	)
+	return err
+}
+
 const updateFlowNodeRunSubFlow = `-- name: UpdateFlowNodeRunSubFlow :exec
 UPDATE flow_node_run_sub_flow
 SET target_flow_id = ?, target_flow_name = ?, inputs = ?
@@ -2979,6 +3740,24 @@ func (q *Queries) UpdateFlowNodeSubFlowTrigger(ctx context.Context, arg UpdateFl
 	return err
 }
 
+const updateFlowNodeSwitch = `-- name: UpdateFlowNodeSwitch :exec
+UPDATE flow_node_switch
+SET
+  cases = ?
+WHERE
+  flow_node_id = ?
+`
+
+type UpdateFlowNodeSwitchParams struct {
+	Cases      []byte
+	FlowNodeID idwrap.IDWrap
+}
+
+func (q *Queries) UpdateFlowNodeSwitch(ctx context.Context, arg UpdateFlowNodeSwitchParams) error {
+	_, err := q.exec(ctx, q.updateFlowNodeSwitchStmt, updateFlowNodeSwitch, arg.Cases, arg.FlowNodeID)
+	return err
+}
+
 const updateFlowNodeWait = `-- name: UpdateFlowNodeWait :exec
 UPDATE flow_node_wait
 SET
@@ -2997,6 +3776,86 @@ func (q *Queries) UpdateFlowNodeWait(ctx context.Context, arg UpdateFlowNodeWait
 	return err
 }
 
+const updateFlowNodeWebhookTrigger = `-- name: UpdateFlowNodeWebhookTrigger :exec
+UPDATE flow_node_webhook_trigger
+SET
+  path = ?,
+  method = ?,
+  secret = ?,
+  signature_header = ?
+WHERE
+  flow_node_id = ?
+`
+
+type UpdateFlowNodeWebhookTriggerParams struct {
+	Path            string
+	Method          string
+	Secret          string
+	SignatureHeader string
+	FlowNodeID      idwrap.IDWrap
+}
+
+func (q *Queries) UpdateFlowNodeWebhookTrigger(ctx context.Context, arg UpdateFlowNodeWebhookTriggerParams) error {
+	_, err := q.exec(ctx, q.updateFlowNodeWebhookTriggerStmt, updateFlowNodeWebhookTrigger,
+		arg.Path,
+		arg.Method,
+		arg.Secret,
+		arg.SignatureHeader,
+		arg.FlowNodeID,
+	)
+	return err
+}
+
+const updateFlowSchedule = `-- name: UpdateFlowSchedule :exec
+UPDATE flow_schedule
+SET
+  cron_expression = ?,
+  interval_seconds = ?,
+  environment_id = ?,
+  enabled = ?
+WHERE
+  id = ?
+`
+
+type UpdateFlowScheduleParams struct {
+	CronExpression  string
+	IntervalSeconds int64
+	EnvironmentID   *idwrap.IDWrap
+	Enabled         bool
+	ID              idwrap.IDWrap
+}
+
+func (q *Queries) UpdateFlowSchedule(ctx context.Context, arg UpdateFlowScheduleParams) error {
+	_, err := q.exec(ctx, q.updateFlowScheduleStmt, updateFlowSchedule,
+		arg.CronExpression,
+		arg.IntervalSeconds,
+		arg.EnvironmentID,
+		arg.Enabled,
+		arg.ID,
+	)
+	return err
+}
+
+const updateFlowScheduleLastRun = `-- name: UpdateFlowScheduleLastRun :exec
+UPDATE flow_schedule
+SET
+  last_run_at = ?,
+  last_state = ?
+WHERE
+  id = ?
+`
+
+type UpdateFlowScheduleLastRunParams struct {
+	LastRunAt sql.NullInt64
+	LastState int8
+	ID        idwrap.IDWrap
+}
+
+func (q *Queries) UpdateFlowScheduleLastRun(ctx context.Context, arg UpdateFlowScheduleLastRunParams) error {
+	_, err := q.exec(ctx, q.updateFlowScheduleLastRunStmt, updateFlowScheduleLastRun, arg.LastRunAt, arg.LastState, arg.ID)
+	return err
+}
+
 const updateFlowVariable = `-- name: UpdateFlowVariable :exec
 UPDATE flow_variable
 SET
@@ -16,11 +16,11 @@ import (
 const createGraphQL = `-- name: CreateGraphQL :exec
 INSERT INTO graphql (
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateGraphQLParams struct {
@@ -31,6 +31,11 @@ type CreateGraphQLParams struct {
 	Url              string
 	Query            string
 	Variables        string
+	OperationName    string
+	QueryFile        string
+	PersistedQuery   bool
+	Batch            []byte
+	Uploads          []byte
 	Description      string
 	LastRunAt        interface{}
 	CreatedAt        int64
@@ -54,6 +59,11 @@ func (q *Queries) CreateGraphQL(ctx context.Context, arg CreateGraphQLParams) er
 		arg.Url,
 		arg.Query,
 		arg.Variables,
+		arg.OperationName,
+		arg.QueryFile,
+		arg.PersistedQuery,
+		arg.Batch,
+		arg.Uploads,
 		arg.Description,
 		arg.LastRunAt,
 		arg.CreatedAt,
@@ -470,11 +480,20 @@ func (q *Queries) DeleteGraphQLResponseHeader(ctx context.Context, id idwrap.IDW
 	return err
 }
 
+const deleteGraphQLSchema = `-- name: DeleteGraphQLSchema :exec
+DELETE FROM graphql_schema WHERE graphql_id = ?
+`
+
+func (q *Queries) DeleteGraphQLSchema(ctx context.Context, graphqlID idwrap.IDWrap) error {
+	_, err := q.exec(ctx, q.deleteGraphQLSchemaStmt, deleteGraphQLSchema, graphqlID)
+	return err
+}
+
 const getGraphQL = `-- name: GetGraphQL :one
 
 SELECT
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 FROM graphql
@@ -493,6 +512,11 @@ func (q *Queries) GetGraphQL(ctx context.Context, id idwrap.IDWrap) (Graphql, er
 		&i.Url,
 		&i.Query,
 		&i.Variables,
+		&i.OperationName,
+		&i.QueryFile,
+		&i.PersistedQuery,
+		&i.Batch,
+		&i.Uploads,
 		&i.Description,
 		&i.LastRunAt,
 		&i.CreatedAt,
@@ -888,7 +912,7 @@ func (q *Queries) GetGraphQLAssertsByIDs(ctx context.Context, ids [][]byte) ([]G
 const getGraphQLDeltasByParentID = `-- name: GetGraphQLDeltasByParentID :many
 SELECT
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 FROM graphql
@@ -913,6 +937,11 @@ func (q *Queries) GetGraphQLDeltasByParentID(ctx context.Context, parentGraphqlI
 			&i.Url,
 			&i.Query,
 			&i.Variables,
+			&i.OperationName,
+			&i.QueryFile,
+			&i.PersistedQuery,
+			&i.Batch,
+			&i.Uploads,
 			&i.Description,
 			&i.LastRunAt,
 			&i.CreatedAt,
@@ -943,7 +972,7 @@ const getGraphQLDeltasByWorkspaceID = `-- name: GetGraphQLDeltasByWorkspaceID :m
 
 SELECT
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 FROM graphql
@@ -969,6 +998,11 @@ func (q *Queries) GetGraphQLDeltasByWorkspaceID(ctx context.Context, workspaceID
 			&i.Url,
 			&i.Query,
 			&i.Variables,
+			&i.OperationName,
+			&i.QueryFile,
+			&i.PersistedQuery,
+			&i.Batch,
+			&i.Uploads,
 			&i.Description,
 			&i.LastRunAt,
 			&i.CreatedAt,
@@ -1476,6 +1510,19 @@ func (q *Queries) GetGraphQLResponsesByWorkspaceID(ctx context.Context, workspac
 	return items, nil
 }
 
+const getGraphQLSchema = `-- name: GetGraphQLSchema :one
+SELECT graphql_id, introspection, fetched_at
+FROM graphql_schema
+WHERE graphql_id = ? LIMIT 1
+`
+
+func (q *Queries) GetGraphQLSchema(ctx context.Context, graphqlID idwrap.IDWrap) (GraphqlSchema, error) {
+	row := q.queryRow(ctx, q.getGraphQLSchemaStmt, getGraphQLSchema, graphqlID)
+	var i GraphqlSchema
+	err := row.Scan(&i.GraphqlID, &i.Introspection, &i.FetchedAt)
+	return i, err
+}
+
 const getGraphQLVersionsByGraphQLID = `-- name: GetGraphQLVersionsByGraphQLID :many
 SELECT id, graphql_id, version_name, version_description, is_active, created_at, created_by
 FROM graphql_version
@@ -1531,7 +1578,7 @@ func (q *Queries) GetGraphQLWorkspaceID(ctx context.Context, id idwrap.IDWrap) (
 const getGraphQLsByWorkspaceID = `-- name: GetGraphQLsByWorkspaceID :many
 SELECT
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 FROM graphql
@@ -1556,6 +1603,11 @@ func (q *Queries) GetGraphQLsByWorkspaceID(ctx context.Context, workspaceID idwr
 			&i.Url,
 			&i.Query,
 			&i.Variables,
+			&i.OperationName,
+			&i.QueryFile,
+			&i.PersistedQuery,
+			&i.Batch,
+			&i.Uploads,
 			&i.Description,
 			&i.LastRunAt,
 			&i.CreatedAt,
@@ -1589,6 +1641,11 @@ SET
   url = ?,
   query = ?,
   variables = ?,
+  operation_name = ?,
+  query_file = ?,
+  persisted_query = ?,
+  batch = ?,
+  uploads = ?,
   description = ?,
   last_run_at = COALESCE(?, last_run_at),
   updated_at = unixepoch()
@@ -1596,13 +1653,18 @@ WHERE id = ?
 `
 
 type UpdateGraphQLParams struct {
-	Name        string
-	Url         string
-	Query       string
-	Variables   string
-	Description string
-	LastRunAt   interface{}
-	ID          idwrap.IDWrap
+	Name           string
+	Url            string
+	Query          string
+	Variables      string
+	OperationName  string
+	QueryFile      string
+	PersistedQuery bool
+	Batch          []byte
+	Uploads        []byte
+	Description    string
+	LastRunAt      interface{}
+	ID             idwrap.IDWrap
 }
 
 func (q *Queries) UpdateGraphQL(ctx context.Context, arg UpdateGraphQLParams) error {
@@ -1611,6 +1673,11 @@ func (q *Queries) UpdateGraphQL(ctx context.Context, arg UpdateGraphQLParams) er
 		arg.Url,
 		arg.Query,
 		arg.Variables,
+		arg.OperationName,
+		arg.QueryFile,
+		arg.PersistedQuery,
+		arg.Batch,
+		arg.Uploads,
 		arg.Description,
 		arg.LastRunAt,
 		arg.ID,
@@ -1782,3 +1849,22 @@ func (q *Queries) UpdateGraphQLHeaderDelta(ctx context.Context, arg UpdateGraphQ
 	)
 	return err
 }
+
+const upsertGraphQLSchema = `-- name: UpsertGraphQLSchema :exec
+INSERT INTO graphql_schema (graphql_id, introspection, fetched_at)
+VALUES (?, ?, ?)
+ON CONFLICT(graphql_id) DO UPDATE SET
+  introspection = excluded.introspection,
+  fetched_at = excluded.fetched_at
+`
+
+type UpsertGraphQLSchemaParams struct {
+	GraphqlID     idwrap.IDWrap
+	Introspection string
+	FetchedAt     int64
+}
+
+func (q *Queries) UpsertGraphQLSchema(ctx context.Context, arg UpsertGraphQLSchemaParams) error {
+	_, err := q.exec(ctx, q.upsertGraphQLSchemaStmt, upsertGraphQLSchema, arg.GraphqlID, arg.Introspection, arg.FetchedAt)
+	return err
+}
@@ -79,6 +79,12 @@ type CredentialAnthropic struct {
 	EncryptionType int8
 }
 
+type CredentialDatabase struct {
+	CredentialID     idwrap.IDWrap
+	ConnectionString []byte
+	EncryptionType   int8
+}
+
 type CredentialGemini struct {
 	CredentialID   idwrap.IDWrap
 	ApiKey         []byte
@@ -86,6 +92,12 @@ type CredentialGemini struct {
 	EncryptionType int8
 }
 
+type CredentialMessageBroker struct {
+	CredentialID   idwrap.IDWrap
+	Url            []byte
+	EncryptionType int8
+}
+
 type CredentialOpenai struct {
 	CredentialID   idwrap.IDWrap
 	Token          []byte
@@ -163,6 +175,24 @@ type FlowNodeCondition struct {
 	Expression string
 }
 
+type FlowNodeDbQuery struct {
+	FlowNodeID   idwrap.IDWrap
+	CredentialID []byte
+	Query        string
+	Params       []byte
+	TimeoutMs    int64
+	Assertions   []byte
+}
+
+type FlowNodeExec struct {
+	FlowNodeID idwrap.IDWrap
+	Command    string
+	Args       []byte
+	WorkDir    string
+	TimeoutMs  int64
+	ParseJson  bool
+}
+
 type FlowNodeFor struct {
 	FlowNodeID    idwrap.IDWrap
 	IterCount     int64
@@ -175,6 +205,7 @@ type FlowNodeForEach struct {
 	IterExpression string
 	ErrorHandling  int8
 	Expression     string
+	Concurrency    int32
 }
 
 type FlowNodeGraphql struct {
@@ -183,6 +214,15 @@ type FlowNodeGraphql struct {
 	DeltaGraphqlID []byte
 }
 
+type FlowNodeGraphqlSubscription struct {
+	FlowNodeID        idwrap.IDWrap
+	GraphqlID         idwrap.IDWrap
+	DeltaGraphqlID    []byte
+	ConnectionPayload string
+	MaxEvents         int64
+	TimeoutMs         int64
+}
+
 type FlowNodeHttp struct {
 	FlowNodeID  idwrap.IDWrap
 	HttpID      idwrap.IDWrap
@@ -201,6 +241,40 @@ type FlowNodeMemory struct {
 	WindowSize int32
 }
 
+type FlowNodeMqConsume struct {
+	FlowNodeID       idwrap.IDWrap
+	CredentialID     []byte
+	Topic            string
+	ConsumerGroup    string
+	FilterExpression string
+	TimeoutMs        int64
+	Count            int64
+	Assertions       []byte
+}
+
+type FlowNodeMqPublish struct {
+	FlowNodeID   idwrap.IDWrap
+	CredentialID []byte
+	Topic        string
+	MessageKey   string
+	Message      string
+}
+
+type FlowNodeParallel struct {
+	FlowNodeID  idwrap.IDWrap
+	JoinMode    int8
+	Concurrency int32
+}
+
+type FlowNodePoll struct {
+	FlowNodeID      idwrap.IDWrap
+	UntilExpression string
+	IntervalMs      int64
+	Backoff         float64
+	MaxDurationMs   int64
+	MaxAttempts     int32
+}
+
 type FlowNodeRunSubFlow struct {
 	FlowNodeID     idwrap.IDWrap
 	TargetFlowID   *idwrap.IDWrap
@@ -208,6 +282,34 @@ type FlowNodeRunSubFlow struct {
 	Inputs         []byte
 }
 
+type FlowNodeSocketConnection struct {
+	FlowNodeID    idwrap.IDWrap
+	Network       int8
+	Address       string
+	Tls           bool
+	TlsSkipVerify bool
+	Framing       int8
+	Delimiter     string
+	LengthBytes   int64
+	TimeoutMs     int64
+}
+
+type FlowNodeSocketReceive struct {
+	FlowNodeID               idwrap.IDWrap
+	SocketConnectionNodeName string
+	MatchExpression          string
+	TimeoutMs                int64
+	Count                    int64
+	Assertions               []byte
+}
+
+type FlowNodeSocketSend struct {
+	FlowNodeID               idwrap.IDWrap
+	SocketConnectionNodeName string
+	Message                  string
+	Encoding                 int8
+}
+
 type FlowNodeSubFlowReturn struct {
 	FlowNodeID idwrap.IDWrap
 	Outputs    []byte
@@ -218,20 +320,70 @@ type FlowNodeSubFlowTrigger struct {
 	Params     []byte
 }
 
+type FlowNodeSwitch struct {
+	FlowNodeID idwrap.IDWrap
+	Cases      []byte
+}
+
 type FlowNodeWait struct {
 	FlowNodeID idwrap.IDWrap
 	DurationMs int64
 }
 
+type FlowNodeWebhookTrigger struct {
+	FlowNodeID      idwrap.IDWrap
+	Path            string
+	Method          string
+	Secret          string
+	SignatureHeader string
+}
+
 type FlowNodeWsConnection struct {
 	FlowNodeID  idwrap.IDWrap
 	WebsocketID *idwrap.IDWrap
 }
 
+type FlowNodeWsExpect struct {
+	FlowNodeID           idwrap.IDWrap
+	WsConnectionNodeName string
+	Message              string
+	Binary               bool
+	MatchExpression      string
+	TimeoutMs            int64
+	Count                int64
+	Assertions           []byte
+	Ping                 bool
+	ExpectClose          bool
+}
+
 type FlowNodeWsSend struct {
 	FlowNodeID           idwrap.IDWrap
 	WsConnectionNodeName string
 	Message              string
+	Binary               bool
+	Event                string
+	Ack                  bool
+}
+
+type FlowSchedule struct {
+	ID              idwrap.IDWrap
+	FlowID          idwrap.IDWrap
+	CronExpression  string
+	IntervalSeconds int64
+	EnvironmentID   *idwrap.IDWrap
+	Enabled         bool
+	LastRunAt       sql.NullInt64
+	LastState       int8
+}
+
+type FlowScheduleRun struct {
+	ID            idwrap.IDWrap
+	ScheduleID    idwrap.IDWrap
+	FlowVersionID *idwrap.IDWrap
+	State         int8
+	Error         sql.NullString
+	StartedAt     int64
+	DurationMs    int64
 }
 
 type FlowTag struct {
@@ -258,6 +410,11 @@ type Graphql struct {
 	Url              string
 	Query            string
 	Variables        string
+	OperationName    string
+	QueryFile        string
+	PersistedQuery   bool
+	Batch            []byte
+	Uploads          []byte
 	Description      string
 	LastRunAt        interface{}
 	CreatedAt        int64
@@ -335,6 +492,12 @@ type GraphqlResponseHeader struct {
 	CreatedAt  int64
 }
 
+type GraphqlSchema struct {
+	GraphqlID     idwrap.IDWrap
+	Introspection string
+	FetchedAt     int64
+}
+
 type GraphqlVersion struct {
 	ID                 []byte
 	GraphqlID          []byte
@@ -563,15 +726,19 @@ type Variable struct {
 }
 
 type Websocket struct {
-	ID          idwrap.IDWrap
-	WorkspaceID idwrap.IDWrap
-	FolderID    *idwrap.IDWrap
-	Name        string
-	Url         string
-	Description string
-	LastRunAt   interface{}
-	CreatedAt   int64
-	UpdatedAt   int64
+	ID             idwrap.IDWrap
+	WorkspaceID    idwrap.IDWrap
+	FolderID       *idwrap.IDWrap
+	Name           string
+	Url            string
+	Description    string
+	Subprotocols   []byte
+	Protocol       int8
+	ConnectPayload string
+	Subscriptions  []byte
+	LastRunAt      interface{}
+	CreatedAt      int64
+	UpdatedAt      int64
 }
 
 type WebsocketHeader struct {
@@ -586,6 +753,15 @@ type WebsocketHeader struct {
 	UpdatedAt    int64
 }
 
+type WebsocketMessage struct {
+	ID          idwrap.IDWrap
+	WebsocketID idwrap.IDWrap
+	Direction   string
+	MessageType string
+	Data        string
+	CreatedAt   int64
+}
+
 type Workspace struct {
 	ID              idwrap.IDWrap
 	Name            string
@@ -25,39 +25,91 @@ func (q *Queries) CreateFlowNodeWsConnection(ctx context.Context, arg CreateFlow
 	return err
 }
 
+const createFlowNodeWsExpect = `-- name: CreateFlowNodeWsExpect :exec
+INSERT INTO flow_node_ws_expect (
+  flow_node_id, ws_connection_node_name, message, binary, match_expression,
+  timeout_ms, count, assertions, ping, expect_close
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateFlowNodeWsExpectParams struct {
+	FlowNodeID           idwrap.IDWrap
+	WsConnectionNodeName string
+	Message              string
+	Binary               bool
+	MatchExpression      string
+	TimeoutMs            int64
+	Count                int64
+	Assertions           []byte
+	Ping                 bool
+	ExpectClose          bool
+}
+
+func (q *Queries) CreateFlowNodeWsExpect(ctx context.Context, arg CreateFlowNodeWsExpectParams) error {
+	_, err := q.exec(ctx, q.createFlowNodeWsExpectStmt, createFlowNodeWsExpect,
+		arg.FlowNodeID,
+		arg.WsConnectionNodeName,
+		arg.Message,
+		arg.Binary,
+		arg.MatchExpression,
+		arg.TimeoutMs,
+		arg.Count,
+		arg.Assertions,
+		arg.Ping,
+		arg.ExpectClose,
+	)
+	return err
+}
+
 const createFlowNodeWsSend = `-- name: CreateFlowNodeWsSend :exec
-INSERT INTO flow_node_ws_send (flow_node_id, ws_connection_node_name, message) VALUES (?, ?, ?)
+INSERT INTO flow_node_ws_send (flow_node_id, ws_connection_node_name, message, binary, event, ack) VALUES (?, ?, ?, ?, ?, ?)
 `
 
 type CreateFlowNodeWsSendParams struct {
 	FlowNodeID           idwrap.IDWrap
 	WsConnectionNodeName string
 	Message              string
+	Binary               bool
+	Event                string
+	Ack                  bool
 }
 
 func (q *Queries) CreateFlowNodeWsSend(ctx context.Context, arg CreateFlowNodeWsSendParams) error {
-	_, err := q.exec(ctx, q.createFlowNodeWsSendStmt, createFlowNodeWsSend, arg.FlowNodeID, arg.WsConnectionNodeName, arg.Message)
+	_, err := q.exec(ctx, q.createFlowNodeWsSendStmt, createFlowNodeWsSend,
+		arg.FlowNodeID,
+		arg.WsConnectionNodeName,
+		arg.Message,
+		arg.Binary,
+		arg.Event,
+		arg.Ack,
+	)
 	return err
 }
 
 const createWebSocket = `-- name: CreateWebSocket :exec
 INSERT INTO websocket (
   id, workspace_id, folder_id, name, url,
-  description, last_run_at, created_at, updated_at
+  description, subprotocols, protocol, connect_payload, subscriptions,
+  last_run_at, created_at, updated_at
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateWebSocketParams struct {
-	ID          idwrap.IDWrap
-	WorkspaceID idwrap.IDWrap
-	FolderID    *idwrap.IDWrap
-	Name        string
-	Url         string
-	Description string
-	LastRunAt   interface{}
-	CreatedAt   int64
-	UpdatedAt   int64
+	ID             idwrap.IDWrap
+	WorkspaceID    idwrap.IDWrap
+	FolderID       *idwrap.IDWrap
+	Name           string
+	Url            string
+	Description    string
+	Subprotocols   []byte
+	Protocol       int8
+	ConnectPayload string
+	Subscriptions  []byte
+	LastRunAt      interface{}
+	CreatedAt      int64
+	UpdatedAt      int64
 }
 
 func (q *Queries) CreateWebSocket(ctx context.Context, arg CreateWebSocketParams) error {
@@ -68,6 +120,10 @@ func (q *Queries) CreateWebSocket(ctx context.Context, arg CreateWebSocketParams
 		arg.Name,
 		arg.Url,
 		arg.Description,
+		arg.Subprotocols,
+		arg.Protocol,
+		arg.ConnectPayload,
+		arg.Subscriptions,
 		arg.LastRunAt,
 		arg.CreatedAt,
 		arg.UpdatedAt,
@@ -110,6 +166,34 @@ func (q *Queries) CreateWebSocketHeader(ctx context.Context, arg CreateWebSocket
 	return err
 }
 
+const createWebSocketMessage = `-- name: CreateWebSocketMessage :exec
+INSERT INTO websocket_message (
+  id, websocket_id, direction, message_type, data, created_at
+)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateWebSocketMessageParams struct {
+	ID          idwrap.IDWrap
+	WebsocketID idwrap.IDWrap
+	Direction   string
+	MessageType string
+	Data        string
+	CreatedAt   int64
+}
+
+func (q *Queries) CreateWebSocketMessage(ctx context.Context, arg CreateWebSocketMessageParams) error {
+	_, err := q.exec(ctx, q.createWebSocketMessageStmt, createWebSocketMessage,
+		arg.ID,
+		arg.WebsocketID,
+		arg.Direction,
+		arg.MessageType,
+		arg.Data,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const deleteFlowNodeWsConnection = `-- name: DeleteFlowNodeWsConnection :exec
 DELETE FROM flow_node_ws_connection WHERE flow_node_id = ?
 `
@@ -119,6 +203,15 @@ func (q *Queries) DeleteFlowNodeWsConnection(ctx context.Context, flowNodeID idw
 	return err
 }
 
+const deleteFlowNodeWsExpect = `-- name: DeleteFlowNodeWsExpect :exec
+DELETE FROM flow_node_ws_expect WHERE flow_node_id = ?
+`
+
+func (q *Queries) DeleteFlowNodeWsExpect(ctx context.Context, flowNodeID idwrap.IDWrap) error {
+	_, err := q.exec(ctx, q.deleteFlowNodeWsExpectStmt, deleteFlowNodeWsExpect, flowNodeID)
+	return err
+}
+
 const deleteFlowNodeWsSend = `-- name: DeleteFlowNodeWsSend :exec
 DELETE FROM flow_node_ws_send WHERE flow_node_id = ?
 `
@@ -158,6 +251,16 @@ func (q *Queries) DeleteWebSocketHeadersByWebSocketID(ctx context.Context, webso
 	return err
 }
 
+const deleteWebSocketMessagesByWebSocketID = `-- name: DeleteWebSocketMessagesByWebSocketID :exec
+DELETE FROM websocket_message
+WHERE websocket_id = ?
+`
+
+func (q *Queries) DeleteWebSocketMessagesByWebSocketID(ctx context.Context, websocketID idwrap.IDWrap) error {
+	_, err := q.exec(ctx, q.deleteWebSocketMessagesByWebSocketIDStmt, deleteWebSocketMessagesByWebSocketID, websocketID)
+	return err
+}
+
 const getFlowNodeWsConnection = `-- name: GetFlowNodeWsConnection :one
 
 SELECT
@@ -176,11 +279,49 @@ func (q *Queries) GetFlowNodeWsConnection(ctx context.Context, flowNodeID idwrap
 	return i, err
 }
 
+const getFlowNodeWsExpect = `-- name: GetFlowNodeWsExpect :one
+SELECT
+  flow_node_id,
+  ws_connection_node_name,
+  message,
+  binary,
+  match_expression,
+  timeout_ms,
+  count,
+  assertions,
+  ping,
+  expect_close
+FROM flow_node_ws_expect
+WHERE flow_node_id = ?
+LIMIT 1
+`
+
+func (q *Queries) GetFlowNodeWsExpect(ctx context.Context, flowNodeID idwrap.IDWrap) (FlowNodeWsExpect, error) {
+	row := q.queryRow(ctx, q.getFlowNodeWsExpectStmt, getFlowNodeWsExpect, flowNodeID)
+	var i FlowNodeWsExpect
+	err := row.Scan(
+		&i.FlowNodeID,
+		&i.WsConnectionNodeName,
+		&i.Message,
+		&i.Binary,
+		&i.MatchExpression,
+		&i.TimeoutMs,
+		&i.Count,
+		&i.Assertions,
+		&i.Ping,
+		&i.ExpectClose,
+	)
+	return i, err
+}
+
 const getFlowNodeWsSend = `-- name: GetFlowNodeWsSend :one
 SELECT
   flow_node_id,
   ws_connection_node_name,
-  message
+  message,
+  binary,
+  event,
+  ack
 FROM flow_node_ws_send
 WHERE flow_node_id = ?
 LIMIT 1
@@ -189,7 +330,14 @@ LIMIT 1
 func (q *Queries) GetFlowNodeWsSend(ctx context.Context, flowNodeID idwrap.IDWrap) (FlowNodeWsSend, error) {
 	row := q.queryRow(ctx, q.getFlowNodeWsSendStmt, getFlowNodeWsSend, flowNodeID)
 	var i FlowNodeWsSend
-	err := row.Scan(&i.FlowNodeID, &i.WsConnectionNodeName, &i.Message)
+	err := row.Scan(
+		&i.FlowNodeID,
+		&i.WsConnectionNodeName,
+		&i.Message,
+		&i.Binary,
+		&i.Event,
+		&i.Ack,
+	)
 	return i, err
 }
 
@@ -197,7 +345,8 @@ const getWebSocket = `-- name: GetWebSocket :one
 
 SELECT
   id, workspace_id, folder_id, name, url,
-  description, last_run_at, created_at, updated_at
+  description, subprotocols, protocol, connect_payload, subscriptions,
+  last_run_at, created_at, updated_at
 FROM websocket
 WHERE id = ? LIMIT 1
 `
@@ -213,6 +362,10 @@ func (q *Queries) GetWebSocket(ctx context.Context, id idwrap.IDWrap) (Websocket
 		&i.Name,
 		&i.Url,
 		&i.Description,
+		&i.Subprotocols,
+		&i.Protocol,
+		&i.ConnectPayload,
+		&i.Subscriptions,
 		&i.LastRunAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -289,6 +442,46 @@ func (q *Queries) GetWebSocketHeaders(ctx context.Context, websocketID idwrap.ID
 	return items, nil
 }
 
+const getWebSocketMessages = `-- name: GetWebSocketMessages :many
+
+SELECT
+  id, websocket_id, direction, message_type, data, created_at
+FROM websocket_message
+WHERE websocket_id = ?
+ORDER BY id
+`
+
+// WebSocket Message Queries
+func (q *Queries) GetWebSocketMessages(ctx context.Context, websocketID idwrap.IDWrap) ([]WebsocketMessage, error) {
+	rows, err := q.query(ctx, q.getWebSocketMessagesStmt, getWebSocketMessages, websocketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebsocketMessage{}
+	for rows.Next() {
+		var i WebsocketMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebsocketID,
+			&i.Direction,
+			&i.MessageType,
+			&i.Data,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWebSocketWorkspaceID = `-- name: GetWebSocketWorkspaceID :one
 SELECT workspace_id
 FROM websocket
@@ -306,7 +499,8 @@ func (q *Queries) GetWebSocketWorkspaceID(ctx context.Context, id idwrap.IDWrap)
 const getWebSocketsByWorkspaceID = `-- name: GetWebSocketsByWorkspaceID :many
 SELECT
   id, workspace_id, folder_id, name, url,
-  description, last_run_at, created_at, updated_at
+  description, subprotocols, protocol, connect_payload, subscriptions,
+  last_run_at, created_at, updated_at
 FROM websocket
 WHERE workspace_id = ?
 ORDER BY updated_at DESC
@@ -328,6 +522,10 @@ func (q *Queries) GetWebSocketsByWorkspaceID(ctx context.Context, workspaceID id
 			&i.Name,
 			&i.Url,
 			&i.Description,
+			&i.Subprotocols,
+			&i.Protocol,
+			&i.ConnectPayload,
+			&i.Subscriptions,
 			&i.LastRunAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -361,21 +559,81 @@ func (q *Queries) UpdateFlowNodeWsConnection(ctx context.Context, arg UpdateFlow
 	return err
 }
 
+const updateFlowNodeWsExpect = `-- name: UpdateFlowNodeWsExpect :exec
+INSERT INTO flow_node_ws_expect (
+  flow_node_id, ws_connection_node_name, message, binary, match_expression,
+  timeout_ms, count, assertions, ping, expect_close
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  ws_connection_node_name = excluded.ws_connection_node_name,
+  message = excluded.message,
+  binary = excluded.binary,
+  match_expression = excluded.match_expression,
+  timeout_ms = excluded.timeout_ms,
+  count = excluded.count,
+  assertions = excluded.assertions,
+  ping = excluded.ping,
+  expect_close = excluded.expect_close
+`
+
+type UpdateFlowNodeWsExpectParams struct {
+	FlowNodeID           idwrap.IDWrap
+	WsConnectionNodeName string
+	Message              string
+	Binary               bool
+	MatchExpression      string
+	TimeoutMs            int64
+	Count                int64
+	Assertions           []byte
+	Ping                 bool
+	ExpectClose          bool
+}
+
+func (q *Queries) UpdateFlowNodeWsExpect(ctx context.Context, arg UpdateFlowNodeWsExpectParams) error {
+	_, err := q.exec(ctx, q.updateFlowNodeWsExpectStmt, updateFlowNodeWsExpect,
+		arg.FlowNodeID,
+		arg.WsConnectionNodeName,
+		arg.Message,
+		arg.Binary,
+		arg.MatchExpression,
+		arg.TimeoutMs,
+		arg.Count,
+		arg.Assertions,
+		arg.Ping,
+		arg.ExpectClose,
+	)
+	return err
+}
+
 const updateFlowNodeWsSend = `-- name: UpdateFlowNodeWsSend :exec
-INSERT INTO flow_node_ws_send (flow_node_id, ws_connection_node_name, message) VALUES (?, ?, ?)
+INSERT INTO flow_node_ws_send (flow_node_id, ws_connection_node_name, message, binary, event, ack) VALUES (?, ?, ?, ?, ?, ?)
 ON CONFLICT(flow_node_id) DO UPDATE SET
   ws_connection_node_name = excluded.ws_connection_node_name,
-  message = excluded.message
+  message = excluded.message,
+  binary = excluded.binary,
+  event = excluded.event,
+  ack = excluded.ack
 `
 
 type UpdateFlowNodeWsSendParams struct {
 	FlowNodeID           idwrap.IDWrap
 	WsConnectionNodeName string
 	Message              string
+	Binary               bool
+	Event                string
+	Ack                  bool
 }
 
 func (q *Queries) UpdateFlowNodeWsSend(ctx context.Context, arg UpdateFlowNodeWsSendParams) error {
-	_, err := q.exec(ctx, q.updateFlowNodeWsSendStmt, updateFlowNodeWsSend, arg.FlowNodeID, arg.WsConnectionNodeName, arg.Message)
+	_, err := q.exec(ctx, q.updateFlowNodeWsSendStmt, updateFlowNodeWsSend,
+		arg.FlowNodeID,
+		arg.WsConnectionNodeName,
+		arg.Message,
+		arg.Binary,
+		arg.Event,
+		arg.Ack,
+	)
 	return err
 }
 
@@ -385,17 +643,25 @@ SET
   name = ?,
   url = ?,
   description = ?,
+  subprotocols = ?,
+  protocol = ?,
+  connect_payload = ?,
+  subscriptions = ?,
   last_run_at = COALESCE(?, last_run_at),
   updated_at = unixepoch()
 WHERE id = ?
 `
 
 type UpdateWebSocketParams struct {
-	Name        string
-	Url         string
-	Description string
-	LastRunAt   interface{}
-	ID          idwrap.IDWrap
+	Name           string
+	Url            string
+	Description    string
+	Subprotocols   []byte
+	Protocol       int8
+	ConnectPayload string
+	Subscriptions  []byte
+	LastRunAt      interface{}
+	ID             idwrap.IDWrap
 }
 
 func (q *Queries) UpdateWebSocket(ctx context.Context, arg UpdateWebSocketParams) error {
@@ -403,6 +669,10 @@ func (q *Queries) UpdateWebSocket(ctx context.Context, arg UpdateWebSocketParams
 		arg.Name,
 		arg.Url,
 		arg.Description,
+		arg.Subprotocols,
+		arg.Protocol,
+		arg.ConnectPayload,
+		arg.Subscriptions,
 		arg.LastRunAt,
 		arg.ID,
 	)
@@ -0,0 +1,70 @@
+--
+-- Credential Database
+--
+
+-- name: GetCredentialDatabase :one
+SELECT
+  credential_id,
+  connection_string,
+  encryption_type
+FROM
+  credential_database
+WHERE
+  credential_id = ?
+LIMIT 1;
+
+-- name: CreateCredentialDatabase :exec
+INSERT INTO
+  credential_database (credential_id, connection_string, encryption_type)
+VALUES
+  (?, ?, ?);
+
+-- name: UpdateCredentialDatabase :exec
+UPDATE credential_database
+SET
+  connection_string = ?,
+  encryption_type = ?
+WHERE
+  credential_id = ?;
+
+-- name: DeleteCredentialDatabase :exec
+DELETE FROM credential_database
+WHERE
+  credential_id = ?;
+
+--
+-- Flow Node DB Query
+--
+
+-- name: GetFlowNodeDbQuery :one
+SELECT
+  flow_node_id,
+  credential_id,
+  query,
+  params,
+  timeout_ms,
+  assertions
+FROM flow_node_db_query
+WHERE flow_node_id = ?
+LIMIT 1;
+
+-- name: CreateFlowNodeDbQuery :exec
+INSERT INTO flow_node_db_query (
+  flow_node_id, credential_id, query, params, timeout_ms, assertions
+)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodeDbQuery :exec
+INSERT INTO flow_node_db_query (
+  flow_node_id, credential_id, query, params, timeout_ms, assertions
+)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  credential_id = excluded.credential_id,
+  query = excluded.query,
+  params = excluded.params,
+  timeout_ms = excluded.timeout_ms,
+  assertions = excluded.assertions;
+
+-- name: DeleteFlowNodeDbQuery :exec
+DELETE FROM flow_node_db_query WHERE flow_node_id = ?;
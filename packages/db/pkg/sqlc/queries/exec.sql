@@ -0,0 +1,36 @@
+--
+-- Flow Node Exec
+--
+
+-- name: GetFlowNodeExec :one
+SELECT
+  flow_node_id,
+  command,
+  args,
+  work_dir,
+  timeout_ms,
+  parse_json
+FROM flow_node_exec
+WHERE flow_node_id = ?
+LIMIT 1;
+
+-- name: CreateFlowNodeExec :exec
+INSERT INTO flow_node_exec (
+  flow_node_id, command, args, work_dir, timeout_ms, parse_json
+)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodeExec :exec
+INSERT INTO flow_node_exec (
+  flow_node_id, command, args, work_dir, timeout_ms, parse_json
+)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  command = excluded.command,
+  args = excluded.args,
+  work_dir = excluded.work_dir,
+  timeout_ms = excluded.timeout_ms,
+  parse_json = excluded.parse_json;
+
+-- name: DeleteFlowNodeExec :exec
+DELETE FROM flow_node_exec WHERE flow_node_id = ?;
@@ -372,7 +372,8 @@ SELECT
   flow_node_id,
   iter_expression,
   error_handling,
-  expression
+  expression,
+  concurrency
 FROM
   flow_node_for_each
 WHERE
@@ -381,16 +382,17 @@ LIMIT 1;
 
 -- name: CreateFlowNodeForEach :exec
 INSERT INTO
-  flow_node_for_each (flow_node_id, iter_expression, error_handling, expression)
+  flow_node_for_each (flow_node_id, iter_expression, error_handling, expression, concurrency)
 VALUES
-  (?, ?, ?, ?);
+  (?, ?, ?, ?, ?);
 
 -- name: UpdateFlowNodeForEach :exec
 UPDATE flow_node_for_each
 SET
   iter_expression = ?,
   error_handling = ?,
-  expression = ?
+  expression = ?,
+  concurrency = ?
 WHERE
   flow_node_id = ?;
 
@@ -463,6 +465,44 @@ DELETE FROM flow_node_graphql WHERE flow_node_id = ?;
 -- name: CleanupOrphanedFlowNodeGraphQL :exec
 DELETE FROM flow_node_graphql WHERE flow_node_id NOT IN (SELECT id FROM flow_node);
 
+-- name: GetFlowNodeGraphQLSubscription :one
+SELECT
+  flow_node_id,
+  graphql_id,
+  delta_graphql_id,
+  connection_payload,
+  max_events,
+  timeout_ms
+FROM
+  flow_node_graphql_subscription
+WHERE
+  flow_node_id = ?
+LIMIT 1;
+
+-- name: CreateFlowNodeGraphQLSubscription :exec
+INSERT INTO
+  flow_node_graphql_subscription (flow_node_id, graphql_id, delta_graphql_id, connection_payload, max_events, timeout_ms)
+VALUES
+  (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodeGraphQLSubscription :exec
+INSERT INTO
+  flow_node_graphql_subscription (flow_node_id, graphql_id, delta_graphql_id, connection_payload, max_events, timeout_ms)
+VALUES
+  (?, ?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  graphql_id = excluded.graphql_id,
+  delta_graphql_id = excluded.delta_graphql_id,
+  connection_payload = excluded.connection_payload,
+  max_events = excluded.max_events,
+  timeout_ms = excluded.timeout_ms;
+
+-- name: DeleteFlowNodeGraphQLSubscription :exec
+DELETE FROM flow_node_graphql_subscription WHERE flow_node_id = ?;
+
+-- name: CleanupOrphanedFlowNodeGraphQLSubscription :exec
+DELETE FROM flow_node_graphql_subscription WHERE flow_node_id NOT IN (SELECT id FROM flow_node);
+
 -- name: GetFlowNodeCondition :one
 SELECT
   flow_node_id,
@@ -549,6 +589,147 @@ DELETE FROM flow_node_wait
 WHERE
   flow_node_id = ?;
 
+-- name: GetFlowNodeParallel :one
+SELECT
+  flow_node_id,
+  join_mode,
+  concurrency
+FROM
+  flow_node_parallel
+WHERE
+  flow_node_id = ?;
+
+-- name: CreateFlowNodeParallel :exec
+INSERT INTO
+  flow_node_parallel (flow_node_id, join_mode, concurrency)
+VALUES
+  (?, ?, ?);
+
+-- name: UpdateFlowNodeParallel :exec
+UPDATE flow_node_parallel
+SET
+  join_mode = ?,
+  concurrency = ?
+WHERE
+  flow_node_id = ?;
+
+-- name: DeleteFlowNodeParallel :exec
+DELETE FROM flow_node_parallel
+WHERE
+  flow_node_id = ?;
+
+-- name: GetFlowNodePoll :one
+SELECT
+  flow_node_id,
+  until_expression,
+  interval_ms,
+  backoff,
+  max_duration_ms,
+  max_attempts
+FROM
+  flow_node_poll
+WHERE
+  flow_node_id = ?;
+
+-- name: CreateFlowNodePoll :exec
+INSERT INTO
+  flow_node_poll (flow_node_id, until_expression, interval_ms, backoff, max_duration_ms, max_attempts)
+VALUES
+  (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodePoll :exec
+UPDATE flow_node_poll
+SET
+  until_expression = ?,
+  interval_ms = ?,
+  backoff = ?,
+  max_duration_ms = ?,
+  max_attempts = ?
+WHERE
+  flow_node_id = ?;
+
+-- name: DeleteFlowNodePoll :exec
+DELETE FROM flow_node_poll
+WHERE
+  flow_node_id = ?;
+
+-- name: GetFlowNodeSwitch :one
+SELECT
+  flow_node_id,
+  cases
+FROM
+  flow_node_switch
+WHERE
+  flow_node_id = ?;
+
+-- name: CreateFlowNodeSwitch :exec
+INSERT INTO
+  flow_node_switch (flow_node_id, cases)
+VALUES
+  (?, ?);
+
+-- name: UpdateFlowNodeSwitch :exec
+UPDATE flow_node_switch
+SET
+  cases = ?
+WHERE
+  flow_node_id = ?;
+
+-- name: DeleteFlowNodeSwitch :exec
+DELETE FROM flow_node_switch
+WHERE
+  flow_node_id = ?;
+
+-- name: GetFlowNodeWebhookTrigger :one
+SELECT
+  flow_node_id,
+  path,
+  method,
+  secret,
+  signature_header
+FROM
+  flow_node_webhook_trigger
+WHERE
+  flow_node_id = ?;
+
+-- name: GetFlowNodeWebhookTriggersByPath :many
+-- Webhook triggers listening on a path, excluding those of flow versions.
+SELECT
+  w.flow_node_id,
+  w.path,
+  w.method,
+  w.secret,
+  w.signature_header,
+  n.flow_id
+FROM
+  flow_node_webhook_trigger w
+  INNER JOIN flow_node n ON n.id = w.flow_node_id
+  INNER JOIN flow f ON f.id = n.flow_id
+WHERE
+  w.path = ?
+  AND f.version_parent_id IS NULL;
+
+-- name: CreateFlowNodeWebhookTrigger :exec
+INSERT INTO
+  flow_node_webhook_trigger (flow_node_id, path, method, secret, signature_header)
+VALUES
+  (?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodeWebhookTrigger :exec
+UPDATE flow_node_webhook_trigger
+SET
+  path = ?,
+  method = ?,
+  secret = ?,
+  signature_header = ?
+WHERE
+  flow_node_id = ?;
+
+-- name: DeleteFlowNodeWebhookTrigger :exec
+DELETE FROM flow_node_webhook_trigger
+WHERE
+  flow_node_id = ?;
+
 -- name: GetMigration :one
 SELECT
   id,
@@ -675,6 +856,123 @@ SET
 WHERE
   id = ?;
 
+-- Flow Schedule
+-- name: GetFlowSchedule :one
+SELECT
+  id,
+  flow_id,
+  cron_expression,
+  interval_seconds,
+  environment_id,
+  enabled,
+  last_run_at,
+  last_state
+FROM
+  flow_schedule
+WHERE
+  id = ?
+LIMIT
+  1;
+
+-- name: GetFlowSchedulesByFlowID :many
+SELECT
+  id,
+  flow_id,
+  cron_expression,
+  interval_seconds,
+  environment_id,
+  enabled,
+  last_run_at,
+  last_state
+FROM
+  flow_schedule
+WHERE
+  flow_id = ?;
+
+-- name: GetEnabledFlowSchedules :many
+SELECT
+  id,
+  flow_id,
+  cron_expression,
+  interval_seconds,
+  environment_id,
+  enabled,
+  last_run_at,
+  last_state
+FROM
+  flow_schedule
+WHERE
+  enabled = TRUE;
+
+-- name: CreateFlowSchedule :exec
+INSERT INTO
+  flow_schedule (id, flow_id, cron_expression, interval_seconds, environment_id, enabled)
+VALUES
+  (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateFlowSchedule :exec
+UPDATE flow_schedule
+SET
+  cron_expression = ?,
+  interval_seconds = ?,
+  environment_id = ?,
+  enabled = ?
+WHERE
+  id = ?;
+
+-- name: UpdateFlowScheduleLastRun :exec
+UPDATE flow_schedule
+SET
+  last_run_at = ?,
+  last_state = ?
+WHERE
+  id = ?;
+
+-- name: DeleteFlowSchedule :exec
+DELETE FROM flow_schedule
+WHERE
+  id = ?;
+
+-- name: CreateFlowScheduleRun :exec
+INSERT INTO
+  flow_schedule_run (id, schedule_id, flow_version_id, state, error, started_at, duration_ms)
+VALUES
+  (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetFlowScheduleRunsByScheduleID :many
+SELECT
+  id,
+  schedule_id,
+  flow_version_id,
+  state,
+  error,
+  started_at,
+  duration_ms
+FROM
+  flow_schedule_run
+WHERE
+  schedule_id = ?
+ORDER BY
+  started_at DESC;
+
+-- name: PruneFlowScheduleRuns :exec
+-- Keeps only the most recent runs of a schedule
+DELETE FROM flow_schedule_run
+WHERE
+  schedule_id = ?
+  AND id NOT IN (
+    SELECT
+      id
+    FROM
+      flow_schedule_run AS recent
+    WHERE
+      recent.schedule_id = ?
+    ORDER BY
+      recent.started_at DESC
+    LIMIT
+      ?
+  );
+
 -- Node Execution
 -- name: GetNodeExecution :one
 SELECT * FROM node_execution
@@ -771,6 +1069,18 @@ DELETE FROM flow_node_js WHERE flow_node_id NOT IN (SELECT id FROM flow_node);
 -- name: CleanupOrphanedFlowNodeWait :exec
 DELETE FROM flow_node_wait WHERE flow_node_id NOT IN (SELECT id FROM flow_node);
 
+-- name: CleanupOrphanedFlowNodeParallel :exec
+DELETE FROM flow_node_parallel WHERE flow_node_id NOT IN (SELECT id FROM flow_node);
+
+-- name: CleanupOrphanedFlowNodePoll :exec
+DELETE FROM flow_node_poll WHERE flow_node_id NOT IN (SELECT id FROM flow_node);
+
+-- name: CleanupOrphanedFlowNodeSwitch :exec
+DELETE FROM flow_node_switch WHERE flow_node_id NOT IN (SELECT id FROM flow_node);
+
+-- name: CleanupOrphanedFlowNodeWebhookTrigger :exec
+DELETE FROM flow_node_webhook_trigger WHERE flow_node_id NOT IN (SELECT id FROM flow_node);
+
 -- Sub-Flow Trigger
 -- name: GetFlowNodeSubFlowTrigger :one
 SELECT flow_node_id, params
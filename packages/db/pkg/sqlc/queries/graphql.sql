@@ -5,7 +5,7 @@
 -- name: GetGraphQL :one
 SELECT
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 FROM graphql
@@ -14,7 +14,7 @@ WHERE id = ? LIMIT 1;
 -- name: GetGraphQLsByWorkspaceID :many
 SELECT
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 FROM graphql
@@ -30,11 +30,11 @@ LIMIT 1;
 -- name: CreateGraphQL :exec
 INSERT INTO graphql (
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: UpdateGraphQL :exec
 UPDATE graphql
@@ -43,6 +43,11 @@ SET
   url = ?,
   query = ?,
   variables = ?,
+  operation_name = ?,
+  query_file = ?,
+  persisted_query = ?,
+  batch = ?,
+  uploads = ?,
   description = ?,
   last_run_at = COALESCE(?, last_run_at),
   updated_at = unixepoch()
@@ -208,7 +213,7 @@ DELETE FROM graphql_response_header WHERE id = ?;
 -- name: GetGraphQLDeltasByWorkspaceID :many
 SELECT
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 FROM graphql
@@ -218,7 +223,7 @@ ORDER BY updated_at DESC;
 -- name: GetGraphQLDeltasByParentID :many
 SELECT
   id, workspace_id, folder_id, name, url, query, variables,
-  description, last_run_at, created_at, updated_at,
+  operation_name, query_file, persisted_query, batch, uploads, description, last_run_at, created_at, updated_at,
   parent_graphql_id, is_delta, is_snapshot,
   delta_name, delta_url, delta_query, delta_variables, delta_description
 FROM graphql
@@ -440,3 +445,22 @@ FROM graphql_response_assert gra
 INNER JOIN graphql_response gr ON gra.response_id = gr.id
 INNER JOIN graphql g ON gr.graphql_id = g.id
 WHERE g.workspace_id = ?;
+
+--
+-- GraphQL Schema Queries
+--
+
+-- name: GetGraphQLSchema :one
+SELECT graphql_id, introspection, fetched_at
+FROM graphql_schema
+WHERE graphql_id = ? LIMIT 1;
+
+-- name: UpsertGraphQLSchema :exec
+INSERT INTO graphql_schema (graphql_id, introspection, fetched_at)
+VALUES (?, ?, ?)
+ON CONFLICT(graphql_id) DO UPDATE SET
+  introspection = excluded.introspection,
+  fetched_at = excluded.fetched_at;
+
+-- name: DeleteGraphQLSchema :exec
+DELETE FROM graphql_schema WHERE graphql_id = ?;
@@ -0,0 +1,109 @@
+--
+-- Credential Message Broker
+--
+
+-- name: GetCredentialMessageBroker :one
+SELECT
+  credential_id,
+  url,
+  encryption_type
+FROM
+  credential_message_broker
+WHERE
+  credential_id = ?
+LIMIT 1;
+
+-- name: CreateCredentialMessageBroker :exec
+INSERT INTO
+  credential_message_broker (credential_id, url, encryption_type)
+VALUES
+  (?, ?, ?);
+
+-- name: UpdateCredentialMessageBroker :exec
+UPDATE credential_message_broker
+SET
+  url = ?,
+  encryption_type = ?
+WHERE
+  credential_id = ?;
+
+-- name: DeleteCredentialMessageBroker :exec
+DELETE FROM credential_message_broker
+WHERE
+  credential_id = ?;
+
+--
+-- Flow Node MQ Publish
+--
+
+-- name: GetFlowNodeMqPublish :one
+SELECT
+  flow_node_id,
+  credential_id,
+  topic,
+  message_key,
+  message
+FROM flow_node_mq_publish
+WHERE flow_node_id = ?
+LIMIT 1;
+
+-- name: CreateFlowNodeMqPublish :exec
+INSERT INTO flow_node_mq_publish (
+  flow_node_id, credential_id, topic, message_key, message
+)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodeMqPublish :exec
+INSERT INTO flow_node_mq_publish (
+  flow_node_id, credential_id, topic, message_key, message
+)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  credential_id = excluded.credential_id,
+  topic = excluded.topic,
+  message_key = excluded.message_key,
+  message = excluded.message;
+
+-- name: DeleteFlowNodeMqPublish :exec
+DELETE FROM flow_node_mq_publish WHERE flow_node_id = ?;
+
+--
+-- Flow Node MQ Consume
+--
+
+-- name: GetFlowNodeMqConsume :one
+SELECT
+  flow_node_id,
+  credential_id,
+  topic,
+  consumer_group,
+  filter_expression,
+  timeout_ms,
+  count,
+  assertions
+FROM flow_node_mq_consume
+WHERE flow_node_id = ?
+LIMIT 1;
+
+-- name: CreateFlowNodeMqConsume :exec
+INSERT INTO flow_node_mq_consume (
+  flow_node_id, credential_id, topic, consumer_group, filter_expression, timeout_ms, count, assertions
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodeMqConsume :exec
+INSERT INTO flow_node_mq_consume (
+  flow_node_id, credential_id, topic, consumer_group, filter_expression, timeout_ms, count, assertions
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  credential_id = excluded.credential_id,
+  topic = excluded.topic,
+  consumer_group = excluded.consumer_group,
+  filter_expression = excluded.filter_expression,
+  timeout_ms = excluded.timeout_ms,
+  count = excluded.count,
+  assertions = excluded.assertions;
+
+-- name: DeleteFlowNodeMqConsume :exec
+DELETE FROM flow_node_mq_consume WHERE flow_node_id = ?;
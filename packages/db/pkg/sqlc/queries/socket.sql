@@ -0,0 +1,111 @@
+--
+-- Flow Node Socket Connection
+--
+
+-- name: GetFlowNodeSocketConnection :one
+SELECT
+  flow_node_id,
+  network,
+  address,
+  tls,
+  tls_skip_verify,
+  framing,
+  delimiter,
+  length_bytes,
+  timeout_ms
+FROM flow_node_socket_connection
+WHERE flow_node_id = ?
+LIMIT 1;
+
+-- name: CreateFlowNodeSocketConnection :exec
+INSERT INTO flow_node_socket_connection (
+  flow_node_id, network, address, tls, tls_skip_verify, framing, delimiter,
+  length_bytes, timeout_ms
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodeSocketConnection :exec
+INSERT INTO flow_node_socket_connection (
+  flow_node_id, network, address, tls, tls_skip_verify, framing, delimiter,
+  length_bytes, timeout_ms
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  network = excluded.network,
+  address = excluded.address,
+  tls = excluded.tls,
+  tls_skip_verify = excluded.tls_skip_verify,
+  framing = excluded.framing,
+  delimiter = excluded.delimiter,
+  length_bytes = excluded.length_bytes,
+  timeout_ms = excluded.timeout_ms;
+
+-- name: DeleteFlowNodeSocketConnection :exec
+DELETE FROM flow_node_socket_connection WHERE flow_node_id = ?;
+
+--
+-- Flow Node Socket Send
+--
+
+-- name: GetFlowNodeSocketSend :one
+SELECT
+  flow_node_id,
+  socket_connection_node_name,
+  message,
+  encoding
+FROM flow_node_socket_send
+WHERE flow_node_id = ?
+LIMIT 1;
+
+-- name: CreateFlowNodeSocketSend :exec
+INSERT INTO flow_node_socket_send (flow_node_id, socket_connection_node_name, message, encoding) VALUES (?, ?, ?, ?);
+
+-- name: UpdateFlowNodeSocketSend :exec
+INSERT INTO flow_node_socket_send (flow_node_id, socket_connection_node_name, message, encoding)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  socket_connection_node_name = excluded.socket_connection_node_name,
+  message = excluded.message,
+  encoding = excluded.encoding;
+
+-- name: DeleteFlowNodeSocketSend :exec
+DELETE FROM flow_node_socket_send WHERE flow_node_id = ?;
+
+--
+-- Flow Node Socket Receive
+--
+
+-- name: GetFlowNodeSocketReceive :one
+SELECT
+  flow_node_id,
+  socket_connection_node_name,
+  match_expression,
+  timeout_ms,
+  count,
+  assertions
+FROM flow_node_socket_receive
+WHERE flow_node_id = ?
+LIMIT 1;
+
+-- name: CreateFlowNodeSocketReceive :exec
+INSERT INTO flow_node_socket_receive (
+  flow_node_id, socket_connection_node_name, match_expression, timeout_ms,
+  count, assertions
+)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodeSocketReceive :exec
+INSERT INTO flow_node_socket_receive (
+  flow_node_id, socket_connection_node_name, match_expression, timeout_ms,
+  count, assertions
+)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  socket_connection_node_name = excluded.socket_connection_node_name,
+  match_expression = excluded.match_expression,
+  timeout_ms = excluded.timeout_ms,
+  count = excluded.count,
+  assertions = excluded.assertions;
+
+-- name: DeleteFlowNodeSocketReceive :exec
+DELETE FROM flow_node_socket_receive WHERE flow_node_id = ?;
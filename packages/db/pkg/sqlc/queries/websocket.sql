@@ -5,14 +5,16 @@
 -- name: GetWebSocket :one
 SELECT
   id, workspace_id, folder_id, name, url,
-  description, last_run_at, created_at, updated_at
+  description, subprotocols, protocol, connect_payload, subscriptions,
+  last_run_at, created_at, updated_at
 FROM websocket
 WHERE id = ? LIMIT 1;
 
 -- name: GetWebSocketsByWorkspaceID :many
 SELECT
   id, workspace_id, folder_id, name, url,
-  description, last_run_at, created_at, updated_at
+  description, subprotocols, protocol, connect_payload, subscriptions,
+  last_run_at, created_at, updated_at
 FROM websocket
 WHERE workspace_id = ?
 ORDER BY updated_at DESC;
@@ -26,9 +28,10 @@ LIMIT 1;
 -- name: CreateWebSocket :exec
 INSERT INTO websocket (
   id, workspace_id, folder_id, name, url,
-  description, last_run_at, created_at, updated_at
+  description, subprotocols, protocol, connect_payload, subscriptions,
+  last_run_at, created_at, updated_at
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: UpdateWebSocket :exec
 UPDATE websocket
@@ -36,6 +39,10 @@ SET
   name = ?,
   url = ?,
   description = ?,
+  subprotocols = ?,
+  protocol = ?,
+  connect_payload = ?,
+  subscriptions = ?,
   last_run_at = COALESCE(?, last_run_at),
   updated_at = unixepoch()
 WHERE id = ?;
@@ -89,6 +96,27 @@ WHERE id = ?;
 DELETE FROM websocket_header
 WHERE websocket_id = ?;
 
+--
+-- WebSocket Message Queries
+--
+
+-- name: GetWebSocketMessages :many
+SELECT
+  id, websocket_id, direction, message_type, data, created_at
+FROM websocket_message
+WHERE websocket_id = ?
+ORDER BY id;
+
+-- name: CreateWebSocketMessage :exec
+INSERT INTO websocket_message (
+  id, websocket_id, direction, message_type, data, created_at
+)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: DeleteWebSocketMessagesByWebSocketID :exec
+DELETE FROM websocket_message
+WHERE websocket_id = ?;
+
 --
 -- Flow Node WebSocket Queries
 --
@@ -116,19 +144,68 @@ DELETE FROM flow_node_ws_connection WHERE flow_node_id = ?;
 SELECT
   flow_node_id,
   ws_connection_node_name,
-  message
+  message,
+  binary,
+  event,
+  ack
 FROM flow_node_ws_send
 WHERE flow_node_id = ?
 LIMIT 1;
 
 -- name: CreateFlowNodeWsSend :exec
-INSERT INTO flow_node_ws_send (flow_node_id, ws_connection_node_name, message) VALUES (?, ?, ?);
+INSERT INTO flow_node_ws_send (flow_node_id, ws_connection_node_name, message, binary, event, ack) VALUES (?, ?, ?, ?, ?, ?);
 
 -- name: UpdateFlowNodeWsSend :exec
-INSERT INTO flow_node_ws_send (flow_node_id, ws_connection_node_name, message) VALUES (?, ?, ?)
+INSERT INTO flow_node_ws_send (flow_node_id, ws_connection_node_name, message, binary, event, ack) VALUES (?, ?, ?, ?, ?, ?)
 ON CONFLICT(flow_node_id) DO UPDATE SET
   ws_connection_node_name = excluded.ws_connection_node_name,
-  message = excluded.message;
+  message = excluded.message,
+  binary = excluded.binary,
+  event = excluded.event,
+  ack = excluded.ack;
 
 -- name: DeleteFlowNodeWsSend :exec
 DELETE FROM flow_node_ws_send WHERE flow_node_id = ?;
+
+-- name: GetFlowNodeWsExpect :one
+SELECT
+  flow_node_id,
+  ws_connection_node_name,
+  message,
+  binary,
+  match_expression,
+  timeout_ms,
+  count,
+  assertions,
+  ping,
+  expect_close
+FROM flow_node_ws_expect
+WHERE flow_node_id = ?
+LIMIT 1;
+
+-- name: CreateFlowNodeWsExpect :exec
+INSERT INTO flow_node_ws_expect (
+  flow_node_id, ws_connection_node_name, message, binary, match_expression,
+  timeout_ms, count, assertions, ping, expect_close
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateFlowNodeWsExpect :exec
+INSERT INTO flow_node_ws_expect (
+  flow_node_id, ws_connection_node_name, message, binary, match_expression,
+  timeout_ms, count, assertions, ping, expect_close
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(flow_node_id) DO UPDATE SET
+  ws_connection_node_name = excluded.ws_connection_node_name,
+  message = excluded.message,
+  binary = excluded.binary,
+  match_expression = excluded.match_expression,
+  timeout_ms = excluded.timeout_ms,
+  count = excluded.count,
+  assertions = excluded.assertions,
+  ping = excluded.ping,
+  expect_close = excluded.expect_close;
+
+-- name: DeleteFlowNodeWsExpect :exec
+DELETE FROM flow_node_ws_expect WHERE flow_node_id = ?;
@@ -72,7 +72,8 @@ CREATE TABLE flow_node_for_each (
   flow_node_id BLOB NOT NULL PRIMARY KEY,
   iter_expression TEXT NOT NULL,
   error_handling INT8 NOT NULL,
-  expression TEXT NOT NULL
+  expression TEXT NOT NULL,
+  concurrency INT NOT NULL DEFAULT 0
 );
 
 CREATE TABLE flow_node_http (
@@ -92,6 +93,17 @@ CREATE TABLE flow_node_graphql (
   FOREIGN KEY (delta_graphql_id) REFERENCES graphql (id) ON DELETE SET NULL
 );
 
+CREATE TABLE flow_node_graphql_subscription (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  graphql_id BLOB NOT NULL,
+  delta_graphql_id BLOB,
+  connection_payload TEXT NOT NULL DEFAULT '',
+  max_events INTEGER NOT NULL DEFAULT 0,
+  timeout_ms INTEGER NOT NULL DEFAULT 0,
+  FOREIGN KEY (graphql_id) REFERENCES graphql (id) ON DELETE CASCADE,
+  FOREIGN KEY (delta_graphql_id) REFERENCES graphql (id) ON DELETE SET NULL
+);
+
 CREATE TABLE flow_node_condition (
   flow_node_id BLOB NOT NULL PRIMARY KEY,
   expression TEXT NOT NULL
@@ -108,6 +120,36 @@ CREATE TABLE flow_node_wait (
   duration_ms BIGINT NOT NULL
 );
 
+CREATE TABLE flow_node_parallel (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  join_mode INT8 NOT NULL,
+  concurrency INT NOT NULL
+);
+
+CREATE TABLE flow_node_poll (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  until_expression TEXT NOT NULL,
+  interval_ms BIGINT NOT NULL,
+  backoff REAL NOT NULL,
+  max_duration_ms BIGINT NOT NULL,
+  max_attempts INT NOT NULL
+);
+
+CREATE TABLE flow_node_switch (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  cases BLOB NOT NULL DEFAULT '[]'
+);
+
+CREATE TABLE flow_node_webhook_trigger (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  path TEXT NOT NULL DEFAULT '',
+  method TEXT NOT NULL DEFAULT '',
+  secret TEXT NOT NULL DEFAULT '',
+  signature_header TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX flow_node_webhook_trigger_idx1 ON flow_node_webhook_trigger (path);
+
 CREATE TABLE flow_node_sub_flow_trigger (
   flow_node_id BLOB NOT NULL PRIMARY KEY,
   params BLOB NOT NULL DEFAULT '[]'
@@ -141,6 +183,36 @@ CREATE TABLE flow_variable (
 -- Performance indexes for flow variable ordering operations
 CREATE INDEX flow_variable_ordering ON flow_variable (flow_id, display_order);
 
+-- Cron or interval schedule that runs a flow on the server
+CREATE TABLE flow_schedule (
+  id BLOB NOT NULL PRIMARY KEY,
+  flow_id BLOB NOT NULL,
+  cron_expression TEXT NOT NULL DEFAULT '',
+  interval_seconds BIGINT NOT NULL DEFAULT 0,
+  environment_id BLOB, -- Environment to run with (NULL uses the workspace's active one)
+  enabled BOOL NOT NULL,
+  last_run_at BIGINT, -- Unix timestamp in milliseconds
+  last_state INT8 NOT NULL DEFAULT 0,
+  FOREIGN KEY (flow_id) REFERENCES flow (id) ON DELETE CASCADE,
+  FOREIGN KEY (environment_id) REFERENCES environment (id) ON DELETE SET NULL
+);
+
+CREATE INDEX flow_schedule_idx1 ON flow_schedule (flow_id);
+
+-- Bounded history of scheduled runs
+CREATE TABLE flow_schedule_run (
+  id BLOB NOT NULL PRIMARY KEY,
+  schedule_id BLOB NOT NULL,
+  flow_version_id BLOB, -- Version snapshot created for the run
+  state INT8 NOT NULL,
+  error TEXT,
+  started_at BIGINT NOT NULL, -- Unix timestamp in milliseconds
+  duration_ms BIGINT NOT NULL,
+  FOREIGN KEY (schedule_id) REFERENCES flow_schedule (id) ON DELETE CASCADE
+);
+
+CREATE INDEX flow_schedule_run_idx1 ON flow_schedule_run (schedule_id, started_at DESC);
+
 CREATE TABLE node_execution (
   id BLOB NOT NULL PRIMARY KEY,
   node_id BLOB NOT NULL,
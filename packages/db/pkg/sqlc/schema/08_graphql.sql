@@ -14,6 +14,16 @@ CREATE TABLE graphql (
   url TEXT NOT NULL,
   query TEXT NOT NULL DEFAULT '',
   variables TEXT NOT NULL DEFAULT '',
+  -- Operation to run when the query document holds several
+  operation_name TEXT NOT NULL DEFAULT '',
+  -- Path of the .graphql file the query was loaded from, if any
+  query_file TEXT NOT NULL DEFAULT '',
+  -- Send the query as an Automatic Persisted Query hash first
+  persisted_query BOOLEAN NOT NULL DEFAULT FALSE,
+  -- Extra operations batched with this one, as a JSON array
+  batch BLOB NOT NULL DEFAULT '[]',
+  -- Files sent per the multipart request spec, as a JSON array
+  uploads BLOB NOT NULL DEFAULT '[]',
   description TEXT NOT NULL DEFAULT '',
   last_run_at BIGINT NULL,
   created_at BIGINT NOT NULL DEFAULT (unixepoch()),
@@ -128,3 +138,12 @@ CREATE TABLE graphql_response_assert (
 
 CREATE INDEX graphql_response_assert_response_idx ON graphql_response_assert (response_id);
 CREATE INDEX graphql_response_assert_success_idx ON graphql_response_assert (response_id, success);
+
+-- Cached GraphQL schema (introspection result) per request
+CREATE TABLE graphql_schema (
+  graphql_id BLOB NOT NULL PRIMARY KEY,
+  introspection TEXT NOT NULL,
+  fetched_at BIGINT NOT NULL DEFAULT (unixepoch()),
+
+  FOREIGN KEY (graphql_id) REFERENCES graphql (id) ON DELETE CASCADE
+);
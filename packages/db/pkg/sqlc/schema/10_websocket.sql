@@ -13,6 +13,10 @@ CREATE TABLE websocket (
   name TEXT NOT NULL,
   url TEXT NOT NULL,
   description TEXT NOT NULL DEFAULT '',
+  subprotocols BLOB NOT NULL DEFAULT '[]', -- JSON array offered in Sec-WebSocket-Protocol
+  protocol INT8 NOT NULL DEFAULT 0, -- 0 raw frames, 1 Socket.IO, 2 STOMP
+  connect_payload TEXT NOT NULL DEFAULT '', -- Socket.IO auth or STOMP CONNECT headers (JSON)
+  subscriptions BLOB NOT NULL DEFAULT '[]', -- JSON array of STOMP destinations
   last_run_at BIGINT NULL,
   created_at BIGINT NOT NULL DEFAULT (unixepoch()),
   updated_at BIGINT NOT NULL DEFAULT (unixepoch()),
@@ -42,6 +46,20 @@ CREATE TABLE websocket_header (
 CREATE INDEX websocket_header_ws_idx ON websocket_header (websocket_id);
 CREATE INDEX websocket_header_order_idx ON websocket_header (websocket_id, display_order);
 
+-- WebSocket message history (frames sent and received, binary data as base64)
+CREATE TABLE websocket_message (
+  id BLOB NOT NULL PRIMARY KEY,
+  websocket_id BLOB NOT NULL,
+  direction TEXT NOT NULL, -- sent, received
+  message_type TEXT NOT NULL DEFAULT 'text', -- text, binary
+  data TEXT NOT NULL,
+  created_at BIGINT NOT NULL DEFAULT (unixepoch()),
+
+  FOREIGN KEY (websocket_id) REFERENCES websocket (id) ON DELETE CASCADE
+);
+
+CREATE INDEX websocket_message_ws_idx ON websocket_message (websocket_id, id);
+
 -- Flow node: WebSocket Connection (entry/listener node)
 CREATE TABLE flow_node_ws_connection (
   flow_node_id BLOB NOT NULL PRIMARY KEY,
@@ -53,5 +71,22 @@ CREATE TABLE flow_node_ws_connection (
 CREATE TABLE flow_node_ws_send (
   flow_node_id BLOB NOT NULL PRIMARY KEY,
   ws_connection_node_name TEXT NOT NULL DEFAULT '',
-  message TEXT NOT NULL DEFAULT ''
+  message TEXT NOT NULL DEFAULT '',
+  binary BOOLEAN NOT NULL DEFAULT FALSE,
+  event TEXT NOT NULL DEFAULT '', -- Socket.IO event or STOMP destination
+  ack BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Flow node: WebSocket Expect (waits for matching frames and asserts on them)
+CREATE TABLE flow_node_ws_expect (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  ws_connection_node_name TEXT NOT NULL DEFAULT '',
+  message TEXT NOT NULL DEFAULT '',
+  binary BOOLEAN NOT NULL DEFAULT FALSE,
+  match_expression TEXT NOT NULL DEFAULT '',
+  timeout_ms INTEGER NOT NULL DEFAULT 0,
+  count INTEGER NOT NULL DEFAULT 0,
+  assertions BLOB NOT NULL DEFAULT '[]',
+  ping BOOLEAN NOT NULL DEFAULT FALSE,
+  expect_close BOOLEAN NOT NULL DEFAULT FALSE
 );
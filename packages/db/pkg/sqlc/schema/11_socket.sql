@@ -0,0 +1,30 @@
+-- Flow node: Socket Connection (TCP/UDP entry/listener node)
+CREATE TABLE flow_node_socket_connection (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  network INT8 NOT NULL DEFAULT 0, -- 0 tcp, 1 udp
+  address TEXT NOT NULL DEFAULT '',
+  tls BOOLEAN NOT NULL DEFAULT FALSE,
+  tls_skip_verify BOOLEAN NOT NULL DEFAULT FALSE,
+  framing INT8 NOT NULL DEFAULT 0, -- 0 none, 1 delimiter, 2 length prefix
+  delimiter TEXT NOT NULL DEFAULT '',
+  length_bytes INTEGER NOT NULL DEFAULT 0,
+  timeout_ms INTEGER NOT NULL DEFAULT 0
+);
+
+-- Flow node: Socket Send (action node)
+CREATE TABLE flow_node_socket_send (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  socket_connection_node_name TEXT NOT NULL DEFAULT '',
+  message TEXT NOT NULL DEFAULT '',
+  encoding INT8 NOT NULL DEFAULT 0 -- 0 text, 1 hex, 2 base64
+);
+
+-- Flow node: Socket Receive (waits for matching messages and asserts on them)
+CREATE TABLE flow_node_socket_receive (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  socket_connection_node_name TEXT NOT NULL DEFAULT '',
+  match_expression TEXT NOT NULL DEFAULT '',
+  timeout_ms INTEGER NOT NULL DEFAULT 0,
+  count INTEGER NOT NULL DEFAULT 0,
+  assertions BLOB NOT NULL DEFAULT '[]'
+);
@@ -0,0 +1,17 @@
+-- Database credential: connection string for DB Query nodes
+CREATE TABLE credential_database (
+  credential_id BLOB NOT NULL PRIMARY KEY,
+  connection_string BLOB NOT NULL, -- Encrypted or plaintext depending on encryption_type
+  encryption_type INT8 NOT NULL DEFAULT 0, -- 0=None, 1=XChaCha20-Poly1305, 2=AES-256-GCM
+  FOREIGN KEY (credential_id) REFERENCES credential (id) ON DELETE CASCADE
+);
+
+-- Flow node: DB Query (runs a parameterized query against a database credential)
+CREATE TABLE flow_node_db_query (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  credential_id BLOB, -- Optional: NULL means no credential set yet
+  query TEXT NOT NULL DEFAULT '',
+  params BLOB NOT NULL DEFAULT '[]', -- JSON array of expressions, bound in order
+  timeout_ms INTEGER NOT NULL DEFAULT 0,
+  assertions BLOB NOT NULL DEFAULT '[]'
+);
@@ -0,0 +1,28 @@
+-- Message broker credential: broker URL for message queue nodes
+CREATE TABLE credential_message_broker (
+  credential_id BLOB NOT NULL PRIMARY KEY,
+  url BLOB NOT NULL, -- Encrypted or plaintext depending on encryption_type
+  encryption_type INT8 NOT NULL DEFAULT 0, -- 0=None, 1=XChaCha20-Poly1305, 2=AES-256-GCM
+  FOREIGN KEY (credential_id) REFERENCES credential (id) ON DELETE CASCADE
+);
+
+-- Flow node: MQ Publish (publishes a message to a topic)
+CREATE TABLE flow_node_mq_publish (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  credential_id BLOB, -- Optional: NULL means no credential set yet
+  topic TEXT NOT NULL DEFAULT '',
+  message_key TEXT NOT NULL DEFAULT '',
+  message TEXT NOT NULL DEFAULT ''
+);
+
+-- Flow node: MQ Consume (listener entry node waiting for matching messages)
+CREATE TABLE flow_node_mq_consume (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  credential_id BLOB, -- Optional: NULL means no credential set yet
+  topic TEXT NOT NULL DEFAULT '',
+  consumer_group TEXT NOT NULL DEFAULT '',
+  filter_expression TEXT NOT NULL DEFAULT '',
+  timeout_ms INTEGER NOT NULL DEFAULT 0,
+  count INTEGER NOT NULL DEFAULT 0,
+  assertions BLOB NOT NULL DEFAULT '[]'
+);
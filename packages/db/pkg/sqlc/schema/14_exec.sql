@@ -0,0 +1,9 @@
+-- Flow node: Exec (runs a local command; only when enabled by the runner)
+CREATE TABLE flow_node_exec (
+  flow_node_id BLOB NOT NULL PRIMARY KEY,
+  command TEXT NOT NULL DEFAULT '',
+  args BLOB NOT NULL DEFAULT '[]', -- JSON array of interpolated arguments
+  work_dir TEXT NOT NULL DEFAULT '',
+  timeout_ms INTEGER NOT NULL DEFAULT 0,
+  parse_json BOOLEAN NOT NULL DEFAULT FALSE
+);
@@ -13,6 +13,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"connectrpc.com/connect"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/rhttp"
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/rimportv2"
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/rlog"
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/rmock"
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/rreference"
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/rwebsocket"
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/rworkspace"
@@ -40,6 +42,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/credvault"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/eventstream"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/eventstream/memory"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowschedule"
 	gqlresolver "github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/resolver"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/http/resolver"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
@@ -180,6 +183,8 @@ func Run() error {
 	flowVariableService := sflow.NewFlowVariableService(queries)
 	flowVariableReader := sflow.NewFlowVariableReader(currentDB)
 
+	flowScheduleService := sflow.NewFlowScheduleService(queries)
+
 	// nodes
 	flowNodeService := sflow.NewNodeService(queries)
 	nodeReader := sflow.NewNodeReader(currentDB)
@@ -201,10 +206,24 @@ func Run() error {
 	flowNodeSubFlowTriggerService := sflow.NewNodeSubFlowTriggerService(queries)
 	flowNodeSubFlowReturnService := sflow.NewNodeSubFlowReturnService(queries)
 	flowNodeRunSubFlowService := sflow.NewNodeRunSubFlowService(queries)
+	flowNodeParallelService := sflow.NewNodeParallelService(queries)
+	flowNodePollService := sflow.NewNodePollService(queries)
+	flowNodeSwitchService := sflow.NewNodeSwitchService(queries)
+	flowNodeWebhookTriggerService := sflow.NewNodeWebhookTriggerService(queries)
+	flowNodeGraphQLSubscriptionService := sflow.NewNodeGraphQLSubscriptionService(queries)
+	flowNodeWsExpectService := sflow.NewNodeWsExpectService(queries)
+	flowNodeSocketConnectionService := sflow.NewNodeSocketConnectionService(queries)
+	flowNodeSocketSendService := sflow.NewNodeSocketSendService(queries)
+	flowNodeSocketReceiveService := sflow.NewNodeSocketReceiveService(queries)
+	flowNodeDbQueryService := sflow.NewNodeDbQueryService(queries)
+	flowNodeMqPublishService := sflow.NewNodeMqPublishService(queries)
+	flowNodeMqConsumeService := sflow.NewNodeMqConsumeService(queries)
+	flowNodeExecService := sflow.NewNodeExecService(queries)
 
 	// WebSocket
 	websocketService := swebsocket.New(queries, logger)
 	websocketHeaderService := swebsocket.NewWebSocketHeaderService(queries)
+	websocketMessageService := swebsocket.NewWebSocketMessageService(queries)
 
 	// GraphQL
 	graphqlService := sgraphql.New(queries, logger)
@@ -212,6 +231,7 @@ func Run() error {
 	graphqlHeaderService := sgraphql.NewGraphQLHeaderService(queries)
 	graphqlAssertService := sgraphql.NewGraphQLAssertService(queries)
 	graphqlResponseService := sgraphql.NewGraphQLResponseService(queries)
+	graphqlSchemaService := sgraphql.NewGraphQLSchemaService(queries)
 
 	nodeExecutionService := sflow.NewNodeExecutionService(queries)
 	nodeExecutionReader := sflow.NewNodeExecutionReader(currentDB)
@@ -379,6 +399,7 @@ func Run() error {
 			Node:               &flowNodeService,
 			NodeRequest:        &flowNodeRequestSevice,
 			Edge:               &flowEdgeService,
+			GraphQL:            &graphqlService,
 		},
 		Readers: rimportv2.ImportV2Readers{
 			Workspace: workspaceReader,
@@ -485,10 +506,25 @@ func Run() error {
 			NodeSubFlowTrigger:   &flowNodeSubFlowTriggerService,
 			NodeSubFlowReturn:    &flowNodeSubFlowReturnService,
 			NodeRunSubFlow:       &flowNodeRunSubFlowService,
+			NodeParallel:         &flowNodeParallelService,
+			NodePoll:             &flowNodePollService,
+			NodeSwitch:           &flowNodeSwitchService,
+			NodeWebhookTrigger:   &flowNodeWebhookTriggerService,
+			NodeGraphQLSubscription: &flowNodeGraphQLSubscriptionService,
+			NodeWsExpect:            &flowNodeWsExpectService,
+			NodeSocketConnection:    &flowNodeSocketConnectionService,
+			NodeSocketSend:          &flowNodeSocketSendService,
+			NodeSocketReceive:       &flowNodeSocketReceiveService,
+			NodeDbQuery:             &flowNodeDbQueryService,
+			NodeMqPublish:           &flowNodeMqPublishService,
+			NodeMqConsume:           &flowNodeMqConsumeService,
+			NodeExec:                &flowNodeExecService,
 			WebSocket:        &websocketService,
 			WebSocketHeader:  &websocketHeaderService,
+			WebSocketMessage: &websocketMessageService,
 			NodeExecution:    &nodeExecutionService,
 			FlowVariable:   &flowVariableService,
+			FlowSchedule:   &flowScheduleService,
 			Env:            &environmentService,
 			Var:            &variableService,
 			Http:           &httpService,
@@ -498,6 +534,7 @@ func Run() error {
 			GraphQL:         &graphqlService,
 			GraphQLHeader:   &graphqlHeaderService,
 			GraphQLAssert:   &graphqlAssertService,
+			GraphQLSchema:   &graphqlSchemaService,
 			File:            fileService,
 			Importer:       workspaceImporter,
 			Credential:     credentialService,
@@ -509,6 +546,8 @@ func Run() error {
 			Http:               streamers.Http,
 			Var:                streamers.FlowVariable,
 			Version:            streamers.FlowVersion,
+			Schedule:           streamers.FlowSchedule,
+			ScheduleRun:        streamers.FlowScheduleRun,
 			For:                streamers.For,
 			Condition:          streamers.Condition,
 			ForEach:            streamers.ForEach,
@@ -533,18 +572,64 @@ func Run() error {
 		GraphQLResolver: graphqlResolver,
 		Logger:          logger,
 		JsClient: jsClient,
+		// Exec nodes start local commands, so they only run when the
+		// desktop app or whoever starts the server opts in.
+		AllowExec: os.Getenv("ALLOW_EXEC") == "true",
+		// SQLite files could be any database on the server's disk,
+		// including the server's own, so they also need an opt-in.
+		AllowLocalDatabases: os.Getenv("ALLOW_LOCAL_DATABASES") == "true",
 	})
 	newServiceManager.addService(rflowv2.CreateService(flowSrvV2, optionsAll))
 
-	// Wire workspace-import sync events through the same publishers the
-	// per-entity RPCs use, so the desktop UI's TanStack DB collections refresh
-	// immediately after an import (instead of waiting for a manual reload).
-	// Has to happen after both flowSrvV2 and httpSrv exist — the publishers
-	// are methods on those services.
-	importV2Srv.SetMutationPublisher(mutation.MultiPublisher{
-		flowSrvV2.MutationPublisher(),
-		httpSrv.MutationPublisher(),
-	})
+	// Run flow schedules through the flow service, which records each run's
+	// node executions like a run started from the UI.
+	flowScheduler := flowschedule.New(&flowScheduleService, flowSrvV2,
+		flowschedule.WithNotifier(flowSrvV2),
+		flowschedule.WithLogger(logger),
+	)
+	flowSrvV2.SetScheduler(flowScheduler)
+	go flowScheduler.Run(ctx)
+
+	// Inbound webhooks run the flow whose webhook node listens on the path.
+	newServiceManager.addService(&api.Service{
+		Path:    rflowv2.WebhookPathPrefix,
+		Handler: flowSrvV2.WebhookHandler(),
+	}, nil)
+
+	// Saved responses of a workspace are served as a mock under
+	// /mock/{workspaceId}/, to members of the workspace only. MOCK_LISTEN
+	// also serves it on a TCP address, for clients that can't reach the Unix
+	// socket; a bare port binds to loopback. MOCK_LATENCY, MOCK_JITTER,
+	// MOCK_FAULT_RATE and MOCK_FAULT_STATUS inject latency and failures.
+	mockOpts, err := rmock.EnvOptions(os.Getenv)
+	if err != nil {
+		return err
+	}
+	mockHandler := mwauth.NewAuthHandler(rmock.New(queries, userService, logger, mockOpts...))
+	newServiceManager.addService(&api.Service{
+		Path:    rmock.PathPrefix,
+		Handler: mockHandler,
+	}, nil)
+	if mockAddr := os.Getenv("MOCK_LISTEN"); mockAddr != "" {
+		mockAddr = rmock.ListenAddr(mockAddr)
+		mockMux := http.NewServeMux()
+		mockMux.Handle(rmock.PathPrefix, mockHandler)
+		mockSrv := &http.Server{Addr: mockAddr, Handler: mockMux} //nolint:gosec // local development server
+		go func() {
+			slog.Info("Mock server listening", "addr", mockAddr)
+			if err := mockSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("Mock server listener error", "error", err)
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := mockSrv.Shutdown(shutdownCtx); err != nil {
+				slog.Error("Mock server shutdown error", "error", err)
+			}
+		}()
+	}
 
 	logSrv := rlog.New(streamers.Log)
 	newServiceManager.addService(rlog.CreateService(logSrv, optionsAll))
@@ -590,10 +675,12 @@ func Run() error {
 			Credential: credentialReader,
 		},
 		Streamers: rcredential.CredentialRPCStreamers{
-			Credential: streamers.Credential,
-			OpenAi:     streamers.CredentialOpenAi,
-			Gemini:     streamers.CredentialGemini,
-			Anthropic:  streamers.CredentialAnthropic,
+			Credential:    streamers.Credential,
+			OpenAi:        streamers.CredentialOpenAi,
+			Gemini:        streamers.CredentialGemini,
+			Anthropic:     streamers.CredentialAnthropic,
+			Database:      streamers.CredentialDatabase,
+			MessageBroker: streamers.CredentialMessageBroker,
 		},
 		Publisher: registry,
 	})
@@ -624,6 +711,7 @@ func Run() error {
 			Env:           environmentService,
 			Variable:      variableService,
 			File:          fileService,
+			Schema:        &graphqlSchemaService,
 		},
 		Readers: rgraphql.GraphQLServiceRPCReaders{
 			GraphQL:   graphqlReader,
@@ -635,6 +723,17 @@ func Run() error {
 	})
 	newServiceManager.addService(rgraphql.CreateService(graphqlSrv, optionsAll))
 
+	// Wire workspace-import sync events through the same publishers the
+	// per-entity RPCs use, so the desktop UI's TanStack DB collections refresh
+	// immediately after an import (instead of waiting for a manual reload).
+	// Has to happen after flowSrvV2, httpSrv and graphqlSrv exist — the
+	// publishers are methods on those services.
+	importV2Srv.SetMutationPublisher(mutation.MultiPublisher{
+		flowSrvV2.MutationPublisher(),
+		httpSrv.MutationPublisher(),
+		graphqlSrv.MutationPublisher(),
+	})
+
 	// Reference Service
 	refServiceRPC := rreference.NewReferenceServiceRPC(rreference.ReferenceServiceRPCDeps{
 		DB: currentDB,
@@ -661,10 +760,12 @@ func Run() error {
 		DB:        currentDB,
 		WS:        websocketService,
 		WSH:       websocketHeaderService,
+		WSM:       websocketMessageService,
 		US:        userService,
 		Workspace: workspaceService,
 		WSStream:  streamers.WebSocket,
 		WSHStream: streamers.WebSocketHeader,
+		WSMStream: streamers.WebSocketMessage,
 	})
 	newServiceManager.addService(rwebsocket.CreateService(wsSrv, optionsAll))
 
@@ -832,6 +933,8 @@ type streamers struct {
 	Edge                eventstream.SyncStreamer[rflowv2.EdgeTopic, rflowv2.EdgeEvent]
 	FlowVariable        eventstream.SyncStreamer[rflowv2.FlowVariableTopic, rflowv2.FlowVariableEvent]
 	FlowVersion         eventstream.SyncStreamer[rflowv2.FlowVersionTopic, rflowv2.FlowVersionEvent]
+	FlowSchedule        eventstream.SyncStreamer[rflowv2.FlowScheduleTopic, rflowv2.FlowScheduleEvent]
+	FlowScheduleRun     eventstream.SyncStreamer[rflowv2.FlowScheduleRunTopic, rflowv2.FlowScheduleRunEvent]
 	For                 eventstream.SyncStreamer[rflowv2.ForTopic, rflowv2.ForEvent]
 	Condition           eventstream.SyncStreamer[rflowv2.ConditionTopic, rflowv2.ConditionEvent]
 	ForEach             eventstream.SyncStreamer[rflowv2.ForEachTopic, rflowv2.ForEachEvent]
@@ -853,8 +956,11 @@ type streamers struct {
 	CredentialOpenAi    eventstream.SyncStreamer[rcredential.CredentialOpenAiTopic, rcredential.CredentialOpenAiEvent]
 	CredentialGemini    eventstream.SyncStreamer[rcredential.CredentialGeminiTopic, rcredential.CredentialGeminiEvent]
 	CredentialAnthropic eventstream.SyncStreamer[rcredential.CredentialAnthropicTopic, rcredential.CredentialAnthropicEvent]
+	CredentialDatabase  eventstream.SyncStreamer[rcredential.CredentialDatabaseTopic, rcredential.CredentialDatabaseEvent]
+	CredentialMessageBroker eventstream.SyncStreamer[rcredential.CredentialMessageBrokerTopic, rcredential.CredentialMessageBrokerEvent]
 	WebSocket           eventstream.SyncStreamer[rwebsocket.WebSocketTopic, rwebsocket.WebSocketEvent]
 	WebSocketHeader     eventstream.SyncStreamer[rwebsocket.WebSocketHeaderTopic, rwebsocket.WebSocketHeaderEvent]
+	WebSocketMessage    eventstream.SyncStreamer[rwebsocket.WebSocketMessageTopic, rwebsocket.WebSocketMessageEvent]
 }
 
 func newStreamers() *streamers {
@@ -879,6 +985,8 @@ func newStreamers() *streamers {
 		Edge:                memory.NewInMemorySyncStreamer[rflowv2.EdgeTopic, rflowv2.EdgeEvent](),
 		FlowVariable:        memory.NewInMemorySyncStreamer[rflowv2.FlowVariableTopic, rflowv2.FlowVariableEvent](),
 		FlowVersion:         memory.NewInMemorySyncStreamer[rflowv2.FlowVersionTopic, rflowv2.FlowVersionEvent](),
+		FlowSchedule:        memory.NewInMemorySyncStreamer[rflowv2.FlowScheduleTopic, rflowv2.FlowScheduleEvent](),
+		FlowScheduleRun:     memory.NewInMemorySyncStreamer[rflowv2.FlowScheduleRunTopic, rflowv2.FlowScheduleRunEvent](),
 		For:                 memory.NewInMemorySyncStreamer[rflowv2.ForTopic, rflowv2.ForEvent](),
 		Condition:           memory.NewInMemorySyncStreamer[rflowv2.ConditionTopic, rflowv2.ConditionEvent](),
 		ForEach:             memory.NewInMemorySyncStreamer[rflowv2.ForEachTopic, rflowv2.ForEachEvent](),
@@ -900,8 +1008,11 @@ func newStreamers() *streamers {
 		CredentialOpenAi:    memory.NewInMemorySyncStreamer[rcredential.CredentialOpenAiTopic, rcredential.CredentialOpenAiEvent](),
 		CredentialGemini:    memory.NewInMemorySyncStreamer[rcredential.CredentialGeminiTopic, rcredential.CredentialGeminiEvent](),
 		CredentialAnthropic: memory.NewInMemorySyncStreamer[rcredential.CredentialAnthropicTopic, rcredential.CredentialAnthropicEvent](),
+		CredentialDatabase:  memory.NewInMemorySyncStreamer[rcredential.CredentialDatabaseTopic, rcredential.CredentialDatabaseEvent](),
+		CredentialMessageBroker: memory.NewInMemorySyncStreamer[rcredential.CredentialMessageBrokerTopic, rcredential.CredentialMessageBrokerEvent](),
 		WebSocket:           memory.NewInMemorySyncStreamer[rwebsocket.WebSocketTopic, rwebsocket.WebSocketEvent](),
 		WebSocketHeader:     memory.NewInMemorySyncStreamer[rwebsocket.WebSocketHeaderTopic, rwebsocket.WebSocketHeaderEvent](),
+		WebSocketMessage:    memory.NewInMemorySyncStreamer[rwebsocket.WebSocketMessageTopic, rwebsocket.WebSocketMessageEvent](),
 	}
 }
 
@@ -926,6 +1037,8 @@ func (s *streamers) shutdown() {
 	s.Edge.Shutdown()
 	s.FlowVariable.Shutdown()
 	s.FlowVersion.Shutdown()
+	s.FlowSchedule.Shutdown()
+	s.FlowScheduleRun.Shutdown()
 	s.For.Shutdown()
 	s.Condition.Shutdown()
 	s.ForEach.Shutdown()
@@ -947,8 +1060,11 @@ func (s *streamers) shutdown() {
 	s.CredentialOpenAi.Shutdown()
 	s.CredentialGemini.Shutdown()
 	s.CredentialAnthropic.Shutdown()
+	s.CredentialDatabase.Shutdown()
+	s.CredentialMessageBroker.Shutdown()
 	s.WebSocket.Shutdown()
 	s.WebSocketHeader.Shutdown()
+	s.WebSocketMessage.Shutdown()
 }
 
 // registerCascadeHandlers registers all handlers needed for cascade deletion events.
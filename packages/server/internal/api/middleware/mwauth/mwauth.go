@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 
 	"connectrpc.com/connect"
@@ -66,6 +67,14 @@ func (i *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc
 	})
 }
 
+// NewAuthHandler wraps a plain HTTP handler, such as one mounted next to the
+// RPC services, so it runs as the same user as NewAuthInterceptor.
+func NewAuthHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(CreateAuthedContext(r.Context(), LocalDummyID)))
+	})
+}
+
 func NewAuthInterceptorOne(secret []byte) connect.UnaryInterceptorFunc {
 	data := AuthInterceptorData{secret: secret}
 	interceptor := func(next connect.UnaryFunc) connect.UnaryFunc {
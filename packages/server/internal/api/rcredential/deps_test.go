@@ -15,6 +15,8 @@ func TestCredentialRPCDeps_Validate(t *testing.T) {
 	mockOpenAiStream := memory.NewInMemorySyncStreamer[CredentialOpenAiTopic, CredentialOpenAiEvent]()
 	mockGeminiStream := memory.NewInMemorySyncStreamer[CredentialGeminiTopic, CredentialGeminiEvent]()
 	mockAnthropicStream := memory.NewInMemorySyncStreamer[CredentialAnthropicTopic, CredentialAnthropicEvent]()
+	mockDatabaseStream := memory.NewInMemorySyncStreamer[CredentialDatabaseTopic, CredentialDatabaseEvent]()
+	mockMessageBrokerStream := memory.NewInMemorySyncStreamer[CredentialMessageBrokerTopic, CredentialMessageBrokerEvent]()
 	mockDB := &sql.DB{} // Note: This is just for validation testing, not actual DB operations
 
 	tests := []struct {
@@ -32,10 +34,12 @@ func TestCredentialRPCDeps_Validate(t *testing.T) {
 					Credential: mockCredReader,
 				},
 				Streamers: CredentialRPCStreamers{
-					Credential: mockCredStream,
-					OpenAi:     mockOpenAiStream,
-					Gemini:     mockGeminiStream,
-					Anthropic:  mockAnthropicStream,
+					Credential:    mockCredStream,
+					OpenAi:        mockOpenAiStream,
+					Gemini:        mockGeminiStream,
+					Anthropic:     mockAnthropicStream,
+					Database:      mockDatabaseStream,
+					MessageBroker: mockMessageBrokerStream,
 				},
 			},
 			wantErr: false,
@@ -49,10 +53,12 @@ func TestCredentialRPCDeps_Validate(t *testing.T) {
 					Credential: mockCredReader,
 				},
 				Streamers: CredentialRPCStreamers{
-					Credential: mockCredStream,
-					OpenAi:     mockOpenAiStream,
-					Gemini:     mockGeminiStream,
-					Anthropic:  mockAnthropicStream,
+					Credential:    mockCredStream,
+					OpenAi:        mockOpenAiStream,
+					Gemini:        mockGeminiStream,
+					Anthropic:     mockAnthropicStream,
+					Database:      mockDatabaseStream,
+					MessageBroker: mockMessageBrokerStream,
 				},
 			},
 			wantErr: true,
@@ -67,10 +73,12 @@ func TestCredentialRPCDeps_Validate(t *testing.T) {
 					Credential: nil,
 				},
 				Streamers: CredentialRPCStreamers{
-					Credential: mockCredStream,
-					OpenAi:     mockOpenAiStream,
-					Gemini:     mockGeminiStream,
-					Anthropic:  mockAnthropicStream,
+					Credential:    mockCredStream,
+					OpenAi:        mockOpenAiStream,
+					Gemini:        mockGeminiStream,
+					Anthropic:     mockAnthropicStream,
+					Database:      mockDatabaseStream,
+					MessageBroker: mockMessageBrokerStream,
 				},
 			},
 			wantErr: true,
@@ -85,10 +93,12 @@ func TestCredentialRPCDeps_Validate(t *testing.T) {
 					Credential: mockCredReader,
 				},
 				Streamers: CredentialRPCStreamers{
-					Credential: nil,
-					OpenAi:     mockOpenAiStream,
-					Gemini:     mockGeminiStream,
-					Anthropic:  mockAnthropicStream,
+					Credential:    nil,
+					OpenAi:        mockOpenAiStream,
+					Gemini:        mockGeminiStream,
+					Anthropic:     mockAnthropicStream,
+					Database:      mockDatabaseStream,
+					MessageBroker: mockMessageBrokerStream,
 				},
 			},
 			wantErr: true,
@@ -103,10 +113,12 @@ func TestCredentialRPCDeps_Validate(t *testing.T) {
 					Credential: mockCredReader,
 				},
 				Streamers: CredentialRPCStreamers{
-					Credential: mockCredStream,
-					OpenAi:     nil,
-					Gemini:     mockGeminiStream,
-					Anthropic:  mockAnthropicStream,
+					Credential:    mockCredStream,
+					OpenAi:        nil,
+					Gemini:        mockGeminiStream,
+					Anthropic:     mockAnthropicStream,
+					Database:      mockDatabaseStream,
+					MessageBroker: mockMessageBrokerStream,
 				},
 			},
 			wantErr: true,
@@ -121,10 +133,12 @@ func TestCredentialRPCDeps_Validate(t *testing.T) {
 					Credential: mockCredReader,
 				},
 				Streamers: CredentialRPCStreamers{
-					Credential: mockCredStream,
-					OpenAi:     mockOpenAiStream,
-					Gemini:     nil,
-					Anthropic:  mockAnthropicStream,
+					Credential:    mockCredStream,
+					OpenAi:        mockOpenAiStream,
+					Gemini:        nil,
+					Anthropic:     mockAnthropicStream,
+					Database:      mockDatabaseStream,
+					MessageBroker: mockMessageBrokerStream,
 				},
 			},
 			wantErr: true,
@@ -139,15 +153,57 @@ func TestCredentialRPCDeps_Validate(t *testing.T) {
 					Credential: mockCredReader,
 				},
 				Streamers: CredentialRPCStreamers{
-					Credential: mockCredStream,
-					OpenAi:     mockOpenAiStream,
-					Gemini:     mockGeminiStream,
-					Anthropic:  nil,
+					Credential:    mockCredStream,
+					OpenAi:        mockOpenAiStream,
+					Gemini:        mockGeminiStream,
+					Anthropic:     nil,
+					Database:      mockDatabaseStream,
+					MessageBroker: mockMessageBrokerStream,
 				},
 			},
 			wantErr: true,
 			errMsg:  "anthropic stream is required",
 		},
+		{
+			name: "missing database stream",
+			deps: CredentialRPCDeps{
+				DB:       mockDB,
+				Services: CredentialRPCServices{},
+				Readers: CredentialRPCReaders{
+					Credential: mockCredReader,
+				},
+				Streamers: CredentialRPCStreamers{
+					Credential:    mockCredStream,
+					OpenAi:        mockOpenAiStream,
+					Gemini:        mockGeminiStream,
+					Anthropic:     mockAnthropicStream,
+					Database:      nil,
+					MessageBroker: mockMessageBrokerStream,
+				},
+			},
+			wantErr: true,
+			errMsg:  "database stream is required",
+		},
+		{
+			name: "missing message broker stream",
+			deps: CredentialRPCDeps{
+				DB:       mockDB,
+				Services: CredentialRPCServices{},
+				Readers: CredentialRPCReaders{
+					Credential: mockCredReader,
+				},
+				Streamers: CredentialRPCStreamers{
+					Credential:    mockCredStream,
+					OpenAi:        mockOpenAiStream,
+					Gemini:        mockGeminiStream,
+					Anthropic:     mockAnthropicStream,
+					Database:      mockDatabaseStream,
+					MessageBroker: nil,
+				},
+			},
+			wantErr: true,
+			errMsg:  "message broker stream is required",
+		},
 	}
 
 	for _, tt := range tests {
@@ -205,6 +261,8 @@ func TestCredentialRPCStreamers_Validate(t *testing.T) {
 	mockOpenAiStream := memory.NewInMemorySyncStreamer[CredentialOpenAiTopic, CredentialOpenAiEvent]()
 	mockGeminiStream := memory.NewInMemorySyncStreamer[CredentialGeminiTopic, CredentialGeminiEvent]()
 	mockAnthropicStream := memory.NewInMemorySyncStreamer[CredentialAnthropicTopic, CredentialAnthropicEvent]()
+	mockDatabaseStream := memory.NewInMemorySyncStreamer[CredentialDatabaseTopic, CredentialDatabaseEvent]()
+	mockMessageBrokerStream := memory.NewInMemorySyncStreamer[CredentialMessageBrokerTopic, CredentialMessageBrokerEvent]()
 
 	tests := []struct {
 		name      string
@@ -214,50 +272,84 @@ func TestCredentialRPCStreamers_Validate(t *testing.T) {
 		{
 			name: "valid - all streamers present",
 			streamers: CredentialRPCStreamers{
-				Credential: mockCredStream,
-				OpenAi:     mockOpenAiStream,
-				Gemini:     mockGeminiStream,
-				Anthropic:  mockAnthropicStream,
+				Credential:    mockCredStream,
+				OpenAi:        mockOpenAiStream,
+				Gemini:        mockGeminiStream,
+				Anthropic:     mockAnthropicStream,
+				Database:      mockDatabaseStream,
+				MessageBroker: mockMessageBrokerStream,
 			},
 			wantErr: false,
 		},
 		{
 			name: "missing credential stream",
 			streamers: CredentialRPCStreamers{
-				Credential: nil,
-				OpenAi:     mockOpenAiStream,
-				Gemini:     mockGeminiStream,
-				Anthropic:  mockAnthropicStream,
+				Credential:    nil,
+				OpenAi:        mockOpenAiStream,
+				Gemini:        mockGeminiStream,
+				Anthropic:     mockAnthropicStream,
+				Database:      mockDatabaseStream,
+				MessageBroker: mockMessageBrokerStream,
 			},
 			wantErr: true,
 		},
 		{
 			name: "missing openai stream",
 			streamers: CredentialRPCStreamers{
-				Credential: mockCredStream,
-				OpenAi:     nil,
-				Gemini:     mockGeminiStream,
-				Anthropic:  mockAnthropicStream,
+				Credential:    mockCredStream,
+				OpenAi:        nil,
+				Gemini:        mockGeminiStream,
+				Anthropic:     mockAnthropicStream,
+				Database:      mockDatabaseStream,
+				MessageBroker: mockMessageBrokerStream,
 			},
 			wantErr: true,
 		},
 		{
 			name: "missing gemini stream",
 			streamers: CredentialRPCStreamers{
-				Credential: mockCredStream,
-				OpenAi:     mockOpenAiStream,
-				Gemini:     nil,
-				Anthropic:  mockAnthropicStream,
+				Credential:    mockCredStream,
+				OpenAi:        mockOpenAiStream,
+				Gemini:        nil,
+				Anthropic:     mockAnthropicStream,
+				Database:      mockDatabaseStream,
+				MessageBroker: mockMessageBrokerStream,
 			},
 			wantErr: true,
 		},
 		{
 			name: "missing anthropic stream",
 			streamers: CredentialRPCStreamers{
-				Credential: mockCredStream,
-				OpenAi:     mockOpenAiStream,
-				Gemini:     mockGeminiStream,
-				Anthropic:  nil,
+				Credential:    mockCredStream,
+				OpenAi:        mockOpenAiStream,
+				Gemini:        mockGeminiStream,
+				Anthropic:     nil,
+				Database:      mockDatabaseStream,
+				MessageBroker: mockMessageBrokerStream,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing database stream",
+			streamers: CredentialRPCStreamers{
+				Credential:    mockCredStream,
+				OpenAi:        mockOpenAiStream,
+				Gemini:        mockGeminiStream,
+				Anthropic:     mockAnthropicStream,
+				Database:      nil,
+				MessageBroker: mockMessageBrokerStream,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing message broker stream",
+			streamers: CredentialRPCStreamers{
+				Credential:    mockCredStream,
+				OpenAi:        mockOpenAiStream,
+				Gemini:        mockGeminiStream,
+				Anthropic:     mockAnthropicStream,
+				Database:      mockDatabaseStream,
+				MessageBroker: nil,
 			},
 			wantErr: true,
 		},
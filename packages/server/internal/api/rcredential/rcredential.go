@@ -42,10 +42,12 @@ type CredentialRPC struct {
 
 	credReader *scredential.CredentialReader
 
-	credStream      eventstream.SyncStreamer[CredentialTopic, CredentialEvent]
-	openAiStream    eventstream.SyncStreamer[CredentialOpenAiTopic, CredentialOpenAiEvent]
-	geminiStream    eventstream.SyncStreamer[CredentialGeminiTopic, CredentialGeminiEvent]
-	anthropicStream eventstream.SyncStreamer[CredentialAnthropicTopic, CredentialAnthropicEvent]
+	credStream          eventstream.SyncStreamer[CredentialTopic, CredentialEvent]
+	openAiStream        eventstream.SyncStreamer[CredentialOpenAiTopic, CredentialOpenAiEvent]
+	geminiStream        eventstream.SyncStreamer[CredentialGeminiTopic, CredentialGeminiEvent]
+	anthropicStream     eventstream.SyncStreamer[CredentialAnthropicTopic, CredentialAnthropicEvent]
+	databaseStream      eventstream.SyncStreamer[CredentialDatabaseTopic, CredentialDatabaseEvent]
+	messageBrokerStream eventstream.SyncStreamer[CredentialMessageBrokerTopic, CredentialMessageBrokerEvent]
 
 	publisher mutation.Publisher // Unified publisher for cascade delete events
 }
@@ -88,6 +90,24 @@ type CredentialAnthropicEvent struct {
 	Secret *credentialv1.CredentialAnthropic
 }
 
+type CredentialDatabaseTopic struct {
+	WorkspaceID idwrap.IDWrap
+}
+
+type CredentialDatabaseEvent struct {
+	Type   string
+	Secret *credentialv1.CredentialDatabase
+}
+
+type CredentialMessageBrokerTopic struct {
+	WorkspaceID idwrap.IDWrap
+}
+
+type CredentialMessageBrokerEvent struct {
+	Type   string
+	Secret *credentialv1.CredentialMessageBroker
+}
+
 // --- Dependencies ---
 
 type CredentialRPCServices struct {
@@ -112,10 +132,12 @@ func (r *CredentialRPCReaders) Validate() error {
 }
 
 type CredentialRPCStreamers struct {
-	Credential eventstream.SyncStreamer[CredentialTopic, CredentialEvent]
-	OpenAi     eventstream.SyncStreamer[CredentialOpenAiTopic, CredentialOpenAiEvent]
-	Gemini     eventstream.SyncStreamer[CredentialGeminiTopic, CredentialGeminiEvent]
-	Anthropic  eventstream.SyncStreamer[CredentialAnthropicTopic, CredentialAnthropicEvent]
+	Credential    eventstream.SyncStreamer[CredentialTopic, CredentialEvent]
+	OpenAi        eventstream.SyncStreamer[CredentialOpenAiTopic, CredentialOpenAiEvent]
+	Gemini        eventstream.SyncStreamer[CredentialGeminiTopic, CredentialGeminiEvent]
+	Anthropic     eventstream.SyncStreamer[CredentialAnthropicTopic, CredentialAnthropicEvent]
+	Database      eventstream.SyncStreamer[CredentialDatabaseTopic, CredentialDatabaseEvent]
+	MessageBroker eventstream.SyncStreamer[CredentialMessageBrokerTopic, CredentialMessageBrokerEvent]
 }
 
 func (s *CredentialRPCStreamers) Validate() error {
@@ -131,6 +153,12 @@ func (s *CredentialRPCStreamers) Validate() error {
 	if s.Anthropic == nil {
 		return fmt.Errorf("anthropic stream is required")
 	}
+	if s.Database == nil {
+		return fmt.Errorf("database stream is required")
+	}
+	if s.MessageBroker == nil {
+		return fmt.Errorf("message broker stream is required")
+	}
 	return nil
 }
 
@@ -164,16 +192,18 @@ func New(deps CredentialRPCDeps) CredentialRPC {
 	}
 
 	return CredentialRPC{
-		DB:              deps.DB,
-		cs:              deps.Services.Credential,
-		us:              deps.Services.User,
-		ws:              deps.Services.Workspace,
-		credReader:      deps.Readers.Credential,
-		credStream:      deps.Streamers.Credential,
-		openAiStream:    deps.Streamers.OpenAi,
-		geminiStream:    deps.Streamers.Gemini,
-		anthropicStream: deps.Streamers.Anthropic,
-		publisher:       deps.Publisher,
+		DB:                  deps.DB,
+		cs:                  deps.Services.Credential,
+		us:                  deps.Services.User,
+		ws:                  deps.Services.Workspace,
+		credReader:          deps.Readers.Credential,
+		credStream:          deps.Streamers.Credential,
+		openAiStream:        deps.Streamers.OpenAi,
+		geminiStream:        deps.Streamers.Gemini,
+		anthropicStream:     deps.Streamers.Anthropic,
+		databaseStream:      deps.Streamers.Database,
+		messageBrokerStream: deps.Streamers.MessageBroker,
+		publisher:           deps.Publisher,
 	}
 }
 
@@ -308,11 +338,13 @@ func (s *CredentialRPC) CredentialInsert(
 
 	// Track provider-specific records created for sync events
 	type providerRecord struct {
-		kind        mcredential.CredentialKind
-		workspaceID idwrap.IDWrap
-		openai      *mcredential.CredentialOpenAI
-		gemini      *mcredential.CredentialGemini
-		anthropic   *mcredential.CredentialAnthropic
+		kind          mcredential.CredentialKind
+		workspaceID   idwrap.IDWrap
+		openai        *mcredential.CredentialOpenAI
+		gemini        *mcredential.CredentialGemini
+		anthropic     *mcredential.CredentialAnthropic
+		database      *mcredential.CredentialDatabase
+		messageBroker *mcredential.CredentialMessageBroker
 	}
 	var providerRecords []providerRecord
 
@@ -349,6 +381,22 @@ func (s *CredentialRPC) CredentialInsert(
 			if err := csTx.CreateCredentialAnthropic(ctx, pr.anthropic); err != nil {
 				return nil, connect.NewError(connect.CodeInternal, err)
 			}
+		case mcredential.CREDENTIAL_KIND_DATABASE:
+			pr.database = &mcredential.CredentialDatabase{
+				CredentialID:     item.credID,
+				ConnectionString: "",
+			}
+			if err := csTx.CreateCredentialDatabase(ctx, pr.database); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		case mcredential.CREDENTIAL_KIND_MESSAGE_BROKER:
+			pr.messageBroker = &mcredential.CredentialMessageBroker{
+				CredentialID: item.credID,
+				Url:          "",
+			}
+			if err := csTx.CreateCredentialMessageBroker(ctx, pr.messageBroker); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
 		}
 		providerRecords = append(providerRecords, pr)
 	}
@@ -391,6 +439,20 @@ func (s *CredentialRPC) CredentialInsert(
 					Secret: converter.ToAPICredentialAnthropic(*pr.anthropic),
 				})
 			}
+		case mcredential.CREDENTIAL_KIND_DATABASE:
+			if s.databaseStream != nil && pr.database != nil {
+				s.databaseStream.Publish(CredentialDatabaseTopic{WorkspaceID: pr.workspaceID}, CredentialDatabaseEvent{
+					Type:   eventTypeInsert,
+					Secret: converter.ToAPICredentialDatabase(*pr.database),
+				})
+			}
+		case mcredential.CREDENTIAL_KIND_MESSAGE_BROKER:
+			if s.messageBrokerStream != nil && pr.messageBroker != nil {
+				s.messageBrokerStream.Publish(CredentialMessageBrokerTopic{WorkspaceID: pr.workspaceID}, CredentialMessageBrokerEvent{
+					Type:   eventTypeInsert,
+					Secret: converter.ToAPICredentialMessageBroker(*pr.messageBroker),
+				})
+			}
 		}
 	}
 
@@ -1773,3 +1835,715 @@ func (s *CredentialRPC) streamCredentialAnthropicSync(
 		}
 	}
 }
+
+// --- CredentialDatabase Collection CRUD+Sync ---
+
+func (s *CredentialRPC) CredentialDatabaseCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[credentialv1.CredentialDatabaseCollectionResponse], error) {
+	workspaces, err := s.getAccessibleWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*credentialv1.CredentialDatabase
+	for _, ws := range workspaces {
+		creds, err := s.credReader.ListCredentials(ctx, ws.ID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, c := range creds {
+			if c.Kind != mcredential.CREDENTIAL_KIND_DATABASE {
+				continue
+			}
+			database, err := s.credReader.GetCredentialDatabase(ctx, c.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			items = append(items, converter.ToAPICredentialDatabase(*database))
+		}
+	}
+
+	return connect.NewResponse(&credentialv1.CredentialDatabaseCollectionResponse{Items: items}), nil
+}
+
+func (s *CredentialRPC) CredentialDatabaseInsert(
+	ctx context.Context,
+	req *connect.Request[credentialv1.CredentialDatabaseInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		credID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+		database    *mcredential.CredentialDatabase
+		exists      bool
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		credID, err := idwrap.NewFromBytes(item.GetCredentialId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		workspaceID, err := s.getCredentialWorkspaceID(ctx, credID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check if record already exists (auto-created by CredentialInsert) - BEFORE transaction
+		_, existErr := s.credReader.GetCredentialDatabase(ctx, credID)
+		var exists bool
+		switch {
+		case existErr == nil:
+			exists = true
+		case errors.Is(existErr, sql.ErrNoRows):
+			exists = false
+		default:
+			return nil, connect.NewError(connect.CodeInternal, existErr)
+		}
+
+		database := &mcredential.CredentialDatabase{
+			CredentialID:     credID,
+			ConnectionString: item.GetConnectionString(),
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			credID:      credID,
+			workspaceID: workspaceID,
+			database:    database,
+			exists:      exists,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer devtoolsdb.TxnRollback(tx)
+
+	csTx := s.cs.TX(tx)
+
+	for _, data := range validatedItems {
+		if data.exists {
+			// Record exists, update it instead
+			if err := csTx.UpdateCredentialDatabase(ctx, data.database); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		} else {
+			// Record doesn't exist, create it
+			if err := csTx.CreateCredentialDatabase(ctx, data.database); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	for _, data := range validatedItems {
+		if s.databaseStream != nil {
+			s.databaseStream.Publish(CredentialDatabaseTopic{WorkspaceID: data.workspaceID}, CredentialDatabaseEvent{
+				Type:   eventTypeInsert,
+				Secret: converter.ToAPICredentialDatabase(*data.database),
+			})
+		}
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *CredentialRPC) CredentialDatabaseUpdate(
+	ctx context.Context,
+	req *connect.Request[credentialv1.CredentialDatabaseUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		credID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+		updated     *mcredential.CredentialDatabase
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		credID, err := idwrap.NewFromBytes(item.GetCredentialId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		workspaceID, err := s.getCredentialWorkspaceID(ctx, credID)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err := s.credReader.GetCredentialDatabase(ctx, credID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		updated := *existing
+
+		if item.ConnectionString != nil {
+			updated.ConnectionString = *item.ConnectionString
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			credID:      credID,
+			workspaceID: workspaceID,
+			updated:     &updated,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer devtoolsdb.TxnRollback(tx)
+
+	csTx := s.cs.TX(tx)
+
+	for _, data := range validatedItems {
+		if err := csTx.UpdateCredentialDatabase(ctx, data.updated); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	for _, data := range validatedItems {
+		if s.databaseStream != nil {
+			s.databaseStream.Publish(CredentialDatabaseTopic{WorkspaceID: data.workspaceID}, CredentialDatabaseEvent{
+				Type:   eventTypeUpdate,
+				Secret: converter.ToAPICredentialDatabase(*data.updated),
+			})
+		}
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *CredentialRPC) CredentialDatabaseDelete(
+	ctx context.Context,
+	req *connect.Request[credentialv1.CredentialDatabaseDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		credID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		credID, err := idwrap.NewFromBytes(item.GetCredentialId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		workspaceID, err := s.getCredentialWorkspaceID(ctx, credID)
+		if err != nil {
+			if connect.CodeOf(err) == connect.CodeNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			credID:      credID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	// Note: File deletion is handled by frontend via File collection
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer devtoolsdb.TxnRollback(tx)
+
+	csTx := s.cs.TX(tx)
+
+	for _, data := range validatedItems {
+		if err := csTx.DeleteCredential(ctx, data.credID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	for _, data := range validatedItems {
+		if s.databaseStream != nil {
+			s.databaseStream.Publish(CredentialDatabaseTopic{WorkspaceID: data.workspaceID}, CredentialDatabaseEvent{
+				Type:   eventTypeDelete,
+				Secret: &credentialv1.CredentialDatabase{CredentialId: data.credID.Bytes()},
+			})
+		}
+		if s.credStream != nil {
+			s.credStream.Publish(CredentialTopic{WorkspaceID: data.workspaceID}, CredentialEvent{
+				Type:       eventTypeDelete,
+				Credential: &credentialv1.Credential{CredentialId: data.credID.Bytes()},
+			})
+		}
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *CredentialRPC) CredentialDatabaseSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[credentialv1.CredentialDatabaseSyncResponse],
+) error {
+	userID, err := mwauth.GetContextUserID(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	return s.streamCredentialDatabaseSync(ctx, userID, stream.Send)
+}
+
+func (s *CredentialRPC) streamCredentialDatabaseSync(
+	ctx context.Context,
+	userID idwrap.IDWrap,
+	send func(*credentialv1.CredentialDatabaseSyncResponse) error,
+) error {
+	var workspaceSet sync.Map
+
+	filter := func(topic CredentialDatabaseTopic) bool {
+		_, ok := workspaceSet.Load(topic.WorkspaceID.String())
+		return ok
+	}
+
+	workspaces, err := s.ws.GetWorkspacesByUserIDOrdered(ctx, userID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	for _, ws := range workspaces {
+		workspaceSet.Store(ws.ID.String(), true)
+	}
+
+	if s.databaseStream == nil {
+		<-ctx.Done()
+		return nil
+	}
+
+	eventCh, err := s.databaseStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-eventCh:
+			if !ok {
+				return nil
+			}
+
+			var syncItem *credentialv1.CredentialDatabaseSync
+			switch evt.Payload.Type {
+			case eventTypeInsert, eventTypeUpdate:
+				syncItem = &credentialv1.CredentialDatabaseSync{
+					Value: &credentialv1.CredentialDatabaseSync_ValueUnion{
+						Kind: credentialv1.CredentialDatabaseSync_ValueUnion_KIND_UPSERT,
+						Upsert: &credentialv1.CredentialDatabaseSyncUpsert{
+							CredentialId:     evt.Payload.Secret.CredentialId,
+							ConnectionString: evt.Payload.Secret.ConnectionString,
+						},
+					},
+				}
+			case eventTypeDelete:
+				syncItem = &credentialv1.CredentialDatabaseSync{
+					Value: &credentialv1.CredentialDatabaseSync_ValueUnion{
+						Kind:   credentialv1.CredentialDatabaseSync_ValueUnion_KIND_DELETE,
+						Delete: &credentialv1.CredentialDatabaseSyncDelete{CredentialId: evt.Payload.Secret.CredentialId},
+					},
+				}
+			}
+
+			if syncItem != nil {
+				if err := send(&credentialv1.CredentialDatabaseSyncResponse{
+					Items: []*credentialv1.CredentialDatabaseSync{syncItem},
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// --- CredentialMessageBroker Collection CRUD+Sync ---
+
+func (s *CredentialRPC) CredentialMessageBrokerCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[credentialv1.CredentialMessageBrokerCollectionResponse], error) {
+	workspaces, err := s.getAccessibleWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*credentialv1.CredentialMessageBroker
+	for _, ws := range workspaces {
+		creds, err := s.credReader.ListCredentials(ctx, ws.ID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, c := range creds {
+			if c.Kind != mcredential.CREDENTIAL_KIND_MESSAGE_BROKER {
+				continue
+			}
+			messageBroker, err := s.credReader.GetCredentialMessageBroker(ctx, c.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			items = append(items, converter.ToAPICredentialMessageBroker(*messageBroker))
+		}
+	}
+
+	return connect.NewResponse(&credentialv1.CredentialMessageBrokerCollectionResponse{Items: items}), nil
+}
+
+func (s *CredentialRPC) CredentialMessageBrokerInsert(
+	ctx context.Context,
+	req *connect.Request[credentialv1.CredentialMessageBrokerInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		credID        idwrap.IDWrap
+		workspaceID   idwrap.IDWrap
+		messageBroker *mcredential.CredentialMessageBroker
+		exists        bool
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		credID, err := idwrap.NewFromBytes(item.GetCredentialId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		workspaceID, err := s.getCredentialWorkspaceID(ctx, credID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check if record already exists (auto-created by CredentialInsert) - BEFORE transaction
+		_, existErr := s.credReader.GetCredentialMessageBroker(ctx, credID)
+		var exists bool
+		switch {
+		case existErr == nil:
+			exists = true
+		case errors.Is(existErr, sql.ErrNoRows):
+			exists = false
+		default:
+			return nil, connect.NewError(connect.CodeInternal, existErr)
+		}
+
+		messageBroker := &mcredential.CredentialMessageBroker{
+			CredentialID: credID,
+			Url:          item.GetUrl(),
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			credID:        credID,
+			workspaceID:   workspaceID,
+			messageBroker: messageBroker,
+			exists:        exists,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer devtoolsdb.TxnRollback(tx)
+
+	csTx := s.cs.TX(tx)
+
+	for _, data := range validatedItems {
+		if data.exists {
+			// Record exists, update it instead
+			if err := csTx.UpdateCredentialMessageBroker(ctx, data.messageBroker); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		} else {
+			// Record doesn't exist, create it
+			if err := csTx.CreateCredentialMessageBroker(ctx, data.messageBroker); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	for _, data := range validatedItems {
+		if s.messageBrokerStream != nil {
+			s.messageBrokerStream.Publish(CredentialMessageBrokerTopic{WorkspaceID: data.workspaceID}, CredentialMessageBrokerEvent{
+				Type:   eventTypeInsert,
+				Secret: converter.ToAPICredentialMessageBroker(*data.messageBroker),
+			})
+		}
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *CredentialRPC) CredentialMessageBrokerUpdate(
+	ctx context.Context,
+	req *connect.Request[credentialv1.CredentialMessageBrokerUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		credID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+		updated     *mcredential.CredentialMessageBroker
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		credID, err := idwrap.NewFromBytes(item.GetCredentialId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		workspaceID, err := s.getCredentialWorkspaceID(ctx, credID)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err := s.credReader.GetCredentialMessageBroker(ctx, credID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		updated := *existing
+
+		if item.Url != nil {
+			updated.Url = *item.Url
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			credID:      credID,
+			workspaceID: workspaceID,
+			updated:     &updated,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer devtoolsdb.TxnRollback(tx)
+
+	csTx := s.cs.TX(tx)
+
+	for _, data := range validatedItems {
+		if err := csTx.UpdateCredentialMessageBroker(ctx, data.updated); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	for _, data := range validatedItems {
+		if s.messageBrokerStream != nil {
+			s.messageBrokerStream.Publish(CredentialMessageBrokerTopic{WorkspaceID: data.workspaceID}, CredentialMessageBrokerEvent{
+				Type:   eventTypeUpdate,
+				Secret: converter.ToAPICredentialMessageBroker(*data.updated),
+			})
+		}
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *CredentialRPC) CredentialMessageBrokerDelete(
+	ctx context.Context,
+	req *connect.Request[credentialv1.CredentialMessageBrokerDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		credID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		credID, err := idwrap.NewFromBytes(item.GetCredentialId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		workspaceID, err := s.getCredentialWorkspaceID(ctx, credID)
+		if err != nil {
+			if connect.CodeOf(err) == connect.CodeNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			credID:      credID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	// Note: File deletion is handled by frontend via File collection
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer devtoolsdb.TxnRollback(tx)
+
+	csTx := s.cs.TX(tx)
+
+	for _, data := range validatedItems {
+		if err := csTx.DeleteCredential(ctx, data.credID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	for _, data := range validatedItems {
+		if s.messageBrokerStream != nil {
+			s.messageBrokerStream.Publish(CredentialMessageBrokerTopic{WorkspaceID: data.workspaceID}, CredentialMessageBrokerEvent{
+				Type:   eventTypeDelete,
+				Secret: &credentialv1.CredentialMessageBroker{CredentialId: data.credID.Bytes()},
+			})
+		}
+		if s.credStream != nil {
+			s.credStream.Publish(CredentialTopic{WorkspaceID: data.workspaceID}, CredentialEvent{
+				Type:       eventTypeDelete,
+				Credential: &credentialv1.Credential{CredentialId: data.credID.Bytes()},
+			})
+		}
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *CredentialRPC) CredentialMessageBrokerSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[credentialv1.CredentialMessageBrokerSyncResponse],
+) error {
+	userID, err := mwauth.GetContextUserID(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	return s.streamCredentialMessageBrokerSync(ctx, userID, stream.Send)
+}
+
+func (s *CredentialRPC) streamCredentialMessageBrokerSync(
+	ctx context.Context,
+	userID idwrap.IDWrap,
+	send func(*credentialv1.CredentialMessageBrokerSyncResponse) error,
+) error {
+	var workspaceSet sync.Map
+
+	filter := func(topic CredentialMessageBrokerTopic) bool {
+		_, ok := workspaceSet.Load(topic.WorkspaceID.String())
+		return ok
+	}
+
+	workspaces, err := s.ws.GetWorkspacesByUserIDOrdered(ctx, userID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	for _, ws := range workspaces {
+		workspaceSet.Store(ws.ID.String(), true)
+	}
+
+	if s.messageBrokerStream == nil {
+		<-ctx.Done()
+		return nil
+	}
+
+	eventCh, err := s.messageBrokerStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-eventCh:
+			if !ok {
+				return nil
+			}
+
+			var syncItem *credentialv1.CredentialMessageBrokerSync
+			switch evt.Payload.Type {
+			case eventTypeInsert, eventTypeUpdate:
+				syncItem = &credentialv1.CredentialMessageBrokerSync{
+					Value: &credentialv1.CredentialMessageBrokerSync_ValueUnion{
+						Kind: credentialv1.CredentialMessageBrokerSync_ValueUnion_KIND_UPSERT,
+						Upsert: &credentialv1.CredentialMessageBrokerSyncUpsert{
+							CredentialId: evt.Payload.Secret.CredentialId,
+							Url:          evt.Payload.Secret.Url,
+						},
+					},
+				}
+			case eventTypeDelete:
+				syncItem = &credentialv1.CredentialMessageBrokerSync{
+					Value: &credentialv1.CredentialMessageBrokerSync_ValueUnion{
+						Kind:   credentialv1.CredentialMessageBrokerSync_ValueUnion_KIND_DELETE,
+						Delete: &credentialv1.CredentialMessageBrokerSyncDelete{CredentialId: evt.Payload.Secret.CredentialId},
+					},
+				}
+			}
+
+			if syncItem != nil {
+				if err := send(&credentialv1.CredentialMessageBrokerSyncResponse{
+					Items: []*credentialv1.CredentialMessageBrokerSync{syncItem},
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
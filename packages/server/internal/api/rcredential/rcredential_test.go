@@ -52,10 +52,14 @@ func newCredentialFixture(t *testing.T) *credentialFixture {
 	openAiStream := memory.NewInMemorySyncStreamer[CredentialOpenAiTopic, CredentialOpenAiEvent]()
 	geminiStream := memory.NewInMemorySyncStreamer[CredentialGeminiTopic, CredentialGeminiEvent]()
 	anthropicStream := memory.NewInMemorySyncStreamer[CredentialAnthropicTopic, CredentialAnthropicEvent]()
+	databaseStream := memory.NewInMemorySyncStreamer[CredentialDatabaseTopic, CredentialDatabaseEvent]()
+	messageBrokerStream := memory.NewInMemorySyncStreamer[CredentialMessageBrokerTopic, CredentialMessageBrokerEvent]()
 	t.Cleanup(credStream.Shutdown)
 	t.Cleanup(openAiStream.Shutdown)
 	t.Cleanup(geminiStream.Shutdown)
 	t.Cleanup(anthropicStream.Shutdown)
+	t.Cleanup(databaseStream.Shutdown)
+	t.Cleanup(messageBrokerStream.Shutdown)
 
 	// Create user
 	userID := idwrap.NewNow()
@@ -81,10 +85,12 @@ func newCredentialFixture(t *testing.T) *credentialFixture {
 			Credential: credReader,
 		},
 		Streamers: CredentialRPCStreamers{
-			Credential: credStream,
-			OpenAi:     openAiStream,
-			Gemini:     geminiStream,
-			Anthropic:  anthropicStream,
+			Credential:    credStream,
+			OpenAi:        openAiStream,
+			Gemini:        geminiStream,
+			Anthropic:     anthropicStream,
+			Database:      databaseStream,
+			MessageBroker: messageBrokerStream,
 		},
 	})
 
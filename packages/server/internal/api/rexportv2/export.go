@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/middleware/mwauth"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/transport"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mfile"
@@ -19,6 +20,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/shttp"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/suser"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/swebsocket"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcodegen"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/yamlflowsimplev2"
 
 	"gopkg.in/yaml.v3"
@@ -142,6 +144,9 @@ func (e *SimpleExporter) ExportToYAML(ctx context.Context, data *WorkspaceExport
 		return nil, fmt.Errorf("failed to export workspace bundle: %w", err)
 	}
 
+	// The download is a single document, so .graphql query files are inlined.
+	yamlflowsimplev2.InlineGraphQLQueries(bundle)
+
 	// Use yamlflowsimplev2 to marshal to YAML
 	yamlData, err := yamlflowsimplev2.MarshalSimplifiedYAML(bundle)
 	if err != nil {
@@ -198,6 +203,35 @@ func (e *SimpleExporter) ExportToCurl(ctx context.Context, data *WorkspaceExport
 	return strings.Join(commands, "\n\n"), nil
 }
 
+// ExportToCode renders HTTP requests as a program of the given language, or
+// flows as a k6 script. Requests are delta-resolved and interpolated with the
+// active environment by the code generator.
+func (e *SimpleExporter) ExportToCode(ctx context.Context, workspaceID idwrap.IDWrap, language tcodegen.Language, httpIDs, flowIDs []idwrap.IDWrap) (string, error) {
+	if e.ioWorkspaceService == nil {
+		return "", fmt.Errorf("ioWorkspaceService is required for code export")
+	}
+
+	bundle, err := e.ioWorkspaceService.Export(ctx, ioworkspace.ExportOptions{
+		WorkspaceID:         workspaceID,
+		IncludeHTTP:         true,
+		IncludeFlows:        language == tcodegen.LanguageK6,
+		IncludeEnvironments: true,
+		FilterByFlowIDs:     flowIDs,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to export workspace bundle: %w", err)
+	}
+
+	code, err := tcodegen.GenerateBundle(bundle, language, tcodegen.BundleOptions{
+		HTTPIDs: httpIDs,
+		FlowIDs: flowIDs,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s code generation failed: %w", language, err)
+	}
+	return code, nil
+}
+
 // ExportGraphQLToCurl exports GraphQL requests as cURL commands (POST with JSON body)
 func (e *SimpleExporter) ExportGraphQLToCurl(ctx context.Context, graphqlIDs []idwrap.IDWrap) (string, error) {
 	if len(graphqlIDs) == 0 {
@@ -218,7 +252,9 @@ func (e *SimpleExporter) ExportGraphQLToCurl(ctx context.Context, graphqlIDs []i
 
 		var cmd strings.Builder
 		cmd.WriteString(fmt.Sprintf("curl -X POST '%s'", gql.Url))
-		cmd.WriteString(" -H \"Content-Type: application/json\"")
+		if len(gql.Uploads) == 0 {
+			cmd.WriteString(" -H \"Content-Type: application/json\"")
+		}
 
 		for _, h := range headers {
 			if h.Enabled {
@@ -226,9 +262,21 @@ func (e *SimpleExporter) ExportGraphQLToCurl(ctx context.Context, graphqlIDs []i
 			}
 		}
 
-		// Build JSON body with query and variables
-		body := buildGraphQLJSONBody(gql.Query, gql.Variables)
-		cmd.WriteString(fmt.Sprintf(" --data-raw '%s'", strings.ReplaceAll(body, "'", "'\"'\"'")))
+		// Build JSON body with query and variables; batched operations
+		// follow the request's own in an array
+		body := buildGraphQLJSONBody(gql.Query, gql.Variables, gql.OperationName)
+		if len(gql.Batch) > 0 {
+			bodies := []string{body}
+			for _, op := range gql.Batch {
+				bodies = append(bodies, buildGraphQLJSONBody(op.Query, op.Variables, op.OperationName))
+			}
+			body = "[" + strings.Join(bodies, ",") + "]"
+		}
+		if len(gql.Uploads) > 0 {
+			writeGraphQLUploadForm(&cmd, body, gql.Uploads)
+		} else {
+			cmd.WriteString(fmt.Sprintf(" --data-raw '%s'", quoteSingle(body)))
+		}
 		cmd.WriteString(fmt.Sprintf(" # %s", gql.Name))
 		commands = append(commands, cmd.String())
 	}
@@ -239,17 +287,54 @@ func (e *SimpleExporter) ExportGraphQLToCurl(ctx context.Context, graphqlIDs []i
 	return strings.Join(commands, "\n\n"), nil
 }
 
+// writeGraphQLUploadForm writes the form fields of the GraphQL multipart
+// request spec: the operations with null at each upload path, the map of file
+// fields to paths, and one field per file.
+func writeGraphQLUploadForm(cmd *strings.Builder, body string, uploads []mgraphql.GraphQLUpload) {
+	tUploads := make([]transport.Upload, len(uploads))
+	for i, u := range uploads {
+		tUploads[i] = transport.Upload{Path: u.Path, File: u.File}
+	}
+
+	operations := body
+	if json.Valid([]byte(body)) {
+		if nulled, err := transport.NullUploads(json.RawMessage(body), tUploads); err == nil {
+			if b, err := json.Marshal(nulled); err == nil {
+				operations = string(b)
+			}
+		}
+	}
+	files, fileMap := transport.UploadMap(tUploads)
+	mapJSON, _ := json.Marshal(fileMap)
+
+	cmd.WriteString(fmt.Sprintf(" -F 'operations=%s'", quoteSingle(operations)))
+	cmd.WriteString(fmt.Sprintf(" -F 'map=%s'", quoteSingle(string(mapJSON))))
+	for i, file := range files {
+		cmd.WriteString(fmt.Sprintf(" -F '%d=@%s'", i, quoteSingle(file)))
+	}
+}
+
+// quoteSingle escapes s for use inside a single-quoted shell string.
+func quoteSingle(s string) string {
+	return strings.ReplaceAll(s, "'", "'\"'\"'")
+}
+
 // buildGraphQLJSONBody builds a JSON string with query and optional variables
-func buildGraphQLJSONBody(query, variables string) string {
+// and operation name
+func buildGraphQLJSONBody(query, variables, operationName string) string {
 	// Escape the query string for JSON
 	queryJSON, _ := json.Marshal(query)
 
-	if variables == "" || variables == "{}" {
-		return fmt.Sprintf(`{"query":%s}`, string(queryJSON))
+	body := fmt.Sprintf(`{"query":%s`, string(queryJSON))
+	if variables != "" && variables != "{}" {
+		// Variables is already a JSON string, use it directly
+		body += fmt.Sprintf(`,"variables":%s`, variables)
 	}
-
-	// Variables is already a JSON string, use it directly
-	return fmt.Sprintf(`{"query":%s,"variables":%s}`, string(queryJSON), variables)
+	if operationName != "" {
+		nameJSON, _ := json.Marshal(operationName)
+		body += fmt.Sprintf(`,"operationName":%s`, string(nameJSON))
+	}
+	return body + "}"
 }
 
 // ExportGraphQLToYAML exports GraphQL requests as a focused YAML
@@ -273,12 +358,16 @@ func (e *SimpleExporter) ExportGraphQLToYAML(ctx context.Context, graphqlIDs []i
 		}
 
 		gqlDef := yamlflowsimplev2.YamlGraphQLDefV2{
-			Name:       gql.Name,
-			URL:        gql.Url,
-			Query:      gql.Query,
-			Variables:  gql.Variables,
-			Headers:    buildGraphQLHeaderMapOrSliceExport(headers),
-			Assertions: buildGraphQLAssertionsExport(asserts),
+			Name:           gql.Name,
+			URL:            gql.Url,
+			Query:          gql.Query,
+			Variables:      gql.Variables,
+			OperationName:  gql.OperationName,
+			Headers:        buildGraphQLHeaderMapOrSliceExport(headers),
+			Assertions:     buildGraphQLAssertionsExport(asserts),
+			PersistedQuery: gql.PersistedQuery,
+			Batch:          buildGraphQLBatchExport(gql.Batch),
+			Uploads:        buildGraphQLUploadsExport(gql.Uploads),
 		}
 		gqlDefs = append(gqlDefs, gqlDef)
 	}
@@ -419,6 +508,28 @@ func buildGraphQLAssertionsExport(asserts []mgraphql.GraphQLAssert) yamlflowsimp
 	return yamlflowsimplev2.AssertionsOrSlice(result)
 }
 
+func buildGraphQLBatchExport(batch []mgraphql.GraphQLOperation) []yamlflowsimplev2.YamlGraphQLOperation {
+	if len(batch) == 0 {
+		return nil
+	}
+	result := make([]yamlflowsimplev2.YamlGraphQLOperation, len(batch))
+	for i, op := range batch {
+		result[i] = yamlflowsimplev2.YamlGraphQLOperation{Query: op.Query, Variables: op.Variables, OperationName: op.OperationName}
+	}
+	return result
+}
+
+func buildGraphQLUploadsExport(uploads []mgraphql.GraphQLUpload) map[string]string {
+	if len(uploads) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(uploads))
+	for _, u := range uploads {
+		result[u.Path] = u.File
+	}
+	return result
+}
+
 // Validator Implementation
 
 // SimpleValidator implements basic validation
@@ -705,3 +816,59 @@ func (s *Service) ExportCurl(ctx context.Context, req *ExportCurlRequest) (*Expo
 		Data: curlData,
 	}, nil
 }
+
+// codeFileExtensions names the file a code export is saved as
+var codeFileExtensions = map[tcodegen.Language]string{
+	tcodegen.LanguageGo:         ".go",
+	tcodegen.LanguagePython:     ".py",
+	tcodegen.LanguageJavaScript: ".mjs",
+	tcodegen.LanguageHTTPie:     ".sh",
+	tcodegen.LanguageK6:         "_k6.js",
+}
+
+// ExportCode performs a code generation export operation
+func (s *Service) ExportCode(ctx context.Context, req *ExportCodeRequest) (*ExportCodeResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	s.logger.Info("Starting code export operation",
+		"workspace_id", req.WorkspaceID,
+		"language", req.Language,
+		"http_ids_count", len(req.HTTPIDs),
+		"flow_ids_count", len(req.FlowIDs))
+
+	if req.WorkspaceID.Compare(idwrap.IDWrap{}) == 0 {
+		return nil, NewValidationError("workspaceId", "workspace ID cannot be empty")
+	}
+	extension, ok := codeFileExtensions[req.Language]
+	if !ok {
+		return nil, NewValidationError("language", fmt.Sprintf("unsupported language: %v", req.Language))
+	}
+
+	if err := s.validator.ValidateWorkspaceAccess(ctx, req.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	simpleExporter, ok := s.exporter.(*SimpleExporter)
+	if !ok {
+		return nil, fmt.Errorf("exporter does not support code export")
+	}
+
+	code, err := simpleExporter.ExportToCode(ctx, req.WorkspaceID, req.Language, req.HTTPIDs, req.FlowIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	name := "export" + extension
+	if workspace, err := s.storage.GetWorkspace(ctx, req.WorkspaceID); err == nil && workspace.Name != "" {
+		name = workspace.Name + extension
+	}
+
+	return &ExportCodeResponse{
+		Name: name,
+		Data: code,
+	}, nil
+}
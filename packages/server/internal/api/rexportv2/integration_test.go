@@ -590,6 +590,7 @@ func createImportService(t *testing.T, fixture *integrationTestFixture) *rimport
 		fixture.services.EdgeService,
 		fixture.services.EnvService,
 		fixture.services.VarService,
+		nil,
 	)
 	validator := rimportv2.NewValidator(&fixture.services.UserService, fixture.services.WorkspaceUserReader)
 
@@ -19,6 +19,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/suser"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/swebsocket"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcodegen"
 	exportv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/export/v1"
 	"github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/export/v1/exportv1connect"
 
@@ -47,6 +48,14 @@ type ExportCurlRequest struct {
 	HTTPIDs     []idwrap.IDWrap
 }
 
+// ExportCodeRequest represents a request to export requests or flows as code
+type ExportCodeRequest struct {
+	WorkspaceID idwrap.IDWrap
+	Language    tcodegen.Language
+	HTTPIDs     []idwrap.IDWrap
+	FlowIDs     []idwrap.IDWrap
+}
+
 // ExportResponse represents the response from an export operation
 type ExportResponse struct {
 	Name string
@@ -58,6 +67,12 @@ type ExportCurlResponse struct {
 	Data string
 }
 
+// ExportCodeResponse represents the response from a code export operation
+type ExportCodeResponse struct {
+	Name string
+	Data string
+}
+
 // ExportFilter represents filters for export operations
 type ExportFilter struct {
 	FileIDs    []idwrap.IDWrap
@@ -343,6 +358,35 @@ func (h *ExportV2RPC) ExportCurlGraphQL(ctx context.Context, req *connect.Reques
 	}), nil
 }
 
+// ExportCode implements the ExportCode RPC method
+func (h *ExportV2RPC) ExportCode(ctx context.Context, req *connect.Request[exportv1.ExportCodeRequest]) (*connect.Response[exportv1.ExportCodeResponse], error) {
+	h.logger.Info("Received ExportCode request",
+		"workspace_id", req.Msg.WorkspaceId,
+		"language", req.Msg.Language,
+		"http_ids_count", len(req.Msg.HttpIds),
+		"flow_ids_count", len(req.Msg.FlowIds))
+
+	codeReq, err := convertToExportCodeRequest(req.Msg)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	response, err := h.service.ExportCode(ctx, codeReq)
+	if err != nil {
+		return nil, handleServiceError(err)
+	}
+
+	h.logger.Info("ExportCode completed successfully",
+		"workspace_id", req.Msg.WorkspaceId,
+		"export_name", response.Name,
+		"data_size", len(response.Data))
+
+	return connect.NewResponse(&exportv1.ExportCodeResponse{
+		Name: response.Name,
+		Data: response.Data,
+	}), nil
+}
+
 // Private conversion functions
 
 // convertToExportRequest converts protobuf request to internal request model
@@ -398,6 +442,53 @@ func convertToExportCurlRequest(msg *exportv1.ExportCurlRequest) (*ExportCurlReq
 	}, nil
 }
 
+// codeLanguages maps the protobuf languages onto code generator targets
+var codeLanguages = map[exportv1.ExportCodeLanguage]tcodegen.Language{
+	exportv1.ExportCodeLanguage_EXPORT_CODE_LANGUAGE_GO:         tcodegen.LanguageGo,
+	exportv1.ExportCodeLanguage_EXPORT_CODE_LANGUAGE_PYTHON:     tcodegen.LanguagePython,
+	exportv1.ExportCodeLanguage_EXPORT_CODE_LANGUAGE_JAVASCRIPT: tcodegen.LanguageJavaScript,
+	exportv1.ExportCodeLanguage_EXPORT_CODE_LANGUAGE_HTTPIE:     tcodegen.LanguageHTTPie,
+	exportv1.ExportCodeLanguage_EXPORT_CODE_LANGUAGE_K6:         tcodegen.LanguageK6,
+}
+
+// convertToExportCodeRequest converts protobuf code request to internal request model
+func convertToExportCodeRequest(msg *exportv1.ExportCodeRequest) (*ExportCodeRequest, error) {
+	workspaceID, err := idwrap.NewFromBytes(msg.WorkspaceId)
+	if err != nil {
+		return nil, NewValidationError("workspaceId", err.Error())
+	}
+
+	language, ok := codeLanguages[msg.Language]
+	if !ok {
+		return nil, NewValidationError("language", fmt.Sprintf("unsupported language: %v", msg.Language))
+	}
+
+	httpIDs := make([]idwrap.IDWrap, 0, len(msg.HttpIds))
+	for _, httpIdBytes := range msg.HttpIds {
+		httpID, err := idwrap.NewFromBytes(httpIdBytes)
+		if err != nil {
+			return nil, NewValidationError("httpIds", err.Error())
+		}
+		httpIDs = append(httpIDs, httpID)
+	}
+
+	flowIDs := make([]idwrap.IDWrap, 0, len(msg.FlowIds))
+	for _, flowIdBytes := range msg.FlowIds {
+		flowID, err := idwrap.NewFromBytes(flowIdBytes)
+		if err != nil {
+			return nil, NewValidationError("flowIds", err.Error())
+		}
+		flowIDs = append(flowIDs, flowID)
+	}
+
+	return &ExportCodeRequest{
+		WorkspaceID: workspaceID,
+		Language:    language,
+		HTTPIDs:     httpIDs,
+		FlowIDs:     flowIDs,
+	}, nil
+}
+
 // convertToExportResponse converts internal response to protobuf response model
 func convertToExportResponse(resp *ExportResponse) (*exportv1.ExportResponse, error) {
 	return &exportv1.ExportResponse{
@@ -19,6 +19,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/eventstream"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowexec"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowbuilder"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowschedule"
 	gqlresolver "github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/resolver"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/http/resolver"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
@@ -96,6 +97,31 @@ type FlowVariableEvent struct {
 	Variable mflow.FlowVariable
 }
 
+// FlowScheduleTopic identifies the flow whose schedules are being published.
+type FlowScheduleTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// FlowScheduleEvent describes a flow schedule change for sync streaming.
+type FlowScheduleEvent struct {
+	Type     string
+	FlowID   idwrap.IDWrap
+	Schedule mflow.FlowSchedule
+}
+
+// FlowScheduleRunTopic identifies the flow whose schedule runs are being
+// published.
+type FlowScheduleRunTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// FlowScheduleRunEvent describes a recorded schedule run for sync streaming.
+type FlowScheduleRunEvent struct {
+	Type   string
+	FlowID idwrap.IDWrap
+	Run    mflow.FlowScheduleRun
+}
+
 // ForTopic identifies the flow whose For nodes are being published.
 type ForTopic struct {
 	FlowID idwrap.IDWrap
@@ -228,6 +254,9 @@ const (
 	flowVarEventInsert     = eventTypeInsert
 	flowVarEventUpdate     = eventTypeUpdate
 	flowVarEventDelete     = eventTypeDelete
+	scheduleEventInsert    = eventTypeInsert
+	scheduleEventUpdate    = eventTypeUpdate
+	scheduleEventDelete    = eventTypeDelete
 	flowVersionEventInsert = eventTypeInsert
 	flowVersionEventUpdate = eventTypeUpdate
 	flowVersionEventDelete = eventTypeDelete
@@ -300,10 +329,25 @@ type FlowServiceV2Services struct {
 	NodeSubFlowTrigger   *sflow.NodeSubFlowTriggerService
 	NodeSubFlowReturn    *sflow.NodeSubFlowReturnService
 	NodeRunSubFlow       *sflow.NodeRunSubFlowService
+	NodeParallel         *sflow.NodeParallelService
+	NodePoll             *sflow.NodePollService
+	NodeSwitch           *sflow.NodeSwitchService
+	NodeWebhookTrigger   *sflow.NodeWebhookTriggerService
+	NodeGraphQLSubscription *sflow.NodeGraphQLSubscriptionService
+	NodeWsExpect         *sflow.NodeWsExpectService
+	NodeSocketConnection *sflow.NodeSocketConnectionService
+	NodeSocketSend       *sflow.NodeSocketSendService
+	NodeSocketReceive    *sflow.NodeSocketReceiveService
+	NodeDbQuery          *sflow.NodeDbQueryService
+	NodeExec          *sflow.NodeExecService
+	NodeMqPublish          *sflow.NodeMqPublishService
+	NodeMqConsume          *sflow.NodeMqConsumeService
 	WebSocket        *swebsocket.WebSocketService
 	WebSocketHeader  *swebsocket.WebSocketHeaderService
+	WebSocketMessage *swebsocket.WebSocketMessageService
 	NodeExecution    *sflow.NodeExecutionService
 	FlowVariable  *sflow.FlowVariableService
+	FlowSchedule  *sflow.FlowScheduleService
 	Env           *senv.EnvironmentService
 	Var           *senv.VariableService
 	Http          *shttp.HTTPService
@@ -313,6 +357,7 @@ type FlowServiceV2Services struct {
 	GraphQL         *sgraphql.GraphQLService
 	GraphQLHeader   *sgraphql.GraphQLHeaderService
 	GraphQLAssert   *sgraphql.GraphQLAssertService
+	GraphQLSchema   *sgraphql.GraphQLSchemaService
 	File            *sfile.FileService
 	Importer      WorkspaceImporter
 	Credential    scredential.CredentialService
@@ -385,6 +430,8 @@ type FlowServiceV2Streamers struct {
 	Edge               eventstream.SyncStreamer[EdgeTopic, EdgeEvent]
 	Var                eventstream.SyncStreamer[FlowVariableTopic, FlowVariableEvent]
 	Version            eventstream.SyncStreamer[FlowVersionTopic, FlowVersionEvent]
+	Schedule           eventstream.SyncStreamer[FlowScheduleTopic, FlowScheduleEvent]
+	ScheduleRun        eventstream.SyncStreamer[FlowScheduleRunTopic, FlowScheduleRunEvent]
 	For                eventstream.SyncStreamer[ForTopic, ForEvent]
 	Condition          eventstream.SyncStreamer[ConditionTopic, ConditionEvent]
 	ForEach            eventstream.SyncStreamer[ForEachTopic, ForEachEvent]
@@ -416,6 +463,12 @@ type FlowServiceV2Deps struct {
 	GraphQLResolver gqlresolver.GraphQLResolver
 	Logger          *slog.Logger
 	JsClient        node_js_executorv1connect.NodeJsExecutorServiceClient
+	// AllowExec lets flows run Exec nodes, which start local commands.
+	// Without it a flow containing one fails to build.
+	AllowExec bool
+	// AllowLocalDatabases lets DB Query nodes open SQLite files on the
+	// server's disk. Without it such a query fails when run.
+	AllowLocalDatabases bool
 }
 
 func (d *FlowServiceV2Deps) Validate() error {
@@ -469,6 +522,19 @@ type FlowServiceV2RPC struct {
 	nsfts         *sflow.NodeSubFlowTriggerService
 	nsfrs         *sflow.NodeSubFlowReturnService
 	nrsfs         *sflow.NodeRunSubFlowService
+	nparallels    *sflow.NodeParallelService
+	npolls        *sflow.NodePollService
+	nswitches     *sflow.NodeSwitchService
+	nwebhooks     *sflow.NodeWebhookTriggerService
+	ngqsubs       *sflow.NodeGraphQLSubscriptionService
+	nwes          *sflow.NodeWsExpectService
+	nsoc          *sflow.NodeSocketConnectionService
+	nsos          *sflow.NodeSocketSendService
+	nsor          *sflow.NodeSocketReceiveService
+	ndbq          *sflow.NodeDbQueryService
+	nexe          *sflow.NodeExecService
+	nmqp          *sflow.NodeMqPublishService
+	nmqc          *sflow.NodeMqConsumeService
 	wsService     *swebsocket.WebSocketService
 	wsHeaderService *swebsocket.WebSocketHeaderService
 	gqls          *sgraphql.GraphQLService
@@ -476,6 +542,7 @@ type FlowServiceV2RPC struct {
 	gqlas         *sgraphql.GraphQLAssertService
 	nes      *sflow.NodeExecutionService
 	fvs      *sflow.FlowVariableService
+	fss      *sflow.FlowScheduleService
 	envs     *senv.EnvironmentService
 	vs       *senv.VariableService
 	hs       *shttp.HTTPService
@@ -491,6 +558,8 @@ type FlowServiceV2RPC struct {
 	edgeStream               eventstream.SyncStreamer[EdgeTopic, EdgeEvent]
 	varStream                eventstream.SyncStreamer[FlowVariableTopic, FlowVariableEvent]
 	versionStream            eventstream.SyncStreamer[FlowVersionTopic, FlowVersionEvent]
+	scheduleStream           eventstream.SyncStreamer[FlowScheduleTopic, FlowScheduleEvent]
+	scheduleRunStream        eventstream.SyncStreamer[FlowScheduleRunTopic, FlowScheduleRunEvent]
 	forStream                eventstream.SyncStreamer[ForTopic, ForEvent]
 	conditionStream          eventstream.SyncStreamer[ConditionTopic, ConditionEvent]
 	forEachStream            eventstream.SyncStreamer[ForEachTopic, ForEachEvent]
@@ -522,6 +591,9 @@ type FlowServiceV2RPC struct {
 	// Running flows map for cancellation
 	runningFlowsMu sync.Mutex
 	runningFlows   map[string]context.CancelFunc
+
+	// scheduler runs flow schedules; it is told when they change.
+	scheduler *flowschedule.Scheduler
 }
 
 func New(deps FlowServiceV2Deps) *FlowServiceV2RPC {
@@ -549,6 +621,24 @@ func New(deps FlowServiceV2Deps) *FlowServiceV2RPC {
 		builder, deps.Services.Flow, deps.Services.Edge, deps.JsClient, deps.Logger,
 	)
 	builder.SubFlowExecutor = subFlowExec
+	builder.NodeParallel = deps.Services.NodeParallel
+	builder.NodePoll = deps.Services.NodePoll
+	builder.NodeSwitch = deps.Services.NodeSwitch
+	builder.NodeWebhookTrigger = deps.Services.NodeWebhookTrigger
+	builder.NodeGraphQLSubscription = deps.Services.NodeGraphQLSubscription
+	builder.NodeWsExpect = deps.Services.NodeWsExpect
+	builder.NodeSocketConnection = deps.Services.NodeSocketConnection
+	builder.NodeSocketSend = deps.Services.NodeSocketSend
+	builder.NodeSocketReceive = deps.Services.NodeSocketReceive
+	builder.NodeDbQuery = deps.Services.NodeDbQuery
+	builder.NodeExec = deps.Services.NodeExec
+	builder.AllowExec = deps.AllowExec
+	builder.AllowLocalDatabases = deps.AllowLocalDatabases
+	builder.NodeMqPublish = deps.Services.NodeMqPublish
+	builder.NodeMqConsume = deps.Services.NodeMqConsume
+	builder.Credential = &deps.Services.Credential
+	builder.WebSocketMessage = deps.Services.WebSocketMessage
+	builder.GraphQLSchema = deps.Services.GraphQLSchema
 
 	// Build snapshot registry for flow version snapshots
 	registry := flowexec.NewSnapshotRegistry()
@@ -587,6 +677,45 @@ func New(deps FlowServiceV2Deps) *FlowServiceV2RPC {
 	if deps.Services.NodeRunSubFlow != nil {
 		registry.Register(&flowexec.RunSubFlowSnapshot{Service: deps.Services.NodeRunSubFlow})
 	}
+	if deps.Services.NodeParallel != nil {
+		registry.Register(&flowexec.ParallelSnapshot{Service: deps.Services.NodeParallel})
+	}
+	if deps.Services.NodePoll != nil {
+		registry.Register(&flowexec.PollSnapshot{Service: deps.Services.NodePoll})
+	}
+	if deps.Services.NodeSwitch != nil {
+		registry.Register(&flowexec.SwitchSnapshot{Service: deps.Services.NodeSwitch})
+	}
+	if deps.Services.NodeWebhookTrigger != nil {
+		registry.Register(&flowexec.WebhookTriggerSnapshot{Service: deps.Services.NodeWebhookTrigger})
+	}
+	if deps.Services.NodeGraphQLSubscription != nil {
+		registry.Register(&flowexec.GraphQLSubscriptionSnapshot{Service: deps.Services.NodeGraphQLSubscription})
+	}
+	if deps.Services.NodeWsExpect != nil {
+		registry.Register(&flowexec.WsExpectSnapshot{Service: deps.Services.NodeWsExpect})
+	}
+	if deps.Services.NodeSocketConnection != nil {
+		registry.Register(&flowexec.SocketConnectionSnapshot{Service: deps.Services.NodeSocketConnection})
+	}
+	if deps.Services.NodeSocketSend != nil {
+		registry.Register(&flowexec.SocketSendSnapshot{Service: deps.Services.NodeSocketSend})
+	}
+	if deps.Services.NodeSocketReceive != nil {
+		registry.Register(&flowexec.SocketReceiveSnapshot{Service: deps.Services.NodeSocketReceive})
+	}
+	if deps.Services.NodeDbQuery != nil {
+		registry.Register(&flowexec.DbQuerySnapshot{Service: deps.Services.NodeDbQuery})
+	}
+	if deps.Services.NodeExec != nil {
+		registry.Register(&flowexec.ExecSnapshot{Service: deps.Services.NodeExec})
+	}
+	if deps.Services.NodeMqPublish != nil {
+		registry.Register(&flowexec.MqPublishSnapshot{Service: deps.Services.NodeMqPublish})
+	}
+	if deps.Services.NodeMqConsume != nil {
+		registry.Register(&flowexec.MqConsumeSnapshot{Service: deps.Services.NodeMqConsume})
+	}
 
 	rpc := &FlowServiceV2RPC{
 		DB:                       deps.DB,
@@ -615,6 +744,19 @@ func New(deps FlowServiceV2Deps) *FlowServiceV2RPC {
 		nsfts:                    deps.Services.NodeSubFlowTrigger,
 		nsfrs:                    deps.Services.NodeSubFlowReturn,
 		nrsfs:                    deps.Services.NodeRunSubFlow,
+		nparallels:               deps.Services.NodeParallel,
+		npolls:                   deps.Services.NodePoll,
+		nswitches:                deps.Services.NodeSwitch,
+		nwebhooks:                deps.Services.NodeWebhookTrigger,
+		ngqsubs:                  deps.Services.NodeGraphQLSubscription,
+		nwes:                     deps.Services.NodeWsExpect,
+		nsoc:                     deps.Services.NodeSocketConnection,
+		nsos:                     deps.Services.NodeSocketSend,
+		nsor:                     deps.Services.NodeSocketReceive,
+		ndbq:                     deps.Services.NodeDbQuery,
+		nexe:                     deps.Services.NodeExec,
+		nmqp:                     deps.Services.NodeMqPublish,
+		nmqc:                     deps.Services.NodeMqConsume,
 		wsService:                deps.Services.WebSocket,
 		wsHeaderService:          deps.Services.WebSocketHeader,
 		gqls:                     deps.Services.GraphQL,
@@ -622,6 +764,7 @@ func New(deps FlowServiceV2Deps) *FlowServiceV2RPC {
 		gqlas:                    deps.Services.GraphQLAssert,
 		nes:                      deps.Services.NodeExecution,
 		fvs:                      deps.Services.FlowVariable,
+		fss:                      deps.Services.FlowSchedule,
 		envs:                     deps.Services.Env,
 		vs:                       deps.Services.Var,
 		hs:                       deps.Services.Http,
@@ -636,6 +779,8 @@ func New(deps FlowServiceV2Deps) *FlowServiceV2RPC {
 		edgeStream:               deps.Streamers.Edge,
 		varStream:                deps.Streamers.Var,
 		versionStream:            deps.Streamers.Version,
+		scheduleStream:           deps.Streamers.Schedule,
+		scheduleRunStream:        deps.Streamers.ScheduleRun,
 		forStream:                deps.Streamers.For,
 		conditionStream:          deps.Streamers.Condition,
 		forEachStream:            deps.Streamers.ForEach,
@@ -698,6 +843,7 @@ func (s *FlowServiceV2RPC) mutationPublisher() mutation.Publisher {
 		edgeStream:       s.edgeStream,
 		varStream:        s.varStream,
 		versionStream:    s.versionStream,
+		scheduleStream:   s.scheduleStream,
 		forStream:        s.forStream,
 		conditionStream:  s.conditionStream,
 		forEachStream:    s.forEachStream,
@@ -715,6 +861,7 @@ type rflowPublisher struct {
 	edgeStream       eventstream.SyncStreamer[EdgeTopic, EdgeEvent]
 	varStream        eventstream.SyncStreamer[FlowVariableTopic, FlowVariableEvent]
 	versionStream    eventstream.SyncStreamer[FlowVersionTopic, FlowVersionEvent]
+	scheduleStream   eventstream.SyncStreamer[FlowScheduleTopic, FlowScheduleEvent]
 	forStream        eventstream.SyncStreamer[ForTopic, ForEvent]
 	conditionStream  eventstream.SyncStreamer[ConditionTopic, ConditionEvent]
 	forEachStream    eventstream.SyncStreamer[ForEachTopic, ForEachEvent]
@@ -763,10 +910,38 @@ func (p *rflowPublisher) PublishAll(events []mutation.Event) {
 			p.publishNodeSubFlowReturn(evt)
 		case mutation.EntityFlowNodeRunSubFlow:
 			p.publishNodeRunSubFlow(evt)
+		case mutation.EntityFlowNodeParallel:
+			p.publishNodeParallel(evt)
+		case mutation.EntityFlowNodePoll:
+			p.publishNodePoll(evt)
+		case mutation.EntityFlowNodeSwitch:
+			p.publishNodeSwitch(evt)
+		case mutation.EntityFlowNodeWebhookTrigger:
+			p.publishNodeWebhookTrigger(evt)
+		case mutation.EntityFlowNodeGraphQLSubscription:
+			p.publishNodeGraphQLSubscription(evt)
+		case mutation.EntityFlowNodeWsExpect:
+			p.publishNodeWsExpect(evt)
+		case mutation.EntityFlowNodeSocketConnection:
+			p.publishNodeSocketConnection(evt)
+		case mutation.EntityFlowNodeSocketSend:
+			p.publishNodeSocketSend(evt)
+		case mutation.EntityFlowNodeSocketReceive:
+			p.publishNodeSocketReceive(evt)
+		case mutation.EntityFlowNodeDbQuery:
+			p.publishNodeDbQuery(evt)
+		case mutation.EntityFlowNodeExec:
+			p.publishNodeExec(evt)
+		case mutation.EntityFlowNodeMqPublish:
+			p.publishNodeMqPublish(evt)
+		case mutation.EntityFlowNodeMqConsume:
+			p.publishNodeMqConsume(evt)
 		case mutation.EntityFlowEdge:
 			p.publishEdge(evt)
 		case mutation.EntityFlowVariable:
 			p.publishVariable(evt)
+		case mutation.EntityFlowSchedule:
+			p.publishSchedule(evt)
 		}
 	}
 }
@@ -1484,3 +1659,523 @@ func (p *rflowPublisher) publishNodeRunSubFlow(evt mutation.Event) {
 		})
 	}
 }
+
+func (p *rflowPublisher) publishNodeParallel(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeParallelWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeParallelWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodePoll(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodePollWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodePollWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeSwitch(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeSwitchWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeSwitchWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeWebhookTrigger(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeWebhookTriggerWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeWebhookTriggerWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeGraphQLSubscription(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeGraphQLSubscriptionWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeGraphQLSubscriptionWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeWsExpect(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeWsExpectWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeWsExpectWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeSocketConnection(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeSocketConnectionWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeSocketConnectionWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeSocketSend(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeSocketSendWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeSocketSendWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeSocketReceive(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeSocketReceiveWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeSocketReceiveWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeDbQuery(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeDbQueryWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeDbQueryWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeMqPublish(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeMqPublishWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeMqPublishWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeMqConsume(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeMqConsumeWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeMqConsumeWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
+
+func (p *rflowPublisher) publishNodeExec(evt mutation.Event) {
+	if p.nodeStream == nil {
+		return
+	}
+
+	var node *flowv1.Node
+	var flowID idwrap.IDWrap
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = nodeEventInsert
+		if data, ok := evt.Payload.(nodeExecWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpUpdate:
+		eventType = nodeEventUpdate
+		if data, ok := evt.Payload.(nodeExecWithFlow); ok && data.baseNode != nil {
+			node = serializeNode(*data.baseNode)
+			flowID = data.flowID
+		}
+	case mutation.OpDelete:
+		eventType = nodeEventDelete
+		node = &flowv1.Node{
+			NodeId: evt.ID.Bytes(),
+			FlowId: evt.ParentID.Bytes(),
+		}
+		flowID = evt.ParentID
+	}
+
+	if node != nil {
+		p.nodeStream.Publish(NodeTopic{FlowID: flowID}, NodeEvent{
+			Type:   eventType,
+			FlowID: flowID,
+			Node:   node,
+		})
+	}
+}
@@ -43,16 +43,28 @@ func serializeFlow(flow mflow.Flow) *flowv1.Flow {
 }
 
 func serializeEdge(e mflow.Edge) *flowv1.Edge {
+	handle, caseIndex := HandleToAPI(e.SourceHandler)
 	return &flowv1.Edge{
 		EdgeId:       e.ID.Bytes(),
 		FlowId:       e.FlowID.Bytes(),
 		SourceId:     e.SourceID.Bytes(),
 		TargetId:     e.TargetID.Bytes(),
-		SourceHandle: flowv1.HandleKind(e.SourceHandler),
+		SourceHandle: handle,
+		CaseIndex:    caseIndex,
 		State:        flowv1.FlowItemState(e.State),
 	}
 }
 
+// HandleToAPI splits a stored edge handle into its API kind and, for switch
+// case handles, the case index.
+func HandleToAPI(h mflow.EdgeHandle) (flowv1.HandleKind, *int32) {
+	if index, ok := mflow.CaseIndex(h); ok {
+		caseIndex := int32(index) //nolint:gosec // case counts are small
+		return flowv1.HandleKind_HANDLE_KIND_CASE, &caseIndex
+	}
+	return flowv1.HandleKind(h), nil
+}
+
 func serializeNode(n mflow.Node) *flowv1.Node {
 	position := &flowv1.Position{
 		X: float32(n.PositionX),
@@ -107,6 +119,7 @@ func serializeNodeForEach(n mflow.NodeForEach) *flowv1.NodeForEach {
 		Path:          n.IterExpression,
 		Condition:     n.Condition.Comparisons.Expression,
 		ErrorHandling: converter.ToAPIErrorHandling(n.ErrorHandling),
+		Concurrency:   n.Concurrency,
 	}
 }
 
@@ -230,7 +243,10 @@ func buildCondition(expression string) mcondition.Condition {
 	}
 }
 
-func convertHandle(h flowv1.HandleKind) mflow.EdgeHandle {
+func convertHandle(h flowv1.HandleKind, caseIndex int32) mflow.EdgeHandle {
+	if h == flowv1.HandleKind_HANDLE_KIND_CASE {
+		return mflow.HandleCase(int(caseIndex))
+	}
 	return mflow.EdgeHandle(h)
 }
 
@@ -188,6 +188,54 @@ func (s *FlowServiceV2RPC) FlowNodesCopy(
 					bundle.FlowWsSendNodes = append(bundle.FlowWsSendNodes, *d)
 				}
 			}
+		case mflow.NODE_KIND_WS_EXPECT:
+			if s.nwes != nil {
+				if d, err := s.nwes.GetNodeWsExpect(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowWsExpectNodes = append(bundle.FlowWsExpectNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_SOCKET_CONNECTION:
+			if s.nsoc != nil {
+				if d, err := s.nsoc.GetNodeSocketConnection(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowSocketConnectionNodes = append(bundle.FlowSocketConnectionNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_SOCKET_SEND:
+			if s.nsos != nil {
+				if d, err := s.nsos.GetNodeSocketSend(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowSocketSendNodes = append(bundle.FlowSocketSendNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_SOCKET_RECEIVE:
+			if s.nsor != nil {
+				if d, err := s.nsor.GetNodeSocketReceive(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowSocketReceiveNodes = append(bundle.FlowSocketReceiveNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_DB_QUERY:
+			if s.ndbq != nil {
+				if d, err := s.ndbq.GetNodeDbQuery(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowDbQueryNodes = append(bundle.FlowDbQueryNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_EXEC:
+			if s.nexe != nil {
+				if d, err := s.nexe.GetNodeExec(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowExecNodes = append(bundle.FlowExecNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_MQ_PUBLISH:
+			if s.nmqp != nil {
+				if d, err := s.nmqp.GetNodeMqPublish(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowMqPublishNodes = append(bundle.FlowMqPublishNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_MQ_CONSUME:
+			if s.nmqc != nil {
+				if d, err := s.nmqc.GetNodeMqConsume(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowMqConsumeNodes = append(bundle.FlowMqConsumeNodes, *d)
+				}
+			}
 		case mflow.NODE_KIND_WAIT:
 			if s.nwaits != nil {
 				if d, err := s.nwaits.GetNodeWait(ctx, n.ID); err == nil && d != nil {
@@ -212,8 +260,42 @@ func (s *FlowServiceV2RPC) FlowNodesCopy(
 					bundle.FlowRunSubFlowNodes = append(bundle.FlowRunSubFlowNodes, *d)
 				}
 			}
+		case mflow.NODE_KIND_PARALLEL:
+			if s.nparallels != nil {
+				if d, err := s.nparallels.GetNodeParallel(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowParallelNodes = append(bundle.FlowParallelNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_POLL:
+			if s.npolls != nil {
+				if d, err := s.npolls.GetNodePoll(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowPollNodes = append(bundle.FlowPollNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_SWITCH:
+			if s.nswitches != nil {
+				if d, err := s.nswitches.GetNodeSwitch(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowSwitchNodes = append(bundle.FlowSwitchNodes, *d)
+				}
+			}
 		case mflow.NODE_KIND_WEBHOOK_TRIGGER:
-			// Not yet implemented
+			if s.nwebhooks != nil {
+				if d, err := s.nwebhooks.GetNodeWebhookTrigger(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowWebhookTriggerNodes = append(bundle.FlowWebhookTriggerNodes, *d)
+				}
+			}
+		case mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION:
+			if s.ngqsubs != nil {
+				if d, err := s.ngqsubs.GetNodeGraphQLSubscription(ctx, n.ID); err == nil && d != nil {
+					bundle.FlowGraphQLSubscriptionNodes = append(bundle.FlowGraphQLSubscriptionNodes, *d)
+					if d.GraphQLID != nil {
+						if g, err := s.gqls.Get(ctx, *d.GraphQLID); err == nil {
+							bundle.GraphQLRequests = append(bundle.GraphQLRequests, *g)
+							s.populateGraphQLBundle(ctx, g.ID, bundle)
+						}
+					}
+				}
+			}
 		}
 	}
 
@@ -228,7 +310,9 @@ func (s *FlowServiceV2RPC) FlowNodesCopy(
 		}
 	}
 
-	// Serialize to YAML
+	// Serialize to YAML. Pasting cannot read .graphql files, so queries are
+	// copied inline.
+	yamlflowsimplev2.InlineGraphQLQueries(bundle)
 	yamlBytes, err := yamlflowsimplev2.MarshalSimplifiedYAML(bundle)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to serialize nodes to YAML: %w", err))
@@ -485,6 +569,71 @@ func (s *FlowServiceV2RPC) FlowNodesPaste(
 			parsed.FlowRunSubFlowNodes[i].FlowNodeID = newID
 		}
 	}
+	for i := range parsed.FlowParallelNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowParallelNodes[i].FlowNodeID]; ok {
+			parsed.FlowParallelNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowPollNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowPollNodes[i].FlowNodeID]; ok {
+			parsed.FlowPollNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowSwitchNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowSwitchNodes[i].FlowNodeID]; ok {
+			parsed.FlowSwitchNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowWsExpectNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowWsExpectNodes[i].FlowNodeID]; ok {
+			parsed.FlowWsExpectNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowSocketConnectionNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowSocketConnectionNodes[i].FlowNodeID]; ok {
+			parsed.FlowSocketConnectionNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowSocketSendNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowSocketSendNodes[i].FlowNodeID]; ok {
+			parsed.FlowSocketSendNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowSocketReceiveNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowSocketReceiveNodes[i].FlowNodeID]; ok {
+			parsed.FlowSocketReceiveNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowDbQueryNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowDbQueryNodes[i].FlowNodeID]; ok {
+			parsed.FlowDbQueryNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowExecNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowExecNodes[i].FlowNodeID]; ok {
+			parsed.FlowExecNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowMqPublishNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowMqPublishNodes[i].FlowNodeID]; ok {
+			parsed.FlowMqPublishNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowMqConsumeNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowMqConsumeNodes[i].FlowNodeID]; ok {
+			parsed.FlowMqConsumeNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowWebhookTriggerNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowWebhookTriggerNodes[i].FlowNodeID]; ok {
+			parsed.FlowWebhookTriggerNodes[i].FlowNodeID = newID
+		}
+	}
+	for i := range parsed.FlowGraphQLSubscriptionNodes {
+		if newID, ok := nodeIDMapping[parsed.FlowGraphQLSubscriptionNodes[i].FlowNodeID]; ok {
+			parsed.FlowGraphQLSubscriptionNodes[i].FlowNodeID = newID
+		}
+	}
 
 	// Remap variable references in expression fields when node names changed
 	if len(nameMapping) > 0 {
@@ -544,6 +693,69 @@ func (s *FlowServiceV2RPC) FlowNodesPaste(
 				parsed.FlowWsSendNodes[i].WsConnectionNodeName = newName
 			}
 		}
+		for i := range parsed.FlowWsExpectNodes {
+			wn := &parsed.FlowWsExpectNodes[i]
+			wn.Message = remapVarRefs(wn.Message, nameMapping)
+			wn.Match = remapVarRefs(wn.Match, nameMapping)
+			for j := range wn.Assertions {
+				wn.Assertions[j] = remapVarRefs(wn.Assertions[j], nameMapping)
+			}
+			if newName, ok := nameMapping[wn.WsConnectionNodeName]; ok {
+				wn.WsConnectionNodeName = newName
+			}
+		}
+		for i := range parsed.FlowSocketConnectionNodes {
+			parsed.FlowSocketConnectionNodes[i].Address = remapVarRefs(parsed.FlowSocketConnectionNodes[i].Address, nameMapping)
+		}
+		for i := range parsed.FlowSocketSendNodes {
+			sn := &parsed.FlowSocketSendNodes[i]
+			sn.Message = remapVarRefs(sn.Message, nameMapping)
+			if newName, ok := nameMapping[sn.SocketConnectionNodeName]; ok {
+				sn.SocketConnectionNodeName = newName
+			}
+		}
+		for i := range parsed.FlowSocketReceiveNodes {
+			rn := &parsed.FlowSocketReceiveNodes[i]
+			rn.Match = remapVarRefs(rn.Match, nameMapping)
+			for j := range rn.Assertions {
+				rn.Assertions[j] = remapVarRefs(rn.Assertions[j], nameMapping)
+			}
+			if newName, ok := nameMapping[rn.SocketConnectionNodeName]; ok {
+				rn.SocketConnectionNodeName = newName
+			}
+		}
+		for i := range parsed.FlowDbQueryNodes {
+			qn := &parsed.FlowDbQueryNodes[i]
+			for j := range qn.Params {
+				qn.Params[j] = remapVarRefs(qn.Params[j], nameMapping)
+			}
+			for j := range qn.Assertions {
+				qn.Assertions[j] = remapVarRefs(qn.Assertions[j], nameMapping)
+			}
+		}
+		for i := range parsed.FlowMqPublishNodes {
+			pn := &parsed.FlowMqPublishNodes[i]
+			pn.Topic = remapVarRefs(pn.Topic, nameMapping)
+			pn.Key = remapVarRefs(pn.Key, nameMapping)
+			pn.Message = remapVarRefs(pn.Message, nameMapping)
+		}
+		for i := range parsed.FlowMqConsumeNodes {
+			cn := &parsed.FlowMqConsumeNodes[i]
+			cn.Topic = remapVarRefs(cn.Topic, nameMapping)
+			cn.Group = remapVarRefs(cn.Group, nameMapping)
+			cn.Filter = remapVarRefs(cn.Filter, nameMapping)
+			for j := range cn.Assertions {
+				cn.Assertions[j] = remapVarRefs(cn.Assertions[j], nameMapping)
+			}
+		}
+		for i := range parsed.FlowExecNodes {
+			en := &parsed.FlowExecNodes[i]
+			en.Command = remapVarRefs(en.Command, nameMapping)
+			en.WorkDir = remapVarRefs(en.WorkDir, nameMapping)
+			for j := range en.Args {
+				en.Args[j] = remapVarRefs(en.Args[j], nameMapping)
+			}
+		}
 		for i := range parsed.WebSockets {
 			parsed.WebSockets[i].Url = remapVarRefs(parsed.WebSockets[i].Url, nameMapping)
 		}
@@ -562,6 +774,12 @@ func (s *FlowServiceV2RPC) FlowNodesPaste(
 					parsed.FlowRunSubFlowNodes[i].Inputs[j].Expression, nameMapping)
 			}
 		}
+		for i := range parsed.FlowSwitchNodes {
+			for j := range parsed.FlowSwitchNodes[i].Cases {
+				parsed.FlowSwitchNodes[i].Cases[j].Expression = remapVarRefs(
+					parsed.FlowSwitchNodes[i].Cases[j].Expression, nameMapping)
+			}
+		}
 	}
 
 	// Remap edges
@@ -723,6 +941,15 @@ func (s *FlowServiceV2RPC) FlowNodesPaste(
 		// Clear delta reference — paste always uses resolved (base) requests
 		gn.DeltaGraphQLID = nil
 	}
+	for i := range parsed.FlowGraphQLSubscriptionNodes {
+		gn := &parsed.FlowGraphQLSubscriptionNodes[i]
+		if gn.GraphQLID != nil {
+			if newID, ok := gqlIDMapping[*gn.GraphQLID]; ok {
+				gn.GraphQLID = &newID
+			}
+		}
+		gn.DeltaGraphQLID = nil
+	}
 
 	// Remap GraphQL children's GraphQLID fields and filter to only those needing creation
 	var gqlHeadersToCreate []mgraphql.GraphQLHeader
@@ -972,6 +1199,70 @@ func (s *FlowServiceV2RPC) FlowNodesPaste(
 			}
 		}
 	}
+	if s.nwes != nil {
+		for _, wen := range parsed.FlowWsExpectNodes {
+			nwesWriter := sflow.NewNodeWsExpectWriter(tx)
+			if err := nwesWriter.CreateNodeWsExpect(ctx, wen); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create ws expect node: %w", err))
+			}
+		}
+	}
+	if s.nsoc != nil {
+		for _, sn := range parsed.FlowSocketConnectionNodes {
+			nsocWriter := sflow.NewNodeSocketConnectionWriter(tx)
+			if err := nsocWriter.CreateNodeSocketConnection(ctx, sn); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create socket connection node: %w", err))
+			}
+		}
+	}
+	if s.nsos != nil {
+		for _, sn := range parsed.FlowSocketSendNodes {
+			nsosWriter := sflow.NewNodeSocketSendWriter(tx)
+			if err := nsosWriter.CreateNodeSocketSend(ctx, sn); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create socket send node: %w", err))
+			}
+		}
+	}
+	if s.nsor != nil {
+		for _, sn := range parsed.FlowSocketReceiveNodes {
+			nsorWriter := sflow.NewNodeSocketReceiveWriter(tx)
+			if err := nsorWriter.CreateNodeSocketReceive(ctx, sn); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create socket receive node: %w", err))
+			}
+		}
+	}
+	if s.ndbq != nil {
+		for _, qn := range parsed.FlowDbQueryNodes {
+			ndbqWriter := sflow.NewNodeDbQueryWriter(tx)
+			if err := ndbqWriter.CreateNodeDbQuery(ctx, qn); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create db query node: %w", err))
+			}
+		}
+	}
+	if s.nexe != nil {
+		for _, en := range parsed.FlowExecNodes {
+			nexeWriter := sflow.NewNodeExecWriter(tx)
+			if err := nexeWriter.CreateNodeExec(ctx, en); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create exec node: %w", err))
+			}
+		}
+	}
+	if s.nmqp != nil {
+		for _, qn := range parsed.FlowMqPublishNodes {
+			nmqpWriter := sflow.NewNodeMqPublishWriter(tx)
+			if err := nmqpWriter.CreateNodeMqPublish(ctx, qn); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create mq publish node: %w", err))
+			}
+		}
+	}
+	if s.nmqc != nil {
+		for _, qn := range parsed.FlowMqConsumeNodes {
+			nmqcWriter := sflow.NewNodeMqConsumeWriter(tx)
+			if err := nmqcWriter.CreateNodeMqConsume(ctx, qn); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create mq consume node: %w", err))
+			}
+		}
+	}
 	if s.nwaits != nil {
 		for _, wn := range parsed.FlowWaitNodes {
 			nwaitsWriter := sflow.NewNodeWaitWriter(tx)
@@ -1004,6 +1295,46 @@ func (s *FlowServiceV2RPC) FlowNodesPaste(
 			}
 		}
 	}
+	if s.nparallels != nil {
+		for _, n := range parsed.FlowParallelNodes {
+			w := sflow.NewNodeParallelWriter(tx)
+			if err := w.CreateNodeParallel(ctx, n); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create parallel node: %w", err))
+			}
+		}
+	}
+	if s.npolls != nil {
+		for _, n := range parsed.FlowPollNodes {
+			w := sflow.NewNodePollWriter(tx)
+			if err := w.CreateNodePoll(ctx, n); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create poll node: %w", err))
+			}
+		}
+	}
+	if s.nswitches != nil {
+		for _, n := range parsed.FlowSwitchNodes {
+			w := sflow.NewNodeSwitchWriter(tx)
+			if err := w.CreateNodeSwitch(ctx, n); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create switch node: %w", err))
+			}
+		}
+	}
+	if s.nwebhooks != nil {
+		for _, n := range parsed.FlowWebhookTriggerNodes {
+			w := sflow.NewNodeWebhookTriggerWriter(tx)
+			if err := w.CreateNodeWebhookTrigger(ctx, n); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create webhook trigger node: %w", err))
+			}
+		}
+	}
+	if s.ngqsubs != nil {
+		for _, n := range parsed.FlowGraphQLSubscriptionNodes {
+			w := sflow.NewNodeGraphQLSubscriptionWriter(tx)
+			if err := w.CreateNodeGraphQLSubscription(ctx, n); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create graphql subscription node: %w", err))
+			}
+		}
+	}
 
 	// Create edges
 	for _, e := range validEdges {
@@ -0,0 +1,191 @@
+package rflowv2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowdebug"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+// FlowDebug runs a flow in debug mode. The stream's first message starts the
+// run with its breakpoints; later ones continue, step over or abort the
+// paused flow, edit its variables or replace the breakpoints. Each pause is
+// sent with the flow's variables, each command is answered as applied or
+// rejected, and the stream ends once the run has finished. Closing the stream
+// aborts the run at its next node.
+func (s *FlowServiceV2RPC) FlowDebug(ctx context.Context, stream *connect.BidiStream[flowv1.FlowDebugRequest, flowv1.FlowDebugResponse]) error {
+	start, err := stream.Receive()
+	if err != nil {
+		return err
+	}
+	if start.GetCommand() != flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_START {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("debug stream must begin with a start command"))
+	}
+	breakpoints, err := debugBreakpoints(start.GetBreakpoints())
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	session := flowdebug.NewSession(breakpoints)
+	defer session.Abort()
+
+	done := make(chan error, 1)
+	version, err := s.startFlowRun(ctx, start.GetFlowId(), flowRunOptions{debugger: session, done: done})
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&flowv1.FlowDebugResponse{
+		Kind:          flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_APPLIED,
+		Command:       flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_START.Enum(),
+		FlowVersionId: version.ID.Bytes(),
+	}); err != nil {
+		return err
+	}
+
+	// Commands are read on their own goroutine so that every Send happens on
+	// this one.
+	requests := make(chan *flowv1.FlowDebugRequest)
+	receiveErr := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Receive()
+			if err != nil {
+				receiveErr <- err
+				return
+			}
+			select {
+			case requests <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case pause := <-session.Pauses():
+			event, err := debugPauseEvent(pause)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case msg := <-requests:
+			if err := stream.Send(applyDebugCommand(session, msg)); err != nil {
+				return err
+			}
+		case <-receiveErr:
+			// The client has stopped sending commands, so nothing could
+			// resume a pause: abort, and report the run once it has ended.
+			session.Abort()
+			receiveErr = nil
+		case runErr := <-done:
+			event := &flowv1.FlowDebugResponse{Kind: flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_FINISHED}
+			if runErr != nil {
+				msg := runErr.Error()
+				event.Error = &msg
+			}
+			return stream.Send(event)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// applyDebugCommand applies a command received while the flow runs and
+// answers it.
+func applyDebugCommand(session *flowdebug.Session, msg *flowv1.FlowDebugRequest) *flowv1.FlowDebugResponse {
+	command := msg.GetCommand()
+
+	var err error
+	switch command {
+	case flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_CONTINUE:
+		err = session.Continue()
+	case flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_STEP_OVER:
+		err = session.StepOver()
+	case flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_ABORT:
+		session.Abort()
+	case flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_SET_VARIABLE:
+		err = session.SetVariable(msg.GetPath(), msg.GetValue().AsInterface())
+	case flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_SET_BREAKPOINTS:
+		var breakpoints []flowdebug.Breakpoint
+		if breakpoints, err = debugBreakpoints(msg.GetBreakpoints()); err == nil {
+			session.SetBreakpoints(breakpoints)
+		}
+	default:
+		err = fmt.Errorf("unexpected %s command", command)
+	}
+
+	event := &flowv1.FlowDebugResponse{
+		Kind:    flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_APPLIED,
+		Command: command.Enum(),
+	}
+	if err != nil {
+		msg := err.Error()
+		event.Kind = flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_REJECTED
+		event.Error = &msg
+	}
+	return event
+}
+
+func debugBreakpoints(breakpoints []*flowv1.FlowBreakpoint) ([]flowdebug.Breakpoint, error) {
+	result := make([]flowdebug.Breakpoint, 0, len(breakpoints))
+	for _, bp := range breakpoints {
+		nodeID, err := idwrap.NewFromBytes(bp.GetNodeId())
+		if err != nil {
+			return nil, fmt.Errorf("invalid breakpoint node id: %w", err)
+		}
+		result = append(result, flowdebug.Breakpoint{NodeID: nodeID, Condition: bp.GetCondition()})
+	}
+	return result, nil
+}
+
+func debugPauseEvent(pause flowdebug.Pause) (*flowv1.FlowDebugResponse, error) {
+	event := &flowv1.FlowDebugResponse{
+		Kind:   flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_BREAKPOINT,
+		NodeId: pause.NodeID.Bytes(),
+	}
+	if pause.Reason == flowdebug.PauseReasonStep {
+		event.Kind = flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_STEP
+	}
+	if pause.IterationContext != nil {
+		for _, iteration := range pause.IterationContext.IterationPath {
+			event.IterationPath = append(event.IterationPath, int32(iteration)) //nolint:gosec // iteration counts fit in int32
+		}
+	}
+	if pause.ConditionErr != nil {
+		msg := pause.ConditionErr.Error()
+		event.Error = &msg
+	}
+
+	var err error
+	if event.Variables, err = debugValue(pause.Variables); err != nil {
+		return nil, fmt.Errorf("encode variables: %w", err)
+	}
+	if event.Changed, err = debugValue(pause.Changed); err != nil {
+		return nil, fmt.Errorf("encode changed variables: %w", err)
+	}
+	return event, nil
+}
+
+// debugValue converts variables to a protobuf value by way of JSON, since
+// they may hold types structpb does not take directly.
+func debugValue(vars map[string]any) (*structpb.Value, error) {
+	data, err := json.Marshal(vars)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(decoded)
+}
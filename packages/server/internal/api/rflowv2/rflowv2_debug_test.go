@@ -0,0 +1,58 @@
+package rflowv2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowdebug"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+func TestDebugPauseEvent(t *testing.T) {
+	nodeID := idwrap.NewNow()
+	event, err := debugPauseEvent(flowdebug.Pause{
+		NodeID:           nodeID,
+		Reason:           flowdebug.PauseReasonStep,
+		IterationContext: &runner.IterationContext{IterationPath: []int{2, 0}},
+		Variables:        map[string]any{"Loop": map[string]any{"index": int64(2)}, "ids": []string{"a"}},
+		Changed:          map[string]any{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_STEP, event.GetKind())
+	require.Equal(t, nodeID.Bytes(), event.GetNodeId())
+	require.Equal(t, []int32{2, 0}, event.GetIterationPath())
+	require.Equal(t, map[string]any{
+		"Loop": map[string]any{"index": float64(2)},
+		"ids":  []any{"a"},
+	}, event.GetVariables().AsInterface())
+}
+
+func TestApplyDebugCommand(t *testing.T) {
+	session := flowdebug.NewSession(nil)
+
+	event := applyDebugCommand(session, &flowv1.FlowDebugRequest{Command: flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_CONTINUE})
+	require.Equal(t, flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_REJECTED, event.GetKind())
+	require.Equal(t, flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_CONTINUE, event.GetCommand())
+	require.Equal(t, flowdebug.ErrNotPaused.Error(), event.GetError())
+
+	event = applyDebugCommand(session, &flowv1.FlowDebugRequest{
+		Command:     flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_SET_BREAKPOINTS,
+		Breakpoints: []*flowv1.FlowBreakpoint{{NodeId: []byte("bad")}},
+	})
+	require.Equal(t, flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_REJECTED, event.GetKind())
+
+	path := "count"
+	event = applyDebugCommand(session, &flowv1.FlowDebugRequest{
+		Command: flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_SET_VARIABLE,
+		Path:    &path,
+		Value:   structpb.NewNumberValue(1),
+	})
+	require.Equal(t, flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_REJECTED, event.GetKind(), "variables are only editable while paused")
+
+	event = applyDebugCommand(session, &flowv1.FlowDebugRequest{Command: flowv1.FlowDebugCommand_FLOW_DEBUG_COMMAND_ABORT})
+	require.Equal(t, flowv1.FlowDebugEventKind_FLOW_DEBUG_EVENT_KIND_APPLIED, event.GetKind())
+}
@@ -104,7 +104,7 @@ func (s *FlowServiceV2RPC) EdgeInsert(ctx context.Context, req *connect.Request[
 				FlowID:        flowID,
 				SourceID:      sourceID,
 				TargetID:      targetID,
-				SourceHandler: convertHandle(item.GetSourceHandle()),
+				SourceHandler: convertHandle(item.GetSourceHandle(), item.GetCaseIndex()),
 			},
 			flowID:      flowID,
 			workspaceID: flow.WorkspaceID,
@@ -204,7 +204,11 @@ func (s *FlowServiceV2RPC) EdgeUpdate(ctx context.Context, req *connect.Request[
 		}
 
 		if item.SourceHandle != nil {
-			existing.SourceHandler = convertHandle(item.GetSourceHandle())
+			existing.SourceHandler = convertHandle(item.GetSourceHandle(), item.GetCaseIndex())
+		} else if item.CaseIndex != nil {
+			if _, ok := mflow.CaseIndex(existing.SourceHandler); ok {
+				existing.SourceHandler = mflow.HandleCase(int(item.GetCaseIndex()))
+			}
 		}
 
 		validatedUpdates = append(validatedUpdates, updateData{
@@ -393,6 +397,7 @@ func edgeEventToSyncResponse(evt EdgeEvent) *flowv1.EdgeSyncResponse {
 			SourceId:     edgePB.GetSourceId(),
 			TargetId:     edgePB.GetTargetId(),
 			SourceHandle: edgePB.GetSourceHandle(),
+			CaseIndex:    edgePB.CaseIndex,
 		}
 		return &flowv1.EdgeSyncResponse{
 			Items: []*flowv1.EdgeSync{{
@@ -419,6 +424,9 @@ func edgeEventToSyncResponse(evt EdgeEvent) *flowv1.EdgeSyncResponse {
 			h := handle
 			update.SourceHandle = &h
 		}
+		if edgePB.CaseIndex != nil {
+			update.CaseIndex = edgePB.CaseIndex
+		}
 		// Always include state to support resetting to UNSPECIFIED
 		s := edgePB.GetState()
 		update.State = &s
@@ -14,6 +14,8 @@ import (
 	devtoolsdb "github.com/the-dev-tools/dev-tools/packages/db"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowexec"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowresult"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwebhook"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sflow"
@@ -21,40 +23,76 @@ import (
 )
 
 func (s *FlowServiceV2RPC) FlowRun(ctx context.Context, req *connect.Request[flowv1.FlowRunRequest]) (*connect.Response[emptypb.Empty], error) {
-	if len(req.Msg.GetFlowId()) == 0 {
-		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("flow id is required"))
+	if _, err := s.startFlowRun(ctx, req.Msg.GetFlowId(), flowRunOptions{
+		resumeVersionID: req.Msg.GetResumeVersionId(),
+		resumeNodeID:    req.Msg.GetResumeNodeId(),
+	}); err != nil {
+		return nil, err
 	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
 
-	flowID, err := idwrap.NewFromBytes(req.Msg.GetFlowId())
+// flowRunOptions tailors a run started by startFlowRun.
+type flowRunOptions struct {
+	// resumeVersionID and resumeNodeID resume a previous execution, as
+	// described on FlowRunRequest.
+	resumeVersionID []byte
+	resumeNodeID    []byte
+	// debugger, when set, runs the flow in debug mode.
+	debugger node.Debugger
+	// environmentID, when set, runs the flow with that environment instead of
+	// the workspace's active one.
+	environmentID idwrap.IDWrap
+	// webhook, when set, is the inbound request that started the run.
+	webhook *nwebhook.Exchange
+	// done, when set, receives the run's error once it has finished. It must
+	// be buffered.
+	done chan<- error
+}
+
+// startFlowRun snapshots the flow into a new version and runs it in the
+// background, returning the version.
+func (s *FlowServiceV2RPC) startFlowRun(ctx context.Context, flowIDBytes []byte, opts flowRunOptions) (mflow.Flow, error) {
+	if len(flowIDBytes) == 0 {
+		return mflow.Flow{}, connect.NewError(connect.CodeInvalidArgument, errors.New("flow id is required"))
+	}
+
+	flowID, err := idwrap.NewFromBytes(flowIDBytes)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid flow id: %w", err))
+		return mflow.Flow{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid flow id: %w", err))
 	}
 
 	if err := s.ensureFlowAccess(ctx, flowID); err != nil {
-		return nil, err
+		return mflow.Flow{}, err
 	}
 
+	return s.launchFlowRun(ctx, flowID, opts)
+}
+
+// launchFlowRun is startFlowRun once the caller's access to the flow has been
+// checked. Scheduled runs, which have no caller, start here.
+func (s *FlowServiceV2RPC) launchFlowRun(ctx context.Context, flowID idwrap.IDWrap, opts flowRunOptions) (mflow.Flow, error) {
 	flow, err := s.fs.GetFlow(ctx, flowID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("flow %s not found", flowID.String()))
+			return mflow.Flow{}, connect.NewError(connect.CodeNotFound, fmt.Errorf("flow %s not found", flowID.String()))
 		}
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return mflow.Flow{}, connect.NewError(connect.CodeInternal, err)
 	}
 
 	nodes, err := s.ns.GetNodesByFlowID(ctx, flowID)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return mflow.Flow{}, connect.NewError(connect.CodeInternal, err)
 	}
 
 	edges, err := s.es.GetEdgesByFlowID(ctx, flowID)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return mflow.Flow{}, connect.NewError(connect.CodeInternal, err)
 	}
 
 	flowVars, err := s.fvs.GetFlowVariablesByFlowID(ctx, flowID)
 	if err != nil && !errors.Is(err, sflow.ErrNoFlowVariableFound) {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return mflow.Flow{}, connect.NewError(connect.CodeInternal, err)
 	}
 
 	// Move existing parent node executions to the previous version before creating a new one
@@ -63,10 +101,20 @@ func (s *FlowServiceV2RPC) FlowRun(ctx context.Context, req *connect.Request[flo
 		// Continue anyway - not a critical failure
 	}
 
+	// Load what the execution being resumed recorded. Its node executions now
+	// sit on its version's nodes, since the move above has run.
+	var resume *flowexec.ResumeState
+	if len(opts.resumeVersionID) > 0 {
+		resume, err = s.loadResumeState(ctx, flow, opts.resumeVersionID, opts.resumeNodeID)
+		if err != nil {
+			return mflow.Flow{}, err
+		}
+	}
+
 	// Create a new flow version for this run (snapshot of the flow with all nodes, edges, etc.)
 	version, nodeIDMapping, err := s.createFlowVersionSnapshot(ctx, flow, nodes, edges, flowVars)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create flow version: %w", err))
+		return mflow.Flow{}, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create flow version: %w", err))
 	}
 
 	// Save the nodeIDMapping to the version flow for future execution moves
@@ -95,6 +143,9 @@ func (s *FlowServiceV2RPC) FlowRun(ctx context.Context, req *connect.Request[flo
 	go func() {
 		// Create a background context for execution with cancellation support
 		bgCtx, cancel := context.WithCancel(context.Background())
+		if opts.webhook != nil {
+			bgCtx = nwebhook.WithExchange(bgCtx, opts.webhook)
+		}
 
 		// Store cancel function
 		s.runningFlowsMu.Lock()
@@ -124,7 +175,7 @@ func (s *FlowServiceV2RPC) FlowRun(ctx context.Context, req *connect.Request[flo
 			cancel()
 		}()
 
-		duration, execErr := s.executeFlow(bgCtx, flow, nodes, edges, flowVars, nodeIDMapping)
+		duration, execErr := s.executeFlow(bgCtx, flow, nodes, edges, flowVars, nodeIDMapping, resume, opts.debugger, opts.environmentID)
 
 		// Copy final node/edge states from parent to version (best-effort).
 		// Use Background() because bgCtx may be cancelled on FlowStop.
@@ -150,9 +201,13 @@ func (s *FlowServiceV2RPC) FlowRun(ctx context.Context, req *connect.Request[flo
 			s.logger.Error("failed to update version with results", "version_id", version.ID.String(), "error", err)
 		}
 		s.publishFlowEvent(flowEventUpdate, version)
+
+		if opts.done != nil {
+			opts.done <- execErr
+		}
 	}()
 
-	return connect.NewResponse(&emptypb.Empty{}), nil
+	return version, nil
 }
 
 func (s *FlowServiceV2RPC) executeFlow(
@@ -162,6 +217,9 @@ func (s *FlowServiceV2RPC) executeFlow(
 	edges []mflow.Edge,
 	flowVars []mflow.FlowVariable,
 	nodeIDMapping map[string]idwrap.IDWrap,
+	resume *flowexec.ResumeState,
+	debugger node.Debugger,
+	environmentID idwrap.IDWrap,
 ) (int32, error) {
 	// Filter orphaned edges (source or target node missing)
 	validEdges := filterValidEdges(nodes, edges)
@@ -186,10 +244,13 @@ func (s *FlowServiceV2RPC) executeFlow(
 	session := s.sessionFactory.Create(proc)
 
 	if err := session.Prepare(ctx, flowexec.ExecutionParams{
-		Flow:     flow,
-		Nodes:    nodes,
-		Edges:    validEdges,
-		FlowVars: flowVars,
+		Flow:          flow,
+		Nodes:         nodes,
+		Edges:         validEdges,
+		FlowVars:      flowVars,
+		Resume:        resume,
+		Debugger:      debugger,
+		EnvironmentID: environmentID,
 	}); err != nil {
 		return 0, err
 	}
@@ -197,12 +258,104 @@ func (s *FlowServiceV2RPC) executeFlow(
 	// Reset node/edge states before execution (batch-publishes UI events)
 	s.resetNodeStates(ctx, flow.ID, nodes)
 	s.resetEdgeStates(ctx, flow.ID, edges)
+	if resume != nil {
+		s.restoreResumedNodeStates(ctx, flow.ID, nodes, resume)
+	}
 
 	// Execute flow and wait for result processing
 	result, err := session.Run(ctx)
 	return result.Duration, err
 }
 
+// loadResumeState reads the node executions recorded by a previous run of
+// flow, identified by its version, and keys them by the flow's own node IDs.
+// Nodes added since that run have no record and run as if new.
+func (s *FlowServiceV2RPC) loadResumeState(ctx context.Context, flow mflow.Flow, versionIDBytes, nodeIDBytes []byte) (*flowexec.ResumeState, error) {
+	versionID, err := idwrap.NewFromBytes(versionIDBytes)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid resume version id: %w", err))
+	}
+
+	version, err := s.fs.GetFlow(ctx, versionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("execution %s not found", versionID.String()))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if version.VersionParentID == nil || version.VersionParentID.Compare(flow.ID) != 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("execution %s is not a run of flow %s", versionID.String(), flow.ID.String()))
+	}
+	if len(version.NodeIDMapping) == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("execution %s recorded no nodes to resume from", versionID.String()))
+	}
+
+	var nodeIDMapping map[string]string
+	if err := json.Unmarshal(version.NodeIDMapping, &nodeIDMapping); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("unmarshal nodeIDMapping: %w", err))
+	}
+
+	executions := make(map[idwrap.IDWrap]mflow.NodeExecution, len(nodeIDMapping))
+	for parentNodeIDStr, versionNodeIDStr := range nodeIDMapping {
+		parentNodeID, err := idwrap.NewText(parentNodeIDStr)
+		if err != nil {
+			continue
+		}
+		versionNodeID, err := idwrap.NewText(versionNodeIDStr)
+		if err != nil {
+			continue
+		}
+		execution, err := s.nes.GetLatestNodeExecutionByNodeID(ctx, versionNodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get node execution: %w", err))
+		}
+		if execution != nil {
+			executions[parentNodeID] = *execution
+		}
+	}
+
+	var from []idwrap.IDWrap
+	if len(nodeIDBytes) > 0 {
+		nodeID, err := idwrap.NewFromBytes(nodeIDBytes)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid resume node id: %w", err))
+		}
+		from = []idwrap.IDWrap{nodeID}
+	}
+
+	resume, err := flowexec.NewResumeState(executions, from)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return resume, nil
+}
+
+// restoreResumedNodeStates shows the nodes that completed in the execution
+// being resumed as succeeded again, since the resumed run does not rerun them.
+// Nodes downstream of the resume point are left reset until they run.
+func (s *FlowServiceV2RPC) restoreResumedNodeStates(ctx context.Context, flowID idwrap.IDWrap, nodes []mflow.Node, resume *flowexec.ResumeState) {
+	nodeEvents := make([]NodeEvent, 0, len(nodes))
+	for _, node := range nodes {
+		if !resume.Completed(node.ID) {
+			continue
+		}
+		if err := s.ns.UpdateNodeState(ctx, node.ID, mflow.NODE_STATE_SUCCESS); err != nil {
+			s.logger.Error("failed to restore node state", "node_id", node.ID.String(), "error", err)
+			continue
+		}
+		restored := node
+		restored.State = mflow.NODE_STATE_SUCCESS
+		nodeEvents = append(nodeEvents, NodeEvent{
+			Type:   nodeEventUpdate,
+			FlowID: flowID,
+			Node:   serializeNode(restored),
+		})
+	}
+	if len(nodeEvents) > 0 && s.nodeStream != nil {
+		s.nodeStream.Publish(NodeTopic{FlowID: flowID}, nodeEvents...)
+	}
+}
+
 // filterValidEdges removes edges whose source or target node is missing.
 func filterValidEdges(nodes []mflow.Node, edges []mflow.Edge) []mflow.Edge {
 	nodeIDSet := make(map[idwrap.IDWrap]struct{}, len(nodes))
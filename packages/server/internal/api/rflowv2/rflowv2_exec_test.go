@@ -1399,3 +1399,60 @@ func TestCopyStatesToVersion_EdgeMatching(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadResumeState(t *testing.T) {
+	svc, _, ctx, _, workspaceID := setupTestService(t)
+
+	flowID := idwrap.NewNow()
+	flow := mflow.Flow{ID: flowID, WorkspaceID: workspaceID, Name: "Resumable"}
+	require.NoError(t, svc.fs.CreateFlow(ctx, flow))
+
+	createID, getID := idwrap.NewNow(), idwrap.NewNow()
+	versionCreateID, versionGetID := idwrap.NewNow(), idwrap.NewNow()
+	mappingJSON, err := json.Marshal(map[string]string{
+		createID.String(): versionCreateID.String(),
+		getID.String():    versionGetID.String(),
+	})
+	require.NoError(t, err)
+
+	versionID := idwrap.NewNow()
+	require.NoError(t, svc.fs.CreateFlow(ctx, mflow.Flow{
+		ID:              versionID,
+		WorkspaceID:     workspaceID,
+		Name:            "Resumable",
+		VersionParentID: &flowID,
+		NodeIDMapping:   mappingJSON,
+	}))
+
+	completedAt := time.Now().UnixMilli()
+	created := mflow.NodeExecution{
+		ID:          idwrap.NewNow(),
+		NodeID:      versionCreateID,
+		Name:        "CreateUser",
+		State:       mflow.NODE_STATE_SUCCESS,
+		CompletedAt: &completedAt,
+	}
+	require.NoError(t, created.SetOutputJSON(json.RawMessage(`{"response":{"body":{"id":"user-1"}}}`)))
+	require.NoError(t, svc.nes.CreateNodeExecution(ctx, created))
+	errMsg := "assertion failed"
+	require.NoError(t, svc.nes.CreateNodeExecution(ctx, mflow.NodeExecution{
+		ID:          idwrap.NewNow(),
+		NodeID:      versionGetID,
+		Name:        "GetUser",
+		State:       mflow.NODE_STATE_FAILURE,
+		Error:       &errMsg,
+		CompletedAt: &completedAt,
+	}))
+
+	resume, err := svc.loadResumeState(ctx, flow, versionID.Bytes(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, mflow.NODE_STATE_SUCCESS, resume.States[createID], "states are keyed by the flow's own node IDs")
+	assert.Equal(t, mflow.NODE_STATE_FAILURE, resume.States[getID])
+	assert.Equal(t, map[string]any{"response": map[string]any{"body": map[string]any{"id": "user-1"}}}, resume.Outputs[createID])
+
+	otherFlow := mflow.Flow{ID: idwrap.NewNow(), WorkspaceID: workspaceID, Name: "Other"}
+	require.NoError(t, svc.fs.CreateFlow(ctx, otherFlow))
+	_, err = svc.loadResumeState(ctx, otherFlow, versionID.Bytes(), nil)
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "a version of another flow cannot be resumed")
+}
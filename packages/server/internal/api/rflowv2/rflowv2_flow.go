@@ -559,6 +559,19 @@ func (s *FlowServiceV2RPC) FlowDuplicate(ctx context.Context, req *connect.Reque
 		subFlowTriggerNode   *mflow.NodeSubFlowTrigger
 		subFlowReturnNode    *mflow.NodeSubFlowReturn
 		runSubFlowNode       *mflow.NodeRunSubFlow
+		parallelNode         *mflow.NodeParallel
+		pollNode             *mflow.NodePoll
+		switchNode           *mflow.NodeSwitch
+		webhookNode          *mflow.NodeWebhookTrigger
+		graphqlSubscription  *mflow.NodeGraphQLSubscription
+		wsExpectNode         *mflow.NodeWsExpect
+		socketConnectionNode *mflow.NodeSocketConnection
+		socketSendNode       *mflow.NodeSocketSend
+		socketReceiveNode    *mflow.NodeSocketReceive
+		dbQueryNode          *mflow.NodeDbQuery
+		execNode          *mflow.NodeExec
+		mqPublishNode          *mflow.NodeMqPublish
+		mqConsumeNode          *mflow.NodeMqConsume
 	}
 	details := make([]nodeDetail, 0, len(sourceNodes))
 	for _, n := range sourceNodes {
@@ -627,6 +640,54 @@ func (s *FlowServiceV2RPC) FlowDuplicate(ctx context.Context, req *connect.Reque
 					detail.wsSendNode = d
 				}
 			}
+		case mflow.NODE_KIND_WS_EXPECT:
+			if s.nwes != nil {
+				if d, err := s.nwes.GetNodeWsExpect(ctx, n.ID); err == nil && d != nil {
+					detail.wsExpectNode = d
+				}
+			}
+		case mflow.NODE_KIND_SOCKET_CONNECTION:
+			if s.nsoc != nil {
+				if d, err := s.nsoc.GetNodeSocketConnection(ctx, n.ID); err == nil && d != nil {
+					detail.socketConnectionNode = d
+				}
+			}
+		case mflow.NODE_KIND_SOCKET_SEND:
+			if s.nsos != nil {
+				if d, err := s.nsos.GetNodeSocketSend(ctx, n.ID); err == nil && d != nil {
+					detail.socketSendNode = d
+				}
+			}
+		case mflow.NODE_KIND_SOCKET_RECEIVE:
+			if s.nsor != nil {
+				if d, err := s.nsor.GetNodeSocketReceive(ctx, n.ID); err == nil && d != nil {
+					detail.socketReceiveNode = d
+				}
+			}
+		case mflow.NODE_KIND_DB_QUERY:
+			if s.ndbq != nil {
+				if d, err := s.ndbq.GetNodeDbQuery(ctx, n.ID); err == nil && d != nil {
+					detail.dbQueryNode = d
+				}
+			}
+		case mflow.NODE_KIND_EXEC:
+			if s.nexe != nil {
+				if d, err := s.nexe.GetNodeExec(ctx, n.ID); err == nil && d != nil {
+					detail.execNode = d
+				}
+			}
+		case mflow.NODE_KIND_MQ_PUBLISH:
+			if s.nmqp != nil {
+				if d, err := s.nmqp.GetNodeMqPublish(ctx, n.ID); err == nil && d != nil {
+					detail.mqPublishNode = d
+				}
+			}
+		case mflow.NODE_KIND_MQ_CONSUME:
+			if s.nmqc != nil {
+				if d, err := s.nmqc.GetNodeMqConsume(ctx, n.ID); err == nil && d != nil {
+					detail.mqConsumeNode = d
+				}
+			}
 		case mflow.NODE_KIND_WAIT:
 			if s.nwaits != nil {
 				if d, err := s.nwaits.GetNodeWait(ctx, n.ID); err == nil && d != nil {
@@ -651,8 +712,36 @@ func (s *FlowServiceV2RPC) FlowDuplicate(ctx context.Context, req *connect.Reque
 					detail.runSubFlowNode = d
 				}
 			}
+		case mflow.NODE_KIND_PARALLEL:
+			if s.nparallels != nil {
+				if d, err := s.nparallels.GetNodeParallel(ctx, n.ID); err == nil && d != nil {
+					detail.parallelNode = d
+				}
+			}
+		case mflow.NODE_KIND_POLL:
+			if s.npolls != nil {
+				if d, err := s.npolls.GetNodePoll(ctx, n.ID); err == nil && d != nil {
+					detail.pollNode = d
+				}
+			}
+		case mflow.NODE_KIND_SWITCH:
+			if s.nswitches != nil {
+				if d, err := s.nswitches.GetNodeSwitch(ctx, n.ID); err == nil && d != nil {
+					detail.switchNode = d
+				}
+			}
 		case mflow.NODE_KIND_WEBHOOK_TRIGGER:
-			// Not yet implemented
+			if s.nwebhooks != nil {
+				if d, err := s.nwebhooks.GetNodeWebhookTrigger(ctx, n.ID); err == nil && d != nil {
+					detail.webhookNode = d
+				}
+			}
+		case mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION:
+			if s.ngqsubs != nil {
+				if d, err := s.ngqsubs.GetNodeGraphQLSubscription(ctx, n.ID); err == nil && d != nil {
+					detail.graphqlSubscription = d
+				}
+			}
 		}
 		details = append(details, detail)
 	}
@@ -809,6 +898,70 @@ func (s *FlowServiceV2RPC) FlowDuplicate(ctx context.Context, req *connect.Reque
 				return nil, connect.NewError(connect.CodeInternal, err)
 			}
 		}
+		if d.wsExpectNode != nil && s.nwes != nil {
+			node := *d.wsExpectNode
+			node.FlowNodeID = newNodeID
+			writer := s.nwes.TX(tx)
+			if err := writer.CreateNodeWsExpect(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.socketConnectionNode != nil && s.nsoc != nil {
+			node := *d.socketConnectionNode
+			node.FlowNodeID = newNodeID
+			writer := s.nsoc.TX(tx)
+			if err := writer.CreateNodeSocketConnection(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.socketSendNode != nil && s.nsos != nil {
+			node := *d.socketSendNode
+			node.FlowNodeID = newNodeID
+			writer := s.nsos.TX(tx)
+			if err := writer.CreateNodeSocketSend(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.socketReceiveNode != nil && s.nsor != nil {
+			node := *d.socketReceiveNode
+			node.FlowNodeID = newNodeID
+			writer := s.nsor.TX(tx)
+			if err := writer.CreateNodeSocketReceive(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.dbQueryNode != nil && s.ndbq != nil {
+			node := *d.dbQueryNode
+			node.FlowNodeID = newNodeID
+			writer := s.ndbq.TX(tx)
+			if err := writer.CreateNodeDbQuery(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.execNode != nil && s.nexe != nil {
+			node := *d.execNode
+			node.FlowNodeID = newNodeID
+			writer := s.nexe.TX(tx)
+			if err := writer.CreateNodeExec(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.mqPublishNode != nil && s.nmqp != nil {
+			node := *d.mqPublishNode
+			node.FlowNodeID = newNodeID
+			writer := s.nmqp.TX(tx)
+			if err := writer.CreateNodeMqPublish(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.mqConsumeNode != nil && s.nmqc != nil {
+			node := *d.mqConsumeNode
+			node.FlowNodeID = newNodeID
+			writer := s.nmqc.TX(tx)
+			if err := writer.CreateNodeMqConsume(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
 		if d.waitNode != nil && s.nwaits != nil {
 			node := *d.waitNode
 			node.FlowNodeID = newNodeID
@@ -841,6 +994,46 @@ func (s *FlowServiceV2RPC) FlowDuplicate(ctx context.Context, req *connect.Reque
 				return nil, connect.NewError(connect.CodeInternal, err)
 			}
 		}
+		if d.parallelNode != nil && s.nparallels != nil {
+			node := *d.parallelNode
+			node.FlowNodeID = newNodeID
+			writer := s.nparallels.TX(tx)
+			if err := writer.CreateNodeParallel(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.pollNode != nil && s.npolls != nil {
+			node := *d.pollNode
+			node.FlowNodeID = newNodeID
+			writer := s.npolls.TX(tx)
+			if err := writer.CreateNodePoll(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.switchNode != nil && s.nswitches != nil {
+			node := *d.switchNode
+			node.FlowNodeID = newNodeID
+			writer := s.nswitches.TX(tx)
+			if err := writer.CreateNodeSwitch(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.webhookNode != nil && s.nwebhooks != nil {
+			node := *d.webhookNode
+			node.FlowNodeID = newNodeID
+			writer := s.nwebhooks.TX(tx)
+			if err := writer.CreateNodeWebhookTrigger(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+		if d.graphqlSubscription != nil && s.ngqsubs != nil {
+			node := *d.graphqlSubscription
+			node.FlowNodeID = newNodeID
+			writer := s.ngqsubs.TX(tx)
+			if err := writer.CreateNodeGraphQLSubscription(ctx, node); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
 	}
 
 	// Track created edges for event publishing
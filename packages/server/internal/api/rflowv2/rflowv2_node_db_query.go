@@ -0,0 +1,492 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeDbQueryWithFlow struct {
+	nodeDbQuery mflow.NodeDbQuery
+	flowID      idwrap.IDWrap
+	baseNode    *mflow.Node
+}
+
+// NodeDbQueryTopic identifies the flow whose DB Query nodes are being published.
+type NodeDbQueryTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// NodeDbQueryEvent describes a DB Query node change for sync streaming.
+type NodeDbQueryEvent struct {
+	Type   string
+	FlowID idwrap.IDWrap
+	Node   *flowv1.NodeDbQuery
+}
+
+func (s *FlowServiceV2RPC) NodeDbQueryCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeDbQueryCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeDbQuery
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_DB_QUERY {
+				continue
+			}
+			nodeDbQuery, err := s.ndbq.GetNodeDbQuery(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeDbQuery == nil {
+				continue
+			}
+			items = append(items, serializeNodeDbQuery(*nodeDbQuery))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeDbQueryCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeDbQueryInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeDbQueryInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeID      idwrap.IDWrap
+		nodeDbQuery mflow.NodeDbQuery
+		baseNode    *mflow.Node
+		flowID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		// CredentialID is optional - can be set later via update
+		var credID *idwrap.IDWrap
+		if len(item.GetCredentialId()) > 0 {
+			id, err := idwrap.NewFromBytes(item.GetCredentialId())
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid credential id: %w", err))
+			}
+			credID = &id
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeID: nodeID,
+			nodeDbQuery: mflow.NodeDbQuery{
+				FlowNodeID:   nodeID,
+				CredentialID: credID,
+				Query:        item.GetQuery(),
+				Params:       item.GetParams(),
+				TimeoutMs:    item.GetTimeoutMs(),
+				Assertions:   item.GetAssertions(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	ndbqWriter := s.ndbq.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeDbQuery := data.nodeDbQuery
+
+		if err := ndbqWriter.CreateNodeDbQuery(ctx, nodeDbQuery); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeDbQuery,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeDbQueryWithFlow{
+					nodeDbQuery: nodeDbQuery,
+					flowID:      data.flowID,
+					baseNode:    data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeDbQueryUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeDbQueryUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID      idwrap.IDWrap
+		nodeDbQuery mflow.NodeDbQuery
+		baseNode    *mflow.Node
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		// Get existing values to merge partial updates
+		existing, err := s.ndbq.GetNodeDbQuery(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			existing = &mflow.NodeDbQuery{FlowNodeID: nodeID}
+		}
+		if item.CredentialId != nil {
+			if len(item.CredentialId) > 0 {
+				credID, err := idwrap.NewFromBytes(item.CredentialId)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid credential id: %w", err))
+				}
+				existing.CredentialID = &credID
+			} else {
+				// Empty bytes means unset the credential
+				existing.CredentialID = nil
+			}
+		}
+		if item.Query != nil {
+			existing.Query = *item.Query
+		}
+		if item.Params != nil {
+			existing.Params = item.Params
+		}
+		if item.TimeoutMs != nil {
+			existing.TimeoutMs = *item.TimeoutMs
+		}
+		if item.Assertions != nil {
+			existing.Assertions = item.Assertions
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:      nodeID,
+			nodeDbQuery: *existing,
+			baseNode:    nodeModel,
+			workspaceID: flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	ndbqWriter := s.ndbq.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeDbQuery := data.nodeDbQuery
+
+		if err := ndbqWriter.UpdateNodeDbQuery(ctx, nodeDbQuery); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeDbQuery,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeDbQueryWithFlow{
+				nodeDbQuery: nodeDbQuery,
+				flowID:      data.baseNode.FlowID,
+				baseNode:    data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeDbQueryDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeDbQueryDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeDbQuery,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeDbQuery(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeDbQuerySync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeDbQuerySyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeDbQuerySync(ctx, func(resp *flowv1.NodeDbQuerySyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeDbQuerySync(
+	ctx context.Context,
+	send func(*flowv1.NodeDbQuerySyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeDbQueryEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert db query node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeDbQueryEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeDbQuerySyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_DB_QUERY {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeDbQuery, err := s.ndbq.GetNodeDbQuery(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeDbQuerySync
+	switch evt.Type {
+	case nodeEventInsert:
+		insert := &flowv1.NodeDbQuerySyncInsert{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeDbQuery != nil {
+			insert.CredentialId = dbQueryCredentialID(*nodeDbQuery)
+			insert.Query = nodeDbQuery.Query
+			insert.Params = nodeDbQuery.Params
+			insert.TimeoutMs = nodeDbQuery.TimeoutMs
+			insert.Assertions = nodeDbQuery.Assertions
+		}
+		syncEvent = &flowv1.NodeDbQuerySync{
+			Value: &flowv1.NodeDbQuerySync_ValueUnion{
+				Kind:   flowv1.NodeDbQuerySync_ValueUnion_KIND_INSERT,
+				Insert: insert,
+			},
+		}
+	case nodeEventUpdate:
+		update := &flowv1.NodeDbQuerySyncUpdate{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeDbQuery != nil {
+			update.CredentialId = dbQueryCredentialID(*nodeDbQuery)
+			update.Query = &nodeDbQuery.Query
+			update.Params = nodeDbQuery.Params
+			update.TimeoutMs = &nodeDbQuery.TimeoutMs
+			update.Assertions = nodeDbQuery.Assertions
+		}
+		syncEvent = &flowv1.NodeDbQuerySync{
+			Value: &flowv1.NodeDbQuerySync_ValueUnion{
+				Kind:   flowv1.NodeDbQuerySync_ValueUnion_KIND_UPDATE,
+				Update: update,
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeDbQuerySync{
+			Value: &flowv1.NodeDbQuerySync_ValueUnion{
+				Kind: flowv1.NodeDbQuerySync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeDbQuerySyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeDbQuerySyncResponse{
+		Items: []*flowv1.NodeDbQuerySync{syncEvent},
+	}, nil
+}
+
+func serializeNodeDbQuery(n mflow.NodeDbQuery) *flowv1.NodeDbQuery {
+	return &flowv1.NodeDbQuery{
+		NodeId:       n.FlowNodeID.Bytes(),
+		CredentialId: dbQueryCredentialID(n),
+		Query:        n.Query,
+		Params:       n.Params,
+		TimeoutMs:    n.TimeoutMs,
+		Assertions:   n.Assertions,
+	}
+}
+
+func dbQueryCredentialID(n mflow.NodeDbQuery) []byte {
+	if n.CredentialID == nil {
+		return nil
+	}
+	return n.CredentialID.Bytes()
+}
@@ -0,0 +1,466 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeExecWithFlow struct {
+	nodeExec mflow.NodeExec
+	flowID   idwrap.IDWrap
+	baseNode *mflow.Node
+}
+
+// NodeExecTopic identifies the flow whose Exec nodes are being published.
+type NodeExecTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// NodeExecEvent describes a Exec node change for sync streaming.
+type NodeExecEvent struct {
+	Type   string
+	FlowID idwrap.IDWrap
+	Node   *flowv1.NodeExec
+}
+
+func (s *FlowServiceV2RPC) NodeExecCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeExecCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeExec
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_EXEC {
+				continue
+			}
+			nodeExec, err := s.nexe.GetNodeExec(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeExec == nil {
+				continue
+			}
+			items = append(items, serializeNodeExec(*nodeExec))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeExecCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeExecInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeExecInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeID      idwrap.IDWrap
+		nodeExec    mflow.NodeExec
+		baseNode    *mflow.Node
+		flowID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeID: nodeID,
+			nodeExec: mflow.NodeExec{
+				FlowNodeID: nodeID,
+				Command:    item.GetCommand(),
+				Args:       item.GetArgs(),
+				WorkDir:    item.GetWorkDir(),
+				TimeoutMs:  item.GetTimeoutMs(),
+				ParseJSON:  item.GetParseJson(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nexeWriter := s.nexe.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeExec := data.nodeExec
+
+		if err := nexeWriter.CreateNodeExec(ctx, nodeExec); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeExec,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeExecWithFlow{
+					nodeExec: nodeExec,
+					flowID:   data.flowID,
+					baseNode: data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeExecUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeExecUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID      idwrap.IDWrap
+		nodeExec    mflow.NodeExec
+		baseNode    *mflow.Node
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		// Get existing values to merge partial updates
+		existing, err := s.nexe.GetNodeExec(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			existing = &mflow.NodeExec{FlowNodeID: nodeID}
+		}
+		if item.Command != nil {
+			existing.Command = *item.Command
+		}
+		if item.Args != nil {
+			existing.Args = item.Args
+		}
+		if item.WorkDir != nil {
+			existing.WorkDir = *item.WorkDir
+		}
+		if item.TimeoutMs != nil {
+			existing.TimeoutMs = *item.TimeoutMs
+		}
+		if item.ParseJson != nil {
+			existing.ParseJSON = *item.ParseJson
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:      nodeID,
+			nodeExec:    *existing,
+			baseNode:    nodeModel,
+			workspaceID: flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nexeWriter := s.nexe.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeExec := data.nodeExec
+
+		if err := nexeWriter.UpdateNodeExec(ctx, nodeExec); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeExec,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeExecWithFlow{
+				nodeExec: nodeExec,
+				flowID:   data.baseNode.FlowID,
+				baseNode: data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeExecDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeExecDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeExec,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeExec(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeExecSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeExecSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeExecSync(ctx, func(resp *flowv1.NodeExecSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeExecSync(
+	ctx context.Context,
+	send func(*flowv1.NodeExecSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeExecEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert exec node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeExecEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeExecSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_EXEC {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeExec, err := s.nexe.GetNodeExec(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeExecSync
+	switch evt.Type {
+	case nodeEventInsert:
+		insert := &flowv1.NodeExecSyncInsert{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeExec != nil {
+			insert.Command = nodeExec.Command
+			insert.Args = nodeExec.Args
+			insert.WorkDir = nodeExec.WorkDir
+			insert.TimeoutMs = nodeExec.TimeoutMs
+			insert.ParseJson = nodeExec.ParseJSON
+		}
+		syncEvent = &flowv1.NodeExecSync{
+			Value: &flowv1.NodeExecSync_ValueUnion{
+				Kind:   flowv1.NodeExecSync_ValueUnion_KIND_INSERT,
+				Insert: insert,
+			},
+		}
+	case nodeEventUpdate:
+		update := &flowv1.NodeExecSyncUpdate{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeExec != nil {
+			update.Command = &nodeExec.Command
+			update.Args = nodeExec.Args
+			update.WorkDir = &nodeExec.WorkDir
+			update.TimeoutMs = &nodeExec.TimeoutMs
+			update.ParseJson = &nodeExec.ParseJSON
+		}
+		syncEvent = &flowv1.NodeExecSync{
+			Value: &flowv1.NodeExecSync_ValueUnion{
+				Kind:   flowv1.NodeExecSync_ValueUnion_KIND_UPDATE,
+				Update: update,
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeExecSync{
+			Value: &flowv1.NodeExecSync_ValueUnion{
+				Kind: flowv1.NodeExecSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeExecSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeExecSyncResponse{
+		Items: []*flowv1.NodeExecSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeExec(n mflow.NodeExec) *flowv1.NodeExec {
+	return &flowv1.NodeExec{
+		NodeId:    n.FlowNodeID.Bytes(),
+		Command:   n.Command,
+		Args:      n.Args,
+		WorkDir:   n.WorkDir,
+		TimeoutMs: n.TimeoutMs,
+		ParseJson: n.ParseJSON,
+	}
+}
@@ -0,0 +1,81 @@
+package rflowv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sflow"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+func TestNodeExec_CollectionAndSync(t *testing.T) {
+	svc, ctx, _, workspaceID, _ := setupTestServiceWithStreams(t)
+	execService := sflow.NewNodeExecService(gen.New(svc.DB))
+	svc.nexe = &execService
+
+	flowID := idwrap.NewNow()
+	err := svc.fs.CreateFlow(ctx, mflow.Flow{ID: flowID, WorkspaceID: workspaceID, Name: "Test Flow"})
+	require.NoError(t, err)
+
+	nodeID := idwrap.NewNow()
+	err = svc.ns.CreateNode(ctx, mflow.Node{ID: nodeID, FlowID: flowID, Name: "Token", NodeKind: mflow.NODE_KIND_EXEC})
+	require.NoError(t, err)
+
+	syncCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	syncItems := make(chan *flowv1.NodeExecSync, 10)
+	go func() {
+		_ = svc.streamNodeExecSync(syncCtx, func(resp *flowv1.NodeExecSyncResponse) error {
+			for _, item := range resp.Items {
+				syncItems <- item
+			}
+			return nil
+		})
+	}()
+	// Give the stream time to subscribe before the insert is published.
+	time.Sleep(50 * time.Millisecond)
+
+	_, err = svc.NodeExecInsert(ctx, connect.NewRequest(&flowv1.NodeExecInsertRequest{
+		Items: []*flowv1.NodeExecInsert{{
+			NodeId:    nodeID.Bytes(),
+			Command:   "./bin/sign-token",
+			Args:      []string{"--subject", "{{ user }}"},
+			WorkDir:   "/tmp",
+			TimeoutMs: 5000,
+			ParseJson: true,
+		}},
+	}))
+	require.NoError(t, err)
+
+	collResp, err := svc.NodeExecCollection(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.Len(t, collResp.Msg.Items, 1)
+	item := collResp.Msg.Items[0]
+	assert.Equal(t, nodeID.Bytes(), item.NodeId)
+	assert.Equal(t, "./bin/sign-token", item.Command)
+	assert.Equal(t, []string{"--subject", "{{ user }}"}, item.Args)
+	assert.Equal(t, "/tmp", item.WorkDir)
+	assert.Equal(t, int64(5000), item.TimeoutMs)
+	assert.True(t, item.ParseJson)
+
+	select {
+	case syncItem := <-syncItems:
+		insert := syncItem.GetValue().GetInsert()
+		require.NotNil(t, insert)
+		assert.Equal(t, nodeID.Bytes(), insert.NodeId)
+		assert.Equal(t, "./bin/sign-token", insert.Command)
+		assert.Equal(t, []string{"--subject", "{{ user }}"}, insert.Args)
+		assert.True(t, insert.ParseJson)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for exec node sync insert")
+	}
+}
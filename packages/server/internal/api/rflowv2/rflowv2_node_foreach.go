@@ -76,6 +76,7 @@ func (s *FlowServiceV2RPC) NodeForEachInsert(ctx context.Context, req *connect.R
 			IterExpression: item.GetPath(),
 			Condition:      buildCondition(item.GetCondition()),
 			ErrorHandling:  mflow.ErrorHandling(item.GetErrorHandling()), // nolint:gosec // G115
+			Concurrency:    item.GetConcurrency(),
 		}
 
 		// CRITICAL FIX: Get base node BEFORE transaction to avoid SQLite deadlock
@@ -189,6 +190,9 @@ func (s *FlowServiceV2RPC) NodeForEachUpdate(ctx context.Context, req *connect.R
 		if item.ErrorHandling != nil {
 			existing.ErrorHandling = mflow.ErrorHandling(item.GetErrorHandling()) // nolint:gosec // G115
 		}
+		if item.Concurrency != nil {
+			existing.Concurrency = item.GetConcurrency()
+		}
 
 		validatedItems = append(validatedItems, updateData{
 			nodeID:      nodeID,
@@ -395,6 +399,7 @@ func (s *FlowServiceV2RPC) forEachEventToSyncResponse(
 					Path:          nodeForEach.IterExpression,
 					Condition:     nodeForEach.Condition.Comparisons.Expression,
 					ErrorHandling: converter.ToAPIErrorHandling(nodeForEach.ErrorHandling),
+					Concurrency:   nodeForEach.Concurrency,
 				},
 			},
 		}
@@ -413,6 +418,9 @@ func (s *FlowServiceV2RPC) forEachEventToSyncResponse(
 			if errorHandling := converter.ToAPIErrorHandling(nodeForEach.ErrorHandling); errorHandling != flowv1.ErrorHandling_ERROR_HANDLING_UNSPECIFIED {
 				update.ErrorHandling = &errorHandling
 			}
+			if concurrency := nodeForEach.Concurrency; concurrency != 0 {
+				update.Concurrency = &concurrency
+			}
 		}
 		syncEvent = &flowv1.NodeForEachSync{
 			Value: &flowv1.NodeForEachSync_ValueUnion{
@@ -0,0 +1,477 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeGraphQLSubscriptionWithFlow struct {
+	nodeGraphQLSubscription mflow.NodeGraphQLSubscription
+	flowID                  idwrap.IDWrap
+	baseNode                *mflow.Node
+}
+
+func (s *FlowServiceV2RPC) NodeGraphQLSubscriptionCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeGraphQLSubscriptionCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeGraphQLSubscription
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION {
+				continue
+			}
+			nodeGraphQLSubscription, err := s.ngqsubs.GetNodeGraphQLSubscription(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeGraphQLSubscription == nil {
+				continue
+			}
+			items = append(items, serializeNodeGraphQLSubscription(*nodeGraphQLSubscription))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeGraphQLSubscriptionCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeGraphQLSubscriptionInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeGraphQLSubscriptionInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeGraphQLSubscription mflow.NodeGraphQLSubscription
+		baseNode                *mflow.Node
+		flowID                  idwrap.IDWrap
+		workspaceID             idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		graphqlID, err := parseOptionalGraphQLID(item.GetGraphqlId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeGraphQLSubscription: mflow.NodeGraphQLSubscription{
+				FlowNodeID:        nodeID,
+				GraphQLID:         graphqlID,
+				ConnectionPayload: item.GetConnectionPayload(),
+				MaxEvents:         item.GetMaxEvents(),
+				TimeoutMs:         item.GetTimeoutMs(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	ngqsubsWriter := s.ngqsubs.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeGraphQLSubscription := data.nodeGraphQLSubscription
+
+		if err := ngqsubsWriter.CreateNodeGraphQLSubscription(ctx, nodeGraphQLSubscription); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeGraphQLSubscription,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeGraphQLSubscription.FlowNodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeGraphQLSubscriptionWithFlow{
+					nodeGraphQLSubscription: nodeGraphQLSubscription,
+					flowID:                  data.flowID,
+					baseNode:                data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeGraphQLSubscriptionUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeGraphQLSubscriptionUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID      idwrap.IDWrap
+		updated     mflow.NodeGraphQLSubscription
+		baseNode    *mflow.Node
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		existing, err := s.ngqsubs.GetNodeGraphQLSubscription(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("node %s does not have GRAPHQL_SUBSCRIPTION config", nodeID.String()))
+		}
+
+		if len(item.GetGraphqlId()) > 0 {
+			graphqlID, err := parseOptionalGraphQLID(item.GetGraphqlId())
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			existing.GraphQLID = graphqlID
+		}
+		if item.ConnectionPayload != nil {
+			existing.ConnectionPayload = item.GetConnectionPayload()
+		}
+		if item.MaxEvents != nil {
+			existing.MaxEvents = item.GetMaxEvents()
+		}
+		if item.TimeoutMs != nil {
+			existing.TimeoutMs = item.GetTimeoutMs()
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:      nodeID,
+			updated:     *existing,
+			baseNode:    nodeModel,
+			workspaceID: flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	ngqsubsWriter := s.ngqsubs.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeGraphQLSubscription := data.updated
+
+		if err := ngqsubsWriter.UpdateNodeGraphQLSubscription(ctx, nodeGraphQLSubscription); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeGraphQLSubscription,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeGraphQLSubscriptionWithFlow{
+				nodeGraphQLSubscription: nodeGraphQLSubscription,
+				flowID:                  data.baseNode.FlowID,
+				baseNode:                data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeGraphQLSubscriptionDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeGraphQLSubscriptionDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeGraphQLSubscription,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeGraphQLSubscription(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeGraphQLSubscriptionSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeGraphQLSubscriptionSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeGraphQLSubscriptionSync(ctx, func(resp *flowv1.NodeGraphQLSubscriptionSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeGraphQLSubscriptionSync(
+	ctx context.Context,
+	send func(*flowv1.NodeGraphQLSubscriptionSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeGraphQLSubscriptionEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert graphql subscription node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeGraphQLSubscriptionEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeGraphQLSubscriptionSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_GRAPH_Q_L_SUBSCRIPTION {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeGraphQLSubscription, err := s.ngqsubs.GetNodeGraphQLSubscription(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeGraphQLSubscriptionSync
+	switch evt.Type {
+	case nodeEventInsert:
+		if nodeGraphQLSubscription == nil {
+			return nil, nil
+		}
+		syncEvent = &flowv1.NodeGraphQLSubscriptionSync{
+			Value: &flowv1.NodeGraphQLSubscriptionSync_ValueUnion{
+				Kind: flowv1.NodeGraphQLSubscriptionSync_ValueUnion_KIND_INSERT,
+				Insert: &flowv1.NodeGraphQLSubscriptionSyncInsert{
+					NodeId:            nodeID.Bytes(),
+					GraphqlId:         graphqlIDBytes(nodeGraphQLSubscription.GraphQLID),
+					ConnectionPayload: nodeGraphQLSubscription.ConnectionPayload,
+					MaxEvents:         nodeGraphQLSubscription.MaxEvents,
+					TimeoutMs:         nodeGraphQLSubscription.TimeoutMs,
+				},
+			},
+		}
+	case nodeEventUpdate:
+		if nodeGraphQLSubscription == nil {
+			return nil, nil
+		}
+		syncEvent = &flowv1.NodeGraphQLSubscriptionSync{
+			Value: &flowv1.NodeGraphQLSubscriptionSync_ValueUnion{
+				Kind: flowv1.NodeGraphQLSubscriptionSync_ValueUnion_KIND_UPDATE,
+				Update: &flowv1.NodeGraphQLSubscriptionSyncUpdate{
+					NodeId:            nodeID.Bytes(),
+					GraphqlId:         graphqlIDBytes(nodeGraphQLSubscription.GraphQLID),
+					ConnectionPayload: &nodeGraphQLSubscription.ConnectionPayload,
+					MaxEvents:         &nodeGraphQLSubscription.MaxEvents,
+					TimeoutMs:         &nodeGraphQLSubscription.TimeoutMs,
+				},
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeGraphQLSubscriptionSync{
+			Value: &flowv1.NodeGraphQLSubscriptionSync_ValueUnion{
+				Kind: flowv1.NodeGraphQLSubscriptionSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeGraphQLSubscriptionSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeGraphQLSubscriptionSyncResponse{
+		Items: []*flowv1.NodeGraphQLSubscriptionSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeGraphQLSubscription(n mflow.NodeGraphQLSubscription) *flowv1.NodeGraphQLSubscription {
+	return &flowv1.NodeGraphQLSubscription{
+		NodeId:            n.FlowNodeID.Bytes(),
+		GraphqlId:         graphqlIDBytes(n.GraphQLID),
+		ConnectionPayload: n.ConnectionPayload,
+		MaxEvents:         n.MaxEvents,
+		TimeoutMs:         n.TimeoutMs,
+	}
+}
+
+// parseOptionalGraphQLID parses a GraphQL request reference, treating empty
+// and zero IDs as unset.
+func parseOptionalGraphQLID(b []byte) (*idwrap.IDWrap, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	id, err := idwrap.NewFromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid graphql id: %w", err)
+	}
+	if isZeroID(id) {
+		return nil, nil
+	}
+	return &id, nil
+}
+
+func graphqlIDBytes(id *idwrap.IDWrap) []byte {
+	if id == nil || isZeroID(*id) {
+		return nil
+	}
+	return id.Bytes()
+}
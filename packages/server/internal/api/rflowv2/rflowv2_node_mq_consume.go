@@ -0,0 +1,506 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeMqConsumeWithFlow struct {
+	nodeMqConsume mflow.NodeMqConsume
+	flowID        idwrap.IDWrap
+	baseNode      *mflow.Node
+}
+
+// NodeMqConsumeTopic identifies the flow whose MQ Consume nodes are being published.
+type NodeMqConsumeTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// NodeMqConsumeEvent describes a MQ Consume node change for sync streaming.
+type NodeMqConsumeEvent struct {
+	Type   string
+	FlowID idwrap.IDWrap
+	Node   *flowv1.NodeMqConsume
+}
+
+func (s *FlowServiceV2RPC) NodeMqConsumeCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeMqConsumeCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeMqConsume
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_MQ_CONSUME {
+				continue
+			}
+			nodeMqConsume, err := s.nmqc.GetNodeMqConsume(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeMqConsume == nil {
+				continue
+			}
+			items = append(items, serializeNodeMqConsume(*nodeMqConsume))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeMqConsumeCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeMqConsumeInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeMqConsumeInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeID        idwrap.IDWrap
+		nodeMqConsume mflow.NodeMqConsume
+		baseNode      *mflow.Node
+		flowID        idwrap.IDWrap
+		workspaceID   idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		// CredentialID is optional - can be set later via update
+		var credID *idwrap.IDWrap
+		if len(item.GetCredentialId()) > 0 {
+			id, err := idwrap.NewFromBytes(item.GetCredentialId())
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid credential id: %w", err))
+			}
+			credID = &id
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeID: nodeID,
+			nodeMqConsume: mflow.NodeMqConsume{
+				FlowNodeID:   nodeID,
+				CredentialID: credID,
+				Topic:        item.GetTopic(),
+				Group:        item.GetGroup(),
+				Filter:       item.GetFilter(),
+				TimeoutMs:    item.GetTimeoutMs(),
+				Count:        item.GetCount(),
+				Assertions:   item.GetAssertions(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nmqcWriter := s.nmqc.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeMqConsume := data.nodeMqConsume
+
+		if err := nmqcWriter.CreateNodeMqConsume(ctx, nodeMqConsume); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeMqConsume,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeMqConsumeWithFlow{
+					nodeMqConsume: nodeMqConsume,
+					flowID:        data.flowID,
+					baseNode:      data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeMqConsumeUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeMqConsumeUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID        idwrap.IDWrap
+		nodeMqConsume mflow.NodeMqConsume
+		baseNode      *mflow.Node
+		workspaceID   idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		// Get existing values to merge partial updates
+		existing, err := s.nmqc.GetNodeMqConsume(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			existing = &mflow.NodeMqConsume{FlowNodeID: nodeID}
+		}
+		if item.CredentialId != nil {
+			if len(item.CredentialId) > 0 {
+				credID, err := idwrap.NewFromBytes(item.CredentialId)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid credential id: %w", err))
+				}
+				existing.CredentialID = &credID
+			} else {
+				// Empty bytes means unset the credential
+				existing.CredentialID = nil
+			}
+		}
+		if item.Topic != nil {
+			existing.Topic = *item.Topic
+		}
+		if item.Group != nil {
+			existing.Group = *item.Group
+		}
+		if item.Filter != nil {
+			existing.Filter = *item.Filter
+		}
+		if item.TimeoutMs != nil {
+			existing.TimeoutMs = *item.TimeoutMs
+		}
+		if item.Count != nil {
+			existing.Count = *item.Count
+		}
+		if item.Assertions != nil {
+			existing.Assertions = item.Assertions
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:        nodeID,
+			nodeMqConsume: *existing,
+			baseNode:      nodeModel,
+			workspaceID:   flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nmqcWriter := s.nmqc.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeMqConsume := data.nodeMqConsume
+
+		if err := nmqcWriter.UpdateNodeMqConsume(ctx, nodeMqConsume); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeMqConsume,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeMqConsumeWithFlow{
+				nodeMqConsume: nodeMqConsume,
+				flowID:        data.baseNode.FlowID,
+				baseNode:      data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeMqConsumeDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeMqConsumeDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeMqConsume,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeMqConsume(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeMqConsumeSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeMqConsumeSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeMqConsumeSync(ctx, func(resp *flowv1.NodeMqConsumeSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeMqConsumeSync(
+	ctx context.Context,
+	send func(*flowv1.NodeMqConsumeSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeMqConsumeEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert mq consume node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeMqConsumeEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeMqConsumeSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_MQ_CONSUME {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeMqConsume, err := s.nmqc.GetNodeMqConsume(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeMqConsumeSync
+	switch evt.Type {
+	case nodeEventInsert:
+		insert := &flowv1.NodeMqConsumeSyncInsert{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeMqConsume != nil {
+			insert.CredentialId = mqConsumeCredentialID(*nodeMqConsume)
+			insert.Topic = nodeMqConsume.Topic
+			insert.Group = nodeMqConsume.Group
+			insert.Filter = nodeMqConsume.Filter
+			insert.TimeoutMs = nodeMqConsume.TimeoutMs
+			insert.Count = nodeMqConsume.Count
+			insert.Assertions = nodeMqConsume.Assertions
+		}
+		syncEvent = &flowv1.NodeMqConsumeSync{
+			Value: &flowv1.NodeMqConsumeSync_ValueUnion{
+				Kind:   flowv1.NodeMqConsumeSync_ValueUnion_KIND_INSERT,
+				Insert: insert,
+			},
+		}
+	case nodeEventUpdate:
+		update := &flowv1.NodeMqConsumeSyncUpdate{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeMqConsume != nil {
+			update.CredentialId = mqConsumeCredentialID(*nodeMqConsume)
+			update.Topic = &nodeMqConsume.Topic
+			update.Group = &nodeMqConsume.Group
+			update.Filter = &nodeMqConsume.Filter
+			update.TimeoutMs = &nodeMqConsume.TimeoutMs
+			update.Count = &nodeMqConsume.Count
+			update.Assertions = nodeMqConsume.Assertions
+		}
+		syncEvent = &flowv1.NodeMqConsumeSync{
+			Value: &flowv1.NodeMqConsumeSync_ValueUnion{
+				Kind:   flowv1.NodeMqConsumeSync_ValueUnion_KIND_UPDATE,
+				Update: update,
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeMqConsumeSync{
+			Value: &flowv1.NodeMqConsumeSync_ValueUnion{
+				Kind: flowv1.NodeMqConsumeSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeMqConsumeSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeMqConsumeSyncResponse{
+		Items: []*flowv1.NodeMqConsumeSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeMqConsume(n mflow.NodeMqConsume) *flowv1.NodeMqConsume {
+	return &flowv1.NodeMqConsume{
+		NodeId:       n.FlowNodeID.Bytes(),
+		CredentialId: mqConsumeCredentialID(n),
+		Topic:        n.Topic,
+		Group:        n.Group,
+		Filter:       n.Filter,
+		TimeoutMs:    n.TimeoutMs,
+		Count:        n.Count,
+		Assertions:   n.Assertions,
+	}
+}
+
+func mqConsumeCredentialID(n mflow.NodeMqConsume) []byte {
+	if n.CredentialID == nil {
+		return nil
+	}
+	return n.CredentialID.Bytes()
+}
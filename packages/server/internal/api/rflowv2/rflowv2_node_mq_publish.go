@@ -0,0 +1,485 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeMqPublishWithFlow struct {
+	nodeMqPublish mflow.NodeMqPublish
+	flowID        idwrap.IDWrap
+	baseNode      *mflow.Node
+}
+
+// NodeMqPublishTopic identifies the flow whose MQ Publish nodes are being published.
+type NodeMqPublishTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// NodeMqPublishEvent describes a MQ Publish node change for sync streaming.
+type NodeMqPublishEvent struct {
+	Type   string
+	FlowID idwrap.IDWrap
+	Node   *flowv1.NodeMqPublish
+}
+
+func (s *FlowServiceV2RPC) NodeMqPublishCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeMqPublishCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeMqPublish
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_MQ_PUBLISH {
+				continue
+			}
+			nodeMqPublish, err := s.nmqp.GetNodeMqPublish(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeMqPublish == nil {
+				continue
+			}
+			items = append(items, serializeNodeMqPublish(*nodeMqPublish))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeMqPublishCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeMqPublishInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeMqPublishInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeID        idwrap.IDWrap
+		nodeMqPublish mflow.NodeMqPublish
+		baseNode      *mflow.Node
+		flowID        idwrap.IDWrap
+		workspaceID   idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		// CredentialID is optional - can be set later via update
+		var credID *idwrap.IDWrap
+		if len(item.GetCredentialId()) > 0 {
+			id, err := idwrap.NewFromBytes(item.GetCredentialId())
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid credential id: %w", err))
+			}
+			credID = &id
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeID: nodeID,
+			nodeMqPublish: mflow.NodeMqPublish{
+				FlowNodeID:   nodeID,
+				CredentialID: credID,
+				Topic:        item.GetTopic(),
+				Key:          item.GetKey(),
+				Message:      item.GetMessage(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nmqpWriter := s.nmqp.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeMqPublish := data.nodeMqPublish
+
+		if err := nmqpWriter.CreateNodeMqPublish(ctx, nodeMqPublish); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeMqPublish,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeMqPublishWithFlow{
+					nodeMqPublish: nodeMqPublish,
+					flowID:        data.flowID,
+					baseNode:      data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeMqPublishUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeMqPublishUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID        idwrap.IDWrap
+		nodeMqPublish mflow.NodeMqPublish
+		baseNode      *mflow.Node
+		workspaceID   idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		// Get existing values to merge partial updates
+		existing, err := s.nmqp.GetNodeMqPublish(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			existing = &mflow.NodeMqPublish{FlowNodeID: nodeID}
+		}
+		if item.CredentialId != nil {
+			if len(item.CredentialId) > 0 {
+				credID, err := idwrap.NewFromBytes(item.CredentialId)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid credential id: %w", err))
+				}
+				existing.CredentialID = &credID
+			} else {
+				// Empty bytes means unset the credential
+				existing.CredentialID = nil
+			}
+		}
+		if item.Topic != nil {
+			existing.Topic = *item.Topic
+		}
+		if item.Key != nil {
+			existing.Key = *item.Key
+		}
+		if item.Message != nil {
+			existing.Message = *item.Message
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:        nodeID,
+			nodeMqPublish: *existing,
+			baseNode:      nodeModel,
+			workspaceID:   flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nmqpWriter := s.nmqp.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeMqPublish := data.nodeMqPublish
+
+		if err := nmqpWriter.UpdateNodeMqPublish(ctx, nodeMqPublish); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeMqPublish,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeMqPublishWithFlow{
+				nodeMqPublish: nodeMqPublish,
+				flowID:        data.baseNode.FlowID,
+				baseNode:      data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeMqPublishDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeMqPublishDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeMqPublish,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeMqPublish(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeMqPublishSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeMqPublishSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeMqPublishSync(ctx, func(resp *flowv1.NodeMqPublishSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeMqPublishSync(
+	ctx context.Context,
+	send func(*flowv1.NodeMqPublishSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeMqPublishEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert mq publish node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeMqPublishEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeMqPublishSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_MQ_PUBLISH {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeMqPublish, err := s.nmqp.GetNodeMqPublish(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeMqPublishSync
+	switch evt.Type {
+	case nodeEventInsert:
+		insert := &flowv1.NodeMqPublishSyncInsert{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeMqPublish != nil {
+			insert.CredentialId = mqPublishCredentialID(*nodeMqPublish)
+			insert.Topic = nodeMqPublish.Topic
+			insert.Key = nodeMqPublish.Key
+			insert.Message = nodeMqPublish.Message
+		}
+		syncEvent = &flowv1.NodeMqPublishSync{
+			Value: &flowv1.NodeMqPublishSync_ValueUnion{
+				Kind:   flowv1.NodeMqPublishSync_ValueUnion_KIND_INSERT,
+				Insert: insert,
+			},
+		}
+	case nodeEventUpdate:
+		update := &flowv1.NodeMqPublishSyncUpdate{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeMqPublish != nil {
+			update.CredentialId = mqPublishCredentialID(*nodeMqPublish)
+			update.Topic = &nodeMqPublish.Topic
+			update.Key = &nodeMqPublish.Key
+			update.Message = &nodeMqPublish.Message
+		}
+		syncEvent = &flowv1.NodeMqPublishSync{
+			Value: &flowv1.NodeMqPublishSync_ValueUnion{
+				Kind:   flowv1.NodeMqPublishSync_ValueUnion_KIND_UPDATE,
+				Update: update,
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeMqPublishSync{
+			Value: &flowv1.NodeMqPublishSync_ValueUnion{
+				Kind: flowv1.NodeMqPublishSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeMqPublishSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeMqPublishSyncResponse{
+		Items: []*flowv1.NodeMqPublishSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeMqPublish(n mflow.NodeMqPublish) *flowv1.NodeMqPublish {
+	return &flowv1.NodeMqPublish{
+		NodeId:       n.FlowNodeID.Bytes(),
+		CredentialId: mqPublishCredentialID(n),
+		Topic:        n.Topic,
+		Key:          n.Key,
+		Message:      n.Message,
+	}
+}
+
+func mqPublishCredentialID(n mflow.NodeMqPublish) []byte {
+	if n.CredentialID == nil {
+		return nil
+	}
+	return n.CredentialID.Bytes()
+}
@@ -0,0 +1,437 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/converter"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeParallelWithFlow struct {
+	nodeParallel mflow.NodeParallel
+	flowID       idwrap.IDWrap
+	baseNode     *mflow.Node
+}
+
+func (s *FlowServiceV2RPC) NodeParallelCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeParallelCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeParallel
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_PARALLEL {
+				continue
+			}
+			nodeParallel, err := s.nparallels.GetNodeParallel(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeParallel == nil {
+				continue
+			}
+			items = append(items, serializeNodeParallel(*nodeParallel))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeParallelCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeParallelInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeParallelInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeID      idwrap.IDWrap
+		joinMode    mflow.ParallelJoin
+		concurrency int32
+		baseNode    *mflow.Node
+		flowID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeID:      nodeID,
+			joinMode:    converter.FromAPIParallelJoin(item.GetJoin()),
+			concurrency: item.GetConcurrency(),
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nparallelsWriter := s.nparallels.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeParallel := mflow.NodeParallel{
+			FlowNodeID:  data.nodeID,
+			JoinMode:    data.joinMode,
+			Concurrency: data.concurrency,
+		}
+
+		if err := nparallelsWriter.CreateNodeParallel(ctx, nodeParallel); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeParallel,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeParallelWithFlow{
+					nodeParallel: nodeParallel,
+					flowID:       data.flowID,
+					baseNode:     data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeParallelUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeParallelUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID      idwrap.IDWrap
+		updated     mflow.NodeParallel
+		baseNode    *mflow.Node
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		existing, err := s.nparallels.GetNodeParallel(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("node %s does not have PARALLEL config", nodeID.String()))
+		}
+
+		if item.Join != nil {
+			existing.JoinMode = converter.FromAPIParallelJoin(item.GetJoin())
+		}
+		if item.Concurrency != nil {
+			existing.Concurrency = item.GetConcurrency()
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:      nodeID,
+			updated:     *existing,
+			baseNode:    nodeModel,
+			workspaceID: flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nparallelsWriter := s.nparallels.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeParallel := data.updated
+
+		if err := nparallelsWriter.UpdateNodeParallel(ctx, nodeParallel); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeParallel,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeParallelWithFlow{
+				nodeParallel: nodeParallel,
+				flowID:       data.baseNode.FlowID,
+				baseNode:     data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeParallelDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeParallelDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeParallel,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeParallel(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeParallelSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeParallelSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeParallelSync(ctx, func(resp *flowv1.NodeParallelSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeParallelSync(
+	ctx context.Context,
+	send func(*flowv1.NodeParallelSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeParallelEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert parallel node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeParallelEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeParallelSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_PARALLEL {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeParallel, err := s.nparallels.GetNodeParallel(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeParallelSync
+	switch evt.Type {
+	case nodeEventInsert:
+		if nodeParallel == nil {
+			return nil, nil
+		}
+		syncEvent = &flowv1.NodeParallelSync{
+			Value: &flowv1.NodeParallelSync_ValueUnion{
+				Kind: flowv1.NodeParallelSync_ValueUnion_KIND_INSERT,
+				Insert: &flowv1.NodeParallelSyncInsert{
+					NodeId:      nodeID.Bytes(),
+					Join:        converter.ToAPIParallelJoin(nodeParallel.JoinMode),
+					Concurrency: nodeParallel.Concurrency,
+				},
+			},
+		}
+	case nodeEventUpdate:
+		if nodeParallel == nil {
+			return nil, nil
+		}
+		join := converter.ToAPIParallelJoin(nodeParallel.JoinMode)
+		syncEvent = &flowv1.NodeParallelSync{
+			Value: &flowv1.NodeParallelSync_ValueUnion{
+				Kind: flowv1.NodeParallelSync_ValueUnion_KIND_UPDATE,
+				Update: &flowv1.NodeParallelSyncUpdate{
+					NodeId:      nodeID.Bytes(),
+					Join:        &join,
+					Concurrency: &nodeParallel.Concurrency,
+				},
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeParallelSync{
+			Value: &flowv1.NodeParallelSync_ValueUnion{
+				Kind: flowv1.NodeParallelSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeParallelSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeParallelSyncResponse{
+		Items: []*flowv1.NodeParallelSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeParallel(n mflow.NodeParallel) *flowv1.NodeParallel {
+	return &flowv1.NodeParallel{
+		NodeId:      n.FlowNodeID.Bytes(),
+		Join:        converter.ToAPIParallelJoin(n.JoinMode),
+		Concurrency: n.Concurrency,
+	}
+}
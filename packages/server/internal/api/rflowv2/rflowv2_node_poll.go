@@ -0,0 +1,452 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodePollWithFlow struct {
+	nodePoll mflow.NodePoll
+	flowID   idwrap.IDWrap
+	baseNode *mflow.Node
+}
+
+func (s *FlowServiceV2RPC) NodePollCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodePollCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodePoll
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_POLL {
+				continue
+			}
+			nodePoll, err := s.npolls.GetNodePoll(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodePoll == nil {
+				continue
+			}
+			items = append(items, serializeNodePoll(*nodePoll))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodePollCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodePollInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodePollInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodePoll    mflow.NodePoll
+		baseNode    *mflow.Node
+		flowID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodePoll: mflow.NodePoll{
+				FlowNodeID:    nodeID,
+				Until:         item.GetUntil(),
+				IntervalMs:    item.GetIntervalMs(),
+				Backoff:       item.GetBackoff(),
+				MaxDurationMs: item.GetMaxDurationMs(),
+				MaxAttempts:   item.GetMaxAttempts(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	npollsWriter := s.npolls.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodePoll := data.nodePoll
+
+		if err := npollsWriter.CreateNodePoll(ctx, nodePoll); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodePoll,
+				Op:          mutation.OpInsert,
+				ID:          data.nodePoll.FlowNodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodePollWithFlow{
+					nodePoll: nodePoll,
+					flowID:   data.flowID,
+					baseNode: data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodePollUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodePollUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID      idwrap.IDWrap
+		updated     mflow.NodePoll
+		baseNode    *mflow.Node
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		existing, err := s.npolls.GetNodePoll(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("node %s does not have POLL config", nodeID.String()))
+		}
+
+		if item.Until != nil {
+			existing.Until = item.GetUntil()
+		}
+		if item.IntervalMs != nil {
+			existing.IntervalMs = item.GetIntervalMs()
+		}
+		if item.Backoff != nil {
+			existing.Backoff = item.GetBackoff()
+		}
+		if item.MaxDurationMs != nil {
+			existing.MaxDurationMs = item.GetMaxDurationMs()
+		}
+		if item.MaxAttempts != nil {
+			existing.MaxAttempts = item.GetMaxAttempts()
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:      nodeID,
+			updated:     *existing,
+			baseNode:    nodeModel,
+			workspaceID: flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	npollsWriter := s.npolls.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodePoll := data.updated
+
+		if err := npollsWriter.UpdateNodePoll(ctx, nodePoll); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodePoll,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodePollWithFlow{
+				nodePoll: nodePoll,
+				flowID:   data.baseNode.FlowID,
+				baseNode: data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodePollDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodePollDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodePoll,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodePoll(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodePollSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodePollSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodePollSync(ctx, func(resp *flowv1.NodePollSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodePollSync(
+	ctx context.Context,
+	send func(*flowv1.NodePollSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodePollEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert poll node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodePollEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodePollSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_POLL {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodePoll, err := s.npolls.GetNodePoll(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodePollSync
+	switch evt.Type {
+	case nodeEventInsert:
+		if nodePoll == nil {
+			return nil, nil
+		}
+		syncEvent = &flowv1.NodePollSync{
+			Value: &flowv1.NodePollSync_ValueUnion{
+				Kind: flowv1.NodePollSync_ValueUnion_KIND_INSERT,
+				Insert: &flowv1.NodePollSyncInsert{
+					NodeId:        nodeID.Bytes(),
+					Until:         nodePoll.Until,
+					IntervalMs:    nodePoll.IntervalMs,
+					Backoff:       nodePoll.Backoff,
+					MaxDurationMs: nodePoll.MaxDurationMs,
+					MaxAttempts:   nodePoll.MaxAttempts,
+				},
+			},
+		}
+	case nodeEventUpdate:
+		if nodePoll == nil {
+			return nil, nil
+		}
+		syncEvent = &flowv1.NodePollSync{
+			Value: &flowv1.NodePollSync_ValueUnion{
+				Kind: flowv1.NodePollSync_ValueUnion_KIND_UPDATE,
+				Update: &flowv1.NodePollSyncUpdate{
+					NodeId:        nodeID.Bytes(),
+					Until:         &nodePoll.Until,
+					IntervalMs:    &nodePoll.IntervalMs,
+					Backoff:       &nodePoll.Backoff,
+					MaxDurationMs: &nodePoll.MaxDurationMs,
+					MaxAttempts:   &nodePoll.MaxAttempts,
+				},
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodePollSync{
+			Value: &flowv1.NodePollSync_ValueUnion{
+				Kind: flowv1.NodePollSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodePollSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodePollSyncResponse{
+		Items: []*flowv1.NodePollSync{syncEvent},
+	}, nil
+}
+
+func serializeNodePoll(n mflow.NodePoll) *flowv1.NodePoll {
+	return &flowv1.NodePoll{
+		NodeId:        n.FlowNodeID.Bytes(),
+		Until:         n.Until,
+		IntervalMs:    n.IntervalMs,
+		Backoff:       n.Backoff,
+		MaxDurationMs: n.MaxDurationMs,
+		MaxAttempts:   n.MaxAttempts,
+	}
+}
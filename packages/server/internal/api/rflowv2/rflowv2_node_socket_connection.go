@@ -0,0 +1,490 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/converter"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeSocketConnectionWithFlow struct {
+	nodeSocketConnection mflow.NodeSocketConnection
+	flowID               idwrap.IDWrap
+	baseNode             *mflow.Node
+}
+
+// NodeSocketConnectionTopic identifies the flow whose Socket Connection nodes are being published.
+type NodeSocketConnectionTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// NodeSocketConnectionEvent describes a Socket Connection node change for sync streaming.
+type NodeSocketConnectionEvent struct {
+	Type   string
+	FlowID idwrap.IDWrap
+	Node   *flowv1.NodeSocketConnection
+}
+
+func (s *FlowServiceV2RPC) NodeSocketConnectionCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeSocketConnectionCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeSocketConnection
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_SOCKET_CONNECTION {
+				continue
+			}
+			nodeSocketConnection, err := s.nsoc.GetNodeSocketConnection(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeSocketConnection == nil {
+				continue
+			}
+			items = append(items, serializeNodeSocketConnection(*nodeSocketConnection))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeSocketConnectionCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketConnectionInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSocketConnectionInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeID               idwrap.IDWrap
+		nodeSocketConnection mflow.NodeSocketConnection
+		baseNode             *mflow.Node
+		flowID               idwrap.IDWrap
+		workspaceID          idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeID: nodeID,
+			nodeSocketConnection: mflow.NodeSocketConnection{
+				FlowNodeID:    nodeID,
+				Network:       converter.FromAPISocketNetwork(item.GetNetwork()),
+				Address:       item.GetAddress(),
+				TLS:           item.GetTls(),
+				TLSSkipVerify: item.GetTlsSkipVerify(),
+				Framing:       converter.FromAPISocketFraming(item.GetFraming()),
+				Delimiter:     item.GetDelimiter(),
+				LengthBytes:   item.GetLengthBytes(),
+				TimeoutMs:     item.GetTimeoutMs(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nsocWriter := s.nsoc.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeSocketConnection := data.nodeSocketConnection
+
+		if err := nsocWriter.CreateNodeSocketConnection(ctx, nodeSocketConnection); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeSocketConnection,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeSocketConnectionWithFlow{
+					nodeSocketConnection: nodeSocketConnection,
+					flowID:               data.flowID,
+					baseNode:             data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketConnectionUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSocketConnectionUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID               idwrap.IDWrap
+		nodeSocketConnection mflow.NodeSocketConnection
+		baseNode             *mflow.Node
+		workspaceID          idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		// Get existing values to merge partial updates
+		existing, err := s.nsoc.GetNodeSocketConnection(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			existing = &mflow.NodeSocketConnection{FlowNodeID: nodeID}
+		}
+		if item.Network != nil {
+			existing.Network = converter.FromAPISocketNetwork(*item.Network)
+		}
+		if item.Address != nil {
+			existing.Address = *item.Address
+		}
+		if item.Tls != nil {
+			existing.TLS = *item.Tls
+		}
+		if item.TlsSkipVerify != nil {
+			existing.TLSSkipVerify = *item.TlsSkipVerify
+		}
+		if item.Framing != nil {
+			existing.Framing = converter.FromAPISocketFraming(*item.Framing)
+		}
+		if item.Delimiter != nil {
+			existing.Delimiter = *item.Delimiter
+		}
+		if item.LengthBytes != nil {
+			existing.LengthBytes = *item.LengthBytes
+		}
+		if item.TimeoutMs != nil {
+			existing.TimeoutMs = *item.TimeoutMs
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:               nodeID,
+			nodeSocketConnection: *existing,
+			baseNode:             nodeModel,
+			workspaceID:          flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nsocWriter := s.nsoc.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeSocketConnection := data.nodeSocketConnection
+
+		if err := nsocWriter.UpdateNodeSocketConnection(ctx, nodeSocketConnection); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeSocketConnection,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeSocketConnectionWithFlow{
+				nodeSocketConnection: nodeSocketConnection,
+				flowID:               data.baseNode.FlowID,
+				baseNode:             data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketConnectionDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSocketConnectionDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeSocketConnection,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeSocketConnection(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketConnectionSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeSocketConnectionSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeSocketConnectionSync(ctx, func(resp *flowv1.NodeSocketConnectionSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeSocketConnectionSync(
+	ctx context.Context,
+	send func(*flowv1.NodeSocketConnectionSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeSocketConnectionEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert socket connection node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeSocketConnectionEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeSocketConnectionSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_SOCKET_CONNECTION {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeSocketConnection, err := s.nsoc.GetNodeSocketConnection(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeSocketConnectionSync
+	switch evt.Type {
+	case nodeEventInsert:
+		insert := &flowv1.NodeSocketConnectionSyncInsert{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeSocketConnection != nil {
+			insert.Network = converter.ToAPISocketNetwork(nodeSocketConnection.Network)
+			insert.Address = nodeSocketConnection.Address
+			insert.Tls = nodeSocketConnection.TLS
+			insert.TlsSkipVerify = nodeSocketConnection.TLSSkipVerify
+			insert.Framing = converter.ToAPISocketFraming(nodeSocketConnection.Framing)
+			insert.Delimiter = nodeSocketConnection.Delimiter
+			insert.LengthBytes = nodeSocketConnection.LengthBytes
+			insert.TimeoutMs = nodeSocketConnection.TimeoutMs
+		}
+		syncEvent = &flowv1.NodeSocketConnectionSync{
+			Value: &flowv1.NodeSocketConnectionSync_ValueUnion{
+				Kind:   flowv1.NodeSocketConnectionSync_ValueUnion_KIND_INSERT,
+				Insert: insert,
+			},
+		}
+	case nodeEventUpdate:
+		update := &flowv1.NodeSocketConnectionSyncUpdate{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeSocketConnection != nil {
+			network := converter.ToAPISocketNetwork(nodeSocketConnection.Network)
+			update.Network = &network
+			update.Address = &nodeSocketConnection.Address
+			update.Tls = &nodeSocketConnection.TLS
+			update.TlsSkipVerify = &nodeSocketConnection.TLSSkipVerify
+			framing := converter.ToAPISocketFraming(nodeSocketConnection.Framing)
+			update.Framing = &framing
+			update.Delimiter = &nodeSocketConnection.Delimiter
+			update.LengthBytes = &nodeSocketConnection.LengthBytes
+			update.TimeoutMs = &nodeSocketConnection.TimeoutMs
+		}
+		syncEvent = &flowv1.NodeSocketConnectionSync{
+			Value: &flowv1.NodeSocketConnectionSync_ValueUnion{
+				Kind:   flowv1.NodeSocketConnectionSync_ValueUnion_KIND_UPDATE,
+				Update: update,
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeSocketConnectionSync{
+			Value: &flowv1.NodeSocketConnectionSync_ValueUnion{
+				Kind: flowv1.NodeSocketConnectionSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeSocketConnectionSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeSocketConnectionSyncResponse{
+		Items: []*flowv1.NodeSocketConnectionSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeSocketConnection(n mflow.NodeSocketConnection) *flowv1.NodeSocketConnection {
+	return &flowv1.NodeSocketConnection{
+		NodeId:        n.FlowNodeID.Bytes(),
+		Network:       converter.ToAPISocketNetwork(n.Network),
+		Address:       n.Address,
+		Tls:           n.TLS,
+		TlsSkipVerify: n.TLSSkipVerify,
+		Framing:       converter.ToAPISocketFraming(n.Framing),
+		Delimiter:     n.Delimiter,
+		LengthBytes:   n.LengthBytes,
+		TimeoutMs:     n.TimeoutMs,
+	}
+}
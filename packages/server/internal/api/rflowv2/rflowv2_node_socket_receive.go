@@ -0,0 +1,466 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeSocketReceiveWithFlow struct {
+	nodeSocketReceive mflow.NodeSocketReceive
+	flowID            idwrap.IDWrap
+	baseNode          *mflow.Node
+}
+
+// NodeSocketReceiveTopic identifies the flow whose Socket Receive nodes are being published.
+type NodeSocketReceiveTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// NodeSocketReceiveEvent describes a Socket Receive node change for sync streaming.
+type NodeSocketReceiveEvent struct {
+	Type   string
+	FlowID idwrap.IDWrap
+	Node   *flowv1.NodeSocketReceive
+}
+
+func (s *FlowServiceV2RPC) NodeSocketReceiveCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeSocketReceiveCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeSocketReceive
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_SOCKET_RECEIVE {
+				continue
+			}
+			nodeSocketReceive, err := s.nsor.GetNodeSocketReceive(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeSocketReceive == nil {
+				continue
+			}
+			items = append(items, serializeNodeSocketReceive(*nodeSocketReceive))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeSocketReceiveCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketReceiveInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSocketReceiveInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeID            idwrap.IDWrap
+		nodeSocketReceive mflow.NodeSocketReceive
+		baseNode          *mflow.Node
+		flowID            idwrap.IDWrap
+		workspaceID       idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeID: nodeID,
+			nodeSocketReceive: mflow.NodeSocketReceive{
+				FlowNodeID:               nodeID,
+				SocketConnectionNodeName: item.GetSocketConnectionNodeName(),
+				Match:                    item.GetMatch(),
+				TimeoutMs:                item.GetTimeoutMs(),
+				Count:                    item.GetCount(),
+				Assertions:               item.GetAssertions(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nsorWriter := s.nsor.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeSocketReceive := data.nodeSocketReceive
+
+		if err := nsorWriter.CreateNodeSocketReceive(ctx, nodeSocketReceive); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeSocketReceive,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeSocketReceiveWithFlow{
+					nodeSocketReceive: nodeSocketReceive,
+					flowID:            data.flowID,
+					baseNode:          data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketReceiveUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSocketReceiveUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID            idwrap.IDWrap
+		nodeSocketReceive mflow.NodeSocketReceive
+		baseNode          *mflow.Node
+		workspaceID       idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		// Get existing values to merge partial updates
+		existing, err := s.nsor.GetNodeSocketReceive(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			existing = &mflow.NodeSocketReceive{FlowNodeID: nodeID}
+		}
+		if item.SocketConnectionNodeName != nil {
+			existing.SocketConnectionNodeName = *item.SocketConnectionNodeName
+		}
+		if item.Match != nil {
+			existing.Match = *item.Match
+		}
+		if item.TimeoutMs != nil {
+			existing.TimeoutMs = *item.TimeoutMs
+		}
+		if item.Count != nil {
+			existing.Count = *item.Count
+		}
+		if item.Assertions != nil {
+			existing.Assertions = item.Assertions
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:            nodeID,
+			nodeSocketReceive: *existing,
+			baseNode:          nodeModel,
+			workspaceID:       flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nsorWriter := s.nsor.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeSocketReceive := data.nodeSocketReceive
+
+		if err := nsorWriter.UpdateNodeSocketReceive(ctx, nodeSocketReceive); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeSocketReceive,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeSocketReceiveWithFlow{
+				nodeSocketReceive: nodeSocketReceive,
+				flowID:            data.baseNode.FlowID,
+				baseNode:          data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketReceiveDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSocketReceiveDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeSocketReceive,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeSocketReceive(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketReceiveSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeSocketReceiveSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeSocketReceiveSync(ctx, func(resp *flowv1.NodeSocketReceiveSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeSocketReceiveSync(
+	ctx context.Context,
+	send func(*flowv1.NodeSocketReceiveSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeSocketReceiveEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert socket receive node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeSocketReceiveEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeSocketReceiveSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_SOCKET_RECEIVE {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeSocketReceive, err := s.nsor.GetNodeSocketReceive(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeSocketReceiveSync
+	switch evt.Type {
+	case nodeEventInsert:
+		insert := &flowv1.NodeSocketReceiveSyncInsert{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeSocketReceive != nil {
+			insert.SocketConnectionNodeName = nodeSocketReceive.SocketConnectionNodeName
+			insert.Match = nodeSocketReceive.Match
+			insert.TimeoutMs = nodeSocketReceive.TimeoutMs
+			insert.Count = nodeSocketReceive.Count
+			insert.Assertions = nodeSocketReceive.Assertions
+		}
+		syncEvent = &flowv1.NodeSocketReceiveSync{
+			Value: &flowv1.NodeSocketReceiveSync_ValueUnion{
+				Kind:   flowv1.NodeSocketReceiveSync_ValueUnion_KIND_INSERT,
+				Insert: insert,
+			},
+		}
+	case nodeEventUpdate:
+		update := &flowv1.NodeSocketReceiveSyncUpdate{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeSocketReceive != nil {
+			update.SocketConnectionNodeName = &nodeSocketReceive.SocketConnectionNodeName
+			update.Match = &nodeSocketReceive.Match
+			update.TimeoutMs = &nodeSocketReceive.TimeoutMs
+			update.Count = &nodeSocketReceive.Count
+			update.Assertions = nodeSocketReceive.Assertions
+		}
+		syncEvent = &flowv1.NodeSocketReceiveSync{
+			Value: &flowv1.NodeSocketReceiveSync_ValueUnion{
+				Kind:   flowv1.NodeSocketReceiveSync_ValueUnion_KIND_UPDATE,
+				Update: update,
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeSocketReceiveSync{
+			Value: &flowv1.NodeSocketReceiveSync_ValueUnion{
+				Kind: flowv1.NodeSocketReceiveSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeSocketReceiveSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeSocketReceiveSyncResponse{
+		Items: []*flowv1.NodeSocketReceiveSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeSocketReceive(n mflow.NodeSocketReceive) *flowv1.NodeSocketReceive {
+	return &flowv1.NodeSocketReceive{
+		NodeId:                   n.FlowNodeID.Bytes(),
+		SocketConnectionNodeName: n.SocketConnectionNodeName,
+		Match:                    n.Match,
+		TimeoutMs:                n.TimeoutMs,
+		Count:                    n.Count,
+		Assertions:               n.Assertions,
+	}
+}
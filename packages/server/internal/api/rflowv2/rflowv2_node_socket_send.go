@@ -0,0 +1,454 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/converter"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeSocketSendWithFlow struct {
+	nodeSocketSend mflow.NodeSocketSend
+	flowID         idwrap.IDWrap
+	baseNode       *mflow.Node
+}
+
+// NodeSocketSendTopic identifies the flow whose Socket Send nodes are being published.
+type NodeSocketSendTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// NodeSocketSendEvent describes a Socket Send node change for sync streaming.
+type NodeSocketSendEvent struct {
+	Type   string
+	FlowID idwrap.IDWrap
+	Node   *flowv1.NodeSocketSend
+}
+
+func (s *FlowServiceV2RPC) NodeSocketSendCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeSocketSendCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeSocketSend
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_SOCKET_SEND {
+				continue
+			}
+			nodeSocketSend, err := s.nsos.GetNodeSocketSend(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeSocketSend == nil {
+				continue
+			}
+			items = append(items, serializeNodeSocketSend(*nodeSocketSend))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeSocketSendCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketSendInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSocketSendInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeID         idwrap.IDWrap
+		nodeSocketSend mflow.NodeSocketSend
+		baseNode       *mflow.Node
+		flowID         idwrap.IDWrap
+		workspaceID    idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeID: nodeID,
+			nodeSocketSend: mflow.NodeSocketSend{
+				FlowNodeID:               nodeID,
+				SocketConnectionNodeName: item.GetSocketConnectionNodeName(),
+				Message:                  item.GetMessage(),
+				Encoding:                 converter.FromAPISocketEncoding(item.GetEncoding()),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nsosWriter := s.nsos.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeSocketSend := data.nodeSocketSend
+
+		if err := nsosWriter.CreateNodeSocketSend(ctx, nodeSocketSend); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeSocketSend,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeSocketSendWithFlow{
+					nodeSocketSend: nodeSocketSend,
+					flowID:         data.flowID,
+					baseNode:       data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketSendUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSocketSendUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID         idwrap.IDWrap
+		nodeSocketSend mflow.NodeSocketSend
+		baseNode       *mflow.Node
+		workspaceID    idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		// Get existing values to merge partial updates
+		existing, err := s.nsos.GetNodeSocketSend(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			existing = &mflow.NodeSocketSend{FlowNodeID: nodeID}
+		}
+		if item.SocketConnectionNodeName != nil {
+			existing.SocketConnectionNodeName = *item.SocketConnectionNodeName
+		}
+		if item.Message != nil {
+			existing.Message = *item.Message
+		}
+		if item.Encoding != nil {
+			existing.Encoding = converter.FromAPISocketEncoding(*item.Encoding)
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:         nodeID,
+			nodeSocketSend: *existing,
+			baseNode:       nodeModel,
+			workspaceID:    flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nsosWriter := s.nsos.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeSocketSend := data.nodeSocketSend
+
+		if err := nsosWriter.UpdateNodeSocketSend(ctx, nodeSocketSend); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeSocketSend,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeSocketSendWithFlow{
+				nodeSocketSend: nodeSocketSend,
+				flowID:         data.baseNode.FlowID,
+				baseNode:       data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketSendDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSocketSendDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeSocketSend,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeSocketSend(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSocketSendSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeSocketSendSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeSocketSendSync(ctx, func(resp *flowv1.NodeSocketSendSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeSocketSendSync(
+	ctx context.Context,
+	send func(*flowv1.NodeSocketSendSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeSocketSendEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert socket send node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeSocketSendEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeSocketSendSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_SOCKET_SEND {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeSocketSend, err := s.nsos.GetNodeSocketSend(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeSocketSendSync
+	switch evt.Type {
+	case nodeEventInsert:
+		insert := &flowv1.NodeSocketSendSyncInsert{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeSocketSend != nil {
+			insert.SocketConnectionNodeName = nodeSocketSend.SocketConnectionNodeName
+			insert.Message = nodeSocketSend.Message
+			insert.Encoding = converter.ToAPISocketEncoding(nodeSocketSend.Encoding)
+		}
+		syncEvent = &flowv1.NodeSocketSendSync{
+			Value: &flowv1.NodeSocketSendSync_ValueUnion{
+				Kind:   flowv1.NodeSocketSendSync_ValueUnion_KIND_INSERT,
+				Insert: insert,
+			},
+		}
+	case nodeEventUpdate:
+		update := &flowv1.NodeSocketSendSyncUpdate{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeSocketSend != nil {
+			update.SocketConnectionNodeName = &nodeSocketSend.SocketConnectionNodeName
+			update.Message = &nodeSocketSend.Message
+			encoding := converter.ToAPISocketEncoding(nodeSocketSend.Encoding)
+			update.Encoding = &encoding
+		}
+		syncEvent = &flowv1.NodeSocketSendSync{
+			Value: &flowv1.NodeSocketSendSync_ValueUnion{
+				Kind:   flowv1.NodeSocketSendSync_ValueUnion_KIND_UPDATE,
+				Update: update,
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeSocketSendSync{
+			Value: &flowv1.NodeSocketSendSync_ValueUnion{
+				Kind: flowv1.NodeSocketSendSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeSocketSendSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeSocketSendSyncResponse{
+		Items: []*flowv1.NodeSocketSendSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeSocketSend(n mflow.NodeSocketSend) *flowv1.NodeSocketSend {
+	return &flowv1.NodeSocketSend{
+		NodeId:                   n.FlowNodeID.Bytes(),
+		SocketConnectionNodeName: n.SocketConnectionNodeName,
+		Message:                  n.Message,
+		Encoding:                 converter.ToAPISocketEncoding(n.Encoding),
+	}
+}
@@ -0,0 +1,452 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeSwitchWithFlow struct {
+	nodeSwitch mflow.NodeSwitch
+	flowID     idwrap.IDWrap
+	baseNode   *mflow.Node
+}
+
+func (s *FlowServiceV2RPC) NodeSwitchCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeSwitchCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeSwitch
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_SWITCH {
+				continue
+			}
+			nodeSwitch, err := s.nswitches.GetNodeSwitch(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeSwitch == nil {
+				continue
+			}
+			items = append(items, serializeNodeSwitch(*nodeSwitch))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeSwitchCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSwitchInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSwitchInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeSwitch  mflow.NodeSwitch
+		baseNode    *mflow.Node
+		flowID      idwrap.IDWrap
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeSwitch: mflow.NodeSwitch{
+				FlowNodeID: nodeID,
+				Cases:      protoToSwitchCases(item.GetCases()),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nswitchesWriter := s.nswitches.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeSwitch := data.nodeSwitch
+
+		if err := nswitchesWriter.CreateNodeSwitch(ctx, nodeSwitch); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeSwitch,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeSwitch.FlowNodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeSwitchWithFlow{
+					nodeSwitch: nodeSwitch,
+					flowID:     data.flowID,
+					baseNode:   data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSwitchUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSwitchUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID      idwrap.IDWrap
+		updated     mflow.NodeSwitch
+		baseNode    *mflow.Node
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		existing, err := s.nswitches.GetNodeSwitch(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("node %s does not have SWITCH config", nodeID.String()))
+		}
+
+		if item.Cases != nil {
+			existing.Cases = protoToSwitchCases(item.Cases)
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:      nodeID,
+			updated:     *existing,
+			baseNode:    nodeModel,
+			workspaceID: flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nswitchesWriter := s.nswitches.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeSwitch := data.updated
+
+		if err := nswitchesWriter.UpdateNodeSwitch(ctx, nodeSwitch); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeSwitch,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeSwitchWithFlow{
+				nodeSwitch: nodeSwitch,
+				flowID:     data.baseNode.FlowID,
+				baseNode:   data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSwitchDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeSwitchDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeSwitch,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeSwitch(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeSwitchSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeSwitchSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeSwitchSync(ctx, func(resp *flowv1.NodeSwitchSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeSwitchSync(
+	ctx context.Context,
+	send func(*flowv1.NodeSwitchSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeSwitchEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert switch node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeSwitchEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeSwitchSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_SWITCH {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeSwitch, err := s.nswitches.GetNodeSwitch(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeSwitchSync
+	switch evt.Type {
+	case nodeEventInsert:
+		if nodeSwitch == nil {
+			return nil, nil
+		}
+		syncEvent = &flowv1.NodeSwitchSync{
+			Value: &flowv1.NodeSwitchSync_ValueUnion{
+				Kind: flowv1.NodeSwitchSync_ValueUnion_KIND_INSERT,
+				Insert: &flowv1.NodeSwitchSyncInsert{
+					NodeId: nodeID.Bytes(),
+					Cases:  switchCasesToProto(nodeSwitch.Cases),
+				},
+			},
+		}
+	case nodeEventUpdate:
+		if nodeSwitch == nil {
+			return nil, nil
+		}
+		syncEvent = &flowv1.NodeSwitchSync{
+			Value: &flowv1.NodeSwitchSync_ValueUnion{
+				Kind: flowv1.NodeSwitchSync_ValueUnion_KIND_UPDATE,
+				Update: &flowv1.NodeSwitchSyncUpdate{
+					NodeId: nodeID.Bytes(),
+					Cases:  switchCasesToProto(nodeSwitch.Cases),
+				},
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeSwitchSync{
+			Value: &flowv1.NodeSwitchSync_ValueUnion{
+				Kind: flowv1.NodeSwitchSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeSwitchSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeSwitchSyncResponse{
+		Items: []*flowv1.NodeSwitchSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeSwitch(n mflow.NodeSwitch) *flowv1.NodeSwitch {
+	return &flowv1.NodeSwitch{
+		NodeId: n.FlowNodeID.Bytes(),
+		Cases:  switchCasesToProto(n.Cases),
+	}
+}
+
+func switchCasesToProto(cases []mflow.SwitchCase) []*flowv1.SwitchCase {
+	if len(cases) == 0 {
+		return nil
+	}
+	result := make([]*flowv1.SwitchCase, len(cases))
+	for i, c := range cases {
+		result[i] = &flowv1.SwitchCase{
+			Name:       c.Name,
+			Expression: c.Expression,
+		}
+	}
+	return result
+}
+
+func protoToSwitchCases(cases []*flowv1.SwitchCase) []mflow.SwitchCase {
+	if len(cases) == 0 {
+		return nil
+	}
+	result := make([]mflow.SwitchCase, len(cases))
+	for i, c := range cases {
+		result[i] = mflow.SwitchCase{
+			Name:       c.GetName(),
+			Expression: c.GetExpression(),
+		}
+	}
+	return result
+}
@@ -0,0 +1,445 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeWebhookTriggerWithFlow struct {
+	nodeWebhookTrigger mflow.NodeWebhookTrigger
+	flowID             idwrap.IDWrap
+	baseNode           *mflow.Node
+}
+
+func (s *FlowServiceV2RPC) NodeWebhookTriggerCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeWebhookTriggerCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeWebhookTrigger
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_WEBHOOK_TRIGGER {
+				continue
+			}
+			nodeWebhookTrigger, err := s.nwebhooks.GetNodeWebhookTrigger(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeWebhookTrigger == nil {
+				continue
+			}
+			items = append(items, serializeNodeWebhookTrigger(*nodeWebhookTrigger))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeWebhookTriggerCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeWebhookTriggerInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeWebhookTriggerInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeWebhookTrigger mflow.NodeWebhookTrigger
+		baseNode           *mflow.Node
+		flowID             idwrap.IDWrap
+		workspaceID        idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeWebhookTrigger: mflow.NodeWebhookTrigger{
+				FlowNodeID:      nodeID,
+				Path:            item.GetPath(),
+				Method:          item.GetMethod(),
+				Secret:          item.GetSecret(),
+				SignatureHeader: item.GetSignatureHeader(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nwebhooksWriter := s.nwebhooks.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeWebhookTrigger := data.nodeWebhookTrigger
+
+		if err := nwebhooksWriter.CreateNodeWebhookTrigger(ctx, nodeWebhookTrigger); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeWebhookTrigger,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeWebhookTrigger.FlowNodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeWebhookTriggerWithFlow{
+					nodeWebhookTrigger: nodeWebhookTrigger,
+					flowID:             data.flowID,
+					baseNode:           data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeWebhookTriggerUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeWebhookTriggerUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID      idwrap.IDWrap
+		updated     mflow.NodeWebhookTrigger
+		baseNode    *mflow.Node
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		existing, err := s.nwebhooks.GetNodeWebhookTrigger(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("node %s does not have WEBHOOK_TRIGGER config", nodeID.String()))
+		}
+
+		if item.Path != nil {
+			existing.Path = item.GetPath()
+		}
+		if item.Method != nil {
+			existing.Method = item.GetMethod()
+		}
+		if item.Secret != nil {
+			existing.Secret = item.GetSecret()
+		}
+		if item.SignatureHeader != nil {
+			existing.SignatureHeader = item.GetSignatureHeader()
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:      nodeID,
+			updated:     *existing,
+			baseNode:    nodeModel,
+			workspaceID: flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nwebhooksWriter := s.nwebhooks.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeWebhookTrigger := data.updated
+
+		if err := nwebhooksWriter.UpdateNodeWebhookTrigger(ctx, nodeWebhookTrigger); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeWebhookTrigger,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeWebhookTriggerWithFlow{
+				nodeWebhookTrigger: nodeWebhookTrigger,
+				flowID:             data.baseNode.FlowID,
+				baseNode:           data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeWebhookTriggerDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeWebhookTriggerDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeWebhookTrigger,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeWebhookTrigger(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeWebhookTriggerSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeWebhookTriggerSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeWebhookTriggerSync(ctx, func(resp *flowv1.NodeWebhookTriggerSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeWebhookTriggerSync(
+	ctx context.Context,
+	send func(*flowv1.NodeWebhookTriggerSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeWebhookTriggerEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert webhook trigger node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeWebhookTriggerEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeWebhookTriggerSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_WEBHOOK_TRIGGER {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeWebhookTrigger, err := s.nwebhooks.GetNodeWebhookTrigger(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeWebhookTriggerSync
+	switch evt.Type {
+	case nodeEventInsert:
+		if nodeWebhookTrigger == nil {
+			return nil, nil
+		}
+		syncEvent = &flowv1.NodeWebhookTriggerSync{
+			Value: &flowv1.NodeWebhookTriggerSync_ValueUnion{
+				Kind: flowv1.NodeWebhookTriggerSync_ValueUnion_KIND_INSERT,
+				Insert: &flowv1.NodeWebhookTriggerSyncInsert{
+					NodeId:          nodeID.Bytes(),
+					Path:            nodeWebhookTrigger.Path,
+					Method:          nodeWebhookTrigger.Method,
+					Secret:          nodeWebhookTrigger.Secret,
+					SignatureHeader: nodeWebhookTrigger.SignatureHeader,
+				},
+			},
+		}
+	case nodeEventUpdate:
+		if nodeWebhookTrigger == nil {
+			return nil, nil
+		}
+		syncEvent = &flowv1.NodeWebhookTriggerSync{
+			Value: &flowv1.NodeWebhookTriggerSync_ValueUnion{
+				Kind: flowv1.NodeWebhookTriggerSync_ValueUnion_KIND_UPDATE,
+				Update: &flowv1.NodeWebhookTriggerSyncUpdate{
+					NodeId:          nodeID.Bytes(),
+					Path:            &nodeWebhookTrigger.Path,
+					Method:          &nodeWebhookTrigger.Method,
+					Secret:          &nodeWebhookTrigger.Secret,
+					SignatureHeader: &nodeWebhookTrigger.SignatureHeader,
+				},
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeWebhookTriggerSync{
+			Value: &flowv1.NodeWebhookTriggerSync_ValueUnion{
+				Kind: flowv1.NodeWebhookTriggerSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeWebhookTriggerSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeWebhookTriggerSyncResponse{
+		Items: []*flowv1.NodeWebhookTriggerSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeWebhookTrigger(n mflow.NodeWebhookTrigger) *flowv1.NodeWebhookTrigger {
+	return &flowv1.NodeWebhookTrigger{
+		NodeId:          n.FlowNodeID.Bytes(),
+		Path:            n.Path,
+		Method:          n.Method,
+		Secret:          n.Secret,
+		SignatureHeader: n.SignatureHeader,
+	}
+}
@@ -0,0 +1,494 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+)
+
+type nodeWsExpectWithFlow struct {
+	nodeWsExpect mflow.NodeWsExpect
+	flowID       idwrap.IDWrap
+	baseNode     *mflow.Node
+}
+
+// NodeWsExpectTopic identifies the flow whose WS Expect nodes are being published.
+type NodeWsExpectTopic struct {
+	FlowID idwrap.IDWrap
+}
+
+// NodeWsExpectEvent describes a WS Expect node change for sync streaming.
+type NodeWsExpectEvent struct {
+	Type   string
+	FlowID idwrap.IDWrap
+	Node   *flowv1.NodeWsExpect
+}
+
+func (s *FlowServiceV2RPC) NodeWsExpectCollection(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[flowv1.NodeWsExpectCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.NodeWsExpect
+	for _, flow := range flows {
+		nodes, err := s.nsReader.GetNodesByFlowID(ctx, flow.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, node := range nodes {
+			if node.NodeKind != mflow.NODE_KIND_WS_EXPECT {
+				continue
+			}
+			nodeWsExpect, err := s.nwes.GetNodeWsExpect(ctx, node.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			if nodeWsExpect == nil {
+				continue
+			}
+			items = append(items, serializeNodeWsExpect(*nodeWsExpect))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.NodeWsExpectCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeWsExpectInsert(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeWsExpectInsertRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		nodeID       idwrap.IDWrap
+		nodeWsExpect mflow.NodeWsExpect
+		baseNode     *mflow.Node
+		flowID       idwrap.IDWrap
+		workspaceID  idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		baseNode, _ := s.ns.GetNode(ctx, nodeID)
+
+		var flowID idwrap.IDWrap
+		var workspaceID idwrap.IDWrap
+		if baseNode != nil {
+			flowID = baseNode.FlowID
+			flow, err := s.fsReader.GetFlow(ctx, flowID)
+			if err == nil {
+				workspaceID = flow.WorkspaceID
+			}
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			nodeID: nodeID,
+			nodeWsExpect: mflow.NodeWsExpect{
+				FlowNodeID:           nodeID,
+				WsConnectionNodeName: item.GetWsConnectionNodeName(),
+				Message:              item.GetMessage(),
+				Binary:               item.GetBinary(),
+				Match:                item.GetMatch(),
+				TimeoutMs:            item.GetTimeoutMs(),
+				Count:                item.GetCount(),
+				Assertions:           item.GetAssertions(),
+				Ping:                 item.GetPing(),
+				ExpectClose:          item.GetExpectClose(),
+			},
+			baseNode:    baseNode,
+			flowID:      flowID,
+			workspaceID: workspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nwesWriter := s.nwes.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeWsExpect := data.nodeWsExpect
+
+		if err := nwesWriter.CreateNodeWsExpect(ctx, nodeWsExpect); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if data.baseNode != nil {
+			mut.Track(mutation.Event{
+				Entity:      mutation.EntityFlowNodeWsExpect,
+				Op:          mutation.OpInsert,
+				ID:          data.nodeID,
+				WorkspaceID: data.workspaceID,
+				ParentID:    data.flowID,
+				Payload: nodeWsExpectWithFlow{
+					nodeWsExpect: nodeWsExpect,
+					flowID:       data.flowID,
+					baseNode:     data.baseNode,
+				},
+			})
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeWsExpectUpdate(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeWsExpectUpdateRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		nodeID       idwrap.IDWrap
+		nodeWsExpect mflow.NodeWsExpect
+		baseNode     *mflow.Node
+		workspaceID  idwrap.IDWrap
+	}
+	var validatedItems []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, nodeModel.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		// Get existing values to merge partial updates
+		existing, err := s.nwes.GetNodeWsExpect(ctx, nodeID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if existing == nil {
+			existing = &mflow.NodeWsExpect{FlowNodeID: nodeID}
+		}
+		if item.WsConnectionNodeName != nil {
+			existing.WsConnectionNodeName = *item.WsConnectionNodeName
+		}
+		if item.Message != nil {
+			existing.Message = *item.Message
+		}
+		if item.Binary != nil {
+			existing.Binary = *item.Binary
+		}
+		if item.Match != nil {
+			existing.Match = *item.Match
+		}
+		if item.TimeoutMs != nil {
+			existing.TimeoutMs = *item.TimeoutMs
+		}
+		if item.Count != nil {
+			existing.Count = *item.Count
+		}
+		if item.Assertions != nil {
+			existing.Assertions = item.Assertions
+		}
+		if item.Ping != nil {
+			existing.Ping = *item.Ping
+		}
+		if item.ExpectClose != nil {
+			existing.ExpectClose = *item.ExpectClose
+		}
+
+		validatedItems = append(validatedItems, updateData{
+			nodeID:       nodeID,
+			nodeWsExpect: *existing,
+			baseNode:     nodeModel,
+			workspaceID:  flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	nwesWriter := s.nwes.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		nodeWsExpect := data.nodeWsExpect
+
+		if err := nwesWriter.UpdateNodeWsExpect(ctx, nodeWsExpect); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowNodeWsExpect,
+			Op:          mutation.OpUpdate,
+			ID:          data.nodeID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.baseNode.FlowID,
+			Payload: nodeWsExpectWithFlow{
+				nodeWsExpect: nodeWsExpect,
+				flowID:       data.baseNode.FlowID,
+				baseNode:     data.baseNode,
+			},
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeWsExpectDelete(
+	ctx context.Context,
+	req *connect.Request[flowv1.NodeWsExpectDeleteRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		nodeID idwrap.IDWrap
+		flowID idwrap.IDWrap
+	}
+	var validatedItems []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		nodeID, err := idwrap.NewFromBytes(item.GetNodeId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid node id: %w", err))
+		}
+
+		nodeModel, err := s.ensureNodeAccess(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedItems = append(validatedItems, deleteData{
+			nodeID: nodeID,
+			flowID: nodeModel.FlowID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedItems {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowNodeWsExpect,
+			Op:       mutation.OpDelete,
+			ID:       data.nodeID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowNodeWsExpect(ctx, data.nodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) NodeWsExpectSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.NodeWsExpectSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamNodeWsExpectSync(ctx, func(resp *flowv1.NodeWsExpectSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamNodeWsExpectSync(
+	ctx context.Context,
+	send func(*flowv1.NodeWsExpectSyncResponse) error,
+) error {
+	if s.nodeStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("node stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic NodeTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.nodeStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp, err := s.nodeWsExpectEventToSyncResponse(ctx, evt.Payload)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert WS expect node event: %w", err))
+			}
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) nodeWsExpectEventToSyncResponse(
+	ctx context.Context,
+	evt NodeEvent,
+) (*flowv1.NodeWsExpectSyncResponse, error) {
+	if evt.Node == nil {
+		return nil, nil
+	}
+
+	if evt.Node.GetKind() != flowv1.NodeKind_NODE_KIND_WS_EXPECT {
+		return nil, nil
+	}
+
+	nodeID, err := idwrap.NewFromBytes(evt.Node.GetNodeId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid node id: %w", err)
+	}
+
+	nodeWsExpect, err := s.nwes.GetNodeWsExpect(ctx, nodeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var syncEvent *flowv1.NodeWsExpectSync
+	switch evt.Type {
+	case nodeEventInsert:
+		insert := &flowv1.NodeWsExpectSyncInsert{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeWsExpect != nil {
+			insert.WsConnectionNodeName = nodeWsExpect.WsConnectionNodeName
+			insert.Message = nodeWsExpect.Message
+			insert.Binary = nodeWsExpect.Binary
+			insert.Match = nodeWsExpect.Match
+			insert.TimeoutMs = nodeWsExpect.TimeoutMs
+			insert.Count = nodeWsExpect.Count
+			insert.Assertions = nodeWsExpect.Assertions
+			insert.Ping = nodeWsExpect.Ping
+			insert.ExpectClose = nodeWsExpect.ExpectClose
+		}
+		syncEvent = &flowv1.NodeWsExpectSync{
+			Value: &flowv1.NodeWsExpectSync_ValueUnion{
+				Kind:   flowv1.NodeWsExpectSync_ValueUnion_KIND_INSERT,
+				Insert: insert,
+			},
+		}
+	case nodeEventUpdate:
+		update := &flowv1.NodeWsExpectSyncUpdate{
+			NodeId: nodeID.Bytes(),
+		}
+		if nodeWsExpect != nil {
+			update.WsConnectionNodeName = &nodeWsExpect.WsConnectionNodeName
+			update.Message = &nodeWsExpect.Message
+			update.Binary = &nodeWsExpect.Binary
+			update.Match = &nodeWsExpect.Match
+			update.TimeoutMs = &nodeWsExpect.TimeoutMs
+			update.Count = &nodeWsExpect.Count
+			update.Assertions = nodeWsExpect.Assertions
+			update.Ping = &nodeWsExpect.Ping
+			update.ExpectClose = &nodeWsExpect.ExpectClose
+		}
+		syncEvent = &flowv1.NodeWsExpectSync{
+			Value: &flowv1.NodeWsExpectSync_ValueUnion{
+				Kind:   flowv1.NodeWsExpectSync_ValueUnion_KIND_UPDATE,
+				Update: update,
+			},
+		}
+	case nodeEventDelete:
+		syncEvent = &flowv1.NodeWsExpectSync{
+			Value: &flowv1.NodeWsExpectSync_ValueUnion{
+				Kind: flowv1.NodeWsExpectSync_ValueUnion_KIND_DELETE,
+				Delete: &flowv1.NodeWsExpectSyncDelete{
+					NodeId: nodeID.Bytes(),
+				},
+			},
+		}
+	default:
+		return nil, nil
+	}
+
+	return &flowv1.NodeWsExpectSyncResponse{
+		Items: []*flowv1.NodeWsExpectSync{syncEvent},
+	}, nil
+}
+
+func serializeNodeWsExpect(n mflow.NodeWsExpect) *flowv1.NodeWsExpect {
+	return &flowv1.NodeWsExpect{
+		NodeId:               n.FlowNodeID.Bytes(),
+		WsConnectionNodeName: n.WsConnectionNodeName,
+		Message:              n.Message,
+		Binary:               n.Binary,
+		Match:                n.Match,
+		TimeoutMs:            n.TimeoutMs,
+		Count:                n.Count,
+		Assertions:           n.Assertions,
+		Ping:                 n.Ping,
+		ExpectClose:          n.ExpectClose,
+	}
+}
@@ -73,6 +73,9 @@ func (s *FlowServiceV2RPC) NodeWsSendInsert(
 		nodeID               idwrap.IDWrap
 		wsConnectionNodeName string
 		message              string
+		binary               bool
+		event                string
+		ack                  bool
 		baseNode             *mflow.Node
 		flowID               idwrap.IDWrap
 		workspaceID          idwrap.IDWrap
@@ -101,6 +104,9 @@ func (s *FlowServiceV2RPC) NodeWsSendInsert(
 			nodeID:               nodeID,
 			wsConnectionNodeName: item.GetWsConnectionNodeName(),
 			message:              item.GetMessage(),
+			binary:               item.GetBinary(),
+			event:                item.GetEvent(),
+			ack:                  item.GetAck(),
 			baseNode:             baseNode,
 			flowID:               flowID,
 			workspaceID:          workspaceID,
@@ -124,6 +130,9 @@ func (s *FlowServiceV2RPC) NodeWsSendInsert(
 			FlowNodeID:           data.nodeID,
 			WsConnectionNodeName: data.wsConnectionNodeName,
 			Message:              data.message,
+			Binary:               data.binary,
+			Event:                data.event,
+			Ack:                  data.ack,
 		}
 
 		if err := nwssWriter.CreateNodeWsSend(ctx, nodeWsSend); err != nil {
@@ -161,6 +170,9 @@ func (s *FlowServiceV2RPC) NodeWsSendUpdate(
 		nodeID               idwrap.IDWrap
 		wsConnectionNodeName string
 		message              string
+		binary               bool
+		event                string
+		ack                  bool
 		baseNode             *mflow.Node
 		workspaceID          idwrap.IDWrap
 	}
@@ -196,11 +208,26 @@ func (s *FlowServiceV2RPC) NodeWsSendUpdate(
 		if item.Message != nil {
 			msg = *item.Message
 		}
+		binary := existing.Binary
+		if item.Binary != nil {
+			binary = *item.Binary
+		}
+		event := existing.Event
+		if item.Event != nil {
+			event = *item.Event
+		}
+		ack := existing.Ack
+		if item.Ack != nil {
+			ack = *item.Ack
+		}
 
 		validatedItems = append(validatedItems, updateData{
 			nodeID:               nodeID,
 			wsConnectionNodeName: wsConnName,
 			message:              msg,
+			binary:               binary,
+			event:                event,
+			ack:                  ack,
 			baseNode:             nodeModel,
 			workspaceID:          flow.WorkspaceID,
 		})
@@ -223,6 +250,9 @@ func (s *FlowServiceV2RPC) NodeWsSendUpdate(
 			FlowNodeID:           data.nodeID,
 			WsConnectionNodeName: data.wsConnectionNodeName,
 			Message:              data.message,
+			Binary:               data.binary,
+			Event:                data.event,
+			Ack:                  data.ack,
 		}
 
 		if err := nwssWriter.UpdateNodeWsSend(ctx, nodeWsSend); err != nil {
@@ -398,6 +428,9 @@ func (s *FlowServiceV2RPC) nodeWsSendEventToSyncResponse(
 		if nodeWsSend != nil {
 			insert.WsConnectionNodeName = nodeWsSend.WsConnectionNodeName
 			insert.Message = nodeWsSend.Message
+			insert.Binary = nodeWsSend.Binary
+			insert.Event = nodeWsSend.Event
+			insert.Ack = nodeWsSend.Ack
 		}
 		syncEvent = &flowv1.NodeWsSendSync{
 			Value: &flowv1.NodeWsSendSync_ValueUnion{
@@ -412,6 +445,9 @@ func (s *FlowServiceV2RPC) nodeWsSendEventToSyncResponse(
 		if nodeWsSend != nil {
 			update.WsConnectionNodeName = &nodeWsSend.WsConnectionNodeName
 			update.Message = &nodeWsSend.Message
+			update.Binary = &nodeWsSend.Binary
+			update.Event = &nodeWsSend.Event
+			update.Ack = &nodeWsSend.Ack
 		}
 		syncEvent = &flowv1.NodeWsSendSync{
 			Value: &flowv1.NodeWsSendSync_ValueUnion{
@@ -442,5 +478,8 @@ func serializeNodeWsSend(n mflow.NodeWsSend) *flowv1.NodeWsSend {
 		NodeId:               n.FlowNodeID.Bytes(),
 		WsConnectionNodeName: n.WsConnectionNodeName,
 		Message:              n.Message,
+		Binary:               n.Binary,
+		Event:                n.Event,
+		Ack:                  n.Ack,
 	}
 }
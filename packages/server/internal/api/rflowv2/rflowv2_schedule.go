@@ -0,0 +1,729 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/rlog"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowschedule"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sflow"
+	flowv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/flow/v1"
+	logv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/log/v1"
+	globalv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/global/v1"
+)
+
+// The service runs scheduled flows and reports on them.
+var (
+	_ flowschedule.Executor = (*FlowServiceV2RPC)(nil)
+	_ flowschedule.Notifier = (*FlowServiceV2RPC)(nil)
+)
+
+// SetScheduler sets the scheduler that runs the flow schedules, so that it
+// picks up schedules as they are changed.
+func (s *FlowServiceV2RPC) SetScheduler(scheduler *flowschedule.Scheduler) {
+	s.scheduler = scheduler
+}
+
+func (s *FlowServiceV2RPC) reloadSchedules() {
+	if s.scheduler != nil {
+		s.scheduler.Reload()
+	}
+}
+
+func (s *FlowServiceV2RPC) FlowScheduleCollection(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[flowv1.FlowScheduleCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.FlowSchedule
+	for _, flow := range flows {
+		schedules, err := s.fss.GetFlowSchedulesByFlowID(ctx, flow.ID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, schedule := range schedules {
+			items = append(items, serializeFlowSchedule(schedule))
+		}
+	}
+
+	return connect.NewResponse(&flowv1.FlowScheduleCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) FlowScheduleInsert(ctx context.Context, req *connect.Request[flowv1.FlowScheduleInsertRequest]) (*connect.Response[emptypb.Empty], error) {
+	type insertData struct {
+		schedule    mflow.FlowSchedule
+		workspaceID idwrap.IDWrap
+	}
+	var validatedItems []insertData
+
+	for _, item := range req.Msg.GetItems() {
+		if len(item.GetFlowId()) == 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("flow id is required"))
+		}
+
+		flowID, err := idwrap.NewFromBytes(item.GetFlowId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid flow id: %w", err))
+		}
+
+		if err := s.ensureFlowAccess(ctx, flowID); err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, flowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		scheduleID := idwrap.NewNow()
+		if len(item.GetFlowScheduleId()) != 0 {
+			scheduleID, err = idwrap.NewFromBytes(item.GetFlowScheduleId())
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid flow schedule id: %w", err))
+			}
+		}
+
+		schedule := mflow.FlowSchedule{
+			ID:              scheduleID,
+			FlowID:          flowID,
+			CronExpression:  item.GetCronExpression(),
+			IntervalSeconds: item.GetIntervalSeconds(),
+			Enabled:         item.GetEnabled(),
+		}
+		if len(item.GetEnvironmentId()) != 0 {
+			envID, err := s.scheduleEnvironmentID(ctx, item.GetEnvironmentId(), flow.WorkspaceID)
+			if err != nil {
+				return nil, err
+			}
+			schedule.EnvironmentID = envID
+		}
+		if err := flowschedule.Validate(schedule); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		validatedItems = append(validatedItems, insertData{
+			schedule:    schedule,
+			workspaceID: flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedItems) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	scheduleWriter := s.fss.TX(mut.TX())
+
+	for _, data := range validatedItems {
+		if err := scheduleWriter.CreateFlowSchedule(ctx, data.schedule); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowSchedule,
+			Op:          mutation.OpInsert,
+			ID:          data.schedule.ID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.schedule.FlowID,
+			Payload:     data.schedule,
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	s.reloadSchedules()
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) FlowScheduleUpdate(ctx context.Context, req *connect.Request[flowv1.FlowScheduleUpdateRequest]) (*connect.Response[emptypb.Empty], error) {
+	type updateData struct {
+		schedule    mflow.FlowSchedule
+		workspaceID idwrap.IDWrap
+	}
+	var validatedUpdates []updateData
+
+	for _, item := range req.Msg.GetItems() {
+		if len(item.GetFlowScheduleId()) == 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("flow schedule id is required"))
+		}
+
+		scheduleID, err := idwrap.NewFromBytes(item.GetFlowScheduleId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid flow schedule id: %w", err))
+		}
+
+		schedule, err := s.fss.GetFlowSchedule(ctx, scheduleID)
+		if err != nil {
+			if errors.Is(err, sflow.ErrNoFlowScheduleFound) {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("flow schedule %s not found", scheduleID.String()))
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if err := s.ensureFlowAccess(ctx, schedule.FlowID); err != nil {
+			return nil, err
+		}
+
+		flow, err := s.fsReader.GetFlow(ctx, schedule.FlowID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if item.CronExpression != nil {
+			schedule.CronExpression = item.GetCronExpression()
+		}
+		if item.IntervalSeconds != nil {
+			schedule.IntervalSeconds = item.GetIntervalSeconds()
+		}
+		if item.Enabled != nil {
+			schedule.Enabled = item.GetEnabled()
+		}
+		if envUnion := item.GetEnvironmentId(); envUnion != nil {
+			schedule.EnvironmentID = nil
+			if envUnion.GetKind() == flowv1.FlowScheduleUpdate_EnvironmentIdUnion_KIND_VALUE && len(envUnion.GetValue()) > 0 {
+				envID, err := s.scheduleEnvironmentID(ctx, envUnion.GetValue(), flow.WorkspaceID)
+				if err != nil {
+					return nil, err
+				}
+				schedule.EnvironmentID = envID
+			}
+		}
+		if err := flowschedule.Validate(schedule); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		validatedUpdates = append(validatedUpdates, updateData{
+			schedule:    schedule,
+			workspaceID: flow.WorkspaceID,
+		})
+	}
+
+	if len(validatedUpdates) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	scheduleWriter := s.fss.TX(mut.TX())
+
+	for _, data := range validatedUpdates {
+		if err := scheduleWriter.UpdateFlowSchedule(ctx, data.schedule); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		mut.Track(mutation.Event{
+			Entity:      mutation.EntityFlowSchedule,
+			Op:          mutation.OpUpdate,
+			ID:          data.schedule.ID,
+			WorkspaceID: data.workspaceID,
+			ParentID:    data.schedule.FlowID,
+			Payload:     data.schedule,
+		})
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	s.reloadSchedules()
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *FlowServiceV2RPC) FlowScheduleDelete(ctx context.Context, req *connect.Request[flowv1.FlowScheduleDeleteRequest]) (*connect.Response[emptypb.Empty], error) {
+	type deleteData struct {
+		scheduleID idwrap.IDWrap
+		flowID     idwrap.IDWrap
+	}
+	var validatedDeletes []deleteData
+
+	for _, item := range req.Msg.GetItems() {
+		scheduleID, err := idwrap.NewFromBytes(item.GetFlowScheduleId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid flow schedule id: %w", err))
+		}
+
+		schedule, err := s.fss.GetFlowSchedule(ctx, scheduleID)
+		if err != nil {
+			if errors.Is(err, sflow.ErrNoFlowScheduleFound) {
+				continue
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		if err := s.ensureFlowAccess(ctx, schedule.FlowID); err != nil {
+			return nil, err
+		}
+
+		validatedDeletes = append(validatedDeletes, deleteData{
+			scheduleID: scheduleID,
+			flowID:     schedule.FlowID,
+		})
+	}
+
+	if len(validatedDeletes) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mut := mutation.New(s.DB, mutation.WithPublisher(s.mutationPublisher()))
+	if err := mut.Begin(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer mut.Rollback()
+
+	for _, data := range validatedDeletes {
+		mut.Track(mutation.Event{
+			Entity:   mutation.EntityFlowSchedule,
+			Op:       mutation.OpDelete,
+			ID:       data.scheduleID,
+			ParentID: data.flowID,
+		})
+		if err := mut.Queries().DeleteFlowSchedule(ctx, data.scheduleID); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	s.reloadSchedules()
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// scheduleEnvironmentID parses the environment a schedule runs with and
+// checks that it belongs to the flow's workspace.
+func (s *FlowServiceV2RPC) scheduleEnvironmentID(ctx context.Context, envIDBytes []byte, workspaceID idwrap.IDWrap) (*idwrap.IDWrap, error) {
+	envID, err := idwrap.NewFromBytes(envIDBytes)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid environment id: %w", err))
+	}
+	env, err := s.envs.GetEnvironment(ctx, envID)
+	if err != nil || env.WorkspaceID != workspaceID {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("environment %s is not in the flow's workspace", envID.String()))
+	}
+	return &envID, nil
+}
+
+func (s *FlowServiceV2RPC) FlowScheduleSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.FlowScheduleSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamFlowScheduleSync(ctx, func(resp *flowv1.FlowScheduleSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamFlowScheduleSync(
+	ctx context.Context,
+	send func(*flowv1.FlowScheduleSyncResponse) error,
+) error {
+	if s.scheduleStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("flow schedule stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic FlowScheduleTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.scheduleStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp := flowScheduleEventToSyncResponse(evt.Payload)
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *FlowServiceV2RPC) FlowScheduleRunCollection(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[flowv1.FlowScheduleRunCollectionResponse], error) {
+	flows, err := s.listAccessibleFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*flowv1.FlowScheduleRun
+	for _, flow := range flows {
+		schedules, err := s.fss.GetFlowSchedulesByFlowID(ctx, flow.ID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, schedule := range schedules {
+			runs, err := s.fss.GetFlowScheduleRuns(ctx, schedule.ID)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			for _, run := range runs {
+				items = append(items, serializeFlowScheduleRun(run))
+			}
+		}
+	}
+
+	return connect.NewResponse(&flowv1.FlowScheduleRunCollectionResponse{Items: items}), nil
+}
+
+func (s *FlowServiceV2RPC) FlowScheduleRunSync(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+	stream *connect.ServerStream[flowv1.FlowScheduleRunSyncResponse],
+) error {
+	if stream == nil {
+		return connect.NewError(connect.CodeInternal, errors.New("stream is required"))
+	}
+	return s.streamFlowScheduleRunSync(ctx, func(resp *flowv1.FlowScheduleRunSyncResponse) error {
+		return stream.Send(resp)
+	})
+}
+
+func (s *FlowServiceV2RPC) streamFlowScheduleRunSync(
+	ctx context.Context,
+	send func(*flowv1.FlowScheduleRunSyncResponse) error,
+) error {
+	if s.scheduleRunStream == nil {
+		return connect.NewError(connect.CodeUnavailable, errors.New("flow schedule run stream not configured"))
+	}
+
+	var flowSet sync.Map
+
+	filter := func(topic FlowScheduleRunTopic) bool {
+		if _, ok := flowSet.Load(topic.FlowID.String()); ok {
+			return true
+		}
+		if err := s.ensureFlowAccess(ctx, topic.FlowID); err != nil {
+			return false
+		}
+		flowSet.Store(topic.FlowID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.scheduleRunStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp := flowScheduleRunEventToSyncResponse(evt.Payload)
+			if resp == nil {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// RunScheduled runs the flow of a schedule with the schedule's environment and
+// waits for it to finish. It implements flowschedule.Executor.
+func (s *FlowServiceV2RPC) RunScheduled(ctx context.Context, schedule mflow.FlowSchedule) flowschedule.RunResult {
+	s.runningFlowsMu.Lock()
+	_, running := s.runningFlows[schedule.FlowID.String()]
+	s.runningFlowsMu.Unlock()
+	if running {
+		return flowschedule.RunResult{Err: flowschedule.ErrAlreadyRunning}
+	}
+
+	opts := flowRunOptions{done: make(chan error, 1)}
+	if schedule.EnvironmentID != nil {
+		opts.environmentID = *schedule.EnvironmentID
+	}
+	done := opts.done
+
+	version, err := s.launchFlowRun(ctx, schedule.FlowID, opts)
+	if err != nil {
+		return flowschedule.RunResult{Err: err}
+	}
+	result := flowschedule.RunResult{FlowVersionID: &version.ID}
+
+	select {
+	case result.Err = <-done:
+	case <-ctx.Done():
+		// The server is shutting down: stop the run and wait for it to
+		// record its results.
+		s.runningFlowsMu.Lock()
+		cancel, ok := s.runningFlows[schedule.FlowID.String()]
+		s.runningFlowsMu.Unlock()
+		if ok {
+			cancel()
+		}
+		result.Err = <-done
+	}
+	return result
+}
+
+// RunRecorded publishes a scheduled run and the schedule's new state. It
+// implements flowschedule.Notifier.
+func (s *FlowServiceV2RPC) RunRecorded(_ context.Context, schedule mflow.FlowSchedule, run mflow.FlowScheduleRun) {
+	if s.scheduleStream != nil {
+		s.scheduleStream.Publish(FlowScheduleTopic{FlowID: schedule.FlowID}, FlowScheduleEvent{
+			Type:     scheduleEventUpdate,
+			FlowID:   schedule.FlowID,
+			Schedule: schedule,
+		})
+	}
+	if s.scheduleRunStream != nil {
+		s.scheduleRunStream.Publish(FlowScheduleRunTopic{FlowID: schedule.FlowID}, FlowScheduleRunEvent{
+			Type:   scheduleEventInsert,
+			FlowID: schedule.FlowID,
+			Run:    run,
+		})
+	}
+}
+
+// StateChanged logs that a scheduled flow started failing or recovered. It
+// implements flowschedule.Notifier.
+func (s *FlowServiceV2RPC) StateChanged(ctx context.Context, schedule mflow.FlowSchedule, run mflow.FlowScheduleRun, _ mflow.NodeState) {
+	flowName := schedule.FlowID.String()
+	if flow, err := s.fsReader.GetFlow(ctx, schedule.FlowID); err == nil {
+		flowName = flow.Name
+	}
+
+	msg := fmt.Sprintf("Scheduled flow %q recovered", flowName)
+	level := logv1.LogLevel_LOG_LEVEL_UNSPECIFIED
+	data := map[string]any{
+		"flow_id":     schedule.FlowID.String(),
+		"schedule_id": schedule.ID.String(),
+		"duration_ms": run.DurationMs,
+	}
+	if run.State == mflow.NODE_STATE_FAILURE {
+		msg = fmt.Sprintf("Scheduled flow %q is failing", flowName)
+		level = logv1.LogLevel_LOG_LEVEL_ERROR
+		if run.Error != nil {
+			data["error"] = *run.Error
+		}
+	}
+	s.logger.Info(msg, "flow_id", schedule.FlowID.String(), "schedule_id", schedule.ID.String())
+
+	if s.logStream == nil {
+		return
+	}
+	val, err := rlog.NewLogValue(data)
+	if err != nil {
+		s.logger.Error("failed to build schedule log value", "error", err)
+		return
+	}
+	s.logStream.Publish(rlog.LogTopic{}, rlog.LogEvent{
+		Type: rlog.EventTypeInsert,
+		Log: &logv1.Log{
+			LogId: idwrap.NewMonotonic().Bytes(),
+			Name:  msg,
+			Level: level,
+			Value: val,
+		},
+	})
+}
+
+func (p *rflowPublisher) publishSchedule(evt mutation.Event) {
+	if p.scheduleStream == nil {
+		return
+	}
+	var schedule mflow.FlowSchedule
+	var eventType string
+
+	switch evt.Op {
+	case mutation.OpInsert:
+		eventType = scheduleEventInsert
+		if v, ok := evt.Payload.(mflow.FlowSchedule); ok {
+			schedule = v
+		}
+	case mutation.OpUpdate:
+		eventType = scheduleEventUpdate
+		if v, ok := evt.Payload.(mflow.FlowSchedule); ok {
+			schedule = v
+		}
+	case mutation.OpDelete:
+		eventType = scheduleEventDelete
+		schedule = mflow.FlowSchedule{ID: evt.ID, FlowID: evt.ParentID}
+	}
+
+	p.scheduleStream.Publish(FlowScheduleTopic{FlowID: evt.ParentID}, FlowScheduleEvent{
+		Type:     eventType,
+		FlowID:   evt.ParentID,
+		Schedule: schedule,
+	})
+}
+
+func serializeFlowSchedule(schedule mflow.FlowSchedule) *flowv1.FlowSchedule {
+	msg := &flowv1.FlowSchedule{
+		FlowScheduleId:  schedule.ID.Bytes(),
+		FlowId:          schedule.FlowID.Bytes(),
+		CronExpression:  schedule.CronExpression,
+		IntervalSeconds: schedule.IntervalSeconds,
+		Enabled:         schedule.Enabled,
+		LastState:       flowv1.FlowItemState(schedule.LastState),
+	}
+	if schedule.EnvironmentID != nil {
+		msg.EnvironmentId = schedule.EnvironmentID.Bytes()
+	}
+	if schedule.LastRunAt != nil {
+		msg.LastRunAt = timestamppb.New(time.UnixMilli(*schedule.LastRunAt))
+	}
+	return msg
+}
+
+func serializeFlowScheduleRun(run mflow.FlowScheduleRun) *flowv1.FlowScheduleRun {
+	msg := &flowv1.FlowScheduleRun{
+		FlowScheduleRunId: run.ID.Bytes(),
+		FlowScheduleId:    run.ScheduleID.Bytes(),
+		State:             flowv1.FlowItemState(run.State),
+		Error:             run.Error,
+		StartedAt:         timestamppb.New(time.UnixMilli(run.StartedAt)),
+		DurationMs:        run.DurationMs,
+	}
+	if run.FlowVersionID != nil {
+		msg.FlowVersionId = run.FlowVersionID.Bytes()
+	}
+	return msg
+}
+
+func flowScheduleEventToSyncResponse(evt FlowScheduleEvent) *flowv1.FlowScheduleSyncResponse {
+	msg := serializeFlowSchedule(evt.Schedule)
+
+	switch evt.Type {
+	case scheduleEventInsert:
+		insert := &flowv1.FlowScheduleSyncInsert{
+			FlowScheduleId:  msg.FlowScheduleId,
+			FlowId:          msg.FlowId,
+			CronExpression:  msg.CronExpression,
+			IntervalSeconds: msg.IntervalSeconds,
+			EnvironmentId:   msg.EnvironmentId,
+			Enabled:         msg.Enabled,
+			LastRunAt:       msg.LastRunAt,
+			LastState:       msg.LastState,
+		}
+		return &flowv1.FlowScheduleSyncResponse{
+			Items: []*flowv1.FlowScheduleSync{{
+				Value: &flowv1.FlowScheduleSync_ValueUnion{
+					Kind:   flowv1.FlowScheduleSync_ValueUnion_KIND_INSERT,
+					Insert: insert,
+				},
+			}},
+		}
+	case scheduleEventUpdate:
+		update := &flowv1.FlowScheduleSyncUpdate{
+			FlowScheduleId:  msg.FlowScheduleId,
+			FlowId:          msg.FlowId,
+			CronExpression:  &msg.CronExpression,
+			IntervalSeconds: &msg.IntervalSeconds,
+			Enabled:         &msg.Enabled,
+			LastState:       &msg.LastState,
+		}
+		if msg.EnvironmentId != nil {
+			update.EnvironmentId = &flowv1.FlowScheduleSyncUpdate_EnvironmentIdUnion{
+				Kind:  flowv1.FlowScheduleSyncUpdate_EnvironmentIdUnion_KIND_VALUE,
+				Value: msg.EnvironmentId,
+			}
+		} else {
+			update.EnvironmentId = &flowv1.FlowScheduleSyncUpdate_EnvironmentIdUnion{
+				Kind:  flowv1.FlowScheduleSyncUpdate_EnvironmentIdUnion_KIND_UNSET,
+				Unset: globalv1.Unset_UNSET.Enum(),
+			}
+		}
+		if msg.LastRunAt != nil {
+			update.LastRunAt = &flowv1.FlowScheduleSyncUpdate_LastRunAtUnion{
+				Kind:  flowv1.FlowScheduleSyncUpdate_LastRunAtUnion_KIND_VALUE,
+				Value: msg.LastRunAt,
+			}
+		}
+		return &flowv1.FlowScheduleSyncResponse{
+			Items: []*flowv1.FlowScheduleSync{{
+				Value: &flowv1.FlowScheduleSync_ValueUnion{
+					Kind:   flowv1.FlowScheduleSync_ValueUnion_KIND_UPDATE,
+					Update: update,
+				},
+			}},
+		}
+	case scheduleEventDelete:
+		return &flowv1.FlowScheduleSyncResponse{
+			Items: []*flowv1.FlowScheduleSync{{
+				Value: &flowv1.FlowScheduleSync_ValueUnion{
+					Kind: flowv1.FlowScheduleSync_ValueUnion_KIND_DELETE,
+					Delete: &flowv1.FlowScheduleSyncDelete{
+						FlowScheduleId: msg.FlowScheduleId,
+					},
+				},
+			}},
+		}
+	default:
+		return nil
+	}
+}
+
+func flowScheduleRunEventToSyncResponse(evt FlowScheduleRunEvent) *flowv1.FlowScheduleRunSyncResponse {
+	if evt.Type != scheduleEventInsert {
+		return nil
+	}
+	msg := serializeFlowScheduleRun(evt.Run)
+	return &flowv1.FlowScheduleRunSyncResponse{
+		Items: []*flowv1.FlowScheduleRunSync{{
+			Value: &flowv1.FlowScheduleRunSync_ValueUnion{
+				Kind: flowv1.FlowScheduleRunSync_ValueUnion_KIND_INSERT,
+				Insert: &flowv1.FlowScheduleRunSyncInsert{
+					FlowScheduleRunId: msg.FlowScheduleRunId,
+					FlowScheduleId:    msg.FlowScheduleId,
+					FlowVersionId:     msg.FlowVersionId,
+					State:             msg.State,
+					Error:             msg.Error,
+					StartedAt:         msg.StartedAt,
+					DurationMs:        msg.DurationMs,
+				},
+			},
+		}},
+	}
+}
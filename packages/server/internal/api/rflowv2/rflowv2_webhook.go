@@ -0,0 +1,79 @@
+//nolint:revive // exported
+package rflowv2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwebhook"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// WebhookPathPrefix is the route webhook trigger nodes are served under.
+const WebhookPathPrefix = "/webhook/"
+
+var errWebhookFlowRunning = errors.New("flow is already running")
+
+// WebhookHandler serves inbound webhooks. It runs the flow whose webhook node
+// listens on the request path and replies with what the flow's return node
+// produced. Webhook routes carry no user authentication; a node's secret is
+// what authenticates its callers.
+func (s *FlowServiceV2RPC) WebhookHandler() http.Handler {
+	return http.HandlerFunc(s.serveWebhook)
+}
+
+func (s *FlowServiceV2RPC) serveWebhook(w http.ResponseWriter, r *http.Request) {
+	path := mflow.NormalizeWebhookPath(strings.TrimPrefix(r.URL.Path, WebhookPathPrefix))
+	if s.nwebhooks == nil {
+		nwebhook.WriteError(w, http.StatusNotFound, fmt.Errorf("no webhook listens on %s", path))
+		return
+	}
+
+	triggers, err := s.nwebhooks.GetNodeWebhookTriggersByPath(r.Context(), path)
+	if err != nil {
+		s.logger.Error("failed to look up webhook", "path", path, "error", err)
+		nwebhook.WriteError(w, http.StatusInternalServerError, errors.New("failed to look up webhook"))
+		return
+	}
+	switch {
+	case len(triggers) == 0:
+		nwebhook.WriteError(w, http.StatusNotFound, fmt.Errorf("no webhook listens on %s", path))
+		return
+	case len(triggers) > 1:
+		nwebhook.WriteError(w, http.StatusConflict, fmt.Errorf("webhook path %s is used by %d nodes", path, len(triggers)))
+		return
+	}
+	trigger := triggers[0]
+
+	ex, status, err := nwebhook.Accept(r, trigger.NodeWebhookTrigger)
+	if err != nil {
+		nwebhook.WriteError(w, status, err)
+		return
+	}
+
+	s.runningFlowsMu.Lock()
+	_, running := s.runningFlows[trigger.FlowID.String()]
+	s.runningFlowsMu.Unlock()
+	if running {
+		nwebhook.WriteError(w, http.StatusConflict, errWebhookFlowRunning)
+		return
+	}
+
+	done := make(chan error, 1)
+	if _, err := s.launchFlowRun(r.Context(), trigger.FlowID, flowRunOptions{webhook: ex, done: done}); err != nil {
+		s.logger.Error("failed to start webhook flow", "flow_id", trigger.FlowID.String(), "error", err)
+		nwebhook.WriteError(w, http.StatusInternalServerError, errors.New("failed to start flow"))
+		return
+	}
+
+	select {
+	case <-ex.Responded():
+		ex.WriteResponse(w, nil)
+	case runErr := <-done:
+		ex.WriteResponse(w, runErr)
+	case <-r.Context().Done():
+		// The caller went away; the run carries on without it.
+	}
+}
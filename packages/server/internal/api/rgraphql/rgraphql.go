@@ -123,6 +123,7 @@ type GraphQLServiceRPC struct {
 	headerService        sgraphql.GraphQLHeaderService
 	graphqlAssertService sgraphql.GraphQLAssertService
 	responseService      sgraphql.GraphQLResponseService
+	schemaService        *sgraphql.GraphQLSchemaService
 	resolver             GraphQLResolver
 
 	us         suser.UserService
@@ -162,6 +163,9 @@ type GraphQLServiceRPCServices struct {
 	Env           senv.EnvService
 	Variable      senv.VariableService
 	File          *sfile.FileService
+	// Schema caches introspected schemas; when set, runs are validated
+	// against the cached schema before they are sent.
+	Schema *sgraphql.GraphQLSchemaService
 }
 
 type GraphQLServiceRPCReaders struct {
@@ -192,6 +196,7 @@ func New(deps GraphQLServiceRPCDeps) GraphQLServiceRPC {
 		headerService:        deps.Services.Header,
 		graphqlAssertService: deps.Services.GraphQLAssert,
 		responseService:      deps.Services.Response,
+		schemaService:        deps.Services.Schema,
 		resolver:             deps.Resolver,
 		us:                   deps.Services.User,
 		ws:                   deps.Services.Workspace,
@@ -274,6 +279,12 @@ func (s *GraphQLServiceRPC) checkWorkspaceDeleteAccess(ctx context.Context, work
 
 // Mutation publisher for auto-publish on commit
 
+// MutationPublisher exposes the GraphQL publisher so other services, such as
+// the workspace importer, can route their GraphQL events to its streamers.
+func (s *GraphQLServiceRPC) MutationPublisher() mutation.Publisher {
+	return s.mutationPublisher()
+}
+
 func (s *GraphQLServiceRPC) mutationPublisher() mutation.Publisher {
 	return &rgraphqlPublisher{streamers: s.streamers}
 }
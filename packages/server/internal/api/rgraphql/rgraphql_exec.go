@@ -20,6 +20,9 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/middleware/mwauth"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/schema"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/subscription"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/transport"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/httpclient"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
@@ -29,94 +32,6 @@ import (
 	graphqlv1 "github.com/the-dev-tools/dev-tools/packages/spec/dist/buf/go/api/graph_q_l/v1"
 )
 
-const introspectionQuery = `query IntrospectionQuery {
-  __schema {
-    queryType { name }
-    mutationType { name }
-    subscriptionType { name }
-    types {
-      ...FullType
-    }
-    directives {
-      name
-      description
-      locations
-      args {
-        ...InputValue
-      }
-    }
-  }
-}
-
-fragment FullType on __Type {
-  kind
-  name
-  description
-  fields(includeDeprecated: true) {
-    name
-    description
-    args {
-      ...InputValue
-    }
-    type {
-      ...TypeRef
-    }
-    isDeprecated
-    deprecationReason
-  }
-  inputFields {
-    ...InputValue
-  }
-  interfaces {
-    ...TypeRef
-  }
-  enumValues(includeDeprecated: true) {
-    name
-    description
-    isDeprecated
-    deprecationReason
-  }
-  possibleTypes {
-    ...TypeRef
-  }
-}
-
-fragment InputValue on __InputValue {
-  name
-  description
-  type { ...TypeRef }
-  defaultValue
-}
-
-fragment TypeRef on __Type {
-  kind
-  name
-  ofType {
-    kind
-    name
-    ofType {
-      kind
-      name
-      ofType {
-        kind
-        name
-        ofType {
-          kind
-          name
-          ofType {
-            kind
-            name
-            ofType {
-              kind
-              name
-            }
-          }
-        }
-      }
-    }
-  }
-}`
-
 func (s *GraphQLServiceRPC) GraphQLRun(ctx context.Context, req *connect.Request[graphqlv1.GraphQLRunRequest]) (*connect.Response[emptypb.Empty], error) {
 	if len(req.Msg.GraphqlId) == 0 {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("graphql_id is required"))
@@ -185,27 +100,26 @@ func (s *GraphQLServiceRPC) GraphQLRun(ctx context.Context, req *connect.Request
 		asserts = assrts
 	}
 
-	// Build and execute GraphQL request
-	httpReq, err := prepareGraphQLRequest(&resolvedGraphQL, headers, varMap)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to prepare request: %w", err))
+	if err := s.validateAgainstSchema(ctx, gqlEntry, &resolvedGraphQL, varMap); err != nil {
+		return nil, err
 	}
 
-	client := httpclient.New()
+	// Subscriptions stream their events over WebSocket instead
+	if subscription.IsSubscription(interpolateString(resolvedGraphQL.Query, varMap), resolvedGraphQL.OperationName) {
+		return s.runGraphQLSubscription(ctx, gqlEntry, &resolvedGraphQL, headers, asserts, varMap)
+	}
+
+	// Build and execute GraphQL request
+	gqlReq := prepareGraphQLRequest(&resolvedGraphQL, headers, varMap)
 	startTime := time.Now()
 
-	resp, err := client.Do(httpReq.WithContext(ctx))
+	resp, err := transport.Do(ctx, httpclient.New(), gqlReq)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("request failed: %w", err))
 	}
-	defer func() { _ = resp.Body.Close() }()
+	body := resp.Body
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read response: %w", err))
-	}
-
-	duration := time.Since(startTime).Milliseconds()
+	duration := resp.Duration.Milliseconds()
 
 	// Store response
 	responseID := idwrap.NewNow()
@@ -214,7 +128,7 @@ func (s *GraphQLServiceRPC) GraphQLRun(ctx context.Context, req *connect.Request
 	gqlResponse := mgraphql.GraphQLResponse{
 		ID:        responseID,
 		GraphQLID: gqlID,
-		Status:    int32(resp.StatusCode), //nolint:gosec
+		Status:    int32(resp.Status), //nolint:gosec
 		Body:      body,
 		Time:      startTime.Unix(),
 		Duration:  int32(duration), //nolint:gosec
@@ -282,16 +196,21 @@ func (s *GraphQLServiceRPC) GraphQLRun(ctx context.Context, req *connect.Request
 
 	// Create snapshot GraphQL entry (using version ID as GraphQL ID)
 	snapshotGraphQL := &mgraphql.GraphQL{
-		ID:          version.ID,
-		WorkspaceID: gqlEntry.WorkspaceID,
-		FolderID:    gqlEntry.FolderID,
-		Name:        gqlEntry.Name,
-		Url:         gqlEntry.Url,
-		Query:       gqlEntry.Query,
-		Variables:   gqlEntry.Variables,
-		Description: gqlEntry.Description,
-		IsSnapshot:  true,
-		IsDelta:     false,
+		ID:             version.ID,
+		WorkspaceID:    gqlEntry.WorkspaceID,
+		FolderID:       gqlEntry.FolderID,
+		Name:           gqlEntry.Name,
+		Url:            gqlEntry.Url,
+		Query:          gqlEntry.Query,
+		Variables:      gqlEntry.Variables,
+		OperationName:  gqlEntry.OperationName,
+		QueryFile:      gqlEntry.QueryFile,
+		PersistedQuery: gqlEntry.PersistedQuery,
+		Batch:          gqlEntry.Batch,
+		Uploads:        gqlEntry.Uploads,
+		Description:    gqlEntry.Description,
+		IsSnapshot:     true,
+		IsDelta:        false,
 	}
 	if err := txGraphqlWriter.Create(ctx, snapshotGraphQL); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create snapshot GraphQL: %w", err))
@@ -418,7 +337,7 @@ func (s *GraphQLServiceRPC) GraphQLRun(ctx context.Context, req *connect.Request
 	if len(asserts) > 0 {
 		// Prepare response data for assertion evaluation
 		respData := GraphQLResponseData{
-			StatusCode: resp.StatusCode,
+			StatusCode: resp.Status,
 			Body:       body,
 			Headers:    responseHeaders,
 		}
@@ -557,14 +476,19 @@ func (s *GraphQLServiceRPC) GraphQLDuplicate(ctx context.Context, req *connect.R
 	txAssertService := s.graphqlAssertService.TX(tx)
 
 	newEntry := &mgraphql.GraphQL{
-		ID:          newGQLID,
-		WorkspaceID: gqlEntry.WorkspaceID,
-		FolderID:    gqlEntry.FolderID,
-		Name:        fmt.Sprintf("Copy of %s", gqlEntry.Name),
-		Url:         gqlEntry.Url,
-		Query:       gqlEntry.Query,
-		Variables:   gqlEntry.Variables,
-		Description: gqlEntry.Description,
+		ID:             newGQLID,
+		WorkspaceID:    gqlEntry.WorkspaceID,
+		FolderID:       gqlEntry.FolderID,
+		Name:           fmt.Sprintf("Copy of %s", gqlEntry.Name),
+		Url:            gqlEntry.Url,
+		Query:          gqlEntry.Query,
+		Variables:      gqlEntry.Variables,
+		OperationName:  gqlEntry.OperationName,
+		QueryFile:      gqlEntry.QueryFile,
+		PersistedQuery: gqlEntry.PersistedQuery,
+		Batch:          gqlEntry.Batch,
+		Uploads:        gqlEntry.Uploads,
+		Description:    gqlEntry.Description,
 	}
 
 	if err := txGraphqlService.Create(ctx, newEntry); err != nil {
@@ -649,7 +573,7 @@ func (s *GraphQLServiceRPC) GraphQLIntrospect(ctx context.Context, req *connect.
 
 	// Build introspection request
 	body, _ := json.Marshal(map[string]any{
-		"query": introspectionQuery,
+		"query": schema.IntrospectionQuery,
 	})
 
 	url := interpolateString(gqlEntry.Url, varMap)
@@ -677,12 +601,65 @@ func (s *GraphQLServiceRPC) GraphQLIntrospect(ctx context.Context, req *connect.
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read response: %w", err))
 	}
 
+	var sdl string
+	if parsed, err := schema.Parse(respBody); err == nil {
+		sdl = parsed.SDL()
+		if s.schemaService != nil {
+			err := s.schemaService.Upsert(ctx, mgraphql.GraphQLSchema{
+				GraphQLID:     schemaOwnerID(gqlEntry),
+				Introspection: string(respBody),
+				FetchedAt:     time.Now().Unix(),
+			})
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to cache schema: %w", err))
+			}
+		}
+	}
+
 	return connect.NewResponse(&graphqlv1.GraphQLIntrospectResponse{
 		IntrospectionJson: string(respBody),
-		Sdl:               "", // SDL conversion would need a graphql library - return empty for now
+		Sdl:               sdl,
 	}), nil
 }
 
+// schemaOwnerID returns the ID the introspected schema of a request is
+// cached under. Deltas share the schema of their base request.
+func schemaOwnerID(gql *mgraphql.GraphQL) idwrap.IDWrap {
+	if gql.IsDelta && gql.ParentGraphQLID != nil {
+		return *gql.ParentGraphQLID
+	}
+	return gql.ID
+}
+
+// validateAgainstSchema checks the resolved query and variables against the
+// cached schema of the request. Requests without a cached schema are not
+// validated.
+func (s *GraphQLServiceRPC) validateAgainstSchema(ctx context.Context, gqlEntry, resolved *mgraphql.GraphQL, varMap map[string]any) error {
+	if s.schemaService == nil {
+		return nil
+	}
+	parsed, err := s.schemaService.Schema(ctx, schemaOwnerID(gqlEntry))
+	if err != nil {
+		if errors.Is(err, sgraphql.ErrNoGraphQLSchemaFound) {
+			return nil
+		}
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load schema: %w", err))
+	}
+	query := interpolateString(resolved.Query, varMap)
+	variables := interpolateString(resolved.Variables, varMap)
+	if err := parsed.Validate(query, resolved.OperationName, []byte(variables)); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("graphql validation failed: %w", err))
+	}
+	for i, op := range resolved.Batch {
+		query := interpolateString(op.Query, varMap)
+		variables := interpolateString(op.Variables, varMap)
+		if err := parsed.Validate(query, op.OperationName, []byte(variables)); err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("graphql validation failed for batch operation %d: %w", i+1, err))
+		}
+	}
+	return nil
+}
+
 // Helper functions
 
 func (s *GraphQLServiceRPC) buildWorkspaceVarMap(ctx context.Context, workspaceID idwrap.IDWrap) (map[string]any, error) {
@@ -709,41 +686,52 @@ func (s *GraphQLServiceRPC) buildWorkspaceVarMap(ctx context.Context, workspaceI
 	return varMap, nil
 }
 
-func prepareGraphQLRequest(gql *mgraphql.GraphQL, headers []mgraphql.GraphQLHeader, varMap map[string]any) (*http.Request, error) {
-	url := interpolateString(gql.Url, varMap)
-	query := interpolateString(gql.Query, varMap)
-	variables := interpolateString(gql.Variables, varMap)
-
-	var varsMap map[string]any
-	if variables != "" {
-		if err := json.Unmarshal([]byte(variables), &varsMap); err != nil {
-			varsMap = nil
-		}
-	}
-
-	bodyMap := map[string]any{"query": query}
-	if varsMap != nil {
-		bodyMap["variables"] = varsMap
+// prepareGraphQLRequest interpolates the request with varMap. Batched
+// operations follow the request's own operation; uploads keep their paths.
+func prepareGraphQLRequest(gql *mgraphql.GraphQL, headers []mgraphql.GraphQLHeader, varMap map[string]any) transport.Request {
+	ops := make([]transport.Operation, 0, 1+len(gql.Batch))
+	ops = append(ops, transport.Operation{
+		Query:         interpolateString(gql.Query, varMap),
+		Variables:     variablesJSON(interpolateString(gql.Variables, varMap)),
+		OperationName: gql.OperationName,
+	})
+	for _, b := range gql.Batch {
+		ops = append(ops, transport.Operation{
+			Query:         interpolateString(b.Query, varMap),
+			Variables:     variablesJSON(interpolateString(b.Variables, varMap)),
+			OperationName: b.OperationName,
+		})
 	}
 
-	bodyBytes, err := json.Marshal(bodyMap)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal body: %w", err)
+	uploads := make([]transport.Upload, 0, len(gql.Uploads))
+	for _, u := range gql.Uploads {
+		uploads = append(uploads, transport.Upload{Path: u.Path, File: interpolateString(u.File, varMap)})
 	}
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
+	header := make(http.Header)
 	for _, h := range headers {
 		if h.Enabled && h.Key != "" {
-			req.Header.Set(interpolateString(h.Key, varMap), interpolateString(h.Value, varMap))
+			header.Set(interpolateString(h.Key, varMap), interpolateString(h.Value, varMap))
 		}
 	}
 
-	return req, nil
+	return transport.Request{
+		URL:            interpolateString(gql.Url, varMap),
+		Header:         header,
+		Operations:     ops,
+		PersistedQuery: gql.PersistedQuery,
+		Uploads:        uploads,
+	}
+}
+
+// variablesJSON returns variables when they hold a JSON object and drops
+// them otherwise.
+func variablesJSON(variables string) json.RawMessage {
+	var varsMap map[string]any
+	if variables == "" || json.Unmarshal([]byte(variables), &varsMap) != nil || varsMap == nil {
+		return nil
+	}
+	return json.RawMessage(variables)
 }
 
 func interpolateString(s string, varMap map[string]any) string {
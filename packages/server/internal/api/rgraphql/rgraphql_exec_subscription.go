@@ -0,0 +1,178 @@
+//nolint:revive // exported
+package rgraphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/subscription"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/httpclient"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mgraphql"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+)
+
+const (
+	// subscriptionRunMaxEvents caps the events a single run stores.
+	subscriptionRunMaxEvents = 100
+	// subscriptionRunTimeout caps how long a single run stays subscribed.
+	subscriptionRunTimeout = 5 * time.Minute
+)
+
+// runGraphQLSubscription runs a subscription operation over WebSocket instead
+// of posting it. Every event is stored as a response, with its assertions,
+// and published right away so the client sees results as they stream in.
+// The run ends when the server completes the subscription, the request is
+// cancelled, or a limit is hit.
+func (s *GraphQLServiceRPC) runGraphQLSubscription(
+	ctx context.Context,
+	gqlEntry *mgraphql.GraphQL,
+	resolved *mgraphql.GraphQL,
+	headers []mgraphql.GraphQLHeader,
+	asserts []mgraphql.GraphQLAssert,
+	varMap map[string]any,
+) (*connect.Response[emptypb.Empty], error) {
+	subReq := subscription.Request{
+		URL:           interpolateString(resolved.Url, varMap),
+		Query:         interpolateString(resolved.Query, varMap),
+		OperationName: resolved.OperationName,
+		Headers:       make(http.Header),
+		HTTPClient:    httpclient.New(),
+	}
+	if variables := interpolateString(resolved.Variables, varMap); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &subReq.Variables); err != nil {
+			subReq.Variables = nil
+		}
+	}
+	// Most servers authenticate subscriptions with connection_init rather
+	// than handshake headers, so the headers go in both.
+	payload := make(map[string]any)
+	for _, h := range headers {
+		if h.Enabled && h.Key != "" {
+			key, value := interpolateString(h.Key, varMap), interpolateString(h.Value, varMap)
+			subReq.Headers.Set(key, value)
+			payload[key] = value
+		}
+	}
+	if len(payload) > 0 {
+		subReq.ConnectionPayload = payload
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, subscriptionRunTimeout)
+	defer cancel()
+
+	startTime := time.Now()
+	_, err := subscription.Subscribe(runCtx, subReq, func(ev subscription.Event) error {
+		if err := s.storeSubscriptionEvent(ctx, gqlEntry, ev.Payload, startTime, asserts); err != nil {
+			return err
+		}
+		if ev.Index+1 >= subscriptionRunMaxEvents {
+			return subscription.ErrStop
+		}
+		return nil
+	})
+
+	var opErr *subscription.OperationError
+	switch {
+	case err == nil, errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+	case errors.As(err, &opErr):
+		// Validation and execution errors arrive as an error message; store
+		// them like an HTTP response carrying errors.
+		body := append(append([]byte(`{"errors":`), opErr.Payload...), '}')
+		if err := s.storeSubscriptionEvent(ctx, gqlEntry, body, startTime, asserts); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	case ctx.Err() != nil:
+		return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+	default:
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("subscription failed: %w", err))
+	}
+
+	now := time.Now().Unix()
+	gqlEntry.LastRunAt = &now
+	if err := s.graphqlService.Update(ctx, gqlEntry); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if s.streamers.GraphQL != nil {
+		s.streamers.GraphQL.Publish(GraphQLTopic{WorkspaceID: gqlEntry.WorkspaceID}, GraphQLEvent{
+			Type:    eventTypeUpdate,
+			GraphQL: ToAPIGraphQL(*gqlEntry),
+		})
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// storeSubscriptionEvent stores one event as a response with status 200,
+// evaluates the assertions against it and publishes both.
+func (s *GraphQLServiceRPC) storeSubscriptionEvent(
+	ctx context.Context,
+	gqlEntry *mgraphql.GraphQL,
+	body []byte,
+	startTime time.Time,
+	asserts []mgraphql.GraphQLAssert,
+) error {
+	gqlResponse := mgraphql.GraphQLResponse{
+		ID:        idwrap.NewNow(),
+		GraphQLID: gqlEntry.ID,
+		Status:    http.StatusOK,
+		Body:      body,
+		Time:      startTime.Unix(),
+		Duration:  int32(time.Since(startTime).Milliseconds()), //nolint:gosec
+		Size:      int32(len(body)),                            //nolint:gosec
+		CreatedAt: time.Now().Unix(),
+	}
+
+	mut := mutation.New(s.DB)
+	if err := mut.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer mut.Rollback()
+
+	tx := mut.TX()
+	if err := s.responseService.TX(tx).Create(ctx, gqlResponse); err != nil {
+		return fmt.Errorf("failed to store subscription event: %w", err)
+	}
+
+	responseAssertions, err := s.evaluateAndStoreAssertions(ctx, tx, gqlEntry.ID, gqlResponse.ID, gqlEntry.WorkspaceID, GraphQLResponseData{
+		StatusCode: http.StatusOK,
+		Body:       body,
+		Headers:    map[string]string{},
+	}, asserts)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to evaluate assertions",
+			"error", err,
+			"graphql_id", gqlEntry.ID.String(),
+			"response_id", gqlResponse.ID.String())
+		responseAssertions = nil
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit subscription event: %w", err)
+	}
+
+	if s.streamers.GraphQLResponse != nil {
+		s.streamers.GraphQLResponse.Publish(GraphQLResponseTopic{WorkspaceID: gqlEntry.WorkspaceID}, GraphQLResponseEvent{
+			Type:            eventTypeInsert,
+			GraphQLResponse: ToAPIGraphQLResponse(gqlResponse),
+		})
+	}
+	if s.streamers.GraphQLResponseAssert != nil {
+		topic := GraphQLResponseAssertTopic{WorkspaceID: gqlEntry.WorkspaceID}
+		for _, assert := range responseAssertions {
+			s.streamers.GraphQLResponseAssert.Publish(topic, GraphQLResponseAssertEvent{
+				Type:                  eventTypeInsert,
+				GraphQLResponseAssert: ToAPIGraphQLResponseAssert(assert),
+			})
+		}
+	}
+	return nil
+}
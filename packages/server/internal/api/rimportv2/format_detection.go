@@ -7,11 +7,13 @@ package rimportv2
 import (
 	"encoding/json"
 	"fmt"
-	"net/url"
 	"regexp"
 	"strings"
 	"unicode/utf8"
 
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/schema"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcurlv2"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -26,6 +28,7 @@ const (
 	FormatCURL
 	FormatPostman
 	FormatOpenAPI
+	FormatGraphQL
 )
 
 const ReasonValidJSON = "Valid JSON; "
@@ -45,6 +48,8 @@ func (f Format) String() string {
 		return "Postman"
 	case FormatOpenAPI:
 		return "OpenAPI"
+	case FormatGraphQL:
+		return "GraphQL"
 	default:
 		return "Unknown"
 	}
@@ -74,7 +79,7 @@ type FormatDetector struct {
 func NewFormatDetector() *FormatDetector {
 	return &FormatDetector{
 		harPattern:      regexp.MustCompile(`^\s*\{?\s*"?log"?[\s\S]*"?entries"?[\s\S]*\}?\s*$`),
-		curlPattern:     regexp.MustCompile(`(?i)^\s*curl\s+`),
+		curlPattern:     regexp.MustCompile(`(?im)^\s*curl\s+`),
 		postmanPattern:  regexp.MustCompile(`(?i)"?info"?\s*:\s*\{[\s\S]*"?name"?[\s\S]*"?schema"?\s*:\s*"https://schema\.getpostman\.com/json/collection/v2\.1\.0/collection\.json"`),
 		yamlPattern:     regexp.MustCompile(`(?i)^\s*flows?\s*:`),
 		swaggerPattern:  regexp.MustCompile(`(?i)"swagger"\s*:\s*"2\.\d+"`),
@@ -106,6 +111,7 @@ func (fd *FormatDetector) DetectFormat(data []byte) *DetectionResult {
 		fd.detectCURL(trimmed),
 		fd.detectYAML(trimmed),
 		fd.detectJSON(trimmed),
+		fd.detectGraphQL(trimmed),
 	}
 
 	// Find the result with highest confidence
@@ -480,6 +486,56 @@ func (fd *FormatDetector) detectOpenAPI(content string) *DetectionResult {
 	}
 }
 
+// detectGraphQL detects GraphQL sources: introspection results, executable
+// documents with operations and fragments, and schemas in SDL. A document
+// that parses is strong evidence, since no other format shares its grammar,
+// and an introspection result must outscore generic JSON, which reaches 1.4.
+func (fd *FormatDetector) detectGraphQL(content string) *DetectionResult {
+	confidence := 0.0
+	reason := ""
+
+	switch {
+	case schema.IsIntrospection([]byte(content)):
+		confidence += 1.2
+		reason += "GraphQL introspection __schema found; "
+		if _, err := schema.Parse([]byte(content)); err == nil {
+			confidence += 0.3
+			reason += "Introspection schema validated; "
+		}
+	default:
+		if doc, err := schema.ParseQuery(content); err == nil {
+			confidence += 1.0
+			reason += fmt.Sprintf("GraphQL document with %d operations; ", len(doc.Operations))
+		} else if _, err := schema.ParseSDL(content); err == nil {
+			confidence += 1.0
+			reason += "GraphQL schema definition language; "
+		}
+	}
+
+	return &DetectionResult{
+		Format:     FormatGraphQL,
+		Confidence: confidence,
+		Reason:     strings.TrimSpace(reason),
+	}
+}
+
+// validateGraphQL validates a GraphQL introspection result, document or SDL
+func (fd *FormatDetector) validateGraphQL(data []byte) error {
+	if schema.IsIntrospection(data) {
+		if _, err := schema.Parse(data); err != nil {
+			return fmt.Errorf("invalid GraphQL introspection: %w", err)
+		}
+		return nil
+	}
+	if _, err := schema.ParseQuery(string(data)); err == nil {
+		return nil
+	}
+	if _, err := schema.ParseSDL(string(data)); err != nil {
+		return fmt.Errorf("invalid GraphQL document or schema: %w", err)
+	}
+	return nil
+}
+
 // validateOpenAPI validates OpenAPI/Swagger format specifically
 func (fd *FormatDetector) validateOpenAPI(data []byte) error {
 	// Try JSON first, then YAML
@@ -585,6 +641,8 @@ func (fd *FormatDetector) ValidateFormat(data []byte, format Format) error {
 		return fd.validateJSON(data)
 	case FormatOpenAPI:
 		return fd.validateOpenAPI(data)
+	case FormatGraphQL:
+		return fd.validateGraphQL(data)
 	default:
 		return fmt.Errorf("unknown format: %v", format)
 	}
@@ -641,18 +699,10 @@ func (fd *FormatDetector) validateCURL(data []byte) error {
 		return fmt.Errorf("does not appear to be a curl command")
 	}
 
-	// Try to extract URL
-	urlPattern := regexp.MustCompile(`(?:https?://|www\.)[^\s'"]+`)
-	urls := urlPattern.FindAllString(content, -1)
-	if len(urls) == 0 {
-		return fmt.Errorf("no URL found in curl command")
-	}
-
-	// Validate URL format
-	for _, urlStr := range urls {
-		if _, err := url.Parse(urlStr); err != nil {
-			return fmt.Errorf("invalid URL in curl command: %s", urlStr)
-		}
+	// The script parser accepts single commands as well as scripts whose URLs
+	// come from shell variables
+	if _, err := tcurlv2.ConvertCurlScript(content, tcurlv2.ConvertCurlScriptOptions{}); err != nil {
+		return fmt.Errorf("invalid curl command: %w", err)
 	}
 
 	return nil
@@ -19,6 +19,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/senv"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sfile"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sgraphql"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/shttp"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/suser"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sworkspace"
@@ -46,6 +47,9 @@ type ImportServices struct {
 	Node               *sflow.NodeService
 	NodeRequest        *sflow.NodeRequestService
 	Edge               *sflow.EdgeService
+	// GraphQL stores imported GraphQL requests. Optional: without it, GraphQL
+	// sources fail to import.
+	GraphQL *sgraphql.GraphQLService
 }
 
 func (s *ImportServices) Validate() error {
@@ -214,7 +218,7 @@ func NewImportV2RPC(deps ImportV2Deps) *ImportV2RPC {
 		deps.Services.Http, deps.Services.Flow, deps.Services.File,
 		deps.Services.HttpHeader, deps.Services.HttpSearchParam, deps.Services.HttpBodyForm, deps.Services.HttpBodyUrlEncoded, deps.Services.HttpBodyRaw,
 		deps.Services.HttpAssert, deps.Services.Node, deps.Services.NodeRequest, deps.Services.Edge,
-		deps.Services.Env, deps.Services.Var)
+		deps.Services.Env, deps.Services.Var, deps.Services.GraphQL)
 
 	// Create the validator for input validation
 	validator := NewValidator(&deps.Services.User, deps.Readers.User)
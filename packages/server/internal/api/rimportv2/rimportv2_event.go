@@ -118,6 +118,7 @@ func (h *ImportV2RPC) publishEvents(ctx context.Context, results *ImportResults)
 	// Add Edge events (only if flow exists)
 	if results.Flow != nil && len(results.Edges) > 0 {
 		eventsync.AddSyncTransformSimple(batch, eventsync.KindEdge, h.EdgeStream, rflowv2.EdgeTopic{FlowID: results.Flow.ID}, results.Edges, func(edge mflow.Edge) rflowv2.EdgeEvent {
+			sourceHandle, caseIndex := rflowv2.HandleToAPI(edge.SourceHandler)
 			return rflowv2.EdgeEvent{
 				Type:   "insert",
 				FlowID: edge.FlowID,
@@ -126,7 +127,8 @@ func (h *ImportV2RPC) publishEvents(ctx context.Context, results *ImportResults)
 					FlowId:       edge.FlowID.Bytes(),
 					SourceId:     edge.SourceID.Bytes(),
 					TargetId:     edge.TargetID.Bytes(),
-					SourceHandle: flowv1.HandleKind(edge.SourceHandler),
+					SourceHandle: sourceHandle,
+					CaseIndex:    caseIndex,
 				},
 			}
 		})
@@ -125,7 +125,7 @@ type ImportConstraints struct {
 func DefaultConstraints() *ImportConstraints {
 	return &ImportConstraints{
 		MaxDataSizeBytes: 50 * 1024 * 1024, // 50MB
-		SupportedFormats: []Format{FormatHAR, FormatYAML, FormatJSON, FormatCURL, FormatPostman, FormatOpenAPI},
+		SupportedFormats: []Format{FormatHAR, FormatYAML, FormatJSON, FormatCURL, FormatPostman, FormatOpenAPI, FormatGraphQL},
 		AllowedMimeTypes: []string{
 			"application/json",
 			"application/har",
@@ -519,6 +519,7 @@ func (s *Service) ImportUnified(ctx context.Context, req *ImportRequest) (*Impor
 		"workspace_id", req.WorkspaceID,
 		"detected_format", translationResult.DetectedFormat,
 		"http_requests", len(translationResult.HTTPRequests),
+		"graphql_requests", len(translationResult.GraphQLRequests),
 		"files", len(translationResult.Files),
 		"flows", len(translationResult.Flows))
 
@@ -593,6 +594,7 @@ func (s *Service) ImportUnified(ctx context.Context, req *ImportRequest) (*Impor
 		"workspace_id", req.WorkspaceID,
 		"format", translationResult.DetectedFormat,
 		"http_requests", len(translationResult.HTTPRequests),
+		"graphql_requests", len(translationResult.GraphQLRequests),
 		"files", len(translationResult.Files),
 		"flows", len(translationResult.Flows),
 		"domains", len(translationResult.Domains))
@@ -19,6 +19,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/senv"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sfile"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sgraphql"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/shttp"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sworkspace"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/harv2"
@@ -43,6 +44,7 @@ type DefaultImporter struct {
 	edgeService               *sflow.EdgeService
 	envService                senv.EnvironmentService
 	varService                senv.VariableService
+	graphqlService            *sgraphql.GraphQLService
 	harTranslator             *defaultHARTranslator
 	dedup                     *Deduplicator
 	// mutationPublisher fans out post-commit events for real-time UI sync.
@@ -76,6 +78,7 @@ func NewImporter(
 	edgeService *sflow.EdgeService,
 	envService senv.EnvironmentService,
 	varService senv.VariableService,
+	graphqlService *sgraphql.GraphQLService,
 ) *DefaultImporter {
 	return &DefaultImporter{
 		db:                        db,
@@ -94,6 +97,7 @@ func NewImporter(
 		edgeService:               edgeService,
 		envService:                envService,
 		varService:                varService,
+		graphqlService:            graphqlService,
 		harTranslator:             newHARTranslator(),
 		dedup:                     NewDeduplicator(*httpService, *fileService, nil),
 	}
@@ -779,6 +783,33 @@ func (imp *DefaultImporter) StoreUnifiedResults(ctx context.Context, results *Tr
 		req.ID = newID
 	}
 
+	// 2.3 Store GraphQL requests. Each one shares its ID with the file that
+	// places it in the tree; when that file was deduplicated against an
+	// existing one, the request is already in the workspace and is skipped.
+	if len(results.GraphQLRequests) > 0 {
+		if imp.graphqlService == nil {
+			return nil, nil, nil, nil, fmt.Errorf("graphql service is required to import GraphQL requests")
+		}
+		txGraphQLService := imp.graphqlService.TX(tx)
+		stored := results.GraphQLRequests[:0]
+		for i := range results.GraphQLRequests {
+			gql := results.GraphQLRequests[i]
+			if newID, ok := fileIDMap[gql.ID]; ok && deduplicatedFileIDs[newID] {
+				continue
+			}
+			if gql.FolderID != nil {
+				if newFolderID, ok := fileIDMap[*gql.FolderID]; ok {
+					gql.FolderID = &newFolderID
+				}
+			}
+			if err := txGraphQLService.Create(ctx, &gql); err != nil {
+				return nil, nil, nil, nil, fmt.Errorf("failed to store GraphQL request %s: %w", gql.Name, err)
+			}
+			stored = append(stored, gql)
+		}
+		results.GraphQLRequests = stored
+	}
+
 	// 2.4 Update IDs in Child Entities and Store
 	for i := range results.Headers {
 		if newID, ok := httpIDMap[results.Headers[i].HttpID]; ok {
@@ -1187,6 +1218,16 @@ func buildImportSyncEvents(results *TranslationResult) []mutation.Event {
 		})
 	}
 
+	for _, g := range results.GraphQLRequests {
+		events = append(events, mutation.Event{
+			Entity:      mutation.EntityGraphQL,
+			Op:          mutation.OpInsert,
+			ID:          g.ID,
+			WorkspaceID: wsID,
+			Payload:     g,
+		})
+	}
+
 	return events
 }
 
@@ -14,10 +14,12 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mfile"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mgraphql"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/shttp"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/harv2"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcurlv2"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tgraphqlv2"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/topenapiv2"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tpostmanv2"
 	yamlflowsimplev2 "github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/yamlflowsimplev2"
@@ -51,6 +53,9 @@ type TranslationResult struct {
 	AINodes        []mflow.NodeAI
 	FlowVariables  []mflow.FlowVariable
 
+	// GraphQL requests, placed in the tree by Files (ContentType=GraphQL)
+	GraphQLRequests []mgraphql.GraphQL
+
 	// Variables (collection or environment level)
 	Variables []menv.Variable
 
@@ -92,6 +97,7 @@ func NewTranslatorRegistry(httpService *shttp.HTTPService) *TranslatorRegistry {
 	registry.RegisterTranslator(NewCURLTranslator())
 	registry.RegisterTranslator(NewPostmanTranslator())
 	registry.RegisterTranslator(NewOpenAPITranslator())
+	registry.RegisterTranslator(NewGraphQLTranslator())
 	registry.RegisterTranslator(NewJSONTranslator())
 
 	return registry
@@ -308,6 +314,14 @@ func (t *CURLTranslator) Validate(data []byte) error {
 }
 
 func (t *CURLTranslator) Translate(ctx context.Context, data []byte, workspaceID idwrap.IDWrap) (*TranslationResult, error) {
+	// Scripts with several commands or shell variables become a flow
+	script, err := tcurlv2.ConvertCurlScript(string(data), tcurlv2.ConvertCurlScriptOptions{
+		WorkspaceID: workspaceID,
+	})
+	if err == nil && (len(script.Requests) > 1 || len(script.Variables) > 0) {
+		return translateCurlScript(script), nil
+	}
+
 	// Convert curl options
 	opts := tcurlv2.ConvertCurlOptions{
 		WorkspaceID: workspaceID,
@@ -340,6 +354,46 @@ func (t *CURLTranslator) Translate(ctx context.Context, data []byte, workspaceID
 	return result, nil
 }
 
+// translateCurlScript maps a multi-command curl script, with one request
+// node per command and its shell variables as environment variables
+func translateCurlScript(script *tcurlv2.CurlScriptResolved) *TranslationResult {
+	result := &TranslationResult{
+		Flows:        []mflow.Flow{script.Flow},
+		Files:        []mfile.File{script.FlowFile},
+		Nodes:        script.Nodes,
+		RequestNodes: script.RequestNodes,
+		Edges:        script.Edges,
+		ProcessedAt:  time.Now().UnixMilli(),
+	}
+
+	for _, r := range script.Requests {
+		result.HTTPRequests = append(result.HTTPRequests, r.HTTP)
+		result.Files = append(result.Files, r.File)
+		result.Headers = append(result.Headers, r.Headers...)
+		result.SearchParams = append(result.SearchParams, r.SearchParams...)
+		result.BodyForms = append(result.BodyForms, r.BodyForms...)
+		result.BodyUrlencoded = append(result.BodyUrlencoded, r.BodyUrlencoded...)
+		if r.BodyRaw != nil {
+			result.BodyRaw = append(result.BodyRaw, *r.BodyRaw)
+		}
+	}
+
+	for i, v := range script.Variables {
+		result.Variables = append(result.Variables, menv.Variable{
+			ID:      idwrap.NewNow(),
+			VarKey:  v.Key,
+			Value:   v.Value,
+			Enabled: true,
+			Order:   float64(i + 1),
+		})
+	}
+
+	// Extract domains from HTTP requests
+	result.Domains = extractDomainsFromHTTP(result.HTTPRequests)
+
+	return result
+}
+
 // PostmanTranslator implements Translator for Postman collection format
 type PostmanTranslator struct {
 	detector *FormatDetector
@@ -459,6 +513,46 @@ func (t *OpenAPITranslator) Translate(ctx context.Context, data []byte, workspac
 	return result, nil
 }
 
+// GraphQLTranslator implements Translator for GraphQL introspection results,
+// .graphql documents and SDL schemas
+type GraphQLTranslator struct {
+	detector *FormatDetector
+}
+
+// NewGraphQLTranslator creates a new GraphQL translator
+func NewGraphQLTranslator() *GraphQLTranslator {
+	return &GraphQLTranslator{
+		detector: NewFormatDetector(),
+	}
+}
+
+func (t *GraphQLTranslator) GetFormat() Format {
+	return FormatGraphQL
+}
+
+func (t *GraphQLTranslator) Validate(data []byte) error {
+	return t.detector.ValidateFormat(data, FormatGraphQL)
+}
+
+func (t *GraphQLTranslator) Translate(ctx context.Context, data []byte, workspaceID idwrap.IDWrap) (*TranslationResult, error) {
+	// Documents keep their fragments next to each operation so the imported
+	// queries read like the source file.
+	resolved, err := tgraphqlv2.Convert(data, tgraphqlv2.ConvertOptions{
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert GraphQL source: %w", err)
+	}
+
+	// GraphQL requests carry no endpoint to extract domains from: schemas and
+	// documents do not name the server they belong to.
+	return &TranslationResult{
+		GraphQLRequests: resolved.GraphQLRequests,
+		Files:           resolved.Files,
+		ProcessedAt:     time.Now().UnixMilli(),
+	}, nil
+}
+
 // JSONTranslator implements Translator for generic JSON format
 type JSONTranslator struct {
 	detector *FormatDetector
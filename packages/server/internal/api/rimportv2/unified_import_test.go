@@ -88,6 +88,32 @@ func TestFormatDetector(t *testing.T) {
 			minConfidence:  0.7,
 			shouldError:    false,
 		},
+		{
+			name: "GraphQL document",
+			data: []byte(`query GetUser($id: ID!) { user(id: $id) { ...UserFields } }
+fragment UserFields on User { id name }`),
+			expectedFormat: FormatGraphQL,
+			minConfidence:  0.9,
+			shouldError:    false,
+		},
+		{
+			name: "GraphQL SDL",
+			data: []byte(`type Query {
+  user(id: ID!): User
+}
+
+type User { id: ID! name: String }`),
+			expectedFormat: FormatGraphQL,
+			minConfidence:  0.9,
+			shouldError:    false,
+		},
+		{
+			name:           "GraphQL introspection",
+			data:           []byte(`{"data": {"__schema": {"queryType": {"name": "Query"}, "types": [{"kind": "OBJECT", "name": "Query", "fields": [{"name": "url", "args": [], "type": {"kind": "SCALAR", "name": "String"}}]}]}}}`),
+			expectedFormat: FormatGraphQL,
+			minConfidence:  0.9,
+			shouldError:    false,
+		},
 		{
 			name:           "Invalid JSON",
 			data:           []byte(`{invalid json`),
@@ -153,7 +179,7 @@ func TestTranslatorRegistry(t *testing.T) {
 
 	// Test supported formats
 	formats := registry.GetSupportedFormats()
-	expectedFormats := []Format{FormatHAR, FormatYAML, FormatJSON, FormatCURL, FormatPostman, FormatOpenAPI}
+	expectedFormats := []Format{FormatHAR, FormatYAML, FormatJSON, FormatCURL, FormatPostman, FormatOpenAPI, FormatGraphQL}
 
 	if len(formats) != len(expectedFormats) {
 		t.Errorf("Expected %d formats, got %d", len(expectedFormats), len(formats))
@@ -358,7 +384,7 @@ func TestConstraints(t *testing.T) {
 		t.Errorf("Expected max data size 50MB, got %d bytes", constraints.MaxDataSizeBytes)
 	}
 
-	expectedFormats := []Format{FormatHAR, FormatYAML, FormatJSON, FormatCURL, FormatPostman, FormatOpenAPI}
+	expectedFormats := []Format{FormatHAR, FormatYAML, FormatJSON, FormatCURL, FormatPostman, FormatOpenAPI, FormatGraphQL}
 	if len(constraints.SupportedFormats) != len(expectedFormats) {
 		t.Errorf("Expected %d supported formats, got %d", len(expectedFormats), len(constraints.SupportedFormats))
 	}
@@ -0,0 +1,139 @@
+// Package rmock serves the saved responses of a workspace as a mock server,
+// mounted under /mock/{workspaceId}/ next to the RPC services.
+package rmock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/middleware/mwauth"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mock"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/suser"
+)
+
+// PathPrefix is where the mock is mounted. The first segment after it is the
+// workspace ID, the rest is matched against the workspace's requests.
+const PathPrefix = "/mock/"
+
+// Handler answers mock requests from the workspace named in the path. Routes
+// are read on every request, so newly saved responses are served right away.
+// Saved responses can hold tokens, so only members of the workspace, as put
+// in the request context by mwauth, are served.
+type Handler struct {
+	queries *gen.Queries
+	us      suser.UserService
+	logger  *slog.Logger
+	opts    []mock.Option
+}
+
+// New creates a Handler. The options configure the mock server of every
+// request, e.g. latency or fault injection.
+func New(queries *gen.Queries, us suser.UserService, logger *slog.Logger, opts ...mock.Option) *Handler {
+	return &Handler{queries: queries, us: us, logger: logger, opts: opts}
+}
+
+// EnvOptions reads latency and fault injection for the mock from the
+// environment, matching the flags of the CLI's mock serve command:
+// MOCK_LATENCY and MOCK_JITTER are durations such as "200ms", MOCK_FAULT_RATE
+// is the share of requests, from 0 to 1, that fail and MOCK_FAULT_STATUS
+// their status, 503 by default; 0 drops the connection instead.
+func EnvOptions(getenv func(string) string) ([]mock.Option, error) {
+	var latency, jitter time.Duration
+	for name, d := range map[string]*time.Duration{"MOCK_LATENCY": &latency, "MOCK_JITTER": &jitter} {
+		v := getenv(name)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative duration, got %q", name, v)
+		}
+		*d = parsed
+	}
+
+	var rate float64
+	if v := getenv("MOCK_FAULT_RATE"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			return nil, fmt.Errorf("MOCK_FAULT_RATE must be between 0 and 1, got %q", v)
+		}
+		rate = parsed
+	}
+	status := http.StatusServiceUnavailable
+	if v := getenv("MOCK_FAULT_STATUS"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || (parsed != 0 && (parsed < 100 || parsed > 599)) {
+			return nil, fmt.Errorf("MOCK_FAULT_STATUS must be an HTTP status or 0, got %q", v)
+		}
+		status = parsed
+	}
+
+	return []mock.Option{
+		mock.WithLatency(latency, jitter),
+		mock.WithFaults(rate, status),
+	}, nil
+}
+
+// ListenAddr returns addr with its host defaulted to the loopback address,
+// so a bare ":8080" is not reachable from other machines.
+func ListenAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("127.0.0.1", port)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.EscapedPath(), PathPrefix)
+	workspaceText, path, _ := strings.Cut(rest, "/")
+
+	workspaceID, err := idwrap.NewText(workspaceText)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid workspace id %q: %w", workspaceText, err))
+		return
+	}
+
+	userID, err := mwauth.GetContextUserID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+	ok, err := h.us.CheckUserBelongsToWorkspace(r.Context(), userID, workspaceID)
+	if err != nil && !errors.Is(err, suser.ErrUserNotFound) {
+		h.logger.Error("mock: failed to check workspace access", "workspace_id", workspaceID.String(), "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("failed to check workspace access"))
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("workspace not found"))
+		return
+	}
+
+	data, err := mock.LoadWorkspace(r.Context(), h.queries, workspaceID)
+	if err != nil {
+		h.logger.Error("mock: failed to load workspace", "workspace_id", workspaceID.String(), "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("failed to load workspace"))
+		return
+	}
+
+	opts := append([]mock.Option{
+		mock.WithLogger(h.logger.With("workspace_id", workspaceID.String())),
+	}, h.opts...)
+	mock.New(mock.WorkspaceRoutes(data), opts...).ServePath(w, r, "/"+path)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
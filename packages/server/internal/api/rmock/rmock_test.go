@@ -0,0 +1,134 @@
+package rmock
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlitemem"
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/middleware/mwauth"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mock"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/shttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/suser"
+)
+
+func TestHandler_ServesSavedResponses(t *testing.T) {
+	ctx := context.Background()
+	db, _, err := sqlitemem.NewSQLiteMem(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	queries := gen.New(db)
+
+	wsID := idwrap.NewNow()
+	require.NoError(t, queries.CreateWorkspace(ctx, gen.CreateWorkspaceParams{ID: wsID, Name: "Mock"}))
+	userID := idwrap.NewNow()
+	require.NoError(t, queries.CreateUser(ctx, gen.CreateUserParams{ID: userID, Email: "mock@example.com"}))
+	require.NoError(t, queries.CreateWorkspaceUser(ctx, gen.CreateWorkspaceUserParams{
+		ID:          idwrap.NewNow(),
+		WorkspaceID: wsID,
+		UserID:      userID,
+		Role:        1,
+	}))
+
+	httpID := idwrap.NewNow()
+	require.NoError(t, shttp.New(queries, slog.Default()).Create(ctx, &mhttp.HTTP{
+		ID:          httpID,
+		WorkspaceID: wsID,
+		Name:        "Get user",
+		Method:      "GET",
+		Url:         "{{ baseUrl }}/users/{{ id }}",
+	}))
+
+	responses := shttp.NewHttpResponseService(queries)
+	respID := idwrap.NewNow()
+	require.NoError(t, responses.Create(ctx, mhttp.HTTPResponse{
+		ID:     respID,
+		HttpID: httpID,
+		Status: 200,
+		Body:   []byte(`{"id": "{{ request.params.id }}"}`),
+		Time:   1,
+	}))
+	require.NoError(t, responses.CreateHeader(ctx, mhttp.HTTPResponseHeader{
+		ID:          idwrap.NewNow(),
+		ResponseID:  respID,
+		HeaderKey:   "Content-Type",
+		HeaderValue: "application/json",
+	}))
+
+	h := New(queries, suser.New(queries), slog.New(slog.DiscardHandler))
+	authed := mwauth.CreateAuthedContext(ctx, userID)
+	get := func(method, path string, reqCtx context.Context) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, path, nil).WithContext(reqCtx))
+		return rec
+	}
+
+	rec := get(http.MethodGet, PathPrefix+wsID.String()+"/users/42", authed)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"id": "42"}`, rec.Body.String())
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	rec = get(http.MethodPost, PathPrefix+wsID.String()+"/users/42", authed)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = get(http.MethodGet, PathPrefix+"not-an-id/users/42", authed)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Saved responses are only served to members of the workspace.
+	rec = get(http.MethodGet, PathPrefix+wsID.String()+"/users/42", mwauth.CreateAuthedContext(ctx, idwrap.NewNow()))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.NotContains(t, rec.Body.String(), "42")
+
+	rec = get(http.MethodGet, PathPrefix+wsID.String()+"/users/42", ctx)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestListenAddr(t *testing.T) {
+	require.Equal(t, "127.0.0.1:8080", ListenAddr(":8080"))
+	require.Equal(t, "0.0.0.0:8080", ListenAddr("0.0.0.0:8080"))
+	require.Equal(t, "localhost:8080", ListenAddr("localhost:8080"))
+}
+
+func TestEnvOptions(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	opts, err := EnvOptions(env(map[string]string{
+		"MOCK_LATENCY":      "30ms",
+		"MOCK_FAULT_RATE":   "1",
+		"MOCK_FAULT_STATUS": "502",
+	}))
+	require.NoError(t, err)
+	s := mock.New([]mock.Route{{Path: "/flaky"}}, opts...)
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/flaky", nil))
+	require.Equal(t, http.StatusBadGateway, rec.Code)
+	require.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	// Without any variables the mock answers right away.
+	opts, err = EnvOptions(env(nil))
+	require.NoError(t, err)
+	rec = httptest.NewRecorder()
+	mock.New([]mock.Route{{Path: "/flaky"}}, opts...).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/flaky", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	for name, value := range map[string]string{
+		"MOCK_LATENCY":      "soon",
+		"MOCK_JITTER":       "-1s",
+		"MOCK_FAULT_RATE":   "1.5",
+		"MOCK_FAULT_STATUS": "42",
+	} {
+		_, err := EnvOptions(env(map[string]string{name: value}))
+		require.ErrorContains(t, err, name)
+	}
+}
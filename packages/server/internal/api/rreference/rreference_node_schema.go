@@ -26,20 +26,141 @@ var nodeDefaultSchemas = map[mflow.NodeKind]map[string]any{
 		},
 		"response": defaultHTTPResponseSchema(),
 	},
+	mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION: {
+		"request": map[string]any{
+			"url":       "string",
+			"query":     "string",
+			"variables": map[string]any{},
+			"headers":   map[string]string{},
+		},
+		"payload":   map[string]any{},
+		"data":      map[string]any{},
+		"errors":    []any{},
+		"index":     0,
+		"events":    0,
+		"completed": false,
+		"protocol":  "string",
+	},
 	mflow.NODE_KIND_WS_CONNECTION: {
 		"url":       "string",
 		"connected": false,
+		"protocol":  "string",
 		"cookies":   map[string]string{},
 		"message":   "string",
+		"event":     map[string]any{},
 		"index":     0,
 		"type":      "string",
 	},
 	mflow.NODE_KIND_WS_SEND: {
 		"type":           "string",
 		"message":        "string",
+		"binary":         false,
+		"event":          "string",
+		"ack_id":         0,
 		"connectionNode": "string",
 		"cookies":        map[string]string{},
 	},
+	mflow.NODE_KIND_WS_EXPECT: {
+		"frames": []any{},
+		"frame": map[string]any{
+			"index":     0,
+			"direction": "string",
+			"type":      "string",
+			"data":      "string",
+			"json":      map[string]any{},
+			"event":     map[string]any{},
+			"time":      0,
+		},
+		"count":        0,
+		"duration":     0,
+		"protocol":     "string",
+		"ping_ms":      0,
+		"close_code":   0,
+		"close_reason": "string",
+	},
+	mflow.NODE_KIND_SOCKET_CONNECTION: {
+		"network":       "string",
+		"address":       "string",
+		"local_address": "string",
+		"connected":     false,
+		"tls":           false,
+		"message":       "string",
+		"data": map[string]any{
+			"index":     0,
+			"direction": "string",
+			"text":      "string",
+			"hex":       "string",
+			"base64":    "string",
+			"json":      map[string]any{},
+			"size":      0,
+			"time":      0,
+		},
+		"index": 0,
+		"type":  "string",
+	},
+	mflow.NODE_KIND_SOCKET_SEND: {
+		"type":           "string",
+		"message":        "string",
+		"hex":            "string",
+		"size":           0,
+		"connectionNode": "string",
+	},
+	mflow.NODE_KIND_SOCKET_RECEIVE: {
+		"messages": []any{},
+		"message": map[string]any{
+			"index":     0,
+			"direction": "string",
+			"text":      "string",
+			"hex":       "string",
+			"base64":    "string",
+			"json":      map[string]any{},
+			"size":      0,
+			"time":      0,
+		},
+		"count":    0,
+		"duration": 0,
+	},
+	mflow.NODE_KIND_DB_QUERY: {
+		"rows":           []any{},
+		"row":            map[string]any{},
+		"columns":        []any{},
+		"count":          0,
+		"rows_affected":  0,
+		"last_insert_id": 0,
+		"duration":       0,
+	},
+	mflow.NODE_KIND_MQ_PUBLISH: {
+		"topic":    "string",
+		"key":      "string",
+		"message":  "string",
+		"id":       "string",
+		"size":     0,
+		"duration": 0,
+	},
+	mflow.NODE_KIND_MQ_CONSUME: {
+		"messages": []any{},
+		"message": map[string]any{
+			"topic":   "string",
+			"key":     "string",
+			"id":      "string",
+			"headers": map[string]any{},
+			"text":    "string",
+			"json":    map[string]any{},
+			"size":    0,
+			"time":    0,
+		},
+		"index":    0,
+		"count":    0,
+		"duration": 0,
+	},
+	mflow.NODE_KIND_EXEC: {
+		"stdout":    "string",
+		"stderr":    "string",
+		"exit_code": 0,
+		"truncated": false,
+		"json":      map[string]any{},
+		"duration":  0,
+	},
 	mflow.NODE_KIND_AI: {
 		"text":          "",
 		"total_metrics": map[string]any{},
@@ -50,6 +171,17 @@ var nodeDefaultSchemas = map[mflow.NodeKind]map[string]any{
 		"condition": "",
 		"result":    false,
 	},
+	mflow.NODE_KIND_SWITCH: {
+		"case":  "",
+		"index": 0,
+	},
+	mflow.NODE_KIND_WEBHOOK_TRIGGER: {
+		"method":  "",
+		"path":    "",
+		"headers": map[string]any{},
+		"query":   map[string]any{},
+		"body":    nil,
+	},
 	mflow.NODE_KIND_AI_PROVIDER: {
 		"text":       "",
 		"tool_calls": []any{},
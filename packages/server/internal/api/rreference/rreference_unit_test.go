@@ -191,10 +191,21 @@ func TestNodeDefaultSchema(t *testing.T) {
 		{"GRAPHQL", mflow.NODE_KIND_GRAPHQL, true},
 		{"JS", mflow.NODE_KIND_JS, true},
 		{"CONDITION", mflow.NODE_KIND_CONDITION, true},
+		{"SWITCH", mflow.NODE_KIND_SWITCH, true},
+		{"WEBHOOK_TRIGGER", mflow.NODE_KIND_WEBHOOK_TRIGGER, true},
 		{"AI", mflow.NODE_KIND_AI, true},
 		{"AI_PROVIDER", mflow.NODE_KIND_AI_PROVIDER, true},
+		{"GRAPHQL_SUBSCRIPTION", mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION, true},
 		{"WS_CONNECTION", mflow.NODE_KIND_WS_CONNECTION, true},
 		{"WS_SEND", mflow.NODE_KIND_WS_SEND, true},
+		{"WS_EXPECT", mflow.NODE_KIND_WS_EXPECT, true},
+		{"SOCKET_CONNECTION", mflow.NODE_KIND_SOCKET_CONNECTION, true},
+		{"SOCKET_SEND", mflow.NODE_KIND_SOCKET_SEND, true},
+		{"SOCKET_RECEIVE", mflow.NODE_KIND_SOCKET_RECEIVE, true},
+		{"DB_QUERY", mflow.NODE_KIND_DB_QUERY, true},
+		{"MQ_PUBLISH", mflow.NODE_KIND_MQ_PUBLISH, true},
+		{"MQ_CONSUME", mflow.NODE_KIND_MQ_CONSUME, true},
+		{"EXEC", mflow.NODE_KIND_EXEC, true},
 		{"RUN_SUB_FLOW", mflow.NODE_KIND_RUN_SUB_FLOW, true},
 		{"SUB_FLOW_RETURN has no schema", mflow.NODE_KIND_SUB_FLOW_RETURN, false},
 		{"SUB_FLOW_TRIGGER handled separately", mflow.NODE_KIND_SUB_FLOW_TRIGGER, false},
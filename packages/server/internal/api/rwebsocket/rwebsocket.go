@@ -9,6 +9,7 @@ import (
 
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	devtoolsdb "github.com/the-dev-tools/dev-tools/packages/db"
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api"
@@ -48,6 +49,15 @@ type WebSocketHeaderEvent struct {
 	WebSocketHeader *apiv1.WebSocketHeader
 }
 
+type WebSocketMessageTopic struct {
+	WorkspaceID idwrap.IDWrap
+}
+
+type WebSocketMessageEvent struct {
+	Type             string
+	WebSocketMessage *apiv1.WebSocketMessage
+}
+
 // WebSocketRPC handles WebSocket CRUD operations and real-time sync.
 type WebSocketRPC struct {
 	web_socketv1connect.UnimplementedWebSocketServiceHandler
@@ -55,20 +65,24 @@ type WebSocketRPC struct {
 	DB        *sql.DB
 	ws        swebsocket.WebSocketService
 	wsh       swebsocket.WebSocketHeaderService
+	wsm       swebsocket.WebSocketMessageService
 	us        suser.UserService
 	wk        sworkspace.WorkspaceService
 	wsStream  eventstream.SyncStreamer[WebSocketTopic, WebSocketEvent]
 	wshStream eventstream.SyncStreamer[WebSocketHeaderTopic, WebSocketHeaderEvent]
+	wsmStream eventstream.SyncStreamer[WebSocketMessageTopic, WebSocketMessageEvent]
 }
 
 type Deps struct {
 	DB        *sql.DB
 	WS        swebsocket.WebSocketService
 	WSH       swebsocket.WebSocketHeaderService
+	WSM       swebsocket.WebSocketMessageService
 	US        suser.UserService
 	Workspace sworkspace.WorkspaceService
 	WSStream  eventstream.SyncStreamer[WebSocketTopic, WebSocketEvent]
 	WSHStream eventstream.SyncStreamer[WebSocketHeaderTopic, WebSocketHeaderEvent]
+	WSMStream eventstream.SyncStreamer[WebSocketMessageTopic, WebSocketMessageEvent]
 }
 
 func New(deps Deps) WebSocketRPC {
@@ -76,10 +90,12 @@ func New(deps Deps) WebSocketRPC {
 		DB:        deps.DB,
 		ws:        deps.WS,
 		wsh:       deps.WSH,
+		wsm:       deps.WSM,
 		us:        deps.US,
 		wk:        deps.Workspace,
 		wsStream:  deps.WSStream,
 		wshStream: deps.WSHStream,
+		wsmStream: deps.WSMStream,
 	}
 }
 
@@ -106,11 +122,7 @@ func (s *WebSocketRPC) WebSocketCollection(ctx context.Context, _ *connect.Reque
 			return nil, connect.NewError(connect.CodeInternal, err)
 		}
 		for _, ws := range wsList {
-			items = append(items, &apiv1.WebSocket{
-				WebsocketId: ws.ID.Bytes(),
-				Name:        ws.Name,
-				Url:         ws.Url,
-			})
+			items = append(items, toAPIWebSocket(ws))
 		}
 	}
 
@@ -234,6 +246,80 @@ func (s *WebSocketRPC) WebSocketHeaderSync(ctx context.Context, _ *connect.Reque
 	}
 }
 
+func (s *WebSocketRPC) WebSocketMessageCollection(ctx context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[apiv1.WebSocketMessageCollectionResponse], error) {
+	userID, err := mwauth.GetContextUserID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	workspaces, err := s.wk.GetWorkspacesByUserIDOrdered(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var items []*apiv1.WebSocketMessage
+	for _, workspace := range workspaces {
+		wsList, err := s.ws.GetByWorkspaceID(ctx, workspace.ID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, ws := range wsList {
+			messages, err := s.wsm.GetByWebSocketID(ctx, ws.ID)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			for _, m := range messages {
+				items = append(items, toAPIWebSocketMessage(m))
+			}
+		}
+	}
+
+	return connect.NewResponse(&apiv1.WebSocketMessageCollectionResponse{Items: items}), nil
+}
+
+func (s *WebSocketRPC) WebSocketMessageSync(ctx context.Context, _ *connect.Request[emptypb.Empty], stream *connect.ServerStream[apiv1.WebSocketMessageSyncResponse]) error {
+	userID, err := mwauth.GetContextUserID(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	var workspaceSet sync.Map
+	filter := func(topic WebSocketMessageTopic) bool {
+		if _, ok := workspaceSet.Load(topic.WorkspaceID.String()); ok {
+			return true
+		}
+		belongs, err := s.us.CheckUserBelongsToWorkspace(ctx, userID, topic.WorkspaceID)
+		if err != nil || !belongs {
+			return false
+		}
+		workspaceSet.Store(topic.WorkspaceID.String(), struct{}{})
+		return true
+	}
+
+	events, err := s.wsmStream.Subscribe(ctx, filter)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+			resp := webSocketMessageSyncResponseFrom(evt.Payload)
+			if resp == nil {
+				continue
+			}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func (s *WebSocketRPC) WebSocketInsert(ctx context.Context, req *connect.Request[apiv1.WebSocketInsertRequest]) (*connect.Response[emptypb.Empty], error) {
 	if len(req.Msg.GetItems()) == 0 {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one item must be provided"))
@@ -272,12 +358,16 @@ func (s *WebSocketRPC) WebSocketInsert(ctx context.Context, req *connect.Request
 			return nil, connect.NewError(connect.CodeInvalidArgument, err)
 		}
 		items = append(items, mwebsocket.WebSocket{
-			ID:          wsID,
-			WorkspaceID: defaultWorkspaceID,
-			Name:        item.GetName(),
-			Url:         item.GetUrl(),
-			CreatedAt:   now,
-			UpdatedAt:   now,
+			ID:             wsID,
+			WorkspaceID:    defaultWorkspaceID,
+			Name:           item.GetName(),
+			Url:            item.GetUrl(),
+			Subprotocols:   item.GetSubprotocols(),
+			Protocol:       fromAPIProtocol(item.GetProtocol()),
+			ConnectPayload: item.GetConnectPayload(),
+			Subscriptions:  item.GetSubscriptions(),
+			CreatedAt:      now,
+			UpdatedAt:      now,
 		})
 	}
 
@@ -301,12 +391,8 @@ func (s *WebSocketRPC) WebSocketInsert(ctx context.Context, req *connect.Request
 
 	for _, item := range items {
 		s.wsStream.Publish(WebSocketTopic{WorkspaceID: item.WorkspaceID}, WebSocketEvent{
-			Type: eventTypeInsert,
-			WebSocket: &apiv1.WebSocket{
-				WebsocketId: item.ID.Bytes(),
-				Name:        item.Name,
-				Url:         item.Url,
-			},
+			Type:      eventTypeInsert,
+			WebSocket: toAPIWebSocket(item),
 		})
 	}
 
@@ -349,6 +435,18 @@ func (s *WebSocketRPC) WebSocketUpdate(ctx context.Context, req *connect.Request
 		if item.Url != nil {
 			existing.Url = *item.Url
 		}
+		if item.Subprotocols != nil {
+			existing.Subprotocols = item.Subprotocols
+		}
+		if item.Protocol != nil {
+			existing.Protocol = fromAPIProtocol(*item.Protocol)
+		}
+		if item.ConnectPayload != nil {
+			existing.ConnectPayload = *item.ConnectPayload
+		}
+		if item.Subscriptions != nil {
+			existing.Subscriptions = item.Subscriptions
+		}
 		existing.UpdatedAt = time.Now().Unix()
 
 		updates = append(updates, *existing)
@@ -374,12 +472,8 @@ func (s *WebSocketRPC) WebSocketUpdate(ctx context.Context, req *connect.Request
 
 	for _, item := range updates {
 		s.wsStream.Publish(WebSocketTopic{WorkspaceID: item.WorkspaceID}, WebSocketEvent{
-			Type: eventTypeUpdate,
-			WebSocket: &apiv1.WebSocket{
-				WebsocketId: item.ID.Bytes(),
-				Name:        item.Name,
-				Url:         item.Url,
-			},
+			Type:      eventTypeUpdate,
+			WebSocket: toAPIWebSocket(item),
 		})
 	}
 
@@ -675,6 +769,41 @@ func (s *WebSocketRPC) WebSocketHeaderDelete(ctx context.Context, req *connect.R
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
+func toAPIWebSocket(ws mwebsocket.WebSocket) *apiv1.WebSocket {
+	return &apiv1.WebSocket{
+		WebsocketId:    ws.ID.Bytes(),
+		Name:           ws.Name,
+		Url:            ws.Url,
+		Subprotocols:   ws.Subprotocols,
+		Protocol:       toAPIProtocol(ws.Protocol),
+		ConnectPayload: ws.ConnectPayload,
+		Subscriptions:  ws.Subscriptions,
+	}
+}
+
+func toAPIProtocol(p mwebsocket.Protocol) apiv1.WebSocketProtocol {
+	switch p {
+	case mwebsocket.ProtocolSocketIO:
+		return apiv1.WebSocketProtocol_WEB_SOCKET_PROTOCOL_SOCKET_IO
+	case mwebsocket.ProtocolStomp:
+		return apiv1.WebSocketProtocol_WEB_SOCKET_PROTOCOL_STOMP
+	default:
+		return apiv1.WebSocketProtocol_WEB_SOCKET_PROTOCOL_RAW
+	}
+}
+
+// fromAPIProtocol converts an API protocol; unspecified means raw frames.
+func fromAPIProtocol(p apiv1.WebSocketProtocol) mwebsocket.Protocol {
+	switch p {
+	case apiv1.WebSocketProtocol_WEB_SOCKET_PROTOCOL_SOCKET_IO:
+		return mwebsocket.ProtocolSocketIO
+	case apiv1.WebSocketProtocol_WEB_SOCKET_PROTOCOL_STOMP:
+		return mwebsocket.ProtocolStomp
+	default:
+		return mwebsocket.ProtocolRaw
+	}
+}
+
 func toAPIWebSocketHeader(h mwebsocket.WebSocketHeader) *apiv1.WebSocketHeader {
 	return &apiv1.WebSocketHeader{
 		WebsocketHeaderId: h.ID.Bytes(),
@@ -687,10 +816,23 @@ func toAPIWebSocketHeader(h mwebsocket.WebSocketHeader) *apiv1.WebSocketHeader {
 	}
 }
 
+func toAPIWebSocketMessage(m mwebsocket.WebSocketMessage) *apiv1.WebSocketMessage {
+	return &apiv1.WebSocketMessage{
+		WebsocketMessageId: m.ID.Bytes(),
+		WebsocketId:        m.WebSocketID.Bytes(),
+		Direction:          m.Direction,
+		Type:               m.Type,
+		Data:               m.Data,
+		Time:               timestamppb.New(time.Unix(m.CreatedAt, 0)),
+	}
+}
+
 func stringPtr(s string) *string   { return &s }
 func boolPtr(b bool) *bool         { return &b }
 func float32Ptr(f float32) *float32 { return &f }
 
+func protocolPtr(p apiv1.WebSocketProtocol) *apiv1.WebSocketProtocol { return &p }
+
 func webSocketSyncResponseFrom(evt WebSocketEvent) *apiv1.WebSocketSyncResponse {
 	if evt.WebSocket == nil {
 		return nil
@@ -702,9 +844,13 @@ func webSocketSyncResponseFrom(evt WebSocketEvent) *apiv1.WebSocketSyncResponse
 			Value: &apiv1.WebSocketSync_ValueUnion{
 				Kind: apiv1.WebSocketSync_ValueUnion_KIND_INSERT,
 				Insert: &apiv1.WebSocketSyncInsert{
-					WebsocketId: evt.WebSocket.WebsocketId,
-					Name:        evt.WebSocket.Name,
-					Url:         evt.WebSocket.Url,
+					WebsocketId:    evt.WebSocket.WebsocketId,
+					Name:           evt.WebSocket.Name,
+					Url:            evt.WebSocket.Url,
+					Subprotocols:   evt.WebSocket.Subprotocols,
+					Protocol:       evt.WebSocket.Protocol,
+					ConnectPayload: evt.WebSocket.ConnectPayload,
+					Subscriptions:  evt.WebSocket.Subscriptions,
 				},
 			},
 		}
@@ -714,9 +860,13 @@ func webSocketSyncResponseFrom(evt WebSocketEvent) *apiv1.WebSocketSyncResponse
 			Value: &apiv1.WebSocketSync_ValueUnion{
 				Kind: apiv1.WebSocketSync_ValueUnion_KIND_UPDATE,
 				Update: &apiv1.WebSocketSyncUpdate{
-					WebsocketId: evt.WebSocket.WebsocketId,
-					Name:        stringPtr(evt.WebSocket.Name),
-					Url:         stringPtr(evt.WebSocket.Url),
+					WebsocketId:    evt.WebSocket.WebsocketId,
+					Name:           stringPtr(evt.WebSocket.Name),
+					Url:            stringPtr(evt.WebSocket.Url),
+					Subprotocols:   evt.WebSocket.Subprotocols,
+					Protocol:       protocolPtr(evt.WebSocket.Protocol),
+					ConnectPayload: stringPtr(evt.WebSocket.ConnectPayload),
+					Subscriptions:  evt.WebSocket.Subscriptions,
 				},
 			},
 		}
@@ -788,3 +938,39 @@ func webSocketHeaderSyncResponseFrom(evt WebSocketHeaderEvent) *apiv1.WebSocketH
 		return nil
 	}
 }
+
+func webSocketMessageSyncResponseFrom(evt WebSocketMessageEvent) *apiv1.WebSocketMessageSyncResponse {
+	if evt.WebSocketMessage == nil {
+		return nil
+	}
+
+	switch evt.Type {
+	case eventTypeInsert:
+		msg := &apiv1.WebSocketMessageSync{
+			Value: &apiv1.WebSocketMessageSync_ValueUnion{
+				Kind: apiv1.WebSocketMessageSync_ValueUnion_KIND_INSERT,
+				Insert: &apiv1.WebSocketMessageSyncInsert{
+					WebsocketMessageId: evt.WebSocketMessage.WebsocketMessageId,
+					WebsocketId:        evt.WebSocketMessage.WebsocketId,
+					Direction:          evt.WebSocketMessage.Direction,
+					Type:               evt.WebSocketMessage.Type,
+					Data:               evt.WebSocketMessage.Data,
+					Time:               evt.WebSocketMessage.Time,
+				},
+			},
+		}
+		return &apiv1.WebSocketMessageSyncResponse{Items: []*apiv1.WebSocketMessageSync{msg}}
+	case eventTypeDelete:
+		msg := &apiv1.WebSocketMessageSync{
+			Value: &apiv1.WebSocketMessageSync_ValueUnion{
+				Kind: apiv1.WebSocketMessageSync_ValueUnion_KIND_DELETE,
+				Delete: &apiv1.WebSocketMessageSyncDelete{
+					WebsocketMessageId: evt.WebSocketMessage.WebsocketMessageId,
+				},
+			},
+		}
+		return &apiv1.WebSocketMessageSyncResponse{Items: []*apiv1.WebSocketMessageSync{msg}}
+	default:
+		return nil
+	}
+}
@@ -2,20 +2,25 @@ package rwebsocket
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/coder/websocket"
 
 	"github.com/the-dev-tools/dev-tools/packages/server/internal/api/middleware/mwauth"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
 )
 
 // WebSocketProxyHandler returns an HTTP handler that proxies WebSocket connections.
 // The client connects to this endpoint, which loads headers from the database,
 // dials the target WebSocket server with those headers, and relays messages
-// bidirectionally between client and target.
+// bidirectionally between client and target. The subprotocol the target
+// picks is passed on to the client, and every relayed frame is added to the
+// WebSocket's message history.
 func (s *WebSocketRPC) WebSocketProxyHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		idStr := r.URL.Query().Get("id")
@@ -54,7 +59,8 @@ func (s *WebSocketRPC) WebSocketProxyHandler() http.Handler {
 
 		// Dial target before accepting client upgrade so failures return HTTP errors
 		targetConn, resp, err := websocket.Dial(ctx, wsEntity.Url, &websocket.DialOptions{
-			HTTPHeader: targetHeaders,
+			HTTPHeader:   targetHeaders,
+			Subprotocols: wsEntity.Subprotocols,
 		})
 		if err != nil {
 			http.Error(w, fmt.Sprintf("failed to connect to target: %v", err), http.StatusBadGateway)
@@ -69,6 +75,7 @@ func (s *WebSocketRPC) WebSocketProxyHandler() http.Handler {
 		// Accept client WebSocket upgrade
 		clientConn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
 			InsecureSkipVerify: true,
+			Subprotocols:       acceptedSubprotocols(targetConn.Subprotocol()),
 		})
 		if err != nil {
 			slog.Error("failed to accept client ws upgrade", "error", err)
@@ -92,6 +99,7 @@ func (s *WebSocketRPC) WebSocketProxyHandler() http.Handler {
 				if err := targetConn.Write(ctx, typ, msg); err != nil {
 					return
 				}
+				s.recordMessage(ctx, wsEntity.WorkspaceID, wsEntity.ID, mwebsocket.MessageDirectionSent, typ, msg)
 			}
 		}()
 
@@ -101,9 +109,47 @@ func (s *WebSocketRPC) WebSocketProxyHandler() http.Handler {
 			if err != nil {
 				return
 			}
+			s.recordMessage(ctx, wsEntity.WorkspaceID, wsEntity.ID, mwebsocket.MessageDirectionReceived, typ, msg)
 			if err := clientConn.Write(ctx, typ, msg); err != nil {
 				return
 			}
 		}
 	})
 }
+
+// acceptedSubprotocols offers the client the subprotocol the target picked,
+// if any, so both ends of the proxy speak the same one.
+func acceptedSubprotocols(protocol string) []string {
+	if protocol == "" {
+		return nil
+	}
+	return []string{protocol}
+}
+
+// recordMessage adds a relayed frame to the history of the WebSocket and
+// publishes it. Failing to record does not interrupt the relay.
+func (s *WebSocketRPC) recordMessage(ctx context.Context, workspaceID, wsID idwrap.IDWrap, direction string, typ websocket.MessageType, data []byte) {
+	msg := mwebsocket.WebSocketMessage{
+		ID:          idwrap.NewMonotonic(),
+		WebSocketID: wsID,
+		Direction:   direction,
+		Type:        mwebsocket.MessageTypeText,
+		Data:        string(data),
+		CreatedAt:   time.Now().Unix(),
+	}
+	if typ == websocket.MessageBinary {
+		msg.Type = mwebsocket.MessageTypeBinary
+		msg.Data = base64.StdEncoding.EncodeToString(data)
+	}
+
+	if err := s.wsm.Create(ctx, msg); err != nil {
+		slog.Warn("failed to record websocket message", "websocket_id", wsID.String(), "error", err)
+		return
+	}
+	if s.wsmStream != nil {
+		s.wsmStream.Publish(WebSocketMessageTopic{WorkspaceID: workspaceID}, WebSocketMessageEvent{
+			Type:             eventTypeInsert,
+			WebSocketMessage: toAPIWebSocketMessage(msg),
+		})
+	}
+}
@@ -385,10 +385,28 @@ func ToAPINodeKind(kind mflow.NodeKind) flowv1.NodeKind {
 		return flowv1.NodeKind_NODE_KIND_AI_MEMORY
 	case mflow.NODE_KIND_GRAPHQL:
 		return flowv1.NodeKind_NODE_KIND_GRAPH_Q_L
+	case mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION:
+		return flowv1.NodeKind_NODE_KIND_GRAPH_Q_L_SUBSCRIPTION
 	case mflow.NODE_KIND_WS_CONNECTION:
 		return flowv1.NodeKind_NODE_KIND_WS_CONNECTION
 	case mflow.NODE_KIND_WS_SEND:
 		return flowv1.NodeKind_NODE_KIND_WS_SEND
+	case mflow.NODE_KIND_WS_EXPECT:
+		return flowv1.NodeKind_NODE_KIND_WS_EXPECT
+	case mflow.NODE_KIND_SOCKET_CONNECTION:
+		return flowv1.NodeKind_NODE_KIND_SOCKET_CONNECTION
+	case mflow.NODE_KIND_SOCKET_SEND:
+		return flowv1.NodeKind_NODE_KIND_SOCKET_SEND
+	case mflow.NODE_KIND_SOCKET_RECEIVE:
+		return flowv1.NodeKind_NODE_KIND_SOCKET_RECEIVE
+	case mflow.NODE_KIND_DB_QUERY:
+		return flowv1.NodeKind_NODE_KIND_DB_QUERY
+	case mflow.NODE_KIND_MQ_PUBLISH:
+		return flowv1.NodeKind_NODE_KIND_MQ_PUBLISH
+	case mflow.NODE_KIND_MQ_CONSUME:
+		return flowv1.NodeKind_NODE_KIND_MQ_CONSUME
+	case mflow.NODE_KIND_EXEC:
+		return flowv1.NodeKind_NODE_KIND_EXEC
 	case mflow.NODE_KIND_WAIT:
 		return flowv1.NodeKind_NODE_KIND_WAIT
 	case mflow.NODE_KIND_WEBHOOK_TRIGGER:
@@ -399,6 +417,16 @@ func ToAPINodeKind(kind mflow.NodeKind) flowv1.NodeKind {
 		return flowv1.NodeKind_NODE_KIND_SUB_FLOW_RETURN
 	case mflow.NODE_KIND_RUN_SUB_FLOW:
 		return flowv1.NodeKind_NODE_KIND_RUN_SUB_FLOW
+	case mflow.NODE_KIND_TRY:
+		return flowv1.NodeKind_NODE_KIND_TRY
+	case mflow.NODE_KIND_PARALLEL:
+		return flowv1.NodeKind_NODE_KIND_PARALLEL
+	case mflow.NODE_KIND_POLL:
+		return flowv1.NodeKind_NODE_KIND_POLL
+	case mflow.NODE_KIND_SWITCH:
+		return flowv1.NodeKind_NODE_KIND_SWITCH
+	case mflow.NODE_KIND_TEARDOWN:
+		return flowv1.NodeKind_NODE_KIND_TEARDOWN
 	default:
 		return flowv1.NodeKind_NODE_KIND_UNSPECIFIED
 	}
@@ -449,6 +477,22 @@ func ToAPICredentialAnthropic(cred mcredential.CredentialAnthropic) *credentialv
 	return api
 }
 
+// ToAPICredentialDatabase converts model CredentialDatabase to API CredentialDatabase
+func ToAPICredentialDatabase(cred mcredential.CredentialDatabase) *credentialv1.CredentialDatabase {
+	return &credentialv1.CredentialDatabase{
+		CredentialId:     cred.CredentialID.Bytes(),
+		ConnectionString: cred.ConnectionString,
+	}
+}
+
+// ToAPICredentialMessageBroker converts model CredentialMessageBroker to API CredentialMessageBroker
+func ToAPICredentialMessageBroker(cred mcredential.CredentialMessageBroker) *credentialv1.CredentialMessageBroker {
+	return &credentialv1.CredentialMessageBroker{
+		CredentialId: cred.CredentialID.Bytes(),
+		Url:          cred.Url,
+	}
+}
+
 // ToAPICredentialKind converts model CredentialKind to API CredentialKind
 func ToAPICredentialKind(kind mcredential.CredentialKind) credentialv1.CredentialKind {
 	switch kind {
@@ -458,6 +502,10 @@ func ToAPICredentialKind(kind mcredential.CredentialKind) credentialv1.Credentia
 		return credentialv1.CredentialKind_CREDENTIAL_KIND_GEMINI
 	case mcredential.CREDENTIAL_KIND_ANTHROPIC:
 		return credentialv1.CredentialKind_CREDENTIAL_KIND_ANTHROPIC
+	case mcredential.CREDENTIAL_KIND_DATABASE:
+		return credentialv1.CredentialKind_CREDENTIAL_KIND_DATABASE
+	case mcredential.CREDENTIAL_KIND_MESSAGE_BROKER:
+		return credentialv1.CredentialKind_CREDENTIAL_KIND_MESSAGE_BROKER
 	default:
 		return credentialv1.CredentialKind_CREDENTIAL_KIND_UNSPECIFIED
 	}
@@ -472,6 +520,10 @@ func ToModelCredentialKind(kind credentialv1.CredentialKind) mcredential.Credent
 		return mcredential.CREDENTIAL_KIND_GEMINI
 	case credentialv1.CredentialKind_CREDENTIAL_KIND_ANTHROPIC:
 		return mcredential.CREDENTIAL_KIND_ANTHROPIC
+	case credentialv1.CredentialKind_CREDENTIAL_KIND_DATABASE:
+		return mcredential.CREDENTIAL_KIND_DATABASE
+	case credentialv1.CredentialKind_CREDENTIAL_KIND_MESSAGE_BROKER:
+		return mcredential.CREDENTIAL_KIND_MESSAGE_BROKER
 	default:
 		return mcredential.CREDENTIAL_KIND_OPENAI // Default to OpenAI
 	}
@@ -491,6 +543,102 @@ func ToAPIErrorHandling(eh mflow.ErrorHandling) flowv1.ErrorHandling {
 	}
 }
 
+// ToAPIParallelJoin converts model ParallelJoin to API ParallelJoin
+func ToAPIParallelJoin(join mflow.ParallelJoin) flowv1.ParallelJoin {
+	switch join {
+	case mflow.ParallelJoinAny:
+		return flowv1.ParallelJoin_PARALLEL_JOIN_ANY
+	case mflow.ParallelJoinFirstSuccess:
+		return flowv1.ParallelJoin_PARALLEL_JOIN_FIRST_SUCCESS
+	default:
+		return flowv1.ParallelJoin_PARALLEL_JOIN_ALL
+	}
+}
+
+// FromAPIParallelJoin converts API ParallelJoin to model ParallelJoin.
+// Unspecified means waiting for all branches.
+func FromAPIParallelJoin(join flowv1.ParallelJoin) mflow.ParallelJoin {
+	switch join {
+	case flowv1.ParallelJoin_PARALLEL_JOIN_ANY:
+		return mflow.ParallelJoinAny
+	case flowv1.ParallelJoin_PARALLEL_JOIN_FIRST_SUCCESS:
+		return mflow.ParallelJoinFirstSuccess
+	default:
+		return mflow.ParallelJoinAll
+	}
+}
+
+// ToAPISocketNetwork converts model SocketNetwork to API SocketNetwork
+func ToAPISocketNetwork(network mflow.SocketNetwork) flowv1.SocketNetwork {
+	switch network {
+	case mflow.SocketNetworkUDP:
+		return flowv1.SocketNetwork_SOCKET_NETWORK_UDP
+	default:
+		return flowv1.SocketNetwork_SOCKET_NETWORK_TCP
+	}
+}
+
+// FromAPISocketNetwork converts API SocketNetwork to model SocketNetwork.
+// Unspecified means TCP.
+func FromAPISocketNetwork(network flowv1.SocketNetwork) mflow.SocketNetwork {
+	switch network {
+	case flowv1.SocketNetwork_SOCKET_NETWORK_UDP:
+		return mflow.SocketNetworkUDP
+	default:
+		return mflow.SocketNetworkTCP
+	}
+}
+
+// ToAPISocketFraming converts model SocketFraming to API SocketFraming
+func ToAPISocketFraming(framing mflow.SocketFraming) flowv1.SocketFraming {
+	switch framing {
+	case mflow.SocketFramingDelimiter:
+		return flowv1.SocketFraming_SOCKET_FRAMING_DELIMITER
+	case mflow.SocketFramingLength:
+		return flowv1.SocketFraming_SOCKET_FRAMING_LENGTH
+	default:
+		return flowv1.SocketFraming_SOCKET_FRAMING_NONE
+	}
+}
+
+// FromAPISocketFraming converts API SocketFraming to model SocketFraming.
+// Unspecified means no framing.
+func FromAPISocketFraming(framing flowv1.SocketFraming) mflow.SocketFraming {
+	switch framing {
+	case flowv1.SocketFraming_SOCKET_FRAMING_DELIMITER:
+		return mflow.SocketFramingDelimiter
+	case flowv1.SocketFraming_SOCKET_FRAMING_LENGTH:
+		return mflow.SocketFramingLength
+	default:
+		return mflow.SocketFramingNone
+	}
+}
+
+// ToAPISocketEncoding converts model SocketEncoding to API SocketEncoding
+func ToAPISocketEncoding(encoding mflow.SocketEncoding) flowv1.SocketEncoding {
+	switch encoding {
+	case mflow.SocketEncodingHex:
+		return flowv1.SocketEncoding_SOCKET_ENCODING_HEX
+	case mflow.SocketEncodingBase64:
+		return flowv1.SocketEncoding_SOCKET_ENCODING_BASE64
+	default:
+		return flowv1.SocketEncoding_SOCKET_ENCODING_TEXT
+	}
+}
+
+// FromAPISocketEncoding converts API SocketEncoding to model SocketEncoding.
+// Unspecified means text.
+func FromAPISocketEncoding(encoding flowv1.SocketEncoding) mflow.SocketEncoding {
+	switch encoding {
+	case flowv1.SocketEncoding_SOCKET_ENCODING_HEX:
+		return mflow.SocketEncodingHex
+	case flowv1.SocketEncoding_SOCKET_ENCODING_BASE64:
+		return mflow.SocketEncodingBase64
+	default:
+		return mflow.SocketEncodingText
+	}
+}
+
 // ToAPIGraphQLAssert converts model GraphQLAssert to API GraphQLAssert
 func ToAPIGraphQLAssert(assert mgraphql.GraphQLAssert) *graphqlv1.GraphQLAssert {
 	return &graphqlv1.GraphQLAssert{
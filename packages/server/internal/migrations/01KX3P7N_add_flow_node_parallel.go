@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddFlowNodeParallelID = "01KX3P7NB4R2QJ8W5FMZ6TGHDA"
+
+const MigrationAddFlowNodeParallelChecksum = "sha256:add-flow-node-parallel-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddFlowNodeParallelID,
+		Checksum:       MigrationAddFlowNodeParallelChecksum,
+		Description:    "Add flow_node_parallel table and for_each concurrency column",
+		Apply:          applyFlowNodeParallel,
+		Validate:       validateFlowNodeParallel,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register flow_node_parallel migration: " + err.Error())
+	}
+}
+
+func applyFlowNodeParallel(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS flow_node_parallel (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			join_mode INT8 NOT NULL,
+			concurrency INT NOT NULL
+		)
+	`); err != nil {
+		return fmt.Errorf("create flow_node_parallel table: %w", err)
+	}
+
+	var count int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM pragma_table_info('flow_node_for_each')
+		WHERE name = 'concurrency'
+	`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("check concurrency column: %w", err)
+	}
+	if count == 0 {
+		if _, err := tx.ExecContext(ctx, `ALTER TABLE flow_node_for_each ADD COLUMN concurrency INT NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add concurrency column: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateFlowNodeParallel(ctx context.Context, db *sql.DB) error {
+	var name string
+	err := db.QueryRowContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='flow_node_parallel'
+	`).Scan(&name)
+	if err != nil {
+		return fmt.Errorf("flow_node_parallel table not found: %w", err)
+	}
+
+	var count int
+	err = db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM pragma_table_info('flow_node_for_each')
+		WHERE name = 'concurrency'
+	`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("validate concurrency column: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("concurrency column not found on flow_node_for_each table")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddFlowNodePollID = "01KX8G2CJ6N4T0VWQ3HBXK9RZE"
+
+const MigrationAddFlowNodePollChecksum = "sha256:add-flow-node-poll-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddFlowNodePollID,
+		Checksum:       MigrationAddFlowNodePollChecksum,
+		Description:    "Add flow_node_poll table for poll/wait-until nodes",
+		Apply:          applyFlowNodePoll,
+		Validate:       validateFlowNodePoll,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register flow_node_poll migration: " + err.Error())
+	}
+}
+
+func applyFlowNodePoll(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS flow_node_poll (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			until_expression TEXT NOT NULL,
+			interval_ms BIGINT NOT NULL,
+			backoff REAL NOT NULL,
+			max_duration_ms BIGINT NOT NULL,
+			max_attempts INT NOT NULL
+		)
+	`); err != nil {
+		return fmt.Errorf("create flow_node_poll table: %w", err)
+	}
+	return nil
+}
+
+func validateFlowNodePoll(ctx context.Context, db *sql.DB) error {
+	var name string
+	err := db.QueryRowContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='flow_node_poll'
+	`).Scan(&name)
+	if err != nil {
+		return fmt.Errorf("flow_node_poll table not found: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddFlowNodeSwitchID = "01KXB7QMD4W8ZC2FNJ5TRY3H6P"
+
+const MigrationAddFlowNodeSwitchChecksum = "sha256:add-flow-node-switch-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddFlowNodeSwitchID,
+		Checksum:       MigrationAddFlowNodeSwitchChecksum,
+		Description:    "Add flow_node_switch table for multi-way switch nodes",
+		Apply:          applyFlowNodeSwitch,
+		Validate:       validateFlowNodeSwitch,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register flow_node_switch migration: " + err.Error())
+	}
+}
+
+func applyFlowNodeSwitch(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS flow_node_switch (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			cases BLOB NOT NULL DEFAULT '[]'
+		)
+	`); err != nil {
+		return fmt.Errorf("create flow_node_switch table: %w", err)
+	}
+	return nil
+}
+
+func validateFlowNodeSwitch(ctx context.Context, db *sql.DB) error {
+	var name string
+	err := db.QueryRowContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='flow_node_switch'
+	`).Scan(&name)
+	if err != nil {
+		return fmt.Errorf("flow_node_switch table not found: %w", err)
+	}
+	return nil
+}
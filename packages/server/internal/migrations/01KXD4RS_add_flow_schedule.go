@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddFlowScheduleID = "01KXD4RS9VQ2M7T3WZB6NCF8HY"
+
+const MigrationAddFlowScheduleChecksum = "sha256:add-flow-schedule-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddFlowScheduleID,
+		Checksum:       MigrationAddFlowScheduleChecksum,
+		Description:    "Add flow_schedule and flow_schedule_run tables for scheduled flow runs",
+		Apply:          applyFlowSchedule,
+		Validate:       validateFlowSchedule,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register flow_schedule migration: " + err.Error())
+	}
+}
+
+func applyFlowSchedule(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS flow_schedule (
+			id BLOB NOT NULL PRIMARY KEY,
+			flow_id BLOB NOT NULL,
+			cron_expression TEXT NOT NULL DEFAULT '',
+			interval_seconds BIGINT NOT NULL DEFAULT 0,
+			environment_id BLOB,
+			enabled BOOL NOT NULL,
+			last_run_at BIGINT,
+			last_state INT8 NOT NULL DEFAULT 0,
+			FOREIGN KEY (flow_id) REFERENCES flow (id) ON DELETE CASCADE,
+			FOREIGN KEY (environment_id) REFERENCES environment (id) ON DELETE SET NULL
+		)
+	`); err != nil {
+		return fmt.Errorf("create flow_schedule table: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS flow_schedule_run (
+			id BLOB NOT NULL PRIMARY KEY,
+			schedule_id BLOB NOT NULL,
+			flow_version_id BLOB,
+			state INT8 NOT NULL,
+			error TEXT,
+			started_at BIGINT NOT NULL,
+			duration_ms BIGINT NOT NULL,
+			FOREIGN KEY (schedule_id) REFERENCES flow_schedule (id) ON DELETE CASCADE
+		)
+	`); err != nil {
+		return fmt.Errorf("create flow_schedule_run table: %w", err)
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS flow_schedule_idx1 ON flow_schedule (flow_id)`,
+		`CREATE INDEX IF NOT EXISTS flow_schedule_run_idx1 ON flow_schedule_run (schedule_id, started_at DESC)`,
+	}
+	for _, idx := range indexes {
+		if _, err := tx.ExecContext(ctx, idx); err != nil {
+			return fmt.Errorf("create flow_schedule index: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateFlowSchedule(ctx context.Context, db *sql.DB) error {
+	for _, table := range []string{"flow_schedule", "flow_schedule_run"} {
+		var name string
+		err := db.QueryRowContext(ctx, `
+			SELECT name FROM sqlite_master
+			WHERE type='table' AND name=?
+		`, table).Scan(&name)
+		if err != nil {
+			return fmt.Errorf("%s table not found: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddFlowNodeWebhookTriggerID = "01KXE2WHB7N4QT8ZK3MDR6VF5C"
+
+const MigrationAddFlowNodeWebhookTriggerChecksum = "sha256:add-flow-node-webhook-trigger-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddFlowNodeWebhookTriggerID,
+		Checksum:       MigrationAddFlowNodeWebhookTriggerChecksum,
+		Description:    "Add flow_node_webhook_trigger table for inbound webhook entry nodes",
+		Apply:          applyFlowNodeWebhookTrigger,
+		Validate:       validateFlowNodeWebhookTrigger,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register flow_node_webhook_trigger migration: " + err.Error())
+	}
+}
+
+func applyFlowNodeWebhookTrigger(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS flow_node_webhook_trigger (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			path TEXT NOT NULL DEFAULT '',
+			method TEXT NOT NULL DEFAULT '',
+			secret TEXT NOT NULL DEFAULT '',
+			signature_header TEXT NOT NULL DEFAULT ''
+		)
+	`); err != nil {
+		return fmt.Errorf("create flow_node_webhook_trigger table: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS flow_node_webhook_trigger_idx1 ON flow_node_webhook_trigger (path)
+	`); err != nil {
+		return fmt.Errorf("create flow_node_webhook_trigger_idx1: %w", err)
+	}
+	return nil
+}
+
+func validateFlowNodeWebhookTrigger(ctx context.Context, db *sql.DB) error {
+	var name string
+	err := db.QueryRowContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='flow_node_webhook_trigger'
+	`).Scan(&name)
+	if err != nil {
+		return fmt.Errorf("flow_node_webhook_trigger table not found: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddFlowNodeGraphQLSubscriptionID = "01KXF6GSM2QW7RT4HJ9KCN3XPD"
+
+const MigrationAddFlowNodeGraphQLSubscriptionChecksum = "sha256:add-flow-node-graphql-subscription-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddFlowNodeGraphQLSubscriptionID,
+		Checksum:       MigrationAddFlowNodeGraphQLSubscriptionChecksum,
+		Description:    "Add flow_node_graphql_subscription table for GraphQL subscription nodes",
+		Apply:          applyFlowNodeGraphQLSubscription,
+		Validate:       validateFlowNodeGraphQLSubscription,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register flow_node_graphql_subscription migration: " + err.Error())
+	}
+}
+
+func applyFlowNodeGraphQLSubscription(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS flow_node_graphql_subscription (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			graphql_id BLOB NOT NULL,
+			delta_graphql_id BLOB,
+			connection_payload TEXT NOT NULL DEFAULT '',
+			max_events INTEGER NOT NULL DEFAULT 0,
+			timeout_ms INTEGER NOT NULL DEFAULT 0,
+			FOREIGN KEY (graphql_id) REFERENCES graphql (id) ON DELETE CASCADE,
+			FOREIGN KEY (delta_graphql_id) REFERENCES graphql (id) ON DELETE SET NULL
+		)
+	`); err != nil {
+		return fmt.Errorf("create flow_node_graphql_subscription table: %w", err)
+	}
+	return nil
+}
+
+func validateFlowNodeGraphQLSubscription(ctx context.Context, db *sql.DB) error {
+	var name string
+	err := db.QueryRowContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='flow_node_graphql_subscription'
+	`).Scan(&name)
+	if err != nil {
+		return fmt.Errorf("flow_node_graphql_subscription table not found: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddGraphQLSchemaID = "01KXG3VQN7HB2KD5TZ8RWM4YCE"
+
+const MigrationAddGraphQLSchemaChecksum = "sha256:add-graphql-schema-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddGraphQLSchemaID,
+		Checksum:       MigrationAddGraphQLSchemaChecksum,
+		Description:    "Add graphql_schema table caching introspection results per GraphQL request",
+		Apply:          applyGraphQLSchema,
+		Validate:       validateGraphQLSchema,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register graphql_schema migration: " + err.Error())
+	}
+}
+
+func applyGraphQLSchema(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS graphql_schema (
+			graphql_id BLOB NOT NULL PRIMARY KEY,
+			introspection TEXT NOT NULL,
+			fetched_at BIGINT NOT NULL DEFAULT (unixepoch()),
+			FOREIGN KEY (graphql_id) REFERENCES graphql (id) ON DELETE CASCADE
+		)
+	`); err != nil {
+		return fmt.Errorf("create graphql_schema table: %w", err)
+	}
+	return nil
+}
+
+func validateGraphQLSchema(ctx context.Context, db *sql.DB) error {
+	var name string
+	err := db.QueryRowContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='graphql_schema'
+	`).Scan(&name)
+	if err != nil {
+		return fmt.Errorf("graphql_schema table not found: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddGraphQLOperationNameID = "01KXHB4TQ2M8VNE6JC3YFZK7PD"
+
+const MigrationAddGraphQLOperationNameChecksum = "sha256:add-graphql-operation-name-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddGraphQLOperationNameID,
+		Checksum:       MigrationAddGraphQLOperationNameChecksum,
+		Description:    "Add operation_name and query_file columns to graphql table",
+		Apply:          applyGraphQLOperationName,
+		Validate:       validateGraphQLOperationName,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register graphql operation_name migration: " + err.Error())
+	}
+}
+
+func applyGraphQLOperationName(ctx context.Context, tx *sql.Tx) error {
+	columns := []struct {
+		name string
+		ddl  string
+	}{
+		{"operation_name", `ALTER TABLE graphql ADD COLUMN operation_name TEXT NOT NULL DEFAULT ''`},
+		{"query_file", `ALTER TABLE graphql ADD COLUMN query_file TEXT NOT NULL DEFAULT ''`},
+	}
+
+	for _, col := range columns {
+		var count int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM pragma_table_info('graphql')
+			WHERE name = ?
+		`, col.name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("check %s column: %w", col.name, err)
+		}
+		if count == 0 {
+			if _, err := tx.ExecContext(ctx, col.ddl); err != nil {
+				return fmt.Errorf("add %s column: %w", col.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateGraphQLOperationName(ctx context.Context, db *sql.DB) error {
+	for _, col := range []string{"operation_name", "query_file"} {
+		var count int
+		err := db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM pragma_table_info('graphql')
+			WHERE name = ?
+		`, col).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("validate %s column: %w", col, err)
+		}
+		if count == 0 {
+			return fmt.Errorf("%s column not found on graphql table", col)
+		}
+	}
+	return nil
+}
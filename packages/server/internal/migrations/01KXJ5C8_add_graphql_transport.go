@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddGraphQLTransportID = "01KXJ5C8R7WZ3HQ6MN2TB9VXEA"
+
+const MigrationAddGraphQLTransportChecksum = "sha256:add-graphql-transport-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddGraphQLTransportID,
+		Checksum:       MigrationAddGraphQLTransportChecksum,
+		Description:    "Add persisted_query, batch and uploads columns to graphql table",
+		Apply:          applyGraphQLTransport,
+		Validate:       validateGraphQLTransport,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register graphql transport migration: " + err.Error())
+	}
+}
+
+func applyGraphQLTransport(ctx context.Context, tx *sql.Tx) error {
+	columns := []struct {
+		name string
+		ddl  string
+	}{
+		{"persisted_query", `ALTER TABLE graphql ADD COLUMN persisted_query BOOLEAN NOT NULL DEFAULT FALSE`},
+		{"batch", `ALTER TABLE graphql ADD COLUMN batch BLOB NOT NULL DEFAULT '[]'`},
+		{"uploads", `ALTER TABLE graphql ADD COLUMN uploads BLOB NOT NULL DEFAULT '[]'`},
+	}
+
+	for _, col := range columns {
+		var count int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM pragma_table_info('graphql')
+			WHERE name = ?
+		`, col.name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("check %s column: %w", col.name, err)
+		}
+		if count == 0 {
+			if _, err := tx.ExecContext(ctx, col.ddl); err != nil {
+				return fmt.Errorf("add %s column: %w", col.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateGraphQLTransport(ctx context.Context, db *sql.DB) error {
+	for _, col := range []string{"persisted_query", "batch", "uploads"} {
+		var count int
+		err := db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM pragma_table_info('graphql')
+			WHERE name = ?
+		`, col).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("validate %s column: %w", col, err)
+		}
+		if count == 0 {
+			return fmt.Errorf("%s column not found on graphql table", col)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddWebSocketExpectID = "01KXKA7WQ3N8ZB5RT2MJH6VCPD"
+
+const MigrationAddWebSocketExpectChecksum = "sha256:add-websocket-expect-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddWebSocketExpectID,
+		Checksum:       MigrationAddWebSocketExpectChecksum,
+		Description:    "Add flow_node_ws_expect and websocket_message tables, websocket subprotocols and ws send binary columns",
+		Apply:          applyWebSocketExpect,
+		Validate:       validateWebSocketExpect,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register websocket expect migration: " + err.Error())
+	}
+}
+
+func applyWebSocketExpect(ctx context.Context, tx *sql.Tx) error {
+	// 1. Create flow_node_ws_expect table
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS flow_node_ws_expect (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			ws_connection_node_name TEXT NOT NULL DEFAULT '',
+			message TEXT NOT NULL DEFAULT '',
+			binary BOOLEAN NOT NULL DEFAULT FALSE,
+			match_expression TEXT NOT NULL DEFAULT '',
+			timeout_ms INTEGER NOT NULL DEFAULT 0,
+			count INTEGER NOT NULL DEFAULT 0,
+			assertions BLOB NOT NULL DEFAULT '[]',
+			ping BOOLEAN NOT NULL DEFAULT FALSE,
+			expect_close BOOLEAN NOT NULL DEFAULT FALSE
+		)
+	`); err != nil {
+		return fmt.Errorf("create flow_node_ws_expect table: %w", err)
+	}
+
+	// 2. Create websocket_message table
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS websocket_message (
+			id BLOB NOT NULL PRIMARY KEY,
+			websocket_id BLOB NOT NULL,
+			direction TEXT NOT NULL,
+			message_type TEXT NOT NULL DEFAULT 'text',
+			data TEXT NOT NULL,
+			created_at BIGINT NOT NULL DEFAULT (unixepoch()),
+
+			FOREIGN KEY (websocket_id) REFERENCES websocket (id) ON DELETE CASCADE
+		)
+	`); err != nil {
+		return fmt.Errorf("create websocket_message table: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS websocket_message_ws_idx ON websocket_message (websocket_id, id)
+	`); err != nil {
+		return fmt.Errorf("create websocket_message index: %w", err)
+	}
+
+	// 3. Add columns to existing tables
+	columns := []struct {
+		table string
+		name  string
+		ddl   string
+	}{
+		{"websocket", "subprotocols", `ALTER TABLE websocket ADD COLUMN subprotocols BLOB NOT NULL DEFAULT '[]'`},
+		{"flow_node_ws_send", "binary", `ALTER TABLE flow_node_ws_send ADD COLUMN binary BOOLEAN NOT NULL DEFAULT FALSE`},
+	}
+	for _, col := range columns {
+		var count int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM pragma_table_info(?)
+			WHERE name = ?
+		`, col.table, col.name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("check %s.%s column: %w", col.table, col.name, err)
+		}
+		if count == 0 {
+			if _, err := tx.ExecContext(ctx, col.ddl); err != nil {
+				return fmt.Errorf("add %s.%s column: %w", col.table, col.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateWebSocketExpect(ctx context.Context, db *sql.DB) error {
+	for _, table := range []string{"flow_node_ws_expect", "websocket_message"} {
+		var name string
+		err := db.QueryRowContext(ctx, `
+			SELECT name FROM sqlite_master
+			WHERE type='table' AND name=?
+		`, table).Scan(&name)
+		if err != nil {
+			return fmt.Errorf("%s table not found: %w", table, err)
+		}
+	}
+	for _, col := range []struct{ table, name string }{
+		{"websocket", "subprotocols"},
+		{"flow_node_ws_send", "binary"},
+	} {
+		var count int
+		err := db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM pragma_table_info(?)
+			WHERE name = ?
+		`, col.table, col.name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("validate %s.%s column: %w", col.table, col.name, err)
+		}
+		if count == 0 {
+			return fmt.Errorf("%s column not found on %s table", col.name, col.table)
+		}
+	}
+	return nil
+}
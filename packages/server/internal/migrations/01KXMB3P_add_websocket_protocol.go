@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddWebSocketProtocolID = "01KXMB3PV7T2HQ9DX4NWE5RKAC"
+
+const MigrationAddWebSocketProtocolChecksum = "sha256:add-websocket-protocol-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddWebSocketProtocolID,
+		Checksum:       MigrationAddWebSocketProtocolChecksum,
+		Description:    "Add websocket protocol, connect payload and subscriptions, and ws send event and ack columns",
+		Apply:          applyWebSocketProtocol,
+		Validate:       validateWebSocketProtocol,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register websocket protocol migration: " + err.Error())
+	}
+}
+
+// webSocketProtocolColumns are the columns added by the websocket protocol migration.
+var webSocketProtocolColumns = []struct {
+	table string
+	name  string
+	ddl   string
+}{
+	{"websocket", "protocol", `ALTER TABLE websocket ADD COLUMN protocol INT8 NOT NULL DEFAULT 0`},
+	{"websocket", "connect_payload", `ALTER TABLE websocket ADD COLUMN connect_payload TEXT NOT NULL DEFAULT ''`},
+	{"websocket", "subscriptions", `ALTER TABLE websocket ADD COLUMN subscriptions BLOB NOT NULL DEFAULT '[]'`},
+	{"flow_node_ws_send", "event", `ALTER TABLE flow_node_ws_send ADD COLUMN event TEXT NOT NULL DEFAULT ''`},
+	{"flow_node_ws_send", "ack", `ALTER TABLE flow_node_ws_send ADD COLUMN ack BOOLEAN NOT NULL DEFAULT FALSE`},
+}
+
+func applyWebSocketProtocol(ctx context.Context, tx *sql.Tx) error {
+	for _, col := range webSocketProtocolColumns {
+		var count int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM pragma_table_info(?)
+			WHERE name = ?
+		`, col.table, col.name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("check %s.%s column: %w", col.table, col.name, err)
+		}
+		if count == 0 {
+			if _, err := tx.ExecContext(ctx, col.ddl); err != nil {
+				return fmt.Errorf("add %s.%s column: %w", col.table, col.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateWebSocketProtocol(ctx context.Context, db *sql.DB) error {
+	for _, col := range webSocketProtocolColumns {
+		var count int
+		err := db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM pragma_table_info(?)
+			WHERE name = ?
+		`, col.table, col.name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("validate %s.%s column: %w", col.table, col.name, err)
+		}
+		if count == 0 {
+			return fmt.Errorf("%s column not found on %s table", col.name, col.table)
+		}
+	}
+	return nil
+}
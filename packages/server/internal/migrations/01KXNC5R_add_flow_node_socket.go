@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddFlowNodeSocketID = "01KXNC5RJ2W8QF4TB7HZ3MXKEV"
+
+const MigrationAddFlowNodeSocketChecksum = "sha256:add-flow-node-socket-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddFlowNodeSocketID,
+		Checksum:       MigrationAddFlowNodeSocketChecksum,
+		Description:    "Add flow_node_socket_connection, flow_node_socket_send and flow_node_socket_receive tables",
+		Apply:          applyFlowNodeSocket,
+		Validate:       validateFlowNodeSocket,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register flow node socket migration: " + err.Error())
+	}
+}
+
+// flowNodeSocketTables are the tables added for the socket node family.
+var flowNodeSocketTables = []struct {
+	name string
+	ddl  string
+}{
+	{"flow_node_socket_connection", `
+		CREATE TABLE IF NOT EXISTS flow_node_socket_connection (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			network INT8 NOT NULL DEFAULT 0,
+			address TEXT NOT NULL DEFAULT '',
+			tls BOOLEAN NOT NULL DEFAULT FALSE,
+			tls_skip_verify BOOLEAN NOT NULL DEFAULT FALSE,
+			framing INT8 NOT NULL DEFAULT 0,
+			delimiter TEXT NOT NULL DEFAULT '',
+			length_bytes INTEGER NOT NULL DEFAULT 0,
+			timeout_ms INTEGER NOT NULL DEFAULT 0
+		)
+	`},
+	{"flow_node_socket_send", `
+		CREATE TABLE IF NOT EXISTS flow_node_socket_send (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			socket_connection_node_name TEXT NOT NULL DEFAULT '',
+			message TEXT NOT NULL DEFAULT '',
+			encoding INT8 NOT NULL DEFAULT 0
+		)
+	`},
+	{"flow_node_socket_receive", `
+		CREATE TABLE IF NOT EXISTS flow_node_socket_receive (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			socket_connection_node_name TEXT NOT NULL DEFAULT '',
+			match_expression TEXT NOT NULL DEFAULT '',
+			timeout_ms INTEGER NOT NULL DEFAULT 0,
+			count INTEGER NOT NULL DEFAULT 0,
+			assertions BLOB NOT NULL DEFAULT '[]'
+		)
+	`},
+}
+
+func applyFlowNodeSocket(ctx context.Context, tx *sql.Tx) error {
+	for _, table := range flowNodeSocketTables {
+		if _, err := tx.ExecContext(ctx, table.ddl); err != nil {
+			return fmt.Errorf("create %s table: %w", table.name, err)
+		}
+	}
+	return nil
+}
+
+func validateFlowNodeSocket(ctx context.Context, db *sql.DB) error {
+	for _, table := range flowNodeSocketTables {
+		var name string
+		err := db.QueryRowContext(ctx, `
+			SELECT name FROM sqlite_master
+			WHERE type='table' AND name=?
+		`, table.name).Scan(&name)
+		if err != nil {
+			return fmt.Errorf("%s table not found: %w", table.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddDbQueryID = "01KXQ4D8M1B6ZC9RVNS2EJ7TWA"
+
+const MigrationAddDbQueryChecksum = "sha256:add-db-query-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddDbQueryID,
+		Checksum:       MigrationAddDbQueryChecksum,
+		Description:    "Add credential_database and flow_node_db_query tables",
+		Apply:          applyDbQuery,
+		Validate:       validateDbQuery,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register db query migration: " + err.Error())
+	}
+}
+
+// dbQueryTables are the tables added for database credentials and DB Query
+// nodes.
+var dbQueryTables = []struct {
+	name string
+	ddl  string
+}{
+	{"credential_database", `
+		CREATE TABLE IF NOT EXISTS credential_database (
+			credential_id BLOB NOT NULL PRIMARY KEY,
+			connection_string BLOB NOT NULL,
+			encryption_type INT8 NOT NULL DEFAULT 0,
+			FOREIGN KEY (credential_id) REFERENCES credential (id) ON DELETE CASCADE
+		)
+	`},
+	{"flow_node_db_query", `
+		CREATE TABLE IF NOT EXISTS flow_node_db_query (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			credential_id BLOB,
+			query TEXT NOT NULL DEFAULT '',
+			params BLOB NOT NULL DEFAULT '[]',
+			timeout_ms INTEGER NOT NULL DEFAULT 0,
+			assertions BLOB NOT NULL DEFAULT '[]'
+		)
+	`},
+}
+
+func applyDbQuery(ctx context.Context, tx *sql.Tx) error {
+	for _, table := range dbQueryTables {
+		if _, err := tx.ExecContext(ctx, table.ddl); err != nil {
+			return fmt.Errorf("create %s table: %w", table.name, err)
+		}
+	}
+	return nil
+}
+
+func validateDbQuery(ctx context.Context, db *sql.DB) error {
+	for _, table := range dbQueryTables {
+		var name string
+		err := db.QueryRowContext(ctx, `
+			SELECT name FROM sqlite_master
+			WHERE type='table' AND name=?
+		`, table.name).Scan(&name)
+		if err != nil {
+			return fmt.Errorf("%s table not found: %w", table.name, err)
+		}
+	}
+	return nil
+}
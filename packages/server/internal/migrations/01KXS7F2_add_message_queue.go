@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddMessageQueueID = "01KXS7F2H4N8QW3CYB6DJ5MRVK"
+
+const MigrationAddMessageQueueChecksum = "sha256:add-message-queue-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddMessageQueueID,
+		Checksum:       MigrationAddMessageQueueChecksum,
+		Description:    "Add credential_message_broker, flow_node_mq_publish and flow_node_mq_consume tables",
+		Apply:          applyMessageQueue,
+		Validate:       validateMessageQueue,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register message queue migration: " + err.Error())
+	}
+}
+
+// messageQueueTables are the tables added for message broker credentials and
+// MQ Publish and MQ Consume nodes.
+var messageQueueTables = []struct {
+	name string
+	ddl  string
+}{
+	{"credential_message_broker", `
+		CREATE TABLE IF NOT EXISTS credential_message_broker (
+			credential_id BLOB NOT NULL PRIMARY KEY,
+			url BLOB NOT NULL,
+			encryption_type INT8 NOT NULL DEFAULT 0,
+			FOREIGN KEY (credential_id) REFERENCES credential (id) ON DELETE CASCADE
+		)
+	`},
+	{"flow_node_mq_publish", `
+		CREATE TABLE IF NOT EXISTS flow_node_mq_publish (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			credential_id BLOB,
+			topic TEXT NOT NULL DEFAULT '',
+			message_key TEXT NOT NULL DEFAULT '',
+			message TEXT NOT NULL DEFAULT ''
+		)
+	`},
+	{"flow_node_mq_consume", `
+		CREATE TABLE IF NOT EXISTS flow_node_mq_consume (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			credential_id BLOB,
+			topic TEXT NOT NULL DEFAULT '',
+			consumer_group TEXT NOT NULL DEFAULT '',
+			filter_expression TEXT NOT NULL DEFAULT '',
+			timeout_ms INTEGER NOT NULL DEFAULT 0,
+			count INTEGER NOT NULL DEFAULT 0,
+			assertions BLOB NOT NULL DEFAULT '[]'
+		)
+	`},
+}
+
+func applyMessageQueue(ctx context.Context, tx *sql.Tx) error {
+	for _, table := range messageQueueTables {
+		if _, err := tx.ExecContext(ctx, table.ddl); err != nil {
+			return fmt.Errorf("create %s table: %w", table.name, err)
+		}
+	}
+	return nil
+}
+
+func validateMessageQueue(ctx context.Context, db *sql.DB) error {
+	for _, table := range messageQueueTables {
+		var name string
+		err := db.QueryRowContext(ctx, `
+			SELECT name FROM sqlite_master
+			WHERE type='table' AND name=?
+		`, table.name).Scan(&name)
+		if err != nil {
+			return fmt.Errorf("%s table not found: %w", table.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/internal/migrate"
+)
+
+const MigrationAddFlowNodeExecID = "01KXV3M9R6T2PZ8HQ4WCNEJ7DA"
+
+const MigrationAddFlowNodeExecChecksum = "sha256:add-flow-node-exec-v1"
+
+func init() {
+	if err := migrate.Register(migrate.Migration{
+		ID:             MigrationAddFlowNodeExecID,
+		Checksum:       MigrationAddFlowNodeExecChecksum,
+		Description:    "Add flow_node_exec table",
+		Apply:          applyFlowNodeExec,
+		Validate:       validateFlowNodeExec,
+		RequiresBackup: false,
+	}); err != nil {
+		panic("failed to register flow node exec migration: " + err.Error())
+	}
+}
+
+func applyFlowNodeExec(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS flow_node_exec (
+			flow_node_id BLOB NOT NULL PRIMARY KEY,
+			command TEXT NOT NULL DEFAULT '',
+			args BLOB NOT NULL DEFAULT '[]',
+			work_dir TEXT NOT NULL DEFAULT '',
+			timeout_ms INTEGER NOT NULL DEFAULT 0,
+			parse_json BOOLEAN NOT NULL DEFAULT FALSE
+		)
+	`); err != nil {
+		return fmt.Errorf("create flow_node_exec table: %w", err)
+	}
+	return nil
+}
+
+func validateFlowNodeExec(ctx context.Context, db *sql.DB) error {
+	var name string
+	err := db.QueryRowContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='flow_node_exec'
+	`).Scan(&name)
+	if err != nil {
+		return fmt.Errorf("flow_node_exec table not found: %w", err)
+	}
+	return nil
+}
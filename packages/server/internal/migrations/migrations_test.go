@@ -361,7 +361,7 @@ func TestGraphQLDeltaColumnsCreated(t *testing.T) {
 // TestMigrationCount ensures no migrations are accidentally omitted.
 func TestMigrationCount(t *testing.T) {
 	migrations := migrate.List()
-	const expectedCount = 11
+	const expectedCount = 26
 	if len(migrations) != expectedCount {
 		t.Errorf("expected %d registered migrations, got %d — update this count if you added/removed a migration", expectedCount, len(migrations))
 	}
@@ -554,6 +554,180 @@ func TestWaitNodeTableCreated(t *testing.T) {
 	assertColumnExists(t, ctx, db, "flow_node_wait", "duration_ms")
 }
 
+// TestParallelNodeTableCreated verifies the parallel node migration.
+func TestParallelNodeTableCreated(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "flow_node_parallel")
+	assertColumnExists(t, ctx, db, "flow_node_parallel", "join_mode")
+	assertColumnExists(t, ctx, db, "flow_node_parallel", "concurrency")
+	assertColumnExists(t, ctx, db, "flow_node_for_each", "concurrency")
+}
+
+// TestPollNodeTableCreated verifies the poll node migration.
+func TestPollNodeTableCreated(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "flow_node_poll")
+	assertColumnExists(t, ctx, db, "flow_node_poll", "until_expression")
+	assertColumnExists(t, ctx, db, "flow_node_poll", "backoff")
+	assertColumnExists(t, ctx, db, "flow_node_poll", "max_attempts")
+}
+
+// TestSwitchNodeTableCreated verifies the switch node migration.
+func TestSwitchNodeTableCreated(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "flow_node_switch")
+	assertColumnExists(t, ctx, db, "flow_node_switch", "cases")
+}
+
+// TestFlowScheduleTablesCreated verifies the flow schedule migration.
+func TestFlowScheduleTablesCreated(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "flow_schedule")
+	assertColumnExists(t, ctx, db, "flow_schedule", "cron_expression")
+	assertColumnExists(t, ctx, db, "flow_schedule", "interval_seconds")
+	assertColumnExists(t, ctx, db, "flow_schedule", "environment_id")
+	assertColumnExists(t, ctx, db, "flow_schedule", "last_state")
+	assertTableExists(t, ctx, db, "flow_schedule_run")
+	assertColumnExists(t, ctx, db, "flow_schedule_run", "duration_ms")
+	assertIndexExists(t, ctx, db, "flow_schedule_run_idx1")
+}
+
+// TestWebhookTriggerNodeTableCreated verifies the webhook trigger node migration.
+func TestWebhookTriggerNodeTableCreated(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "flow_node_webhook_trigger")
+	assertColumnExists(t, ctx, db, "flow_node_webhook_trigger", "path")
+	assertColumnExists(t, ctx, db, "flow_node_webhook_trigger", "signature_header")
+	assertIndexExists(t, ctx, db, "flow_node_webhook_trigger_idx1")
+}
+
+// TestGraphQLSubscriptionNodeTableCreated verifies the GraphQL subscription node migration.
+func TestGraphQLSubscriptionNodeTableCreated(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "flow_node_graphql_subscription")
+	assertColumnExists(t, ctx, db, "flow_node_graphql_subscription", "connection_payload")
+	assertColumnExists(t, ctx, db, "flow_node_graphql_subscription", "max_events")
+	assertColumnExists(t, ctx, db, "flow_node_graphql_subscription", "timeout_ms")
+}
+
+// TestGraphQLSchemaTableCreated verifies the GraphQL schema cache migration.
+func TestGraphQLSchemaTableCreated(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "graphql_schema")
+	assertColumnExists(t, ctx, db, "graphql_schema", "introspection")
+	assertColumnExists(t, ctx, db, "graphql_schema", "fetched_at")
+}
+
+// TestGraphQLOperationNameColumnsCreated verifies the GraphQL operation
+// name and query file columns.
+func TestGraphQLOperationNameColumnsCreated(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertColumnExists(t, ctx, db, "graphql", "operation_name")
+	assertColumnExists(t, ctx, db, "graphql", "query_file")
+}
+
+// TestGraphQLTransportColumnsCreated verifies the GraphQL persisted query,
+// batch and upload columns.
+func TestGraphQLTransportColumnsCreated(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertColumnExists(t, ctx, db, "graphql", "persisted_query")
+	assertColumnExists(t, ctx, db, "graphql", "batch")
+	assertColumnExists(t, ctx, db, "graphql", "uploads")
+}
+
+// TestWebSocketExpectMigration verifies the ws_expect node, message history
+// and subprotocol/binary columns.
+func TestWebSocketExpectMigration(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "flow_node_ws_expect")
+	assertColumnExists(t, ctx, db, "flow_node_ws_expect", "match_expression")
+	assertColumnExists(t, ctx, db, "flow_node_ws_expect", "assertions")
+	assertTableExists(t, ctx, db, "websocket_message")
+	assertIndexExists(t, ctx, db, "websocket_message_ws_idx")
+	assertColumnExists(t, ctx, db, "websocket", "subprotocols")
+	assertColumnExists(t, ctx, db, "flow_node_ws_send", "binary")
+}
+
+// TestWebSocketProtocolMigration verifies the protocol adapter columns.
+func TestWebSocketProtocolMigration(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertColumnExists(t, ctx, db, "websocket", "protocol")
+	assertColumnExists(t, ctx, db, "websocket", "connect_payload")
+	assertColumnExists(t, ctx, db, "websocket", "subscriptions")
+	assertColumnExists(t, ctx, db, "flow_node_ws_send", "event")
+	assertColumnExists(t, ctx, db, "flow_node_ws_send", "ack")
+}
+
+// TestFlowNodeSocketMigration verifies the socket node tables.
+func TestFlowNodeSocketMigration(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "flow_node_socket_connection")
+	assertColumnExists(t, ctx, db, "flow_node_socket_connection", "framing")
+	assertTableExists(t, ctx, db, "flow_node_socket_send")
+	assertColumnExists(t, ctx, db, "flow_node_socket_send", "encoding")
+	assertTableExists(t, ctx, db, "flow_node_socket_receive")
+	assertColumnExists(t, ctx, db, "flow_node_socket_receive", "assertions")
+}
+
+// TestDbQueryMigration verifies the database credential and DB Query node
+// tables.
+func TestDbQueryMigration(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "credential_database")
+	assertColumnExists(t, ctx, db, "credential_database", "connection_string")
+	assertTableExists(t, ctx, db, "flow_node_db_query")
+	assertColumnExists(t, ctx, db, "flow_node_db_query", "params")
+}
+
+// TestMessageQueueMigration verifies the message broker credential and MQ
+// node tables.
+func TestMessageQueueMigration(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "credential_message_broker")
+	assertColumnExists(t, ctx, db, "credential_message_broker", "url")
+	assertTableExists(t, ctx, db, "flow_node_mq_publish")
+	assertColumnExists(t, ctx, db, "flow_node_mq_publish", "message_key")
+	assertTableExists(t, ctx, db, "flow_node_mq_consume")
+	assertColumnExists(t, ctx, db, "flow_node_mq_consume", "filter_expression")
+}
+
+// TestFlowNodeExecMigration verifies the Exec node table.
+func TestFlowNodeExecMigration(t *testing.T) {
+	ctx := context.Background()
+	db := runAllMigrations(t, ctx)
+
+	assertTableExists(t, ctx, db, "flow_node_exec")
+	assertColumnExists(t, ctx, db, "flow_node_exec", "parse_json")
+}
+
 // TestFlowErrorColumnsCreated verifies flow error/node_id_mapping columns.
 func TestFlowErrorColumnsCreated(t *testing.T) {
 	ctx := context.Background()
@@ -0,0 +1,68 @@
+package dirsync
+
+import (
+	"sort"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/contenthash"
+)
+
+// Op is what a sync does to one file or entity
+type Op int8
+
+const (
+	OpAdd Op = iota
+	OpModify
+	OpRemove
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpAdd:
+		return "added"
+	case OpModify:
+		return "modified"
+	case OpRemove:
+		return "removed"
+	default:
+		return ""
+	}
+}
+
+// Change is one file of the layout that differs between two trees
+type Change struct {
+	Path string
+	Kind Kind
+	Op   Op
+}
+
+// Diff lists the layout files that must change for have to match want,
+// sorted by path. Files are compared by content hash; files outside the
+// layout are ignored.
+func Diff(want, have map[string][]byte) []Change {
+	hasher := contenthash.New()
+	var changes []Change
+	for p, data := range want {
+		kind, ok := KindOf(p)
+		if !ok {
+			continue
+		}
+		current, exists := have[p]
+		switch {
+		case !exists:
+			changes = append(changes, Change{Path: p, Kind: kind, Op: OpAdd})
+		case hasher.HashBytes(data) != hasher.HashBytes(current):
+			changes = append(changes, Change{Path: p, Kind: kind, Op: OpModify})
+		}
+	}
+	for p := range have {
+		kind, ok := KindOf(p)
+		if !ok {
+			continue
+		}
+		if _, exists := want[p]; !exists {
+			changes = append(changes, Change{Path: p, Kind: kind, Op: OpRemove})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
@@ -0,0 +1,231 @@
+// Package dirsync renders a workspace as a directory meant to live in version
+// control, and syncs such a directory back into the workspace.
+//
+// The layout mirrors the file tree of the workspace:
+//
+//	.devtools/workspace.yaml           the workspace the directory is bound to
+//	.devtools/environments/<env>.yaml  one file per environment, secrets excluded
+//	<folder>/.../<name>.request.yaml   one file per HTTP request
+//	<folder>/.../<name>.flow.yaml      one file per flow, as a yamlflow document
+//
+// Entities are identified by their path, so no IDs appear in the files and a
+// diff of the directory reads as a diff of the workspace. Request drafts
+// (deltas), GraphQL and WebSocket requests are not part of the layout and are
+// left untouched by a push.
+package dirsync
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mfile"
+)
+
+const (
+	// MetaDir holds the files that are not part of the request tree
+	MetaDir = ".devtools"
+	// WorkspaceFile binds the directory to a workspace
+	WorkspaceFile = MetaDir + "/workspace.yaml"
+	// EnvironmentDir holds one file per environment
+	EnvironmentDir = MetaDir + "/environments"
+
+	RequestSuffix     = ".request.yaml"
+	FlowSuffix        = ".flow.yaml"
+	EnvironmentSuffix = ".yaml"
+)
+
+var ErrNotBound = errors.New("directory is not bound to a workspace")
+
+// Kind is the entity a file of the layout holds
+type Kind int8
+
+const (
+	KindWorkspace Kind = iota
+	KindEnvironment
+	KindRequest
+	KindFlow
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindWorkspace:
+		return "workspace"
+	case KindEnvironment:
+		return "environment"
+	case KindRequest:
+		return "request"
+	case KindFlow:
+		return "flow"
+	default:
+		return "unknown"
+	}
+}
+
+// KindOf reports which entity the file at the slash-separated path p holds,
+// or false when the file is not part of the layout
+func KindOf(p string) (Kind, bool) {
+	switch {
+	case p == WorkspaceFile:
+		return KindWorkspace, true
+	case path.Dir(p) == EnvironmentDir && strings.HasSuffix(p, EnvironmentSuffix):
+		return KindEnvironment, true
+	case strings.HasPrefix(p, MetaDir+"/"):
+		return 0, false
+	case strings.HasSuffix(p, RequestSuffix):
+		return KindRequest, true
+	case strings.HasSuffix(p, FlowSuffix):
+		return KindFlow, true
+	}
+	return 0, false
+}
+
+// Snapshot is a workspace rendered in the directory layout
+type Snapshot struct {
+	// Files maps slash-separated paths to file content
+	Files map[string][]byte
+	// IDs maps the path of every entity file to the entity it was rendered
+	// from: the environment, HTTP request or flow
+	IDs map[string]idwrap.IDWrap
+	// Folders maps the path of every folder to its file ID
+	Folders map[string]idwrap.IDWrap
+	// Entries maps the path of every request and flow to its file ID
+	Entries map[string]idwrap.IDWrap
+}
+
+// Encode renders bundle in the directory layout. The bundle must include
+// files, HTTP requests, flows and environments.
+func Encode(bundle *ioworkspace.WorkspaceBundle) (*Snapshot, error) {
+	s := &Snapshot{
+		Files:   make(map[string][]byte),
+		IDs:     make(map[string]idwrap.IDWrap),
+		Folders: make(map[string]idwrap.IDWrap),
+		Entries: make(map[string]idwrap.IDWrap),
+	}
+
+	data, err := encodeWorkspace(bundle.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	s.Files[WorkspaceFile] = data
+
+	used := make(map[string]bool)
+	envs := append(bundle.Environments[:0:0], bundle.Environments...)
+	sort.SliceStable(envs, func(i, j int) bool { return envs[i].Order < envs[j].Order })
+	for _, env := range envs {
+		data, err := encodeEnvironment(bundle, env)
+		if err != nil {
+			return nil, fmt.Errorf("environment %q: %w", env.Name, err)
+		}
+		p := uniquePath(used, EnvironmentDir, fileName(env.Name), EnvironmentSuffix)
+		s.Files[p] = data
+		s.IDs[p] = env.ID
+	}
+
+	children := make(map[idwrap.IDWrap][]mfile.File)
+	var roots []mfile.File
+	for _, f := range bundle.Files {
+		if f.ParentID == nil {
+			roots = append(roots, f)
+		} else {
+			children[*f.ParentID] = append(children[*f.ParentID], f)
+		}
+	}
+	e := &encoder{bundle: bundle, snapshot: s, children: children, used: used}
+	if err := e.encodeFiles("", roots); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+type encoder struct {
+	bundle   *ioworkspace.WorkspaceBundle
+	snapshot *Snapshot
+	children map[idwrap.IDWrap][]mfile.File
+	used     map[string]bool
+}
+
+func (e *encoder) encodeFiles(dir string, files []mfile.File) error {
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].Order != files[j].Order {
+			return files[i].Order < files[j].Order
+		}
+		return files[i].ID.Compare(files[j].ID) < 0
+	})
+
+	for _, f := range files {
+		switch f.ContentType {
+		case mfile.ContentTypeFolder:
+			p := uniquePath(e.used, dir, fileName(f.Name), "")
+			e.snapshot.Folders[p] = f.ID
+			if err := e.encodeFiles(p, e.children[f.ID]); err != nil {
+				return err
+			}
+
+		case mfile.ContentTypeHTTP:
+			if f.ContentID == nil {
+				continue
+			}
+			data, ok, err := encodeRequest(e.bundle, *f.ContentID)
+			if err != nil {
+				return fmt.Errorf("request %q: %w", f.Name, err)
+			}
+			if !ok {
+				continue
+			}
+			p := uniquePath(e.used, dir, fileName(f.Name), RequestSuffix)
+			e.snapshot.Files[p] = data
+			e.snapshot.IDs[p] = *f.ContentID
+			e.snapshot.Entries[p] = f.ID
+
+		case mfile.ContentTypeFlow:
+			if f.ContentID == nil {
+				continue
+			}
+			data, ok, err := encodeFlow(e.bundle, *f.ContentID)
+			if err != nil {
+				return fmt.Errorf("flow %q: %w", f.Name, err)
+			}
+			if !ok {
+				continue
+			}
+			p := uniquePath(e.used, dir, fileName(f.Name), FlowSuffix)
+			e.snapshot.Files[p] = data
+			e.snapshot.IDs[p] = *f.ContentID
+			e.snapshot.Entries[p] = f.ID
+		}
+	}
+	return nil
+}
+
+// fileName turns an entity name into a file name that is valid on every
+// platform the directory may be checked out on
+func fileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return "untitled"
+	}
+	return name
+}
+
+// uniquePath joins dir and name+suffix, numbering the name when the path is
+// taken. Paths are compared case-insensitively so that the layout survives a
+// checkout on a case-insensitive file system.
+func uniquePath(used map[string]bool, dir, name, suffix string) string {
+	p := path.Join(dir, name+suffix)
+	for i := 2; used[strings.ToLower(p)]; i++ {
+		p = path.Join(dir, fmt.Sprintf("%s (%d)%s", name, i, suffix))
+	}
+	used[strings.ToLower(p)] = true
+	return p
+}
@@ -0,0 +1,50 @@
+package dirsync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
+)
+
+func TestKindOf(t *testing.T) {
+	cases := map[string]struct {
+		kind Kind
+		ok   bool
+	}{
+		WorkspaceFile:                   {KindWorkspace, true},
+		EnvironmentDir + "/dev.yaml":    {KindEnvironment, true},
+		EnvironmentDir + "/a/dev.yaml":  {0, false},
+		MetaDir + "/cache.request.yaml": {0, false},
+		"users/List.request.yaml":       {KindRequest, true},
+		"Checkout.flow.yaml":            {KindFlow, true},
+		"README.md":                     {0, false},
+		"users/notes.yaml":              {0, false},
+	}
+	for p, want := range cases {
+		kind, ok := KindOf(p)
+		require.Equal(t, want.ok, ok, p)
+		if ok {
+			require.Equal(t, want.kind, kind, p)
+		}
+	}
+}
+
+func TestFileNames(t *testing.T) {
+	require.Equal(t, "GET -users-{id}", fileName("GET /users/{id}"))
+	require.Equal(t, "untitled", fileName(" .. "))
+
+	used := make(map[string]bool)
+	require.Equal(t, "a/Login.request.yaml", uniquePath(used, "a", "Login", RequestSuffix))
+	require.Equal(t, "a/login (2).request.yaml", uniquePath(used, "a", "login", RequestSuffix))
+	require.Equal(t, "b/Login.request.yaml", uniquePath(used, "b", "Login", RequestSuffix))
+}
+
+func TestIsSecret(t *testing.T) {
+	require.True(t, IsSecret(menv.Variable{VarKey: "API_KEY", Value: "abc"}))
+	require.True(t, IsSecret(menv.Variable{VarKey: "db_password", Value: "abc"}))
+	require.False(t, IsSecret(menv.Variable{VarKey: "auth_token", Value: "{{#env:AUTH_TOKEN}}"}),
+		"a reference to the process environment holds no secret")
+	require.False(t, IsSecret(menv.Variable{VarKey: "base_url", Value: "https://example.com"}))
+}
@@ -0,0 +1,86 @@
+package dirsync
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
+)
+
+// environmentFile is the content of an environment file
+type environmentFile struct {
+	Name        string                `yaml:"name"`
+	Description string                `yaml:"description,omitempty"`
+	Global      bool                  `yaml:"global,omitempty"`
+	Variables   []environmentVariable `yaml:"variables,omitempty"`
+}
+
+// environmentVariable is a variable of an environment file. A secret is
+// written without its value; pushing the file leaves the value stored in the
+// workspace as it is.
+type environmentVariable struct {
+	Name        string `yaml:"name"`
+	Value       string `yaml:"value,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Enabled     bool   `yaml:"enabled"`
+	Secret      bool   `yaml:"secret,omitempty"`
+}
+
+func (v *environmentVariable) UnmarshalYAML(value *yaml.Node) error {
+	type alias environmentVariable
+	aux := &alias{Enabled: true}
+	if err := value.Decode(aux); err != nil {
+		return err
+	}
+	*v = environmentVariable(*aux)
+	return nil
+}
+
+// secretPattern matches variable names that hold credentials
+var secretPattern = regexp.MustCompile(`(?i)(secret|token|passw(or)?d|api[-_]?key|private[-_]?key|credential)`)
+
+// IsSecret reports whether v is kept out of environment files. Variables
+// named like credentials are secrets, unless their value only refers to the
+// process environment.
+func IsSecret(v menv.Variable) bool {
+	return secretPattern.MatchString(v.VarKey) && !strings.Contains(v.Value, "#env:")
+}
+
+func encodeEnvironment(bundle *ioworkspace.WorkspaceBundle, env menv.Env) ([]byte, error) {
+	ef := environmentFile{
+		Name:        env.Name,
+		Description: env.Description,
+		Global:      env.Type == menv.EnvGlobal || env.ID == bundle.Workspace.GlobalEnv,
+	}
+
+	var vars []menv.Variable
+	for _, v := range bundle.EnvironmentVars {
+		if v.EnvID == env.ID {
+			vars = append(vars, v)
+		}
+	}
+	sort.SliceStable(vars, func(i, j int) bool { return vars[i].Order < vars[j].Order })
+	for _, v := range vars {
+		ev := environmentVariable{Name: v.VarKey, Description: v.Description, Enabled: v.Enabled}
+		if IsSecret(v) {
+			ev.Secret = true
+		} else {
+			ev.Value = v.Value
+		}
+		ef.Variables = append(ef.Variables, ev)
+	}
+
+	return yaml.Marshal(ef)
+}
+
+func decodeEnvironment(data []byte) (*environmentFile, error) {
+	var ef environmentFile
+	if err := yaml.Unmarshal(data, &ef); err != nil {
+		return nil, err
+	}
+	return &ef, nil
+}
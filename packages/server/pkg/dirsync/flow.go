@@ -0,0 +1,84 @@
+package dirsync
+
+import (
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/yamlflowsimplev2"
+)
+
+// encodeFlow renders flow flowID of bundle as a yamlflow document holding
+// that flow alone, so the file can also be run as is
+func encodeFlow(bundle *ioworkspace.WorkspaceBundle, flowID idwrap.IDWrap) ([]byte, bool, error) {
+	var flow *mflow.Flow
+	for i := range bundle.Flows {
+		if bundle.Flows[i].ID == flowID {
+			flow = &bundle.Flows[i]
+			break
+		}
+	}
+	if flow == nil {
+		return nil, false, nil
+	}
+
+	// The document carries no workspace data: environments have their own
+	// files, and a workspace rename must not touch every flow.
+	single := *bundle
+	single.Workspace = mworkspace.Workspace{}
+	single.Flows = []mflow.Flow{*flow}
+	single.Environments = nil
+	single.EnvironmentVars = nil
+	single.Credentials = nil
+	single.LoadScenarios = nil
+	// Flow files are self-contained, so queries loaded from .graphql files
+	// are written inline.
+	yamlflowsimplev2.InlineGraphQLQueries(&single)
+
+	data, err := yamlflowsimplev2.MarshalSimplifiedYAML(&single)
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// decodeFlow converts a flow file into a bundle holding its flow and the
+// requests the flow runs, with fresh IDs
+func decodeFlow(data []byte, workspaceID idwrap.IDWrap) (*ioworkspace.WorkspaceBundle, error) {
+	bundle, err := yamlflowsimplev2.ConvertSimplifiedYAML(data, yamlflowsimplev2.ConvertOptionsV2{WorkspaceID: workspaceID})
+	if err != nil {
+		return nil, err
+	}
+	if len(bundle.Flows) != 1 {
+		return nil, fmt.Errorf("a flow file holds exactly one flow, found %d", len(bundle.Flows))
+	}
+	bundle.Files = nil
+	bundle.Environments = nil
+	bundle.EnvironmentVars = nil
+	return bundle, nil
+}
+
+// flowRequests returns the HTTP requests the request nodes of flowID run
+func flowRequests(bundle *ioworkspace.WorkspaceBundle, flowID idwrap.IDWrap) []idwrap.IDWrap {
+	nodes := make(map[idwrap.IDWrap]bool)
+	for _, n := range bundle.FlowNodes {
+		if n.FlowID == flowID {
+			nodes[n.ID] = true
+		}
+	}
+	var ids []idwrap.IDWrap
+	for _, rn := range bundle.FlowRequestNodes {
+		if !nodes[rn.FlowNodeID] {
+			continue
+		}
+		if rn.HttpID != nil {
+			ids = append(ids, *rn.HttpID)
+		}
+		if rn.DeltaHttpID != nil {
+			ids = append(ids, *rn.DeltaHttpID)
+		}
+	}
+	return ids
+}
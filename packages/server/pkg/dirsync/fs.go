@@ -0,0 +1,79 @@
+package dirsync
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// ReadDir loads the layout files found under dir, keyed by slash-separated
+// path. A missing dir reads as empty.
+func ReadDir(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == dir && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if _, ok := KindOf(rel); !ok {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[rel] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// writeChanges applies changes to dir so that its layout files match files.
+// Directories left empty by a removal are removed as well.
+func writeChanges(dir string, files map[string][]byte, changes []Change) error {
+	for _, c := range changes {
+		target := filepath.Join(dir, filepath.FromSlash(c.Path))
+		if c.Op == OpRemove {
+			if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			removeEmptyParents(dir, path.Dir(c.Path))
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, files[c.Path], 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeEmptyParents(dir, rel string) {
+	for rel != "." && rel != "" {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(rel))); err != nil {
+			return
+		}
+		rel = path.Dir(rel)
+	}
+}
@@ -0,0 +1,494 @@
+package dirsync
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mfile"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/senv"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sfile"
+)
+
+// pusher applies the changes of a Push inside its mutation transaction.
+// bundle and remote describe the workspace before the push; remote.Folders
+// grows as folders are created.
+type pusher struct {
+	mut         *mutation.Context
+	logger      *slog.Logger
+	workspaceID idwrap.IDWrap
+	bundle      *ioworkspace.WorkspaceBundle
+	remote      *Snapshot
+	local       map[string][]byte
+}
+
+func (p *pusher) apply(ctx context.Context, c Change) error {
+	switch c.Kind {
+	case KindEnvironment:
+		return p.applyEnvironment(ctx, c)
+	case KindRequest:
+		return p.applyRequest(ctx, c)
+	case KindFlow:
+		return p.applyFlow(ctx, c)
+	}
+	return nil
+}
+
+func (p *pusher) applyEnvironment(ctx context.Context, c Change) error {
+	if c.Op == OpRemove {
+		return p.mut.DeleteEnvironment(ctx, p.remote.IDs[c.Path], p.workspaceID)
+	}
+
+	ef, err := decodeEnvironment(p.local[c.Path])
+	if err != nil {
+		return err
+	}
+	if ef.Name == "" {
+		ef.Name = baseName(c.Path, EnvironmentSuffix)
+	}
+
+	envWriter := senv.NewEnvWriterFromQueries(p.mut.Queries())
+	varWriter := senv.NewVariableWriterFromQueries(p.mut.Queries())
+
+	var env menv.Env
+	var current []menv.Variable
+	if c.Op == OpAdd {
+		env = menv.Env{ID: idwrap.NewNow(), WorkspaceID: p.workspaceID, Type: menv.EnvNormal}
+		if ef.Global {
+			env.Type = menv.EnvGlobal
+		}
+	} else {
+		envID := p.remote.IDs[c.Path]
+		for _, e := range p.bundle.Environments {
+			if e.ID == envID {
+				env = e
+			}
+		}
+		for _, v := range p.bundle.EnvironmentVars {
+			if v.EnvID == envID {
+				current = append(current, v)
+			}
+		}
+	}
+	env.Name = ef.Name
+	env.Description = ef.Description
+
+	if c.Op == OpAdd {
+		if err := envWriter.CreateEnvironment(ctx, &env); err != nil {
+			return err
+		}
+		p.mut.Track(mutation.Event{Entity: mutation.EntityEnvironment, Op: mutation.OpInsert, ID: env.ID, WorkspaceID: p.workspaceID, Payload: env})
+	} else {
+		if err := envWriter.UpdateEnvironment(ctx, &env); err != nil {
+			return err
+		}
+		p.mut.Track(mutation.Event{Entity: mutation.EntityEnvironment, Op: mutation.OpUpdate, ID: env.ID, WorkspaceID: p.workspaceID, Payload: env})
+	}
+
+	// Variables are matched by name so that unchanged ones keep their ID
+	// and secrets keep the value the file leaves out.
+	byName := make(map[string]menv.Variable, len(current))
+	for i := len(current) - 1; i >= 0; i-- {
+		byName[current[i].VarKey] = current[i]
+	}
+	kept := make(map[idwrap.IDWrap]bool)
+	for i, ev := range ef.Variables {
+		v := menv.Variable{
+			EnvID:       env.ID,
+			VarKey:      ev.Name,
+			Value:       ev.Value,
+			Enabled:     ev.Enabled,
+			Description: ev.Description,
+			Order:       float64(i + 1),
+		}
+		old, ok := byName[ev.Name]
+		if !ok || kept[old.ID] {
+			v.ID = idwrap.NewNow()
+			if err := varWriter.Create(ctx, v); err != nil {
+				return err
+			}
+			p.mut.Track(mutation.Event{Entity: mutation.EntityEnvironmentValue, Op: mutation.OpInsert, ID: v.ID, WorkspaceID: p.workspaceID, ParentID: env.ID, Payload: v})
+			continue
+		}
+
+		kept[old.ID] = true
+		v.ID = old.ID
+		if ev.Secret {
+			v.Value = old.Value
+		}
+		if v == old {
+			continue
+		}
+		if err := varWriter.Update(ctx, &v); err != nil {
+			return err
+		}
+		p.mut.Track(mutation.Event{Entity: mutation.EntityEnvironmentValue, Op: mutation.OpUpdate, ID: v.ID, WorkspaceID: p.workspaceID, ParentID: env.ID, Payload: v})
+	}
+	for _, old := range current {
+		if kept[old.ID] {
+			continue
+		}
+		if err := varWriter.Delete(ctx, old.ID); err != nil {
+			return err
+		}
+		p.mut.Track(mutation.Event{Entity: mutation.EntityEnvironmentValue, Op: mutation.OpDelete, ID: old.ID, WorkspaceID: p.workspaceID, ParentID: env.ID})
+	}
+	return nil
+}
+
+func (p *pusher) applyRequest(ctx context.Context, c Change) error {
+	now := time.Now().UnixMilli()
+
+	switch c.Op {
+	case OpRemove:
+		return p.mut.DeleteHTTP(ctx, mutation.HTTPDeleteItem{ID: p.remote.IDs[c.Path], WorkspaceID: p.workspaceID})
+
+	case OpAdd:
+		folderID, err := p.folder(ctx, path.Dir(c.Path))
+		if err != nil {
+			return err
+		}
+		req, err := decodeRequest(p.local[c.Path], idwrap.NewNow())
+		if err != nil {
+			return err
+		}
+		if req.HTTP.Name == "" {
+			req.HTTP.Name = baseName(c.Path, RequestSuffix)
+		}
+		req.HTTP.WorkspaceID = p.workspaceID
+		req.HTTP.FolderID = folderID
+		req.HTTP.CreatedAt = now
+		req.HTTP.UpdatedAt = now
+
+		if err := p.mut.InsertHTTP(ctx, mutation.HTTPInsertItem{HTTP: &req.HTTP, WorkspaceID: p.workspaceID}); err != nil {
+			return err
+		}
+		if err := p.insertRequestChildren(ctx, req, now); err != nil {
+			return err
+		}
+		return p.createFile(ctx, &mfile.File{
+			ID:          idwrap.NewNow(),
+			WorkspaceID: p.workspaceID,
+			ParentID:    folderID,
+			ContentID:   &req.HTTP.ID,
+			ContentType: mfile.ContentTypeHTTP,
+			Name:        req.HTTP.Name,
+		})
+
+	case OpModify:
+		httpID := p.remote.IDs[c.Path]
+		current, ok := bundleRequest(p.bundle, httpID)
+		if !ok {
+			return fmt.Errorf("request %s not found", httpID)
+		}
+		req, err := decodeRequest(p.local[c.Path], httpID)
+		if err != nil {
+			return err
+		}
+		if req.HTTP.Name == "" {
+			req.HTTP.Name = baseName(c.Path, RequestSuffix)
+		}
+
+		updated := current.HTTP
+		updated.Name = req.HTTP.Name
+		updated.Method = req.HTTP.Method
+		updated.Url = req.HTTP.Url
+		updated.Description = req.HTTP.Description
+		updated.BodyKind = req.HTTP.BodyKind
+		updated.UpdatedAt = now
+		if _, err := p.mut.UpdateHTTP(ctx, mutation.HTTPUpdateItem{HTTP: &updated, WorkspaceID: p.workspaceID}); err != nil {
+			return err
+		}
+		if updated.Name != current.HTTP.Name {
+			if err := p.renameFile(ctx, p.remote.Entries[c.Path], updated.Name); err != nil {
+				return err
+			}
+		}
+
+		// Children have no identity of their own in the file, so they are
+		// replaced as a whole.
+		if err := p.deleteRequestChildren(ctx, current); err != nil {
+			return err
+		}
+		return p.insertRequestChildren(ctx, req, now)
+	}
+	return nil
+}
+
+func (p *pusher) insertRequestChildren(ctx context.Context, req *request, now int64) error {
+	httpID, ws := req.HTTP.ID, p.workspaceID
+	for _, h := range req.Headers {
+		if err := p.mut.InsertHTTPHeader(ctx, mutation.HTTPHeaderInsertItem{
+			ID: h.ID, HttpID: httpID, WorkspaceID: ws,
+			Params: gen.CreateHTTPHeaderParams{
+				ID: h.ID, HttpID: httpID, HeaderKey: h.Key, HeaderValue: h.Value, Description: h.Description,
+				Enabled: h.Enabled, DisplayOrder: float64(h.DisplayOrder), CreatedAt: now, UpdatedAt: now,
+			},
+		}); err != nil {
+			return err
+		}
+		p.mut.UpdateLastEventPayload(h)
+	}
+	for _, q := range req.SearchParams {
+		if err := p.mut.InsertHTTPSearchParam(ctx, mutation.HTTPSearchParamInsertItem{
+			ID: q.ID, HttpID: httpID, WorkspaceID: ws,
+			Params: gen.CreateHTTPSearchParamParams{
+				ID: q.ID, HttpID: httpID, Key: q.Key, Value: q.Value, Description: q.Description,
+				Enabled: q.Enabled, DisplayOrder: q.DisplayOrder, CreatedAt: now, UpdatedAt: now,
+			},
+		}); err != nil {
+			return err
+		}
+		p.mut.UpdateLastEventPayload(q)
+	}
+	for _, f := range req.BodyForms {
+		if err := p.mut.InsertHTTPBodyForm(ctx, mutation.HTTPBodyFormInsertItem{
+			ID: f.ID, HttpID: httpID, WorkspaceID: ws,
+			Params: gen.CreateHTTPBodyFormParams{
+				ID: f.ID, HttpID: httpID, Key: f.Key, Value: f.Value, Description: f.Description,
+				Enabled: f.Enabled, DisplayOrder: float64(f.DisplayOrder), CreatedAt: now, UpdatedAt: now,
+			},
+		}); err != nil {
+			return err
+		}
+		p.mut.UpdateLastEventPayload(f)
+	}
+	for _, u := range req.BodyUrlencoded {
+		if err := p.mut.InsertHTTPBodyUrlEncoded(ctx, mutation.HTTPBodyUrlEncodedInsertItem{
+			ID: u.ID, HttpID: httpID, WorkspaceID: ws,
+			Params: gen.CreateHTTPBodyUrlEncodedParams{
+				ID: u.ID, HttpID: httpID, Key: u.Key, Value: u.Value, Description: u.Description,
+				Enabled: u.Enabled, DisplayOrder: float64(u.DisplayOrder), CreatedAt: now, UpdatedAt: now,
+			},
+		}); err != nil {
+			return err
+		}
+		p.mut.UpdateLastEventPayload(u)
+	}
+	if raw := req.BodyRaw; raw != nil {
+		if err := p.mut.InsertHTTPBodyRaw(ctx, mutation.HTTPBodyRawInsertItem{
+			ID: raw.ID, HttpID: httpID, WorkspaceID: ws,
+			Params: gen.CreateHTTPBodyRawParams{
+				ID: raw.ID, HttpID: httpID, RawData: raw.RawData, CreatedAt: now, UpdatedAt: now,
+			},
+		}); err != nil {
+			return err
+		}
+		p.mut.UpdateLastEventPayload(*raw)
+	}
+	for _, a := range req.Asserts {
+		if err := p.mut.InsertHTTPAssert(ctx, mutation.HTTPAssertInsertItem{
+			ID: a.ID, HttpID: httpID, WorkspaceID: ws,
+			Params: gen.CreateHTTPAssertParams{
+				ID: a.ID, HttpID: httpID, Value: a.Value, Description: a.Description,
+				Enabled: a.Enabled, DisplayOrder: float64(a.DisplayOrder), CreatedAt: now, UpdatedAt: now,
+			},
+		}); err != nil {
+			return err
+		}
+		p.mut.UpdateLastEventPayload(a)
+	}
+	return nil
+}
+
+func (p *pusher) deleteRequestChildren(ctx context.Context, req *request) error {
+	q := p.mut.Queries()
+	track := func(entity mutation.EntityType, id idwrap.IDWrap) {
+		p.mut.Track(mutation.Event{Entity: entity, Op: mutation.OpDelete, ID: id, WorkspaceID: p.workspaceID, ParentID: req.HTTP.ID})
+	}
+	for _, h := range req.Headers {
+		if err := q.DeleteHTTPHeader(ctx, h.ID); err != nil {
+			return err
+		}
+		track(mutation.EntityHTTPHeader, h.ID)
+	}
+	for _, sp := range req.SearchParams {
+		if err := q.DeleteHTTPSearchParam(ctx, sp.ID); err != nil {
+			return err
+		}
+		track(mutation.EntityHTTPParam, sp.ID)
+	}
+	for _, f := range req.BodyForms {
+		if err := q.DeleteHTTPBodyForm(ctx, f.ID); err != nil {
+			return err
+		}
+		track(mutation.EntityHTTPBodyForm, f.ID)
+	}
+	for _, u := range req.BodyUrlencoded {
+		if err := q.DeleteHTTPBodyUrlEncoded(ctx, u.ID); err != nil {
+			return err
+		}
+		track(mutation.EntityHTTPBodyURL, u.ID)
+	}
+	if req.BodyRaw != nil {
+		if err := q.DeleteHTTPBodyRaw(ctx, req.BodyRaw.ID); err != nil {
+			return err
+		}
+		track(mutation.EntityHTTPBodyRaw, req.BodyRaw.ID)
+	}
+	for _, a := range req.Asserts {
+		if err := q.DeleteHTTPAssert(ctx, a.ID); err != nil {
+			return err
+		}
+		track(mutation.EntityHTTPAssert, a.ID)
+	}
+	return nil
+}
+
+// applyFlow replaces a changed flow as a whole: a flow file carries no node
+// identity to match against. The file keeps its place in the tree.
+func (p *pusher) applyFlow(ctx context.Context, c Change) error {
+	var order float64
+	if c.Op != OpAdd {
+		flowID := p.remote.IDs[c.Path]
+		if f, ok := p.file(p.remote.Entries[c.Path]); ok {
+			order = f.Order
+		}
+		private := p.privateRequests(flowID)
+		if err := p.mut.DeleteFlow(ctx, mutation.FlowDeleteItem{ID: flowID, WorkspaceID: p.workspaceID}); err != nil {
+			return err
+		}
+		// Deltas go before the requests they overlay.
+		for i := len(private) - 1; i >= 0; i-- {
+			if err := p.mut.DeleteHTTP(ctx, mutation.HTTPDeleteItem{ID: private[i].ID, WorkspaceID: p.workspaceID, IsDelta: private[i].IsDelta}); err != nil {
+				return err
+			}
+		}
+		if c.Op == OpRemove {
+			return nil
+		}
+	}
+
+	folderID, err := p.folder(ctx, path.Dir(c.Path))
+	if err != nil {
+		return err
+	}
+	flowBundle, err := decodeFlow(p.local[c.Path], p.workspaceID)
+	if err != nil {
+		return err
+	}
+	result, err := ioworkspace.New(p.mut.Queries(), p.logger).Import(ctx, p.mut.TX(), flowBundle, ioworkspace.ImportOptions{
+		WorkspaceID: p.workspaceID,
+		ImportHTTP:  true,
+		ImportFlows: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	flow := flowBundle.Flows[0]
+	flow.ID = result.FlowIDMap[flow.ID]
+	flow.WorkspaceID = p.workspaceID
+	p.mut.Track(mutation.Event{Entity: mutation.EntityFlow, Op: mutation.OpInsert, ID: flow.ID, WorkspaceID: p.workspaceID, Payload: flow})
+	return p.createFile(ctx, &mfile.File{
+		ID:          idwrap.NewNow(),
+		WorkspaceID: p.workspaceID,
+		ParentID:    folderID,
+		ContentID:   &flow.ID,
+		ContentType: mfile.ContentTypeFlow,
+		Name:        flow.Name,
+		Order:       order,
+	})
+}
+
+// privateRequests returns the HTTP requests only flowID runs and that have
+// no place in the file tree. They belong to the flow and go with it.
+func (p *pusher) privateRequests(flowID idwrap.IDWrap) []mhttp.HTTP {
+	inTree := make(map[idwrap.IDWrap]bool)
+	for _, f := range p.bundle.Files {
+		if f.ContentID != nil {
+			inTree[*f.ContentID] = true
+		}
+	}
+	for _, other := range p.bundle.Flows {
+		if other.ID == flowID {
+			continue
+		}
+		for _, id := range flowRequests(p.bundle, other.ID) {
+			inTree[id] = true
+		}
+	}
+
+	var private []mhttp.HTTP
+	for _, id := range flowRequests(p.bundle, flowID) {
+		if inTree[id] {
+			continue
+		}
+		for _, h := range p.bundle.HTTPRequests {
+			if h.ID == id {
+				private = append(private, h)
+			}
+		}
+	}
+	return private
+}
+
+// folder returns the folder at the slash-separated path dir, creating the
+// missing folders along the way. The root is nil.
+func (p *pusher) folder(ctx context.Context, dir string) (*idwrap.IDWrap, error) {
+	if dir == "." || dir == "" {
+		return nil, nil
+	}
+	if id, ok := p.remote.Folders[dir]; ok {
+		return &id, nil
+	}
+	parentID, err := p.folder(ctx, path.Dir(dir))
+	if err != nil {
+		return nil, err
+	}
+	f := &mfile.File{
+		ID:          idwrap.NewNow(),
+		WorkspaceID: p.workspaceID,
+		ParentID:    parentID,
+		ContentType: mfile.ContentTypeFolder,
+		Name:        path.Base(dir),
+	}
+	if err := p.createFile(ctx, f); err != nil {
+		return nil, err
+	}
+	p.remote.Folders[dir] = f.ID
+	return &f.ID, nil
+}
+
+func (p *pusher) createFile(ctx context.Context, f *mfile.File) error {
+	if err := sfile.NewWriterFromQueries(p.mut.Queries(), p.logger).CreateFile(ctx, f); err != nil {
+		return err
+	}
+	p.mut.Track(mutation.Event{Entity: mutation.EntityFile, Op: mutation.OpInsert, ID: f.ID, WorkspaceID: p.workspaceID, Payload: *f})
+	return nil
+}
+
+func (p *pusher) renameFile(ctx context.Context, fileID idwrap.IDWrap, name string) error {
+	f, ok := p.file(fileID)
+	if !ok {
+		return nil
+	}
+	f.Name = name
+	if err := sfile.NewWriterFromQueries(p.mut.Queries(), p.logger).UpdateFile(ctx, &f); err != nil {
+		return err
+	}
+	p.mut.Track(mutation.Event{Entity: mutation.EntityFile, Op: mutation.OpUpdate, ID: f.ID, WorkspaceID: p.workspaceID, Payload: f})
+	return nil
+}
+
+func (p *pusher) file(id idwrap.IDWrap) (mfile.File, bool) {
+	for _, f := range p.bundle.Files {
+		if f.ID == id {
+			return f, true
+		}
+	}
+	return mfile.File{}, false
+}
+
+func baseName(p, suffix string) string {
+	return strings.TrimSuffix(path.Base(p), suffix)
+}
@@ -0,0 +1,229 @@
+package dirsync
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/compress"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/yamlflowsimplev2"
+)
+
+// requestFile is the content of a request file. Keys follow the request
+// definitions of yamlflow, but lists are always written as lists so that
+// order and repeated keys survive the round trip.
+type requestFile struct {
+	Name        string                                 `yaml:"name"`
+	Method      string                                 `yaml:"method"`
+	URL         string                                 `yaml:"url"`
+	Description string                                 `yaml:"description,omitempty"`
+	QueryParams []yamlflowsimplev2.YamlNameValuePairV2 `yaml:"query_params,omitempty"`
+	Headers     []yamlflowsimplev2.YamlNameValuePairV2 `yaml:"headers,omitempty"`
+	Body        *requestBody                           `yaml:"body,omitempty"`
+	Assertions  []yamlflowsimplev2.YamlAssertionV2     `yaml:"assertions,omitempty"`
+}
+
+// requestBody holds exactly one of its fields, matching the body kind
+type requestBody struct {
+	Raw        string                                 `yaml:"raw,omitempty"`
+	FormData   []yamlflowsimplev2.YamlNameValuePairV2 `yaml:"form_data,omitempty"`
+	UrlEncoded []yamlflowsimplev2.YamlNameValuePairV2 `yaml:"urlencoded,omitempty"`
+}
+
+// request is a base HTTP request with its children
+type request struct {
+	HTTP           mhttp.HTTP
+	Headers        []mhttp.HTTPHeader
+	SearchParams   []mhttp.HTTPSearchParam
+	BodyRaw        *mhttp.HTTPBodyRaw
+	BodyForms      []mhttp.HTTPBodyForm
+	BodyUrlencoded []mhttp.HTTPBodyUrlencoded
+	Asserts        []mhttp.HTTPAssert
+}
+
+// bundleRequest collects the base HTTP request httpID of bundle with its
+// children, or false when bundle has no such request
+func bundleRequest(bundle *ioworkspace.WorkspaceBundle, httpID idwrap.IDWrap) (*request, bool) {
+	req := &request{}
+	found := false
+	for _, h := range bundle.HTTPRequests {
+		if h.ID == httpID && !h.IsDelta {
+			req.HTTP = h
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	for _, h := range bundle.HTTPHeaders {
+		if h.HttpID == httpID && !h.IsDelta {
+			req.Headers = append(req.Headers, h)
+		}
+	}
+	for _, p := range bundle.HTTPSearchParams {
+		if p.HttpID == httpID && !p.IsDelta {
+			req.SearchParams = append(req.SearchParams, p)
+		}
+	}
+	for i := range bundle.HTTPBodyRaw {
+		if raw := bundle.HTTPBodyRaw[i]; raw.HttpID == httpID && !raw.IsDelta {
+			req.BodyRaw = &raw
+		}
+	}
+	for _, f := range bundle.HTTPBodyForms {
+		if f.HttpID == httpID && !f.IsDelta {
+			req.BodyForms = append(req.BodyForms, f)
+		}
+	}
+	for _, u := range bundle.HTTPBodyUrlencoded {
+		if u.HttpID == httpID && !u.IsDelta {
+			req.BodyUrlencoded = append(req.BodyUrlencoded, u)
+		}
+	}
+	for _, a := range bundle.HTTPAsserts {
+		if a.HttpID == httpID && !a.IsDelta {
+			req.Asserts = append(req.Asserts, a)
+		}
+	}
+
+	sort.SliceStable(req.Headers, func(i, j int) bool { return req.Headers[i].DisplayOrder < req.Headers[j].DisplayOrder })
+	sort.SliceStable(req.SearchParams, func(i, j int) bool { return req.SearchParams[i].DisplayOrder < req.SearchParams[j].DisplayOrder })
+	sort.SliceStable(req.BodyForms, func(i, j int) bool { return req.BodyForms[i].DisplayOrder < req.BodyForms[j].DisplayOrder })
+	sort.SliceStable(req.BodyUrlencoded, func(i, j int) bool { return req.BodyUrlencoded[i].DisplayOrder < req.BodyUrlencoded[j].DisplayOrder })
+	sort.SliceStable(req.Asserts, func(i, j int) bool { return req.Asserts[i].DisplayOrder < req.Asserts[j].DisplayOrder })
+	return req, true
+}
+
+func encodeRequest(bundle *ioworkspace.WorkspaceBundle, httpID idwrap.IDWrap) ([]byte, bool, error) {
+	req, ok := bundleRequest(bundle, httpID)
+	if !ok {
+		return nil, false, nil
+	}
+
+	rf := requestFile{
+		Name:        req.HTTP.Name,
+		Method:      req.HTTP.Method,
+		URL:         req.HTTP.Url,
+		Description: req.HTTP.Description,
+	}
+	for _, p := range req.SearchParams {
+		rf.QueryParams = append(rf.QueryParams, pair(p.Key, p.Value, p.Description, p.Enabled))
+	}
+	for _, h := range req.Headers {
+		rf.Headers = append(rf.Headers, pair(h.Key, h.Value, h.Description, h.Enabled))
+	}
+	for _, a := range req.Asserts {
+		rf.Assertions = append(rf.Assertions, yamlflowsimplev2.YamlAssertionV2{Expression: a.Value, Enabled: a.Enabled})
+	}
+
+	switch req.HTTP.BodyKind {
+	case mhttp.HttpBodyKindRaw:
+		if req.BodyRaw != nil && len(req.BodyRaw.RawData) > 0 {
+			data := req.BodyRaw.RawData
+			if req.BodyRaw.CompressionType != compress.CompressTypeNone {
+				decompressed, err := compress.Decompress(data, req.BodyRaw.CompressionType)
+				if err != nil {
+					return nil, false, fmt.Errorf("failed to decompress body: %w", err)
+				}
+				data = decompressed
+			}
+			rf.Body = &requestBody{Raw: string(data)}
+		}
+	case mhttp.HttpBodyKindFormData:
+		body := &requestBody{}
+		for _, f := range req.BodyForms {
+			body.FormData = append(body.FormData, pair(f.Key, f.Value, f.Description, f.Enabled))
+		}
+		if len(body.FormData) > 0 {
+			rf.Body = body
+		}
+	case mhttp.HttpBodyKindUrlEncoded:
+		body := &requestBody{}
+		for _, u := range req.BodyUrlencoded {
+			body.UrlEncoded = append(body.UrlEncoded, pair(u.Key, u.Value, u.Description, u.Enabled))
+		}
+		if len(body.UrlEncoded) > 0 {
+			rf.Body = body
+		}
+	}
+
+	data, err := yaml.Marshal(rf)
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// decodeRequest parses a request file into a request with fresh IDs for
+// httpID, which the caller fills in for a new request
+func decodeRequest(data []byte, httpID idwrap.IDWrap) (*request, error) {
+	var rf requestFile
+	if err := yaml.Unmarshal(data, &rf); err != nil {
+		return nil, err
+	}
+	if rf.Method == "" {
+		rf.Method = "GET"
+	}
+
+	req := &request{HTTP: mhttp.HTTP{
+		ID:          httpID,
+		Name:        rf.Name,
+		Url:         rf.URL,
+		Method:      rf.Method,
+		Description: rf.Description,
+		BodyKind:    mhttp.HttpBodyKindNone,
+	}}
+	for i, p := range rf.QueryParams {
+		req.SearchParams = append(req.SearchParams, mhttp.HTTPSearchParam{
+			ID: idwrap.NewNow(), HttpID: httpID, Key: p.Name, Value: p.Value,
+			Description: p.Description, Enabled: p.Enabled, DisplayOrder: float64(i + 1),
+		})
+	}
+	for i, h := range rf.Headers {
+		req.Headers = append(req.Headers, mhttp.HTTPHeader{
+			ID: idwrap.NewNow(), HttpID: httpID, Key: h.Name, Value: h.Value,
+			Description: h.Description, Enabled: h.Enabled, DisplayOrder: float32(i + 1),
+		})
+	}
+	for i, a := range rf.Assertions {
+		req.Asserts = append(req.Asserts, mhttp.HTTPAssert{
+			ID: idwrap.NewNow(), HttpID: httpID, Value: a.Expression,
+			Enabled: a.Enabled, DisplayOrder: float32(i + 1),
+		})
+	}
+
+	if body := rf.Body; body != nil {
+		switch {
+		case len(body.FormData) > 0:
+			req.HTTP.BodyKind = mhttp.HttpBodyKindFormData
+			for i, f := range body.FormData {
+				req.BodyForms = append(req.BodyForms, mhttp.HTTPBodyForm{
+					ID: idwrap.NewNow(), HttpID: httpID, Key: f.Name, Value: f.Value,
+					Description: f.Description, Enabled: f.Enabled, DisplayOrder: float32(i + 1),
+				})
+			}
+		case len(body.UrlEncoded) > 0:
+			req.HTTP.BodyKind = mhttp.HttpBodyKindUrlEncoded
+			for i, u := range body.UrlEncoded {
+				req.BodyUrlencoded = append(req.BodyUrlencoded, mhttp.HTTPBodyUrlencoded{
+					ID: idwrap.NewNow(), HttpID: httpID, Key: u.Name, Value: u.Value,
+					Description: u.Description, Enabled: u.Enabled, DisplayOrder: float32(i + 1),
+				})
+			}
+		case body.Raw != "":
+			req.HTTP.BodyKind = mhttp.HttpBodyKindRaw
+			req.BodyRaw = &mhttp.HTTPBodyRaw{ID: idwrap.NewNow(), HttpID: httpID, RawData: []byte(body.Raw)}
+		}
+	}
+	return req, nil
+}
+
+func pair(key, value, description string, enabled bool) yamlflowsimplev2.YamlNameValuePairV2 {
+	return yamlflowsimplev2.YamlNameValuePairV2{Name: key, Value: value, Description: description, Enabled: enabled}
+}
@@ -0,0 +1,147 @@
+package dirsync
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+)
+
+// Options controls a Pull or a Push
+type Options struct {
+	// DryRun reports the changes without applying them
+	DryRun bool
+	// Publisher receives the events of a Push once it is committed
+	Publisher mutation.Publisher
+	Logger    *slog.Logger
+}
+
+func (o Options) logger() *slog.Logger {
+	if o.Logger == nil {
+		return slog.Default()
+	}
+	return o.Logger
+}
+
+// Pull writes workspace workspaceID to dir in the directory layout, touching
+// only the files whose content changed. A zero workspaceID pulls the
+// workspace dir is already bound to.
+func Pull(ctx context.Context, db *sql.DB, dir string, workspaceID idwrap.IDWrap, opts Options) ([]Change, error) {
+	local, err := ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if workspaceID == (idwrap.IDWrap{}) {
+		if workspaceID, err = WorkspaceID(local); err != nil {
+			return nil, err
+		}
+	}
+
+	bundle, err := exportWorkspace(ctx, gen.New(db), workspaceID, opts.logger())
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := Encode(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := Diff(snapshot.Files, local)
+	if opts.DryRun {
+		return changes, nil
+	}
+	if err := writeChanges(dir, snapshot.Files, changes); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", dir, err)
+	}
+	return changes, nil
+}
+
+// Push applies the changes made in dir to the workspace it is bound to. The
+// workspace is rendered in the directory layout and compared file by file
+// by content hash; only the entities whose file differs are written, through
+// the mutation layer and in a single transaction.
+func Push(ctx context.Context, db *sql.DB, dir string, opts Options) ([]Change, error) {
+	local, err := ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	workspaceID, err := WorkspaceID(local)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutOpts []mutation.Option
+	if opts.Publisher != nil {
+		mutOpts = append(mutOpts, mutation.WithPublisher(opts.Publisher))
+	}
+	mut := mutation.New(db, mutOpts...)
+	if err := mut.Begin(ctx); err != nil {
+		return nil, err
+	}
+	defer mut.Rollback()
+
+	bundle, err := exportWorkspace(ctx, mut.Queries(), workspaceID, opts.logger())
+	if err != nil {
+		return nil, err
+	}
+	remote, err := Encode(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for _, c := range Diff(local, remote.Files) {
+		// The workspace file only binds the directory.
+		if c.Kind != KindWorkspace {
+			changes = append(changes, c)
+		}
+	}
+	if opts.DryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	p := &pusher{
+		mut:         mut,
+		logger:      opts.logger(),
+		workspaceID: workspaceID,
+		bundle:      bundle,
+		remote:      remote,
+		local:       local,
+	}
+	// Environments first, so that requests and flows pushed alongside them
+	// can be run right away; flows last, as they may run pushed requests.
+	for _, kind := range []Kind{KindEnvironment, KindRequest, KindFlow} {
+		for _, c := range changes {
+			if c.Kind != kind {
+				continue
+			}
+			if err := p.apply(ctx, c); err != nil {
+				return nil, fmt.Errorf("%s: %w", c.Path, err)
+			}
+		}
+	}
+
+	if err := mut.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func exportWorkspace(ctx context.Context, queries *gen.Queries, workspaceID idwrap.IDWrap, logger *slog.Logger) (*ioworkspace.WorkspaceBundle, error) {
+	bundle, err := ioworkspace.New(queries, logger).Export(ctx, ioworkspace.ExportOptions{
+		WorkspaceID:         workspaceID,
+		IncludeHTTP:         true,
+		IncludeFlows:        true,
+		IncludeEnvironments: true,
+		IncludeFiles:        true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export workspace: %w", err)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,256 @@
+package dirsync
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlitemem"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mutation"
+)
+
+const listUsersRequest = `name: List users
+method: GET
+url: https://api.example.com/users
+query_params:
+  - name: page
+    value: "1"
+headers:
+  - name: Accept
+    value: application/json
+  - name: Accept
+    value: text/plain
+assertions:
+  - expression: response.status == 200
+`
+
+const devEnvironment = `name: dev
+variables:
+  - name: base_url
+    value: https://api.example.com
+  - name: api_token
+    value: s3cr3t
+`
+
+const checkoutFlow = `workspace_name: Sync
+flows:
+  - name: Checkout
+    steps:
+      - manual_start:
+          name: Start
+      - request:
+          name: Cart
+          depends_on: Start
+          method: POST
+          url: https://api.example.com/cart
+`
+
+type recordingPublisher struct {
+	events []mutation.Event
+}
+
+func (p *recordingPublisher) PublishAll(events []mutation.Event) {
+	p.events = append(p.events, events...)
+}
+
+func newSyncWorkspace(t *testing.T) (*sql.DB, idwrap.IDWrap) {
+	t.Helper()
+	ctx := context.Background()
+
+	db, _, err := sqlitemem.NewSQLiteMem(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	wsID := idwrap.NewNow()
+	require.NoError(t, gen.New(db).CreateWorkspace(ctx, gen.CreateWorkspaceParams{
+		ID:   wsID,
+		Name: "Sync",
+	}))
+	return db, wsID
+}
+
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	target := filepath.Join(dir, filepath.FromSlash(rel))
+	require.NoError(t, os.MkdirAll(filepath.Dir(target), 0o755))
+	require.NoError(t, os.WriteFile(target, []byte(content), 0o644))
+}
+
+func readFile(t *testing.T, dir, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestPushPullRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db, wsID := newSyncWorkspace(t)
+
+	authored := t.TempDir()
+	changes, err := Pull(ctx, db, authored, wsID, Options{})
+	require.NoError(t, err)
+	require.Equal(t, []Change{{Path: WorkspaceFile, Kind: KindWorkspace, Op: OpAdd}}, changes)
+
+	writeFile(t, authored, "users/List users.request.yaml", listUsersRequest)
+	writeFile(t, authored, EnvironmentDir+"/dev.yaml", devEnvironment)
+	writeFile(t, authored, "Checkout.flow.yaml", checkoutFlow)
+
+	changes, err = Push(ctx, db, authored, Options{})
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	pulled := t.TempDir()
+	_, err = Pull(ctx, db, pulled, wsID, Options{})
+	require.NoError(t, err)
+
+	request := readFile(t, pulled, "users/List users.request.yaml")
+	require.Contains(t, request, "https://api.example.com/users")
+	require.Equal(t, 2, strings.Count(request, "name: Accept"), "duplicate headers must survive")
+	require.Contains(t, request, "response.status == 200")
+
+	env := readFile(t, pulled, EnvironmentDir+"/dev.yaml")
+	require.Contains(t, env, "https://api.example.com")
+	require.Contains(t, env, "api_token")
+	require.NotContains(t, env, "s3cr3t", "secrets must not be written")
+
+	require.Contains(t, readFile(t, pulled, "Checkout.flow.yaml"), "https://api.example.com/cart")
+
+	// The pulled directory is what the workspace renders to, so pushing it
+	// back changes nothing - not even the secret it left out.
+	changes, err = Push(ctx, db, pulled, Options{})
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	changes, err = Pull(ctx, db, pulled, idwrap.IDWrap{}, Options{})
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestPushAppliesOnlyChangedEntities(t *testing.T) {
+	ctx := context.Background()
+	db, wsID := newSyncWorkspace(t)
+
+	dir := t.TempDir()
+	_, err := Pull(ctx, db, dir, wsID, Options{})
+	require.NoError(t, err)
+	writeFile(t, dir, "users/List users.request.yaml", listUsersRequest)
+	writeFile(t, dir, "users/Get user.request.yaml", "name: Get user\nurl: https://api.example.com/users/1\n")
+	writeFile(t, dir, EnvironmentDir+"/dev.yaml", devEnvironment)
+	_, err = Push(ctx, db, dir, Options{})
+	require.NoError(t, err)
+
+	// Re-pull so the files are in canonical form before editing them.
+	_, err = Pull(ctx, db, dir, wsID, Options{})
+	require.NoError(t, err)
+	edited := strings.Replace(readFile(t, dir, "users/Get user.request.yaml"), "/users/1", "/users/2", 1)
+	writeFile(t, dir, "users/Get user.request.yaml", edited)
+	require.NoError(t, os.Remove(filepath.Join(dir, "users", "List users.request.yaml")))
+
+	publisher := &recordingPublisher{}
+	changes, err := Push(ctx, db, dir, Options{Publisher: publisher})
+	require.NoError(t, err)
+	require.Equal(t, []Change{
+		{Path: "users/Get user.request.yaml", Kind: KindRequest, Op: OpModify},
+		{Path: "users/List users.request.yaml", Kind: KindRequest, Op: OpRemove},
+	}, changes)
+
+	for _, evt := range publisher.events {
+		require.NotEqual(t, mutation.EntityEnvironment, evt.Entity, "untouched environment must not be written")
+		require.NotEqual(t, mutation.EntityEnvironmentValue, evt.Entity, "untouched environment must not be written")
+	}
+
+	// The secret the pulled file left out is still in the database.
+	bundle, err := exportWorkspace(ctx, gen.New(db), wsID, slog.Default())
+	require.NoError(t, err)
+	var found bool
+	for _, v := range bundle.EnvironmentVars {
+		if v.VarKey == "api_token" {
+			found = true
+			require.Equal(t, "s3cr3t", v.Value)
+		}
+	}
+	require.True(t, found)
+
+	other := t.TempDir()
+	_, err = Pull(ctx, db, other, wsID, Options{})
+	require.NoError(t, err)
+	require.Contains(t, readFile(t, other, "users/Get user.request.yaml"), "/users/2")
+	_, err = os.Stat(filepath.Join(other, "users", "List users.request.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestPullRemovesStaleFiles(t *testing.T) {
+	ctx := context.Background()
+	db, wsID := newSyncWorkspace(t)
+
+	dir := t.TempDir()
+	_, err := Pull(ctx, db, dir, wsID, Options{})
+	require.NoError(t, err)
+	writeFile(t, dir, "old/Gone.request.yaml", "url: https://example.com\n")
+	writeFile(t, dir, "README.md", "not part of the layout\n")
+
+	changes, err := Pull(ctx, db, dir, idwrap.IDWrap{}, Options{DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, []Change{{Path: "old/Gone.request.yaml", Kind: KindRequest, Op: OpRemove}}, changes)
+	_, err = os.Stat(filepath.Join(dir, "old", "Gone.request.yaml"))
+	require.NoError(t, err, "a dry run must not touch the directory")
+
+	_, err = Pull(ctx, db, dir, idwrap.IDWrap{}, Options{})
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "old"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(filepath.Join(dir, "README.md"))
+	require.NoError(t, err)
+}
+
+func TestPushRequiresBoundDirectory(t *testing.T) {
+	db, _ := newSyncWorkspace(t)
+	_, err := Push(context.Background(), db, t.TempDir(), Options{})
+	require.ErrorIs(t, err, ErrNotBound)
+}
+
+func TestPushReplacesModifiedFlow(t *testing.T) {
+	ctx := context.Background()
+	db, wsID := newSyncWorkspace(t)
+
+	dir := t.TempDir()
+	_, err := Pull(ctx, db, dir, wsID, Options{})
+	require.NoError(t, err)
+	writeFile(t, dir, "checkout/Checkout.flow.yaml", checkoutFlow)
+	_, err = Push(ctx, db, dir, Options{})
+	require.NoError(t, err)
+
+	writeFile(t, dir, "checkout/Checkout.flow.yaml", strings.Replace(checkoutFlow, "/cart", "/basket", 1))
+	changes, err := Push(ctx, db, dir, Options{})
+	require.NoError(t, err)
+	require.Equal(t, []Change{{Path: "checkout/Checkout.flow.yaml", Kind: KindFlow, Op: OpModify}}, changes)
+
+	bundle, err := exportWorkspace(ctx, gen.New(db), wsID, slog.Default())
+	require.NoError(t, err)
+	require.Len(t, bundle.Flows, 1)
+	for _, h := range bundle.HTTPRequests {
+		require.NotContains(t, h.Url, "/cart", "the requests of the replaced flow must go with it")
+	}
+
+	var flowFiles int
+	for _, f := range bundle.Files {
+		if f.ContentID != nil && *f.ContentID == bundle.Flows[0].ID {
+			flowFiles++
+		}
+	}
+	require.Equal(t, 1, flowFiles)
+
+	other := t.TempDir()
+	_, err = Pull(ctx, db, other, wsID, Options{})
+	require.NoError(t, err)
+	require.Contains(t, readFile(t, other, "checkout/Checkout.flow.yaml"), "/basket")
+}
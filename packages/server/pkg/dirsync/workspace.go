@@ -0,0 +1,38 @@
+package dirsync
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mworkspace"
+)
+
+// workspaceFile is the content of WorkspaceFile
+type workspaceFile struct {
+	WorkspaceID string `yaml:"workspace_id"`
+	Name        string `yaml:"name"`
+}
+
+func encodeWorkspace(ws mworkspace.Workspace) ([]byte, error) {
+	return yaml.Marshal(workspaceFile{WorkspaceID: ws.ID.String(), Name: ws.Name})
+}
+
+// WorkspaceID returns the workspace a directory is bound to, read from its
+// WorkspaceFile
+func WorkspaceID(files map[string][]byte) (idwrap.IDWrap, error) {
+	data, ok := files[WorkspaceFile]
+	if !ok {
+		return idwrap.IDWrap{}, ErrNotBound
+	}
+	var wf workspaceFile
+	if err := yaml.Unmarshal(data, &wf); err != nil {
+		return idwrap.IDWrap{}, fmt.Errorf("%s: %w", WorkspaceFile, err)
+	}
+	id, err := idwrap.NewText(wf.WorkspaceID)
+	if err != nil {
+		return idwrap.IDWrap{}, fmt.Errorf("%s: invalid workspace_id: %w", WorkspaceFile, err)
+	}
+	return id, nil
+}
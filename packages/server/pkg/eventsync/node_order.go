@@ -11,24 +11,39 @@ import (
 // Lower values = higher priority (published first).
 // Container nodes have lower priority so they exist before children.
 const (
-	PriorityManualStart = 0   // Entry point - always first
-	PriorityFor         = 100 // Loop container
-	PriorityForEach     = 100 // Loop container
-	PriorityCondition   = 100 // Branch container
-	PriorityRequest     = 200 // Leaf node
-	PriorityJS          = 200 // Leaf node
-	PriorityUnspecified = 999 // Unknown - last
+	PriorityManualStart  = 0   // Entry point - always first
+	PriorityTeardown     = 0   // Teardown entry point - first of its section
+	PriorityWebhook      = 0   // Webhook entry point
+	PriorityFor          = 100 // Loop container
+	PriorityForEach      = 100 // Loop container
+	PriorityCondition    = 100 // Branch container
+	PriorityTry          = 100 // Try body container
+	PriorityParallel     = 100 // Parallel branch container
+	PriorityPoll         = 100 // Poll body container
+	PrioritySwitch       = 100 // Branch container
+	PrioritySubscription = 100 // Event chain container
+	PriorityRequest      = 200 // Leaf node
+	PriorityJS           = 200 // Leaf node
+	PriorityUnspecified  = 999 // Unknown - last
 )
 
 // NodeKindPriority maps node kinds to their base priority.
 var NodeKindPriority = map[mflow.NodeKind]int{
-	mflow.NODE_KIND_MANUAL_START: PriorityManualStart,
-	mflow.NODE_KIND_FOR:          PriorityFor,
-	mflow.NODE_KIND_FOR_EACH:     PriorityForEach,
-	mflow.NODE_KIND_CONDITION:    PriorityCondition,
-	mflow.NODE_KIND_REQUEST:      PriorityRequest,
-	mflow.NODE_KIND_JS:           PriorityJS,
-	mflow.NODE_KIND_UNSPECIFIED:  PriorityUnspecified,
+	mflow.NODE_KIND_MANUAL_START:         PriorityManualStart,
+	mflow.NODE_KIND_FOR:                  PriorityFor,
+	mflow.NODE_KIND_FOR_EACH:             PriorityForEach,
+	mflow.NODE_KIND_CONDITION:            PriorityCondition,
+	mflow.NODE_KIND_TRY:                  PriorityTry,
+	mflow.NODE_KIND_PARALLEL:             PriorityParallel,
+	mflow.NODE_KIND_POLL:                 PriorityPoll,
+	mflow.NODE_KIND_SWITCH:               PrioritySwitch,
+	mflow.NODE_KIND_TEARDOWN:             PriorityTeardown,
+	mflow.NODE_KIND_WEBHOOK_TRIGGER:      PriorityWebhook,
+	mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION: PrioritySubscription,
+	mflow.NODE_KIND_MQ_CONSUME:           PrioritySubscription,
+	mflow.NODE_KIND_REQUEST:              PriorityRequest,
+	mflow.NODE_KIND_JS:                   PriorityJS,
+	mflow.NODE_KIND_UNSPECIFIED:          PriorityUnspecified,
 }
 
 // GetNodeKindPriority returns the base priority for a node kind.
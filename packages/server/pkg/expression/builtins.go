@@ -2,6 +2,7 @@
 package expression
 
 import (
+	"encoding/base64"
 	"fmt"
 
 	"github.com/go-faker/faker/v4"
@@ -47,6 +48,13 @@ func helperULID() string {
 	return ulid.Make().String()
 }
 
+// helperBase64 encodes a string as standard base64, e.g. for Basic auth
+// headers built from variables.
+// Usage in expressions: base64(user + ":" + password)
+func helperBase64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
 // fakerNamespaceMap is the shared faker map built once at package init. It's
 // stateless (every value is a plain wrapper calling the underlying go-faker
 // function), so reusing a single map across all expression evaluations avoids
@@ -200,6 +200,23 @@ func TestBuiltinULID_Interpolation(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Base64 Built-in Tests
+// =============================================================================
+
+func TestBuiltinBase64_Interpolate(t *testing.T) {
+	env := NewUnifiedEnv(map[string]any{"USER": "admin", "PASS": "s3cret"})
+
+	result, err := env.Interpolate(`Basic {{ base64(USER + ":" + PASS) }}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != "Basic YWRtaW46czNjcmV0" {
+		t.Errorf("expected Basic YWRtaW46czNjcmV0, got: %s", result)
+	}
+}
+
 // =============================================================================
 // AI Built-in Tests
 // =============================================================================
@@ -242,6 +242,9 @@ func (e *UnifiedEnv) buildExprEnv() map[string]any {
 	env["uuid"] = helperUUID
 	env["ulid"] = helperULID
 
+	// Add encoding helpers
+	env["base64"] = helperBase64
+
 	// Add faker namespace for fake-data generators (faker.email(), faker.name(), ...)
 	env["faker"] = fakerNamespaceMap
 
@@ -3,6 +3,7 @@ package flowbuilder
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -15,17 +16,32 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nfor"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nforeach"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/ngraphql"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/ngraphqlsubscription"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nif"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/njs"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nmemory"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nparallel"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/npoll"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nswitch"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/naiprovider"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nrequest"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nstart"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nrunsubflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nsocketconnection"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/ndbquery"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nexec"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nmqconsume"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nmqpublish"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nsocketreceive"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nsocketsend"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nsubflowreturn"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nsubflowtrigger"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nteardown"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/ntry"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwait"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwebhook"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwsconnection"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwsexpect"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwssend"
 	gqlresolver "github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/resolver"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/http/resolver"
@@ -33,6 +49,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mcondition"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/scredential"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/senv"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/sflow"
@@ -60,6 +77,58 @@ type Builder struct {
 	NodeSubFlowReturn    *sflow.NodeSubFlowReturnService
 	NodeRunSubFlow       *sflow.NodeRunSubFlowService
 	SubFlowExecutor      nrunsubflow.SubFlowExecutor
+	// NodeParallel is optional; without it parallel nodes wait for all
+	// branches with no concurrency limit.
+	NodeParallel *sflow.NodeParallelService
+	// NodePoll is optional; without it poll nodes have no until expression
+	// and fail when run.
+	NodePoll *sflow.NodePollService
+	// NodeSwitch is optional; without it switch nodes have no cases and
+	// always follow their default branch.
+	NodeSwitch *sflow.NodeSwitchService
+	// NodeWebhookTrigger is optional; without it webhook nodes have no path
+	// and only expose an empty request.
+	NodeWebhookTrigger *sflow.NodeWebhookTriggerService
+	// NodeGraphQLSubscription is optional; without it subscription nodes
+	// have no GraphQL request and fail when run.
+	NodeGraphQLSubscription *sflow.NodeGraphQLSubscriptionService
+	// NodeWsExpect is optional; without it ws expect nodes have no
+	// connection and fail when run.
+	NodeWsExpect *sflow.NodeWsExpectService
+	// NodeSocketConnection, NodeSocketSend and NodeSocketReceive are
+	// optional; without them socket nodes have no address or connection and
+	// fail when run.
+	NodeSocketConnection *sflow.NodeSocketConnectionService
+	NodeSocketSend       *sflow.NodeSocketSendService
+	NodeSocketReceive    *sflow.NodeSocketReceiveService
+	// NodeDbQuery is optional; without it db query nodes have no query and
+	// fail when run.
+	NodeDbQuery *sflow.NodeDbQueryService
+	// NodeMqPublish and NodeMqConsume are optional; without them message
+	// queue nodes have no topic and fail when run.
+	NodeMqPublish *sflow.NodeMqPublishService
+	NodeMqConsume *sflow.NodeMqConsumeService
+	// NodeExec is optional; without it exec nodes have no command and fail
+	// when run.
+	NodeExec *sflow.NodeExecService
+	// AllowExec lets exec nodes run local commands. Flows may come from
+	// untrusted files, so it is off by default and building a flow with an
+	// exec node fails with nexec.ErrNotAllowed.
+	AllowExec bool
+	// AllowLocalDatabases lets db query nodes open SQLite files. On a server
+	// any workspace member could otherwise point a credential at a file the
+	// server can read, such as its own database, so it is off by default and
+	// such a query fails with ndbquery.ErrLocalFileNotAllowed.
+	AllowLocalDatabases bool
+	// Credential is optional; without it db query and message queue nodes
+	// have no connection details and fail when run.
+	Credential *scredential.CredentialService
+	// WebSocketMessage is optional; without it frames sent and received by
+	// WS Connection nodes are not added to their WebSocket's history.
+	WebSocketMessage *swebsocket.WebSocketMessageService
+	// GraphQLSchema is optional; without it GraphQL nodes send their query
+	// without validating it against the introspected schema.
+	GraphQLSchema *sgraphql.GraphQLSchemaService
 	WebSocket        *swebsocket.WebSocketService
 	WebSocketHeader  *swebsocket.WebSocketHeaderService
 	GraphQL          *sgraphql.GraphQLService
@@ -212,7 +281,9 @@ func (b *Builder) BuildNodes(
 				// Default configuration if missing
 				flowNodeMap[nodeModel.ID] = nforeach.New(nodeModel.ID, nodeModel.Name, "", timeout, mcondition.Condition{}, mflow.ErrorHandling_ERROR_HANDLING_BREAK)
 			} else {
-				flowNodeMap[nodeModel.ID] = nforeach.New(nodeModel.ID, nodeModel.Name, forEachCfg.IterExpression, timeout, forEachCfg.Condition, forEachCfg.ErrorHandling)
+				forEachNode := nforeach.New(nodeModel.ID, nodeModel.Name, forEachCfg.IterExpression, timeout, forEachCfg.Condition, forEachCfg.ErrorHandling)
+				forEachNode.Concurrency = int(forEachCfg.Concurrency)
+				flowNodeMap[nodeModel.ID] = forEachNode
 			}
 		case mflow.NODE_KIND_CONDITION:
 			condCfg, err := b.NodeIf.GetNodeIf(ctx, nodeModel.ID)
@@ -328,7 +399,7 @@ func (b *Builder) BuildNodes(
 				return nil, nil, fmt.Errorf("resolve graphql %s: %w", gqlCfg.GraphQLID.String(), err)
 			}
 
-			flowNodeMap[nodeModel.ID] = ngraphql.New(
+			gqlNode := ngraphql.New(
 				nodeModel.ID,
 				nodeModel.Name,
 				resolved.Resolved,
@@ -338,9 +409,51 @@ func (b *Builder) BuildNodes(
 				gqlRespChan,
 				b.Logger,
 			)
+			if b.GraphQLSchema != nil {
+				gqlSchema, err := b.GraphQLSchema.Schema(ctx, *gqlCfg.GraphQLID)
+				if err != nil && !errors.Is(err, sgraphql.ErrNoGraphQLSchemaFound) {
+					return nil, nil, fmt.Errorf("load graphql schema %s: %w", gqlCfg.GraphQLID.String(), err)
+				}
+				gqlNode.Schema = gqlSchema
+			}
+			flowNodeMap[nodeModel.ID] = gqlNode
+		case mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION:
+			var subCfg *mflow.NodeGraphQLSubscription
+			if b.NodeGraphQLSubscription != nil {
+				var err error
+				subCfg, err = b.NodeGraphQLSubscription.GetNodeGraphQLSubscription(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get graphql subscription config: %w", err)
+				}
+			}
+			if subCfg == nil || subCfg.GraphQLID == nil || isZeroID(*subCfg.GraphQLID) {
+				return nil, nil, fmt.Errorf("graphql subscription node %s missing graphql configuration", nodeModel.ID.String())
+			}
+
+			resolved, err := b.GraphQLResolver.Resolve(ctx, *subCfg.GraphQLID, subCfg.DeltaGraphQLID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("resolve graphql %s: %w", subCfg.GraphQLID.String(), err)
+			}
+
+			// Share the cookie jar with the other requests of this execution.
+			var concreteClient *http.Client
+			if hc, ok := httpClient.(*http.Client); ok {
+				concreteClient = hc
+			}
+			flowNodeMap[nodeModel.ID] = ngraphqlsubscription.New(
+				nodeModel.ID,
+				nodeModel.Name,
+				resolved.Resolved,
+				resolved.ResolvedHeaders,
+				*subCfg,
+				concreteClient,
+			)
 		case mflow.NODE_KIND_WS_CONNECTION:
 			var url string
 			var headers map[string]string
+			var subprotocols []string
+			var protocol nwsconnection.ProtocolConfig
+			var onFrame func(nwsconnection.Frame)
 			if b.NodeWsConnection != nil {
 				wsCfg, err := b.NodeWsConnection.GetNodeWsConnection(ctx, nodeModel.ID)
 				if err != nil {
@@ -352,6 +465,15 @@ func (b *Builder) BuildNodes(
 						return nil, nil, fmt.Errorf("resolve websocket %s: %w", wsCfg.WebSocketID.String(), err)
 					}
 					url = wsEntity.Url
+					subprotocols = wsEntity.Subprotocols
+					protocol = nwsconnection.ProtocolConfig{
+						Protocol:       wsEntity.Protocol,
+						ConnectPayload: wsEntity.ConnectPayload,
+						Subscriptions:  wsEntity.Subscriptions,
+					}
+					if b.WebSocketMessage != nil {
+						onFrame = b.recordWebSocketMessage(ctx, *wsCfg.WebSocketID)
+					}
 					if b.WebSocketHeader != nil {
 						wsHeaders, err := b.WebSocketHeader.GetByWebSocketID(ctx, *wsCfg.WebSocketID)
 						if err != nil {
@@ -373,10 +495,16 @@ func (b *Builder) BuildNodes(
 			concreteClient = hc
 		}
 		wsNode := nwsconnection.New(nodeModel.ID, nodeModel.Name, url, headers, concreteClient)
+			wsNode.Subprotocols = subprotocols
+			wsNode.Protocol = protocol
+			wsNode.OnFrame = onFrame
 			flowNodeMap[nodeModel.ID] = wsNode
 		case mflow.NODE_KIND_WS_SEND:
 			var wsConnName string
 			var message string
+			var binary bool
+			var event string
+			var ack bool
 			if b.NodeWsSend != nil {
 				wsCfg, err := b.NodeWsSend.GetNodeWsSend(ctx, nodeModel.ID)
 				if err != nil {
@@ -385,9 +513,135 @@ func (b *Builder) BuildNodes(
 				if wsCfg != nil {
 					wsConnName = wsCfg.WsConnectionNodeName
 					message = wsCfg.Message
+					binary = wsCfg.Binary
+					event = wsCfg.Event
+					ack = wsCfg.Ack
+				}
+			}
+			sendNode := nwssend.New(nodeModel.ID, nodeModel.Name, wsConnName, message)
+			sendNode.Binary = binary
+			sendNode.Event = event
+			sendNode.Ack = ack
+			flowNodeMap[nodeModel.ID] = sendNode
+		case mflow.NODE_KIND_WS_EXPECT:
+			var expectCfg mflow.NodeWsExpect
+			if b.NodeWsExpect != nil {
+				cfg, err := b.NodeWsExpect.GetNodeWsExpect(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get ws expect config: %w", err)
+				}
+				if cfg != nil {
+					expectCfg = *cfg
+				}
+			}
+			flowNodeMap[nodeModel.ID] = nwsexpect.New(nodeModel.ID, nodeModel.Name, expectCfg)
+		case mflow.NODE_KIND_SOCKET_CONNECTION:
+			var connCfg mflow.NodeSocketConnection
+			if b.NodeSocketConnection != nil {
+				cfg, err := b.NodeSocketConnection.GetNodeSocketConnection(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get socket connection config: %w", err)
+				}
+				if cfg != nil {
+					connCfg = *cfg
+				}
+			}
+			flowNodeMap[nodeModel.ID] = nsocketconnection.New(nodeModel.ID, nodeModel.Name, connCfg)
+		case mflow.NODE_KIND_SOCKET_SEND:
+			var sendCfg mflow.NodeSocketSend
+			if b.NodeSocketSend != nil {
+				cfg, err := b.NodeSocketSend.GetNodeSocketSend(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get socket send config: %w", err)
+				}
+				if cfg != nil {
+					sendCfg = *cfg
+				}
+			}
+			flowNodeMap[nodeModel.ID] = nsocketsend.New(nodeModel.ID, nodeModel.Name, sendCfg)
+		case mflow.NODE_KIND_SOCKET_RECEIVE:
+			var receiveCfg mflow.NodeSocketReceive
+			if b.NodeSocketReceive != nil {
+				cfg, err := b.NodeSocketReceive.GetNodeSocketReceive(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get socket receive config: %w", err)
+				}
+				if cfg != nil {
+					receiveCfg = *cfg
+				}
+			}
+			flowNodeMap[nodeModel.ID] = nsocketreceive.New(nodeModel.ID, nodeModel.Name, receiveCfg)
+		case mflow.NODE_KIND_DB_QUERY:
+			var queryCfg mflow.NodeDbQuery
+			if b.NodeDbQuery != nil {
+				cfg, err := b.NodeDbQuery.GetNodeDbQuery(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get db query config: %w", err)
+				}
+				if cfg != nil {
+					queryCfg = *cfg
+				}
+			}
+			var connectionString string
+			if queryCfg.CredentialID != nil && b.Credential != nil {
+				cred, err := b.Credential.GetCredentialDatabase(ctx, *queryCfg.CredentialID)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return nil, nil, fmt.Errorf("get database credential: %w", err)
+				}
+				if cred != nil {
+					connectionString = cred.ConnectionString
+				}
+			}
+			queryNode := ndbquery.New(nodeModel.ID, nodeModel.Name, connectionString, queryCfg)
+			queryNode.AllowLocalFiles = b.AllowLocalDatabases
+			flowNodeMap[nodeModel.ID] = queryNode
+		case mflow.NODE_KIND_MQ_PUBLISH:
+			var publishCfg mflow.NodeMqPublish
+			if b.NodeMqPublish != nil {
+				cfg, err := b.NodeMqPublish.GetNodeMqPublish(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get mq publish config: %w", err)
+				}
+				if cfg != nil {
+					publishCfg = *cfg
+				}
+			}
+			brokerURL, err := b.messageBrokerURL(ctx, publishCfg.CredentialID)
+			if err != nil {
+				return nil, nil, err
+			}
+			flowNodeMap[nodeModel.ID] = nmqpublish.New(nodeModel.ID, nodeModel.Name, brokerURL, publishCfg)
+		case mflow.NODE_KIND_MQ_CONSUME:
+			var consumeCfg mflow.NodeMqConsume
+			if b.NodeMqConsume != nil {
+				cfg, err := b.NodeMqConsume.GetNodeMqConsume(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get mq consume config: %w", err)
+				}
+				if cfg != nil {
+					consumeCfg = *cfg
+				}
+			}
+			brokerURL, err := b.messageBrokerURL(ctx, consumeCfg.CredentialID)
+			if err != nil {
+				return nil, nil, err
+			}
+			flowNodeMap[nodeModel.ID] = nmqconsume.New(nodeModel.ID, nodeModel.Name, brokerURL, consumeCfg)
+		case mflow.NODE_KIND_EXEC:
+			if !b.AllowExec {
+				return nil, nil, fmt.Errorf("exec node %q: %w", nodeModel.Name, nexec.ErrNotAllowed)
+			}
+			var execCfg mflow.NodeExec
+			if b.NodeExec != nil {
+				cfg, err := b.NodeExec.GetNodeExec(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get exec config: %w", err)
+				}
+				if cfg != nil {
+					execCfg = *cfg
 				}
 			}
-			flowNodeMap[nodeModel.ID] = nwssend.New(nodeModel.ID, nodeModel.Name, wsConnName, message)
+			flowNodeMap[nodeModel.ID] = nexec.New(nodeModel.ID, nodeModel.Name, execCfg)
 		case mflow.NODE_KIND_WAIT:
 			var durationMs int64 = 1000 // default 1 second
 			if b.NodeWait != nil {
@@ -413,6 +667,19 @@ func (b *Builder) BuildNodes(
 			}
 			flowNodeMap[nodeModel.ID] = nsubflowtrigger.New(nodeModel.ID, nodeModel.Name, params)
 			startNodeIDs = append(startNodeIDs, nodeModel.ID)
+		case mflow.NODE_KIND_WEBHOOK_TRIGGER:
+			cfg := mflow.NodeWebhookTrigger{FlowNodeID: nodeModel.ID}
+			if b.NodeWebhookTrigger != nil {
+				webhookCfg, err := b.NodeWebhookTrigger.GetNodeWebhookTrigger(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get webhook trigger config: %w", err)
+				}
+				if webhookCfg != nil {
+					cfg = *webhookCfg
+				}
+			}
+			flowNodeMap[nodeModel.ID] = nwebhook.New(nodeModel.ID, nodeModel.Name, cfg)
+			startNodeIDs = append(startNodeIDs, nodeModel.ID)
 		case mflow.NODE_KIND_SUB_FLOW_RETURN:
 			var outputs []mflow.SubFlowOutput
 			if b.NodeSubFlowReturn != nil {
@@ -442,6 +709,49 @@ func (b *Builder) BuildNodes(
 			}
 			runNode := nrunsubflow.New(nodeModel.ID, nodeModel.Name, targetFlowID, targetFlowName, inputs, b.SubFlowExecutor)
 			flowNodeMap[nodeModel.ID] = runNode
+		case mflow.NODE_KIND_TRY:
+			flowNodeMap[nodeModel.ID] = ntry.New(nodeModel.ID, nodeModel.Name)
+		case mflow.NODE_KIND_TEARDOWN:
+			// Teardown is an entry node too; the runner holds it back until
+			// the rest of the flow has ended.
+			flowNodeMap[nodeModel.ID] = nteardown.New(nodeModel.ID, nodeModel.Name)
+			startNodeIDs = append(startNodeIDs, nodeModel.ID)
+		case mflow.NODE_KIND_PARALLEL:
+			joinMode, concurrency := mflow.ParallelJoinAll, 0
+			if b.NodeParallel != nil {
+				parallelCfg, err := b.NodeParallel.GetNodeParallel(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get parallel config: %w", err)
+				}
+				if parallelCfg != nil {
+					joinMode, concurrency = parallelCfg.JoinMode, int(parallelCfg.Concurrency)
+				}
+			}
+			flowNodeMap[nodeModel.ID] = nparallel.New(nodeModel.ID, nodeModel.Name, joinMode, concurrency)
+		case mflow.NODE_KIND_POLL:
+			var pollCfg mflow.NodePoll
+			if b.NodePoll != nil {
+				cfg, err := b.NodePoll.GetNodePoll(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get poll config: %w", err)
+				}
+				if cfg != nil {
+					pollCfg = *cfg
+				}
+			}
+			flowNodeMap[nodeModel.ID] = npoll.New(nodeModel.ID, nodeModel.Name, pollCfg)
+		case mflow.NODE_KIND_SWITCH:
+			var cases []mflow.SwitchCase
+			if b.NodeSwitch != nil {
+				cfg, err := b.NodeSwitch.GetNodeSwitch(ctx, nodeModel.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get switch config: %w", err)
+				}
+				if cfg != nil {
+					cases = cfg.Cases
+				}
+			}
+			flowNodeMap[nodeModel.ID] = nswitch.New(nodeModel.ID, nodeModel.Name, cases)
 		default:
 			return nil, nil, fmt.Errorf("node kind %d not supported", nodeModel.NodeKind)
 		}
@@ -458,6 +768,17 @@ func (b *Builder) BuildVariables(
 	ctx context.Context,
 	workspaceID idwrap.IDWrap,
 	flowVars []mflow.FlowVariable,
+) (map[string]any, error) {
+	return b.BuildVariablesForEnv(ctx, workspaceID, idwrap.IDWrap{}, flowVars)
+}
+
+// BuildVariablesForEnv is BuildVariables with envID in place of the
+// workspace's active environment. A zero envID uses the active environment.
+func (b *Builder) BuildVariablesForEnv(
+	ctx context.Context,
+	workspaceID idwrap.IDWrap,
+	envID idwrap.IDWrap,
+	flowVars []mflow.FlowVariable,
 ) (map[string]any, error) {
 	baseVars := make(map[string]any)
 	envVars := make(map[string]any)
@@ -484,10 +805,14 @@ func (b *Builder) BuildVariables(
 
 		// 2. Add active environment variables (override global)
 		// Only if ActiveEnv is different from GlobalEnv
-		if workspace.ActiveEnv != (idwrap.IDWrap{}) && workspace.ActiveEnv != workspace.GlobalEnv {
-			activeVars, err := b.Variable.GetVariableByEnvID(ctx, workspace.ActiveEnv)
+		activeEnv := workspace.ActiveEnv
+		if envID != (idwrap.IDWrap{}) {
+			activeEnv = envID
+		}
+		if activeEnv != (idwrap.IDWrap{}) && activeEnv != workspace.GlobalEnv {
+			activeVars, err := b.Variable.GetVariableByEnvID(ctx, activeEnv)
 			if err != nil && !errors.Is(err, senv.ErrNoVarFound) {
-				b.Logger.Warn("failed to get active environment variables", "env_id", workspace.ActiveEnv.String(), "error", err)
+				b.Logger.Warn("failed to get active environment variables", "env_id", activeEnv.String(), "error", err)
 			} else {
 				for _, v := range activeVars {
 					if v.Enabled {
@@ -517,3 +842,41 @@ func (b *Builder) BuildVariables(
 func isZeroID(id idwrap.IDWrap) bool {
 	return id == idwrap.IDWrap{}
 }
+
+// recordWebSocketMessage returns a frame hook adding every frame of a WS
+// Connection node to the history of the WebSocket it was configured from.
+// History is best effort: a frame that fails to save is logged and dropped.
+func (b *Builder) recordWebSocketMessage(ctx context.Context, wsID idwrap.IDWrap) func(nwsconnection.Frame) {
+	// Frames keep arriving until the connection closes, which may be after
+	// the flow's context is canceled.
+	ctx = context.WithoutCancel(ctx)
+	return func(f nwsconnection.Frame) {
+		err := b.WebSocketMessage.Create(ctx, mwebsocket.WebSocketMessage{
+			ID:          idwrap.NewMonotonic(),
+			WebSocketID: wsID,
+			Direction:   f.Direction,
+			Type:        f.Type,
+			Data:        f.Data,
+			CreatedAt:   f.Time.Unix(),
+		})
+		if err != nil && b.Logger != nil {
+			b.Logger.Warn("failed to record websocket message", "websocket_id", wsID.String(), "error", err)
+		}
+	}
+}
+
+// messageBrokerURL returns the URL of a message queue node's broker
+// credential, or "" when the node has none.
+func (b *Builder) messageBrokerURL(ctx context.Context, credentialID *idwrap.IDWrap) (string, error) {
+	if credentialID == nil || b.Credential == nil {
+		return "", nil
+	}
+	cred, err := b.Credential.GetCredentialMessageBroker(ctx, *credentialID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("get message broker credential: %w", err)
+	}
+	if cred == nil {
+		return "", nil
+	}
+	return cred.Url, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/ngraphql"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nrequest"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nrunsubflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwebhook"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowresult"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
@@ -110,6 +111,8 @@ func (e *SubFlowExecutorImpl) ExecuteSubFlow(ctx context.Context, targetFlowID *
 	copy(newStack.flowIDs, stack.flowIDs)
 	newStack.flowIDs[len(stack.flowIDs)] = flow.ID
 	ctx = withCallStack(ctx, newStack)
+	// Only the outer flow's return node replies to a webhook caller.
+	ctx = nwebhook.WithoutExchange(ctx)
 
 	// Load flow data
 	nodes, err := e.Builder.Node.GetNodesByFlowID(ctx, flow.ID)
@@ -0,0 +1,298 @@
+// Package flowdebug steps through a flow run. A Session pauses the run before
+// the nodes that carry breakpoints, publishes the flow's variables at each
+// pause and waits for a continue, step-over or abort command. Variables may
+// be edited while the flow is paused.
+package flowdebug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/tracking"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+)
+
+var (
+	// ErrAborted is the result of the node a session was aborted at. It wraps
+	// context.Canceled, so the node and the run are reported as canceled.
+	ErrAborted = fmt.Errorf("flow aborted from the debugger: %w", context.Canceled)
+	// ErrNotPaused is returned by commands that need the flow to be paused.
+	ErrNotPaused = errors.New("flow is not paused")
+)
+
+// Breakpoint pauses the flow before a node runs.
+type Breakpoint struct {
+	NodeID idwrap.IDWrap
+	// Condition is an optional expression. When set, the flow only pauses at
+	// the node if it evaluates to true against the flow's variables.
+	Condition string
+}
+
+// PauseReason says why the flow paused.
+type PauseReason int
+
+const (
+	PauseReasonBreakpoint PauseReason = iota
+	PauseReasonStep
+)
+
+// Pause describes the node the flow is paused before.
+type Pause struct {
+	NodeID           idwrap.IDWrap
+	NodeName         string
+	ExecutionID      idwrap.IDWrap
+	IterationContext *runner.IterationContext
+	Reason           PauseReason
+	// Variables is a copy of the flow's variables as the node would see them
+	// if it ran now.
+	Variables map[string]any
+	// Changed holds the variables the nodes that ran since the previous pause
+	// wrote.
+	Changed map[string]any
+	// ConditionErr is set when the breakpoint's condition could not be
+	// evaluated. The flow pauses anyway, so the condition can be looked into.
+	ConditionErr error
+}
+
+type command int
+
+const (
+	commandContinue command = iota
+	commandStepOver
+	commandAbort
+)
+
+// pausedNode is the node a session is paused at.
+type pausedNode struct {
+	req    *node.FlowNodeRequest
+	depth  int
+	resume chan command
+}
+
+// Session is a node.Debugger driven by commands from a client. Pauses are
+// published on Pauses, one at a time: while the flow is paused, other nodes
+// that reach a pause wait for it to be resumed.
+type Session struct {
+	pauses chan Pause
+	// gate is held for as long as the flow is paused.
+	gate    sync.Mutex
+	tracker *tracking.VariableTracker
+
+	mu          sync.Mutex
+	breakpoints map[idwrap.IDWrap]Breakpoint
+	paused      *pausedNode
+	// stepDepth is the loop depth a step-over was taken at, or -1 when the
+	// flow runs on to the next breakpoint.
+	stepDepth int
+	aborted   bool
+}
+
+var _ node.Debugger = (*Session)(nil)
+
+// NewSession creates a session that pauses at breakpoints.
+func NewSession(breakpoints []Breakpoint) *Session {
+	s := &Session{
+		pauses:    make(chan Pause),
+		tracker:   tracking.NewVariableTracker(),
+		stepDepth: -1,
+	}
+	s.SetBreakpoints(breakpoints)
+	return s
+}
+
+// Pauses delivers a Pause each time the flow is suspended.
+func (s *Session) Pauses() <-chan Pause {
+	return s.pauses
+}
+
+// SetBreakpoints replaces the session's breakpoints. It may be called while
+// the flow runs.
+func (s *Session) SetBreakpoints(breakpoints []Breakpoint) {
+	byNode := make(map[idwrap.IDWrap]Breakpoint, len(breakpoints))
+	for _, bp := range breakpoints {
+		byNode[bp.NodeID] = bp
+	}
+	s.mu.Lock()
+	s.breakpoints = byNode
+	s.mu.Unlock()
+}
+
+// BeforeNode pauses the flow before n when n has a breakpoint whose condition
+// holds, or when a step-over left off at the node's loop depth or deeper.
+func (s *Session) BeforeNode(ctx context.Context, n node.FlowNode, req *node.FlowNodeRequest) error {
+	depth := loopDepth(req)
+
+	s.mu.Lock()
+	aborted := s.aborted
+	stepping := s.stepDepth >= 0 && depth <= s.stepDepth
+	bp, hasBreakpoint := s.breakpoints[n.GetID()]
+	s.mu.Unlock()
+
+	if aborted {
+		return ErrAborted
+	}
+
+	pause := Pause{
+		NodeID:           n.GetID(),
+		NodeName:         n.GetName(),
+		ExecutionID:      req.ExecutionID,
+		IterationContext: req.IterationContext,
+		Reason:           PauseReasonStep,
+	}
+	if !stepping {
+		if !hasBreakpoint {
+			return nil
+		}
+		if bp.Condition != "" {
+			hit, err := expression.NewUnifiedEnv(node.DeepCopyVarMap(req)).EvalBool(ctx, bp.Condition)
+			if err != nil {
+				pause.ConditionErr = fmt.Errorf("evaluate breakpoint condition: %w", err)
+			} else if !hit {
+				return nil
+			}
+		}
+		pause.Reason = PauseReasonBreakpoint
+	}
+	return s.pause(ctx, req, depth, pause)
+}
+
+// AfterNode records the variables n wrote, for the next pause to report.
+func (s *Session) AfterNode(_ node.FlowNode, written map[string]any) {
+	for key, value := range written {
+		s.tracker.TrackWrite(key, value)
+	}
+}
+
+func (s *Session) pause(ctx context.Context, req *node.FlowNodeRequest, depth int, pause Pause) error {
+	s.gate.Lock()
+	defer s.gate.Unlock()
+
+	paused := &pausedNode{req: req, depth: depth, resume: make(chan command, 1)}
+	s.mu.Lock()
+	if s.aborted {
+		s.mu.Unlock()
+		return ErrAborted
+	}
+	s.paused = paused
+	s.stepDepth = -1
+	s.mu.Unlock()
+
+	pause.Variables = node.DeepCopyVarMap(req)
+	pause.Changed = s.tracker.GetWrittenVarsAsTree()
+	s.tracker.Reset()
+
+	select {
+	case s.pauses <- pause:
+	case <-ctx.Done():
+		s.unpause(paused)
+		return ctx.Err()
+	}
+
+	select {
+	case cmd := <-paused.resume:
+		if cmd == commandAbort {
+			return ErrAborted
+		}
+		return nil
+	case <-ctx.Done():
+		s.unpause(paused)
+		return ctx.Err()
+	}
+}
+
+func (s *Session) unpause(paused *pausedNode) {
+	s.mu.Lock()
+	if s.paused == paused {
+		s.paused = nil
+	}
+	s.mu.Unlock()
+}
+
+// Continue resumes the flow until the next breakpoint.
+func (s *Session) Continue() error {
+	return s.resume(commandContinue)
+}
+
+// StepOver runs the node the flow is paused at, including any loop body it
+// drives, and pauses again before the next node at the same loop depth or
+// shallower.
+func (s *Session) StepOver() error {
+	return s.resume(commandStepOver)
+}
+
+// Abort stops the flow. The node it is paused at, or the next node to reach
+// the debugger, fails with ErrAborted without running.
+func (s *Session) Abort() {
+	s.mu.Lock()
+	s.aborted = true
+	s.mu.Unlock()
+	_ = s.resume(commandAbort)
+}
+
+func (s *Session) resume(cmd command) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	paused := s.paused
+	if paused == nil {
+		return ErrNotPaused
+	}
+	s.paused = nil
+	if cmd == commandStepOver {
+		s.stepDepth = paused.depth
+	}
+	paused.resume <- cmd
+	return nil
+}
+
+// SetVariable sets the variable at a dotted path, such as
+// "CreateUser.response.body.id", while the flow is paused. Maps missing along
+// the path are created.
+func (s *Session) SetVariable(path string, value any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.paused == nil {
+		return ErrNotPaused
+	}
+	keys := strings.Split(path, ".")
+	for _, key := range keys {
+		if key == "" {
+			return fmt.Errorf("invalid variable path %q", path)
+		}
+	}
+
+	req := s.paused.req
+	req.ReadWriteLock.Lock()
+	defer req.ReadWriteLock.Unlock()
+
+	vars := req.VarMap
+	for i, key := range keys[:len(keys)-1] {
+		next, ok := vars[key]
+		if !ok {
+			created := make(map[string]any)
+			vars[key] = created
+			vars = created
+			continue
+		}
+		nested, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("variable %s is not a map", strings.Join(keys[:i+1], "."))
+		}
+		vars = nested
+	}
+	vars[keys[len(keys)-1]] = value
+	return nil
+}
+
+// loopDepth is how many loops deep the node of req runs.
+func loopDepth(req *node.FlowNodeRequest) int {
+	if req.IterationContext == nil {
+		return 0
+	}
+	return len(req.IterationContext.ParentNodes)
+}
@@ -0,0 +1,227 @@
+package flowdebug_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowdebug"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nfor"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nstart"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	flowlocalrunner "github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// countNode writes "<name>.value" as one more than the "count" variable it
+// reads, and records every value it read.
+type countNode struct {
+	id   idwrap.IDWrap
+	name string
+	next []idwrap.IDWrap
+	seen []any
+}
+
+func (n *countNode) GetID() idwrap.IDWrap { return n.id }
+
+func (n *countNode) GetName() string { return n.name }
+
+func (n *countNode) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	count, _ := node.ReadVarRaw(req, "count")
+	n.seen = append(n.seen, count)
+	value, _ := count.(int)
+	if err := node.WriteNodeVarWithTracking(req, n.name, "value", value+1, req.VariableTracker); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	return node.FlowNodeResult{NextNodeID: n.next}
+}
+
+func (n *countNode) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+type debugRun struct {
+	session *flowdebug.Session
+	done    chan error
+}
+
+func startDebugRun(t *testing.T, startID idwrap.IDWrap, nodeMap map[idwrap.IDWrap]node.FlowNode, edges mflow.EdgesMap, baseVars map[string]any, breakpoints ...flowdebug.Breakpoint) debugRun {
+	t.Helper()
+	session := flowdebug.NewSession(breakpoints)
+	flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), []idwrap.IDWrap{startID}, nodeMap, edges, time.Second, slog.Default(), flowlocalrunner.WithDebugger(session))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	done := make(chan error, 1)
+	go func() {
+		done <- flowRunner.RunWithEvents(ctx, runner.FlowEventChannels{}, baseVars)
+	}()
+	return debugRun{session: session, done: done}
+}
+
+func (r debugRun) nextPause(t *testing.T) flowdebug.Pause {
+	t.Helper()
+	select {
+	case pause := <-r.session.Pauses():
+		return pause
+	case err := <-r.done:
+		t.Fatalf("flow finished without pausing: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the flow to pause")
+	}
+	return flowdebug.Pause{}
+}
+
+func (r debugRun) wait(t *testing.T) error {
+	t.Helper()
+	select {
+	case err := <-r.done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the flow to finish")
+	}
+	return nil
+}
+
+func TestBreakpointPausesBeforeNode(t *testing.T) {
+	startID := idwrap.NewNow()
+	second := &countNode{id: idwrap.NewNow(), name: "Second"}
+	first := &countNode{id: idwrap.NewNow(), name: "First", next: []idwrap.IDWrap{second.id}}
+	nodeMap := map[idwrap.IDWrap]node.FlowNode{
+		startID:   nstart.New(startID, "Start"),
+		first.id:  first,
+		second.id: second,
+	}
+	edges := mflow.EdgesMap{
+		startID:  {mflow.HandleUnspecified: {first.id}},
+		first.id: {mflow.HandleUnspecified: {second.id}},
+	}
+
+	run := startDebugRun(t, startID, nodeMap, edges, map[string]any{"count": 1}, flowdebug.Breakpoint{NodeID: second.id})
+
+	pause := run.nextPause(t)
+	require.Equal(t, second.id, pause.NodeID)
+	require.Equal(t, flowdebug.PauseReasonBreakpoint, pause.Reason)
+	require.Empty(t, second.seen, "the node has not run yet")
+	require.Equal(t, map[string]any{"value": 2}, pause.Variables["First"])
+	require.Equal(t, map[string]any{"First": map[string]any{"value": 2}}, pause.Changed)
+
+	require.NoError(t, run.session.Continue())
+	require.NoError(t, run.wait(t))
+	require.Equal(t, []any{1}, second.seen)
+	require.ErrorIs(t, run.session.Continue(), flowdebug.ErrNotPaused)
+}
+
+func TestConditionalBreakpointInLoopBody(t *testing.T) {
+	startID, forID := idwrap.NewNow(), idwrap.NewNow()
+	body := &countNode{id: idwrap.NewNow(), name: "Body"}
+	nodeMap := map[idwrap.IDWrap]node.FlowNode{
+		startID: nstart.New(startID, "Start"),
+		forID:   nfor.New(forID, "Loop", 3, time.Second, mflow.ErrorHandling_ERROR_HANDLING_BREAK),
+		body.id: body,
+	}
+	edges := mflow.EdgesMap{
+		startID: {mflow.HandleUnspecified: {forID}},
+		forID:   {mflow.HandleLoop: {body.id}},
+	}
+
+	run := startDebugRun(t, startID, nodeMap, edges, nil, flowdebug.Breakpoint{NodeID: body.id, Condition: "Loop.index == 1"})
+
+	pause := run.nextPause(t)
+	require.Equal(t, body.id, pause.NodeID)
+	require.Equal(t, map[string]any{"index": int64(1)}, pause.Variables["Loop"])
+	require.NotNil(t, pause.IterationContext)
+	require.Len(t, body.seen, 1, "the first iteration did not pause")
+
+	require.NoError(t, run.session.Continue())
+	require.NoError(t, run.wait(t))
+	require.Len(t, body.seen, 3)
+}
+
+func TestStepOverPausesAtNextNode(t *testing.T) {
+	startID := idwrap.NewNow()
+	third := &countNode{id: idwrap.NewNow(), name: "Third"}
+	second := &countNode{id: idwrap.NewNow(), name: "Second", next: []idwrap.IDWrap{third.id}}
+	first := &countNode{id: idwrap.NewNow(), name: "First", next: []idwrap.IDWrap{second.id}}
+	nodeMap := map[idwrap.IDWrap]node.FlowNode{
+		startID:   nstart.New(startID, "Start"),
+		first.id:  first,
+		second.id: second,
+		third.id:  third,
+	}
+	edges := mflow.EdgesMap{
+		startID:   {mflow.HandleUnspecified: {first.id}},
+		first.id:  {mflow.HandleUnspecified: {second.id}},
+		second.id: {mflow.HandleUnspecified: {third.id}},
+	}
+
+	run := startDebugRun(t, startID, nodeMap, edges, nil, flowdebug.Breakpoint{NodeID: first.id})
+
+	require.Equal(t, first.id, run.nextPause(t).NodeID)
+	require.NoError(t, run.session.StepOver())
+
+	pause := run.nextPause(t)
+	require.Equal(t, second.id, pause.NodeID)
+	require.Equal(t, flowdebug.PauseReasonStep, pause.Reason)
+	require.Equal(t, map[string]any{"First": map[string]any{"value": 1}}, pause.Changed)
+
+	require.NoError(t, run.session.Continue())
+	require.NoError(t, run.wait(t))
+	require.Len(t, third.seen, 1, "continue runs to the end without pausing")
+}
+
+func TestSetVariableWhilePaused(t *testing.T) {
+	startID := idwrap.NewNow()
+	target := &countNode{id: idwrap.NewNow(), name: "Target"}
+	nodeMap := map[idwrap.IDWrap]node.FlowNode{
+		startID:   nstart.New(startID, "Start"),
+		target.id: target,
+	}
+	edges := mflow.EdgesMap{
+		startID: {mflow.HandleUnspecified: {target.id}},
+	}
+
+	run := startDebugRun(t, startID, nodeMap, edges, map[string]any{"count": 1}, flowdebug.Breakpoint{NodeID: target.id})
+
+	require.ErrorIs(t, run.session.SetVariable("count", 5), flowdebug.ErrNotPaused)
+	run.nextPause(t)
+	require.NoError(t, run.session.SetVariable("count", 41))
+	require.NoError(t, run.session.SetVariable("Extra.nested.key", "value"))
+	require.Error(t, run.session.SetVariable("count.inner", 1), "count is not a map")
+	require.Error(t, run.session.SetVariable("bad..path", 1))
+
+	require.NoError(t, run.session.Continue())
+	require.NoError(t, run.wait(t))
+	require.Equal(t, []any{41}, target.seen)
+}
+
+func TestAbortStopsFlow(t *testing.T) {
+	startID := idwrap.NewNow()
+	second := &countNode{id: idwrap.NewNow(), name: "Second"}
+	first := &countNode{id: idwrap.NewNow(), name: "First", next: []idwrap.IDWrap{second.id}}
+	nodeMap := map[idwrap.IDWrap]node.FlowNode{
+		startID:   nstart.New(startID, "Start"),
+		first.id:  first,
+		second.id: second,
+	}
+	edges := mflow.EdgesMap{
+		startID:  {mflow.HandleUnspecified: {first.id}},
+		first.id: {mflow.HandleUnspecified: {second.id}},
+	}
+
+	run := startDebugRun(t, startID, nodeMap, edges, nil, flowdebug.Breakpoint{NodeID: first.id})
+
+	run.nextPause(t)
+	run.session.Abort()
+	err := run.wait(t)
+	require.ErrorIs(t, err, flowdebug.ErrAborted)
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Empty(t, first.seen)
+	require.Empty(t, second.seen)
+}
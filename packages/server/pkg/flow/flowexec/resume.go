@@ -0,0 +1,73 @@
+package flowexec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// ResumeState is what a previous execution recorded for the nodes of the flow
+// being run, keyed by node ID. Prepare uses it to skip the nodes that
+// completed, restoring their outputs as variables, and to start from the
+// nodes that failed.
+type ResumeState struct {
+	States  map[idwrap.IDWrap]mflow.NodeState
+	Outputs map[idwrap.IDWrap]any
+	// From optionally names the nodes to resume from. When empty, the run
+	// resumes from the nodes that failed.
+	From []idwrap.IDWrap
+
+	// completed is set by Prepare to the nodes the resumed run skips.
+	completed map[idwrap.IDWrap]struct{}
+}
+
+// NewResumeState builds a ResumeState from the latest execution recorded for
+// each node, keyed by the ID of the node in the flow being run.
+func NewResumeState(executions map[idwrap.IDWrap]mflow.NodeExecution, from []idwrap.IDWrap) (*ResumeState, error) {
+	state := &ResumeState{
+		States:  make(map[idwrap.IDWrap]mflow.NodeState, len(executions)),
+		Outputs: make(map[idwrap.IDWrap]any, len(executions)),
+		From:    from,
+	}
+	for nodeID, execution := range executions {
+		state.States[nodeID] = execution.State
+
+		raw, err := execution.GetOutputJSON()
+		if err != nil {
+			return nil, fmt.Errorf("read output of node %s: %w", execution.Name, err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		var output any
+		if err := json.Unmarshal(raw, &output); err != nil {
+			return nil, fmt.Errorf("decode output of node %s: %w", execution.Name, err)
+		}
+		state.Outputs[nodeID] = output
+	}
+	return state, nil
+}
+
+// Completed reports whether the resumed run skips nodeID because it completed
+// in the execution being resumed. It is only meaningful after Prepare.
+func (r *ResumeState) Completed(nodeID idwrap.IDWrap) bool {
+	_, ok := r.completed[nodeID]
+	return ok
+}
+
+// restoreOutputs writes the recorded output of each completed node into
+// baseVars under the node's name, where the node itself would have written it.
+func (r *ResumeState) restoreOutputs(baseVars map[string]any, nodes []mflow.Node, point runner.ResumePoint) {
+	r.completed = point.Completed
+	for _, n := range nodes {
+		if _, ok := point.Completed[n.ID]; !ok {
+			continue
+		}
+		if output, ok := r.Outputs[n.ID]; ok && output != nil {
+			baseVars[n.Name] = output
+		}
+	}
+}
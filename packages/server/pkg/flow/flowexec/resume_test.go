@@ -0,0 +1,48 @@
+package flowexec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func TestNewResumeState_RestoresCompletedOutputs(t *testing.T) {
+	t.Parallel()
+
+	createID, readID := idwrap.NewNow(), idwrap.NewNow()
+
+	create := mflow.NodeExecution{ID: idwrap.NewNow(), NodeID: createID, Name: "CreateUser", State: mflow.NODE_STATE_SUCCESS}
+	require.NoError(t, create.SetOutputJSON(json.RawMessage(`{"response":{"body":{"id":"user-1"}}}`)))
+	read := mflow.NodeExecution{ID: idwrap.NewNow(), NodeID: readID, Name: "GetUser", State: mflow.NODE_STATE_FAILURE}
+
+	state, err := NewResumeState(map[idwrap.IDWrap]mflow.NodeExecution{
+		createID: create,
+		readID:   read,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, mflow.NODE_STATE_SUCCESS, state.States[createID])
+	assert.Equal(t, mflow.NODE_STATE_FAILURE, state.States[readID])
+
+	nodes := []mflow.Node{
+		{ID: createID, Name: "CreateUser"},
+		{ID: readID, Name: "GetUser"},
+	}
+	baseVars := map[string]any{"env": "staging"}
+	state.restoreOutputs(baseVars, nodes, runner.ResumePoint{
+		NodeIDs:   []idwrap.IDWrap{readID},
+		Completed: map[idwrap.IDWrap]struct{}{createID: {}},
+	})
+
+	assert.True(t, state.Completed(createID))
+	assert.False(t, state.Completed(readID))
+	assert.Equal(t, map[string]any{
+		"env":        "staging",
+		"CreateUser": map[string]any{"response": map[string]any{"body": map[string]any{"id": "user-1"}}},
+	}, baseVars)
+}
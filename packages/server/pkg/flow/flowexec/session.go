@@ -14,6 +14,7 @@ import (
 
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowbuilder"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/flowresult"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/httpclient"
@@ -41,6 +42,18 @@ type ExecutionParams struct {
 	Nodes    []mflow.Node
 	Edges    []mflow.Edge // Only valid edges (no orphaned source/target references)
 	FlowVars []mflow.FlowVariable
+
+	// EnvironmentID, when set, runs the flow with this environment instead of
+	// the workspace's active one.
+	EnvironmentID idwrap.IDWrap
+
+	// Resume, when set, continues a previous failed execution instead of
+	// running the flow from its start node.
+	Resume *ResumeState
+
+	// Debugger, when set, runs the flow in debug mode, letting it suspend the
+	// flow before each node.
+	Debugger node.Debugger
 }
 
 // ExecutionResult contains the outcome of a flow execution.
@@ -104,7 +117,7 @@ func NewServerSession(opts ServerSessionOpts) *ServerSession {
 
 // Prepare builds execution variables, constructs flow nodes, and creates the runner.
 func (s *ServerSession) Prepare(ctx context.Context, params ExecutionParams) error {
-	baseVars, err := s.builder.BuildVariables(ctx, params.Flow.WorkspaceID, params.FlowVars)
+	baseVars, err := s.builder.BuildVariablesForEnv(ctx, params.Flow.WorkspaceID, params.EnvironmentID, params.FlowVars)
 	if err != nil {
 		return fmt.Errorf("failed to build execution variables: %w", err)
 	}
@@ -130,7 +143,7 @@ func (s *ServerSession) Prepare(ctx context.Context, params ExecutionParams) err
 		return err
 	}
 
-	s.flowRunner = flowlocalrunner.CreateFlowRunner(
+	flowRunner := flowlocalrunner.CreateFlowRunner(
 		idwrap.NewMonotonic(),
 		params.Flow.ID,
 		startNodeIDs,
@@ -138,8 +151,19 @@ func (s *ServerSession) Prepare(ctx context.Context, params ExecutionParams) err
 		edgeMap,
 		0,
 		nil,
+		flowlocalrunner.WithDebugger(params.Debugger),
 	)
 
+	if params.Resume != nil {
+		point, err := flowRunner.PlanResume(params.Resume.States, params.Resume.From)
+		if err != nil {
+			return fmt.Errorf("failed to plan resume: %w", err)
+		}
+		params.Resume.restoreOutputs(s.baseVars, params.Nodes, point)
+		flowRunner.SetResumePoint(&point)
+	}
+	s.flowRunner = flowRunner
+
 	return nil
 }
 
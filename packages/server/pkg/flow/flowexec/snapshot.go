@@ -332,6 +332,31 @@ func (s *GraphQLSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idw
 	return newData, writer.CreateNodeGraphQL(ctx, newData)
 }
 
+// --- GraphQL Subscription ---
+
+type GraphQLSubscriptionSnapshot struct {
+	Service *sflow.NodeGraphQLSubscriptionService
+}
+
+func (s *GraphQLSubscriptionSnapshot) Kind() mflow.NodeKind {
+	return mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION
+}
+
+func (s *GraphQLSubscriptionSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeGraphQLSubscription(ctx, nodeID)
+}
+
+func (s *GraphQLSubscriptionSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeGraphQLSubscription)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeGraphQLSubscription(ctx, newData)
+}
+
 // --- WebSocket Connection ---
 
 type WsConnectionSnapshot struct{ Service *sflow.NodeWsConnectionService }
@@ -374,6 +399,178 @@ func (s *WsSendSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwr
 	return newData, writer.CreateNodeWsSend(ctx, newData)
 }
 
+// --- WebSocket Expect ---
+
+type WsExpectSnapshot struct{ Service *sflow.NodeWsExpectService }
+
+func (s *WsExpectSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_WS_EXPECT }
+
+func (s *WsExpectSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeWsExpect(ctx, nodeID)
+}
+
+func (s *WsExpectSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeWsExpect)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	newData.Assertions = append([]string(nil), src.Assertions...)
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeWsExpect(ctx, newData)
+}
+
+// --- Socket Connection ---
+
+type SocketConnectionSnapshot struct{ Service *sflow.NodeSocketConnectionService }
+
+func (s *SocketConnectionSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_SOCKET_CONNECTION }
+
+func (s *SocketConnectionSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeSocketConnection(ctx, nodeID)
+}
+
+func (s *SocketConnectionSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeSocketConnection)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeSocketConnection(ctx, newData)
+}
+
+// --- Socket Send ---
+
+type SocketSendSnapshot struct{ Service *sflow.NodeSocketSendService }
+
+func (s *SocketSendSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_SOCKET_SEND }
+
+func (s *SocketSendSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeSocketSend(ctx, nodeID)
+}
+
+func (s *SocketSendSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeSocketSend)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeSocketSend(ctx, newData)
+}
+
+// --- Socket Receive ---
+
+type SocketReceiveSnapshot struct{ Service *sflow.NodeSocketReceiveService }
+
+func (s *SocketReceiveSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_SOCKET_RECEIVE }
+
+func (s *SocketReceiveSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeSocketReceive(ctx, nodeID)
+}
+
+func (s *SocketReceiveSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeSocketReceive)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	newData.Assertions = append([]string(nil), src.Assertions...)
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeSocketReceive(ctx, newData)
+}
+
+// --- DB Query ---
+
+type DbQuerySnapshot struct{ Service *sflow.NodeDbQueryService }
+
+func (s *DbQuerySnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_DB_QUERY }
+
+func (s *DbQuerySnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeDbQuery(ctx, nodeID)
+}
+
+func (s *DbQuerySnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeDbQuery)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	newData.Params = append([]string(nil), src.Params...)
+	newData.Assertions = append([]string(nil), src.Assertions...)
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeDbQuery(ctx, newData)
+}
+
+// --- Message Queue ---
+
+type MqPublishSnapshot struct{ Service *sflow.NodeMqPublishService }
+
+func (s *MqPublishSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_MQ_PUBLISH }
+
+func (s *MqPublishSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeMqPublish(ctx, nodeID)
+}
+
+func (s *MqPublishSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeMqPublish)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeMqPublish(ctx, newData)
+}
+
+type MqConsumeSnapshot struct{ Service *sflow.NodeMqConsumeService }
+
+func (s *MqConsumeSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_MQ_CONSUME }
+
+func (s *MqConsumeSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeMqConsume(ctx, nodeID)
+}
+
+func (s *MqConsumeSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeMqConsume)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	newData.Assertions = append([]string(nil), src.Assertions...)
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeMqConsume(ctx, newData)
+}
+
+// --- Exec ---
+
+type ExecSnapshot struct{ Service *sflow.NodeExecService }
+
+func (s *ExecSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_EXEC }
+
+func (s *ExecSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeExec(ctx, nodeID)
+}
+
+func (s *ExecSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeExec)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	newData.Args = append([]string(nil), src.Args...)
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeExec(ctx, newData)
+}
+
 // --- Wait ---
 
 type WaitSnapshot struct{ Service *sflow.NodeWaitService }
@@ -395,6 +592,90 @@ func (s *WaitSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap
 	return newData, writer.CreateNodeWait(ctx, newData)
 }
 
+// --- Parallel ---
+
+type ParallelSnapshot struct{ Service *sflow.NodeParallelService }
+
+func (s *ParallelSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_PARALLEL }
+
+func (s *ParallelSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeParallel(ctx, nodeID)
+}
+
+func (s *ParallelSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeParallel)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeParallel(ctx, newData)
+}
+
+// --- Poll ---
+
+type PollSnapshot struct{ Service *sflow.NodePollService }
+
+func (s *PollSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_POLL }
+
+func (s *PollSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodePoll(ctx, nodeID)
+}
+
+func (s *PollSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodePoll)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodePoll(ctx, newData)
+}
+
+// --- Switch ---
+
+type SwitchSnapshot struct{ Service *sflow.NodeSwitchService }
+
+func (s *SwitchSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_SWITCH }
+
+func (s *SwitchSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeSwitch(ctx, nodeID)
+}
+
+func (s *SwitchSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeSwitch)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeSwitch(ctx, newData)
+}
+
+// --- WebhookTrigger ---
+
+type WebhookTriggerSnapshot struct{ Service *sflow.NodeWebhookTriggerService }
+
+func (s *WebhookTriggerSnapshot) Kind() mflow.NodeKind { return mflow.NODE_KIND_WEBHOOK_TRIGGER }
+
+func (s *WebhookTriggerSnapshot) Read(ctx context.Context, nodeID idwrap.IDWrap) (any, error) {
+	return s.Service.GetNodeWebhookTrigger(ctx, nodeID)
+}
+
+func (s *WebhookTriggerSnapshot) WriteTx(ctx context.Context, tx *sql.Tx, newNodeID idwrap.IDWrap, config any) (any, error) {
+	src, _ := config.(*mflow.NodeWebhookTrigger)
+	if src == nil {
+		return nil, nil
+	}
+	newData := *src
+	newData.FlowNodeID = newNodeID
+	writer := s.Service.TX(tx)
+	return newData, writer.CreateNodeWebhookTrigger(ctx, newData)
+}
+
 // --- SubFlowTrigger ---
 
 type SubFlowTriggerSnapshot struct{ Service *sflow.NodeSubFlowTriggerService }
@@ -72,6 +72,14 @@ func TestWriteTx_TypedNilConfig(t *testing.T) {
 	ngqsService := sflow.NewNodeGraphQLService(queries)
 	nwcsService := sflow.NewNodeWsConnectionService(queries)
 	nwssService := sflow.NewNodeWsSendService(queries)
+	nwesService := sflow.NewNodeWsExpectService(queries)
+	nsocService := sflow.NewNodeSocketConnectionService(queries)
+	nsosService := sflow.NewNodeSocketSendService(queries)
+	nsorService := sflow.NewNodeSocketReceiveService(queries)
+	ndbqService := sflow.NewNodeDbQueryService(queries)
+	nmqpService := sflow.NewNodeMqPublishService(queries)
+	nmqcService := sflow.NewNodeMqConsumeService(queries)
+	nexeService := sflow.NewNodeExecService(queries)
 	nwaitsService := sflow.NewNodeWaitService(queries)
 
 	tests := []struct {
@@ -99,6 +107,46 @@ func TestWriteTx_TypedNilConfig(t *testing.T) {
 			handler: &WsSendSnapshot{Service: &nwssService},
 			config:  (*mflow.NodeWsSend)(nil),
 		},
+		{
+			name:    "WsExpect typed nil",
+			handler: &WsExpectSnapshot{Service: &nwesService},
+			config:  (*mflow.NodeWsExpect)(nil),
+		},
+		{
+			name:    "SocketConnection typed nil",
+			handler: &SocketConnectionSnapshot{Service: &nsocService},
+			config:  (*mflow.NodeSocketConnection)(nil),
+		},
+		{
+			name:    "SocketSend typed nil",
+			handler: &SocketSendSnapshot{Service: &nsosService},
+			config:  (*mflow.NodeSocketSend)(nil),
+		},
+		{
+			name:    "SocketReceive typed nil",
+			handler: &SocketReceiveSnapshot{Service: &nsorService},
+			config:  (*mflow.NodeSocketReceive)(nil),
+		},
+		{
+			name:    "DbQuery typed nil",
+			handler: &DbQuerySnapshot{Service: &ndbqService},
+			config:  (*mflow.NodeDbQuery)(nil),
+		},
+		{
+			name:    "MqPublish typed nil",
+			handler: &MqPublishSnapshot{Service: &nmqpService},
+			config:  (*mflow.NodeMqPublish)(nil),
+		},
+		{
+			name:    "MqConsume typed nil",
+			handler: &MqConsumeSnapshot{Service: &nmqcService},
+			config:  (*mflow.NodeMqConsume)(nil),
+		},
+		{
+			name:    "Exec typed nil",
+			handler: &ExecSnapshot{Service: &nexeService},
+			config:  (*mflow.NodeExec)(nil),
+		},
 		{
 			name:    "Wait typed nil",
 			handler: &WaitSnapshot{Service: &nwaitsService},
@@ -104,7 +104,9 @@ func (t *ExecutionStateTracker) processStatus(ctx context.Context, status runner
 
 	// Check if this is a loop coordinator wrapper status
 	nodeKind := t.nodeKindMap[status.NodeID]
-	isLoopNode := nodeKind == mflow.NODE_KIND_FOR || nodeKind == mflow.NODE_KIND_FOR_EACH || nodeKind == mflow.NODE_KIND_WS_CONNECTION
+	isLoopNode := nodeKind == mflow.NODE_KIND_FOR || nodeKind == mflow.NODE_KIND_FOR_EACH || nodeKind == mflow.NODE_KIND_WS_CONNECTION ||
+		nodeKind == mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION || nodeKind == mflow.NODE_KIND_SOCKET_CONNECTION ||
+		nodeKind == mflow.NODE_KIND_MQ_CONSUME
 	skipExecution := isLoopNode && !status.IterationEvent
 
 	// Persist execution state (skip for loop node wrapper statuses)
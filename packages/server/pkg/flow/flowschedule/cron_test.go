@@ -0,0 +1,81 @@
+package flowschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func TestCronNext(t *testing.T) {
+	// 2026-03-10 is a Tuesday.
+	base := time.Date(2026, 3, 10, 14, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 10, 14, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 10, 14, 15, 0, 0, time.UTC)},
+		{"5 * * * *", time.Date(2026, 3, 10, 15, 5, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2026, 3, 10, 17, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2026, 3, 11, 8, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"10,20 14 * * *", time.Date(2026, 3, 10, 14, 10, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 1st of the month or any Friday.
+		{"0 12 1 * 5", time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			got, err := cron.Next(base)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every",
+	} {
+		_, err := ParseCron(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	base := time.Date(2026, 3, 10, 14, 7, 30, 0, time.UTC)
+
+	next, err := NextRun(mflow.FlowSchedule{IntervalSeconds: 90}, base)
+	require.NoError(t, err)
+	require.Equal(t, base.Add(90*time.Second), next)
+
+	next, err = NextRun(mflow.FlowSchedule{CronExpression: "0 * * * *", IntervalSeconds: 90}, base)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC), next, "the cron expression wins over the interval")
+
+	_, err = NextRun(mflow.FlowSchedule{}, base)
+	require.ErrorIs(t, err, ErrNoTiming)
+
+	require.Error(t, Validate(mflow.FlowSchedule{CronExpression: "0 0 30 2 *"}), "February 30th never comes")
+	require.Error(t, Validate(mflow.FlowSchedule{IntervalSeconds: -1}))
+	require.NoError(t, Validate(mflow.FlowSchedule{CronExpression: "@hourly"}))
+}
@@ -0,0 +1,39 @@
+package flowschedule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// ErrNoTiming is returned for a schedule with neither a cron expression nor an
+// interval.
+var ErrNoTiming = errors.New("schedule needs a cron expression or an interval")
+
+// Validate checks that a schedule's timing can be evaluated.
+func Validate(schedule mflow.FlowSchedule) error {
+	if schedule.IntervalSeconds < 0 {
+		return fmt.Errorf("schedule interval must not be negative, got %d", schedule.IntervalSeconds)
+	}
+	_, err := NextRun(schedule, time.Now())
+	return err
+}
+
+// NextRun returns when a schedule is next due after t. The cron expression is
+// used when set, and the interval otherwise.
+func NextRun(schedule mflow.FlowSchedule, t time.Time) (time.Time, error) {
+	if expr := strings.TrimSpace(schedule.CronExpression); expr != "" {
+		cron, err := ParseCron(expr)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return cron.Next(t)
+	}
+	if schedule.IntervalSeconds > 0 {
+		return t.Add(time.Duration(schedule.IntervalSeconds) * time.Second), nil
+	}
+	return time.Time{}, ErrNoTiming
+}
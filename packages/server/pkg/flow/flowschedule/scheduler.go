@@ -0,0 +1,306 @@
+// Package flowschedule runs flows on cron expressions or fixed intervals. A
+// Scheduler watches the enabled schedules, starts each flow when it is due,
+// records the outcome in a bounded run history and reports schedules that
+// start or stop failing.
+package flowschedule
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// ErrAlreadyRunning is returned by an Executor when the schedule's flow is
+// already running. The scheduler skips that occurrence.
+var ErrAlreadyRunning = errors.New("flow is already running")
+
+const (
+	// DefaultHistorySize is how many runs are kept per schedule.
+	DefaultHistorySize = 50
+	// DefaultReloadInterval is the longest the scheduler sleeps before
+	// reloading the schedules.
+	DefaultReloadInterval = time.Minute
+)
+
+// Store persists schedules and their runs. sflow.FlowScheduleService
+// implements it.
+type Store interface {
+	GetEnabledFlowSchedules(ctx context.Context) ([]mflow.FlowSchedule, error)
+	CreateFlowScheduleRun(ctx context.Context, run mflow.FlowScheduleRun) error
+	PruneFlowScheduleRuns(ctx context.Context, scheduleID idwrap.IDWrap, keep int) error
+	UpdateFlowScheduleLastRun(ctx context.Context, id idwrap.IDWrap, lastRunAt int64, state mflow.NodeState) error
+}
+
+// RunResult is the outcome of a scheduled flow run.
+type RunResult struct {
+	// FlowVersionID is the version snapshot the run executed, if one was made.
+	FlowVersionID *idwrap.IDWrap
+	Err           error
+}
+
+// Executor runs the flow of a schedule to completion.
+type Executor interface {
+	RunScheduled(ctx context.Context, schedule mflow.FlowSchedule) RunResult
+}
+
+// Notifier is told about scheduled runs.
+type Notifier interface {
+	// RunRecorded is called after every run has been stored. schedule carries
+	// the updated last run.
+	RunRecorded(ctx context.Context, schedule mflow.FlowSchedule, run mflow.FlowScheduleRun)
+	// StateChanged is called when a schedule starts failing after passing, or
+	// passes again after failing. A schedule that has never run counts as
+	// passing.
+	StateChanged(ctx context.Context, schedule mflow.FlowSchedule, run mflow.FlowScheduleRun, previous mflow.NodeState)
+}
+
+// Option configures a Scheduler.
+type Option func(*Scheduler)
+
+// WithNotifier sets the notifier told about runs.
+func WithNotifier(notifier Notifier) Option {
+	return func(s *Scheduler) { s.notifier = notifier }
+}
+
+// WithLogger sets the scheduler's logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Scheduler) { s.logger = logger }
+}
+
+// WithHistorySize sets how many runs are kept per schedule.
+func WithHistorySize(size int) Option {
+	return func(s *Scheduler) { s.historySize = size }
+}
+
+// WithReloadInterval sets the longest the scheduler sleeps before reloading
+// the schedules.
+func WithReloadInterval(interval time.Duration) Option {
+	return func(s *Scheduler) { s.reloadInterval = interval }
+}
+
+// WithClock replaces time.Now, for tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Scheduler) { s.now = now }
+}
+
+// Scheduler starts flows when their schedules are due. A schedule whose flow
+// is still running when it comes due again is skipped, and occurrences missed
+// while the server was down are collapsed into a single run.
+type Scheduler struct {
+	store          Store
+	executor       Executor
+	notifier       Notifier
+	logger         *slog.Logger
+	historySize    int
+	reloadInterval time.Duration
+	now            func() time.Time
+
+	reload chan struct{}
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// running holds the schedules with a run in flight.
+	running map[idwrap.IDWrap]bool
+	// firstSeen is when a schedule that has never run was first loaded; its
+	// first occurrence is counted from then.
+	firstSeen map[idwrap.IDWrap]time.Time
+}
+
+// New creates a scheduler. Call Run to start it.
+func New(store Store, executor Executor, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		store:          store,
+		executor:       executor,
+		logger:         slog.Default(),
+		historySize:    DefaultHistorySize,
+		reloadInterval: DefaultReloadInterval,
+		now:            time.Now,
+		reload:         make(chan struct{}, 1),
+		running:        make(map[idwrap.IDWrap]bool),
+		firstSeen:      make(map[idwrap.IDWrap]time.Time),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Reload makes the scheduler re-read the schedules, such as after one was
+// created or changed.
+func (s *Scheduler) Reload() {
+	select {
+	case s.reload <- struct{}{}:
+	default:
+	}
+}
+
+// Run starts due flows until ctx is done, then waits for the runs in flight.
+func (s *Scheduler) Run(ctx context.Context) {
+	defer s.wg.Wait()
+	for {
+		timer := time.NewTimer(s.tick(ctx))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		case <-s.reload:
+			timer.Stop()
+		}
+	}
+}
+
+// tick starts the schedules that are due and returns how long to sleep until
+// the next one is.
+func (s *Scheduler) tick(ctx context.Context) time.Duration {
+	schedules, err := s.store.GetEnabledFlowSchedules(ctx)
+	if err != nil {
+		s.logger.Error("load flow schedules", "error", err)
+		return s.reloadInterval
+	}
+
+	now := s.now()
+	wait := s.reloadInterval
+	seen := make(map[idwrap.IDWrap]bool, len(schedules))
+	for _, schedule := range schedules {
+		seen[schedule.ID] = true
+		next, err := NextRun(schedule, s.base(schedule, now))
+		if err != nil {
+			s.logger.Warn("skip flow schedule", "schedule_id", schedule.ID.String(), "error", err)
+			continue
+		}
+		if next.After(now) {
+			wait = min(wait, next.Sub(now))
+			continue
+		}
+		s.start(ctx, schedule, now)
+		if next, err = NextRun(schedule, now); err == nil {
+			wait = min(wait, next.Sub(now))
+		}
+	}
+
+	s.mu.Lock()
+	for id := range s.firstSeen {
+		if !seen[id] {
+			delete(s.firstSeen, id)
+		}
+	}
+	s.mu.Unlock()
+	return wait
+}
+
+// base is the time a schedule's next occurrence is counted from.
+func (s *Scheduler) base(schedule mflow.FlowSchedule, now time.Time) time.Time {
+	if schedule.LastRunAt != nil {
+		return time.UnixMilli(*schedule.LastRunAt)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	first, ok := s.firstSeen[schedule.ID]
+	if !ok {
+		first = now
+		s.firstSeen[schedule.ID] = first
+	}
+	return first
+}
+
+func (s *Scheduler) start(ctx context.Context, schedule mflow.FlowSchedule, now time.Time) {
+	s.mu.Lock()
+	if s.running[schedule.ID] {
+		s.mu.Unlock()
+		return
+	}
+	s.running[schedule.ID] = true
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, schedule.ID)
+			s.mu.Unlock()
+			s.Reload()
+		}()
+		s.run(ctx, schedule, now)
+	}()
+}
+
+func (s *Scheduler) run(ctx context.Context, schedule mflow.FlowSchedule, startedAt time.Time) {
+	result := s.executor.RunScheduled(ctx, schedule)
+	duration := s.now().Sub(startedAt)
+
+	// The outcome is stored even when the server is shutting down.
+	storeCtx := context.WithoutCancel(ctx)
+	lastRunAt := startedAt.UnixMilli()
+
+	if errors.Is(result.Err, ErrAlreadyRunning) {
+		s.logger.Info("skip scheduled flow run, flow is already running", "schedule_id", schedule.ID.String(), "flow_id", schedule.FlowID.String())
+		if err := s.store.UpdateFlowScheduleLastRun(storeCtx, schedule.ID, lastRunAt, schedule.LastState); err != nil {
+			s.logger.Error("update flow schedule", "schedule_id", schedule.ID.String(), "error", err)
+		}
+		return
+	}
+
+	run := mflow.FlowScheduleRun{
+		ID:            idwrap.NewNow(),
+		ScheduleID:    schedule.ID,
+		FlowVersionID: result.FlowVersionID,
+		State:         runState(result.Err),
+		StartedAt:     lastRunAt,
+		DurationMs:    duration.Milliseconds(),
+	}
+	if result.Err != nil {
+		msg := result.Err.Error()
+		run.Error = &msg
+	}
+
+	// A canceled run says nothing about the flow, so it keeps the schedule's
+	// state.
+	previous := schedule.LastState
+	state := previous
+	if run.State != mflow.NODE_STATE_CANCELED {
+		state = run.State
+	}
+
+	if err := s.store.CreateFlowScheduleRun(storeCtx, run); err != nil {
+		s.logger.Error("record scheduled flow run", "schedule_id", schedule.ID.String(), "error", err)
+		return
+	}
+	if err := s.store.PruneFlowScheduleRuns(storeCtx, schedule.ID, s.historySize); err != nil {
+		s.logger.Error("prune scheduled flow runs", "schedule_id", schedule.ID.String(), "error", err)
+	}
+	if err := s.store.UpdateFlowScheduleLastRun(storeCtx, schedule.ID, lastRunAt, state); err != nil {
+		s.logger.Error("update flow schedule", "schedule_id", schedule.ID.String(), "error", err)
+	}
+
+	schedule.LastRunAt = &lastRunAt
+	schedule.LastState = state
+	if s.notifier == nil {
+		return
+	}
+	s.notifier.RunRecorded(storeCtx, schedule, run)
+	if isFailing(previous) != isFailing(state) {
+		s.notifier.StateChanged(storeCtx, schedule, run, previous)
+	}
+}
+
+func runState(err error) mflow.NodeState {
+	switch {
+	case err == nil:
+		return mflow.NODE_STATE_SUCCESS
+	case errors.Is(err, context.Canceled):
+		return mflow.NODE_STATE_CANCELED
+	default:
+		return mflow.NODE_STATE_FAILURE
+	}
+}
+
+func isFailing(state mflow.NodeState) bool {
+	return state == mflow.NODE_STATE_FAILURE
+}
@@ -0,0 +1,231 @@
+package flowschedule
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type fakeStore struct {
+	mu        sync.Mutex
+	schedules map[idwrap.IDWrap]mflow.FlowSchedule
+	runs      []mflow.FlowScheduleRun
+	pruned    []int
+}
+
+func newFakeStore(schedules ...mflow.FlowSchedule) *fakeStore {
+	store := &fakeStore{schedules: make(map[idwrap.IDWrap]mflow.FlowSchedule)}
+	for _, schedule := range schedules {
+		store.schedules[schedule.ID] = schedule
+	}
+	return store
+}
+
+func (f *fakeStore) GetEnabledFlowSchedules(context.Context) ([]mflow.FlowSchedule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result []mflow.FlowSchedule
+	for _, schedule := range f.schedules {
+		if schedule.Enabled {
+			result = append(result, schedule)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeStore) CreateFlowScheduleRun(_ context.Context, run mflow.FlowScheduleRun) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runs = append(f.runs, run)
+	return nil
+}
+
+func (f *fakeStore) PruneFlowScheduleRuns(_ context.Context, _ idwrap.IDWrap, keep int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pruned = append(f.pruned, keep)
+	return nil
+}
+
+func (f *fakeStore) UpdateFlowScheduleLastRun(_ context.Context, id idwrap.IDWrap, lastRunAt int64, state mflow.NodeState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	schedule := f.schedules[id]
+	schedule.LastRunAt = &lastRunAt
+	schedule.LastState = state
+	f.schedules[id] = schedule
+	return nil
+}
+
+func (f *fakeStore) schedule(id idwrap.IDWrap) mflow.FlowSchedule {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.schedules[id]
+}
+
+type fakeExecutor struct {
+	mu      sync.Mutex
+	results []error
+	calls   int
+	block   chan struct{}
+}
+
+func (f *fakeExecutor) RunScheduled(context.Context, mflow.FlowSchedule) RunResult {
+	if f.block != nil {
+		<-f.block
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	var err error
+	if len(f.results) > 0 {
+		err, f.results = f.results[0], f.results[1:]
+	}
+	return RunResult{Err: err}
+}
+
+type stateChange struct {
+	previous, current mflow.NodeState
+}
+
+type fakeNotifier struct {
+	mu       sync.Mutex
+	recorded int
+	changes  []stateChange
+}
+
+func (f *fakeNotifier) RunRecorded(context.Context, mflow.FlowSchedule, mflow.FlowScheduleRun) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recorded++
+}
+
+func (f *fakeNotifier) StateChanged(_ context.Context, schedule mflow.FlowSchedule, _ mflow.FlowScheduleRun, previous mflow.NodeState) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.changes = append(f.changes, stateChange{previous: previous, current: schedule.LastState})
+}
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestSchedulerRunsDueSchedules(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 10, 14, 0, 30, 0, time.UTC)}
+	schedule := mflow.FlowSchedule{ID: idwrap.NewNow(), FlowID: idwrap.NewNow(), IntervalSeconds: 60, Enabled: true}
+	disabled := mflow.FlowSchedule{ID: idwrap.NewNow(), FlowID: idwrap.NewNow(), IntervalSeconds: 1}
+	store := newFakeStore(schedule, disabled)
+	executor := &fakeExecutor{results: []error{nil, errors.New("status 500"), errors.New("status 500"), nil}}
+	notifier := &fakeNotifier{}
+	scheduler := New(store, executor, WithClock(clock.Now), WithNotifier(notifier), WithHistorySize(3))
+	ctx := context.Background()
+
+	// The first occurrence is an interval after the schedule was first seen.
+	require.Equal(t, time.Minute, scheduler.tick(ctx))
+	scheduler.wg.Wait()
+	require.Zero(t, executor.calls)
+
+	for range 4 {
+		clock.advance(time.Minute)
+		require.Equal(t, time.Minute, scheduler.tick(ctx))
+		scheduler.wg.Wait()
+	}
+
+	require.Equal(t, 4, executor.calls, "disabled schedules never run")
+	require.Len(t, store.runs, 4)
+	require.Equal(t, []int{3, 3, 3, 3}, store.pruned)
+	require.Equal(t, mflow.NODE_STATE_FAILURE, store.runs[1].State)
+	require.Equal(t, "status 500", *store.runs[1].Error)
+	require.Equal(t, clock.Now().UnixMilli(), store.runs[3].StartedAt)
+
+	stored := store.schedule(schedule.ID)
+	require.Equal(t, clock.Now().UnixMilli(), *stored.LastRunAt)
+	require.Equal(t, mflow.NODE_STATE_SUCCESS, stored.LastState)
+
+	require.Equal(t, 4, notifier.recorded)
+	require.Equal(t, []stateChange{
+		{previous: mflow.NODE_STATE_SUCCESS, current: mflow.NODE_STATE_FAILURE},
+		{previous: mflow.NODE_STATE_FAILURE, current: mflow.NODE_STATE_SUCCESS},
+	}, notifier.changes, "only transitions between passing and failing are reported")
+}
+
+func TestSchedulerCollapsesMissedRuns(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)}
+	lastRun := clock.now.Add(-3 * time.Hour).UnixMilli()
+	schedule := mflow.FlowSchedule{ID: idwrap.NewNow(), CronExpression: "*/5 * * * *", Enabled: true, LastRunAt: &lastRun}
+	store := newFakeStore(schedule)
+	executor := &fakeExecutor{}
+	scheduler := New(store, executor, WithClock(clock.Now), WithReloadInterval(time.Hour))
+
+	require.Equal(t, 5*time.Minute, scheduler.tick(context.Background()))
+	scheduler.wg.Wait()
+	require.Equal(t, 1, executor.calls)
+
+	clock.advance(time.Minute)
+	scheduler.tick(context.Background())
+	scheduler.wg.Wait()
+	require.Equal(t, 1, executor.calls, "the next run waits for the next occurrence")
+}
+
+func TestSchedulerSkipsOverlappingRuns(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)}
+	lastRun := clock.now.Add(-time.Hour).UnixMilli()
+	schedule := mflow.FlowSchedule{ID: idwrap.NewNow(), IntervalSeconds: 1, Enabled: true, LastRunAt: &lastRun}
+	store := newFakeStore(schedule)
+	executor := &fakeExecutor{block: make(chan struct{})}
+	scheduler := New(store, executor, WithClock(clock.Now), WithLogger(slog.New(slog.DiscardHandler)))
+
+	scheduler.tick(context.Background())
+	scheduler.tick(context.Background())
+	close(executor.block)
+	scheduler.wg.Wait()
+	require.Equal(t, 1, executor.calls)
+
+	executor.results = []error{ErrAlreadyRunning}
+	clock.advance(time.Hour)
+	scheduler.tick(context.Background())
+	scheduler.wg.Wait()
+	require.Len(t, store.runs, 1, "a skipped occurrence is not recorded")
+	require.Equal(t, clock.Now().UnixMilli(), *store.schedule(schedule.ID).LastRunAt)
+}
+
+func TestSchedulerRunStopsWithContext(t *testing.T) {
+	store := newFakeStore()
+	scheduler := New(store, &fakeExecutor{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+	scheduler.Reload()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+}
@@ -24,3 +24,11 @@ type TriggerEntry interface {
 	// TriggerType returns a string identifier for the trigger kind (e.g., "webhook", "queue").
 	TriggerType() string
 }
+
+// TeardownEntry is an entry node that starts the flow's cleanup section.
+// The runner holds it back while the flow runs and starts it once the run has
+// ended, whether it succeeded, failed, timed out or was canceled.
+type TeardownEntry interface {
+	EntryNode
+	IsTeardownEntry() bool
+}
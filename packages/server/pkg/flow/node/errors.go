@@ -0,0 +1,73 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+)
+
+// ErrAssertionFailed marks a node failure caused by a failed assertion.
+var ErrAssertionFailed = errors.New("assertion failed")
+
+// Kinds of failure reported to error branches.
+const (
+	ErrorKindTimeout   = "timeout"
+	ErrorKindAssertion = "assertion"
+	ErrorKindError     = "error"
+)
+
+// OutputError is the key a failure is written under for the error branch that
+// handles it: "<node>.error" after an on_error edge, "<try>.error" in the
+// catch branch of a try node.
+const OutputError = "error"
+
+// ErrorKind classifies a node failure for error branches.
+func ErrorKind(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorKindTimeout
+	case errors.Is(err, ErrAssertionFailed):
+		return ErrorKindAssertion
+	default:
+		return ErrorKindError
+	}
+}
+
+// IsHandleableError reports whether an error branch may take over after err.
+// Cancellation of the flow itself is never handled.
+func IsHandleableError(err error) bool {
+	return err != nil && !errors.Is(err, context.Canceled)
+}
+
+// BuildErrorOutput describes a failure for the error branch that handles it:
+// its kind and message, the name of the node it started at and the response
+// that node recorded before failing, if any. nodeName is used when err does
+// not name the node it started at.
+func BuildErrorOutput(req *FlowNodeRequest, nodeName string, err error) map[string]any {
+	var nodeErr *runner.NodeError
+	if errors.As(err, &nodeErr) && nodeErr.Name != "" {
+		nodeName = nodeErr.Name
+	}
+
+	output := map[string]any{
+		"kind":    ErrorKind(err),
+		"message": errorMessage(err),
+		"node":    nodeName,
+	}
+	if v, readErr := ReadVarRaw(req, nodeName); readErr == nil {
+		if vars, ok := v.(map[string]any); ok {
+			if resp, ok := vars["response"]; ok {
+				output["response"] = DeepCopyValue(resp)
+			}
+		}
+	}
+	return output
+}
+
+func errorMessage(err error) string {
+	// Loops join the failure of an iteration with ErrFlowCanceledByThrow,
+	// which says nothing about what went wrong.
+	return strings.TrimPrefix(err.Error(), runner.ErrFlowCanceledByThrow.Error()+"\n")
+}
@@ -0,0 +1,61 @@
+package node
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// RunFunc runs the chain of nodes starting at startNodeID, as
+// flowlocalrunner.RunNodeSync and RunNodeASync do. Nodes that run a body of
+// other nodes take it as a parameter, since the runner imports this package.
+type RunFunc func(ctx context.Context, startNodeID idwrap.IDWrap, req *FlowNodeRequest,
+	statusLogFunc LogPushFunc, predecessorMap map[idwrap.IDWrap][]idwrap.IDWrap) error
+
+// IterationContext returns the iteration context of iteration index of the
+// loop node nodeID, nested in the iteration the request itself runs in, if
+// any. The parent's slices are copied, so iterations never share them.
+func IterationContext(req *FlowNodeRequest, nodeID idwrap.IDWrap, name string, index int) *runner.IterationContext {
+	var parentPath []int
+	var parentNodes []idwrap.IDWrap
+	var parentLabels []runner.IterationLabel
+	if req.IterationContext != nil {
+		parentPath = append(parentPath, req.IterationContext.IterationPath...)
+		parentNodes = append(parentNodes, req.IterationContext.ParentNodes...)
+		parentLabels = CloneIterationLabels(req.IterationContext.Labels)
+	}
+	return &runner.IterationContext{
+		IterationPath:  append(parentPath, index),
+		ExecutionIndex: index,
+		ParentNodes:    append(parentNodes, nodeID),
+		Labels: append(parentLabels, runner.IterationLabel{
+			NodeID:    nodeID,
+			Name:      name,
+			Iteration: index + 1,
+		}),
+	}
+}
+
+// LogIteration reports the state of iteration index of the loop node nodeID
+// as an execution named name, when the request has a log.
+func LogIteration(req *FlowNodeRequest, executionID, nodeID idwrap.IDWrap, name string, index int,
+	state mflow.NodeState, data map[string]any, iterContext *runner.IterationContext, err error,
+) {
+	if req.LogPushFunc == nil {
+		return
+	}
+	req.LogPushFunc(runner.FlowNodeStatus{
+		ExecutionID:      executionID,
+		NodeID:           nodeID,
+		Name:             name,
+		State:            state,
+		Error:            err,
+		OutputData:       data,
+		IterationEvent:   true,
+		IterationIndex:   index,
+		LoopNodeID:       nodeID,
+		IterationContext: iterContext,
+	})
+}
@@ -0,0 +1,51 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterationContext_Nested(t *testing.T) {
+	outerID, innerID := idwrap.NewNow(), idwrap.NewNow()
+
+	outer := node.IterationContext(&node.FlowNodeRequest{}, outerID, "Outer", 2)
+	require.Equal(t, []int{2}, outer.IterationPath)
+	require.Equal(t, []idwrap.IDWrap{outerID}, outer.ParentNodes)
+	require.Equal(t, 3, outer.Labels[0].Iteration)
+
+	req := &node.FlowNodeRequest{IterationContext: outer}
+	first := node.IterationContext(req, innerID, "Inner", 0)
+	second := node.IterationContext(req, innerID, "Inner", 1)
+	require.Equal(t, []int{2, 0}, first.IterationPath)
+	require.Equal(t, []int{2, 1}, second.IterationPath)
+	require.Equal(t, []idwrap.IDWrap{outerID, innerID}, second.ParentNodes)
+	require.Len(t, second.Labels, 2)
+	require.Equal(t, 1, second.ExecutionIndex)
+
+	// Iterations never share the parent's slices.
+	require.Equal(t, []int{2}, outer.IterationPath)
+	require.Len(t, outer.Labels, 1)
+}
+
+func TestLogIteration(t *testing.T) {
+	nodeID, executionID := idwrap.NewNow(), idwrap.NewNow()
+	var logged []runner.FlowNodeStatus
+	req := &node.FlowNodeRequest{LogPushFunc: func(s runner.FlowNodeStatus) { logged = append(logged, s) }}
+
+	iterContext := node.IterationContext(req, nodeID, "Loop", 0)
+	node.LogIteration(req, executionID, nodeID, "Loop Iteration 1", 0, mflow.NODE_STATE_SUCCESS, map[string]any{"item": 1}, iterContext, nil)
+	require.Len(t, logged, 1)
+	require.True(t, logged[0].IterationEvent)
+	require.Equal(t, nodeID, logged[0].LoopNodeID)
+	require.Equal(t, "Loop Iteration 1", logged[0].Name)
+	require.Same(t, iterContext, logged[0].IterationContext)
+
+	// Without a log nothing is reported.
+	node.LogIteration(&node.FlowNodeRequest{}, executionID, nodeID, "Loop Iteration 1", 0, mflow.NODE_STATE_SUCCESS, nil, iterContext, nil)
+}
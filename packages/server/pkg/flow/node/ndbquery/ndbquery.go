@@ -0,0 +1,252 @@
+//nolint:revive // exported
+package ndbquery
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+
+	_ "modernc.org/sqlite"
+)
+
+// ErrNoConnection is returned when the node has no database credential or
+// the credential has an empty connection string.
+var ErrNoConnection = errors.New("db query has no connection string")
+
+// DefaultTimeoutMs is how long a query may run when no timeout is set.
+const DefaultTimeoutMs = 30000
+
+// Compile-time check that NodeDbQuery implements VariableIntrospector.
+var _ node.VariableIntrospector = (*NodeDbQuery)(nil)
+
+// NodeDbQuery runs Query against the database ConnectionString points at,
+// binding each of Params, evaluated as an expression, to the query's
+// placeholders in order. The query text itself is never interpolated, so
+// values only reach the database as parameters.
+//
+// Statements that return rows (SELECT, WITH, ... RETURNING) expose them as
+// "rows", a list of column-to-value maps usable by for_each; other statements
+// expose "rows_affected" and "last_insert_id".
+//
+// SQLite files are only opened when AllowLocalFiles is set; otherwise such a
+// connection string fails with ErrLocalFileNotAllowed.
+type NodeDbQuery struct {
+	FlowNodeID       idwrap.IDWrap
+	Name             string
+	ConnectionString string
+	Query            string
+	Params           []string
+	TimeoutMs        int64
+	Assertions       []string
+	AllowLocalFiles  bool
+}
+
+func New(id idwrap.IDWrap, name, connectionString string, cfg mflow.NodeDbQuery) *NodeDbQuery {
+	return &NodeDbQuery{
+		FlowNodeID:       id,
+		Name:             name,
+		ConnectionString: connectionString,
+		Query:            cfg.Query,
+		Params:           cfg.Params,
+		TimeoutMs:        cfg.TimeoutMs,
+		Assertions:       cfg.Assertions,
+	}
+}
+
+func (n *NodeDbQuery) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeDbQuery) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeDbQuery) GetName() string {
+	return n.Name
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeDbQuery) GetRequiredVariables() []string {
+	return expression.ExtractVarKeysFromMultiple(append([]string{n.ConnectionString}, n.Params...)...)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeDbQuery) GetOutputVariables() []string {
+	return []string{
+		"rows",
+		"row",
+		"columns",
+		"count",
+		"rows_affected",
+		"last_insert_id",
+		"duration",
+	}
+}
+
+func (n *NodeDbQuery) timeout() time.Duration {
+	if n.TimeoutMs > 0 {
+		return time.Duration(n.TimeoutMs) * time.Millisecond
+	}
+	return DefaultTimeoutMs * time.Millisecond
+}
+
+func (n *NodeDbQuery) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	if n.ConnectionString == "" {
+		return node.FlowNodeResult{Err: ErrNoConnection}
+	}
+
+	varMapCopy := node.DeepCopyVarMap(req)
+	env := expression.NewUnifiedEnv(varMapCopy)
+
+	connectionString, err := env.Interpolate(n.ConnectionString)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate connection string: %w", err)}
+	}
+	// Checked after interpolation, so a variable cannot smuggle in a path.
+	if !n.AllowLocalFiles && IsLocalFile(connectionString) {
+		return node.FlowNodeResult{Err: fmt.Errorf("db query %q: %w", n.Name, ErrLocalFileNotAllowed)}
+	}
+	driver, dsn, err := ParseConnectionString(connectionString)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	args := make([]any, len(n.Params))
+	for i, param := range n.Params {
+		v, err := env.EvalInterpolated(ctx, param)
+		if err != nil {
+			return node.FlowNodeResult{Err: fmt.Errorf("evaluate param %d '%s': %w", i+1, param, err)}
+		}
+		args[i] = v
+	}
+
+	start := time.Now()
+	queryCtx, cancel := context.WithTimeout(ctx, n.timeout())
+	defer cancel()
+
+	outputs, err := n.run(queryCtx, driver, dsn, args)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	outputs["duration"] = time.Since(start).Milliseconds()
+
+	if err := node.WriteNodeOutputs(req, n.Name, outputs); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	if err := node.AssertOutputs(ctx, n.Name, varMapCopy, outputs, n.Assertions); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified),
+	}
+}
+
+func (n *NodeDbQuery) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+// run opens the database for this run only, so nothing is shared between
+// flows or left open after the node finishes.
+func (n *NodeDbQuery) run(ctx context.Context, driver, dsn string, args []any) (map[string]any, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open %s database: %w", driver, err)
+	}
+	defer db.Close()
+
+	outputs := map[string]any{
+		"rows":           []any{},
+		"row":            nil,
+		"columns":        []any{},
+		"count":          0,
+		"rows_affected":  int64(0),
+		"last_insert_id": int64(0),
+	}
+
+	if !ReturnsRows(n.Query) {
+		res, err := db.ExecContext(ctx, n.Query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("exec query: %w", err)
+		}
+		// Drivers that cannot report these return an error, which only
+		// means the value is unknown.
+		if affected, err := res.RowsAffected(); err == nil {
+			outputs["rows_affected"] = affected
+		}
+		if id, err := res.LastInsertId(); err == nil {
+			outputs["last_insert_id"] = id
+		}
+		return outputs, nil
+	}
+
+	rows, err := db.QueryContext(ctx, n.Query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("run query: %w", err)
+	}
+	defer rows.Close()
+
+	columns, result, err := scanRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("read rows: %w", err)
+	}
+	outputs["columns"] = columns
+	outputs["rows"] = result
+	outputs["count"] = len(result)
+	outputs["rows_affected"] = int64(len(result))
+	if len(result) > 0 {
+		outputs["row"] = result[0]
+	}
+	return outputs, nil
+}
+
+// scanRows reads every row into a column-to-value map. Byte slices become
+// strings and times RFC 3339 strings, so rows read the same in expressions
+// and in exported results whichever driver produced them.
+func scanRows(rows *sql.Rows) ([]any, []any, error) {
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make([]any, len(names))
+	for i, name := range names {
+		columns[i] = name
+	}
+
+	result := []any{}
+	values := make([]any, len(names))
+	ptrs := make([]any, len(names))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, nil, err
+		}
+		row := make(map[string]any, len(names))
+		for i, name := range names {
+			row[name] = normalizeValue(values[i])
+		}
+		result = append(result, row)
+	}
+	return columns, result, rows.Err()
+}
+
+func normalizeValue(v any) any {
+	switch val := v.(type) {
+	case []byte:
+		return string(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	default:
+		return val
+	}
+}
@@ -0,0 +1,209 @@
+package ndbquery
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func newReq() *node.FlowNodeRequest {
+	return &node.FlowNodeRequest{
+		VarMap:           make(map[string]any),
+		ReadWriteLock:    &sync.RWMutex{},
+		EdgeSourceMap:    mflow.EdgesMap{},
+		Timeout:          10 * time.Second,
+		PendingAtmoicMap: make(map[idwrap.IDWrap]uint32),
+		PendingMapMu:     &sync.Mutex{},
+	}
+}
+
+func run(t *testing.T, req *node.FlowNodeRequest, name, conn string, cfg mflow.NodeDbQuery) (map[string]any, error) {
+	t.Helper()
+	n := New(idwrap.NewNow(), name, conn, cfg)
+	n.AllowLocalFiles = true
+	result := n.RunSync(context.Background(), req)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	out, err := node.ReadVarRaw(req, name)
+	if err != nil {
+		t.Fatalf("ReadVarRaw: %v", err)
+	}
+	return out.(map[string]any), nil
+}
+
+func TestNodeDbQuery_SeedAndVerify(t *testing.T) {
+	conn := "sqlite://" + filepath.Join(t.TempDir(), "app.db")
+	req := newReq()
+	req.VarMap["user"] = map[string]any{"name": "alice", "age": 31}
+
+	if _, err := run(t, req, "Schema", conn, mflow.NodeDbQuery{
+		Query: "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, age INTEGER)",
+	}); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	out, err := run(t, req, "Seed", conn, mflow.NodeDbQuery{
+		Query:      "INSERT INTO users (name, age) VALUES (?, ?)",
+		Params:     []string{"user.name", "{{ user.age }}"},
+		Assertions: []string{"rows_affected == 1", "Seed.last_insert_id == 1"},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if out["rows_affected"] != int64(1) {
+		t.Fatalf("rows_affected = %v, want 1", out["rows_affected"])
+	}
+
+	out, err = run(t, req, "Verify", conn, mflow.NodeDbQuery{
+		Query:      "-- the seeded user\nSELECT id, name, age FROM users WHERE name = ?",
+		Params:     []string{`"alice"`},
+		Assertions: []string{"count == 1", `row.name == "alice"`, "rows[0].age == 31"},
+	})
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	rows := out["rows"].([]any)
+	if len(rows) != 1 {
+		t.Fatalf("rows = %v, want 1 row", rows)
+	}
+	if cols := out["columns"].([]any); len(cols) != 3 || cols[1] != "name" {
+		t.Fatalf("columns = %v", cols)
+	}
+}
+
+func TestNodeDbQuery_Returning(t *testing.T) {
+	conn := "sqlite:" + filepath.Join(t.TempDir(), "app.db")
+	req := newReq()
+	if _, err := run(t, req, "Schema", conn, mflow.NodeDbQuery{
+		Query: "CREATE TABLE items (id INTEGER PRIMARY KEY, label TEXT)",
+	}); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	out, err := run(t, req, "Insert", conn, mflow.NodeDbQuery{
+		Query:  "INSERT INTO items (label) VALUES (?), (?) RETURNING id, label",
+		Params: []string{`"a"`, `"b"`},
+	})
+	if err != nil {
+		t.Fatalf("insert returning: %v", err)
+	}
+	if out["count"] != 2 {
+		t.Fatalf("count = %v, want 2", out["count"])
+	}
+}
+
+func TestNodeDbQuery_AssertionFailed(t *testing.T) {
+	_, err := run(t, newReq(), "Q", "sqlite::memory:", mflow.NodeDbQuery{
+		Query:      "SELECT 1 AS one",
+		Assertions: []string{"row.one == 2"},
+	})
+	if !errors.Is(err, node.ErrAssertionFailed) {
+		t.Fatalf("err = %v, want ErrAssertionFailed", err)
+	}
+}
+
+func TestNodeDbQuery_NoConnection(t *testing.T) {
+	_, err := run(t, newReq(), "Q", "", mflow.NodeDbQuery{Query: "SELECT 1"})
+	if !errors.Is(err, ErrNoConnection) {
+		t.Fatalf("err = %v, want ErrNoConnection", err)
+	}
+}
+
+func TestNodeDbQuery_LocalFileNotAllowed(t *testing.T) {
+	req := newReq()
+	req.VarMap["db"] = filepath.Join(t.TempDir(), "app.db")
+
+	for _, conn := range []string{"sqlite:{{ db }}", "file:{{ db }}?mode=ro"} {
+		n := New(idwrap.NewNow(), "Q", conn, mflow.NodeDbQuery{Query: "SELECT 1"})
+		if err := n.RunSync(context.Background(), req).Err; !errors.Is(err, ErrLocalFileNotAllowed) {
+			t.Fatalf("%s: err = %v, want ErrLocalFileNotAllowed", conn, err)
+		}
+	}
+
+	n := New(idwrap.NewNow(), "Q", ":memory:", mflow.NodeDbQuery{Query: "SELECT 1"})
+	if err := n.RunSync(context.Background(), req).Err; err != nil {
+		t.Fatalf(":memory: without local files: %v", err)
+	}
+}
+
+func TestIsLocalFile(t *testing.T) {
+	tests := map[string]bool{
+		"sqlite:///tmp/app.db":          true,
+		"SQLite:app.db":                 true,
+		"file:app.db?mode=ro":           true,
+		":memory:":                      false,
+		"sqlite::memory:":               false,
+		"file::memory:?cache=shared":    false,
+		"postgres://u:p@localhost/app":  false,
+		"mysql://u:p@localhost:3306/db": false,
+	}
+	for in, want := range tests {
+		if got := IsLocalFile(in); got != want {
+			t.Errorf("IsLocalFile(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestParseConnectionString(t *testing.T) {
+	tests := []struct {
+		in     string
+		driver string
+		dsn    string
+		err    error
+	}{
+		{in: "sqlite:///tmp/app.db", driver: "sqlite", dsn: "/tmp/app.db"},
+		{in: "sqlite:app.db", driver: "sqlite", dsn: "app.db"},
+		{in: "file:app.db?mode=ro", driver: "sqlite", dsn: "file:app.db?mode=ro"},
+		{in: ":memory:", driver: "sqlite", dsn: ":memory:"},
+		{in: "postgres://u:p@localhost/app", err: ErrDriverNotAvailable},
+		{in: "mysql://u:p@localhost/app", err: ErrDriverNotAvailable},
+		{in: "oracle://u:p@localhost/app", err: ErrUnsupportedConnection},
+	}
+	for _, tt := range tests {
+		driver, dsn, err := ParseConnectionString(tt.in)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("%s: err = %v, want %v", tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || driver != tt.driver || dsn != tt.dsn {
+			t.Errorf("%s: got (%q, %q, %v), want (%q, %q)", tt.in, driver, dsn, err, tt.driver, tt.dsn)
+		}
+	}
+}
+
+func TestMysqlDSN(t *testing.T) {
+	got, err := mysqlDSN("mysql://root:secret@db/app?parseTime=true")
+	if err != nil {
+		t.Fatalf("mysqlDSN: %v", err)
+	}
+	if want := "root:secret@tcp(db:3306)/app?parseTime=true"; got != want {
+		t.Fatalf("mysqlDSN = %q, want %q", got, want)
+	}
+}
+
+func TestReturnsRows(t *testing.T) {
+	tests := map[string]bool{
+		"SELECT 1":                                true,
+		"  with t as (select 1) select * from t":  true,
+		"/* seed */ select 1":                     true,
+		"-- comment\nSELECT 1":                    true,
+		"INSERT INTO t VALUES (1)":                false,
+		"DELETE FROM t WHERE id = ? RETURNING id": true,
+		"UPDATE t SET a = 1":                      false,
+		"CREATE TABLE t (id INTEGER)":             false,
+	}
+	for query, want := range tests {
+		if got := ReturnsRows(query); got != want {
+			t.Errorf("ReturnsRows(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
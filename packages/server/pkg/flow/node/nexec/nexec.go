@@ -0,0 +1,225 @@
+//nolint:revive // exported
+package nexec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+var (
+	// ErrNotAllowed is returned when building a flow with an exec node while
+	// running commands has not been enabled. Flows can come from untrusted
+	// files, so exec nodes are off unless the caller opts in.
+	ErrNotAllowed = errors.New("exec nodes are not allowed")
+	// ErrNoCommand is returned when the node has no command to run.
+	ErrNoCommand = errors.New("exec node has no command")
+	// ErrExitStatus is returned when the command exits with a non-zero
+	// status. The node's outputs are still written, so stderr and exit_code
+	// can be read by an error handler.
+	ErrExitStatus = errors.New("command failed")
+	// ErrTimeout is returned when the command is still running after the
+	// timeout; it is killed.
+	ErrTimeout = errors.New("command timed out")
+)
+
+// DefaultTimeoutMs is how long a command may run when no timeout is set.
+const DefaultTimeoutMs = 30000
+
+// MaxOutputSize caps how much of stdout and of stderr is kept. Anything the
+// command writes past it is discarded and the node's truncated output is set.
+const MaxOutputSize = 1 << 20
+
+// killGrace is how long to wait for the command's output to close after it
+// has been killed, in case a child process it started still holds it open.
+const killGrace = time.Second
+
+// Compile-time check that NodeExec implements VariableIntrospector.
+var _ node.VariableIntrospector = (*NodeExec)(nil)
+
+// NodeExec runs Command with Args in WorkDir, each interpolated, and exposes
+// its stdout, stderr and exit code. The command is started directly rather
+// than through a shell, so arguments are never re-split or expanded; run
+// "sh -c" explicitly for pipes and redirections.
+type NodeExec struct {
+	FlowNodeID idwrap.IDWrap
+	Name       string
+	Command    string
+	Args       []string
+	WorkDir    string
+	TimeoutMs  int64
+	ParseJSON  bool
+}
+
+func New(id idwrap.IDWrap, name string, cfg mflow.NodeExec) *NodeExec {
+	return &NodeExec{
+		FlowNodeID: id,
+		Name:       name,
+		Command:    cfg.Command,
+		Args:       cfg.Args,
+		WorkDir:    cfg.WorkDir,
+		TimeoutMs:  cfg.TimeoutMs,
+		ParseJSON:  cfg.ParseJSON,
+	}
+}
+
+func (n *NodeExec) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeExec) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeExec) GetName() string {
+	return n.Name
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeExec) GetRequiredVariables() []string {
+	return expression.ExtractVarKeysFromMultiple(append([]string{n.Command, n.WorkDir}, n.Args...)...)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeExec) GetOutputVariables() []string {
+	return []string{
+		"stdout",
+		"stderr",
+		"exit_code",
+		"truncated",
+		"json",
+		"duration",
+	}
+}
+
+func (n *NodeExec) timeout() time.Duration {
+	if n.TimeoutMs > 0 {
+		return time.Duration(n.TimeoutMs) * time.Millisecond
+	}
+	return DefaultTimeoutMs * time.Millisecond
+}
+
+func (n *NodeExec) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	if strings.TrimSpace(n.Command) == "" {
+		return node.FlowNodeResult{Err: ErrNoCommand}
+	}
+
+	env := expression.NewUnifiedEnv(node.DeepCopyVarMap(req))
+	command, err := env.InterpolateCtx(ctx, n.Command)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate command: %w", err)}
+	}
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i], err = env.InterpolateCtx(ctx, arg)
+		if err != nil {
+			return node.FlowNodeResult{Err: fmt.Errorf("interpolate arg %d: %w", i+1, err)}
+		}
+	}
+	workDir, err := env.InterpolateCtx(ctx, n.WorkDir)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate work dir: %w", err)}
+	}
+
+	start := time.Now()
+	runCtx, cancel := context.WithTimeout(ctx, n.timeout())
+	defer cancel()
+
+	var stdout, stderr limitedBuffer
+	cmd := exec.CommandContext(runCtx, command, args...) //nolint:gosec // running the configured command is the node's purpose; see ErrNotAllowed
+	cmd.Dir = workDir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = killGrace
+
+	exitCode := 0
+	if runErr := cmd.Run(); runErr != nil {
+		var exitErr *exec.ExitError
+		switch {
+		case ctx.Err() != nil:
+			return node.FlowNodeResult{Err: ctx.Err()}
+		case runCtx.Err() != nil:
+			return node.FlowNodeResult{Err: fmt.Errorf("%w after %s: %s", ErrTimeout, n.timeout(), command)}
+		case errors.As(runErr, &exitErr):
+			exitCode = exitErr.ExitCode()
+		default:
+			return node.FlowNodeResult{Err: fmt.Errorf("run %s: %w", command, runErr)}
+		}
+	}
+
+	outputs := map[string]any{
+		"stdout":    stdout.String(),
+		"stderr":    stderr.String(),
+		"exit_code": exitCode,
+		"truncated": stdout.truncated || stderr.truncated,
+		"json":      nil,
+		"duration":  time.Since(start).Milliseconds(),
+	}
+	if n.ParseJSON && exitCode == 0 {
+		if stdout.truncated {
+			return node.FlowNodeResult{Err: fmt.Errorf("parse stdout as json: output is larger than %d bytes", MaxOutputSize)}
+		}
+		var parsed any
+		if err := json.Unmarshal(stdout.Bytes(), &parsed); err != nil {
+			return node.FlowNodeResult{Err: fmt.Errorf("parse stdout as json: %w", err)}
+		}
+		outputs["json"] = parsed
+	}
+
+	if err := node.WriteNodeOutputs(req, n.Name, outputs); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	if exitCode != 0 {
+		return node.FlowNodeResult{Err: exitError(command, exitCode, stderr.String())}
+	}
+
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified),
+	}
+}
+
+func (n *NodeExec) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+// exitError reports a non-zero exit with the last line the command wrote to
+// stderr, which is where most tools put the reason they failed.
+func exitError(command string, exitCode int, stderr string) error {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return fmt.Errorf("%w: %s exited with status %d: %s", ErrExitStatus, command, exitCode, last)
+	}
+	return fmt.Errorf("%w: %s exited with status %d", ErrExitStatus, command, exitCode)
+}
+
+// limitedBuffer keeps the first MaxOutputSize bytes written to it. Later
+// writes are discarded rather than refused, so the command isn't stopped by a
+// broken pipe.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := MaxOutputSize - b.buf.Len(); len(p) > room {
+		b.truncated = true
+		b.buf.Write(p[:room])
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte { return b.buf.Bytes() }
+
+func (b *limitedBuffer) String() string { return b.buf.String() }
@@ -0,0 +1,177 @@
+package nexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// TestHelperProcess is not a real test: it is the command the tests run,
+// re-executing the test binary so they don't depend on tools being installed.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("NEXEC_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	args = args[1:]
+
+	switch args[0] {
+	case "json":
+		wd, _ := os.Getwd()
+		fmt.Printf(`{"arg": %q, "cwd": %q}`, args[1], wd)
+	case "fail":
+		fmt.Fprintln(os.Stderr, "loading config")
+		fmt.Fprintln(os.Stderr, "token expired")
+		os.Exit(3)
+	case "flood":
+		chunk := strings.Repeat("x", 64<<10)
+		for range MaxOutputSize/len(chunk) + 2 {
+			fmt.Print(chunk)
+		}
+		fmt.Fprint(os.Stderr, "done")
+	case "sleep":
+		time.Sleep(10 * time.Second)
+	}
+	os.Exit(0)
+}
+
+func helper(args ...string) mflow.NodeExec {
+	return mflow.NodeExec{
+		Command: os.Args[0],
+		Args:    append([]string{"-test.run=^TestHelperProcess$", "--"}, args...),
+	}
+}
+
+func newReq() *node.FlowNodeRequest {
+	return &node.FlowNodeRequest{
+		VarMap:           make(map[string]any),
+		ReadWriteLock:    &sync.RWMutex{},
+		EdgeSourceMap:    mflow.EdgesMap{},
+		Timeout:          10 * time.Second,
+		PendingAtmoicMap: make(map[idwrap.IDWrap]uint32),
+		PendingMapMu:     &sync.Mutex{},
+	}
+}
+
+func TestNodeExec_JSON(t *testing.T) {
+	t.Setenv("NEXEC_HELPER_PROCESS", "1")
+	dir := t.TempDir()
+	req := newReq()
+	req.VarMap["user"] = map[string]any{"id": "u-42"}
+	req.VarMap["dir"] = dir
+
+	cfg := helper("json", "sub={{ user.id }}")
+	cfg.WorkDir = "{{ dir }}"
+	cfg.ParseJSON = true
+	nextID := idwrap.NewNow()
+	n := New(idwrap.NewNow(), "Token", cfg)
+	req.EdgeSourceMap = mflow.NewEdgesMap([]mflow.Edge{
+		mflow.NewEdge(idwrap.NewNow(), n.FlowNodeID, nextID, mflow.HandleUnspecified),
+	})
+
+	result := n.RunSync(context.Background(), req)
+	if result.Err != nil {
+		t.Fatalf("RunSync: %v", result.Err)
+	}
+	if len(result.NextNodeID) != 1 || result.NextNodeID[0] != nextID {
+		t.Errorf("expected to continue to the next node, got %v", result.NextNodeID)
+	}
+	parsed, _ := node.ReadNodeVar(req, "Token", "json")
+	out, ok := parsed.(map[string]any)
+	if !ok {
+		t.Fatalf("json = %#v, want an object", parsed)
+	}
+	if out["arg"] != "sub=u-42" {
+		t.Errorf("arg = %v, want sub=u-42", out["arg"])
+	}
+	if out["cwd"] != dir {
+		t.Errorf("cwd = %v, want %s", out["cwd"], dir)
+	}
+	if code, _ := node.ReadNodeVar(req, "Token", "exit_code"); code != 0 {
+		t.Errorf("exit_code = %v, want 0", code)
+	}
+}
+
+func TestNodeExec_ExitStatus(t *testing.T) {
+	t.Setenv("NEXEC_HELPER_PROCESS", "1")
+	req := newReq()
+	n := New(idwrap.NewNow(), "Migrate", helper("fail"))
+
+	result := n.RunSync(context.Background(), req)
+	if !errors.Is(result.Err, ErrExitStatus) {
+		t.Fatalf("err = %v, want ErrExitStatus", result.Err)
+	}
+	if want := "status 3: token expired"; !strings.Contains(result.Err.Error(), want) {
+		t.Errorf("err = %q, want it to mention %q", result.Err, want)
+	}
+	if code, _ := node.ReadNodeVar(req, "Migrate", "exit_code"); code != 3 {
+		t.Errorf("exit_code = %v, want 3", code)
+	}
+	if stderr, _ := node.ReadNodeVar(req, "Migrate", "stderr"); stderr != "loading config\ntoken expired\n" {
+		t.Errorf("stderr = %q", stderr)
+	}
+}
+
+func TestNodeExec_Truncated(t *testing.T) {
+	t.Setenv("NEXEC_HELPER_PROCESS", "1")
+	req := newReq()
+	n := New(idwrap.NewNow(), "Noisy", helper("flood"))
+
+	if result := n.RunSync(context.Background(), req); result.Err != nil {
+		t.Fatalf("RunSync: %v", result.Err)
+	}
+	if stdout, _ := node.ReadNodeVar(req, "Noisy", "stdout"); len(stdout.(string)) != MaxOutputSize {
+		t.Errorf("len(stdout) = %d, want %d", len(stdout.(string)), MaxOutputSize)
+	}
+	if stderr, _ := node.ReadNodeVar(req, "Noisy", "stderr"); stderr != "done" {
+		t.Errorf("stderr = %q, want done", stderr)
+	}
+	if truncated, _ := node.ReadNodeVar(req, "Noisy", "truncated"); truncated != true {
+		t.Errorf("truncated = %v, want true", truncated)
+	}
+
+	cfg := helper("flood")
+	cfg.ParseJSON = true
+	result := New(idwrap.NewNow(), "Noisy", cfg).RunSync(context.Background(), newReq())
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "larger than") {
+		t.Errorf("err = %v, want the output to be too large to parse", result.Err)
+	}
+}
+
+func TestNodeExec_Timeout(t *testing.T) {
+	t.Setenv("NEXEC_HELPER_PROCESS", "1")
+	cfg := helper("sleep")
+	cfg.TimeoutMs = 200
+	n := New(idwrap.NewNow(), "Slow", cfg)
+
+	start := time.Now()
+	result := n.RunSync(context.Background(), newReq())
+	if !errors.Is(result.Err, ErrTimeout) {
+		t.Fatalf("err = %v, want ErrTimeout", result.Err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("command was not killed at the timeout, took %s", elapsed)
+	}
+}
+
+func TestNodeExec_Errors(t *testing.T) {
+	if result := New(idwrap.NewNow(), "Empty", mflow.NodeExec{}).RunSync(context.Background(), newReq()); !errors.Is(result.Err, ErrNoCommand) {
+		t.Errorf("err = %v, want ErrNoCommand", result.Err)
+	}
+	missing := New(idwrap.NewNow(), "Missing", mflow.NodeExec{Command: "devtools-no-such-command"})
+	if result := missing.RunSync(context.Background(), newReq()); result.Err == nil || errors.Is(result.Err, ErrExitStatus) {
+		t.Errorf("err = %v, want a start error", result.Err)
+	}
+}
@@ -0,0 +1,233 @@
+package nforeach
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// runConcurrent runs up to nr.Concurrency iterations at once. Each iteration
+// works on its own copy of the flow variables, holding its own item and key,
+// so iterations cannot see each other's writes. When an iteration finishes,
+// the outputs of the loop body are copied back into the flow variables; with
+// several iterations in flight the last one to finish wins.
+//
+// The error policy applies as for sequential loops, except that iterations
+// already in flight are let finish: BREAK and a met break condition stop
+// launching new iterations, and an unhandled failure also cancels the
+// iterations still running.
+func (nr *NodeForEach) runConcurrent(ctx context.Context, req *node.FlowNodeRequest, runBody node.RunFunc) node.FlowNodeResult {
+	loopTargets := mflow.GetNextNodeID(req.EdgeSourceMap, nr.FlowNodeID, mflow.HandleLoop)
+	loopTargets = node.FilterLoopEntryNodes(req.EdgeSourceMap, loopTargets)
+	loopEdgeMap := node.BuildLoopExecutionEdgeMap(req.EdgeSourceMap, nr.FlowNodeID, loopTargets)
+	nextID := mflow.GetNextNodeID(req.EdgeSourceMap, nr.FlowNodeID, mflow.HandleThen)
+	predecessorMap := flowlocalrunner.BuildPredecessorMap(loopEdgeMap)
+	pendingTemplate := node.BuildPendingMap(predecessorMap)
+	bodyNames := loopBodyNames(req, loopEdgeMap, loopTargets)
+
+	env := expression.NewUnifiedEnv(node.DeepCopyVarMap(req))
+	if req.VariableTracker != nil {
+		env = env.WithTracking(req.VariableTracker)
+	}
+	result, err := env.EvalIter(ctx, nr.IterPath)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	entries, err := iterEntries(result)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		stop      bool
+		loopError error
+		wg        sync.WaitGroup
+	)
+	sem := make(chan struct{}, nr.Concurrency)
+
+	runIteration := func(index int, key, item any) {
+		defer wg.Done()
+		defer func() { <-sem }()
+
+		iterReq := *req
+		iterReq.VarMap = node.DeepCopyVarMap(req)
+		iterReq.ReadWriteLock = &sync.RWMutex{}
+		iterReq.EdgeSourceMap = loopEdgeMap
+		iterReq.IterationContext = node.IterationContext(req, nr.FlowNodeID, nr.Name, index)
+
+		iterErr := nr.writeIterationVars(&iterReq, key, item)
+		executionID := idwrap.NewMonotonic()
+		iterationData := map[string]any{"item": item, "key": key}
+		nr.logIteration(req, executionID, index, mflow.NODE_STATE_RUNNING, iterationData, iterReq.IterationContext, nil)
+
+		for _, entryID := range loopTargets {
+			if iterErr != nil {
+				break
+			}
+			iterReq.PendingAtmoicMap = node.ClonePendingMap(pendingTemplate)
+			iterReq.ExecutionID = idwrap.NewMonotonic()
+			iterErr = runBody(loopCtx, entryID, &iterReq, req.LogPushFunc, predecessorMap)
+		}
+
+		state := mflow.NODE_STATE_SUCCESS
+		if iterErr != nil {
+			state = mflow.NODE_STATE_FAILURE
+		}
+		nr.logIteration(req, executionID, index, state, iterationData, iterReq.IterationContext, iterErr)
+
+		req.ReadWriteLock.Lock()
+		for _, name := range bodyNames {
+			if v, ok := iterReq.VarMap[name]; ok {
+				req.VarMap[name] = v
+			}
+		}
+		req.ReadWriteLock.Unlock()
+
+		mu.Lock()
+		defer mu.Unlock()
+		if iterErr != nil {
+			switch nr.ErrorHandling {
+			case mflow.ErrorHandling_ERROR_HANDLING_IGNORE:
+			case mflow.ErrorHandling_ERROR_HANDLING_BREAK:
+				stop = true
+			default:
+				if loopError == nil && !runner.IsCancellationError(iterErr) {
+					loopError = iterErr
+					cancel()
+				}
+				stop = true
+			}
+			return
+		}
+		if nr.checkBreakCondition(ctx, &iterReq) {
+			stop = true
+		}
+	}
+
+	totalItems := 0
+	for key, item := range entries {
+		acquired := false
+		select {
+		case sem <- struct{}{}:
+			acquired = true
+		case <-loopCtx.Done():
+		}
+		mu.Lock()
+		stopped := stop
+		mu.Unlock()
+		if !acquired || stopped {
+			if acquired {
+				<-sem
+			}
+			break
+		}
+
+		wg.Add(1)
+		go runIteration(totalItems, key, item)
+		totalItems++
+	}
+	wg.Wait()
+
+	if loopError == nil && ctx.Err() != nil {
+		loopError = ctx.Err()
+	}
+	if loopError != nil {
+		if !runner.IsCancellationError(loopError) {
+			loopError = errors.Join(runner.ErrFlowCanceledByThrow, loopError)
+		}
+		return node.FlowNodeResult{Err: loopError}
+	}
+
+	if req.VariableTracker != nil {
+		err = node.WriteNodeVarWithTracking(req, nr.Name, "totalItems", totalItems, req.VariableTracker)
+	} else {
+		err = node.WriteNodeVar(req, nr.Name, "totalItems", totalItems)
+	}
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	return node.FlowNodeResult{NextNodeID: nextID}
+}
+
+// iterEntries turns the evaluated iteration path into key/item pairs: index
+// and element for sequences, key and value for maps.
+func iterEntries(result any) (iter.Seq2[any, any], error) {
+	switch seq := result.(type) {
+	case iter.Seq[any]:
+		return func(yield func(any, any) bool) {
+			index := 0
+			for item := range seq {
+				if !yield(index, item) {
+					return
+				}
+				index++
+			}
+		}, nil
+	case iter.Seq2[string, any]:
+		return func(yield func(any, any) bool) {
+			for key, value := range seq {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected iterator type: %T", result)
+	}
+}
+
+// loopBodyNames returns the names of the nodes reachable from the loop's
+// entry nodes, whose outputs an iteration hands back to the flow.
+func loopBodyNames(req *node.FlowNodeRequest, loopEdgeMap mflow.EdgesMap, loopTargets []idwrap.IDWrap) []string {
+	seen := make(map[idwrap.IDWrap]bool)
+	queue := append([]idwrap.IDWrap(nil), loopTargets...)
+	var names []string
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if n, ok := req.NodeMap[id]; ok {
+			names = append(names, n.GetName())
+		}
+		for _, targets := range loopEdgeMap[id] {
+			queue = append(queue, targets...)
+		}
+	}
+	return names
+}
+
+func (nr *NodeForEach) writeIterationVars(req *node.FlowNodeRequest, key, item any) error {
+	if req.VariableTracker != nil {
+		if err := node.WriteNodeVarWithTracking(req, nr.Name, "item", item, req.VariableTracker); err != nil {
+			return err
+		}
+		return node.WriteNodeVarWithTracking(req, nr.Name, "key", key, req.VariableTracker)
+	}
+	if err := node.WriteNodeVar(req, nr.Name, "item", item); err != nil {
+		return err
+	}
+	return node.WriteNodeVar(req, nr.Name, "key", key)
+}
+
+func (nr *NodeForEach) logIteration(req *node.FlowNodeRequest, executionID idwrap.IDWrap, index int,
+	state mflow.NodeState, data map[string]any, iterContext *runner.IterationContext, err error,
+) {
+	name := fmt.Sprintf("%s Iteration %d", nr.Name, index+1)
+	node.LogIteration(req, executionID, nr.FlowNodeID, name, index, state, data, iterContext, err)
+}
@@ -25,6 +25,9 @@ type NodeForEach struct {
 	Timeout       time.Duration
 	Condition     mcondition.Condition
 	ErrorHandling mflow.ErrorHandling
+	// Concurrency is how many iterations may run at once; see runConcurrent.
+	// Zero or one runs them one after another.
+	Concurrency int
 }
 
 func New(id idwrap.IDWrap, name string, iterPath string, timeout time.Duration,
@@ -121,6 +124,10 @@ func (nr *NodeForEach) checkBreakCondition(ctx context.Context, req *node.FlowNo
 }
 
 func (nr *NodeForEach) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	if nr.Concurrency > 1 {
+		return nr.runConcurrent(ctx, req, flowlocalrunner.RunNodeSync)
+	}
+
 	loopTargets := mflow.GetNextNodeID(req.EdgeSourceMap, nr.FlowNodeID, mflow.HandleLoop)
 	loopTargets = node.FilterLoopEntryNodes(req.EdgeSourceMap, loopTargets)
 	loopEdgeMap := node.BuildLoopExecutionEdgeMap(req.EdgeSourceMap, nr.FlowNodeID, loopTargets)
@@ -519,6 +526,11 @@ func (nr *NodeForEach) RunSync(ctx context.Context, req *node.FlowNodeRequest) n
 }
 
 func (nr *NodeForEach) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	if nr.Concurrency > 1 {
+		resultChan <- nr.runConcurrent(ctx, req, flowlocalrunner.RunNodeASync)
+		return
+	}
+
 	loopTargets := mflow.GetNextNodeID(req.EdgeSourceMap, nr.FlowNodeID, mflow.HandleLoop)
 	loopTargets = node.FilterLoopEntryNodes(req.EdgeSourceMap, loopTargets)
 	loopEdgeMap := node.BuildLoopExecutionEdgeMap(req.EdgeSourceMap, nr.FlowNodeID, loopTargets)
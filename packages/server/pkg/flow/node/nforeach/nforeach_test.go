@@ -5,6 +5,7 @@ import (
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -383,3 +384,119 @@ func TestNodeForEachBreakConditionToleratesUndefinedIdentifier(t *testing.T) {
 
 	require.Equal(t, 5, runs, "loop should iterate over all 5 items when break expression references an undefined identifier")
 }
+
+// itemRecordingNode reads the loop's current item, holds it for a moment and
+// records it, tracking how many iterations are in flight at once.
+type itemRecordingNode struct {
+	id       idwrap.IDWrap
+	name     string
+	mu       *sync.Mutex
+	seen     map[any]int
+	inFlight *int
+	maxSeen  *int
+	failOn   any
+}
+
+func (n itemRecordingNode) GetID() idwrap.IDWrap { return n.id }
+func (n itemRecordingNode) GetName() string      { return n.name }
+
+func (n itemRecordingNode) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	item, err := node.ReadNodeVar(req, "ForEachNode", "item")
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	n.mu.Lock()
+	*n.inFlight++
+	*n.maxSeen = max(*n.maxSeen, *n.inFlight)
+	n.mu.Unlock()
+
+	select {
+	case <-time.After(5 * time.Millisecond):
+	case <-ctx.Done():
+	}
+
+	n.mu.Lock()
+	*n.inFlight--
+	n.seen[item]++
+	n.mu.Unlock()
+
+	if n.failOn != nil && item == n.failOn {
+		return node.FlowNodeResult{Err: errors.New("item rejected")}
+	}
+	_ = node.WriteNodeVar(req, n.name, "item", item)
+	return node.FlowNodeResult{NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.id, mflow.HandleThen)}
+}
+
+func (n itemRecordingNode) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+func runConcurrentForEach(t *testing.T, child itemRecordingNode, items []any) (map[string]any, error) {
+	t.Helper()
+	loopID := idwrap.NewNow()
+	loop := New(loopID, "ForEachNode", "items", 0, mcondition.Condition{}, mflow.ErrorHandling_ERROR_HANDLING_UNSPECIFIED)
+	loop.Concurrency = 4
+
+	edgeMap := mflow.EdgesMap{
+		loopID: {mflow.HandleLoop: []idwrap.IDWrap{child.id}},
+	}
+	flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), []idwrap.IDWrap{loopID},
+		map[idwrap.IDWrap]node.FlowNode{loopID: loop, child.id: child}, edgeMap, 0, nil)
+
+	statusCh := make(chan runner.FlowNodeStatus, 1024)
+	flowCh := make(chan runner.FlowStatus, 4)
+	vars := map[string]any{"items": items}
+	err := flowRunner.Run(context.Background(), statusCh, flowCh, vars)
+	for range statusCh {
+	}
+	for range flowCh {
+	}
+	return vars, err
+}
+
+func newItemRecordingNode() itemRecordingNode {
+	return itemRecordingNode{
+		id:       idwrap.NewNow(),
+		name:     "child",
+		mu:       &sync.Mutex{},
+		seen:     make(map[any]int),
+		inFlight: new(int),
+		maxSeen:  new(int),
+	}
+}
+
+func TestNodeForEachConcurrencyLimitsIterationsInFlight(t *testing.T) {
+	child := newItemRecordingNode()
+	items := make([]any, 20)
+	for i := range items {
+		items[i] = float64(i)
+	}
+
+	vars, err := runConcurrentForEach(t, child, items)
+	require.NoError(t, err)
+
+	require.Len(t, child.seen, 20, "every iteration sees its own item")
+	for _, item := range items {
+		require.Equal(t, 1, child.seen[item])
+	}
+	require.LessOrEqual(t, *child.maxSeen, 4)
+	require.Greater(t, *child.maxSeen, 1, "iterations overlap")
+
+	loopVars := vars["ForEachNode"].(map[string]any)
+	require.Equal(t, 20, loopVars["totalItems"])
+	require.Contains(t, vars, "child", "body outputs are handed back to the flow")
+}
+
+func TestNodeForEachConcurrencyFailsOnIterationError(t *testing.T) {
+	child := newItemRecordingNode()
+	child.failOn = float64(2)
+	items := make([]any, 50)
+	for i := range items {
+		items[i] = float64(i)
+	}
+
+	_, err := runConcurrentForEach(t, child, items)
+	require.ErrorContains(t, err, "item rejected")
+	require.Less(t, len(child.seen), 50, "no new iterations start after the failure")
+}
@@ -2,18 +2,17 @@
 package ngraphql
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
-	"time"
 
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
 	graphqlresponse "github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/response"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/schema"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/transport"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/httpclient"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
@@ -30,6 +29,10 @@ type NodeGraphQL struct {
 	HttpClient httpclient.HttpClient
 	SideRespChan chan NodeGraphQLSideResp
 	logger       *slog.Logger
+
+	// Schema, when set, is the request's introspected schema. The query and
+	// variables are validated against it before anything is sent.
+	Schema *schema.Schema
 }
 
 type NodeGraphQLSideResp struct {
@@ -47,11 +50,6 @@ const (
 	outputRequestName  = "request"
 )
 
-type graphqlRequestBody struct {
-	Query     string          `json:"query"`
-	Variables json.RawMessage `json:"variables,omitempty"`
-}
-
 func New(
 	id idwrap.IDWrap,
 	name string,
@@ -137,38 +135,54 @@ func (n *NodeGraphQL) RunSync(ctx context.Context, req *node.FlowNodeRequest) no
 		return result
 	}
 
-	// Build request body
-	var varsJSON json.RawMessage
-	if variables != "" {
-		// Try to parse as JSON; if invalid, use as string
-		if json.Valid([]byte(variables)) {
-			varsJSON = json.RawMessage(variables)
-		} else {
-			// Wrap as JSON string
-			b, _ := json.Marshal(variables)
-			varsJSON = b
+	ops := []transport.Operation{{
+		Query:         query,
+		Variables:     variablesJSON(variables),
+		OperationName: n.GraphQL.OperationName,
+	}}
+	for i, b := range n.GraphQL.Batch {
+		batchQuery, err := interpolate(b.Query)
+		if err != nil {
+			result.Err = fmt.Errorf("failed to interpolate batch query %d: %w", i+1, err)
+			return result
 		}
+		batchVariables, err := interpolate(b.Variables)
+		if err != nil {
+			result.Err = fmt.Errorf("failed to interpolate batch variables %d: %w", i+1, err)
+			return result
+		}
+		ops = append(ops, transport.Operation{
+			Query:         batchQuery,
+			Variables:     variablesJSON(batchVariables),
+			OperationName: b.OperationName,
+		})
 	}
 
-	body := graphqlRequestBody{
-		Query:     query,
-		Variables: varsJSON,
-	}
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		result.Err = fmt.Errorf("failed to marshal graphql request body: %w", err)
-		return result
+	if n.Schema != nil {
+		for i, op := range ops {
+			if err := n.Schema.Validate(op.Query, op.OperationName, op.Variables); err != nil {
+				if len(ops) > 1 {
+					result.Err = fmt.Errorf("graphql validation failed for operation %d: %w", i, err)
+				} else {
+					result.Err = fmt.Errorf("graphql validation failed: %w", err)
+				}
+				return result
+			}
+		}
 	}
 
-	// Build HTTP request
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
-	if err != nil {
-		result.Err = fmt.Errorf("failed to create graphql http request: %w", err)
-		return result
+	uploads := make([]transport.Upload, 0, len(n.GraphQL.Uploads))
+	for _, u := range n.GraphQL.Uploads {
+		file, err := interpolate(u.File)
+		if err != nil {
+			result.Err = fmt.Errorf("failed to interpolate upload file: %w", err)
+			return result
+		}
+		uploads = append(uploads, transport.Upload{Path: u.Path, File: file})
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
 	// Apply headers with tracking
+	header := make(http.Header)
 	for _, h := range n.Headers {
 		if h.Enabled && h.Key != "" {
 			key, err := interpolate(h.Key)
@@ -181,7 +195,7 @@ func (n *NodeGraphQL) RunSync(ctx context.Context, req *node.FlowNodeRequest) no
 				result.Err = fmt.Errorf("failed to interpolate header value: %w", err)
 				return result
 			}
-			httpReq.Header.Set(key, value)
+			header.Set(key, value)
 		}
 	}
 
@@ -197,21 +211,19 @@ func (n *NodeGraphQL) RunSync(ctx context.Context, req *node.FlowNodeRequest) no
 	}
 
 	// Execute request
-	startTime := time.Now()
-	httpResp, err := n.HttpClient.Do(httpReq)
-	duration := time.Since(startTime)
-	if err != nil {
-		result.Err = fmt.Errorf("graphql request failed: %w", err)
-		return result
-	}
-	defer func() { _ = httpResp.Body.Close() }()
-
-	// Read response body
-	respBody, err := io.ReadAll(httpResp.Body)
+	gqlResp, err := transport.Do(ctx, n.HttpClient, transport.Request{
+		URL:            url,
+		Header:         header,
+		Operations:     ops,
+		PersistedQuery: n.GraphQL.PersistedQuery,
+		Uploads:        uploads,
+	})
 	if err != nil {
-		result.Err = fmt.Errorf("failed to read graphql response body: %w", err)
+		result.Err = err
 		return result
 	}
+	respBody := gqlResp.Body
+	duration := gqlResp.Duration
 
 	if ctx.Err() != nil {
 		return result
@@ -219,7 +231,7 @@ func (n *NodeGraphQL) RunSync(ctx context.Context, req *node.FlowNodeRequest) no
 
 	// Build response headers
 	respHeaderModels := make([]mgraphql.GraphQLResponseHeader, 0)
-	for key, values := range httpResp.Header {
+	for key, values := range gqlResp.Header {
 		for _, value := range values {
 			respHeaderModels = append(respHeaderModels, mgraphql.GraphQLResponseHeader{
 				ID:          idwrap.NewNow(),
@@ -244,7 +256,7 @@ func (n *NodeGraphQL) RunSync(ctx context.Context, req *node.FlowNodeRequest) no
 	}
 
 	respHeaders := make(map[string]any)
-	for key, values := range httpResp.Header {
+	for key, values := range gqlResp.Header {
 		if len(values) == 1 {
 			respHeaders[key] = values[0]
 		} else {
@@ -256,6 +268,25 @@ func (n *NodeGraphQL) RunSync(ctx context.Context, req *node.FlowNodeRequest) no
 		}
 	}
 
+	responseOutput := map[string]any{
+		"status":   float64(gqlResp.Status),
+		"body":     respBodyParsed,
+		"headers":  respHeaders,
+		"duration": float64(duration.Milliseconds()),
+	}
+	// Batched operations get one result each, in request order
+	if len(ops) > 1 {
+		results := make([]any, len(gqlResp.Results))
+		for i, raw := range gqlResp.Results {
+			var parsed any
+			if err := json.Unmarshal(raw, &parsed); err != nil {
+				parsed = string(raw)
+			}
+			results[i] = parsed
+		}
+		responseOutput["results"] = results
+	}
+
 	outputMap := map[string]any{
 		outputRequestName: map[string]any{
 			"url":       url,
@@ -263,12 +294,7 @@ func (n *NodeGraphQL) RunSync(ctx context.Context, req *node.FlowNodeRequest) no
 			"variables": variables,
 			"headers":   requestHeaders,
 		},
-		outputResponseName: map[string]any{
-			"status":   float64(httpResp.StatusCode),
-			"body":     respBodyParsed,
-			"headers":  respHeaders,
-			"duration": float64(duration.Milliseconds()),
-		},
+		outputResponseName: responseOutput,
 	}
 
 	// Use tracking version if tracker is available (same pattern as HTTP REQUEST nodes)
@@ -288,7 +314,7 @@ func (n *NodeGraphQL) RunSync(ctx context.Context, req *node.FlowNodeRequest) no
 	respCreate, err := graphqlresponse.ResponseCreateGraphQL(
 		ctx,
 		respBody,
-		httpResp.StatusCode,
+		gqlResp.Status,
 		duration,
 		respHeaderModels,
 		n.GraphQL.ID,
@@ -306,7 +332,7 @@ func (n *NodeGraphQL) RunSync(ctx context.Context, req *node.FlowNodeRequest) no
 	done := make(chan struct{})
 	for _, assertRes := range respCreate.ResponseAsserts {
 		if !assertRes.Success {
-			result.Err = fmt.Errorf("assertion failed: %s", assertRes.Value)
+			result.Err = fmt.Errorf("%w: %s", node.ErrAssertionFailed, assertRes.Value)
 
 			// Still send the response data even though we're failing
 			n.SideRespChan <- NodeGraphQLSideResp{
@@ -356,6 +382,12 @@ func (n *NodeGraphQL) RunAsync(ctx context.Context, req *node.FlowNodeRequest, r
 func (n *NodeGraphQL) GetRequiredVariables() []string {
 	var sources []string
 	sources = append(sources, n.GraphQL.Url, n.GraphQL.Query, n.GraphQL.Variables)
+	for _, b := range n.GraphQL.Batch {
+		sources = append(sources, b.Query, b.Variables)
+	}
+	for _, u := range n.GraphQL.Uploads {
+		sources = append(sources, u.File)
+	}
 	for _, h := range n.Headers {
 		if h.Enabled {
 			sources = append(sources, h.Key, h.Value)
@@ -366,7 +398,7 @@ func (n *NodeGraphQL) GetRequiredVariables() []string {
 
 // GetOutputVariables implements node.VariableIntrospector.
 func (n *NodeGraphQL) GetOutputVariables() []string {
-	return []string{
+	outputs := []string{
 		"response.status",
 		"response.body",
 		"response.headers",
@@ -376,4 +408,21 @@ func (n *NodeGraphQL) GetOutputVariables() []string {
 		"request.variables",
 		"request.headers",
 	}
+	if len(n.GraphQL.Batch) > 0 {
+		outputs = append(outputs, "response.results")
+	}
+	return outputs
+}
+
+// variablesJSON encodes interpolated variables for the request body. Text
+// that is not JSON is sent as a JSON string.
+func variablesJSON(variables string) json.RawMessage {
+	if variables == "" {
+		return nil
+	}
+	if json.Valid([]byte(variables)) {
+		return json.RawMessage(variables)
+	}
+	b, _ := json.Marshal(variables)
+	return b
 }
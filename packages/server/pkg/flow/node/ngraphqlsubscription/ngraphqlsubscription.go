@@ -0,0 +1,405 @@
+//nolint:revive // exported
+package ngraphqlsubscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/subscription"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mgraphql"
+)
+
+// Compile-time check that NodeGraphQLSubscription implements VariableIntrospector.
+var _ node.VariableIntrospector = (*NodeGraphQLSubscription)(nil)
+
+// NodeGraphQLSubscription is a listener entry node that runs a GraphQL
+// subscription and dispatches a HandleWsMessage chain for every event. Unlike
+// a WebSocket connection node it holds the flow until the server completes
+// the subscription, MaxEvents events arrived or Timeout passed, then
+// continues on its unspecified handle.
+type NodeGraphQLSubscription struct {
+	FlowNodeID idwrap.IDWrap
+	Name       string
+
+	GraphQL mgraphql.GraphQL
+	Headers []mgraphql.GraphQLHeader
+	// ConnectionPayload is a JSON object template sent with connection_init.
+	// Empty sends the enabled headers instead.
+	ConnectionPayload string
+	MaxEvents         int32
+	Timeout           time.Duration
+	HTTPClient        *http.Client // shared client with cookie jar for upgrade handshake
+}
+
+func New(
+	id idwrap.IDWrap,
+	name string,
+	gql mgraphql.GraphQL,
+	headers []mgraphql.GraphQLHeader,
+	cfg mflow.NodeGraphQLSubscription,
+	httpClient *http.Client,
+) *NodeGraphQLSubscription {
+	return &NodeGraphQLSubscription{
+		FlowNodeID:        id,
+		Name:              name,
+		GraphQL:           gql,
+		Headers:           headers,
+		ConnectionPayload: cfg.ConnectionPayload,
+		MaxEvents:         cfg.MaxEvents,
+		Timeout:           time.Duration(cfg.TimeoutMs) * time.Millisecond,
+		HTTPClient:        httpClient,
+	}
+}
+
+func (n *NodeGraphQLSubscription) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeGraphQLSubscription) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeGraphQLSubscription) GetName() string {
+	return n.Name
+}
+
+// IsEntryNode marks this as a valid flow entry point (no incoming edges).
+func (n *NodeGraphQLSubscription) IsEntryNode() bool {
+	return true
+}
+
+// IsLoopCoordinator prevents the runner from applying per-node timeout.
+func (n *NodeGraphQLSubscription) IsLoopCoordinator() bool {
+	return true
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeGraphQLSubscription) GetRequiredVariables() []string {
+	sources := []string{n.GraphQL.Url, n.GraphQL.Query, n.GraphQL.Variables, n.ConnectionPayload}
+	for _, h := range n.Headers {
+		if h.Enabled {
+			sources = append(sources, h.Key, h.Value)
+		}
+	}
+	return expression.ExtractVarKeysFromMultiple(sources...)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeGraphQLSubscription) GetOutputVariables() []string {
+	return []string{
+		"request.url",
+		"request.query",
+		"request.variables",
+		"request.headers",
+		"payload",
+		"data",
+		"errors",
+		"index",
+		"events",
+		"completed",
+		"protocol",
+	}
+}
+
+func (n *NodeGraphQLSubscription) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	nextID := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified)
+
+	subReq, requestOutput, err := n.prepare(req)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	if err := n.writeVar(req, "request", requestOutput); err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("write request var: %w", err)}
+	}
+
+	subCtx := ctx
+	if n.Timeout > 0 {
+		var cancel context.CancelFunc
+		subCtx, cancel = context.WithTimeout(ctx, n.Timeout)
+		defer cancel()
+	}
+
+	d := newDispatcher(n, req)
+	res, err := subscription.Subscribe(subCtx, subReq, func(ev subscription.Event) error {
+		d.dispatch(ctx, ev)
+		if n.MaxEvents > 0 && ev.Index+1 >= int(n.MaxEvents) {
+			return subscription.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return node.FlowNodeResult{Err: ctx.Err()}
+		}
+		// Running out of time is a normal way for a subscription to end.
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return node.FlowNodeResult{Err: fmt.Errorf("graphql subscription: %w", err)}
+		}
+	}
+
+	summary := map[string]any{
+		"events":    res.Events,
+		"completed": res.Completed,
+		"protocol":  res.Protocol,
+	}
+	if req.VariableTracker != nil {
+		err = node.WriteNodeVarBulkWithTracking(req, n.Name, summary, req.VariableTracker)
+	} else {
+		err = node.WriteNodeVarBulk(req, n.Name, summary)
+	}
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	return node.FlowNodeResult{NextNodeID: nextID}
+}
+
+func (n *NodeGraphQLSubscription) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+// prepare interpolates the request and returns it along with the request
+// output variable.
+func (n *NodeGraphQLSubscription) prepare(req *node.FlowNodeRequest) (subscription.Request, map[string]any, error) {
+	env := expression.NewUnifiedEnv(node.DeepCopyVarMap(req))
+	readVars := make(map[string]any)
+	interpolate := func(raw string) (string, error) {
+		if !expression.HasVars(raw) {
+			return raw, nil
+		}
+		result, err := env.InterpolateWithResult(raw)
+		if err != nil {
+			return "", err
+		}
+		for k, v := range result.ReadVars {
+			readVars[k] = v
+		}
+		return result.Value, nil
+	}
+
+	var subReq subscription.Request
+	var err error
+	if subReq.URL, err = interpolate(n.GraphQL.Url); err != nil {
+		return subReq, nil, fmt.Errorf("failed to interpolate url: %w", err)
+	}
+	if subReq.Query, err = interpolate(n.GraphQL.Query); err != nil {
+		return subReq, nil, fmt.Errorf("failed to interpolate query: %w", err)
+	}
+	subReq.OperationName = n.GraphQL.OperationName
+	variables, err := interpolate(n.GraphQL.Variables)
+	if err != nil {
+		return subReq, nil, fmt.Errorf("failed to interpolate variables: %w", err)
+	}
+	if subReq.Variables, err = decodeObject(variables); err != nil {
+		return subReq, nil, fmt.Errorf("invalid variables: %w", err)
+	}
+
+	subReq.Headers = http.Header{}
+	headers := make(map[string]any)
+	for _, h := range n.Headers {
+		if !h.Enabled || h.Key == "" {
+			continue
+		}
+		key, err := interpolate(h.Key)
+		if err != nil {
+			return subReq, nil, fmt.Errorf("failed to interpolate header key: %w", err)
+		}
+		value, err := interpolate(h.Value)
+		if err != nil {
+			return subReq, nil, fmt.Errorf("failed to interpolate header value: %w", err)
+		}
+		subReq.Headers.Set(key, value)
+		headers[key] = value
+	}
+
+	if n.ConnectionPayload == "" {
+		// Servers commonly read auth from connection_init rather than the
+		// upgrade request, so send the headers there too.
+		if len(headers) > 0 {
+			subReq.ConnectionPayload = headers
+		}
+	} else {
+		payload, err := interpolate(n.ConnectionPayload)
+		if err != nil {
+			return subReq, nil, fmt.Errorf("failed to interpolate connection payload: %w", err)
+		}
+		if subReq.ConnectionPayload, err = decodeObject(payload); err != nil {
+			return subReq, nil, fmt.Errorf("invalid connection payload: %w", err)
+		}
+	}
+	subReq.HTTPClient = n.HTTPClient
+
+	if req.VariableTracker != nil {
+		for varKey, varValue := range readVars {
+			req.VariableTracker.TrackRead(varKey, varValue)
+		}
+	}
+
+	return subReq, map[string]any{
+		"url":       subReq.URL,
+		"query":     subReq.Query,
+		"variables": variables,
+		"headers":   headers,
+	}, nil
+}
+
+func (n *NodeGraphQLSubscription) writeVar(req *node.FlowNodeRequest, key string, v any) error {
+	if req.VariableTracker != nil {
+		return node.WriteNodeVarWithTracking(req, n.Name, key, v, req.VariableTracker)
+	}
+	return node.WriteNodeVar(req, n.Name, key, v)
+}
+
+// dispatcher runs the HandleWsMessage chain of a node once per event.
+type dispatcher struct {
+	n   *NodeGraphQLSubscription
+	req *node.FlowNodeRequest
+
+	targets         []idwrap.IDWrap
+	edgeMap         mflow.EdgesMap
+	predecessorMap  map[idwrap.IDWrap][]idwrap.IDWrap
+	pendingTemplate map[idwrap.IDWrap]uint32
+}
+
+func newDispatcher(n *NodeGraphQLSubscription, req *node.FlowNodeRequest) *dispatcher {
+	d := &dispatcher{n: n, req: req}
+	targets := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleWsMessage)
+	if targets == nil {
+		return d
+	}
+	d.targets = node.FilterLoopEntryNodes(req.EdgeSourceMap, targets)
+	d.edgeMap = node.BuildHandleExecutionEdgeMap(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleWsMessage, d.targets)
+	d.predecessorMap = flowlocalrunner.BuildPredecessorMap(d.edgeMap)
+	d.pendingTemplate = node.BuildPendingMap(d.predecessorMap)
+	return d
+}
+
+// dispatch exposes the event as node variables and runs the event chain.
+// A failing chain is reported on the event and does not end the
+// subscription, matching WebSocket connection nodes.
+func (d *dispatcher) dispatch(ctx context.Context, ev subscription.Event) {
+	n, req := d.n, d.req
+
+	var payload map[string]any
+	if err := json.Unmarshal(ev.Payload, &payload); err != nil {
+		payload = map[string]any{"data": string(ev.Payload)}
+	}
+	_ = node.WriteNodeVar(req, n.Name, "payload", payload)
+	_ = node.WriteNodeVar(req, n.Name, "data", payload["data"])
+	_ = node.WriteNodeVar(req, n.Name, "errors", payload["errors"])
+	_ = node.WriteNodeVar(req, n.Name, "index", ev.Index)
+
+	output := map[string]any{"index": ev.Index, "payload": payload}
+	executionID := idwrap.NewMonotonic()
+	executionName := fmt.Sprintf("%s Event %d", n.Name, ev.Index+1)
+
+	if len(d.targets) == 0 {
+		if req.LogPushFunc != nil {
+			req.LogPushFunc(runner.FlowNodeStatus{
+				ExecutionID:    executionID,
+				NodeID:         n.FlowNodeID,
+				Name:           executionName,
+				State:          mflow.NODE_STATE_SUCCESS,
+				OutputData:     output,
+				IterationEvent: true,
+				IterationIndex: ev.Index,
+				LoopNodeID:     n.FlowNodeID,
+			})
+		}
+		return
+	}
+
+	var parentPath []int
+	var parentNodes []idwrap.IDWrap
+	var parentLabels []runner.IterationLabel
+	if req.IterationContext != nil {
+		parentPath = req.IterationContext.IterationPath
+		parentNodes = req.IterationContext.ParentNodes
+		parentLabels = node.CloneIterationLabels(req.IterationContext.Labels)
+	}
+	labels := make([]runner.IterationLabel, len(parentLabels), len(parentLabels)+1)
+	copy(labels, parentLabels)
+	labels = append(labels, runner.IterationLabel{
+		NodeID:    n.FlowNodeID,
+		Name:      n.Name,
+		Iteration: ev.Index + 1,
+	})
+	iterContext := &runner.IterationContext{
+		IterationPath: append(append([]int(nil), parentPath...), ev.Index),
+		ParentNodes:   append(append([]idwrap.IDWrap(nil), parentNodes...), n.FlowNodeID),
+		Labels:        labels,
+	}
+
+	if req.LogPushFunc != nil {
+		req.LogPushFunc(runner.FlowNodeStatus{
+			ExecutionID:      executionID,
+			NodeID:           n.FlowNodeID,
+			Name:             executionName,
+			State:            mflow.NODE_STATE_RUNNING,
+			OutputData:       output,
+			IterationEvent:   true,
+			IterationIndex:   ev.Index,
+			LoopNodeID:       n.FlowNodeID,
+			IterationContext: iterContext,
+		})
+	}
+
+	var iterErr error
+	for _, targetID := range d.targets {
+		childReq := *req
+		childReq.EdgeSourceMap = d.edgeMap
+		childReq.PendingAtmoicMap = node.ClonePendingMap(d.pendingTemplate)
+		childReq.IterationContext = &runner.IterationContext{
+			IterationPath:  append([]int(nil), iterContext.IterationPath...),
+			ExecutionIndex: ev.Index,
+			ParentNodes:    append([]idwrap.IDWrap(nil), iterContext.ParentNodes...),
+			Labels:         node.CloneIterationLabels(iterContext.Labels),
+		}
+		childReq.ExecutionID = idwrap.NewMonotonic()
+
+		if err := flowlocalrunner.RunNodeSync(ctx, targetID, &childReq, req.LogPushFunc, d.predecessorMap); err != nil {
+			iterErr = err
+			break
+		}
+	}
+
+	if req.LogPushFunc != nil {
+		state := mflow.NODE_STATE_SUCCESS
+		if iterErr != nil {
+			state = mflow.NODE_STATE_FAILURE
+		}
+		req.LogPushFunc(runner.FlowNodeStatus{
+			ExecutionID:      executionID,
+			NodeID:           n.FlowNodeID,
+			Name:             executionName,
+			State:            state,
+			Error:            iterErr,
+			OutputData:       output,
+			IterationEvent:   true,
+			IterationIndex:   ev.Index,
+			LoopNodeID:       n.FlowNodeID,
+			IterationContext: iterContext,
+		})
+	}
+}
+
+// decodeObject parses a JSON object, treating an empty string as no object.
+func decodeObject(raw string) (map[string]any, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(raw), &obj); err != nil {
+		return nil, fmt.Errorf("expected a JSON object: %w", err)
+	}
+	return obj, nil
+}
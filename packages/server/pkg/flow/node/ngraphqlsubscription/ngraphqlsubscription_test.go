@@ -0,0 +1,193 @@
+package ngraphqlsubscription
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mgraphql"
+)
+
+// tickServer speaks graphql-transport-ws and pushes count ticks before
+// completing. It reports the connection_init payload it received.
+func tickServer(t *testing.T, count int) (*httptest.Server, chan map[string]any) {
+	t.Helper()
+	payloads := make(chan map[string]any, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{"graphql-transport-ws"}})
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow() //nolint:errcheck // test server
+		ctx := r.Context()
+
+		read := func() map[string]any {
+			_, data, err := conn.Read(ctx)
+			if err != nil {
+				return nil
+			}
+			var msg map[string]any
+			_ = json.Unmarshal(data, &msg)
+			return msg
+		}
+		send := func(msg string) { _ = conn.Write(ctx, websocket.MessageText, []byte(msg)) }
+
+		init := read()
+		payload, _ := init["payload"].(map[string]any)
+		payloads <- payload
+		send(`{"type":"connection_ack"}`)
+		sub := read()
+		id, _ := sub["id"].(string)
+		for i := range count {
+			send(fmt.Sprintf(`{"id":%q,"type":"next","payload":{"data":{"tick":%d}}}`, id, i))
+		}
+		send(fmt.Sprintf(`{"id":%q,"type":"complete"}`, id))
+		read()
+	}))
+	t.Cleanup(srv.Close)
+	return srv, payloads
+}
+
+// recordNode records the ticks its subscription node exposed when it ran.
+type recordNode struct {
+	id     idwrap.IDWrap
+	source string
+	mu     sync.Mutex
+	ticks  []float64
+}
+
+func (r *recordNode) GetID() idwrap.IDWrap { return r.id }
+func (r *recordNode) GetName() string      { return "Record" }
+
+func (r *recordNode) RunSync(_ context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	data, err := node.ReadNodeVar(req, r.source, "data")
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ticks = append(r.ticks, data.(map[string]any)["tick"].(float64))
+	return node.FlowNodeResult{}
+}
+
+func (r *recordNode) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- r.RunSync(ctx, req)
+}
+
+func newReq(edgeMap mflow.EdgesMap, nodeMap map[idwrap.IDWrap]node.FlowNode) *node.FlowNodeRequest {
+	return &node.FlowNodeRequest{
+		VarMap:           make(map[string]any),
+		ReadWriteLock:    &sync.RWMutex{},
+		NodeMap:          nodeMap,
+		EdgeSourceMap:    edgeMap,
+		Timeout:          10 * time.Second,
+		PendingAtmoicMap: make(map[idwrap.IDWrap]uint32),
+		PendingMapMu:     &sync.Mutex{},
+	}
+}
+
+func TestNodeGraphQLSubscription_DispatchesEventChain(t *testing.T) {
+	srv, payloads := tickServer(t, 3)
+
+	subID, recordID, nextID := idwrap.NewNow(), idwrap.NewNow(), idwrap.NewNow()
+	record := &recordNode{id: recordID, source: "Ticks"}
+	edges := mflow.NewEdgesMap([]mflow.Edge{
+		mflow.NewEdge(idwrap.NewNow(), subID, recordID, mflow.HandleWsMessage),
+		mflow.NewEdge(idwrap.NewNow(), subID, nextID, mflow.HandleUnspecified),
+	})
+
+	n := New(subID, "Ticks", mgraphql.GraphQL{
+		Url:   "{{ baseUrl }}/graphql",
+		Query: "subscription { tick }",
+	}, []mgraphql.GraphQLHeader{
+		{Key: "Authorization", Value: "Bearer {{ token }}", Enabled: true},
+		{Key: "X-Disabled", Value: "1", Enabled: false},
+	}, mflow.NodeGraphQLSubscription{}, nil)
+
+	req := newReq(edges, map[idwrap.IDWrap]node.FlowNode{recordID: record})
+	req.VarMap["baseUrl"] = srv.URL
+	req.VarMap["token"] = "abc"
+	var statuses []runner.FlowNodeStatus
+	req.LogPushFunc = func(s runner.FlowNodeStatus) { statuses = append(statuses, s) }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result := n.RunSync(ctx, req)
+	if result.Err != nil {
+		t.Fatalf("RunSync error: %v", result.Err)
+	}
+	if len(result.NextNodeID) != 1 || result.NextNodeID[0] != nextID {
+		t.Errorf("expected to continue to the next node, got %v", result.NextNodeID)
+	}
+
+	if len(record.ticks) != 3 || record.ticks[2] != 2 {
+		t.Errorf("expected the chain to run for every event, got %v", record.ticks)
+	}
+	if got := <-payloads; got["Authorization"] != "Bearer abc" || len(got) != 1 {
+		t.Errorf("expected enabled headers as connection payload, got %v", got)
+	}
+	var eventStatuses int
+	for _, s := range statuses {
+		if s.NodeID == subID && s.IterationEvent && s.State == mflow.NODE_STATE_SUCCESS {
+			eventStatuses++
+		}
+	}
+	if eventStatuses != 3 {
+		t.Errorf("expected a finished status per event, got %d", eventStatuses)
+	}
+	if events, _ := node.ReadNodeVar(req, "Ticks", "events"); events != 3 {
+		t.Errorf("events = %v, want 3", events)
+	}
+	if completed, _ := node.ReadNodeVar(req, "Ticks", "completed"); completed != true {
+		t.Errorf("completed = %v, want true", completed)
+	}
+}
+
+func TestNodeGraphQLSubscription_MaxEventsAndPayload(t *testing.T) {
+	srv, payloads := tickServer(t, 10)
+
+	n := New(idwrap.NewNow(), "Ticks", mgraphql.GraphQL{
+		Url:   srv.URL,
+		Query: "subscription { tick }",
+	}, nil, mflow.NodeGraphQLSubscription{
+		ConnectionPayload: `{"authToken": "{{ token }}"}`,
+		MaxEvents:         2,
+	}, nil)
+
+	req := newReq(mflow.EdgesMap{}, nil)
+	req.VarMap["token"] = "abc"
+
+	result := n.RunSync(context.Background(), req)
+	if result.Err != nil {
+		t.Fatalf("RunSync error: %v", result.Err)
+	}
+	if events, _ := node.ReadNodeVar(req, "Ticks", "events"); events != 2 {
+		t.Errorf("events = %v, want 2", events)
+	}
+	if completed, _ := node.ReadNodeVar(req, "Ticks", "completed"); completed != false {
+		t.Errorf("completed = %v, want false", completed)
+	}
+	if got := <-payloads; got["authToken"] != "abc" {
+		t.Errorf("expected interpolated connection payload, got %v", got)
+	}
+}
+
+func TestNodeGraphQLSubscription_InvalidPayload(t *testing.T) {
+	n := New(idwrap.NewNow(), "Ticks", mgraphql.GraphQL{Url: "ws://127.0.0.1:1"}, nil,
+		mflow.NodeGraphQLSubscription{ConnectionPayload: "not json"}, nil)
+
+	if result := n.RunSync(context.Background(), newReq(mflow.EdgesMap{}, nil)); result.Err == nil {
+		t.Fatal("expected an error for a connection payload that is not a JSON object")
+	}
+}
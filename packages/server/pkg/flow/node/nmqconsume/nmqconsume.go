@@ -0,0 +1,364 @@
+//nolint:revive // exported
+package nmqconsume
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mq"
+)
+
+// ErrNoBroker is returned when the node has no message broker credential or
+// the credential has an empty URL.
+var ErrNoBroker = errors.New("message queue consumer has no broker url")
+
+// ErrConsumeTimeout is returned when fewer than Count matching messages
+// arrived before the timeout.
+var ErrConsumeTimeout = errors.New("timed out waiting for messages")
+
+const (
+	// DefaultTimeoutMs is how long to wait for messages when no timeout is set.
+	DefaultTimeoutMs = 30000
+	// DefaultCount is how many matching messages to wait for when none is set.
+	DefaultCount = 1
+)
+
+// Compile-time check that NodeMqConsume implements VariableIntrospector.
+var _ node.VariableIntrospector = (*NodeMqConsume)(nil)
+
+// NodeMqConsume is a listener entry node that subscribes to Topic on the
+// broker BrokerURL points at as soon as the flow starts, so it sees messages
+// that nodes running alongside it cause to be published. Each message
+// matching Filter runs the node's HandleWsMessage chain. Once Count messages
+// have matched the node asserts on them and continues on its unspecified
+// handle; if the timeout passes first it fails with ErrConsumeTimeout.
+type NodeMqConsume struct {
+	FlowNodeID idwrap.IDWrap
+	Name       string
+	BrokerURL  string
+	Topic      string
+	Group      string
+	Filter     string
+	TimeoutMs  int64
+	Count      int32
+	Assertions []string
+}
+
+func New(id idwrap.IDWrap, name, brokerURL string, cfg mflow.NodeMqConsume) *NodeMqConsume {
+	return &NodeMqConsume{
+		FlowNodeID: id,
+		Name:       name,
+		BrokerURL:  brokerURL,
+		Topic:      cfg.Topic,
+		Group:      cfg.Group,
+		Filter:     cfg.Filter,
+		TimeoutMs:  cfg.TimeoutMs,
+		Count:      cfg.Count,
+		Assertions: cfg.Assertions,
+	}
+}
+
+func (n *NodeMqConsume) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeMqConsume) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeMqConsume) GetName() string {
+	return n.Name
+}
+
+// IsEntryNode marks this as a valid flow entry point (no incoming edges).
+func (n *NodeMqConsume) IsEntryNode() bool {
+	return true
+}
+
+// IsLoopCoordinator prevents the runner from applying per-node timeout.
+func (n *NodeMqConsume) IsLoopCoordinator() bool {
+	return true
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeMqConsume) GetRequiredVariables() []string {
+	return expression.ExtractVarKeysFromMultiple(n.BrokerURL, n.Topic, n.Group)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeMqConsume) GetOutputVariables() []string {
+	return []string{
+		"message",
+		"messages",
+		"index",
+		"count",
+		"duration",
+	}
+}
+
+func (n *NodeMqConsume) timeout() time.Duration {
+	if n.TimeoutMs > 0 {
+		return time.Duration(n.TimeoutMs) * time.Millisecond
+	}
+	return DefaultTimeoutMs * time.Millisecond
+}
+
+func (n *NodeMqConsume) count() int {
+	if n.Count > 0 {
+		return int(n.Count)
+	}
+	return DefaultCount
+}
+
+func (n *NodeMqConsume) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	if n.BrokerURL == "" {
+		return node.FlowNodeResult{Err: ErrNoBroker}
+	}
+
+	varMapCopy := node.DeepCopyVarMap(req)
+	env := expression.NewUnifiedEnv(varMapCopy)
+	brokerURL, err := env.InterpolateCtx(ctx, n.BrokerURL)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate broker url: %w", err)}
+	}
+	topic, err := env.InterpolateCtx(ctx, n.Topic)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate topic: %w", err)}
+	}
+	group, err := env.InterpolateCtx(ctx, n.Group)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate group: %w", err)}
+	}
+
+	start := time.Now()
+	waitCtx, cancel := context.WithTimeout(ctx, n.timeout())
+	defer cancel()
+
+	client, err := mq.Dial(waitCtx, brokerURL)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	defer client.Close()
+
+	d := newDispatcher(n, req)
+	var matched []any
+	err = client.Subscribe(waitCtx, mq.Subscription{Topic: topic, Group: group}, func(m mq.Message) error {
+		output := Output(m)
+		if !n.matches(ctx, env, output) {
+			return nil
+		}
+		d.dispatch(ctx, len(matched), output)
+		matched = append(matched, output)
+		if len(matched) >= n.count() {
+			return mq.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		what := fmt.Sprintf("%d of %d messages", len(matched), n.count())
+		return node.FlowNodeResult{Err: node.WaitError(ctx, ErrConsumeTimeout, what, topic, n.timeout(), err)}
+	}
+
+	outputs := map[string]any{
+		"messages": matched,
+		"message":  matched[0],
+		"count":    len(matched),
+		"duration": time.Since(start).Milliseconds(),
+	}
+	if err := node.WriteNodeOutputs(req, n.Name, outputs); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	if err := node.AssertOutputs(ctx, n.Name, varMapCopy, outputs, n.Assertions); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified),
+	}
+}
+
+func (n *NodeMqConsume) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+// Output is a message as exposed to the filter, the message chain and the
+// node outputs: its text, parsed when it holds JSON, with the broker's
+// metadata.
+func Output(m mq.Message) map[string]any {
+	headers := make(map[string]any, len(m.Headers))
+	for k, v := range m.Headers {
+		headers[k] = v
+	}
+	var timestamp int64
+	if !m.Time.IsZero() {
+		timestamp = m.Time.UnixMilli()
+	}
+	return map[string]any{
+		"topic":   m.Topic,
+		"key":     m.Key,
+		"id":      m.ID,
+		"headers": headers,
+		"text":    string(m.Payload),
+		"json":    decodeJSON(m.Payload),
+		"size":    len(m.Payload),
+		"time":    timestamp,
+	}
+}
+
+func decodeJSON(data []byte) any {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+	return v
+}
+
+// matches evaluates Filter with the message as "message". A filter that
+// cannot be evaluated against the message, e.g. because it is not JSON, does
+// not match.
+func (n *NodeMqConsume) matches(ctx context.Context, env *expression.UnifiedEnv, output map[string]any) bool {
+	if n.Filter == "" {
+		return true
+	}
+	messageEnv := env.Clone()
+	messageEnv.GetData()["message"] = output
+	ok, err := messageEnv.EvalBool(ctx, n.Filter)
+	return err == nil && ok
+}
+
+// dispatcher runs the HandleWsMessage chain of a node once per matching
+// message.
+type dispatcher struct {
+	n   *NodeMqConsume
+	req *node.FlowNodeRequest
+
+	targets         []idwrap.IDWrap
+	edgeMap         mflow.EdgesMap
+	predecessorMap  map[idwrap.IDWrap][]idwrap.IDWrap
+	pendingTemplate map[idwrap.IDWrap]uint32
+}
+
+func newDispatcher(n *NodeMqConsume, req *node.FlowNodeRequest) *dispatcher {
+	d := &dispatcher{n: n, req: req}
+	targets := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleWsMessage)
+	if targets == nil {
+		return d
+	}
+	d.targets = node.FilterLoopEntryNodes(req.EdgeSourceMap, targets)
+	d.edgeMap = node.BuildHandleExecutionEdgeMap(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleWsMessage, d.targets)
+	d.predecessorMap = flowlocalrunner.BuildPredecessorMap(d.edgeMap)
+	d.pendingTemplate = node.BuildPendingMap(d.predecessorMap)
+	return d
+}
+
+// dispatch exposes the message as node variables and runs the message chain.
+// A failing chain is reported on the message and does not end the
+// subscription, matching WebSocket connection nodes.
+func (d *dispatcher) dispatch(ctx context.Context, index int, output map[string]any) {
+	n, req := d.n, d.req
+
+	_ = node.WriteNodeVar(req, n.Name, "message", output)
+	_ = node.WriteNodeVar(req, n.Name, "index", index)
+
+	executionID := idwrap.NewMonotonic()
+	executionName := fmt.Sprintf("%s Message %d", n.Name, index+1)
+
+	if len(d.targets) == 0 {
+		if req.LogPushFunc != nil {
+			req.LogPushFunc(runner.FlowNodeStatus{
+				ExecutionID:    executionID,
+				NodeID:         n.FlowNodeID,
+				Name:           executionName,
+				State:          mflow.NODE_STATE_SUCCESS,
+				OutputData:     output,
+				IterationEvent: true,
+				IterationIndex: index,
+				LoopNodeID:     n.FlowNodeID,
+			})
+		}
+		return
+	}
+
+	var parentPath []int
+	var parentNodes []idwrap.IDWrap
+	var parentLabels []runner.IterationLabel
+	if req.IterationContext != nil {
+		parentPath = req.IterationContext.IterationPath
+		parentNodes = req.IterationContext.ParentNodes
+		parentLabels = node.CloneIterationLabels(req.IterationContext.Labels)
+	}
+	labels := make([]runner.IterationLabel, len(parentLabels), len(parentLabels)+1)
+	copy(labels, parentLabels)
+	labels = append(labels, runner.IterationLabel{
+		NodeID:    n.FlowNodeID,
+		Name:      n.Name,
+		Iteration: index + 1,
+	})
+	iterContext := &runner.IterationContext{
+		IterationPath: append(append([]int(nil), parentPath...), index),
+		ParentNodes:   append(append([]idwrap.IDWrap(nil), parentNodes...), n.FlowNodeID),
+		Labels:        labels,
+	}
+
+	if req.LogPushFunc != nil {
+		req.LogPushFunc(runner.FlowNodeStatus{
+			ExecutionID:      executionID,
+			NodeID:           n.FlowNodeID,
+			Name:             executionName,
+			State:            mflow.NODE_STATE_RUNNING,
+			OutputData:       output,
+			IterationEvent:   true,
+			IterationIndex:   index,
+			LoopNodeID:       n.FlowNodeID,
+			IterationContext: iterContext,
+		})
+	}
+
+	var iterErr error
+	for _, targetID := range d.targets {
+		childReq := *req
+		childReq.EdgeSourceMap = d.edgeMap
+		childReq.PendingAtmoicMap = node.ClonePendingMap(d.pendingTemplate)
+		childReq.IterationContext = &runner.IterationContext{
+			IterationPath:  append([]int(nil), iterContext.IterationPath...),
+			ExecutionIndex: index,
+			ParentNodes:    append([]idwrap.IDWrap(nil), iterContext.ParentNodes...),
+			Labels:         node.CloneIterationLabels(iterContext.Labels),
+		}
+		childReq.ExecutionID = idwrap.NewMonotonic()
+
+		if err := flowlocalrunner.RunNodeSync(ctx, targetID, &childReq, req.LogPushFunc, d.predecessorMap); err != nil {
+			iterErr = err
+			break
+		}
+	}
+
+	if req.LogPushFunc != nil {
+		state := mflow.NODE_STATE_SUCCESS
+		if iterErr != nil {
+			state = mflow.NODE_STATE_FAILURE
+		}
+		req.LogPushFunc(runner.FlowNodeStatus{
+			ExecutionID:      executionID,
+			NodeID:           n.FlowNodeID,
+			Name:             executionName,
+			State:            state,
+			Error:            iterErr,
+			OutputData:       output,
+			IterationEvent:   true,
+			IterationIndex:   index,
+			LoopNodeID:       n.FlowNodeID,
+			IterationContext: iterContext,
+		})
+	}
+}
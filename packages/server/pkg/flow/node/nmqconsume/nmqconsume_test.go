@@ -0,0 +1,203 @@
+package nmqconsume
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mq"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mq/mqtest"
+)
+
+// recordNode records the order ids its consumer node exposed when it ran.
+type recordNode struct {
+	id     idwrap.IDWrap
+	source string
+	mu     sync.Mutex
+	ids    []float64
+}
+
+func (r *recordNode) GetID() idwrap.IDWrap { return r.id }
+func (r *recordNode) GetName() string      { return "Record" }
+
+func (r *recordNode) RunSync(_ context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	message, err := node.ReadNodeVar(req, r.source, "message")
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body := message.(map[string]any)["json"].(map[string]any)
+	r.ids = append(r.ids, body["orderId"].(float64))
+	return node.FlowNodeResult{}
+}
+
+func (r *recordNode) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- r.RunSync(ctx, req)
+}
+
+func newReq(edgeMap mflow.EdgesMap, nodeMap map[idwrap.IDWrap]node.FlowNode) *node.FlowNodeRequest {
+	return &node.FlowNodeRequest{
+		VarMap:           make(map[string]any),
+		ReadWriteLock:    &sync.RWMutex{},
+		NodeMap:          nodeMap,
+		EdgeSourceMap:    edgeMap,
+		Timeout:          10 * time.Second,
+		PendingAtmoicMap: make(map[idwrap.IDWrap]uint32),
+		PendingMapMu:     &sync.Mutex{},
+	}
+}
+
+type subscribers interface {
+	Subscribers() int
+}
+
+// runConsumer runs n in the background and returns once it has subscribed.
+func runConsumer(t *testing.T, server subscribers, n *NodeMqConsume, req *node.FlowNodeRequest) <-chan node.FlowNodeResult {
+	t.Helper()
+	results := make(chan node.FlowNodeResult, 1)
+	go func() { results <- n.RunSync(context.Background(), req) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for server.Subscribers() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("consumer did not subscribe")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return results
+}
+
+func publish(t *testing.T, brokerURL, topic string, payloads ...string) {
+	t.Helper()
+	client, err := mq.Dial(context.Background(), brokerURL)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+	for _, p := range payloads {
+		if _, err := client.Publish(context.Background(), mq.Message{Topic: topic, Payload: []byte(p)}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+}
+
+func TestNodeMqConsume_FilterAndDispatch(t *testing.T) {
+	server := mqtest.NewNATSServer(t)
+
+	consumeID, recordID, nextID := idwrap.NewNow(), idwrap.NewNow(), idwrap.NewNow()
+	record := &recordNode{id: recordID, source: "Orders"}
+	edges := mflow.NewEdgesMap([]mflow.Edge{
+		mflow.NewEdge(idwrap.NewNow(), consumeID, recordID, mflow.HandleWsMessage),
+		mflow.NewEdge(idwrap.NewNow(), consumeID, nextID, mflow.HandleUnspecified),
+	})
+	n := New(consumeID, "Orders", "{{ brokerUrl }}", mflow.NodeMqConsume{
+		Topic:      "orders.created",
+		Filter:     "message.json.customer == customer",
+		Count:      2,
+		TimeoutMs:  5000,
+		Assertions: []string{"count == 2", "Orders.messages[1].json.orderId == 3"},
+	})
+
+	req := newReq(edges, map[idwrap.IDWrap]node.FlowNode{recordID: record})
+	req.VarMap["brokerUrl"] = server.URL
+	req.VarMap["customer"] = "alice"
+	var mu sync.Mutex
+	var statuses []runner.FlowNodeStatus
+	req.LogPushFunc = func(s runner.FlowNodeStatus) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses = append(statuses, s)
+	}
+
+	results := runConsumer(t, server, n, req)
+	publish(t, server.URL, "orders.created",
+		`{"orderId": 1, "customer": "alice"}`,
+		`{"orderId": 2, "customer": "bob"}`,
+		`not json`,
+		`{"orderId": 3, "customer": "alice"}`,
+	)
+
+	result := <-results
+	if result.Err != nil {
+		t.Fatalf("RunSync: %v", result.Err)
+	}
+	if len(result.NextNodeID) != 1 || result.NextNodeID[0] != nextID {
+		t.Errorf("expected to continue to the next node, got %v", result.NextNodeID)
+	}
+	if len(record.ids) != 2 || record.ids[0] != 1 || record.ids[1] != 3 {
+		t.Errorf("expected the chain to run for each matching message, got %v", record.ids)
+	}
+	var messageStatuses int
+	for _, s := range statuses {
+		if s.NodeID == consumeID && s.IterationEvent && s.State == mflow.NODE_STATE_SUCCESS {
+			messageStatuses++
+		}
+	}
+	if messageStatuses != 2 {
+		t.Errorf("expected a finished status per message, got %d", messageStatuses)
+	}
+	if count, _ := node.ReadNodeVar(req, "Orders", "count"); count != 2 {
+		t.Errorf("count = %v, want 2", count)
+	}
+}
+
+func TestNodeMqConsume_RedisGroup(t *testing.T) {
+	server := mqtest.NewRedisServer(t)
+	n := New(idwrap.NewNow(), "Events", server.URL, mflow.NodeMqConsume{
+		Topic:     "events",
+		Group:     "e2e",
+		TimeoutMs: 5000,
+	})
+	req := newReq(mflow.EdgesMap{}, nil)
+
+	results := runConsumer(t, server, n, req)
+	publish(t, server.URL, "events", "hello")
+
+	if result := <-results; result.Err != nil {
+		t.Fatalf("RunSync: %v", result.Err)
+	}
+	message, _ := node.ReadNodeVar(req, "Events", "message")
+	if text := message.(map[string]any)["text"]; text != "hello" {
+		t.Errorf("message text = %v, want hello", text)
+	}
+}
+
+func TestNodeMqConsume_Timeout(t *testing.T) {
+	server := mqtest.NewNATSServer(t)
+	n := New(idwrap.NewNow(), "Orders", server.URL, mflow.NodeMqConsume{
+		Topic:     "orders.created",
+		Filter:    "message.json.orderId == 99",
+		TimeoutMs: 300,
+	})
+
+	results := runConsumer(t, server, n, newReq(mflow.EdgesMap{}, nil))
+	publish(t, server.URL, "orders.created", `{"orderId": 1}`)
+
+	result := <-results
+	if !errors.Is(result.Err, ErrConsumeTimeout) {
+		t.Fatalf("err = %v, want ErrConsumeTimeout", result.Err)
+	}
+}
+
+func TestNodeMqConsume_AssertionFailed(t *testing.T) {
+	server := mqtest.NewNATSServer(t)
+	n := New(idwrap.NewNow(), "Orders", server.URL, mflow.NodeMqConsume{
+		Topic:      "orders.created",
+		TimeoutMs:  5000,
+		Assertions: []string{`message.json.status == "paid"`},
+	})
+
+	results := runConsumer(t, server, n, newReq(mflow.EdgesMap{}, nil))
+	publish(t, server.URL, "orders.created", `{"status": "pending"}`)
+
+	if result := <-results; !errors.Is(result.Err, node.ErrAssertionFailed) {
+		t.Fatalf("err = %v, want ErrAssertionFailed", result.Err)
+	}
+}
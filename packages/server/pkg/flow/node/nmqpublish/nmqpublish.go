@@ -0,0 +1,129 @@
+//nolint:revive // exported
+package nmqpublish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mq"
+)
+
+// ErrNoBroker is returned when the node has no message broker credential or
+// the credential has an empty URL.
+var ErrNoBroker = errors.New("message queue node has no broker url")
+
+// Compile-time check that NodeMqPublish implements VariableIntrospector.
+var _ node.VariableIntrospector = (*NodeMqPublish)(nil)
+
+// NodeMqPublish publishes the interpolated Message to Topic on the broker
+// BrokerURL points at and continues once the broker has accepted it.
+type NodeMqPublish struct {
+	FlowNodeID idwrap.IDWrap
+	Name       string
+	BrokerURL  string
+	Topic      string
+	Key        string
+	Message    string
+}
+
+func New(id idwrap.IDWrap, name, brokerURL string, cfg mflow.NodeMqPublish) *NodeMqPublish {
+	return &NodeMqPublish{
+		FlowNodeID: id,
+		Name:       name,
+		BrokerURL:  brokerURL,
+		Topic:      cfg.Topic,
+		Key:        cfg.Key,
+		Message:    cfg.Message,
+	}
+}
+
+func (n *NodeMqPublish) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeMqPublish) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeMqPublish) GetName() string {
+	return n.Name
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeMqPublish) GetRequiredVariables() []string {
+	return expression.ExtractVarKeysFromMultiple(n.BrokerURL, n.Topic, n.Key, n.Message)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeMqPublish) GetOutputVariables() []string {
+	return []string{
+		"topic",
+		"key",
+		"message",
+		"id",
+		"size",
+		"duration",
+	}
+}
+
+func (n *NodeMqPublish) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	if n.BrokerURL == "" {
+		return node.FlowNodeResult{Err: ErrNoBroker}
+	}
+
+	env := expression.NewUnifiedEnv(node.DeepCopyVarMap(req))
+	brokerURL, err := env.InterpolateCtx(ctx, n.BrokerURL)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate broker url: %w", err)}
+	}
+	topic, err := env.InterpolateCtx(ctx, n.Topic)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate topic: %w", err)}
+	}
+	key, err := env.InterpolateCtx(ctx, n.Key)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate key: %w", err)}
+	}
+	message, err := env.InterpolateCtx(ctx, n.Message)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate message: %w", err)}
+	}
+
+	start := time.Now()
+	client, err := mq.Dial(ctx, brokerURL)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	defer client.Close()
+
+	id, err := client.Publish(ctx, mq.Message{Topic: topic, Key: key, Payload: []byte(message)})
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	outputs := map[string]any{
+		"topic":    topic,
+		"key":      key,
+		"message":  message,
+		"id":       id,
+		"size":     len(message),
+		"duration": time.Since(start).Milliseconds(),
+	}
+	if err := node.WriteNodeOutputs(req, n.Name, outputs); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified),
+	}
+}
+
+func (n *NodeMqPublish) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
@@ -0,0 +1,74 @@
+package nmqpublish
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mq"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/mq/mqtest"
+)
+
+func newReq() *node.FlowNodeRequest {
+	return &node.FlowNodeRequest{
+		VarMap:           make(map[string]any),
+		ReadWriteLock:    &sync.RWMutex{},
+		EdgeSourceMap:    mflow.EdgesMap{},
+		Timeout:          10 * time.Second,
+		PendingAtmoicMap: make(map[idwrap.IDWrap]uint32),
+		PendingMapMu:     &sync.Mutex{},
+	}
+}
+
+func TestNodeMqPublish_Redis(t *testing.T) {
+	server := mqtest.NewRedisServer(t)
+	req := newReq()
+	req.VarMap["order"] = map[string]any{"id": 42}
+	req.VarMap["env"] = "test"
+
+	nextID := idwrap.NewNow()
+	n := New(idwrap.NewNow(), "Publish", server.URL, mflow.NodeMqPublish{
+		Topic:   "{{ env }}.orders",
+		Key:     "order-{{ order.id }}",
+		Message: `{"orderId": {{ order.id }}}`,
+	})
+	req.EdgeSourceMap = mflow.NewEdgesMap([]mflow.Edge{
+		mflow.NewEdge(idwrap.NewNow(), n.FlowNodeID, nextID, mflow.HandleUnspecified),
+	})
+
+	result := n.RunSync(context.Background(), req)
+	if result.Err != nil {
+		t.Fatalf("RunSync: %v", result.Err)
+	}
+	if len(result.NextNodeID) != 1 || result.NextNodeID[0] != nextID {
+		t.Errorf("expected to continue to the next node, got %v", result.NextNodeID)
+	}
+	if got := server.Entries("test.orders"); len(got) != 1 || got[0] != `{"orderId": 42}` {
+		t.Fatalf("stream entries = %v", got)
+	}
+	if id, _ := node.ReadNodeVar(req, "Publish", "id"); id == "" {
+		t.Error("expected the stream entry id as output")
+	}
+	if key, _ := node.ReadNodeVar(req, "Publish", "key"); key != "order-42" {
+		t.Errorf("key = %v, want order-42", key)
+	}
+}
+
+func TestNodeMqPublish_NoBroker(t *testing.T) {
+	n := New(idwrap.NewNow(), "Publish", "", mflow.NodeMqPublish{Topic: "t", Message: "m"})
+	if result := n.RunSync(context.Background(), newReq()); !errors.Is(result.Err, ErrNoBroker) {
+		t.Fatalf("err = %v, want ErrNoBroker", result.Err)
+	}
+}
+
+func TestNodeMqPublish_UnsupportedBroker(t *testing.T) {
+	n := New(idwrap.NewNow(), "Publish", "mqtt://localhost:1883", mflow.NodeMqPublish{Topic: "t", Message: "m"})
+	if result := n.RunSync(context.Background(), newReq()); !errors.Is(result.Err, mq.ErrUnsupportedURL) {
+		t.Fatalf("err = %v, want ErrUnsupportedURL", result.Err)
+	}
+}
@@ -43,6 +43,16 @@ type VariableIntrospector interface {
 	GetOutputVariables() []string
 }
 
+// Debugger suspends a flow running in debug mode. The runner calls
+// BeforeNode before it executes each node, loop bodies included, and
+// AfterNode with the variables the node wrote once it has run. BeforeNode
+// blocks while the flow is paused at the node; an error stops the node from
+// running and stands in for its result.
+type Debugger interface {
+	BeforeNode(ctx context.Context, n FlowNode, req *FlowNodeRequest) error
+	AfterNode(n FlowNode, written map[string]any)
+}
+
 // LoopCoordinator marks nodes that orchestrate loop execution.
 type LoopCoordinator interface {
 	IsLoopCoordinator() bool
@@ -68,6 +78,10 @@ type FlowNodeRequest struct {
 	// downstream nodes cannot extract from a body that was not retained.
 	// Off by default.
 	LeanMode bool
+
+	// Debugger, when set, may suspend the flow before each node runs. It is
+	// only set for debug runs.
+	Debugger Debugger
 }
 
 type LogPushFunc func(status runner.FlowNodeStatus)
@@ -351,9 +365,9 @@ func ReadVarRaw(a *FlowNodeRequest, key string) (interface{}, error) {
 
 func ReadNodeVar(a *FlowNodeRequest, name, key string) (interface{}, error) {
 	a.ReadWriteLock.RLock()
+	defer a.ReadWriteLock.RUnlock()
 	nodeKey := name
 	nodeVarMap, ok := a.VarMap[nodeKey]
-	a.ReadWriteLock.RUnlock()
 
 	if !ok {
 		return nil, ErrVarNodeNotFound
@@ -0,0 +1,231 @@
+//nolint:revive // exported
+package nparallel
+
+import (
+	"context"
+	"sync"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// Statuses reported for each branch in "<parallel>.branches".
+const (
+	BranchSuccess  = "success"
+	BranchFailure  = "failure"
+	BranchCanceled = "canceled"
+	BranchSkipped  = "skipped"
+)
+
+// OutputBranches is the key the per-branch results are written under.
+const OutputBranches = "branches"
+
+// NodeParallel runs each node connected to its loop handle as an independent
+// branch, at most Concurrency of them at a time, and follows the then handle
+// once JoinMode is satisfied. Branches share the flow's variables, so a node
+// after the join can read the outputs of every branch that ran.
+type NodeParallel struct {
+	FlowNodeID  idwrap.IDWrap
+	Name        string
+	JoinMode    mflow.ParallelJoin
+	Concurrency int
+}
+
+func New(id idwrap.IDWrap, name string, joinMode mflow.ParallelJoin, concurrency int) *NodeParallel {
+	return &NodeParallel{
+		FlowNodeID:  id,
+		Name:        name,
+		JoinMode:    joinMode,
+		Concurrency: concurrency,
+	}
+}
+
+func (n *NodeParallel) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeParallel) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeParallel) GetName() string {
+	return n.Name
+}
+
+// IsLoopCoordinator exempts the parallel node from the per-node timeout; the
+// nodes of its branches keep their own.
+func (n *NodeParallel) IsLoopCoordinator() bool {
+	return true
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeParallel) GetOutputVariables() []string {
+	return []string{OutputBranches, "succeeded", "failed"}
+}
+
+func (n *NodeParallel) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	return n.run(ctx, req, flowlocalrunner.RunNodeSync)
+}
+
+func (n *NodeParallel) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.run(ctx, req, flowlocalrunner.RunNodeASync)
+}
+
+type branchResult struct {
+	index int
+	err   error
+}
+
+func (n *NodeParallel) run(ctx context.Context, req *node.FlowNodeRequest, runBranch node.RunFunc) node.FlowNodeResult {
+	branchTargets := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleLoop)
+	branchTargets = node.FilterLoopEntryNodes(req.EdgeSourceMap, branchTargets)
+	branchEdgeMap := node.BuildLoopExecutionEdgeMap(req.EdgeSourceMap, n.FlowNodeID, branchTargets)
+	predecessorMap := flowlocalrunner.BuildPredecessorMap(branchEdgeMap)
+	pendingTemplate := node.BuildPendingMap(predecessorMap)
+
+	limit := n.Concurrency
+	if limit <= 0 || limit > len(branchTargets) {
+		limit = len(branchTargets)
+	}
+
+	branchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	statuses := make([]string, len(branchTargets))
+	errs := make([]error, len(branchTargets))
+	for i := range statuses {
+		statuses[i] = BranchSkipped
+	}
+
+	results := make(chan branchResult, len(branchTargets))
+	sem := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+
+	launch := func(i int) {
+		branchReq := *req
+		branchReq.EdgeSourceMap = branchEdgeMap
+		branchReq.PendingAtmoicMap = node.ClonePendingMap(pendingTemplate)
+		branchReq.ExecutionID = idwrap.NewMonotonic()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := runBranch(branchCtx, branchTargets[i], &branchReq, req.LogPushFunc, predecessorMap)
+			results <- branchResult{index: i, err: err}
+		}()
+	}
+
+	var (
+		joinErr  error
+		decided  bool
+		launched int
+		finished int
+	)
+	// settle applies the join mode to a finished branch. It returns true once
+	// the outcome of the node no longer depends on the remaining branches.
+	settle := func(res branchResult) bool {
+		finished++
+		errs[res.index] = res.err
+		switch {
+		case res.err == nil:
+			statuses[res.index] = BranchSuccess
+		case decided && runner.IsCancellationError(res.err):
+			statuses[res.index] = BranchCanceled
+		default:
+			statuses[res.index] = BranchFailure
+		}
+		if decided {
+			return true
+		}
+
+		switch n.JoinMode {
+		case mflow.ParallelJoinAny:
+			joinErr = res.err
+			return true
+		case mflow.ParallelJoinFirstSuccess:
+			if res.err == nil {
+				joinErr = nil
+				return true
+			}
+			if joinErr == nil {
+				joinErr = res.err
+			}
+			return false
+		default:
+			if res.err != nil {
+				joinErr = res.err
+				return true
+			}
+			return false
+		}
+	}
+
+	for launched < len(branchTargets) && !decided {
+		select {
+		case sem <- struct{}{}:
+			launch(launched)
+			launched++
+		case res := <-results:
+			decided = settle(res)
+		case <-ctx.Done():
+			decided = true
+			joinErr = ctx.Err()
+		}
+	}
+	if decided {
+		cancel()
+	}
+	for finished < launched {
+		if settle(<-results) && !decided {
+			decided = true
+			cancel()
+		}
+	}
+	wg.Wait()
+
+	if err := n.writeOutput(req, branchTargets, statuses, errs); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	if joinErr != nil {
+		return node.FlowNodeResult{Err: joinErr}
+	}
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleThen),
+	}
+}
+
+// writeOutput records the outcome of every branch, named after the node the
+// branch starts at, along with success and failure counts.
+func (n *NodeParallel) writeOutput(req *node.FlowNodeRequest, branchTargets []idwrap.IDWrap, statuses []string, errs []error) error {
+	branches := make([]any, 0, len(branchTargets))
+	succeeded, failed := 0, 0
+	for i, id := range branchTargets {
+		branch := map[string]any{"status": statuses[i]}
+		if entry, ok := req.NodeMap[id]; ok {
+			branch["name"] = entry.GetName()
+		}
+		switch statuses[i] {
+		case BranchSuccess:
+			succeeded++
+		case BranchFailure:
+			failed++
+			branch["error"] = errs[i].Error()
+		}
+		branches = append(branches, branch)
+	}
+
+	output := map[string]any{
+		OutputBranches: branches,
+		"succeeded":    succeeded,
+		"failed":       failed,
+	}
+	if req.VariableTracker != nil {
+		return node.WriteNodeVarBulkWithTracking(req, n.Name, output, req.VariableTracker)
+	}
+	return node.WriteNodeVarBulk(req, n.Name, output)
+}
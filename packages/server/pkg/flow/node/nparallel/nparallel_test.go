@@ -0,0 +1,216 @@
+package nparallel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nstart"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// branchNode waits for delay (or until canceled), records that it ran and
+// fails with err when set.
+type branchNode struct {
+	id    idwrap.IDWrap
+	name  string
+	delay time.Duration
+	err   error
+	stats *runStats
+}
+
+type runStats struct {
+	mu       sync.Mutex
+	ran      map[string]bool
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+}
+
+func (n *branchNode) GetID() idwrap.IDWrap { return n.id }
+
+func (n *branchNode) GetName() string { return n.name }
+
+func (n *branchNode) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	current := n.stats.inFlight.Add(1)
+	defer n.stats.inFlight.Add(-1)
+	for {
+		seen := n.stats.maxSeen.Load()
+		if current <= seen || n.stats.maxSeen.CompareAndSwap(seen, current) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(n.delay):
+	case <-ctx.Done():
+		return node.FlowNodeResult{Err: ctx.Err()}
+	}
+
+	n.stats.mu.Lock()
+	n.stats.ran[n.name] = true
+	n.stats.mu.Unlock()
+	if n.err != nil {
+		return node.FlowNodeResult{Err: n.err}
+	}
+	return node.FlowNodeResult{NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.id, mflow.HandleUnspecified)}
+}
+
+func (n *branchNode) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+type parallelFlow struct {
+	nodes map[idwrap.IDWrap]node.FlowNode
+	edges []mflow.Edge
+	ids   map[string]idwrap.IDWrap
+	stats *runStats
+}
+
+// newParallelFlow builds start -> parallel, with one branch per entry of
+// branches and then after.
+func newParallelFlow(join mflow.ParallelJoin, concurrency int, branches ...*branchNode) *parallelFlow {
+	f := &parallelFlow{
+		nodes: make(map[idwrap.IDWrap]node.FlowNode),
+		ids:   make(map[string]idwrap.IDWrap),
+		stats: &runStats{ran: make(map[string]bool)},
+	}
+	for _, name := range []string{"start", "parallel"} {
+		f.ids[name] = idwrap.NewNow()
+	}
+	f.nodes[f.ids["start"]] = nstart.New(f.ids["start"], "start")
+	f.nodes[f.ids["parallel"]] = New(f.ids["parallel"], "parallel", join, concurrency)
+	f.edge("start", "parallel", mflow.HandleUnspecified)
+
+	after := &branchNode{name: "after"}
+	for _, b := range append(branches, after) {
+		b.id = idwrap.NewNow()
+		b.stats = f.stats
+		f.ids[b.name] = b.id
+		f.nodes[b.id] = b
+	}
+	for _, b := range branches {
+		f.edge("parallel", b.name, mflow.HandleLoop)
+	}
+	f.edge("parallel", "after", mflow.HandleThen)
+	return f
+}
+
+func (f *parallelFlow) edge(source, target string, handle mflow.EdgeHandle) {
+	f.edges = append(f.edges, mflow.NewEdge(idwrap.NewNow(), f.ids[source], f.ids[target], handle))
+}
+
+func (f *parallelFlow) run(t *testing.T) (map[string]any, error) {
+	t.Helper()
+	flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), []idwrap.IDWrap{f.ids["start"]}, f.nodes, mflow.NewEdgesMap(f.edges), 0, nil)
+
+	statusChan := make(chan runner.FlowNodeStatus, 256)
+	flowStatusChan := make(chan runner.FlowStatus, 8)
+	vars := make(map[string]any)
+	err := flowRunner.Run(context.Background(), statusChan, flowStatusChan, vars)
+	for range statusChan {
+	}
+	for range flowStatusChan {
+	}
+	return vars, err
+}
+
+// branchStatuses maps each branch name to the status the parallel node
+// reported for it.
+func branchStatuses(t *testing.T, vars map[string]any) map[string]string {
+	t.Helper()
+	out, ok := vars["parallel"].(map[string]any)
+	require.True(t, ok, "parallel writes its output: %#v", vars)
+	branches, ok := out[OutputBranches].([]any)
+	require.True(t, ok)
+
+	statuses := make(map[string]string, len(branches))
+	for _, b := range branches {
+		branch := b.(map[string]any)
+		statuses[branch["name"].(string)] = branch["status"].(string)
+	}
+	return statuses
+}
+
+func TestParallelJoinAllRunsEveryBranch(t *testing.T) {
+	f := newParallelFlow(mflow.ParallelJoinAll, 0,
+		&branchNode{name: "a", delay: 30 * time.Millisecond},
+		&branchNode{name: "b", delay: 40 * time.Millisecond},
+		&branchNode{name: "c", delay: 30 * time.Millisecond},
+	)
+	vars, err := f.run(t)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"a": true, "b": true, "c": true, "after": true}, f.stats.ran)
+	require.Equal(t, map[string]string{"a": BranchSuccess, "b": BranchSuccess, "c": BranchSuccess}, branchStatuses(t, vars))
+	require.Equal(t, int32(3), f.stats.maxSeen.Load(), "branches run at the same time")
+}
+
+func TestParallelJoinAllFailsOnFirstFailure(t *testing.T) {
+	branchErr := errors.New("branch failed")
+	f := newParallelFlow(mflow.ParallelJoinAll, 0,
+		&branchNode{name: "fails", err: branchErr},
+		&branchNode{name: "slow", delay: time.Minute},
+	)
+	vars, err := f.run(t)
+	require.ErrorIs(t, err, branchErr)
+	require.False(t, f.stats.ran["after"])
+	require.Equal(t, map[string]string{"fails": BranchFailure, "slow": BranchCanceled}, branchStatuses(t, vars))
+}
+
+func TestParallelJoinAnyFinishesWithFirstBranch(t *testing.T) {
+	f := newParallelFlow(mflow.ParallelJoinAny, 0,
+		&branchNode{name: "fast"},
+		&branchNode{name: "slow", delay: time.Minute},
+	)
+	vars, err := f.run(t)
+	require.NoError(t, err)
+	require.True(t, f.stats.ran["after"])
+	require.Equal(t, map[string]string{"fast": BranchSuccess, "slow": BranchCanceled}, branchStatuses(t, vars))
+}
+
+func TestParallelJoinFirstSuccessSkipsFailures(t *testing.T) {
+	f := newParallelFlow(mflow.ParallelJoinFirstSuccess, 0,
+		&branchNode{name: "fails", err: errors.New("mirror down")},
+		&branchNode{name: "works", delay: 20 * time.Millisecond},
+		&branchNode{name: "slow", delay: time.Minute},
+	)
+	vars, err := f.run(t)
+	require.NoError(t, err)
+	require.True(t, f.stats.ran["after"])
+	require.Equal(t, map[string]string{"fails": BranchFailure, "works": BranchSuccess, "slow": BranchCanceled}, branchStatuses(t, vars))
+}
+
+func TestParallelJoinFirstSuccessFailsWhenEveryBranchFails(t *testing.T) {
+	firstErr := errors.New("first mirror down")
+	f := newParallelFlow(mflow.ParallelJoinFirstSuccess, 0,
+		&branchNode{name: "a", err: firstErr},
+		&branchNode{name: "b", delay: 20 * time.Millisecond, err: errors.New("second mirror down")},
+	)
+	_, err := f.run(t)
+	require.ErrorIs(t, err, firstErr)
+	require.False(t, f.stats.ran["after"])
+}
+
+func TestParallelConcurrencyLimit(t *testing.T) {
+	var branches []*branchNode
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		branches = append(branches, &branchNode{name: name, delay: 10 * time.Millisecond})
+	}
+	f := newParallelFlow(mflow.ParallelJoinAll, 2, branches...)
+	vars, err := f.run(t)
+	require.NoError(t, err)
+	require.Len(t, f.stats.ran, 7)
+	require.LessOrEqual(t, f.stats.maxSeen.Load(), int32(2))
+
+	out := vars["parallel"].(map[string]any)
+	require.Equal(t, 6, out["succeeded"])
+	require.Equal(t, 0, out["failed"])
+}
@@ -0,0 +1,246 @@
+//nolint:revive // exported
+package npoll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// ErrPollTimeout is returned when the until expression does not hold before
+// the poll node runs out of attempts or time.
+var ErrPollTimeout = errors.New("poll timed out")
+
+const (
+	// DefaultIntervalMs is the wait between attempts when none is configured.
+	DefaultIntervalMs = 1000
+	// DefaultMaxAttempts bounds a poll node that sets neither a maximum
+	// duration nor a maximum number of attempts.
+	DefaultMaxAttempts = 10
+)
+
+// NodePoll runs the nodes connected to its loop handle, its body, until the
+// Until expression holds. Between attempts it waits IntervalMs, multiplied by
+// Backoff after every attempt. It gives up with ErrPollTimeout after
+// MaxAttempts attempts or MaxDurationMs, whichever comes first.
+//
+// A failed attempt does not fail the node: eventually consistent APIs often
+// answer with errors until the resource exists. The last failure is reported
+// in the timeout error instead.
+type NodePoll struct {
+	FlowNodeID    idwrap.IDWrap
+	Name          string
+	Until         string
+	IntervalMs    int64
+	Backoff       float64
+	MaxDurationMs int64
+	MaxAttempts   int32
+}
+
+func New(id idwrap.IDWrap, name string, cfg mflow.NodePoll) *NodePoll {
+	return &NodePoll{
+		FlowNodeID:    id,
+		Name:          name,
+		Until:         cfg.Until,
+		IntervalMs:    cfg.IntervalMs,
+		Backoff:       cfg.Backoff,
+		MaxDurationMs: cfg.MaxDurationMs,
+		MaxAttempts:   cfg.MaxAttempts,
+	}
+}
+
+func (n *NodePoll) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodePoll) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodePoll) GetName() string {
+	return n.Name
+}
+
+// IsLoopCoordinator exempts the poll node from the per-node timeout; it is
+// bounded by its own maximum duration and its body nodes keep their timeouts.
+func (n *NodePoll) IsLoopCoordinator() bool {
+	return true
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodePoll) GetRequiredVariables() []string {
+	if n.Until == "" {
+		return nil
+	}
+	return expression.ExtractExprIdentifiers(n.Until)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodePoll) GetOutputVariables() []string {
+	return []string{"attempt", "attempts", "elapsedMs"}
+}
+
+func (n *NodePoll) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	return n.run(ctx, req, flowlocalrunner.RunNodeSync)
+}
+
+func (n *NodePoll) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.run(ctx, req, flowlocalrunner.RunNodeASync)
+}
+
+func (n *NodePoll) run(ctx context.Context, req *node.FlowNodeRequest, runBody node.RunFunc) node.FlowNodeResult {
+	if n.Until == "" {
+		return node.FlowNodeResult{Err: fmt.Errorf("poll node %q has no until expression", n.Name)}
+	}
+
+	bodyTargets := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleLoop)
+	bodyTargets = node.FilterLoopEntryNodes(req.EdgeSourceMap, bodyTargets)
+	bodyEdgeMap := node.BuildLoopExecutionEdgeMap(req.EdgeSourceMap, n.FlowNodeID, bodyTargets)
+	predecessorMap := flowlocalrunner.BuildPredecessorMap(bodyEdgeMap)
+	pendingTemplate := node.BuildPendingMap(predecessorMap)
+
+	maxAttempts := int(n.MaxAttempts)
+	if maxAttempts <= 0 && n.MaxDurationMs <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	interval := time.Duration(n.IntervalMs) * time.Millisecond
+	if n.IntervalMs <= 0 {
+		interval = DefaultIntervalMs * time.Millisecond
+	}
+	start := time.Now()
+	var deadline time.Time
+	if n.MaxDurationMs > 0 {
+		deadline = start.Add(time.Duration(n.MaxDurationMs) * time.Millisecond)
+	}
+
+	var lastErr error
+	attempt := 0
+	for {
+		attempt++
+		if err := n.writeVar(req, "attempt", attempt); err != nil {
+			return node.FlowNodeResult{Err: err}
+		}
+
+		iterContext := node.IterationContext(req, n.FlowNodeID, n.Name, attempt-1)
+		executionID := idwrap.NewMonotonic()
+		n.logAttempt(req, executionID, attempt, mflow.NODE_STATE_RUNNING, iterContext, nil)
+
+		// The attempt runs under the poll's deadline too, so a body that hangs
+		// cannot outlast MaxDurationMs.
+		attemptCtx, cancelAttempt := ctx, context.CancelFunc(func() {})
+		if !deadline.IsZero() {
+			attemptCtx, cancelAttempt = context.WithDeadline(ctx, deadline)
+		}
+
+		var attemptErr error
+		for _, entryID := range bodyTargets {
+			bodyReq := *req
+			bodyReq.EdgeSourceMap = bodyEdgeMap
+			bodyReq.PendingAtmoicMap = node.ClonePendingMap(pendingTemplate)
+			bodyReq.IterationContext = iterContext
+			bodyReq.ExecutionID = idwrap.NewMonotonic()
+			if attemptErr = runBody(attemptCtx, entryID, &bodyReq, req.LogPushFunc, predecessorMap); attemptErr != nil {
+				break
+			}
+		}
+		if ctx.Err() != nil {
+			cancelAttempt()
+			n.logAttempt(req, executionID, attempt, mflow.NODE_STATE_CANCELED, iterContext, ctx.Err())
+			return node.FlowNodeResult{Err: ctx.Err()}
+		}
+
+		done := false
+		if attemptErr == nil {
+			done, attemptErr = n.checkUntil(attemptCtx, req)
+		}
+		deadlineHit := attemptCtx.Err() != nil
+		cancelAttempt()
+		state := mflow.NODE_STATE_SUCCESS
+		if attemptErr != nil {
+			state = mflow.NODE_STATE_FAILURE
+			lastErr = attemptErr
+		}
+		n.logAttempt(req, executionID, attempt, state, iterContext, attemptErr)
+
+		if done {
+			break
+		}
+
+		if deadlineHit || (maxAttempts > 0 && attempt >= maxAttempts) {
+			return node.FlowNodeResult{Err: n.timeoutError(attempt, time.Since(start), lastErr)}
+		}
+		wait := interval
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return node.FlowNodeResult{Err: n.timeoutError(attempt, time.Since(start), lastErr)}
+			}
+			wait = min(wait, remaining)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return node.FlowNodeResult{Err: ctx.Err()}
+		case <-timer.C:
+		}
+		if n.Backoff > 1 {
+			interval = time.Duration(float64(interval) * n.Backoff)
+		}
+	}
+
+	if err := n.writeVar(req, "attempts", attempt); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	if err := n.writeVar(req, "elapsedMs", time.Since(start).Milliseconds()); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleThen),
+	}
+}
+
+// checkUntil evaluates the until expression against the variables the
+// attempt produced. An expression that cannot be evaluated, for example
+// because the response lacks a field, counts as not done yet.
+func (n *NodePoll) checkUntil(ctx context.Context, req *node.FlowNodeRequest) (bool, error) {
+	env := expression.NewUnifiedEnv(node.DeepCopyVarMap(req))
+	if req.VariableTracker != nil {
+		env = env.WithTracking(req.VariableTracker)
+	}
+	done, err := env.EvalBool(ctx, n.Until)
+	if err != nil {
+		return false, fmt.Errorf("evaluate until expression: %w", err)
+	}
+	return done, nil
+}
+
+func (n *NodePoll) timeoutError(attempts int, elapsed time.Duration, lastErr error) error {
+	err := fmt.Errorf("%w: %q did not hold after %d attempts in %s", ErrPollTimeout, n.Until, attempts, elapsed.Round(time.Millisecond))
+	if lastErr != nil {
+		return fmt.Errorf("%w; last attempt failed: %w", err, lastErr)
+	}
+	return err
+}
+
+func (n *NodePoll) writeVar(req *node.FlowNodeRequest, key string, value any) error {
+	if req.VariableTracker != nil {
+		return node.WriteNodeVarWithTracking(req, n.Name, key, value, req.VariableTracker)
+	}
+	return node.WriteNodeVar(req, n.Name, key, value)
+}
+
+func (n *NodePoll) logAttempt(req *node.FlowNodeRequest, executionID idwrap.IDWrap, attempt int,
+	state mflow.NodeState, iterContext *runner.IterationContext, err error,
+) {
+	name := fmt.Sprintf("%s Attempt %d", n.Name, attempt)
+	node.LogIteration(req, executionID, n.FlowNodeID, name, attempt-1, state, map[string]any{"attempt": attempt}, iterContext, err)
+}
@@ -0,0 +1,194 @@
+package npoll
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nstart"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// jobNode stands in for a status request: it reports "pending" until its
+// doneAfter-th run and fails on the runs listed in failOn. With hang set it
+// instead blocks until its context ends, like a request that never answers.
+type jobNode struct {
+	id        idwrap.IDWrap
+	name      string
+	doneAfter int
+	failOn    map[int]bool
+	hang      bool
+	runs      int
+}
+
+func (n *jobNode) GetID() idwrap.IDWrap { return n.id }
+
+func (n *jobNode) GetName() string { return n.name }
+
+func (n *jobNode) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	n.runs++
+	if n.hang {
+		<-ctx.Done()
+		return node.FlowNodeResult{Err: ctx.Err()}
+	}
+	if n.failOn[n.runs] {
+		return node.FlowNodeResult{Err: errors.New("job not found")}
+	}
+	status := "pending"
+	if n.runs >= n.doneAfter {
+		status = "done"
+	}
+	if err := node.WriteNodeVar(req, n.name, "response", map[string]any{"status": status}); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	return node.FlowNodeResult{NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.id, mflow.HandleUnspecified)}
+}
+
+func (n *jobNode) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+type pollResult struct {
+	vars     map[string]any
+	err      error
+	attempts []runner.FlowNodeStatus
+	afterRan bool
+}
+
+// runPoll runs start -> poll, with job as the poll body and after on the
+// then handle.
+func runPoll(t *testing.T, cfg mflow.NodePoll, job *jobNode) pollResult {
+	t.Helper()
+	startID, pollID, afterID := idwrap.NewNow(), idwrap.NewNow(), idwrap.NewNow()
+	job.id = idwrap.NewNow()
+	job.name = "job"
+	after := &jobNode{id: afterID, name: "after"}
+
+	nodes := map[idwrap.IDWrap]node.FlowNode{
+		startID: nstart.New(startID, "start"),
+		pollID:  New(pollID, "poll", cfg),
+		job.id:  job,
+		afterID: after,
+	}
+	edges := []mflow.Edge{
+		mflow.NewEdge(idwrap.NewNow(), startID, pollID, mflow.HandleUnspecified),
+		mflow.NewEdge(idwrap.NewNow(), pollID, job.id, mflow.HandleLoop),
+		mflow.NewEdge(idwrap.NewNow(), pollID, afterID, mflow.HandleThen),
+	}
+	flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), []idwrap.IDWrap{startID}, nodes, mflow.NewEdgesMap(edges), 0, nil)
+
+	statusChan := make(chan runner.FlowNodeStatus, 256)
+	flowStatusChan := make(chan runner.FlowStatus, 8)
+	res := pollResult{vars: make(map[string]any)}
+	res.err = flowRunner.Run(context.Background(), statusChan, flowStatusChan, res.vars)
+	for status := range statusChan {
+		if status.IterationEvent && status.NodeID == pollID && status.State != mflow.NODE_STATE_RUNNING {
+			res.attempts = append(res.attempts, status)
+		}
+	}
+	for range flowStatusChan {
+	}
+	res.afterRan = after.runs > 0
+	return res
+}
+
+func TestPollUntilConditionHolds(t *testing.T) {
+	job := &jobNode{doneAfter: 3}
+	res := runPoll(t, mflow.NodePoll{
+		Until:       `job.response.status == "done"`,
+		IntervalMs:  1,
+		MaxAttempts: 5,
+	}, job)
+
+	require.NoError(t, res.err)
+	require.True(t, res.afterRan)
+	require.Equal(t, 3, job.runs)
+	require.Equal(t, 3, res.vars["poll"].(map[string]any)["attempts"])
+	require.Len(t, res.attempts, 3)
+	for i, attempt := range res.attempts {
+		require.Equal(t, i, attempt.IterationIndex)
+		require.Equal(t, []int{i}, attempt.IterationContext.IterationPath)
+	}
+}
+
+func TestPollRetriesFailedAttempts(t *testing.T) {
+	job := &jobNode{doneAfter: 2, failOn: map[int]bool{1: true}}
+	res := runPoll(t, mflow.NodePoll{
+		Until:       `job.response.status == "done"`,
+		IntervalMs:  1,
+		MaxAttempts: 5,
+	}, job)
+
+	require.NoError(t, res.err)
+	require.Equal(t, 2, job.runs)
+	require.Equal(t, mflow.NODE_STATE_FAILURE, res.attempts[0].State)
+	require.Equal(t, mflow.NODE_STATE_SUCCESS, res.attempts[1].State)
+}
+
+func TestPollFailsAfterMaxAttempts(t *testing.T) {
+	job := &jobNode{doneAfter: 100}
+	res := runPoll(t, mflow.NodePoll{
+		Until:       `job.response.status == "done"`,
+		IntervalMs:  1,
+		MaxAttempts: 3,
+	}, job)
+
+	require.ErrorIs(t, res.err, ErrPollTimeout)
+	require.ErrorContains(t, res.err, "after 3 attempts")
+	require.False(t, res.afterRan)
+	require.Equal(t, 3, job.runs)
+}
+
+func TestPollFailsAfterMaxDuration(t *testing.T) {
+	job := &jobNode{doneAfter: 1000, failOn: map[int]bool{1: true}}
+	started := time.Now()
+	res := runPoll(t, mflow.NodePoll{
+		Until:         `job.response.status == "done"`,
+		IntervalMs:    10,
+		MaxDurationMs: 60,
+	}, job)
+
+	require.ErrorIs(t, res.err, ErrPollTimeout)
+	require.Less(t, time.Since(started), time.Second)
+	require.GreaterOrEqual(t, job.runs, 2)
+}
+
+func TestPollMaxDurationBoundsHangingAttempt(t *testing.T) {
+	job := &jobNode{hang: true}
+	started := time.Now()
+	res := runPoll(t, mflow.NodePoll{
+		Until:         `job.response.status == "done"`,
+		IntervalMs:    10,
+		MaxDurationMs: 50,
+	}, job)
+
+	require.ErrorIs(t, res.err, ErrPollTimeout)
+	require.NotErrorIs(t, res.err, context.Canceled)
+	require.Less(t, time.Since(started), time.Second)
+	require.Equal(t, 1, job.runs)
+	require.False(t, res.afterRan)
+	require.Len(t, res.attempts, 1)
+	require.Equal(t, mflow.NODE_STATE_FAILURE, res.attempts[0].State)
+}
+
+func TestPollBackoffGrowsInterval(t *testing.T) {
+	job := &jobNode{doneAfter: 4}
+	started := time.Now()
+	res := runPoll(t, mflow.NodePoll{
+		Until:       `job.response.status == "done"`,
+		IntervalMs:  10,
+		Backoff:     2,
+		MaxAttempts: 4,
+	}, job)
+
+	require.NoError(t, res.err)
+	// Waits of 10, 20 and 40ms between the four attempts.
+	require.GreaterOrEqual(t, time.Since(started), 70*time.Millisecond)
+}
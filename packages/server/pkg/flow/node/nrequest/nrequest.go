@@ -249,7 +249,7 @@ func (nr *NodeRequest) RunSync(ctx context.Context, req *node.FlowNodeRequest) n
 	// Check if any assertions failed
 	for _, assertRes := range respCreate.ResponseAsserts {
 		if !assertRes.Success {
-			result.Err = fmt.Errorf("assertion failed: %s", assertRes.Value)
+			result.Err = fmt.Errorf("%w: %s", node.ErrAssertionFailed, assertRes.Value)
 
 			// Still send the response data even though we're failing
 			nr.NodeRequestSideRespChan <- NodeRequestSideResp{
@@ -429,7 +429,7 @@ func (nr *NodeRequest) RunAsync(ctx context.Context, req *node.FlowNodeRequest,
 	// Check if any assertions failed
 	for _, assertRes := range respCreate.ResponseAsserts {
 		if !assertRes.Success {
-			result.Err = fmt.Errorf("assertion failed: %s", assertRes.Value)
+			result.Err = fmt.Errorf("%w: %s", node.ErrAssertionFailed, assertRes.Value)
 
 			nr.NodeRequestSideRespChan <- NodeRequestSideResp{
 				ExecutionID: req.ExecutionID,
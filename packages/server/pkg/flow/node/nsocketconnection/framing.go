@@ -0,0 +1,149 @@
+package nsocketconnection
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// maxMessageSize bounds a single message; longer ones end the connection.
+const maxMessageSize = 1 << 20
+
+// DefaultLengthBytes is the length prefix size when none is set.
+const DefaultLengthBytes = 4
+
+// Framing splits a TCP stream into messages and frames messages sent on it.
+type Framing struct {
+	Mode        mflow.SocketFraming
+	Delimiter   []byte // with delimiter framing
+	LengthBytes int    // with length framing: 1, 2 or 4
+}
+
+// NewFraming validates a node's framing settings. delimiter may hold escapes
+// such as \n, \r\n or \x00; empty means a newline.
+func NewFraming(mode mflow.SocketFraming, delimiter string, lengthBytes int32) (Framing, error) {
+	f := Framing{Mode: mode}
+	switch mode {
+	case mflow.SocketFramingNone:
+	case mflow.SocketFramingDelimiter:
+		f.Delimiter = decodeDelimiter(delimiter)
+	case mflow.SocketFramingLength:
+		f.LengthBytes = int(lengthBytes)
+		if f.LengthBytes == 0 {
+			f.LengthBytes = DefaultLengthBytes
+		}
+		if f.LengthBytes != 1 && f.LengthBytes != 2 && f.LengthBytes != 4 {
+			return f, fmt.Errorf("length prefix must be 1, 2 or 4 bytes, got %d", f.LengthBytes)
+		}
+	default:
+		return f, fmt.Errorf("unknown socket framing %d", mode)
+	}
+	return f, nil
+}
+
+func decodeDelimiter(s string) []byte {
+	if s == "" {
+		return []byte("\n")
+	}
+	if unquoted, err := strconv.Unquote(`"` + s + `"`); err == nil && unquoted != "" {
+		return []byte(unquoted)
+	}
+	return []byte(s)
+}
+
+// Frame returns msg as written on the stream.
+func (f Framing) Frame(msg []byte) ([]byte, error) {
+	switch f.Mode {
+	case mflow.SocketFramingDelimiter:
+		return append(append([]byte(nil), msg...), f.Delimiter...), nil
+	case mflow.SocketFramingLength:
+		if limit := uint64(1)<<(8*f.LengthBytes) - 1; uint64(len(msg)) > limit {
+			return nil, fmt.Errorf("message of %d bytes does not fit a %d byte length prefix", len(msg), f.LengthBytes)
+		}
+		prefix := make([]byte, 4)
+		binary.BigEndian.PutUint32(prefix, uint32(len(msg))) //nolint:gosec // G115: bounded above
+		return append(prefix[4-f.LengthBytes:], msg...), nil
+	default:
+		return msg, nil
+	}
+}
+
+// scanner returns a scanner yielding the messages read from r.
+func (f Framing) scanner(r io.Reader) *bufio.Scanner {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	s.Split(f.split)
+	return s
+}
+
+func (f Framing) split(data []byte, atEOF bool) (int, []byte, error) {
+	switch f.Mode {
+	case mflow.SocketFramingDelimiter:
+		if i := bytes.Index(data, f.Delimiter); i >= 0 {
+			return i + len(f.Delimiter), data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	case mflow.SocketFramingLength:
+		if len(data) < f.LengthBytes {
+			if atEOF && len(data) > 0 {
+				return 0, nil, io.ErrUnexpectedEOF
+			}
+			return 0, nil, nil
+		}
+		var n uint64
+		for _, b := range data[:f.LengthBytes] {
+			n = n<<8 | uint64(b)
+		}
+		if n > maxMessageSize {
+			return 0, nil, fmt.Errorf("message of %d bytes exceeds the %d byte limit", n, maxMessageSize)
+		}
+		end := f.LengthBytes + int(n)
+		if len(data) < end {
+			if atEOF {
+				return 0, nil, io.ErrUnexpectedEOF
+			}
+			return 0, nil, nil
+		}
+		return end, data[f.LengthBytes:end], nil
+	default:
+		if len(data) == 0 {
+			return 0, nil, nil
+		}
+		return len(data), data, nil
+	}
+}
+
+// Encode turns a message template into bytes: text as is, or hex or base64
+// decoded. Whitespace between hex digits is ignored.
+func Encode(message string, encoding mflow.SocketEncoding) ([]byte, error) {
+	switch encoding {
+	case mflow.SocketEncodingText:
+		return []byte(message), nil
+	case mflow.SocketEncodingHex:
+		b, err := hex.DecodeString(strings.Join(strings.Fields(message), ""))
+		if err != nil {
+			return nil, fmt.Errorf("decode hex message: %w", err)
+		}
+		return b, nil
+	case mflow.SocketEncodingBase64:
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(message))
+		if err != nil {
+			return nil, fmt.Errorf("decode base64 message: %w", err)
+		}
+		return b, nil
+	default:
+		return nil, errors.New("unknown socket message encoding")
+	}
+}
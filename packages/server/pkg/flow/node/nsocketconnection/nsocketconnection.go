@@ -0,0 +1,342 @@
+//nolint:revive // exported
+package nsocketconnection
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// DefaultTimeoutMs is the connect timeout when none is set.
+const DefaultTimeoutMs = 10000
+
+// Compile-time check that NodeSocketConnection implements VariableIntrospector.
+var _ node.VariableIntrospector = (*NodeSocketConnection)(nil)
+
+// NodeSocketConnection is a listener entry node that opens a TCP (optionally
+// TLS) or UDP socket and dispatches HandleWsMessage chains for each message
+// received on it. TCP streams are split into messages by Framing.
+type NodeSocketConnection struct {
+	FlowNodeID    idwrap.IDWrap
+	Name          string
+	Network       mflow.SocketNetwork
+	Address       string
+	TLS           bool
+	TLSSkipVerify bool
+	Framing       mflow.SocketFraming
+	Delimiter     string
+	LengthBytes   int32
+	TimeoutMs     int64
+}
+
+func New(id idwrap.IDWrap, name string, cfg mflow.NodeSocketConnection) *NodeSocketConnection {
+	return &NodeSocketConnection{
+		FlowNodeID:    id,
+		Name:          name,
+		Network:       cfg.Network,
+		Address:       cfg.Address,
+		TLS:           cfg.TLS,
+		TLSSkipVerify: cfg.TLSSkipVerify,
+		Framing:       cfg.Framing,
+		Delimiter:     cfg.Delimiter,
+		LengthBytes:   cfg.LengthBytes,
+		TimeoutMs:     cfg.TimeoutMs,
+	}
+}
+
+func (n *NodeSocketConnection) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeSocketConnection) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeSocketConnection) GetName() string {
+	return n.Name
+}
+
+// IsEntryNode marks this as a valid flow entry point (no incoming edges).
+func (n *NodeSocketConnection) IsEntryNode() bool {
+	return true
+}
+
+// IsLoopCoordinator prevents the runner from applying per-node timeout.
+func (n *NodeSocketConnection) IsLoopCoordinator() bool {
+	return true
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeSocketConnection) GetRequiredVariables() []string {
+	return expression.ExtractVarKeysFromMultiple(n.Address)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeSocketConnection) GetOutputVariables() []string {
+	return []string{
+		"network",
+		"address",
+		"local_address",
+		"connected",
+		"tls",
+		"message",
+		"data",
+		"index",
+		"type",
+	}
+}
+
+func (n *NodeSocketConnection) timeout() time.Duration {
+	if n.TimeoutMs > 0 {
+		return time.Duration(n.TimeoutMs) * time.Millisecond
+	}
+	return DefaultTimeoutMs * time.Millisecond
+}
+
+func (n *NodeSocketConnection) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	varMapCopy := node.DeepCopyVarMap(req)
+	env := expression.NewUnifiedEnv(varMapCopy)
+	address, err := env.InterpolateCtx(ctx, n.Address)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate address: %w", err)}
+	}
+
+	framing, err := NewFraming(n.Framing, n.Delimiter, n.LengthBytes)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	conn, err := n.dial(ctx, address)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	closeConn := func() {
+		_ = conn.Close()
+	}
+
+	session := NewSession(conn, n.Network, framing)
+	outputs := map[string]any{
+		"network":       networkName(n.Network),
+		"address":       address,
+		"local_address": conn.LocalAddr().String(),
+		"connected":     true,
+		"tls":           n.useTLS(),
+	}
+	if err := node.WriteNodeOutputs(req, n.Name, outputs); err != nil {
+		closeConn()
+		return node.FlowNodeResult{Err: err}
+	}
+	// The session tracks received messages for Socket Receive nodes (internal, not tracked)
+	if err := node.WriteNodeVar(req, n.Name, SessionVar, session); err != nil {
+		closeConn()
+		return node.FlowNodeResult{Err: fmt.Errorf("write session var: %w", err)}
+	}
+
+	// Reads only return on error, so the connection is closed when the flow ends.
+	go func() {
+		<-ctx.Done()
+		closeConn()
+	}()
+
+	nextID := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified)
+	msgTargets := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleWsMessage)
+	d := newDispatcher(n, req, msgTargets)
+	go func() {
+		defer closeConn()
+		var msgIndex int
+		for {
+			m, err := session.Read()
+			if err != nil {
+				return
+			}
+			_ = node.WriteNodeVar(req, n.Name, "message", string(m.Data))
+			_ = node.WriteNodeVar(req, n.Name, "data", m.Output())
+			_ = node.WriteNodeVar(req, n.Name, "index", msgIndex)
+			_ = node.WriteNodeVar(req, n.Name, "type", DirectionReceived)
+			d.dispatch(ctx, msgIndex, m)
+			msgIndex++
+		}
+	}()
+
+	return node.FlowNodeResult{NextNodeID: nextID}
+}
+
+func (n *NodeSocketConnection) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+func (n *NodeSocketConnection) useTLS() bool {
+	return n.TLS && n.Network == mflow.SocketNetworkTCP
+}
+
+// dial connects to address within the connect timeout and completes the TLS
+// handshake when TLS is enabled.
+func (n *NodeSocketConnection) dial(ctx context.Context, address string) (net.Conn, error) {
+	network := networkName(n.Network)
+	dialCtx, cancel := context.WithTimeout(ctx, n.timeout())
+	defer cancel()
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(dialCtx, network, address)
+	if err != nil {
+		return nil, fmt.Errorf("socket dial %s %s: %w", network, address, err)
+	}
+	if !n.useTLS() {
+		return conn, nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("socket address %q: %w", address, err)
+	}
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: n.TLSSkipVerify, //nolint:gosec // G402: opted into per node
+		MinVersion:         tls.VersionTLS12,
+	})
+	if err := tlsConn.HandshakeContext(dialCtx); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("tls handshake with %s: %w", address, err)
+	}
+	return tlsConn, nil
+}
+
+func networkName(network mflow.SocketNetwork) string {
+	if network == mflow.SocketNetworkUDP {
+		return "udp"
+	}
+	return "tcp"
+}
+
+// dispatcher runs the HandleWsMessage chain once per received message, or
+// only logs the message when the node has no such chain.
+type dispatcher struct {
+	n   *NodeSocketConnection
+	req *node.FlowNodeRequest
+
+	targets         []idwrap.IDWrap
+	edgeMap         mflow.EdgesMap
+	predecessorMap  map[idwrap.IDWrap][]idwrap.IDWrap
+	pendingTemplate map[idwrap.IDWrap]uint32
+}
+
+func newDispatcher(n *NodeSocketConnection, req *node.FlowNodeRequest, targets []idwrap.IDWrap) *dispatcher {
+	d := &dispatcher{n: n, req: req}
+	if targets == nil {
+		return d
+	}
+	d.targets = node.FilterLoopEntryNodes(req.EdgeSourceMap, targets)
+	d.edgeMap = node.BuildHandleExecutionEdgeMap(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleWsMessage, d.targets)
+	d.predecessorMap = flowlocalrunner.BuildPredecessorMap(d.edgeMap)
+	d.pendingTemplate = node.BuildPendingMap(d.predecessorMap)
+	return d
+}
+
+func (d *dispatcher) dispatch(ctx context.Context, msgIndex int, m Message) {
+	n, req := d.n, d.req
+	output := map[string]any{"type": DirectionReceived, "index": msgIndex, "message": string(m.Data), "data": m.Output()}
+	executionID := idwrap.NewMonotonic()
+	executionName := fmt.Sprintf("%s Message %d", n.Name, msgIndex+1)
+
+	if d.targets == nil {
+		if req.LogPushFunc != nil {
+			req.LogPushFunc(runner.FlowNodeStatus{
+				ExecutionID:    executionID,
+				NodeID:         n.FlowNodeID,
+				Name:           executionName,
+				State:          mflow.NODE_STATE_SUCCESS,
+				OutputData:     output,
+				IterationEvent: true,
+				IterationIndex: msgIndex,
+				LoopNodeID:     n.FlowNodeID,
+			})
+		}
+		return
+	}
+
+	// Build iteration context for this message
+	var parentPath []int
+	var parentNodes []idwrap.IDWrap
+	var parentLabels []runner.IterationLabel
+	if req.IterationContext != nil {
+		parentPath = req.IterationContext.IterationPath
+		parentNodes = req.IterationContext.ParentNodes
+		parentLabels = node.CloneIterationLabels(req.IterationContext.Labels)
+	}
+	labels := make([]runner.IterationLabel, len(parentLabels), len(parentLabels)+1)
+	copy(labels, parentLabels)
+	labels = append(labels, runner.IterationLabel{
+		NodeID:    n.FlowNodeID,
+		Name:      n.Name,
+		Iteration: msgIndex + 1,
+	})
+	iterContext := &runner.IterationContext{
+		IterationPath: append(parentPath, msgIndex),
+		ParentNodes:   append(parentNodes, n.FlowNodeID),
+		Labels:        labels,
+	}
+
+	if req.LogPushFunc != nil {
+		req.LogPushFunc(runner.FlowNodeStatus{
+			ExecutionID:      executionID,
+			NodeID:           n.FlowNodeID,
+			Name:             executionName,
+			State:            mflow.NODE_STATE_RUNNING,
+			OutputData:       output,
+			IterationEvent:   true,
+			IterationIndex:   msgIndex,
+			LoopNodeID:       n.FlowNodeID,
+			IterationContext: iterContext,
+		})
+	}
+
+	// Execute message handler chain
+	var iterErr error
+	for _, targetID := range d.targets {
+		childIterCtx := &runner.IterationContext{
+			IterationPath:  append([]int(nil), iterContext.IterationPath...),
+			ExecutionIndex: msgIndex,
+			ParentNodes:    append([]idwrap.IDWrap(nil), iterContext.ParentNodes...),
+			Labels:         node.CloneIterationLabels(iterContext.Labels),
+		}
+
+		childReq := *req
+		childReq.EdgeSourceMap = d.edgeMap
+		childReq.PendingAtmoicMap = node.ClonePendingMap(d.pendingTemplate)
+		childReq.IterationContext = childIterCtx
+		childReq.ExecutionID = idwrap.NewMonotonic()
+
+		if err := flowlocalrunner.RunNodeSync(ctx, targetID, &childReq, req.LogPushFunc, d.predecessorMap); err != nil {
+			iterErr = err
+			break
+		}
+	}
+
+	if req.LogPushFunc != nil {
+		state := mflow.NODE_STATE_SUCCESS
+		if iterErr != nil {
+			state = mflow.NODE_STATE_FAILURE
+		}
+		req.LogPushFunc(runner.FlowNodeStatus{
+			ExecutionID:      executionID,
+			NodeID:           n.FlowNodeID,
+			Name:             executionName,
+			State:            state,
+			Error:            iterErr,
+			OutputData:       output,
+			IterationEvent:   true,
+			IterationIndex:   msgIndex,
+			LoopNodeID:       n.FlowNodeID,
+			IterationContext: iterContext,
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package nsocketconnection
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// lineServer answers every newline terminated line with "echo: <line>\n".
+func lineServer(t *testing.T, ln net.Listener) string {
+	t.Helper()
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if _, err := io.WriteString(conn, "echo: "+line); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func listenTCP(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	return ln
+}
+
+func newReq() *node.FlowNodeRequest {
+	return &node.FlowNodeRequest{
+		VarMap:           make(map[string]any),
+		ReadWriteLock:    &sync.RWMutex{},
+		EdgeSourceMap:    mflow.EdgesMap{},
+		Timeout:          10 * time.Second,
+		PendingAtmoicMap: make(map[idwrap.IDWrap]uint32),
+		PendingMapMu:     &sync.Mutex{},
+	}
+}
+
+// connect runs a Socket Connection node named "Sock" and returns its session.
+func connect(t *testing.T, ctx context.Context, cfg mflow.NodeSocketConnection) (*node.FlowNodeRequest, *Session) {
+	t.Helper()
+	req := newReq()
+	if result := New(idwrap.NewNow(), "Sock", cfg).RunSync(ctx, req); result.Err != nil {
+		t.Fatalf("RunSync error: %v", result.Err)
+	}
+	session, err := ReadSession(req, "Sock")
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+	return req, session
+}
+
+func expectOne(t *testing.T, ctx context.Context, session *Session) Message {
+	t.Helper()
+	got, err := session.Expect(ctx, 1, func(Message) (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("Expect: %v", err)
+	}
+	return got[0]
+}
+
+func TestNodeSocketConnection_TCPDelimiter(t *testing.T) {
+	addr := lineServer(t, listenTCP(t))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, session := connect(t, ctx, mflow.NodeSocketConnection{
+		Address: addr,
+		Framing: mflow.SocketFramingDelimiter,
+	})
+	if v, _ := node.ReadNodeVar(req, "Sock", "network"); v != "tcp" {
+		t.Errorf("network = %v, want tcp", v)
+	}
+
+	if _, err := session.Send(ctx, []byte("ping")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	m := expectOne(t, ctx, session)
+	if string(m.Data) != "echo: ping" {
+		t.Errorf("message = %q, want the line without its delimiter", m.Data)
+	}
+	if out := m.Output(); out["hex"] != "6563686f3a2070696e67" || out["size"] != 10 {
+		t.Errorf("output = %v", out)
+	}
+}
+
+func TestNodeSocketConnection_TCPLength(t *testing.T) {
+	ln := listenTCP(t)
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		prefix := make([]byte, 2)
+		if _, err := io.ReadFull(conn, prefix); err != nil {
+			return
+		}
+		body := make([]byte, int(prefix[0])<<8|int(prefix[1]))
+		if _, err := io.ReadFull(conn, body); err != nil {
+			return
+		}
+		// Reply with the body upper-cased, written in two parts.
+		reply := append([]byte{0, byte(len(body))}, bytes.ToUpper(body)...)
+		_, _ = conn.Write(reply[:3])
+		time.Sleep(20 * time.Millisecond)
+		_, _ = conn.Write(reply[3:])
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, session := connect(t, ctx, mflow.NodeSocketConnection{
+		Address:     ln.Addr().String(),
+		Framing:     mflow.SocketFramingLength,
+		LengthBytes: 2,
+	})
+	if _, err := session.Send(ctx, []byte(`{"id":1}`)); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	m := expectOne(t, ctx, session)
+	if string(m.Data) != `{"ID":1}` {
+		t.Fatalf("message = %q", m.Data)
+	}
+	if json, _ := m.Output()["json"].(map[string]any); json["ID"] != float64(1) {
+		t.Errorf("json = %v", m.Output()["json"])
+	}
+}
+
+func TestNodeSocketConnection_UDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer pc.Close()
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, from, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = pc.WriteTo(append([]byte("ack:"), buf[:n]...), from)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Framing does not apply to datagrams.
+	_, session := connect(t, ctx, mflow.NodeSocketConnection{
+		Network: mflow.SocketNetworkUDP,
+		Address: pc.LocalAddr().String(),
+		Framing: mflow.SocketFramingDelimiter,
+	})
+	if _, err := session.Send(ctx, []byte("app.hits:1|c")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if m := expectOne(t, ctx, session); string(m.Data) != "ack:app.hits:1|c" {
+		t.Errorf("message = %q", m.Data)
+	}
+}
+
+func TestNodeSocketConnection_TLS(t *testing.T) {
+	// httptest provides a self-signed certificate for 127.0.0.1.
+	certSrv := httptest.NewUnstartedServer(nil)
+	certSrv.StartTLS()
+	defer certSrv.Close()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", certSrv.TLS.Clone())
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lineServer(t, ln)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg := mflow.NodeSocketConnection{Address: addr, TLS: true, Framing: mflow.SocketFramingDelimiter}
+	result := New(idwrap.NewNow(), "Sock", cfg).RunSync(ctx, newReq())
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "tls handshake") {
+		t.Fatalf("err = %v, want a certificate error", result.Err)
+	}
+
+	cfg.TLSSkipVerify = true
+	req, session := connect(t, ctx, cfg)
+	if v, _ := node.ReadNodeVar(req, "Sock", "tls"); v != true {
+		t.Errorf("tls = %v, want true", v)
+	}
+	if _, err := session.Send(ctx, []byte("secure")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if m := expectOne(t, ctx, session); string(m.Data) != "echo: secure" {
+		t.Errorf("message = %q", m.Data)
+	}
+}
+
+func TestNodeSocketConnection_ClosedByPeer(t *testing.T) {
+	ln := listenTCP(t)
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			_, _ = conn.Write([]byte("bye"))
+			_ = conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, session := connect(t, ctx, mflow.NodeSocketConnection{
+		Address: ln.Addr().String(),
+		Framing: mflow.SocketFramingDelimiter,
+	})
+	got, err := session.Expect(ctx, 2, func(Message) (bool, error) { return true, nil })
+	if !errors.Is(err, ErrConnectionClosed) {
+		t.Fatalf("err = %v, want ErrConnectionClosed", err)
+	}
+	if len(got) != 1 || string(got[0].Data) != "bye" {
+		t.Errorf("got %v, want the unterminated trailing message", got)
+	}
+}
+
+func TestNodeSocketConnection_DialError(t *testing.T) {
+	ln := listenTCP(t)
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result := New(idwrap.NewNow(), "Sock", mflow.NodeSocketConnection{Address: addr}).RunSync(ctx, newReq())
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "socket dial tcp") {
+		t.Fatalf("err = %v, want a dial error", result.Err)
+	}
+}
+
+func TestFraming(t *testing.T) {
+	f, err := NewFraming(mflow.SocketFramingDelimiter, `\r\n`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	framed, _ := f.Frame([]byte("a"))
+	if string(framed) != "a\r\n" {
+		t.Errorf("framed %q", framed)
+	}
+	var got []string
+	s := f.scanner(strings.NewReader("one\r\ntwo\r\nrest"))
+	for s.Scan() {
+		got = append(got, s.Text())
+	}
+	if strings.Join(got, "|") != "one|two|rest" || s.Err() != nil {
+		t.Errorf("split %q, err %v", got, s.Err())
+	}
+
+	f, _ = NewFraming(mflow.SocketFramingLength, "", 1)
+	if _, err := f.Frame(make([]byte, 256)); err == nil {
+		t.Error("expected an error for a message too long for its prefix")
+	}
+	s = f.scanner(bytes.NewReader([]byte{2, 'h', 'i', 3, 'x'}))
+	if !s.Scan() || s.Text() != "hi" {
+		t.Fatalf("first message %q", s.Text())
+	}
+	if s.Scan() || !errors.Is(s.Err(), io.ErrUnexpectedEOF) {
+		t.Errorf("err = %v, want ErrUnexpectedEOF for a truncated message", s.Err())
+	}
+
+	if _, err := NewFraming(mflow.SocketFramingLength, "", 3); err == nil {
+		t.Error("expected an error for a 3 byte prefix")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	cases := []struct {
+		message  string
+		encoding mflow.SocketEncoding
+		want     []byte
+	}{
+		{"hi", mflow.SocketEncodingText, []byte("hi")},
+		{"de ad\nbe ef", mflow.SocketEncodingHex, []byte{0xde, 0xad, 0xbe, 0xef}},
+		{"aGk=", mflow.SocketEncodingBase64, []byte("hi")},
+	}
+	for _, c := range cases {
+		got, err := Encode(c.message, c.encoding)
+		if err != nil || !bytes.Equal(got, c.want) {
+			t.Errorf("Encode(%q, %d) = %v, %v; want %v", c.message, c.encoding, got, err, c.want)
+		}
+	}
+	if _, err := Encode("zz", mflow.SocketEncodingHex); err == nil {
+		t.Error("expected an error for invalid hex")
+	}
+}
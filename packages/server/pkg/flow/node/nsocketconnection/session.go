@@ -0,0 +1,281 @@
+package nsocketconnection
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// SessionVar is the internal variable a Socket Connection node stores its
+// Session under.
+const SessionVar = "_session"
+
+// maxMessages bounds the received messages a session keeps; older ones are
+// dropped and can no longer be matched.
+const maxMessages = 10000
+
+// maxDatagramSize is the read buffer for a UDP datagram.
+const maxDatagramSize = 64 * 1024
+
+// Message directions.
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+)
+
+// ErrConnectionClosed is returned when the peer closed the connection before
+// the messages waited for arrived.
+var ErrConnectionClosed = errors.New("socket connection closed")
+
+// Message is a message sent or received on a connection.
+type Message struct {
+	// Index is the position of a received message on its connection; sent
+	// messages share the index of the next message to be received.
+	Index     int
+	Direction string
+	Data      []byte
+	Time      time.Time
+}
+
+// Output is the message as exposed to expressions and node outputs: the
+// bytes as text, hex and base64, and parsed when they hold JSON.
+func (m Message) Output() map[string]any {
+	return map[string]any{
+		"index":     m.Index,
+		"direction": m.Direction,
+		"text":      string(m.Data),
+		"hex":       hex.EncodeToString(m.Data),
+		"base64":    base64.StdEncoding.EncodeToString(m.Data),
+		"json":      decodeJSON(m.Data),
+		"size":      len(m.Data),
+		"time":      m.Time.UnixMilli(),
+	}
+}
+
+// Session is what a Socket Connection node shares with the nodes using its
+// connection: the connection and the messages received on it so far.
+// Messages are matched at most once, and only those received after the
+// latest send.
+type Session struct {
+	Conn    net.Conn
+	Network mflow.SocketNetwork
+	Framing Framing
+
+	scanner *bufio.Scanner // TCP reads; only used by the reading goroutine
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	messages []Message
+	base     int // index of messages[0]
+	mark     int // index of the first message received after the latest send
+	consumed map[int]struct{}
+	changed  chan struct{}
+	closed   bool
+}
+
+// NewSession wraps conn. UDP connections ignore framing: each datagram is a
+// message.
+func NewSession(conn net.Conn, network mflow.SocketNetwork, framing Framing) *Session {
+	s := &Session{
+		Conn:     conn,
+		Network:  network,
+		Framing:  framing,
+		consumed: make(map[int]struct{}),
+		changed:  make(chan struct{}),
+	}
+	if network == mflow.SocketNetworkTCP {
+		s.scanner = framing.scanner(conn)
+	}
+	return s
+}
+
+// ReadSession returns the session of the Socket Connection node named name.
+func ReadSession(req *node.FlowNodeRequest, name string) (*Session, error) {
+	raw, err := node.ReadNodeVar(req, name, SessionVar)
+	if err != nil {
+		return nil, fmt.Errorf("read socket connection from node %q: %w", name, err)
+	}
+	s, ok := raw.(*Session)
+	if !ok {
+		return nil, fmt.Errorf("socket connection from node %q is not a valid socket connection", name)
+	}
+	return s, nil
+}
+
+// Send frames and writes a message and moves the start of matching past
+// every message received so far. It returns the message as sent, before
+// framing.
+func (s *Session) Send(ctx context.Context, data []byte) (Message, error) {
+	payload := data
+	if s.Network == mflow.SocketNetworkTCP {
+		var err error
+		if payload, err = s.Framing.Frame(data); err != nil {
+			return Message{}, err
+		}
+	}
+
+	s.mu.Lock()
+	s.mark = s.base + len(s.messages)
+	index := s.mark
+	s.mu.Unlock()
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.Conn.SetWriteDeadline(deadline)
+		defer s.Conn.SetWriteDeadline(time.Time{}) //nolint:errcheck // best-effort reset
+	}
+	if _, err := s.Conn.Write(payload); err != nil {
+		return Message{}, fmt.Errorf("socket write: %w", err)
+	}
+	return Message{Index: index, Direction: DirectionSent, Data: data, Time: time.Now()}, nil
+}
+
+// Read blocks for the next message, records it and returns it. Once it fails
+// the session is closed.
+func (s *Session) Read() (Message, error) {
+	data, err := s.read()
+	if err != nil {
+		s.close()
+		return Message{}, err
+	}
+
+	s.mu.Lock()
+	m := Message{
+		Index:     s.base + len(s.messages),
+		Direction: DirectionReceived,
+		Data:      data,
+		Time:      time.Now(),
+	}
+	s.messages = append(s.messages, m)
+	if len(s.messages) > maxMessages {
+		drop := len(s.messages) - maxMessages
+		for i := s.base; i < s.base+drop; i++ {
+			delete(s.consumed, i)
+		}
+		s.messages = append([]Message(nil), s.messages[drop:]...)
+		s.base += drop
+	}
+	s.notifyLocked()
+	s.mu.Unlock()
+	return m, nil
+}
+
+func (s *Session) read() ([]byte, error) {
+	if s.scanner == nil {
+		buf := make([]byte, maxDatagramSize)
+		n, err := s.Conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+	if !s.scanner.Scan() {
+		if err := s.scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrConnectionClosed
+	}
+	return append([]byte(nil), s.scanner.Bytes()...), nil
+}
+
+func (s *Session) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.notifyLocked()
+}
+
+func (s *Session) notifyLocked() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// Closed reports whether the connection is closed.
+func (s *Session) Closed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// Expect waits until count messages received after the latest send satisfy
+// match and marks them as matched. It returns the messages matched so far
+// with ErrConnectionClosed or the context's error when it gives up.
+func (s *Session) Expect(ctx context.Context, count int, match func(Message) (bool, error)) ([]Message, error) {
+	s.mu.Lock()
+	next := s.mark
+	s.mu.Unlock()
+
+	var matched []Message
+	for {
+		s.mu.Lock()
+		next = max(next, s.base)
+		pending := append([]Message(nil), s.messages[next-s.base:]...)
+		changed := s.changed
+		closed := s.closed
+		s.mu.Unlock()
+
+		for _, m := range pending {
+			next = m.Index + 1
+			if s.isConsumed(m.Index) {
+				continue
+			}
+			ok, err := match(m)
+			if err != nil {
+				return matched, err
+			}
+			if !ok {
+				continue
+			}
+			s.consume(m.Index)
+			matched = append(matched, m)
+			if len(matched) == count {
+				return matched, nil
+			}
+		}
+
+		if closed {
+			return matched, ErrConnectionClosed
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return matched, ctx.Err()
+		}
+	}
+}
+
+func (s *Session) isConsumed(index int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.consumed[index]
+	return ok
+}
+
+func (s *Session) consume(index int) {
+	s.mu.Lock()
+	s.consumed[index] = struct{}{}
+	s.mu.Unlock()
+}
+
+// decodeJSON parses data holding JSON, or returns nil.
+func decodeJSON(data []byte) any {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,161 @@
+//nolint:revive // exported
+package nsocketreceive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nsocketconnection"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// ErrReceiveTimeout is returned when fewer messages than expected matched
+// before the timeout. It is reported to error branches as a timeout.
+var ErrReceiveTimeout = errors.New("socket receive timed out")
+
+const (
+	// DefaultTimeoutMs is how long the node waits when no timeout is set.
+	DefaultTimeoutMs = 5000
+	// DefaultCount is the number of messages awaited when no count is set.
+	DefaultCount = 1
+)
+
+// Compile-time check that NodeSocketReceive implements VariableIntrospector.
+var _ node.VariableIntrospector = (*NodeSocketReceive)(nil)
+
+// NodeSocketReceive waits for Count messages received on the connection of a
+// SocketConnection node after its latest send that satisfy Match, and checks
+// Assertions against them. Match sees the candidate as "message", e.g.
+// `message.text == "PONG"` or `message.hex startsWith "ff"`; an empty Match
+// accepts any message.
+type NodeSocketReceive struct {
+	FlowNodeID               idwrap.IDWrap
+	Name                     string
+	SocketConnectionNodeName string
+	Match                    string
+	TimeoutMs                int64
+	Count                    int32
+	Assertions               []string
+}
+
+func New(id idwrap.IDWrap, name string, cfg mflow.NodeSocketReceive) *NodeSocketReceive {
+	return &NodeSocketReceive{
+		FlowNodeID:               id,
+		Name:                     name,
+		SocketConnectionNodeName: cfg.SocketConnectionNodeName,
+		Match:                    cfg.Match,
+		TimeoutMs:                cfg.TimeoutMs,
+		Count:                    cfg.Count,
+		Assertions:               cfg.Assertions,
+	}
+}
+
+func (n *NodeSocketReceive) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeSocketReceive) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeSocketReceive) GetName() string {
+	return n.Name
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeSocketReceive) GetRequiredVariables() []string {
+	return expression.ExtractVarKeysFromMultiple(n.SocketConnectionNodeName)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeSocketReceive) GetOutputVariables() []string {
+	return []string{
+		"messages",
+		"message",
+		"count",
+		"duration",
+	}
+}
+
+func (n *NodeSocketReceive) timeout() time.Duration {
+	if n.TimeoutMs > 0 {
+		return time.Duration(n.TimeoutMs) * time.Millisecond
+	}
+	return DefaultTimeoutMs * time.Millisecond
+}
+
+func (n *NodeSocketReceive) count() int {
+	if n.Count > 0 {
+		return int(n.Count)
+	}
+	return DefaultCount
+}
+
+func (n *NodeSocketReceive) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	session, err := nsocketconnection.ReadSession(req, n.SocketConnectionNodeName)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	varMapCopy := node.DeepCopyVarMap(req)
+	env := expression.NewUnifiedEnv(varMapCopy)
+
+	start := time.Now()
+	waitCtx, cancel := context.WithTimeout(ctx, n.timeout())
+	defer cancel()
+
+	messages, err := session.Expect(waitCtx, n.count(), func(m nsocketconnection.Message) (bool, error) {
+		return n.matches(ctx, env, m)
+	})
+	if err != nil {
+		what := fmt.Sprintf("%d of %d messages", len(messages), n.count())
+		return node.FlowNodeResult{Err: node.WaitError(ctx, ErrReceiveTimeout, what, n.SocketConnectionNodeName, n.timeout(), err)}
+	}
+
+	messageOutputs := make([]any, len(messages))
+	for i, m := range messages {
+		messageOutputs[i] = m.Output()
+	}
+	outputs := map[string]any{
+		"messages": messageOutputs,
+		"message":  messageOutputs[0],
+		"count":    len(messages),
+		"duration": time.Since(start).Milliseconds(),
+	}
+
+	if err := node.WriteNodeOutputs(req, n.Name, outputs); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	if err := node.AssertOutputs(ctx, n.Name, varMapCopy, outputs, n.Assertions); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified),
+	}
+}
+
+func (n *NodeSocketReceive) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+// matches evaluates Match with m as "message". An expression that cannot be
+// evaluated against m, e.g. because it is not JSON, does not match.
+func (n *NodeSocketReceive) matches(ctx context.Context, env *expression.UnifiedEnv, m nsocketconnection.Message) (bool, error) {
+	if n.Match == "" {
+		return true, nil
+	}
+	messageEnv := env.Clone()
+	messageEnv.GetData()["message"] = m.Output()
+	ok, err := messageEnv.EvalBool(ctx, n.Match)
+	if err != nil {
+		return false, nil //nolint:nilerr // a message the expression does not apply to is not a match
+	}
+	return ok, nil
+}
@@ -0,0 +1,129 @@
+package nsocketreceive
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nsocketconnection"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// probeServer answers "PING n" with a "STATUS" line followed by "PONG n", and
+// ignores anything else.
+func probeServer(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s := bufio.NewScanner(conn)
+		for s.Scan() {
+			var n int
+			if _, err := fmt.Sscanf(s.Text(), "PING %d", &n); err == nil {
+				fmt.Fprintf(conn, "STATUS ok\nPONG %d\n", n)
+			}
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func newReq() *node.FlowNodeRequest {
+	return &node.FlowNodeRequest{
+		VarMap:           make(map[string]any),
+		ReadWriteLock:    &sync.RWMutex{},
+		EdgeSourceMap:    mflow.EdgesMap{},
+		Timeout:          10 * time.Second,
+		PendingAtmoicMap: make(map[idwrap.IDWrap]uint32),
+		PendingMapMu:     &sync.Mutex{},
+	}
+}
+
+func connect(t *testing.T, ctx context.Context) (*node.FlowNodeRequest, *nsocketconnection.Session) {
+	t.Helper()
+	req := newReq()
+	conn := nsocketconnection.New(idwrap.NewNow(), "Probe", mflow.NodeSocketConnection{
+		Address: probeServer(t),
+		Framing: mflow.SocketFramingDelimiter,
+	})
+	if result := conn.RunSync(ctx, req); result.Err != nil {
+		t.Fatalf("connect: %v", result.Err)
+	}
+	session, err := nsocketconnection.ReadSession(req, "Probe")
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+	return req, session
+}
+
+func TestNodeSocketReceive_Match(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, session := connect(t, ctx)
+	if _, err := session.Send(ctx, []byte("PING 7")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	n := New(idwrap.NewNow(), "Receive", mflow.NodeSocketReceive{
+		SocketConnectionNodeName: "Probe",
+		Match:                    `message.text startsWith "PONG"`,
+		Assertions:               []string{`message.text == "PONG 7"`, "Receive.count == 1"},
+	})
+	if result := n.RunSync(ctx, req); result.Err != nil {
+		t.Fatalf("RunSync error: %v", result.Err)
+	}
+	msg, err := node.ReadNodeVar(req, "Receive", "message")
+	if err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	if out := msg.(map[string]any); out["hex"] != "504f4e472037" {
+		t.Errorf("message = %v", out)
+	}
+}
+
+func TestNodeSocketReceive_AssertionFails(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, session := connect(t, ctx)
+	if _, err := session.Send(ctx, []byte("PING 1")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	n := New(idwrap.NewNow(), "Receive", mflow.NodeSocketReceive{
+		SocketConnectionNodeName: "Probe",
+		Assertions:               []string{`message.text == "PONG 1"`},
+	})
+	result := n.RunSync(ctx, req)
+	if !errors.Is(result.Err, node.ErrAssertionFailed) {
+		t.Fatalf("err = %v, want an assertion failure on the STATUS line", result.Err)
+	}
+}
+
+func TestNodeSocketReceive_Timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := connect(t, ctx)
+
+	n := New(idwrap.NewNow(), "Receive", mflow.NodeSocketReceive{
+		SocketConnectionNodeName: "Probe",
+		TimeoutMs:                50,
+	})
+	result := n.RunSync(ctx, req)
+	if !errors.Is(result.Err, ErrReceiveTimeout) || !errors.Is(result.Err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want ErrReceiveTimeout", result.Err)
+	}
+}
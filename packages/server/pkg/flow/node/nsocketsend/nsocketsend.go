@@ -0,0 +1,109 @@
+//nolint:revive // exported
+package nsocketsend
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nsocketconnection"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// Compile-time check that NodeSocketSend implements VariableIntrospector.
+var _ node.VariableIntrospector = (*NodeSocketSend)(nil)
+
+// NodeSocketSend writes a message on the connection of a SocketConnection
+// node. The interpolated message is decoded according to Encoding and framed
+// as the connection frames messages.
+type NodeSocketSend struct {
+	FlowNodeID               idwrap.IDWrap
+	Name                     string
+	SocketConnectionNodeName string
+	Message                  string
+	Encoding                 mflow.SocketEncoding
+}
+
+func New(id idwrap.IDWrap, name string, cfg mflow.NodeSocketSend) *NodeSocketSend {
+	return &NodeSocketSend{
+		FlowNodeID:               id,
+		Name:                     name,
+		SocketConnectionNodeName: cfg.SocketConnectionNodeName,
+		Message:                  cfg.Message,
+		Encoding:                 cfg.Encoding,
+	}
+}
+
+func (n *NodeSocketSend) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeSocketSend) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeSocketSend) GetName() string {
+	return n.Name
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeSocketSend) GetRequiredVariables() []string {
+	return expression.ExtractVarKeysFromMultiple(n.Message, n.SocketConnectionNodeName)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeSocketSend) GetOutputVariables() []string {
+	return []string{
+		"type",
+		"message",
+		"hex",
+		"size",
+		"connectionNode",
+	}
+}
+
+func (n *NodeSocketSend) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	session, err := nsocketconnection.ReadSession(req, n.SocketConnectionNodeName)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	varMapCopy := node.DeepCopyVarMap(req)
+	env := expression.NewUnifiedEnv(varMapCopy)
+	interpolated, err := env.InterpolateCtx(ctx, n.Message)
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate message: %w", err)}
+	}
+	payload, err := nsocketconnection.Encode(interpolated, n.Encoding)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	// Sending through the session moves SocketReceive matching past every
+	// message received before it.
+	if _, err := session.Send(ctx, payload); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	outputs := map[string]any{
+		"type":           nsocketconnection.DirectionSent,
+		"message":        interpolated,
+		"hex":            hex.EncodeToString(payload),
+		"size":           len(payload),
+		"connectionNode": n.SocketConnectionNodeName,
+	}
+	if err := node.WriteNodeOutputs(req, n.Name, outputs); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified),
+	}
+}
+
+func (n *NodeSocketSend) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
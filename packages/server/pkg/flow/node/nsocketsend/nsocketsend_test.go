@@ -0,0 +1,91 @@
+package nsocketsend
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nsocketconnection"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func newReq() *node.FlowNodeRequest {
+	return &node.FlowNodeRequest{
+		VarMap:           make(map[string]any),
+		ReadWriteLock:    &sync.RWMutex{},
+		EdgeSourceMap:    mflow.EdgesMap{},
+		Timeout:          10 * time.Second,
+		PendingAtmoicMap: make(map[idwrap.IDWrap]uint32),
+		PendingMapMu:     &sync.Mutex{},
+	}
+}
+
+func TestNodeSocketSend_HexLengthFramed(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(conn, buf); err == nil {
+			received <- buf
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := newReq()
+	conn := nsocketconnection.New(idwrap.NewNow(), "Sock", mflow.NodeSocketConnection{
+		Address:     ln.Addr().String(),
+		Framing:     mflow.SocketFramingLength,
+		LengthBytes: 1,
+	})
+	if result := conn.RunSync(ctx, req); result.Err != nil {
+		t.Fatalf("connect: %v", result.Err)
+	}
+
+	req.VarMap["payload"] = "beef"
+	n := New(idwrap.NewNow(), "Send", mflow.NodeSocketSend{
+		SocketConnectionNodeName: "Sock",
+		Message:                  "ca {{ payload }}",
+		Encoding:                 mflow.SocketEncodingHex,
+	})
+	if result := n.RunSync(ctx, req); result.Err != nil {
+		t.Fatalf("RunSync error: %v", result.Err)
+	}
+
+	select {
+	case got := <-received:
+		if want := []byte{3, 0xca, 0xbe, 0xef}; string(got) != string(want) {
+			t.Errorf("server got %x, want %x", got, want)
+		}
+	case <-ctx.Done():
+		t.Fatal("server received nothing")
+	}
+	if v, _ := node.ReadNodeVar(req, "Send", "hex"); v != "cabeef" {
+		t.Errorf("hex = %v, want cabeef", v)
+	}
+	if v, _ := node.ReadNodeVar(req, "Send", "size"); v != 3 {
+		t.Errorf("size = %v, want 3", v)
+	}
+}
+
+func TestNodeSocketSend_NoConnection(t *testing.T) {
+	n := New(idwrap.NewNow(), "Send", mflow.NodeSocketSend{SocketConnectionNodeName: "Missing", Message: "hi"})
+	if result := n.RunSync(context.Background(), newReq()); result.Err == nil {
+		t.Fatal("expected an error without a connection")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwebhook"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
 )
@@ -14,6 +15,8 @@ import (
 // NodeSubFlowReturn is a terminal node in a sub-flow. It evaluates output
 // expressions against the sub-flow's VarMap and writes results under the node
 // name. The RunSubFlow caller reads these outputs after execution completes.
+// In a flow started by a webhook, the outputs also become the reply to the
+// webhook caller.
 type NodeSubFlowReturn struct {
 	FlowNodeID idwrap.IDWrap
 	Name       string
@@ -68,6 +71,10 @@ func (n *NodeSubFlowReturn) RunSync(ctx context.Context, req *node.FlowNodeReque
 		}
 	}
 
+	if ex := nwebhook.ExchangeFromContext(ctx); ex != nil {
+		ex.Respond(outputData)
+	}
+
 	// Terminal node — no next nodes
 	return node.FlowNodeResult{}
 }
@@ -0,0 +1,125 @@
+//nolint:revive // exported
+package nswitch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// DefaultLabel is the "case" output of a switch node when no case matches.
+const DefaultLabel = "default"
+
+// NodeSwitch evaluates its cases in order and follows the edge on the handle
+// of the first case whose expression holds, or the default handle when none
+// does. Cases with an empty expression never match.
+type NodeSwitch struct {
+	FlowNodeID idwrap.IDWrap
+	Name       string
+	Cases      []mflow.SwitchCase
+}
+
+func New(id idwrap.IDWrap, name string, cases []mflow.SwitchCase) *NodeSwitch {
+	return &NodeSwitch{
+		FlowNodeID: id,
+		Name:       name,
+		Cases:      cases,
+	}
+}
+
+func (n *NodeSwitch) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeSwitch) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeSwitch) GetName() string {
+	return n.Name
+}
+
+func (n *NodeSwitch) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	env := expression.NewUnifiedEnv(node.DeepCopyVarMap(req))
+	if req.VariableTracker != nil {
+		env = env.WithTracking(req.VariableTracker)
+	}
+
+	matched := -1
+	for i, c := range n.Cases {
+		if c.Expression == "" {
+			continue
+		}
+		ok, err := env.EvalBool(ctx, c.Expression)
+		if err != nil {
+			return node.FlowNodeResult{
+				Err: fmt.Errorf("failed to evaluate case %q expression '%s': %w", c.Label(i), c.Expression, err),
+			}
+		}
+		if ok {
+			matched = i
+			break
+		}
+	}
+
+	label := DefaultLabel
+	handle := mflow.HandleDefault
+	if matched >= 0 {
+		label = n.Cases[matched].Label(matched)
+		handle = mflow.HandleCase(matched)
+	}
+
+	outputData := map[string]any{
+		"case":  label,
+		"index": matched,
+	}
+	var err error
+	if req.VariableTracker != nil {
+		err = node.WriteNodeVarBulkWithTracking(req, n.Name, outputData, req.VariableTracker)
+	} else {
+		err = node.WriteNodeVarBulk(req, n.Name, outputData)
+	}
+	if err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("failed to write node output: %w", err)}
+	}
+
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, handle),
+	}
+}
+
+func (n *NodeSwitch) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+// It extracts variable references from every case expression.
+func (n *NodeSwitch) GetRequiredVariables() []string {
+	var vars []string
+	seen := make(map[string]bool)
+	for _, c := range n.Cases {
+		if c.Expression == "" {
+			continue
+		}
+		for _, v := range expression.ExtractExprIdentifiers(c.Expression) {
+			if !seen[v] {
+				seen[v] = true
+				vars = append(vars, v)
+			}
+		}
+	}
+	return vars
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+// "case" is the matched case's label and "index" its position, -1 for default.
+func (n *NodeSwitch) GetOutputVariables() []string {
+	return []string{
+		"case",
+		"index",
+	}
+}
@@ -0,0 +1,105 @@
+package nswitch_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nswitch"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// runSwitch wires one target per case plus a default target and runs the
+// switch against varMap. It returns the result and the target IDs, the
+// default target last.
+func runSwitch(t *testing.T, cases []mflow.SwitchCase, varMap map[string]any) (node.FlowNodeResult, []idwrap.IDWrap) {
+	t.Helper()
+	id := idwrap.NewNow()
+	var edges []mflow.Edge
+	var targets []idwrap.IDWrap
+	for i := range cases {
+		target := idwrap.NewNow()
+		targets = append(targets, target)
+		edges = append(edges, mflow.NewEdge(idwrap.NewNow(), id, target, mflow.HandleCase(i)))
+	}
+	defaultTarget := idwrap.NewNow()
+	targets = append(targets, defaultTarget)
+	edges = append(edges, mflow.NewEdge(idwrap.NewNow(), id, defaultTarget, mflow.HandleDefault))
+
+	req := &node.FlowNodeRequest{
+		VarMap:        varMap,
+		ReadWriteLock: &sync.RWMutex{},
+		EdgeSourceMap: mflow.NewEdgesMap(edges),
+	}
+	return nswitch.New(id, "route", cases).RunSync(context.Background(), req), targets
+}
+
+var statusCases = []mflow.SwitchCase{
+	{Name: "ok", Expression: "status == 200"},
+	{Name: "missing", Expression: "status == 404"},
+	{Expression: "status >= 400"},
+}
+
+func TestSwitchFollowsFirstMatchingCase(t *testing.T) {
+	varMap := map[string]any{"status": 404}
+	res, targets := runSwitch(t, statusCases, varMap)
+
+	require.NoError(t, res.Err)
+	require.Equal(t, []idwrap.IDWrap{targets[1]}, res.NextNodeID)
+	require.Equal(t, map[string]any{"case": "missing", "index": 1}, varMap["route"])
+}
+
+func TestSwitchLabelsUnnamedCaseByIndex(t *testing.T) {
+	varMap := map[string]any{"status": 500}
+	res, targets := runSwitch(t, statusCases, varMap)
+
+	require.NoError(t, res.Err)
+	require.Equal(t, []idwrap.IDWrap{targets[2]}, res.NextNodeID)
+	require.Equal(t, "case_2", varMap["route"].(map[string]any)["case"])
+}
+
+func TestSwitchFallsBackToDefault(t *testing.T) {
+	varMap := map[string]any{"status": 302}
+	res, targets := runSwitch(t, statusCases, varMap)
+
+	require.NoError(t, res.Err)
+	require.Equal(t, []idwrap.IDWrap{targets[3]}, res.NextNodeID)
+	require.Equal(t, map[string]any{"case": nswitch.DefaultLabel, "index": -1}, varMap["route"])
+}
+
+func TestSwitchSkipsEmptyExpressions(t *testing.T) {
+	cases := []mflow.SwitchCase{{Name: "blank"}, {Name: "any", Expression: "true"}}
+	res, targets := runSwitch(t, cases, map[string]any{})
+
+	require.NoError(t, res.Err)
+	require.Equal(t, []idwrap.IDWrap{targets[1]}, res.NextNodeID)
+}
+
+func TestSwitchFailsOnInvalidExpression(t *testing.T) {
+	cases := []mflow.SwitchCase{{Name: "bad", Expression: "status +"}}
+	res, _ := runSwitch(t, cases, map[string]any{"status": 200})
+
+	require.ErrorContains(t, res.Err, `case "bad"`)
+}
+
+func TestSwitchRequiredVariables(t *testing.T) {
+	n := nswitch.New(idwrap.NewNow(), "route", []mflow.SwitchCase{
+		{Expression: "req.response.status == 200"},
+		{Expression: "req.response.status == 404 && retry"},
+	})
+	vars := n.GetRequiredVariables()
+	require.Len(t, vars, len(uniq(vars)))
+	require.Contains(t, vars, "retry")
+}
+
+func uniq(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,58 @@
+//nolint:revive // exported
+package nteardown
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// NodeTeardown is the entry of a flow's cleanup section. The runner does not
+// start it with the other entry nodes; it runs the nodes connected to it after
+// the flow has ended, with the flow's variables still in place, so cleanup
+// happens even when an assertion failed or the run was canceled.
+type NodeTeardown struct {
+	FlowNodeID idwrap.IDWrap
+	Name       string
+}
+
+var _ node.TeardownEntry = (*NodeTeardown)(nil)
+
+func New(id idwrap.IDWrap, name string) *NodeTeardown {
+	return &NodeTeardown{
+		FlowNodeID: id,
+		Name:       name,
+	}
+}
+
+func (n *NodeTeardown) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeTeardown) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeTeardown) GetName() string {
+	return n.Name
+}
+
+func (n *NodeTeardown) IsEntryNode() bool {
+	return true
+}
+
+func (n *NodeTeardown) IsTeardownEntry() bool {
+	return true
+}
+
+func (n *NodeTeardown) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified),
+	}
+}
+
+func (n *NodeTeardown) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
@@ -0,0 +1,77 @@
+//nolint:revive // exported
+package ntry
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// NodeTry runs the nodes connected to its loop handle once, as its body. When
+// the body succeeds the flow follows the then handle. When a body node fails
+// the try node fails with that node's error, so the runner follows the error
+// handle (the catch branch) with "<try>.error" describing the failure. Without
+// a catch branch the failure ends the flow as usual.
+type NodeTry struct {
+	FlowNodeID idwrap.IDWrap
+	Name       string
+}
+
+func New(id idwrap.IDWrap, name string) *NodeTry {
+	return &NodeTry{
+		FlowNodeID: id,
+		Name:       name,
+	}
+}
+
+func (n *NodeTry) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeTry) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeTry) GetName() string {
+	return n.Name
+}
+
+// IsLoopCoordinator exempts the try node from the per-node timeout; the nodes
+// of its body keep their own.
+func (n *NodeTry) IsLoopCoordinator() bool {
+	return true
+}
+
+func (n *NodeTry) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	return n.run(ctx, req, flowlocalrunner.RunNodeSync)
+}
+
+func (n *NodeTry) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.run(ctx, req, flowlocalrunner.RunNodeASync)
+}
+
+func (n *NodeTry) run(ctx context.Context, req *node.FlowNodeRequest, runBody node.RunFunc) node.FlowNodeResult {
+	bodyTargets := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleLoop)
+	bodyTargets = node.FilterLoopEntryNodes(req.EdgeSourceMap, bodyTargets)
+	bodyEdgeMap := node.BuildLoopExecutionEdgeMap(req.EdgeSourceMap, n.FlowNodeID, bodyTargets)
+	predecessorMap := flowlocalrunner.BuildPredecessorMap(bodyEdgeMap)
+	pendingTemplate := node.BuildPendingMap(predecessorMap)
+
+	for _, entryID := range bodyTargets {
+		bodyReq := *req
+		bodyReq.EdgeSourceMap = bodyEdgeMap
+		bodyReq.PendingAtmoicMap = node.ClonePendingMap(pendingTemplate)
+		bodyReq.ExecutionID = idwrap.NewMonotonic()
+
+		if err := runBody(ctx, entryID, &bodyReq, req.LogPushFunc, predecessorMap); err != nil {
+			return node.FlowNodeResult{Err: err}
+		}
+	}
+
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleThen),
+	}
+}
@@ -0,0 +1,137 @@
+package ntry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nstart"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// testNode writes its response, records that it ran and fails with err when set.
+type testNode struct {
+	id   idwrap.IDWrap
+	name string
+	err  error
+	ran  map[string]bool
+}
+
+func (n *testNode) GetID() idwrap.IDWrap { return n.id }
+
+func (n *testNode) GetName() string { return n.name }
+
+func (n *testNode) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	req.ReadWriteLock.Lock()
+	n.ran[n.name] = true
+	req.ReadWriteLock.Unlock()
+	if err := node.WriteNodeVar(req, n.name, "response", map[string]any{"status": float64(409)}); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	if n.err != nil {
+		return node.FlowNodeResult{Err: n.err}
+	}
+	return node.FlowNodeResult{NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.id, mflow.HandleUnspecified)}
+}
+
+func (n *testNode) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+type tryFlow struct {
+	nodes map[idwrap.IDWrap]node.FlowNode
+	edges []mflow.Edge
+	ids   map[string]idwrap.IDWrap
+	ran   map[string]bool
+}
+
+// newTryFlow builds start -> try, with try's body create -> check, catch
+// cleanup and then after. check fails with checkErr when it is set.
+func newTryFlow(checkErr error, withCatch bool) *tryFlow {
+	f := &tryFlow{
+		nodes: make(map[idwrap.IDWrap]node.FlowNode),
+		ids:   make(map[string]idwrap.IDWrap),
+		ran:   make(map[string]bool),
+	}
+	for _, name := range []string{"start", "try", "create", "check", "cleanup", "after"} {
+		f.ids[name] = idwrap.NewNow()
+	}
+	f.nodes[f.ids["start"]] = nstart.New(f.ids["start"], "start")
+	f.nodes[f.ids["try"]] = New(f.ids["try"], "try")
+	for _, name := range []string{"create", "check", "cleanup", "after"} {
+		n := &testNode{id: f.ids[name], name: name, ran: f.ran}
+		if name == "check" {
+			n.err = checkErr
+		}
+		f.nodes[n.id] = n
+	}
+
+	f.edge("start", "try", mflow.HandleUnspecified)
+	f.edge("try", "create", mflow.HandleLoop)
+	f.edge("create", "check", mflow.HandleUnspecified)
+	f.edge("try", "after", mflow.HandleThen)
+	if withCatch {
+		f.edge("try", "cleanup", mflow.HandleError)
+	}
+	return f
+}
+
+func (f *tryFlow) edge(source, target string, handle mflow.EdgeHandle) {
+	f.edges = append(f.edges, mflow.NewEdge(idwrap.NewNow(), f.ids[source], f.ids[target], handle))
+}
+
+func (f *tryFlow) run(t *testing.T) (map[string]any, error) {
+	t.Helper()
+	flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), []idwrap.IDWrap{f.ids["start"]}, f.nodes, mflow.NewEdgesMap(f.edges), 0, nil)
+
+	statusChan := make(chan runner.FlowNodeStatus, 64)
+	flowStatusChan := make(chan runner.FlowStatus, 8)
+	vars := make(map[string]any)
+	err := flowRunner.Run(context.Background(), statusChan, flowStatusChan, vars)
+	for range statusChan {
+	}
+	for range flowStatusChan {
+	}
+	return vars, err
+}
+
+func TestTryBodySucceeds(t *testing.T) {
+	f := newTryFlow(nil, true)
+	_, err := f.run(t)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"create": true, "check": true, "after": true}, f.ran)
+}
+
+func TestTryCatchesBodyFailure(t *testing.T) {
+	f := newTryFlow(errors.New("user already exists"), true)
+	vars, err := f.run(t)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"create": true, "check": true, "cleanup": true}, f.ran)
+
+	tryVars, ok := vars["try"].(map[string]any)
+	require.True(t, ok, "try writes its output: %#v", vars)
+	require.Equal(t, map[string]any{
+		"kind":     node.ErrorKindError,
+		"message":  "user already exists",
+		"node":     "check",
+		"response": map[string]any{"status": float64(409)},
+	}, tryVars[node.OutputError])
+}
+
+func TestTryWithoutCatchFailsFlow(t *testing.T) {
+	checkErr := errors.New("user already exists")
+	f := newTryFlow(checkErr, false)
+	_, err := f.run(t)
+	require.ErrorIs(t, err, checkErr)
+	require.False(t, f.ran["after"])
+
+	var nodeErr *runner.NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	require.Equal(t, "check", nodeErr.Name)
+}
@@ -0,0 +1,329 @@
+package nwebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// MaxBodySize caps how much of an inbound webhook body is read.
+const MaxBodySize = 10 << 20
+
+var (
+	ErrMethodNotAllowed = errors.New("webhook method not allowed")
+	ErrInvalidSignature = errors.New("webhook signature is invalid")
+	ErrBodyTooLarge     = errors.New("webhook body is too large")
+)
+
+// Request is the inbound HTTP request as seen by the flow.
+type Request struct {
+	Method  string
+	Path    string
+	Headers map[string]any
+	Query   map[string]any
+	Body    any
+}
+
+// ToMap returns the node output for the request.
+func (r Request) ToMap() map[string]any {
+	headers := r.Headers
+	if headers == nil {
+		headers = map[string]any{}
+	}
+	query := r.Query
+	if query == nil {
+		query = map[string]any{}
+	}
+	return map[string]any{
+		"method":  r.Method,
+		"path":    r.Path,
+		"headers": headers,
+		"query":   query,
+		"body":    r.Body,
+	}
+}
+
+// Response is the synchronous reply a flow sends back to the webhook caller.
+type Response struct {
+	Status  int
+	Headers map[string]string
+	Body    any
+}
+
+// Exchange ties an inbound request to the reply the flow produces for it.
+// The first Respond call wins; later ones are ignored.
+type Exchange struct {
+	Request Request
+	// TriggerID is the webhook node the request arrived on. When set, other
+	// webhook nodes of the flow do not start their branches.
+	TriggerID idwrap.IDWrap
+
+	mu        sync.Mutex
+	response  *Response
+	responded chan struct{}
+}
+
+func NewExchange(request Request) *Exchange {
+	return &Exchange{Request: request, responded: make(chan struct{})}
+}
+
+// Respond records the reply from a return node's outputs. The outputs
+// "status", "headers" and "body" shape the reply; when none of them is set,
+// all outputs are sent as a JSON body with status 200.
+func (e *Exchange) Respond(outputs map[string]any) {
+	resp := responseFromOutputs(outputs)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.response == nil {
+		e.response = &resp
+		close(e.responded)
+	}
+}
+
+// Responded is closed once the flow has recorded its reply, which lets the
+// caller answer before the rest of the flow has finished.
+func (e *Exchange) Responded() <-chan struct{} {
+	return e.responded
+}
+
+// Response returns the recorded reply, if any.
+func (e *Exchange) Response() (Response, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.response == nil {
+		return Response{}, false
+	}
+	return *e.response, true
+}
+
+// WriteResponse writes the recorded reply to w. Without one it answers 204
+// when the run succeeded and 500 with the error otherwise.
+func (e *Exchange) WriteResponse(w http.ResponseWriter, runErr error) {
+	resp, ok := e.Response()
+	if !ok {
+		if runErr != nil {
+			WriteError(w, http.StatusInternalServerError, runErr)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	for k, v := range resp.Headers {
+		w.Header().Set(k, v)
+	}
+	var payload []byte
+	switch body := resp.Body.(type) {
+	case nil:
+	case string:
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		payload = []byte(body)
+	case []byte:
+		payload = body
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			WriteError(w, http.StatusInternalServerError, fmt.Errorf("encode webhook response: %w", err))
+			return
+		}
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		payload = data
+	}
+	w.WriteHeader(resp.Status)
+	if len(payload) > 0 {
+		_, _ = w.Write(payload)
+	}
+}
+
+// WriteError answers a webhook caller with a JSON error.
+func WriteError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// Accept validates r against the trigger configuration and returns the
+// exchange to run the flow with. The returned status is the HTTP status to
+// answer with when err is non-nil.
+func Accept(r *http.Request, trigger mflow.NodeWebhookTrigger) (*Exchange, int, error) {
+	if trigger.Method != "" && !strings.EqualFold(trigger.Method, r.Method) {
+		return nil, http.StatusMethodNotAllowed, fmt.Errorf("%w: expected %s, got %s", ErrMethodNotAllowed, strings.ToUpper(trigger.Method), r.Method)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("read webhook body: %w", err)
+	}
+	if len(body) > MaxBodySize {
+		return nil, http.StatusRequestEntityTooLarge, ErrBodyTooLarge
+	}
+
+	if trigger.Secret != "" {
+		if err := VerifySignature(trigger.Secret, r.Header.Get(trigger.SignatureHeaderName()), body); err != nil {
+			return nil, http.StatusUnauthorized, err
+		}
+	}
+
+	ex := NewExchange(Request{
+		Method:  r.Method,
+		Path:    mflow.NormalizeWebhookPath(trigger.Path),
+		Headers: headersToMap(r.Header),
+		Query:   queryToMap(r),
+		Body:    decodeBody(body),
+	})
+	ex.TriggerID = trigger.FlowNodeID
+	return ex, http.StatusOK, nil
+}
+
+// VerifySignature checks a hex HMAC-SHA256 of body keyed with secret. The
+// signature may carry a "sha256=" prefix as sent by GitHub and others.
+func VerifySignature(secret, signature string, body []byte) error {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	if signature == "" {
+		return fmt.Errorf("%w: missing signature", ErrInvalidSignature)
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%w: signature is not hex", ErrInvalidSignature)
+	}
+	if !hmac.Equal(got, Sign(secret, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns the HMAC-SHA256 of body keyed with secret.
+func Sign(secret string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func headersToMap(h http.Header) map[string]any {
+	out := make(map[string]any, len(h))
+	for k, v := range h {
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+func queryToMap(r *http.Request) map[string]any {
+	values := r.URL.Query()
+	out := make(map[string]any, len(values))
+	for k, v := range values {
+		if len(v) == 1 {
+			out[k] = v[0]
+			continue
+		}
+		list := make([]any, len(v))
+		for i, s := range v {
+			list[i] = s
+		}
+		out[k] = list
+	}
+	return out
+}
+
+// decodeBody returns the body as JSON when it parses as JSON and as a string
+// otherwise.
+func decodeBody(body []byte) any {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(trimmed, &v); err == nil {
+		return v
+	}
+	return string(body)
+}
+
+func responseFromOutputs(outputs map[string]any) Response {
+	status, hasStatus := outputs["status"]
+	headers, hasHeaders := outputs["headers"]
+	body, hasBody := outputs["body"]
+	if !hasStatus && !hasHeaders && !hasBody {
+		return Response{Status: http.StatusOK, Body: outputs}
+	}
+
+	resp := Response{Status: http.StatusOK, Body: body}
+	if code, ok := toStatus(status); ok {
+		resp.Status = code
+	}
+	if m, ok := headers.(map[string]any); ok {
+		resp.Headers = make(map[string]string, len(m))
+		for k, v := range m {
+			resp.Headers[k] = fmt.Sprint(v)
+		}
+	}
+	return resp
+}
+
+func toStatus(v any) (int, bool) {
+	var code int
+	switch s := v.(type) {
+	case int:
+		code = s
+	case int64:
+		code = int(s)
+	case float64:
+		code = int(s)
+	case json.Number:
+		i, err := s.Int64()
+		if err != nil {
+			return 0, false
+		}
+		code = int(i)
+	case string:
+		i, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return 0, false
+		}
+		code = i
+	default:
+		return 0, false
+	}
+	if code < 100 || code > 999 {
+		return 0, false
+	}
+	return code, true
+}
+
+type exchangeKey struct{}
+
+// WithExchange attaches a webhook exchange to a flow run context.
+func WithExchange(ctx context.Context, ex *Exchange) context.Context {
+	return context.WithValue(ctx, exchangeKey{}, ex)
+}
+
+// WithoutExchange hides the exchange from ctx, so nested sub-flow runs do not
+// reply to the caller of their parent.
+func WithoutExchange(ctx context.Context) context.Context {
+	if ExchangeFromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, exchangeKey{}, (*Exchange)(nil))
+}
+
+// ExchangeFromContext returns the exchange of the run, or nil.
+func ExchangeFromContext(ctx context.Context) *Exchange {
+	ex, _ := ctx.Value(exchangeKey{}).(*Exchange)
+	return ex
+}
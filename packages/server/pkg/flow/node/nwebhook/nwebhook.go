@@ -0,0 +1,95 @@
+//nolint:revive // exported
+package nwebhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// TriggerType is the trigger kind reported by webhook entry nodes.
+const TriggerType = "webhook"
+
+// NodeWebhookTrigger is the entry node of a flow started by an inbound HTTP
+// request. The server (or the CLI listener) accepts the request, attaches it
+// to the run context as an Exchange and starts the flow; this node exposes
+// the request as its output. A manual run without a request sees an empty
+// request so downstream nodes can still be exercised.
+type NodeWebhookTrigger struct {
+	FlowNodeID idwrap.IDWrap
+	Name       string
+	Config     mflow.NodeWebhookTrigger
+}
+
+func New(id idwrap.IDWrap, name string, config mflow.NodeWebhookTrigger) *NodeWebhookTrigger {
+	return &NodeWebhookTrigger{
+		FlowNodeID: id,
+		Name:       name,
+		Config:     config,
+	}
+}
+
+func (n *NodeWebhookTrigger) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeWebhookTrigger) GetName() string {
+	return n.Name
+}
+
+func (n *NodeWebhookTrigger) IsEntryNode() bool {
+	return true
+}
+
+// TriggerType implements node.TriggerEntry.
+func (n *NodeWebhookTrigger) TriggerType() string {
+	return TriggerType
+}
+
+func (n *NodeWebhookTrigger) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	request := Request{Path: mflow.NormalizeWebhookPath(n.Config.Path)}
+	if ex := ExchangeFromContext(ctx); ex != nil {
+		if ex.TriggerID.Compare(idwrap.IDWrap{}) != 0 && ex.TriggerID != n.FlowNodeID {
+			// The request arrived on another webhook node of this flow.
+			return node.FlowNodeResult{}
+		}
+		request = ex.Request
+	}
+
+	output := request.ToMap()
+	if req.VariableTracker != nil {
+		if err := node.WriteNodeVarBulkWithTracking(req, n.Name, output, req.VariableTracker); err != nil {
+			return node.FlowNodeResult{Err: fmt.Errorf("failed to write webhook request: %w", err)}
+		}
+	} else {
+		if err := node.WriteNodeVarBulk(req, n.Name, output); err != nil {
+			return node.FlowNodeResult{Err: fmt.Errorf("failed to write webhook request: %w", err)}
+		}
+	}
+
+	nextID := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified)
+	return node.FlowNodeResult{NextNodeID: nextID}
+}
+
+func (n *NodeWebhookTrigger) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeWebhookTrigger) GetRequiredVariables() []string {
+	return nil
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeWebhookTrigger) GetOutputVariables() []string {
+	return []string{
+		n.Name + ".method",
+		n.Name + ".path",
+		n.Name + ".headers",
+		n.Name + ".query",
+		n.Name + ".body",
+	}
+}
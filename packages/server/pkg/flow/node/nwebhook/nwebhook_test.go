@@ -0,0 +1,162 @@
+package nwebhook_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwebhook"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func runTrigger(t *testing.T, ctx context.Context, config mflow.NodeWebhookTrigger) (node.FlowNodeResult, map[string]any, idwrap.IDWrap) {
+	t.Helper()
+	id := idwrap.NewNow()
+	next := idwrap.NewNow()
+	varMap := map[string]any{}
+	req := &node.FlowNodeRequest{
+		VarMap:        varMap,
+		ReadWriteLock: &sync.RWMutex{},
+		EdgeSourceMap: mflow.NewEdgesMap([]mflow.Edge{mflow.NewEdge(idwrap.NewNow(), id, next, mflow.HandleUnspecified)}),
+	}
+	return nwebhook.New(id, "hook", config).RunSync(ctx, req), varMap, next
+}
+
+func TestTriggerExposesRequest(t *testing.T) {
+	config := mflow.NodeWebhookTrigger{Path: "orders", Method: http.MethodPost}
+	r := httptest.NewRequest(http.MethodPost, "/webhook/orders?id=7&tag=a&tag=b", strings.NewReader(`{"total":3}`))
+	r.Header.Set("X-Event", "created")
+	ex, _, err := nwebhook.Accept(r, config)
+	require.NoError(t, err)
+
+	res, varMap, next := runTrigger(t, nwebhook.WithExchange(context.Background(), ex), config)
+
+	require.NoError(t, res.Err)
+	require.Equal(t, []idwrap.IDWrap{next}, res.NextNodeID)
+	out := varMap["hook"].(map[string]any)
+	require.Equal(t, http.MethodPost, out["method"])
+	require.Equal(t, "/orders", out["path"])
+	require.Equal(t, "created", out["headers"].(map[string]any)["X-Event"])
+	require.Equal(t, map[string]any{"id": "7", "tag": []any{"a", "b"}}, out["query"])
+	require.Equal(t, map[string]any{"total": float64(3)}, out["body"])
+}
+
+func TestTriggerWithoutExchangeWritesEmptyRequest(t *testing.T) {
+	res, varMap, _ := runTrigger(t, context.Background(), mflow.NodeWebhookTrigger{Path: "/orders/"})
+
+	require.NoError(t, res.Err)
+	out := varMap["hook"].(map[string]any)
+	require.Equal(t, "/orders", out["path"])
+	require.Equal(t, map[string]any{}, out["headers"])
+}
+
+func TestAcceptRejectsWrongMethod(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/webhook/orders", nil)
+	_, status, err := nwebhook.Accept(r, mflow.NodeWebhookTrigger{Path: "orders", Method: "post"})
+
+	require.ErrorIs(t, err, nwebhook.ErrMethodNotAllowed)
+	require.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func TestAcceptVerifiesSignature(t *testing.T) {
+	config := mflow.NodeWebhookTrigger{Path: "orders", Secret: "s3cret"}
+	body := `{"id":1}`
+	sig := "sha256=" + hex.EncodeToString(nwebhook.Sign("s3cret", []byte(body)))
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook/orders", strings.NewReader(body))
+	r.Header.Set(mflow.DefaultWebhookSignatureHeader, sig)
+	_, _, err := nwebhook.Accept(r, config)
+	require.NoError(t, err)
+
+	r = httptest.NewRequest(http.MethodPost, "/webhook/orders", strings.NewReader(`{"id":2}`))
+	r.Header.Set(mflow.DefaultWebhookSignatureHeader, sig)
+	_, status, err := nwebhook.Accept(r, config)
+	require.ErrorIs(t, err, nwebhook.ErrInvalidSignature)
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	r = httptest.NewRequest(http.MethodPost, "/webhook/orders", strings.NewReader(body))
+	_, _, err = nwebhook.Accept(r, config)
+	require.ErrorIs(t, err, nwebhook.ErrInvalidSignature)
+}
+
+func TestExchangeWritesShapedResponse(t *testing.T) {
+	ex := nwebhook.NewExchange(nwebhook.Request{})
+	ex.Respond(map[string]any{
+		"status":  int64(201),
+		"headers": map[string]any{"X-Id": 9},
+		"body":    map[string]any{"ok": true},
+	})
+	ex.Respond(map[string]any{"status": 500})
+
+	rec := httptest.NewRecorder()
+	ex.WriteResponse(rec, nil)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, "9", rec.Header().Get("X-Id"))
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"ok":true}`, rec.Body.String())
+}
+
+func TestExchangeSendsAllOutputsAsBody(t *testing.T) {
+	ex := nwebhook.NewExchange(nwebhook.Request{})
+	ex.Respond(map[string]any{"id": "abc"})
+
+	rec := httptest.NewRecorder()
+	ex.WriteResponse(rec, nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"id":"abc"}`, rec.Body.String())
+}
+
+func TestExchangeDefaultResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	nwebhook.NewExchange(nwebhook.Request{}).WriteResponse(rec, nil)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	nwebhook.NewExchange(nwebhook.Request{}).WriteResponse(rec, errors.New("boom"))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.JSONEq(t, `{"error":"boom"}`, rec.Body.String())
+}
+
+func TestWithoutExchangeHidesExchange(t *testing.T) {
+	ctx := nwebhook.WithExchange(context.Background(), nwebhook.NewExchange(nwebhook.Request{}))
+	require.NotNil(t, nwebhook.ExchangeFromContext(ctx))
+	require.Nil(t, nwebhook.ExchangeFromContext(nwebhook.WithoutExchange(ctx)))
+}
+
+func TestTriggerSkipsRequestForOtherNode(t *testing.T) {
+	ex := nwebhook.NewExchange(nwebhook.Request{Method: http.MethodPost})
+	ex.TriggerID = idwrap.NewNow()
+
+	res, varMap, _ := runTrigger(t, nwebhook.WithExchange(context.Background(), ex), mflow.NodeWebhookTrigger{Path: "other"})
+
+	require.NoError(t, res.Err)
+	require.Empty(t, res.NextNodeID)
+	require.NotContains(t, varMap, "hook")
+}
+
+func TestExchangeSignalsResponded(t *testing.T) {
+	ex := nwebhook.NewExchange(nwebhook.Request{})
+	select {
+	case <-ex.Responded():
+		t.Fatal("responded before Respond")
+	default:
+	}
+
+	ex.Respond(map[string]any{"status": 202})
+	ex.Respond(map[string]any{"status": 500})
+	<-ex.Responded()
+	resp, ok := ex.Response()
+	require.True(t, ok)
+	require.Equal(t, http.StatusAccepted, resp.Status)
+}
@@ -26,6 +26,15 @@ type NodeWsConnection struct {
 	URL        string
 	Headers    map[string]string
 	HTTPClient *http.Client // shared client with cookie jar for upgrade handshake
+	// Subprotocols are offered in Sec-WebSocket-Protocol; the one the server
+	// picked is written to the "protocol" output.
+	Subprotocols []string
+	// OnFrame, when set, is called for every frame sent or received on the
+	// connection, e.g. to persist the WebSocket's message history.
+	OnFrame func(Frame)
+	// Protocol selects the protocol spoken on the connection. Decoded
+	// frames are written to the "event" output.
+	Protocol ProtocolConfig
 }
 
 func New(id idwrap.IDWrap, name string, url string, headers map[string]string, httpClient *http.Client) *NodeWsConnection {
@@ -62,10 +71,11 @@ func (n *NodeWsConnection) IsLoopCoordinator() bool {
 
 // GetRequiredVariables implements node.VariableIntrospector.
 func (n *NodeWsConnection) GetRequiredVariables() []string {
-	sources := []string{n.URL}
+	sources := []string{n.URL, n.Protocol.ConnectPayload}
 	for _, v := range n.Headers {
 		sources = append(sources, v)
 	}
+	sources = append(sources, n.Protocol.Subscriptions...)
 	return expression.ExtractVarKeysFromMultiple(sources...)
 }
 
@@ -74,8 +84,10 @@ func (n *NodeWsConnection) GetOutputVariables() []string {
 	return []string{
 		"url",
 		"connected",
+		"protocol",
 		"cookies",
 		"message",
+		"event",
 		"index",
 		"type",
 	}
@@ -90,6 +102,19 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 		return node.FlowNodeResult{Err: fmt.Errorf("interpolate url: %w", err)}
 	}
 
+	protocol, err := n.interpolateProtocol(ctx, env)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	dialURL, err := protocol.dialURL(url)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	adapter, err := protocol.newAdapter(url)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
 	// Build HTTP headers
 	httpHeaders := http.Header{}
 	for k, v := range n.Headers {
@@ -102,12 +127,13 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 
 	// Dial WebSocket using the shared HTTP client (cookie jar)
 	dialOpts := &websocket.DialOptions{
-		HTTPHeader: httpHeaders,
+		HTTPHeader:   httpHeaders,
+		Subprotocols: protocol.subprotocols(n.Subprotocols),
 	}
 	if n.HTTPClient != nil {
 		dialOpts.HTTPClient = n.HTTPClient
 	}
-	conn, resp, err := websocket.Dial(ctx, url, dialOpts)
+	conn, resp, err := websocket.Dial(ctx, dialURL, dialOpts)
 
 	// Extract cookies from the upgrade response before closing the body.
 	var cookies []*http.Cookie
@@ -118,13 +144,20 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 		}
 	}
 	if err != nil {
-		return node.FlowNodeResult{Err: fmt.Errorf("websocket dial %s: %w", url, err)}
+		return node.FlowNodeResult{Err: fmt.Errorf("websocket dial %s: %w", dialURL, err)}
 	}
 
 	closeConn := func() {
 		_ = conn.Close(websocket.StatusNormalClosure, "")
 	}
 
+	if adapter != nil {
+		if err := adapter.handshake(ctx, conn); err != nil {
+			closeConn()
+			return node.FlowNodeResult{Err: err}
+		}
+	}
+
 	// Store connection in VarMap so WsSend nodes can use it
 	writeVar := func(key string, v any) error {
 		if req.VariableTracker != nil {
@@ -140,6 +173,12 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 		closeConn()
 		return node.FlowNodeResult{Err: fmt.Errorf("write connected var: %w", err)}
 	}
+	session := NewSession(conn, n.OnFrame)
+	session.adapter = adapter
+	if err := writeVar("protocol", session.Protocol); err != nil {
+		closeConn()
+		return node.FlowNodeResult{Err: fmt.Errorf("write protocol var: %w", err)}
+	}
 	// Store cookies from the upgrade response so downstream nodes can reference them.
 	cookieMap := make(map[string]string, len(cookies))
 	for _, c := range cookies {
@@ -154,6 +193,11 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 		closeConn()
 		return node.FlowNodeResult{Err: fmt.Errorf("write conn var: %w", err)}
 	}
+	// The session tracks received frames for WsExpect nodes (internal, not tracked)
+	if err := node.WriteNodeVar(req, n.Name, SessionVar, session); err != nil {
+		closeConn()
+		return node.FlowNodeResult{Err: fmt.Errorf("write session var: %w", err)}
+	}
 	nextID := mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified)
 
 	// Check for HandleWsMessage targets — if present, read messages and dispatch child chains
@@ -170,12 +214,13 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 					return
 				default:
 				}
-				_, msg, err := conn.Read(ctx)
+				frame, err := session.Read(ctx)
 				if err != nil {
 					return
 				}
-				msgStr := string(msg)
+				msgStr := frame.Data
 				_ = node.WriteNodeVar(req, n.Name, "message", msgStr)
+				_ = node.WriteNodeVar(req, n.Name, "event", frame.Event)
 				_ = node.WriteNodeVar(req, n.Name, "index", msgIndex)
 				_ = node.WriteNodeVar(req, n.Name, "type", "received")
 
@@ -186,7 +231,7 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 						NodeID:         n.FlowNodeID,
 						Name:           fmt.Sprintf("%s Message %d", n.Name, msgIndex+1),
 						State:          mflow.NODE_STATE_SUCCESS,
-						OutputData:     map[string]any{"type": "received", "index": msgIndex, "message": msgStr},
+						OutputData:     map[string]any{"type": "received", "index": msgIndex, "message": msgStr, "event": frame.Event},
 						IterationEvent: true,
 						IterationIndex: msgIndex,
 						LoopNodeID:     n.FlowNodeID,
@@ -213,13 +258,14 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 				return
 			default:
 			}
-			_, msg, err := conn.Read(ctx)
+			frame, err := session.Read(ctx)
 			if err != nil {
 				return
 			}
 
-			msgStr := string(msg)
+			msgStr := frame.Data
 			_ = node.WriteNodeVar(req, n.Name, "message", msgStr)
+			_ = node.WriteNodeVar(req, n.Name, "event", frame.Event)
 			_ = node.WriteNodeVar(req, n.Name, "index", msgIndex)
 			_ = node.WriteNodeVar(req, n.Name, "type", "received")
 
@@ -254,7 +300,7 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 					NodeID:           n.FlowNodeID,
 					Name:             executionName,
 					State:            mflow.NODE_STATE_RUNNING,
-					OutputData:       map[string]any{"type": "received", "index": msgIndex, "message": msgStr},
+					OutputData:       map[string]any{"type": "received", "index": msgIndex, "message": msgStr, "event": frame.Event},
 					IterationEvent:   true,
 					IterationIndex:   msgIndex,
 					LoopNodeID:       n.FlowNodeID,
@@ -295,7 +341,7 @@ func (n *NodeWsConnection) RunSync(ctx context.Context, req *node.FlowNodeReques
 					Name:             executionName,
 					State:            state,
 					Error:            iterErr,
-					OutputData:       map[string]any{"type": "received", "index": msgIndex, "message": msgStr},
+					OutputData:       map[string]any{"type": "received", "index": msgIndex, "message": msgStr, "event": frame.Event},
 					IterationEvent:   true,
 					IterationIndex:   msgIndex,
 					LoopNodeID:       n.FlowNodeID,
@@ -314,6 +360,25 @@ func (n *NodeWsConnection) RunAsync(ctx context.Context, req *node.FlowNodeReque
 	resultChan <- n.RunSync(ctx, req)
 }
 
+// interpolateProtocol returns the protocol config with its connect payload
+// and subscriptions interpolated.
+func (n *NodeWsConnection) interpolateProtocol(ctx context.Context, env *expression.UnifiedEnv) (ProtocolConfig, error) {
+	protocol := n.Protocol
+	payload, err := env.InterpolateCtx(ctx, protocol.ConnectPayload)
+	if err != nil {
+		return protocol, fmt.Errorf("interpolate connect payload: %w", err)
+	}
+	protocol.ConnectPayload = payload
+	protocol.Subscriptions = make([]string, len(n.Protocol.Subscriptions))
+	for i, destination := range n.Protocol.Subscriptions {
+		protocol.Subscriptions[i], err = env.InterpolateCtx(ctx, destination)
+		if err != nil {
+			return protocol, fmt.Errorf("interpolate subscription %q: %w", destination, err)
+		}
+	}
+	return protocol, nil
+}
+
 func newExprEnv(varMap map[string]any) *expression.UnifiedEnv {
 	return expression.NewUnifiedEnv(varMap)
 }
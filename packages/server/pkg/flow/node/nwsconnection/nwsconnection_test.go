@@ -258,3 +258,58 @@ func TestNodeWsConnection_URLInterpolation(t *testing.T) {
 
 	cancel()
 }
+
+func TestNodeWsConnection_Subprotocols(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{"v2.chat"}})
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.StatusNormalClosure, "")
+		_, _, _ = conn.Read(r.Context())
+	}))
+	defer srv.Close()
+
+	n := New(idwrap.NewNow(), "MyWS", wsURL(srv), nil, nil)
+	n.Subprotocols = []string{"v1.chat", "v2.chat"}
+
+	var mu sync.Mutex
+	var frames []Frame
+	n.OnFrame = func(f Frame) {
+		mu.Lock()
+		defer mu.Unlock()
+		frames = append(frames, f)
+	}
+
+	req := newReq(mflow.EdgesMap{}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result := n.RunSync(ctx, req)
+	if result.Err != nil {
+		t.Fatalf("RunSync error: %v", result.Err)
+	}
+
+	protocol, err := node.ReadNodeVar(req, "MyWS", "protocol")
+	if err != nil {
+		t.Fatalf("read protocol var: %v", err)
+	}
+	if protocol != "v2.chat" {
+		t.Errorf("protocol = %v, want v2.chat", protocol)
+	}
+
+	session, err := ReadSession(req, "MyWS")
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+	if err := session.Send(ctx, websocket.MessageText, []byte("hi")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(frames) != 1 || frames[0].Direction != DirectionSent || frames[0].Data != "hi" {
+		t.Errorf("frames = %+v, want one sent frame", frames)
+	}
+}
@@ -0,0 +1,67 @@
+package nwsconnection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coder/websocket"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
+)
+
+// ErrNoProtocol is returned when a message is emitted as an event on a
+// connection that exchanges raw frames.
+var ErrNoProtocol = errors.New("events need a Socket.IO or STOMP connection")
+
+// adapter speaks a message protocol on top of the connection's frames.
+type adapter interface {
+	// handshake runs once after dialing, before frames are read.
+	handshake(ctx context.Context, conn *websocket.Conn) error
+	// decode turns a received frame into the event exposed to nodes. It
+	// answers and drops protocol frames such as pings by returning ok false.
+	decode(ctx context.Context, conn *websocket.Conn, typ websocket.MessageType, data []byte) (event map[string]any, ok bool, err error)
+	// encode builds the frame emitting payload to target, an event name or
+	// destination. A non-zero ackID asks the server to acknowledge it.
+	encode(target, payload string, ackID int) ([]byte, error)
+}
+
+// ProtocolConfig configures the protocol spoken on a connection.
+type ProtocolConfig struct {
+	Protocol mwebsocket.Protocol
+	// ConnectPayload is a JSON object: the Socket.IO auth payload, or extra
+	// STOMP CONNECT headers such as login and passcode.
+	ConnectPayload string
+	// Subscriptions are the STOMP destinations subscribed to after connecting.
+	Subscriptions []string
+}
+
+// dialURL returns the URL to dial for rawURL.
+func (c ProtocolConfig) dialURL(rawURL string) (string, error) {
+	if c.Protocol == mwebsocket.ProtocolSocketIO {
+		return socketIOURL(rawURL)
+	}
+	return rawURL, nil
+}
+
+// subprotocols returns the subprotocols to offer when none are configured.
+func (c ProtocolConfig) subprotocols(configured []string) []string {
+	if len(configured) == 0 && c.Protocol == mwebsocket.ProtocolStomp {
+		return stompSubprotocols
+	}
+	return configured
+}
+
+// newAdapter returns the adapter for the protocol, or nil for raw frames.
+func (c ProtocolConfig) newAdapter(rawURL string) (adapter, error) {
+	switch c.Protocol {
+	case mwebsocket.ProtocolRaw:
+		return nil, nil
+	case mwebsocket.ProtocolSocketIO:
+		return newSocketIO(rawURL, c.ConnectPayload)
+	case mwebsocket.ProtocolStomp:
+		return newStomp(rawURL, c.ConnectPayload, c.Subscriptions)
+	default:
+		return nil, fmt.Errorf("unknown websocket protocol %d", c.Protocol)
+	}
+}
@@ -0,0 +1,345 @@
+package nwsconnection
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+)
+
+// SessionVar is the internal variable a WS Connection node stores its
+// Session under, next to the bare connection in "_conn".
+const SessionVar = "_session"
+
+// maxFrames bounds the received frames a session keeps; older ones are
+// dropped and can no longer be matched.
+const maxFrames = 10000
+
+// Frame directions and types.
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+
+	TypeText   = "text"
+	TypeBinary = "binary"
+)
+
+// ErrConnectionClosed is returned when the server closed the connection
+// before the frames waited for arrived.
+var ErrConnectionClosed = errors.New("websocket connection closed")
+
+// Frame is a message sent or received on a connection.
+type Frame struct {
+	// Index is the position of a received frame on its connection; sent
+	// frames share the index of the next frame to be received.
+	Index     int
+	Direction string
+	Type      string
+	// Data holds text as is and binary payloads base64 encoded.
+	Data string
+	// Event is the frame decoded by the connection's protocol, e.g. a
+	// Socket.IO event or a STOMP MESSAGE; nil for raw connections.
+	Event map[string]any
+	Time  time.Time
+}
+
+// Output is the frame as exposed to expressions and node outputs.
+func (f Frame) Output() map[string]any {
+	return map[string]any{
+		"index":     f.Index,
+		"direction": f.Direction,
+		"type":      f.Type,
+		"data":      f.Data,
+		"json":      decodeJSON(f),
+		"event":     f.Event,
+		"time":      f.Time.UnixMilli(),
+	}
+}
+
+// Session is what a WS Connection node shares with the nodes using its
+// connection: the connection and the frames received on it so far. Frames
+// are matched at most once, and only those received after the latest send.
+type Session struct {
+	Conn     *websocket.Conn
+	Protocol string
+
+	onFrame func(Frame)
+	adapter adapter
+
+	mu       sync.Mutex
+	ackID    int
+	frames   []Frame
+	base     int // index of frames[0]
+	mark     int // index of the first frame received after the latest send
+	consumed map[int]struct{}
+	changed  chan struct{}
+
+	closed      bool
+	closeCode   int
+	closeReason string
+}
+
+// NewSession wraps conn. onFrame, when set, is called for every frame sent
+// or received, e.g. to keep the connection's history.
+func NewSession(conn *websocket.Conn, onFrame func(Frame)) *Session {
+	return &Session{
+		Conn:     conn,
+		Protocol: conn.Subprotocol(),
+		onFrame:  onFrame,
+		consumed: make(map[int]struct{}),
+		changed:  make(chan struct{}),
+	}
+}
+
+// ReadSession returns the session of the WS Connection node named name.
+func ReadSession(req *node.FlowNodeRequest, name string) (*Session, error) {
+	raw, err := node.ReadNodeVar(req, name, SessionVar)
+	if err != nil {
+		return nil, fmt.Errorf("read ws connection from node %q: %w", name, err)
+	}
+	s, ok := raw.(*Session)
+	if !ok {
+		return nil, fmt.Errorf("ws connection from node %q is not a valid WebSocket connection", name)
+	}
+	return s, nil
+}
+
+// Send writes a message and moves the start of matching past every frame
+// received so far.
+func (s *Session) Send(ctx context.Context, typ websocket.MessageType, data []byte) error {
+	s.mu.Lock()
+	s.mark = s.base + len(s.frames)
+	index := s.mark
+	s.mu.Unlock()
+
+	if err := s.Conn.Write(ctx, typ, data); err != nil {
+		return fmt.Errorf("websocket write: %w", err)
+	}
+	if s.onFrame != nil {
+		s.onFrame(newFrame(index, DirectionSent, typ, data))
+	}
+	return nil
+}
+
+// Emit sends payload to target, a Socket.IO event name or STOMP destination,
+// using the connection's protocol. With ack set, it returns the id the
+// server's acknowledgement carries as "id".
+func (s *Session) Emit(ctx context.Context, target, payload string, ack bool) (int, error) {
+	if s.adapter == nil {
+		return 0, ErrNoProtocol
+	}
+	var ackID int
+	if ack {
+		s.mu.Lock()
+		s.ackID++
+		ackID = s.ackID
+		s.mu.Unlock()
+	}
+	data, err := s.adapter.encode(target, payload, ackID)
+	if err != nil {
+		return 0, err
+	}
+	return ackID, s.Send(ctx, websocket.MessageText, data)
+}
+
+// Read blocks for the next message, records it and returns it. Frames the
+// protocol handles itself, such as pings, are answered and skipped. Once it
+// fails the session is closed, with the close status sent by the server if
+// there was one.
+func (s *Session) Read(ctx context.Context) (Frame, error) {
+	var (
+		typ   websocket.MessageType
+		data  []byte
+		event map[string]any
+	)
+	for {
+		var err error
+		typ, data, err = s.Conn.Read(ctx)
+		if err != nil {
+			s.closeWith(err)
+			return Frame{}, err
+		}
+		if s.adapter == nil {
+			break
+		}
+		var ok bool
+		event, ok, err = s.adapter.decode(ctx, s.Conn, typ, data)
+		if err != nil {
+			s.closeWith(err)
+			return Frame{}, err
+		}
+		if ok {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	f := newFrame(s.base+len(s.frames), DirectionReceived, typ, data)
+	f.Event = event
+	s.frames = append(s.frames, f)
+	if len(s.frames) > maxFrames {
+		drop := len(s.frames) - maxFrames
+		for i := s.base; i < s.base+drop; i++ {
+			delete(s.consumed, i)
+		}
+		s.frames = append([]Frame(nil), s.frames[drop:]...)
+		s.base += drop
+	}
+	s.notifyLocked()
+	s.mu.Unlock()
+
+	if s.onFrame != nil {
+		s.onFrame(f)
+	}
+	return f, nil
+}
+
+func (s *Session) closeWith(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	var closeErr websocket.CloseError
+	if errors.As(err, &closeErr) {
+		s.closeCode = int(closeErr.Code)
+		s.closeReason = closeErr.Reason
+	}
+	s.notifyLocked()
+}
+
+func (s *Session) notifyLocked() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// Expect waits until count frames received after the latest send satisfy
+// match and marks them as matched. It returns the frames matched so far with
+// ErrConnectionClosed or the context's error when it gives up.
+func (s *Session) Expect(ctx context.Context, count int, match func(Frame) (bool, error)) ([]Frame, error) {
+	s.mu.Lock()
+	next := s.mark
+	s.mu.Unlock()
+
+	var matched []Frame
+	for {
+		s.mu.Lock()
+		next = max(next, s.base)
+		pending := append([]Frame(nil), s.frames[next-s.base:]...)
+		changed := s.changed
+		closed := s.closed
+		s.mu.Unlock()
+
+		for _, f := range pending {
+			next = f.Index + 1
+			if s.isConsumed(f.Index) {
+				continue
+			}
+			ok, err := match(f)
+			if err != nil {
+				return matched, err
+			}
+			if !ok {
+				continue
+			}
+			s.consume(f.Index)
+			matched = append(matched, f)
+			if len(matched) == count {
+				return matched, nil
+			}
+		}
+
+		if closed {
+			return matched, ErrConnectionClosed
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return matched, ctx.Err()
+		}
+	}
+}
+
+func (s *Session) isConsumed(index int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.consumed[index]
+	return ok
+}
+
+func (s *Session) consume(index int) {
+	s.mu.Lock()
+	s.consumed[index] = struct{}{}
+	s.mu.Unlock()
+}
+
+// WaitClose waits for the connection to close and returns the close code
+// and reason sent by the server; the code is 0 when it sent none.
+func (s *Session) WaitClose(ctx context.Context) (int, string, error) {
+	for {
+		s.mu.Lock()
+		closed, code, reason, changed := s.closed, s.closeCode, s.closeReason, s.changed
+		s.mu.Unlock()
+		if closed {
+			return code, reason, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return 0, "", ctx.Err()
+		}
+	}
+}
+
+// CloseStatus reports whether the connection is closed, with the close code
+// and reason sent by the server.
+func (s *Session) CloseStatus() (bool, int, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed, s.closeCode, s.closeReason
+}
+
+// Ping sends a ping and waits for the pong. The connection must be read
+// concurrently, which the WS Connection node does.
+func (s *Session) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	if err := s.Conn.Ping(ctx); err != nil {
+		return 0, fmt.Errorf("websocket ping: %w", err)
+	}
+	return time.Since(start), nil
+}
+
+func newFrame(index int, direction string, typ websocket.MessageType, data []byte) Frame {
+	f := Frame{
+		Index:     index,
+		Direction: direction,
+		Type:      TypeText,
+		Data:      string(data),
+		Time:      time.Now(),
+	}
+	if typ == websocket.MessageBinary {
+		f.Type = TypeBinary
+		f.Data = base64.StdEncoding.EncodeToString(data)
+	}
+	return f
+}
+
+// decodeJSON parses a text frame holding JSON, or returns nil.
+func decodeJSON(f Frame) any {
+	if f.Type != TypeText {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal([]byte(f.Data), &v); err != nil {
+		return nil
+	}
+	return v
+}
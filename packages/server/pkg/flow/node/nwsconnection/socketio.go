@@ -0,0 +1,311 @@
+package nwsconnection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/coder/websocket"
+)
+
+// Engine.IO v4 packet types.
+const (
+	eioOpen    = '0'
+	eioPing    = '2'
+	eioPong    = '3'
+	eioMessage = '4'
+)
+
+// Socket.IO v4 packet types.
+const (
+	sioConnect      = '0'
+	sioDisconnect   = '1'
+	sioEvent        = '2'
+	sioAck          = '3'
+	sioConnectError = '4'
+	sioBinaryEvent  = '5'
+	sioBinaryAck    = '6'
+)
+
+// socketIOPath is where Socket.IO servers serve Engine.IO by default.
+const socketIOPath = "/socket.io/"
+
+// socketIO speaks Socket.IO v4 on one namespace. Events the server asks to
+// be acknowledged are acknowledged with no arguments; binary attachments are
+// passed on as raw frames.
+type socketIO struct {
+	namespace string
+	auth      json.RawMessage
+}
+
+func newSocketIO(rawURL, connectPayload string) (*socketIO, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse socket.io url: %w", err)
+	}
+	s := &socketIO{namespace: socketIONamespace(u.Path)}
+	if connectPayload != "" {
+		if !json.Valid([]byte(connectPayload)) {
+			return nil, errors.New("socket.io connect payload is not valid JSON")
+		}
+		s.auth = json.RawMessage(connectPayload)
+	}
+	return s, nil
+}
+
+// socketIONamespace returns the namespace named by a URL path, like the
+// Socket.IO client does; a path already pointing at the engine is "/".
+func socketIONamespace(path string) string {
+	if path == "" || isSocketIOPath(path) {
+		return "/"
+	}
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+func isSocketIOPath(path string) bool {
+	return strings.HasPrefix(path, strings.TrimSuffix(socketIOPath, "/"))
+}
+
+// socketIOURL returns the Engine.IO WebSocket endpoint serving rawURL.
+func socketIOURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parse socket.io url: %w", err)
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	if !isSocketIOPath(u.Path) {
+		u.Path = socketIOPath
+	}
+	q := u.Query()
+	q.Set("EIO", "4")
+	q.Set("transport", "websocket")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func (s *socketIO) prefix(namespace string) string {
+	if namespace == "/" {
+		return ""
+	}
+	return namespace + ","
+}
+
+func (s *socketIO) handshake(ctx context.Context, conn *websocket.Conn) error {
+	connect := string([]byte{eioMessage, sioConnect}) + s.prefix(s.namespace) + string(s.auth)
+	opened := false
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return fmt.Errorf("socket.io handshake: %w", err)
+		}
+		msg := string(data)
+		if msg == "" {
+			continue
+		}
+		switch msg[0] {
+		case eioOpen:
+			if opened {
+				continue
+			}
+			opened = true
+			if err := conn.Write(ctx, websocket.MessageText, []byte(connect)); err != nil {
+				return fmt.Errorf("socket.io connect: %w", err)
+			}
+		case eioPing:
+			if err := conn.Write(ctx, websocket.MessageText, []byte{eioPong}); err != nil {
+				return fmt.Errorf("socket.io pong: %w", err)
+			}
+		case eioMessage:
+			p, err := parseSocketIOPacket(msg[1:])
+			if err != nil || p.namespace != s.namespace {
+				continue
+			}
+			switch p.typ {
+			case sioConnect:
+				return nil
+			case sioConnectError:
+				return fmt.Errorf("socket.io namespace %q refused the connection: %s", s.namespace, p.data)
+			}
+		}
+	}
+}
+
+func (s *socketIO) decode(ctx context.Context, conn *websocket.Conn, typ websocket.MessageType, data []byte) (map[string]any, bool, error) {
+	if typ != websocket.MessageText {
+		return nil, true, nil
+	}
+	msg := string(data)
+	if msg == "" {
+		return nil, false, nil
+	}
+	switch msg[0] {
+	case eioMessage:
+	case eioPing:
+		if err := conn.Write(ctx, websocket.MessageText, append([]byte{eioPong}, msg[1:]...)); err != nil {
+			return nil, false, fmt.Errorf("socket.io pong: %w", err)
+		}
+		return nil, false, nil
+	default:
+		return nil, false, nil
+	}
+
+	p, err := parseSocketIOPacket(msg[1:])
+	if err != nil {
+		return nil, true, nil //nolint:nilerr // passed on undecoded
+	}
+	event := map[string]any{"namespace": p.namespace}
+	switch p.typ {
+	case sioEvent, sioBinaryEvent:
+		event["type"] = "event"
+		args := p.args()
+		if len(args) > 0 {
+			event["event"] = args[0]
+			args = args[1:]
+		}
+		setArgs(event, args)
+		if p.id >= 0 {
+			event["id"] = p.id
+			ack := string([]byte{eioMessage, sioAck}) + s.prefix(p.namespace) + strconv.Itoa(p.id) + "[]"
+			if err := conn.Write(ctx, websocket.MessageText, []byte(ack)); err != nil {
+				return nil, false, fmt.Errorf("socket.io ack: %w", err)
+			}
+		}
+	case sioAck, sioBinaryAck:
+		event["type"] = "ack"
+		event["id"] = p.id
+		setArgs(event, p.args())
+	case sioConnect:
+		event["type"] = "connect"
+		event["data"] = p.value()
+	case sioDisconnect:
+		event["type"] = "disconnect"
+	case sioConnectError:
+		event["type"] = "connect_error"
+		event["data"] = p.value()
+	default:
+		return nil, true, nil
+	}
+	return event, true, nil
+}
+
+// setArgs exposes an event's arguments as "args" and the first one as "data".
+func setArgs(event map[string]any, args []any) {
+	if args == nil {
+		args = []any{}
+	}
+	event["args"] = args
+	event["data"] = nil
+	if len(args) > 0 {
+		event["data"] = args[0]
+	}
+}
+
+func (s *socketIO) encode(event, payload string, ackID int) ([]byte, error) {
+	if event == "" {
+		return nil, errors.New("socket.io messages need an event name")
+	}
+	args := []any{event}
+	if payload != "" {
+		if json.Valid([]byte(payload)) {
+			args = append(args, json.RawMessage(payload))
+		} else {
+			args = append(args, payload)
+		}
+	}
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("encode socket.io event: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteByte(eioMessage)
+	b.WriteByte(sioEvent)
+	b.WriteString(s.prefix(s.namespace))
+	if ackID > 0 {
+		b.WriteString(strconv.Itoa(ackID))
+	}
+	b.Write(encoded)
+	return []byte(b.String()), nil
+}
+
+// socketIOPacket is a Socket.IO packet: type, namespace, ack id (-1 when
+// absent) and JSON data.
+type socketIOPacket struct {
+	typ       byte
+	namespace string
+	id        int
+	data      json.RawMessage
+}
+
+func parseSocketIOPacket(s string) (socketIOPacket, error) {
+	if s == "" {
+		return socketIOPacket{}, errors.New("empty socket.io packet")
+	}
+	p := socketIOPacket{typ: s[0], namespace: "/", id: -1}
+	s = s[1:]
+	if p.typ == sioBinaryEvent || p.typ == sioBinaryAck {
+		// Skip the attachment count, e.g. "1-".
+		if n := leadingDigits(s); n > 0 && n < len(s) && s[n] == '-' {
+			s = s[n+1:]
+		}
+	}
+	if strings.HasPrefix(s, "/") {
+		if i := strings.IndexByte(s, ','); i >= 0 {
+			p.namespace, s = s[:i], s[i+1:]
+		} else {
+			p.namespace, s = s, ""
+		}
+	}
+	if n := leadingDigits(s); n > 0 {
+		id, err := strconv.Atoi(s[:n])
+		if err != nil {
+			return p, fmt.Errorf("socket.io ack id: %w", err)
+		}
+		p.id, s = id, s[n:]
+	}
+	if s != "" {
+		if !json.Valid([]byte(s)) {
+			return p, errors.New("socket.io packet data is not JSON")
+		}
+		p.data = json.RawMessage(s)
+	}
+	return p, nil
+}
+
+func leadingDigits(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// args returns the packet's data as an argument list.
+func (p socketIOPacket) args() []any {
+	var args []any
+	if err := json.Unmarshal(p.data, &args); err != nil {
+		return nil
+	}
+	return args
+}
+
+// value returns the packet's data decoded, or nil.
+func (p socketIOPacket) value() any {
+	var v any
+	if err := json.Unmarshal(p.data, &v); err != nil {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,187 @@
+package nwsconnection
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
+)
+
+// socketIOServer accepts Socket.IO clients on the /chat namespace with auth
+// token "t". It pings once, answers "echo" events with an ack and an
+// "echoed" event, and sends each client a "confirm" event asking for an ack.
+// Frames it receives after connecting are sent to got.
+func socketIOServer(t *testing.T, got chan<- string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/socket.io/" || r.URL.Query().Get("EIO") != "4" {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.StatusNormalClosure, "")
+		ctx := r.Context()
+		write := func(s string) { _ = conn.Write(ctx, websocket.MessageText, []byte(s)) }
+
+		write(`0{"sid":"e1","upgrades":[],"pingInterval":25000,"pingTimeout":20000,"maxPayload":1000000}`)
+		_, connect, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+		if string(connect) != `40/chat,{"token":"t"}` {
+			write(`44/chat,{"message":"unauthorized"}`)
+			return
+		}
+		write(`40/chat,{"sid":"s1"}`)
+		write(`2`)
+		write(`42/chat,5["confirm"]`)
+
+		for {
+			_, data, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			msg := string(data)
+			got <- msg
+			if rest, ok := strings.CutPrefix(msg, `42/chat,`); ok {
+				n := leadingDigits(rest)
+				id, args := rest[:n], strings.TrimPrefix(rest[n:], `["echo",`)
+				write(`43/chat,` + id + `[` + args)
+				write(`42/chat,["echoed",` + args)
+			}
+		}
+	}))
+}
+
+func TestNodeWsConnection_SocketIO(t *testing.T) {
+	got := make(chan string, 10)
+	srv := socketIOServer(t, got)
+	defer srv.Close()
+
+	n := New(idwrap.NewNow(), "IO", srv.URL+"/chat", nil, nil)
+	n.Protocol = ProtocolConfig{
+		Protocol:       mwebsocket.ProtocolSocketIO,
+		ConnectPayload: `{"token":"{{ token }}"}`,
+	}
+	req := newReq(mflow.EdgesMap{}, nil)
+	req.VarMap["token"] = "t"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if result := n.RunSync(ctx, req); result.Err != nil {
+		t.Fatalf("RunSync error: %v", result.Err)
+	}
+	session, err := ReadSession(req, "IO")
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+
+	// The ping is answered and the event asking for an ack acknowledged.
+	for _, want := range []string{"3", "43/chat,5[]"} {
+		select {
+		case msg := <-got:
+			if msg != want {
+				t.Fatalf("server got %q, want %q", msg, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("server never got %q", want)
+		}
+	}
+
+	ackID, err := session.Emit(ctx, "echo", `{"n":1}`, true)
+	if err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+	if msg := <-got; msg != `42/chat,1["echo",{"n":1}]` {
+		t.Fatalf("server got %q", msg)
+	}
+
+	frames, err := session.Expect(ctx, 2, func(f Frame) (bool, error) {
+		return f.Event["type"] == "ack" || f.Event["event"] == "echoed", nil
+	})
+	if err != nil {
+		t.Fatalf("Expect: %v", err)
+	}
+	ack, echoed := frames[0].Event, frames[1].Event
+	if ack["id"] != ackID || !reflect.DeepEqual(ack["data"], map[string]any{"n": float64(1)}) {
+		t.Errorf("ack = %v, want id %d with data {n: 1}", ack, ackID)
+	}
+	if echoed["namespace"] != "/chat" || !reflect.DeepEqual(echoed["args"], []any{map[string]any{"n": float64(1)}}) {
+		t.Errorf("echoed = %v", echoed)
+	}
+}
+
+func TestNodeWsConnection_SocketIORefused(t *testing.T) {
+	srv := socketIOServer(t, make(chan string, 10))
+	defer srv.Close()
+
+	n := New(idwrap.NewNow(), "IO", srv.URL+"/chat", nil, nil)
+	n.Protocol = ProtocolConfig{Protocol: mwebsocket.ProtocolSocketIO, ConnectPayload: `{"token":"wrong"}`}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result := n.RunSync(ctx, newReq(mflow.EdgesMap{}, nil))
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "unauthorized") {
+		t.Fatalf("err = %v, want the connect error", result.Err)
+	}
+}
+
+func TestParseSocketIOPacket(t *testing.T) {
+	tests := []struct {
+		in        string
+		typ       byte
+		namespace string
+		id        int
+		data      string
+	}{
+		{`0`, sioConnect, "/", -1, ""},
+		{`2["hello",1]`, sioEvent, "/", -1, `["hello",1]`},
+		{`2/admin,["hello"]`, sioEvent, "/admin", -1, `["hello"]`},
+		{`212["hello"]`, sioEvent, "/", 12, `["hello"]`},
+		{`3/admin,7["ok"]`, sioAck, "/admin", 7, `["ok"]`},
+		{`51-/admin,3["file",{"_placeholder":true,"num":0}]`, sioBinaryEvent, "/admin", 3, `["file",{"_placeholder":true,"num":0}]`},
+		{`1/admin,`, sioDisconnect, "/admin", -1, ""},
+	}
+	for _, tt := range tests {
+		p, err := parseSocketIOPacket(tt.in)
+		if err != nil {
+			t.Errorf("parse %q: %v", tt.in, err)
+			continue
+		}
+		if p.typ != tt.typ || p.namespace != tt.namespace || p.id != tt.id || string(p.data) != tt.data {
+			t.Errorf("parse %q = %c %q %d %s", tt.in, p.typ, p.namespace, p.id, p.data)
+		}
+	}
+}
+
+func TestSocketIOURL(t *testing.T) {
+	tests := map[string]string{
+		"http://host:3000":           "ws://host:3000/socket.io/?EIO=4&transport=websocket",
+		"https://host/admin?v=1":     "wss://host/socket.io/?EIO=4&transport=websocket&v=1",
+		"ws://host/socket.io/?EIO=4": "ws://host/socket.io/?EIO=4&transport=websocket",
+	}
+	for in, want := range tests {
+		got, err := socketIOURL(in)
+		if err != nil {
+			t.Errorf("socketIOURL(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("socketIOURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
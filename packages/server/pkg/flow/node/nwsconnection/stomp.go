@@ -0,0 +1,229 @@
+package nwsconnection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/coder/websocket"
+)
+
+// stompSubprotocols are offered when a STOMP connection has none configured.
+var stompSubprotocols = []string{"v12.stomp", "v11.stomp", "v10.stomp"}
+
+// stomp speaks STOMP 1.2 (down to 1.0) and subscribes to its destinations
+// after connecting. Messages are sent with SEND; asking for an ack adds a
+// receipt header answered by a RECEIPT frame.
+type stomp struct {
+	host          string
+	headers       map[string]string
+	subscriptions []string
+}
+
+func newStomp(rawURL, connectPayload string, subscriptions []string) (*stomp, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse stomp url: %w", err)
+	}
+	s := &stomp{host: u.Hostname(), subscriptions: subscriptions}
+	if connectPayload != "" {
+		if err := json.Unmarshal([]byte(connectPayload), &s.headers); err != nil {
+			return nil, fmt.Errorf("stomp connect payload must be a JSON object of strings: %w", err)
+		}
+	}
+	return s, nil
+}
+
+func (s *stomp) handshake(ctx context.Context, conn *websocket.Conn) error {
+	headers := map[string]string{
+		"accept-version": "1.2,1.1,1.0",
+		"host":           s.host,
+		"heart-beat":     "0,0",
+	}
+	for k, v := range s.headers {
+		headers[k] = v
+	}
+	if err := conn.Write(ctx, websocket.MessageText, encodeStompFrame("CONNECT", headers, "")); err != nil {
+		return fmt.Errorf("stomp connect: %w", err)
+	}
+
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return fmt.Errorf("stomp handshake: %w", err)
+		}
+		f, ok, err := parseStompFrame(data)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if f.command == "ERROR" {
+			return fmt.Errorf("stomp server refused the connection: %s", f.errorMessage())
+		}
+		if f.command == "CONNECTED" {
+			break
+		}
+	}
+
+	for i, destination := range s.subscriptions {
+		frame := encodeStompFrame("SUBSCRIBE", map[string]string{
+			"id":          "sub-" + strconv.Itoa(i),
+			"destination": destination,
+			"ack":         "auto",
+		}, "")
+		if err := conn.Write(ctx, websocket.MessageText, frame); err != nil {
+			return fmt.Errorf("stomp subscribe %s: %w", destination, err)
+		}
+	}
+	return nil
+}
+
+func (s *stomp) decode(_ context.Context, _ *websocket.Conn, _ websocket.MessageType, data []byte) (map[string]any, bool, error) {
+	f, ok, err := parseStompFrame(data)
+	if err != nil {
+		return nil, true, nil //nolint:nilerr // passed on undecoded
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	headers := make(map[string]any, len(f.headers))
+	for k, v := range f.headers {
+		headers[k] = v
+	}
+	event := map[string]any{
+		"command": f.command,
+		"headers": headers,
+		"body":    f.body,
+		"json":    nil,
+	}
+	var v any
+	if json.Unmarshal([]byte(f.body), &v) == nil {
+		event["json"] = v
+	}
+	if receipt, ok := f.headers["receipt-id"]; ok {
+		if id, err := strconv.Atoi(receipt); err == nil {
+			event["id"] = id
+		}
+	}
+	return event, true, nil
+}
+
+func (s *stomp) encode(destination, payload string, ackID int) ([]byte, error) {
+	if destination == "" {
+		return nil, errors.New("stomp messages need a destination")
+	}
+	headers := map[string]string{
+		"destination":    destination,
+		"content-type":   "text/plain",
+		"content-length": strconv.Itoa(len(payload)),
+	}
+	if json.Valid([]byte(payload)) {
+		headers["content-type"] = "application/json"
+	}
+	if ackID > 0 {
+		headers["receipt"] = strconv.Itoa(ackID)
+	}
+	return encodeStompFrame("SEND", headers, payload), nil
+}
+
+// stompFrame is a parsed STOMP frame.
+type stompFrame struct {
+	command string
+	headers map[string]string
+	body    string
+}
+
+func (f stompFrame) errorMessage() string {
+	msg := f.headers["message"]
+	if f.body != "" {
+		if msg != "" {
+			msg += ": "
+		}
+		msg += strings.TrimSpace(f.body)
+	}
+	return msg
+}
+
+// parseStompFrame parses a frame. ok is false for heart-beats, which are
+// bare end-of-line characters.
+func parseStompFrame(data []byte) (stompFrame, bool, error) {
+	data = bytes.TrimLeft(data, "\r\n")
+	if len(data) == 0 {
+		return stompFrame{}, false, nil
+	}
+
+	head, body, found := bytes.Cut(data, []byte("\n\n"))
+	if !found {
+		head, body, found = bytes.Cut(data, []byte("\r\n\r\n"))
+	}
+	if !found {
+		return stompFrame{}, false, errors.New("stomp frame has no end of headers")
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(head), "\r\n", "\n"), "\n")
+	f := stompFrame{command: lines[0], headers: make(map[string]string, len(lines)-1)}
+	for _, line := range lines[1:] {
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		k, v = unescapeStompHeader(k), unescapeStompHeader(v)
+		// The first occurrence of a repeated header wins.
+		if _, exists := f.headers[k]; !exists {
+			f.headers[k] = v
+		}
+	}
+
+	if n, err := strconv.Atoi(f.headers["content-length"]); err == nil && n >= 0 && n <= len(body) {
+		body = body[:n]
+	} else if i := bytes.IndexByte(body, 0); i >= 0 {
+		body = body[:i]
+	}
+	f.body = string(body)
+	return f, true, nil
+}
+
+// encodeStompFrame builds a frame; headers are written in sorted order and,
+// except on CONNECT, escaped.
+func encodeStompFrame(command string, headers map[string]string, body string) []byte {
+	escape := escapeStompHeader
+	if command == "CONNECT" {
+		escape = func(s string) string { return s }
+	}
+
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	b.WriteString(command)
+	b.WriteByte('\n')
+	for _, k := range keys {
+		b.WriteString(escape(k))
+		b.WriteByte(':')
+		b.WriteString(escape(headers[k]))
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+	b.WriteString(body)
+	b.WriteByte(0)
+	return b.Bytes()
+}
+
+var (
+	stompHeaderEscaper   = strings.NewReplacer(`\`, `\\`, "\r", `\r`, "\n", `\n`, ":", `\c`)
+	stompHeaderUnescaper = strings.NewReplacer(`\\`, `\`, `\r`, "\r", `\n`, "\n", `\c`, ":")
+)
+
+func escapeStompHeader(s string) string   { return stompHeaderEscaper.Replace(s) }
+func unescapeStompHeader(s string) string { return stompHeaderUnescaper.Replace(s) }
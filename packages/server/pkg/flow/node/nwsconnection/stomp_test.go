@@ -0,0 +1,168 @@
+package nwsconnection
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
+)
+
+// stompServer is a broker accepting login "guest". Messages sent to a
+// subscribed destination are delivered back as MESSAGE frames and receipts
+// are answered. Frames it receives are sent to got.
+func stompServer(t *testing.T, got chan<- stompFrame) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{"v12.stomp"}})
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.StatusNormalClosure, "")
+		ctx := r.Context()
+		write := func(command string, headers map[string]string, body string) {
+			_ = conn.Write(ctx, websocket.MessageText, encodeStompFrame(command, headers, body))
+		}
+
+		subscriptions := map[string]string{}
+		for {
+			_, data, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			f, ok, err := parseStompFrame(data)
+			if err != nil || !ok {
+				continue
+			}
+			got <- f
+			switch f.command {
+			case "CONNECT":
+				if f.headers["login"] != "guest" {
+					write("ERROR", map[string]string{"message": "bad credentials"}, "login refused")
+					return
+				}
+				write("CONNECTED", map[string]string{"version": "1.2"}, "")
+				_ = conn.Write(ctx, websocket.MessageText, []byte("\n"))
+			case "SUBSCRIBE":
+				subscriptions[f.headers["destination"]] = f.headers["id"]
+			case "SEND":
+				if id, ok := subscriptions[f.headers["destination"]]; ok {
+					write("MESSAGE", map[string]string{
+						"destination":  f.headers["destination"],
+						"subscription": id,
+						"message-id":   "m1",
+						"content-type": f.headers["content-type"],
+					}, f.body)
+				}
+				if receipt := f.headers["receipt"]; receipt != "" {
+					write("RECEIPT", map[string]string{"receipt-id": receipt}, "")
+				}
+			}
+		}
+	}))
+}
+
+func TestNodeWsConnection_Stomp(t *testing.T) {
+	got := make(chan stompFrame, 10)
+	srv := stompServer(t, got)
+	defer srv.Close()
+
+	n := New(idwrap.NewNow(), "Broker", wsURL(srv)+"/ws", nil, nil)
+	n.Protocol = ProtocolConfig{
+		Protocol:       mwebsocket.ProtocolStomp,
+		ConnectPayload: `{"login":"{{ user }}","passcode":"secret"}`,
+		Subscriptions:  []string{"/topic/{{ topic }}"},
+	}
+	req := newReq(mflow.EdgesMap{}, nil)
+	req.VarMap["user"] = "guest"
+	req.VarMap["topic"] = "orders"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if result := n.RunSync(ctx, req); result.Err != nil {
+		t.Fatalf("RunSync error: %v", result.Err)
+	}
+	session, err := ReadSession(req, "Broker")
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+
+	connect, subscribe := <-got, <-got
+	if connect.headers["accept-version"] != "1.2,1.1,1.0" || connect.headers["passcode"] != "secret" {
+		t.Errorf("CONNECT headers = %v", connect.headers)
+	}
+	if subscribe.command != "SUBSCRIBE" || subscribe.headers["destination"] != "/topic/orders" {
+		t.Errorf("SUBSCRIBE = %+v", subscribe)
+	}
+
+	ackID, err := session.Emit(ctx, "/topic/orders", `{"id":7}`, true)
+	if err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+	send := <-got
+	if send.headers["content-type"] != "application/json" || send.headers["receipt"] != "1" || send.body != `{"id":7}` {
+		t.Errorf("SEND = %+v", send)
+	}
+
+	frames, err := session.Expect(ctx, 2, func(Frame) (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("Expect: %v", err)
+	}
+	message, receipt := frames[0].Event, frames[1].Event
+	if message["command"] != "MESSAGE" || message["json"].(map[string]any)["id"] != float64(7) {
+		t.Errorf("message = %v", message)
+	}
+	if headers := message["headers"].(map[string]any); headers["subscription"] != "sub-0" {
+		t.Errorf("message headers = %v", headers)
+	}
+	if receipt["command"] != "RECEIPT" || receipt["id"] != ackID {
+		t.Errorf("receipt = %v, want id %d", receipt, ackID)
+	}
+}
+
+func TestNodeWsConnection_StompRefused(t *testing.T) {
+	srv := stompServer(t, make(chan stompFrame, 10))
+	defer srv.Close()
+
+	n := New(idwrap.NewNow(), "Broker", wsURL(srv), nil, nil)
+	n.Protocol = ProtocolConfig{Protocol: mwebsocket.ProtocolStomp, ConnectPayload: `{"login":"nobody"}`}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result := n.RunSync(ctx, newReq(mflow.EdgesMap{}, nil))
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "bad credentials: login refused") {
+		t.Fatalf("err = %v, want the broker's error", result.Err)
+	}
+}
+
+func TestStompFrameRoundTrip(t *testing.T) {
+	headers := map[string]string{"destination": "/queue/a:b", "note": "line\nbreak"}
+	data := encodeStompFrame("SEND", headers, "body")
+	if want := "SEND\ndestination:/queue/a\\cb\nnote:line\\nbreak\n\nbody\x00"; string(data) != want {
+		t.Fatalf("encoded %q, want %q", data, want)
+	}
+
+	f, ok, err := parseStompFrame(data)
+	if err != nil || !ok {
+		t.Fatalf("parse: ok=%v err=%v", ok, err)
+	}
+	if f.command != "SEND" || f.headers["destination"] != "/queue/a:b" || f.headers["note"] != "line\nbreak" || f.body != "body" {
+		t.Errorf("parsed %+v", f)
+	}
+
+	if _, ok, err := parseStompFrame([]byte("\r\n")); ok || err != nil {
+		t.Errorf("heart-beat: ok=%v err=%v", ok, err)
+	}
+	if _, _, err := parseStompFrame([]byte("MESSAGE\ndestination:/a")); err == nil {
+		t.Error("expected an error for a frame without a header end")
+	}
+}
@@ -0,0 +1,233 @@
+//nolint:revive // exported
+package nwsexpect
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwsconnection"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// ErrExpectTimeout is returned when fewer frames than expected matched before
+// the timeout. It is reported to error branches as a timeout.
+var ErrExpectTimeout = errors.New("ws expect timed out")
+
+const (
+	// DefaultTimeoutMs is how long the node waits when no timeout is set.
+	DefaultTimeoutMs = 5000
+	// DefaultCount is the number of frames awaited when no count is set.
+	DefaultCount = 1
+)
+
+// Compile-time check that NodeWsExpect implements VariableIntrospector.
+var _ node.VariableIntrospector = (*NodeWsExpect)(nil)
+
+// NodeWsExpect optionally sends a message on the connection of a WsConnection
+// node, then waits for Count frames received after it that satisfy Match, and
+// checks Assertions against them. Match sees the candidate as "frame", e.g.
+// `frame.json.id == 42`; an empty Match accepts any frame.
+//
+// Ping checks the connection answers a ping, and ExpectClose waits for the
+// server to close it so assertions can check "close_code".
+type NodeWsExpect struct {
+	FlowNodeID           idwrap.IDWrap
+	Name                 string
+	WsConnectionNodeName string
+	Message              string
+	Binary               bool
+	Match                string
+	TimeoutMs            int64
+	Count                int32
+	Assertions           []string
+	Ping                 bool
+	ExpectClose          bool
+}
+
+func New(id idwrap.IDWrap, name string, cfg mflow.NodeWsExpect) *NodeWsExpect {
+	return &NodeWsExpect{
+		FlowNodeID:           id,
+		Name:                 name,
+		WsConnectionNodeName: cfg.WsConnectionNodeName,
+		Message:              cfg.Message,
+		Binary:               cfg.Binary,
+		Match:                cfg.Match,
+		TimeoutMs:            cfg.TimeoutMs,
+		Count:                cfg.Count,
+		Assertions:           cfg.Assertions,
+		Ping:                 cfg.Ping,
+		ExpectClose:          cfg.ExpectClose,
+	}
+}
+
+func (n *NodeWsExpect) GetID() idwrap.IDWrap {
+	return n.FlowNodeID
+}
+
+func (n *NodeWsExpect) SetID(id idwrap.IDWrap) {
+	n.FlowNodeID = id
+}
+
+func (n *NodeWsExpect) GetName() string {
+	return n.Name
+}
+
+// GetRequiredVariables implements node.VariableIntrospector.
+func (n *NodeWsExpect) GetRequiredVariables() []string {
+	return expression.ExtractVarKeysFromMultiple(n.Message, n.WsConnectionNodeName)
+}
+
+// GetOutputVariables implements node.VariableIntrospector.
+func (n *NodeWsExpect) GetOutputVariables() []string {
+	return []string{
+		"frames",
+		"frame",
+		"count",
+		"duration",
+		"protocol",
+		"ping_ms",
+		"close_code",
+		"close_reason",
+	}
+}
+
+func (n *NodeWsExpect) timeout() time.Duration {
+	if n.TimeoutMs > 0 {
+		return time.Duration(n.TimeoutMs) * time.Millisecond
+	}
+	return DefaultTimeoutMs * time.Millisecond
+}
+
+func (n *NodeWsExpect) count() int {
+	if n.Count > 0 {
+		return int(n.Count)
+	}
+	return DefaultCount
+}
+
+func (n *NodeWsExpect) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	session, err := nwsconnection.ReadSession(req, n.WsConnectionNodeName)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	varMapCopy := node.DeepCopyVarMap(req)
+	env := expression.NewUnifiedEnv(varMapCopy)
+
+	start := time.Now()
+	waitCtx, cancel := context.WithTimeout(ctx, n.timeout())
+	defer cancel()
+
+	if n.Message != "" {
+		if err := n.send(waitCtx, env, session); err != nil {
+			return node.FlowNodeResult{Err: err}
+		}
+	}
+
+	outputs := map[string]any{
+		"protocol":     session.Protocol,
+		"ping_ms":      nil,
+		"close_code":   nil,
+		"close_reason": nil,
+	}
+
+	if n.Ping {
+		rtt, err := session.Ping(waitCtx)
+		if err != nil {
+			return node.FlowNodeResult{Err: n.waitError(ctx, "ping", err)}
+		}
+		outputs["ping_ms"] = rtt.Milliseconds()
+	}
+
+	// Ping and close checks only await frames when given a match expression.
+	var frames []nwsconnection.Frame
+	if n.Match != "" || (!n.Ping && !n.ExpectClose) {
+		frames, err = session.Expect(waitCtx, n.count(), func(f nwsconnection.Frame) (bool, error) {
+			return n.matches(ctx, env, f)
+		})
+		if err != nil {
+			return node.FlowNodeResult{Err: n.waitError(ctx, fmt.Sprintf("%d of %d frames", len(frames), n.count()), err)}
+		}
+	}
+
+	if n.ExpectClose {
+		code, reason, err := session.WaitClose(waitCtx)
+		if err != nil {
+			return node.FlowNodeResult{Err: n.waitError(ctx, "close", err)}
+		}
+		outputs["close_code"] = code
+		outputs["close_reason"] = reason
+	}
+
+	frameOutputs := make([]any, len(frames))
+	for i, f := range frames {
+		frameOutputs[i] = f.Output()
+	}
+	outputs["frames"] = frameOutputs
+	outputs["frame"] = nil
+	if len(frameOutputs) > 0 {
+		outputs["frame"] = frameOutputs[0]
+	}
+	outputs["count"] = len(frames)
+	outputs["duration"] = time.Since(start).Milliseconds()
+
+	if err := node.WriteNodeOutputs(req, n.Name, outputs); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	if err := node.AssertOutputs(ctx, n.Name, varMapCopy, outputs, n.Assertions); err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+
+	return node.FlowNodeResult{
+		NextNodeID: mflow.GetNextNodeID(req.EdgeSourceMap, n.FlowNodeID, mflow.HandleUnspecified),
+	}
+}
+
+func (n *NodeWsExpect) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- n.RunSync(ctx, req)
+}
+
+func (n *NodeWsExpect) send(ctx context.Context, env *expression.UnifiedEnv, session *nwsconnection.Session) error {
+	interpolated, err := env.InterpolateCtx(ctx, n.Message)
+	if err != nil {
+		return fmt.Errorf("interpolate message: %w", err)
+	}
+	if !n.Binary {
+		return session.Send(ctx, websocket.MessageText, []byte(interpolated))
+	}
+	payload, err := base64.StdEncoding.DecodeString(interpolated)
+	if err != nil {
+		return fmt.Errorf("decode binary message: %w", err)
+	}
+	return session.Send(ctx, websocket.MessageBinary, payload)
+}
+
+// matches evaluates Match with f as "frame". An expression that cannot be
+// evaluated against f, e.g. because a text frame is not JSON, does not match.
+func (n *NodeWsExpect) matches(ctx context.Context, env *expression.UnifiedEnv, f nwsconnection.Frame) (bool, error) {
+	if n.Match == "" {
+		return true, nil
+	}
+	frameEnv := env.Clone()
+	frameEnv.GetData()["frame"] = f.Output()
+	ok, err := frameEnv.EvalBool(ctx, n.Match)
+	if err != nil {
+		return false, nil //nolint:nilerr // a frame the expression does not apply to is not a match
+	}
+	return ok, nil
+}
+
+// waitError describes a wait on the connection that did not finish; see
+// node.WaitError.
+func (n *NodeWsExpect) waitError(ctx context.Context, what string, err error) error {
+	return node.WaitError(ctx, ErrExpectTimeout, what, n.WsConnectionNodeName, n.timeout(), err)
+}
@@ -0,0 +1,259 @@
+package nwsexpect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwsconnection"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// rpcServer answers {"id": n} with an unrelated notification followed by
+// {"id": n, "result": "ok"}. "close" makes it close with code 4001, and any
+// other message is echoed.
+func rpcServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.StatusNormalClosure, "")
+		for {
+			typ, msg, err := conn.Read(r.Context())
+			if err != nil {
+				return
+			}
+			if string(msg) == "close" {
+				_ = conn.Close(websocket.StatusCode(4001), "bye")
+				return
+			}
+			var call struct {
+				ID *int `json:"id"`
+			}
+			if typ == websocket.MessageText && json.Unmarshal(msg, &call) == nil && call.ID != nil {
+				_ = conn.Write(r.Context(), websocket.MessageText, []byte(`{"event":"tick"}`))
+				reply, _ := json.Marshal(map[string]any{"id": *call.ID, "result": "ok"})
+				_ = conn.Write(r.Context(), websocket.MessageText, reply)
+				continue
+			}
+			if err := conn.Write(r.Context(), typ, msg); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func newReq() *node.FlowNodeRequest {
+	return &node.FlowNodeRequest{
+		VarMap:           make(map[string]any),
+		ReadWriteLock:    &sync.RWMutex{},
+		EdgeSourceMap:    mflow.EdgesMap{},
+		Timeout:          10 * time.Second,
+		PendingAtmoicMap: make(map[idwrap.IDWrap]uint32),
+		PendingMapMu:     &sync.Mutex{},
+	}
+}
+
+// connect runs a WsConnection node named "WS" against srv.
+func connect(t *testing.T, ctx context.Context, srv *httptest.Server, req *node.FlowNodeRequest) {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	conn := nwsconnection.New(idwrap.NewNow(), "WS", url, nil, nil)
+	if result := conn.RunSync(ctx, req); result.Err != nil {
+		t.Fatalf("connect: %v", result.Err)
+	}
+}
+
+func run(t *testing.T, ctx context.Context, req *node.FlowNodeRequest, cfg mflow.NodeWsExpect) error {
+	t.Helper()
+	cfg.WsConnectionNodeName = "WS"
+	return New(idwrap.NewNow(), "Expect", cfg).RunSync(ctx, req).Err
+}
+
+func readOutput(t *testing.T, req *node.FlowNodeRequest, key string) any {
+	t.Helper()
+	v, err := node.ReadNodeVar(req, "Expect", key)
+	if err != nil {
+		t.Fatalf("read %s: %v", key, err)
+	}
+	return v
+}
+
+func TestNodeWsExpect_Correlation(t *testing.T) {
+	srv := rpcServer(t)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := newReq()
+	connect(t, ctx, srv, req)
+	req.VarMap["callId"] = 7
+
+	err := run(t, ctx, req, mflow.NodeWsExpect{
+		Message:    `{"id": {{ callId }}}`,
+		Match:      "frame.json.id == 7",
+		Assertions: []string{`frame.json.result == "ok"`, "Expect.count == 1"},
+	})
+	if err != nil {
+		t.Fatalf("RunSync error: %v", err)
+	}
+
+	frame, ok := readOutput(t, req, "frame").(map[string]any)
+	if !ok {
+		t.Fatalf("frame output is %T", readOutput(t, req, "frame"))
+	}
+	if frame["data"] != `{"id":7,"result":"ok"}` {
+		t.Errorf("frame data = %v", frame["data"])
+	}
+
+	// The notification skipped over by the match is left for an expectation
+	// without a send, but the reply already matched is not matched again.
+	if err := run(t, ctx, req, mflow.NodeWsExpect{Match: `frame.json.event == "tick"`}); err != nil {
+		t.Errorf("expect skipped notification: %v", err)
+	}
+	if err := run(t, ctx, req, mflow.NodeWsExpect{Match: "frame.json.id == 7", TimeoutMs: 100}); !errors.Is(err, ErrExpectTimeout) {
+		t.Errorf("err = %v, want the matched reply not to match twice", err)
+	}
+}
+
+func TestNodeWsExpect_Count(t *testing.T) {
+	srv := rpcServer(t)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := newReq()
+	connect(t, ctx, srv, req)
+
+	if err := run(t, ctx, req, mflow.NodeWsExpect{Message: `{"id": 1}`, Count: 2}); err != nil {
+		t.Fatalf("RunSync error: %v", err)
+	}
+	if got := readOutput(t, req, "count"); got != 2 {
+		t.Errorf("count = %v, want 2", got)
+	}
+	if frames := readOutput(t, req, "frames").([]any); len(frames) != 2 {
+		t.Errorf("frames = %d, want 2", len(frames))
+	}
+}
+
+func TestNodeWsExpect_Timeout(t *testing.T) {
+	srv := rpcServer(t)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := newReq()
+	connect(t, ctx, srv, req)
+
+	err := run(t, ctx, req, mflow.NodeWsExpect{
+		Message:   `{"id": 1}`,
+		Match:     "frame.json.id == 2",
+		TimeoutMs: 100,
+	})
+	if !errors.Is(err, ErrExpectTimeout) {
+		t.Fatalf("err = %v, want ErrExpectTimeout", err)
+	}
+	if kind := node.ErrorKind(err); kind != node.ErrorKindTimeout {
+		t.Errorf("error kind = %q, want %q", kind, node.ErrorKindTimeout)
+	}
+}
+
+func TestNodeWsExpect_AssertionFailed(t *testing.T) {
+	srv := rpcServer(t)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := newReq()
+	connect(t, ctx, srv, req)
+
+	err := run(t, ctx, req, mflow.NodeWsExpect{
+		Message:    `{"id": 3}`,
+		Match:      "frame.json.id == 3",
+		Assertions: []string{`frame.json.result == "error"`},
+	})
+	if !errors.Is(err, node.ErrAssertionFailed) {
+		t.Fatalf("err = %v, want ErrAssertionFailed", err)
+	}
+}
+
+func TestNodeWsExpect_Binary(t *testing.T) {
+	srv := rpcServer(t)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := newReq()
+	connect(t, ctx, srv, req)
+
+	err := run(t, ctx, req, mflow.NodeWsExpect{
+		Message:    "AAEC/w==",
+		Binary:     true,
+		Match:      `frame.type == "binary"`,
+		Assertions: []string{`frame.data == "AAEC/w=="`},
+	})
+	if err != nil {
+		t.Fatalf("RunSync error: %v", err)
+	}
+}
+
+func TestNodeWsExpect_Ping(t *testing.T) {
+	srv := rpcServer(t)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := newReq()
+	connect(t, ctx, srv, req)
+
+	if err := run(t, ctx, req, mflow.NodeWsExpect{Ping: true, Assertions: []string{"ping_ms >= 0"}}); err != nil {
+		t.Fatalf("RunSync error: %v", err)
+	}
+	if readOutput(t, req, "ping_ms") == nil {
+		t.Error("ping_ms not set")
+	}
+}
+
+func TestNodeWsExpect_CloseCode(t *testing.T) {
+	srv := rpcServer(t)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := newReq()
+	connect(t, ctx, srv, req)
+
+	err := run(t, ctx, req, mflow.NodeWsExpect{
+		Message:     "close",
+		ExpectClose: true,
+		Assertions:  []string{"close_code == 4001", `close_reason == "bye"`},
+	})
+	if err != nil {
+		t.Fatalf("RunSync error: %v", err)
+	}
+
+	// Frames awaited on a connection the server closes fail the node.
+	req = newReq()
+	connect(t, ctx, srv, req)
+	err = run(t, ctx, req, mflow.NodeWsExpect{
+		Message:     "close",
+		Match:       "true",
+		TimeoutMs:   1000,
+		ExpectClose: true,
+	})
+	if !errors.Is(err, nwsconnection.ErrConnectionClosed) {
+		t.Fatalf("err = %v, want ErrConnectionClosed", err)
+	}
+}
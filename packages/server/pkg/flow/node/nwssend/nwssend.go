@@ -3,10 +3,12 @@ package nwssend
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwsconnection"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
 	"github.com/coder/websocket"
@@ -21,6 +23,14 @@ type NodeWsSend struct {
 	Name                 string
 	WsConnectionNodeName string
 	Message              string
+	// Binary sends the interpolated message, base64 decoded, as a binary frame.
+	Binary bool
+	// Event, on a Socket.IO or STOMP connection, is the event emitted or the
+	// destination sent to, with the message as its payload.
+	Event string
+	// Ack asks the server to acknowledge the event; the id its
+	// acknowledgement carries is written to the "ack_id" output.
+	Ack bool
 }
 
 func New(id idwrap.IDWrap, name string, wsConnectionNodeName string, message string) *NodeWsSend {
@@ -46,7 +56,7 @@ func (n *NodeWsSend) GetName() string {
 
 // GetRequiredVariables implements node.VariableIntrospector.
 func (n *NodeWsSend) GetRequiredVariables() []string {
-	return expression.ExtractVarKeysFromMultiple(n.Message, n.WsConnectionNodeName)
+	return expression.ExtractVarKeysFromMultiple(n.Message, n.WsConnectionNodeName, n.Event)
 }
 
 // GetOutputVariables implements node.VariableIntrospector.
@@ -54,6 +64,9 @@ func (n *NodeWsSend) GetOutputVariables() []string {
 	return []string{
 		"type",
 		"message",
+		"binary",
+		"event",
+		"ack_id",
 		"connectionNode",
 		"cookies",
 	}
@@ -68,15 +81,22 @@ func (n *NodeWsSend) RunSync(ctx context.Context, req *node.FlowNodeRequest) nod
 		return node.FlowNodeResult{Err: fmt.Errorf("interpolate message: %w", err)}
 	}
 
-	// Read the WebSocket connection from the WsConnection node's VarMap
-	connRaw, err := node.ReadNodeVar(req, n.WsConnectionNodeName, "_conn")
+	event, err := env.InterpolateCtx(ctx, n.Event)
 	if err != nil {
-		return node.FlowNodeResult{Err: fmt.Errorf("read ws connection from node %q: %w", n.WsConnectionNodeName, err)}
+		return node.FlowNodeResult{Err: fmt.Errorf("interpolate event: %w", err)}
+	}
+	if event != "" && n.Binary {
+		return node.FlowNodeResult{Err: fmt.Errorf("binary messages cannot be sent as event %q", event)}
 	}
 
-	conn, ok := connRaw.(*websocket.Conn)
-	if !ok {
-		return node.FlowNodeResult{Err: fmt.Errorf("ws connection from node %q is not a valid WebSocket connection", n.WsConnectionNodeName)}
+	msgType := websocket.MessageText
+	payload := []byte(interpolated)
+	if n.Binary {
+		msgType = websocket.MessageBinary
+		payload, err = base64.StdEncoding.DecodeString(interpolated)
+		if err != nil {
+			return node.FlowNodeResult{Err: fmt.Errorf("decode binary message: %w", err)}
+		}
 	}
 
 	// Read cookies from the connection node (set during the upgrade handshake).
@@ -88,9 +108,37 @@ func (n *NodeWsSend) RunSync(ctx context.Context, req *node.FlowNodeRequest) nod
 		}
 	}
 
-	// Send the message
-	if err := conn.Write(ctx, websocket.MessageText, []byte(interpolated)); err != nil {
-		return node.FlowNodeResult{Err: fmt.Errorf("websocket write: %w", err)}
+	// Send the message, through the connection's session when there is one so
+	// that WsExpect nodes only match replies received after it.
+	var ackID any
+	if event != "" {
+		session, err := nwsconnection.ReadSession(req, n.WsConnectionNodeName)
+		if err != nil {
+			return node.FlowNodeResult{Err: err}
+		}
+		id, err := session.Emit(ctx, event, interpolated, n.Ack)
+		if err != nil {
+			return node.FlowNodeResult{Err: fmt.Errorf("emit %q: %w", event, err)}
+		}
+		if n.Ack {
+			ackID = id
+		}
+	} else if session, err := nwsconnection.ReadSession(req, n.WsConnectionNodeName); err == nil {
+		if err := session.Send(ctx, msgType, payload); err != nil {
+			return node.FlowNodeResult{Err: err}
+		}
+	} else {
+		connRaw, err := node.ReadNodeVar(req, n.WsConnectionNodeName, "_conn")
+		if err != nil {
+			return node.FlowNodeResult{Err: fmt.Errorf("read ws connection from node %q: %w", n.WsConnectionNodeName, err)}
+		}
+		conn, ok := connRaw.(*websocket.Conn)
+		if !ok {
+			return node.FlowNodeResult{Err: fmt.Errorf("ws connection from node %q is not a valid WebSocket connection", n.WsConnectionNodeName)}
+		}
+		if err := conn.Write(ctx, msgType, payload); err != nil {
+			return node.FlowNodeResult{Err: fmt.Errorf("websocket write: %w", err)}
+		}
 	}
 
 	// Write the sent message to output vars
@@ -106,6 +154,15 @@ func (n *NodeWsSend) RunSync(ctx context.Context, req *node.FlowNodeRequest) nod
 	if err := writeVar("message", interpolated); err != nil {
 		return node.FlowNodeResult{Err: fmt.Errorf("write message var: %w", err)}
 	}
+	if err := writeVar("binary", n.Binary); err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("write binary var: %w", err)}
+	}
+	if err := writeVar("event", event); err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("write event var: %w", err)}
+	}
+	if err := writeVar("ack_id", ackID); err != nil {
+		return node.FlowNodeResult{Err: fmt.Errorf("write ack_id var: %w", err)}
+	}
 	if err := writeVar("connectionNode", n.WsConnectionNodeName); err != nil {
 		return node.FlowNodeResult{Err: fmt.Errorf("write connectionNode var: %w", err)}
 	}
@@ -2,6 +2,7 @@ package nwssend
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nwsconnection"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
 	"github.com/coder/websocket"
@@ -150,3 +152,77 @@ func TestNodeWsSend_MissingConnection(t *testing.T) {
 		t.Fatal("expected error for missing connection node")
 	}
 }
+
+func TestNodeWsSend_Binary(t *testing.T) {
+	srv := echoServer(t)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, wsURL(srv), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	n := New(idwrap.NewNow(), "SendMsg", "MyWS", "AAEC/w==")
+	n.Binary = true
+
+	req := newReq(mflow.EdgesMap{})
+	_ = node.WriteNodeVar(req, "MyWS", "_conn", conn)
+
+	result := n.RunSync(ctx, req)
+	if result.Err != nil {
+		t.Fatalf("RunSync error: %v", result.Err)
+	}
+
+	typ, msg, err := conn.Read(ctx)
+	if err != nil {
+		t.Fatalf("read echo: %v", err)
+	}
+	if typ != websocket.MessageBinary {
+		t.Errorf("type = %v, want binary", typ)
+	}
+	if string(msg) != "\x00\x01\x02\xff" {
+		t.Errorf("echoed = %q, want 00 01 02 ff", msg)
+	}
+}
+
+func TestNodeWsSend_BinaryInvalidBase64(t *testing.T) {
+	n := New(idwrap.NewNow(), "SendMsg", "MyWS", "not base64!")
+	n.Binary = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	result := n.RunSync(ctx, newReq(mflow.EdgesMap{}))
+	if result.Err == nil {
+		t.Fatal("expected error for invalid base64 message")
+	}
+}
+
+func TestNodeWsSend_EventNeedsProtocol(t *testing.T) {
+	srv := echoServer(t)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, wsURL(srv), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	n := New(idwrap.NewNow(), "SendMsg", "MyWS", `{"room":"a"}`)
+	n.Event = "join"
+
+	req := newReq(mflow.EdgesMap{})
+	_ = node.WriteNodeVar(req, "MyWS", nwsconnection.SessionVar, nwsconnection.NewSession(conn, nil))
+
+	result := n.RunSync(ctx, req)
+	if !errors.Is(result.Err, nwsconnection.ErrNoProtocol) {
+		t.Fatalf("err = %v, want ErrNoProtocol", result.Err)
+	}
+}
@@ -0,0 +1,65 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+)
+
+// WriteNodeOutputs writes the outputs of a node under its name, tracking the
+// writes when the request has a variable tracker.
+func WriteNodeOutputs(req *FlowNodeRequest, name string, outputs map[string]any) error {
+	var err error
+	if req.VariableTracker != nil {
+		err = WriteNodeVarBulkWithTracking(req, name, outputs, req.VariableTracker)
+	} else {
+		err = WriteNodeVarBulk(req, name, outputs)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write node output: %w", err)
+	}
+	return nil
+}
+
+// AssertOutputs evaluates each assertion with the node's outputs in scope,
+// both bare ("count") and under the node's name ("Check.count"). Empty
+// assertions are skipped; the first one that does not hold fails with
+// ErrAssertionFailed.
+func AssertOutputs(ctx context.Context, name string, varMap map[string]any, outputs map[string]any, exprs []string) error {
+	if len(exprs) == 0 {
+		return nil
+	}
+	env := expression.NewUnifiedEnv(varMap)
+	data := env.GetData()
+	data[name] = outputs
+	for k, v := range outputs {
+		data[k] = v
+	}
+	for _, expr := range exprs {
+		if expr == "" {
+			continue
+		}
+		ok, err := env.EvalBool(ctx, expr)
+		if err != nil {
+			return fmt.Errorf("evaluate assertion '%s': %w", expr, err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrAssertionFailed, expr)
+		}
+	}
+	return nil
+}
+
+// WaitError describes a wait for what on source that did not finish. Running
+// out of the node's own timeout is reported as timeoutErr, still matching
+// context.DeadlineExceeded; cancellation of the flow, when ctx itself is done,
+// is passed through.
+func WaitError(ctx context.Context, timeoutErr error, what, source string, timeout time.Duration, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return fmt.Errorf("%w: waiting for %s on %q after %s: %w", timeoutErr, what, source, timeout, context.DeadlineExceeded)
+	}
+	return fmt.Errorf("waiting for %s on %q: %w", what, source, err)
+}
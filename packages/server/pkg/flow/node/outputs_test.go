@@ -0,0 +1,54 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteNodeOutputs(t *testing.T) {
+	req := &node.FlowNodeRequest{
+		VarMap:        make(map[string]interface{}),
+		ReadWriteLock: &sync.RWMutex{},
+	}
+
+	require.NoError(t, node.WriteNodeOutputs(req, "Check", map[string]any{"count": 2}))
+
+	count, err := node.ReadNodeVar(req, "Check", "count")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
+
+func TestAssertOutputs(t *testing.T) {
+	ctx := context.Background()
+	varMap := map[string]any{"want": 2}
+	outputs := map[string]any{"count": 2}
+
+	require.NoError(t, node.AssertOutputs(ctx, "Check", varMap, outputs, nil))
+	require.NoError(t, node.AssertOutputs(ctx, "Check", varMap, outputs, []string{"", "count == want", "Check.count == 2"}))
+
+	err := node.AssertOutputs(ctx, "Check", varMap, outputs, []string{"count == 2", "count > 2"})
+	require.ErrorIs(t, err, node.ErrAssertionFailed)
+	require.ErrorContains(t, err, "count > 2")
+}
+
+func TestWaitError(t *testing.T) {
+	errTimeout := errors.New("receive timed out")
+
+	err := node.WaitError(context.Background(), errTimeout, "1 of 2 messages", "Conn", time.Second, context.DeadlineExceeded)
+	require.ErrorIs(t, err, errTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, `waiting for 1 of 2 messages on "Conn" after 1s`)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = node.WaitError(canceled, errTimeout, "1 of 2 messages", "Conn", time.Second, context.Canceled)
+	require.NotErrorIs(t, err, errTimeout)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -3,6 +3,7 @@ package flowlocalrunner
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"runtime"
 	"sync"
@@ -49,9 +50,16 @@ type FlowLocalRunner struct {
 	leanMode       bool
 
 	enableDataTracking bool
+	teardownTimeout    time.Duration
+	resume             *runner.ResumePoint
+	debugger           node.Debugger
 	logger             *slog.Logger
 }
 
+// defaultTeardownTimeout bounds the teardown section when the run gave it no
+// deadline of its own, since it runs after the flow's context has ended.
+const defaultTeardownTimeout = 5 * time.Minute
+
 var _ runner.FlowRunner = (*FlowLocalRunner)(nil)
 
 // Option customises a FlowLocalRunner at construction time. Passing no options
@@ -69,6 +77,17 @@ func WithMaxConcurrency(n int) Option {
 	}
 }
 
+// WithTeardownTimeout bounds how long the teardown section may run. Values
+// <= 0 are ignored so the default is kept.
+func WithTeardownTimeout(d time.Duration) Option {
+	return func(r *FlowLocalRunner) {
+		if d <= 0 {
+			return
+		}
+		r.teardownTimeout = d
+	}
+}
+
 // WithLeanMode enables lean execution: nodes drop response bodies from their
 // flow output once assertions have been evaluated, keeping memory flat across
 // long load runs. Downstream nodes cannot extract from a dropped body, so this
@@ -79,6 +98,17 @@ func WithLeanMode(enabled bool) Option {
 	}
 }
 
+// WithDebugger runs the flow in debug mode: debugger may suspend it before
+// each node. Unless an execution mode is set explicitly, debug runs execute
+// one node at a time so that stepping follows the flow's order. Teardown
+// sections run without the debugger, so they still clean up after an
+// aborted session.
+func WithDebugger(debugger node.Debugger) Option {
+	return func(r *FlowLocalRunner) {
+		r.debugger = debugger
+	}
+}
+
 func CreateFlowRunner(id, flowID idwrap.IDWrap, startNodeIDs []idwrap.IDWrap, flowNodeMap map[idwrap.IDWrap]node.FlowNode, edgesMap mflow.EdgesMap, timeout time.Duration, logger *slog.Logger, opts ...Option) *FlowLocalRunner {
 	r := &FlowLocalRunner{
 		ID:                 id,
@@ -90,6 +120,7 @@ func CreateFlowRunner(id, flowID idwrap.IDWrap, startNodeIDs []idwrap.IDWrap, fl
 		mode:               ExecutionModeAuto,
 		selectedMode:       ExecutionModeMulti,
 		enableDataTracking: true,
+		teardownTimeout:    defaultTeardownTimeout,
 		logger:             logger,
 	}
 	for _, opt := range opts {
@@ -114,6 +145,21 @@ func (r *FlowLocalRunner) SelectedMode() ExecutionMode {
 	return r.selectedMode
 }
 
+// PlanResume works out where to resume a previous run of this flow from the
+// node states it recorded. See runner.PlanResume; teardown sections are left
+// out of the walk, since they run again after every run.
+func (r *FlowLocalRunner) PlanResume(states map[idwrap.IDWrap]mflow.NodeState, from []idwrap.IDWrap) (runner.ResumePoint, error) {
+	startNodeIDs, _ := r.splitTeardownEntries()
+	return runner.PlanResume(r.graph.Edges, startNodeIDs, states, from)
+}
+
+// SetResumePoint makes the next run start from the resume point's nodes
+// instead of the flow's entry nodes. The caller restores the completed nodes'
+// outputs into the base variables. Passing nil restores a full run.
+func (r *FlowLocalRunner) SetResumePoint(point *runner.ResumePoint) {
+	r.resume = point
+}
+
 // SetDataTrackingEnabled toggles variable tracking during execution.
 func (r *FlowLocalRunner) SetDataTrackingEnabled(enabled bool) {
 	r.enableDataTracking = enabled
@@ -189,6 +235,9 @@ func (r *FlowLocalRunner) RunWithEvents(ctx context.Context, channels runner.Flo
 	for k, v := range r.graph.ConvergeCounts {
 		pendingAtmoicMap[k] = v
 	}
+	if r.resume != nil {
+		pendingAtmoicMap = r.resume.ConvergeCounts(r.graph)
+	}
 
 	if baseVars == nil {
 		baseVars = make(map[string]any)
@@ -217,11 +266,16 @@ func (r *FlowLocalRunner) RunWithEvents(ctx context.Context, channels runner.Flo
 		PendingMapMu:     pendingMu,
 		Logger:           r.logger,
 		LeanMode:         r.leanMode,
+		Debugger:         r.debugger,
 	}
 
 	mode := r.mode
 	if mode == ExecutionModeAuto {
-		mode = selectExecutionMode(r.FlowNodeMap, r.graph.Edges)
+		if r.debugger != nil {
+			mode = ExecutionModeSingle
+		} else {
+			mode = selectExecutionMode(r.FlowNodeMap, r.graph.Edges)
+		}
 	}
 	r.selectedMode = mode
 
@@ -238,14 +292,21 @@ func (r *FlowLocalRunner) RunWithEvents(ctx context.Context, channels runner.Flo
 		PredecessorMap: r.graph.Predecessors,
 	}
 
+	startNodeIDs, teardownNodeIDs := r.splitTeardownEntries()
+	if r.resume != nil {
+		startNodeIDs = r.resume.NodeIDs
+	}
+
 	var err error
-	if len(r.graph.StartNodeIDs) == 1 {
+	switch len(startNodeIDs) {
+	case 0:
+	case 1:
 		// Single entry — fast path, no errgroup overhead
-		err = runNodes(ctx, r.graph.StartNodeIDs[0], req, mode, cfg)
-	} else {
+		err = runNodes(ctx, startNodeIDs[0], req, mode, cfg)
+	default:
 		// Multiple entries — run each chain concurrently
 		eg, egCtx := errgroup.WithContext(ctx)
-		for _, startID := range r.graph.StartNodeIDs {
+		for _, startID := range startNodeIDs {
 			eg.Go(func() error {
 				return runNodes(egCtx, startID, req, mode, cfg)
 			})
@@ -253,6 +314,12 @@ func (r *FlowLocalRunner) RunWithEvents(ctx context.Context, channels runner.Flo
 		err = eg.Wait()
 	}
 
+	if len(teardownNodeIDs) > 0 {
+		if teardownErr := r.runTeardown(ctx, teardownNodeIDs, req, mode, cfg, emitFn); teardownErr != nil && err == nil {
+			err = &runner.TeardownError{Err: teardownErr}
+		}
+	}
+
 	if channels.FlowStatus != nil {
 		if err != nil {
 			channels.FlowStatus <- runner.FlowStatusFailed
@@ -287,3 +354,48 @@ func SetGoroutineCountForTesting(n int) func() {
 func BuildPredecessorMap(edgesMap mflow.EdgesMap) map[idwrap.IDWrap][]idwrap.IDWrap {
 	return runner.BuildPredecessorMap(edgesMap)
 }
+
+// splitTeardownEntries separates the teardown entries, which only run once
+// the flow has ended, from the entries the run starts with.
+func (r *FlowLocalRunner) splitTeardownEntries() (startNodeIDs, teardownNodeIDs []idwrap.IDWrap) {
+	for _, id := range r.graph.StartNodeIDs {
+		if entry, ok := r.FlowNodeMap[id].(node.TeardownEntry); ok && entry.IsTeardownEntry() {
+			teardownNodeIDs = append(teardownNodeIDs, id)
+			continue
+		}
+		startNodeIDs = append(startNodeIDs, id)
+	}
+	return startNodeIDs, teardownNodeIDs
+}
+
+// runTeardown runs each teardown section after the main run, sharing its
+// variables. The sections get a context that survives the run's cancellation
+// and deadline, so cleanup still happens after a canceled or timed out run,
+// and their node statuses are marked as teardown statuses. They are never
+// paused by a debugger.
+func (r *FlowLocalRunner) runTeardown(ctx context.Context, teardownNodeIDs []idwrap.IDWrap, req *node.FlowNodeRequest,
+	mode ExecutionMode, cfg RunConfig, emitFn func(runner.FlowNodeStatus),
+) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.teardownTimeout)
+	defer cancel()
+
+	teardownEmitFn := func(status runner.FlowNodeStatus) {
+		status.Teardown = true
+		emitFn(status)
+	}
+	statusFunc := node.LogPushFunc(teardownEmitFn)
+	cfg.Emitter = runner.NewStatusEmitter(teardownEmitFn)
+	cfg.StatusLogFunc = statusFunc
+
+	teardownReq := *req
+	teardownReq.LogPushFunc = statusFunc
+	teardownReq.Debugger = nil
+
+	var errs []error
+	for _, teardownID := range teardownNodeIDs {
+		if err := runNodes(ctx, teardownID, &teardownReq, mode, cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,97 @@
+package flowlocalrunner_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nstart"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	flowlocalrunner "github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func TestResumeContinuesFromFailedNode(t *testing.T) {
+	startID := idwrap.NewNow()
+	var callLog []string
+	done := &stubNode{id: idwrap.NewNow(), name: "Done", callLog: &callLog}
+	read := &cleanupNode{id: idwrap.NewNow(), name: "GetUser", source: "CreateUser", key: "id", next: []idwrap.IDWrap{done.id}}
+	create := newFailingNode(idwrap.NewNow(), "CreateUser", nil, errors.New("must not run again"))
+
+	nodeMap := map[idwrap.IDWrap]node.FlowNode{
+		startID:   nstart.New(startID, "Start"),
+		create.id: create,
+		read.id:   read,
+		done.id:   done,
+	}
+	edgesMap := mflow.EdgesMap{
+		startID:   {mflow.HandleUnspecified: {create.id}},
+		create.id: {mflow.HandleUnspecified: {read.id}},
+		read.id:   {mflow.HandleUnspecified: {done.id}},
+	}
+	flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), []idwrap.IDWrap{startID}, nodeMap, edgesMap, time.Second, slog.Default())
+
+	point, err := flowRunner.PlanResume(map[idwrap.IDWrap]mflow.NodeState{
+		startID:   mflow.NODE_STATE_SUCCESS,
+		create.id: mflow.NODE_STATE_SUCCESS,
+		read.id:   mflow.NODE_STATE_FAILURE,
+	}, nil)
+	require.NoError(t, err)
+	flowRunner.SetResumePoint(&point)
+
+	baseVars := map[string]any{"CreateUser": map[string]any{"id": "user-1"}}
+	nodeStates := make(chan runner.FlowNodeStatus, 100)
+	err = flowRunner.RunWithEvents(context.Background(), runner.FlowEventChannels{NodeStates: nodeStates}, baseVars)
+	require.NoError(t, err)
+
+	require.Equal(t, []any{"user-1"}, read.calls(), "resumed node reads the restored output")
+	require.Equal(t, []string{"Done"}, callLog)
+	for _, status := range drainStates(nodeStates) {
+		require.NotEqual(t, "CreateUser", status.Name, "completed nodes are not run again")
+		require.NotEqual(t, "Start", status.Name, "completed nodes are not run again")
+	}
+}
+
+func TestResumeDoesNotWaitOnCompletedJoinPredecessor(t *testing.T) {
+	for _, mode := range []flowlocalrunner.ExecutionMode{flowlocalrunner.ExecutionModeSingle, flowlocalrunner.ExecutionModeMulti} {
+		startID := idwrap.NewNow()
+		var callLog []string
+		join := &stubNode{id: idwrap.NewNow(), name: "Join", callLog: &callLog}
+		left := &stubNode{id: idwrap.NewNow(), name: "Left", next: []idwrap.IDWrap{join.id}, callLog: &callLog}
+		right := &stubNode{id: idwrap.NewNow(), name: "Right", next: []idwrap.IDWrap{join.id}, callLog: &callLog}
+
+		nodeMap := map[idwrap.IDWrap]node.FlowNode{
+			startID:  nstart.New(startID, "Start"),
+			left.id:  left,
+			right.id: right,
+			join.id:  join,
+		}
+		edgesMap := mflow.EdgesMap{
+			startID:  {mflow.HandleUnspecified: {left.id, right.id}},
+			left.id:  {mflow.HandleUnspecified: {join.id}},
+			right.id: {mflow.HandleUnspecified: {join.id}},
+		}
+		flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), []idwrap.IDWrap{startID}, nodeMap, edgesMap, time.Second, slog.Default())
+		flowRunner.SetExecutionMode(mode)
+
+		point, err := flowRunner.PlanResume(map[idwrap.IDWrap]mflow.NodeState{
+			startID:  mflow.NODE_STATE_SUCCESS,
+			left.id:  mflow.NODE_STATE_SUCCESS,
+			right.id: mflow.NODE_STATE_FAILURE,
+		}, nil)
+		require.NoError(t, err)
+		flowRunner.SetResumePoint(&point)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err = flowRunner.RunWithEvents(ctx, runner.FlowEventChannels{}, nil)
+		cancel()
+		require.NoError(t, err)
+		require.Equal(t, []string{"Right", "Join"}, callLog, "mode %d", mode)
+	}
+}
@@ -0,0 +1,150 @@
+package flowlocalrunner_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nstart"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node/nteardown"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	flowlocalrunner "github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner/flowlocalrunner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// cleanupNode reads a variable written by the main run and records it, the
+// way a teardown request would read the ID of the resource it deletes.
+type cleanupNode struct {
+	id     idwrap.IDWrap
+	name   string
+	source string
+	key    string
+	next   []idwrap.IDWrap
+	err    error
+
+	mu   sync.Mutex
+	seen []any
+}
+
+func (c *cleanupNode) GetID() idwrap.IDWrap { return c.id }
+
+func (c *cleanupNode) GetName() string { return c.name }
+
+func (c *cleanupNode) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	value, _ := node.ReadNodeVar(req, c.source, c.key)
+	c.mu.Lock()
+	c.seen = append(c.seen, value)
+	c.mu.Unlock()
+	return node.FlowNodeResult{NextNodeID: c.next, Err: c.err}
+}
+
+func (c *cleanupNode) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- c.RunSync(ctx, req)
+}
+
+func (c *cleanupNode) calls() []any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]any(nil), c.seen...)
+}
+
+// buildTeardownFlow wires start -> body and teardown -> cleanup.
+func buildTeardownFlow(body node.FlowNode, cleanup *cleanupNode) ([]idwrap.IDWrap, map[idwrap.IDWrap]node.FlowNode, mflow.EdgesMap) {
+	startID, teardownID := idwrap.NewNow(), idwrap.NewNow()
+	nodeMap := map[idwrap.IDWrap]node.FlowNode{
+		startID:      nstart.New(startID, "Start"),
+		teardownID:   nteardown.New(teardownID, "Teardown"),
+		body.GetID(): body,
+		cleanup.id:   cleanup,
+	}
+	edgesMap := mflow.EdgesMap{
+		startID:    {mflow.HandleUnspecified: {body.GetID()}},
+		teardownID: {mflow.HandleUnspecified: {cleanup.id}},
+	}
+	return []idwrap.IDWrap{startID, teardownID}, nodeMap, edgesMap
+}
+
+func runTeardownFlow(t *testing.T, ctx context.Context, entryIDs []idwrap.IDWrap, nodeMap map[idwrap.IDWrap]node.FlowNode, edgesMap mflow.EdgesMap) ([]runner.FlowNodeStatus, []runner.FlowStatus, error) {
+	t.Helper()
+	flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), entryIDs, nodeMap, edgesMap, time.Second, slog.Default())
+
+	nodeStates := make(chan runner.FlowNodeStatus, 100)
+	flowStatus := make(chan runner.FlowStatus, 10)
+	err := flowRunner.RunWithEvents(ctx, runner.FlowEventChannels{
+		NodeStates: nodeStates,
+		FlowStatus: flowStatus,
+	}, nil)
+	return drainStates(nodeStates), drainFlowStatus(flowStatus), err
+}
+
+func teardownStatuses(statuses []runner.FlowNodeStatus) map[string]mflow.NodeState {
+	states := make(map[string]mflow.NodeState)
+	for _, status := range statuses {
+		if status.Teardown && status.State != mflow.NODE_STATE_RUNNING {
+			states[status.Name] = status.State
+		}
+	}
+	return states
+}
+
+func TestTeardownRunsAfterFailedFlow(t *testing.T) {
+	mainErr := errors.New("assertion failed")
+	body := newFailingNode(idwrap.NewNow(), "CreateUser", map[string]any{"id": "user-1"}, mainErr)
+	cleanup := &cleanupNode{id: idwrap.NewNow(), name: "DeleteUser", source: "CreateUser", key: "id"}
+	entryIDs, nodeMap, edgesMap := buildTeardownFlow(body, cleanup)
+
+	statuses, flowStatuses, err := runTeardownFlow(t, context.Background(), entryIDs, nodeMap, edgesMap)
+
+	require.ErrorIs(t, err, mainErr)
+	var teardownErr *runner.TeardownError
+	require.False(t, errors.As(err, &teardownErr), "a failed flow reports its own error")
+	require.Equal(t, []any{"user-1"}, cleanup.calls(), "teardown reads the flow's variables")
+	require.Equal(t, mflow.NODE_STATE_SUCCESS, teardownStatuses(statuses)["DeleteUser"])
+	require.Equal(t, runner.FlowStatusFailed, flowStatuses[len(flowStatuses)-1])
+
+	for _, status := range statuses {
+		if status.Name == "CreateUser" {
+			require.False(t, status.Teardown, "main run statuses are not marked as teardown")
+		}
+	}
+}
+
+func TestTeardownRunsAfterCanceledFlow(t *testing.T) {
+	body := newBlockingNode("Wait", make(chan struct{}))
+	cleanup := &cleanupNode{id: idwrap.NewNow(), name: "Cleanup", source: "Wait", key: "id"}
+	entryIDs, nodeMap, edgesMap := buildTeardownFlow(body, cleanup)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		waitForStart(t, body.started, body.name)
+		cancel()
+	}()
+
+	statuses, _, err := runTeardownFlow(t, ctx, entryIDs, nodeMap, edgesMap)
+
+	require.True(t, runner.IsCancellationError(err), "expected cancellation, got %v", err)
+	require.Len(t, cleanup.calls(), 1)
+	require.Equal(t, mflow.NODE_STATE_SUCCESS, teardownStatuses(statuses)["Cleanup"])
+}
+
+func TestTeardownFailureAfterSuccessfulFlow(t *testing.T) {
+	cleanupErr := errors.New("delete failed")
+	body := &stubNode{id: idwrap.NewNow(), name: "CreateUser"}
+	cleanup := &cleanupNode{id: idwrap.NewNow(), name: "DeleteUser", source: "CreateUser", key: "id", err: cleanupErr}
+	entryIDs, nodeMap, edgesMap := buildTeardownFlow(body, cleanup)
+
+	statuses, flowStatuses, err := runTeardownFlow(t, context.Background(), entryIDs, nodeMap, edgesMap)
+
+	var teardownErr *runner.TeardownError
+	require.ErrorAs(t, err, &teardownErr)
+	require.ErrorIs(t, err, cleanupErr)
+	require.Equal(t, mflow.NODE_STATE_FAILURE, teardownStatuses(statuses)["DeleteUser"])
+	require.Equal(t, runner.FlowStatusFailed, flowStatuses[len(flowStatuses)-1])
+}
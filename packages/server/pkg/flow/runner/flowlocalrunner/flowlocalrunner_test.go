@@ -1376,3 +1376,100 @@ func TestEventDrivenSemaphoreBoundsConcurrency(t *testing.T) {
 
 	require.LessOrEqual(t, finalMax, 1, "expected max concurrent processing to be 1 (semaphore bound), got %d", finalMax)
 }
+
+type errorReadingNode struct {
+	id     idwrap.IDWrap
+	name   string
+	source string
+	got    *map[string]any
+}
+
+func (e *errorReadingNode) GetID() idwrap.IDWrap { return e.id }
+
+func (e *errorReadingNode) GetName() string { return e.name }
+
+func (e *errorReadingNode) RunSync(ctx context.Context, req *node.FlowNodeRequest) node.FlowNodeResult {
+	v, err := node.ReadNodeVar(req, e.source, node.OutputError)
+	if err != nil {
+		return node.FlowNodeResult{Err: err}
+	}
+	*e.got, _ = v.(map[string]any)
+	return node.FlowNodeResult{}
+}
+
+func (e *errorReadingNode) RunAsync(ctx context.Context, req *node.FlowNodeRequest, resultChan chan node.FlowNodeResult) {
+	resultChan <- e.RunSync(ctx, req)
+}
+
+func TestOnErrorEdgeContinuesFlow(t *testing.T) {
+	for _, mode := range []flowlocalrunner.ExecutionMode{flowlocalrunner.ExecutionModeSingle, flowlocalrunner.ExecutionModeMulti} {
+		startID := idwrap.NewNow()
+		failID := idwrap.NewNow()
+		cleanupID := idwrap.NewNow()
+
+		var got map[string]any
+		nodeMap := map[idwrap.IDWrap]node.FlowNode{
+			startID: &stubNode{id: startID, name: "start", next: []idwrap.IDWrap{failID}},
+			failID: newFailingNode(failID, "create_user", map[string]any{
+				"response": map[string]any{"status": float64(500)},
+			}, errors.New("boom")),
+			cleanupID: &errorReadingNode{id: cleanupID, name: "cleanup", source: "create_user", got: &got},
+		}
+		edgesMap := mflow.EdgesMap{
+			startID: {mflow.HandleUnspecified: []idwrap.IDWrap{failID}},
+			failID:  {mflow.HandleError: []idwrap.IDWrap{cleanupID}},
+		}
+
+		flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), []idwrap.IDWrap{startID}, nodeMap, edgesMap, 0, nil)
+		flowRunner.SetExecutionMode(mode)
+
+		stateChan := make(chan runner.FlowNodeStatus, 16)
+		flowStatusChan := make(chan runner.FlowStatus, 4)
+		err := flowRunner.Run(context.Background(), stateChan, flowStatusChan, nil)
+		require.NoError(t, err, "mode %v", mode)
+
+		var failed bool
+		for status := range stateChan {
+			if status.NodeID == failID && status.State == mflow.NODE_STATE_FAILURE {
+				failed = true
+			}
+		}
+		var flowStatuses []runner.FlowStatus
+		for status := range flowStatusChan {
+			flowStatuses = append(flowStatuses, status)
+		}
+
+		require.True(t, failed, "the failing node still reports FAILURE")
+		require.Equal(t, runner.FlowStatusSuccess, flowStatuses[len(flowStatuses)-1])
+		require.Equal(t, map[string]any{
+			"kind":     node.ErrorKindError,
+			"message":  "boom",
+			"node":     "create_user",
+			"response": map[string]any{"status": float64(500)},
+		}, got)
+	}
+}
+
+func TestUnhandledNodeErrorNamesNode(t *testing.T) {
+	startID := idwrap.NewNow()
+	failID := idwrap.NewNow()
+	failErr := errors.New("boom")
+
+	nodeMap := map[idwrap.IDWrap]node.FlowNode{
+		startID: &stubNode{id: startID, name: "start", next: []idwrap.IDWrap{failID}},
+		failID:  newFailingNode(failID, "create_user", nil, failErr),
+	}
+	edgesMap := mflow.EdgesMap{
+		startID: {mflow.HandleUnspecified: []idwrap.IDWrap{failID}},
+	}
+
+	flowRunner := flowlocalrunner.CreateFlowRunner(idwrap.NewNow(), idwrap.NewNow(), []idwrap.IDWrap{startID}, nodeMap, edgesMap, 0, nil)
+	err := flowRunner.Run(context.Background(), make(chan runner.FlowNodeStatus, 16), make(chan runner.FlowStatus, 4), nil)
+	require.ErrorIs(t, err, failErr)
+	require.Equal(t, "boom", err.Error())
+
+	var nodeErr *runner.NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	require.Equal(t, failID, nodeErr.NodeID)
+	require.Equal(t, "create_user", nodeErr.Name)
+}
@@ -6,9 +6,51 @@ import (
 
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/node"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
 )
 
+// errorBranch returns the nodes to continue with when the failure of nodeID
+// is handled by its on_error edges. The failure is written under
+// "<node>.error" for the branch to read. A canceled flow is never handled.
+func errorBranch(req *node.FlowNodeRequest, nodeID idwrap.IDWrap, nodeName string, nodeErr error) ([]idwrap.IDWrap, bool) {
+	if !node.IsHandleableError(nodeErr) {
+		return nil, false
+	}
+	targets := mflow.GetNextNodeID(req.EdgeSourceMap, nodeID, mflow.HandleError)
+	if len(targets) == 0 {
+		return nil, false
+	}
+	if err := node.WriteNodeVar(req, nodeName, node.OutputError, node.BuildErrorOutput(req, nodeName, nodeErr)); err != nil {
+		return nil, false
+	}
+	return targets, true
+}
+
+// debugBeforeNode lets the run's debugger, if any, suspend the flow before n
+// executes.
+func debugBeforeNode(ctx context.Context, n node.FlowNode, req *node.FlowNodeRequest) error {
+	if req.Debugger == nil {
+		return nil
+	}
+	return req.Debugger.BeforeNode(ctx, n, req)
+}
+
+// debugAfterNode hands the run's debugger, if any, the variables n wrote. When
+// data tracking is off, the node's whole output stands in for them.
+func debugAfterNode(n node.FlowNode, req *node.FlowNodeRequest, outcome ExecutionOutcome) {
+	if req.Debugger == nil {
+		return
+	}
+	written := outcome.TrackedOutput
+	if len(written) == 0 {
+		if output := collectSingleModeOutput(req, n.GetName()); output != nil {
+			written = map[string]any{n.GetName(): output}
+		}
+	}
+	req.Debugger.AfterNode(n, written)
+}
+
 // buildTerminalStatus consolidates state classification, data attachment, and
 // output flattening into a single function. Both strategies call this instead
 // of duplicating ~50 lines each.
@@ -140,6 +140,18 @@ func runNodesMultiEventDriven(ctx context.Context, startNodeID idwrap.IDWrap, re
 			nodeReq := *req
 			nodeReq.ExecutionID = executionID
 
+			// A debugger may pause the flow here; the pause is not timed.
+			if err := debugBeforeNode(flowCtx, currentNode, &nodeReq); err != nil {
+				resultChan <- processResult{
+					originalID:  currentNode.GetID(),
+					executionID: executionID,
+					err:         err,
+					startTime:   startTime,
+				}
+				return
+			}
+			startTime = time.Now()
+
 			// Per-node timeout (skip for LoopCoordinator nodes)
 			nodeCtx := flowCtx
 			var cancelNode context.CancelFunc
@@ -154,6 +166,7 @@ func runNodesMultiEventDriven(ctx context.Context, startNodeID idwrap.IDWrap, re
 
 			// Execute the node with variable tracking
 			outcome := executor.Execute(nodeCtx, currentNode, &nodeReq)
+			debugAfterNode(currentNode, &nodeReq, outcome)
 			inputData := outcome.TrackedInput
 			outputData := outcome.TrackedOutput
 			result := outcome.Result
@@ -218,11 +231,28 @@ func runNodesMultiEventDriven(ctx context.Context, startNodeID idwrap.IDWrap, re
 
 			// ERROR PATH
 			if result.err != nil {
+				var errorTargets []idwrap.IDWrap
+				handled := false
+				if firstErr == nil {
+					errorTargets, handled = errorBranch(req, result.originalID, nodeName, result.err)
+				}
+
 				status := buildTerminalStatus(base, result.err, result.timedOut, result.inputData, result.outputData, req, cfg.TrackData)
 				cfg.Emitter.EmitTerminal(result.executionID, status, false)
 
+				// Handled by on_error edges: the flow goes on down the error branch.
+				if handled {
+					for _, nextID := range errorTargets {
+						if !tracker.Arrive(nextID) {
+							continue
+						}
+						launchNode(nextID)
+					}
+					continue
+				}
+
 				if firstErr == nil {
-					firstErr = result.err
+					firstErr = runner.WrapNodeError(result.originalID, nodeName, result.err)
 				}
 				// Cancel all other in-flight work
 				flowCancel()
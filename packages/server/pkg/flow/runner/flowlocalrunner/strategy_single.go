@@ -57,6 +57,10 @@ func runNodesSingle(ctx context.Context, startNodeID idwrap.IDWrap, req *node.Fl
 		nodeReq := *req
 		nodeReq.ExecutionID = executionID
 
+		// Time spent paused by a debugger counts toward neither the node's
+		// timeout nor its duration.
+		debugErr := debugBeforeNode(ctx, currentNode, &nodeReq)
+
 		nodeCtx := ctx
 		cancelNodeCtx := func() {}
 		if cfg.Timeout > 0 {
@@ -64,7 +68,13 @@ func runNodesSingle(ctx context.Context, startNodeID idwrap.IDWrap, req *node.Fl
 		}
 		startTime := time.Now()
 
-		outcome := executor.Execute(nodeCtx, currentNode, &nodeReq)
+		var outcome ExecutionOutcome
+		if debugErr != nil {
+			outcome.Result.Err = debugErr
+		} else {
+			outcome = executor.Execute(nodeCtx, currentNode, &nodeReq)
+			debugAfterNode(currentNode, &nodeReq, outcome)
+		}
 		nodeCtxErr := nodeCtx.Err()
 		cancelNodeCtx()
 
@@ -84,9 +94,19 @@ func runNodesSingle(ctx context.Context, startNodeID idwrap.IDWrap, req *node.Fl
 		}
 
 		if nodeErr != nil {
+			errorTargets, handled := errorBranch(req, nodeID, currentNode.GetName(), nodeErr)
 			status := buildTerminalStatus(base, nodeErr, false, outcome.TrackedInput, outcome.TrackedOutput, req, cfg.TrackData)
 			cfg.StatusLogFunc(status)
-			return nodeErr
+			if !handled {
+				return runner.WrapNodeError(nodeID, currentNode.GetName(), nodeErr)
+			}
+			for _, nextID := range errorTargets {
+				if !tracker.Arrive(nextID) {
+					continue
+				}
+				queue = append(queue, nextID)
+			}
+			continue
 		}
 
 		if !outcome.Result.SkipFinalStatus {
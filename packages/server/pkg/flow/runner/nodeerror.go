@@ -0,0 +1,37 @@
+package runner
+
+import (
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+)
+
+// NodeError records the node a flow failure started at. It keeps the message
+// of the node's error, so callers matching on the message or with errors.Is
+// are unaffected.
+type NodeError struct {
+	NodeID idwrap.IDWrap
+	Name   string
+	Err    error
+}
+
+func (e *NodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NodeError) Unwrap() error {
+	return e.Err
+}
+
+// WrapNodeError attributes err to the given node, unless err already names the
+// node it started at (a failure coming up from a loop or try body).
+func WrapNodeError(nodeID idwrap.IDWrap, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var nodeErr *NodeError
+	if errors.As(err, &nodeErr) {
+		return err
+	}
+	return &NodeError{NodeID: nodeID, Name: name, Err: err}
+}
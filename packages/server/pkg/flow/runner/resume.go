@@ -0,0 +1,186 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+// ErrNothingToResume is returned when a recorded run has no failed node on
+// its main path, so there is nowhere to pick it up from.
+var ErrNothingToResume = errors.New("execution has no failed node to resume from")
+
+// ResumePoint picks a previous, failed run up part-way through its graph
+// instead of starting again from the flow's entry nodes.
+type ResumePoint struct {
+	// NodeIDs are the nodes the resumed run starts from.
+	NodeIDs []idwrap.IDWrap
+	// Completed holds the nodes that finished in the previous run and are not
+	// run again. Their recorded outputs are restored into the variable state,
+	// and each counts as already arrived at any join node it feeds.
+	Completed map[idwrap.IDWrap]struct{}
+}
+
+// isBodyHandle reports whether edges on handle are driven by their source
+// node (loop bodies, WebSocket message chains, AI providers and tools) rather
+// than followed by the runner. Nodes behind them are never resumed directly:
+// a failure inside a body is resumed from the node that owns the body.
+func isBodyHandle(handle mflow.EdgeHandle) bool {
+	switch handle {
+	case mflow.HandleLoop, mflow.HandleWsMessage, mflow.HandleAiProvider, mflow.HandleAiMemory, mflow.HandleAiTools:
+		return true
+	default:
+		return false
+	}
+}
+
+// PlanResume works out where to resume a run from the last state each node
+// recorded in it. Walking the main path from entryIDs, it passes through the
+// nodes that succeeded and stops at the ones that did not: a node that failed,
+// was canceled or was still running is resumed from, as is a node that never
+// ran although a completed node's plain edge leads to it. A failure whose
+// on_error branch was taken was handled, so the walk follows that branch.
+//
+// When from is not empty the run is resumed from those nodes instead, which
+// must lie on the main path. Either way a node downstream of a resume node is
+// never treated as completed, since it runs again.
+func PlanResume(edges mflow.EdgesMap, entryIDs []idwrap.IDWrap, states map[idwrap.IDWrap]mflow.NodeState, from []idwrap.IDWrap) (ResumePoint, error) {
+	ran := func(id idwrap.IDWrap) bool {
+		state, ok := states[id]
+		return ok && state != mflow.NODE_STATE_UNSPECIFIED
+	}
+
+	mainPath := make(map[idwrap.IDWrap]struct{})
+	succeeded := make(map[idwrap.IDWrap]struct{})
+	// failed holds the nodes that ran without succeeding; pending the ones a
+	// completed node's plain edge leads to but that never ran.
+	var failed, pending []idwrap.IDWrap
+
+	queue := append([]idwrap.IDWrap(nil), entryIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, ok := mainPath[id]; ok {
+			continue
+		}
+		mainPath[id] = struct{}{}
+
+		switch states[id] {
+		case mflow.NODE_STATE_SUCCESS:
+			succeeded[id] = struct{}{}
+			for handle, targets := range edges[id] {
+				if isBodyHandle(handle) || handle == mflow.HandleError {
+					continue
+				}
+				for _, target := range targets {
+					if handle == mflow.HandleUnspecified && !ran(target) {
+						mainPath[target] = struct{}{}
+						pending = append(pending, target)
+						continue
+					}
+					queue = append(queue, target)
+				}
+			}
+		case mflow.NODE_STATE_UNSPECIFIED:
+			// Never reached in the previous run: a branch it did not take.
+		default:
+			handled := false
+			for _, target := range edges[id][mflow.HandleError] {
+				if ran(target) {
+					handled = true
+					queue = append(queue, target)
+				}
+			}
+			if handled {
+				succeeded[id] = struct{}{}
+				continue
+			}
+			failed = append(failed, id)
+		}
+	}
+
+	// A pending node that a failed node leads to was waiting on it, as a join
+	// does, and runs once the failed node has been resumed.
+	afterFailed := downstream(edges, failed)
+	resumeIDs := failed
+	for _, id := range pending {
+		if _, ok := afterFailed[id]; !ok && !slices.Contains(resumeIDs, id) {
+			resumeIDs = append(resumeIDs, id)
+		}
+	}
+
+	if len(from) > 0 {
+		for _, id := range from {
+			if _, ok := mainPath[id]; !ok {
+				return ResumePoint{}, fmt.Errorf("node %s is not on the flow's main path", id)
+			}
+		}
+		resumeIDs = from
+	}
+	if len(resumeIDs) == 0 {
+		return ResumePoint{}, ErrNothingToResume
+	}
+
+	// Everything reachable from a resume node runs again.
+	rerun := downstream(edges, resumeIDs)
+	for _, id := range resumeIDs {
+		rerun[id] = struct{}{}
+	}
+
+	completed := make(map[idwrap.IDWrap]struct{}, len(succeeded))
+	for id := range succeeded {
+		if _, ok := rerun[id]; !ok {
+			completed[id] = struct{}{}
+		}
+	}
+
+	return ResumePoint{
+		NodeIDs:   append([]idwrap.IDWrap(nil), resumeIDs...),
+		Completed: completed,
+	}, nil
+}
+
+// downstream returns the nodes reachable from ids over any edge, not counting
+// ids themselves unless a cycle leads back to them.
+func downstream(edges mflow.EdgesMap, ids []idwrap.IDWrap) map[idwrap.IDWrap]struct{} {
+	reached := make(map[idwrap.IDWrap]struct{})
+	var queue []idwrap.IDWrap
+	for _, id := range ids {
+		for _, targets := range edges[id] {
+			queue = append(queue, targets...)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, ok := reached[id]; ok {
+			continue
+		}
+		reached[id] = struct{}{}
+		for _, targets := range edges[id] {
+			queue = append(queue, targets...)
+		}
+	}
+	return reached
+}
+
+// ConvergeCounts returns the graph's join counts less the predecessors that
+// already completed, so a join fed by both a completed node and a resumed one
+// does not wait for an arrival that happened in the previous run.
+func (p ResumePoint) ConvergeCounts(g *FlowGraph) map[idwrap.IDWrap]uint32 {
+	counts := make(map[idwrap.IDWrap]uint32, len(g.ConvergeCounts))
+	for nodeID, count := range g.ConvergeCounts {
+		for _, pred := range g.Predecessors[nodeID] {
+			if _, ok := p.Completed[pred]; ok && count > 0 {
+				count--
+			}
+		}
+		if count > 1 {
+			counts[nodeID] = count
+		}
+	}
+	return counts
+}
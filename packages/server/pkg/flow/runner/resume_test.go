@@ -0,0 +1,156 @@
+package runner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/flow/runner"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func newIDs(n int) []idwrap.IDWrap {
+	ids := make([]idwrap.IDWrap, n)
+	for i := range ids {
+		ids[i] = idwrap.NewNow()
+	}
+	return ids
+}
+
+func completedSet(ids ...idwrap.IDWrap) map[idwrap.IDWrap]struct{} {
+	set := make(map[idwrap.IDWrap]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+func TestPlanResumeFromFailedNode(t *testing.T) {
+	ids := newIDs(4)
+	start, a, b, c := ids[0], ids[1], ids[2], ids[3]
+	edges := mflow.EdgesMap{
+		start: {mflow.HandleUnspecified: {a}},
+		a:     {mflow.HandleUnspecified: {b}},
+		b:     {mflow.HandleUnspecified: {c}},
+	}
+	states := map[idwrap.IDWrap]mflow.NodeState{
+		start: mflow.NODE_STATE_SUCCESS,
+		a:     mflow.NODE_STATE_SUCCESS,
+		b:     mflow.NODE_STATE_FAILURE,
+	}
+
+	point, err := runner.PlanResume(edges, []idwrap.IDWrap{start}, states, nil)
+	require.NoError(t, err)
+	require.Equal(t, []idwrap.IDWrap{b}, point.NodeIDs)
+	require.Equal(t, completedSet(start, a), point.Completed)
+}
+
+func TestPlanResumeFromExplicitNode(t *testing.T) {
+	ids := newIDs(4)
+	start, a, b, loopBody := ids[0], ids[1], ids[2], ids[3]
+	edges := mflow.EdgesMap{
+		start: {mflow.HandleUnspecified: {a}},
+		a:     {mflow.HandleUnspecified: {b}, mflow.HandleLoop: {loopBody}},
+	}
+	states := map[idwrap.IDWrap]mflow.NodeState{
+		start:    mflow.NODE_STATE_SUCCESS,
+		a:        mflow.NODE_STATE_SUCCESS,
+		loopBody: mflow.NODE_STATE_SUCCESS,
+		b:        mflow.NODE_STATE_FAILURE,
+	}
+
+	point, err := runner.PlanResume(edges, []idwrap.IDWrap{start}, states, []idwrap.IDWrap{a})
+	require.NoError(t, err)
+	require.Equal(t, []idwrap.IDWrap{a}, point.NodeIDs)
+	require.Equal(t, completedSet(start), point.Completed, "nodes after the resume node run again")
+
+	_, err = runner.PlanResume(edges, []idwrap.IDWrap{start}, states, []idwrap.IDWrap{loopBody})
+	require.Error(t, err, "a node inside a loop body is resumed from its loop")
+}
+
+func TestPlanResumeSkipsBranchNotTaken(t *testing.T) {
+	ids := newIDs(4)
+	start, cond, then, els := ids[0], ids[1], ids[2], ids[3]
+	edges := mflow.EdgesMap{
+		start: {mflow.HandleUnspecified: {cond}},
+		cond:  {mflow.HandleThen: {then}, mflow.HandleElse: {els}},
+	}
+	states := map[idwrap.IDWrap]mflow.NodeState{
+		start: mflow.NODE_STATE_SUCCESS,
+		cond:  mflow.NODE_STATE_SUCCESS,
+		then:  mflow.NODE_STATE_FAILURE,
+	}
+
+	point, err := runner.PlanResume(edges, []idwrap.IDWrap{start}, states, nil)
+	require.NoError(t, err)
+	require.Equal(t, []idwrap.IDWrap{then}, point.NodeIDs)
+}
+
+func TestPlanResumeIncludesNodesNeverDispatched(t *testing.T) {
+	ids := newIDs(3)
+	start, a, b := ids[0], ids[1], ids[2]
+	edges := mflow.EdgesMap{
+		start: {mflow.HandleUnspecified: {a, b}},
+	}
+	states := map[idwrap.IDWrap]mflow.NodeState{
+		start: mflow.NODE_STATE_SUCCESS,
+		a:     mflow.NODE_STATE_FAILURE,
+	}
+
+	point, err := runner.PlanResume(edges, []idwrap.IDWrap{start}, states, nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []idwrap.IDWrap{a, b}, point.NodeIDs)
+}
+
+func TestPlanResumeFollowsHandledFailure(t *testing.T) {
+	ids := newIDs(4)
+	start, a, recover, next := ids[0], ids[1], ids[2], ids[3]
+	edges := mflow.EdgesMap{
+		start:   {mflow.HandleUnspecified: {a}},
+		a:       {mflow.HandleError: {recover}},
+		recover: {mflow.HandleUnspecified: {next}},
+	}
+	states := map[idwrap.IDWrap]mflow.NodeState{
+		start:   mflow.NODE_STATE_SUCCESS,
+		a:       mflow.NODE_STATE_FAILURE,
+		recover: mflow.NODE_STATE_SUCCESS,
+		next:    mflow.NODE_STATE_CANCELED,
+	}
+
+	point, err := runner.PlanResume(edges, []idwrap.IDWrap{start}, states, nil)
+	require.NoError(t, err)
+	require.Equal(t, []idwrap.IDWrap{next}, point.NodeIDs)
+	require.Equal(t, completedSet(start, a, recover), point.Completed)
+}
+
+func TestPlanResumeNothingFailed(t *testing.T) {
+	ids := newIDs(2)
+	start, a := ids[0], ids[1]
+	edges := mflow.EdgesMap{start: {mflow.HandleUnspecified: {a}}}
+	states := map[idwrap.IDWrap]mflow.NodeState{
+		start: mflow.NODE_STATE_SUCCESS,
+		a:     mflow.NODE_STATE_SUCCESS,
+	}
+
+	_, err := runner.PlanResume(edges, []idwrap.IDWrap{start}, states, nil)
+	require.ErrorIs(t, err, runner.ErrNothingToResume)
+}
+
+func TestResumePointConvergeCounts(t *testing.T) {
+	ids := newIDs(5)
+	start, a, b, c, join := ids[0], ids[1], ids[2], ids[3], ids[4]
+	edges := mflow.EdgesMap{
+		start: {mflow.HandleUnspecified: {a, b, c}},
+		a:     {mflow.HandleUnspecified: {join}},
+		b:     {mflow.HandleUnspecified: {join}},
+		c:     {mflow.HandleUnspecified: {join}},
+	}
+	graph := runner.NewFlowGraph(edges, []idwrap.IDWrap{start})
+
+	point := runner.ResumePoint{NodeIDs: []idwrap.IDWrap{c}, Completed: completedSet(start, a)}
+	require.Equal(t, map[idwrap.IDWrap]uint32{join: 2}, point.ConvergeCounts(graph))
+
+	point.Completed = completedSet(start, a, b)
+	require.Empty(t, point.ConvergeCounts(graph))
+}
@@ -14,6 +14,20 @@ var (
 	ErrNodeNotFound             = errors.New("next node not found")
 )
 
+// TeardownError is returned when a flow succeeded but its teardown section
+// failed. A failure of the flow itself takes precedence over the teardown's.
+type TeardownError struct {
+	Err error
+}
+
+func (e *TeardownError) Error() string {
+	return "teardown failed: " + e.Err.Error()
+}
+
+func (e *TeardownError) Unwrap() error {
+	return e.Err
+}
+
 type FlowRunner interface {
 	RunWithEvents(context.Context, FlowEventChannels, map[string]any) error
 }
@@ -68,6 +82,8 @@ type FlowNodeStatus struct {
 	IterationIndex   int               `json:"iteration_index,omitempty"`
 	LoopNodeID       idwrap.IDWrap     `json:"loop_node_id,omitempty"`
 	AuxiliaryID      *idwrap.IDWrap
+	// Teardown marks statuses of nodes that ran in the flow's teardown section.
+	Teardown bool `json:"teardown,omitempty"`
 }
 
 type FlowNodeEventTarget uint8
@@ -93,6 +109,7 @@ type FlowNodeLogPayload struct {
 	IterationEvent   bool
 	IterationIndex   int
 	LoopNodeID       idwrap.IDWrap
+	Teardown         bool
 }
 
 type FlowNodeEvent struct {
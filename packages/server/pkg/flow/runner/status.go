@@ -154,6 +154,7 @@ func NewChannelEmitFunc(channels FlowEventChannels) func(FlowNodeStatus) {
 				IterationEvent:   status.IterationEvent,
 				IterationIndex:   status.IterationIndex,
 				LoopNodeID:       status.LoopNodeID,
+				Teardown:         status.Teardown,
 			}
 		}
 	}
@@ -99,10 +99,12 @@ func BuildIncomingAdjacency(edges []mflow.Edge) map[idwrap.IDWrap][]idwrap.IDWra
 	return adj
 }
 
-// FindStartNode finds the start node (NODE_KIND_MANUAL_START or NODE_KIND_SUB_FLOW_TRIGGER) in a node slice.
+// FindStartNode finds the start node (NODE_KIND_MANUAL_START, NODE_KIND_SUB_FLOW_TRIGGER
+// or NODE_KIND_WEBHOOK_TRIGGER) in a node slice.
 func FindStartNode(nodes []mflow.Node) (*mflow.Node, bool) {
 	for i := range nodes {
-		if nodes[i].NodeKind == mflow.NODE_KIND_MANUAL_START || nodes[i].NodeKind == mflow.NODE_KIND_SUB_FLOW_TRIGGER {
+		if nodes[i].NodeKind == mflow.NODE_KIND_MANUAL_START || nodes[i].NodeKind == mflow.NODE_KIND_SUB_FLOW_TRIGGER ||
+			nodes[i].NodeKind == mflow.NODE_KIND_WEBHOOK_TRIGGER {
 			return &nodes[i], true
 		}
 	}
@@ -0,0 +1,621 @@
+package schema
+
+import (
+	"fmt"
+)
+
+// Document is a parsed executable GraphQL document.
+type Document struct {
+	Operations []*Operation
+	Fragments  []*Fragment
+}
+
+// Operation is a query, mutation or subscription definition.
+type Operation struct {
+	Type         string // "query", "mutation" or "subscription"
+	Name         string
+	Variables    []*VariableDefinition
+	Directives   []*Directive
+	SelectionSet []Selection
+	Pos          int
+}
+
+// VariableDefinition declares an operation variable.
+type VariableDefinition struct {
+	Name         string
+	Type         *TypeRef
+	DefaultValue *Value
+	Pos          int
+}
+
+// Fragment is a named fragment definition.
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Pos           int
+}
+
+// Selection is a *FieldSelection, *FragmentSpread or *InlineFragment.
+type Selection interface {
+	position() int
+}
+
+// FieldSelection selects a field, optionally under an alias.
+type FieldSelection struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Pos          int
+}
+
+// FragmentSpread includes a named fragment.
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Pos        int
+}
+
+// InlineFragment groups selections, optionally under a type condition.
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Pos           int
+}
+
+func (f *FieldSelection) position() int { return f.Pos }
+func (f *FragmentSpread) position() int { return f.Pos }
+func (f *InlineFragment) position() int { return f.Pos }
+
+// Directive is a directive applied to a definition or selection.
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+	Pos       int
+}
+
+// Argument is a named value passed to a field or directive.
+type Argument struct {
+	Name  string
+	Value *Value
+	Pos   int
+}
+
+// ValueKind classifies a literal value.
+type ValueKind int
+
+const (
+	ValueVariable ValueKind = iota
+	ValueInt
+	ValueFloat
+	ValueString
+	ValueBoolean
+	ValueNull
+	ValueEnum
+	ValueList
+	ValueObject
+)
+
+// Value is a literal value or a variable reference. Raw holds the variable
+// name, the number, string or enum text, or "true"/"false".
+type Value struct {
+	Kind   ValueKind
+	Raw    string
+	List   []*Value
+	Fields []*ObjectField
+	Pos    int
+}
+
+// ObjectField is one field of an input object literal.
+type ObjectField struct {
+	Name  string
+	Value *Value
+}
+
+// String renders the value in GraphQL syntax.
+func (v *Value) String() string {
+	switch v.Kind {
+	case ValueVariable:
+		return "$" + v.Raw
+	case ValueString:
+		return fmt.Sprintf("%q", v.Raw)
+	case ValueNull:
+		return "null"
+	case ValueList:
+		s := "["
+		for i, item := range v.List {
+			if i > 0 {
+				s += ", "
+			}
+			s += item.String()
+		}
+		return s + "]"
+	case ValueObject:
+		s := "{"
+		for i, f := range v.Fields {
+			if i > 0 {
+				s += ", "
+			}
+			s += f.Name + ": " + f.Value.String()
+		}
+		return s + "}"
+	default:
+		return v.Raw
+	}
+}
+
+// Operation returns the operation to execute for the given name. With an
+// empty name the document must hold a single operation.
+func (d *Document) Operation(name string) (*Operation, error) {
+	if name == "" {
+		switch len(d.Operations) {
+		case 0:
+			return nil, fmt.Errorf("document has no operations")
+		case 1:
+			return d.Operations[0], nil
+		default:
+			return nil, fmt.Errorf("document has %d operations, an operation name is required", len(d.Operations))
+		}
+	}
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// Fragment returns the named fragment, or nil.
+func (d *Document) Fragment(name string) *Fragment {
+	for _, f := range d.Fragments {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// ParseQuery parses an executable document: operations and fragments.
+func ParseQuery(src string) (*Document, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			pos := p.tok.pos
+			set, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: set, Pos: pos})
+		case p.peekName("query"), p.peekName("mutation"), p.peekName("subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.peekName("fragment"):
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			doc.Fragments = append(doc.Fragments, frag)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, p.errorf(p.tok.pos, "document has no operations")
+	}
+	return doc, nil
+}
+
+// parser reads documents token by token.
+type parser struct {
+	*lexer
+}
+
+func newParser(src string) (*parser, error) {
+	l, err := newLexer(src)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{lexer: l}, nil
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokenPunct && p.tok.value == punct
+}
+
+func (p *parser) peekName(name string) bool {
+	return p.tok.kind == tokenName && p.tok.value == name
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return p.errorf(p.tok.pos, "unexpected end of document")
+	}
+	return p.errorf(p.tok.pos, "unexpected %q", p.src[p.tok.pos:p.pos])
+}
+
+// skip consumes the punctuator if it is next, reporting whether it was.
+func (p *parser) skip(punct string) (bool, error) {
+	if !p.peek(punct) {
+		return false, nil
+	}
+	return true, p.next()
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.peek(punct) {
+		if p.tok.kind == tokenEOF {
+			return p.errorf(p.tok.pos, "expected %q, found end of document", punct)
+		}
+		return p.errorf(p.tok.pos, "expected %q, found %q", punct, p.src[p.tok.pos:p.pos])
+	}
+	return p.next()
+}
+
+func (p *parser) expectKeyword(name string) error {
+	if !p.peekName(name) {
+		return p.errorf(p.tok.pos, "expected %q", name)
+	}
+	return p.next()
+}
+
+func (p *parser) parseName() (string, error) {
+	if p.tok.kind != tokenName {
+		if p.tok.kind == tokenEOF {
+			return "", p.errorf(p.tok.pos, "expected name, found end of document")
+		}
+		return "", p.errorf(p.tok.pos, "expected name, found %q", p.src[p.tok.pos:p.pos])
+	}
+	name := p.tok.value
+	return name, p.next()
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Type: p.tok.value, Pos: p.tok.pos}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		op.Name = p.tok.value
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		vars, err := p.parseVariableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		op.Variables = vars
+	}
+	dirs, err := p.parseDirectives(false)
+	if err != nil {
+		return nil, err
+	}
+	op.Directives = dirs
+	if op.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var defs []*VariableDefinition
+	for {
+		if ok, err := p.skip(")"); err != nil || ok {
+			return defs, err
+		}
+		def := &VariableDefinition{Pos: p.tok.pos}
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		def.Name = name
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if def.Type, err = p.parseTypeRef(); err != nil {
+			return nil, err
+		}
+		if ok, err := p.skip("="); err != nil {
+			return nil, err
+		} else if ok {
+			if def.DefaultValue, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.parseDirectives(true); err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+}
+
+// parseTypeRef parses a type such as "[ID!]!". Named references carry no
+// kind, since a document does not say what kind of type a name is.
+func (p *parser) parseTypeRef() (*TypeRef, error) {
+	var t *TypeRef
+	if ok, err := p.skip("["); err != nil {
+		return nil, err
+	} else if ok {
+		inner, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		t = &TypeRef{Kind: KindList, OfType: inner}
+	} else {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		t = &TypeRef{Name: name}
+	}
+	if ok, err := p.skip("!"); err != nil {
+		return nil, err
+	} else if ok {
+		t = &TypeRef{Kind: KindNonNull, OfType: t}
+	}
+	return t, nil
+}
+
+func (p *parser) parseDirectives(constant bool) ([]*Directive, error) {
+	var dirs []*Directive
+	for p.peek("@") {
+		d := &Directive{Pos: p.tok.pos}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		d.Name = name
+		if d.Arguments, err = p.parseArguments(constant); err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, d)
+	}
+	return dirs, nil
+}
+
+func (p *parser) parseArguments(constant bool) ([]*Argument, error) {
+	if !p.peek("(") {
+		return nil, nil
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	var args []*Argument
+	for {
+		if ok, err := p.skip(")"); err != nil || ok {
+			return args, err
+		}
+		arg := &Argument{Pos: p.tok.pos}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		arg.Name = name
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if arg.Value, err = p.parseValue(constant); err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var set []Selection
+	for {
+		if ok, err := p.skip("}"); err != nil {
+			return nil, err
+		} else if ok {
+			if len(set) == 0 {
+				return nil, p.errorf(p.tok.pos, "selection set is empty")
+			}
+			return set, nil
+		}
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, sel)
+	}
+}
+
+func (p *parser) parseSelection() (Selection, error) {
+	pos := p.tok.pos
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			spread := &FragmentSpread{Name: p.tok.value, Pos: pos}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			var err error
+			spread.Directives, err = p.parseDirectives(false)
+			return spread, err
+		}
+		inline := &InlineFragment{Pos: pos}
+		if p.peekName("on") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			inline.TypeCondition = name
+		}
+		var err error
+		if inline.Directives, err = p.parseDirectives(false); err != nil {
+			return nil, err
+		}
+		if inline.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+		return inline, nil
+	}
+
+	field := &FieldSelection{Pos: pos}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = name
+		if name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	field.Name = name
+	if field.Arguments, err = p.parseArguments(false); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.parseDirectives(false); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		if field.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	frag := &Fragment{Pos: p.tok.pos}
+	if err := p.expectKeyword("fragment"); err != nil {
+		return nil, err
+	}
+	if p.peekName("on") {
+		return nil, p.errorf(p.tok.pos, "fragment cannot be named \"on\"")
+	}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	frag.Name = name
+	if err := p.expectKeyword("on"); err != nil {
+		return nil, err
+	}
+	if frag.TypeCondition, err = p.parseName(); err != nil {
+		return nil, err
+	}
+	if frag.Directives, err = p.parseDirectives(false); err != nil {
+		return nil, err
+	}
+	if frag.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return frag, nil
+}
+
+// parseValue parses a literal. Constant values, such as defaults, may not
+// reference variables.
+func (p *parser) parseValue(constant bool) (*Value, error) {
+	v := &Value{Pos: p.tok.pos}
+	switch p.tok.kind {
+	case tokenPunct:
+		switch p.tok.value {
+		case "$":
+			if constant {
+				return nil, p.errorf(p.tok.pos, "unexpected variable in constant value")
+			}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			v.Kind, v.Raw = ValueVariable, name
+			return v, nil
+		case "[":
+			v.Kind = ValueList
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			for {
+				if ok, err := p.skip("]"); err != nil || ok {
+					return v, err
+				}
+				item, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				v.List = append(v.List, item)
+			}
+		case "{":
+			v.Kind = ValueObject
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			for {
+				if ok, err := p.skip("}"); err != nil || ok {
+					return v, err
+				}
+				name, err := p.parseName()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				fv, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				v.Fields = append(v.Fields, &ObjectField{Name: name, Value: fv})
+			}
+		}
+		return nil, p.unexpected()
+	case tokenInt:
+		v.Kind = ValueInt
+	case tokenFloat:
+		v.Kind = ValueFloat
+	case tokenString, tokenBlockString:
+		v.Kind = ValueString
+	case tokenName:
+		switch p.tok.value {
+		case "true", "false":
+			v.Kind = ValueBoolean
+		case "null":
+			v.Kind = ValueNull
+		default:
+			v.Kind = ValueEnum
+		}
+	default:
+		return nil, p.unexpected()
+	}
+	v.Raw = p.tok.value
+	return v, p.next()
+}
@@ -0,0 +1,207 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// DefaultDepth is the selection depth Generate uses when given none.
+const DefaultDepth = 2
+
+// maxPlaceholderDepth bounds how deep placeholder input objects nest.
+const maxPlaceholderDepth = 5
+
+// GeneratedOperation is a ready-to-run operation for one root field.
+type GeneratedOperation struct {
+	Type        string // "query" or "mutation"
+	Field       string
+	Description string
+	Query       string
+	// Variables is a JSON object with a placeholder for every required
+	// argument, or empty when the field has none.
+	Variables string
+}
+
+// Generate builds an operation for every query and mutation root field.
+// Each operation declares a variable per argument and selects the leaf
+// fields of the result, descending into object fields up to depth levels.
+// Deprecated fields and fields with required arguments are left out of
+// the selection.
+func (s *Schema) Generate(depth int) []GeneratedOperation {
+	if depth <= 0 {
+		depth = DefaultDepth
+	}
+	var ops []GeneratedOperation
+	for _, opType := range []string{"query", "mutation"} {
+		root := s.RootType(opType)
+		if root == nil {
+			continue
+		}
+		for _, field := range root.Fields {
+			if strings.HasPrefix(field.Name, "__") {
+				continue
+			}
+			ops = append(ops, s.GenerateOperation(opType, field, depth))
+		}
+	}
+	return ops
+}
+
+// GenerateOperation builds the operation for one root field.
+func (s *Schema) GenerateOperation(opType string, field *Field, depth int) GeneratedOperation {
+	var b strings.Builder
+	b.WriteString(opType)
+	b.WriteString(" ")
+	b.WriteString(field.Name)
+
+	placeholders := make(map[string]any)
+	if len(field.Args) > 0 {
+		b.WriteString("(")
+		for i, arg := range field.Args {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("$" + arg.Name + ": " + arg.Type.String())
+			if arg.Required() {
+				placeholders[arg.Name] = s.placeholder(arg.Type, 0)
+			}
+		}
+		b.WriteString(")")
+	}
+	b.WriteString(" {\n  ")
+	b.WriteString(field.Name)
+	if len(field.Args) > 0 {
+		b.WriteString("(")
+		for i, arg := range field.Args {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(arg.Name + ": $" + arg.Name)
+		}
+		b.WriteString(")")
+	}
+	if typ := s.Type(field.Type.NamedType()); typ != nil && typ.IsComposite() {
+		writeSelection(&b, s.selection(typ, depth), "  ")
+	}
+	b.WriteString("\n}\n")
+
+	op := GeneratedOperation{
+		Type:        opType,
+		Field:       field.Name,
+		Description: field.Description,
+		Query:       b.String(),
+	}
+	if len(placeholders) > 0 {
+		if vars, err := json.MarshalIndent(placeholders, "", "  "); err == nil {
+			op.Variables = string(vars)
+		}
+	}
+	return op
+}
+
+// selectionLine is one line of a generated selection set, with the lines of
+// its own selection set if it has one.
+type selectionLine struct {
+	text     string
+	children []selectionLine
+}
+
+// selection picks the fields selected on a composite type.
+func (s *Schema) selection(typ *Type, depth int) []selectionLine {
+	var lines []selectionLine
+	switch typ.Kind {
+	case KindUnion:
+		lines = append(lines, selectionLine{text: "__typename"})
+		if depth > 1 {
+			for _, name := range typ.PossibleTypes {
+				if member := s.Type(name); member != nil {
+					lines = append(lines, selectionLine{
+						text:     "... on " + name,
+						children: s.selection(member, depth-1),
+					})
+				}
+			}
+		}
+		return lines
+	case KindObject, KindInterface:
+		for _, f := range typ.Fields {
+			if f.IsDeprecated || hasRequiredArgs(f) {
+				continue
+			}
+			ft := s.Type(f.Type.NamedType())
+			switch {
+			case ft == nil:
+			case ft.IsLeaf():
+				lines = append(lines, selectionLine{text: f.Name})
+			case depth > 1:
+				lines = append(lines, selectionLine{text: f.Name, children: s.selection(ft, depth-1)})
+			}
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, selectionLine{text: "__typename"})
+	}
+	return lines
+}
+
+func writeSelection(b *strings.Builder, lines []selectionLine, indent string) {
+	b.WriteString(" {\n")
+	for _, line := range lines {
+		b.WriteString(indent + "  " + line.text)
+		if len(line.children) > 0 {
+			writeSelection(b, line.children, indent+"  ")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(indent + "}")
+}
+
+func hasRequiredArgs(f *Field) bool {
+	for _, a := range f.Args {
+		if a.Required() {
+			return true
+		}
+	}
+	return false
+}
+
+// placeholder builds a value of the given input type for a generated
+// variables object: zero values for scalars, the first value of an enum
+// and the required fields of an input object.
+func (s *Schema) placeholder(t *TypeRef, depth int) any {
+	if t.NonNull() {
+		return s.placeholder(t.OfType, depth)
+	}
+	if t.Kind == KindList {
+		return []any{}
+	}
+	typ := s.Type(t.Name)
+	if typ == nil {
+		return nil
+	}
+	switch typ.Kind {
+	case KindEnum:
+		if len(typ.EnumValues) > 0 {
+			return typ.EnumValues[0]
+		}
+		return ""
+	case KindInputObject:
+		obj := make(map[string]any)
+		if depth >= maxPlaceholderDepth {
+			return obj
+		}
+		for _, f := range typ.InputFields {
+			if f.Required() {
+				obj[f.Name] = s.placeholder(f.Type, depth+1)
+			}
+		}
+		return obj
+	}
+	switch typ.Name {
+	case "Int", "Float":
+		return 0
+	case "Boolean":
+		return false
+	}
+	return ""
+}
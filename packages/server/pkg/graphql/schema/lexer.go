@@ -0,0 +1,278 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokenKind classifies a lexical token of a GraphQL document.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenBlockString
+)
+
+// token is one lexical token. For strings, value holds the decoded text.
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// SyntaxError reports a malformed GraphQL document.
+type SyntaxError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// lexer splits a GraphQL document into tokens. Whitespace, commas and
+// comments are insignificant and skipped.
+type lexer struct {
+	src string
+	pos int
+	tok token
+}
+
+func newLexer(src string) (*lexer, error) {
+	l := &lexer{src: src}
+	if err := l.next(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// errorf builds a SyntaxError located at the given byte offset.
+func (l *lexer) errorf(pos int, format string, args ...any) error {
+	line, col := 1, 1
+	for _, r := range l.src[:pos] {
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return &SyntaxError{Line: line, Column: col, Message: fmt.Sprintf(format, args...)}
+}
+
+// next advances to the following token.
+func (l *lexer) next() error {
+	l.skipIgnored()
+	start := l.pos
+	if l.pos >= len(l.src) {
+		l.tok = token{kind: tokenEOF, pos: start}
+		return nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		l.tok = token{kind: tokenPunct, value: "...", pos: start}
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		l.pos++
+		l.tok = token{kind: tokenPunct, value: string(c), pos: start}
+	case isNameStart(c):
+		for l.pos < len(l.src) && isNameContinue(l.src[l.pos]) {
+			l.pos++
+		}
+		l.tok = token{kind: tokenName, value: l.src[start:l.pos], pos: start}
+	case c == '-' || isDigit(c):
+		return l.readNumber()
+	case strings.HasPrefix(l.src[l.pos:], `"""`):
+		return l.readBlockString()
+	case c == '"':
+		return l.readString()
+	default:
+		return l.errorf(start, "unexpected character %q", c)
+	}
+	return nil
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; c {
+		case ' ', '\t', '\n', '\r', ',':
+			l.pos++
+		case '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' && l.src[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			// The byte order mark is ignored too.
+			if strings.HasPrefix(l.src[l.pos:], "\uFEFF") {
+				l.pos += len("\uFEFF")
+				continue
+			}
+			return
+		}
+	}
+}
+
+func (l *lexer) readNumber() error {
+	start := l.pos
+	kind := tokenInt
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	if !l.readDigits() {
+		return l.errorf(start, "invalid number")
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.pos++
+		if !l.readDigits() {
+			return l.errorf(start, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if !l.readDigits() {
+			return l.errorf(start, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || l.src[l.pos] == '.') {
+		return l.errorf(start, "invalid number")
+	}
+	l.tok = token{kind: kind, value: l.src[start:l.pos], pos: start}
+	return nil
+}
+
+func (l *lexer) readDigits() bool {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+	return l.pos > start
+}
+
+func (l *lexer) readString() error {
+	start := l.pos
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			l.tok = token{kind: tokenString, value: b.String(), pos: start}
+			return nil
+		case '\n', '\r':
+			return l.errorf(start, "unterminated string")
+		case '\\':
+			if l.pos+1 >= len(l.src) {
+				return l.errorf(start, "unterminated string")
+			}
+			esc := l.src[l.pos+1]
+			l.pos += 2
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return l.errorf(start, "invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(l.src[l.pos:l.pos+4], 16, 16)
+				if err != nil {
+					return l.errorf(start, "invalid unicode escape")
+				}
+				b.WriteRune(rune(r))
+				l.pos += 4
+			default:
+				return l.errorf(l.pos-2, "invalid escape sequence \\%c", esc)
+			}
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return l.errorf(start, "unterminated string")
+}
+
+func (l *lexer) readBlockString() error {
+	start := l.pos
+	l.pos += 3
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		switch {
+		case strings.HasPrefix(l.src[l.pos:], `\"""`):
+			b.WriteString(`"""`)
+			l.pos += 4
+		case strings.HasPrefix(l.src[l.pos:], `"""`):
+			l.pos += 3
+			l.tok = token{kind: tokenBlockString, value: blockStringValue(b.String()), pos: start}
+			return nil
+		default:
+			b.WriteByte(l.src[l.pos])
+			l.pos++
+		}
+	}
+	return l.errorf(start, "unterminated block string")
+}
+
+// blockStringValue removes the common indentation and the leading and
+// trailing blank lines of a block string, as the spec requires.
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	common := -1
+	for _, line := range lines[1:] {
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < len(line) && (common < 0 || indent < common) {
+			common = indent
+		}
+	}
+	if common > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= common {
+				lines[i] = lines[i][common:]
+			} else {
+				lines[i] = ""
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
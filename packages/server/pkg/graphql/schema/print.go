@@ -0,0 +1,180 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PrintOperation renders op as a standalone document. By default the
+// definitions of the fragments op uses, directly or through other fragments,
+// follow the operation in document order. With inline set, every fragment
+// spread is replaced by an inline fragment on the fragment's type, so the
+// result holds the operation alone.
+func (d *Document) PrintOperation(op *Operation, inline bool) (string, error) {
+	pr := &printer{doc: d, inline: inline, active: make(map[string]bool)}
+	pr.operation(op)
+	if pr.err != nil {
+		return "", pr.err
+	}
+	if inline {
+		return pr.b.String(), nil
+	}
+
+	used := make(map[string]bool)
+	if err := d.collectFragments(op.SelectionSet, used); err != nil {
+		return "", err
+	}
+	for _, frag := range d.Fragments {
+		if used[frag.Name] {
+			pr.b.WriteString("\n")
+			pr.fragment(frag)
+		}
+	}
+	return pr.b.String(), pr.err
+}
+
+// collectFragments adds the names of the fragments set spreads, directly or
+// transitively, to used.
+func (d *Document) collectFragments(set []Selection, used map[string]bool) error {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *FieldSelection:
+			if err := d.collectFragments(sel.SelectionSet, used); err != nil {
+				return err
+			}
+		case *InlineFragment:
+			if err := d.collectFragments(sel.SelectionSet, used); err != nil {
+				return err
+			}
+		case *FragmentSpread:
+			if used[sel.Name] {
+				continue
+			}
+			frag := d.Fragment(sel.Name)
+			if frag == nil {
+				return fmt.Errorf("unknown fragment %q", sel.Name)
+			}
+			used[sel.Name] = true
+			if err := d.collectFragments(frag.SelectionSet, used); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// printer writes definitions with two-space indentation. active holds the
+// fragments being inlined, to reject cycles.
+type printer struct {
+	b      strings.Builder
+	doc    *Document
+	inline bool
+	active map[string]bool
+	err    error
+}
+
+func (pr *printer) operation(op *Operation) {
+	pr.b.WriteString(op.Type)
+	if op.Name != "" {
+		pr.b.WriteString(" " + op.Name)
+	}
+	if len(op.Variables) > 0 {
+		pr.b.WriteString("(")
+		for i, v := range op.Variables {
+			if i > 0 {
+				pr.b.WriteString(", ")
+			}
+			pr.b.WriteString("$" + v.Name + ": " + v.Type.String())
+			if v.DefaultValue != nil {
+				pr.b.WriteString(" = " + v.DefaultValue.String())
+			}
+		}
+		pr.b.WriteString(")")
+	}
+	pr.directives(op.Directives)
+	pr.selectionSet(op.SelectionSet, "")
+	pr.b.WriteString("\n")
+}
+
+func (pr *printer) fragment(frag *Fragment) {
+	pr.b.WriteString("fragment " + frag.Name + " on " + frag.TypeCondition)
+	pr.directives(frag.Directives)
+	pr.selectionSet(frag.SelectionSet, "")
+	pr.b.WriteString("\n")
+}
+
+func (pr *printer) selectionSet(set []Selection, indent string) {
+	pr.b.WriteString(" {\n")
+	inner := indent + "  "
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *FieldSelection:
+			pr.b.WriteString(inner)
+			if sel.Alias != "" {
+				pr.b.WriteString(sel.Alias + ": ")
+			}
+			pr.b.WriteString(sel.Name)
+			pr.arguments(sel.Arguments)
+			pr.directives(sel.Directives)
+			if len(sel.SelectionSet) > 0 {
+				pr.selectionSet(sel.SelectionSet, inner)
+			}
+		case *InlineFragment:
+			pr.b.WriteString(inner + "...")
+			if sel.TypeCondition != "" {
+				pr.b.WriteString(" on " + sel.TypeCondition)
+			}
+			pr.directives(sel.Directives)
+			pr.selectionSet(sel.SelectionSet, inner)
+		case *FragmentSpread:
+			if !pr.inline {
+				pr.b.WriteString(inner + "..." + sel.Name)
+				pr.directives(sel.Directives)
+				break
+			}
+			frag := pr.doc.Fragment(sel.Name)
+			if frag == nil {
+				pr.fail(fmt.Errorf("unknown fragment %q", sel.Name))
+				continue
+			}
+			if pr.active[frag.Name] {
+				pr.fail(fmt.Errorf("fragment %q spreads itself", frag.Name))
+				continue
+			}
+			pr.active[frag.Name] = true
+			pr.b.WriteString(inner + "... on " + frag.TypeCondition)
+			pr.directives(append(append([]*Directive{}, sel.Directives...), frag.Directives...))
+			pr.selectionSet(frag.SelectionSet, inner)
+			delete(pr.active, frag.Name)
+		}
+		pr.b.WriteString("\n")
+	}
+	pr.b.WriteString(indent + "}")
+}
+
+func (pr *printer) directives(dirs []*Directive) {
+	for _, d := range dirs {
+		pr.b.WriteString(" @" + d.Name)
+		pr.arguments(d.Arguments)
+	}
+}
+
+func (pr *printer) arguments(args []*Argument) {
+	if len(args) == 0 {
+		return
+	}
+	pr.b.WriteString("(")
+	for i, a := range args {
+		if i > 0 {
+			pr.b.WriteString(", ")
+		}
+		pr.b.WriteString(a.Name + ": " + a.Value.String())
+	}
+	pr.b.WriteString(")")
+}
+
+func (pr *printer) fail(err error) {
+	if pr.err == nil {
+		pr.err = err
+	}
+}
@@ -0,0 +1,351 @@
+// Package schema models a GraphQL schema read from an introspection result
+// and works with documents against it: parsing queries, validating them and
+// their variables, and generating an operation per root field.
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Type kinds, as reported by introspection.
+const (
+	KindScalar      = "SCALAR"
+	KindObject      = "OBJECT"
+	KindInterface   = "INTERFACE"
+	KindUnion       = "UNION"
+	KindEnum        = "ENUM"
+	KindInputObject = "INPUT_OBJECT"
+	KindList        = "LIST"
+	KindNonNull     = "NON_NULL"
+)
+
+// Schema is a GraphQL schema indexed by type name.
+type Schema struct {
+	QueryType        string
+	MutationType     string
+	SubscriptionType string
+	Types            map[string]*Type
+}
+
+// Type is a named type of the schema.
+type Type struct {
+	Kind          string
+	Name          string
+	Description   string
+	Fields        []*Field
+	InputFields   []*InputValue
+	Interfaces    []string
+	PossibleTypes []string
+	EnumValues    []string
+}
+
+// Field is an output field of an object or interface type.
+type Field struct {
+	Name         string
+	Description  string
+	Args         []*InputValue
+	Type         *TypeRef
+	IsDeprecated bool
+}
+
+// InputValue is an argument or an input object field.
+type InputValue struct {
+	Name         string
+	Description  string
+	Type         *TypeRef
+	DefaultValue *string
+}
+
+// TypeRef references a type, wrapped in any number of list and non-null
+// modifiers. Named references leave OfType nil.
+type TypeRef struct {
+	Kind   string
+	Name   string
+	OfType *TypeRef
+}
+
+// String renders the reference in SDL notation, e.g. "[Int!]!".
+func (t *TypeRef) String() string {
+	if t == nil {
+		return ""
+	}
+	switch t.Kind {
+	case KindNonNull:
+		return t.OfType.String() + "!"
+	case KindList:
+		return "[" + t.OfType.String() + "]"
+	default:
+		return t.Name
+	}
+}
+
+// NonNull reports whether the reference is a non-null type.
+func (t *TypeRef) NonNull() bool {
+	return t != nil && t.Kind == KindNonNull
+}
+
+// NamedType returns the name of the innermost named type.
+func (t *TypeRef) NamedType() string {
+	for t != nil && t.OfType != nil {
+		t = t.OfType
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+// Field returns the named field of the type, or nil.
+func (t *Type) Field(name string) *Field {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// InputField returns the named input field of the type, or nil.
+func (t *Type) InputField(name string) *InputValue {
+	for _, f := range t.InputFields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// Arg returns the named argument of the field, or nil.
+func (f *Field) Arg(name string) *InputValue {
+	for _, a := range f.Args {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// Required reports whether a value must be given for the input value: it is
+// non-null and has no default.
+func (v *InputValue) Required() bool {
+	return v.Type.NonNull() && v.DefaultValue == nil
+}
+
+// IsComposite reports whether selections are made on the type.
+func (t *Type) IsComposite() bool {
+	return t.Kind == KindObject || t.Kind == KindInterface || t.Kind == KindUnion
+}
+
+// IsLeaf reports whether the type is a scalar or an enum.
+func (t *Type) IsLeaf() bool {
+	return t.Kind == KindScalar || t.Kind == KindEnum
+}
+
+// IsInput reports whether the type may be used for arguments and variables.
+func (t *Type) IsInput() bool {
+	return t.Kind == KindScalar || t.Kind == KindEnum || t.Kind == KindInputObject
+}
+
+// Type returns the named type, or nil.
+func (s *Schema) Type(name string) *Type {
+	return s.Types[name]
+}
+
+// RootType returns the root type for an operation type ("query", "mutation"
+// or "subscription"), or nil when the schema does not support it.
+func (s *Schema) RootType(operation string) *Type {
+	switch operation {
+	case "", "query":
+		return s.Types[s.QueryType]
+	case "mutation":
+		if s.MutationType == "" {
+			return nil
+		}
+		return s.Types[s.MutationType]
+	case "subscription":
+		if s.SubscriptionType == "" {
+			return nil
+		}
+		return s.Types[s.SubscriptionType]
+	}
+	return nil
+}
+
+// TypeNames returns the names of the schema's types in sorted order.
+func (s *Schema) TypeNames() []string {
+	names := make([]string, 0, len(s.Types))
+	for name := range s.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// introspectionResult mirrors the JSON of an introspection query result.
+type introspectionResult struct {
+	Data *struct {
+		Schema *introspectionSchema `json:"__schema"`
+	} `json:"data"`
+	Schema *introspectionSchema `json:"__schema"`
+	Errors json.RawMessage      `json:"errors"`
+}
+
+type introspectionSchema struct {
+	QueryType        *struct{ Name string } `json:"queryType"`
+	MutationType     *struct{ Name string } `json:"mutationType"`
+	SubscriptionType *struct{ Name string } `json:"subscriptionType"`
+	Types            []struct {
+		Kind          string                    `json:"kind"`
+		Name          string                    `json:"name"`
+		Description   string                    `json:"description"`
+		Fields        []introspectionField      `json:"fields"`
+		InputFields   []introspectionInputValue `json:"inputFields"`
+		Interfaces    []TypeRef                 `json:"interfaces"`
+		PossibleTypes []TypeRef                 `json:"possibleTypes"`
+		EnumValues    []struct {
+			Name string `json:"name"`
+		} `json:"enumValues"`
+	} `json:"types"`
+}
+
+type introspectionField struct {
+	Name         string                    `json:"name"`
+	Description  string                    `json:"description"`
+	Args         []introspectionInputValue `json:"args"`
+	Type         *TypeRef                  `json:"type"`
+	IsDeprecated bool                      `json:"isDeprecated"`
+}
+
+type introspectionInputValue struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Type         *TypeRef `json:"type"`
+	DefaultValue *string  `json:"defaultValue"`
+}
+
+// UnmarshalJSON reads an introspection type reference, whose kind is lower
+// case in some servers' output.
+func (t *TypeRef) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Kind   string   `json:"kind"`
+		Name   *string  `json:"name"`
+		OfType *TypeRef `json:"ofType"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	t.Kind = strings.ToUpper(raw.Kind)
+	if raw.Name != nil {
+		t.Name = *raw.Name
+	}
+	t.OfType = raw.OfType
+	return nil
+}
+
+// IsIntrospection reports whether data looks like an introspection result,
+// either the full response or just its data.
+func IsIntrospection(data []byte) bool {
+	var res introspectionResult
+	if err := json.Unmarshal(data, &res); err != nil {
+		return false
+	}
+	return res.Schema != nil || (res.Data != nil && res.Data.Schema != nil)
+}
+
+// Parse reads the JSON result of an introspection query. It accepts the
+// whole response ({"data": {"__schema": ...}}) as well as its data
+// ({"__schema": ...}).
+func Parse(data []byte) (*Schema, error) {
+	var res introspectionResult
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("invalid introspection JSON: %w", err)
+	}
+	raw := res.Schema
+	if raw == nil && res.Data != nil {
+		raw = res.Data.Schema
+	}
+	if raw == nil {
+		if len(res.Errors) > 0 && string(res.Errors) != "null" {
+			return nil, fmt.Errorf("introspection failed: %s", res.Errors)
+		}
+		return nil, errors.New("introspection result has no __schema")
+	}
+	if raw.QueryType == nil || raw.QueryType.Name == "" {
+		return nil, errors.New("introspection result has no query type")
+	}
+
+	s := &Schema{
+		QueryType: raw.QueryType.Name,
+		Types:     make(map[string]*Type, len(raw.Types)),
+	}
+	if raw.MutationType != nil {
+		s.MutationType = raw.MutationType.Name
+	}
+	if raw.SubscriptionType != nil {
+		s.SubscriptionType = raw.SubscriptionType.Name
+	}
+
+	for _, rt := range raw.Types {
+		if rt.Name == "" {
+			continue
+		}
+		t := &Type{
+			Kind:        strings.ToUpper(rt.Kind),
+			Name:        rt.Name,
+			Description: rt.Description,
+			InputFields: convertInputValues(rt.InputFields),
+		}
+		for _, f := range rt.Fields {
+			t.Fields = append(t.Fields, &Field{
+				Name:         f.Name,
+				Description:  f.Description,
+				Args:         convertInputValues(f.Args),
+				Type:         f.Type,
+				IsDeprecated: f.IsDeprecated,
+			})
+		}
+		for _, i := range rt.Interfaces {
+			t.Interfaces = append(t.Interfaces, i.Name)
+		}
+		for _, p := range rt.PossibleTypes {
+			t.PossibleTypes = append(t.PossibleTypes, p.Name)
+		}
+		for _, v := range rt.EnumValues {
+			t.EnumValues = append(t.EnumValues, v.Name)
+		}
+		s.Types[t.Name] = t
+	}
+
+	if s.Types[s.QueryType] == nil {
+		return nil, fmt.Errorf("query type %q is not defined", s.QueryType)
+	}
+	addBuiltinScalars(s)
+	return s, nil
+}
+
+func convertInputValues(in []introspectionInputValue) []*InputValue {
+	out := make([]*InputValue, 0, len(in))
+	for _, v := range in {
+		out = append(out, &InputValue{
+			Name:         v.Name,
+			Description:  v.Description,
+			Type:         v.Type,
+			DefaultValue: v.DefaultValue,
+		})
+	}
+	return out
+}
+
+// addBuiltinScalars adds the specified scalars some servers leave out of
+// their introspection result when unused.
+func addBuiltinScalars(s *Schema) {
+	for _, name := range []string{"Int", "Float", "String", "Boolean", "ID"} {
+		if s.Types[name] == nil {
+			s.Types[name] = &Type{Kind: KindScalar, Name: name}
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func loadSchema(t *testing.T) *Schema {
+	t.Helper()
+	data, err := os.ReadFile("testdata/introspection.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return s
+}
+
+func TestParse(t *testing.T) {
+	s := loadSchema(t)
+
+	if s.QueryType != "Query" || s.MutationType != "Mutation" || s.SubscriptionType != "Subscription" {
+		t.Fatalf("root types = %q, %q, %q", s.QueryType, s.MutationType, s.SubscriptionType)
+	}
+	users := s.Type("Query").Field("users")
+	if users == nil {
+		t.Fatal("Query.users not found")
+	}
+	if got := users.Type.String(); got != "[User!]!" {
+		t.Errorf("Query.users type = %q, want [User!]!", got)
+	}
+	if first := users.Arg("first"); first == nil || first.DefaultValue == nil || *first.DefaultValue != "10" {
+		t.Errorf("Query.users(first) default not parsed: %+v", first)
+	}
+	if got := s.Type("SearchResult").PossibleTypes; len(got) != 2 {
+		t.Errorf("SearchResult possible types = %v", got)
+	}
+	if s.Type("Float") == nil {
+		t.Error("built-in Float scalar not added")
+	}
+
+	// The data object alone is accepted too.
+	var full struct {
+		Data json.RawMessage `json:"data"`
+	}
+	data, _ := os.ReadFile("testdata/introspection.json")
+	if err := json.Unmarshal(data, &full); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(full.Data); err != nil {
+		t.Errorf("Parse(data only): %v", err)
+	}
+	if !IsIntrospection(full.Data) || IsIntrospection([]byte(`{"openapi":"3.0.0"}`)) {
+		t.Error("IsIntrospection misdetects")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for name, input := range map[string]string{
+		"not json":     `{`,
+		"no schema":    `{"data":{}}`,
+		"errors":       `{"errors":[{"message":"introspection disabled"}]}`,
+		"no queryType": `{"__schema":{"types":[]}}`,
+	} {
+		if _, err := Parse([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	doc, err := ParseQuery(`
+		# comment
+		query GetUser($id: ID!, $first: Int = 5) @cached {
+			me: user(id: $id) {
+				...UserFields
+				posts(first: $first) { title }
+				... on Node { id }
+			}
+		}
+		mutation { deleteUser(id: "1", note: """multi
+		  line""") }
+		fragment UserFields on User { name role }
+	`)
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+	if len(doc.Operations) != 2 || len(doc.Fragments) != 1 {
+		t.Fatalf("got %d operations and %d fragments", len(doc.Operations), len(doc.Fragments))
+	}
+	op, err := doc.Operation("GetUser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(op.Variables) != 2 || op.Variables[0].Type.String() != "ID!" || op.Variables[1].DefaultValue.Raw != "5" {
+		t.Errorf("variables not parsed: %+v", op.Variables)
+	}
+	field := op.SelectionSet[0].(*FieldSelection)
+	if field.Alias != "me" || field.Name != "user" || len(field.SelectionSet) != 3 {
+		t.Errorf("field not parsed: %+v", field)
+	}
+	if _, err := doc.Operation(""); err == nil {
+		t.Error("expected an error for an unnamed pick among two operations")
+	}
+}
+
+func TestParseQuery_IntrospectionQuery(t *testing.T) {
+	doc, err := ParseQuery(IntrospectionQuery)
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+	if len(doc.Operations) != 1 || len(doc.Fragments) != 3 {
+		t.Errorf("got %d operations and %d fragments", len(doc.Operations), len(doc.Fragments))
+	}
+}
+
+func TestParseQuery_SyntaxError(t *testing.T) {
+	_, err := ParseQuery("query {\n  user(id: ) { id }\n}")
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected a SyntaxError, got %v", err)
+	}
+	if syntaxErr.Line != 2 {
+		t.Errorf("error line = %d, want 2", syntaxErr.Line)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s := loadSchema(t)
+	ops := s.Generate(2)
+
+	byField := make(map[string]GeneratedOperation)
+	for _, op := range ops {
+		byField[op.Type+" "+op.Field] = op
+	}
+	if len(ops) != 7 {
+		t.Fatalf("generated %d operations, want 7", len(ops))
+	}
+
+	user := byField["query user"]
+	wantUser := `query user($id: ID!) {
+  user(id: $id) {
+    id
+    name
+    role
+    createdAt
+    posts {
+      id
+      title
+    }
+  }
+}
+`
+	if user.Query != wantUser {
+		t.Errorf("query user =\n%s\nwant\n%s", user.Query, wantUser)
+	}
+	if user.Description != "Fetches a user by ID." {
+		t.Errorf("description = %q", user.Description)
+	}
+	if !strings.Contains(user.Variables, `"id": ""`) {
+		t.Errorf("variables = %q", user.Variables)
+	}
+
+	create := byField["mutation createUser"]
+	var vars map[string]map[string]any
+	if err := json.Unmarshal([]byte(create.Variables), &vars); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := vars["input"]["name"]; !ok || len(vars["input"]) != 1 {
+		t.Errorf("createUser variables = %v, want the required input field only", vars)
+	}
+
+	if byField["query users"].Variables != "" {
+		t.Errorf("users has no required arguments, got variables %q", byField["query users"].Variables)
+	}
+	if q := byField["query version"].Query; strings.Contains(q, "  version {") {
+		t.Errorf("leaf root field got a selection set:\n%s", q)
+	}
+	if q := byField["query search"].Query; !strings.Contains(q, "... on Post {") {
+		t.Errorf("union selection missing fragments:\n%s", q)
+	}
+
+	// Every generated operation is valid against the schema.
+	for _, op := range ops {
+		if err := s.Validate(op.Query, "", []byte(op.Variables)); err != nil {
+			t.Errorf("%s %s: %v", op.Type, op.Field, err)
+		}
+	}
+
+	// A depth of one only selects leaf fields.
+	if q := s.GenerateOperation("query", s.Type("Query").Field("user"), 1).Query; strings.Contains(q, "posts") {
+		t.Errorf("depth 1 selected nested fields:\n%s", q)
+	}
+}
+
+func TestSDL(t *testing.T) {
+	sdl := loadSchema(t).SDL()
+	for _, want := range []string{
+		"\"A registered user.\"\ntype User implements Node {",
+		"  posts(first: Int): [Post!]!\n",
+		"  users(first: Int = 10, role: Role, filter: PostFilter): [User!]!\n",
+		"  legacyName: String @deprecated\n",
+		"union SearchResult = User | Post\n",
+		"enum Role {\n  ADMIN\n  MEMBER\n}\n",
+		"scalar DateTime\n",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SDL missing %q:\n%s", want, sdl)
+		}
+	}
+	if strings.Contains(sdl, "__Schema") || strings.Contains(sdl, "scalar String") || strings.HasPrefix(sdl, "schema {") {
+		t.Errorf("SDL includes built-ins or a default schema block:\n%s", sdl)
+	}
+}
+
+func TestParseSDL(t *testing.T) {
+	introspected := loadSchema(t)
+	sdl := introspected.SDL()
+
+	s, err := ParseSDL(sdl)
+	if err != nil {
+		t.Fatalf("ParseSDL: %v", err)
+	}
+	if got := s.SDL(); got != sdl {
+		t.Errorf("SDL does not round-trip:\n--- printed\n%s\n--- reparsed\n%s", sdl, got)
+	}
+	if s.QueryType != "Query" || s.MutationType != "Mutation" || s.SubscriptionType != "Subscription" {
+		t.Errorf("root types = %q, %q, %q", s.QueryType, s.MutationType, s.SubscriptionType)
+	}
+	if got := s.Type("Query").Field("users").Type; got.OfType.OfType.OfType.Kind != KindObject {
+		t.Errorf("named reference kind not resolved: %+v", got.OfType.OfType.OfType)
+	}
+	if got := s.Type("Node").PossibleTypes; len(got) != len(introspected.Type("Node").PossibleTypes) {
+		t.Errorf("Node possible types = %v, want %v", got, introspected.Type("Node").PossibleTypes)
+	}
+	if err := s.Validate(`query Q($id: ID!) { user(id: $id) { ...F } } fragment F on User { id name }`, "", []byte(`{"id": "u1"}`)); err != nil {
+		t.Errorf("Validate against SDL schema: %v", err)
+	}
+}
+
+func TestParseSDL_Features(t *testing.T) {
+	s, err := ParseSDL(`
+"""Custom roots."""
+schema @link(url: "x") { query: Root mutation: Writes }
+
+directive @auth(role: String = "admin") repeatable on FIELD_DEFINITION | OBJECT
+
+type Root { ping: String @auth }
+type Writes { touch(id: ID!, at: Int = 3): Boolean }
+extend type Root { pong(filter: [String!] = ["a"]): Int @deprecated(reason: "no") }
+interface Named { name: String }
+type Cat implements & Named { name: String }
+union Pet = | Cat
+enum Color { "red" RED GREEN }
+extend enum Color { BLUE }
+input Filter { color: Color = RED }
+`)
+	if err != nil {
+		t.Fatalf("ParseSDL: %v", err)
+	}
+	if s.QueryType != "Root" || s.MutationType != "Writes" || s.SubscriptionType != "" {
+		t.Errorf("root types = %q, %q, %q", s.QueryType, s.MutationType, s.SubscriptionType)
+	}
+	pong := s.Type("Root").Field("pong")
+	if pong == nil || !pong.IsDeprecated || *pong.Arg("filter").DefaultValue != `["a"]` {
+		t.Errorf("extended field not parsed: %+v", pong)
+	}
+	if got := s.Type("Color").EnumValues; strings.Join(got, ",") != "RED,GREEN,BLUE" {
+		t.Errorf("Color values = %v", got)
+	}
+	if got := s.Type("Named").PossibleTypes; len(got) != 1 || got[0] != "Cat" {
+		t.Errorf("Named possible types = %v", got)
+	}
+	if got := *s.Type("Filter").InputField("color").DefaultValue; got != "RED" {
+		t.Errorf("Filter.color default = %q", got)
+	}
+}
+
+func TestParseSDL_Errors(t *testing.T) {
+	for name, src := range map[string]string{
+		"no query type":       `type Foo { a: Int }`,
+		"unknown type":        `type Query { a: Missing }`,
+		"duplicate type":      `type Query { a: Int } type Query { b: Int }`,
+		"extend undefined":    `type Query { a: Int } extend type Foo { b: Int }`,
+		"executable document": `query { a }`,
+		"bad union member":    `type Query { a: Int } union U = Query | Int`,
+		"syntax":              `type Query { a: }`,
+	} {
+		if _, err := ParseSDL(src); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPrintOperation(t *testing.T) {
+	doc, err := ParseQuery(`
+query A($id: ID!, $n: Int = 2) { user(id: $id) { ...UserFields posts(first: $n) @include(if: true) { title } } }
+fragment Unused on Post { id }
+fragment UserFields on User { id ...More }
+query B { ping }
+fragment More on User { name }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	op, err := doc.Operation("A")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := doc.PrintOperation(op, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `query A($id: ID!, $n: Int = 2) {
+  user(id: $id) {
+    ...UserFields
+    posts(first: $n) @include(if: true) {
+      title
+    }
+  }
+}
+
+fragment UserFields on User {
+  id
+  ...More
+}
+
+fragment More on User {
+  name
+}
+`
+	if stored != want {
+		t.Errorf("stored fragments:\n%s\nwant:\n%s", stored, want)
+	}
+
+	inlined, err := doc.PrintOperation(op, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(inlined, "fragment") || !strings.Contains(inlined, "    ... on User {\n      id\n      ... on User {\n        name\n") {
+		t.Errorf("inlined fragments:\n%s", inlined)
+	}
+	if _, err := ParseQuery(inlined); err != nil {
+		t.Errorf("inlined output does not parse: %v", err)
+	}
+
+	cyclic, err := ParseQuery(`{ a { ...F } } fragment F on A { ...F }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cyclic.PrintOperation(cyclic.Operations[0], true); err == nil {
+		t.Error("expected an error for a self-spreading fragment")
+	}
+}
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"strconv"
+	"strings"
+)
+
+// builtinScalars are implied by every schema and left out of printed SDL.
+var builtinScalars = map[string]bool{"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true}
+
+// SDL prints the schema in the GraphQL schema definition language, types
+// in name order. Introspection and built-in scalar types are omitted.
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	if (s.QueryType != "" && s.QueryType != "Query") ||
+		(s.MutationType != "" && s.MutationType != "Mutation") ||
+		(s.SubscriptionType != "" && s.SubscriptionType != "Subscription") {
+		b.WriteString("schema {\n")
+		b.WriteString("  query: " + s.QueryType + "\n")
+		if s.MutationType != "" {
+			b.WriteString("  mutation: " + s.MutationType + "\n")
+		}
+		if s.SubscriptionType != "" {
+			b.WriteString("  subscription: " + s.SubscriptionType + "\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, name := range s.TypeNames() {
+		t := s.Types[name]
+		if strings.HasPrefix(name, "__") || (t.Kind == KindScalar && builtinScalars[name]) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		writeDescription(&b, t.Description, "")
+		switch t.Kind {
+		case KindScalar:
+			b.WriteString("scalar " + name + "\n")
+		case KindObject, KindInterface:
+			keyword := "type "
+			if t.Kind == KindInterface {
+				keyword = "interface "
+			}
+			b.WriteString(keyword + name)
+			if len(t.Interfaces) > 0 {
+				b.WriteString(" implements " + strings.Join(t.Interfaces, " & "))
+			}
+			b.WriteString(" {\n")
+			for _, f := range t.Fields {
+				writeDescription(&b, f.Description, "  ")
+				b.WriteString("  " + f.Name)
+				if len(f.Args) > 0 {
+					b.WriteString("(")
+					for i, a := range f.Args {
+						if i > 0 {
+							b.WriteString(", ")
+						}
+						writeInputValue(&b, a)
+					}
+					b.WriteString(")")
+				}
+				b.WriteString(": " + f.Type.String())
+				if f.IsDeprecated {
+					b.WriteString(" @deprecated")
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString("}\n")
+		case KindUnion:
+			b.WriteString("union " + name + " = " + strings.Join(t.PossibleTypes, " | ") + "\n")
+		case KindEnum:
+			b.WriteString("enum " + name + " {\n")
+			for _, v := range t.EnumValues {
+				b.WriteString("  " + v + "\n")
+			}
+			b.WriteString("}\n")
+		case KindInputObject:
+			b.WriteString("input " + name + " {\n")
+			for _, f := range t.InputFields {
+				writeDescription(&b, f.Description, "  ")
+				b.WriteString("  ")
+				writeInputValue(&b, f)
+				b.WriteString("\n")
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.String()
+}
+
+func writeInputValue(b *strings.Builder, v *InputValue) {
+	b.WriteString(v.Name + ": " + v.Type.String())
+	if v.DefaultValue != nil {
+		b.WriteString(" = " + *v.DefaultValue)
+	}
+}
+
+func writeDescription(b *strings.Builder, desc, indent string) {
+	if desc == "" {
+		return
+	}
+	if !strings.Contains(desc, "\n") {
+		b.WriteString(indent + strconv.Quote(desc) + "\n")
+		return
+	}
+	b.WriteString(indent + `"""` + "\n")
+	for _, line := range strings.Split(desc, "\n") {
+		b.WriteString(indent + strings.ReplaceAll(line, `"""`, `\"""`) + "\n")
+	}
+	b.WriteString(indent + `"""` + "\n")
+}
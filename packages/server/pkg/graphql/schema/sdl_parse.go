@@ -0,0 +1,445 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ParseSDL reads a schema written in the GraphQL schema definition language.
+// Directive definitions and applied directives other than @deprecated are
+// ignored. Without a schema block, the root types are the types named Query,
+// Mutation and Subscription.
+func ParseSDL(src string) (*Schema, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{Types: make(map[string]*Type)}
+	explicitRoots := false
+	for p.tok.kind != tokenEOF {
+		desc, err := p.parseDescription()
+		if err != nil {
+			return nil, err
+		}
+		extend := false
+		if p.peekName("extend") {
+			extend = true
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if p.tok.kind != tokenName {
+			return nil, p.unexpected()
+		}
+		switch keyword := p.tok.value; keyword {
+		case "schema":
+			explicitRoots = true
+			if err := p.parseSchemaDefinition(s); err != nil {
+				return nil, err
+			}
+		case "directive":
+			if extend {
+				return nil, p.unexpected()
+			}
+			if err := p.skipDirectiveDefinition(); err != nil {
+				return nil, err
+			}
+		case "scalar", "type", "interface", "union", "enum", "input":
+			pos := p.tok.pos
+			t, err := p.parseTypeDefinition()
+			if err != nil {
+				return nil, err
+			}
+			existing := s.Types[t.Name]
+			switch {
+			case extend && existing == nil:
+				return nil, p.errorf(pos, "cannot extend undefined type %q", t.Name)
+			case extend && existing.Kind != t.Kind:
+				return nil, p.errorf(pos, "cannot extend type %q with a different kind", t.Name)
+			case extend:
+				existing.Fields = append(existing.Fields, t.Fields...)
+				existing.InputFields = append(existing.InputFields, t.InputFields...)
+				existing.Interfaces = append(existing.Interfaces, t.Interfaces...)
+				existing.PossibleTypes = append(existing.PossibleTypes, t.PossibleTypes...)
+				existing.EnumValues = append(existing.EnumValues, t.EnumValues...)
+			case existing != nil:
+				return nil, p.errorf(pos, "type %q is defined more than once", t.Name)
+			default:
+				t.Description = desc
+				s.Types[t.Name] = t
+			}
+		case "query", "mutation", "subscription", "fragment":
+			return nil, p.errorf(p.tok.pos, "unexpected %s definition in schema document", keyword)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if !explicitRoots {
+		for name, root := range map[string]*string{"Query": &s.QueryType, "Mutation": &s.MutationType, "Subscription": &s.SubscriptionType} {
+			if s.Types[name] != nil {
+				*root = name
+			}
+		}
+	}
+	if s.QueryType == "" {
+		return nil, errors.New("schema has no query type")
+	}
+	addBuiltinScalars(s)
+	if err := linkSDLTypes(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// linkSDLTypes checks that every referenced type is defined, sets the kind of
+// named type references as introspection reports them, and lists the
+// implementations of each interface as its possible types.
+func linkSDLTypes(s *Schema) error {
+	for _, root := range []string{s.QueryType, s.MutationType, s.SubscriptionType} {
+		if root == "" {
+			continue
+		}
+		if t := s.Types[root]; t == nil || t.Kind != KindObject {
+			return fmt.Errorf("root type %q is not a defined object type", root)
+		}
+	}
+	for _, name := range s.TypeNames() {
+		t := s.Types[name]
+		for _, f := range t.Fields {
+			if err := linkTypeRef(s, f.Type); err != nil {
+				return fmt.Errorf("field %s.%s: %w", name, f.Name, err)
+			}
+			for _, a := range f.Args {
+				if err := linkTypeRef(s, a.Type); err != nil {
+					return fmt.Errorf("argument %s.%s(%s): %w", name, f.Name, a.Name, err)
+				}
+			}
+		}
+		for _, f := range t.InputFields {
+			if err := linkTypeRef(s, f.Type); err != nil {
+				return fmt.Errorf("input field %s.%s: %w", name, f.Name, err)
+			}
+		}
+		for _, member := range t.PossibleTypes {
+			if m := s.Types[member]; m == nil || m.Kind != KindObject {
+				return fmt.Errorf("union %s: member %q is not a defined object type", name, member)
+			}
+		}
+		for _, iface := range t.Interfaces {
+			i := s.Types[iface]
+			if i == nil || i.Kind != KindInterface {
+				return fmt.Errorf("type %s: %q is not a defined interface", name, iface)
+			}
+			if t.Kind == KindObject {
+				i.PossibleTypes = append(i.PossibleTypes, name)
+			}
+		}
+	}
+	for _, t := range s.Types {
+		if t.Kind == KindInterface {
+			sort.Strings(t.PossibleTypes)
+		}
+	}
+	return nil
+}
+
+func linkTypeRef(s *Schema, ref *TypeRef) error {
+	for ref.OfType != nil {
+		ref = ref.OfType
+	}
+	t := s.Types[ref.Name]
+	if t == nil {
+		return fmt.Errorf("unknown type %q", ref.Name)
+	}
+	ref.Kind = t.Kind
+	return nil
+}
+
+// parseDescription consumes an optional description string.
+func (p *parser) parseDescription() (string, error) {
+	if p.tok.kind != tokenString && p.tok.kind != tokenBlockString {
+		return "", nil
+	}
+	desc := p.tok.value
+	return desc, p.next()
+}
+
+func (p *parser) parseSchemaDefinition(s *Schema) error {
+	if err := p.expectKeyword("schema"); err != nil {
+		return err
+	}
+	if _, err := p.parseDirectives(true); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		if ok, err := p.skip("}"); err != nil || ok {
+			return err
+		}
+		pos := p.tok.pos
+		op, err := p.parseName()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return err
+		}
+		switch op {
+		case "query":
+			s.QueryType = name
+		case "mutation":
+			s.MutationType = name
+		case "subscription":
+			s.SubscriptionType = name
+		default:
+			return p.errorf(pos, "unknown operation type %q", op)
+		}
+	}
+}
+
+// skipDirectiveDefinition consumes "directive @name(args) repeatable on A | B".
+func (p *parser) skipDirectiveDefinition() error {
+	if err := p.expectKeyword("directive"); err != nil {
+		return err
+	}
+	if err := p.expect("@"); err != nil {
+		return err
+	}
+	if _, err := p.parseName(); err != nil {
+		return err
+	}
+	if p.peek("(") {
+		if _, err := p.parseInputValues("(", ")"); err != nil {
+			return err
+		}
+	}
+	if p.peekName("repeatable") {
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+	if err := p.expectKeyword("on"); err != nil {
+		return err
+	}
+	if _, err := p.skip("|"); err != nil {
+		return err
+	}
+	for {
+		if _, err := p.parseName(); err != nil {
+			return err
+		}
+		if ok, err := p.skip("|"); err != nil || !ok {
+			return err
+		}
+	}
+}
+
+// parseTypeDefinition parses a scalar, type, interface, union, enum or input
+// definition, leaving its description to the caller.
+func (p *parser) parseTypeDefinition() (*Type, error) {
+	keyword := p.tok.value
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	t := &Type{Name: name}
+
+	switch keyword {
+	case "scalar":
+		t.Kind = KindScalar
+		_, err = p.parseDirectives(true)
+		return t, err
+
+	case "type", "interface":
+		t.Kind = KindObject
+		if keyword == "interface" {
+			t.Kind = KindInterface
+		}
+		if p.peekName("implements") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if _, err := p.skip("&"); err != nil {
+				return nil, err
+			}
+			for {
+				iface, err := p.parseName()
+				if err != nil {
+					return nil, err
+				}
+				t.Interfaces = append(t.Interfaces, iface)
+				if ok, err := p.skip("&"); err != nil {
+					return nil, err
+				} else if !ok {
+					break
+				}
+			}
+		}
+		if _, err := p.parseDirectives(true); err != nil {
+			return nil, err
+		}
+		if p.peek("{") {
+			if t.Fields, err = p.parseFieldDefinitions(); err != nil {
+				return nil, err
+			}
+		}
+		return t, nil
+
+	case "union":
+		t.Kind = KindUnion
+		if _, err := p.parseDirectives(true); err != nil {
+			return nil, err
+		}
+		if ok, err := p.skip("="); err != nil || !ok {
+			return t, err
+		}
+		if _, err := p.skip("|"); err != nil {
+			return nil, err
+		}
+		for {
+			member, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			t.PossibleTypes = append(t.PossibleTypes, member)
+			if ok, err := p.skip("|"); err != nil {
+				return nil, err
+			} else if !ok {
+				return t, nil
+			}
+		}
+
+	case "enum":
+		t.Kind = KindEnum
+		if _, err := p.parseDirectives(true); err != nil {
+			return nil, err
+		}
+		if ok, err := p.skip("{"); err != nil || !ok {
+			return t, err
+		}
+		for {
+			if ok, err := p.skip("}"); err != nil || ok {
+				return t, err
+			}
+			if _, err := p.parseDescription(); err != nil {
+				return nil, err
+			}
+			value, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.parseDirectives(true); err != nil {
+				return nil, err
+			}
+			t.EnumValues = append(t.EnumValues, value)
+		}
+
+	default: // input
+		t.Kind = KindInputObject
+		if _, err := p.parseDirectives(true); err != nil {
+			return nil, err
+		}
+		if p.peek("{") {
+			if t.InputFields, err = p.parseInputValues("{", "}"); err != nil {
+				return nil, err
+			}
+		}
+		return t, nil
+	}
+}
+
+func (p *parser) parseFieldDefinitions() ([]*Field, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var fields []*Field
+	for {
+		if ok, err := p.skip("}"); err != nil || ok {
+			return fields, err
+		}
+		desc, err := p.parseDescription()
+		if err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		f := &Field{Name: name, Description: desc}
+		if p.peek("(") {
+			if f.Args, err = p.parseInputValues("(", ")"); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if f.Type, err = p.parseTypeRef(); err != nil {
+			return nil, err
+		}
+		dirs, err := p.parseDirectives(true)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range dirs {
+			if d.Name == "deprecated" {
+				f.IsDeprecated = true
+			}
+		}
+		fields = append(fields, f)
+	}
+}
+
+// parseInputValues parses argument or input field definitions enclosed in
+// open and closing punctuators.
+func (p *parser) parseInputValues(open, closing string) ([]*InputValue, error) {
+	if err := p.expect(open); err != nil {
+		return nil, err
+	}
+	var values []*InputValue
+	for {
+		if ok, err := p.skip(closing); err != nil || ok {
+			return values, err
+		}
+		desc, err := p.parseDescription()
+		if err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		v := &InputValue{Name: name, Description: desc}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if v.Type, err = p.parseTypeRef(); err != nil {
+			return nil, err
+		}
+		if ok, err := p.skip("="); err != nil {
+			return nil, err
+		} else if ok {
+			def, err := p.parseValue(true)
+			if err != nil {
+				return nil, err
+			}
+			raw := def.String()
+			v.DefaultValue = &raw
+		}
+		if _, err := p.parseDirectives(true); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+}
@@ -0,0 +1,513 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidationError lists every problem found in a document and its
+// variables.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// Validate checks a query and its JSON variables against the schema. It
+// reports unknown fields, arguments, fragments and types, arguments of the
+// wrong type, missing required arguments and variables, and selections that
+// do not fit the field's type. A malformed query yields a *SyntaxError and
+// everything else a *ValidationError.
+func (s *Schema) Validate(query, operationName string, variables []byte) error {
+	doc, err := ParseQuery(query)
+	if err != nil {
+		return err
+	}
+	return s.ValidateDocument(doc, operationName, variables)
+}
+
+// ValidateDocument is Validate for an already parsed document.
+func (s *Schema) ValidateDocument(doc *Document, operationName string, variables []byte) error {
+	v := &validator{
+		schema:    s,
+		doc:       doc,
+		varDefs:   make(map[string]*VariableDefinition),
+		usedVars:  make(map[string]bool),
+		fragments: make(map[string]int),
+		seen:      make(map[string]bool),
+	}
+
+	op, err := doc.Operation(operationName)
+	if err != nil {
+		return &ValidationError{Problems: []string{err.Error()}}
+	}
+	root := s.RootType(op.Type)
+	if root == nil {
+		return &ValidationError{Problems: []string{fmt.Sprintf("schema does not support %s operations", op.Type)}}
+	}
+
+	for _, def := range op.Variables {
+		if v.varDefs[def.Name] != nil {
+			v.report("variable \"$%s\" is declared more than once", def.Name)
+			continue
+		}
+		v.varDefs[def.Name] = def
+		typ := s.Type(def.Type.NamedType())
+		switch {
+		case typ == nil:
+			v.report("unknown type %q for variable \"$%s\"", def.Type.NamedType(), def.Name)
+		case !typ.IsInput():
+			v.report("variable \"$%s\" cannot be of non-input type %q", def.Name, typ.Name)
+		case def.DefaultValue != nil:
+			v.checkLiteral(def.DefaultValue, def.Type, fmt.Sprintf("default value of \"$%s\"", def.Name))
+		}
+	}
+
+	v.checkDirectives(op.Directives)
+	v.checkSelectionSet(root, op.SelectionSet)
+
+	for _, def := range op.Variables {
+		if !v.usedVars[def.Name] {
+			v.report("variable \"$%s\" is never used", def.Name)
+		}
+	}
+
+	v.checkVariables(op, variables)
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// validator walks one operation, collecting problems.
+type validator struct {
+	schema   *Schema
+	doc      *Document
+	varDefs  map[string]*VariableDefinition
+	usedVars map[string]bool
+	// fragments tracks fragment validation: 1 while a fragment's selections
+	// are walked, 2 once they have been.
+	fragments map[string]int
+	seen      map[string]bool
+	problems  []string
+}
+
+func (v *validator) report(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if v.seen[msg] {
+		return
+	}
+	v.seen[msg] = true
+	v.problems = append(v.problems, msg)
+}
+
+func (v *validator) checkSelectionSet(parent *Type, set []Selection) {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *FieldSelection:
+			v.checkField(parent, sel)
+		case *FragmentSpread:
+			v.checkDirectives(sel.Directives)
+			frag := v.doc.Fragment(sel.Name)
+			if frag == nil {
+				v.report("unknown fragment %q", sel.Name)
+				continue
+			}
+			switch v.fragments[frag.Name] {
+			case 1:
+				v.report("fragment %q spreads itself", frag.Name)
+				continue
+			case 2:
+				continue
+			}
+			v.fragments[frag.Name] = 1
+			v.checkDirectives(frag.Directives)
+			if typ := v.fragmentType(frag.TypeCondition, "fragment "+strconv.Quote(frag.Name)); typ != nil {
+				v.checkSelectionSet(typ, frag.SelectionSet)
+			}
+			v.fragments[frag.Name] = 2
+		case *InlineFragment:
+			v.checkDirectives(sel.Directives)
+			typ := parent
+			if sel.TypeCondition != "" {
+				typ = v.fragmentType(sel.TypeCondition, "inline fragment")
+			}
+			if typ != nil {
+				v.checkSelectionSet(typ, sel.SelectionSet)
+			}
+		}
+	}
+}
+
+// fragmentType resolves a fragment's type condition, which must name a
+// composite type.
+func (v *validator) fragmentType(name, where string) *Type {
+	typ := v.schema.Type(name)
+	if typ == nil {
+		v.report("unknown type %q in %s", name, where)
+		return nil
+	}
+	if !typ.IsComposite() {
+		v.report("%s cannot condition on non-composite type %q", where, name)
+		return nil
+	}
+	return typ
+}
+
+func (v *validator) checkField(parent *Type, sel *FieldSelection) {
+	v.checkDirectives(sel.Directives)
+
+	if sel.Name == "__typename" {
+		if len(sel.SelectionSet) > 0 {
+			v.report("field \"__typename\" must not have a selection set")
+		}
+		return
+	}
+	// Introspection fields are served by every schema, whether or not the
+	// introspection result describes the meta types.
+	if (sel.Name == "__schema" || sel.Name == "__type") && parent.Name == v.schema.QueryType {
+		for _, arg := range sel.Arguments {
+			v.collectVariables(arg.Value)
+		}
+		return
+	}
+
+	var field *Field
+	if parent.Kind != KindUnion {
+		field = parent.Field(sel.Name)
+	}
+	if field == nil {
+		v.report("unknown field %q on type %q", sel.Name, parent.Name)
+		for _, arg := range sel.Arguments {
+			v.collectVariables(arg.Value)
+		}
+		return
+	}
+	coord := parent.Name + "." + field.Name
+
+	provided := make(map[string]bool, len(sel.Arguments))
+	for _, arg := range sel.Arguments {
+		provided[arg.Name] = true
+		def := field.Arg(arg.Name)
+		if def == nil {
+			v.report("unknown argument %q on field %q", arg.Name, coord)
+			v.collectVariables(arg.Value)
+			continue
+		}
+		v.checkLiteral(arg.Value, def.Type, fmt.Sprintf("argument %q on field %q", arg.Name, coord))
+	}
+	for _, def := range field.Args {
+		if def.Required() && !provided[def.Name] {
+			v.report("missing required argument %q of type %q on field %q", def.Name, def.Type.String(), coord)
+		}
+	}
+
+	typ := v.schema.Type(field.Type.NamedType())
+	if typ == nil {
+		return
+	}
+	switch {
+	case typ.IsLeaf() && len(sel.SelectionSet) > 0:
+		v.report("field %q of type %q must not have a selection set", coord, field.Type.String())
+	case typ.IsComposite() && len(sel.SelectionSet) == 0:
+		v.report("field %q of type %q must have a selection set", coord, field.Type.String())
+	case typ.IsComposite():
+		v.checkSelectionSet(typ, sel.SelectionSet)
+	}
+}
+
+// checkDirectives marks the variables directive arguments use.
+func (v *validator) checkDirectives(dirs []*Directive) {
+	for _, d := range dirs {
+		for _, arg := range d.Arguments {
+			v.collectVariables(arg.Value)
+		}
+	}
+}
+
+// collectVariables marks the variables a value uses without type checking
+// it, for values whose expected type is unknown.
+func (v *validator) collectVariables(val *Value) {
+	switch val.Kind {
+	case ValueVariable:
+		v.useVariable(val.Raw)
+	case ValueList:
+		for _, item := range val.List {
+			v.collectVariables(item)
+		}
+	case ValueObject:
+		for _, f := range val.Fields {
+			v.collectVariables(f.Value)
+		}
+	}
+}
+
+func (v *validator) useVariable(name string) *VariableDefinition {
+	v.usedVars[name] = true
+	def := v.varDefs[name]
+	if def == nil {
+		v.report("variable \"$%s\" is not defined", name)
+	}
+	return def
+}
+
+// checkLiteral checks a literal, or a variable reference, against the type
+// expected where it appears.
+func (v *validator) checkLiteral(val *Value, t *TypeRef, where string) {
+	if val.Kind == ValueVariable {
+		def := v.useVariable(val.Raw)
+		if def != nil && !variableFits(def.Type, def.DefaultValue != nil, t) {
+			v.report("%s: variable \"$%s\" of type %q cannot be used where %q is expected", where, val.Raw, def.Type.String(), t.String())
+		}
+		return
+	}
+	if t.NonNull() {
+		if val.Kind == ValueNull {
+			v.report("%s: expected %s, got null", where, t.String())
+			return
+		}
+		t = t.OfType
+	}
+	if val.Kind == ValueNull {
+		return
+	}
+	if t.Kind == KindList {
+		if val.Kind != ValueList {
+			v.checkLiteral(val, t.OfType, where)
+			return
+		}
+		for _, item := range val.List {
+			v.checkLiteral(item, t.OfType, where)
+		}
+		return
+	}
+
+	typ := v.schema.Type(t.Name)
+	if typ == nil {
+		return
+	}
+	switch typ.Kind {
+	case KindScalar:
+		if !scalarLiteralFits(typ.Name, val) {
+			v.report("%s: expected %s, got %s", where, typ.Name, val.String())
+		}
+	case KindEnum:
+		if val.Kind != ValueEnum || !contains(typ.EnumValues, val.Raw) {
+			v.report("%s: expected a value of enum %s, got %s", where, typ.Name, val.String())
+		}
+	case KindInputObject:
+		if val.Kind != ValueObject {
+			v.report("%s: expected input object %s, got %s", where, typ.Name, val.String())
+			v.collectVariables(val)
+			return
+		}
+		provided := make(map[string]bool, len(val.Fields))
+		for _, f := range val.Fields {
+			provided[f.Name] = true
+			def := typ.InputField(f.Name)
+			if def == nil {
+				v.report("%s: unknown field %q on input type %q", where, f.Name, typ.Name)
+				v.collectVariables(f.Value)
+				continue
+			}
+			v.checkLiteral(f.Value, def.Type, where)
+		}
+		for _, def := range typ.InputFields {
+			if def.Required() && !provided[def.Name] {
+				v.report("%s: missing required field %q of type %q on input type %q", where, def.Name, def.Type.String(), typ.Name)
+			}
+		}
+	}
+}
+
+// scalarLiteralFits reports whether a literal is valid for a built-in
+// scalar. Custom scalars accept any literal.
+func scalarLiteralFits(scalar string, val *Value) bool {
+	switch scalar {
+	case "Int":
+		if val.Kind != ValueInt {
+			return false
+		}
+		_, err := strconv.ParseInt(val.Raw, 10, 32)
+		return err == nil
+	case "Float":
+		return val.Kind == ValueInt || val.Kind == ValueFloat
+	case "String":
+		return val.Kind == ValueString
+	case "Boolean":
+		return val.Kind == ValueBoolean
+	case "ID":
+		return val.Kind == ValueString || val.Kind == ValueInt
+	}
+	return true
+}
+
+// variableFits reports whether a variable of type varType may be used where
+// loc is expected. A nullable variable with a default may fill a non-null
+// position.
+func variableFits(varType *TypeRef, hasDefault bool, loc *TypeRef) bool {
+	if loc.NonNull() && !varType.NonNull() {
+		if !hasDefault {
+			return false
+		}
+		loc = loc.OfType
+	}
+	return typeFits(varType, loc)
+}
+
+func typeFits(t, loc *TypeRef) bool {
+	if loc.NonNull() {
+		return t.NonNull() && typeFits(t.OfType, loc.OfType)
+	}
+	if t.NonNull() {
+		return typeFits(t.OfType, loc)
+	}
+	if loc.Kind == KindList {
+		return t.Kind == KindList && typeFits(t.OfType, loc.OfType)
+	}
+	return t.Kind != KindList && t.Name == loc.Name
+}
+
+// checkVariables checks the JSON variables against the operation's
+// variable definitions.
+func (v *validator) checkVariables(op *Operation, variables []byte) {
+	values := map[string]any{}
+	if trimmed := bytes.TrimSpace(variables); len(trimmed) > 0 && string(trimmed) != "null" {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+		if err := dec.Decode(&values); err != nil {
+			v.report("variables must be a JSON object: %v", err)
+			return
+		}
+	}
+
+	for _, def := range op.Variables {
+		val, ok := values[def.Name]
+		if !ok {
+			if def.Type.NonNull() && def.DefaultValue == nil {
+				v.report("missing required variable \"$%s\" of type %q", def.Name, def.Type.String())
+			}
+			continue
+		}
+		v.checkJSON(val, def.Type, "variable \"$"+def.Name+"\"")
+	}
+}
+
+func (v *validator) checkJSON(val any, t *TypeRef, where string) {
+	if t.NonNull() {
+		if val == nil {
+			v.report("%s: expected %s, got null", where, t.String())
+			return
+		}
+		t = t.OfType
+	}
+	if val == nil {
+		return
+	}
+	if t.Kind == KindList {
+		items, ok := val.([]any)
+		if !ok {
+			v.checkJSON(val, t.OfType, where)
+			return
+		}
+		for i, item := range items {
+			v.checkJSON(item, t.OfType, fmt.Sprintf("%s[%d]", where, i))
+		}
+		return
+	}
+
+	typ := v.schema.Type(t.Name)
+	if typ == nil {
+		return
+	}
+	switch typ.Kind {
+	case KindScalar:
+		if !scalarJSONFits(typ.Name, val) {
+			v.report("%s: expected %s, got %s", where, typ.Name, describeJSON(val))
+		}
+	case KindEnum:
+		if s, ok := val.(string); !ok || !contains(typ.EnumValues, s) {
+			v.report("%s: expected a value of enum %s, got %s", where, typ.Name, describeJSON(val))
+		}
+	case KindInputObject:
+		obj, ok := val.(map[string]any)
+		if !ok {
+			v.report("%s: expected input object %s, got %s", where, typ.Name, describeJSON(val))
+			return
+		}
+		for name, fv := range obj {
+			def := typ.InputField(name)
+			if def == nil {
+				v.report("%s: unknown field %q on input type %q", where, name, typ.Name)
+				continue
+			}
+			v.checkJSON(fv, def.Type, where+"."+name)
+		}
+		for _, def := range typ.InputFields {
+			if _, ok := obj[def.Name]; !ok && def.Required() {
+				v.report("%s: missing required field %q of type %q on input type %q", where, def.Name, def.Type.String(), typ.Name)
+			}
+		}
+	}
+}
+
+// scalarJSONFits reports whether a JSON value is valid input for a built-in
+// scalar. Custom scalars accept any value.
+func scalarJSONFits(scalar string, val any) bool {
+	switch scalar {
+	case "Int":
+		n, ok := val.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := strconv.ParseInt(n.String(), 10, 32)
+		return err == nil
+	case "Float":
+		_, ok := val.(json.Number)
+		return ok
+	case "String":
+		_, ok := val.(string)
+		return ok
+	case "Boolean":
+		_, ok := val.(bool)
+		return ok
+	case "ID":
+		switch val := val.(type) {
+		case string:
+			return true
+		case json.Number:
+			_, err := strconv.ParseInt(val.String(), 10, 64)
+			return err == nil
+		}
+		return false
+	}
+	return true
+}
+
+// describeJSON renders a JSON value for an error message.
+func describeJSON(val any) string {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+	const maxLen = 40
+	if len(b) > maxLen {
+		return string(b[:maxLen]) + "..."
+	}
+	return string(b)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
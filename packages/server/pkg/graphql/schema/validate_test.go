@@ -0,0 +1,188 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	s := loadSchema(t)
+
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		variables string
+		// want lists substrings of the expected problems; empty means valid.
+		want []string
+	}{
+		{
+			name:      "valid query with fragments and variables",
+			query:     `query Q($id: ID!) { user(id: $id) { ...F posts(first: 2) { title } } } fragment F on User { id name __typename }`,
+			variables: `{"id": "u1"}`,
+		},
+		{
+			name:  "valid union and interface selections",
+			query: `{ search(term: "x") { __typename ... on Post { title } } node(id: 1) { id ... on User { name } } }`,
+		},
+		{
+			name:      "default value fills non-null argument",
+			query:     `query ($first: Int = 3) { users(first: $first) { id } }`,
+			variables: `{}`,
+		},
+		{
+			name:  "introspection fields",
+			query: `{ __schema { queryType { name } } }`,
+		},
+		{
+			name:  "unknown field",
+			query: `{ user(id: "1") { id email } }`,
+			want:  []string{`unknown field "email" on type "User"`},
+		},
+		{
+			name:  "field on union",
+			query: `{ search(term: "x") { id } }`,
+			want:  []string{`unknown field "id" on type "SearchResult"`},
+		},
+		{
+			name:  "unknown argument",
+			query: `{ user(id: "1", expand: true) { id } }`,
+			want:  []string{`unknown argument "expand" on field "Query.user"`},
+		},
+		{
+			name:  "missing required argument",
+			query: `{ user { id } }`,
+			want:  []string{`missing required argument "id" of type "ID!" on field "Query.user"`},
+		},
+		{
+			name:  "wrong argument types",
+			query: `{ users(first: "ten", role: OWNER) { id } user(id: true) { id } }`,
+			want: []string{
+				`argument "first" on field "Query.users": expected Int, got "ten"`,
+				`argument "role" on field "Query.users": expected a value of enum Role, got OWNER`,
+				`argument "id" on field "Query.user": expected ID, got true`,
+			},
+		},
+		{
+			name:  "input object literal",
+			query: `mutation { createUser(input: {role: ADMIN, nick: "x"}) { id } }`,
+			want: []string{
+				`unknown field "nick" on input type "CreateUserInput"`,
+				`missing required field "name" of type "String!" on input type "CreateUserInput"`,
+			},
+		},
+		{
+			name:  "null for non-null argument",
+			query: `{ user(id: null) { id } }`,
+			want:  []string{`expected ID!, got null`},
+		},
+		{
+			name:  "selection set on leaf and missing on object",
+			query: `{ version { length } user(id: 1) }`,
+			want: []string{
+				`field "Query.version" of type "String!" must not have a selection set`,
+				`field "Query.user" of type "User" must have a selection set`,
+			},
+		},
+		{
+			name:  "unknown fragment and type condition",
+			query: `{ user(id: 1) { ...Missing ... on Comment { id } } }`,
+			want:  []string{`unknown fragment "Missing"`, `unknown type "Comment" in inline fragment`},
+		},
+		{
+			name:  "fragment cycle",
+			query: `{ user(id: 1) { ...A } } fragment A on User { ...B } fragment B on User { ...A }`,
+			want:  []string{`spreads itself`},
+		},
+		{
+			name:  "undefined and unused variables",
+			query: `query ($unused: Int) { user(id: $id) { id } }`,
+			want:  []string{`variable "$id" is not defined`, `variable "$unused" is never used`},
+		},
+		{
+			name:      "variable of wrong type",
+			query:     `query ($id: String!) { user(id: $id) { id } }`,
+			variables: `{"id": "1"}`,
+			want:      []string{`variable "$id" of type "String!" cannot be used where "ID!" is expected`},
+		},
+		{
+			name:      "nullable variable in non-null position",
+			query:     `query ($id: ID) { user(id: $id) { id } }`,
+			variables: `{"id": "1"}`,
+			want:      []string{`variable "$id" of type "ID" cannot be used where "ID!" is expected`},
+		},
+		{
+			name:  "missing required variable",
+			query: `query ($id: ID!) { user(id: $id) { id } }`,
+			want:  []string{`missing required variable "$id" of type "ID!"`},
+		},
+		{
+			name:      "variable values of wrong type",
+			query:     `query ($first: Int, $role: Role, $filter: PostFilter) { users(first: $first, role: $role, filter: $filter) { id } }`,
+			variables: `{"first": 1.5, "role": "OWNER", "filter": {"tags": ["a", 2], "author": "x"}}`,
+			want: []string{
+				`variable "$first": expected Int, got 1.5`,
+				`variable "$role": expected a value of enum Role, got "OWNER"`,
+				`variable "$filter".tags[1]: expected String, got 2`,
+				`variable "$filter": unknown field "author" on input type "PostFilter"`,
+			},
+		},
+		{
+			name:      "null for required variable",
+			query:     `mutation ($input: CreateUserInput!) { createUser(input: $input) { id } }`,
+			variables: `{"input": null}`,
+			want:      []string{`variable "$input": expected CreateUserInput!, got null`},
+		},
+		{
+			name:      "variables not an object",
+			query:     `{ version }`,
+			variables: `[1]`,
+			want:      []string{`variables must be a JSON object`},
+		},
+		{
+			name:      "operation name picks the operation",
+			query:     `query A { version } query B { bogus }`,
+			operation: "A",
+		},
+		{
+			name:  "operation name required",
+			query: `query A { version } query B { version }`,
+			want:  []string{`an operation name is required`},
+		},
+		{
+			name:  "subscription operation",
+			query: `subscription { userCreated { id } }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Validate(tt.query, tt.operation, []byte(tt.variables))
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("expected no problems, got %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(verr.Error(), want) {
+					t.Errorf("problems %q do not mention %q", verr.Problems, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_NoMutationType(t *testing.T) {
+	s := loadSchema(t)
+	s.MutationType = ""
+	err := s.Validate(`mutation { deleteUser(id: 1) }`, "", nil)
+	if err == nil || !strings.Contains(err.Error(), "schema does not support mutation operations") {
+		t.Fatalf("got %v", err)
+	}
+}
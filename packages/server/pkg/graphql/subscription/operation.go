@@ -0,0 +1,111 @@
+package subscription
+
+import "unicode"
+
+type operation struct {
+	kind string
+	name string
+}
+
+// operations lists the operations defined by a GraphQL document in order.
+// A shorthand selection set counts as an anonymous query. It only looks at
+// top-level definitions and does not validate the document.
+func operations(doc string) []operation {
+	var ops []operation
+	depth, parens := 0, 0
+	// claimed is set while the next top-level selection set belongs to a
+	// definition already seen.
+	claimed := false
+	runes := []rune(doc)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '"':
+			i = skipString(runes, i)
+		case r == '(':
+			parens++
+		case r == ')':
+			if parens > 0 {
+				parens--
+			}
+		case parens > 0:
+		case r == '{':
+			if depth == 0 {
+				if !claimed {
+					ops = append(ops, operation{kind: "query"})
+				}
+				claimed = false
+			}
+			depth++
+		case r == '}':
+			if depth > 0 {
+				depth--
+			}
+		case depth == 0 && isNameStart(r):
+			word, end := readName(runes, i)
+			i = end - 1
+			switch word {
+			case "query", "mutation", "subscription":
+				op := operation{kind: word}
+				if j := skipSpace(runes, end); j < len(runes) && isNameStart(runes[j]) {
+					op.name, end = readName(runes, j)
+					i = end - 1
+				}
+				ops = append(ops, op)
+				claimed = true
+			case "fragment":
+				claimed = true
+			}
+		}
+	}
+	return ops
+}
+
+// skipString returns the index of the closing quote of the string starting
+// at i, handling block strings and escapes.
+func skipString(runes []rune, i int) int {
+	if i+2 < len(runes) && runes[i+1] == '"' && runes[i+2] == '"' {
+		for j := i + 3; j+2 < len(runes); j++ {
+			if runes[j] == '\\' {
+				j++
+				continue
+			}
+			if runes[j] == '"' && runes[j+1] == '"' && runes[j+2] == '"' {
+				return j + 2
+			}
+		}
+		return len(runes)
+	}
+	for j := i + 1; j < len(runes); j++ {
+		switch runes[j] {
+		case '\\':
+			j++
+		case '"', '\n':
+			return j
+		}
+	}
+	return len(runes)
+}
+
+func skipSpace(runes []rune, i int) int {
+	for i < len(runes) && (unicode.IsSpace(runes[i]) || runes[i] == ',') {
+		i++
+	}
+	return i
+}
+
+func readName(runes []rune, i int) (string, int) {
+	start := i
+	for i < len(runes) && (isNameStart(runes[i]) || unicode.IsDigit(runes[i])) {
+		i++
+	}
+	return string(runes[start:i]), i
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
@@ -0,0 +1,330 @@
+// Package subscription runs GraphQL subscriptions over WebSocket. It speaks
+// both graphql-transport-ws (the graphql-ws library) and the legacy graphql-ws
+// subprotocol of subscriptions-transport-ws, using whichever one the server
+// negotiates.
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+const (
+	// ProtocolTransportWS is the subprotocol of the graphql-ws library.
+	ProtocolTransportWS = "graphql-transport-ws"
+	// ProtocolLegacyWS is the subprotocol of subscriptions-transport-ws.
+	ProtocolLegacyWS = "graphql-ws"
+
+	// DefaultAckTimeout bounds the wait for connection_ack.
+	DefaultAckTimeout = 10 * time.Second
+	// MaxMessageSize caps a single message read from the server.
+	MaxMessageSize = 10 << 20
+
+	subscriptionID = "1"
+	closeTimeout   = 2 * time.Second
+)
+
+// ErrStop is returned by an event handler to end the subscription without
+// an error. The server is told to stop sending.
+var ErrStop = errors.New("stop subscription")
+
+// Request describes a subscription operation.
+type Request struct {
+	URL           string
+	Query         string
+	Variables     map[string]any
+	OperationName string
+	// Headers are sent with the WebSocket handshake.
+	Headers http.Header
+	// ConnectionPayload is the payload of connection_init, commonly used for
+	// authentication. It is omitted when nil.
+	ConnectionPayload map[string]any
+	HTTPClient        *http.Client
+	// AckTimeout bounds the wait for connection_ack; DefaultAckTimeout when zero.
+	AckTimeout time.Duration
+}
+
+// Event is one result the server pushed for the subscription.
+type Event struct {
+	// Index counts events from 0.
+	Index int
+	// Payload is the execution result, with data and errors fields.
+	Payload json.RawMessage
+}
+
+// Result summarizes a finished subscription.
+type Result struct {
+	// Protocol is the negotiated subprotocol.
+	Protocol string
+	// Events is the number of events handled.
+	Events int
+	// Completed reports whether the server ended the subscription.
+	Completed bool
+}
+
+// OperationError is an error message the server sent for the operation, such
+// as a validation failure.
+type OperationError struct {
+	Payload json.RawMessage
+}
+
+func (e *OperationError) Error() string {
+	return "subscription error: " + string(e.Payload)
+}
+
+type message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type subscribePayload struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+// WebSocketURL converts an http(s) URL to its ws(s) counterpart and leaves
+// other URLs unchanged.
+func WebSocketURL(rawURL string) string {
+	switch {
+	case strings.HasPrefix(rawURL, "http://"):
+		return "ws://" + strings.TrimPrefix(rawURL, "http://")
+	case strings.HasPrefix(rawURL, "https://"):
+		return "wss://" + strings.TrimPrefix(rawURL, "https://")
+	}
+	return rawURL
+}
+
+// IsSubscription reports whether the operation of query selected by
+// operationName is a subscription. Without an operation name the first
+// operation counts.
+func IsSubscription(query, operationName string) bool {
+	for _, op := range operations(query) {
+		if operationName == "" || op.name == operationName {
+			return op.kind == "subscription"
+		}
+	}
+	return false
+}
+
+// Subscribe connects to req.URL, starts the subscription and calls onNext for
+// every event until the server completes it, onNext returns ErrStop or ctx is
+// done. The server is told to stop whenever the client ends the subscription.
+// A done ctx returns its error alongside the result.
+func Subscribe(ctx context.Context, req Request, onNext func(Event) error) (Result, error) {
+	wsURL := WebSocketURL(req.URL)
+	conn, resp, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{
+		HTTPHeader:   req.Headers,
+		HTTPClient:   req.HTTPClient,
+		Subprotocols: []string{ProtocolTransportWS, ProtocolLegacyWS},
+	})
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to %s: %w", wsURL, err)
+	}
+	defer conn.CloseNow() //nolint:errcheck // best effort after a normal close
+	conn.SetReadLimit(MaxMessageSize)
+
+	s := &session{
+		conn:     conn,
+		protocol: conn.Subprotocol(),
+		msgs:     make(chan message),
+		readErr:  make(chan error, 1),
+	}
+	if s.protocol == "" {
+		s.protocol = ProtocolTransportWS
+	}
+	result := Result{Protocol: s.protocol}
+
+	// Reads outlive ctx so the stop message can still be sent once it is done.
+	readCtx, cancelRead := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRead()
+	go s.readLoop(readCtx)
+
+	if err := s.init(ctx, req); err != nil {
+		return result, err
+	}
+	if err := s.subscribe(ctx, req); err != nil {
+		return result, err
+	}
+
+	for {
+		msg, err := s.next(ctx, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				s.stop()
+				return result, ctx.Err()
+			}
+			if websocket.CloseStatus(err) == websocket.StatusNormalClosure {
+				result.Completed = true
+				return result, nil
+			}
+			return result, fmt.Errorf("subscription connection failed: %w", err)
+		}
+
+		switch msg.Type {
+		case "next", "data":
+			if msg.ID != subscriptionID {
+				continue
+			}
+			err := onNext(Event{Index: result.Events, Payload: msg.Payload})
+			result.Events++
+			if err != nil {
+				s.stop()
+				if errors.Is(err, ErrStop) {
+					return result, nil
+				}
+				return result, err
+			}
+		case "error":
+			return result, &OperationError{Payload: msg.Payload}
+		case "complete":
+			if msg.ID != subscriptionID {
+				continue
+			}
+			result.Completed = true
+			_ = conn.Close(websocket.StatusNormalClosure, "")
+			return result, nil
+		case "connection_error":
+			return result, fmt.Errorf("connection rejected: %s", msg.Payload)
+		}
+	}
+}
+
+type session struct {
+	conn     *websocket.Conn
+	protocol string
+	msgs     chan message
+	readErr  chan error
+}
+
+func (s *session) readLoop(ctx context.Context) {
+	for {
+		_, data, err := s.conn.Read(ctx)
+		if err != nil {
+			s.readErr <- err
+			return
+		}
+		var msg message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.readErr <- fmt.Errorf("invalid message %q: %w", data, err)
+			return
+		}
+		select {
+		case s.msgs <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// next returns the next message that is not a keep-alive, answering pings
+// on the way. A nil timeout waits as long as ctx.
+func (s *session) next(ctx context.Context, timeout <-chan time.Time) (message, error) {
+	for {
+		select {
+		case msg := <-s.msgs:
+			switch msg.Type {
+			case "ka", "pong":
+				continue
+			case "ping":
+				if err := s.write(ctx, message{Type: "pong"}); err != nil {
+					return message{}, err
+				}
+				continue
+			}
+			return msg, nil
+		case err := <-s.readErr:
+			return message{}, err
+		case <-timeout:
+			return message{}, errors.New("timed out")
+		case <-ctx.Done():
+			return message{}, ctx.Err()
+		}
+	}
+}
+
+func (s *session) init(ctx context.Context, req Request) error {
+	init := message{Type: "connection_init"}
+	if req.ConnectionPayload != nil {
+		payload, err := json.Marshal(req.ConnectionPayload)
+		if err != nil {
+			return fmt.Errorf("invalid connection payload: %w", err)
+		}
+		init.Payload = payload
+	}
+	if err := s.write(ctx, init); err != nil {
+		return fmt.Errorf("failed to send connection_init: %w", err)
+	}
+
+	ackTimeout := req.AckTimeout
+	if ackTimeout <= 0 {
+		ackTimeout = DefaultAckTimeout
+	}
+	timer := time.NewTimer(ackTimeout)
+	defer timer.Stop()
+
+	msg, err := s.next(ctx, timer.C)
+	if err != nil {
+		return fmt.Errorf("waiting for connection_ack: %w", err)
+	}
+	switch msg.Type {
+	case "connection_ack":
+		return nil
+	case "connection_error":
+		return fmt.Errorf("connection rejected: %s", msg.Payload)
+	}
+	return fmt.Errorf("expected connection_ack, got %s", msg.Type)
+}
+
+func (s *session) subscribe(ctx context.Context, req Request) error {
+	payload, err := json.Marshal(subscribePayload{
+		Query:         req.Query,
+		Variables:     req.Variables,
+		OperationName: req.OperationName,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid subscription variables: %w", err)
+	}
+	msgType := "subscribe"
+	if s.protocol == ProtocolLegacyWS {
+		msgType = "start"
+	}
+	if err := s.write(ctx, message{ID: subscriptionID, Type: msgType, Payload: payload}); err != nil {
+		return fmt.Errorf("failed to send %s: %w", msgType, err)
+	}
+	return nil
+}
+
+// stop tells the server to stop the subscription and closes the connection.
+// It is best effort and bounded by closeTimeout.
+func (s *session) stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+
+	if s.protocol == ProtocolLegacyWS {
+		_ = s.write(ctx, message{ID: subscriptionID, Type: "stop"})
+		_ = s.write(ctx, message{Type: "connection_terminate"})
+	} else {
+		_ = s.write(ctx, message{ID: subscriptionID, Type: "complete"})
+	}
+	_ = s.conn.Close(websocket.StatusNormalClosure, "")
+}
+
+func (s *session) write(ctx context.Context, msg message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.conn.Write(ctx, websocket.MessageText, data)
+}
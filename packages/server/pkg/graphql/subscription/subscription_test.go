@@ -0,0 +1,271 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+// testServer pushes count events for every subscription, then completes it
+// unless count is negative. It records the connection payload and whether
+// the client stopped the subscription.
+type testServer struct {
+	protocol string
+	count    int
+	token    string
+
+	gotPayload chan map[string]any
+	stopped    chan string
+}
+
+func newTestServer(t *testing.T, protocol string, count int) (*testServer, string) {
+	t.Helper()
+	ts := &testServer{
+		protocol:   protocol,
+		count:      count,
+		gotPayload: make(chan map[string]any, 1),
+		stopped:    make(chan string, 1),
+	}
+	srv := httptest.NewServer(ts)
+	t.Cleanup(srv.Close)
+	return ts, srv.URL + "/graphql"
+}
+
+func (ts *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{ts.protocol}})
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow() //nolint:errcheck // test server
+	ctx := r.Context()
+
+	read := func() message {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return message{}
+		}
+		var msg message
+		_ = json.Unmarshal(data, &msg)
+		return msg
+	}
+	send := func(msg message) {
+		data, _ := json.Marshal(msg)
+		_ = conn.Write(ctx, websocket.MessageText, data)
+	}
+
+	init := read()
+	var payload map[string]any
+	_ = json.Unmarshal(init.Payload, &payload)
+	ts.gotPayload <- payload
+	if ts.token != "" && payload["token"] != ts.token {
+		if ts.protocol == ProtocolLegacyWS {
+			send(message{Type: "connection_error", Payload: json.RawMessage(`{"message":"unauthorized"}`)})
+			return
+		}
+		_ = conn.Close(4403, "Forbidden")
+		return
+	}
+	send(message{Type: "connection_ack"})
+	if ts.protocol == ProtocolLegacyWS {
+		send(message{Type: "ka"})
+	} else {
+		send(message{Type: "ping"})
+	}
+
+	sub := read()
+	for sub.Type == "pong" {
+		sub = read()
+	}
+	wantType := "subscribe"
+	nextType := "next"
+	if ts.protocol == ProtocolLegacyWS {
+		wantType, nextType = "start", "data"
+	}
+	if sub.Type != wantType {
+		send(message{ID: sub.ID, Type: "error", Payload: json.RawMessage(`[{"message":"unexpected ` + sub.Type + `"}]`)})
+		return
+	}
+
+	stops := make(chan string, 1)
+	go func() {
+		for {
+			msg := read()
+			if msg.Type == "" {
+				return
+			}
+			if msg.Type == "complete" || msg.Type == "stop" {
+				stops <- msg.Type
+				return
+			}
+		}
+	}()
+
+	for i := 0; ts.count < 0 || i < ts.count; i++ {
+		send(message{ID: sub.ID, Type: nextType, Payload: json.RawMessage(fmt.Sprintf(`{"data":{"tick":%d}}`, i))})
+		select {
+		case stop := <-stops:
+			ts.stopped <- stop
+			return
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	if ts.count >= 0 {
+		send(message{ID: sub.ID, Type: "complete"})
+		_ = conn.Close(websocket.StatusNormalClosure, "")
+		return
+	}
+}
+
+func tick(t *testing.T, ev Event) int {
+	t.Helper()
+	var payload struct {
+		Data struct {
+			Tick int `json:"tick"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(ev.Payload, &payload); err != nil {
+		t.Fatalf("invalid payload %s: %v", ev.Payload, err)
+	}
+	return payload.Data.Tick
+}
+
+func TestSubscribe_ServerCompletes(t *testing.T) {
+	for _, protocol := range []string{ProtocolTransportWS, ProtocolLegacyWS} {
+		t.Run(protocol, func(t *testing.T) {
+			ts, url := newTestServer(t, protocol, 3)
+
+			var ticks []int
+			result, err := Subscribe(context.Background(), Request{
+				URL:               url,
+				Query:             "subscription { tick }",
+				ConnectionPayload: map[string]any{"token": "secret"},
+			}, func(ev Event) error {
+				if ev.Index != len(ticks) {
+					t.Errorf("expected index %d, got %d", len(ticks), ev.Index)
+				}
+				ticks = append(ticks, tick(t, ev))
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Subscribe: %v", err)
+			}
+			if result.Protocol != protocol || !result.Completed || result.Events != 3 {
+				t.Errorf("unexpected result: %+v", result)
+			}
+			if len(ticks) != 3 || ticks[2] != 2 {
+				t.Errorf("unexpected events: %v", ticks)
+			}
+			if got := <-ts.gotPayload; got["token"] != "secret" {
+				t.Errorf("expected connection payload, got %v", got)
+			}
+		})
+	}
+}
+
+func TestSubscribe_ClientStops(t *testing.T) {
+	for protocol, wantStop := range map[string]string{ProtocolTransportWS: "complete", ProtocolLegacyWS: "stop"} {
+		t.Run(protocol, func(t *testing.T) {
+			ts, url := newTestServer(t, protocol, -1)
+
+			result, err := Subscribe(context.Background(), Request{URL: url, Query: "subscription { tick }"}, func(ev Event) error {
+				if ev.Index == 1 {
+					return ErrStop
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Subscribe: %v", err)
+			}
+			if result.Events != 2 || result.Completed {
+				t.Errorf("unexpected result: %+v", result)
+			}
+			select {
+			case got := <-ts.stopped:
+				if got != wantStop {
+					t.Errorf("expected %s, got %s", wantStop, got)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("server was not told to stop")
+			}
+		})
+	}
+}
+
+func TestSubscribe_ContextDone(t *testing.T) {
+	ts, url := newTestServer(t, ProtocolTransportWS, -1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	result, err := Subscribe(ctx, Request{URL: url, Query: "subscription { tick }"}, func(Event) error { return nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if result.Events == 0 {
+		t.Error("expected events before the deadline")
+	}
+	select {
+	case <-ts.stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server was not told to stop")
+	}
+}
+
+func TestSubscribe_Rejected(t *testing.T) {
+	for _, protocol := range []string{ProtocolTransportWS, ProtocolLegacyWS} {
+		t.Run(protocol, func(t *testing.T) {
+			ts, url := newTestServer(t, protocol, 1)
+			ts.token = "secret"
+
+			_, err := Subscribe(context.Background(), Request{
+				URL:               url,
+				Query:             "subscription { tick }",
+				ConnectionPayload: map[string]any{"token": "wrong"},
+			}, func(Event) error {
+				t.Error("unexpected event")
+				return nil
+			})
+			if err == nil {
+				t.Fatal("expected the connection to be rejected")
+			}
+		})
+	}
+}
+
+func TestIsSubscription(t *testing.T) {
+	cases := []struct {
+		query, operation string
+		want             bool
+	}{
+		{"subscription { tick }", "", true},
+		{"{ user { id } }", "", false},
+		{"query Q { a }\nsubscription S { b }", "S", true},
+		{"query Q { a }\nsubscription S { b }", "", false},
+		{"# subscription\nquery Q($f: In = {a: \"subscription {\"}) { a }", "", false},
+		{"fragment F on T { id }\nsubscription OnTick { tick { ...F } }", "", true},
+	}
+	for _, tc := range cases {
+		if got := IsSubscription(tc.query, tc.operation); got != tc.want {
+			t.Errorf("IsSubscription(%q, %q) = %v, want %v", tc.query, tc.operation, got, tc.want)
+		}
+	}
+}
+
+func TestWebSocketURL(t *testing.T) {
+	cases := map[string]string{
+		"http://localhost:4000/graphql": "ws://localhost:4000/graphql",
+		"https://api.example.com/gql":   "wss://api.example.com/gql",
+		"wss://api.example.com/gql":     "wss://api.example.com/gql",
+	}
+	for in, want := range cases {
+		if got := WebSocketURL(in); got != want {
+			t.Errorf("WebSocketURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+// Package transport sends GraphQL operations over HTTP. Besides the plain
+// {query, variables} JSON body it batches several operations in one array,
+// speaks Automatic Persisted Queries (APQ) and builds multipart bodies per the
+// GraphQL multipart request spec for file uploads.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/httpclient"
+)
+
+const (
+	// persistedQueryVersion is the only APQ protocol version in use.
+	persistedQueryVersion = 1
+
+	errPersistedQueryNotFound  = "PersistedQueryNotFound"
+	codePersistedQueryNotFound = "PERSISTED_QUERY_NOT_FOUND"
+)
+
+// Operation is a single GraphQL operation as sent to the server.
+type Operation struct {
+	Query         string
+	Variables     json.RawMessage
+	OperationName string
+}
+
+// Upload attaches a local file to the request.
+type Upload struct {
+	// Path locates the file within the operations payload, e.g.
+	// "variables.file", or "1.variables.file" for the second operation of a
+	// batch. The value at Path is sent as null.
+	Path string
+	// File is the local path of the file to send.
+	File string
+}
+
+// Request describes one HTTP round trip. More than one operation makes it a
+// batch, sent as a JSON array.
+type Request struct {
+	URL        string
+	Header     http.Header
+	Operations []Operation
+	// PersistedQuery sends only the sha256 hash of each query first and falls
+	// back to the full query when the server does not know the hash.
+	PersistedQuery bool
+	Uploads        []Upload
+}
+
+// Response is the outcome of a Request.
+type Response struct {
+	Status   int
+	Header   http.Header
+	Body     []byte
+	Duration time.Duration
+	// Results holds the result of each operation of a batch, in order. It is
+	// empty for a single operation or when the server did not reply with an
+	// array.
+	Results []json.RawMessage
+	// PersistedQueryMiss is set when the server did not know a query hash and
+	// the full query had to be sent.
+	PersistedQueryMiss bool
+}
+
+// Batch reports whether req is sent as a batch.
+func (req Request) Batch() bool {
+	return len(req.Operations) > 1
+}
+
+// Do sends req with client. With PersistedQuery set it may take two round
+// trips; Duration covers both.
+func Do(ctx context.Context, client httpclient.HttpClient, req Request) (*Response, error) {
+	if len(req.Operations) == 0 {
+		return nil, errors.New("no graphql operation to send")
+	}
+
+	start := time.Now()
+	resp, err := roundTrip(ctx, client, req, !req.PersistedQuery)
+	if err != nil {
+		return nil, err
+	}
+	if req.PersistedQuery && persistedQueryNotFound(resp.Body) {
+		resp, err = roundTrip(ctx, client, req, true)
+		if err != nil {
+			return nil, err
+		}
+		resp.PersistedQueryMiss = true
+	}
+	resp.Duration = time.Since(start)
+
+	if req.Batch() {
+		var results []json.RawMessage
+		if err := json.Unmarshal(resp.Body, &results); err == nil {
+			resp.Results = results
+		}
+	}
+	return resp, nil
+}
+
+func roundTrip(ctx context.Context, client httpclient.HttpClient, req Request, includeQuery bool) (*Response, error) {
+	body, contentType, err := Body(req, includeQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create graphql http request: %w", err)
+	}
+	for key, values := range req.Header {
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("graphql request failed: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read graphql response body: %w", err)
+	}
+	return &Response{
+		Status: httpResp.StatusCode,
+		Header: httpResp.Header,
+		Body:   respBody,
+	}, nil
+}
+
+// Body encodes req as an HTTP request body and returns it with its content
+// type. includeQuery controls whether query text is sent alongside the
+// persisted query hash; it is ignored unless req.PersistedQuery is set.
+func Body(req Request, includeQuery bool) ([]byte, string, error) {
+	payload := Payload(req, includeQuery)
+	if len(req.Uploads) == 0 {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal graphql request body: %w", err)
+		}
+		return b, "application/json", nil
+	}
+	return multipartBody(payload, req.Uploads)
+}
+
+// Payload returns the JSON value describing req's operations: an object for
+// a single operation and an array for a batch.
+func Payload(req Request, includeQuery bool) any {
+	if !req.PersistedQuery {
+		includeQuery = true
+	}
+	entries := make([]map[string]any, len(req.Operations))
+	for i, op := range req.Operations {
+		entry := map[string]any{}
+		if includeQuery {
+			entry["query"] = op.Query
+		}
+		if len(op.Variables) > 0 {
+			entry["variables"] = op.Variables
+		}
+		if op.OperationName != "" {
+			entry["operationName"] = op.OperationName
+		}
+		if req.PersistedQuery {
+			entry["extensions"] = map[string]any{
+				"persistedQuery": map[string]any{
+					"version":    persistedQueryVersion,
+					"sha256Hash": QueryHash(op.Query),
+				},
+			}
+		}
+		entries[i] = entry
+	}
+	if len(entries) == 1 {
+		return entries[0]
+	}
+	return entries
+}
+
+// QueryHash is the APQ hash of query: its hex encoded sha256 digest.
+func QueryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// persistedQueryNotFound reports whether body is the error a server returns
+// for an unknown persisted query hash, on its own or within a batch.
+func persistedQueryNotFound(body []byte) bool {
+	type gqlError struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	}
+	type result struct {
+		Errors []gqlError `json:"errors"`
+	}
+
+	var results []result
+	if err := json.Unmarshal(body, &results); err != nil {
+		var single result
+		if err := json.Unmarshal(body, &single); err != nil {
+			return false
+		}
+		results = []result{single}
+	}
+	for _, r := range results {
+		for _, e := range r.Errors {
+			if e.Message == errPersistedQueryNotFound || e.Extensions.Code == codePersistedQueryNotFound {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// multipartBody builds a multipart/form-data body with the operations, map
+// and file fields of the GraphQL multipart request spec. Uploads of the same
+// file share one file field.
+func multipartBody(payload any, uploads []Upload) ([]byte, string, error) {
+	operations, err := NullUploads(payload, uploads)
+	if err != nil {
+		return nil, "", err
+	}
+	opsJSON, err := json.Marshal(operations)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal graphql operations: %w", err)
+	}
+
+	files, fileMap := UploadMap(uploads)
+	mapJSON, err := json.Marshal(fileMap)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal graphql upload map: %w", err)
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.WriteField("operations", string(opsJSON)); err != nil {
+		return nil, "", err
+	}
+	if err := w.WriteField("map", string(mapJSON)); err != nil {
+		return nil, "", err
+	}
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read upload %q: %w", file, err)
+		}
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%d"; filename="%s"`, i, escapeQuotes(filepath.Base(file))))
+		h.Set("Content-Type", contentTypeOf(file))
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(data); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+// UploadMap groups uploads by file. It returns the distinct files in first
+// use order and the spec's map field, keyed by each file's index.
+func UploadMap(uploads []Upload) ([]string, map[string][]string) {
+	var files []string
+	index := make(map[string]int)
+	fileMap := make(map[string][]string)
+	for _, u := range uploads {
+		i, ok := index[u.File]
+		if !ok {
+			i = len(files)
+			index[u.File] = i
+			files = append(files, u.File)
+		}
+		key := strconv.Itoa(i)
+		fileMap[key] = append(fileMap[key], u.Path)
+	}
+	return files, fileMap
+}
+
+// NullUploads returns a copy of payload with null at each upload path,
+// creating missing object keys on the way.
+func NullUploads(payload any, uploads []Upload) (any, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal graphql operations: %w", err)
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode graphql operations: %w", err)
+	}
+	for _, u := range uploads {
+		if err := setNull(doc, strings.Split(u.Path, ".")); err != nil {
+			return nil, fmt.Errorf("upload path %q: %w", u.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func setNull(doc any, path []string) error {
+	if len(path) == 0 || path[0] == "" {
+		return errors.New("empty path segment")
+	}
+	last := len(path) == 1
+	switch v := doc.(type) {
+	case map[string]any:
+		if last {
+			v[path[0]] = nil
+			return nil
+		}
+		next, ok := v[path[0]]
+		if !ok || next == nil {
+			next = map[string]any{}
+			v[path[0]] = next
+		}
+		return setNull(next, path[1:])
+	case []any:
+		i, err := strconv.Atoi(path[0])
+		if err != nil || i < 0 || i >= len(v) {
+			return fmt.Errorf("index %q out of range", path[0])
+		}
+		if last {
+			v[i] = nil
+			return nil
+		}
+		return setNull(v[i], path[1:])
+	default:
+		return fmt.Errorf("cannot descend into %q", path[0])
+	}
+}
+
+func contentTypeOf(file string) string {
+	if ct := mime.TypeByExtension(filepath.Ext(file)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,195 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDo_Single(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("content type = %q", ct)
+		}
+		if r.Header.Get("Authorization") != "Bearer t" {
+			t.Errorf("missing authorization header")
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"data":{"ok":true}}`))
+	}))
+	defer srv.Close()
+
+	resp, err := Do(context.Background(), srv.Client(), Request{
+		URL:    srv.URL,
+		Header: http.Header{"Authorization": {"Bearer t"}},
+		Operations: []Operation{{
+			Query:         "query Q { ok }",
+			Variables:     json.RawMessage(`{"a":1}`),
+			OperationName: "Q",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != http.StatusOK || string(resp.Body) != `{"data":{"ok":true}}` {
+		t.Fatalf("unexpected response %d %s", resp.Status, resp.Body)
+	}
+	if resp.Results != nil {
+		t.Errorf("single operation should have no batch results")
+	}
+	if got["query"] != "query Q { ok }" || got["operationName"] != "Q" {
+		t.Errorf("unexpected body %v", got)
+	}
+	if vars, _ := got["variables"].(map[string]any); vars["a"] != float64(1) {
+		t.Errorf("unexpected variables %v", got["variables"])
+	}
+}
+
+func TestDo_Batch(t *testing.T) {
+	var got []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`[{"data":{"a":1}},{"data":{"b":2}}]`))
+	}))
+	defer srv.Close()
+
+	resp, err := Do(context.Background(), srv.Client(), Request{
+		URL:        srv.URL,
+		Operations: []Operation{{Query: "{ a }"}, {Query: "{ b }"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0]["query"] != "{ a }" || got[1]["query"] != "{ b }" {
+		t.Fatalf("unexpected batch body %v", got)
+	}
+	if len(resp.Results) != 2 || string(resp.Results[1]) != `{"data":{"b":2}}` {
+		t.Fatalf("unexpected results %q", resp.Results)
+	}
+}
+
+func TestDo_PersistedQuery(t *testing.T) {
+	const query = "{ hello }"
+	hash := QueryHash(query)
+
+	for _, known := range []bool{true, false} {
+		var bodies []map[string]any
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			bodies = append(bodies, body)
+			ext, _ := body["extensions"].(map[string]any)
+			pq, _ := ext["persistedQuery"].(map[string]any)
+			if pq["sha256Hash"] != hash || pq["version"] != float64(1) {
+				t.Errorf("missing persisted query extension: %v", body)
+			}
+			if _, hasQuery := body["query"]; !hasQuery && !known {
+				_, _ = w.Write([]byte(`{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"hello":"world"}}`))
+		}))
+
+		resp, err := Do(context.Background(), srv.Client(), Request{
+			URL:            srv.URL,
+			Operations:     []Operation{{Query: query}},
+			PersistedQuery: true,
+		})
+		srv.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(resp.Body) != `{"data":{"hello":"world"}}` {
+			t.Fatalf("known=%v: unexpected body %s", known, resp.Body)
+		}
+		if _, hasQuery := bodies[0]["query"]; hasQuery {
+			t.Errorf("known=%v: first attempt should only send the hash", known)
+		}
+		wantRequests := 1
+		if !known {
+			wantRequests = 2
+			if bodies[1]["query"] != query {
+				t.Errorf("retry should send the full query: %v", bodies[1])
+			}
+		}
+		if len(bodies) != wantRequests || resp.PersistedQueryMiss == known {
+			t.Errorf("known=%v: %d requests, miss=%v", known, len(bodies), resp.PersistedQueryMiss)
+		}
+	}
+}
+
+func TestDo_Uploads(t *testing.T) {
+	dir := t.TempDir()
+	avatar := filepath.Join(dir, "avatar.txt")
+	if err := os.WriteFile(avatar, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("parse multipart: %v", err)
+			return
+		}
+		var ops map[string]any
+		if err := json.Unmarshal([]byte(r.FormValue("operations")), &ops); err != nil {
+			t.Errorf("operations: %v", err)
+		}
+		vars, _ := ops["variables"].(map[string]any)
+		if v, ok := vars["file"]; !ok || v != nil {
+			t.Errorf("file variable should be null, got %v", ops["variables"])
+		}
+		if vars["id"] != float64(7) {
+			t.Errorf("other variables should be kept, got %v", vars)
+		}
+		if docs, _ := vars["docs"].([]any); len(docs) != 1 || docs[0] != nil {
+			t.Errorf("list upload should be null, got %v", vars["docs"])
+		}
+		if got := r.FormValue("map"); got != `{"0":["variables.file","variables.docs.0"]}` {
+			t.Errorf("map = %s", got)
+		}
+		f, hdr, err := r.FormFile("0")
+		if err != nil {
+			t.Errorf("file part: %v", err)
+			return
+		}
+		data, _ := io.ReadAll(f)
+		if hdr.Filename != "avatar.txt" || string(data) != "hello" {
+			t.Errorf("unexpected file %q %q", hdr.Filename, data)
+		}
+		_, _ = w.Write([]byte(`{"data":{"upload":true}}`))
+	}))
+	defer srv.Close()
+
+	resp, err := Do(context.Background(), srv.Client(), Request{
+		URL: srv.URL,
+		Operations: []Operation{{
+			Query:     "mutation($file: Upload!, $docs: [Upload!]!, $id: Int) { upload(file: $file, docs: $docs, id: $id) }",
+			Variables: json.RawMessage(`{"id":7,"docs":[null]}`),
+		}},
+		Uploads: []Upload{
+			{Path: "variables.file", File: avatar},
+			{Path: "variables.docs.0", File: avatar},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.Status, resp.Body)
+	}
+}
+
+func TestNullUploads_Errors(t *testing.T) {
+	payload := map[string]any{"variables": map[string]any{"list": []any{}}}
+	for _, path := range []string{"variables..file", "variables.list.3", ""} {
+		if _, err := NullUploads(payload, []Upload{{Path: path}}); err == nil {
+			t.Errorf("expected error for path %q", path)
+		}
+	}
+}
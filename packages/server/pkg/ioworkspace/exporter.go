@@ -235,6 +235,19 @@ func (s *IOWorkspaceService) exportFlows(ctx context.Context, opts ExportOptions
 	nodeSubFlowTriggerService := sflow.NewNodeSubFlowTriggerService(s.queries)
 	nodeSubFlowReturnService := sflow.NewNodeSubFlowReturnService(s.queries)
 	nodeRunSubFlowService := sflow.NewNodeRunSubFlowService(s.queries)
+	nodeParallelService := sflow.NewNodeParallelService(s.queries)
+	nodePollService := sflow.NewNodePollService(s.queries)
+	nodeSwitchService := sflow.NewNodeSwitchService(s.queries)
+	nodeWebhookTriggerService := sflow.NewNodeWebhookTriggerService(s.queries)
+	nodeGraphQLSubscriptionService := sflow.NewNodeGraphQLSubscriptionService(s.queries)
+	nodeWsExpectService := sflow.NewNodeWsExpectService(s.queries)
+	nodeSocketConnectionService := sflow.NewNodeSocketConnectionService(s.queries)
+	nodeSocketSendService := sflow.NewNodeSocketSendService(s.queries)
+	nodeSocketReceiveService := sflow.NewNodeSocketReceiveService(s.queries)
+	nodeDbQueryService := sflow.NewNodeDbQueryService(s.queries)
+	nodeMqPublishService := sflow.NewNodeMqPublishService(s.queries)
+	nodeMqConsumeService := sflow.NewNodeMqConsumeService(s.queries)
+	nodeExecService := sflow.NewNodeExecService(s.queries)
 	websocketService := swebsocket.New(s.queries, s.logger)
 	websocketHeaderService := swebsocket.NewWebSocketHeaderService(s.queries)
 
@@ -290,7 +303,7 @@ func (s *IOWorkspaceService) exportFlows(ctx context.Context, opts ExportOptions
 
 		// Export node implementations based on node types
 		for _, node := range nodes {
-			if err := s.exportNodeImplementation(ctx, node, bundle, nodeRequestService, nodeIfService, nodeForService, nodeForEachService, nodeJSService, nodeAIService, nodeAIProviderService, nodeMemoryService, nodeGraphQLService, nodeWsConnectionService, nodeWsSendService, nodeWaitService, nodeSubFlowTriggerService, nodeSubFlowReturnService, nodeRunSubFlowService, websocketService, websocketHeaderService); err != nil {
+			if err := s.exportNodeImplementation(ctx, node, bundle, nodeRequestService, nodeIfService, nodeForService, nodeForEachService, nodeJSService, nodeAIService, nodeAIProviderService, nodeMemoryService, nodeGraphQLService, nodeWsConnectionService, nodeWsSendService, nodeWaitService, nodeSubFlowTriggerService, nodeSubFlowReturnService, nodeRunSubFlowService, nodeParallelService, nodePollService, nodeSwitchService, nodeWebhookTriggerService, nodeGraphQLSubscriptionService, nodeWsExpectService, nodeSocketConnectionService, nodeSocketSendService, nodeSocketReceiveService, nodeDbQueryService, nodeMqPublishService, nodeMqConsumeService, nodeExecService, websocketService, websocketHeaderService); err != nil {
 				return fmt.Errorf("failed to export node implementation for node %s: %w", node.ID.String(), err)
 			}
 		}
@@ -314,7 +327,20 @@ func (s *IOWorkspaceService) exportFlows(ctx context.Context, opts ExportOptions
 		"wait_nodes", len(bundle.FlowWaitNodes),
 		"sub_flow_trigger_nodes", len(bundle.FlowSubFlowTriggerNodes),
 		"sub_flow_return_nodes", len(bundle.FlowSubFlowReturnNodes),
-		"run_sub_flow_nodes", len(bundle.FlowRunSubFlowNodes))
+		"run_sub_flow_nodes", len(bundle.FlowRunSubFlowNodes),
+		"parallel_nodes", len(bundle.FlowParallelNodes),
+		"poll_nodes", len(bundle.FlowPollNodes),
+		"switch_nodes", len(bundle.FlowSwitchNodes),
+		"webhook_trigger_nodes", len(bundle.FlowWebhookTriggerNodes),
+		"graphql_subscription_nodes", len(bundle.FlowGraphQLSubscriptionNodes),
+		"ws_expect_nodes", len(bundle.FlowWsExpectNodes),
+		"socket_connection_nodes", len(bundle.FlowSocketConnectionNodes),
+		"socket_send_nodes", len(bundle.FlowSocketSendNodes),
+		"socket_receive_nodes", len(bundle.FlowSocketReceiveNodes),
+		"db_query_nodes", len(bundle.FlowDbQueryNodes),
+		"mq_publish_nodes", len(bundle.FlowMqPublishNodes),
+		"mq_consume_nodes", len(bundle.FlowMqConsumeNodes),
+		"exec_nodes", len(bundle.FlowExecNodes))
 
 	return nil
 }
@@ -373,6 +399,19 @@ func (s *IOWorkspaceService) exportNodeImplementation(
 	nodeSubFlowTriggerService sflow.NodeSubFlowTriggerService,
 	nodeSubFlowReturnService sflow.NodeSubFlowReturnService,
 	nodeRunSubFlowService sflow.NodeRunSubFlowService,
+	nodeParallelService sflow.NodeParallelService,
+	nodePollService sflow.NodePollService,
+	nodeSwitchService sflow.NodeSwitchService,
+	nodeWebhookTriggerService sflow.NodeWebhookTriggerService,
+	nodeGraphQLSubscriptionService sflow.NodeGraphQLSubscriptionService,
+	nodeWsExpectService sflow.NodeWsExpectService,
+	nodeSocketConnectionService sflow.NodeSocketConnectionService,
+	nodeSocketSendService sflow.NodeSocketSendService,
+	nodeSocketReceiveService sflow.NodeSocketReceiveService,
+	nodeDbQueryService sflow.NodeDbQueryService,
+	nodeMqPublishService sflow.NodeMqPublishService,
+	nodeMqConsumeService sflow.NodeMqConsumeService,
+	nodeExecService sflow.NodeExecService,
 	websocketService swebsocket.WebSocketService,
 	websocketHeaderService swebsocket.WebSocketHeaderService,
 ) error {
@@ -529,8 +568,122 @@ func (s *IOWorkspaceService) exportNodeImplementation(
 			bundle.FlowRunSubFlowNodes = append(bundle.FlowRunSubFlowNodes, *nodeRunSubFlow)
 		}
 
+	case mflow.NODE_KIND_PARALLEL:
+		nodeParallel, err := nodeParallelService.GetNodeParallel(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get parallel node: %w", err)
+		}
+		if nodeParallel != nil {
+			bundle.FlowParallelNodes = append(bundle.FlowParallelNodes, *nodeParallel)
+		}
+
+	case mflow.NODE_KIND_POLL:
+		nodePoll, err := nodePollService.GetNodePoll(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get poll node: %w", err)
+		}
+		if nodePoll != nil {
+			bundle.FlowPollNodes = append(bundle.FlowPollNodes, *nodePoll)
+		}
+
+	case mflow.NODE_KIND_SWITCH:
+		nodeSwitch, err := nodeSwitchService.GetNodeSwitch(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get switch node: %w", err)
+		}
+		if nodeSwitch != nil {
+			bundle.FlowSwitchNodes = append(bundle.FlowSwitchNodes, *nodeSwitch)
+		}
+
 	case mflow.NODE_KIND_WEBHOOK_TRIGGER:
-		// Not yet implemented
+		nodeWebhookTrigger, err := nodeWebhookTriggerService.GetNodeWebhookTrigger(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get webhook trigger node: %w", err)
+		}
+		if nodeWebhookTrigger != nil {
+			bundle.FlowWebhookTriggerNodes = append(bundle.FlowWebhookTriggerNodes, *nodeWebhookTrigger)
+		}
+
+	case mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION:
+		nodeGraphQLSubscription, err := nodeGraphQLSubscriptionService.GetNodeGraphQLSubscription(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get graphql subscription node: %w", err)
+		}
+		if nodeGraphQLSubscription != nil {
+			bundle.FlowGraphQLSubscriptionNodes = append(bundle.FlowGraphQLSubscriptionNodes, *nodeGraphQLSubscription)
+		}
+
+	case mflow.NODE_KIND_WS_EXPECT:
+		nodeWsExpect, err := nodeWsExpectService.GetNodeWsExpect(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get ws expect node: %w", err)
+		}
+		if nodeWsExpect != nil {
+			bundle.FlowWsExpectNodes = append(bundle.FlowWsExpectNodes, *nodeWsExpect)
+		}
+
+	case mflow.NODE_KIND_SOCKET_CONNECTION:
+		nodeSocketConnection, err := nodeSocketConnectionService.GetNodeSocketConnection(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get socket connection node: %w", err)
+		}
+		if nodeSocketConnection != nil {
+			bundle.FlowSocketConnectionNodes = append(bundle.FlowSocketConnectionNodes, *nodeSocketConnection)
+		}
+
+	case mflow.NODE_KIND_SOCKET_SEND:
+		nodeSocketSend, err := nodeSocketSendService.GetNodeSocketSend(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get socket send node: %w", err)
+		}
+		if nodeSocketSend != nil {
+			bundle.FlowSocketSendNodes = append(bundle.FlowSocketSendNodes, *nodeSocketSend)
+		}
+
+	case mflow.NODE_KIND_SOCKET_RECEIVE:
+		nodeSocketReceive, err := nodeSocketReceiveService.GetNodeSocketReceive(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get socket receive node: %w", err)
+		}
+		if nodeSocketReceive != nil {
+			bundle.FlowSocketReceiveNodes = append(bundle.FlowSocketReceiveNodes, *nodeSocketReceive)
+		}
+
+	case mflow.NODE_KIND_DB_QUERY:
+		nodeDbQuery, err := nodeDbQueryService.GetNodeDbQuery(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get db query node: %w", err)
+		}
+		if nodeDbQuery != nil {
+			bundle.FlowDbQueryNodes = append(bundle.FlowDbQueryNodes, *nodeDbQuery)
+		}
+
+	case mflow.NODE_KIND_MQ_PUBLISH:
+		nodeMqPublish, err := nodeMqPublishService.GetNodeMqPublish(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get mq publish node: %w", err)
+		}
+		if nodeMqPublish != nil {
+			bundle.FlowMqPublishNodes = append(bundle.FlowMqPublishNodes, *nodeMqPublish)
+		}
+
+	case mflow.NODE_KIND_MQ_CONSUME:
+		nodeMqConsume, err := nodeMqConsumeService.GetNodeMqConsume(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get mq consume node: %w", err)
+		}
+		if nodeMqConsume != nil {
+			bundle.FlowMqConsumeNodes = append(bundle.FlowMqConsumeNodes, *nodeMqConsume)
+		}
+
+	case mflow.NODE_KIND_EXEC:
+		nodeExec, err := nodeExecService.GetNodeExec(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get exec node: %w", err)
+		}
+		if nodeExec != nil {
+			bundle.FlowExecNodes = append(bundle.FlowExecNodes, *nodeExec)
+		}
 	}
 
 	return nil
@@ -45,6 +45,19 @@ type ImportResult struct {
 	FlowSubFlowTriggerNodesCreated     int
 	FlowSubFlowReturnNodesCreated      int
 	FlowRunSubFlowNodesCreated         int
+	FlowParallelNodesCreated           int
+	FlowPollNodesCreated               int
+	FlowSwitchNodesCreated             int
+	FlowWebhookTriggerNodesCreated     int
+	FlowGraphQLSubscriptionNodesCreated int
+	FlowWsExpectNodesCreated           int
+	FlowSocketConnectionNodesCreated int
+	FlowSocketSendNodesCreated       int
+	FlowSocketReceiveNodesCreated    int
+	FlowDbQueryNodesCreated          int
+	FlowMqPublishNodesCreated          int
+	FlowMqConsumeNodesCreated          int
+	FlowExecNodesCreated          int
 	WebSocketsCreated              int
 	WebSocketHeadersCreated        int
 	GraphQLRequestsCreated         int
@@ -115,6 +128,19 @@ func (s *IOWorkspaceService) Import(ctx context.Context, tx *sql.Tx, bundle *Wor
 	nodeSubFlowTriggerService := sflow.NewNodeSubFlowTriggerService(s.queries).TX(tx)
 	nodeSubFlowReturnService := sflow.NewNodeSubFlowReturnService(s.queries).TX(tx)
 	nodeRunSubFlowService := sflow.NewNodeRunSubFlowService(s.queries).TX(tx)
+	nodeParallelService := sflow.NewNodeParallelService(s.queries).TX(tx)
+	nodePollService := sflow.NewNodePollService(s.queries).TX(tx)
+	nodeSwitchService := sflow.NewNodeSwitchService(s.queries).TX(tx)
+	nodeWebhookTriggerService := sflow.NewNodeWebhookTriggerService(s.queries).TX(tx)
+	nodeGraphQLSubscriptionService := sflow.NewNodeGraphQLSubscriptionService(s.queries).TX(tx)
+	nodeWsExpectService := sflow.NewNodeWsExpectService(s.queries).TX(tx)
+	nodeSocketConnectionService := sflow.NewNodeSocketConnectionService(s.queries).TX(tx)
+	nodeSocketSendService := sflow.NewNodeSocketSendService(s.queries).TX(tx)
+	nodeSocketReceiveService := sflow.NewNodeSocketReceiveService(s.queries).TX(tx)
+	nodeDbQueryService := sflow.NewNodeDbQueryService(s.queries).TX(tx)
+	nodeMqPublishService := sflow.NewNodeMqPublishService(s.queries).TX(tx)
+	nodeMqConsumeService := sflow.NewNodeMqConsumeService(s.queries).TX(tx)
+	nodeExecService := sflow.NewNodeExecService(s.queries).TX(tx)
 
 	graphqlService := sgraphql.New(s.queries, nil).TX(tx)
 	graphqlHeaderService := sgraphql.NewGraphQLHeaderService(s.queries).TX(tx)
@@ -340,6 +366,84 @@ func (s *IOWorkspaceService) Import(ctx context.Context, tx *sql.Tx, bundle *Wor
 				return nil, fmt.Errorf("failed to import flow run sub-flow nodes: %w", err)
 			}
 		}
+
+		if len(bundle.FlowParallelNodes) > 0 {
+			if err := s.importFlowParallelNodes(ctx, nodeParallelService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow parallel nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowPollNodes) > 0 {
+			if err := s.importFlowPollNodes(ctx, nodePollService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow poll nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowSwitchNodes) > 0 {
+			if err := s.importFlowSwitchNodes(ctx, nodeSwitchService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow switch nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowWebhookTriggerNodes) > 0 {
+			if err := s.importFlowWebhookTriggerNodes(ctx, nodeWebhookTriggerService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow webhook trigger nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowGraphQLSubscriptionNodes) > 0 {
+			if err := s.importFlowGraphQLSubscriptionNodes(ctx, nodeGraphQLSubscriptionService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow GraphQL subscription nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowWsExpectNodes) > 0 {
+			if err := s.importFlowWsExpectNodes(ctx, nodeWsExpectService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow WS expect nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowSocketConnectionNodes) > 0 {
+			if err := s.importFlowSocketConnectionNodes(ctx, nodeSocketConnectionService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow socket connection nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowSocketSendNodes) > 0 {
+			if err := s.importFlowSocketSendNodes(ctx, nodeSocketSendService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow socket send nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowSocketReceiveNodes) > 0 {
+			if err := s.importFlowSocketReceiveNodes(ctx, nodeSocketReceiveService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow socket receive nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowDbQueryNodes) > 0 {
+			if err := s.importFlowDbQueryNodes(ctx, nodeDbQueryService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow db query nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowMqPublishNodes) > 0 {
+			if err := s.importFlowMqPublishNodes(ctx, nodeMqPublishService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow mq publish nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowMqConsumeNodes) > 0 {
+			if err := s.importFlowMqConsumeNodes(ctx, nodeMqConsumeService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow mq consume nodes: %w", err)
+			}
+		}
+
+		if len(bundle.FlowExecNodes) > 0 {
+			if err := s.importFlowExecNodes(ctx, nodeExecService, bundle, opts, result); err != nil {
+				return nil, fmt.Errorf("failed to import flow exec nodes: %w", err)
+			}
+		}
 	}
 
 	return result, nil
@@ -467,6 +467,38 @@ func (s *IOWorkspaceService) importFlowRunSubFlowNodes(ctx context.Context, serv
 	return nil
 }
 
+// importFlowParallelNodes imports flow parallel nodes from the bundle.
+func (s *IOWorkspaceService) importFlowParallelNodes(ctx context.Context, service sflow.NodeParallelService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowParallelNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeParallel(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow parallel node: %w", err)
+		}
+
+		result.FlowParallelNodesCreated++
+	}
+	return nil
+}
+
+// importFlowPollNodes imports flow poll nodes from the bundle.
+func (s *IOWorkspaceService) importFlowPollNodes(ctx context.Context, service sflow.NodePollService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowPollNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodePoll(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow poll node: %w", err)
+		}
+
+		result.FlowPollNodesCreated++
+	}
+	return nil
+}
+
 // importWebSockets imports WebSocket entities from the bundle.
 func (s *IOWorkspaceService) importWebSockets(ctx context.Context, wsService swebsocket.WebSocketService, bundle *WorkspaceBundle, opts ImportOptions, result *ImportResult) error {
 	for _, ws := range bundle.WebSockets {
@@ -508,3 +540,179 @@ func (s *IOWorkspaceService) importWebSocketHeaders(ctx context.Context, wsHeade
 	}
 	return nil
 }
+
+// importFlowSwitchNodes imports flow switch nodes from the bundle.
+func (s *IOWorkspaceService) importFlowSwitchNodes(ctx context.Context, service sflow.NodeSwitchService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowSwitchNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeSwitch(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow switch node: %w", err)
+		}
+
+		result.FlowSwitchNodesCreated++
+	}
+	return nil
+}
+
+// importFlowWebhookTriggerNodes imports flow webhook trigger nodes from the bundle.
+func (s *IOWorkspaceService) importFlowWebhookTriggerNodes(ctx context.Context, service sflow.NodeWebhookTriggerService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowWebhookTriggerNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeWebhookTrigger(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow webhook trigger node: %w", err)
+		}
+
+		result.FlowWebhookTriggerNodesCreated++
+	}
+	return nil
+}
+
+// importFlowGraphQLSubscriptionNodes imports flow GraphQL subscription nodes from the bundle.
+func (s *IOWorkspaceService) importFlowGraphQLSubscriptionNodes(ctx context.Context, service sflow.NodeGraphQLSubscriptionService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowGraphQLSubscriptionNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeGraphQLSubscription(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow GraphQL subscription node: %w", err)
+		}
+
+		result.FlowGraphQLSubscriptionNodesCreated++
+	}
+	return nil
+}
+
+// importFlowWsExpectNodes imports flow WS expect nodes from the bundle.
+func (s *IOWorkspaceService) importFlowWsExpectNodes(ctx context.Context, service sflow.NodeWsExpectService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowWsExpectNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeWsExpect(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow WS expect node: %w", err)
+		}
+
+		result.FlowWsExpectNodesCreated++
+	}
+	return nil
+}
+
+// importFlowSocketConnectionNodes imports flow socket connection nodes from the bundle.
+func (s *IOWorkspaceService) importFlowSocketConnectionNodes(ctx context.Context, service sflow.NodeSocketConnectionService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowSocketConnectionNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeSocketConnection(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow socket connection node: %w", err)
+		}
+
+		result.FlowSocketConnectionNodesCreated++
+	}
+	return nil
+}
+
+// importFlowSocketSendNodes imports flow socket send nodes from the bundle.
+func (s *IOWorkspaceService) importFlowSocketSendNodes(ctx context.Context, service sflow.NodeSocketSendService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowSocketSendNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeSocketSend(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow socket send node: %w", err)
+		}
+
+		result.FlowSocketSendNodesCreated++
+	}
+	return nil
+}
+
+// importFlowSocketReceiveNodes imports flow socket receive nodes from the bundle.
+func (s *IOWorkspaceService) importFlowSocketReceiveNodes(ctx context.Context, service sflow.NodeSocketReceiveService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowSocketReceiveNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeSocketReceive(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow socket receive node: %w", err)
+		}
+
+		result.FlowSocketReceiveNodesCreated++
+	}
+	return nil
+}
+
+// importFlowDbQueryNodes imports flow db query nodes from the bundle.
+func (s *IOWorkspaceService) importFlowDbQueryNodes(ctx context.Context, service sflow.NodeDbQueryService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowDbQueryNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeDbQuery(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow db query node: %w", err)
+		}
+
+		result.FlowDbQueryNodesCreated++
+	}
+	return nil
+}
+
+// importFlowMqPublishNodes imports flow mq publish nodes from the bundle.
+func (s *IOWorkspaceService) importFlowMqPublishNodes(ctx context.Context, service sflow.NodeMqPublishService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowMqPublishNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeMqPublish(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow mq publish node: %w", err)
+		}
+
+		result.FlowMqPublishNodesCreated++
+	}
+	return nil
+}
+
+// importFlowMqConsumeNodes imports flow mq consume nodes from the bundle.
+func (s *IOWorkspaceService) importFlowMqConsumeNodes(ctx context.Context, service sflow.NodeMqConsumeService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowMqConsumeNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeMqConsume(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow mq consume node: %w", err)
+		}
+
+		result.FlowMqConsumeNodesCreated++
+	}
+	return nil
+}
+
+// importFlowExecNodes imports flow exec nodes from the bundle.
+func (s *IOWorkspaceService) importFlowExecNodes(ctx context.Context, service sflow.NodeExecService, bundle *WorkspaceBundle, _ ImportOptions, result *ImportResult) error {
+	for _, node := range bundle.FlowExecNodes {
+		if newNodeID, ok := result.NodeIDMap[node.FlowNodeID]; ok {
+			node.FlowNodeID = newNodeID
+		}
+
+		if err := service.CreateNodeExec(ctx, node); err != nil {
+			return fmt.Errorf("failed to create flow exec node: %w", err)
+		}
+
+		result.FlowExecNodesCreated++
+	}
+	return nil
+}
@@ -39,7 +39,8 @@ func (wb *WorkspaceBundle) ensureStartNodeForFlow(flowID idwrap.IDWrap) error {
 	var startNodeID *idwrap.IDWrap
 	for j := range wb.FlowNodes {
 		if (wb.FlowNodes[j].NodeKind == mflow.NODE_KIND_MANUAL_START ||
-			wb.FlowNodes[j].NodeKind == mflow.NODE_KIND_SUB_FLOW_TRIGGER) &&
+			wb.FlowNodes[j].NodeKind == mflow.NODE_KIND_SUB_FLOW_TRIGGER ||
+			wb.FlowNodes[j].NodeKind == mflow.NODE_KIND_WEBHOOK_TRIGGER) &&
 			wb.FlowNodes[j].FlowID.Compare(flowID) == 0 {
 			startNodeID = &wb.FlowNodes[j].ID
 			break
@@ -110,11 +111,37 @@ func (wb *WorkspaceBundle) layoutFlowNodes(flowID idwrap.IDWrap) error {
 	}
 
 	// Apply positions back to the original nodes in wb.FlowNodes
+	maxY := 0.0
 	for nodeID, pos := range layoutResult.Positions {
 		if idx, ok := nodeIndexMap[nodeID]; ok {
 			wb.FlowNodes[idx].PositionX = pos.X
 			wb.FlowNodes[idx].PositionY = pos.Y
 		}
+		maxY = max(maxY, pos.Y)
+	}
+
+	// Teardown sections are not reachable from the start node, so each one is
+	// laid out as its own graph below everything placed before it.
+	for _, n := range flowNodes {
+		if n.NodeKind != mflow.NODE_KIND_TEARDOWN {
+			continue
+		}
+		teardownLayout, err := flowgraph.Layout(flowNodes, flowEdges, n.ID, config)
+		if err != nil {
+			return err
+		}
+		minY := 0.0
+		for _, pos := range teardownLayout.Positions {
+			minY = min(minY, pos.Y)
+		}
+		offsetY := maxY + 2*config.SpacingSecondary - minY
+		for nodeID, pos := range teardownLayout.Positions {
+			if idx, ok := nodeIndexMap[nodeID]; ok {
+				wb.FlowNodes[idx].PositionX = pos.X
+				wb.FlowNodes[idx].PositionY = pos.Y + offsetY
+			}
+			maxY = max(maxY, pos.Y+offsetY)
+		}
 	}
 
 	return nil
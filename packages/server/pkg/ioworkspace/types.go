@@ -63,6 +63,19 @@ type WorkspaceBundle struct {
 	FlowSubFlowTriggerNodes    []mflow.NodeSubFlowTrigger
 	FlowSubFlowReturnNodes     []mflow.NodeSubFlowReturn
 	FlowRunSubFlowNodes        []mflow.NodeRunSubFlow
+	FlowParallelNodes          []mflow.NodeParallel
+	FlowPollNodes              []mflow.NodePoll
+	FlowSwitchNodes            []mflow.NodeSwitch
+	FlowWebhookTriggerNodes    []mflow.NodeWebhookTrigger
+	FlowGraphQLSubscriptionNodes []mflow.NodeGraphQLSubscription
+	FlowWsExpectNodes          []mflow.NodeWsExpect
+	FlowSocketConnectionNodes []mflow.NodeSocketConnection
+	FlowSocketSendNodes       []mflow.NodeSocketSend
+	FlowSocketReceiveNodes    []mflow.NodeSocketReceive
+	FlowDbQueryNodes          []mflow.NodeDbQuery
+	FlowMqPublishNodes          []mflow.NodeMqPublish
+	FlowMqConsumeNodes          []mflow.NodeMqConsume
+	FlowExecNodes          []mflow.NodeExec
 
 	// Environments and variables
 	Environments    []menv.Env
@@ -117,6 +130,19 @@ func (wb *WorkspaceBundle) CountEntities() map[string]int {
 		"flow_sub_flow_trigger_nodes":    len(wb.FlowSubFlowTriggerNodes),
 		"flow_sub_flow_return_nodes":     len(wb.FlowSubFlowReturnNodes),
 		"flow_run_sub_flow_nodes":        len(wb.FlowRunSubFlowNodes),
+		"flow_parallel_nodes":            len(wb.FlowParallelNodes),
+		"flow_poll_nodes":                len(wb.FlowPollNodes),
+		"flow_switch_nodes":              len(wb.FlowSwitchNodes),
+		"flow_webhook_trigger_nodes":     len(wb.FlowWebhookTriggerNodes),
+		"flow_graphql_subscription_nodes": len(wb.FlowGraphQLSubscriptionNodes),
+		"flow_ws_expect_nodes":           len(wb.FlowWsExpectNodes),
+		"flow_socket_connection_nodes":   len(wb.FlowSocketConnectionNodes),
+		"flow_socket_send_nodes":         len(wb.FlowSocketSendNodes),
+		"flow_socket_receive_nodes":      len(wb.FlowSocketReceiveNodes),
+		"flow_db_query_nodes":            len(wb.FlowDbQueryNodes),
+		"flow_mq_publish_nodes":            len(wb.FlowMqPublishNodes),
+		"flow_mq_consume_nodes":            len(wb.FlowMqConsumeNodes),
+		"flow_exec_nodes":            len(wb.FlowExecNodes),
 		"environments":              len(wb.Environments),
 		"environment_vars":     len(wb.EnvironmentVars),
 		"credentials":          len(wb.Credentials),
@@ -0,0 +1,282 @@
+// Package mock serves stored request examples over HTTP so clients and flows
+// can run against them offline. Requests are matched on method, path
+// template, query and headers; response bodies may be templates resolved with
+// the expression engine, including the faker namespace.
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/expression"
+)
+
+const (
+	// MaxBodySize caps the request body read for templates.
+	MaxBodySize = 10 << 20
+	// DefaultUnmatchedHistory is how many unmatched requests a Server keeps.
+	DefaultUnmatchedHistory = 100
+)
+
+// Unmatched is a request no route answered.
+type Unmatched struct {
+	Time   time.Time
+	Method string
+	Path   string
+	Query  string
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithLogger sets the logger matched and unmatched requests are written to.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Server) { s.logger = logger }
+}
+
+// WithLatency delays every matched response by delay plus a random duration
+// up to jitter.
+func WithLatency(delay, jitter time.Duration) Option {
+	return func(s *Server) {
+		s.delay = delay
+		s.jitter = jitter
+	}
+}
+
+// WithFaults fails a rate (0 to 1) of matched requests. A status of 0 drops
+// the connection instead of answering.
+func WithFaults(rate float64, status int) Option {
+	return func(s *Server) {
+		s.faultRate = rate
+		s.faultStatus = status
+	}
+}
+
+// WithUnmatchedHistory sets how many unmatched requests are kept.
+func WithUnmatchedHistory(size int) Option {
+	return func(s *Server) { s.historySize = size }
+}
+
+// Server answers requests from a set of routes. It is safe for concurrent use.
+type Server struct {
+	routes []compiledRoute
+	logger *slog.Logger
+
+	delay, jitter time.Duration
+	faultRate     float64
+	faultStatus   int
+
+	historySize int
+	mu          sync.Mutex
+	unmatched   []Unmatched
+}
+
+// New creates a Server for routes. When several routes match a request the
+// most specific one wins, and among equals the first one.
+func New(routes []Route, opts ...Option) *Server {
+	s := &Server{
+		logger:      slog.New(slog.DiscardHandler),
+		historySize: DefaultUnmatchedHistory,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.routes = make([]compiledRoute, len(routes))
+	for i, r := range routes {
+		s.routes[i] = compiledRoute{Route: r, path: compilePath(r.Path)}
+	}
+	return s
+}
+
+// Routes returns the routes the server answers from.
+func (s *Server) Routes() []Route {
+	routes := make([]Route, len(s.routes))
+	for i, r := range s.routes {
+		routes[i] = r.Route
+	}
+	return routes
+}
+
+// Unmatched returns the most recent requests no route answered, oldest first.
+func (s *Server) Unmatched() []Unmatched {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Unmatched(nil), s.unmatched...)
+}
+
+// ServeHTTP answers r from the best matching route.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.ServePath(w, r, r.URL.EscapedPath())
+}
+
+// ServePath answers r as if its escaped path were path, for servers that
+// mount the mock under a prefix.
+func (s *Server) ServePath(w http.ResponseWriter, r *http.Request, path string) {
+	route, params, ok := s.find(r, path)
+	if !ok {
+		s.recordUnmatched(r, path)
+		writeError(w, http.StatusNotFound, fmt.Errorf("no mock route matches %s %s", r.Method, path))
+		return
+	}
+	s.logger.Debug("mock request matched", "method", r.Method, "path", path, "route", route.Name)
+
+	if !s.wait(r) {
+		return
+	}
+	if s.faultRate > 0 && rand.Float64() < s.faultRate { //nolint:gosec // fault injection needs no crypto randomness
+		s.logger.Info("mock fault injected", "method", r.Method, "path", path, "route", route.Name)
+		if s.faultStatus == 0 {
+			panic(http.ErrAbortHandler)
+		}
+		writeError(w, s.faultStatus, fmt.Errorf("mock fault injected"))
+		return
+	}
+
+	body := route.Response.Body
+	if expression.HasVars(body) {
+		rendered, err := render(r, path, params, body)
+		if err != nil {
+			s.logger.Warn("mock body template failed, serving it verbatim", "route", route.Name, "error", err)
+		} else {
+			body = rendered
+		}
+	}
+
+	for key, value := range route.Response.Headers {
+		w.Header().Set(key, value)
+	}
+	status := route.Response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, body)
+}
+
+// find returns the most specific route matching r.
+func (s *Server) find(r *http.Request, path string) (compiledRoute, map[string]string, bool) {
+	var (
+		best       compiledRoute
+		bestParams map[string]string
+		bestScore  = -1
+	)
+	for _, route := range s.routes {
+		params, ok := route.match(r, path)
+		if !ok {
+			continue
+		}
+		if score := route.specificity(); score > bestScore {
+			best, bestParams, bestScore = route, params, score
+		}
+	}
+	return best, bestParams, bestScore >= 0
+}
+
+// wait applies the configured latency and reports false when the client went
+// away meanwhile.
+func (s *Server) wait(r *http.Request) bool {
+	delay := s.delay
+	if s.jitter > 0 {
+		delay += rand.N(s.jitter) //nolint:gosec // latency jitter needs no crypto randomness
+	}
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func (s *Server) recordUnmatched(r *http.Request, path string) {
+	s.logger.Warn("mock request unmatched", "method", r.Method, "path", path, "query", r.URL.RawQuery)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.historySize <= 0 {
+		return
+	}
+	s.unmatched = append(s.unmatched, Unmatched{
+		Time:   time.Now(),
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.RawQuery,
+	})
+	if over := len(s.unmatched) - s.historySize; over > 0 {
+		s.unmatched = append(s.unmatched[:0], s.unmatched[over:]...)
+	}
+}
+
+// render resolves the templates of body. The request is available as
+// request.method, request.path, request.params, request.query,
+// request.headers and request.body.
+func render(r *http.Request, path string, params map[string]string, body string) (string, error) {
+	reqBody, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize))
+	if err != nil {
+		return "", fmt.Errorf("read request body: %w", err)
+	}
+
+	pathParams := make(map[string]any, len(params))
+	for k, v := range params {
+		pathParams[k] = v
+	}
+	env := expression.NewUnifiedEnv(map[string]any{
+		"request": map[string]any{
+			"method":  r.Method,
+			"path":    path,
+			"params":  pathParams,
+			"query":   valuesToMap(r.URL.Query()),
+			"headers": valuesToMap(r.Header),
+			"body":    decodeBody(reqBody),
+		},
+	})
+	return env.InterpolateCtx(r.Context(), body)
+}
+
+// valuesToMap flattens single-valued keys and keeps lists for repeated ones.
+func valuesToMap(values map[string][]string) map[string]any {
+	out := make(map[string]any, len(values))
+	for k, v := range values {
+		if len(v) == 1 {
+			out[k] = v[0]
+			continue
+		}
+		list := make([]any, len(v))
+		for i, s := range v {
+			list[i] = s
+		}
+		out[k] = list
+	}
+	return out
+}
+
+// decodeBody returns the body as JSON when it parses as JSON and as a string
+// otherwise.
+func decodeBody(body []byte) any {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(trimmed, &v); err == nil {
+		return v
+	}
+	return string(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,268 @@
+package mock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+)
+
+func serve(t *testing.T, s *Server, method, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_MatchesMostSpecificRoute(t *testing.T) {
+	s := New([]Route{
+		{Name: "any user", Method: "GET", Path: "/users/{{ userId }}", Response: Response{Body: "any"}},
+		{Name: "me", Method: "GET", Path: "/users/me", Response: Response{Body: "me"}},
+		{Name: "admins", Method: "GET", Path: "/users/:id", Query: map[string]string{"role": "admin"}, Response: Response{Body: "admin"}},
+		{Name: "json", Path: "/users/{id}", Headers: map[string]string{"Accept": "application/json"}, Response: Response{Status: 201, Body: "json"}},
+	})
+
+	cases := []struct {
+		target string
+		header http.Header
+		want   string
+	}{
+		{"/users/42", nil, "any"},
+		{"/users/me", nil, "me"},
+		{"/users/42?role=admin", nil, "admin"},
+		{"/users/42?role=guest", nil, "any"},
+	}
+	for _, tc := range cases {
+		rec := serve(t, s, http.MethodGet, tc.target, tc.header)
+		if got := rec.Body.String(); got != tc.want {
+			t.Errorf("GET %s: expected %q, got %q", tc.target, tc.want, got)
+		}
+	}
+
+	rec := serve(t, s, http.MethodPost, "/users/42", http.Header{"Accept": {"application/json"}})
+	if rec.Code != 201 || rec.Body.String() != "json" {
+		t.Errorf("expected header-matched route, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestServer_TemplatedBody(t *testing.T) {
+	s := New([]Route{{
+		Method: "POST",
+		Path:   "/users/{id}",
+		Response: Response{
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body:    `{"id": "{{ request.params.id }}", "name": "{{ request.body.name }}", "page": "{{ request.query.page }}", "email": "{{ faker.email() }}"}`,
+		},
+	}})
+
+	req := httptest.NewRequest(http.MethodPost, "/users/7?page=2", strings.NewReader(`{"name": "Ada"}`))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	var got map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("body is not JSON: %v: %s", err, rec.Body.String())
+	}
+	if got["id"] != "7" || got["name"] != "Ada" || got["page"] != "2" {
+		t.Errorf("request values not rendered: %v", got)
+	}
+	if !strings.Contains(got["email"], "@") {
+		t.Errorf("expected a fake email, got %q", got["email"])
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected stored content type, got %q", ct)
+	}
+}
+
+func TestServer_BrokenTemplateServedVerbatim(t *testing.T) {
+	body := "<div>{{ missing( }}</div>"
+	s := New([]Route{{Path: "/page", Response: Response{Body: body}}})
+
+	rec := serve(t, s, http.MethodGet, "/page", nil)
+	if rec.Body.String() != body {
+		t.Errorf("expected verbatim body, got %q", rec.Body.String())
+	}
+}
+
+func TestServer_Unmatched(t *testing.T) {
+	s := New([]Route{{Method: "GET", Path: "/users"}}, WithUnmatchedHistory(2))
+
+	for _, target := range []string{"/a", "/b?x=1", "/c"} {
+		if rec := serve(t, s, http.MethodGet, target, nil); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s: expected 404, got %d", target, rec.Code)
+		}
+	}
+	if rec := serve(t, s, http.MethodDelete, "/users", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another method, got %d", rec.Code)
+	}
+
+	unmatched := s.Unmatched()
+	if len(unmatched) != 2 {
+		t.Fatalf("expected history capped at 2, got %d", len(unmatched))
+	}
+	if unmatched[0].Path != "/c" || unmatched[1].Method != "DELETE" {
+		t.Errorf("unexpected history: %+v", unmatched)
+	}
+}
+
+func TestServer_Latency(t *testing.T) {
+	s := New([]Route{{Path: "/slow"}}, WithLatency(50*time.Millisecond, 0))
+
+	start := time.Now()
+	serve(t, s, http.MethodGet, "/slow", nil)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected at least 50ms latency, got %s", elapsed)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	s := New([]Route{{Path: "/flaky", Response: Response{Body: "ok"}}}, WithFaults(1, http.StatusServiceUnavailable))
+	if rec := serve(t, s, http.MethodGet, "/flaky", nil); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected injected 503, got %d", rec.Code)
+	}
+
+	dropped := httptest.NewServer(New([]Route{{Path: "/flaky"}}, WithFaults(1, 0)))
+	defer dropped.Close()
+	resp, err := http.Get(dropped.URL + "/flaky") //nolint:noctx // test server
+	if err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected the connection to be dropped")
+	}
+
+	healthy := New([]Route{{Path: "/flaky", Response: Response{Body: "ok"}}}, WithFaults(0, http.StatusServiceUnavailable))
+	if rec := serve(t, healthy, http.MethodGet, "/flaky", nil); rec.Code != http.StatusOK {
+		t.Errorf("expected no fault at rate 0, got %d", rec.Code)
+	}
+}
+
+func TestURLPath(t *testing.T) {
+	cases := map[string]string{
+		"https://api.example.com/v1/users?limit=10": "/v1/users",
+		"http://localhost:8080":                     "/",
+		"{{ baseUrl }}/users/{{ userId }}":          "/users/{{ userId }}",
+		"{{baseUrl}}/items#top":                     "/items",
+		"users/1":                                   "/users/1",
+	}
+	for in, want := range cases {
+		if got := URLPath(in); got != want {
+			t.Errorf("URLPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWorkspaceRoutes(t *testing.T) {
+	withResponse, withoutResponse := idwrap.NewNow(), idwrap.NewNow()
+	oldResp, newResp := idwrap.NewNow(), idwrap.NewNow()
+
+	routes := WorkspaceRoutes(WorkspaceData{
+		Requests: []mhttp.HTTP{
+			{ID: withResponse, Name: "Get user", Method: "get", Url: "{{ baseUrl }}/users/{{ id }}?expand=true"},
+			{ID: withoutResponse, Name: "Never run", Method: "GET", Url: "https://example.com/never"},
+		},
+		SearchParams: []mhttp.HTTPSearchParam{
+			{HttpID: withResponse, Key: "expand", Value: "true", Enabled: true},
+			{HttpID: withResponse, Key: "token", Value: "{{ token }}", Enabled: true},
+			{HttpID: withResponse, Key: "debug", Value: "1", Enabled: false},
+		},
+		Headers: []mhttp.HTTPHeader{
+			{HttpID: withResponse, Key: "accept", Value: "application/json", Enabled: true},
+			{HttpID: withResponse, Key: "Authorization", Value: "Bearer abc", Enabled: true},
+		},
+		Responses: []mhttp.HTTPResponse{
+			{ID: oldResp, HttpID: withResponse, Status: 500, Body: []byte("old"), Time: 1},
+			{ID: newResp, HttpID: withResponse, Status: 200, Body: []byte(`{"id": 1}`), Time: 2},
+		},
+		ResponseHeaders: []mhttp.HTTPResponseHeader{
+			{ResponseID: newResp, HeaderKey: "content-type", HeaderValue: "application/json"},
+			{ResponseID: newResp, HeaderKey: "Content-Length", HeaderValue: "9"},
+			{ResponseID: oldResp, HeaderKey: "X-Old", HeaderValue: "1"},
+		},
+	})
+
+	if len(routes) != 1 {
+		t.Fatalf("expected only the request with a response, got %d routes", len(routes))
+	}
+	r := routes[0]
+	if r.Method != "GET" || r.Path != "/users/{{ id }}" {
+		t.Errorf("unexpected route: %s %s", r.Method, r.Path)
+	}
+	if len(r.Query) != 1 || r.Query["expand"] != "true" {
+		t.Errorf("expected only the literal enabled query param, got %v", r.Query)
+	}
+	if len(r.Headers) != 1 || r.Headers["Accept"] != "application/json" {
+		t.Errorf("expected only the Accept header, got %v", r.Headers)
+	}
+	if r.Response.Status != 200 || r.Response.Body != `{"id": 1}` {
+		t.Errorf("expected the latest response, got %+v", r.Response)
+	}
+	if len(r.Response.Headers) != 1 || r.Response.Headers["Content-Type"] != "application/json" {
+		t.Errorf("unexpected response headers: %v", r.Response.Headers)
+	}
+
+	rec := serve(t, New(routes), http.MethodGet, "/users/5?expand=true", http.Header{"Accept": {"application/json"}})
+	if rec.Code != 200 || rec.Body.String() != `{"id": 1}` {
+		t.Errorf("unexpected mock response: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	spec := `
+swagger: "2.0"
+info:
+  title: Users
+  version: "1"
+host: api.example.com
+basePath: /api
+paths:
+  /users/{id}:
+    get:
+      responses:
+        "200":
+          description: OK
+          schema:
+            type: object
+            properties:
+              name:
+                type: string
+              email:
+                type: string
+                format: email
+`
+	routes, err := OpenAPIRoutes([]byte(spec))
+	if err != nil {
+		t.Fatalf("OpenAPIRoutes: %v", err)
+	}
+	if len(routes) != 1 || routes[0].Path != "/api/users/{id}" {
+		t.Fatalf("unexpected routes: %+v", routes)
+	}
+
+	srv := httptest.NewServer(New(routes))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/api/users/3") //nolint:noctx // test server
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	var got map[string]string
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("body is not JSON: %v: %s", err, body)
+	}
+	if !strings.Contains(got["email"], "@") || got["name"] == "" {
+		t.Errorf("expected faker values, got %v", got)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/topenapiv2"
+)
+
+// OpenAPIRoutes builds one route per operation of an OpenAPI/Swagger spec.
+// Bodies are generated from the response schemas, using the documented
+// examples and faker values for the rest, so every request gets fresh data.
+func OpenAPIRoutes(data []byte) ([]Route, error) {
+	responses, err := topenapiv2.MockResponses(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI responses: %w", err)
+	}
+
+	routes := make([]Route, 0, len(responses))
+	for _, resp := range responses {
+		route := Route{
+			Name:     strings.ToUpper(resp.Method) + " " + resp.Path,
+			Method:   strings.ToUpper(resp.Method),
+			Path:     resp.Path,
+			Response: Response{Status: resp.Status, Body: resp.Body},
+		}
+		if resp.ContentType != "" {
+			route.Response.Headers = map[string]string{"Content-Type": resp.ContentType}
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
@@ -0,0 +1,175 @@
+package mock
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Route is one stored example: the request it answers and the response it
+// serves.
+type Route struct {
+	// Name identifies the route in logs, e.g. the request it was built from.
+	Name string
+	// Method matches the request method; empty matches any method.
+	Method string
+	// Path is a path template. Segments written as {name}, :name or
+	// {{ expression }} match any value and are captured as path parameters.
+	Path string
+	// Query and Headers must all be present on the request with these values.
+	Query   map[string]string
+	Headers map[string]string
+
+	Response Response
+}
+
+// Response is what a route serves. Body may contain {{ }} templates, which are
+// resolved against the request with the expression engine.
+type Response struct {
+	Status  int
+	Headers map[string]string
+	Body    string
+}
+
+// pathTemplate is a compiled Route.Path.
+type pathTemplate struct {
+	segments []string
+	// params holds the parameter name of each segment, "" for literal ones.
+	params   []string
+	literals int
+}
+
+// compilePath splits a path template into segments.
+func compilePath(path string) pathTemplate {
+	segments := splitPath(path)
+	t := pathTemplate{segments: segments, params: make([]string, len(segments))}
+	for i, seg := range segments {
+		if name, ok := paramName(seg); ok {
+			t.params[i] = name
+			continue
+		}
+		t.literals++
+	}
+	return t
+}
+
+// match reports whether an escaped request path fits the template and returns
+// the captured parameters.
+func (t pathTemplate) match(path string) (map[string]string, bool) {
+	segments := splitPath(path)
+	if len(segments) != len(t.segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, seg := range segments {
+		if value, err := url.PathUnescape(seg); err == nil {
+			seg = value
+		}
+		if name := t.params[i]; name != "" {
+			params[name] = seg
+			continue
+		}
+		if seg != t.segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// paramName returns the parameter name of a template segment.
+func paramName(seg string) (string, bool) {
+	switch {
+	case strings.Contains(seg, "{{"):
+		name := strings.TrimSpace(strings.Trim(seg, "{} "))
+		if name == "" {
+			name = seg
+		}
+		return name, true
+	case len(seg) > 2 && strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+		return seg[1 : len(seg)-1], true
+	case len(seg) > 1 && strings.HasPrefix(seg, ":"):
+		return seg[1:], true
+	}
+	return "", false
+}
+
+// URLPath extracts the path template from a stored request URL, dropping the
+// scheme and host or a leading {{ baseUrl }} template, and the query string.
+func URLPath(rawURL string) string {
+	rest := strings.TrimSpace(rawURL)
+	if i := strings.Index(rest, "://"); i >= 0 {
+		rest = rest[i+3:]
+		if j := strings.Index(rest, "/"); j >= 0 {
+			rest = rest[j:]
+		} else {
+			rest = "/"
+		}
+	} else if strings.HasPrefix(rest, "{{") {
+		if end := strings.Index(rest, "}}"); end >= 0 {
+			rest = rest[end+2:]
+		}
+	}
+	if i := strings.IndexAny(rest, "?#"); i >= 0 {
+		rest = rest[:i]
+	}
+	if !strings.HasPrefix(rest, "/") {
+		rest = "/" + rest
+	}
+	return rest
+}
+
+// compiledRoute is a Route ready for matching.
+type compiledRoute struct {
+	Route
+	path pathTemplate
+}
+
+// match reports whether r answers req and returns the path parameters.
+func (r compiledRoute) match(req *http.Request, path string) (map[string]string, bool) {
+	if r.Method != "" && !strings.EqualFold(r.Method, req.Method) {
+		return nil, false
+	}
+	params, ok := r.path.match(path)
+	if !ok {
+		return nil, false
+	}
+	query := req.URL.Query()
+	for key, value := range r.Query {
+		if !containsValue(query[key], value) {
+			return nil, false
+		}
+	}
+	for key, value := range r.Headers {
+		if !containsValue(req.Header.Values(key), value) {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// specificity ranks matching routes: literal path segments first, then a
+// fixed method, then the number of query and header constraints.
+func (r compiledRoute) specificity() int {
+	score := r.path.literals*1000 + len(r.Query) + len(r.Headers)
+	if r.Method != "" {
+		score += 500
+	}
+	return score
+}
+
+func containsValue(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
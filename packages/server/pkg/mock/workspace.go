@@ -0,0 +1,155 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/service/shttp"
+)
+
+// headerMatchIgnored lists request headers that vary between clients and are
+// never used to match a stored example.
+var headerMatchIgnored = map[string]bool{
+	"Accept-Encoding": true,
+	"Authorization":   true,
+	"Connection":      true,
+	"Content-Length":  true,
+	"Cookie":          true,
+	"Host":            true,
+	"User-Agent":      true,
+}
+
+// responseHeaderIgnored lists stored response headers that describe the
+// original transfer and must not be replayed.
+var responseHeaderIgnored = map[string]bool{
+	"Connection":        true,
+	"Content-Encoding":  true,
+	"Content-Length":    true,
+	"Date":              true,
+	"Keep-Alive":        true,
+	"Transfer-Encoding": true,
+}
+
+// WorkspaceData is the part of a workspace routes are built from.
+type WorkspaceData struct {
+	Requests        []mhttp.HTTP
+	SearchParams    []mhttp.HTTPSearchParam
+	Headers         []mhttp.HTTPHeader
+	Responses       []mhttp.HTTPResponse
+	ResponseHeaders []mhttp.HTTPResponseHeader
+}
+
+// LoadWorkspace reads the requests of a workspace and their saved responses.
+func LoadWorkspace(ctx context.Context, queries *gen.Queries, workspaceID idwrap.IDWrap) (WorkspaceData, error) {
+	var data WorkspaceData
+	var err error
+
+	if data.Requests, err = shttp.New(queries, slog.New(slog.DiscardHandler)).GetByWorkspaceID(ctx, workspaceID); err != nil {
+		return data, fmt.Errorf("failed to load requests: %w", err)
+	}
+	responses := shttp.NewHttpResponseService(queries)
+	if data.Responses, err = responses.GetByWorkspaceID(ctx, workspaceID); err != nil {
+		return data, fmt.Errorf("failed to load responses: %w", err)
+	}
+	if data.ResponseHeaders, err = responses.GetHeadersByWorkspaceID(ctx, workspaceID); err != nil {
+		return data, fmt.Errorf("failed to load response headers: %w", err)
+	}
+
+	params := shttp.NewHttpSearchParamService(queries)
+	headers := shttp.NewHttpHeaderService(queries)
+	for _, req := range data.Requests {
+		reqParams, err := params.GetByHttpID(ctx, req.ID)
+		if err != nil {
+			return data, fmt.Errorf("failed to load query params of %s: %w", req.Name, err)
+		}
+		data.SearchParams = append(data.SearchParams, reqParams...)
+
+		reqHeaders, err := headers.GetByHttpID(ctx, req.ID)
+		if err != nil {
+			return data, fmt.Errorf("failed to load headers of %s: %w", req.Name, err)
+		}
+		data.Headers = append(data.Headers, reqHeaders...)
+	}
+	return data, nil
+}
+
+// WorkspaceRoutes builds one route per request with a saved response, serving
+// its latest response. Enabled query params and headers without templates
+// become match constraints; templated path segments match any value.
+func WorkspaceRoutes(data WorkspaceData) []Route {
+	latest := make(map[idwrap.IDWrap]mhttp.HTTPResponse)
+	for _, resp := range data.Responses {
+		prev, ok := latest[resp.HttpID]
+		if !ok || resp.Time > prev.Time || (resp.Time == prev.Time && resp.CreatedAt > prev.CreatedAt) {
+			latest[resp.HttpID] = resp
+		}
+	}
+
+	responseHeaders := make(map[idwrap.IDWrap]map[string]string)
+	for _, h := range data.ResponseHeaders {
+		key := http.CanonicalHeaderKey(h.HeaderKey)
+		if key == "" || responseHeaderIgnored[key] {
+			continue
+		}
+		if responseHeaders[h.ResponseID] == nil {
+			responseHeaders[h.ResponseID] = make(map[string]string)
+		}
+		responseHeaders[h.ResponseID][key] = h.HeaderValue
+	}
+
+	query := make(map[idwrap.IDWrap]map[string]string)
+	for _, p := range data.SearchParams {
+		if p.IsDelta || !p.Enabled || p.Key == "" || !isLiteral(p.Key) || !isLiteral(p.Value) {
+			continue
+		}
+		if query[p.HttpID] == nil {
+			query[p.HttpID] = make(map[string]string)
+		}
+		query[p.HttpID][p.Key] = p.Value
+	}
+
+	headers := make(map[idwrap.IDWrap]map[string]string)
+	for _, h := range data.Headers {
+		key := http.CanonicalHeaderKey(h.Key)
+		if h.IsDelta || !h.Enabled || key == "" || headerMatchIgnored[key] || !isLiteral(key) || !isLiteral(h.Value) {
+			continue
+		}
+		if headers[h.HttpID] == nil {
+			headers[h.HttpID] = make(map[string]string)
+		}
+		headers[h.HttpID][key] = h.Value
+	}
+
+	var routes []Route
+	for _, req := range data.Requests {
+		resp, ok := latest[req.ID]
+		if !ok || req.IsDelta || req.IsSnapshot {
+			continue
+		}
+		routes = append(routes, Route{
+			Name:    req.Name,
+			Method:  strings.ToUpper(req.Method),
+			Path:    URLPath(req.Url),
+			Query:   query[req.ID],
+			Headers: headers[req.ID],
+			Response: Response{
+				Status:  int(resp.Status),
+				Headers: responseHeaders[resp.ID],
+				Body:    string(resp.Body),
+			},
+		})
+	}
+	return routes
+}
+
+// isLiteral reports whether a stored value has no templates and can be
+// matched as is.
+func isLiteral(value string) bool {
+	return !strings.Contains(value, "{{")
+}
@@ -23,10 +23,35 @@ const (
 	HandleAiProvider
 	HandleAiMemory
 	HandleAiTools
+	// HandleWsMessage is followed for every message a WebSocket or socket
+	// connection node receives and every event of a GraphQL subscription node.
 	HandleWsMessage
+	// HandleError is followed when the source node fails. On a try node it
+	// is the catch branch of the whole try body.
+	HandleError
+	// HandleDefault is followed by a switch node when none of its cases match.
+	HandleDefault
 	HandleLength
 )
 
+// HandleCaseBase is the handle of a switch node's first case; case i uses
+// HandleCaseBase + i. Case handles sit far above HandleLength so new fixed
+// handles can be added without renumbering stored case edges.
+const HandleCaseBase EdgeHandle = 1000
+
+// HandleCase returns the edge handle of the switch case at index.
+func HandleCase(index int) EdgeHandle {
+	return HandleCaseBase + EdgeHandle(index) //nolint:gosec // case counts are small
+}
+
+// CaseIndex reports which switch case a handle belongs to.
+func CaseIndex(handle EdgeHandle) (int, bool) {
+	if handle < HandleCaseBase {
+		return 0, false
+	}
+	return int(handle - HandleCaseBase), true
+}
+
 var ErrEdgeNotFound = errors.New("edge not found")
 
 type Edge struct {
@@ -108,9 +133,8 @@ func IsNodeCheckTarget(edgesMap EdgesMap, sourceNode, targetNode idwrap.IDWrap)
 			return NodeBefore
 		}
 
-		// Check all possible edges from current node
-		for handle := HandleUnspecified; handle < HandleLength; handle++ {
-			nextNodes := GetNextNodeID(edgesMap, current, handle)
+		// Check all edges from current node, switch case handles included
+		for _, nextNodes := range edgesMap[current] {
 			for _, next := range nextNodes {
 				if !visited[next] {
 					visited[next] = true
@@ -132,8 +156,7 @@ func IsNodeCheckTarget(edgesMap EdgesMap, sourceNode, targetNode idwrap.IDWrap)
 			return NodeAfter
 		}
 
-		for handle := HandleUnspecified; handle < HandleLength; handle++ {
-			nextNodes := GetNextNodeID(edgesMap, current, handle)
+		for _, nextNodes := range edgesMap[current] {
 			for _, next := range nextNodes {
 				if !visited[next] {
 					visited[next] = true
@@ -0,0 +1,32 @@
+package mflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+)
+
+func TestCaseIndex(t *testing.T) {
+	index, ok := CaseIndex(HandleCase(3))
+	assert.True(t, ok)
+	assert.Equal(t, 3, index)
+
+	for handle := HandleUnspecified; handle < HandleLength; handle++ {
+		_, ok := CaseIndex(handle)
+		assert.False(t, ok, "handle %d", handle)
+	}
+}
+
+func TestIsNodeCheckTargetFollowsCaseHandles(t *testing.T) {
+	switchID, caseTarget, defaultTarget := idwrap.NewNow(), idwrap.NewNow(), idwrap.NewNow()
+	edgesMap := NewEdgesMap([]Edge{
+		NewEdge(idwrap.NewNow(), switchID, caseTarget, HandleCase(1)),
+		NewEdge(idwrap.NewNow(), switchID, defaultTarget, HandleDefault),
+	})
+
+	assert.Equal(t, NodeBefore, IsNodeCheckTarget(edgesMap, switchID, caseTarget))
+	assert.Equal(t, NodeAfter, IsNodeCheckTarget(edgesMap, defaultTarget, switchID))
+	assert.Equal(t, NodeUnrelated, IsNodeCheckTarget(edgesMap, caseTarget, defaultTarget))
+}
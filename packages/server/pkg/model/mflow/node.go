@@ -26,6 +26,20 @@ const (
 	NODE_KIND_SUB_FLOW_TRIGGER NodeKind = 15
 	NODE_KIND_SUB_FLOW_RETURN  NodeKind = 16
 	NODE_KIND_RUN_SUB_FLOW     NodeKind = 17
+	NODE_KIND_TRY              NodeKind = 18
+	NODE_KIND_PARALLEL         NodeKind = 19
+	NODE_KIND_POLL             NodeKind = 20
+	NODE_KIND_SWITCH           NodeKind = 21
+	NODE_KIND_TEARDOWN         NodeKind = 22
+	NODE_KIND_GRAPHQL_SUBSCRIPTION NodeKind = 23
+	NODE_KIND_WS_EXPECT            NodeKind = 24
+	NODE_KIND_SOCKET_CONNECTION    NodeKind = 25
+	NODE_KIND_SOCKET_SEND          NodeKind = 26
+	NODE_KIND_SOCKET_RECEIVE       NodeKind = 27
+	NODE_KIND_DB_QUERY             NodeKind = 28
+	NODE_KIND_MQ_PUBLISH           NodeKind = 29
+	NODE_KIND_MQ_CONSUME           NodeKind = 30
+	NODE_KIND_EXEC                 NodeKind = 31
 )
 
 type NodeState = int8
@@ -117,3 +117,15 @@ func TestStringNodeStateWithIcons(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeWebhookPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"":               "/",
+		"/":              "/",
+		"hooks/push":     "/hooks/push",
+		" /hooks/push/ ": "/hooks/push",
+		"//hooks//":      "/hooks",
+	} {
+		assert.Equal(t, want, NormalizeWebhookPath(in), in)
+	}
+}
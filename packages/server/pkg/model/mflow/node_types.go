@@ -2,6 +2,8 @@
 package mflow
 
 import (
+	"fmt"
+
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/compress"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mcondition"
@@ -61,6 +63,9 @@ type NodeForEach struct {
 	IterExpression string
 	Condition      mcondition.Condition
 	ErrorHandling  ErrorHandling
+	// Concurrency is how many iterations may run at once. Zero or one runs
+	// them one after another.
+	Concurrency int32
 }
 
 // --- AI Node ---
@@ -261,6 +266,20 @@ type NodeGraphQL struct {
 	DeltaGraphQLID *idwrap.IDWrap
 }
 
+// NodeGraphQLSubscription is an entry node that runs a GraphQL subscription
+// over WebSocket and follows HandleWsMessage once for every event. The flow
+// continues once the server completes the subscription or a limit is hit.
+type NodeGraphQLSubscription struct {
+	FlowNodeID     idwrap.IDWrap
+	GraphQLID      *idwrap.IDWrap
+	DeltaGraphQLID *idwrap.IDWrap
+	// ConnectionPayload is a JSON object template sent with connection_init,
+	// commonly carrying auth. Empty sends the request's enabled headers.
+	ConnectionPayload string
+	MaxEvents         int32 // 0 means no limit
+	TimeoutMs         int64 // 0 means no limit
+}
+
 // --- WebSocket Nodes ---
 
 type NodeWsConnection struct {
@@ -272,6 +291,157 @@ type NodeWsSend struct {
 	FlowNodeID           idwrap.IDWrap
 	WsConnectionNodeName string
 	Message              string
+	// Binary sends Message as a binary frame; the message is base64 encoded.
+	Binary bool
+	// Event emits Message through the connection's protocol: the Socket.IO
+	// event name or STOMP destination. Empty sends a raw frame.
+	Event string
+	// Ack asks the server to acknowledge an emitted event.
+	Ack bool
+}
+
+// NodeWsExpect waits on a connection opened by a WS Connection node for
+// frames matching an expression, optionally sending a message first, and
+// asserts on what it received.
+type NodeWsExpect struct {
+	FlowNodeID           idwrap.IDWrap
+	WsConnectionNodeName string
+	// Message is sent before waiting when not empty.
+	Message string
+	Binary  bool
+	// Match selects the frames to wait for; empty matches any frame.
+	Match       string
+	TimeoutMs   int64    // 0 means 5 seconds
+	Count       int32    // 0 means 1
+	Assertions  []string // Stored as JSON blob in DB
+	Ping        bool     // measure a ping/pong round trip
+	ExpectClose bool     // wait for the server to close the connection
+}
+
+// --- Socket Nodes ---
+
+// SocketNetwork is the transport a socket connection uses.
+type SocketNetwork int8
+
+const (
+	SocketNetworkTCP SocketNetwork = 0
+	SocketNetworkUDP SocketNetwork = 1
+)
+
+// SocketFraming splits a TCP stream into messages. UDP datagrams are always
+// messages of their own.
+type SocketFraming int8
+
+const (
+	// SocketFramingNone makes every read its own message.
+	SocketFramingNone SocketFraming = 0
+	// SocketFramingDelimiter ends each message with a delimiter.
+	SocketFramingDelimiter SocketFraming = 1
+	// SocketFramingLength prefixes each message with its big-endian length.
+	SocketFramingLength SocketFraming = 2
+)
+
+// SocketEncoding is how a message template is turned into bytes.
+type SocketEncoding int8
+
+const (
+	SocketEncodingText   SocketEncoding = 0
+	SocketEncodingHex    SocketEncoding = 1
+	SocketEncodingBase64 SocketEncoding = 2
+)
+
+// NodeSocketConnection is an entry node that opens a TCP or UDP socket and
+// follows HandleWsMessage once for every message received on it.
+type NodeSocketConnection struct {
+	FlowNodeID idwrap.IDWrap
+	Network    SocketNetwork
+	Address    string // host:port
+	TLS        bool   // TCP only
+	// TLSSkipVerify accepts any server certificate.
+	TLSSkipVerify bool
+	Framing       SocketFraming
+	// Delimiter ends messages with delimiter framing. Escapes such as \n
+	// and \x00 are decoded; empty means a newline.
+	Delimiter   string
+	LengthBytes int32 // size of the length prefix: 1, 2 or 4; 0 means 4
+	TimeoutMs   int64 // connect timeout; 0 means 10 seconds
+}
+
+// NodeSocketSend writes a message on a connection opened by a Socket
+// Connection node, framed as the connection frames messages.
+type NodeSocketSend struct {
+	FlowNodeID               idwrap.IDWrap
+	SocketConnectionNodeName string
+	Message                  string
+	Encoding                 SocketEncoding
+}
+
+// NodeSocketReceive waits on a connection opened by a Socket Connection node
+// for messages matching an expression and asserts on what it received.
+type NodeSocketReceive struct {
+	FlowNodeID               idwrap.IDWrap
+	SocketConnectionNodeName string
+	// Match selects the messages to wait for; empty matches any message.
+	Match      string
+	TimeoutMs  int64    // 0 means 5 seconds
+	Count      int32    // 0 means 1
+	Assertions []string // Stored as JSON blob in DB
+}
+
+// --- Database Query Node ---
+
+// NodeDbQuery runs a SQL statement against the database named by a database
+// credential and exposes the resulting rows for assertions and for_each.
+type NodeDbQuery struct {
+	FlowNodeID   idwrap.IDWrap
+	CredentialID *idwrap.IDWrap
+	Query        string
+	// Params are expressions bound to the query's placeholders in order.
+	Params     []string // Stored as JSON blob in DB
+	TimeoutMs  int64    // 0 means 30 seconds
+	Assertions []string // Stored as JSON blob in DB
+}
+
+// --- Message Queue Nodes ---
+
+// NodeMqPublish publishes a message to a topic on the broker named by a
+// message broker credential.
+type NodeMqPublish struct {
+	FlowNodeID   idwrap.IDWrap
+	CredentialID *idwrap.IDWrap
+	Topic        string
+	Key          string
+	Message      string
+}
+
+// NodeMqConsume is a listener entry node that subscribes to a topic and runs
+// its message chain for each message matching Filter, until Count messages
+// have matched or the timeout passes.
+type NodeMqConsume struct {
+	FlowNodeID   idwrap.IDWrap
+	CredentialID *idwrap.IDWrap
+	Topic        string
+	// Group consumes as a member of a consumer or queue group.
+	Group string
+	// Filter selects the messages to handle; empty matches any message.
+	Filter     string
+	TimeoutMs  int64    // 0 means 30 seconds
+	Count      int32    // 0 means 1
+	Assertions []string // Stored as JSON blob in DB
+}
+
+// --- Exec Node ---
+
+// NodeExec runs a local command. Command, Args and WorkDir are interpolated;
+// the command is started directly, not through a shell.
+type NodeExec struct {
+	FlowNodeID idwrap.IDWrap
+	Command    string
+	Args       []string // Stored as JSON blob in DB
+	WorkDir    string   // Empty runs in the current working directory
+	TimeoutMs  int64    // 0 means 30 seconds
+	// ParseJSON parses stdout as JSON into the node's "json" output.
+	ParseJSON bool
 }
 
 // --- Wait Node ---
@@ -280,3 +450,63 @@ type NodeWait struct {
 	FlowNodeID idwrap.IDWrap
 	DurationMs int64
 }
+
+// --- Parallel Node ---
+
+// ParallelJoin decides when a parallel node is done with its branches.
+type ParallelJoin int8
+
+const (
+	// ParallelJoinAll waits for every branch and fails on the first failure.
+	ParallelJoinAll ParallelJoin = 0
+	// ParallelJoinAny finishes with the first branch to finish, successful or not.
+	ParallelJoinAny ParallelJoin = 1
+	// ParallelJoinFirstSuccess finishes with the first successful branch and
+	// fails only when every branch fails.
+	ParallelJoinFirstSuccess ParallelJoin = 2
+)
+
+type NodeParallel struct {
+	FlowNodeID idwrap.IDWrap
+	JoinMode   ParallelJoin
+	// Concurrency caps the branches in flight; zero runs them all at once.
+	Concurrency int32
+}
+
+// --- Poll Node ---
+
+type NodePoll struct {
+	FlowNodeID idwrap.IDWrap
+	// Until is the expression that ends polling once it holds.
+	Until      string
+	IntervalMs int64
+	// Backoff multiplies the interval after every attempt; values up to 1
+	// keep it constant.
+	Backoff       float64
+	MaxDurationMs int64
+	MaxAttempts   int32
+}
+
+// --- Switch Node ---
+
+// SwitchCase is one branch of a switch node. The node follows the edge on
+// the case's handle, HandleCase(index), when Expression holds.
+type SwitchCase struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
+
+// Label names the case in outputs and YAML dependencies, falling back to
+// its position when the case has no name.
+func (c SwitchCase) Label(index int) string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return fmt.Sprintf("case_%d", index)
+}
+
+type NodeSwitch struct {
+	FlowNodeID idwrap.IDWrap
+	// Cases are evaluated in order; the first one that holds wins.
+	Cases []SwitchCase // Stored as JSON blob in DB
+}
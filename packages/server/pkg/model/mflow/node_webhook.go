@@ -0,0 +1,45 @@
+//nolint:revive // exported
+package mflow
+
+import (
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+)
+
+// DefaultWebhookSignatureHeader carries the request signature when a webhook
+// trigger names no header.
+const DefaultWebhookSignatureHeader = "X-Signature-256"
+
+// NodeWebhookTrigger is an entry node that starts its flow when an HTTP
+// request arrives on Path.
+type NodeWebhookTrigger struct {
+	FlowNodeID idwrap.IDWrap
+	Path       string // Normalized by NormalizeWebhookPath
+	Method     string // Empty accepts any method
+	// Secret is the HMAC-SHA256 key requests must be signed with. Empty
+	// disables signature verification.
+	Secret          string
+	SignatureHeader string // Empty means DefaultWebhookSignatureHeader
+}
+
+// SignatureHeaderName returns the header the request signature is read from.
+func (n NodeWebhookTrigger) SignatureHeaderName() string {
+	if n.SignatureHeader == "" {
+		return DefaultWebhookSignatureHeader
+	}
+	return n.SignatureHeader
+}
+
+// FlowWebhookTrigger is a webhook trigger together with the flow it starts.
+type FlowWebhookTrigger struct {
+	FlowID idwrap.IDWrap
+	NodeWebhookTrigger
+}
+
+// NormalizeWebhookPath gives a webhook path a single leading slash and no
+// trailing one, so "hooks/push/" and "/hooks/push" name the same webhook.
+func NormalizeWebhookPath(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	return "/" + path
+}
@@ -0,0 +1,34 @@
+//nolint:revive // exported
+package mflow
+
+import "github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+
+// FlowSchedule runs a flow on the server, either on a cron expression or at a
+// fixed interval.
+type FlowSchedule struct {
+	ID     idwrap.IDWrap `json:"id"`
+	FlowID idwrap.IDWrap `json:"flow_id"`
+	// CronExpression is a five-field cron expression. It takes precedence over
+	// IntervalSeconds when both are set.
+	CronExpression  string `json:"cron_expression"`
+	IntervalSeconds int64  `json:"interval_seconds"`
+	// EnvironmentID selects the environment the flow runs with. Nil uses the
+	// workspace's active environment.
+	EnvironmentID *idwrap.IDWrap `json:"environment_id,omitempty"`
+	Enabled       bool           `json:"enabled"`
+	// LastRunAt is when the last scheduled run started, in Unix milliseconds.
+	LastRunAt *int64    `json:"last_run_at,omitempty"`
+	LastState NodeState `json:"last_state"`
+}
+
+// FlowScheduleRun is one run of a FlowSchedule.
+type FlowScheduleRun struct {
+	ID            idwrap.IDWrap  `json:"id"`
+	ScheduleID    idwrap.IDWrap  `json:"schedule_id"`
+	FlowVersionID *idwrap.IDWrap `json:"flow_version_id,omitempty"`
+	State         NodeState      `json:"state"`
+	Error         *string        `json:"error,omitempty"`
+	// StartedAt is in Unix milliseconds.
+	StartedAt  int64 `json:"started_at"`
+	DurationMs int64 `json:"duration_ms"`
+}
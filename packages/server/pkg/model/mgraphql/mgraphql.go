@@ -5,25 +5,46 @@ import (
 )
 
 type GraphQL struct {
-	ID               idwrap.IDWrap  `json:"id"`
-	WorkspaceID      idwrap.IDWrap  `json:"workspace_id"`
-	FolderID         *idwrap.IDWrap `json:"folder_id,omitempty"`
-	Name             string         `json:"name"`
-	Url              string         `json:"url"`
-	Query            string         `json:"query"`
-	Variables        string         `json:"variables"`
-	Description      string         `json:"description"`
-	ParentGraphQLID  *idwrap.IDWrap `json:"parent_graphql_id,omitempty"`
-	IsDelta          bool           `json:"is_delta"`
-	IsSnapshot       bool           `json:"is_snapshot"`
-	DeltaName        *string        `json:"delta_name,omitempty"`
-	DeltaUrl         *string        `json:"delta_url,omitempty"`
-	DeltaQuery       *string        `json:"delta_query,omitempty"`
-	DeltaVariables   *string        `json:"delta_variables,omitempty"`
-	DeltaDescription *string        `json:"delta_description,omitempty"`
-	LastRunAt        *int64         `json:"last_run_at,omitempty"`
-	CreatedAt        int64          `json:"created_at"`
-	UpdatedAt        int64          `json:"updated_at"`
+	ID               idwrap.IDWrap      `json:"id"`
+	WorkspaceID      idwrap.IDWrap      `json:"workspace_id"`
+	FolderID         *idwrap.IDWrap     `json:"folder_id,omitempty"`
+	Name             string             `json:"name"`
+	Url              string             `json:"url"`
+	Query            string             `json:"query"`
+	Variables        string             `json:"variables"`
+	OperationName    string             `json:"operation_name,omitempty"`
+	QueryFile        string             `json:"query_file,omitempty"`
+	PersistedQuery   bool               `json:"persisted_query,omitempty"`
+	Batch            []GraphQLOperation `json:"batch,omitempty"`
+	Uploads          []GraphQLUpload    `json:"uploads,omitempty"`
+	Description      string             `json:"description"`
+	ParentGraphQLID  *idwrap.IDWrap     `json:"parent_graphql_id,omitempty"`
+	IsDelta          bool               `json:"is_delta"`
+	IsSnapshot       bool               `json:"is_snapshot"`
+	DeltaName        *string            `json:"delta_name,omitempty"`
+	DeltaUrl         *string            `json:"delta_url,omitempty"`
+	DeltaQuery       *string            `json:"delta_query,omitempty"`
+	DeltaVariables   *string            `json:"delta_variables,omitempty"`
+	DeltaDescription *string            `json:"delta_description,omitempty"`
+	LastRunAt        *int64             `json:"last_run_at,omitempty"`
+	CreatedAt        int64              `json:"created_at"`
+	UpdatedAt        int64              `json:"updated_at"`
+}
+
+// GraphQLOperation is an operation sent after the request's own one when
+// batching. Its query and variables are interpolated like the request's.
+type GraphQLOperation struct {
+	Query         string `json:"query"`
+	Variables     string `json:"variables,omitempty"`
+	OperationName string `json:"operation_name,omitempty"`
+}
+
+// GraphQLUpload sends a local file per the GraphQL multipart request spec.
+// Path is the file's location in the operations payload, e.g.
+// "variables.file", prefixed with the operation index when batching.
+type GraphQLUpload struct {
+	Path string `json:"path"`
+	File string `json:"file"`
 }
 
 type GraphQLHeader struct {
@@ -102,3 +123,11 @@ type GraphQLVersion struct {
 	CreatedAt          int64          `json:"created_at"`
 	CreatedBy          *idwrap.IDWrap `json:"created_by,omitempty"`
 }
+
+// GraphQLSchema is the introspection result last fetched for a request,
+// cached so queries can be validated without introspecting again.
+type GraphQLSchema struct {
+	GraphQLID     idwrap.IDWrap `json:"graphql_id"`
+	Introspection string        `json:"introspection"`
+	FetchedAt     int64         `json:"fetched_at"`
+}
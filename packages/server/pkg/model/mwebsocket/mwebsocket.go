@@ -4,16 +4,36 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
 )
 
+// Protocol is the message protocol spoken over a WebSocket connection.
+type Protocol int8
+
+const (
+	// ProtocolRaw exchanges frames as they are.
+	ProtocolRaw Protocol = 0
+	// ProtocolSocketIO speaks Socket.IO v4 over Engine.IO v4. The URL path
+	// is the namespace, as with the Socket.IO client.
+	ProtocolSocketIO Protocol = 1
+	// ProtocolStomp speaks STOMP 1.2, falling back to 1.1 and 1.0.
+	ProtocolStomp Protocol = 2
+)
+
 type WebSocket struct {
-	ID          idwrap.IDWrap  `json:"id"`
-	WorkspaceID idwrap.IDWrap  `json:"workspace_id"`
-	FolderID    *idwrap.IDWrap `json:"folder_id,omitempty"`
-	Name        string         `json:"name"`
-	Url         string         `json:"url"`
-	Description string         `json:"description"`
-	LastRunAt   *int64         `json:"last_run_at,omitempty"`
-	CreatedAt   int64          `json:"created_at"`
-	UpdatedAt   int64          `json:"updated_at"`
+	ID           idwrap.IDWrap  `json:"id"`
+	WorkspaceID  idwrap.IDWrap  `json:"workspace_id"`
+	FolderID     *idwrap.IDWrap `json:"folder_id,omitempty"`
+	Name         string         `json:"name"`
+	Url          string         `json:"url"`
+	Description  string         `json:"description"`
+	Subprotocols []string       `json:"subprotocols,omitempty"`
+	Protocol     Protocol       `json:"protocol"`
+	// ConnectPayload is a JSON object sent when connecting: the Socket.IO
+	// auth payload, or extra STOMP CONNECT headers such as login and passcode.
+	ConnectPayload string `json:"connect_payload,omitempty"`
+	// Subscriptions are the STOMP destinations subscribed to after connecting.
+	Subscriptions []string `json:"subscriptions,omitempty"`
+	LastRunAt     *int64   `json:"last_run_at,omitempty"`
+	CreatedAt     int64    `json:"created_at"`
+	UpdatedAt     int64    `json:"updated_at"`
 }
 
 type WebSocketHeader struct {
@@ -27,3 +47,23 @@ type WebSocketHeader struct {
 	CreatedAt    int64         `json:"created_at"`
 	UpdatedAt    int64         `json:"updated_at"`
 }
+
+// Message directions and types of WebSocketMessage.
+const (
+	MessageDirectionSent     = "sent"
+	MessageDirectionReceived = "received"
+
+	MessageTypeText   = "text"
+	MessageTypeBinary = "binary"
+)
+
+// WebSocketMessage is a frame sent or received on a connection to a
+// WebSocket, kept as its history. Binary frames hold base64 data.
+type WebSocketMessage struct {
+	ID          idwrap.IDWrap `json:"id"`
+	WebSocketID idwrap.IDWrap `json:"websocket_id"`
+	Direction   string        `json:"direction"`
+	Type        string        `json:"type"`
+	Data        string        `json:"data"`
+	CreatedAt   int64         `json:"created_at"`
+}
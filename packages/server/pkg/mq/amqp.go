@@ -0,0 +1,132 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// amqpExchange is the topic exchange messages are published to. Every AMQP
+// 0-9-1 broker declares it, so no setup is needed on the broker.
+const amqpExchange = "amq.topic"
+
+// amqpClient publishes to amqpExchange with the topic as routing key and
+// subscribes by binding a queue to it, so topics may use the * (one word)
+// and # (any words) wildcards. A subscription without a group gets its own
+// queue, deleted when it ends; a group is a durable queue of that name whose
+// consumers share the messages.
+type amqpClient struct {
+	conn *amqp.Connection
+	// ch publishes in confirm mode, so Publish returns once the broker has
+	// taken responsibility for the message.
+	ch *amqp.Channel
+}
+
+func dialAMQP(ctx context.Context, u *url.URL) (Client, error) {
+	conn, err := amqp.DialConfig(u.String(), amqp.Config{
+		Dial:       amqp.DefaultDial(dialTimeout(ctx)),
+		Properties: amqp.Table{"connection_name": "devtools"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ch, err := conn.Channel()
+	if err == nil {
+		err = ch.Confirm(false)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &amqpClient{conn: conn, ch: ch}, nil
+}
+
+func (c *amqpClient) Publish(ctx context.Context, m Message) (string, error) {
+	publishing := amqp.Publishing{Body: m.Payload, Timestamp: time.Now()}
+	if len(m.Headers) > 0 {
+		publishing.Headers = make(amqp.Table, len(m.Headers))
+		for k, v := range m.Headers {
+			publishing.Headers[k] = v
+		}
+	}
+	confirm, err := c.ch.PublishWithDeferredConfirmWithContext(ctx, amqpExchange, m.Topic, false, false, publishing)
+	if err != nil {
+		return "", fmt.Errorf("amqp publish: %w", err)
+	}
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("amqp publish: %w", err)
+	}
+	if !acked {
+		return "", errors.New("amqp publish: broker rejected the message")
+	}
+	return "", nil
+}
+
+func (c *amqpClient) Subscribe(ctx context.Context, sub Subscription, handle Handler) error {
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return fmt.Errorf("amqp subscribe: %w", err)
+	}
+	defer ch.Close()
+
+	var queue amqp.Queue
+	if sub.Group != "" {
+		queue, err = ch.QueueDeclare(sub.Group, true, false, false, false, nil)
+	} else {
+		queue, err = ch.QueueDeclare("", false, true, true, false, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("amqp declare queue: %w", err)
+	}
+	if err := ch.QueueBind(queue.Name, sub.Topic, amqpExchange, false, nil); err != nil {
+		return fmt.Errorf("amqp bind queue: %w", err)
+	}
+	deliveries, err := ch.Consume(queue.Name, "devtools-"+randomSuffix(), false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("amqp consume: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("amqp consume: channel closed by the broker")
+			}
+			handleErr := handle(amqpMessage(d))
+			if err := d.Ack(false); err != nil {
+				return fmt.Errorf("amqp ack: %w", err)
+			}
+			if handleErr != nil {
+				if errors.Is(handleErr, ErrStop) {
+					return nil
+				}
+				return handleErr
+			}
+		}
+	}
+}
+
+func (c *amqpClient) Close() error {
+	return c.conn.Close()
+}
+
+func amqpMessage(d amqp.Delivery) Message {
+	m := Message{Topic: d.RoutingKey, Payload: d.Body, ID: d.MessageId, Time: d.Timestamp}
+	if m.Time.IsZero() {
+		m.Time = time.Now()
+	}
+	if len(d.Headers) > 0 {
+		m.Headers = make(map[string]string, len(d.Headers))
+		for k, v := range d.Headers {
+			m.Headers[k] = fmt.Sprint(v)
+		}
+	}
+	return m
+}
@@ -0,0 +1,146 @@
+package mq
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+)
+
+// kafkaClient produces to and consumes from Kafka topics. Message keys are
+// record keys, so they pick the partition, and groups are consumer groups.
+type kafkaClient struct {
+	// opts connect to the cluster; each subscription opens its own client
+	// with them, since consumer options are fixed when a client is made.
+	opts     []kgo.Opt
+	producer *kgo.Client
+}
+
+// dialKafka connects to the brokers listed in the URL's host, separated by
+// commas. kafkas:// connects over TLS, and a user and password in the URL
+// authenticate with SASL/PLAIN.
+func dialKafka(ctx context.Context, u *url.URL) (Client, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(strings.Split(u.Host, ",")...),
+		kgo.ClientID("devtools"),
+		kgo.AllowAutoTopicCreation(),
+	}
+	if strings.EqualFold(u.Scheme, "kafkas") {
+		opts = append(opts, kgo.DialTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		opts = append(opts, kgo.SASL(plain.Auth{User: u.User.Username(), Pass: password}.AsMechanism()))
+	}
+
+	producer, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := producer.Ping(ctx); err != nil {
+		producer.Close()
+		return nil, err
+	}
+	return &kafkaClient{opts: opts, producer: producer}, nil
+}
+
+func (c *kafkaClient) Publish(ctx context.Context, m Message) (string, error) {
+	if m.Topic == "" {
+		return "", errors.New("kafka topic is empty")
+	}
+	record := &kgo.Record{Topic: m.Topic, Value: m.Payload}
+	if m.Key != "" {
+		record.Key = []byte(m.Key)
+	}
+	keys := make([]string, 0, len(m.Headers))
+	for k := range m.Headers {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: k, Value: []byte(m.Headers[k])})
+	}
+
+	produced, err := c.producer.ProduceSync(ctx, record).First()
+	if err != nil {
+		return "", fmt.Errorf("kafka produce: %w", err)
+	}
+	return kafkaID(produced), nil
+}
+
+// Subscribe consumes from the end of the topic's partitions. As a member of a
+// group, each record's offset is committed once it has been handled.
+func (c *kafkaClient) Subscribe(ctx context.Context, sub Subscription, handle Handler) error {
+	if sub.Topic == "" {
+		return errors.New("kafka topic is empty")
+	}
+	opts := append(slices.Clip(c.opts),
+		kgo.ConsumeTopics(sub.Topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtEnd()),
+	)
+	if sub.Group != "" {
+		opts = append(opts, kgo.ConsumerGroup(sub.Group), kgo.DisableAutoCommit())
+	}
+	consumer, err := kgo.NewClient(opts...)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	for {
+		fetches := consumer.PollFetches(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errs := fetches.Errors(); len(errs) > 0 {
+			return fmt.Errorf("kafka fetch %s: %w", errs[0].Topic, errs[0].Err)
+		}
+		for _, record := range fetches.Records() {
+			handleErr := handle(kafkaMessage(record))
+			if sub.Group != "" {
+				if err := consumer.CommitRecords(ctx, record); err != nil {
+					return fmt.Errorf("kafka commit: %w", err)
+				}
+			}
+			if handleErr != nil {
+				if errors.Is(handleErr, ErrStop) {
+					return nil
+				}
+				return handleErr
+			}
+		}
+	}
+}
+
+func (c *kafkaClient) Close() error {
+	c.producer.Close()
+	return nil
+}
+
+func kafkaMessage(record *kgo.Record) Message {
+	m := Message{
+		Topic:   record.Topic,
+		Key:     string(record.Key),
+		Payload: record.Value,
+		ID:      kafkaID(record),
+		Time:    record.Timestamp,
+	}
+	if len(record.Headers) > 0 {
+		m.Headers = make(map[string]string, len(record.Headers))
+		for _, h := range record.Headers {
+			m.Headers[h.Key] = string(h.Value)
+		}
+	}
+	return m
+}
+
+// kafkaID identifies a record by its partition and offset, e.g. "2-118".
+func kafkaID(record *kgo.Record) string {
+	return fmt.Sprintf("%d-%d", record.Partition, record.Offset)
+}
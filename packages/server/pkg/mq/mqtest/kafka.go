@@ -0,0 +1,52 @@
+package mqtest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// KafkaServer is a single-broker kfake cluster. Topics are created on first
+// use with three partitions.
+type KafkaServer struct {
+	// URL is the kafka:// URL clients connect to.
+	URL string
+
+	mu        sync.Mutex
+	consumers int
+}
+
+// NewKafkaServer starts a Kafka cluster on a loopback port and stops it when
+// the test ends.
+func NewKafkaServer(t testing.TB) *KafkaServer {
+	t.Helper()
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.AllowAutoTopicCreation(), kfake.DefaultNumPartitions(3))
+	if err != nil {
+		t.Fatalf("mqtest: start kafka cluster: %v", err)
+	}
+	t.Cleanup(cluster.Close)
+
+	s := &KafkaServer{URL: "kafka://" + strings.Join(cluster.ListenAddrs(), ",")}
+	// A consumer starting at the end of a topic lists its partitions' end
+	// offsets. The cluster handles requests one at a time, so anything
+	// produced after that request has been seen is delivered.
+	cluster.ControlKey(int16(kmsg.ListOffsets), func(kmsg.Request) (kmsg.Response, error, bool) {
+		cluster.KeepControl()
+		s.mu.Lock()
+		s.consumers++
+		s.mu.Unlock()
+		return nil, nil, false
+	})
+	return s
+}
+
+// Subscribers returns the number of consumers that have looked up where to
+// start reading, so a test can wait for a consumer before publishing.
+func (s *KafkaServer) Subscribers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.consumers
+}
@@ -0,0 +1,50 @@
+// Package mqtest runs in-process message brokers for tests: an embedded NATS
+// server, a miniredis Redis server and a kfake Kafka cluster, so package mq
+// and the message queue nodes can be tested without external brokers.
+package mqtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+// NATSServer is an embedded NATS server.
+type NATSServer struct {
+	// URL is the nats:// URL clients connect to.
+	URL string
+
+	srv *server.Server
+}
+
+// NewNATSServer starts a NATS server on a loopback port and stops it when
+// the test ends.
+func NewNATSServer(t testing.TB) *NATSServer {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: server.RANDOM_PORT, NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatalf("mqtest: start nats server: %v", err)
+	}
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("mqtest: nats server did not start")
+	}
+	return &NATSServer{URL: srv.ClientURL(), srv: srv}
+}
+
+// Subscribers returns the number of subscriptions held by clients, leaving
+// out the server's internal ones, so a test can wait for a consumer before
+// publishing.
+func (s *NATSServer) Subscribers() int {
+	connz, err := s.srv.Connz(nil)
+	if err != nil {
+		return 0
+	}
+	subs := 0
+	for _, conn := range connz.Conns {
+		subs += int(conn.NumSubs)
+	}
+	return subs
+}
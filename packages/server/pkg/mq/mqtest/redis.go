@@ -0,0 +1,62 @@
+package mqtest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+// RedisServer is a miniredis server, which keeps streams in memory.
+type RedisServer struct {
+	// URL is the redis:// URL clients connect to.
+	URL string
+
+	mr      *miniredis.Miniredis
+	mu      sync.Mutex
+	readers map[*server.Peer]bool
+}
+
+// NewRedisServer starts a Redis server on a loopback port and stops it when
+// the test ends.
+func NewRedisServer(t testing.TB) *RedisServer {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	s := &RedisServer{URL: "redis://" + mr.Addr(), mr: mr, readers: map[*server.Peer]bool{}}
+	mr.Server().SetPreHook(func(peer *server.Peer, cmd string, _ ...string) bool {
+		if cmd := strings.ToUpper(cmd); cmd == "XREAD" || cmd == "XREADGROUP" {
+			s.mu.Lock()
+			s.readers[peer] = true
+			s.mu.Unlock()
+		}
+		return false
+	})
+	return s
+}
+
+// Subscribers returns the number of clients that have started reading a
+// stream, so a test can wait for a consumer before publishing.
+func (s *RedisServer) Subscribers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.readers)
+}
+
+// Entries returns the payload field of each entry in a stream.
+func (s *RedisServer) Entries(stream string) []string {
+	entries, err := s.mr.Stream(stream)
+	if err != nil {
+		return nil
+	}
+	var payloads []string
+	for _, e := range entries {
+		for i := 0; i+1 < len(e.Values); i += 2 {
+			if e.Values[i] == "payload" {
+				payloads = append(payloads, e.Values[i+1])
+			}
+		}
+	}
+	return payloads
+}
@@ -0,0 +1,98 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsClient publishes and subscribes through a NATS connection. Subjects are
+// used as topics and queue groups as groups.
+type natsClient struct {
+	nc *nats.Conn
+}
+
+func dialNATS(ctx context.Context, u *url.URL) (Client, error) {
+	opts := []nats.Option{nats.Name("devtools"), nats.Timeout(dialTimeout(ctx))}
+	if strings.EqualFold(u.Scheme, "tls") {
+		opts = append(opts, nats.Secure())
+	}
+	// nats.Connect takes credentials from the URL itself, including a bare
+	// user as a token.
+	nc, err := nats.Connect(u.String(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &natsClient{nc: nc}, nil
+}
+
+func (c *natsClient) Publish(ctx context.Context, m Message) (string, error) {
+	msg := nats.NewMsg(m.Topic)
+	msg.Data = m.Payload
+	for k, v := range m.Headers {
+		msg.Header.Set(k, v)
+	}
+	if err := c.nc.PublishMsg(msg); err != nil {
+		return "", fmt.Errorf("nats publish: %w", err)
+	}
+	// The flush round trip confirms the server processed the message.
+	if err := c.flush(ctx); err != nil {
+		return "", fmt.Errorf("nats publish: %w", err)
+	}
+	return "", nil
+}
+
+func (c *natsClient) Subscribe(ctx context.Context, sub Subscription, handle Handler) error {
+	s, err := c.nc.QueueSubscribeSync(sub.Topic, sub.Group)
+	if err != nil {
+		return fmt.Errorf("nats subscribe: %w", err)
+	}
+	defer func() { _ = s.Unsubscribe() }()
+	if err := c.flush(ctx); err != nil {
+		return fmt.Errorf("nats subscribe: %w", err)
+	}
+
+	for {
+		msg, err := s.NextMsgWithContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("nats subscribe: %w", err)
+		}
+		m := Message{Topic: msg.Subject, Payload: msg.Data, Time: time.Now()}
+		if len(msg.Header) > 0 {
+			m.Headers = make(map[string]string, len(msg.Header))
+			for k := range msg.Header {
+				m.Headers[k] = msg.Header.Get(k)
+			}
+		}
+		if err := handle(m); err != nil {
+			if errors.Is(err, ErrStop) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// flush waits for the server to have processed everything sent so far. The
+// wait needs a deadline, so one is added when ctx has none.
+func (c *natsClient) flush(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultDialTimeout)
+		defer cancel()
+	}
+	return c.nc.FlushWithContext(ctx)
+}
+
+func (c *natsClient) Close() error {
+	c.nc.Close()
+	return nil
+}
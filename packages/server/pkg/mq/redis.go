@@ -0,0 +1,206 @@
+package mq
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Fields of a Redis stream entry that carry the message; every other field is
+// exposed as a header.
+const (
+	redisPayloadField = "payload"
+	redisKeyField     = "key"
+)
+
+// redisBlock is how long each blocking stream read waits. go-redis only
+// interrupts a blocked read at the context's deadline, not when it is
+// canceled, so reads are kept short and the context checked between them.
+const redisBlock = time.Second
+
+// redisClient uses Redis Streams (https://redis.io/docs/latest/develop/data-types/streams/):
+// topics are stream keys, XADD publishes, XREAD consumes and XREADGROUP
+// consumes as a member of a consumer group.
+type redisClient struct {
+	rdb *redis.Client
+}
+
+func dialRedis(ctx context.Context, u *url.URL) (Client, error) {
+	opts, err := redis.ParseURL(u.String())
+	if err != nil {
+		return nil, err
+	}
+	rdb := redis.NewClient(opts)
+	// Connect now so that a wrong address or password fails here rather
+	// than on the first publish.
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		rdb.Close()
+		return nil, err
+	}
+	return &redisClient{rdb: rdb}, nil
+}
+
+func (c *redisClient) Publish(ctx context.Context, m Message) (string, error) {
+	if m.Topic == "" {
+		return "", errors.New("redis stream name is empty")
+	}
+	values := []any{redisPayloadField, m.Payload}
+	if m.Key != "" {
+		values = append(values, redisKeyField, m.Key)
+	}
+	keys := make([]string, 0, len(m.Headers))
+	for k := range m.Headers {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		values = append(values, k, m.Headers[k])
+	}
+
+	id, err := c.rdb.XAdd(ctx, &redis.XAddArgs{Stream: m.Topic, Values: values}).Result()
+	if err != nil {
+		return "", fmt.Errorf("redis XADD: %w", err)
+	}
+	return id, nil
+}
+
+func (c *redisClient) Subscribe(ctx context.Context, sub Subscription, handle Handler) error {
+	if sub.Topic == "" {
+		return errors.New("redis stream name is empty")
+	}
+	var err error
+	if sub.Group != "" {
+		err = c.consumeGroup(ctx, sub, handle)
+	} else {
+		err = c.consume(ctx, sub, handle)
+	}
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// consume reads entries added after the subscription is made. It starts from
+// the stream's current last entry rather than "$" so that nothing added
+// between two reads is missed.
+func (c *redisClient) consume(ctx context.Context, sub Subscription, handle Handler) error {
+	last := "0-0"
+	entries, err := c.rdb.XRevRangeN(ctx, sub.Topic, "+", "-", 1).Result()
+	if err != nil {
+		return fmt.Errorf("redis XREVRANGE: %w", err)
+	}
+	if len(entries) > 0 {
+		last = entries[0].ID
+	}
+
+	for ctx.Err() == nil {
+		streams, err := c.rdb.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{sub.Topic, last},
+			Count:   100,
+			Block:   redisBlock,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("redis XREAD: %w", err)
+		}
+		for _, m := range streamMessages(streams) {
+			last = m.ID
+			if err := handle(m); err != nil {
+				if errors.Is(err, ErrStop) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+// consumeGroup reads as a member of the consumer group, creating the group
+// at the end of the stream if it does not exist, and acknowledges each entry
+// once it has been handled.
+func (c *redisClient) consumeGroup(ctx context.Context, sub Subscription, handle Handler) error {
+	err := c.rdb.XGroupCreateMkStream(ctx, sub.Topic, sub.Group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("redis XGROUP CREATE: %w", err)
+	}
+	consumer := "devtools-" + randomSuffix()
+
+	for ctx.Err() == nil {
+		streams, err := c.rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    sub.Group,
+			Consumer: consumer,
+			Streams:  []string{sub.Topic, ">"},
+			Count:    100,
+			Block:    redisBlock,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("redis XREADGROUP: %w", err)
+		}
+		for _, m := range streamMessages(streams) {
+			handleErr := handle(m)
+			if err := c.rdb.XAck(ctx, sub.Topic, sub.Group, m.ID).Err(); err != nil {
+				return fmt.Errorf("redis XACK: %w", err)
+			}
+			if handleErr != nil {
+				if errors.Is(handleErr, ErrStop) {
+					return nil
+				}
+				return handleErr
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+func (c *redisClient) Close() error {
+	return c.rdb.Close()
+}
+
+// streamMessages converts the entries of an XREAD reply into messages.
+func streamMessages(streams []redis.XStream) []Message {
+	var messages []Message
+	for _, stream := range streams {
+		for _, entry := range stream.Messages {
+			m := Message{Topic: stream.Stream, ID: entry.ID, Headers: map[string]string{}}
+			if ms, _, ok := strings.Cut(entry.ID, "-"); ok {
+				if millis, err := strconv.ParseInt(ms, 10, 64); err == nil {
+					m.Time = time.UnixMilli(millis)
+				}
+			}
+			for name, v := range entry.Values {
+				value := fmt.Sprint(v)
+				switch name {
+				case redisPayloadField:
+					m.Payload = []byte(value)
+				case redisKeyField:
+					m.Key = value
+				default:
+					m.Headers[name] = value
+				}
+			}
+			messages = append(messages, m)
+		}
+	}
+	return messages
+}
+
+func randomSuffix() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -45,8 +45,22 @@ const (
 	EntityFlowNodeSubFlowTrigger
 	EntityFlowNodeSubFlowReturn
 	EntityFlowNodeRunSubFlow
+	EntityFlowNodeParallel
+	EntityFlowNodePoll
+	EntityFlowNodeSwitch
+	EntityFlowNodeWebhookTrigger
+	EntityFlowNodeGraphQLSubscription
+	EntityFlowNodeWsExpect
+	EntityFlowNodeSocketConnection
+	EntityFlowNodeSocketSend
+	EntityFlowNodeSocketReceive
+	EntityFlowNodeDbQuery
+	EntityFlowNodeMqPublish
+	EntityFlowNodeMqConsume
+	EntityFlowNodeExec
 	EntityFlowEdge
 	EntityFlowVariable
+	EntityFlowSchedule
 	EntityFlowTag
 
 	// File system
@@ -116,3 +116,39 @@ func ConvertCredentialAnthropicToDB(mc mcredential.CredentialAnthropic, encrypte
 		EncryptionType: mc.EncryptionType,
 	}
 }
+
+// ConvertDBToCredentialDatabaseRaw converts DB row to model with raw secret (bytes).
+func ConvertDBToCredentialDatabaseRaw(c gen.CredentialDatabase) (*mcredential.CredentialDatabase, []byte) {
+	return &mcredential.CredentialDatabase{
+		CredentialID:     c.CredentialID,
+		ConnectionString: "", // Will be set by caller after decryption
+		EncryptionType:   c.EncryptionType,
+	}, c.ConnectionString
+}
+
+// ConvertCredentialDatabaseToDB converts model to DB row with encrypted secret.
+func ConvertCredentialDatabaseToDB(mc mcredential.CredentialDatabase, encryptedConnectionString []byte) gen.CredentialDatabase {
+	return gen.CredentialDatabase{
+		CredentialID:     mc.CredentialID,
+		ConnectionString: encryptedConnectionString,
+		EncryptionType:   mc.EncryptionType,
+	}
+}
+
+// ConvertDBToCredentialMessageBrokerRaw converts DB row to model with raw secret (bytes).
+func ConvertDBToCredentialMessageBrokerRaw(c gen.CredentialMessageBroker) (*mcredential.CredentialMessageBroker, []byte) {
+	return &mcredential.CredentialMessageBroker{
+		CredentialID:   c.CredentialID,
+		Url:            "", // Will be set by caller after decryption
+		EncryptionType: c.EncryptionType,
+	}, c.Url
+}
+
+// ConvertCredentialMessageBrokerToDB converts model to DB row with encrypted secret.
+func ConvertCredentialMessageBrokerToDB(mc mcredential.CredentialMessageBroker, encryptedUrl []byte) gen.CredentialMessageBroker {
+	return gen.CredentialMessageBroker{
+		CredentialID:   mc.CredentialID,
+		Url:            encryptedUrl,
+		EncryptionType: mc.EncryptionType,
+	}
+}
@@ -126,6 +126,46 @@ func (r *CredentialReader) GetCredentialAnthropic(ctx context.Context, id idwrap
 	return result, nil
 }
 
+func (r *CredentialReader) GetCredentialDatabase(ctx context.Context, id idwrap.IDWrap) (*mcredential.CredentialDatabase, error) {
+	dbCred, err := r.queries.GetCredentialDatabase(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+
+	result, encrypted := ConvertDBToCredentialDatabaseRaw(dbCred)
+
+	connectionString, err := r.decryptSecret(encrypted, result.EncryptionType)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt connection string: %w", err)
+	}
+	result.ConnectionString = connectionString
+
+	return result, nil
+}
+
+func (r *CredentialReader) GetCredentialMessageBroker(ctx context.Context, id idwrap.IDWrap) (*mcredential.CredentialMessageBroker, error) {
+	dbCred, err := r.queries.GetCredentialMessageBroker(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+
+	result, encrypted := ConvertDBToCredentialMessageBrokerRaw(dbCred)
+
+	url, err := r.decryptSecret(encrypted, result.EncryptionType)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt url: %w", err)
+	}
+	result.Url = url
+
+	return result, nil
+}
+
 func (r *CredentialReader) ListCredentials(ctx context.Context, workspaceID idwrap.IDWrap) ([]mcredential.Credential, error) {
 	creds, err := r.queries.GetCredentialsByWorkspaceID(ctx, workspaceID)
 	if err != nil {
@@ -85,6 +85,14 @@ func (s CredentialService) GetCredentialAnthropic(ctx context.Context, id idwrap
 	return s.Reader().GetCredentialAnthropic(ctx, id)
 }
 
+func (s CredentialService) GetCredentialDatabase(ctx context.Context, id idwrap.IDWrap) (*mcredential.CredentialDatabase, error) {
+	return s.Reader().GetCredentialDatabase(ctx, id)
+}
+
+func (s CredentialService) GetCredentialMessageBroker(ctx context.Context, id idwrap.IDWrap) (*mcredential.CredentialMessageBroker, error) {
+	return s.Reader().GetCredentialMessageBroker(ctx, id)
+}
+
 func (s CredentialService) ListCredentials(ctx context.Context, workspaceID idwrap.IDWrap) ([]mcredential.Credential, error) {
 	return s.Reader().ListCredentials(ctx, workspaceID)
 }
@@ -105,6 +113,14 @@ func (s CredentialService) CreateCredentialAnthropic(ctx context.Context, cred *
 	return s.writer().CreateCredentialAnthropic(ctx, cred)
 }
 
+func (s CredentialService) CreateCredentialDatabase(ctx context.Context, cred *mcredential.CredentialDatabase) error {
+	return s.writer().CreateCredentialDatabase(ctx, cred)
+}
+
+func (s CredentialService) CreateCredentialMessageBroker(ctx context.Context, cred *mcredential.CredentialMessageBroker) error {
+	return s.writer().CreateCredentialMessageBroker(ctx, cred)
+}
+
 func (s CredentialService) UpdateCredential(ctx context.Context, cred *mcredential.Credential) error {
 	return s.writer().UpdateCredential(ctx, cred)
 }
@@ -121,6 +137,14 @@ func (s CredentialService) UpdateCredentialAnthropic(ctx context.Context, cred *
 	return s.writer().UpdateCredentialAnthropic(ctx, cred)
 }
 
+func (s CredentialService) UpdateCredentialDatabase(ctx context.Context, cred *mcredential.CredentialDatabase) error {
+	return s.writer().UpdateCredentialDatabase(ctx, cred)
+}
+
+func (s CredentialService) UpdateCredentialMessageBroker(ctx context.Context, cred *mcredential.CredentialMessageBroker) error {
+	return s.writer().UpdateCredentialMessageBroker(ctx, cred)
+}
+
 func (s CredentialService) DeleteCredential(ctx context.Context, id idwrap.IDWrap) error {
 	return s.writer().DeleteCredential(ctx, id)
 }
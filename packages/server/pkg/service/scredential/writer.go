@@ -110,6 +110,32 @@ func (w *CredentialWriter) CreateCredentialAnthropic(ctx context.Context, cred *
 	})
 }
 
+func (w *CredentialWriter) CreateCredentialDatabase(ctx context.Context, cred *mcredential.CredentialDatabase) error {
+	connBytes, encType, err := w.encryptSecret([]byte(cred.ConnectionString), cred.EncryptionType)
+	if err != nil {
+		return fmt.Errorf("encrypt connection string: %w", err)
+	}
+
+	return w.queries.CreateCredentialDatabase(ctx, gen.CreateCredentialDatabaseParams{
+		CredentialID:     cred.CredentialID,
+		ConnectionString: connBytes,
+		EncryptionType:   encType,
+	})
+}
+
+func (w *CredentialWriter) CreateCredentialMessageBroker(ctx context.Context, cred *mcredential.CredentialMessageBroker) error {
+	urlBytes, encType, err := w.encryptSecret([]byte(cred.Url), cred.EncryptionType)
+	if err != nil {
+		return fmt.Errorf("encrypt url: %w", err)
+	}
+
+	return w.queries.CreateCredentialMessageBroker(ctx, gen.CreateCredentialMessageBrokerParams{
+		CredentialID:   cred.CredentialID,
+		Url:            urlBytes,
+		EncryptionType: encType,
+	})
+}
+
 func (w *CredentialWriter) UpdateCredential(ctx context.Context, cred *mcredential.Credential) error {
 	return w.queries.UpdateCredential(ctx, gen.UpdateCredentialParams{
 		ID:   cred.ID,
@@ -175,6 +201,32 @@ func (w *CredentialWriter) UpdateCredentialAnthropic(ctx context.Context, cred *
 	})
 }
 
+func (w *CredentialWriter) UpdateCredentialDatabase(ctx context.Context, cred *mcredential.CredentialDatabase) error {
+	connBytes, encType, err := w.encryptSecret([]byte(cred.ConnectionString), cred.EncryptionType)
+	if err != nil {
+		return fmt.Errorf("encrypt connection string: %w", err)
+	}
+
+	return w.queries.UpdateCredentialDatabase(ctx, gen.UpdateCredentialDatabaseParams{
+		CredentialID:     cred.CredentialID,
+		ConnectionString: connBytes,
+		EncryptionType:   encType,
+	})
+}
+
+func (w *CredentialWriter) UpdateCredentialMessageBroker(ctx context.Context, cred *mcredential.CredentialMessageBroker) error {
+	urlBytes, encType, err := w.encryptSecret([]byte(cred.Url), cred.EncryptionType)
+	if err != nil {
+		return fmt.Errorf("encrypt url: %w", err)
+	}
+
+	return w.queries.UpdateCredentialMessageBroker(ctx, gen.UpdateCredentialMessageBrokerParams{
+		CredentialID:   cred.CredentialID,
+		Url:            urlBytes,
+		EncryptionType: encType,
+	})
+}
+
 func (w *CredentialWriter) DeleteCredential(ctx context.Context, id idwrap.IDWrap) error {
 	return w.queries.DeleteCredential(ctx, id)
 }
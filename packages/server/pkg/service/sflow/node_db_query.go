@@ -0,0 +1,49 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeDbQueryService struct {
+	reader  *NodeDbQueryReader
+	queries *gen.Queries
+}
+
+func NewNodeDbQueryService(queries *gen.Queries) NodeDbQueryService {
+	return NodeDbQueryService{
+		reader:  NewNodeDbQueryReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeDbQueryService) TX(tx *sql.Tx) NodeDbQueryService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeDbQueryService{
+		reader:  NewNodeDbQueryReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeDbQueryService) GetNodeDbQuery(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeDbQuery, error) {
+	return s.reader.GetNodeDbQuery(ctx, id)
+}
+
+func (s NodeDbQueryService) CreateNodeDbQuery(ctx context.Context, n mflow.NodeDbQuery) error {
+	return NewNodeDbQueryWriterFromQueries(s.queries).CreateNodeDbQuery(ctx, n)
+}
+
+func (s NodeDbQueryService) UpdateNodeDbQuery(ctx context.Context, n mflow.NodeDbQuery) error {
+	return NewNodeDbQueryWriterFromQueries(s.queries).UpdateNodeDbQuery(ctx, n)
+}
+
+func (s NodeDbQueryService) DeleteNodeDbQuery(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeDbQueryWriterFromQueries(s.queries).DeleteNodeDbQuery(ctx, id)
+}
+
+func (s NodeDbQueryService) Reader() *NodeDbQueryReader { return s.reader }
@@ -0,0 +1,62 @@
+package sflow
+
+import (
+	"encoding/json"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertToDBNodeDbQuery(n mflow.NodeDbQuery) gen.FlowNodeDbQuery {
+	params, _ := json.Marshal(n.Params)
+	if params == nil || string(params) == "null" {
+		params = []byte("[]")
+	}
+	assertions, _ := json.Marshal(n.Assertions)
+	if assertions == nil || string(assertions) == "null" {
+		assertions = []byte("[]")
+	}
+
+	var credentialID []byte
+	if n.CredentialID != nil {
+		credentialID = n.CredentialID.Bytes()
+	}
+
+	return gen.FlowNodeDbQuery{
+		FlowNodeID:   n.FlowNodeID,
+		CredentialID: credentialID,
+		Query:        n.Query,
+		Params:       params,
+		TimeoutMs:    n.TimeoutMs,
+		Assertions:   assertions,
+	}
+}
+
+func ConvertToModelNodeDbQuery(n gen.FlowNodeDbQuery) *mflow.NodeDbQuery {
+	var credID *idwrap.IDWrap
+	if len(n.CredentialID) > 0 {
+		id, err := idwrap.NewFromBytes(n.CredentialID)
+		if err == nil {
+			credID = &id
+		}
+	}
+
+	var params []string
+	if len(n.Params) > 0 {
+		_ = json.Unmarshal(n.Params, &params)
+	}
+	var assertions []string
+	if len(n.Assertions) > 0 {
+		_ = json.Unmarshal(n.Assertions, &assertions)
+	}
+
+	return &mflow.NodeDbQuery{
+		FlowNodeID:   n.FlowNodeID,
+		CredentialID: credID,
+		Query:        n.Query,
+		Params:       params,
+		TimeoutMs:    n.TimeoutMs,
+		Assertions:   assertions,
+	}
+}
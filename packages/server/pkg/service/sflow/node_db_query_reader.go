@@ -0,0 +1,30 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeDbQueryReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeDbQueryReaderFromQueries(queries *gen.Queries) *NodeDbQueryReader {
+	return &NodeDbQueryReader{queries: queries}
+}
+
+func (r *NodeDbQueryReader) GetNodeDbQuery(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeDbQuery, error) {
+	nodeDbQuery, err := r.queries.GetFlowNodeDbQuery(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertToModelNodeDbQuery(nodeDbQuery), nil
+}
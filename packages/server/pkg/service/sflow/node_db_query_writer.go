@@ -0,0 +1,35 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeDbQueryWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeDbQueryWriter(tx gen.DBTX) *NodeDbQueryWriter {
+	return &NodeDbQueryWriter{queries: gen.New(tx)}
+}
+
+func NewNodeDbQueryWriterFromQueries(queries *gen.Queries) *NodeDbQueryWriter {
+	return &NodeDbQueryWriter{queries: queries}
+}
+
+func (w *NodeDbQueryWriter) CreateNodeDbQuery(ctx context.Context, n mflow.NodeDbQuery) error {
+	dbModel := ConvertToDBNodeDbQuery(n)
+	return w.queries.CreateFlowNodeDbQuery(ctx, gen.CreateFlowNodeDbQueryParams(dbModel))
+}
+
+func (w *NodeDbQueryWriter) UpdateNodeDbQuery(ctx context.Context, n mflow.NodeDbQuery) error {
+	dbModel := ConvertToDBNodeDbQuery(n)
+	return w.queries.UpdateFlowNodeDbQuery(ctx, gen.UpdateFlowNodeDbQueryParams(dbModel))
+}
+
+func (w *NodeDbQueryWriter) DeleteNodeDbQuery(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeDbQuery(ctx, id)
+}
@@ -0,0 +1,49 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeExecService struct {
+	reader  *NodeExecReader
+	queries *gen.Queries
+}
+
+func NewNodeExecService(queries *gen.Queries) NodeExecService {
+	return NodeExecService{
+		reader:  NewNodeExecReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeExecService) TX(tx *sql.Tx) NodeExecService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeExecService{
+		reader:  NewNodeExecReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeExecService) GetNodeExec(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeExec, error) {
+	return s.reader.GetNodeExec(ctx, id)
+}
+
+func (s NodeExecService) CreateNodeExec(ctx context.Context, n mflow.NodeExec) error {
+	return NewNodeExecWriterFromQueries(s.queries).CreateNodeExec(ctx, n)
+}
+
+func (s NodeExecService) UpdateNodeExec(ctx context.Context, n mflow.NodeExec) error {
+	return NewNodeExecWriterFromQueries(s.queries).UpdateNodeExec(ctx, n)
+}
+
+func (s NodeExecService) DeleteNodeExec(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeExecWriterFromQueries(s.queries).DeleteNodeExec(ctx, id)
+}
+
+func (s NodeExecService) Reader() *NodeExecReader { return s.reader }
@@ -0,0 +1,40 @@
+package sflow
+
+import (
+	"encoding/json"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertToDBNodeExec(n mflow.NodeExec) gen.FlowNodeExec {
+	args, _ := json.Marshal(n.Args)
+	if args == nil || string(args) == "null" {
+		args = []byte("[]")
+	}
+
+	return gen.FlowNodeExec{
+		FlowNodeID: n.FlowNodeID,
+		Command:    n.Command,
+		Args:       args,
+		WorkDir:    n.WorkDir,
+		TimeoutMs:  n.TimeoutMs,
+		ParseJson:  n.ParseJSON,
+	}
+}
+
+func ConvertToModelNodeExec(n gen.FlowNodeExec) *mflow.NodeExec {
+	var args []string
+	if len(n.Args) > 0 {
+		_ = json.Unmarshal(n.Args, &args)
+	}
+
+	return &mflow.NodeExec{
+		FlowNodeID: n.FlowNodeID,
+		Command:    n.Command,
+		Args:       args,
+		WorkDir:    n.WorkDir,
+		TimeoutMs:  n.TimeoutMs,
+		ParseJSON:  n.ParseJson,
+	}
+}
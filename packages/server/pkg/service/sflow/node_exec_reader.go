@@ -0,0 +1,30 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeExecReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeExecReaderFromQueries(queries *gen.Queries) *NodeExecReader {
+	return &NodeExecReader{queries: queries}
+}
+
+func (r *NodeExecReader) GetNodeExec(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeExec, error) {
+	nodeExec, err := r.queries.GetFlowNodeExec(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertToModelNodeExec(nodeExec), nil
+}
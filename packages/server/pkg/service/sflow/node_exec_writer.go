@@ -0,0 +1,35 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeExecWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeExecWriter(tx gen.DBTX) *NodeExecWriter {
+	return &NodeExecWriter{queries: gen.New(tx)}
+}
+
+func NewNodeExecWriterFromQueries(queries *gen.Queries) *NodeExecWriter {
+	return &NodeExecWriter{queries: queries}
+}
+
+func (w *NodeExecWriter) CreateNodeExec(ctx context.Context, n mflow.NodeExec) error {
+	dbModel := ConvertToDBNodeExec(n)
+	return w.queries.CreateFlowNodeExec(ctx, gen.CreateFlowNodeExecParams(dbModel))
+}
+
+func (w *NodeExecWriter) UpdateNodeExec(ctx context.Context, n mflow.NodeExec) error {
+	dbModel := ConvertToDBNodeExec(n)
+	return w.queries.UpdateFlowNodeExec(ctx, gen.UpdateFlowNodeExecParams(dbModel))
+}
+
+func (w *NodeExecWriter) DeleteNodeExec(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeExec(ctx, id)
+}
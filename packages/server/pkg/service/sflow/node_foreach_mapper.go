@@ -12,6 +12,7 @@ func ConvertNodeForEachToDB(nf mflow.NodeForEach) gen.FlowNodeForEach {
 		IterExpression: nf.IterExpression,
 		ErrorHandling:  int8(nf.ErrorHandling),
 		Expression:     nf.Condition.Comparisons.Expression,
+		Concurrency:    nf.Concurrency,
 	}
 }
 
@@ -20,6 +21,7 @@ func ConvertDBToNodeForEach(nf gen.FlowNodeForEach) *mflow.NodeForEach {
 		FlowNodeID:     nf.FlowNodeID,
 		IterExpression: nf.IterExpression,
 		ErrorHandling:  mflow.ErrorHandling(nf.ErrorHandling),
+		Concurrency:    nf.Concurrency,
 		Condition: mcondition.Condition{
 			Comparisons: mcondition.Comparison{
 				Expression: nf.Expression,
@@ -42,6 +42,7 @@ func (w *NodeForEachWriter) UpdateNodeForEach(ctx context.Context, nf mflow.Node
 		IterExpression: nodeForEach.IterExpression,
 		ErrorHandling:  nodeForEach.ErrorHandling,
 		Expression:     nodeForEach.Expression,
+		Concurrency:    nodeForEach.Concurrency,
 	})
 }
 
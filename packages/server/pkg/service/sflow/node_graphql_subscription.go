@@ -0,0 +1,62 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeGraphQLSubscriptionService struct {
+	reader  *NodeGraphQLSubscriptionReader
+	queries *gen.Queries
+}
+
+func NewNodeGraphQLSubscriptionService(queries *gen.Queries) NodeGraphQLSubscriptionService {
+	return NodeGraphQLSubscriptionService{
+		reader:  NewNodeGraphQLSubscriptionReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (ngss NodeGraphQLSubscriptionService) TX(tx *sql.Tx) NodeGraphQLSubscriptionService {
+	newQueries := ngss.queries.WithTx(tx)
+	return NodeGraphQLSubscriptionService{
+		reader:  NewNodeGraphQLSubscriptionReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func NewNodeGraphQLSubscriptionServiceTX(ctx context.Context, tx *sql.Tx) (*NodeGraphQLSubscriptionService, error) {
+	queries, err := gen.Prepare(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeGraphQLSubscriptionService{
+		reader:  NewNodeGraphQLSubscriptionReaderFromQueries(queries),
+		queries: queries,
+	}, nil
+}
+
+func (ngss NodeGraphQLSubscriptionService) GetNodeGraphQLSubscription(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeGraphQLSubscription, error) {
+	return ngss.reader.GetNodeGraphQLSubscription(ctx, id)
+}
+
+func (ngss NodeGraphQLSubscriptionService) CreateNodeGraphQLSubscription(ctx context.Context, ng mflow.NodeGraphQLSubscription) error {
+	return NewNodeGraphQLSubscriptionWriterFromQueries(ngss.queries).CreateNodeGraphQLSubscription(ctx, ng)
+}
+
+func (ngss NodeGraphQLSubscriptionService) UpdateNodeGraphQLSubscription(ctx context.Context, ng mflow.NodeGraphQLSubscription) error {
+	return NewNodeGraphQLSubscriptionWriterFromQueries(ngss.queries).UpdateNodeGraphQLSubscription(ctx, ng)
+}
+
+func (ngss NodeGraphQLSubscriptionService) DeleteNodeGraphQLSubscription(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeGraphQLSubscriptionWriterFromQueries(ngss.queries).DeleteNodeGraphQLSubscription(ctx, id)
+}
+
+func (ngss NodeGraphQLSubscriptionService) Reader() *NodeGraphQLSubscriptionReader {
+	return ngss.reader
+}
@@ -0,0 +1,47 @@
+package sflow
+
+import (
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertToDBNodeGraphQLSubscription(ns mflow.NodeGraphQLSubscription) (gen.FlowNodeGraphqlSubscription, bool) {
+	if ns.GraphQLID == nil || isZeroID(*ns.GraphQLID) {
+		return gen.FlowNodeGraphqlSubscription{}, false
+	}
+
+	dbNode := gen.FlowNodeGraphqlSubscription{
+		FlowNodeID:        ns.FlowNodeID,
+		GraphqlID:         *ns.GraphQLID,
+		ConnectionPayload: ns.ConnectionPayload,
+		MaxEvents:         int64(ns.MaxEvents),
+		TimeoutMs:         ns.TimeoutMs,
+	}
+
+	if ns.DeltaGraphQLID != nil {
+		dbNode.DeltaGraphqlID = ns.DeltaGraphQLID.Bytes()
+	}
+
+	return dbNode, true
+}
+
+func ConvertToModelNodeGraphQLSubscription(ns gen.FlowNodeGraphqlSubscription) *mflow.NodeGraphQLSubscription {
+	graphqlID := ns.GraphqlID
+	modelNode := &mflow.NodeGraphQLSubscription{
+		FlowNodeID:        ns.FlowNodeID,
+		GraphQLID:         &graphqlID,
+		ConnectionPayload: ns.ConnectionPayload,
+		MaxEvents:         int32(ns.MaxEvents), //nolint:gosec // stored from an int32
+		TimeoutMs:         ns.TimeoutMs,
+	}
+
+	if len(ns.DeltaGraphqlID) > 0 {
+		deltaID, err := idwrap.NewFromBytes(ns.DeltaGraphqlID)
+		if err == nil {
+			modelNode.DeltaGraphQLID = &deltaID
+		}
+	}
+
+	return modelNode
+}
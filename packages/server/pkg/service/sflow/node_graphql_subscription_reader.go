@@ -0,0 +1,34 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeGraphQLSubscriptionReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeGraphQLSubscriptionReader(db *sql.DB) *NodeGraphQLSubscriptionReader {
+	return &NodeGraphQLSubscriptionReader{queries: gen.New(db)}
+}
+
+func NewNodeGraphQLSubscriptionReaderFromQueries(queries *gen.Queries) *NodeGraphQLSubscriptionReader {
+	return &NodeGraphQLSubscriptionReader{queries: queries}
+}
+
+func (r *NodeGraphQLSubscriptionReader) GetNodeGraphQLSubscription(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeGraphQLSubscription, error) {
+	nodeGQL, err := r.queries.GetFlowNodeGraphQLSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertToModelNodeGraphQLSubscription(nodeGQL), nil
+}
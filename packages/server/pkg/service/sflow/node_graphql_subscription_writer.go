@@ -0,0 +1,47 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeGraphQLSubscriptionWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeGraphQLSubscriptionWriter(tx gen.DBTX) *NodeGraphQLSubscriptionWriter {
+	return &NodeGraphQLSubscriptionWriter{queries: gen.New(tx)}
+}
+
+func NewNodeGraphQLSubscriptionWriterFromQueries(queries *gen.Queries) *NodeGraphQLSubscriptionWriter {
+	return &NodeGraphQLSubscriptionWriter{queries: queries}
+}
+
+func (w *NodeGraphQLSubscriptionWriter) CreateNodeGraphQLSubscription(ctx context.Context, ng mflow.NodeGraphQLSubscription) error {
+	dbModel, ok := ConvertToDBNodeGraphQLSubscription(ng)
+	if !ok {
+		return nil
+	}
+	return w.queries.CreateFlowNodeGraphQLSubscription(ctx, gen.CreateFlowNodeGraphQLSubscriptionParams(dbModel))
+}
+
+func (w *NodeGraphQLSubscriptionWriter) UpdateNodeGraphQLSubscription(ctx context.Context, ng mflow.NodeGraphQLSubscription) error {
+	dbModel, ok := ConvertToDBNodeGraphQLSubscription(ng)
+	if !ok {
+		// Treat removal of GraphQLID as request to delete any existing binding.
+		if err := w.queries.DeleteFlowNodeGraphQLSubscription(ctx, ng.FlowNodeID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		return nil
+	}
+	return w.queries.UpdateFlowNodeGraphQLSubscription(ctx, gen.UpdateFlowNodeGraphQLSubscriptionParams(dbModel))
+}
+
+func (w *NodeGraphQLSubscriptionWriter) DeleteNodeGraphQLSubscription(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeGraphQLSubscription(ctx, id)
+}
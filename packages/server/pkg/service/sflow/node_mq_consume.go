@@ -0,0 +1,49 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeMqConsumeService struct {
+	reader  *NodeMqConsumeReader
+	queries *gen.Queries
+}
+
+func NewNodeMqConsumeService(queries *gen.Queries) NodeMqConsumeService {
+	return NodeMqConsumeService{
+		reader:  NewNodeMqConsumeReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeMqConsumeService) TX(tx *sql.Tx) NodeMqConsumeService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeMqConsumeService{
+		reader:  NewNodeMqConsumeReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeMqConsumeService) GetNodeMqConsume(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeMqConsume, error) {
+	return s.reader.GetNodeMqConsume(ctx, id)
+}
+
+func (s NodeMqConsumeService) CreateNodeMqConsume(ctx context.Context, n mflow.NodeMqConsume) error {
+	return NewNodeMqConsumeWriterFromQueries(s.queries).CreateNodeMqConsume(ctx, n)
+}
+
+func (s NodeMqConsumeService) UpdateNodeMqConsume(ctx context.Context, n mflow.NodeMqConsume) error {
+	return NewNodeMqConsumeWriterFromQueries(s.queries).UpdateNodeMqConsume(ctx, n)
+}
+
+func (s NodeMqConsumeService) DeleteNodeMqConsume(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeMqConsumeWriterFromQueries(s.queries).DeleteNodeMqConsume(ctx, id)
+}
+
+func (s NodeMqConsumeService) Reader() *NodeMqConsumeReader { return s.reader }
@@ -0,0 +1,58 @@
+package sflow
+
+import (
+	"encoding/json"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertToDBNodeMqConsume(n mflow.NodeMqConsume) gen.FlowNodeMqConsume {
+	assertions, _ := json.Marshal(n.Assertions)
+	if assertions == nil || string(assertions) == "null" {
+		assertions = []byte("[]")
+	}
+
+	var credentialID []byte
+	if n.CredentialID != nil {
+		credentialID = n.CredentialID.Bytes()
+	}
+
+	return gen.FlowNodeMqConsume{
+		FlowNodeID:       n.FlowNodeID,
+		CredentialID:     credentialID,
+		Topic:            n.Topic,
+		ConsumerGroup:    n.Group,
+		FilterExpression: n.Filter,
+		TimeoutMs:        n.TimeoutMs,
+		Count:            int64(n.Count),
+		Assertions:       assertions,
+	}
+}
+
+func ConvertToModelNodeMqConsume(n gen.FlowNodeMqConsume) *mflow.NodeMqConsume {
+	var credID *idwrap.IDWrap
+	if len(n.CredentialID) > 0 {
+		id, err := idwrap.NewFromBytes(n.CredentialID)
+		if err == nil {
+			credID = &id
+		}
+	}
+
+	var assertions []string
+	if len(n.Assertions) > 0 {
+		_ = json.Unmarshal(n.Assertions, &assertions)
+	}
+
+	return &mflow.NodeMqConsume{
+		FlowNodeID:   n.FlowNodeID,
+		CredentialID: credID,
+		Topic:        n.Topic,
+		Group:        n.ConsumerGroup,
+		Filter:       n.FilterExpression,
+		TimeoutMs:    n.TimeoutMs,
+		Count:        int32(n.Count), //nolint:gosec // stored from an int32
+		Assertions:   assertions,
+	}
+}
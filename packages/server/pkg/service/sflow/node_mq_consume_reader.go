@@ -0,0 +1,30 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeMqConsumeReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeMqConsumeReaderFromQueries(queries *gen.Queries) *NodeMqConsumeReader {
+	return &NodeMqConsumeReader{queries: queries}
+}
+
+func (r *NodeMqConsumeReader) GetNodeMqConsume(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeMqConsume, error) {
+	nodeMqConsume, err := r.queries.GetFlowNodeMqConsume(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertToModelNodeMqConsume(nodeMqConsume), nil
+}
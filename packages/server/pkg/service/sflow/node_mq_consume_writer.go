@@ -0,0 +1,35 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeMqConsumeWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeMqConsumeWriter(tx gen.DBTX) *NodeMqConsumeWriter {
+	return &NodeMqConsumeWriter{queries: gen.New(tx)}
+}
+
+func NewNodeMqConsumeWriterFromQueries(queries *gen.Queries) *NodeMqConsumeWriter {
+	return &NodeMqConsumeWriter{queries: queries}
+}
+
+func (w *NodeMqConsumeWriter) CreateNodeMqConsume(ctx context.Context, n mflow.NodeMqConsume) error {
+	dbModel := ConvertToDBNodeMqConsume(n)
+	return w.queries.CreateFlowNodeMqConsume(ctx, gen.CreateFlowNodeMqConsumeParams(dbModel))
+}
+
+func (w *NodeMqConsumeWriter) UpdateNodeMqConsume(ctx context.Context, n mflow.NodeMqConsume) error {
+	dbModel := ConvertToDBNodeMqConsume(n)
+	return w.queries.UpdateFlowNodeMqConsume(ctx, gen.UpdateFlowNodeMqConsumeParams(dbModel))
+}
+
+func (w *NodeMqConsumeWriter) DeleteNodeMqConsume(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeMqConsume(ctx, id)
+}
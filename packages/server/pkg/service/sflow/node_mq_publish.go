@@ -0,0 +1,49 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeMqPublishService struct {
+	reader  *NodeMqPublishReader
+	queries *gen.Queries
+}
+
+func NewNodeMqPublishService(queries *gen.Queries) NodeMqPublishService {
+	return NodeMqPublishService{
+		reader:  NewNodeMqPublishReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeMqPublishService) TX(tx *sql.Tx) NodeMqPublishService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeMqPublishService{
+		reader:  NewNodeMqPublishReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeMqPublishService) GetNodeMqPublish(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeMqPublish, error) {
+	return s.reader.GetNodeMqPublish(ctx, id)
+}
+
+func (s NodeMqPublishService) CreateNodeMqPublish(ctx context.Context, n mflow.NodeMqPublish) error {
+	return NewNodeMqPublishWriterFromQueries(s.queries).CreateNodeMqPublish(ctx, n)
+}
+
+func (s NodeMqPublishService) UpdateNodeMqPublish(ctx context.Context, n mflow.NodeMqPublish) error {
+	return NewNodeMqPublishWriterFromQueries(s.queries).UpdateNodeMqPublish(ctx, n)
+}
+
+func (s NodeMqPublishService) DeleteNodeMqPublish(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeMqPublishWriterFromQueries(s.queries).DeleteNodeMqPublish(ctx, id)
+}
+
+func (s NodeMqPublishService) Reader() *NodeMqPublishReader { return s.reader }
@@ -0,0 +1,40 @@
+package sflow
+
+import (
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertToDBNodeMqPublish(n mflow.NodeMqPublish) gen.FlowNodeMqPublish {
+	var credentialID []byte
+	if n.CredentialID != nil {
+		credentialID = n.CredentialID.Bytes()
+	}
+
+	return gen.FlowNodeMqPublish{
+		FlowNodeID:   n.FlowNodeID,
+		CredentialID: credentialID,
+		Topic:        n.Topic,
+		MessageKey:   n.Key,
+		Message:      n.Message,
+	}
+}
+
+func ConvertToModelNodeMqPublish(n gen.FlowNodeMqPublish) *mflow.NodeMqPublish {
+	var credID *idwrap.IDWrap
+	if len(n.CredentialID) > 0 {
+		id, err := idwrap.NewFromBytes(n.CredentialID)
+		if err == nil {
+			credID = &id
+		}
+	}
+
+	return &mflow.NodeMqPublish{
+		FlowNodeID:   n.FlowNodeID,
+		CredentialID: credID,
+		Topic:        n.Topic,
+		Key:          n.MessageKey,
+		Message:      n.Message,
+	}
+}
@@ -0,0 +1,30 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeMqPublishReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeMqPublishReaderFromQueries(queries *gen.Queries) *NodeMqPublishReader {
+	return &NodeMqPublishReader{queries: queries}
+}
+
+func (r *NodeMqPublishReader) GetNodeMqPublish(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeMqPublish, error) {
+	nodeMqPublish, err := r.queries.GetFlowNodeMqPublish(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertToModelNodeMqPublish(nodeMqPublish), nil
+}
@@ -0,0 +1,35 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeMqPublishWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeMqPublishWriter(tx gen.DBTX) *NodeMqPublishWriter {
+	return &NodeMqPublishWriter{queries: gen.New(tx)}
+}
+
+func NewNodeMqPublishWriterFromQueries(queries *gen.Queries) *NodeMqPublishWriter {
+	return &NodeMqPublishWriter{queries: queries}
+}
+
+func (w *NodeMqPublishWriter) CreateNodeMqPublish(ctx context.Context, n mflow.NodeMqPublish) error {
+	dbModel := ConvertToDBNodeMqPublish(n)
+	return w.queries.CreateFlowNodeMqPublish(ctx, gen.CreateFlowNodeMqPublishParams(dbModel))
+}
+
+func (w *NodeMqPublishWriter) UpdateNodeMqPublish(ctx context.Context, n mflow.NodeMqPublish) error {
+	dbModel := ConvertToDBNodeMqPublish(n)
+	return w.queries.UpdateFlowNodeMqPublish(ctx, gen.UpdateFlowNodeMqPublishParams(dbModel))
+}
+
+func (w *NodeMqPublishWriter) DeleteNodeMqPublish(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeMqPublish(ctx, id)
+}
@@ -0,0 +1,51 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+var ErrNoNodeParallelFound = sql.ErrNoRows
+
+type NodeParallelService struct {
+	reader  *NodeParallelReader
+	queries *gen.Queries
+}
+
+func NewNodeParallelService(queries *gen.Queries) NodeParallelService {
+	return NodeParallelService{
+		reader:  NewNodeParallelReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeParallelService) TX(tx *sql.Tx) NodeParallelService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeParallelService{
+		reader:  NewNodeParallelReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeParallelService) GetNodeParallel(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeParallel, error) {
+	return s.reader.GetNodeParallel(ctx, id)
+}
+
+func (s NodeParallelService) CreateNodeParallel(ctx context.Context, mn mflow.NodeParallel) error {
+	return NewNodeParallelWriterFromQueries(s.queries).CreateNodeParallel(ctx, mn)
+}
+
+func (s NodeParallelService) UpdateNodeParallel(ctx context.Context, mn mflow.NodeParallel) error {
+	return NewNodeParallelWriterFromQueries(s.queries).UpdateNodeParallel(ctx, mn)
+}
+
+func (s NodeParallelService) DeleteNodeParallel(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeParallelWriterFromQueries(s.queries).DeleteNodeParallel(ctx, id)
+}
+
+func (s NodeParallelService) Reader() *NodeParallelReader { return s.reader }
@@ -0,0 +1,22 @@
+package sflow
+
+import (
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertDBToNodeParallel(np gen.FlowNodeParallel) *mflow.NodeParallel {
+	return &mflow.NodeParallel{
+		FlowNodeID:  np.FlowNodeID,
+		JoinMode:    mflow.ParallelJoin(np.JoinMode),
+		Concurrency: np.Concurrency,
+	}
+}
+
+func ConvertNodeParallelToDB(mn mflow.NodeParallel) gen.FlowNodeParallel {
+	return gen.FlowNodeParallel{
+		FlowNodeID:  mn.FlowNodeID,
+		JoinMode:    int8(mn.JoinMode),
+		Concurrency: mn.Concurrency,
+	}
+}
@@ -0,0 +1,34 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeParallelReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeParallelReader(db *sql.DB) *NodeParallelReader {
+	return &NodeParallelReader{queries: gen.New(db)}
+}
+
+func NewNodeParallelReaderFromQueries(queries *gen.Queries) *NodeParallelReader {
+	return &NodeParallelReader{queries: queries}
+}
+
+func (r *NodeParallelReader) GetNodeParallel(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeParallel, error) {
+	nodeParallel, err := r.queries.GetFlowNodeParallel(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertDBToNodeParallel(nodeParallel), nil
+}
@@ -0,0 +1,39 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeParallelWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeParallelWriter(tx gen.DBTX) *NodeParallelWriter {
+	return &NodeParallelWriter{queries: gen.New(tx)}
+}
+
+func NewNodeParallelWriterFromQueries(queries *gen.Queries) *NodeParallelWriter {
+	return &NodeParallelWriter{queries: queries}
+}
+
+func (w *NodeParallelWriter) CreateNodeParallel(ctx context.Context, mn mflow.NodeParallel) error {
+	nodeParallel := ConvertNodeParallelToDB(mn)
+	return w.queries.CreateFlowNodeParallel(ctx, gen.CreateFlowNodeParallelParams(nodeParallel))
+}
+
+func (w *NodeParallelWriter) UpdateNodeParallel(ctx context.Context, mn mflow.NodeParallel) error {
+	nodeParallel := ConvertNodeParallelToDB(mn)
+	return w.queries.UpdateFlowNodeParallel(ctx, gen.UpdateFlowNodeParallelParams{
+		JoinMode:    nodeParallel.JoinMode,
+		Concurrency: nodeParallel.Concurrency,
+		FlowNodeID:  nodeParallel.FlowNodeID,
+	})
+}
+
+func (w *NodeParallelWriter) DeleteNodeParallel(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeParallel(ctx, id)
+}
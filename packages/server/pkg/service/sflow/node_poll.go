@@ -0,0 +1,51 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+var ErrNoNodePollFound = sql.ErrNoRows
+
+type NodePollService struct {
+	reader  *NodePollReader
+	queries *gen.Queries
+}
+
+func NewNodePollService(queries *gen.Queries) NodePollService {
+	return NodePollService{
+		reader:  NewNodePollReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodePollService) TX(tx *sql.Tx) NodePollService {
+	newQueries := s.queries.WithTx(tx)
+	return NodePollService{
+		reader:  NewNodePollReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodePollService) GetNodePoll(ctx context.Context, id idwrap.IDWrap) (*mflow.NodePoll, error) {
+	return s.reader.GetNodePoll(ctx, id)
+}
+
+func (s NodePollService) CreateNodePoll(ctx context.Context, mn mflow.NodePoll) error {
+	return NewNodePollWriterFromQueries(s.queries).CreateNodePoll(ctx, mn)
+}
+
+func (s NodePollService) UpdateNodePoll(ctx context.Context, mn mflow.NodePoll) error {
+	return NewNodePollWriterFromQueries(s.queries).UpdateNodePoll(ctx, mn)
+}
+
+func (s NodePollService) DeleteNodePoll(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodePollWriterFromQueries(s.queries).DeleteNodePoll(ctx, id)
+}
+
+func (s NodePollService) Reader() *NodePollReader { return s.reader }
@@ -0,0 +1,28 @@
+package sflow
+
+import (
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertDBToNodePoll(np gen.FlowNodePoll) *mflow.NodePoll {
+	return &mflow.NodePoll{
+		FlowNodeID:    np.FlowNodeID,
+		Until:         np.UntilExpression,
+		IntervalMs:    np.IntervalMs,
+		Backoff:       np.Backoff,
+		MaxDurationMs: np.MaxDurationMs,
+		MaxAttempts:   np.MaxAttempts,
+	}
+}
+
+func ConvertNodePollToDB(mn mflow.NodePoll) gen.FlowNodePoll {
+	return gen.FlowNodePoll{
+		FlowNodeID:      mn.FlowNodeID,
+		UntilExpression: mn.Until,
+		IntervalMs:      mn.IntervalMs,
+		Backoff:         mn.Backoff,
+		MaxDurationMs:   mn.MaxDurationMs,
+		MaxAttempts:     mn.MaxAttempts,
+	}
+}
@@ -0,0 +1,34 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodePollReader struct {
+	queries *gen.Queries
+}
+
+func NewNodePollReader(db *sql.DB) *NodePollReader {
+	return &NodePollReader{queries: gen.New(db)}
+}
+
+func NewNodePollReaderFromQueries(queries *gen.Queries) *NodePollReader {
+	return &NodePollReader{queries: queries}
+}
+
+func (r *NodePollReader) GetNodePoll(ctx context.Context, id idwrap.IDWrap) (*mflow.NodePoll, error) {
+	nodePoll, err := r.queries.GetFlowNodePoll(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertDBToNodePoll(nodePoll), nil
+}
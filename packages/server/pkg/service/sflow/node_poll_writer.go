@@ -0,0 +1,42 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodePollWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodePollWriter(tx gen.DBTX) *NodePollWriter {
+	return &NodePollWriter{queries: gen.New(tx)}
+}
+
+func NewNodePollWriterFromQueries(queries *gen.Queries) *NodePollWriter {
+	return &NodePollWriter{queries: queries}
+}
+
+func (w *NodePollWriter) CreateNodePoll(ctx context.Context, mn mflow.NodePoll) error {
+	nodePoll := ConvertNodePollToDB(mn)
+	return w.queries.CreateFlowNodePoll(ctx, gen.CreateFlowNodePollParams(nodePoll))
+}
+
+func (w *NodePollWriter) UpdateNodePoll(ctx context.Context, mn mflow.NodePoll) error {
+	nodePoll := ConvertNodePollToDB(mn)
+	return w.queries.UpdateFlowNodePoll(ctx, gen.UpdateFlowNodePollParams{
+		UntilExpression: nodePoll.UntilExpression,
+		IntervalMs:      nodePoll.IntervalMs,
+		Backoff:         nodePoll.Backoff,
+		MaxDurationMs:   nodePoll.MaxDurationMs,
+		MaxAttempts:     nodePoll.MaxAttempts,
+		FlowNodeID:      nodePoll.FlowNodeID,
+	})
+}
+
+func (w *NodePollWriter) DeleteNodePoll(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodePoll(ctx, id)
+}
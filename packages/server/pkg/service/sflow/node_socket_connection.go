@@ -0,0 +1,49 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSocketConnectionService struct {
+	reader  *NodeSocketConnectionReader
+	queries *gen.Queries
+}
+
+func NewNodeSocketConnectionService(queries *gen.Queries) NodeSocketConnectionService {
+	return NodeSocketConnectionService{
+		reader:  NewNodeSocketConnectionReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeSocketConnectionService) TX(tx *sql.Tx) NodeSocketConnectionService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeSocketConnectionService{
+		reader:  NewNodeSocketConnectionReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeSocketConnectionService) GetNodeSocketConnection(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeSocketConnection, error) {
+	return s.reader.GetNodeSocketConnection(ctx, id)
+}
+
+func (s NodeSocketConnectionService) CreateNodeSocketConnection(ctx context.Context, n mflow.NodeSocketConnection) error {
+	return NewNodeSocketConnectionWriterFromQueries(s.queries).CreateNodeSocketConnection(ctx, n)
+}
+
+func (s NodeSocketConnectionService) UpdateNodeSocketConnection(ctx context.Context, n mflow.NodeSocketConnection) error {
+	return NewNodeSocketConnectionWriterFromQueries(s.queries).UpdateNodeSocketConnection(ctx, n)
+}
+
+func (s NodeSocketConnectionService) DeleteNodeSocketConnection(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeSocketConnectionWriterFromQueries(s.queries).DeleteNodeSocketConnection(ctx, id)
+}
+
+func (s NodeSocketConnectionService) Reader() *NodeSocketConnectionReader { return s.reader }
@@ -0,0 +1,34 @@
+package sflow
+
+import (
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertToDBNodeSocketConnection(n mflow.NodeSocketConnection) gen.FlowNodeSocketConnection {
+	return gen.FlowNodeSocketConnection{
+		FlowNodeID:    n.FlowNodeID,
+		Network:       int8(n.Network),
+		Address:       n.Address,
+		Tls:           n.TLS,
+		TlsSkipVerify: n.TLSSkipVerify,
+		Framing:       int8(n.Framing),
+		Delimiter:     n.Delimiter,
+		LengthBytes:   int64(n.LengthBytes),
+		TimeoutMs:     n.TimeoutMs,
+	}
+}
+
+func ConvertToModelNodeSocketConnection(n gen.FlowNodeSocketConnection) *mflow.NodeSocketConnection {
+	return &mflow.NodeSocketConnection{
+		FlowNodeID:    n.FlowNodeID,
+		Network:       mflow.SocketNetwork(n.Network),
+		Address:       n.Address,
+		TLS:           n.Tls,
+		TLSSkipVerify: n.TlsSkipVerify,
+		Framing:       mflow.SocketFraming(n.Framing),
+		Delimiter:     n.Delimiter,
+		LengthBytes:   int32(n.LengthBytes), //nolint:gosec // stored from an int32
+		TimeoutMs:     n.TimeoutMs,
+	}
+}
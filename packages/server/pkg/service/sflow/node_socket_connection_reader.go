@@ -0,0 +1,30 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSocketConnectionReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeSocketConnectionReaderFromQueries(queries *gen.Queries) *NodeSocketConnectionReader {
+	return &NodeSocketConnectionReader{queries: queries}
+}
+
+func (r *NodeSocketConnectionReader) GetNodeSocketConnection(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeSocketConnection, error) {
+	nodeSocketConnection, err := r.queries.GetFlowNodeSocketConnection(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertToModelNodeSocketConnection(nodeSocketConnection), nil
+}
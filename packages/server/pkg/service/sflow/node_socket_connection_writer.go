@@ -0,0 +1,35 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSocketConnectionWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeSocketConnectionWriter(tx gen.DBTX) *NodeSocketConnectionWriter {
+	return &NodeSocketConnectionWriter{queries: gen.New(tx)}
+}
+
+func NewNodeSocketConnectionWriterFromQueries(queries *gen.Queries) *NodeSocketConnectionWriter {
+	return &NodeSocketConnectionWriter{queries: queries}
+}
+
+func (w *NodeSocketConnectionWriter) CreateNodeSocketConnection(ctx context.Context, n mflow.NodeSocketConnection) error {
+	dbModel := ConvertToDBNodeSocketConnection(n)
+	return w.queries.CreateFlowNodeSocketConnection(ctx, gen.CreateFlowNodeSocketConnectionParams(dbModel))
+}
+
+func (w *NodeSocketConnectionWriter) UpdateNodeSocketConnection(ctx context.Context, n mflow.NodeSocketConnection) error {
+	dbModel := ConvertToDBNodeSocketConnection(n)
+	return w.queries.UpdateFlowNodeSocketConnection(ctx, gen.UpdateFlowNodeSocketConnectionParams(dbModel))
+}
+
+func (w *NodeSocketConnectionWriter) DeleteNodeSocketConnection(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeSocketConnection(ctx, id)
+}
@@ -0,0 +1,49 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSocketReceiveService struct {
+	reader  *NodeSocketReceiveReader
+	queries *gen.Queries
+}
+
+func NewNodeSocketReceiveService(queries *gen.Queries) NodeSocketReceiveService {
+	return NodeSocketReceiveService{
+		reader:  NewNodeSocketReceiveReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeSocketReceiveService) TX(tx *sql.Tx) NodeSocketReceiveService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeSocketReceiveService{
+		reader:  NewNodeSocketReceiveReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeSocketReceiveService) GetNodeSocketReceive(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeSocketReceive, error) {
+	return s.reader.GetNodeSocketReceive(ctx, id)
+}
+
+func (s NodeSocketReceiveService) CreateNodeSocketReceive(ctx context.Context, n mflow.NodeSocketReceive) error {
+	return NewNodeSocketReceiveWriterFromQueries(s.queries).CreateNodeSocketReceive(ctx, n)
+}
+
+func (s NodeSocketReceiveService) UpdateNodeSocketReceive(ctx context.Context, n mflow.NodeSocketReceive) error {
+	return NewNodeSocketReceiveWriterFromQueries(s.queries).UpdateNodeSocketReceive(ctx, n)
+}
+
+func (s NodeSocketReceiveService) DeleteNodeSocketReceive(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeSocketReceiveWriterFromQueries(s.queries).DeleteNodeSocketReceive(ctx, id)
+}
+
+func (s NodeSocketReceiveService) Reader() *NodeSocketReceiveReader { return s.reader }
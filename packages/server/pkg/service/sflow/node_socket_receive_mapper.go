@@ -0,0 +1,38 @@
+package sflow
+
+import (
+	"encoding/json"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertToDBNodeSocketReceive(n mflow.NodeSocketReceive) gen.FlowNodeSocketReceive {
+	assertions, _ := json.Marshal(n.Assertions)
+	if assertions == nil || string(assertions) == "null" {
+		assertions = []byte("[]")
+	}
+	return gen.FlowNodeSocketReceive{
+		FlowNodeID:               n.FlowNodeID,
+		SocketConnectionNodeName: n.SocketConnectionNodeName,
+		MatchExpression:          n.Match,
+		TimeoutMs:                n.TimeoutMs,
+		Count:                    int64(n.Count),
+		Assertions:               assertions,
+	}
+}
+
+func ConvertToModelNodeSocketReceive(n gen.FlowNodeSocketReceive) *mflow.NodeSocketReceive {
+	var assertions []string
+	if len(n.Assertions) > 0 {
+		_ = json.Unmarshal(n.Assertions, &assertions)
+	}
+	return &mflow.NodeSocketReceive{
+		FlowNodeID:               n.FlowNodeID,
+		SocketConnectionNodeName: n.SocketConnectionNodeName,
+		Match:                    n.MatchExpression,
+		TimeoutMs:                n.TimeoutMs,
+		Count:                    int32(n.Count), //nolint:gosec // stored from an int32
+		Assertions:               assertions,
+	}
+}
@@ -0,0 +1,30 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSocketReceiveReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeSocketReceiveReaderFromQueries(queries *gen.Queries) *NodeSocketReceiveReader {
+	return &NodeSocketReceiveReader{queries: queries}
+}
+
+func (r *NodeSocketReceiveReader) GetNodeSocketReceive(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeSocketReceive, error) {
+	nodeSocketReceive, err := r.queries.GetFlowNodeSocketReceive(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertToModelNodeSocketReceive(nodeSocketReceive), nil
+}
@@ -0,0 +1,35 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSocketReceiveWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeSocketReceiveWriter(tx gen.DBTX) *NodeSocketReceiveWriter {
+	return &NodeSocketReceiveWriter{queries: gen.New(tx)}
+}
+
+func NewNodeSocketReceiveWriterFromQueries(queries *gen.Queries) *NodeSocketReceiveWriter {
+	return &NodeSocketReceiveWriter{queries: queries}
+}
+
+func (w *NodeSocketReceiveWriter) CreateNodeSocketReceive(ctx context.Context, n mflow.NodeSocketReceive) error {
+	dbModel := ConvertToDBNodeSocketReceive(n)
+	return w.queries.CreateFlowNodeSocketReceive(ctx, gen.CreateFlowNodeSocketReceiveParams(dbModel))
+}
+
+func (w *NodeSocketReceiveWriter) UpdateNodeSocketReceive(ctx context.Context, n mflow.NodeSocketReceive) error {
+	dbModel := ConvertToDBNodeSocketReceive(n)
+	return w.queries.UpdateFlowNodeSocketReceive(ctx, gen.UpdateFlowNodeSocketReceiveParams(dbModel))
+}
+
+func (w *NodeSocketReceiveWriter) DeleteNodeSocketReceive(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeSocketReceive(ctx, id)
+}
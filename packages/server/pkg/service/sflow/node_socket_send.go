@@ -0,0 +1,49 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSocketSendService struct {
+	reader  *NodeSocketSendReader
+	queries *gen.Queries
+}
+
+func NewNodeSocketSendService(queries *gen.Queries) NodeSocketSendService {
+	return NodeSocketSendService{
+		reader:  NewNodeSocketSendReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeSocketSendService) TX(tx *sql.Tx) NodeSocketSendService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeSocketSendService{
+		reader:  NewNodeSocketSendReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeSocketSendService) GetNodeSocketSend(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeSocketSend, error) {
+	return s.reader.GetNodeSocketSend(ctx, id)
+}
+
+func (s NodeSocketSendService) CreateNodeSocketSend(ctx context.Context, n mflow.NodeSocketSend) error {
+	return NewNodeSocketSendWriterFromQueries(s.queries).CreateNodeSocketSend(ctx, n)
+}
+
+func (s NodeSocketSendService) UpdateNodeSocketSend(ctx context.Context, n mflow.NodeSocketSend) error {
+	return NewNodeSocketSendWriterFromQueries(s.queries).UpdateNodeSocketSend(ctx, n)
+}
+
+func (s NodeSocketSendService) DeleteNodeSocketSend(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeSocketSendWriterFromQueries(s.queries).DeleteNodeSocketSend(ctx, id)
+}
+
+func (s NodeSocketSendService) Reader() *NodeSocketSendReader { return s.reader }
@@ -0,0 +1,24 @@
+package sflow
+
+import (
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertToDBNodeSocketSend(n mflow.NodeSocketSend) gen.FlowNodeSocketSend {
+	return gen.FlowNodeSocketSend{
+		FlowNodeID:               n.FlowNodeID,
+		SocketConnectionNodeName: n.SocketConnectionNodeName,
+		Message:                  n.Message,
+		Encoding:                 int8(n.Encoding),
+	}
+}
+
+func ConvertToModelNodeSocketSend(n gen.FlowNodeSocketSend) *mflow.NodeSocketSend {
+	return &mflow.NodeSocketSend{
+		FlowNodeID:               n.FlowNodeID,
+		SocketConnectionNodeName: n.SocketConnectionNodeName,
+		Message:                  n.Message,
+		Encoding:                 mflow.SocketEncoding(n.Encoding),
+	}
+}
@@ -0,0 +1,30 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSocketSendReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeSocketSendReaderFromQueries(queries *gen.Queries) *NodeSocketSendReader {
+	return &NodeSocketSendReader{queries: queries}
+}
+
+func (r *NodeSocketSendReader) GetNodeSocketSend(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeSocketSend, error) {
+	nodeSocketSend, err := r.queries.GetFlowNodeSocketSend(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertToModelNodeSocketSend(nodeSocketSend), nil
+}
@@ -0,0 +1,35 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSocketSendWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeSocketSendWriter(tx gen.DBTX) *NodeSocketSendWriter {
+	return &NodeSocketSendWriter{queries: gen.New(tx)}
+}
+
+func NewNodeSocketSendWriterFromQueries(queries *gen.Queries) *NodeSocketSendWriter {
+	return &NodeSocketSendWriter{queries: queries}
+}
+
+func (w *NodeSocketSendWriter) CreateNodeSocketSend(ctx context.Context, n mflow.NodeSocketSend) error {
+	dbModel := ConvertToDBNodeSocketSend(n)
+	return w.queries.CreateFlowNodeSocketSend(ctx, gen.CreateFlowNodeSocketSendParams(dbModel))
+}
+
+func (w *NodeSocketSendWriter) UpdateNodeSocketSend(ctx context.Context, n mflow.NodeSocketSend) error {
+	dbModel := ConvertToDBNodeSocketSend(n)
+	return w.queries.UpdateFlowNodeSocketSend(ctx, gen.UpdateFlowNodeSocketSendParams(dbModel))
+}
+
+func (w *NodeSocketSendWriter) DeleteNodeSocketSend(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeSocketSend(ctx, id)
+}
@@ -0,0 +1,51 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+var ErrNoNodeSwitchFound = sql.ErrNoRows
+
+type NodeSwitchService struct {
+	reader  *NodeSwitchReader
+	queries *gen.Queries
+}
+
+func NewNodeSwitchService(queries *gen.Queries) NodeSwitchService {
+	return NodeSwitchService{
+		reader:  NewNodeSwitchReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeSwitchService) TX(tx *sql.Tx) NodeSwitchService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeSwitchService{
+		reader:  NewNodeSwitchReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeSwitchService) GetNodeSwitch(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeSwitch, error) {
+	return s.reader.GetNodeSwitch(ctx, id)
+}
+
+func (s NodeSwitchService) CreateNodeSwitch(ctx context.Context, mn mflow.NodeSwitch) error {
+	return NewNodeSwitchWriterFromQueries(s.queries).CreateNodeSwitch(ctx, mn)
+}
+
+func (s NodeSwitchService) UpdateNodeSwitch(ctx context.Context, mn mflow.NodeSwitch) error {
+	return NewNodeSwitchWriterFromQueries(s.queries).UpdateNodeSwitch(ctx, mn)
+}
+
+func (s NodeSwitchService) DeleteNodeSwitch(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeSwitchWriterFromQueries(s.queries).DeleteNodeSwitch(ctx, id)
+}
+
+func (s NodeSwitchService) Reader() *NodeSwitchReader { return s.reader }
@@ -0,0 +1,30 @@
+package sflow
+
+import (
+	"encoding/json"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertDBToNodeSwitch(row gen.FlowNodeSwitch) *mflow.NodeSwitch {
+	var cases []mflow.SwitchCase
+	if len(row.Cases) > 0 {
+		_ = json.Unmarshal(row.Cases, &cases)
+	}
+	return &mflow.NodeSwitch{
+		FlowNodeID: row.FlowNodeID,
+		Cases:      cases,
+	}
+}
+
+func ConvertNodeSwitchToDB(m mflow.NodeSwitch) gen.FlowNodeSwitch {
+	cases, _ := json.Marshal(m.Cases)
+	if cases == nil || string(cases) == "null" {
+		cases = []byte("[]")
+	}
+	return gen.FlowNodeSwitch{
+		FlowNodeID: m.FlowNodeID,
+		Cases:      cases,
+	}
+}
@@ -0,0 +1,34 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSwitchReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeSwitchReader(db *sql.DB) *NodeSwitchReader {
+	return &NodeSwitchReader{queries: gen.New(db)}
+}
+
+func NewNodeSwitchReaderFromQueries(queries *gen.Queries) *NodeSwitchReader {
+	return &NodeSwitchReader{queries: queries}
+}
+
+func (r *NodeSwitchReader) GetNodeSwitch(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeSwitch, error) {
+	nodeSwitch, err := r.queries.GetFlowNodeSwitch(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertDBToNodeSwitch(nodeSwitch), nil
+}
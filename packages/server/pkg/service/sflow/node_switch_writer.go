@@ -0,0 +1,38 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeSwitchWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeSwitchWriter(tx gen.DBTX) *NodeSwitchWriter {
+	return &NodeSwitchWriter{queries: gen.New(tx)}
+}
+
+func NewNodeSwitchWriterFromQueries(queries *gen.Queries) *NodeSwitchWriter {
+	return &NodeSwitchWriter{queries: queries}
+}
+
+func (w *NodeSwitchWriter) CreateNodeSwitch(ctx context.Context, mn mflow.NodeSwitch) error {
+	nodeSwitch := ConvertNodeSwitchToDB(mn)
+	return w.queries.CreateFlowNodeSwitch(ctx, gen.CreateFlowNodeSwitchParams(nodeSwitch))
+}
+
+func (w *NodeSwitchWriter) UpdateNodeSwitch(ctx context.Context, mn mflow.NodeSwitch) error {
+	nodeSwitch := ConvertNodeSwitchToDB(mn)
+	return w.queries.UpdateFlowNodeSwitch(ctx, gen.UpdateFlowNodeSwitchParams{
+		Cases:      nodeSwitch.Cases,
+		FlowNodeID: nodeSwitch.FlowNodeID,
+	})
+}
+
+func (w *NodeSwitchWriter) DeleteNodeSwitch(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeSwitch(ctx, id)
+}
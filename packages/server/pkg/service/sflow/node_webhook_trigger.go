@@ -0,0 +1,57 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+var ErrNoNodeWebhookTriggerFound = sql.ErrNoRows
+
+type NodeWebhookTriggerService struct {
+	reader  *NodeWebhookTriggerReader
+	queries *gen.Queries
+}
+
+func NewNodeWebhookTriggerService(queries *gen.Queries) NodeWebhookTriggerService {
+	return NodeWebhookTriggerService{
+		reader:  NewNodeWebhookTriggerReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeWebhookTriggerService) TX(tx *sql.Tx) NodeWebhookTriggerService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeWebhookTriggerService{
+		reader:  NewNodeWebhookTriggerReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeWebhookTriggerService) GetNodeWebhookTrigger(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeWebhookTrigger, error) {
+	return s.reader.GetNodeWebhookTrigger(ctx, id)
+}
+
+// GetNodeWebhookTriggersByPath returns the webhook triggers listening on a
+// path, ignoring those of flow versions.
+func (s NodeWebhookTriggerService) GetNodeWebhookTriggersByPath(ctx context.Context, path string) ([]mflow.FlowWebhookTrigger, error) {
+	return s.reader.GetNodeWebhookTriggersByPath(ctx, path)
+}
+
+func (s NodeWebhookTriggerService) CreateNodeWebhookTrigger(ctx context.Context, mn mflow.NodeWebhookTrigger) error {
+	return NewNodeWebhookTriggerWriterFromQueries(s.queries).CreateNodeWebhookTrigger(ctx, mn)
+}
+
+func (s NodeWebhookTriggerService) UpdateNodeWebhookTrigger(ctx context.Context, mn mflow.NodeWebhookTrigger) error {
+	return NewNodeWebhookTriggerWriterFromQueries(s.queries).UpdateNodeWebhookTrigger(ctx, mn)
+}
+
+func (s NodeWebhookTriggerService) DeleteNodeWebhookTrigger(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeWebhookTriggerWriterFromQueries(s.queries).DeleteNodeWebhookTrigger(ctx, id)
+}
+
+func (s NodeWebhookTriggerService) Reader() *NodeWebhookTriggerReader { return s.reader }
@@ -0,0 +1,26 @@
+package sflow
+
+import (
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertDBToNodeWebhookTrigger(row gen.FlowNodeWebhookTrigger) *mflow.NodeWebhookTrigger {
+	return &mflow.NodeWebhookTrigger{
+		FlowNodeID:      row.FlowNodeID,
+		Path:            row.Path,
+		Method:          row.Method,
+		Secret:          row.Secret,
+		SignatureHeader: row.SignatureHeader,
+	}
+}
+
+func ConvertNodeWebhookTriggerToDB(m mflow.NodeWebhookTrigger) gen.FlowNodeWebhookTrigger {
+	return gen.FlowNodeWebhookTrigger{
+		FlowNodeID:      m.FlowNodeID,
+		Path:            mflow.NormalizeWebhookPath(m.Path),
+		Method:          m.Method,
+		Secret:          m.Secret,
+		SignatureHeader: m.SignatureHeader,
+	}
+}
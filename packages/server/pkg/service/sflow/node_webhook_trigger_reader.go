@@ -0,0 +1,55 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeWebhookTriggerReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeWebhookTriggerReader(db *sql.DB) *NodeWebhookTriggerReader {
+	return &NodeWebhookTriggerReader{queries: gen.New(db)}
+}
+
+func NewNodeWebhookTriggerReaderFromQueries(queries *gen.Queries) *NodeWebhookTriggerReader {
+	return &NodeWebhookTriggerReader{queries: queries}
+}
+
+func (r *NodeWebhookTriggerReader) GetNodeWebhookTrigger(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeWebhookTrigger, error) {
+	trigger, err := r.queries.GetFlowNodeWebhookTrigger(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertDBToNodeWebhookTrigger(trigger), nil
+}
+
+func (r *NodeWebhookTriggerReader) GetNodeWebhookTriggersByPath(ctx context.Context, path string) ([]mflow.FlowWebhookTrigger, error) {
+	rows, err := r.queries.GetFlowNodeWebhookTriggersByPath(ctx, mflow.NormalizeWebhookPath(path))
+	if err != nil {
+		return nil, err
+	}
+	triggers := make([]mflow.FlowWebhookTrigger, 0, len(rows))
+	for _, row := range rows {
+		triggers = append(triggers, mflow.FlowWebhookTrigger{
+			FlowID: row.FlowID,
+			NodeWebhookTrigger: *ConvertDBToNodeWebhookTrigger(gen.FlowNodeWebhookTrigger{
+				FlowNodeID:      row.FlowNodeID,
+				Path:            row.Path,
+				Method:          row.Method,
+				Secret:          row.Secret,
+				SignatureHeader: row.SignatureHeader,
+			}),
+		})
+	}
+	return triggers, nil
+}
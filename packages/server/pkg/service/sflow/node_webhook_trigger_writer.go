@@ -0,0 +1,41 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeWebhookTriggerWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeWebhookTriggerWriter(tx gen.DBTX) *NodeWebhookTriggerWriter {
+	return &NodeWebhookTriggerWriter{queries: gen.New(tx)}
+}
+
+func NewNodeWebhookTriggerWriterFromQueries(queries *gen.Queries) *NodeWebhookTriggerWriter {
+	return &NodeWebhookTriggerWriter{queries: queries}
+}
+
+func (w *NodeWebhookTriggerWriter) CreateNodeWebhookTrigger(ctx context.Context, mn mflow.NodeWebhookTrigger) error {
+	trigger := ConvertNodeWebhookTriggerToDB(mn)
+	return w.queries.CreateFlowNodeWebhookTrigger(ctx, gen.CreateFlowNodeWebhookTriggerParams(trigger))
+}
+
+func (w *NodeWebhookTriggerWriter) UpdateNodeWebhookTrigger(ctx context.Context, mn mflow.NodeWebhookTrigger) error {
+	trigger := ConvertNodeWebhookTriggerToDB(mn)
+	return w.queries.UpdateFlowNodeWebhookTrigger(ctx, gen.UpdateFlowNodeWebhookTriggerParams{
+		Path:            trigger.Path,
+		Method:          trigger.Method,
+		Secret:          trigger.Secret,
+		SignatureHeader: trigger.SignatureHeader,
+		FlowNodeID:      trigger.FlowNodeID,
+	})
+}
+
+func (w *NodeWebhookTriggerWriter) DeleteNodeWebhookTrigger(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeWebhookTrigger(ctx, id)
+}
@@ -0,0 +1,49 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeWsExpectService struct {
+	reader  *NodeWsExpectReader
+	queries *gen.Queries
+}
+
+func NewNodeWsExpectService(queries *gen.Queries) NodeWsExpectService {
+	return NodeWsExpectService{
+		reader:  NewNodeWsExpectReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s NodeWsExpectService) TX(tx *sql.Tx) NodeWsExpectService {
+	newQueries := s.queries.WithTx(tx)
+	return NodeWsExpectService{
+		reader:  NewNodeWsExpectReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s NodeWsExpectService) GetNodeWsExpect(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeWsExpect, error) {
+	return s.reader.GetNodeWsExpect(ctx, id)
+}
+
+func (s NodeWsExpectService) CreateNodeWsExpect(ctx context.Context, n mflow.NodeWsExpect) error {
+	return NewNodeWsExpectWriterFromQueries(s.queries).CreateNodeWsExpect(ctx, n)
+}
+
+func (s NodeWsExpectService) UpdateNodeWsExpect(ctx context.Context, n mflow.NodeWsExpect) error {
+	return NewNodeWsExpectWriterFromQueries(s.queries).UpdateNodeWsExpect(ctx, n)
+}
+
+func (s NodeWsExpectService) DeleteNodeWsExpect(ctx context.Context, id idwrap.IDWrap) error {
+	return NewNodeWsExpectWriterFromQueries(s.queries).DeleteNodeWsExpect(ctx, id)
+}
+
+func (s NodeWsExpectService) Reader() *NodeWsExpectReader { return s.reader }
@@ -0,0 +1,46 @@
+package sflow
+
+import (
+	"encoding/json"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertToDBNodeWsExpect(n mflow.NodeWsExpect) gen.FlowNodeWsExpect {
+	assertions, _ := json.Marshal(n.Assertions)
+	if assertions == nil || string(assertions) == "null" {
+		assertions = []byte("[]")
+	}
+	return gen.FlowNodeWsExpect{
+		FlowNodeID:           n.FlowNodeID,
+		WsConnectionNodeName: n.WsConnectionNodeName,
+		Message:              n.Message,
+		Binary:               n.Binary,
+		MatchExpression:      n.Match,
+		TimeoutMs:            n.TimeoutMs,
+		Count:                int64(n.Count),
+		Assertions:           assertions,
+		Ping:                 n.Ping,
+		ExpectClose:          n.ExpectClose,
+	}
+}
+
+func ConvertToModelNodeWsExpect(n gen.FlowNodeWsExpect) *mflow.NodeWsExpect {
+	var assertions []string
+	if len(n.Assertions) > 0 {
+		_ = json.Unmarshal(n.Assertions, &assertions)
+	}
+	return &mflow.NodeWsExpect{
+		FlowNodeID:           n.FlowNodeID,
+		WsConnectionNodeName: n.WsConnectionNodeName,
+		Message:              n.Message,
+		Binary:               n.Binary,
+		Match:                n.MatchExpression,
+		TimeoutMs:            n.TimeoutMs,
+		Count:                int32(n.Count), //nolint:gosec // stored from an int32
+		Assertions:           assertions,
+		Ping:                 n.Ping,
+		ExpectClose:          n.ExpectClose,
+	}
+}
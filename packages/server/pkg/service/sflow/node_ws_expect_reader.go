@@ -0,0 +1,30 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeWsExpectReader struct {
+	queries *gen.Queries
+}
+
+func NewNodeWsExpectReaderFromQueries(queries *gen.Queries) *NodeWsExpectReader {
+	return &NodeWsExpectReader{queries: queries}
+}
+
+func (r *NodeWsExpectReader) GetNodeWsExpect(ctx context.Context, id idwrap.IDWrap) (*mflow.NodeWsExpect, error) {
+	nodeWsExpect, err := r.queries.GetFlowNodeWsExpect(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ConvertToModelNodeWsExpect(nodeWsExpect), nil
+}
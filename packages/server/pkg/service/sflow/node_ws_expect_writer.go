@@ -0,0 +1,35 @@
+package sflow
+
+import (
+	"context"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type NodeWsExpectWriter struct {
+	queries *gen.Queries
+}
+
+func NewNodeWsExpectWriter(tx gen.DBTX) *NodeWsExpectWriter {
+	return &NodeWsExpectWriter{queries: gen.New(tx)}
+}
+
+func NewNodeWsExpectWriterFromQueries(queries *gen.Queries) *NodeWsExpectWriter {
+	return &NodeWsExpectWriter{queries: queries}
+}
+
+func (w *NodeWsExpectWriter) CreateNodeWsExpect(ctx context.Context, n mflow.NodeWsExpect) error {
+	dbModel := ConvertToDBNodeWsExpect(n)
+	return w.queries.CreateFlowNodeWsExpect(ctx, gen.CreateFlowNodeWsExpectParams(dbModel))
+}
+
+func (w *NodeWsExpectWriter) UpdateNodeWsExpect(ctx context.Context, n mflow.NodeWsExpect) error {
+	dbModel := ConvertToDBNodeWsExpect(n)
+	return w.queries.UpdateFlowNodeWsExpect(ctx, gen.UpdateFlowNodeWsExpectParams(dbModel))
+}
+
+func (w *NodeWsExpectWriter) DeleteNodeWsExpect(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowNodeWsExpect(ctx, id)
+}
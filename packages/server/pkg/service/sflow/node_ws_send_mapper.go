@@ -10,6 +10,9 @@ func ConvertToDBNodeWsSend(n mflow.NodeWsSend) gen.FlowNodeWsSend {
 		FlowNodeID:           n.FlowNodeID,
 		WsConnectionNodeName: n.WsConnectionNodeName,
 		Message:              n.Message,
+		Binary:               n.Binary,
+		Event:                n.Event,
+		Ack:                  n.Ack,
 	}
 }
 
@@ -18,5 +21,8 @@ func ConvertToModelNodeWsSend(n gen.FlowNodeWsSend) *mflow.NodeWsSend {
 		FlowNodeID:           n.FlowNodeID,
 		WsConnectionNodeName: n.WsConnectionNodeName,
 		Message:              n.Message,
+		Binary:               n.Binary,
+		Event:                n.Event,
+		Ack:                  n.Ack,
 	}
 }
@@ -0,0 +1,76 @@
+//nolint:revive // exported
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+var ErrNoFlowScheduleFound = sql.ErrNoRows
+
+type FlowScheduleService struct {
+	reader  *FlowScheduleReader
+	queries *gen.Queries
+}
+
+func NewFlowScheduleService(queries *gen.Queries) FlowScheduleService {
+	return FlowScheduleService{
+		reader:  NewFlowScheduleReaderFromQueries(queries),
+		queries: queries,
+	}
+}
+
+func (s FlowScheduleService) TX(tx *sql.Tx) FlowScheduleService {
+	newQueries := s.queries.WithTx(tx)
+	return FlowScheduleService{
+		reader:  NewFlowScheduleReaderFromQueries(newQueries),
+		queries: newQueries,
+	}
+}
+
+func (s FlowScheduleService) GetFlowSchedule(ctx context.Context, id idwrap.IDWrap) (mflow.FlowSchedule, error) {
+	return s.reader.GetFlowSchedule(ctx, id)
+}
+
+func (s FlowScheduleService) GetFlowSchedulesByFlowID(ctx context.Context, flowID idwrap.IDWrap) ([]mflow.FlowSchedule, error) {
+	return s.reader.GetFlowSchedulesByFlowID(ctx, flowID)
+}
+
+func (s FlowScheduleService) GetEnabledFlowSchedules(ctx context.Context) ([]mflow.FlowSchedule, error) {
+	return s.reader.GetEnabledFlowSchedules(ctx)
+}
+
+func (s FlowScheduleService) GetFlowScheduleRuns(ctx context.Context, scheduleID idwrap.IDWrap) ([]mflow.FlowScheduleRun, error) {
+	return s.reader.GetFlowScheduleRuns(ctx, scheduleID)
+}
+
+func (s FlowScheduleService) CreateFlowSchedule(ctx context.Context, schedule mflow.FlowSchedule) error {
+	return NewFlowScheduleWriterFromQueries(s.queries).CreateFlowSchedule(ctx, schedule)
+}
+
+func (s FlowScheduleService) UpdateFlowSchedule(ctx context.Context, schedule mflow.FlowSchedule) error {
+	return NewFlowScheduleWriterFromQueries(s.queries).UpdateFlowSchedule(ctx, schedule)
+}
+
+func (s FlowScheduleService) UpdateFlowScheduleLastRun(ctx context.Context, id idwrap.IDWrap, lastRunAt int64, state mflow.NodeState) error {
+	return NewFlowScheduleWriterFromQueries(s.queries).UpdateFlowScheduleLastRun(ctx, id, lastRunAt, state)
+}
+
+func (s FlowScheduleService) DeleteFlowSchedule(ctx context.Context, id idwrap.IDWrap) error {
+	return NewFlowScheduleWriterFromQueries(s.queries).DeleteFlowSchedule(ctx, id)
+}
+
+func (s FlowScheduleService) CreateFlowScheduleRun(ctx context.Context, run mflow.FlowScheduleRun) error {
+	return NewFlowScheduleWriterFromQueries(s.queries).CreateFlowScheduleRun(ctx, run)
+}
+
+// PruneFlowScheduleRuns deletes all but the keep most recent runs of a schedule.
+func (s FlowScheduleService) PruneFlowScheduleRuns(ctx context.Context, scheduleID idwrap.IDWrap, keep int) error {
+	return NewFlowScheduleWriterFromQueries(s.queries).PruneFlowScheduleRuns(ctx, scheduleID, keep)
+}
+
+func (s FlowScheduleService) Reader() *FlowScheduleReader { return s.reader }
@@ -0,0 +1,57 @@
+package sflow
+
+import (
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func ConvertDBToFlowSchedule(fs gen.FlowSchedule) mflow.FlowSchedule {
+	var lastRunAt *int64
+	if fs.LastRunAt.Valid {
+		lastRunAt = &fs.LastRunAt.Int64
+	}
+	return mflow.FlowSchedule{
+		ID:              fs.ID,
+		FlowID:          fs.FlowID,
+		CronExpression:  fs.CronExpression,
+		IntervalSeconds: fs.IntervalSeconds,
+		EnvironmentID:   fs.EnvironmentID,
+		Enabled:         fs.Enabled,
+		LastRunAt:       lastRunAt,
+		LastState:       fs.LastState,
+	}
+}
+
+func ConvertDBToFlowScheduleRun(run gen.FlowScheduleRun) mflow.FlowScheduleRun {
+	var errorPtr *string
+	if run.Error.Valid {
+		errorPtr = &run.Error.String
+	}
+	return mflow.FlowScheduleRun{
+		ID:            run.ID,
+		ScheduleID:    run.ScheduleID,
+		FlowVersionID: run.FlowVersionID,
+		State:         run.State,
+		Error:         errorPtr,
+		StartedAt:     run.StartedAt,
+		DurationMs:    run.DurationMs,
+	}
+}
+
+func ConvertFlowScheduleRunToDB(run mflow.FlowScheduleRun) gen.FlowScheduleRun {
+	var errorSQL sql.NullString
+	if run.Error != nil {
+		errorSQL = sql.NullString{String: *run.Error, Valid: true}
+	}
+	return gen.FlowScheduleRun{
+		ID:            run.ID,
+		ScheduleID:    run.ScheduleID,
+		FlowVersionID: run.FlowVersionID,
+		State:         run.State,
+		Error:         errorSQL,
+		StartedAt:     run.StartedAt,
+		DurationMs:    run.DurationMs,
+	}
+}
@@ -0,0 +1,67 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type FlowScheduleReader struct {
+	queries *gen.Queries
+}
+
+func NewFlowScheduleReader(db *sql.DB) *FlowScheduleReader {
+	return &FlowScheduleReader{queries: gen.New(db)}
+}
+
+func NewFlowScheduleReaderFromQueries(queries *gen.Queries) *FlowScheduleReader {
+	return &FlowScheduleReader{queries: queries}
+}
+
+func (r *FlowScheduleReader) GetFlowSchedule(ctx context.Context, id idwrap.IDWrap) (mflow.FlowSchedule, error) {
+	schedule, err := r.queries.GetFlowSchedule(ctx, id)
+	if err != nil {
+		return mflow.FlowSchedule{}, err
+	}
+	return ConvertDBToFlowSchedule(schedule), nil
+}
+
+func (r *FlowScheduleReader) GetFlowSchedulesByFlowID(ctx context.Context, flowID idwrap.IDWrap) ([]mflow.FlowSchedule, error) {
+	schedules, err := r.queries.GetFlowSchedulesByFlowID(ctx, flowID)
+	if err != nil {
+		return nil, err
+	}
+	return convertFlowSchedules(schedules), nil
+}
+
+func (r *FlowScheduleReader) GetEnabledFlowSchedules(ctx context.Context) ([]mflow.FlowSchedule, error) {
+	schedules, err := r.queries.GetEnabledFlowSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertFlowSchedules(schedules), nil
+}
+
+// GetFlowScheduleRuns returns the runs of a schedule, most recent first.
+func (r *FlowScheduleReader) GetFlowScheduleRuns(ctx context.Context, scheduleID idwrap.IDWrap) ([]mflow.FlowScheduleRun, error) {
+	runs, err := r.queries.GetFlowScheduleRunsByScheduleID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]mflow.FlowScheduleRun, len(runs))
+	for i, run := range runs {
+		result[i] = ConvertDBToFlowScheduleRun(run)
+	}
+	return result, nil
+}
+
+func convertFlowSchedules(schedules []gen.FlowSchedule) []mflow.FlowSchedule {
+	result := make([]mflow.FlowSchedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = ConvertDBToFlowSchedule(schedule)
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package sflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/dbtest"
+	gen "github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+func TestFlowScheduleService(t *testing.T) {
+	ctx := context.Background()
+	db, err := dbtest.GetTestDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	queries := gen.New(db)
+	service := NewFlowScheduleService(queries)
+
+	flowID := idwrap.NewNow()
+	require.NoError(t, queries.CreateFlow(ctx, gen.CreateFlowParams{
+		ID:          flowID,
+		WorkspaceID: idwrap.NewNow(),
+		Name:        "Monitor",
+	}))
+
+	enabled := mflow.FlowSchedule{ID: idwrap.NewNow(), FlowID: flowID, CronExpression: "*/5 * * * *", Enabled: true}
+	disabled := mflow.FlowSchedule{ID: idwrap.NewNow(), FlowID: flowID, IntervalSeconds: 60}
+	require.NoError(t, service.CreateFlowSchedule(ctx, enabled))
+	require.NoError(t, service.CreateFlowSchedule(ctx, disabled))
+
+	byFlow, err := service.GetFlowSchedulesByFlowID(ctx, flowID)
+	require.NoError(t, err)
+	require.Len(t, byFlow, 2)
+
+	active, err := service.GetEnabledFlowSchedules(ctx)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	require.Equal(t, enabled.ID, active[0].ID)
+	require.Nil(t, active[0].LastRunAt)
+
+	require.NoError(t, service.UpdateFlowScheduleLastRun(ctx, enabled.ID, 1234, mflow.NODE_STATE_FAILURE))
+	got, err := service.GetFlowSchedule(ctx, enabled.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1234), *got.LastRunAt)
+	require.Equal(t, mflow.NODE_STATE_FAILURE, got.LastState)
+	require.Equal(t, "*/5 * * * *", got.CronExpression)
+
+	for i := range 5 {
+		msg := "boom"
+		require.NoError(t, service.CreateFlowScheduleRun(ctx, mflow.FlowScheduleRun{
+			ID:         idwrap.NewNow(),
+			ScheduleID: enabled.ID,
+			State:      mflow.NODE_STATE_FAILURE,
+			Error:      &msg,
+			StartedAt:  int64(i) * 1000,
+			DurationMs: 10,
+		}))
+	}
+	require.NoError(t, service.PruneFlowScheduleRuns(ctx, enabled.ID, 3))
+
+	runs, err := service.GetFlowScheduleRuns(ctx, enabled.ID)
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	require.Equal(t, []int64{4000, 3000, 2000}, []int64{runs[0].StartedAt, runs[1].StartedAt, runs[2].StartedAt})
+	require.Equal(t, "boom", *runs[0].Error)
+
+	require.NoError(t, service.DeleteFlowSchedule(ctx, enabled.ID))
+	_, err = service.GetFlowSchedule(ctx, enabled.ID)
+	require.ErrorIs(t, err, ErrNoFlowScheduleFound)
+}
@@ -0,0 +1,67 @@
+package sflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+)
+
+type FlowScheduleWriter struct {
+	queries *gen.Queries
+}
+
+func NewFlowScheduleWriter(tx gen.DBTX) *FlowScheduleWriter {
+	return &FlowScheduleWriter{queries: gen.New(tx)}
+}
+
+func NewFlowScheduleWriterFromQueries(queries *gen.Queries) *FlowScheduleWriter {
+	return &FlowScheduleWriter{queries: queries}
+}
+
+func (w *FlowScheduleWriter) CreateFlowSchedule(ctx context.Context, schedule mflow.FlowSchedule) error {
+	return w.queries.CreateFlowSchedule(ctx, gen.CreateFlowScheduleParams{
+		ID:              schedule.ID,
+		FlowID:          schedule.FlowID,
+		CronExpression:  schedule.CronExpression,
+		IntervalSeconds: schedule.IntervalSeconds,
+		EnvironmentID:   schedule.EnvironmentID,
+		Enabled:         schedule.Enabled,
+	})
+}
+
+func (w *FlowScheduleWriter) UpdateFlowSchedule(ctx context.Context, schedule mflow.FlowSchedule) error {
+	return w.queries.UpdateFlowSchedule(ctx, gen.UpdateFlowScheduleParams{
+		CronExpression:  schedule.CronExpression,
+		IntervalSeconds: schedule.IntervalSeconds,
+		EnvironmentID:   schedule.EnvironmentID,
+		Enabled:         schedule.Enabled,
+		ID:              schedule.ID,
+	})
+}
+
+func (w *FlowScheduleWriter) UpdateFlowScheduleLastRun(ctx context.Context, id idwrap.IDWrap, lastRunAt int64, state mflow.NodeState) error {
+	return w.queries.UpdateFlowScheduleLastRun(ctx, gen.UpdateFlowScheduleLastRunParams{
+		LastRunAt: sql.NullInt64{Int64: lastRunAt, Valid: true},
+		LastState: state,
+		ID:        id,
+	})
+}
+
+func (w *FlowScheduleWriter) DeleteFlowSchedule(ctx context.Context, id idwrap.IDWrap) error {
+	return w.queries.DeleteFlowSchedule(ctx, id)
+}
+
+func (w *FlowScheduleWriter) CreateFlowScheduleRun(ctx context.Context, run mflow.FlowScheduleRun) error {
+	return w.queries.CreateFlowScheduleRun(ctx, gen.CreateFlowScheduleRunParams(ConvertFlowScheduleRunToDB(run)))
+}
+
+func (w *FlowScheduleWriter) PruneFlowScheduleRuns(ctx context.Context, scheduleID idwrap.IDWrap, keep int) error {
+	return w.queries.PruneFlowScheduleRuns(ctx, gen.PruneFlowScheduleRunsParams{
+		ScheduleID:   scheduleID,
+		ScheduleID_2: scheduleID,
+		Limit:        int64(keep),
+	})
+}
@@ -1,6 +1,7 @@
 package sgraphql
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
@@ -8,6 +9,26 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mgraphql"
 )
 
+// marshalJSONList encodes a list column, storing an empty list as "[]".
+func marshalJSONList[T any](items []T) []byte {
+	b, _ := json.Marshal(items)
+	if b == nil || string(b) == "null" {
+		return []byte("[]")
+	}
+	return b
+}
+
+func unmarshalJSONList[T any](b []byte) []T {
+	var items []T
+	if len(b) > 0 {
+		_ = json.Unmarshal(b, &items)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return items
+}
+
 func interfaceToInt64Ptr(v interface{}) *int64 {
 	if v == nil {
 		return nil
@@ -108,6 +129,11 @@ func ConvertToDBGraphQL(gql mgraphql.GraphQL) gen.Graphql {
 		Url:              gql.Url,
 		Query:            gql.Query,
 		Variables:        gql.Variables,
+		OperationName:    gql.OperationName,
+		QueryFile:        gql.QueryFile,
+		PersistedQuery:   gql.PersistedQuery,
+		Batch:            marshalJSONList(gql.Batch),
+		Uploads:          marshalJSONList(gql.Uploads),
 		Description:      gql.Description,
 		ParentGraphqlID:  idWrapPtrToBytes(gql.ParentGraphQLID),
 		IsDelta:          gql.IsDelta,
@@ -132,6 +158,11 @@ func ConvertToModelGraphQL(gql gen.Graphql) *mgraphql.GraphQL {
 		Url:              gql.Url,
 		Query:            gql.Query,
 		Variables:        gql.Variables,
+		OperationName:    gql.OperationName,
+		QueryFile:        gql.QueryFile,
+		PersistedQuery:   gql.PersistedQuery,
+		Batch:            unmarshalJSONList[mgraphql.GraphQLOperation](gql.Batch),
+		Uploads:          unmarshalJSONList[mgraphql.GraphQLUpload](gql.Uploads),
 		Description:      gql.Description,
 		ParentGraphQLID:  bytesToIDWrapPtr(gql.ParentGraphqlID),
 		IsDelta:          gql.IsDelta,
@@ -0,0 +1,102 @@
+package sgraphql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/schema"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mgraphql"
+)
+
+var ErrNoGraphQLSchemaFound = errors.New("no graphql schema found")
+
+// GraphQLSchemaService stores the introspection result of each GraphQL
+// request and keeps the parsed schemas in memory, so validating a query
+// does not reparse the introspection JSON every run.
+type GraphQLSchemaService struct {
+	queries *gen.Queries
+	parsed  *parsedSchemas
+}
+
+// parsedSchemas caches parsed schemas by request, along with the
+// introspection JSON they were parsed from.
+type parsedSchemas struct {
+	mu      sync.Mutex
+	schemas map[idwrap.IDWrap]parsedSchema
+}
+
+type parsedSchema struct {
+	introspection string
+	schema        *schema.Schema
+}
+
+func NewGraphQLSchemaService(queries *gen.Queries) GraphQLSchemaService {
+	return GraphQLSchemaService{
+		queries: queries,
+		parsed:  &parsedSchemas{schemas: make(map[idwrap.IDWrap]parsedSchema)},
+	}
+}
+
+func (s GraphQLSchemaService) TX(tx *sql.Tx) GraphQLSchemaService {
+	return GraphQLSchemaService{queries: s.queries.WithTx(tx), parsed: s.parsed}
+}
+
+func (s GraphQLSchemaService) Get(ctx context.Context, graphqlID idwrap.IDWrap) (*mgraphql.GraphQLSchema, error) {
+	row, err := s.queries.GetGraphQLSchema(ctx, graphqlID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoGraphQLSchemaFound
+		}
+		return nil, err
+	}
+	return &mgraphql.GraphQLSchema{
+		GraphQLID:     row.GraphqlID,
+		Introspection: row.Introspection,
+		FetchedAt:     row.FetchedAt,
+	}, nil
+}
+
+func (s GraphQLSchemaService) Upsert(ctx context.Context, gqlSchema mgraphql.GraphQLSchema) error {
+	return s.queries.UpsertGraphQLSchema(ctx, gen.UpsertGraphQLSchemaParams{
+		GraphqlID:     gqlSchema.GraphQLID,
+		Introspection: gqlSchema.Introspection,
+		FetchedAt:     gqlSchema.FetchedAt,
+	})
+}
+
+func (s GraphQLSchemaService) Delete(ctx context.Context, graphqlID idwrap.IDWrap) error {
+	s.parsed.mu.Lock()
+	delete(s.parsed.schemas, graphqlID)
+	s.parsed.mu.Unlock()
+	return s.queries.DeleteGraphQLSchema(ctx, graphqlID)
+}
+
+// Schema returns the parsed schema of a request, or ErrNoGraphQLSchemaFound
+// when it has never been introspected. Parsed schemas are reused until a
+// different introspection result is stored.
+func (s GraphQLSchemaService) Schema(ctx context.Context, graphqlID idwrap.IDWrap) (*schema.Schema, error) {
+	stored, err := s.Get(ctx, graphqlID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.parsed.mu.Lock()
+	cached, ok := s.parsed.schemas[graphqlID]
+	s.parsed.mu.Unlock()
+	if ok && cached.introspection == stored.Introspection {
+		return cached.schema, nil
+	}
+
+	parsed, err := schema.Parse([]byte(stored.Introspection))
+	if err != nil {
+		return nil, err
+	}
+	s.parsed.mu.Lock()
+	s.parsed.schemas[graphqlID] = parsedSchema{introspection: stored.Introspection, schema: parsed}
+	s.parsed.mu.Unlock()
+	return parsed, nil
+}
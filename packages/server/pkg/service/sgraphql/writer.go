@@ -53,13 +53,18 @@ func (w *Writer) Update(ctx context.Context, gql *mgraphql.GraphQL) error {
 
 	// Update base fields
 	return w.queries.UpdateGraphQL(ctx, gen.UpdateGraphQLParams{
-		ID:          gql.ID,
-		Name:        gql.Name,
-		Url:         gql.Url,
-		Query:       gql.Query,
-		Variables:   gql.Variables,
-		Description: gql.Description,
-		LastRunAt:   lastRunAt,
+		ID:             gql.ID,
+		Name:           gql.Name,
+		Url:            gql.Url,
+		Query:          gql.Query,
+		Variables:      gql.Variables,
+		OperationName:  gql.OperationName,
+		QueryFile:      gql.QueryFile,
+		PersistedQuery: gql.PersistedQuery,
+		Batch:          dbGQL.Batch,
+		Uploads:        dbGQL.Uploads,
+		Description:    gql.Description,
+		LastRunAt:      lastRunAt,
 	})
 }
 
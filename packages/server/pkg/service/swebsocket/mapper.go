@@ -1,20 +1,47 @@
 package swebsocket
 
 import (
+	"encoding/json"
+
 	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
 )
 
+// marshalStrings encodes a JSON list column such as subprotocols, storing
+// none as "[]".
+func marshalStrings(values []string) []byte {
+	b, _ := json.Marshal(values)
+	if b == nil || string(b) == "null" {
+		return []byte("[]")
+	}
+	return b
+}
+
+func unmarshalStrings(b []byte) []string {
+	var values []string
+	if len(b) > 0 {
+		_ = json.Unmarshal(b, &values)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
 func convertToModelWebSocket(db gen.Websocket) *mwebsocket.WebSocket {
 	ws := &mwebsocket.WebSocket{
-		ID:          db.ID,
-		WorkspaceID: db.WorkspaceID,
-		FolderID:    db.FolderID,
-		Name:        db.Name,
-		Url:         db.Url,
-		Description: db.Description,
-		CreatedAt:   db.CreatedAt,
-		UpdatedAt:   db.UpdatedAt,
+		ID:             db.ID,
+		WorkspaceID:    db.WorkspaceID,
+		FolderID:       db.FolderID,
+		Name:           db.Name,
+		Url:            db.Url,
+		Description:    db.Description,
+		Subprotocols:   unmarshalStrings(db.Subprotocols),
+		Protocol:       mwebsocket.Protocol(db.Protocol),
+		ConnectPayload: db.ConnectPayload,
+		Subscriptions:  unmarshalStrings(db.Subscriptions),
+		CreatedAt:      db.CreatedAt,
+		UpdatedAt:      db.UpdatedAt,
 	}
 
 	if db.LastRunAt != nil {
@@ -28,14 +55,18 @@ func convertToModelWebSocket(db gen.Websocket) *mwebsocket.WebSocket {
 
 func convertToDBCreateWebSocket(ws mwebsocket.WebSocket) gen.CreateWebSocketParams {
 	p := gen.CreateWebSocketParams{
-		ID:          ws.ID,
-		WorkspaceID: ws.WorkspaceID,
-		FolderID:    ws.FolderID,
-		Name:        ws.Name,
-		Url:         ws.Url,
-		Description: ws.Description,
-		CreatedAt:   ws.CreatedAt,
-		UpdatedAt:   ws.UpdatedAt,
+		ID:             ws.ID,
+		WorkspaceID:    ws.WorkspaceID,
+		FolderID:       ws.FolderID,
+		Name:           ws.Name,
+		Url:            ws.Url,
+		Description:    ws.Description,
+		Subprotocols:   marshalStrings(ws.Subprotocols),
+		Protocol:       int8(ws.Protocol),
+		ConnectPayload: ws.ConnectPayload,
+		Subscriptions:  marshalStrings(ws.Subscriptions),
+		CreatedAt:      ws.CreatedAt,
+		UpdatedAt:      ws.UpdatedAt,
 	}
 	if ws.LastRunAt != nil {
 		p.LastRunAt = *ws.LastRunAt
@@ -56,3 +87,14 @@ func convertToModelHeader(db gen.WebsocketHeader) mwebsocket.WebSocketHeader {
 		UpdatedAt:    db.UpdatedAt,
 	}
 }
+
+func convertToModelMessage(db gen.WebsocketMessage) mwebsocket.WebSocketMessage {
+	return mwebsocket.WebSocketMessage{
+		ID:          db.ID,
+		WebSocketID: db.WebsocketID,
+		Direction:   db.Direction,
+		Type:        db.MessageType,
+		Data:        db.Data,
+		CreatedAt:   db.CreatedAt,
+	}
+}
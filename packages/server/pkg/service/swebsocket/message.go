@@ -0,0 +1,53 @@
+package swebsocket
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
+)
+
+// WebSocketMessageService stores the history of frames exchanged with a
+// WebSocket, from the UI proxy and from flow runs.
+type WebSocketMessageService struct {
+	queries *gen.Queries
+}
+
+func NewWebSocketMessageService(queries *gen.Queries) WebSocketMessageService {
+	return WebSocketMessageService{queries: queries}
+}
+
+func (s WebSocketMessageService) TX(tx *sql.Tx) WebSocketMessageService {
+	return WebSocketMessageService{queries: s.queries.WithTx(tx)}
+}
+
+// GetByWebSocketID returns the history of a WebSocket, oldest first.
+func (s WebSocketMessageService) GetByWebSocketID(ctx context.Context, wsID idwrap.IDWrap) ([]mwebsocket.WebSocketMessage, error) {
+	messages, err := s.queries.GetWebSocketMessages(ctx, wsID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]mwebsocket.WebSocketMessage, len(messages))
+	for i, m := range messages {
+		result[i] = convertToModelMessage(m)
+	}
+	return result, nil
+}
+
+func (s WebSocketMessageService) Create(ctx context.Context, m mwebsocket.WebSocketMessage) error {
+	return s.queries.CreateWebSocketMessage(ctx, gen.CreateWebSocketMessageParams{
+		ID:          m.ID,
+		WebsocketID: m.WebSocketID,
+		Direction:   m.Direction,
+		MessageType: m.Type,
+		Data:        m.Data,
+		CreatedAt:   m.CreatedAt,
+	})
+}
+
+// DeleteByWebSocketID clears the history of a WebSocket.
+func (s WebSocketMessageService) DeleteByWebSocketID(ctx context.Context, wsID idwrap.IDWrap) error {
+	return s.queries.DeleteWebSocketMessagesByWebSocketID(ctx, wsID)
+}
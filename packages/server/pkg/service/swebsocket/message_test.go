@@ -0,0 +1,65 @@
+package swebsocket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/dbtest"
+	gen "github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
+)
+
+func TestWebSocketMessageService_History(t *testing.T) {
+	ctx := context.Background()
+	db, err := dbtest.GetTestDB(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	queries := gen.New(db)
+
+	workspaceID := idwrap.NewNow()
+	require.NoError(t, queries.CreateWorkspace(ctx, gen.CreateWorkspaceParams{ID: workspaceID, Name: "ws"}))
+
+	ws := &mwebsocket.WebSocket{
+		ID:           idwrap.NewNow(),
+		WorkspaceID:  workspaceID,
+		Name:         "Echo",
+		Url:          "wss://echo.example.com",
+		Subprotocols: []string{"graphql-transport-ws", "json"},
+	}
+	wsService := New(queries, nil)
+	require.NoError(t, wsService.Create(ctx, ws))
+
+	got, err := wsService.Get(ctx, ws.ID)
+	require.NoError(t, err)
+	require.Equal(t, ws.Subprotocols, got.Subprotocols)
+
+	messages := NewWebSocketMessageService(queries)
+	sent := mwebsocket.WebSocketMessage{
+		ID:          idwrap.NewMonotonic(),
+		WebSocketID: ws.ID,
+		Direction:   mwebsocket.MessageDirectionSent,
+		Type:        mwebsocket.MessageTypeText,
+		Data:        `{"id":1}`,
+	}
+	received := mwebsocket.WebSocketMessage{
+		ID:          idwrap.NewMonotonic(),
+		WebSocketID: ws.ID,
+		Direction:   mwebsocket.MessageDirectionReceived,
+		Type:        mwebsocket.MessageTypeBinary,
+		Data:        "AAE=",
+	}
+	// Insert out of order: history follows the monotonic ids.
+	require.NoError(t, messages.Create(ctx, received))
+	require.NoError(t, messages.Create(ctx, sent))
+
+	history, err := messages.GetByWebSocketID(ctx, ws.ID)
+	require.NoError(t, err)
+	require.Equal(t, []mwebsocket.WebSocketMessage{sent, received}, history)
+
+	require.NoError(t, messages.DeleteByWebSocketID(ctx, ws.ID))
+	history, err = messages.GetByWebSocketID(ctx, ws.ID)
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
@@ -59,11 +59,15 @@ func (s WebSocketService) Update(ctx context.Context, ws *mwebsocket.WebSocket)
 		lastRunAt = *ws.LastRunAt
 	}
 	return s.queries.UpdateWebSocket(ctx, gen.UpdateWebSocketParams{
-		ID:          ws.ID,
-		Name:        ws.Name,
-		Url:         ws.Url,
-		Description: ws.Description,
-		LastRunAt:   lastRunAt,
+		ID:             ws.ID,
+		Name:           ws.Name,
+		Url:            ws.Url,
+		Description:    ws.Description,
+		Subprotocols:   marshalStrings(ws.Subprotocols),
+		Protocol:       int8(ws.Protocol),
+		ConnectPayload: ws.ConnectPayload,
+		Subscriptions:  marshalStrings(ws.Subscriptions),
+		LastRunAt:      lastRunAt,
 	})
 }
 
@@ -0,0 +1,50 @@
+package swebsocket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/the-dev-tools/dev-tools/packages/db/pkg/dbtest"
+	gen "github.com/the-dev-tools/dev-tools/packages/db/pkg/sqlc/gen"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
+)
+
+func TestWebSocketService_Protocol(t *testing.T) {
+	ctx := context.Background()
+	db, err := dbtest.GetTestDB(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	queries := gen.New(db)
+
+	workspaceID := idwrap.NewNow()
+	require.NoError(t, queries.CreateWorkspace(ctx, gen.CreateWorkspaceParams{ID: workspaceID, Name: "ws"}))
+
+	ws := &mwebsocket.WebSocket{
+		ID:             idwrap.NewNow(),
+		WorkspaceID:    workspaceID,
+		Name:           "Broker",
+		Url:            "wss://broker.example.com/ws",
+		Protocol:       mwebsocket.ProtocolStomp,
+		ConnectPayload: `{"login":"guest"}`,
+		Subscriptions:  []string{"/topic/orders"},
+	}
+	wsService := New(queries, nil)
+	require.NoError(t, wsService.Create(ctx, ws))
+
+	got, err := wsService.Get(ctx, ws.ID)
+	require.NoError(t, err)
+	require.Equal(t, mwebsocket.ProtocolStomp, got.Protocol)
+	require.Equal(t, ws.ConnectPayload, got.ConnectPayload)
+	require.Equal(t, ws.Subscriptions, got.Subscriptions)
+
+	got.Protocol = mwebsocket.ProtocolSocketIO
+	got.Subscriptions = nil
+	require.NoError(t, wsService.Update(ctx, got))
+
+	got, err = wsService.Get(ctx, ws.ID)
+	require.NoError(t, err)
+	require.Equal(t, mwebsocket.ProtocolSocketIO, got.Protocol)
+	require.Nil(t, got.Subscriptions)
+}
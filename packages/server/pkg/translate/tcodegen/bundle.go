@@ -0,0 +1,138 @@
+package tcodegen
+
+import (
+	"fmt"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcurlv2"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/varsystem"
+)
+
+// BundleOptions narrows what GenerateBundle renders
+type BundleOptions struct {
+	// HTTPIDs limits request code to these base requests, all when empty
+	HTTPIDs []idwrap.IDWrap
+	// FlowIDs limits a k6 export to these flows, all when empty
+	FlowIDs []idwrap.IDWrap
+}
+
+// GenerateBundle renders the base HTTP requests of bundle in lang, or its
+// flows when lang is k6. Request code has the variables of the active
+// environment interpolated; k6 scripts read them from __ENV instead.
+func GenerateBundle(bundle *ioworkspace.WorkspaceBundle, lang Language, opts BundleOptions) (string, error) {
+	sources := bundleSources(bundle)
+	vars := bundleVariables(bundle)
+
+	if lang == LanguageK6 {
+		input := K6Input{Variables: vars}
+		for _, flow := range bundle.Flows {
+			if len(opts.FlowIDs) > 0 && !containsID(opts.FlowIDs, flow.ID) {
+				continue
+			}
+			fi := FlowInput{Flow: flow, Sources: sources}
+			for _, n := range bundle.FlowNodes {
+				if n.FlowID == flow.ID {
+					fi.Nodes = append(fi.Nodes, n)
+				}
+			}
+			for _, e := range bundle.FlowEdges {
+				if e.FlowID == flow.ID {
+					fi.Edges = append(fi.Edges, e)
+				}
+			}
+			fi.RequestNodes = bundle.FlowRequestNodes
+			input.Flows = append(input.Flows, fi)
+		}
+		return GenerateK6(input)
+	}
+
+	if _, ok := generators[lang]; !ok {
+		return "", fmt.Errorf("%w %q", ErrUnsupportedLanguage, lang)
+	}
+	varMap := varsystem.NewVarMap(vars)
+	var requests []*Request
+	for _, h := range bundle.HTTPRequests {
+		if h.IsDelta || (len(opts.HTTPIDs) > 0 && !containsID(opts.HTTPIDs, h.ID)) {
+			continue
+		}
+		req, err := NewRequest(sources[h.ID], varMap)
+		if err != nil {
+			return "", err
+		}
+		requests = append(requests, req)
+	}
+	if len(requests) == 0 {
+		return "", nil
+	}
+	return Generate(lang, requests)
+}
+
+// bundleSources groups the child entities of every HTTP request of bundle,
+// delta requests included
+func bundleSources(bundle *ioworkspace.WorkspaceBundle) map[idwrap.IDWrap]*Source {
+	sources := make(map[idwrap.IDWrap]*Source, len(bundle.HTTPRequests))
+	for _, h := range bundle.HTTPRequests {
+		sources[h.ID] = &Source{Resolved: &tcurlv2.CurlResolvedV2{HTTP: h}}
+	}
+	for _, h := range bundle.HTTPHeaders {
+		if s, ok := sources[h.HttpID]; ok {
+			s.Resolved.Headers = append(s.Resolved.Headers, h)
+		}
+	}
+	for _, p := range bundle.HTTPSearchParams {
+		if s, ok := sources[p.HttpID]; ok {
+			s.Resolved.SearchParams = append(s.Resolved.SearchParams, p)
+		}
+	}
+	for _, f := range bundle.HTTPBodyForms {
+		if s, ok := sources[f.HttpID]; ok {
+			s.Resolved.BodyForms = append(s.Resolved.BodyForms, f)
+		}
+	}
+	for _, u := range bundle.HTTPBodyUrlencoded {
+		if s, ok := sources[u.HttpID]; ok {
+			s.Resolved.BodyUrlencoded = append(s.Resolved.BodyUrlencoded, u)
+		}
+	}
+	for i := range bundle.HTTPBodyRaw {
+		raw := bundle.HTTPBodyRaw[i]
+		if s, ok := sources[raw.HttpID]; ok {
+			s.Resolved.BodyRaw = &raw
+		}
+	}
+	for _, a := range bundle.HTTPAsserts {
+		if s, ok := sources[a.HttpID]; ok {
+			s.Asserts = append(s.Asserts, a)
+		}
+	}
+	return sources
+}
+
+// bundleVariables returns the enabled variables of the global environment
+// overlaid with those of the active one
+func bundleVariables(bundle *ioworkspace.WorkspaceBundle) []menv.Variable {
+	var global, active []menv.Variable
+	for _, v := range bundle.EnvironmentVars {
+		if !v.Enabled {
+			continue
+		}
+		switch v.EnvID {
+		case bundle.Workspace.ActiveEnv:
+			active = append(active, v)
+		case bundle.Workspace.GlobalEnv:
+			global = append(global, v)
+		}
+	}
+	return varsystem.MergeVars(global, active)
+}
+
+func containsID(ids []idwrap.IDWrap, id idwrap.IDWrap) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package tcodegen
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+)
+
+// goGenerator renders a program using net/http with one function per request
+type goGenerator struct{}
+
+func (goGenerator) Generate(requests []*Request) (string, error) {
+	imports := map[string]bool{"fmt": true, "io": true, "log": true, "net/http": true}
+	// Function names must not shadow the imported packages or the
+	// predeclared identifiers the generated code relies on.
+	used := map[string]bool{"main": true, "error": true, "nil": true, "string": true,
+		"bytes": true, "filepath": true, "fmt": true, "http": true, "io": true, "log": true,
+		"multipart": true, "os": true, "strings": true, "url": true}
+
+	var funcs strings.Builder
+	var calls strings.Builder
+	for _, req := range requests {
+		name := uniqueName(goFuncName(req.Name), used)
+		fmt.Fprintf(&calls, "\tif err := %s(); err != nil {\n\t\tlog.Fatal(err)\n\t}\n", name)
+		fmt.Fprintf(&funcs, "\n// %s sends %s\nfunc %s() error {\n", name, goComment(req), name)
+		writeGoRequest(&funcs, req, imports)
+		funcs.WriteString("}\n")
+	}
+
+	paths := make([]string, 0, len(imports))
+	for p := range imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	b.WriteString("package main\n\nimport (\n")
+	for _, p := range paths {
+		fmt.Fprintf(&b, "\t%q\n", p)
+	}
+	b.WriteString(")\n\nfunc main() {\n")
+	b.WriteString(calls.String())
+	b.WriteString("}\n")
+	b.WriteString(funcs.String())
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", fmt.Errorf("tcodegen: format go source: %w", err)
+	}
+	return string(src), nil
+}
+
+func writeGoRequest(b *strings.Builder, req *Request, imports map[string]bool) {
+	const checkErr = "\tif err != nil {\n\t\treturn err\n\t}\n"
+
+	body := "nil"
+	contentType := ""
+	switch {
+	case req.BodyKind == mhttp.HttpBodyKindRaw && req.Body != "":
+		imports["strings"] = true
+		body = "strings.NewReader(" + goString(req.Body) + ")"
+	case req.BodyKind == mhttp.HttpBodyKindUrlEncoded && len(req.URLEncoded) > 0:
+		imports["net/url"] = true
+		imports["strings"] = true
+		b.WriteString("\tform := url.Values{}\n")
+		for _, p := range req.URLEncoded {
+			fmt.Fprintf(b, "\tform.Add(%s, %s)\n", goString(p.Key), goString(p.Value))
+		}
+		body = "strings.NewReader(form.Encode())"
+		if _, ok := req.Header("Content-Type"); !ok {
+			contentType = strconv.Quote("application/x-www-form-urlencoded")
+		}
+	case req.BodyKind == mhttp.HttpBodyKindFormData && len(req.Form) > 0:
+		imports["bytes"] = true
+		imports["mime/multipart"] = true
+		b.WriteString("\tbody := &bytes.Buffer{}\n\twriter := multipart.NewWriter(body)\n")
+		files := 0
+		for _, p := range req.Form {
+			if !p.File {
+				fmt.Fprintf(b, "\tif err := writer.WriteField(%s, %s); err != nil {\n\t\treturn err\n\t}\n", goString(p.Key), goString(p.Value))
+				continue
+			}
+			imports["os"] = true
+			imports["path/filepath"] = true
+			files++
+			file, part := fmt.Sprintf("file%d", files), fmt.Sprintf("part%d", files)
+			fmt.Fprintf(b, "\t%s, err := os.Open(%s)\n%s\tdefer %s.Close()\n", file, goString(p.Value), checkErr, file)
+			fmt.Fprintf(b, "\t%s, err := writer.CreateFormFile(%s, filepath.Base(%s.Name()))\n%s", part, goString(p.Key), file, checkErr)
+			fmt.Fprintf(b, "\tif _, err := io.Copy(%s, %s); err != nil {\n\t\treturn err\n\t}\n", part, file)
+		}
+		b.WriteString("\tif err := writer.Close(); err != nil {\n\t\treturn err\n\t}\n")
+		body = "body"
+		contentType = "writer.FormDataContentType()"
+	}
+
+	fmt.Fprintf(b, "\treq, err := http.NewRequest(%s, %s, %s)\n%s", goString(req.Method), goString(req.FullURL()), body, checkErr)
+	for _, h := range req.Headers {
+		fmt.Fprintf(b, "\treq.Header.Add(%s, %s)\n", goString(h.Key), goString(h.Value))
+	}
+	if contentType != "" {
+		fmt.Fprintf(b, "\treq.Header.Set(\"Content-Type\", %s)\n", contentType)
+	}
+
+	b.WriteString("\n\tresp, err := http.DefaultClient.Do(req)\n" + checkErr)
+	b.WriteString("\tdefer resp.Body.Close()\n\n")
+	b.WriteString("\tdata, err := io.ReadAll(resp.Body)\n" + checkErr)
+	b.WriteString("\tfmt.Println(resp.Status)\n\tfmt.Println(string(data))\n\treturn nil\n")
+}
+
+// goString quotes s, preferring a raw string literal for text with quotes or
+// line breaks such as JSON bodies
+func goString(s string) string {
+	if strings.ContainsAny(s, "\"\n") && !strings.ContainsAny(s, "`\r") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+func goFuncName(name string) string {
+	words := identWords(name)
+	if len(words) == 0 {
+		return "sendRequest"
+	}
+	var b strings.Builder
+	for i, w := range words {
+		if i == 0 {
+			b.WriteString(strings.ToLower(w[:1]) + w[1:])
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	ident := b.String()
+	if ident[0] >= '0' && ident[0] <= '9' {
+		ident = "request" + ident
+	}
+	if token.IsKeyword(ident) {
+		ident += "Request"
+	}
+	return ident
+}
+
+func goComment(req *Request) string {
+	if req.Name == "" {
+		return req.Method + " " + req.URL
+	}
+	return strings.ReplaceAll(req.Name, "\n", " ")
+}
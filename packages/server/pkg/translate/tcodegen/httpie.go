@@ -0,0 +1,69 @@
+package tcodegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+)
+
+// httpieGenerator renders one HTTPie command per request
+type httpieGenerator struct{}
+
+func (httpieGenerator) Generate(requests []*Request) (string, error) {
+	commands := make([]string, 0, len(requests))
+	for _, req := range requests {
+		args := []string{"http"}
+		var items []string
+
+		switch {
+		case req.BodyKind == mhttp.HttpBodyKindRaw && req.Body != "":
+			args = append(args, "--raw "+shellQuote(req.Body))
+		case req.BodyKind == mhttp.HttpBodyKindUrlEncoded && len(req.URLEncoded) > 0:
+			args = append(args, "--form")
+			for _, p := range req.URLEncoded {
+				items = append(items, shellQuote(p.Key+"="+p.Value))
+			}
+		case req.BodyKind == mhttp.HttpBodyKindFormData && len(req.Form) > 0:
+			args = append(args, "--multipart")
+			for _, p := range req.Form {
+				if p.File {
+					items = append(items, shellQuote(p.Key+"@"+p.Value))
+				} else {
+					items = append(items, shellQuote(p.Key+"="+p.Value))
+				}
+			}
+		}
+
+		args = append(args, req.Method, shellQuote(req.URL))
+		var fields []string
+		for _, h := range req.Headers {
+			// "Key:" would unset the header, "Key;" sends it empty.
+			if h.Value == "" {
+				fields = append(fields, shellQuote(h.Key+";"))
+			} else {
+				fields = append(fields, shellQuote(h.Key+":"+h.Value))
+			}
+		}
+		for _, q := range req.Query {
+			fields = append(fields, shellQuote(q.Key+"=="+q.Value))
+		}
+
+		lines := append([]string{strings.Join(args, " ")}, append(fields, items...)...)
+		commands = append(commands, fmt.Sprintf("# %s\n%s", goComment(req), strings.Join(lines, " \\\n  ")))
+	}
+	if len(commands) == 0 {
+		return "", nil
+	}
+	return strings.Join(commands, "\n\n") + "\n", nil
+}
+
+// shellQuote wraps s in single quotes unless it is a plain word
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@", r))
+	}) == -1 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,92 @@
+package tcodegen
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+)
+
+// javascriptGenerator renders an ES module using fetch. File uploads read
+// the file with Node's openAsBlob.
+type javascriptGenerator struct{}
+
+func (javascriptGenerator) Generate(requests []*Request) (string, error) {
+	var b strings.Builder
+	b.WriteString("// Run as an ES module, e.g. node requests.mjs\n")
+	if hasFileUpload(requests) {
+		b.WriteString("import { openAsBlob } from \"node:fs\";\n")
+	}
+
+	// Each request gets its own block so the response bindings do not clash.
+	block := len(requests) > 1
+	indent := ""
+	if block {
+		indent = "  "
+	}
+	for _, req := range requests {
+		fmt.Fprintf(&b, "\n// %s\n", goComment(req))
+		if block {
+			b.WriteString("{\n")
+		}
+		writeJSRequest(&b, req, indent)
+		if block {
+			b.WriteString("}\n")
+		}
+	}
+	return b.String(), nil
+}
+
+func writeJSRequest(b *strings.Builder, req *Request, indent string) {
+	body := ""
+	switch {
+	case req.BodyKind == mhttp.HttpBodyKindRaw && req.Body != "":
+		body = quoteJSON(req.Body)
+	case req.BodyKind == mhttp.HttpBodyKindUrlEncoded && len(req.URLEncoded) > 0:
+		fmt.Fprintf(b, "%sconst body = new URLSearchParams([\n", indent)
+		for _, p := range req.URLEncoded {
+			fmt.Fprintf(b, "%s  [%s, %s],\n", indent, quoteJSON(p.Key), quoteJSON(p.Value))
+		}
+		fmt.Fprintf(b, "%s]);\n", indent)
+		body = "body"
+	case req.BodyKind == mhttp.HttpBodyKindFormData && len(req.Form) > 0:
+		fmt.Fprintf(b, "%sconst body = new FormData();\n", indent)
+		for _, p := range req.Form {
+			if p.File {
+				fmt.Fprintf(b, "%sbody.append(%s, await openAsBlob(%s), %s);\n", indent,
+					quoteJSON(p.Key), quoteJSON(p.Value), quoteJSON(path.Base(p.Value)))
+				continue
+			}
+			fmt.Fprintf(b, "%sbody.append(%s, %s);\n", indent, quoteJSON(p.Key), quoteJSON(p.Value))
+		}
+		body = "body"
+	}
+
+	fmt.Fprintf(b, "%sconst response = await fetch(%s, {\n", indent, quoteJSON(req.FullURL()))
+	fmt.Fprintf(b, "%s  method: %s,\n", indent, quoteJSON(req.Method))
+	if len(req.Headers) > 0 {
+		fmt.Fprintf(b, "%s  headers: {\n", indent)
+		for _, h := range mergedHeaders(req.Headers) {
+			fmt.Fprintf(b, "%s    %s: %s,\n", indent, quoteJSON(h.Key), quoteJSON(h.Value))
+		}
+		fmt.Fprintf(b, "%s  },\n", indent)
+	}
+	if body != "" {
+		fmt.Fprintf(b, "%s  body: %s,\n", indent, body)
+	}
+	fmt.Fprintf(b, "%s});\n", indent)
+	fmt.Fprintf(b, "%sconsole.log(response.status);\n", indent)
+	fmt.Fprintf(b, "%sconsole.log(await response.text());\n", indent)
+}
+
+func hasFileUpload(requests []*Request) bool {
+	for _, req := range requests {
+		for _, p := range req.Form {
+			if p.File {
+				return true
+			}
+		}
+	}
+	return false
+}
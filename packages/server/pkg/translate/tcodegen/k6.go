@@ -0,0 +1,660 @@
+package tcodegen
+
+import (
+	"fmt"
+	"net/textproto"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+)
+
+// FlowInput is one flow of a k6 export
+type FlowInput struct {
+	Flow         mflow.Flow
+	Nodes        []mflow.Node
+	Edges        []mflow.Edge
+	RequestNodes []mflow.NodeRequest
+	// Sources holds the base and delta requests of the flow keyed by HTTP ID
+	Sources map[idwrap.IDWrap]*Source
+}
+
+// K6Input is the input of GenerateK6
+type K6Input struct {
+	Flows []FlowInput
+	// Variables provide the defaults of environment references. The script
+	// reads __ENV first so values can be overridden with `k6 run -e`.
+	Variables []menv.Variable
+}
+
+// GenerateK6 renders flows as a k6 script. Request nodes run in flow order,
+// chaining references become reads of earlier responses and assertions become
+// checks. Nodes and expressions k6 has no equivalent for are left as comments.
+func GenerateK6(input K6Input) (string, error) {
+	if len(input.Flows) == 0 {
+		return "", fmt.Errorf("tcodegen: k6 export needs at least one flow")
+	}
+
+	script := &k6Script{
+		defaults: make(map[string]string),
+		envRefs:  make(map[string]bool),
+		files:    make(map[string]string),
+	}
+	for _, v := range input.Variables {
+		if v.Enabled {
+			script.defaults[v.VarKey] = v.Value
+		}
+	}
+
+	used := make(map[string]bool, len(k6Reserved))
+	for name := range k6Reserved {
+		used[name] = true
+	}
+	var funcs strings.Builder
+	var scenarios []string
+	for _, flow := range input.Flows {
+		if len(input.Flows) == 1 {
+			funcs.WriteString("\nexport default function () {\n")
+		} else {
+			name := uniqueName(goFuncName(flow.Flow.Name), used)
+			scenarios = append(scenarios, name)
+			fmt.Fprintf(&funcs, "\nexport function %s() {\n", name)
+		}
+		if err := script.writeFlow(&funcs, flow); err != nil {
+			return "", err
+		}
+		funcs.WriteString("}\n")
+	}
+
+	var b strings.Builder
+	if len(input.Flows) == 1 {
+		fmt.Fprintf(&b, "// k6 script for flow %s\n", quoteJSON(input.Flows[0].Flow.Name))
+	}
+	b.WriteString("import http from \"k6/http\";\nimport { check } from \"k6\";\n")
+
+	if len(script.envRefs) > 0 {
+		names := make([]string, 0, len(script.envRefs))
+		for name := range script.envRefs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		b.WriteString("\nconst env = {\n")
+		for _, name := range names {
+			value := k6EnvLookup(name)
+			if def, ok := script.defaults[name]; ok {
+				value += " || " + quoteJSON(def)
+			}
+			fmt.Fprintf(&b, "  %s: %s,\n", jsKey(name), value)
+		}
+		b.WriteString("};\n")
+	}
+
+	if len(script.fileOrder) > 0 {
+		b.WriteString("\n")
+		for _, line := range script.fileOrder {
+			b.WriteString(line + "\n")
+		}
+	}
+
+	b.WriteString("\nexport const options = {\n")
+	if len(scenarios) == 0 {
+		b.WriteString("  vus: 1,\n  iterations: 1,\n")
+	} else {
+		b.WriteString("  scenarios: {\n")
+		for _, name := range scenarios {
+			fmt.Fprintf(&b, "    %s: { executor: \"shared-iterations\", vus: 1, iterations: 1, exec: %s },\n", name, quoteJSON(name))
+		}
+		b.WriteString("  },\n")
+	}
+	b.WriteString("};\n")
+	b.WriteString(funcs.String())
+	return b.String(), nil
+}
+
+// k6Reserved holds the identifiers the script itself binds
+var k6Reserved = map[string]bool{
+	"http": true, "check": true, "open": true, "env": true, "options": true, "r": true,
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"default": true, "delete": true, "do": true, "else": true, "export": true, "extends": true,
+	"finally": true, "for": true, "function": true, "if": true, "import": true, "in": true,
+	"let": true, "new": true, "null": true, "return": true, "switch": true, "this": true,
+	"throw": true, "try": true, "typeof": true, "var": true, "void": true, "while": true,
+	"with": true, "yield": true, "true": true, "false": true,
+}
+
+type k6Script struct {
+	defaults  map[string]string
+	envRefs   map[string]bool
+	files     map[string]string // path and mode -> init context binding
+	fileOrder []string
+}
+
+// k6Flow translates the expressions of one flow
+type k6Flow struct {
+	script *k6Script
+	// responses maps flow node names to the JS binding of their response
+	responses map[string]string
+	// respVar is the response parameter inside check callbacks
+	respVar string
+}
+
+func (s *k6Script) writeFlow(b *strings.Builder, flow FlowInput) error {
+	requestNodes := make(map[idwrap.IDWrap]mflow.NodeRequest, len(flow.RequestNodes))
+	for _, rn := range flow.RequestNodes {
+		requestNodes[rn.FlowNodeID] = rn
+	}
+
+	scope := &k6Flow{script: s, responses: make(map[string]string)}
+	used := make(map[string]bool, len(k6Reserved))
+	for name := range k6Reserved {
+		used[name] = true
+	}
+	ordered := flowOrder(flow.Nodes, flow.Edges)
+	for _, node := range ordered {
+		if node.NodeKind == mflow.NODE_KIND_REQUEST {
+			scope.responses[node.Name] = uniqueName(jsIdent(node.Name), used)
+		}
+	}
+
+	first := true
+	for _, node := range ordered {
+		if node.NodeKind == mflow.NODE_KIND_MANUAL_START {
+			continue
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+
+		if node.NodeKind != mflow.NODE_KIND_REQUEST {
+			fmt.Fprintf(b, "  // %s node %s has no k6 equivalent and is skipped\n", nodeKindName(node.NodeKind), quoteJSON(node.Name))
+			continue
+		}
+
+		rn, ok := requestNodes[node.ID]
+		var src *Source
+		if ok && rn.HttpID != nil {
+			src = flow.Sources[*rn.HttpID]
+		}
+		if src == nil {
+			fmt.Fprintf(b, "  // request node %s has no request and is skipped\n", quoteJSON(node.Name))
+			continue
+		}
+		if rn.DeltaHttpID != nil {
+			if d, ok := flow.Sources[*rn.DeltaHttpID]; ok {
+				src = ResolveDelta(src, d)
+			}
+		}
+
+		req, err := NewRequest(src, nil)
+		if err != nil {
+			return err
+		}
+		scope.writeRequest(b, scope.responses[node.Name], req)
+	}
+	return nil
+}
+
+func (f *k6Flow) writeRequest(b *strings.Builder, binding string, req *Request) {
+	var untranslated []string
+	tmpl := func(s string) string {
+		js, missed := f.template(s)
+		untranslated = append(untranslated, missed...)
+		return js
+	}
+
+	target := tmpl(req.URL)
+	if len(req.Query) > 0 {
+		parts := make([]string, 0, len(req.Query))
+		for _, q := range req.Query {
+			parts = append(parts, queryEscape(q.Key)+"="+queryEscape(q.Value))
+		}
+		separator := "?"
+		if strings.Contains(req.URL, "?") {
+			separator = "&"
+		}
+		target = tmpl(req.URL + separator + strings.Join(parts, "&"))
+	}
+
+	body := "null"
+	switch {
+	case req.BodyKind == mhttp.HttpBodyKindRaw && req.Body != "":
+		body = tmpl(req.Body)
+	case req.BodyKind == mhttp.HttpBodyKindUrlEncoded && len(req.URLEncoded) > 0:
+		var obj strings.Builder
+		obj.WriteString("{\n")
+		for _, p := range req.URLEncoded {
+			fmt.Fprintf(&obj, "    %s: %s,\n", quoteJSON(p.Key), tmpl(p.Value))
+		}
+		obj.WriteString("  }")
+		body = obj.String()
+	case req.BodyKind == mhttp.HttpBodyKindFormData && len(req.Form) > 0:
+		var obj strings.Builder
+		obj.WriteString("{\n")
+		for _, p := range req.Form {
+			if p.File {
+				fmt.Fprintf(&obj, "    %s: http.file(%s, %s),\n", quoteJSON(p.Key), f.script.openFile(p.Value, true), quoteJSON(path.Base(p.Value)))
+				continue
+			}
+			fmt.Fprintf(&obj, "    %s: %s,\n", quoteJSON(p.Key), tmpl(p.Value))
+		}
+		obj.WriteString("  }")
+		body = obj.String()
+	}
+
+	var params strings.Builder
+	if len(req.Headers) > 0 {
+		params.WriteString(", {\n    headers: {\n")
+		for _, h := range mergedHeaders(req.Headers) {
+			fmt.Fprintf(&params, "      %s: %s,\n", quoteJSON(h.Key), tmpl(h.Value))
+		}
+		params.WriteString("    },\n  }")
+	}
+
+	var checks []string
+	f.respVar = "r"
+	for _, a := range req.Asserts {
+		js, ok := f.expression(a)
+		if !ok {
+			untranslated = append(untranslated, a)
+			continue
+		}
+		checks = append(checks, fmt.Sprintf("    %s: (r) => %s,\n", quoteJSON(a), js))
+	}
+	f.respVar = ""
+
+	fmt.Fprintf(b, "  // %s\n", goComment(req))
+	for _, expr := range untranslated {
+		fmt.Fprintf(b, "  // not translated: %s\n", expr)
+	}
+	fmt.Fprintf(b, "  const %s = http.request(%s, %s, %s%s);\n", binding, quoteJSON(req.Method), target, body, params.String())
+	if len(checks) > 0 {
+		fmt.Fprintf(b, "  check(%s, {\n%s  });\n", binding, strings.Join(checks, ""))
+	}
+}
+
+// template renders s as a JS string. References that cannot be translated
+// are kept verbatim and returned.
+func (f *k6Flow) template(s string) (string, []string) {
+	matches := templatePattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return quoteJSON(s), nil
+	}
+
+	var missed []string
+	var b strings.Builder
+	b.WriteByte('`')
+	last := 0
+	for _, m := range matches {
+		b.WriteString(escapeTemplate(s[last:m[0]]))
+		expr := s[m[2]:m[3]]
+		if js, ok := f.reference(expr); ok {
+			b.WriteString("${" + js + "}")
+		} else {
+			b.WriteString(escapeTemplate(s[m[0]:m[1]]))
+			missed = append(missed, s[m[0]:m[1]])
+		}
+		last = m[1]
+	}
+	b.WriteString(escapeTemplate(s[last:]))
+	b.WriteByte('`')
+	return b.String(), missed
+}
+
+func (f *k6Flow) reference(expr string) (string, bool) {
+	if name, ok := strings.CutPrefix(expr, "#env:"); ok {
+		return k6EnvLookup(strings.TrimSpace(name)), true
+	}
+	if p, ok := strings.CutPrefix(expr, fileRefPrefix); ok {
+		return f.script.openFile(strings.TrimSpace(p), false), true
+	}
+	return f.expression(expr)
+}
+
+// expression translates a flow expression to JavaScript. Paths, literals and
+// operators are supported; function calls are not.
+func (f *k6Flow) expression(expr string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			b.WriteByte(' ')
+			for i < len(expr) && strings.IndexByte(" \t\n", expr[i]) >= 0 {
+				i++
+			}
+		case c == '"' || c == '\'':
+			end := scanQuoted(expr, i)
+			if end < 0 {
+				return "", false
+			}
+			b.WriteString(expr[i:end])
+			i = end
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(expr) && (expr[j] >= '0' && expr[j] <= '9' || expr[j] == '.' || expr[j] == '_') {
+				j++
+			}
+			b.WriteString(strings.ReplaceAll(expr[i:j], "_", ""))
+			i = j
+		case isIdentStart(c):
+			js, next, ok := f.path(expr, i)
+			if !ok {
+				return "", false
+			}
+			b.WriteString(js)
+			i = next
+		case strings.HasPrefix(expr[i:], "=="), strings.HasPrefix(expr[i:], "!="):
+			b.WriteString(expr[i:i+2] + "=")
+			i += 2
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return strings.TrimSpace(b.String()), true
+}
+
+// path translates the identifier path starting at expr[i]
+func (f *k6Flow) path(expr string, i int) (string, int, bool) {
+	j := scanIdent(expr, i)
+	root := expr[i:j]
+	switch root {
+	case "and":
+		return "&&", j, true
+	case "or":
+		return "||", j, true
+	case "not":
+		return "!", j, true
+	case "nil":
+		return "null", j, true
+	case "true", "false", "in":
+		return root, j, true
+	case "contains", "matches", "startsWith", "endsWith":
+		return "", j, false
+	}
+
+	// segments holds the JS accessors following the root
+	var segments []string
+	var names []string
+	for j < len(expr) {
+		if expr[j] == '.' && j+1 < len(expr) && isIdentStart(expr[j+1]) {
+			end := scanIdent(expr, j+1)
+			segments = append(segments, "."+expr[j+1:end])
+			names = append(names, expr[j+1:end])
+			j = end
+			continue
+		}
+		if expr[j] == '[' {
+			end := strings.IndexByte(expr[j:], ']')
+			if end < 0 {
+				return "", j, false
+			}
+			index := strings.TrimSpace(expr[j+1 : j+end])
+			if unquoted, err := strconv.Unquote(strings.ReplaceAll(index, "'", `"`)); err == nil {
+				names = append(names, unquoted)
+			} else if _, err := strconv.Atoi(index); err == nil {
+				names = append(names, index)
+			} else {
+				return "", j, false
+			}
+			segments = append(segments, "["+index+"]")
+			j += end + 1
+			continue
+		}
+		break
+	}
+
+	rest := j
+	for rest < len(expr) && expr[rest] == ' ' {
+		rest++
+	}
+	if rest < len(expr) && expr[rest] == '(' {
+		return "", j, false
+	}
+
+	if root == "response" && f.respVar != "" {
+		js, ok := responseAccess(f.respVar, segments, names)
+		return js, j, ok
+	}
+	if binding, ok := f.responses[root]; ok {
+		if len(names) == 0 || names[0] != "response" {
+			return "", j, false
+		}
+		js, ok := responseAccess(binding, segments[1:], names[1:])
+		return js, j, ok
+	}
+	if len(segments) > 0 {
+		return "", j, false
+	}
+	f.script.envRefs[root] = true
+	return "env" + jsAccessor(root), j, true
+}
+
+// responseAccess maps the fields of a flow response onto a k6 Response
+func responseAccess(binding string, segments, names []string) (string, bool) {
+	if len(names) == 0 {
+		return binding, true
+	}
+	switch names[0] {
+	case "status":
+		return binding + ".status" + strings.Join(segments[1:], ""), true
+	case "body":
+		return binding + ".json()" + strings.Join(segments[1:], ""), true
+	case "duration":
+		return binding + ".timings.duration", len(names) == 1
+	case "headers":
+		if len(names) == 1 {
+			return binding + ".headers", true
+		}
+		key := textproto.CanonicalMIMEHeaderKey(names[1])
+		return binding + ".headers[" + quoteJSON(key) + "]" + strings.Join(segments[2:], ""), true
+	}
+	return "", false
+}
+
+// openFile binds a file in the init context, where k6 allows open()
+func (s *k6Script) openFile(p string, binary bool) string {
+	key := p
+	call := "open(" + quoteJSON(p) + ")"
+	if binary {
+		key += "\x00b"
+		call = "open(" + quoteJSON(p) + ", \"b\")"
+	}
+	if name, ok := s.files[key]; ok {
+		return name
+	}
+	name := fmt.Sprintf("file%d", len(s.files)+1)
+	s.files[key] = name
+	s.fileOrder = append(s.fileOrder, fmt.Sprintf("const %s = %s;", name, call))
+	return name
+}
+
+// flowOrder sorts the nodes reachable from the start nodes topologically,
+// breaking ties by the order of nodes
+func flowOrder(nodes []mflow.Node, edges []mflow.Edge) []mflow.Node {
+	index := make(map[idwrap.IDWrap]int, len(nodes))
+	for i, n := range nodes {
+		index[n.ID] = i
+	}
+	next := make(map[idwrap.IDWrap][]idwrap.IDWrap)
+	for _, e := range edges {
+		next[e.SourceID] = append(next[e.SourceID], e.TargetID)
+	}
+
+	reachable := make(map[idwrap.IDWrap]bool)
+	var stack []idwrap.IDWrap
+	for _, n := range nodes {
+		if n.NodeKind == mflow.NODE_KIND_MANUAL_START {
+			stack = append(stack, n.ID)
+		}
+	}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if reachable[id] {
+			continue
+		}
+		reachable[id] = true
+		stack = append(stack, next[id]...)
+	}
+
+	indegree := make(map[idwrap.IDWrap]int)
+	for _, e := range edges {
+		if reachable[e.SourceID] && reachable[e.TargetID] {
+			indegree[e.TargetID]++
+		}
+	}
+
+	var ordered []mflow.Node
+	done := make(map[idwrap.IDWrap]bool)
+	for len(ordered) < len(reachable) {
+		picked := -1
+		for i, n := range nodes {
+			if reachable[n.ID] && !done[n.ID] && indegree[n.ID] == 0 {
+				picked = i
+				break
+			}
+		}
+		if picked < 0 {
+			// A cycle: fall back to node order for what is left.
+			for _, n := range nodes {
+				if reachable[n.ID] && !done[n.ID] {
+					picked = index[n.ID]
+					break
+				}
+			}
+		}
+		n := nodes[picked]
+		done[n.ID] = true
+		ordered = append(ordered, n)
+		for _, target := range next[n.ID] {
+			indegree[target]--
+		}
+	}
+	return ordered
+}
+
+func nodeKindName(kind mflow.NodeKind) string {
+	switch kind {
+	case mflow.NODE_KIND_CONDITION:
+		return "condition"
+	case mflow.NODE_KIND_FOR:
+		return "for"
+	case mflow.NODE_KIND_FOR_EACH:
+		return "for_each"
+	case mflow.NODE_KIND_JS:
+		return "js"
+	case mflow.NODE_KIND_AI:
+		return "ai"
+	case mflow.NODE_KIND_GRAPHQL:
+		return "graphql"
+	case mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION:
+		return "graphql_subscription"
+	case mflow.NODE_KIND_WS_CONNECTION, mflow.NODE_KIND_WS_SEND, mflow.NODE_KIND_WS_EXPECT:
+		return "websocket"
+	case mflow.NODE_KIND_SOCKET_CONNECTION, mflow.NODE_KIND_SOCKET_SEND, mflow.NODE_KIND_SOCKET_RECEIVE:
+		return "socket"
+	case mflow.NODE_KIND_DB_QUERY:
+		return "db_query"
+	case mflow.NODE_KIND_MQ_PUBLISH, mflow.NODE_KIND_MQ_CONSUME:
+		return "message_queue"
+	case mflow.NODE_KIND_EXEC:
+		return "exec"
+	case mflow.NODE_KIND_WAIT:
+		return "wait"
+	case mflow.NODE_KIND_RUN_SUB_FLOW:
+		return "sub_flow"
+	case mflow.NODE_KIND_TRY:
+		return "try"
+	case mflow.NODE_KIND_PARALLEL:
+		return "parallel"
+	case mflow.NODE_KIND_POLL:
+		return "poll"
+	case mflow.NODE_KIND_SWITCH:
+		return "switch"
+	case mflow.NODE_KIND_WEBHOOK_TRIGGER:
+		return "webhook"
+	}
+	return "unsupported"
+}
+
+func k6EnvLookup(name string) string {
+	return "__ENV" + jsAccessor(name)
+}
+
+func jsAccessor(name string) string {
+	if isJSIdent(name) {
+		return "." + name
+	}
+	return "[" + quoteJSON(name) + "]"
+}
+
+func jsKey(name string) string {
+	if isJSIdent(name) {
+		return name
+	}
+	return quoteJSON(name)
+}
+
+// jsIdent turns a node name into a JS identifier
+func jsIdent(name string) string {
+	ident := strings.Join(identWords(name), "_")
+	if name != "" && isJSIdent(name) {
+		ident = name
+	}
+	if ident == "" {
+		return "res"
+	}
+	if ident[0] >= '0' && ident[0] <= '9' {
+		ident = "_" + ident
+	}
+	if k6Reserved[ident] {
+		ident += "_res"
+	}
+	return ident
+}
+
+func isJSIdent(s string) bool {
+	if s == "" || !isIdentStart(s[0]) {
+		return false
+	}
+	return scanIdent(s, 0) == len(s)
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func scanIdent(s string, i int) int {
+	for i < len(s) && (isIdentStart(s[i]) || s[i] >= '0' && s[i] <= '9') {
+		i++
+	}
+	return i
+}
+
+// scanQuoted returns the index after the string literal starting at s[i], or
+// -1 when it is not terminated
+func scanQuoted(s string, i int) int {
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1
+		}
+	}
+	return -1
+}
+
+func escapeTemplate(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "`", "\\`")
+	return strings.ReplaceAll(s, "${", "\\${")
+}
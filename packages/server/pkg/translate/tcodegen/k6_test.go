@@ -0,0 +1,101 @@
+package tcodegen
+
+import (
+	"testing"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcurlv2"
+
+	"github.com/stretchr/testify/require"
+)
+
+const usersScript = `
+curl -X POST "$BASE_URL/users" -H 'Content-Type: application/json' --data-raw '{"name":"Ada","bio":"{{ #file:bio.txt }}"}'
+curl "$BASE_URL/users/{{ http_1.response.body.id }}" -H 'X-Trace: {{ #env:TRACE_ID }}' -H 'X-Seed: {{ faker.uuid() }}'
+`
+
+func usersFlow(t *testing.T) FlowInput {
+	t.Helper()
+	script, err := tcurlv2.ConvertCurlScript(usersScript, tcurlv2.ConvertCurlScriptOptions{
+		WorkspaceID: idwrap.NewNow(),
+		FlowName:    "Users",
+	})
+	require.NoError(t, err)
+
+	sources := make(map[idwrap.IDWrap]*Source, len(script.Requests))
+	for _, resolved := range script.Requests {
+		sources[resolved.HTTP.ID] = &Source{Resolved: resolved}
+	}
+	created, fetched := script.Requests[0].HTTP.ID, script.Requests[1].HTTP.ID
+	sources[created].Asserts = []mhttp.HTTPAssert{
+		{HttpID: created, Value: "response.status == 201", Enabled: true},
+		{HttpID: created, Value: `"id" in response.body`, Enabled: true},
+		{HttpID: created, Value: "disabled == true", Enabled: false},
+	}
+	sources[fetched].Asserts = []mhttp.HTTPAssert{
+		{HttpID: fetched, Value: `response.body.id == http_1.response.body.id and response.headers["content-type"] != nil`, Enabled: true},
+		{HttpID: fetched, Value: `len(response.body.name) > 0`, Enabled: true},
+	}
+
+	return FlowInput{
+		Flow:         script.Flow,
+		Nodes:        script.Nodes,
+		Edges:        script.Edges,
+		RequestNodes: script.RequestNodes,
+		Sources:      sources,
+	}
+}
+
+func TestGenerateK6(t *testing.T) {
+	flow := usersFlow(t)
+	// An unsupported node is reported in place.
+	cond := mflow.Node{ID: idwrap.NewNow(), FlowID: flow.Flow.ID, Name: "branch", NodeKind: mflow.NODE_KIND_CONDITION}
+	flow.Nodes = append(flow.Nodes, cond)
+	flow.Edges = append(flow.Edges, mflow.Edge{ID: idwrap.NewNow(), SourceID: flow.Nodes[2].ID, TargetID: cond.ID})
+
+	out, err := GenerateK6(K6Input{
+		Flows:     []FlowInput{flow},
+		Variables: []menv.Variable{{VarKey: "BASE_URL", Value: "https://api.example.com", Enabled: true}},
+	})
+	require.NoError(t, err)
+
+	for _, want := range []string{
+		`import http from "k6/http";`,
+		"const env = {\n  BASE_URL: __ENV.BASE_URL || \"https://api.example.com\",\n};",
+		`const file1 = open("bio.txt");`,
+		"export default function () {",
+		"const http_1 = http.request(\"POST\", `${env.BASE_URL}/users`, `{\"name\":\"Ada\",\"bio\":\"${file1}\"}`, {",
+		`"response.status == 201": (r) => r.status === 201,`,
+		`"\"id\" in response.body": (r) => "id" in r.json(),`,
+		"const http_2 = http.request(\"GET\", `${env.BASE_URL}/users/${http_1.json().id}`, null, {",
+		"\"X-Trace\": `${__ENV.TRACE_ID}`,",
+		"// not translated: {{ faker.uuid() }}",
+		`(r) => r.json().id === http_1.json().id && r.headers["Content-Type"] !== null,`,
+		"// not translated: len(response.body.name) > 0",
+		`// condition node "branch" has no k6 equivalent and is skipped`,
+	} {
+		require.Contains(t, out, want)
+	}
+	require.NotContains(t, out, "disabled")
+}
+
+func TestGenerateK6_Scenarios(t *testing.T) {
+	first, second := usersFlow(t), usersFlow(t)
+	second.Flow.Name = "Users"
+
+	out, err := GenerateK6(K6Input{Flows: []FlowInput{first, second}})
+	require.NoError(t, err)
+	require.Contains(t, out, `users: { executor: "shared-iterations", vus: 1, iterations: 1, exec: "users" },`)
+	require.Contains(t, out, `users2: { executor: "shared-iterations", vus: 1, iterations: 1, exec: "users2" },`)
+	require.Contains(t, out, "export function users() {")
+	require.Contains(t, out, "export function users2() {")
+	require.Contains(t, out, "  BASE_URL: __ENV.BASE_URL,\n", "variables without a default read __ENV only")
+}
+
+func TestGenerateK6_NoFlows(t *testing.T) {
+	_, err := GenerateK6(K6Input{})
+	require.Error(t, err)
+}
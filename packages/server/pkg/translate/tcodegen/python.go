@@ -0,0 +1,76 @@
+package tcodegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+)
+
+// pythonGenerator renders a script using the requests library
+type pythonGenerator struct{}
+
+func (pythonGenerator) Generate(requests []*Request) (string, error) {
+	var b strings.Builder
+	b.WriteString("import requests\n")
+	for _, req := range requests {
+		fmt.Fprintf(&b, "\n# %s\n", goComment(req))
+		fmt.Fprintf(&b, "response = requests.request(\n    %s,\n    %s,\n", quoteJSON(req.Method), quoteJSON(req.FullURL()))
+		if len(req.Headers) > 0 {
+			b.WriteString("    headers=" + pythonDict(mergedHeaders(req.Headers), "    ") + ",\n")
+		}
+
+		switch {
+		case req.BodyKind == mhttp.HttpBodyKindRaw && req.Body != "":
+			fmt.Fprintf(&b, "    data=%s,\n", quoteJSON(req.Body))
+		case req.BodyKind == mhttp.HttpBodyKindUrlEncoded && len(req.URLEncoded) > 0:
+			b.WriteString("    data=" + pythonPairs(req.URLEncoded, "    ") + ",\n")
+		case req.BodyKind == mhttp.HttpBodyKindFormData && len(req.Form) > 0:
+			var fields, files []Pair
+			for _, p := range req.Form {
+				if p.File {
+					files = append(files, p)
+				} else {
+					fields = append(fields, p)
+				}
+			}
+			if len(fields) > 0 {
+				b.WriteString("    data=" + pythonPairs(fields, "    ") + ",\n")
+			}
+			if len(files) > 0 {
+				b.WriteString("    files=[\n")
+				for _, f := range files {
+					fmt.Fprintf(&b, "        (%s, open(%s, \"rb\")),\n", quoteJSON(f.Key), quoteJSON(f.Value))
+				}
+				b.WriteString("    ],\n")
+			}
+		}
+
+		b.WriteString(")\nprint(response.status_code)\nprint(response.text)\n")
+	}
+	return b.String(), nil
+}
+
+// pythonPairs renders a dict, or a list of tuples when keys repeat
+func pythonPairs(pairs []Pair, indent string) string {
+	if !hasDuplicateKeys(pairs) {
+		return pythonDict(pairs, indent)
+	}
+	var b strings.Builder
+	b.WriteString("[\n")
+	for _, p := range pairs {
+		fmt.Fprintf(&b, "%s    (%s, %s),\n", indent, quoteJSON(p.Key), quoteJSON(p.Value))
+	}
+	b.WriteString(indent + "]")
+	return b.String()
+}
+
+func pythonDict(pairs []Pair, indent string) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, p := range pairs {
+		fmt.Fprintf(&b, "%s    %s: %s,\n", indent, quoteJSON(p.Key), quoteJSON(p.Value))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
@@ -0,0 +1,336 @@
+//nolint:revive // exported
+package tcodegen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/compress"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/delta"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcurlv2"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/varsystem"
+)
+
+// Language names a code generation target
+type Language string
+
+const (
+	LanguageGo         Language = "go"
+	LanguagePython     Language = "python"
+	LanguageJavaScript Language = "javascript"
+	LanguageHTTPie     Language = "httpie"
+	LanguageK6         Language = "k6"
+)
+
+// Generator renders requests as a single source file of one language
+type Generator interface {
+	Generate(requests []*Request) (string, error)
+}
+
+var generators = map[Language]Generator{
+	LanguageGo:         goGenerator{},
+	LanguagePython:     pythonGenerator{},
+	LanguageJavaScript: javascriptGenerator{},
+	LanguageHTTPie:     httpieGenerator{},
+}
+
+var ErrUnsupportedLanguage = errors.New("tcodegen: unsupported language")
+
+// Languages returns every supported target in a stable order, k6 included
+func Languages() []string {
+	languages := make([]string, 0, len(generators)+1)
+	for l := range generators {
+		languages = append(languages, string(l))
+	}
+	languages = append(languages, string(LanguageK6))
+	sort.Strings(languages)
+	return languages
+}
+
+// Generate renders requests with the generator registered for lang. k6 works
+// on whole flows and goes through GenerateK6 instead.
+func Generate(lang Language, requests []*Request) (string, error) {
+	gen, ok := generators[lang]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnsupportedLanguage, lang)
+	}
+	return gen.Generate(requests)
+}
+
+// Source is a stored HTTP request together with its child entities
+type Source struct {
+	Resolved *tcurlv2.CurlResolvedV2
+	Asserts  []mhttp.HTTPAssert
+}
+
+// ResolveDelta overlays a delta request on its base request so the generated
+// code sends what the flow runner would send
+func ResolveDelta(base, d *Source) *Source {
+	input := delta.ResolveHTTPInput{
+		Base:                base.Resolved.HTTP,
+		Delta:               d.Resolved.HTTP,
+		BaseQueries:         base.Resolved.SearchParams,
+		DeltaQueries:        d.Resolved.SearchParams,
+		BaseHeaders:         base.Resolved.Headers,
+		DeltaHeaders:        d.Resolved.Headers,
+		BaseFormBody:        base.Resolved.BodyForms,
+		DeltaFormBody:       d.Resolved.BodyForms,
+		BaseUrlEncodedBody:  base.Resolved.BodyUrlencoded,
+		DeltaUrlEncodedBody: d.Resolved.BodyUrlencoded,
+		BaseAsserts:         base.Asserts,
+		DeltaAsserts:        d.Asserts,
+	}
+	if base.Resolved.BodyRaw != nil {
+		input.BaseRawBody = *base.Resolved.BodyRaw
+	}
+	if d.Resolved.BodyRaw != nil {
+		input.DeltaRawBody = *d.Resolved.BodyRaw
+	}
+
+	output := delta.ResolveHTTP(input)
+	resolved := &tcurlv2.CurlResolvedV2{
+		HTTP:           output.Resolved,
+		SearchParams:   output.ResolvedQueries,
+		Headers:        output.ResolvedHeaders,
+		BodyForms:      output.ResolvedFormBody,
+		BodyUrlencoded: output.ResolvedUrlEncodedBody,
+		File:           base.Resolved.File,
+	}
+	if len(output.ResolvedRawBody.RawData) > 0 {
+		resolved.BodyRaw = &output.ResolvedRawBody
+	}
+	return &Source{Resolved: resolved, Asserts: output.ResolvedAsserts}
+}
+
+// Pair is an enabled key/value entry of a request
+type Pair struct {
+	Key   string
+	Value string
+	// File marks a form entry whose Value is the path of a file to upload
+	File bool
+}
+
+// Request is the language neutral form of an HTTP request that generators
+// render. Only enabled entries of the configured body kind are kept.
+type Request struct {
+	Name       string
+	Method     string
+	URL        string
+	Query      []Pair
+	Headers    []Pair
+	BodyKind   mhttp.HttpBodyKind
+	Body       string
+	Form       []Pair
+	URLEncoded []Pair
+	Asserts    []string
+}
+
+const fileRefPrefix = "#file:"
+
+// NewRequest flattens src into a Request. When vars is not nil, `{{ key }}`
+// references to known variables are replaced by their values; everything
+// else, including chaining references and expressions, is kept verbatim.
+func NewRequest(src *Source, vars varsystem.VarMap) (*Request, error) {
+	resolved := src.Resolved
+	sub := func(s string) string { return interpolate(s, vars) }
+
+	method := strings.ToUpper(strings.TrimSpace(resolved.HTTP.Method))
+	if method == "" {
+		method = tcurlv2.MethodGET
+	}
+	req := &Request{
+		Name:     resolved.HTTP.Name,
+		Method:   method,
+		URL:      sub(resolved.HTTP.Url),
+		BodyKind: resolved.HTTP.BodyKind,
+	}
+
+	params := append([]mhttp.HTTPSearchParam(nil), resolved.SearchParams...)
+	sort.SliceStable(params, func(i, j int) bool { return params[i].DisplayOrder < params[j].DisplayOrder })
+	for _, p := range params {
+		if p.Enabled {
+			req.Query = append(req.Query, Pair{Key: sub(p.Key), Value: sub(p.Value)})
+		}
+	}
+
+	headers := append([]mhttp.HTTPHeader(nil), resolved.Headers...)
+	sort.SliceStable(headers, func(i, j int) bool { return headers[i].DisplayOrder < headers[j].DisplayOrder })
+	for _, h := range headers {
+		if h.Enabled {
+			req.Headers = append(req.Headers, Pair{Key: h.Key, Value: sub(h.Value)})
+		}
+	}
+
+	switch resolved.HTTP.BodyKind {
+	case mhttp.HttpBodyKindRaw:
+		if resolved.BodyRaw != nil && len(resolved.BodyRaw.RawData) > 0 {
+			data := resolved.BodyRaw.RawData
+			if resolved.BodyRaw.CompressionType != compress.CompressTypeNone {
+				decompressed, err := compress.Decompress(data, resolved.BodyRaw.CompressionType)
+				if err != nil {
+					return nil, fmt.Errorf("tcodegen: decompress raw body of %s: %w", req.Name, err)
+				}
+				data = decompressed
+			}
+			req.Body = sub(string(data))
+		}
+	case mhttp.HttpBodyKindFormData:
+		forms := append([]mhttp.HTTPBodyForm(nil), resolved.BodyForms...)
+		sort.SliceStable(forms, func(i, j int) bool { return forms[i].DisplayOrder < forms[j].DisplayOrder })
+		for _, f := range forms {
+			if !f.Enabled {
+				continue
+			}
+			if path, ok := strings.CutPrefix(strings.TrimSpace(f.Value), fileRefPrefix); ok {
+				req.Form = append(req.Form, Pair{Key: sub(f.Key), Value: sub(path), File: true})
+				continue
+			}
+			req.Form = append(req.Form, Pair{Key: sub(f.Key), Value: sub(f.Value)})
+		}
+		// The boundary is chosen by the client, a stored multipart content type
+		// would not match it.
+		req.Headers = withoutHeader(req.Headers, "Content-Type")
+	case mhttp.HttpBodyKindUrlEncoded:
+		bodies := append([]mhttp.HTTPBodyUrlencoded(nil), resolved.BodyUrlencoded...)
+		sort.SliceStable(bodies, func(i, j int) bool { return bodies[i].DisplayOrder < bodies[j].DisplayOrder })
+		for _, u := range bodies {
+			if u.Enabled {
+				req.URLEncoded = append(req.URLEncoded, Pair{Key: sub(u.Key), Value: sub(u.Value)})
+			}
+		}
+	}
+
+	for _, a := range src.Asserts {
+		if a.Enabled && strings.TrimSpace(a.Value) != "" {
+			req.Asserts = append(req.Asserts, a.Value)
+		}
+	}
+
+	return req, nil
+}
+
+// FullURL returns the request URL with its query parameters appended.
+// Template references are left unescaped so they stay readable.
+func (r *Request) FullURL() string {
+	if len(r.Query) == 0 {
+		return r.URL
+	}
+	parts := make([]string, 0, len(r.Query))
+	for _, q := range r.Query {
+		parts = append(parts, queryEscape(q.Key)+"="+queryEscape(q.Value))
+	}
+	separator := "?"
+	if strings.Contains(r.URL, "?") {
+		separator = "&"
+	}
+	return r.URL + separator + strings.Join(parts, "&")
+}
+
+// Header returns the value of the first header named key
+func (r *Request) Header(key string) (string, bool) {
+	for _, h := range r.Headers {
+		if strings.EqualFold(h.Key, key) {
+			return h.Value, true
+		}
+	}
+	return "", false
+}
+
+// templatePattern matches `{{ ... }}` references
+var templatePattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+func interpolate(s string, vars varsystem.VarMap) string {
+	if vars == nil || !strings.Contains(s, "{{") {
+		return s
+	}
+	return templatePattern.ReplaceAllStringFunc(s, func(match string) string {
+		key := templatePattern.FindStringSubmatch(match)[1]
+		if v, ok := vars.Get(key); ok {
+			return v.Value
+		}
+		return match
+	})
+}
+
+func queryEscape(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range templatePattern.FindAllStringIndex(s, -1) {
+		b.WriteString(url.QueryEscape(s[last:loc[0]]))
+		b.WriteString(s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(url.QueryEscape(s[last:]))
+	return b.String()
+}
+
+func withoutHeader(headers []Pair, key string) []Pair {
+	kept := headers[:0]
+	for _, h := range headers {
+		if !strings.EqualFold(h.Key, key) {
+			kept = append(kept, h)
+		}
+	}
+	return kept
+}
+
+// mergedHeaders folds repeated header keys into one comma separated value for
+// targets that take headers as a map
+func mergedHeaders(headers []Pair) []Pair {
+	var merged []Pair
+	index := make(map[string]int, len(headers))
+	for _, h := range headers {
+		key := strings.ToLower(h.Key)
+		if i, ok := index[key]; ok {
+			merged[i].Value += ", " + h.Value
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, h)
+	}
+	return merged
+}
+
+func hasDuplicateKeys(pairs []Pair) bool {
+	seen := make(map[string]bool, len(pairs))
+	for _, p := range pairs {
+		if seen[p.Key] {
+			return true
+		}
+		seen[p.Key] = true
+	}
+	return false
+}
+
+// identWords splits a request name into alphanumeric words
+func identWords(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	})
+}
+
+// uniqueName returns name, or name with a numeric suffix when it is taken
+func uniqueName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// quoteJSON quotes s as a JSON string, which is also a valid Python and
+// JavaScript string literal
+func quoteJSON(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,175 @@
+package tcodegen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/menv"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/translate/tcurlv2"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/varsystem"
+
+	"github.com/stretchr/testify/require"
+)
+
+const codegenScript = `
+curl -X POST "$BASE_URL/users?dry_run=true" \
+  -H 'Content-Type: application/json' \
+  -H "Authorization: Bearer $TOKEN" \
+  --data-raw '{"name":"Ada","note":"it'\''s \"quoted\""}'
+
+curl "$BASE_URL/users/42/avatar" -F 'avatar=@./avatar.png' -F 'title=Profile picture'
+
+curl "$BASE_URL/login" --data-urlencode 'user=ada' --data-urlencode 'pass=s3cr3t&x'
+`
+
+func codegenRequests(t *testing.T) []*Request {
+	t.Helper()
+	script, err := tcurlv2.ConvertCurlScript(codegenScript, tcurlv2.ConvertCurlScriptOptions{WorkspaceID: idwrap.NewNow()})
+	require.NoError(t, err)
+	require.Len(t, script.Requests, 3)
+
+	vars := varsystem.NewVarMap([]menv.Variable{{VarKey: "BASE_URL", Value: "https://api.example.com"}})
+	requests := make([]*Request, 0, len(script.Requests))
+	for _, resolved := range script.Requests {
+		req, err := NewRequest(&Source{Resolved: resolved}, vars)
+		require.NoError(t, err)
+		requests = append(requests, req)
+	}
+	return requests
+}
+
+func TestNewRequest(t *testing.T) {
+	requests := codegenRequests(t)
+
+	create := requests[0]
+	require.Equal(t, "POST", create.Method)
+	require.Equal(t, "https://api.example.com/users", create.URL)
+	require.Equal(t, "https://api.example.com/users?dry_run=true", create.FullURL())
+	value, ok := create.Header("authorization")
+	require.True(t, ok)
+	require.Equal(t, "Bearer {{TOKEN}}", value, "unknown variables are kept")
+
+	upload := requests[1]
+	require.Equal(t, []Pair{
+		{Key: "avatar", Value: "./avatar.png", File: true},
+		{Key: "title", Value: "Profile picture"},
+	}, upload.Form)
+
+	login := requests[2]
+	require.Equal(t, mhttp.HttpBodyKindUrlEncoded, login.BodyKind)
+	require.Equal(t, []Pair{{Key: "user", Value: "ada"}, {Key: "pass", Value: "s3cr3t&x"}}, login.URLEncoded)
+}
+
+func TestGenerate(t *testing.T) {
+	requests := codegenRequests(t)
+
+	tests := []struct {
+		lang Language
+		want []string
+	}{
+		{
+			lang: LanguageGo,
+			want: []string{
+				`req, err := http.NewRequest("POST", "https://api.example.com/users?dry_run=true", strings.NewReader(` + "`" + `{"name":"Ada","note":"it's \"quoted\""}` + "`" + `))`,
+				`req.Header.Add("Authorization", "Bearer {{TOKEN}}")`,
+				`file1, err := os.Open("./avatar.png")`,
+				`req.Header.Set("Content-Type", writer.FormDataContentType())`,
+				`form.Add("pass", "s3cr3t&x")`,
+				"if err := users(); err != nil {",
+			},
+		},
+		{
+			lang: LanguagePython,
+			want: []string{
+				"import requests",
+				`"https://api.example.com/users?dry_run=true",`,
+				`"Content-Type": "application/json",`,
+				`("avatar", open("./avatar.png", "rb")),`,
+				`"pass": "s3cr3t&x",`,
+			},
+		},
+		{
+			lang: LanguageJavaScript,
+			want: []string{
+				`import { openAsBlob } from "node:fs";`,
+				`const response = await fetch("https://api.example.com/users?dry_run=true", {`,
+				`body.append("avatar", await openAsBlob("./avatar.png"), "avatar.png");`,
+				`["pass", "s3cr3t&x"],`,
+			},
+		},
+		{
+			lang: LanguageHTTPie,
+			want: []string{
+				`http --raw '{"name":"Ada","note":"it'\''s \"quoted\""}' POST https://api.example.com/users \`,
+				`dry_run==true`,
+				`'Authorization:Bearer {{TOKEN}}'`,
+				`http --multipart POST https://api.example.com/users/42/avatar \`,
+				`avatar@./avatar.png`,
+				`http --form POST https://api.example.com/login \`,
+				`'pass=s3cr3t&x'`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			out, err := Generate(tt.lang, requests)
+			require.NoError(t, err)
+			for _, want := range tt.want {
+				require.Contains(t, out, want)
+			}
+		})
+	}
+}
+
+func TestGenerate_GoCompilesToValidSource(t *testing.T) {
+	requests := codegenRequests(t)
+	// Clashing names must still give distinct functions that do not shadow
+	// the imported packages.
+	requests[1].Name = "http"
+	requests[2].Name = "users"
+
+	out, err := Generate(LanguageGo, requests)
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "main.go", out, parser.AllErrors)
+	require.NoError(t, err)
+	require.Contains(t, out, "func http2() error {")
+	require.Contains(t, out, "func users2() error {")
+}
+
+func TestGenerate_UnsupportedLanguage(t *testing.T) {
+	_, err := Generate("cobol", nil)
+	require.ErrorIs(t, err, ErrUnsupportedLanguage)
+	_, err = Generate(LanguageK6, nil)
+	require.ErrorIs(t, err, ErrUnsupportedLanguage)
+}
+
+func TestResolveDelta(t *testing.T) {
+	baseID, deltaID := idwrap.NewNow(), idwrap.NewNow()
+	baseHeaderID := idwrap.NewNow()
+	base := &Source{Resolved: &tcurlv2.CurlResolvedV2{
+		HTTP:    mhttp.HTTP{ID: baseID, Name: "Get user", Method: "GET", Url: "https://example.com/users/1"},
+		Headers: []mhttp.HTTPHeader{{ID: baseHeaderID, HttpID: baseID, Key: "X-Mode", Value: "base", Enabled: true}},
+	}}
+	deltaURL := "https://example.com/users/2"
+	deltaValue := "delta"
+	d := &Source{Resolved: &tcurlv2.CurlResolvedV2{
+		HTTP: mhttp.HTTP{ID: deltaID, ParentHttpID: &baseID, IsDelta: true, DeltaUrl: &deltaURL},
+		Headers: []mhttp.HTTPHeader{{
+			ID: idwrap.NewNow(), HttpID: deltaID, Key: "X-Mode", Enabled: true,
+			ParentHttpHeaderID: &baseHeaderID, IsDelta: true, DeltaValue: &deltaValue,
+		}},
+	}}
+
+	req, err := NewRequest(ResolveDelta(base, d), nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/users/2", req.URL)
+	require.Equal(t, []Pair{{Key: "X-Mode", Value: "delta"}}, req.Headers)
+}
+
+func TestLanguages(t *testing.T) {
+	require.Equal(t, []string{"go", "httpie", "javascript", "k6", "python"}, Languages())
+}
@@ -0,0 +1,765 @@
+package tcurlv2
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/compress"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mfile"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+)
+
+// CurlScriptResolved contains a flow with one request node per curl command
+// of a shell script
+type CurlScriptResolved struct {
+	Requests []*CurlResolvedV2
+
+	// Shell variables used by the script, to be stored as environment variables
+	Variables []CurlVariable
+
+	Flow         mflow.Flow
+	Nodes        []mflow.Node
+	RequestNodes []mflow.NodeRequest
+	Edges        []mflow.Edge
+	FlowFile     mfile.File
+}
+
+// CurlVariable is a shell variable of a curl script. Value is empty for
+// variables the script reads but never assigns.
+type CurlVariable struct {
+	Key   string
+	Value string
+}
+
+// ConvertCurlScriptOptions contains options for the curl script conversion
+type ConvertCurlScriptOptions struct {
+	WorkspaceID idwrap.IDWrap
+	FolderID    *idwrap.IDWrap
+	FlowName    string // Optional flow name (defaults to "cURL Script")
+}
+
+// defaultAcceptEncoding is what --compressed asks the server for.
+const defaultAcceptEncoding = "deflate, gzip, br, zstd"
+
+// templateVarPattern matches the {{VAR}} references shell variables become.
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// ConvertCurlScript converts a shell snippet holding one or more curl
+// commands into a flow with a request node per command, chained in script
+// order. Shell variables ($VAR, ${VAR}, ${VAR:-default}) become {{VAR}}
+// references and are returned as Variables, file arguments (@path) become
+// #file: references.
+func ConvertCurlScript(script string, opts ConvertCurlScriptOptions) (*CurlScriptResolved, error) {
+	statements, defaults, err := splitShellScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make(map[string]string)
+	var commands [][]string
+	for _, words := range statements {
+		if words[0] == "export" || words[0] == "local" || words[0] == "readonly" {
+			words = words[1:]
+		}
+		i := 0
+		for ; i < len(words); i++ {
+			name, value, ok := splitAssignment(words[i])
+			if !ok {
+				break
+			}
+			assigned[name] = resolveAssigned(value, assigned)
+		}
+		if i < len(words) && words[i] == "curl" {
+			commands = append(commands, words[i+1:])
+		}
+	}
+
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("no curl command found in script")
+	}
+
+	flowName := opts.FlowName
+	if flowName == "" {
+		flowName = "cURL Script"
+	}
+	flowID := idwrap.NewNow()
+	result := &CurlScriptResolved{
+		Flow: mflow.Flow{
+			ID:          flowID,
+			WorkspaceID: opts.WorkspaceID,
+			Name:        flowName,
+		},
+	}
+
+	startNodeID := idwrap.NewNow()
+	result.Nodes = append(result.Nodes, mflow.Node{
+		ID:       startNodeID,
+		FlowID:   flowID,
+		Name:     "Start",
+		NodeKind: mflow.NODE_KIND_MANUAL_START,
+	})
+	previousNodeID := startNodeID
+
+	for i, args := range commands {
+		resolved, err := convertCurlArgs(args, opts)
+		if err != nil {
+			return nil, fmt.Errorf("curl command %d: %w", i+1, err)
+		}
+		result.Requests = append(result.Requests, resolved)
+
+		nodeID := idwrap.NewNow()
+		result.Nodes = append(result.Nodes, mflow.Node{
+			ID:        nodeID,
+			FlowID:    flowID,
+			Name:      fmt.Sprintf("http_%d", i+1),
+			NodeKind:  mflow.NODE_KIND_REQUEST,
+			PositionX: float64(i+1) * 300,
+		})
+		result.RequestNodes = append(result.RequestNodes, mflow.NodeRequest{
+			FlowNodeID: nodeID,
+			HttpID:     &resolved.HTTP.ID,
+		})
+		result.Edges = append(result.Edges, mflow.Edge{
+			ID:            idwrap.NewNow(),
+			FlowID:        flowID,
+			SourceID:      previousNodeID,
+			TargetID:      nodeID,
+			SourceHandler: mflow.HandleUnspecified,
+		})
+		previousNodeID = nodeID
+	}
+
+	result.FlowFile = mfile.File{
+		ID:          flowID,
+		WorkspaceID: opts.WorkspaceID,
+		ParentID:    opts.FolderID,
+		ContentID:   &flowID,
+		ContentType: mfile.ContentTypeFlow,
+		Name:        flowName,
+		Order:       -1,
+		UpdatedAt:   time.Now(),
+	}
+
+	result.Variables = collectVariables(commands, assigned, defaults)
+
+	return result, nil
+}
+
+// collectVariables lists every variable the curl commands reference, plus
+// those the script assigns, sorted by name. Unassigned variables take their
+// ${VAR:-default} value, if any.
+func collectVariables(commands [][]string, assigned, defaults map[string]string) []CurlVariable {
+	values := make(map[string]string, len(assigned))
+	for k, v := range assigned {
+		values[k] = v
+	}
+	for _, args := range commands {
+		for _, arg := range args {
+			for _, m := range templateVarPattern.FindAllStringSubmatch(arg, -1) {
+				if _, ok := values[m[1]]; !ok {
+					values[m[1]] = defaults[m[1]]
+				}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	vars := make([]CurlVariable, 0, len(keys))
+	for _, k := range keys {
+		vars = append(vars, CurlVariable{Key: k, Value: values[k]})
+	}
+	return vars
+}
+
+// resolveAssigned inlines variables that were assigned earlier in the script,
+// so FOO=$BAR/x stores BAR's value rather than a nested reference.
+func resolveAssigned(value string, assigned map[string]string) string {
+	return templateVarPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := templateVarPattern.FindStringSubmatch(ref)[1]
+		if v, ok := assigned[name]; ok {
+			return v
+		}
+		return ref
+	})
+}
+
+func splitAssignment(word string) (string, string, bool) {
+	eq := strings.IndexByte(word, '=')
+	if eq <= 0 || !isShellName(word[:eq]) {
+		return "", "", false
+	}
+	return word[:eq], word[eq+1:], true
+}
+
+func isShellName(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
+// --- curl arguments ---
+
+// curlArgFlags are the curl options that consume the following word.
+var curlArgFlags = map[string]bool{
+	"-X": true, "--request": true,
+	"-H": true, "--header": true,
+	"-A": true, "--user-agent": true,
+	"-e": true, "--referer": true,
+	"-b": true, "--cookie": true,
+	"-u": true, "--user": true,
+	"-d": true, "--data": true, "--data-ascii": true, "--data-raw": true, "--data-binary": true,
+	"--data-urlencode": true, "--json": true,
+	"-F": true, "--form": true, "--form-string": true,
+	"--url": true, "--oauth2-bearer": true,
+
+	// Options without an equivalent in a request definition
+	"-o": true, "--output": true, "-w": true, "--write-out": true,
+	"-m": true, "--max-time": true, "--connect-timeout": true,
+	"--retry": true, "--retry-delay": true, "--retry-max-time": true,
+	"-x": true, "--proxy": true, "-U": true, "--proxy-user": true,
+	"--cacert": true, "--capath": true, "-E": true, "--cert": true, "--key": true,
+	"--resolve": true, "--connect-to": true, "--limit-rate": true,
+	"-c": true, "--cookie-jar": true, "-T": true, "--upload-file": true,
+	"-K": true, "--config": true, "--max-redirs": true, "-r": true, "--range": true,
+	"-z": true, "--time-cond": true, "--interface": true, "--dns-servers": true,
+	"-D": true, "--dump-header": true, "--trace": true, "--trace-ascii": true,
+}
+
+// convertCurlArgs converts the arguments of one curl invocation, already
+// split and unquoted by the shell lexer.
+func convertCurlArgs(args []string, opts ConvertCurlScriptOptions) (*CurlResolvedV2, error) {
+	httpID := idwrap.NewNow()
+	now := time.Now().UnixMilli()
+
+	var (
+		rawURL     string
+		method     string
+		dataParts  []string
+		getMode    bool
+		headMode   bool
+		headers    []mhttp.HTTPHeader
+		forms      []mhttp.HTTPBodyForm
+		urlencoded []mhttp.HTTPBodyUrlencoded
+	)
+
+	addHeader := func(key, value string) {
+		headers = append(headers, mhttp.HTTPHeader{
+			ID:           idwrap.NewNow(),
+			HttpID:       httpID,
+			Key:          key,
+			Value:        value,
+			Enabled:      true,
+			DisplayOrder: float32(len(headers)),
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+	}
+
+	handle := func(flag, value string) {
+		switch flag {
+		case "-X", "--request":
+			method = strings.ToUpper(value)
+		case "-H", "--header":
+			if key, val, ok := strings.Cut(value, ":"); ok {
+				addHeader(strings.TrimSpace(key), strings.TrimSpace(val))
+			}
+		case "-A", "--user-agent":
+			addHeader("User-Agent", value)
+		case "-e", "--referer":
+			addHeader("Referer", value)
+		case "-b", "--cookie":
+			// Without "=" the value names a cookie file, not cookies.
+			if strings.Contains(value, "=") {
+				addHeader("Cookie", value)
+			}
+		case "-u", "--user":
+			addHeader("Authorization", basicAuth(value))
+		case "--oauth2-bearer":
+			addHeader("Authorization", "Bearer "+value)
+		case "-d", "--data", "--data-ascii", "--data-binary":
+			dataParts = append(dataParts, fileData(value))
+		case "--data-raw":
+			dataParts = append(dataParts, value)
+		case "--json":
+			dataParts = append(dataParts, fileData(value))
+			addHeader("Content-Type", "application/json")
+			addHeader("Accept", "application/json")
+		case "--data-urlencode":
+			key, val := splitURLEncoded(value)
+			urlencoded = append(urlencoded, mhttp.HTTPBodyUrlencoded{
+				ID:           idwrap.NewNow(),
+				HttpID:       httpID,
+				Key:          key,
+				Value:        val,
+				Enabled:      true,
+				DisplayOrder: float32(len(urlencoded)),
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			})
+		case "-F", "--form", "--form-string":
+			key, val, _ := strings.Cut(value, "=")
+			if flag != "--form-string" {
+				val = formValue(val)
+			}
+			forms = append(forms, mhttp.HTTPBodyForm{
+				ID:           idwrap.NewNow(),
+				HttpID:       httpID,
+				Key:          key,
+				Value:        val,
+				Enabled:      true,
+				DisplayOrder: float32(len(forms)),
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			})
+		case "--url":
+			if rawURL == "" {
+				rawURL = value
+			}
+		}
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--compressed":
+			addHeader("Accept-Encoding", defaultAcceptEncoding)
+		case arg == "-G" || arg == "--get":
+			getMode = true
+		case arg == "-I" || arg == "--head":
+			headMode = true
+		case strings.HasPrefix(arg, "--"):
+			if curlArgFlags[arg] {
+				if i+1 >= len(args) {
+					return nil, fmt.Errorf("option %s requires a value", arg)
+				}
+				handle(arg, args[i+1])
+				i++
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			// Short options can be combined (-sSL) and carry their value
+			// attached (-XPOST) as long as the value-taking one comes last.
+			for j := 1; j < len(arg); j++ {
+				flag := "-" + string(arg[j])
+				switch flag {
+				case "-G":
+					getMode = true
+					continue
+				case "-I":
+					headMode = true
+					continue
+				}
+				if !curlArgFlags[flag] {
+					continue
+				}
+				value := arg[j+1:]
+				if value == "" {
+					if i+1 >= len(args) {
+						return nil, fmt.Errorf("option %s requires a value", flag)
+					}
+					i++
+					value = args[i]
+				}
+				handle(flag, value)
+				break
+			}
+		default:
+			if rawURL == "" {
+				rawURL = arg
+			}
+		}
+	}
+
+	if rawURL == "" {
+		return nil, fmt.Errorf("URL not found in curl command")
+	}
+	if !strings.Contains(rawURL, "://") && !strings.HasPrefix(rawURL, "{{") {
+		rawURL = "http://" + rawURL
+	}
+
+	data := strings.Join(dataParts, "&")
+	if getMode && data != "" {
+		separator := "?"
+		if strings.Contains(rawURL, "?") {
+			separator = "&"
+		}
+		rawURL += separator + data
+		data = ""
+	}
+
+	baseURL, searchParams := parseURLAndSearchQueries(rawURL, httpID)
+	if getMode {
+		for _, u := range urlencoded {
+			searchParams = append(searchParams, mhttp.HTTPSearchParam{
+				ID:           idwrap.NewNow(),
+				HttpID:       httpID,
+				Key:          u.Key,
+				Value:        u.Value,
+				Enabled:      true,
+				DisplayOrder: float64(len(searchParams)),
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			})
+		}
+		urlencoded = nil
+	}
+
+	bodyKind := mhttp.HttpBodyKindNone
+	var bodyRaw *mhttp.HTTPBodyRaw
+	switch {
+	case data != "":
+		bodyKind = mhttp.HttpBodyKindRaw
+		bodyRaw = &mhttp.HTTPBodyRaw{
+			ID:              idwrap.NewNow(),
+			HttpID:          httpID,
+			RawData:         []byte(data),
+			CompressionType: compress.CompressTypeNone,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+	case len(forms) > 0:
+		bodyKind = mhttp.HttpBodyKindFormData
+	case len(urlencoded) > 0:
+		bodyKind = mhttp.HttpBodyKindUrlEncoded
+	}
+
+	switch {
+	case method != "":
+	case headMode:
+		method = "HEAD"
+	case bodyKind != mhttp.HttpBodyKindNone:
+		method = "POST"
+	default:
+		method = MethodGET
+	}
+
+	name := generateFilenameFromURL(templateVarPattern.ReplaceAllString(baseURL, ""))
+	httpReq := mhttp.HTTP{
+		ID:          httpID,
+		WorkspaceID: opts.WorkspaceID,
+		FolderID:    opts.FolderID,
+		Name:        name,
+		Url:         baseURL,
+		Method:      method,
+		BodyKind:    bodyKind,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	return &CurlResolvedV2{
+		HTTP:           httpReq,
+		SearchParams:   searchParams,
+		Headers:        headers,
+		BodyForms:      forms,
+		BodyUrlencoded: urlencoded,
+		BodyRaw:        bodyRaw,
+		File: mfile.File{
+			ID:          idwrap.NewNow(),
+			WorkspaceID: opts.WorkspaceID,
+			ParentID:    opts.FolderID,
+			ContentID:   &httpID,
+			ContentType: mfile.ContentTypeHTTP,
+			Name:        name,
+			UpdatedAt:   time.Now(),
+		},
+	}, nil
+}
+
+// basicAuth builds the Authorization header for -u user:pass. Credentials
+// holding shell variables are encoded at run time through base64().
+func basicAuth(credentials string) string {
+	if !strings.Contains(credentials, ":") {
+		credentials += ":"
+	}
+	refs := templateVarPattern.FindAllStringSubmatchIndex(credentials, -1)
+	if len(refs) == 0 {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+
+	var parts []string
+	last := 0
+	for _, loc := range refs {
+		if loc[0] > last {
+			parts = append(parts, strconv.Quote(credentials[last:loc[0]]))
+		}
+		parts = append(parts, credentials[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	if last < len(credentials) {
+		parts = append(parts, strconv.Quote(credentials[last:]))
+	}
+	return "Basic {{ base64(" + strings.Join(parts, " + ") + ") }}"
+}
+
+// fileData maps curl's @path data argument to a #file: reference that is
+// read when the request runs.
+func fileData(value string) string {
+	if path, ok := strings.CutPrefix(value, "@"); ok && path != "-" {
+		return "{{ #file:" + path + " }}"
+	}
+	return value
+}
+
+// formValue maps -F name=@path (upload) and name=<path (contents) values,
+// dropping curl's ;type= and ;filename= attributes.
+func formValue(value string) string {
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		path, _, _ = strings.Cut(path, ";")
+		return "#file:" + path
+	}
+	if path, ok := strings.CutPrefix(value, "<"); ok {
+		path, _, _ = strings.Cut(path, ";")
+		return "{{ #file:" + path + " }}"
+	}
+	return value
+}
+
+// splitURLEncoded splits a --data-urlencode argument in its curl forms:
+// "content", "=content", "name=content", "@file" and "name@file".
+func splitURLEncoded(value string) (string, string) {
+	eq := strings.IndexByte(value, '=')
+	at := strings.IndexByte(value, '@')
+	switch {
+	case at >= 0 && (eq < 0 || at < eq):
+		return value[:at], "{{ #file:" + value[at+1:] + " }}"
+	case eq >= 0:
+		return value[:eq], value[eq+1:]
+	default:
+		return value, ""
+	}
+}
+
+// --- shell lexer ---
+
+var errUnterminatedQuote = errors.New("unterminated quote in script")
+
+// scriptLexer splits a shell snippet into statements of words, applying
+// quote removal, line continuations and comment stripping. Variable
+// expansions outside single quotes become {{NAME}} references.
+type scriptLexer struct {
+	defaults   map[string]string
+	src        []rune
+	pos        int
+	statements [][]string
+	words      []string
+	word       strings.Builder
+	inWord     bool
+}
+
+func splitShellScript(script string) ([][]string, map[string]string, error) {
+	l := &scriptLexer{
+		defaults: make(map[string]string),
+		src:      []rune(strings.ReplaceAll(script, "\r\n", "\n")),
+	}
+	if err := l.run(); err != nil {
+		return nil, nil, err
+	}
+	return l.statements, l.defaults, nil
+}
+
+func (l *scriptLexer) run() error {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\\':
+			if l.pos+1 < len(l.src) && l.src[l.pos+1] != '\n' {
+				l.write(l.src[l.pos+1])
+			}
+			l.pos += 2
+		case c == '\'':
+			end := l.indexFrom(l.pos+1, '\'')
+			if end < 0 {
+				return errUnterminatedQuote
+			}
+			l.writeString(string(l.src[l.pos+1 : end]))
+			l.pos = end + 1
+		case c == '$' && l.peek(1) == '\'':
+			if err := l.ansiQuoted(); err != nil {
+				return err
+			}
+		case c == '"':
+			if err := l.doubleQuoted(); err != nil {
+				return err
+			}
+		case c == '$':
+			l.expand()
+		case c == '#' && !l.inWord:
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		case c == ' ' || c == '\t':
+			l.endWord()
+			l.pos++
+		case c == '\n' || c == ';' || c == '&' || c == '|':
+			l.endStatement()
+			l.pos++
+		default:
+			l.write(c)
+			l.pos++
+		}
+	}
+	l.endStatement()
+	return nil
+}
+
+func (l *scriptLexer) doubleQuoted() error {
+	l.inWord = true
+	l.pos++
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return nil
+		case c == '\\' && l.pos+1 < len(l.src):
+			next := l.src[l.pos+1]
+			switch next {
+			case '$', '`', '"', '\\':
+				l.write(next)
+			case '\n':
+			default:
+				l.write(c)
+				l.write(next)
+			}
+			l.pos += 2
+		case c == '$':
+			l.expand()
+		default:
+			l.write(c)
+			l.pos++
+		}
+	}
+	return errUnterminatedQuote
+}
+
+// ansiQuoted handles $'...' strings, which browsers use when copying
+// requests as curl.
+func (l *scriptLexer) ansiQuoted() error {
+	l.inWord = true
+	l.pos += 2
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == '\'' {
+			l.pos++
+			return nil
+		}
+		if c == '\\' && l.pos+1 < len(l.src) {
+			next := l.src[l.pos+1]
+			switch next {
+			case 'n':
+				l.write('\n')
+			case 't':
+				l.write('\t')
+			case 'r':
+				l.write('\r')
+			case '\\', '\'', '"':
+				l.write(next)
+			default:
+				l.write(c)
+				l.write(next)
+			}
+			l.pos += 2
+			continue
+		}
+		l.write(c)
+		l.pos++
+	}
+	return errUnterminatedQuote
+}
+
+// expand reads a $NAME or ${NAME} expansion at the current position.
+func (l *scriptLexer) expand() {
+	l.inWord = true
+	if l.peek(1) == '{' {
+		end := l.indexFrom(l.pos+2, '}')
+		if end >= 0 {
+			inner := string(l.src[l.pos+2 : end])
+			name := inner
+			if i := strings.IndexAny(inner, ":-=+?"); i >= 0 {
+				name = inner[:i]
+				op := strings.TrimPrefix(inner[i:], ":")
+				if strings.HasPrefix(op, "-") || strings.HasPrefix(op, "=") {
+					l.defaults[name] = op[1:]
+				}
+			}
+			if isShellName(name) {
+				l.writeString("{{" + name + "}}")
+				l.pos = end + 1
+				return
+			}
+		}
+	}
+
+	start := l.pos + 1
+	end := start
+	for end < len(l.src) && isShellName(string(l.src[start:end+1])) {
+		end++
+	}
+	if end == start {
+		l.write('$')
+		l.pos++
+		return
+	}
+	l.writeString("{{" + string(l.src[start:end]) + "}}")
+	l.pos = end
+}
+
+func (l *scriptLexer) peek(offset int) rune {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *scriptLexer) indexFrom(from int, r rune) int {
+	for i := from; i < len(l.src); i++ {
+		if l.src[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+func (l *scriptLexer) write(r rune) {
+	l.word.WriteRune(r)
+	l.inWord = true
+}
+
+func (l *scriptLexer) writeString(s string) {
+	l.word.WriteString(s)
+	l.inWord = true
+}
+
+func (l *scriptLexer) endWord() {
+	if l.inWord {
+		l.words = append(l.words, l.word.String())
+		l.word.Reset()
+		l.inWord = false
+	}
+}
+
+func (l *scriptLexer) endStatement() {
+	l.endWord()
+	if len(l.words) > 0 {
+		l.statements = append(l.statements, l.words)
+		l.words = nil
+	}
+}
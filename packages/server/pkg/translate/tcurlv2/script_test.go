@@ -0,0 +1,195 @@
+package tcurlv2
+
+import (
+	"testing"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+
+	"github.com/stretchr/testify/require"
+)
+
+const runbookScript = `#!/usr/bin/env bash
+# Create a user and upload an avatar
+export BASE_URL=https://api.example.com
+API="${BASE_URL}/v1"
+
+curl -sS --compressed -u "$ADMIN_USER:$ADMIN_PASS" \
+  -X POST "$API/users" \
+  -H 'Content-Type: application/json' \
+  --data-binary @user.json
+
+curl -F "avatar=@./avatar.png;type=image/png" -F 'name=$literal' \
+  -H "Authorization: Bearer ${TOKEN}" "$API/users/42/avatar" | jq .
+
+curl -G "$API/search" --data-urlencode "q=hello world" -d limit=5 && echo done
+`
+
+func TestConvertCurlScript(t *testing.T) {
+	result, err := ConvertCurlScript(runbookScript, ConvertCurlScriptOptions{
+		WorkspaceID: idwrap.NewNow(),
+		FlowName:    "Runbook",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Requests, 3)
+
+	// Flow: start node plus one request node per command, chained in order.
+	require.Equal(t, "Runbook", result.Flow.Name)
+	require.Len(t, result.Nodes, 4)
+	require.Equal(t, mflow.NODE_KIND_MANUAL_START, result.Nodes[0].NodeKind)
+	require.Len(t, result.RequestNodes, 3)
+	require.Len(t, result.Edges, 3)
+	for i, edge := range result.Edges {
+		require.Equal(t, result.Nodes[i].ID, edge.SourceID)
+		require.Equal(t, result.Nodes[i+1].ID, edge.TargetID)
+		require.Equal(t, result.Requests[i].HTTP.ID, *result.RequestNodes[i].HttpID)
+	}
+
+	create := result.Requests[0]
+	require.Equal(t, "POST", create.HTTP.Method)
+	require.Equal(t, "{{API}}/users", create.HTTP.Url)
+	require.Equal(t, mhttp.HttpBodyKindRaw, create.HTTP.BodyKind)
+	require.Equal(t, "{{ #file:user.json }}", string(create.BodyRaw.RawData))
+	requireHeader(t, create, "Accept-Encoding", defaultAcceptEncoding)
+	requireHeader(t, create, "Authorization", `Basic {{ base64(ADMIN_USER + ":" + ADMIN_PASS) }}`)
+	requireHeader(t, create, "Content-Type", "application/json")
+
+	upload := result.Requests[1]
+	require.Equal(t, "POST", upload.HTTP.Method)
+	require.Equal(t, "{{API}}/users/42/avatar", upload.HTTP.Url)
+	require.Equal(t, mhttp.HttpBodyKindFormData, upload.HTTP.BodyKind)
+	require.Len(t, upload.BodyForms, 2)
+	require.Equal(t, "#file:./avatar.png", upload.BodyForms[0].Value)
+	require.Equal(t, "$literal", upload.BodyForms[1].Value, "single quotes must not expand variables")
+	requireHeader(t, upload, "Authorization", "Bearer {{TOKEN}}")
+
+	search := result.Requests[2]
+	require.Equal(t, "GET", search.HTTP.Method)
+	require.Equal(t, "{{API}}/search", search.HTTP.Url)
+	require.Equal(t, mhttp.HttpBodyKindNone, search.HTTP.BodyKind)
+	require.Len(t, search.SearchParams, 2)
+	require.Equal(t, "limit", search.SearchParams[0].Key)
+	require.Equal(t, "q", search.SearchParams[1].Key)
+	require.Equal(t, "hello world", search.SearchParams[1].Value)
+
+	require.Equal(t, []CurlVariable{
+		{Key: "ADMIN_PASS"},
+		{Key: "ADMIN_USER"},
+		{Key: "API", Value: "https://api.example.com/v1"},
+		{Key: "BASE_URL", Value: "https://api.example.com"},
+		{Key: "TOKEN"},
+	}, result.Variables)
+}
+
+func TestConvertCurlScript_BrowserCopy(t *testing.T) {
+	script := `curl 'https://api.example.com/graphql' \
+  -H 'accept: */*' \
+  --data-raw $'{"query":"{ me { id } }","note":"it\'s\\n"}' \
+  --compressed`
+
+	result, err := ConvertCurlScript(script, ConvertCurlScriptOptions{WorkspaceID: idwrap.NewNow()})
+	require.NoError(t, err)
+	require.Len(t, result.Requests, 1)
+	require.Equal(t, "POST", result.Requests[0].HTTP.Method)
+	require.Equal(t, `{"query":"{ me { id } }","note":"it's\n"}`, string(result.Requests[0].BodyRaw.RawData))
+	require.Empty(t, result.Variables)
+}
+
+func TestConvertCurlScript_Options(t *testing.T) {
+	tests := []struct {
+		name       string
+		script     string
+		wantMethod string
+		wantURL    string
+		wantHeader [2]string
+		wantVars   []CurlVariable
+	}{
+		{
+			name:       "combined short flags with attached value",
+			script:     `curl -sSLXPUT https://example.com/a`,
+			wantMethod: "PUT",
+			wantURL:    "https://example.com/a",
+		},
+		{
+			name:       "literal basic auth",
+			script:     `curl -u admin:secret example.com/a`,
+			wantMethod: "GET",
+			wantURL:    "http://example.com/a",
+			wantHeader: [2]string{"Authorization", "Basic YWRtaW46c2VjcmV0"},
+		},
+		{
+			name:       "head request",
+			script:     `curl -I https://example.com`,
+			wantMethod: "HEAD",
+			wantURL:    "https://example.com",
+		},
+		{
+			name:       "json shorthand",
+			script:     `curl --json '{"a":1}' https://example.com/a`,
+			wantMethod: "POST",
+			wantURL:    "https://example.com/a",
+			wantHeader: [2]string{"Content-Type", "application/json"},
+		},
+		{
+			name:       "url option and default variable",
+			script:     `curl --url "${HOST:-https://example.com}/a"`,
+			wantMethod: "GET",
+			wantURL:    "{{HOST}}/a",
+			wantVars:   []CurlVariable{{Key: "HOST", Value: "https://example.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConvertCurlScript(tt.script, ConvertCurlScriptOptions{WorkspaceID: idwrap.NewNow()})
+			require.NoError(t, err)
+			require.Len(t, result.Requests, 1)
+			require.Equal(t, tt.wantMethod, result.Requests[0].HTTP.Method)
+			require.Equal(t, tt.wantURL, result.Requests[0].HTTP.Url)
+			if tt.wantVars != nil {
+				require.Equal(t, tt.wantVars, result.Variables)
+			}
+			if tt.wantHeader[0] != "" {
+				requireHeader(t, result.Requests[0], tt.wantHeader[0], tt.wantHeader[1])
+			}
+		})
+	}
+}
+
+func TestConvertCurlScript_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{name: "no curl command", script: "echo hello\nls -la"},
+		{name: "unterminated quote", script: `curl 'https://example.com`},
+		{name: "missing url", script: `curl -X POST`},
+		{name: "missing option value", script: `curl https://example.com -H`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertCurlScript(tt.script, ConvertCurlScriptOptions{WorkspaceID: idwrap.NewNow()})
+			require.Error(t, err)
+		})
+	}
+}
+
+func requireHeader(t *testing.T, resolved *CurlResolvedV2, key, value string) {
+	t.Helper()
+	for _, h := range resolved.Headers {
+		if h.Key == key {
+			require.Equal(t, value, h.Value, "header %s", key)
+			return
+		}
+	}
+	t.Fatalf("header %s not found in %+v", key, resolved.Headers)
+}
+
+func TestConvertCurlScript_RequestNames(t *testing.T) {
+	result, err := ConvertCurlScript("curl $API/users/1\ncurl https://example.com/a/b", ConvertCurlScriptOptions{WorkspaceID: idwrap.NewNow()})
+	require.NoError(t, err)
+	require.Equal(t, "users_1", result.Requests[0].HTTP.Name)
+	require.Equal(t, "a_b", result.Requests[1].HTTP.Name)
+}
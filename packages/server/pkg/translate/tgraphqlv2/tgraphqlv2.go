@@ -0,0 +1,183 @@
+// Package tgraphqlv2 converts GraphQL sources into GraphQL requests: schemas,
+// either introspection results or SDL, yield one request per query and
+// mutation root field, and executable documents one per operation.
+package tgraphqlv2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/schema"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mfile"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mgraphql"
+)
+
+// GraphQLResolved contains the GraphQL requests generated from a schema
+type GraphQLResolved struct {
+	GraphQLRequests []mgraphql.GraphQL
+	Files           []mfile.File
+}
+
+// ConvertOptions defines configuration for introspection conversion
+type ConvertOptions struct {
+	WorkspaceID idwrap.IDWrap
+	FolderID    *idwrap.IDWrap
+
+	// Endpoint is the URL every generated request is sent to.
+	Endpoint string
+	// Depth bounds how many levels of object fields the default selection
+	// set descends into; zero uses schema.DefaultDepth.
+	Depth int
+	// Operations restricts the import to the listed root fields, matched
+	// either by name or by "type name" (e.g. "mutation createUser"). For
+	// executable documents it lists operation names.
+	Operations []string
+	// InlineFragments replaces the fragment spreads of document operations
+	// with inline fragments instead of storing the fragment definitions
+	// alongside each operation.
+	InlineFragments bool
+}
+
+// Convert detects whether data is an introspection result, an executable
+// document or a schema in SDL and converts it accordingly.
+func Convert(data []byte, opts ConvertOptions) (*GraphQLResolved, error) {
+	if schema.IsIntrospection(data) {
+		return ConvertIntrospection(data, opts)
+	}
+	if _, err := schema.ParseQuery(string(data)); err == nil {
+		return ConvertDocument(data, opts)
+	}
+	return ConvertSDL(data, opts)
+}
+
+// ConvertIntrospection converts an introspection result, with or without
+// its "data" envelope, to GraphQL requests.
+func ConvertIntrospection(data []byte, opts ConvertOptions) (*GraphQLResolved, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty introspection data")
+	}
+
+	s, err := schema.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse introspection: %w", err)
+	}
+	return convertSchema(s, opts)
+}
+
+// ConvertSDL converts a schema written in the schema definition language to
+// GraphQL requests.
+func ConvertSDL(data []byte, opts ConvertOptions) (*GraphQLResolved, error) {
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, fmt.Errorf("empty schema")
+	}
+
+	s, err := schema.ParseSDL(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	return convertSchema(s, opts)
+}
+
+// ConvertDocument converts an executable document to one GraphQL request per
+// operation, named after it and carrying the fragments it uses.
+func ConvertDocument(data []byte, opts ConvertOptions) (*GraphQLResolved, error) {
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, fmt.Errorf("empty document")
+	}
+
+	doc, err := schema.ParseQuery(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	filter := make(map[string]bool, len(opts.Operations))
+	for _, op := range opts.Operations {
+		filter[op] = true
+	}
+
+	result := &GraphQLResolved{}
+	now := time.Now()
+	for i, op := range doc.Operations {
+		if len(filter) > 0 && !filter[op.Name] {
+			continue
+		}
+		query, err := doc.PrintOperation(op, opts.InlineFragments)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", operationLabel(op, i), err)
+		}
+		result.add(mgraphql.GraphQL{
+			ID:            idwrap.NewNow(),
+			WorkspaceID:   opts.WorkspaceID,
+			FolderID:      opts.FolderID,
+			Name:          operationLabel(op, i),
+			Url:           opts.Endpoint,
+			Query:         query,
+			OperationName: op.Name,
+		}, opts, now)
+	}
+
+	if len(opts.Operations) > 0 && len(result.GraphQLRequests) == 0 {
+		return nil, fmt.Errorf("no operation matches the requested operations")
+	}
+	return result, nil
+}
+
+// operationLabel names an operation, numbering anonymous ones by position.
+func operationLabel(op *schema.Operation, index int) string {
+	if op.Name != "" {
+		return op.Name
+	}
+	return op.Type + " " + strconv.Itoa(index+1)
+}
+
+// convertSchema builds a request for every generated root field operation.
+func convertSchema(s *schema.Schema, opts ConvertOptions) (*GraphQLResolved, error) {
+	filter := make(map[string]bool, len(opts.Operations))
+	for _, op := range opts.Operations {
+		filter[op] = true
+	}
+
+	result := &GraphQLResolved{}
+	now := time.Now()
+	for _, op := range s.Generate(opts.Depth) {
+		if len(filter) > 0 && !filter[op.Field] && !filter[op.Type+" "+op.Field] {
+			continue
+		}
+
+		result.add(mgraphql.GraphQL{
+			ID:          idwrap.NewNow(),
+			WorkspaceID: opts.WorkspaceID,
+			FolderID:    opts.FolderID,
+			Name:        op.Field,
+			Url:         opts.Endpoint,
+			Query:       op.Query,
+			Variables:   op.Variables,
+			Description: op.Description,
+		}, opts, now)
+	}
+
+	if len(opts.Operations) > 0 && len(result.GraphQLRequests) == 0 {
+		return nil, fmt.Errorf("no root field matches the requested operations")
+	}
+	return result, nil
+}
+
+// add appends a request and the file that places it in the tree.
+func (r *GraphQLResolved) add(gql mgraphql.GraphQL, opts ConvertOptions, now time.Time) {
+	gql.CreatedAt = now.Unix()
+	gql.UpdatedAt = now.Unix()
+	r.GraphQLRequests = append(r.GraphQLRequests, gql)
+	r.Files = append(r.Files, mfile.File{
+		ID:          gql.ID,
+		WorkspaceID: opts.WorkspaceID,
+		ParentID:    opts.FolderID,
+		ContentID:   &gql.ID,
+		ContentType: mfile.ContentTypeGraphQL,
+		Name:        gql.Name,
+		Order:       float64(len(r.Files)),
+		UpdatedAt:   now,
+	})
+}
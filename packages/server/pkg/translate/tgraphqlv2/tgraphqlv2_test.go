@@ -0,0 +1,206 @@
+package tgraphqlv2
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/graphql/schema"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mfile"
+)
+
+func readIntrospection(t *testing.T) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/introspection.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestConvertIntrospection(t *testing.T) {
+	wsID := idwrap.NewNow()
+	folderID := idwrap.NewNow()
+
+	result, err := ConvertIntrospection(readIntrospection(t), ConvertOptions{
+		WorkspaceID: wsID,
+		FolderID:    &folderID,
+		Endpoint:    "https://api.example.com/graphql",
+	})
+	if err != nil {
+		t.Fatalf("ConvertIntrospection: %v", err)
+	}
+
+	if len(result.GraphQLRequests) != 7 || len(result.Files) != 7 {
+		t.Fatalf("got %d requests and %d files, want 7 each", len(result.GraphQLRequests), len(result.Files))
+	}
+
+	names := make([]string, 0, len(result.GraphQLRequests))
+	for i, gql := range result.GraphQLRequests {
+		names = append(names, gql.Name)
+		if gql.WorkspaceID != wsID || gql.FolderID == nil || *gql.FolderID != folderID {
+			t.Errorf("%s: not placed in the target workspace/folder", gql.Name)
+		}
+		if gql.Url != "https://api.example.com/graphql" {
+			t.Errorf("%s: url = %q", gql.Name, gql.Url)
+		}
+		file := result.Files[i]
+		if file.ContentType != mfile.ContentTypeGraphQL || file.ContentID == nil || *file.ContentID != gql.ID {
+			t.Errorf("%s: file does not reference the request: %+v", gql.Name, file)
+		}
+	}
+	if got := strings.Join(names, ","); got != "user,users,search,node,version,createUser,deleteUser" {
+		t.Errorf("request names = %s", got)
+	}
+
+	createUser := result.GraphQLRequests[5]
+	if !strings.HasPrefix(createUser.Query, "mutation createUser($input: CreateUserInput!) {") {
+		t.Errorf("createUser query =\n%s", createUser.Query)
+	}
+	if !strings.Contains(createUser.Variables, `"input"`) {
+		t.Errorf("createUser variables = %q", createUser.Variables)
+	}
+}
+
+func TestConvertIntrospection_Depth(t *testing.T) {
+	shallow, err := ConvertIntrospection(readIntrospection(t), ConvertOptions{Depth: 1, Operations: []string{"user"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deep, err := ConvertIntrospection(readIntrospection(t), ConvertOptions{Depth: 3, Operations: []string{"query user"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shallow.GraphQLRequests) != 1 || len(deep.GraphQLRequests) != 1 {
+		t.Fatalf("operation filter not applied: %d, %d", len(shallow.GraphQLRequests), len(deep.GraphQLRequests))
+	}
+	if strings.Contains(shallow.GraphQLRequests[0].Query, "posts") {
+		t.Errorf("depth 1 selected nested fields:\n%s", shallow.GraphQLRequests[0].Query)
+	}
+	if !strings.Contains(deep.GraphQLRequests[0].Query, "posts {") {
+		t.Errorf("depth 3 did not select nested fields:\n%s", deep.GraphQLRequests[0].Query)
+	}
+}
+
+func TestConvertIntrospection_Errors(t *testing.T) {
+	if _, err := ConvertIntrospection(nil, ConvertOptions{}); err == nil {
+		t.Error("expected an error for empty data")
+	}
+	if _, err := ConvertIntrospection([]byte(`{"openapi":"3.0.0"}`), ConvertOptions{}); err == nil {
+		t.Error("expected an error for a non-introspection document")
+	}
+	if _, err := ConvertIntrospection(readIntrospection(t), ConvertOptions{Operations: []string{"missing"}}); err == nil {
+		t.Error("expected an error when no operation matches")
+	}
+}
+
+const testDocument = `
+query GetUser($id: ID!) { user(id: $id) { ...UserFields } }
+mutation DeleteUser($id: ID!) { deleteUser(id: $id) }
+{ version }
+fragment UserFields on User { id name }
+`
+
+func TestConvertDocument(t *testing.T) {
+	wsID := idwrap.NewNow()
+
+	result, err := ConvertDocument([]byte(testDocument), ConvertOptions{
+		WorkspaceID: wsID,
+		Endpoint:    "https://api.example.com/graphql",
+	})
+	if err != nil {
+		t.Fatalf("ConvertDocument: %v", err)
+	}
+	if len(result.GraphQLRequests) != 3 || len(result.Files) != 3 {
+		t.Fatalf("got %d requests and %d files, want 3 each", len(result.GraphQLRequests), len(result.Files))
+	}
+
+	getUser := result.GraphQLRequests[0]
+	if getUser.Name != "GetUser" || getUser.OperationName != "GetUser" {
+		t.Errorf("GetUser name = %q, operation name = %q", getUser.Name, getUser.OperationName)
+	}
+	if !strings.Contains(getUser.Query, "fragment UserFields on User {") {
+		t.Errorf("GetUser does not store its fragment:\n%s", getUser.Query)
+	}
+	if strings.Contains(result.GraphQLRequests[1].Query, "fragment") {
+		t.Errorf("DeleteUser carries an unused fragment:\n%s", result.GraphQLRequests[1].Query)
+	}
+	anonymous := result.GraphQLRequests[2]
+	if anonymous.Name != "query 3" || anonymous.OperationName != "" {
+		t.Errorf("anonymous operation name = %q, operation name = %q", anonymous.Name, anonymous.OperationName)
+	}
+	for i, gql := range result.GraphQLRequests {
+		if gql.Url != "https://api.example.com/graphql" || gql.WorkspaceID != wsID {
+			t.Errorf("%s: not placed in the target workspace or endpoint", gql.Name)
+		}
+		if file := result.Files[i]; file.ID != gql.ID || file.Order != float64(i) {
+			t.Errorf("%s: file = %+v", gql.Name, file)
+		}
+	}
+
+	inlined, err := ConvertDocument([]byte(testDocument), ConvertOptions{
+		Operations:      []string{"GetUser"},
+		InlineFragments: true,
+	})
+	if err != nil {
+		t.Fatalf("ConvertDocument(inline): %v", err)
+	}
+	if len(inlined.GraphQLRequests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(inlined.GraphQLRequests))
+	}
+	if q := inlined.GraphQLRequests[0].Query; strings.Contains(q, "fragment") || !strings.Contains(q, "... on User {") {
+		t.Errorf("fragments not inlined:\n%s", q)
+	}
+}
+
+func TestConvertSDL(t *testing.T) {
+	s, err := schema.Parse(readIntrospection(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromSDL, err := ConvertSDL([]byte(s.SDL()), ConvertOptions{})
+	if err != nil {
+		t.Fatalf("ConvertSDL: %v", err)
+	}
+	fromIntrospection, err := ConvertIntrospection(readIntrospection(t), ConvertOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fromSDL.GraphQLRequests) != len(fromIntrospection.GraphQLRequests) {
+		t.Fatalf("got %d requests from SDL, want %d", len(fromSDL.GraphQLRequests), len(fromIntrospection.GraphQLRequests))
+	}
+	for i, gql := range fromSDL.GraphQLRequests {
+		want := fromIntrospection.GraphQLRequests[i]
+		if gql.Name != want.Name || gql.Query != want.Query {
+			t.Errorf("request %d = %s\n%s\nwant %s\n%s", i, gql.Name, gql.Query, want.Name, want.Query)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	s, err := schema.Parse(readIntrospection(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, tc := range map[string]struct {
+		data  []byte
+		count int
+	}{
+		"introspection": {readIntrospection(t), 7},
+		"sdl":           {[]byte(s.SDL()), 7},
+		"document":      {[]byte(testDocument), 3},
+	} {
+		result, err := Convert(tc.data, ConvertOptions{})
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if len(result.GraphQLRequests) != tc.count {
+			t.Errorf("%s: got %d requests, want %d", name, len(result.GraphQLRequests), tc.count)
+		}
+	}
+	if _, err := Convert([]byte("not graphql {"), ConvertOptions{}); err == nil {
+		t.Error("expected an error for invalid input")
+	}
+}
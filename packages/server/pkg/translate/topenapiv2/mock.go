@@ -0,0 +1,82 @@
+package topenapiv2
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MockResponse is the documented response of one operation, used to serve a
+// spec from a mock server.
+type MockResponse struct {
+	Method string
+	// Path is the operation path with {param} segments, prefixed by the path of
+	// the spec's base URL.
+	Path        string
+	Status      int
+	ContentType string
+	// Body is JSON generated from the response schema. Values without an
+	// example are {{ faker.* }} expressions, resolved on every request.
+	Body string
+}
+
+// MockResponses returns one response per operation of an OpenAPI/Swagger spec:
+// the first documented 2xx response, or the first documented status when the
+// operation has none.
+func MockResponses(data []byte) ([]MockResponse, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty spec data")
+	}
+
+	s, err := parseSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	basePath := ""
+	if u, err := url.Parse(s.BaseURL); err == nil {
+		basePath = strings.TrimRight(u.Path, "/")
+	}
+
+	var responses []MockResponse
+	for _, pathStr := range sortedKeys(s.Paths) {
+		for _, method := range sortedKeys(s.Paths[pathStr].Operations) {
+			op := s.Paths[pathStr].Operations[method]
+			status, resp := mockStatus(op)
+			mock := MockResponse{
+				Method: method,
+				Path:   basePath + pathStr,
+				Status: status,
+			}
+			if resp.Schema != nil {
+				mock.ContentType = "application/json"
+				mock.Body = renderJSON(fakeValue(resp.Schema, ""), "")
+			}
+			responses = append(responses, mock)
+		}
+	}
+	return responses, nil
+}
+
+// mockStatus picks the response a mock serves for op, falling back to 200
+// when no numeric status is documented.
+func mockStatus(op operation) (int, response) {
+	fallback := 0
+	for _, code := range sortedKeys(op.Responses) {
+		status, err := strconv.Atoi(code)
+		if err != nil {
+			continue
+		}
+		if status >= 200 && status < 300 {
+			return status, op.Responses[code]
+		}
+		if fallback == 0 {
+			fallback = status
+		}
+	}
+	if fallback == 0 {
+		return 200, op.Responses["default"]
+	}
+	return fallback, op.Responses[strconv.Itoa(fallback)]
+}
@@ -0,0 +1,61 @@
+package topenapiv2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMockResponses(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /pets/{petId}:
+    get:
+      responses:
+        "404":
+          description: Not found
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: integer
+                    example: 7
+                  email:
+                    type: string
+                    format: email
+    delete:
+      responses:
+        "404":
+          description: Not found
+`
+	responses, err := MockResponses([]byte(spec))
+	if err != nil {
+		t.Fatalf("MockResponses: %v", err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+
+	del, get := responses[0], responses[1]
+	if del.Method != "DELETE" || del.Status != 404 || del.Body != "" {
+		t.Errorf("unexpected DELETE response: %+v", del)
+	}
+	if get.Method != "GET" || get.Path != "/v1/pets/{petId}" || get.Status != 200 {
+		t.Errorf("unexpected GET response: %+v", get)
+	}
+	if get.ContentType != "application/json" {
+		t.Errorf("expected JSON content type, got %q", get.ContentType)
+	}
+	if !strings.Contains(get.Body, `"id": 7`) || !strings.Contains(get.Body, "{{ faker.email() }}") {
+		t.Errorf("expected example and faker values in body, got %s", get.Body)
+	}
+}
@@ -0,0 +1,497 @@
+package topenapiv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// expectClientError is the assertion every negative test case carries.
+const expectClientError = "response.status >= 400 && response.status < 500"
+
+// testCase is one request the suite generator derives from an operation.
+// The zero value is the plain import of the operation.
+type testCase struct {
+	// Suffix is appended to the node name, empty for the happy path
+	Suffix string
+	// Body replaces the generated request body when HasBody is set
+	Body    string
+	HasBody bool
+	// DropQuery names a required query parameter the case omits
+	DropQuery string
+	// QueryValues fills query parameters the spec gives no example for
+	QueryValues map[string]string
+	Asserts     []string
+}
+
+// generateTestCases builds the happy path followed by the negative and
+// boundary cases of an operation: missing required fields, wrong types,
+// out-of-range enum/min/max/length values and missing required query
+// parameters. Negative cases expect a 4xx response.
+func generateTestCases(op operation) []testCase {
+	happy := testCase{
+		Asserts:     responseAsserts(op),
+		QueryValues: map[string]string{},
+	}
+	for _, p := range op.Parameters {
+		if p.In == "query" && p.Example == "" && p.Schema != nil {
+			happy.QueryValues[p.Name] = fakeParamValue(p.Schema, p.Name)
+		}
+	}
+
+	var bodySchema *schemaObj
+	if op.RequestBody != nil && op.RequestBody.Schema != nil && isJSONContentType(op.RequestBody.ContentType) {
+		bodySchema = op.RequestBody.Schema
+	}
+
+	var base map[string]interface{}
+	if bodySchema != nil {
+		if obj, ok := fakeValue(bodySchema, "").(map[string]interface{}); ok {
+			base = obj
+		}
+		happy.Body = renderJSON(fakeValue(bodySchema, ""), "")
+		happy.HasBody = true
+	}
+
+	cases := []testCase{happy}
+	negative := func(suffix string, body map[string]interface{}) {
+		cases = append(cases, testCase{
+			Suffix:      suffix,
+			Body:        renderJSON(body, ""),
+			HasBody:     true,
+			QueryValues: happy.QueryValues,
+			Asserts:     []string{expectClientError},
+		})
+	}
+
+	if base != nil {
+		required := append([]string(nil), bodySchema.Required...)
+		sort.Strings(required)
+		for _, name := range required {
+			if _, ok := base[name]; !ok {
+				continue
+			}
+			body := copyObject(base)
+			delete(body, name)
+			negative("missing_"+name, body)
+		}
+
+		for _, name := range sortedKeys(bodySchema.Properties) {
+			prop := bodySchema.Properties[name]
+			if wrong, ok := wrongTypeValue(prop.Type); ok {
+				negative(name+"_wrong_type", withField(base, name, wrong))
+			}
+			if len(prop.Enum) > 0 {
+				negative(name+"_invalid_enum", withField(base, name, invalidEnumValue(prop.Enum)))
+			}
+			if prop.Minimum != nil {
+				below := *prop.Minimum
+				if !prop.ExclusiveMinimum {
+					below--
+				}
+				negative(name+"_below_minimum", withField(base, name, below))
+			}
+			if prop.Maximum != nil {
+				above := *prop.Maximum
+				if !prop.ExclusiveMaximum {
+					above++
+				}
+				negative(name+"_above_maximum", withField(base, name, above))
+			}
+			if prop.MinLength != nil && *prop.MinLength > 0 {
+				negative(name+"_too_short", withField(base, name, strings.Repeat("a", *prop.MinLength-1)))
+			}
+			if prop.MaxLength != nil {
+				negative(name+"_too_long", withField(base, name, strings.Repeat("a", *prop.MaxLength+1)))
+			}
+		}
+	}
+
+	for _, p := range op.Parameters {
+		if p.In != "query" || !p.Required {
+			continue
+		}
+		cases = append(cases, testCase{
+			Suffix:      "missing_query_" + p.Name,
+			Body:        happy.Body,
+			HasBody:     happy.HasBody,
+			DropQuery:   p.Name,
+			QueryValues: happy.QueryValues,
+			Asserts:     []string{expectClientError},
+		})
+	}
+
+	return cases
+}
+
+// responseAsserts asserts the first documented 2xx status and the shape of
+// its JSON response schema.
+func responseAsserts(op operation) []string {
+	for _, code := range sortedKeys(op.Responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		statusCode, err := strconv.Atoi(code)
+		if err != nil {
+			break
+		}
+		asserts := []string{fmt.Sprintf("response.status == %d", statusCode)}
+		return append(asserts, schemaAsserts(op.Responses[code].Schema)...)
+	}
+	return nil
+}
+
+// schemaAsserts checks the top level of a response body: its type, the
+// presence of required properties and their types.
+func schemaAsserts(s *schemaObj) []string {
+	if s == nil {
+		return nil
+	}
+	switch s.Type {
+	case "array":
+		return []string{`type(response.body) == "array"`}
+	case "object":
+	default:
+		return nil
+	}
+
+	asserts := []string{`type(response.body) == "map"`}
+	required := append([]string(nil), s.Required...)
+	sort.Strings(required)
+	for _, name := range required {
+		asserts = append(asserts, fmt.Sprintf("%s in response.body", strconv.Quote(name)))
+		prop, ok := s.Properties[name]
+		if !ok {
+			continue
+		}
+		if check := typeCheck(bodyField(name), prop.Type); check != "" {
+			asserts = append(asserts, check)
+		}
+	}
+	return asserts
+}
+
+// typeCheck returns an expression asserting that expr holds a JSON schema type.
+func typeCheck(expr, schemaType string) string {
+	switch schemaType {
+	case "string":
+		return fmt.Sprintf(`type(%s) == "string"`, expr)
+	case "integer", "number":
+		return fmt.Sprintf(`type(%s) in ["int", "float"]`, expr)
+	case "boolean":
+		return fmt.Sprintf(`type(%s) == "bool"`, expr)
+	case "array":
+		return fmt.Sprintf(`type(%s) == "array"`, expr)
+	case "object":
+		return fmt.Sprintf(`type(%s) == "map"`, expr)
+	}
+	return ""
+}
+
+// bodyField addresses a top-level response property, falling back to index
+// syntax for keys that are not identifiers.
+func bodyField(name string) string {
+	if isIdentifier(name) {
+		return "response.body." + name
+	}
+	return fmt.Sprintf("response.body[%s]", strconv.Quote(name))
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// --- Schema-valid data ---
+
+// fakerPlaceholder is a value rendered verbatim into the request body, used
+// for {{ faker.* }} expressions that are resolved at run time.
+type fakerPlaceholder string
+
+// fakeValue produces schema-valid data for s. Examples and enums are used as
+// is; everything else becomes a faker expression or a deterministic literal
+// when the schema constrains it.
+func fakeValue(s *schemaObj, name string) interface{} {
+	if s == nil {
+		return nil
+	}
+	if s.Example != nil {
+		return s.Example
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+
+	switch s.Type {
+	case "object", "":
+		if len(s.Properties) == 0 {
+			if s.Type == "" {
+				return nil
+			}
+			return map[string]interface{}{}
+		}
+		obj := make(map[string]interface{}, len(s.Properties))
+		for key, prop := range s.Properties {
+			obj[key] = fakeValue(prop, key)
+		}
+		return obj
+	case "array":
+		count := 1
+		if s.MinItems != nil && *s.MinItems > count {
+			count = *s.MinItems
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			items[i] = fakeValue(s.Items, name)
+		}
+		return items
+	case "boolean":
+		return true
+	case "integer", "number":
+		lo, hi := intRange(s)
+		return fakerPlaceholder(fmt.Sprintf("{{ faker.randomInt(%d, %d) }}", lo, hi))
+	case "string":
+		if literal, ok := constrainedString(s); ok {
+			return literal
+		}
+		if s.Format == "date-time" {
+			return "2024-01-01T00:00:00Z"
+		}
+		return fakerPlaceholder(strconv.Quote("{{ " + stringFaker(s.Format, name) + " }}"))
+	}
+	return nil
+}
+
+// fakeParamValue is fakeValue for parameters, which are sent as plain text.
+func fakeParamValue(s *schemaObj, name string) string {
+	switch v := fakeValue(s, name).(type) {
+	case nil:
+		return ""
+	case fakerPlaceholder:
+		if unquoted, err := strconv.Unquote(string(v)); err == nil {
+			return unquoted
+		}
+		return string(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// stringFaker picks the faker function for a string from its format, then
+// from common property names.
+func stringFaker(format, name string) string {
+	switch format {
+	case "email":
+		return "faker.email()"
+	case "uuid":
+		return "faker.uuid()"
+	case "uri", "url":
+		return "faker.url()"
+	case "date":
+		return "faker.date()"
+	case "ipv4":
+		return "faker.ipv4()"
+	case "hostname":
+		return "faker.domainName()"
+	case "password":
+		return "faker.password()"
+	}
+
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, "email"):
+		return "faker.email()"
+	case strings.Contains(lower, "firstname") || strings.Contains(lower, "first_name"):
+		return "faker.firstName()"
+	case strings.Contains(lower, "lastname") || strings.Contains(lower, "last_name"):
+		return "faker.lastName()"
+	case strings.Contains(lower, "username"):
+		return "faker.username()"
+	case strings.Contains(lower, "phone"):
+		return "faker.phoneNumber()"
+	case strings.Contains(lower, "url"):
+		return "faker.url()"
+	case lower == "name":
+		return "faker.name()"
+	}
+	return "faker.word()"
+}
+
+// constrainedString returns a literal of valid length for strings with
+// length constraints, where faker output could fall outside the bounds.
+func constrainedString(s *schemaObj) (string, bool) {
+	if s.MinLength == nil && s.MaxLength == nil {
+		return "", false
+	}
+	n := 8
+	if s.MinLength != nil && *s.MinLength > n {
+		n = *s.MinLength
+	}
+	if s.MaxLength != nil && *s.MaxLength < n {
+		n = *s.MaxLength
+	}
+	return strings.Repeat("a", n), true
+}
+
+// intRange returns the inclusive integer bounds faker.randomInt draws from.
+func intRange(s *schemaObj) (int, int) {
+	lo, hi := 1, 1000
+	if s.Minimum != nil {
+		lo = int(math.Ceil(*s.Minimum))
+		if s.ExclusiveMinimum && float64(lo) == *s.Minimum {
+			lo++
+		}
+		hi = lo + 1000
+	}
+	if s.Maximum != nil {
+		hi = int(math.Floor(*s.Maximum))
+		if s.ExclusiveMaximum && float64(hi) == *s.Maximum {
+			hi--
+		}
+		if s.Minimum == nil {
+			lo = hi - 1000
+		}
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
+
+// --- Invalid data ---
+
+// wrongTypeValue returns a value that does not satisfy the schema type.
+func wrongTypeValue(schemaType string) (interface{}, bool) {
+	switch schemaType {
+	case "string":
+		return 12345, true
+	case "integer", "number":
+		return "not-a-number", true
+	case "boolean":
+		return "not-a-boolean", true
+	case "array":
+		return "not-an-array", true
+	case "object":
+		return "not-an-object", true
+	}
+	return nil, false
+}
+
+// invalidEnumValue returns a value outside of enum, of the same kind as its members.
+func invalidEnumValue(enum []interface{}) interface{} {
+	if _, ok := enum[0].(string); ok {
+		return "not-a-valid-enum-value"
+	}
+	highest := math.Inf(-1)
+	for _, v := range enum {
+		if f, ok := v.(float64); ok && f > highest {
+			highest = f
+		}
+		if i, ok := v.(int); ok && float64(i) > highest {
+			highest = float64(i)
+		}
+	}
+	if math.IsInf(highest, -1) {
+		return "not-a-valid-enum-value"
+	}
+	return highest + 1
+}
+
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		out[k] = v
+	}
+	return out
+}
+
+func withField(obj map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	out := copyObject(obj)
+	out[key] = value
+	return out
+}
+
+// --- Rendering ---
+
+// renderJSON renders v as indented JSON with sorted keys, writing
+// fakerPlaceholder values verbatim so numeric expressions stay unquoted.
+func renderJSON(v interface{}, indent string) string {
+	switch val := v.(type) {
+	case fakerPlaceholder:
+		return string(val)
+	case map[string]interface{}:
+		if len(val) == 0 {
+			return "{}"
+		}
+		inner := indent + "  "
+		parts := make([]string, 0, len(val))
+		for _, key := range sortedKeys(val) {
+			parts = append(parts, inner+strconv.Quote(key)+": "+renderJSON(val[key], inner))
+		}
+		return "{\n" + strings.Join(parts, ",\n") + "\n" + indent + "}"
+	case []interface{}:
+		if len(val) == 0 {
+			return "[]"
+		}
+		inner := indent + "  "
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, inner+renderJSON(item, inner))
+		}
+		return "[\n" + strings.Join(parts, ",\n") + "\n" + indent + "]"
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "null"
+		}
+		return string(b)
+	}
+}
+
+// testNodeName derives an identifier-like node name for an operation.
+func testNodeName(method, pathStr string, op operation) string {
+	source := op.OperationID
+	if source == "" {
+		source = strings.ToLower(method) + "_" + pathStr
+	}
+	var b strings.Builder
+	lastUnderscore := true
+	for _, r := range source {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			if r == '_' && lastUnderscore {
+				continue
+			}
+			b.WriteRune(r)
+			lastUnderscore = r == '_'
+			continue
+		}
+		if !lastUnderscore {
+			b.WriteByte('_')
+			lastUnderscore = true
+		}
+	}
+	name := strings.TrimRight(b.String(), "_")
+	if name == "" {
+		name = "request"
+	}
+	return name
+}
+
+func isJSONContentType(contentType string) bool {
+	return contentType == "" || strings.Contains(contentType, "json")
+}
@@ -0,0 +1,281 @@
+package topenapiv2
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/idwrap"
+)
+
+const petSuiteSpec = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: integer
+            minimum: 1
+            maximum: 100
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/NewPet'
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        "400":
+          description: Bad request
+components:
+  schemas:
+    NewPet:
+      type: object
+      required: [name, status]
+      properties:
+        name:
+          type: string
+          minLength: 2
+          maxLength: 20
+        email:
+          type: string
+          format: email
+        age:
+          type: integer
+          minimum: 0
+          maximum: 30
+        status:
+          type: string
+          enum: [available, sold]
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/NewPet'
+        - type: object
+          required: [id]
+          properties:
+            id:
+              type: integer
+            children:
+              type: array
+              items:
+                $ref: '#/components/schemas/Pet'
+`
+
+func TestConvertOpenAPI_GenerateTests(t *testing.T) {
+	resolved, err := ConvertOpenAPI([]byte(petSuiteSpec), ConvertOptions{
+		WorkspaceID:   idwrap.NewNow(),
+		GenerateTests: true,
+	})
+	if err != nil {
+		t.Fatalf("ConvertOpenAPI failed: %v", err)
+	}
+
+	if resolved.Flow.Name != "Pets Tests" {
+		t.Errorf("flow name = %q, want %q", resolved.Flow.Name, "Pets Tests")
+	}
+
+	nodeByName := make(map[string]idwrap.IDWrap)
+	for _, n := range resolved.Nodes {
+		nodeByName[n.Name] = n.ID
+	}
+	httpByNode := make(map[string]idwrap.IDWrap)
+	for _, n := range resolved.Nodes {
+		for _, rn := range resolved.RequestNodes {
+			if rn.FlowNodeID == n.ID {
+				httpByNode[n.Name] = *rn.HttpID
+			}
+		}
+	}
+
+	wantNodes := []string{
+		"createPet",
+		"createPet_missing_name",
+		"createPet_missing_status",
+		"createPet_age_wrong_type",
+		"createPet_age_below_minimum",
+		"createPet_age_above_maximum",
+		"createPet_name_too_short",
+		"createPet_name_too_long",
+		"createPet_status_invalid_enum",
+		"listPets",
+		"listPets_missing_query_limit",
+	}
+	for _, name := range wantNodes {
+		if _, ok := nodeByName[name]; !ok {
+			t.Errorf("missing node %q", name)
+		}
+	}
+
+	assertsOf := func(node string) []string {
+		var values []string
+		for _, a := range resolved.Asserts {
+			if a.HttpID == httpByNode[node] {
+				values = append(values, a.Value)
+			}
+		}
+		return values
+	}
+	bodyOf := func(node string) string {
+		for _, b := range resolved.BodyRaw {
+			if b.HttpID == httpByNode[node] {
+				return string(b.RawData)
+			}
+		}
+		return ""
+	}
+
+	happy := assertsOf("createPet")
+	for _, want := range []string{
+		"response.status == 201",
+		`type(response.body) == "map"`,
+		`"id" in response.body`,
+		`type(response.body.id) in ["int", "float"]`,
+		`"name" in response.body`,
+		`type(response.body.name) == "string"`,
+	} {
+		if !contains(happy, want) {
+			t.Errorf("createPet asserts %v missing %q", happy, want)
+		}
+	}
+	if got := assertsOf("listPets"); !contains(got, `type(response.body) == "array"`) {
+		t.Errorf("listPets asserts %v missing array check", got)
+	}
+
+	body := bodyOf("createPet")
+	for _, want := range []string{`"{{ faker.email() }}"`, `{{ faker.randomInt(0, 30) }}`, `"available"`, `"aaaaaaaa"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("happy body missing %s:\n%s", want, body)
+		}
+	}
+	if !json.Valid([]byte(substituteFaker(body))) {
+		t.Errorf("happy body is not valid JSON once interpolated:\n%s", body)
+	}
+
+	negatives := map[string]string{
+		"createPet_missing_name":        `"name"`,
+		"createPet_age_wrong_type":      `"age": "not-a-number"`,
+		"createPet_age_below_minimum":   `"age": -1`,
+		"createPet_age_above_maximum":   `"age": 31`,
+		"createPet_name_too_short":      `"name": "a"`,
+		"createPet_status_invalid_enum": `"status": "not-a-valid-enum-value"`,
+	}
+	for node, fragment := range negatives {
+		got := bodyOf(node)
+		if node == "createPet_missing_name" {
+			if strings.Contains(got, fragment) {
+				t.Errorf("%s body still contains %s:\n%s", node, fragment, got)
+			}
+		} else if !strings.Contains(got, fragment) {
+			t.Errorf("%s body missing %s:\n%s", node, fragment, got)
+		}
+		if asserts := assertsOf(node); len(asserts) != 1 || asserts[0] != expectClientError {
+			t.Errorf("%s asserts = %v, want [%s]", node, asserts, expectClientError)
+		}
+	}
+
+	for _, p := range resolved.SearchParams {
+		if p.Key != "limit" {
+			continue
+		}
+		switch p.HttpID {
+		case httpByNode["listPets"]:
+			if !p.Enabled || p.Value != "{{ faker.randomInt(1, 100) }}" {
+				t.Errorf("listPets limit = %q (enabled %v)", p.Value, p.Enabled)
+			}
+		case httpByNode["listPets_missing_query_limit"]:
+			if p.Enabled {
+				t.Error("listPets_missing_query_limit should disable limit")
+			}
+		}
+	}
+}
+
+func TestConvertOpenAPI_GenerateTestsDisabled(t *testing.T) {
+	resolved, err := ConvertOpenAPI([]byte(petSuiteSpec), ConvertOptions{WorkspaceID: idwrap.NewNow()})
+	if err != nil {
+		t.Fatalf("ConvertOpenAPI failed: %v", err)
+	}
+	if len(resolved.HTTPRequests) != 2 {
+		t.Errorf("expected 2 requests without test generation, got %d", len(resolved.HTTPRequests))
+	}
+	if resolved.Flow.Name != "Pets" {
+		t.Errorf("flow name = %q, want %q", resolved.Flow.Name, "Pets")
+	}
+}
+
+func TestInlineRefs_Recursive(t *testing.T) {
+	s, err := parseSpec([]byte(petSuiteSpec))
+	if err != nil {
+		t.Fatalf("parseSpec failed: %v", err)
+	}
+	created := s.Paths["/pets"].Operations["POST"].Responses["201"].Schema
+	if created == nil || created.Type != "object" {
+		t.Fatalf("expected the Pet $ref to resolve to an object, got %+v", created)
+	}
+	if _, ok := created.Properties["name"]; !ok {
+		t.Error("expected allOf members to be merged into Pet")
+	}
+	if children := created.Properties["children"]; children == nil || children.Items == nil {
+		t.Error("expected the recursive children reference to be inlined")
+	}
+}
+
+func TestTestNodeName(t *testing.T) {
+	tests := []struct {
+		method, path string
+		op           operation
+		want         string
+	}{
+		{"GET", "/pets", operation{OperationID: "listPets"}, "listPets"},
+		{"GET", "/pets/{petId}", operation{}, "get_pets_petId"},
+		{"POST", "/", operation{}, "post"},
+		{"GET", "/x", operation{OperationID: "pets.list-all"}, "pets_list_all"},
+	}
+	for _, tt := range tests {
+		if got := testNodeName(tt.method, tt.path, tt.op); got != tt.want {
+			t.Errorf("testNodeName(%s %s) = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+var fakerCall = regexp.MustCompile(`\{\{[^}]*\}\}`)
+
+// substituteFaker stands in for run-time interpolation of faker expressions.
+func substituteFaker(body string) string {
+	return fakerCall.ReplaceAllString(body, "1")
+}
+
+func contains(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
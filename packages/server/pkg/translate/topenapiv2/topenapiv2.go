@@ -36,6 +36,18 @@ type OpenAPIResolved struct {
 type ConvertOptions struct {
 	WorkspaceID idwrap.IDWrap
 	FolderID    *idwrap.IDWrap
+
+	// Tags restricts the import to operations carrying at least one of these tags.
+	Tags []string
+	// Operations restricts the import to the listed operations, matched either by
+	// operationId or by "METHOD /path" (e.g. "GET /pets/{petId}").
+	Operations []string
+	// BaseURL replaces the server URL declared by the spec (servers[0] or host+basePath).
+	BaseURL string
+	// GenerateTests turns the import into a test suite: every operation gets a
+	// happy-path request with faker-generated, schema-valid data and response
+	// schema assertions, followed by negative and boundary cases expecting 4xx.
+	GenerateTests bool
 }
 
 // ConvertOpenAPI converts OpenAPI/Swagger spec data (JSON or YAML) to HTTP models.
@@ -71,6 +83,7 @@ type pathItem struct {
 type operation struct {
 	Summary     string
 	OperationID string
+	Tags        []string
 	Parameters  []parameter
 	RequestBody *requestBody
 	Responses   map[string]response
@@ -95,13 +108,27 @@ type requestBody struct {
 // response represents an API response
 type response struct {
 	Description string
+	Schema      *schemaObj
 }
 
 // schemaObj is a minimal schema representation to extract example values
+// and the constraints the test suite generator probes
 type schemaObj struct {
 	Type       string
+	Format     string
 	Example    interface{}
 	Properties map[string]*schemaObj
+	Items      *schemaObj
+	Required   []string
+	Enum       []interface{}
+
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	MinLength        *int
+	MaxLength        *int
+	MinItems         *int
 }
 
 // parseSpec parses raw data (JSON or YAML) into our normalized spec.
@@ -114,6 +141,10 @@ func parseSpec(data []byte) (*spec, error) {
 		}
 	}
 
+	if inlined, ok := inlineRefs(raw, raw, 0).(map[string]interface{}); ok {
+		raw = inlined
+	}
+
 	if v, ok := raw["swagger"]; ok {
 		if s, ok := v.(string); ok && strings.HasPrefix(s, "2") {
 			return parseSwagger2(raw)
@@ -263,6 +294,7 @@ func parseSwagger2Operation(opMap map[string]interface{}, pathParams []parameter
 	if opID, ok := opMap["operationId"].(string); ok {
 		op.OperationID = opID
 	}
+	op.Tags = parseTags(opMap)
 
 	// Start with path-level parameters
 	op.Parameters = append(op.Parameters, pathParams...)
@@ -295,7 +327,11 @@ func parseSwagger2Operation(opMap map[string]interface{}, pathParams []parameter
 		for code, respData := range responses {
 			if respMap, ok := respData.(map[string]interface{}); ok {
 				desc, _ := respMap["description"].(string)
-				op.Responses[code] = response{Description: desc}
+				resp := response{Description: desc}
+				if schemaRaw, ok := respMap["schema"].(map[string]interface{}); ok {
+					resp.Schema = parseSchema(schemaRaw)
+				}
+				op.Responses[code] = resp
 			}
 		}
 	}
@@ -315,6 +351,7 @@ func parseOpenAPI3Operation(opMap map[string]interface{}, pathParams []parameter
 	if opID, ok := opMap["operationId"].(string); ok {
 		op.OperationID = opID
 	}
+	op.Tags = parseTags(opMap)
 
 	// Start with path-level parameters
 	op.Parameters = append(op.Parameters, pathParams...)
@@ -335,7 +372,15 @@ func parseOpenAPI3Operation(opMap map[string]interface{}, pathParams []parameter
 		for code, respData := range responses {
 			if respMap, ok := respData.(map[string]interface{}); ok {
 				desc, _ := respMap["description"].(string)
-				op.Responses[code] = response{Description: desc}
+				resp := response{Description: desc}
+				if content, ok := respMap["content"].(map[string]interface{}); ok {
+					if ctMap, ok := content["application/json"].(map[string]interface{}); ok {
+						if schemaRaw, ok := ctMap["schema"].(map[string]interface{}); ok {
+							resp.Schema = parseSchema(schemaRaw)
+						}
+					}
+				}
+				op.Responses[code] = resp
 			}
 		}
 	}
@@ -343,6 +388,21 @@ func parseOpenAPI3Operation(opMap map[string]interface{}, pathParams []parameter
 	return op
 }
 
+// parseTags extracts the tag names of an operation.
+func parseTags(opMap map[string]interface{}) []string {
+	tagsRaw, ok := opMap["tags"].([]interface{})
+	if !ok {
+		return nil
+	}
+	tags := make([]string, 0, len(tagsRaw))
+	for _, t := range tagsRaw {
+		if tag, ok := t.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // parseParameters parses a list of parameter objects.
 func parseParameters(paramsRaw []interface{}) []parameter {
 	var params []parameter
@@ -367,6 +427,9 @@ func parseParameters(paramsRaw []interface{}) []parameter {
 			if p.Example == "" && p.Schema.Example != nil {
 				p.Example = fmt.Sprintf("%v", p.Schema.Example)
 			}
+		} else if _, ok := pMap["type"].(string); ok {
+			// Swagger 2.0 declares non-body parameter types inline.
+			p.Schema = parseSchema(pMap)
 		}
 
 		params = append(params, p)
@@ -432,14 +495,12 @@ func applyContentType(rb *requestBody, ct string, ctData interface{}) {
 	}
 }
 
-// parseSchema parses a minimal schema object.
-// TODO: Does not resolve $ref references. Real-world specs use $ref extensively
-// (e.g. "$ref": "#/definitions/Pet"), so imported schemas with $ref will have
-// missing data. This is a known V1 limitation — a follow-up should resolve
-// references from the spec's definitions/components.
+// parseSchema parses a minimal schema object. Local $ref references have
+// already been inlined by parseSpec; allOf members are merged into one object.
 func parseSchema(raw map[string]interface{}) *schemaObj {
 	s := &schemaObj{}
 	s.Type, _ = raw["type"].(string)
+	s.Format, _ = raw["format"].(string)
 	s.Example = raw["example"]
 	if props, ok := raw["properties"].(map[string]interface{}); ok {
 		s.Properties = make(map[string]*schemaObj)
@@ -449,9 +510,146 @@ func parseSchema(raw map[string]interface{}) *schemaObj {
 			}
 		}
 	}
+	if items, ok := raw["items"].(map[string]interface{}); ok {
+		s.Items = parseSchema(items)
+	}
+	if required, ok := raw["required"].([]interface{}); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+	if enum, ok := raw["enum"].([]interface{}); ok {
+		s.Enum = enum
+	}
+
+	s.Minimum = floatField(raw, "minimum")
+	s.Maximum = floatField(raw, "maximum")
+	// OpenAPI 3.0 / Swagger use a boolean flag, OpenAPI 3.1 a number.
+	switch v := raw["exclusiveMinimum"].(type) {
+	case bool:
+		s.ExclusiveMinimum = v
+	default:
+		if f := floatField(raw, "exclusiveMinimum"); f != nil {
+			s.Minimum, s.ExclusiveMinimum = f, true
+		}
+	}
+	switch v := raw["exclusiveMaximum"].(type) {
+	case bool:
+		s.ExclusiveMaximum = v
+	default:
+		if f := floatField(raw, "exclusiveMaximum"); f != nil {
+			s.Maximum, s.ExclusiveMaximum = f, true
+		}
+	}
+	s.MinLength = intField(raw, "minLength")
+	s.MaxLength = intField(raw, "maxLength")
+	s.MinItems = intField(raw, "minItems")
+
+	if allOf, ok := raw["allOf"].([]interface{}); ok {
+		for _, member := range allOf {
+			memberMap, ok := member.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			m := parseSchema(memberMap)
+			if s.Type == "" {
+				s.Type = m.Type
+			}
+			for key, prop := range m.Properties {
+				if s.Properties == nil {
+					s.Properties = make(map[string]*schemaObj)
+				}
+				s.Properties[key] = prop
+			}
+			s.Required = append(s.Required, m.Required...)
+		}
+	}
+	if s.Type == "" && len(s.Properties) > 0 {
+		s.Type = "object"
+	}
 	return s
 }
 
+// floatField reads a numeric schema keyword.
+func floatField(raw map[string]interface{}, key string) *float64 {
+	switch v := raw[key].(type) {
+	case float64:
+		return &v
+	case int:
+		f := float64(v)
+		return &f
+	}
+	return nil
+}
+
+// intField reads an integer schema keyword.
+func intField(raw map[string]interface{}, key string) *int {
+	if f := floatField(raw, key); f != nil {
+		i := int(*f)
+		return &i
+	}
+	return nil
+}
+
+// maxRefDepth bounds $ref inlining so recursive schemas (a Node with
+// children of type Node) terminate.
+const maxRefDepth = 8
+
+// inlineRefs replaces local "$ref" objects ("#/definitions/Pet",
+// "#/components/schemas/Pet", ...) with a copy of the referenced value.
+// References that cannot be resolved, or that are nested deeper than
+// maxRefDepth, are left as empty objects.
+func inlineRefs(node interface{}, root map[string]interface{}, depth int) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			if depth >= maxRefDepth {
+				return map[string]interface{}{}
+			}
+			target, ok := lookupRef(root, ref)
+			if !ok {
+				return map[string]interface{}{}
+			}
+			return inlineRefs(target, root, depth+1)
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			out[key] = inlineRefs(val, root, depth)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = inlineRefs(val, root, depth)
+		}
+		return out
+	default:
+		return node
+	}
+}
+
+// lookupRef resolves a local JSON pointer such as "#/components/schemas/Pet".
+func lookupRef(root map[string]interface{}, ref string) (interface{}, bool) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, false
+	}
+	var current interface{} = root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
 // --- Conversion to HTTP Models ---
 
 // convertSpec converts a parsed spec into resolved HTTP models.
@@ -464,6 +662,9 @@ func convertSpec(s *spec, opts ConvertOptions) (*OpenAPIResolved, error) {
 	if flowName == "" {
 		flowName = "Imported OpenAPI Spec"
 	}
+	if opts.GenerateTests {
+		flowName += " Tests"
+	}
 	resolved.Flow = mflow.Flow{
 		ID:          flowID,
 		WorkspaceID: opts.WorkspaceID,
@@ -482,6 +683,12 @@ func convertSpec(s *spec, opts ConvertOptions) (*OpenAPIResolved, error) {
 	})
 
 	previousNodeID := startNodeID
+	usedNames := make(map[string]bool)
+
+	baseURL := s.BaseURL
+	if opts.BaseURL != "" {
+		baseURL = strings.TrimRight(opts.BaseURL, "/")
+	}
 
 	// Sort paths for deterministic output
 	sortedPaths := sortedKeys(s.Paths)
@@ -494,56 +701,85 @@ func convertSpec(s *spec, opts ConvertOptions) (*OpenAPIResolved, error) {
 
 		for _, method := range sortedMethods {
 			op := pi.Operations[method]
-
-			httpReq, headers, searchParams, bodyRaw, assert := convertOperation(
-				method, pathStr, s.BaseURL, op, opts,
-			)
-
-			// Create flow node
-			nodeID := idwrap.NewNow()
-			node := mflow.Node{
-				ID:        nodeID,
-				FlowID:    flowID,
-				Name:      fmt.Sprintf("http_%d", len(resolved.RequestNodes)+1),
-				NodeKind:  mflow.NODE_KIND_REQUEST,
-				PositionX: float64(len(resolved.RequestNodes)+1) * 300,
-				PositionY: 0,
+			if !matchesFilters(method, pathStr, op, opts) {
+				continue
 			}
 
-			reqNode := mflow.NodeRequest{
-				FlowNodeID: nodeID,
-				HttpID:     &httpReq.ID,
+			cases := []testCase{{}}
+			if opts.GenerateTests {
+				cases = generateTestCases(op)
 			}
 
-			// Edge from previous node
-			resolved.Edges = append(resolved.Edges, mflow.Edge{
-				ID:            idwrap.NewNow(),
-				FlowID:        flowID,
-				SourceID:      previousNodeID,
-				TargetID:      nodeID,
-				SourceHandler: mflow.HandleUnspecified,
-			})
-			previousNodeID = nodeID
+			for _, tc := range cases {
+				httpReq, headers, searchParams, bodyRaw, assert := convertOperation(
+					method, pathStr, baseURL, op, opts,
+				)
+				var asserts []mhttp.HTTPAssert
+				if assert != nil {
+					asserts = append(asserts, *assert)
+				}
 
-			// Create file record
-			file := createFileRecord(httpReq, opts)
+				nodeName := fmt.Sprintf("http_%d", len(resolved.RequestNodes)+1)
+				if opts.GenerateTests {
+					nodeName = testNodeName(method, pathStr, op)
+					if tc.Suffix != "" {
+						nodeName += "_" + tc.Suffix
+					}
+					nodeName = uniqueName(nodeName, usedNames)
+					searchParams, bodyRaw, asserts = applyTestCase(tc, &httpReq, searchParams, bodyRaw)
+					if tc.Suffix != "" {
+						httpReq.Name += " (" + strings.ReplaceAll(tc.Suffix, "_", " ") + ")"
+					}
+				}
 
-			// Collect entities
-			resolved.HTTPRequests = append(resolved.HTTPRequests, httpReq)
-			resolved.Headers = append(resolved.Headers, headers...)
-			resolved.SearchParams = append(resolved.SearchParams, searchParams...)
-			if bodyRaw != nil {
-				resolved.BodyRaw = append(resolved.BodyRaw, *bodyRaw)
-			}
-			if assert != nil {
-				resolved.Asserts = append(resolved.Asserts, *assert)
+				// Create flow node
+				nodeID := idwrap.NewNow()
+				node := mflow.Node{
+					ID:        nodeID,
+					FlowID:    flowID,
+					Name:      nodeName,
+					NodeKind:  mflow.NODE_KIND_REQUEST,
+					PositionX: float64(len(resolved.RequestNodes)+1) * 300,
+					PositionY: 0,
+				}
+
+				reqNode := mflow.NodeRequest{
+					FlowNodeID: nodeID,
+					HttpID:     &httpReq.ID,
+				}
+
+				// Edge from previous node
+				resolved.Edges = append(resolved.Edges, mflow.Edge{
+					ID:            idwrap.NewNow(),
+					FlowID:        flowID,
+					SourceID:      previousNodeID,
+					TargetID:      nodeID,
+					SourceHandler: mflow.HandleUnspecified,
+				})
+				previousNodeID = nodeID
+
+				// Create file record
+				file := createFileRecord(httpReq, opts)
+
+				// Collect entities
+				resolved.HTTPRequests = append(resolved.HTTPRequests, httpReq)
+				resolved.Headers = append(resolved.Headers, headers...)
+				resolved.SearchParams = append(resolved.SearchParams, searchParams...)
+				if bodyRaw != nil {
+					resolved.BodyRaw = append(resolved.BodyRaw, *bodyRaw)
+				}
+				resolved.Asserts = append(resolved.Asserts, asserts...)
+				resolved.Files = append(resolved.Files, file)
+				resolved.Nodes = append(resolved.Nodes, node)
+				resolved.RequestNodes = append(resolved.RequestNodes, reqNode)
 			}
-			resolved.Files = append(resolved.Files, file)
-			resolved.Nodes = append(resolved.Nodes, node)
-			resolved.RequestNodes = append(resolved.RequestNodes, reqNode)
 		}
 	}
 
+	if len(resolved.HTTPRequests) == 0 && (len(opts.Tags) > 0 || len(opts.Operations) > 0) {
+		return nil, fmt.Errorf("no operations match the given tag/operation filters")
+	}
+
 	// Create folder structure from URLs
 	folderFiles := buildFolderStructure(resolved.HTTPRequests, resolved.Files, opts)
 	resolved.Files = append(resolved.Files, folderFiles...)
@@ -709,8 +945,102 @@ func convertOperation(
 	return httpReq, headers, searchParams, bodyRaw, assert
 }
 
+// applyTestCase rewrites the converted request of an operation into one
+// generated test case: its body, query parameters and assertions.
+func applyTestCase(
+	tc testCase,
+	httpReq *mhttp.HTTP,
+	searchParams []mhttp.HTTPSearchParam,
+	bodyRaw *mhttp.HTTPBodyRaw,
+) ([]mhttp.HTTPSearchParam, *mhttp.HTTPBodyRaw, []mhttp.HTTPAssert) {
+	now := time.Now().UnixMilli()
+
+	for i := range searchParams {
+		if value, ok := tc.QueryValues[searchParams[i].Key]; ok && searchParams[i].Value == "" {
+			searchParams[i].Value = value
+		}
+		if searchParams[i].Key == tc.DropQuery {
+			searchParams[i].Enabled = false
+		}
+	}
+
+	if tc.HasBody {
+		httpReq.BodyKind = mhttp.HttpBodyKindRaw
+		if bodyRaw == nil {
+			bodyRaw = &mhttp.HTTPBodyRaw{
+				ID:        idwrap.NewNow(),
+				HttpID:    httpReq.ID,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+		}
+		bodyRaw.RawData = []byte(tc.Body)
+	}
+
+	asserts := make([]mhttp.HTTPAssert, 0, len(tc.Asserts))
+	for i, value := range tc.Asserts {
+		asserts = append(asserts, mhttp.HTTPAssert{
+			ID:           idwrap.NewNow(),
+			HttpID:       httpReq.ID,
+			Value:        value,
+			Enabled:      true,
+			Description:  "Generated from OpenAPI spec",
+			DisplayOrder: float32(i),
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+	}
+
+	return searchParams, bodyRaw, asserts
+}
+
 // --- Helper Functions ---
 
+// matchesFilters reports whether an operation passes the tag and operation
+// filters of opts. Empty filters match everything; when both are set an
+// operation has to satisfy both.
+func matchesFilters(method, pathStr string, op operation, opts ConvertOptions) bool {
+	if len(opts.Tags) > 0 {
+		tagged := false
+		for _, want := range opts.Tags {
+			for _, tag := range op.Tags {
+				if strings.EqualFold(tag, want) {
+					tagged = true
+				}
+			}
+		}
+		if !tagged {
+			return false
+		}
+	}
+
+	if len(opts.Operations) > 0 {
+		key := method + " " + pathStr
+		for _, want := range opts.Operations {
+			want = strings.TrimSpace(want)
+			if want == op.OperationID && op.OperationID != "" {
+				return true
+			}
+			if parts := strings.Fields(want); len(parts) == 2 && strings.ToUpper(parts[0])+" "+parts[1] == key {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// uniqueName returns name, suffixed with a counter when already taken.
+func uniqueName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
 // generateExampleJSON generates a minimal example JSON from a schema.
 func generateExampleJSON(s *schemaObj) string {
 	if s == nil {
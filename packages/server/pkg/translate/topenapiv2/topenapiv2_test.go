@@ -466,3 +466,67 @@ func TestConvertOpenAPI_PathLevelParameters(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertOpenAPI_Filters(t *testing.T) {
+	spec := []byte(`{
+		"openapi": "3.0.0",
+		"info": {"title": "Filtered"},
+		"servers": [{"url": "https://api.test.com/v1/"}],
+		"paths": {
+			"/pets": {
+				"get": {"operationId": "listPets", "tags": ["pets"], "responses": {"200": {"description": "OK"}}},
+				"post": {"operationId": "createPet", "tags": ["pets", "admin"], "responses": {"201": {"description": "OK"}}}
+			},
+			"/users": {
+				"get": {"operationId": "listUsers", "tags": ["users"], "responses": {"200": {"description": "OK"}}}
+			}
+		}
+	}`)
+
+	tests := []struct {
+		name    string
+		opts    ConvertOptions
+		want    []string
+		wantErr bool
+	}{
+		{"no filters", ConvertOptions{}, []string{"GET https://api.test.com/v1/pets", "POST https://api.test.com/v1/pets", "GET https://api.test.com/v1/users"}, false},
+		{"tag", ConvertOptions{Tags: []string{"Pets"}}, []string{"GET https://api.test.com/v1/pets", "POST https://api.test.com/v1/pets"}, false},
+		{"operation id", ConvertOptions{Operations: []string{"listUsers"}}, []string{"GET https://api.test.com/v1/users"}, false},
+		{"method and path", ConvertOptions{Operations: []string{"post /pets"}}, []string{"POST https://api.test.com/v1/pets"}, false},
+		{"tag and operation", ConvertOptions{Tags: []string{"admin"}, Operations: []string{"listPets", "createPet"}}, []string{"POST https://api.test.com/v1/pets"}, false},
+		{"base url override", ConvertOptions{Operations: []string{"listUsers"}, BaseURL: "http://localhost:8080/"}, []string{"GET http://localhost:8080/users"}, false},
+		{"nothing matches", ConvertOptions{Tags: []string{"orders"}}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.WorkspaceID = idwrap.NewNow()
+			resolved, err := ConvertOpenAPI(spec, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConvertOpenAPI() error = %v", err)
+			}
+
+			var got []string
+			for _, req := range resolved.HTTPRequests {
+				got = append(got, req.Method+" "+req.Url)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("request %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+			if len(resolved.RequestNodes) != len(tt.want) {
+				t.Errorf("expected %d request nodes, got %d", len(tt.want), len(resolved.RequestNodes))
+			}
+		})
+	}
+}
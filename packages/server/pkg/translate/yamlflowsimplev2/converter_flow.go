@@ -78,8 +78,14 @@ func processFlow(flowEntry YamlFlowFlowV2, runEntries []YamlRunEntryV2, template
 		createStartNodeWithID(startNodeID, flowID, result)
 	}
 
+	var teardownNodeID idwrap.IDWrap
+	if len(flowEntry.Teardown) > 0 {
+		teardownNodeID = idwrap.NewNow()
+		createTeardownNode(teardownNodeID, flowID, result)
+	}
+
 	// Create edges
-	if err := createEdges(flowID, startNodeID, processRes.NodeInfoMap, processRes.NodeList, flowEntry.Steps, processRes.StartNodeFound, result); err != nil {
+	if err := createEdges(flowID, startNodeID, teardownNodeID, processRes.NodeInfoMap, processRes.NodeList, flowEntry, processRes.StartNodeFound, result); err != nil {
 		return nil, fmt.Errorf("failed to create edges: %w", err)
 	}
 
@@ -108,6 +114,73 @@ func processFlowVariables(flowEntry YamlFlowFlowV2, flowID idwrap.IDWrap, result
 	return varsystem.NewVarMap(nil), nil
 }
 
+// flowSection names the part of a flow a step is listed in.
+type flowSection string
+
+const (
+	sectionSetup    flowSection = "setup"
+	sectionSteps    flowSection = "steps"
+	sectionTeardown flowSection = "teardown"
+)
+
+// allSteps lists the setup, main and teardown steps of a flow in that order;
+// step indexes used while converting refer to this list.
+func (f YamlFlowFlowV2) allSteps() []YamlStepWrapper {
+	steps := make([]YamlStepWrapper, 0, len(f.Setup)+len(f.Steps)+len(f.Teardown))
+	steps = append(steps, f.Setup...)
+	steps = append(steps, f.Steps...)
+	return append(steps, f.Teardown...)
+}
+
+// section reports which section the step at index i of allSteps is in.
+func (f YamlFlowFlowV2) section(i int) flowSection {
+	switch {
+	case i < len(f.Setup):
+		return sectionSetup
+	case i < len(f.Setup)+len(f.Steps):
+		return sectionSteps
+	default:
+		return sectionTeardown
+	}
+}
+
+// sectionDependencyAllowed reports whether a step in section may depend on a
+// step in source. Steps may depend on the start node and on earlier sections;
+// the teardown section only runs after the flow, so it stands on its own.
+func sectionDependencyAllowed(section, source flowSection, sourceIsStart bool) bool {
+	switch section {
+	case sectionSetup:
+		return source == sectionSetup || sourceIsStart
+	case sectionSteps:
+		return source != sectionTeardown
+	default:
+		return source == sectionTeardown
+	}
+}
+
+// setupExits returns the setup steps no other setup step depends on. Steps
+// without depends_on wait for all of them, so they run once setup is done.
+func setupExits(flowEntry YamlFlowFlowV2, nodeList []*nodeInfo) []idwrap.IDWrap {
+	hasDependents := make(map[string]bool)
+	for _, node := range nodeList {
+		if flowEntry.section(node.index) != sectionSetup {
+			continue
+		}
+		for _, depName := range node.dependsOn {
+			sourceName, _, _ := strings.Cut(depName, ".")
+			hasDependents[sourceName] = true
+		}
+	}
+
+	var exits []idwrap.IDWrap
+	for _, node := range nodeList {
+		if flowEntry.section(node.index) == sectionSetup && !hasDependents[node.name] {
+			exits = append(exits, node.id)
+		}
+	}
+	return exits
+}
+
 // nodeInfo tracks information about a flow node
 type nodeInfo struct {
 	id         idwrap.IDWrap
@@ -134,8 +207,26 @@ type HTTPAssociatedData struct {
 	RequestNode    *mflow.NodeRequest
 }
 
-func createEdges(flowID, startNodeID idwrap.IDWrap, nodeInfoMap map[string]*nodeInfo, nodeList []*nodeInfo, steps []YamlStepWrapper, startNodeFound bool, result *ioworkspace.WorkspaceBundle) error {
+// switchHandle resolves the part of a "<switch>.<label>" dependency after the
+// dot to the edge handle of that case, or of the default branch.
+func switchHandle(step *YamlStepSwitch, label string) (mflow.EdgeHandle, bool) {
+	if label == strings.TrimPrefix(DependsSuffixDefault, ".") {
+		return mflow.HandleDefault, true
+	}
+	for i, c := range step.Cases {
+		if (mflow.SwitchCase{Name: c.Name}).Label(i) == label {
+			return mflow.HandleCase(i), true
+		}
+	}
+	return mflow.HandleUnspecified, false
+}
+
+func createEdges(flowID, startNodeID, teardownNodeID idwrap.IDWrap, nodeInfoMap map[string]*nodeInfo, nodeList []*nodeInfo, flowEntry YamlFlowFlowV2, startNodeFound bool, result *ioworkspace.WorkspaceBundle) error {
+	steps := flowEntry.allSteps()
+	setupExitIDs := setupExits(flowEntry, nodeList)
+
 	for _, node := range nodeList {
+		section := flowEntry.section(node.index)
 		for _, depName := range node.dependsOn {
 			sourceName := depName
 			handler := mflow.HandleUnspecified
@@ -150,8 +241,12 @@ func createEdges(flowID, startNodeID idwrap.IDWrap, nodeInfoMap map[string]*node
 						handler = mflow.HandleThen
 					case "else":
 						handler = mflow.HandleElse
-					case "loop":
+					case "loop", "try", "branch":
 						handler = mflow.HandleLoop
+					case "on_error", "catch":
+						handler = mflow.HandleError
+					case "ws_message", "message", "next":
+						handler = mflow.HandleWsMessage
 					}
 				}
 			}
@@ -160,6 +255,15 @@ func createEdges(flowID, startNodeID idwrap.IDWrap, nodeInfoMap map[string]*node
 			if !ok {
 				return NewYamlFlowErrorV2(fmt.Sprintf("step '%s' depends on unknown step '%s'", node.name, sourceName), "depends_on", sourceName)
 			}
+			if sourceSection := flowEntry.section(targetInfo.index); !sectionDependencyAllowed(section, sourceSection, targetInfo.id == startNodeID) {
+				return NewYamlFlowErrorV2(fmt.Sprintf("step '%s' in %s cannot depend on step '%s' in %s", node.name, section, sourceName, sourceSection), "depends_on", sourceName)
+			}
+			if source := steps[targetInfo.index].Switch; source != nil && sourceName != depName {
+				handler, ok = switchHandle(source, strings.TrimPrefix(depName, sourceName+"."))
+				if !ok {
+					return NewYamlFlowErrorV2(fmt.Sprintf("step '%s' depends on unknown case '%s'", node.name, depName), "depends_on", depName)
+				}
+			}
 			result.FlowEdges = append(result.FlowEdges, createEdge(targetInfo.id, node.id, flowID, handler))
 		}
 
@@ -202,6 +306,63 @@ func createEdges(flowID, startNodeID idwrap.IDWrap, nodeInfoMap map[string]*node
 			}
 		}
 
+		if step.Try != nil {
+			if step.Try.Body != "" {
+				target, ok := nodeInfoMap[step.Try.Body]
+				if !ok {
+					return NewYamlFlowErrorV2("try 'body' target not found", "body", step.Try.Body)
+				}
+				result.FlowEdges = append(result.FlowEdges, createEdge(node.id, target.id, flowID, mflow.HandleLoop))
+			}
+			if step.Try.Catch != "" {
+				target, ok := nodeInfoMap[step.Try.Catch]
+				if !ok {
+					return NewYamlFlowErrorV2("try 'catch' target not found", "catch", step.Try.Catch)
+				}
+				result.FlowEdges = append(result.FlowEdges, createEdge(node.id, target.id, flowID, mflow.HandleError))
+			}
+		}
+
+		if step.Switch != nil {
+			for i, c := range step.Switch.Cases {
+				if c.Then == "" {
+					continue
+				}
+				target, ok := nodeInfoMap[c.Then]
+				if !ok {
+					return NewYamlFlowErrorV2("switch case 'then' target not found", "then", c.Then)
+				}
+				result.FlowEdges = append(result.FlowEdges, createEdge(node.id, target.id, flowID, mflow.HandleCase(i)))
+			}
+			if step.Switch.Default != "" {
+				target, ok := nodeInfoMap[step.Switch.Default]
+				if !ok {
+					return NewYamlFlowErrorV2("switch 'default' target not found", "default", step.Switch.Default)
+				}
+				result.FlowEdges = append(result.FlowEdges, createEdge(node.id, target.id, flowID, mflow.HandleDefault))
+			}
+		}
+
+		if step.Poll != nil {
+			if step.Poll.Loop != "" {
+				target, ok := nodeInfoMap[step.Poll.Loop]
+				if !ok {
+					return NewYamlFlowErrorV2("poll 'loop' target not found", "loop", step.Poll.Loop)
+				}
+				result.FlowEdges = append(result.FlowEdges, createEdge(node.id, target.id, flowID, mflow.HandleLoop))
+			}
+		}
+
+		if step.Parallel != nil {
+			for _, branch := range step.Parallel.Branches {
+				target, ok := nodeInfoMap[branch]
+				if !ok {
+					return NewYamlFlowErrorV2("parallel 'branches' target not found", "branches", branch)
+				}
+				result.FlowEdges = append(result.FlowEdges, createEdge(node.id, target.id, flowID, mflow.HandleLoop))
+			}
+		}
+
 		// AI node edges: provider, memory, and tools
 		if node.aiProvider != "" {
 			target, ok := nodeInfoMap[node.aiProvider]
@@ -225,12 +386,23 @@ func createEdges(flowID, startNodeID idwrap.IDWrap, nodeInfoMap map[string]*node
 			result.FlowEdges = append(result.FlowEdges, createEdge(node.id, target.id, flowID, mflow.HandleAiTools))
 		}
 
-		// Only auto-connect nodes to start if there's no explicit start node in the YAML
-		// When an explicit start node exists, disconnected nodes should remain disconnected (won't run)
-		if len(node.dependsOn) == 0 {
-			if node.id != startNodeID && !startNodeFound {
-				result.FlowEdges = append(result.FlowEdges, createEdge(startNodeID, node.id, flowID, mflow.HandleUnspecified))
+		if len(node.dependsOn) > 0 || node.id == startNodeID {
+			continue
+		}
+		switch {
+		case section == sectionSetup:
+			result.FlowEdges = append(result.FlowEdges, createEdge(startNodeID, node.id, flowID, mflow.HandleUnspecified))
+		case section == sectionTeardown:
+			result.FlowEdges = append(result.FlowEdges, createEdge(teardownNodeID, node.id, flowID, mflow.HandleUnspecified))
+		case startNodeFound:
+			// Only auto-connect nodes to start if there's no explicit start node in the YAML
+			// When an explicit start node exists, disconnected nodes should remain disconnected (won't run)
+		case len(setupExitIDs) > 0:
+			for _, exitID := range setupExitIDs {
+				result.FlowEdges = append(result.FlowEdges, createEdge(exitID, node.id, flowID, mflow.HandleUnspecified))
 			}
+		default:
+			result.FlowEdges = append(result.FlowEdges, createEdge(startNodeID, node.id, flowID, mflow.HandleUnspecified))
 		}
 	}
 	return nil
@@ -278,10 +450,23 @@ func mergeFlowData(result *ioworkspace.WorkspaceBundle, flowData *ioworkspace.Wo
 	result.FlowGraphQLNodes = append(result.FlowGraphQLNodes, flowData.FlowGraphQLNodes...)
 	result.FlowWsConnectionNodes = append(result.FlowWsConnectionNodes, flowData.FlowWsConnectionNodes...)
 	result.FlowWsSendNodes = append(result.FlowWsSendNodes, flowData.FlowWsSendNodes...)
+	result.FlowWsExpectNodes = append(result.FlowWsExpectNodes, flowData.FlowWsExpectNodes...)
+	result.FlowSocketConnectionNodes = append(result.FlowSocketConnectionNodes, flowData.FlowSocketConnectionNodes...)
+	result.FlowSocketSendNodes = append(result.FlowSocketSendNodes, flowData.FlowSocketSendNodes...)
+	result.FlowSocketReceiveNodes = append(result.FlowSocketReceiveNodes, flowData.FlowSocketReceiveNodes...)
+	result.FlowDbQueryNodes = append(result.FlowDbQueryNodes, flowData.FlowDbQueryNodes...)
+	result.FlowMqPublishNodes = append(result.FlowMqPublishNodes, flowData.FlowMqPublishNodes...)
+	result.FlowMqConsumeNodes = append(result.FlowMqConsumeNodes, flowData.FlowMqConsumeNodes...)
+	result.FlowExecNodes = append(result.FlowExecNodes, flowData.FlowExecNodes...)
 	result.FlowWaitNodes = append(result.FlowWaitNodes, flowData.FlowWaitNodes...)
 	result.FlowSubFlowTriggerNodes = append(result.FlowSubFlowTriggerNodes, flowData.FlowSubFlowTriggerNodes...)
 	result.FlowSubFlowReturnNodes = append(result.FlowSubFlowReturnNodes, flowData.FlowSubFlowReturnNodes...)
 	result.FlowRunSubFlowNodes = append(result.FlowRunSubFlowNodes, flowData.FlowRunSubFlowNodes...)
+	result.FlowParallelNodes = append(result.FlowParallelNodes, flowData.FlowParallelNodes...)
+	result.FlowPollNodes = append(result.FlowPollNodes, flowData.FlowPollNodes...)
+	result.FlowSwitchNodes = append(result.FlowSwitchNodes, flowData.FlowSwitchNodes...)
+	result.FlowWebhookTriggerNodes = append(result.FlowWebhookTriggerNodes, flowData.FlowWebhookTriggerNodes...)
+	result.FlowGraphQLSubscriptionNodes = append(result.FlowGraphQLSubscriptionNodes, flowData.FlowGraphQLSubscriptionNodes...)
 	result.WebSockets = append(result.WebSockets, flowData.WebSockets...)
 	result.WebSocketHeaders = append(result.WebSocketHeaders, flowData.WebSocketHeaders...)
 }
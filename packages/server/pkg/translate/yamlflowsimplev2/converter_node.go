@@ -43,6 +43,22 @@ func getStepCommon(sw YamlStepWrapper) *YamlStepCommon {
 		return &sw.WsConnection.YamlStepCommon
 	case sw.WsSend != nil:
 		return &sw.WsSend.YamlStepCommon
+	case sw.WsExpect != nil:
+		return &sw.WsExpect.YamlStepCommon
+	case sw.SocketConnection != nil:
+		return &sw.SocketConnection.YamlStepCommon
+	case sw.SocketSend != nil:
+		return &sw.SocketSend.YamlStepCommon
+	case sw.SocketReceive != nil:
+		return &sw.SocketReceive.YamlStepCommon
+	case sw.DbQuery != nil:
+		return &sw.DbQuery.YamlStepCommon
+	case sw.MqPublish != nil:
+		return &sw.MqPublish.YamlStepCommon
+	case sw.MqConsume != nil:
+		return &sw.MqConsume.YamlStepCommon
+	case sw.Exec != nil:
+		return &sw.Exec.YamlStepCommon
 	case sw.Wait != nil:
 		return &sw.Wait.YamlStepCommon
 	case sw.ManualStart != nil:
@@ -53,6 +69,18 @@ func getStepCommon(sw YamlStepWrapper) *YamlStepCommon {
 		return &sw.SubFlowReturn.YamlStepCommon
 	case sw.RunSubFlow != nil:
 		return &sw.RunSubFlow.YamlStepCommon
+	case sw.Try != nil:
+		return &sw.Try.YamlStepCommon
+	case sw.Parallel != nil:
+		return &sw.Parallel.YamlStepCommon
+	case sw.Poll != nil:
+		return &sw.Poll.YamlStepCommon
+	case sw.Switch != nil:
+		return &sw.Switch.YamlStepCommon
+	case sw.Webhook != nil:
+		return &sw.Webhook.YamlStepCommon
+	case sw.GraphQLSubscription != nil:
+		return &sw.GraphQLSubscription.YamlStepCommon
 	default:
 		return nil
 	}
@@ -69,12 +97,22 @@ func createStartNodeWithID(nodeID, flowID idwrap.IDWrap, result *ioworkspace.Wor
 	result.FlowNodes = append(result.FlowNodes, startNode)
 }
 
+// createTeardownNode creates the entry node of the flow's teardown section
+func createTeardownNode(nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) {
+	result.FlowNodes = append(result.FlowNodes, mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     TeardownNodeName,
+		NodeKind: mflow.NODE_KIND_TEARDOWN,
+	})
+}
+
 // processSteps processes all steps in a flow
 func processSteps(flowEntry YamlFlowFlowV2, templates map[string]YamlRequestDefV2, graphqlTemplates map[string]YamlGraphQLDefV2, varMap varsystem.VarMap, flowID, startNodeID idwrap.IDWrap, opts ConvertOptionsV2, result *ioworkspace.WorkspaceBundle) (*StepProcessingResult, error) {
 	nodeInfoMap := make(map[string]*nodeInfo)
 	nodeList := make([]*nodeInfo, 0)
 	startNodeFound := false
-	steps := flowEntry.Steps
+	steps := flowEntry.allSteps()
 
 	for i, stepWrapper := range steps {
 		var nodeName string
@@ -116,6 +154,30 @@ func processSteps(flowEntry YamlFlowFlowV2, templates map[string]YamlRequestDefV
 		case stepWrapper.WsSend != nil:
 			nodeName = stepWrapper.WsSend.Name
 			dependsOn = stepWrapper.WsSend.DependsOn
+		case stepWrapper.WsExpect != nil:
+			nodeName = stepWrapper.WsExpect.Name
+			dependsOn = stepWrapper.WsExpect.DependsOn
+		case stepWrapper.SocketConnection != nil:
+			nodeName = stepWrapper.SocketConnection.Name
+			dependsOn = stepWrapper.SocketConnection.DependsOn
+		case stepWrapper.SocketSend != nil:
+			nodeName = stepWrapper.SocketSend.Name
+			dependsOn = stepWrapper.SocketSend.DependsOn
+		case stepWrapper.SocketReceive != nil:
+			nodeName = stepWrapper.SocketReceive.Name
+			dependsOn = stepWrapper.SocketReceive.DependsOn
+		case stepWrapper.DbQuery != nil:
+			nodeName = stepWrapper.DbQuery.Name
+			dependsOn = stepWrapper.DbQuery.DependsOn
+		case stepWrapper.MqPublish != nil:
+			nodeName = stepWrapper.MqPublish.Name
+			dependsOn = stepWrapper.MqPublish.DependsOn
+		case stepWrapper.MqConsume != nil:
+			nodeName = stepWrapper.MqConsume.Name
+			dependsOn = stepWrapper.MqConsume.DependsOn
+		case stepWrapper.Exec != nil:
+			nodeName = stepWrapper.Exec.Name
+			dependsOn = stepWrapper.Exec.DependsOn
 		case stepWrapper.Wait != nil:
 			nodeName = stepWrapper.Wait.Name
 			dependsOn = stepWrapper.Wait.DependsOn
@@ -131,6 +193,24 @@ func processSteps(flowEntry YamlFlowFlowV2, templates map[string]YamlRequestDefV
 		case stepWrapper.RunSubFlow != nil:
 			nodeName = stepWrapper.RunSubFlow.Name
 			dependsOn = stepWrapper.RunSubFlow.DependsOn
+		case stepWrapper.Try != nil:
+			nodeName = stepWrapper.Try.Name
+			dependsOn = stepWrapper.Try.DependsOn
+		case stepWrapper.Parallel != nil:
+			nodeName = stepWrapper.Parallel.Name
+			dependsOn = stepWrapper.Parallel.DependsOn
+		case stepWrapper.Poll != nil:
+			nodeName = stepWrapper.Poll.Name
+			dependsOn = stepWrapper.Poll.DependsOn
+		case stepWrapper.Switch != nil:
+			nodeName = stepWrapper.Switch.Name
+			dependsOn = stepWrapper.Switch.DependsOn
+		case stepWrapper.Webhook != nil:
+			nodeName = stepWrapper.Webhook.Name
+			dependsOn = stepWrapper.Webhook.DependsOn
+		case stepWrapper.GraphQLSubscription != nil:
+			nodeName = stepWrapper.GraphQLSubscription.Name
+			dependsOn = stepWrapper.GraphQLSubscription.DependsOn
 		default:
 			return nil, NewYamlFlowErrorV2("empty step definition", "step", i)
 		}
@@ -138,6 +218,9 @@ func processSteps(flowEntry YamlFlowFlowV2, templates map[string]YamlRequestDefV
 		if nodeName == "" {
 			return nil, NewYamlFlowErrorV2("missing step name", "step", i)
 		}
+		if section := flowEntry.section(i); section != sectionSteps && (stepWrapper.ManualStart != nil || stepWrapper.SubFlowTrigger != nil || stepWrapper.Webhook != nil || stepWrapper.GraphQLSubscription != nil) {
+			return nil, NewYamlFlowErrorV2(fmt.Sprintf("%s cannot contain entry step '%s'", section, nodeName), string(section), nodeName)
+		}
 
 		info = &nodeInfo{
 			id:        nodeID,
@@ -221,6 +304,59 @@ func processSteps(flowEntry YamlFlowFlowV2, templates map[string]YamlRequestDefV
 			if err := processWsSendStructStep(stepWrapper.WsSend, nodeID, flowID, result); err != nil {
 				return nil, err
 			}
+		case stepWrapper.WsExpect != nil:
+			if err := processWsExpectStructStep(stepWrapper.WsExpect, nodeID, flowID, result); err != nil {
+				return nil, err
+			}
+		case stepWrapper.SocketConnection != nil:
+			if err := processSocketConnectionStructStep(stepWrapper.SocketConnection, nodeID, flowID, result); err != nil {
+				return nil, err
+			}
+		case stepWrapper.SocketSend != nil:
+			if err := processSocketSendStructStep(stepWrapper.SocketSend, nodeID, flowID, result); err != nil {
+				return nil, err
+			}
+		case stepWrapper.SocketReceive != nil:
+			if err := processSocketReceiveStructStep(stepWrapper.SocketReceive, nodeID, flowID, result); err != nil {
+				return nil, err
+			}
+		case stepWrapper.DbQuery != nil:
+			if stepWrapper.DbQuery.Credential == "" {
+				return nil, NewYamlFlowErrorV2("missing required credential", "db_query", i)
+			}
+			if strings.TrimSpace(stepWrapper.DbQuery.Query) == "" {
+				return nil, NewYamlFlowErrorV2("missing required query", "db_query", i)
+			}
+			if err := processDbQueryStructStep(stepWrapper.DbQuery, nodeID, flowID, opts, result); err != nil {
+				return nil, err
+			}
+		case stepWrapper.MqPublish != nil:
+			if stepWrapper.MqPublish.Credential == "" {
+				return nil, NewYamlFlowErrorV2("missing required credential", "mq_publish", i)
+			}
+			if stepWrapper.MqPublish.Topic == "" {
+				return nil, NewYamlFlowErrorV2("missing required topic", "mq_publish", i)
+			}
+			if err := processMqPublishStructStep(stepWrapper.MqPublish, nodeID, flowID, opts, result); err != nil {
+				return nil, err
+			}
+		case stepWrapper.MqConsume != nil:
+			if stepWrapper.MqConsume.Credential == "" {
+				return nil, NewYamlFlowErrorV2("missing required credential", "mq_consume", i)
+			}
+			if stepWrapper.MqConsume.Topic == "" {
+				return nil, NewYamlFlowErrorV2("missing required topic", "mq_consume", i)
+			}
+			if err := processMqConsumeStructStep(stepWrapper.MqConsume, nodeID, flowID, opts, result); err != nil {
+				return nil, err
+			}
+		case stepWrapper.Exec != nil:
+			if stepWrapper.Exec.Command == "" {
+				return nil, NewYamlFlowErrorV2("missing required command", "exec", i)
+			}
+			if err := processExecStructStep(stepWrapper.Exec, nodeID, flowID, result); err != nil {
+				return nil, err
+			}
 		case stepWrapper.Wait != nil:
 			if err := processWaitStructStep(stepWrapper.Wait, nodeID, flowID, result); err != nil {
 				return nil, err
@@ -234,6 +370,20 @@ func processSteps(flowEntry YamlFlowFlowV2, templates map[string]YamlRequestDefV
 			lastIdx := len(result.FlowNodes) - 1
 			result.FlowNodes[lastIdx].ID = startNodeID
 			startNodeFound = true
+		case stepWrapper.Webhook != nil:
+			// Webhook is an entry node — use startNodeID like ManualStart
+			if err := processWebhookStructStep(stepWrapper.Webhook, startNodeID, flowID, result); err != nil {
+				return nil, err
+			}
+			info.id = startNodeID
+			startNodeFound = true
+		case stepWrapper.GraphQLSubscription != nil:
+			// A subscription is an entry node too
+			if err := processGraphQLSubscriptionStructStep(stepWrapper.GraphQLSubscription, startNodeID, flowID, graphqlTemplates, opts, result); err != nil {
+				return nil, err
+			}
+			info.id = startNodeID
+			startNodeFound = true
 		case stepWrapper.SubFlowReturn != nil:
 			if err := processSubFlowReturnStructStep(stepWrapper.SubFlowReturn, nodeID, flowID, result); err != nil {
 				return nil, err
@@ -242,6 +392,28 @@ func processSteps(flowEntry YamlFlowFlowV2, templates map[string]YamlRequestDefV
 			if err := processRunSubFlowStructStep(stepWrapper.RunSubFlow, nodeID, flowID, result); err != nil {
 				return nil, err
 			}
+		case stepWrapper.Try != nil:
+			result.FlowNodes = append(result.FlowNodes, mflow.Node{
+				ID:       nodeID,
+				FlowID:   flowID,
+				Name:     nodeName,
+				NodeKind: mflow.NODE_KIND_TRY,
+			})
+		case stepWrapper.Parallel != nil:
+			if err := processParallelStructStep(stepWrapper.Parallel, nodeID, flowID, result); err != nil {
+				return nil, err
+			}
+		case stepWrapper.Poll != nil:
+			if strings.TrimSpace(stepWrapper.Poll.Until) == "" {
+				return nil, NewYamlFlowErrorV2("missing required until", "poll", i)
+			}
+			if err := processPollStructStep(stepWrapper.Poll, nodeID, flowID, result); err != nil {
+				return nil, err
+			}
+		case stepWrapper.Switch != nil:
+			if err := processSwitchStructStep(stepWrapper.Switch, nodeID, flowID, result); err != nil {
+				return nil, err
+			}
 		case stepWrapper.ManualStart != nil:
 			info.id = startNodeID
 			createStartNodeWithID(startNodeID, flowID, result)
@@ -436,6 +608,7 @@ func processForEachStructStep(step *YamlStepForEach, nodeID, flowID idwrap.IDWra
 	forEachNode := mflow.NodeForEach{
 		FlowNodeID:     nodeID,
 		IterExpression: step.Items,
+		Concurrency:    int32(step.Concurrency), //nolint:gosec // G115: small config value
 	}
 	if step.BreakCondition != "" {
 		forEachNode.Condition.Comparisons.Expression = step.BreakCondition
@@ -563,9 +736,69 @@ func processAIMemoryStructStep(step *YamlStepAIMemory, nodeID, flowID idwrap.IDW
 }
 
 func processGraphQLStructStep(step *YamlStepGraphQL, nodeID, flowID idwrap.IDWrap, templates map[string]YamlGraphQLDefV2, opts ConvertOptionsV2, result *ioworkspace.WorkspaceBundle) error {
+	gqlID, err := processGraphQLRequest(step, "graphql", templates, opts, result)
+	if err != nil {
+		return err
+	}
+
+	// Create flow node
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_GRAPHQL,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	// Create GraphQL node linking flow node to GraphQL entity
+	graphqlNode := mflow.NodeGraphQL{
+		FlowNodeID: nodeID,
+		GraphQLID:  &gqlID,
+	}
+	result.FlowGraphQLNodes = append(result.FlowGraphQLNodes, graphqlNode)
+
+	return nil
+}
+
+func processGraphQLSubscriptionStructStep(step *YamlStepGraphQLSubscription, nodeID, flowID idwrap.IDWrap, templates map[string]YamlGraphQLDefV2, opts ConvertOptionsV2, result *ioworkspace.WorkspaceBundle) error {
+	if step.MaxEvents < 0 {
+		return NewYamlFlowErrorV2("max_events must not be negative", "max_events", step.MaxEvents)
+	}
+	if step.TimeoutMs < 0 {
+		return NewYamlFlowErrorV2("timeout_ms must not be negative", "timeout_ms", step.TimeoutMs)
+	}
+	gqlID, err := processGraphQLRequest(&step.YamlStepGraphQL, "graphql_subscription", templates, opts, result)
+	if err != nil {
+		return err
+	}
+
+	result.FlowNodes = append(result.FlowNodes, mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION,
+	})
+	result.FlowGraphQLSubscriptionNodes = append(result.FlowGraphQLSubscriptionNodes, mflow.NodeGraphQLSubscription{
+		FlowNodeID:        nodeID,
+		GraphQLID:         &gqlID,
+		ConnectionPayload: step.ConnectionPayload,
+		MaxEvents:         step.MaxEvents,
+		TimeoutMs:         step.TimeoutMs,
+	})
+	return nil
+}
+
+// processGraphQLRequest creates the GraphQL request of a graphql or
+// graphql_subscription step, with its headers and assertions, and returns
+// its ID. Step-level values override the template's.
+func processGraphQLRequest(step *YamlStepGraphQL, stepType string, templates map[string]YamlGraphQLDefV2, opts ConvertOptionsV2, result *ioworkspace.WorkspaceBundle) (idwrap.IDWrap, error) {
 	url := step.URL
 	query := step.Query
 	variables := step.Variables
+	operationName := step.OperationName
+	persistedQuery := step.PersistedQuery
+	batch := step.Batch
+	uploads := make(map[string]string)
 	var headers HeaderMapOrSlice
 	var assertions AssertionsOrSlice
 
@@ -580,10 +813,20 @@ func processGraphQLStructStep(step *YamlStepGraphQL, nodeID, flowID idwrap.IDWra
 			if tmpl.Variables != "" {
 				variables = tmpl.Variables
 			}
+			if tmpl.OperationName != "" {
+				operationName = tmpl.OperationName
+			}
+			persistedQuery = persistedQuery || tmpl.PersistedQuery
+			if len(tmpl.Batch) > 0 {
+				batch = tmpl.Batch
+			}
+			for path, file := range tmpl.Uploads {
+				uploads[path] = file
+			}
 			headers = tmpl.Headers
 			assertions = tmpl.Assertions
 		} else {
-			return NewYamlFlowErrorV2(fmt.Sprintf("graphql step '%s' references unknown template '%s'", step.Name, step.UseRequest), "use_request", step.UseRequest)
+			return idwrap.IDWrap{}, NewYamlFlowErrorV2(fmt.Sprintf("%s step '%s' references unknown template '%s'", stepType, step.Name, step.UseRequest), "use_request", step.UseRequest)
 		}
 	}
 
@@ -597,6 +840,15 @@ func processGraphQLStructStep(step *YamlStepGraphQL, nodeID, flowID idwrap.IDWra
 	if step.Variables != "" {
 		variables = step.Variables
 	}
+	if step.OperationName != "" {
+		operationName = step.OperationName
+	}
+	if len(step.Batch) > 0 {
+		batch = step.Batch
+	}
+	for path, file := range step.Uploads {
+		uploads[path] = file
+	}
 	if len(step.Headers) > 0 {
 		headers = append(headers, step.Headers...)
 	}
@@ -605,22 +857,32 @@ func processGraphQLStructStep(step *YamlStepGraphQL, nodeID, flowID idwrap.IDWra
 	}
 
 	if url == "" {
-		return NewYamlFlowErrorV2(fmt.Sprintf("graphql step '%s' missing required url", step.Name), "url", nil)
+		return idwrap.IDWrap{}, NewYamlFlowErrorV2(fmt.Sprintf("%s step '%s' missing required url", stepType, step.Name), "url", nil)
+	}
+
+	query, queryFile, err := resolveGraphQLQuery(query, opts)
+	if err != nil {
+		return idwrap.IDWrap{}, NewYamlFlowErrorV2(fmt.Sprintf("%s step '%s': %v", stepType, step.Name, err), "query", queryFile)
 	}
 
 	gqlID := idwrap.NewNow()
 	now := time.Now().UnixMilli()
 
 	gqlReq := mgraphql.GraphQL{
-		ID:          gqlID,
-		WorkspaceID: opts.WorkspaceID,
-		FolderID:    opts.FolderID,
-		Name:        step.Name,
-		Url:         url,
-		Query:       query,
-		Variables:   variables,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:             gqlID,
+		WorkspaceID:    opts.WorkspaceID,
+		FolderID:       opts.FolderID,
+		Name:           step.Name,
+		Url:            url,
+		Query:          query,
+		Variables:      variables,
+		OperationName:  operationName,
+		QueryFile:      queryFile,
+		PersistedQuery: persistedQuery,
+		Batch:          convertGraphQLBatch(batch),
+		Uploads:        convertGraphQLUploads(uploads),
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 	result.GraphQLRequests = append(result.GraphQLRequests, gqlReq)
 
@@ -653,23 +915,64 @@ func processGraphQLStructStep(step *YamlStepGraphQL, nodeID, flowID idwrap.IDWra
 		result.GraphQLAsserts = append(result.GraphQLAsserts, assert)
 	}
 
-	// Create flow node
-	flowNode := mflow.Node{
-		ID:       nodeID,
-		FlowID:   flowID,
-		Name:     step.Name,
-		NodeKind: mflow.NODE_KIND_GRAPHQL,
+	return gqlID, nil
+}
+
+// graphQLFilePrefix marks a GraphQL query stored in a separate .graphql file.
+const graphQLFilePrefix = "#file:"
+
+// resolveGraphQLQuery loads a "#file:<path>" query through opts.ReadFile and
+// returns its content with the referenced path. Inline queries are returned
+// unchanged with an empty path.
+func resolveGraphQLQuery(query string, opts ConvertOptionsV2) (string, string, error) {
+	trimmed := strings.TrimSpace(query)
+	if !strings.HasPrefix(trimmed, graphQLFilePrefix) {
+		return query, "", nil
 	}
-	result.FlowNodes = append(result.FlowNodes, flowNode)
+	path := strings.TrimSpace(strings.TrimPrefix(trimmed, graphQLFilePrefix))
+	if path == "" {
+		return "", "", fmt.Errorf("empty %s reference", graphQLFilePrefix)
+	}
+	if opts.ReadFile == nil {
+		return "", path, fmt.Errorf("cannot resolve query file %q: file references are not supported here", path)
+	}
+	data, err := opts.ReadFile(path)
+	if err != nil {
+		return "", path, fmt.Errorf("failed to read query file %q: %w", path, err)
+	}
+	return string(data), path, nil
+}
 
-	// Create GraphQL node linking flow node to GraphQL entity
-	graphqlNode := mflow.NodeGraphQL{
-		FlowNodeID: nodeID,
-		GraphQLID:  &gqlID,
+func convertGraphQLBatch(batch []YamlGraphQLOperation) []mgraphql.GraphQLOperation {
+	if len(batch) == 0 {
+		return nil
 	}
-	result.FlowGraphQLNodes = append(result.FlowGraphQLNodes, graphqlNode)
+	ops := make([]mgraphql.GraphQLOperation, len(batch))
+	for i, op := range batch {
+		ops[i] = mgraphql.GraphQLOperation{
+			Query:         op.Query,
+			Variables:     op.Variables,
+			OperationName: op.OperationName,
+		}
+	}
+	return ops
+}
 
-	return nil
+// convertGraphQLUploads orders uploads by path so conversions are stable.
+func convertGraphQLUploads(uploads map[string]string) []mgraphql.GraphQLUpload {
+	if len(uploads) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(uploads))
+	for path := range uploads {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	result := make([]mgraphql.GraphQLUpload, len(paths))
+	for i, path := range paths {
+		result[i] = mgraphql.GraphQLUpload{Path: path, File: uploads[path]}
+	}
+	return result
 }
 
 func processWsConnectionStructStep(step *YamlStepWsConnection, nodeID, flowID idwrap.IDWrap, opts ConvertOptionsV2, result *ioworkspace.WorkspaceBundle) error {
@@ -677,6 +980,21 @@ func processWsConnectionStructStep(step *YamlStepWsConnection, nodeID, flowID id
 		return NewYamlFlowErrorV2(fmt.Sprintf("ws_connection step '%s' missing required url", step.Name), "url", nil)
 	}
 
+	var protocol mwebsocket.Protocol
+	switch strings.ToLower(strings.TrimSpace(step.Protocol)) {
+	case "", WsProtocolRaw:
+		protocol = mwebsocket.ProtocolRaw
+	case WsProtocolSocketIO:
+		protocol = mwebsocket.ProtocolSocketIO
+	case WsProtocolStomp:
+		protocol = mwebsocket.ProtocolStomp
+	default:
+		return NewYamlFlowErrorV2(fmt.Sprintf("invalid protocol value '%s', expected raw, socketio or stomp", step.Protocol), "protocol", step.Protocol)
+	}
+	if len(step.Subscriptions) > 0 && protocol != mwebsocket.ProtocolStomp {
+		return NewYamlFlowErrorV2(fmt.Sprintf("ws_connection step '%s' has subscriptions but protocol is not stomp", step.Name), "subscriptions", nil)
+	}
+
 	flowNode := mflow.Node{
 		ID:       nodeID,
 		FlowID:   flowID,
@@ -689,12 +1007,16 @@ func processWsConnectionStructStep(step *YamlStepWsConnection, nodeID, flowID id
 	wsID := idwrap.NewNow()
 	now := time.Now().UnixMilli()
 	ws := mwebsocket.WebSocket{
-		ID:          wsID,
-		WorkspaceID: opts.WorkspaceID,
-		Name:        step.Name,
-		Url:         step.URL,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:             wsID,
+		WorkspaceID:    opts.WorkspaceID,
+		Name:           step.Name,
+		Url:            step.URL,
+		Subprotocols:   step.Subprotocols,
+		Protocol:       protocol,
+		ConnectPayload: step.ConnectPayload,
+		Subscriptions:  step.Subscriptions,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 	result.WebSockets = append(result.WebSockets, ws)
 
@@ -734,11 +1056,278 @@ func processWsSendStructStep(step *YamlStepWsSend, nodeID, flowID idwrap.IDWrap,
 		FlowNodeID:           nodeID,
 		WsConnectionNodeName: step.WsConnectionNodeName,
 		Message:              step.Message,
+		Binary:               step.Binary,
+		Event:                step.Event,
+		Ack:                  step.Ack,
 	}
 	result.FlowWsSendNodes = append(result.FlowWsSendNodes, wsSendNode)
 	return nil
 }
 
+func processWsExpectStructStep(step *YamlStepWsExpect, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
+	if step.WsConnectionNodeName == "" {
+		return NewYamlFlowErrorV2(fmt.Sprintf("ws_expect step '%s' missing required ws_connection_node_name", step.Name), "ws_connection_node_name", nil)
+	}
+
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_WS_EXPECT,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	wsExpectNode := mflow.NodeWsExpect{
+		FlowNodeID:           nodeID,
+		WsConnectionNodeName: step.WsConnectionNodeName,
+		Message:              step.Message,
+		Binary:               step.Binary,
+		Match:                step.Match,
+		TimeoutMs:            step.TimeoutMs,
+		Count:                step.Count,
+		Assertions:           step.Assertions,
+		Ping:                 step.Ping,
+		ExpectClose:          step.ExpectClose,
+	}
+	result.FlowWsExpectNodes = append(result.FlowWsExpectNodes, wsExpectNode)
+	return nil
+}
+
+func processSocketConnectionStructStep(step *YamlStepSocketConnection, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
+	if step.Address == "" {
+		return NewYamlFlowErrorV2(fmt.Sprintf("socket_connection step '%s' missing required address", step.Name), "address", nil)
+	}
+
+	var network mflow.SocketNetwork
+	switch strings.ToLower(strings.TrimSpace(step.Network)) {
+	case "", SocketNetworkTCP:
+		network = mflow.SocketNetworkTCP
+	case SocketNetworkUDP:
+		network = mflow.SocketNetworkUDP
+	default:
+		return NewYamlFlowErrorV2(fmt.Sprintf("invalid network value '%s', expected tcp or udp", step.Network), "network", step.Network)
+	}
+	if step.TLS && network == mflow.SocketNetworkUDP {
+		return NewYamlFlowErrorV2(fmt.Sprintf("socket_connection step '%s' enables tls on a udp connection", step.Name), "tls", nil)
+	}
+
+	var framing mflow.SocketFraming
+	switch strings.ToLower(strings.TrimSpace(step.Framing)) {
+	case "", SocketFramingNone:
+		framing = mflow.SocketFramingNone
+	case SocketFramingDelimiter:
+		framing = mflow.SocketFramingDelimiter
+	case SocketFramingLength:
+		framing = mflow.SocketFramingLength
+	default:
+		return NewYamlFlowErrorV2(fmt.Sprintf("invalid framing value '%s', expected none, delimiter or length", step.Framing), "framing", step.Framing)
+	}
+	switch step.LengthBytes {
+	case 0, 1, 2, 4:
+	default:
+		return NewYamlFlowErrorV2(fmt.Sprintf("invalid length_bytes value %d, expected 1, 2 or 4", step.LengthBytes), "length_bytes", step.LengthBytes)
+	}
+
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_SOCKET_CONNECTION,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	socketNode := mflow.NodeSocketConnection{
+		FlowNodeID:    nodeID,
+		Network:       network,
+		Address:       step.Address,
+		TLS:           step.TLS,
+		TLSSkipVerify: step.TLSSkipVerify,
+		Framing:       framing,
+		Delimiter:     step.Delimiter,
+		LengthBytes:   step.LengthBytes,
+		TimeoutMs:     step.TimeoutMs,
+	}
+	result.FlowSocketConnectionNodes = append(result.FlowSocketConnectionNodes, socketNode)
+	return nil
+}
+
+func processSocketSendStructStep(step *YamlStepSocketSend, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
+	if step.SocketConnectionNodeName == "" {
+		return NewYamlFlowErrorV2(fmt.Sprintf("socket_send step '%s' missing required socket_connection_node_name", step.Name), "socket_connection_node_name", nil)
+	}
+
+	var encoding mflow.SocketEncoding
+	switch strings.ToLower(strings.TrimSpace(step.Encoding)) {
+	case "", SocketEncodingText:
+		encoding = mflow.SocketEncodingText
+	case SocketEncodingHex:
+		encoding = mflow.SocketEncodingHex
+	case SocketEncodingBase64:
+		encoding = mflow.SocketEncodingBase64
+	default:
+		return NewYamlFlowErrorV2(fmt.Sprintf("invalid encoding value '%s', expected text, hex or base64", step.Encoding), "encoding", step.Encoding)
+	}
+
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_SOCKET_SEND,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	socketNode := mflow.NodeSocketSend{
+		FlowNodeID:               nodeID,
+		SocketConnectionNodeName: step.SocketConnectionNodeName,
+		Message:                  step.Message,
+		Encoding:                 encoding,
+	}
+	result.FlowSocketSendNodes = append(result.FlowSocketSendNodes, socketNode)
+	return nil
+}
+
+func processSocketReceiveStructStep(step *YamlStepSocketReceive, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
+	if step.SocketConnectionNodeName == "" {
+		return NewYamlFlowErrorV2(fmt.Sprintf("socket_receive step '%s' missing required socket_connection_node_name", step.Name), "socket_connection_node_name", nil)
+	}
+
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_SOCKET_RECEIVE,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	socketNode := mflow.NodeSocketReceive{
+		FlowNodeID:               nodeID,
+		SocketConnectionNodeName: step.SocketConnectionNodeName,
+		Match:                    step.Match,
+		TimeoutMs:                step.TimeoutMs,
+		Count:                    step.Count,
+		Assertions:               step.Assertions,
+	}
+	result.FlowSocketReceiveNodes = append(result.FlowSocketReceiveNodes, socketNode)
+	return nil
+}
+
+func processDbQueryStructStep(step *YamlStepDbQuery, nodeID, flowID idwrap.IDWrap, opts ConvertOptionsV2, result *ioworkspace.WorkspaceBundle) error {
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_DB_QUERY,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	// Resolve credential name to ID
+	var credentialID *idwrap.IDWrap
+	if opts.CredentialMap != nil {
+		if id, ok := opts.CredentialMap[step.Credential]; ok {
+			credentialID = &id
+		}
+	}
+
+	queryNode := mflow.NodeDbQuery{
+		FlowNodeID:   nodeID,
+		CredentialID: credentialID,
+		Query:        step.Query,
+		Params:       step.Params,
+		TimeoutMs:    step.TimeoutMs,
+		Assertions:   step.Assertions,
+	}
+	result.FlowDbQueryNodes = append(result.FlowDbQueryNodes, queryNode)
+	return nil
+}
+
+func processMqPublishStructStep(step *YamlStepMqPublish, nodeID, flowID idwrap.IDWrap, opts ConvertOptionsV2, result *ioworkspace.WorkspaceBundle) error {
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_MQ_PUBLISH,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	// Resolve credential name to ID
+	var credentialID *idwrap.IDWrap
+	if opts.CredentialMap != nil {
+		if id, ok := opts.CredentialMap[step.Credential]; ok {
+			credentialID = &id
+		}
+	}
+
+	publishNode := mflow.NodeMqPublish{
+		FlowNodeID:   nodeID,
+		CredentialID: credentialID,
+		Topic:        step.Topic,
+		Key:          step.Key,
+		Message:      step.Message,
+	}
+	result.FlowMqPublishNodes = append(result.FlowMqPublishNodes, publishNode)
+	return nil
+}
+
+func processMqConsumeStructStep(step *YamlStepMqConsume, nodeID, flowID idwrap.IDWrap, opts ConvertOptionsV2, result *ioworkspace.WorkspaceBundle) error {
+	if step.Count < 0 {
+		return NewYamlFlowErrorV2(fmt.Sprintf("invalid count value %d, expected a positive number", step.Count), "count", step.Count)
+	}
+
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_MQ_CONSUME,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	// Resolve credential name to ID
+	var credentialID *idwrap.IDWrap
+	if opts.CredentialMap != nil {
+		if id, ok := opts.CredentialMap[step.Credential]; ok {
+			credentialID = &id
+		}
+	}
+
+	consumeNode := mflow.NodeMqConsume{
+		FlowNodeID:   nodeID,
+		CredentialID: credentialID,
+		Topic:        step.Topic,
+		Group:        step.Group,
+		Filter:       step.Filter,
+		TimeoutMs:    step.TimeoutMs,
+		Count:        step.Count,
+		Assertions:   step.Assertions,
+	}
+	result.FlowMqConsumeNodes = append(result.FlowMqConsumeNodes, consumeNode)
+	return nil
+}
+
+func processExecStructStep(step *YamlStepExec, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
+	if step.TimeoutMs < 0 {
+		return NewYamlFlowErrorV2("timeout_ms must not be negative", "timeout_ms", step.TimeoutMs)
+	}
+
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_EXEC,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	execNode := mflow.NodeExec{
+		FlowNodeID: nodeID,
+		Command:    step.Command,
+		Args:       step.Args,
+		WorkDir:    step.WorkDir,
+		TimeoutMs:  step.TimeoutMs,
+		ParseJSON:  step.ParseJSON,
+	}
+	result.FlowExecNodes = append(result.FlowExecNodes, execNode)
+	return nil
+}
+
 func processWaitStructStep(step *YamlStepWait, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
 	flowNode := mflow.Node{
 		ID:       nodeID,
@@ -765,6 +1354,101 @@ func processWaitStructStep(step *YamlStepWait, nodeID, flowID idwrap.IDWrap, res
 	return nil
 }
 
+func processParallelStructStep(step *YamlStepParallel, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_PARALLEL,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	var join mflow.ParallelJoin
+	switch strings.ToLower(strings.TrimSpace(step.Join)) {
+	case "", ParallelJoinAll:
+		join = mflow.ParallelJoinAll
+	case ParallelJoinAny:
+		join = mflow.ParallelJoinAny
+	case ParallelJoinFirstSuccess:
+		join = mflow.ParallelJoinFirstSuccess
+	default:
+		return NewYamlFlowErrorV2(fmt.Sprintf("invalid join value '%s', expected all, any or first_success", step.Join), "join", step.Join)
+	}
+	if step.Concurrency < 0 {
+		return NewYamlFlowErrorV2("concurrency must not be negative", "concurrency", step.Concurrency)
+	}
+
+	parallelNode := mflow.NodeParallel{
+		FlowNodeID:  nodeID,
+		JoinMode:    join,
+		Concurrency: int32(step.Concurrency), //nolint:gosec // G115: small config value
+	}
+	result.FlowParallelNodes = append(result.FlowParallelNodes, parallelNode)
+	return nil
+}
+
+func processPollStructStep(step *YamlStepPoll, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
+	if step.IntervalMs < 0 || step.MaxDurationMs < 0 || step.MaxAttempts < 0 {
+		return NewYamlFlowErrorV2("interval_ms, max_duration_ms and max_attempts must not be negative", "poll", step.Name)
+	}
+
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_POLL,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	pollNode := mflow.NodePoll{
+		FlowNodeID:    nodeID,
+		Until:         step.Until,
+		IntervalMs:    step.IntervalMs,
+		Backoff:       step.Backoff,
+		MaxDurationMs: step.MaxDurationMs,
+		MaxAttempts:   step.MaxAttempts,
+	}
+	result.FlowPollNodes = append(result.FlowPollNodes, pollNode)
+	return nil
+}
+
+func processSwitchStructStep(step *YamlStepSwitch, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
+	if len(step.Cases) == 0 {
+		return NewYamlFlowErrorV2("switch needs at least one case", "cases", step.Name)
+	}
+
+	cases := make([]mflow.SwitchCase, len(step.Cases))
+	seen := make(map[string]bool, len(step.Cases))
+	for i, c := range step.Cases {
+		if strings.TrimSpace(c.When) == "" {
+			return NewYamlFlowErrorV2(fmt.Sprintf("switch case %d is missing 'when'", i), "when", step.Name)
+		}
+		cases[i] = mflow.SwitchCase{Name: c.Name, Expression: c.When}
+		label := cases[i].Label(i)
+		if strings.Contains(label, ".") || label == strings.TrimPrefix(DependsSuffixDefault, ".") {
+			return NewYamlFlowErrorV2(fmt.Sprintf("invalid switch case name '%s'", label), "name", step.Name)
+		}
+		if seen[label] {
+			return NewYamlFlowErrorV2(fmt.Sprintf("duplicate switch case name '%s'", label), "name", step.Name)
+		}
+		seen[label] = true
+	}
+
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_SWITCH,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	result.FlowSwitchNodes = append(result.FlowSwitchNodes, mflow.NodeSwitch{
+		FlowNodeID: nodeID,
+		Cases:      cases,
+	})
+	return nil
+}
+
 func processSubFlowTriggerStructStep(step *YamlStepSubFlowTrigger, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
 	flowNode := mflow.Node{
 		ID:       nodeID,
@@ -792,6 +1476,29 @@ func processSubFlowTriggerStructStep(step *YamlStepSubFlowTrigger, nodeID, flowI
 	return nil
 }
 
+func processWebhookStructStep(step *YamlStepWebhook, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
+	if strings.TrimSpace(step.Path) == "" {
+		return NewYamlFlowErrorV2("missing required path", "webhook", step.Name)
+	}
+
+	flowNode := mflow.Node{
+		ID:       nodeID,
+		FlowID:   flowID,
+		Name:     step.Name,
+		NodeKind: mflow.NODE_KIND_WEBHOOK_TRIGGER,
+	}
+	result.FlowNodes = append(result.FlowNodes, flowNode)
+
+	result.FlowWebhookTriggerNodes = append(result.FlowWebhookTriggerNodes, mflow.NodeWebhookTrigger{
+		FlowNodeID:      nodeID,
+		Path:            mflow.NormalizeWebhookPath(step.Path),
+		Method:          strings.ToUpper(step.Method),
+		Secret:          step.Secret,
+		SignatureHeader: step.SignatureHeader,
+	})
+	return nil
+}
+
 func processSubFlowReturnStructStep(step *YamlStepSubFlowReturn, nodeID, flowID idwrap.IDWrap, result *ioworkspace.WorkspaceBundle) error {
 	flowNode := mflow.Node{
 		ID:       nodeID,
@@ -135,11 +135,69 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 		wsSendNodeMap[n.FlowNodeID] = n
 	}
 
+	wsExpectNodeMap := make(map[idwrap.IDWrap]mflow.NodeWsExpect)
+	for _, n := range data.FlowWsExpectNodes {
+		wsExpectNodeMap[n.FlowNodeID] = n
+	}
+
+	socketConnectionNodeMap := make(map[idwrap.IDWrap]mflow.NodeSocketConnection)
+	for _, n := range data.FlowSocketConnectionNodes {
+		socketConnectionNodeMap[n.FlowNodeID] = n
+	}
+
+	socketSendNodeMap := make(map[idwrap.IDWrap]mflow.NodeSocketSend)
+	for _, n := range data.FlowSocketSendNodes {
+		socketSendNodeMap[n.FlowNodeID] = n
+	}
+
+	dbQueryNodeMap := make(map[idwrap.IDWrap]mflow.NodeDbQuery)
+	for _, n := range data.FlowDbQueryNodes {
+		dbQueryNodeMap[n.FlowNodeID] = n
+	}
+	mqPublishNodeMap := make(map[idwrap.IDWrap]mflow.NodeMqPublish)
+	for _, n := range data.FlowMqPublishNodes {
+		mqPublishNodeMap[n.FlowNodeID] = n
+	}
+	mqConsumeNodeMap := make(map[idwrap.IDWrap]mflow.NodeMqConsume)
+	for _, n := range data.FlowMqConsumeNodes {
+		mqConsumeNodeMap[n.FlowNodeID] = n
+	}
+	execNodeMap := make(map[idwrap.IDWrap]mflow.NodeExec)
+	for _, n := range data.FlowExecNodes {
+		execNodeMap[n.FlowNodeID] = n
+	}
+	socketReceiveNodeMap := make(map[idwrap.IDWrap]mflow.NodeSocketReceive)
+	for _, n := range data.FlowSocketReceiveNodes {
+		socketReceiveNodeMap[n.FlowNodeID] = n
+	}
+
 	waitNodeMap := make(map[idwrap.IDWrap]mflow.NodeWait)
 	for _, n := range data.FlowWaitNodes {
 		waitNodeMap[n.FlowNodeID] = n
 	}
 
+	parallelNodeMap := make(map[idwrap.IDWrap]mflow.NodeParallel)
+	for _, n := range data.FlowParallelNodes {
+		parallelNodeMap[n.FlowNodeID] = n
+	}
+
+	pollNodeMap := make(map[idwrap.IDWrap]mflow.NodePoll)
+	for _, n := range data.FlowPollNodes {
+		pollNodeMap[n.FlowNodeID] = n
+	}
+	switchNodeMap := make(map[idwrap.IDWrap]mflow.NodeSwitch)
+	for _, n := range data.FlowSwitchNodes {
+		switchNodeMap[n.FlowNodeID] = n
+	}
+	webhookNodeMap := make(map[idwrap.IDWrap]mflow.NodeWebhookTrigger)
+	for _, n := range data.FlowWebhookTriggerNodes {
+		webhookNodeMap[n.FlowNodeID] = n
+	}
+	graphqlSubscriptionNodeMap := make(map[idwrap.IDWrap]mflow.NodeGraphQLSubscription)
+	for _, n := range data.FlowGraphQLSubscriptionNodes {
+		graphqlSubscriptionNodeMap[n.FlowNodeID] = n
+	}
+
 	subFlowTriggerNodeMap := make(map[idwrap.IDWrap]mflow.NodeSubFlowTrigger)
 	for _, n := range data.FlowSubFlowTriggerNodes {
 		subFlowTriggerNodeMap[n.FlowNodeID] = n
@@ -284,14 +342,20 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 	// First pass: collect all GraphQL requests used in flows
 	for _, flow := range data.Flows {
 		for _, n := range data.FlowNodes {
-			if n.FlowID != flow.ID || n.NodeKind != mflow.NODE_KIND_GRAPHQL {
+			if n.FlowID != flow.ID {
 				continue
 			}
-			gqlNode, ok := graphqlNodeMap[n.ID]
-			if !ok || gqlNode.GraphQLID == nil {
+			var gqlID *idwrap.IDWrap
+			switch n.NodeKind {
+			case mflow.NODE_KIND_GRAPHQL:
+				gqlID = graphqlNodeMap[n.ID].GraphQLID
+			case mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION:
+				gqlID = graphqlSubscriptionNodeMap[n.ID].GraphQLID
+			}
+			if gqlID == nil {
 				continue
 			}
-			gqlReq, ok := graphqlMap[*gqlNode.GraphQLID]
+			gqlReq, ok := graphqlMap[*gqlID]
 			if !ok {
 				continue
 			}
@@ -331,12 +395,16 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 		gqlReq := graphqlMap[gqlID]
 
 		gqlDef := YamlGraphQLDefV2{
-			Name:       gqlName,
-			URL:        gqlReq.Url,
-			Query:      gqlReq.Query,
-			Variables:  gqlReq.Variables,
-			Headers:    buildGraphQLHeaderMapOrSlice(graphqlHeadersMap[gqlID]),
-			Assertions: buildGraphQLAssertions(graphqlAssertsMap[gqlID]),
+			Name:           gqlName,
+			URL:            gqlReq.Url,
+			Query:          exportGraphQLQuery(gqlReq),
+			Variables:      gqlReq.Variables,
+			OperationName:  gqlReq.OperationName,
+			Headers:        buildGraphQLHeaderMapOrSlice(graphqlHeadersMap[gqlID]),
+			Assertions:     buildGraphQLAssertions(graphqlAssertsMap[gqlID]),
+			PersistedQuery: gqlReq.PersistedQuery,
+			Batch:          exportGraphQLBatch(gqlReq.Batch),
+			Uploads:        exportGraphQLUploads(gqlReq.Uploads),
 		}
 		graphqlRequests = append(graphqlRequests, gqlDef)
 	}
@@ -382,7 +450,8 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 		for _, n := range data.FlowNodes {
 			if n.FlowID == flow.ID {
 				flowNodes = append(flowNodes, n)
-				if n.NodeKind == mflow.NODE_KIND_MANUAL_START || n.NodeKind == mflow.NODE_KIND_SUB_FLOW_TRIGGER {
+				if n.NodeKind == mflow.NODE_KIND_MANUAL_START || n.NodeKind == mflow.NODE_KIND_SUB_FLOW_TRIGGER ||
+					n.NodeKind == mflow.NODE_KIND_WEBHOOK_TRIGGER || n.NodeKind == mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION {
 					startNodeID = n.ID
 				}
 			}
@@ -393,7 +462,23 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 			}
 		}
 
-		orderedNodes := flowgraph.LinearizeNodes(startNodeID, flowNodes, flowEdges)
+		// Nodes reachable from a teardown node form the teardown section;
+		// they are linearized on their own and listed after the steps.
+		teardownSet := teardownSection(flowNodes, flowEdges)
+		var mainNodes, teardownNodes []mflow.Node
+		var teardownStartID idwrap.IDWrap
+		for _, n := range flowNodes {
+			if !teardownSet[n.ID] {
+				mainNodes = append(mainNodes, n)
+				continue
+			}
+			if n.NodeKind == mflow.NODE_KIND_TEARDOWN && len(teardownNodes) == 0 {
+				teardownStartID = n.ID
+			}
+			teardownNodes = append(teardownNodes, n)
+		}
+		orderedNodes := flowgraph.LinearizeNodes(startNodeID, mainNodes, flowEdges)
+		orderedNodes = append(orderedNodes, flowgraph.LinearizeNodes(teardownStartID, teardownNodes, flowEdges)...)
 
 		for _, node := range orderedNodes {
 			var stepWrapper YamlStepWrapper
@@ -406,6 +491,10 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 				if !ok {
 					continue
 				}
+				if sourceNode.NodeKind == mflow.NODE_KIND_TEARDOWN {
+					// Teardown steps without depends_on start the section.
+					continue
+				}
 
 				depStr := sourceNode.Name
 				switch e.SourceHandler {
@@ -414,13 +503,42 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 				case mflow.HandleElse:
 					depStr += DependsSuffixElse
 				case mflow.HandleLoop:
-					depStr += DependsSuffixLoop
+					switch sourceNode.NodeKind {
+					case mflow.NODE_KIND_TRY:
+						depStr += DependsSuffixTry
+					case mflow.NODE_KIND_PARALLEL:
+						depStr += DependsSuffixBranch
+					default:
+						depStr += DependsSuffixLoop
+					}
 				case mflow.HandleWsMessage:
-					depStr += DependsSuffixWsMessage
+					switch sourceNode.NodeKind {
+					case mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION:
+						depStr += DependsSuffixNext
+					case mflow.NODE_KIND_SOCKET_CONNECTION, mflow.NODE_KIND_MQ_CONSUME:
+						depStr += DependsSuffixMessage
+					default:
+						depStr += DependsSuffixWsMessage
+					}
+				case mflow.HandleError:
+					if sourceNode.NodeKind == mflow.NODE_KIND_TRY {
+						depStr += DependsSuffixCatch
+					} else {
+						depStr += DependsSuffixOnError
+					}
+				case mflow.HandleDefault:
+					depStr += DependsSuffixDefault
 				case mflow.HandleUnspecified:
 					// Do nothing, just the name
 				default:
-					// Unknown handler, default to name
+					// Switch case handles; any other handler keeps the bare name
+					if index, ok := mflow.CaseIndex(e.SourceHandler); ok {
+						var c mflow.SwitchCase
+						if cases := switchNodeMap[sourceNode.ID].Cases; index < len(cases) {
+							c = cases[index]
+						}
+						depStr += "." + c.Label(index)
+					}
 				}
 
 				explicitDeps = append(explicitDeps, depStr)
@@ -494,6 +612,7 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 					YamlStepCommon: common,
 					Items:          forEachNode.IterExpression,
 					BreakCondition: forEachNode.Condition.Comparisons.Expression,
+					Concurrency:    int(forEachNode.Concurrency),
 				}
 				// Removed legacy loop field
 				stepWrapper.ForEach = forEachStep
@@ -602,10 +721,14 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 					gqlStep.UseRequest = gqlName
 				} else {
 					gqlStep.URL = gqlReq.Url
-					gqlStep.Query = gqlReq.Query
+					gqlStep.Query = exportGraphQLQuery(gqlReq)
 					gqlStep.Variables = gqlReq.Variables
+					gqlStep.OperationName = gqlReq.OperationName
 					gqlStep.Headers = buildGraphQLHeaderMapOrSlice(graphqlHeadersMap[gqlReq.ID])
 					gqlStep.Assertions = buildGraphQLAssertions(graphqlAssertsMap[gqlReq.ID])
+					gqlStep.PersistedQuery = gqlReq.PersistedQuery
+					gqlStep.Batch = exportGraphQLBatch(gqlReq.Batch)
+					gqlStep.Uploads = exportGraphQLUploads(gqlReq.Uploads)
 				}
 				stepWrapper.GraphQL = gqlStep
 
@@ -620,6 +743,15 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 				if wsConnNode.WebSocketID != nil {
 					if wsEntity, ok := wsEntityMap[*wsConnNode.WebSocketID]; ok {
 						wsStep.URL = wsEntity.Url
+						wsStep.Subprotocols = wsEntity.Subprotocols
+						switch wsEntity.Protocol {
+						case mwebsocket.ProtocolSocketIO:
+							wsStep.Protocol = WsProtocolSocketIO
+						case mwebsocket.ProtocolStomp:
+							wsStep.Protocol = WsProtocolStomp
+						}
+						wsStep.ConnectPayload = wsEntity.ConnectPayload
+						wsStep.Subscriptions = wsEntity.Subscriptions
 					}
 					if headers, ok := wsHeaderMap[*wsConnNode.WebSocketID]; ok {
 						for _, h := range headers {
@@ -644,9 +776,167 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 					YamlStepCommon:       common,
 					WsConnectionNodeName: wsSendNode.WsConnectionNodeName,
 					Message:              wsSendNode.Message,
+					Binary:               wsSendNode.Binary,
+					Event:                wsSendNode.Event,
+					Ack:                  wsSendNode.Ack,
 				}
 				stepWrapper.WsSend = wsStep
 
+			case mflow.NODE_KIND_WS_EXPECT:
+				wsExpectNode, ok := wsExpectNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				stepWrapper.WsExpect = &YamlStepWsExpect{
+					YamlStepCommon:       common,
+					WsConnectionNodeName: wsExpectNode.WsConnectionNodeName,
+					Message:              wsExpectNode.Message,
+					Binary:               wsExpectNode.Binary,
+					Match:                wsExpectNode.Match,
+					TimeoutMs:            wsExpectNode.TimeoutMs,
+					Count:                wsExpectNode.Count,
+					Assertions:           wsExpectNode.Assertions,
+					Ping:                 wsExpectNode.Ping,
+					ExpectClose:          wsExpectNode.ExpectClose,
+				}
+
+			case mflow.NODE_KIND_SOCKET_CONNECTION:
+				socketNode, ok := socketConnectionNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				socketStep := &YamlStepSocketConnection{
+					YamlStepCommon: common,
+					Address:        socketNode.Address,
+					TLS:            socketNode.TLS,
+					TLSSkipVerify:  socketNode.TLSSkipVerify,
+					Delimiter:      socketNode.Delimiter,
+					LengthBytes:    socketNode.LengthBytes,
+					TimeoutMs:      socketNode.TimeoutMs,
+				}
+				if socketNode.Network == mflow.SocketNetworkUDP {
+					socketStep.Network = SocketNetworkUDP
+				}
+				switch socketNode.Framing {
+				case mflow.SocketFramingDelimiter:
+					socketStep.Framing = SocketFramingDelimiter
+				case mflow.SocketFramingLength:
+					socketStep.Framing = SocketFramingLength
+				}
+				stepWrapper.SocketConnection = socketStep
+
+			case mflow.NODE_KIND_SOCKET_SEND:
+				socketNode, ok := socketSendNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				socketStep := &YamlStepSocketSend{
+					YamlStepCommon:           common,
+					SocketConnectionNodeName: socketNode.SocketConnectionNodeName,
+					Message:                  socketNode.Message,
+				}
+				switch socketNode.Encoding {
+				case mflow.SocketEncodingHex:
+					socketStep.Encoding = SocketEncodingHex
+				case mflow.SocketEncodingBase64:
+					socketStep.Encoding = SocketEncodingBase64
+				}
+				stepWrapper.SocketSend = socketStep
+
+			case mflow.NODE_KIND_SOCKET_RECEIVE:
+				socketNode, ok := socketReceiveNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				stepWrapper.SocketReceive = &YamlStepSocketReceive{
+					YamlStepCommon:           common,
+					SocketConnectionNodeName: socketNode.SocketConnectionNodeName,
+					Match:                    socketNode.Match,
+					TimeoutMs:                socketNode.TimeoutMs,
+					Count:                    socketNode.Count,
+					Assertions:               socketNode.Assertions,
+				}
+
+			case mflow.NODE_KIND_DB_QUERY:
+				queryNode, ok := dbQueryNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				queryStep := &YamlStepDbQuery{
+					YamlStepCommon: common,
+					Query:          queryNode.Query,
+					Params:         queryNode.Params,
+					TimeoutMs:      queryNode.TimeoutMs,
+					Assertions:     queryNode.Assertions,
+				}
+
+				// Use real credential name if available, otherwise generate placeholder
+				if queryNode.CredentialID != nil {
+					if cred, ok := credentialMap[*queryNode.CredentialID]; ok {
+						queryStep.Credential = cred.Name
+					} else {
+						queryStep.Credential = fmt.Sprintf("%s-credential", node.Name)
+					}
+				}
+				stepWrapper.DbQuery = queryStep
+
+			case mflow.NODE_KIND_MQ_PUBLISH:
+				publishNode, ok := mqPublishNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				publishStep := &YamlStepMqPublish{
+					YamlStepCommon: common,
+					Topic:          publishNode.Topic,
+					Key:            publishNode.Key,
+					Message:        publishNode.Message,
+				}
+				if publishNode.CredentialID != nil {
+					if cred, ok := credentialMap[*publishNode.CredentialID]; ok {
+						publishStep.Credential = cred.Name
+					} else {
+						publishStep.Credential = fmt.Sprintf("%s-credential", node.Name)
+					}
+				}
+				stepWrapper.MqPublish = publishStep
+
+			case mflow.NODE_KIND_MQ_CONSUME:
+				consumeNode, ok := mqConsumeNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				consumeStep := &YamlStepMqConsume{
+					YamlStepCommon: common,
+					Topic:          consumeNode.Topic,
+					Group:          consumeNode.Group,
+					Filter:         consumeNode.Filter,
+					TimeoutMs:      consumeNode.TimeoutMs,
+					Count:          consumeNode.Count,
+					Assertions:     consumeNode.Assertions,
+				}
+				if consumeNode.CredentialID != nil {
+					if cred, ok := credentialMap[*consumeNode.CredentialID]; ok {
+						consumeStep.Credential = cred.Name
+					} else {
+						consumeStep.Credential = fmt.Sprintf("%s-credential", node.Name)
+					}
+				}
+				stepWrapper.MqConsume = consumeStep
+
+			case mflow.NODE_KIND_EXEC:
+				execNode, ok := execNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				stepWrapper.Exec = &YamlStepExec{
+					YamlStepCommon: common,
+					Command:        execNode.Command,
+					Args:           execNode.Args,
+					WorkDir:        execNode.WorkDir,
+					TimeoutMs:      execNode.TimeoutMs,
+					ParseJSON:      execNode.ParseJSON,
+				}
+
 			case mflow.NODE_KIND_WAIT:
 				waitNode, ok := waitNodeMap[node.ID]
 				if !ok {
@@ -709,6 +999,58 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 				}
 				stepWrapper.RunSubFlow = runStep
 
+			case mflow.NODE_KIND_TRY:
+				stepWrapper.Try = &YamlStepTry{
+					YamlStepCommon: common,
+				}
+
+			case mflow.NODE_KIND_PARALLEL:
+				parallelNode, ok := parallelNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				parallelStep := &YamlStepParallel{
+					YamlStepCommon: common,
+					Concurrency:    int(parallelNode.Concurrency),
+				}
+				switch parallelNode.JoinMode {
+				case mflow.ParallelJoinAny:
+					parallelStep.Join = ParallelJoinAny
+				case mflow.ParallelJoinFirstSuccess:
+					parallelStep.Join = ParallelJoinFirstSuccess
+				}
+				stepWrapper.Parallel = parallelStep
+
+			case mflow.NODE_KIND_POLL:
+				pollNode, ok := pollNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				stepWrapper.Poll = &YamlStepPoll{
+					YamlStepCommon: common,
+					Until:          pollNode.Until,
+					IntervalMs:     pollNode.IntervalMs,
+					Backoff:        pollNode.Backoff,
+					MaxDurationMs:  pollNode.MaxDurationMs,
+					MaxAttempts:    pollNode.MaxAttempts,
+				}
+
+			case mflow.NODE_KIND_SWITCH:
+				switchNode, ok := switchNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				switchStep := &YamlStepSwitch{
+					YamlStepCommon: common,
+				}
+				for _, c := range switchNode.Cases {
+					switchStep.Cases = append(switchStep.Cases, YamlSwitchCase{
+						Name: c.Name,
+						When: c.Expression,
+					})
+				}
+				stepWrapper.Switch = switchStep
+
 			case mflow.NODE_KIND_MANUAL_START:
 				if node.ID == startNodeID {
 					stepWrapper.ManualStart = &common
@@ -716,9 +1058,49 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 					continue
 				}
 
-			case mflow.NODE_KIND_WEBHOOK_TRIGGER:
-				// Not yet implemented
+			case mflow.NODE_KIND_TEARDOWN:
+				// Implied by the flow's teardown section
 				continue
+
+			case mflow.NODE_KIND_WEBHOOK_TRIGGER:
+				webhookNode, ok := webhookNodeMap[node.ID]
+				if !ok {
+					continue
+				}
+				stepWrapper.Webhook = &YamlStepWebhook{
+					YamlStepCommon:  common,
+					Path:            webhookNode.Path,
+					Method:          webhookNode.Method,
+					Secret:          webhookNode.Secret,
+					SignatureHeader: webhookNode.SignatureHeader,
+				}
+
+			case mflow.NODE_KIND_GRAPHQL_SUBSCRIPTION:
+				subNode, ok := graphqlSubscriptionNodeMap[node.ID]
+				if !ok || subNode.GraphQLID == nil {
+					continue
+				}
+				gqlReq, ok := graphqlMap[*subNode.GraphQLID]
+				if !ok {
+					continue
+				}
+
+				subStep := &YamlStepGraphQLSubscription{
+					YamlStepGraphQL:   YamlStepGraphQL{YamlStepCommon: common},
+					ConnectionPayload: subNode.ConnectionPayload,
+					MaxEvents:         subNode.MaxEvents,
+					TimeoutMs:         subNode.TimeoutMs,
+				}
+				if gqlName, exists := graphqlIDToRequestName[gqlReq.ID]; exists {
+					subStep.UseRequest = gqlName
+				} else {
+					subStep.URL = gqlReq.Url
+					subStep.Query = exportGraphQLQuery(gqlReq)
+					subStep.Variables = gqlReq.Variables
+					subStep.OperationName = gqlReq.OperationName
+					subStep.Headers = buildGraphQLHeaderMapOrSlice(graphqlHeadersMap[gqlReq.ID])
+				}
+				stepWrapper.GraphQLSubscription = subStep
 			}
 
 			// Add to flow
@@ -727,9 +1109,14 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 			isValid := stepWrapper.Request != nil || stepWrapper.GraphQL != nil || stepWrapper.If != nil || stepWrapper.For != nil ||
 				stepWrapper.ForEach != nil || stepWrapper.JS != nil || stepWrapper.AI != nil ||
 				stepWrapper.AIProvider != nil || stepWrapper.AIMemory != nil || stepWrapper.WsConnection != nil ||
-				stepWrapper.WsSend != nil || stepWrapper.Wait != nil || stepWrapper.ManualStart != nil ||
-				stepWrapper.SubFlowTrigger != nil || stepWrapper.SubFlowReturn != nil || stepWrapper.RunSubFlow != nil
-			if isValid {
+				stepWrapper.WsSend != nil || stepWrapper.WsExpect != nil || stepWrapper.SocketConnection != nil ||
+				stepWrapper.SocketSend != nil || stepWrapper.SocketReceive != nil || stepWrapper.DbQuery != nil || stepWrapper.MqPublish != nil || stepWrapper.MqConsume != nil || stepWrapper.Exec != nil || stepWrapper.Wait != nil || stepWrapper.ManualStart != nil ||
+				stepWrapper.SubFlowTrigger != nil || stepWrapper.SubFlowReturn != nil || stepWrapper.RunSubFlow != nil ||
+				stepWrapper.Try != nil || stepWrapper.Parallel != nil || stepWrapper.Poll != nil ||
+				stepWrapper.Switch != nil || stepWrapper.Webhook != nil || stepWrapper.GraphQLSubscription != nil
+			if isValid && teardownSet[node.ID] {
+				flowYaml.Teardown = append(flowYaml.Teardown, stepWrapper)
+			} else if isValid {
 				flowYaml.Steps = append(flowYaml.Steps, stepWrapper)
 			}
 		}
@@ -753,6 +1140,12 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 		case mcredential.CREDENTIAL_KIND_GEMINI:
 			yamlCred.Type = CredentialTypeGemini
 			yamlCred.APIKey = fmt.Sprintf(EnvVarTemplateAPIKey, envVarName)
+		case mcredential.CREDENTIAL_KIND_DATABASE:
+			yamlCred.Type = CredentialTypeDatabase
+			yamlCred.ConnectionString = fmt.Sprintf(EnvVarTemplateConnectionString, envVarName)
+		case mcredential.CREDENTIAL_KIND_MESSAGE_BROKER:
+			yamlCred.Type = CredentialTypeBroker
+			yamlCred.URL = fmt.Sprintf(EnvVarTemplateURL, envVarName)
 		default:
 			yamlCred.Type = CredentialTypeOpenAI
 			yamlCred.Token = fmt.Sprintf(EnvVarTemplateToken, envVarName)
@@ -799,6 +1192,89 @@ func MarshalSimplifiedYAML(data *ioworkspace.WorkspaceBundle) ([]byte, error) {
 	return yaml.Marshal(yamlFormat)
 }
 
+// teardownSection returns the teardown nodes of a flow together with every
+// node reachable from them.
+func teardownSection(nodes []mflow.Node, edges []mflow.Edge) map[idwrap.IDWrap]bool {
+	next := flowgraph.BuildOutgoingAdjacency(edges)
+	section := make(map[idwrap.IDWrap]bool)
+	var stack []idwrap.IDWrap
+	for _, n := range nodes {
+		if n.NodeKind == mflow.NODE_KIND_TEARDOWN {
+			stack = append(stack, n.ID)
+		}
+	}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if section[id] {
+			continue
+		}
+		section[id] = true
+		stack = append(stack, next[id]...)
+	}
+	return section
+}
+
+// exportGraphQLQuery returns the "#file:" reference for queries loaded from a
+// .graphql file, and the inline query otherwise.
+func exportGraphQLQuery(gql mgraphql.GraphQL) string {
+	if gql.QueryFile != "" {
+		return graphQLFilePrefix + gql.QueryFile
+	}
+	return gql.Query
+}
+
+func exportGraphQLBatch(batch []mgraphql.GraphQLOperation) []YamlGraphQLOperation {
+	if len(batch) == 0 {
+		return nil
+	}
+	ops := make([]YamlGraphQLOperation, len(batch))
+	for i, op := range batch {
+		ops[i] = YamlGraphQLOperation{
+			Query:         op.Query,
+			Variables:     op.Variables,
+			OperationName: op.OperationName,
+		}
+	}
+	return ops
+}
+
+func exportGraphQLUploads(uploads []mgraphql.GraphQLUpload) map[string]string {
+	if len(uploads) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(uploads))
+	for _, u := range uploads {
+		result[u.Path] = u.File
+	}
+	return result
+}
+
+// GraphQLQueryFiles returns the content of every "#file:" query in the bundle
+// keyed by its path, so callers can write the files next to the exported YAML.
+func GraphQLQueryFiles(bundle *ioworkspace.WorkspaceBundle) map[string]string {
+	files := make(map[string]string)
+	for _, gql := range bundle.GraphQLRequests {
+		if gql.QueryFile != "" {
+			files[gql.QueryFile] = gql.Query
+		}
+	}
+	return files
+}
+
+// InlineGraphQLQueries drops the "#file:" references of the bundle's GraphQL
+// requests, so the exported document carries every query inline and stands
+// alone. The requests slice is replaced rather than modified, leaving bundles
+// that share it untouched.
+func InlineGraphQLQueries(bundle *ioworkspace.WorkspaceBundle) {
+	inlined := make([]mgraphql.GraphQL, len(bundle.GraphQLRequests))
+	for i, gql := range bundle.GraphQLRequests {
+		gql.QueryFile = ""
+		inlined[i] = gql
+	}
+	bundle.GraphQLRequests = inlined
+}
+
 func buildGraphQLHeaderMapOrSlice(headers []mgraphql.GraphQLHeader) HeaderMapOrSlice {
 	if len(headers) == 0 {
 		return nil
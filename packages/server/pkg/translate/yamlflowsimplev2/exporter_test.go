@@ -1,6 +1,8 @@
 package yamlflowsimplev2
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -10,7 +12,9 @@ import (
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/ioworkspace"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mcredential"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mflow"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mgraphql"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mhttp"
+	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mwebsocket"
 	"github.com/the-dev-tools/dev-tools/packages/server/pkg/model/mworkspace"
 )
 
@@ -1302,3 +1306,1154 @@ func TestMarshalSimplifiedYAML_AIRoundTrip(t *testing.T) {
 
 	t.Log("Round-trip successful!")
 }
+
+func TestMarshalSimplifiedYAML_ErrorHandlingRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Error Handling Test
+flows:
+  - name: Cleanup Flow
+    steps:
+      - manual_start:
+          name: Start
+      - try:
+          name: Setup
+          depends_on: Start
+      - js:
+          name: Create
+          code: "return 1"
+          depends_on: Setup.try
+      - js:
+          name: Cleanup
+          code: "return Setup.error.node"
+          depends_on: Setup.catch
+      - js:
+          name: Verify
+          code: "return 2"
+          depends_on: Setup.then
+      - js:
+          name: Fallback
+          code: "return 3"
+          depends_on: Verify.on_error
+`
+
+	workspaceID := idwrap.NewNow()
+	opts := GetDefaultOptions(workspaceID)
+
+	edgeHandles := func(data *ioworkspace.WorkspaceBundle) map[string]mflow.EdgeHandle {
+		names := make(map[idwrap.IDWrap]string, len(data.FlowNodes))
+		for _, n := range data.FlowNodes {
+			names[n.ID] = n.Name
+		}
+		handles := make(map[string]mflow.EdgeHandle, len(data.FlowEdges))
+		for _, e := range data.FlowEdges {
+			handles[names[e.SourceID]+"->"+names[e.TargetID]] = e.SourceHandler
+		}
+		return handles
+	}
+	want := map[string]mflow.EdgeHandle{
+		"Start->Setup":     mflow.HandleUnspecified,
+		"Setup->Create":    mflow.HandleLoop,
+		"Setup->Cleanup":   mflow.HandleError,
+		"Setup->Verify":    mflow.HandleThen,
+		"Verify->Fallback": mflow.HandleError,
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	require.Equal(t, want, edgeHandles(importedData))
+
+	var tryNodes int
+	for _, n := range importedData.FlowNodes {
+		if n.NodeKind == mflow.NODE_KIND_TRY {
+			tryNodes++
+			require.Equal(t, "Setup", n.Name)
+		}
+	}
+	require.Equal(t, 1, tryNodes)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "Setup.try")
+	require.Contains(t, string(exportedYAML), "Setup.catch")
+	require.Contains(t, string(exportedYAML), "Verify.on_error")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	require.Equal(t, want, edgeHandles(reImportedData))
+}
+
+func TestTryStepExplicitTargets(t *testing.T) {
+	sourceYAML := `
+workspace_name: Try Targets
+flows:
+  - name: Flow
+    steps:
+      - try:
+          name: Setup
+          body: Create
+          catch: Cleanup
+      - js:
+          name: Create
+          code: "return 1"
+      - js:
+          name: Cleanup
+          code: "return 2"
+`
+	data, err := ConvertSimplifiedYAML([]byte(sourceYAML), GetDefaultOptions(idwrap.NewNow()))
+	require.NoError(t, err)
+
+	handles := make(map[mflow.EdgeHandle]int)
+	for _, e := range data.FlowEdges {
+		handles[e.SourceHandler]++
+	}
+	require.Equal(t, 1, handles[mflow.HandleLoop])
+	require.Equal(t, 1, handles[mflow.HandleError])
+}
+
+func TestMarshalSimplifiedYAML_ParallelRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Parallel Test
+flows:
+  - name: Fan Out
+    steps:
+      - manual_start:
+          name: Start
+      - parallel:
+          name: Mirrors
+          depends_on: Start
+          join: first_success
+          concurrency: 2
+          branches: [EU]
+      - js:
+          name: EU
+          code: "return 1"
+      - js:
+          name: US
+          code: "return 2"
+          depends_on: Mirrors.branch
+      - for_each:
+          name: Each
+          items: "[1, 2, 3]"
+          concurrency: 10
+          depends_on: Mirrors.then
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.FlowParallelNodes, 1)
+		require.Equal(t, mflow.ParallelJoinFirstSuccess, data.FlowParallelNodes[0].JoinMode)
+		require.Equal(t, int32(2), data.FlowParallelNodes[0].Concurrency)
+		require.Len(t, data.FlowForEachNodes, 1)
+		require.Equal(t, int32(10), data.FlowForEachNodes[0].Concurrency)
+
+		var branches int
+		for _, e := range data.FlowEdges {
+			if e.SourceID == data.FlowParallelNodes[0].FlowNodeID && e.SourceHandler == mflow.HandleLoop {
+				branches++
+			}
+		}
+		require.Equal(t, 2, branches)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "Mirrors.branch")
+	require.Contains(t, string(exportedYAML), "join: first_success")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+}
+
+func TestParallelStepRejectsUnknownJoin(t *testing.T) {
+	sourceYAML := `
+workspace_name: Parallel Test
+flows:
+  - name: Flow
+    steps:
+      - parallel:
+          name: Fan
+          join: most
+`
+	_, err := ConvertSimplifiedYAML([]byte(sourceYAML), GetDefaultOptions(idwrap.NewNow()))
+	require.ErrorContains(t, err, "invalid join value")
+}
+
+func TestMarshalSimplifiedYAML_PollRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Poll Test
+flows:
+  - name: Job
+    steps:
+      - manual_start:
+          name: Start
+      - poll:
+          name: WaitForJob
+          depends_on: Start
+          until: CheckJob.response.body.status == "done"
+          interval_ms: 500
+          backoff: 1.5
+          max_duration_ms: 120000
+          max_attempts: 20
+      - js:
+          name: CheckJob
+          code: "return {status: 'done'}"
+          depends_on: WaitForJob.loop
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+	want := mflow.NodePoll{
+		Until:         `CheckJob.response.body.status == "done"`,
+		IntervalMs:    500,
+		Backoff:       1.5,
+		MaxDurationMs: 120000,
+		MaxAttempts:   20,
+	}
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.FlowPollNodes, 1)
+		got := data.FlowPollNodes[0]
+		got.FlowNodeID = idwrap.IDWrap{}
+		require.Equal(t, want, got)
+
+		var loops int
+		for _, e := range data.FlowEdges {
+			if e.SourceID == data.FlowPollNodes[0].FlowNodeID && e.SourceHandler == mflow.HandleLoop {
+				loops++
+			}
+		}
+		require.Equal(t, 1, loops)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "WaitForJob.loop")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+}
+
+func TestMarshalSimplifiedYAML_SwitchRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Switch Test
+flows:
+  - name: Route
+    steps:
+      - manual_start:
+          name: Start
+      - js:
+          name: Fetch
+          code: "return {status: 404}"
+          depends_on: Start
+      - switch:
+          name: ByStatus
+          depends_on: Fetch
+          cases:
+            - name: ok
+              when: Fetch.status == 200
+              then: Done
+            - when: Fetch.status == 404
+          default: Failed
+      - js:
+          name: Done
+          code: "return {}"
+      - js:
+          name: Missing
+          code: "return {}"
+          depends_on: ByStatus.case_1
+      - js:
+          name: Failed
+          code: "return {}"
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+	want := []mflow.SwitchCase{
+		{Name: "ok", Expression: "Fetch.status == 200"},
+		{Expression: "Fetch.status == 404"},
+	}
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.FlowSwitchNodes, 1)
+		require.Equal(t, want, data.FlowSwitchNodes[0].Cases)
+
+		names := make(map[idwrap.IDWrap]string)
+		for _, n := range data.FlowNodes {
+			names[n.ID] = n.Name
+		}
+		handles := make(map[string]mflow.EdgeHandle)
+		for _, e := range data.FlowEdges {
+			if e.SourceID == data.FlowSwitchNodes[0].FlowNodeID {
+				handles[names[e.TargetID]] = e.SourceHandler
+			}
+		}
+		require.Equal(t, map[string]mflow.EdgeHandle{
+			"Done":    mflow.HandleCase(0),
+			"Missing": mflow.HandleCase(1),
+			"Failed":  mflow.HandleDefault,
+		}, handles)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "ByStatus.ok")
+	require.Contains(t, string(exportedYAML), "ByStatus.case_1")
+	require.Contains(t, string(exportedYAML), "ByStatus.default")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+}
+
+func TestConvertSimplifiedYAML_SwitchRejectsUnknownCase(t *testing.T) {
+	sourceYAML := `
+workspace_name: Switch Test
+flows:
+  - name: Route
+    steps:
+      - manual_start:
+          name: Start
+      - switch:
+          name: ByStatus
+          depends_on: Start
+          cases:
+            - name: ok
+              when: "true"
+      - js:
+          name: Done
+          code: "return {}"
+          depends_on: ByStatus.missing
+`
+	_, err := ConvertSimplifiedYAML([]byte(sourceYAML), GetDefaultOptions(idwrap.NewNow()))
+	require.ErrorContains(t, err, "unknown case")
+}
+
+func TestMarshalSimplifiedYAML_SetupTeardownRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Teardown Test
+flows:
+  - name: Users
+    setup:
+      - js:
+          name: CreateUser
+          code: "return {id: 1}"
+    steps:
+      - js:
+          name: Check
+          code: "return {}"
+    teardown:
+      - js:
+          name: DeleteUser
+          code: "return {}"
+      - js:
+          name: Report
+          code: "return {}"
+          depends_on: DeleteUser
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+
+	edgesOf := func(data *ioworkspace.WorkspaceBundle) []string {
+		t.Helper()
+		names := make(map[idwrap.IDWrap]string)
+		teardownNodes := 0
+		for _, n := range data.FlowNodes {
+			names[n.ID] = n.Name
+			if n.NodeKind == mflow.NODE_KIND_TEARDOWN {
+				teardownNodes++
+			}
+		}
+		require.Equal(t, 1, teardownNodes)
+		var edges []string
+		for _, e := range data.FlowEdges {
+			edges = append(edges, names[e.SourceID]+" -> "+names[e.TargetID])
+		}
+		sort.Strings(edges)
+		return edges
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	want := []string{
+		"CreateUser -> Check",
+		"DeleteUser -> Report",
+		"Start -> CreateUser",
+		"Teardown -> DeleteUser",
+	}
+	require.Equal(t, want, edgesOf(importedData))
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+
+	exported, err := parseYAMLData(exportedYAML)
+	require.NoError(t, err)
+	require.Len(t, exported.Flows, 1)
+	var teardownNames []string
+	for _, step := range exported.Flows[0].Teardown {
+		teardownNames = append(teardownNames, getStepCommon(step).Name)
+	}
+	require.Equal(t, []string{"DeleteUser", "Report"}, teardownNames)
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	require.Equal(t, want, edgesOf(reImportedData))
+}
+
+func TestConvertSimplifiedYAML_TeardownSectionIsolation(t *testing.T) {
+	tests := []struct {
+		name string
+		flow string
+		want string
+	}{
+		{
+			name: "teardown depends on steps",
+			flow: `
+    steps:
+      - js:
+          name: Check
+          code: "return {}"
+    teardown:
+      - js:
+          name: Cleanup
+          code: "return {}"
+          depends_on: Check`,
+			want: "cannot depend on step 'Check' in steps",
+		},
+		{
+			name: "steps depend on teardown",
+			flow: `
+    steps:
+      - js:
+          name: Check
+          code: "return {}"
+          depends_on: Cleanup
+    teardown:
+      - js:
+          name: Cleanup
+          code: "return {}"`,
+			want: "cannot depend on step 'Cleanup' in teardown",
+		},
+		{
+			name: "entry step in setup",
+			flow: `
+    setup:
+      - manual_start:
+          name: Begin`,
+			want: "setup cannot contain entry step 'Begin'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourceYAML := "workspace_name: Teardown Test\nflows:\n  - name: Users" + tt.flow + "\n"
+			_, err := ConvertSimplifiedYAML([]byte(sourceYAML), GetDefaultOptions(idwrap.NewNow()))
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestMarshalSimplifiedYAML_WebhookRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Webhook Test
+flows:
+  - name: Receive
+    steps:
+      - webhook:
+          name: Hook
+          path: /orders/created/
+          method: post
+          secret: s3cret
+      - js:
+          name: Handle
+          code: "return {}"
+          depends_on: Hook
+      - sub_flow_return:
+          name: Reply
+          depends_on: Handle
+          outputs:
+            - name: status
+              expression: "202"
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.FlowWebhookTriggerNodes, 1)
+		hook := data.FlowWebhookTriggerNodes[0]
+		require.Equal(t, "/orders/created", hook.Path)
+		require.Equal(t, "POST", hook.Method)
+		require.Equal(t, "s3cret", hook.Secret)
+
+		var hookNode *mflow.Node
+		for i := range data.FlowNodes {
+			if data.FlowNodes[i].NodeKind == mflow.NODE_KIND_WEBHOOK_TRIGGER {
+				hookNode = &data.FlowNodes[i]
+			}
+			require.NotEqual(t, mflow.NODE_KIND_MANUAL_START, data.FlowNodes[i].NodeKind)
+		}
+		require.NotNil(t, hookNode)
+		require.Equal(t, hookNode.ID, hook.FlowNodeID)
+
+		var fromHook int
+		for _, e := range data.FlowEdges {
+			if e.SourceID == hook.FlowNodeID {
+				fromHook++
+			}
+		}
+		require.Equal(t, 1, fromHook)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "webhook:")
+	require.Contains(t, string(exportedYAML), "path: /orders/created")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+}
+
+func TestMarshalSimplifiedYAML_GraphQLSubscriptionRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Subscription Test
+flows:
+  - name: Listen
+    steps:
+      - graphql_subscription:
+          name: Ticks
+          url: "{{ baseUrl }}/graphql"
+          query: "subscription { tick }"
+          connection_payload: '{"authToken": "{{ token }}"}'
+          max_events: 5
+          timeout_ms: 30000
+      - js:
+          name: Record
+          code: "return {}"
+          depends_on: Ticks.next
+      - js:
+          name: Summary
+          code: "return {}"
+          depends_on: Ticks
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.FlowGraphQLSubscriptionNodes, 1)
+		sub := data.FlowGraphQLSubscriptionNodes[0]
+		require.Equal(t, `{"authToken": "{{ token }}"}`, sub.ConnectionPayload)
+		require.Equal(t, int32(5), sub.MaxEvents)
+		require.Equal(t, int64(30000), sub.TimeoutMs)
+		require.NotNil(t, sub.GraphQLID)
+
+		require.Len(t, data.GraphQLRequests, 1)
+		require.Equal(t, *sub.GraphQLID, data.GraphQLRequests[0].ID)
+		require.Equal(t, "subscription { tick }", data.GraphQLRequests[0].Query)
+
+		handles := make(map[mflow.EdgeHandle]int)
+		for _, e := range data.FlowEdges {
+			if e.SourceID == sub.FlowNodeID {
+				handles[e.SourceHandler]++
+			}
+		}
+		require.Equal(t, 1, handles[mflow.HandleWsMessage])
+		require.Equal(t, 1, handles[mflow.HandleUnspecified])
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "graphql_subscription:")
+	require.Contains(t, string(exportedYAML), "Ticks.next")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+}
+
+func TestMarshalSimplifiedYAML_WsExpectRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: WebSocket Test
+flows:
+  - name: Chat
+    steps:
+      - ws_connection:
+          name: WS
+          url: "{{ wsUrl }}"
+          subprotocols: [v2.chat, v1.chat]
+      - ws_send:
+          name: Hello
+          ws_connection_node_name: WS
+          message: "AAEC"
+          binary: true
+          depends_on: WS
+      - ws_expect:
+          name: Reply
+          ws_connection_node_name: WS
+          message: '{"id": 7}'
+          match: frame.json.id == 7
+          timeout_ms: 2000
+          count: 1
+          assertions:
+            - frame.json.result == "ok"
+          ping: true
+          depends_on: Hello
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.WebSockets, 1)
+		require.Equal(t, []string{"v2.chat", "v1.chat"}, data.WebSockets[0].Subprotocols)
+
+		require.Len(t, data.FlowWsSendNodes, 1)
+		require.True(t, data.FlowWsSendNodes[0].Binary)
+
+		require.Len(t, data.FlowWsExpectNodes, 1)
+		expect := data.FlowWsExpectNodes[0]
+		require.Equal(t, "WS", expect.WsConnectionNodeName)
+		require.Equal(t, `{"id": 7}`, expect.Message)
+		require.Equal(t, "frame.json.id == 7", expect.Match)
+		require.Equal(t, int64(2000), expect.TimeoutMs)
+		require.Equal(t, int32(1), expect.Count)
+		require.Equal(t, []string{`frame.json.result == "ok"`}, expect.Assertions)
+		require.True(t, expect.Ping)
+		require.False(t, expect.ExpectClose)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "ws_expect:")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+}
+
+func TestMarshalSimplifiedYAML_GraphQLQueryFileRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Query File Test
+graphql_requests:
+  - name: GetUser
+    url: "{{ baseUrl }}/graphql"
+    query: "#file:queries/user.graphql"
+    operation_name: GetUser
+    variables: '{"id": "1"}'
+flows:
+  - name: Users
+    steps:
+      - graphql:
+          name: Fetch
+          use_request: GetUser
+      - graphql:
+          name: Inline
+          url: "{{ baseUrl }}/graphql"
+          query: "query A { a } query B { b }"
+          operation_name: B
+          depends_on: Fetch
+`
+	files := map[string][]byte{
+		"queries/user.graphql": []byte("query GetUser($id: ID!) { user(id: $id) { ...UserFields } }\nfragment UserFields on User { id name }\n"),
+	}
+	opts := GetDefaultOptions(idwrap.NewNow())
+	opts.ReadFile = func(path string) ([]byte, error) {
+		data, ok := files[path]
+		if !ok {
+			return nil, fmt.Errorf("no such file: %s", path)
+		}
+		return data, nil
+	}
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.GraphQLRequests, 2)
+		byName := make(map[string]mgraphql.GraphQL)
+		for _, gql := range data.GraphQLRequests {
+			byName[gql.Name] = gql
+		}
+		fetch := byName["Fetch"]
+		require.Equal(t, string(files["queries/user.graphql"]), fetch.Query)
+		require.Equal(t, "queries/user.graphql", fetch.QueryFile)
+		require.Equal(t, "GetUser", fetch.OperationName)
+
+		inline := byName["Inline"]
+		require.Equal(t, "query A { a } query B { b }", inline.Query)
+		require.Empty(t, inline.QueryFile)
+		require.Equal(t, "B", inline.OperationName)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "#file:queries/user.graphql")
+	require.Contains(t, string(exportedYAML), "operation_name: GetUser")
+	require.NotContains(t, string(exportedYAML), "fragment UserFields")
+	require.Equal(t, map[string]string{"queries/user.graphql": string(files["queries/user.graphql"])}, GraphQLQueryFiles(importedData))
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+
+	// Inlined, the document no longer needs the query file.
+	InlineGraphQLQueries(importedData)
+	inlinedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.NotContains(t, string(inlinedYAML), "#file:")
+	require.Contains(t, string(inlinedYAML), "fragment UserFields")
+	_, err = ConvertSimplifiedYAML(inlinedYAML, GetDefaultOptions(idwrap.NewNow()))
+	require.NoError(t, err)
+}
+
+func TestMarshalSimplifiedYAML_GraphQLTransportRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Transport Test
+graphql_requests:
+  - name: Upload
+    url: "{{ baseUrl }}/graphql"
+    query: "mutation Upload($file: Upload!) { upload(file: $file) }"
+    uploads:
+      variables.file: "{{ avatarPath }}"
+flows:
+  - name: Batching
+    steps:
+      - graphql:
+          name: Fetch
+          url: "{{ baseUrl }}/graphql"
+          query: "query A { a }"
+          persisted_query: true
+          batch:
+            - query: "query B($id: ID!) { b(id: $id) }"
+              variables: '{"id": "1"}'
+              operation_name: B
+      - graphql:
+          name: Send
+          use_request: Upload
+          persisted_query: true
+          uploads:
+            variables.file: ./override.png
+          depends_on: Fetch
+`
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.GraphQLRequests, 2)
+		byName := make(map[string]mgraphql.GraphQL)
+		for _, gql := range data.GraphQLRequests {
+			byName[gql.Name] = gql
+		}
+		fetch := byName["Fetch"]
+		require.True(t, fetch.PersistedQuery)
+		require.Equal(t, []mgraphql.GraphQLOperation{{
+			Query:         "query B($id: ID!) { b(id: $id) }",
+			Variables:     `{"id": "1"}`,
+			OperationName: "B",
+		}}, fetch.Batch)
+		require.Empty(t, fetch.Uploads)
+
+		send := byName["Send"]
+		require.True(t, send.PersistedQuery)
+		require.Empty(t, send.Batch)
+		require.Equal(t, []mgraphql.GraphQLUpload{{Path: "variables.file", File: "./override.png"}}, send.Uploads)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), GetDefaultOptions(idwrap.NewNow()))
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "persisted_query: true")
+	require.Contains(t, string(exportedYAML), "operation_name: B")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, GetDefaultOptions(idwrap.NewNow()))
+	require.NoError(t, err)
+	check(reImportedData)
+}
+
+func TestConvertSimplifiedYAML_GraphQLQueryFileWithoutReader(t *testing.T) {
+	sourceYAML := `
+workspace_name: Query File Test
+flows:
+  - name: Users
+    steps:
+      - graphql:
+          name: Fetch
+          url: "http://localhost/graphql"
+          query: "#file:user.graphql"
+`
+	_, err := ConvertSimplifiedYAML([]byte(sourceYAML), GetDefaultOptions(idwrap.NewNow()))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "user.graphql")
+}
+
+func TestMarshalSimplifiedYAML_WsProtocolRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: WebSocket Protocols
+flows:
+  - name: Notify
+    steps:
+      - ws_connection:
+          name: IO
+          url: "{{ baseUrl }}/notifications"
+          protocol: socketio
+          connect_payload: '{"token": "{{ token }}"}'
+      - ws_send:
+          name: Subscribe
+          ws_connection_node_name: IO
+          event: subscribe
+          message: '{"room": "orders"}'
+          ack: true
+          depends_on: IO
+      - ws_connection:
+          name: Broker
+          url: "{{ brokerUrl }}"
+          protocol: stomp
+          connect_payload: '{"login": "guest", "passcode": "guest"}'
+          subscriptions: [/topic/orders]
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.WebSockets, 2)
+		protocols := map[string]mwebsocket.WebSocket{}
+		for _, ws := range data.WebSockets {
+			protocols[ws.Name] = ws
+		}
+		require.Equal(t, mwebsocket.ProtocolSocketIO, protocols["IO"].Protocol)
+		require.Equal(t, `{"token": "{{ token }}"}`, protocols["IO"].ConnectPayload)
+		require.Equal(t, mwebsocket.ProtocolStomp, protocols["Broker"].Protocol)
+		require.Equal(t, []string{"/topic/orders"}, protocols["Broker"].Subscriptions)
+
+		require.Len(t, data.FlowWsSendNodes, 1)
+		require.Equal(t, "subscribe", data.FlowWsSendNodes[0].Event)
+		require.True(t, data.FlowWsSendNodes[0].Ack)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "protocol: socketio")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+
+	_, err = ConvertSimplifiedYAML([]byte(strings.Replace(sourceYAML, "protocol: socketio", "protocol: mqtt", 1)), opts)
+	require.ErrorContains(t, err, "invalid protocol value 'mqtt'")
+}
+
+func TestMarshalSimplifiedYAML_SocketRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Socket Test
+flows:
+  - name: Echo
+    steps:
+      - socket_connection:
+          name: Sock
+          address: "{{ host }}:7000"
+          tls: true
+          framing: length
+          length_bytes: 2
+          timeout_ms: 3000
+      - socket_send:
+          name: Hello
+          socket_connection_node_name: Sock
+          message: "de ad be ef"
+          encoding: hex
+          depends_on: Sock
+      - socket_receive:
+          name: Reply
+          socket_connection_node_name: Sock
+          match: message.hex == "cafe"
+          count: 2
+          assertions:
+            - message.size == 2
+          depends_on: Hello
+      - js:
+          name: Log
+          code: "export default function(ctx) {}"
+          depends_on: Sock.message
+      - socket_connection:
+          name: Metrics
+          network: udp
+          address: localhost:8125
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.FlowSocketConnectionNodes, 2)
+		conns := map[mflow.SocketNetwork]mflow.NodeSocketConnection{}
+		for _, n := range data.FlowSocketConnectionNodes {
+			conns[n.Network] = n
+		}
+		tcp := conns[mflow.SocketNetworkTCP]
+		require.Equal(t, "{{ host }}:7000", tcp.Address)
+		require.True(t, tcp.TLS)
+		require.Equal(t, mflow.SocketFramingLength, tcp.Framing)
+		require.Equal(t, int32(2), tcp.LengthBytes)
+		require.Equal(t, int64(3000), tcp.TimeoutMs)
+		require.Equal(t, "localhost:8125", conns[mflow.SocketNetworkUDP].Address)
+
+		require.Len(t, data.FlowSocketSendNodes, 1)
+		require.Equal(t, mflow.SocketEncodingHex, data.FlowSocketSendNodes[0].Encoding)
+
+		require.Len(t, data.FlowSocketReceiveNodes, 1)
+		receive := data.FlowSocketReceiveNodes[0]
+		require.Equal(t, "Sock", receive.SocketConnectionNodeName)
+		require.Equal(t, `message.hex == "cafe"`, receive.Match)
+		require.Equal(t, int32(2), receive.Count)
+		require.Equal(t, []string{"message.size == 2"}, receive.Assertions)
+
+		var messageEdges int
+		for _, e := range data.FlowEdges {
+			if e.SourceHandler == mflow.HandleWsMessage {
+				messageEdges++
+			}
+		}
+		require.Equal(t, 1, messageEdges)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "socket_receive:")
+	require.Contains(t, string(exportedYAML), "Sock.message")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+
+	_, err = ConvertSimplifiedYAML([]byte(strings.Replace(sourceYAML, "encoding: hex", "encoding: utf16", 1)), opts)
+	require.ErrorContains(t, err, "invalid encoding value 'utf16'")
+}
+
+func TestMarshalSimplifiedYAML_DbQueryRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: DB Test
+flows:
+  - name: Signup
+    steps:
+      - db_query:
+          name: Seed
+          credential: AppDB
+          query: INSERT INTO invites (code) VALUES (?)
+          params:
+            - '"WELCOME"'
+      - db_query:
+          name: Check
+          credential: AppDB
+          query: SELECT id FROM users WHERE email = ?
+          params:
+            - user.email
+          timeout_ms: 2000
+          assertions:
+            - count == 1
+          depends_on: Seed
+`
+	workspaceID := idwrap.NewNow()
+	credentialID := idwrap.NewNow()
+	opts := GetDefaultOptions(workspaceID)
+	opts.CredentialMap = map[string]idwrap.IDWrap{"AppDB": credentialID}
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.FlowDbQueryNodes, 2)
+		queries := map[string]mflow.NodeDbQuery{}
+		for _, n := range data.FlowDbQueryNodes {
+			queries[n.Query] = n
+		}
+		seed := queries["INSERT INTO invites (code) VALUES (?)"]
+		require.NotNil(t, seed.CredentialID)
+		require.Equal(t, credentialID, *seed.CredentialID)
+		require.Equal(t, []string{`"WELCOME"`}, seed.Params)
+
+		verify := queries["SELECT id FROM users WHERE email = ?"]
+		require.Equal(t, []string{"user.email"}, verify.Params)
+		require.Equal(t, int64(2000), verify.TimeoutMs)
+		require.Equal(t, []string{"count == 1"}, verify.Assertions)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	importedData.Credentials = []mcredential.Credential{
+		{ID: credentialID, WorkspaceID: workspaceID, Name: "AppDB", Kind: mcredential.CREDENTIAL_KIND_DATABASE},
+	}
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "db_query:")
+	require.Contains(t, string(exportedYAML), "type: database")
+	require.Contains(t, string(exportedYAML), "{{ #env:APPDB_CONNECTION_STRING }}")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+
+	_, err = ConvertSimplifiedYAML([]byte(strings.Replace(sourceYAML, "credential: AppDB\n          query: SELECT", "query: SELECT", 1)), opts)
+	require.ErrorContains(t, err, "missing required credential")
+}
+
+func TestMarshalSimplifiedYAML_MessageQueueRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: MQ Test
+flows:
+  - name: Orders
+    steps:
+      - mq_consume:
+          name: Created
+          credential: Events
+          topic: orders.created
+          group: e2e
+          filter: message.json.customer == "alice"
+          timeout_ms: 2000
+          count: 2
+          assertions:
+            - count == 2
+      - js:
+          name: Each
+          code: "return Created.index"
+          depends_on: Created.message
+      - mq_publish:
+          name: Ack
+          credential: Events
+          topic: orders.acknowledged
+          key: "{{ Created.message.key }}"
+          message: '{"orderId": {{ Created.message.json.orderId }}}'
+          depends_on: Created
+`
+	workspaceID := idwrap.NewNow()
+	credentialID := idwrap.NewNow()
+	opts := GetDefaultOptions(workspaceID)
+	opts.CredentialMap = map[string]idwrap.IDWrap{"Events": credentialID}
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.FlowMqConsumeNodes, 1)
+		consume := data.FlowMqConsumeNodes[0]
+		require.NotNil(t, consume.CredentialID)
+		require.Equal(t, credentialID, *consume.CredentialID)
+		require.Equal(t, "orders.created", consume.Topic)
+		require.Equal(t, "e2e", consume.Group)
+		require.Equal(t, `message.json.customer == "alice"`, consume.Filter)
+		require.Equal(t, int64(2000), consume.TimeoutMs)
+		require.Equal(t, int32(2), consume.Count)
+		require.Equal(t, []string{"count == 2"}, consume.Assertions)
+
+		require.Len(t, data.FlowMqPublishNodes, 1)
+		publish := data.FlowMqPublishNodes[0]
+		require.NotNil(t, publish.CredentialID)
+		require.Equal(t, "orders.acknowledged", publish.Topic)
+		require.Equal(t, "{{ Created.message.key }}", publish.Key)
+		require.Equal(t, `{"orderId": {{ Created.message.json.orderId }}}`, publish.Message)
+
+		var messageEdges int
+		for _, e := range data.FlowEdges {
+			if e.SourceID == consume.FlowNodeID && e.SourceHandler == mflow.HandleWsMessage {
+				messageEdges++
+			}
+		}
+		require.Equal(t, 1, messageEdges)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	importedData.Credentials = []mcredential.Credential{
+		{ID: credentialID, WorkspaceID: workspaceID, Name: "Events", Kind: mcredential.CREDENTIAL_KIND_MESSAGE_BROKER},
+	}
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "mq_consume:")
+	require.Contains(t, string(exportedYAML), "mq_publish:")
+	require.Contains(t, string(exportedYAML), "Created.message")
+	require.Contains(t, string(exportedYAML), "type: broker")
+	require.Contains(t, string(exportedYAML), "{{ #env:EVENTS_URL }}")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+
+	_, err = ConvertSimplifiedYAML([]byte(strings.Replace(sourceYAML, "          topic: orders.created\n", "", 1)), opts)
+	require.ErrorContains(t, err, "missing required topic")
+}
+
+func TestMarshalSimplifiedYAML_ExecRoundTrip(t *testing.T) {
+	sourceYAML := `
+workspace_name: Exec Test
+flows:
+  - name: Setup
+    steps:
+      - exec:
+          name: Token
+          command: ./bin/sign-token
+          args: ["--subject", "{{ adminUser }}"]
+          work_dir: "{{ repoRoot }}"
+          timeout_ms: 5000
+          parse_json: true
+      - js:
+          name: Use
+          code: "return Token.json.token"
+          depends_on: Token
+`
+	opts := GetDefaultOptions(idwrap.NewNow())
+
+	check := func(data *ioworkspace.WorkspaceBundle) {
+		t.Helper()
+		require.Len(t, data.FlowExecNodes, 1)
+		execNode := data.FlowExecNodes[0]
+		require.Equal(t, "./bin/sign-token", execNode.Command)
+		require.Equal(t, []string{"--subject", "{{ adminUser }}"}, execNode.Args)
+		require.Equal(t, "{{ repoRoot }}", execNode.WorkDir)
+		require.Equal(t, int64(5000), execNode.TimeoutMs)
+		require.True(t, execNode.ParseJSON)
+	}
+
+	importedData, err := ConvertSimplifiedYAML([]byte(sourceYAML), opts)
+	require.NoError(t, err)
+	check(importedData)
+
+	exportedYAML, err := MarshalSimplifiedYAML(importedData)
+	require.NoError(t, err)
+	require.Contains(t, string(exportedYAML), "exec:")
+
+	reImportedData, err := ConvertSimplifiedYAML(exportedYAML, opts)
+	require.NoError(t, err)
+	check(reImportedData)
+
+	_, err = ConvertSimplifiedYAML([]byte(strings.Replace(sourceYAML, "          command: ./bin/sign-token\n", "", 1)), opts)
+	require.ErrorContains(t, err, "missing required command")
+}
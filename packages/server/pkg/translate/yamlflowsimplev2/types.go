@@ -59,10 +59,16 @@ type YamlLoadScenario struct {
 // YamlCredentialV2 represents an LLM provider credential
 type YamlCredentialV2 struct {
 	Name    string `yaml:"name"`               // Credential name for reference
-	Type    string `yaml:"type"`               // openai, anthropic, gemini
+	Type    string `yaml:"type"`               // openai, anthropic, gemini, database, broker
 	Token   string `yaml:"token,omitempty"`    // For OpenAI (supports {{ #env:VAR_NAME }})
 	APIKey  string `yaml:"api_key,omitempty"`  // For Anthropic/Gemini (supports {{ #env:VAR_NAME }})
 	BaseURL string `yaml:"base_url,omitempty"` // Optional custom endpoint (supports {{ #env:VAR_NAME }})
+	// ConnectionString is the database URL for database credentials
+	// (supports {{ #env:VAR_NAME }}).
+	ConnectionString string `yaml:"connection_string,omitempty"`
+	// URL is the broker URL for message broker credentials, e.g.
+	// nats://localhost:4222 (supports {{ #env:VAR_NAME }}).
+	URL string `yaml:"url,omitempty"`
 }
 
 // YamlRunEntryV2 represents an entry in the run list
@@ -85,19 +91,33 @@ type YamlRequestDefV2 struct {
 
 // YamlGraphQLDefV2 represents a GraphQL request definition (template or standalone)
 type YamlGraphQLDefV2 struct {
-	Name       string            `yaml:"name,omitempty"`
-	URL        string            `yaml:"url,omitempty"`
-	Query      string            `yaml:"query"`
-	Variables  string            `yaml:"variables,omitempty"`
-	Headers    HeaderMapOrSlice  `yaml:"headers,omitempty"`
-	Assertions AssertionsOrSlice `yaml:"assertions,omitempty"`
+	Name          string            `yaml:"name,omitempty"`
+	URL           string            `yaml:"url,omitempty"`
+	Query         string            `yaml:"query"` // Inline document or "#file:<path>"
+	Variables     string            `yaml:"variables,omitempty"`
+	OperationName string            `yaml:"operation_name,omitempty"`
+	Headers       HeaderMapOrSlice  `yaml:"headers,omitempty"`
+	Assertions    AssertionsOrSlice `yaml:"assertions,omitempty"`
+
+	PersistedQuery bool                   `yaml:"persisted_query,omitempty"` // Send an APQ hash first
+	Batch          []YamlGraphQLOperation `yaml:"batch,omitempty"`           // Operations sent after this one in one array
+	Uploads        map[string]string      `yaml:"uploads,omitempty"`         // Operations path -> local file
+}
+
+// YamlGraphQLOperation is an operation batched with a GraphQL request's own
+type YamlGraphQLOperation struct {
+	Query         string `yaml:"query"`
+	Variables     string `yaml:"variables,omitempty"`
+	OperationName string `yaml:"operation_name,omitempty"`
 }
 
 // YamlFlowFlowV2 represents a flow in the modern YAML format
 type YamlFlowFlowV2 struct {
 	Name      string                 `yaml:"name"`
 	Variables []YamlFlowVariableV2   `yaml:"variables,omitempty"`
+	Setup     []YamlStepWrapper      `yaml:"setup,omitempty"` // Steps that run before the flow's steps
 	Steps     []YamlStepWrapper      `yaml:"steps,omitempty"`
+	Teardown  []YamlStepWrapper      `yaml:"teardown,omitempty"` // Steps that run after the flow, whatever its outcome
 	Timeout   *int                   `yaml:"timeout,omitempty"`  // Flow timeout in seconds
 	Metadata  map[string]interface{} `yaml:"metadata,omitempty"` // Additional flow metadata
 }
@@ -105,22 +125,36 @@ type YamlFlowFlowV2 struct {
 // YamlStepWrapper handles the polymorphic step list
 // A step is a map with a single key that identifies the type
 type YamlStepWrapper struct {
-	Request     *YamlStepRequest    `yaml:"request,omitempty"`
-	GraphQL     *YamlStepGraphQL    `yaml:"graphql,omitempty"`
-	If          *YamlStepIf         `yaml:"if,omitempty"`
-	For         *YamlStepFor        `yaml:"for,omitempty"`
-	ForEach     *YamlStepForEach    `yaml:"for_each,omitempty"`
-	JS          *YamlStepJS         `yaml:"js,omitempty"`
-	AI          *YamlStepAI         `yaml:"ai,omitempty"`
-	AIProvider  *YamlStepAIProvider `yaml:"ai_provider,omitempty"`
-	AIMemory       *YamlStepAIMemory      `yaml:"ai_memory,omitempty"`
-	WsConnection   *YamlStepWsConnection  `yaml:"ws_connection,omitempty"`
-	WsSend         *YamlStepWsSend        `yaml:"ws_send,omitempty"`
-	Wait              *YamlStepWait             `yaml:"wait,omitempty"`
-	ManualStart       *YamlStepCommon           `yaml:"manual_start,omitempty"`
-	SubFlowTrigger    *YamlStepSubFlowTrigger   `yaml:"sub_flow_trigger,omitempty"`
-	SubFlowReturn     *YamlStepSubFlowReturn    `yaml:"sub_flow_return,omitempty"`
-	RunSubFlow        *YamlStepRunSubFlow       `yaml:"run_sub_flow,omitempty"`
+	Request             *YamlStepRequest             `yaml:"request,omitempty"`
+	GraphQL             *YamlStepGraphQL             `yaml:"graphql,omitempty"`
+	If                  *YamlStepIf                  `yaml:"if,omitempty"`
+	For                 *YamlStepFor                 `yaml:"for,omitempty"`
+	ForEach             *YamlStepForEach             `yaml:"for_each,omitempty"`
+	JS                  *YamlStepJS                  `yaml:"js,omitempty"`
+	AI                  *YamlStepAI                  `yaml:"ai,omitempty"`
+	AIProvider          *YamlStepAIProvider          `yaml:"ai_provider,omitempty"`
+	AIMemory            *YamlStepAIMemory            `yaml:"ai_memory,omitempty"`
+	WsConnection        *YamlStepWsConnection        `yaml:"ws_connection,omitempty"`
+	WsSend              *YamlStepWsSend              `yaml:"ws_send,omitempty"`
+	WsExpect            *YamlStepWsExpect            `yaml:"ws_expect,omitempty"`
+	SocketConnection    *YamlStepSocketConnection    `yaml:"socket_connection,omitempty"`
+	SocketSend          *YamlStepSocketSend          `yaml:"socket_send,omitempty"`
+	SocketReceive       *YamlStepSocketReceive       `yaml:"socket_receive,omitempty"`
+	DbQuery             *YamlStepDbQuery             `yaml:"db_query,omitempty"`
+	MqPublish           *YamlStepMqPublish           `yaml:"mq_publish,omitempty"`
+	MqConsume           *YamlStepMqConsume           `yaml:"mq_consume,omitempty"`
+	Exec                *YamlStepExec                `yaml:"exec,omitempty"`
+	Wait                *YamlStepWait                `yaml:"wait,omitempty"`
+	ManualStart         *YamlStepCommon              `yaml:"manual_start,omitempty"`
+	SubFlowTrigger      *YamlStepSubFlowTrigger      `yaml:"sub_flow_trigger,omitempty"`
+	SubFlowReturn       *YamlStepSubFlowReturn       `yaml:"sub_flow_return,omitempty"`
+	RunSubFlow          *YamlStepRunSubFlow          `yaml:"run_sub_flow,omitempty"`
+	Try                 *YamlStepTry                 `yaml:"try,omitempty"`
+	Parallel            *YamlStepParallel            `yaml:"parallel,omitempty"`
+	Poll                *YamlStepPoll                `yaml:"poll,omitempty"`
+	Switch              *YamlStepSwitch              `yaml:"switch,omitempty"`
+	Webhook             *YamlStepWebhook             `yaml:"webhook,omitempty"`
+	GraphQLSubscription *YamlStepGraphQLSubscription `yaml:"graphql_subscription,omitempty"`
 }
 
 // Common fields for all step types
@@ -146,10 +180,15 @@ type YamlStepGraphQL struct {
 	YamlStepCommon `yaml:",inline"`
 	UseRequest     string            `yaml:"use_request,omitempty"`
 	URL            string            `yaml:"url,omitempty"`
-	Query          string            `yaml:"query,omitempty"`
+	Query          string            `yaml:"query,omitempty"` // Inline document or "#file:<path>"
 	Variables      string            `yaml:"variables,omitempty"`
+	OperationName  string            `yaml:"operation_name,omitempty"`
 	Headers        HeaderMapOrSlice  `yaml:"headers,omitempty"`
 	Assertions     AssertionsOrSlice `yaml:"assertions,omitempty"`
+
+	PersistedQuery bool                   `yaml:"persisted_query,omitempty"`
+	Batch          []YamlGraphQLOperation `yaml:"batch,omitempty"`
+	Uploads        map[string]string      `yaml:"uploads,omitempty"`
 }
 
 type YamlStepIf struct {
@@ -159,6 +198,21 @@ type YamlStepIf struct {
 	Else           string `yaml:"else,omitempty"`
 }
 
+// YamlStepSwitch follows the first of Cases whose When holds, or Default
+// when none does. Steps join a case with "<switch>.<case name>" and the
+// default branch with "<switch>.default".
+type YamlStepSwitch struct {
+	YamlStepCommon `yaml:",inline"`
+	Cases          []YamlSwitchCase `yaml:"cases"`
+	Default        string           `yaml:"default,omitempty"`
+}
+
+type YamlSwitchCase struct {
+	Name string `yaml:"name,omitempty"` // Defaults to case_<index>
+	When string `yaml:"when"`           // expr-lang expression
+	Then string `yaml:"then,omitempty"`
+}
+
 type YamlStepFor struct {
 	YamlStepCommon `yaml:",inline"`
 	IterCount      string `yaml:"iter_count"`                // Expression or number
@@ -166,11 +220,41 @@ type YamlStepFor struct {
 	BreakCondition string `yaml:"break_condition,omitempty"` // expr-lang expression; loop exits when true (evaluated AFTER each iteration's children)
 }
 
+// YamlStepTry runs Body once. When a step of the body fails, the flow goes on
+// with Catch, which reads the failure from "<try>.error".
+type YamlStepTry struct {
+	YamlStepCommon `yaml:",inline"`
+	Body           string `yaml:"body,omitempty"`
+	Catch          string `yaml:"catch,omitempty"`
+}
+
 type YamlStepForEach struct {
 	YamlStepCommon `yaml:",inline"`
 	Items          string `yaml:"items"` // Expression
 	Loop           string `yaml:"loop,omitempty"`
 	BreakCondition string `yaml:"break_condition,omitempty"` // expr-lang expression; loop exits when true (evaluated AFTER each iteration's children)
+	Concurrency    int    `yaml:"concurrency,omitempty"`     // Iterations in flight at once; 0 or 1 runs them in order
+}
+
+// YamlStepParallel runs each of Branches (and every step depending on
+// "<parallel>.branch") at the same time and goes on once Join is satisfied.
+type YamlStepParallel struct {
+	YamlStepCommon `yaml:",inline"`
+	Join           string        `yaml:"join,omitempty"`        // all (default) | any | first_success
+	Concurrency    int           `yaml:"concurrency,omitempty"` // Branches in flight at once; 0 runs them all
+	Branches       StringOrSlice `yaml:"branches,omitempty"`
+}
+
+// YamlStepPoll runs Loop (and every step depending on "<poll>.loop") until
+// Until holds, waiting IntervalMs between attempts.
+type YamlStepPoll struct {
+	YamlStepCommon `yaml:",inline"`
+	Until          string  `yaml:"until"`                     // expr-lang expression; polling stops when true
+	Loop           string  `yaml:"loop,omitempty"`            //
+	IntervalMs     int64   `yaml:"interval_ms,omitempty"`     // Wait between attempts (default 1000)
+	Backoff        float64 `yaml:"backoff,omitempty"`         // Interval multiplier after each attempt
+	MaxDurationMs  int64   `yaml:"max_duration_ms,omitempty"` // Give up after this long
+	MaxAttempts    int32   `yaml:"max_attempts,omitempty"`    // Give up after this many attempts
 }
 
 type YamlStepJS struct {
@@ -190,11 +274,11 @@ type YamlStepAI struct {
 // YamlStepAIProvider represents an AI Provider node (LLM executor)
 type YamlStepAIProvider struct {
 	YamlStepCommon `yaml:",inline"`
-	Credential     string   `yaml:"credential"`               // Reference to credential name
-	Model          string   `yaml:"model"`                    // Model name (gpt-4o, claude-opus-4.5, etc.)
-	CustomModel    string   `yaml:"custom_model,omitempty"`   // For custom model selection
-	Temperature    *float64 `yaml:"temperature,omitempty"`    // LLM temperature (0.0-2.0)
-	MaxTokens      *int32   `yaml:"max_tokens,omitempty"`     // Max output tokens
+	Credential     string   `yaml:"credential"`             // Reference to credential name
+	Model          string   `yaml:"model"`                  // Model name (gpt-4o, claude-opus-4.5, etc.)
+	CustomModel    string   `yaml:"custom_model,omitempty"` // For custom model selection
+	Temperature    *float64 `yaml:"temperature,omitempty"`  // LLM temperature (0.0-2.0)
+	MaxTokens      *int32   `yaml:"max_tokens,omitempty"`   // Max output tokens
 }
 
 // YamlStepAIMemory represents an AI Memory node (conversation history)
@@ -208,12 +292,111 @@ type YamlStepWsConnection struct {
 	YamlStepCommon `yaml:",inline"`
 	URL            string           `yaml:"url,omitempty"`
 	Headers        HeaderMapOrSlice `yaml:"headers,omitempty"`
+	Subprotocols   []string         `yaml:"subprotocols,omitempty"`
+	Protocol       string           `yaml:"protocol,omitempty"`        // raw (default), socketio or stomp
+	ConnectPayload string           `yaml:"connect_payload,omitempty"` // JSON: Socket.IO auth or STOMP CONNECT headers
+	Subscriptions  []string         `yaml:"subscriptions,omitempty"`   // STOMP destinations
 }
 
 type YamlStepWsSend struct {
 	YamlStepCommon       `yaml:",inline"`
 	WsConnectionNodeName string `yaml:"ws_connection_node_name"`
 	Message              string `yaml:"message,omitempty"`
+	Binary               bool   `yaml:"binary,omitempty"` // Message is base64 encoded
+	Event                string `yaml:"event,omitempty"`  // Socket.IO event or STOMP destination
+	Ack                  bool   `yaml:"ack,omitempty"`
+}
+
+// YamlStepWsExpect optionally sends Message, then waits up to TimeoutMs for
+// Count frames satisfying Match and checks Assertions against them.
+type YamlStepWsExpect struct {
+	YamlStepCommon       `yaml:",inline"`
+	WsConnectionNodeName string   `yaml:"ws_connection_node_name"`
+	Message              string   `yaml:"message,omitempty"`
+	Binary               bool     `yaml:"binary,omitempty"`
+	Match                string   `yaml:"match,omitempty"`
+	TimeoutMs            int64    `yaml:"timeout_ms,omitempty"`
+	Count                int32    `yaml:"count,omitempty"`
+	Assertions           []string `yaml:"assertions,omitempty"`
+	Ping                 bool     `yaml:"ping,omitempty"`
+	ExpectClose          bool     `yaml:"expect_close,omitempty"`
+}
+
+// YamlStepSocketConnection opens a TCP or UDP connection to Address and
+// splits what it receives into messages according to Framing.
+type YamlStepSocketConnection struct {
+	YamlStepCommon `yaml:",inline"`
+	Network        string `yaml:"network,omitempty"` // tcp (default) or udp
+	Address        string `yaml:"address"`           // host:port
+	TLS            bool   `yaml:"tls,omitempty"`
+	TLSSkipVerify  bool   `yaml:"tls_skip_verify,omitempty"`
+	Framing        string `yaml:"framing,omitempty"`      // none (default), delimiter or length
+	Delimiter      string `yaml:"delimiter,omitempty"`    // escapes allowed, default \n
+	LengthBytes    int32  `yaml:"length_bytes,omitempty"` // 1, 2 or 4 (default)
+	TimeoutMs      int64  `yaml:"timeout_ms,omitempty"`
+}
+
+type YamlStepSocketSend struct {
+	YamlStepCommon           `yaml:",inline"`
+	SocketConnectionNodeName string `yaml:"socket_connection_node_name"`
+	Message                  string `yaml:"message,omitempty"`
+	Encoding                 string `yaml:"encoding,omitempty"` // text (default), hex or base64
+}
+
+// YamlStepSocketReceive waits up to TimeoutMs for Count messages satisfying
+// Match and checks Assertions against them.
+type YamlStepSocketReceive struct {
+	YamlStepCommon           `yaml:",inline"`
+	SocketConnectionNodeName string   `yaml:"socket_connection_node_name"`
+	Match                    string   `yaml:"match,omitempty"`
+	TimeoutMs                int64    `yaml:"timeout_ms,omitempty"`
+	Count                    int32    `yaml:"count,omitempty"`
+	Assertions               []string `yaml:"assertions,omitempty"`
+}
+
+// YamlStepDbQuery runs Query against the database of the named database
+// credential, binding Params (expressions) to its placeholders in order.
+type YamlStepDbQuery struct {
+	YamlStepCommon `yaml:",inline"`
+	Credential     string   `yaml:"credential"` // Reference to a database credential name
+	Query          string   `yaml:"query"`
+	Params         []string `yaml:"params,omitempty"`
+	TimeoutMs      int64    `yaml:"timeout_ms,omitempty"`
+	Assertions     []string `yaml:"assertions,omitempty"`
+}
+
+// YamlStepMqPublish publishes Message to Topic on the broker of the named
+// broker credential.
+type YamlStepMqPublish struct {
+	YamlStepCommon `yaml:",inline"`
+	Credential     string `yaml:"credential"` // Reference to a broker credential name
+	Topic          string `yaml:"topic"`
+	Key            string `yaml:"key,omitempty"`
+	Message        string `yaml:"message"`
+}
+
+// YamlStepMqConsume waits for Count messages on Topic matching Filter,
+// running the steps that depend on "<name>.message" for each of them.
+type YamlStepMqConsume struct {
+	YamlStepCommon `yaml:",inline"`
+	Credential     string   `yaml:"credential"` // Reference to a broker credential name
+	Topic          string   `yaml:"topic"`
+	Group          string   `yaml:"group,omitempty"`
+	Filter         string   `yaml:"filter,omitempty"`
+	TimeoutMs      int64    `yaml:"timeout_ms,omitempty"`
+	Count          int32    `yaml:"count,omitempty"`
+	Assertions     []string `yaml:"assertions,omitempty"`
+}
+
+// YamlStepExec runs Command with Args (expressions) in WorkDir without a
+// shell. The runner refuses it unless exec steps were explicitly allowed.
+type YamlStepExec struct {
+	YamlStepCommon `yaml:",inline"`
+	Command        string   `yaml:"command"`
+	Args           []string `yaml:"args,omitempty"`
+	WorkDir        string   `yaml:"work_dir,omitempty"`
+	TimeoutMs      int64    `yaml:"timeout_ms,omitempty"`
+	ParseJSON      bool     `yaml:"parse_json,omitempty"`
 }
 
 type YamlStepWait struct {
@@ -226,6 +409,27 @@ type YamlStepSubFlowTrigger struct {
 	Params         []YamlSubFlowParam `yaml:"params,omitempty"`
 }
 
+// YamlStepWebhook starts the flow when an HTTP request arrives on Path.
+// When Secret is set, requests must carry a hex HMAC-SHA256 of the body in
+// SignatureHeader.
+type YamlStepWebhook struct {
+	YamlStepCommon  `yaml:",inline"`
+	Path            string `yaml:"path"`
+	Method          string `yaml:"method,omitempty"` // Empty accepts any method
+	Secret          string `yaml:"secret,omitempty"`
+	SignatureHeader string `yaml:"signature_header,omitempty"` // Defaults to X-Signature-256
+}
+
+// YamlStepGraphQLSubscription starts the flow by subscribing to a GraphQL
+// operation over WebSocket. Steps depending on "<name>.next" run once per
+// event; steps depending on "<name>" run after the subscription ends.
+type YamlStepGraphQLSubscription struct {
+	YamlStepGraphQL   `yaml:",inline"`
+	ConnectionPayload string `yaml:"connection_payload,omitempty"` // JSON object sent with connection_init
+	MaxEvents         int32  `yaml:"max_events,omitempty"`         // Stop after this many events
+	TimeoutMs         int64  `yaml:"timeout_ms,omitempty"`         // Stop after this long
+}
+
 type YamlSubFlowParam struct {
 	Name         string `yaml:"name"`
 	Type         string `yaml:"type,omitempty"`
@@ -476,6 +680,10 @@ type ConvertOptionsV2 struct {
 	// CredentialMap maps credential names to their IDs for AI node resolution.
 	// If nil, credential_id in YAML must be a valid ID string.
 	CredentialMap map[string]idwrap.IDWrap
+
+	// ReadFile resolves "#file:<path>" GraphQL queries. If nil, such
+	// references are rejected.
+	ReadFile func(path string) ([]byte, error)
 }
 
 // YamlFlowDataV2 contains the intermediate data structure during YAML parsing
@@ -493,21 +701,21 @@ type YamlFlowDataV2 struct {
 	GraphQLHeaders  []mgraphql.GraphQLHeader
 
 	// Flow node implementations
-	RequestNodes     []mflow.NodeRequest
-	ConditionNodes   []mflow.NodeIf
-	ForNodes         []mflow.NodeFor
-	ForEachNodes     []mflow.NodeForEach
-	JSNodes          []mflow.NodeJS
-	AINodes          []mflow.NodeAI
-	AIProviderNodes  []mflow.NodeAiProvider
-	AIMemoryNodes    []mflow.NodeMemory
+	RequestNodes        []mflow.NodeRequest
+	ConditionNodes      []mflow.NodeIf
+	ForNodes            []mflow.NodeFor
+	ForEachNodes        []mflow.NodeForEach
+	JSNodes             []mflow.NodeJS
+	AINodes             []mflow.NodeAI
+	AIProviderNodes     []mflow.NodeAiProvider
+	AIMemoryNodes       []mflow.NodeMemory
 	GraphQLNodes        []mflow.NodeGraphQL
-	WsConnectionNodes      []mflow.NodeWsConnection
-	WsSendNodes            []mflow.NodeWsSend
-	WaitNodes              []mflow.NodeWait
-	SubFlowTriggerNodes    []mflow.NodeSubFlowTrigger
-	SubFlowReturnNodes     []mflow.NodeSubFlowReturn
-	RunSubFlowNodes        []mflow.NodeRunSubFlow
+	WsConnectionNodes   []mflow.NodeWsConnection
+	WsSendNodes         []mflow.NodeWsSend
+	WaitNodes           []mflow.NodeWait
+	SubFlowTriggerNodes []mflow.NodeSubFlowTrigger
+	SubFlowReturnNodes  []mflow.NodeSubFlowReturn
+	RunSubFlowNodes     []mflow.NodeRunSubFlow
 }
 
 // YamlVariableV2 represents a variable during parsing
@@ -27,27 +27,59 @@ const (
 	CredentialTypeAnthropic = "anthropic"
 	CredentialTypeGemini    = "gemini"
 	CredentialTypeGoogle    = "google" // Alias for gemini
+	CredentialTypeDatabase  = "database"
+	CredentialTypeBroker    = "broker"
 
 	// Memory type constants (used in ai_memory steps)
 	MemoryTypeWindowBuffer = "window_buffer"
 	MemoryTypeSummary      = "summary"
 
+	// Join modes (used in parallel steps)
+	ParallelJoinAll          = "all"
+	ParallelJoinAny          = "any"
+	ParallelJoinFirstSuccess = "first_success"
+
+	// WebSocket protocols (used in ws_connection steps)
+	WsProtocolRaw      = "raw"
+	WsProtocolSocketIO = "socketio"
+	WsProtocolStomp    = "stomp"
+
+	// Socket settings (used in socket_connection and socket_send steps)
+	SocketNetworkTCP       = "tcp"
+	SocketNetworkUDP       = "udp"
+	SocketFramingNone      = "none"
+	SocketFramingDelimiter = "delimiter"
+	SocketFramingLength    = "length"
+	SocketEncodingText     = "text"
+	SocketEncodingHex      = "hex"
+	SocketEncodingBase64   = "base64"
+
 	// Default name constants (used in exporter/importer)
 	DefaultFileName       = "untitled"
 	DefaultWorkspaceName  = "Exported Workspace"
 	DefaultRequestName    = "Request"
 	DefaultFlowName       = "Flow"
 	DefaultCollectionName = "Imported Collection"
+	TeardownNodeName      = "Teardown"
 
 	// Dependency handler suffixes (used in depends_on field)
 	DependsSuffixThen      = ".then"
 	DependsSuffixElse      = ".else"
 	DependsSuffixLoop      = ".loop"
 	DependsSuffixWsMessage = ".ws_message"
+	DependsSuffixMessage   = ".message"
+	DependsSuffixNext      = ".next"
+	DependsSuffixOnError   = ".on_error"
+	DependsSuffixTry       = ".try"
+	DependsSuffixCatch     = ".catch"
+	DependsSuffixBranch    = ".branch"
+	DependsSuffixDefault   = ".default"
 
 	// Environment variable template patterns (used in credential export)
-	EnvVarTemplateToken  = "{{ #env:%s_TOKEN }}"  //nolint:gosec // G101: template pattern, not a credential
-	EnvVarTemplateAPIKey = "{{ #env:%s_API_KEY }}" //nolint:gosec // G101: template pattern, not a credential
+	EnvVarTemplateToken            = "{{ #env:%s_TOKEN }}"             //nolint:gosec // G101: template pattern, not a credential
+	EnvVarTemplateAPIKey           = "{{ #env:%s_API_KEY }}"           //nolint:gosec // G101: template pattern, not a credential
+	EnvVarTemplateConnectionString = "{{ #env:%s_CONNECTION_STRING }}" //nolint:gosec // G101: template pattern, not a credential
+	EnvVarTemplateURL              = "{{ #env:%s_URL }}"
 )
 
 // envVarInvalidChars matches any character that is not a letter, digit, or underscore.